@@ -0,0 +1,83 @@
+package client
+
+import (
+	"encoding/json"
+	"strings"
+
+	"google.golang.org/grpc"
+
+	// Registers the client-side health checks of the backends, which the health-aware load balancing uses.
+	_ "google.golang.org/grpc/health"
+)
+
+const (
+	// RoundRobin is the load balancing policy that spreads the calls across all the resolved backends,
+	// rather than sending them all to the first one, like grpc's default policy.
+	RoundRobin = "round_robin"
+
+	// DNSScheme is the scheme of the targets that are resolved to all of the addresses of their host,
+	// such as of a headless Kubernetes service.
+	DNSScheme = "dns"
+
+	// XDSScheme is the scheme of the targets that are resolved, and balanced, by an xDS control plane.
+	// The binary of the client must import grpc's xds package, which registers the scheme.
+	XDSScheme = "xds"
+)
+
+// LoadBalancing is the client-side load balancing of the calls across the replicas of the service,
+// so that the calls of a client are spread across them instead of piling onto a single one.
+type LoadBalancing struct {
+	// Policy is the load balancing policy, RoundRobin if empty.
+	Policy string
+
+	// HealthCheck is whether the backends are health checked with the grpc health checking protocol,
+	// so that the calls are only balanced across the subset of the backends that are SERVING, and a replica
+	// that's warming up or failing its MongoDB checks doesn't get any.
+	HealthCheck bool
+}
+
+// serviceConfig is the JSON grpc service config of the load balancing.
+type serviceConfig struct {
+	LoadBalancingConfig []map[string]struct{} `json:"loadBalancingConfig"`
+	HealthCheckConfig   *healthCheckConfig    `json:"healthCheckConfig,omitempty"`
+}
+
+// healthCheckConfig is the health checking of the backends in the JSON grpc service config.
+type healthCheckConfig struct {
+	ServiceName string `json:"serviceName"`
+}
+
+// ServiceConfig returns the JSON grpc service config of the load balancing.
+func (l LoadBalancing) ServiceConfig() string {
+	policy := l.Policy
+	if policy == "" {
+		policy = RoundRobin
+	}
+
+	config := serviceConfig{LoadBalancingConfig: []map[string]struct{}{{policy: {}}}}
+	if l.HealthCheck {
+		// The server reports its overall health as the health of the empty service name.
+		config.HealthCheckConfig = &healthCheckConfig{ServiceName: ""}
+	}
+
+	// The config only holds strings, so it's always marshaled.
+	encoded, _ := json.Marshal(config)
+	return string(encoded)
+}
+
+// DialOptions returns the dial options of the load balancing. The service config that an xDS
+// control plane provides takes precedence over them.
+func (l LoadBalancing) DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{grpc.WithDefaultServiceConfig(l.ServiceConfig())}
+}
+
+// Target returns the grpc target of address, such as "permission-service:8080", that's resolved by DNS
+// to all of its addresses, so that the calls are balanced across them, or address if it already has
+// a scheme, such as "xds:///permission-service".
+func Target(address string) string {
+	if strings.Contains(address, "://") {
+		return address
+	}
+
+	return DNSScheme + ":///" + address
+}
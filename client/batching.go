@@ -0,0 +1,235 @@
+// Package client provides helpers for the clients of the permission service.
+package client
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/meateam/permission-service/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultBatchWindow is the default duration that an IsPermitted check waits for more checks to batch with.
+	DefaultBatchWindow = 2 * time.Millisecond
+
+	// DefaultMaxBatchSize is the default maximum number of checks of a batch.
+	DefaultMaxBatchSize = 100
+)
+
+// BatchingClient is a pb.PermissionClient that coalesces the IsPermitted checks that are issued
+// within a few milliseconds of each other into a single CheckPermissionsMatrix call, and returns
+// the result of each check to its caller as if it was checked on its own.
+// Only checks with the same outgoing metadata are batched together, and checks with call options
+// aren't batched. The rest of the RPCs are called on the wrapped client.
+type BatchingClient struct {
+	pb.PermissionClient
+
+	window       time.Duration
+	maxBatchSize int
+
+	mu      sync.Mutex
+	batches map[string]*checkBatch
+}
+
+// checkBatch is the pending IsPermitted checks of the same outgoing metadata.
+type checkBatch struct {
+	md    metadata.MD
+	calls []*checkCall
+	timer *time.Timer
+
+	// deadline is the latest deadline of the calls, the batch has no deadline if noDeadline.
+	deadline   time.Time
+	noDeadline bool
+}
+
+// checkCall is a pending IsPermitted check, done is closed once res or err is set.
+type checkCall struct {
+	req  *pb.IsPermittedRequest
+	res  *pb.IsPermittedResponse
+	err  error
+	done chan struct{}
+}
+
+// NewBatchingClient creates a BatchingClient of client whose checks wait up to window for more checks
+// to batch with, and are sent once maxBatchSize checks are pending, and returns it.
+// window and maxBatchSize default to DefaultBatchWindow and DefaultMaxBatchSize if not positive.
+func NewBatchingClient(client pb.PermissionClient, window time.Duration, maxBatchSize int) *BatchingClient {
+	if window <= 0 {
+		window = DefaultBatchWindow
+	}
+
+	if maxBatchSize <= 0 {
+		maxBatchSize = DefaultMaxBatchSize
+	}
+
+	return &BatchingClient{
+		PermissionClient: client,
+		window:           window,
+		maxBatchSize:     maxBatchSize,
+		batches:          map[string]*checkBatch{},
+	}
+}
+
+// IsPermitted checks whether the user of in is permitted to its file in the next batch of checks,
+// it returns once the batch returns or ctx is done.
+func (c *BatchingClient) IsPermitted(
+	ctx context.Context,
+	in *pb.IsPermittedRequest,
+	opts ...grpc.CallOption) (*pb.IsPermittedResponse, error) {
+	if len(opts) > 0 {
+		return c.PermissionClient.IsPermitted(ctx, in, opts...)
+	}
+
+	call := &checkCall{req: in, done: make(chan struct{})}
+	c.enqueue(ctx, call)
+
+	select {
+	case <-call.done:
+		return call.res, call.err
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+// enqueue adds call to the pending batch of the outgoing metadata of ctx, and sends the batch if it's full.
+func (c *BatchingClient) enqueue(ctx context.Context, call *checkCall) {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	key := metadataKey(md)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	batch, ok := c.batches[key]
+	if !ok {
+		batch = &checkBatch{md: md}
+		batch.timer = time.AfterFunc(c.window, func() { c.flush(key, batch) })
+		c.batches[key] = batch
+	}
+
+	batch.calls = append(batch.calls, call)
+	if deadline, ok := ctx.Deadline(); !ok {
+		batch.noDeadline = true
+	} else if deadline.After(batch.deadline) {
+		batch.deadline = deadline
+	}
+
+	if len(batch.calls) >= c.maxBatchSize {
+		batch.timer.Stop()
+		delete(c.batches, key)
+		go c.send(batch)
+	}
+}
+
+// flush sends batch once its window is over, unless it was already sent when it was full.
+func (c *BatchingClient) flush(key string, batch *checkBatch) {
+	c.mu.Lock()
+	pending := c.batches[key] == batch
+	if pending {
+		delete(c.batches, key)
+	}
+	c.mu.Unlock()
+
+	if pending {
+		c.send(batch)
+	}
+}
+
+// send checks the calls of batch and sets their results, a batch of a single call is checked with IsPermitted.
+// The checks are made one by one if the server doesn't implement CheckPermissionsMatrix.
+func (c *BatchingClient) send(batch *checkBatch) {
+	ctx := metadata.NewOutgoingContext(context.Background(), batch.md)
+	if !batch.noDeadline {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, batch.deadline)
+		defer cancel()
+	}
+
+	if len(batch.calls) == 1 {
+		c.sendEach(ctx, batch.calls)
+		return
+	}
+
+	req := &pb.CheckPermissionsMatrixRequest{Checks: make([]*pb.IsPermittedRequest, 0, len(batch.calls))}
+	for _, call := range batch.calls {
+		req.Checks = append(req.Checks, call.req)
+	}
+
+	res, err := c.PermissionClient.CheckPermissionsMatrix(ctx, req)
+	if status.Code(err) == codes.Unimplemented {
+		c.sendEach(ctx, batch.calls)
+		return
+	}
+
+	if err == nil && len(res.GetResults()) != len(batch.calls) {
+		err = status.Errorf(
+			codes.Internal,
+			"expected %d results of CheckPermissionsMatrix, got %d",
+			len(batch.calls),
+			len(res.GetResults()),
+		)
+	}
+
+	for i, call := range batch.calls {
+		if err != nil {
+			call.err = err
+		} else {
+			call.res, call.err = checkResult(res.GetResults()[i])
+		}
+
+		close(call.done)
+	}
+}
+
+// sendEach checks each of calls with IsPermitted, concurrently, and sets their results.
+func (c *BatchingClient) sendEach(ctx context.Context, calls []*checkCall) {
+	var wg sync.WaitGroup
+	for _, call := range calls {
+		wg.Add(1)
+		go func(call *checkCall) {
+			defer wg.Done()
+			call.res, call.err = c.PermissionClient.IsPermitted(ctx, call.req)
+			close(call.done)
+		}(call)
+	}
+
+	wg.Wait()
+}
+
+// checkResult returns the response, or the error, that IsPermitted would have returned for the check of result.
+func checkResult(result *pb.CheckPermissionsMatrixResponse_Result) (*pb.IsPermittedResponse, error) {
+	if code := codes.Code(result.GetCode()); code != codes.OK {
+		return nil, status.Error(code, result.GetMessage())
+	}
+
+	return &pb.IsPermittedResponse{Permitted: result.GetPermitted(), MaxAge: result.GetMaxAge()}, nil
+}
+
+// metadataKey returns a key of md, which is the same for metadata with the same keys and values.
+func metadataKey(md metadata.MD) string {
+	keys := make([]string, 0, len(md))
+	for key := range md {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	var builder strings.Builder
+	for _, key := range keys {
+		builder.WriteString(key)
+		for _, value := range md[key] {
+			builder.WriteString("\x00")
+			builder.WriteString(value)
+		}
+
+		builder.WriteString("\x01")
+	}
+
+	return builder.String()
+}
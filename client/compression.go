@@ -0,0 +1,35 @@
+package client
+
+import (
+	"google.golang.org/grpc"
+
+	// Registers the gzip compressor, which the server also registers, so that the calls may be compressed.
+	"google.golang.org/grpc/encoding/gzip"
+)
+
+// Compression is the compression of the calls to the service with gzip, so that large requests and responses,
+// such as the permissions of a shared folder, take less bandwidth at the cost of the CPU of both sides.
+// The server compresses the responses of the compressed calls with its `COMPRESSION_LEVEL`.
+type Compression struct {
+	// Level is the gzip level of the requests, from 1 (fastest) to 9 (smallest), gzip's default level if 0.
+	// grpc registers a single gzip compressor, so it's the level of every gzip compressed call of the binary.
+	Level int
+}
+
+// DialOptions returns the dial options that compress every call of the connection with gzip,
+// or an error if the level is invalid.
+func (c Compression) DialOptions() ([]grpc.DialOption, error) {
+	if c.Level != 0 {
+		if err := gzip.SetLevel(c.Level); err != nil {
+			return nil, err
+		}
+	}
+
+	return []grpc.DialOption{grpc.WithDefaultCallOptions(Compressed())}, nil
+}
+
+// Compressed returns the call option that compresses a single call with gzip, for the clients
+// whose connections don't compress every call.
+func Compressed() grpc.CallOption {
+	return grpc.UseCompressor(gzip.Name)
+}
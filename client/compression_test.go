@@ -0,0 +1,114 @@
+package client
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// compressionRecorder is a server stats handler that records the compression of the calls it receives.
+type compressionRecorder struct {
+	mu          sync.Mutex
+	compression []string
+}
+
+// TagRPC returns ctx.
+func (r *compressionRecorder) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+// HandleRPC records the compression of the headers of the calls.
+func (r *compressionRecorder) HandleRPC(_ context.Context, s stats.RPCStats) {
+	if header, ok := s.(*stats.InHeader); ok {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.compression = append(r.compression, header.Compression)
+	}
+}
+
+// TagConn returns ctx.
+func (r *compressionRecorder) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+// HandleConn does nothing.
+func (r *compressionRecorder) HandleConn(context.Context, stats.ConnStats) {}
+
+// last returns the compression of the last call that was received.
+func (r *compressionRecorder) last() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.compression) == 0 {
+		return ""
+	}
+
+	return r.compression[len(r.compression)-1]
+}
+
+func TestCompression(t *testing.T) {
+	recorder := &compressionRecorder{}
+	server := grpc.NewServer(grpc.StatsHandler(recorder))
+	grpc_health_v1.RegisterHealthServer(server, health.NewServer())
+	listener := bufconn.Listen(1 << 20)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	dial := func(opts ...grpc.DialOption) grpc_health_v1.HealthClient {
+		opts = append(
+			opts,
+			grpc.WithInsecure(),
+			grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+				return listener.Dial()
+			}),
+		)
+
+		conn, err := grpc.DialContext(context.Background(), "bufconn", opts...)
+		if err != nil {
+			t.Fatalf("failed dialing the server: %v", err)
+		}
+
+		return grpc_health_v1.NewHealthClient(conn)
+	}
+
+	compressionOpts, err := Compression{Level: 9}.DialOptions()
+	if err != nil {
+		t.Fatalf("DialOptions failed: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		client      grpc_health_v1.HealthClient
+		opts        []grpc.CallOption
+		compression string
+	}{
+		{name: "uncompressed", client: dial()},
+		{name: "compressed connection", client: dial(compressionOpts...), compression: "gzip"},
+		{name: "compressed call", client: dial(), opts: []grpc.CallOption{Compressed()}, compression: "gzip"},
+	}
+
+	for _, test := range tests {
+		if _, err := test.client.Check(
+			context.Background(),
+			&grpc_health_v1.HealthCheckRequest{},
+			test.opts...,
+		); err != nil {
+			t.Errorf("%s: Check failed: %v", test.name, err)
+			continue
+		}
+
+		if compression := recorder.last(); compression != test.compression {
+			t.Errorf("%s: expected the call to be compressed with %q, got %q", test.name, test.compression, compression)
+		}
+	}
+
+	if _, err := (Compression{Level: 10}).DialOptions(); err == nil {
+		t.Errorf("expected DialOptions to fail with an invalid level")
+	}
+}
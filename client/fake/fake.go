@@ -0,0 +1,169 @@
+// Package fake provides an in-memory fake of the permission service, for testing the services that share
+// files through it without a live permission service and MongoDB. The fake serves the v1 Permission service
+// with the semantics of the permission service's default configuration, such as the role hierarchy, the
+// resharing of permissions and the etags, and its failures may be injected by method.
+package fake
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path"
+	"sync"
+	"time"
+
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/meateam/permission-service/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// listenerBufferSize is the size of the buffer of the in-memory listener of a client.
+const listenerBufferSize = 1 << 20
+
+// permissionKey is the key of a permission of a user to a file.
+type permissionKey struct {
+	resourceType string
+	fileID       string
+	userID       string
+}
+
+// record is a permission held by the fake, with the fields that aren't in its proto.
+type record struct {
+	permission   *pb.PermissionObject
+	sharingChain []string
+	createdAt    time.Time
+	sequence     int64
+	version      int64
+}
+
+// Server is an in-memory fake of the Permission service. It's safe for concurrent use.
+// ListPermissionChanges and HandleFileMoved are Unimplemented, since the fake has no change log
+// and no file tree.
+type Server struct {
+	pb.UnimplementedPermissionServer
+
+	mu          sync.Mutex
+	permissions map[permissionKey]*record
+	sequence    int64
+
+	// failures are the errors that the calls of the methods fail with, by method name.
+	failures map[string]error
+
+	// now returns the current time, of the creations and the accesses of the permissions.
+	now func() time.Time
+}
+
+// NewServer creates an empty fake server and returns it.
+func NewServer() *Server {
+	return &Server{
+		permissions: map[permissionKey]*record{},
+		failures:    map[string]error{},
+		now:         time.Now,
+	}
+}
+
+// Fail makes the calls of method, such as "IsPermitted", fail with err through the clients of the server,
+// until it's called again with a nil err.
+func (s *Server) Fail(method string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err == nil {
+		delete(s.failures, method)
+		return
+	}
+
+	s.failures[method] = err
+}
+
+// Reset deletes the permissions of the server and the failures that were injected into it.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.permissions = map[permissionKey]*record{}
+	s.failures = map[string]error{}
+}
+
+// failure returns the error that the calls of fullMethod fail with, or nil.
+func (s *Server) failure(fullMethod string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.failures[path.Base(fullMethod)]
+}
+
+// unaryInterceptor is a grpc unary server interceptor that fails the calls whose failures were injected.
+func (s *Server) unaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if err := s.failure(info.FullMethod); err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// Client is a Permission client of a fake server, connected to it on an in-memory listener.
+// The requests are validated by the validation rules of the proto, as by the permission service.
+type Client struct {
+	pb.PermissionClient
+
+	// Server is the fake server of the client, whose failures may be injected.
+	Server *Server
+
+	grpcServer *grpc.Server
+	conn       *grpc.ClientConn
+}
+
+// NewClient serves a new fake server on an in-memory listener, and returns a client connected to it,
+// or any error if occurred. The client must be closed once it's no longer used.
+func NewClient() (*Client, error) {
+	return NewClientOf(NewServer())
+}
+
+// NewClientOf serves server on an in-memory listener, and returns a client connected to it,
+// or any error if occurred. The client must be closed once it's no longer used.
+func NewClientOf(server *Server) (*Client, error) {
+	validation := service.ValidateRequests()
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(server.unaryInterceptor, validation.Unary)),
+		grpc.StreamInterceptor(validation.Stream),
+	)
+	pb.RegisterPermissionServer(grpcServer, server)
+
+	listener := bufconn.Listen(listenerBufferSize)
+	go grpcServer.Serve(listener)
+
+	conn, err := grpc.DialContext(
+		context.Background(),
+		"bufconn",
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+	)
+	if err != nil {
+		grpcServer.Stop()
+		return nil, fmt.Errorf("failed dialing the fake server: %v", err)
+	}
+
+	return &Client{
+		PermissionClient: pb.NewPermissionClient(conn),
+		Server:           server,
+		grpcServer:       grpcServer,
+		conn:             conn,
+	}, nil
+}
+
+// Close closes the client's connection and stops its server.
+func (c *Client) Close() error {
+	err := c.conn.Close()
+	c.grpcServer.Stop()
+	return err
+}
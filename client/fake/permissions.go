@@ -0,0 +1,643 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/meateam/permission-service/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CreatePermission creates a permission of a user to a file, or replaces it if override is set,
+// and returns it. A user that reshares a file must be allowed to reshare it.
+func (s *Server) CreatePermission(
+	ctx context.Context,
+	req *pb.CreatePermissionRequest,
+) (*pb.PermissionObject, error) {
+	if pb.Role_name[int32(req.GetRole())] == "" {
+		return nil, status.Error(codes.InvalidArgument, "role does not exist")
+	}
+
+	if err := service.ValidateLabels(req.GetLabels()); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := keyOf(req.GetResourceType(), req.GetFileID(), req.GetUserID())
+	existing, ok := s.permissions[key]
+	if ok && !req.GetOverride() {
+		return s.marshal(existing), nil
+	}
+
+	creator := req.GetCreator()
+	var sharingChain []string
+	if creator != req.GetUserID() {
+		creatorRecord, ok := s.permissions[keyOf(key.resourceType, key.fileID, creator)]
+		if !ok || !creatorRecord.permission.GetCanReshare() {
+			return nil, service.RejectionError(
+				codes.PermissionDenied,
+				service.Rejection{Kind: service.RejectionPolicy, Rule: "can_reshare", Subject: creator},
+				"user %s is not allowed to share file %s",
+				creator,
+				key.fileID,
+			)
+		}
+
+		sharingChain = append(append([]string{}, creatorRecord.sharingChain...), creator)
+	}
+
+	canReshare := true
+	if req.GetCanReshare() != nil {
+		canReshare = req.GetCanReshare().GetValue()
+	}
+
+	permission := &pb.PermissionObject{
+		FileID:       key.fileID,
+		UserID:       key.userID,
+		Role:         req.GetRole(),
+		Creator:      creator,
+		CanReshare:   canReshare,
+		Message:      req.GetMessage(),
+		Label:        req.GetLabel(),
+		ResourceType: key.resourceType,
+		ResourceKind: orDefault(req.GetResourceKind(), service.ResourceKindFile),
+		GranteeType:  orDefault(req.GetGranteeType(), service.GranteeTypeUser),
+		Labels:       req.GetLabels(),
+		Source:       orDefault(req.GetSource(), service.SourceAPI),
+	}
+
+	// A replaced permission keeps its ID and creation, and its version is incremented.
+	if ok {
+		permission.Id, permission.LastAccessedAt = existing.permission.GetId(), existing.permission.GetLastAccessedAt()
+		existing.permission, existing.sharingChain = permission, sharingChain
+		existing.version++
+		return s.marshal(existing), nil
+	}
+
+	s.sequence++
+	created := &record{
+		permission:   permission,
+		sharingChain: sharingChain,
+		createdAt:    s.now(),
+		sequence:     s.sequence,
+		version:      1,
+	}
+	created.permission.Id = fmt.Sprintf("%024x", created.sequence)
+	s.permissions[key] = created
+
+	return s.marshal(created), nil
+}
+
+// DeletePermission deletes the permission of a user to a file and returns it. It fails with NotFound
+// if there's none, or with Aborted if the request's etag isn't the permission's current etag.
+func (s *Server) DeletePermission(
+	ctx context.Context,
+	req *pb.DeletePermissionRequest,
+) (*pb.PermissionObject, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := keyOf(req.GetResourceType(), req.GetFileID(), req.GetUserID())
+	deleted, ok := s.permissions[key]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "permission not found")
+	}
+
+	if etag := req.GetEtag(); etag != "" && etag != deleted.etag() {
+		return nil, status.Errorf(codes.Aborted, "permission etag %s does not match the current etag", etag)
+	}
+
+	delete(s.permissions, key)
+	return s.marshal(deleted), nil
+}
+
+// GetPermission returns the permission of a user to a file, or fails with NotFound if there's none.
+func (s *Server) GetPermission(ctx context.Context, req *pb.GetPermissionRequest) (*pb.PermissionObject, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	found, ok := s.permissions[keyOf(req.GetResourceType(), req.GetFileID(), req.GetUserID())]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "permission not found")
+	}
+
+	return s.marshal(found), nil
+}
+
+// TouchPermission sets the last access of the permission of a user to a file to now, and returns it.
+func (s *Server) TouchPermission(
+	ctx context.Context,
+	req *pb.TouchPermissionRequest,
+) (*pb.PermissionObject, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	touched, ok := s.permissions[keyOf(req.GetResourceType(), req.GetFileID(), req.GetUserID())]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "permission not found")
+	}
+
+	lastAccessedAt, err := ptypes.TimestampProto(s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	touched.permission.LastAccessedAt = lastAccessedAt
+	return s.marshal(touched), nil
+}
+
+// IsPermitted returns whether the permission of a user to a file grants the request's role, or capability
+// if it's set. It fails with NotFound if the user has no permission to the file.
+func (s *Server) IsPermitted(ctx context.Context, req *pb.IsPermittedRequest) (*pb.IsPermittedResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.isPermitted(req)
+}
+
+// CheckPermissionsMatrix makes each of the request's checks as IsPermitted, and reports the failure
+// of a check in its result.
+func (s *Server) CheckPermissionsMatrix(
+	ctx context.Context,
+	req *pb.CheckPermissionsMatrixRequest,
+) (*pb.CheckPermissionsMatrixResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	response := &pb.CheckPermissionsMatrixResponse{
+		Results: make([]*pb.CheckPermissionsMatrixResponse_Result, 0, len(req.GetChecks())),
+	}
+	for _, check := range req.GetChecks() {
+		res, err := s.isPermitted(check)
+		result := &pb.CheckPermissionsMatrixResponse_Result{Permitted: res.GetPermitted(), MaxAge: res.GetMaxAge()}
+		if err != nil {
+			errStatus := status.Convert(err)
+			result.Code, result.Message = int32(errStatus.Code()), errStatus.Message()
+		}
+
+		response.Results = append(response.Results, result)
+	}
+
+	return response, nil
+}
+
+// isPermitted checks whether the user of req is permitted to its file.
+func (s *Server) isPermitted(req *pb.IsPermittedRequest) (*pb.IsPermittedResponse, error) {
+	if pb.Role_name[int32(req.GetRole())] == "" {
+		return nil, status.Error(codes.InvalidArgument, "role does not exist")
+	}
+
+	found, ok := s.permissions[keyOf(req.GetResourceType(), req.GetFileID(), req.GetUserID())]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "permission not found")
+	}
+
+	permitted := service.IsSubRole(found.permission.GetRole(), req.GetRole())
+	if capability := req.GetCapability(); capability != pb.Capability_NO_CAPABILITY {
+		permitted = service.HasCapability(found.permission.GetResourceKind(), found.permission.GetRole(), capability)
+	}
+
+	return &pb.IsPermittedResponse{Permitted: permitted, MaxAge: ptypes.DurationProto(0)}, nil
+}
+
+// GetFilePermissions returns the permissions of the users to a file that match the request's selector,
+// in the order they were created, or most recently accessed first.
+func (s *Server) GetFilePermissions(
+	ctx context.Context,
+	req *pb.GetFilePermissionsRequest,
+) (*pb.GetFilePermissionsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resourceType := orDefault(req.GetResourceType(), service.DefaultResourceType)
+	records := s.find(func(r *record) bool {
+		return r.permission.GetResourceType() == resourceType &&
+			r.permission.GetFileID() == req.GetFileID() &&
+			matches(r.permission, req.GetLabelSelector(), req.GetSource())
+	}, req.GetOrder())
+
+	page, nextPageToken, err := paginate(records, req.GetPageSize(), req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.GetFilePermissionsResponse{
+		Permissions:   make([]*pb.GetFilePermissionsResponse_UserRole, 0, len(page)),
+		NextPageToken: nextPageToken,
+	}
+	for _, r := range page {
+		response.Permissions = append(response.Permissions, &pb.GetFilePermissionsResponse_UserRole{
+			UserID:         r.permission.GetUserID(),
+			Role:           r.permission.GetRole(),
+			Creator:        r.permission.GetCreator(),
+			CanReshare:     r.permission.GetCanReshare(),
+			Message:        r.permission.GetMessage(),
+			Label:          r.permission.GetLabel(),
+			LastAccessedAt: r.permission.GetLastAccessedAt(),
+			Labels:         r.permission.GetLabels(),
+			Source:         r.permission.GetSource(),
+		})
+	}
+
+	return response, nil
+}
+
+// GetUserPermissions returns the permissions of a user to the files that match the request's selector,
+// in the order they were created, or most recently accessed first.
+func (s *Server) GetUserPermissions(
+	ctx context.Context,
+	req *pb.GetUserPermissionsRequest,
+) (*pb.GetUserPermissionsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resourceType := orDefault(req.GetResourceType(), service.DefaultResourceType)
+	records := s.find(func(r *record) bool {
+		return r.permission.GetResourceType() == resourceType &&
+			r.permission.GetUserID() == req.GetUserID() &&
+			matches(r.permission, req.GetLabelSelector(), req.GetSource())
+	}, req.GetOrder())
+
+	page, nextPageToken, err := paginate(records, req.GetPageSize(), req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.GetUserPermissionsResponse{
+		Permissions:   make([]*pb.GetUserPermissionsResponse_FileRole, 0, len(page)),
+		NextPageToken: nextPageToken,
+	}
+	for _, r := range page {
+		response.Permissions = append(response.Permissions, &pb.GetUserPermissionsResponse_FileRole{
+			FileID:         r.permission.GetFileID(),
+			Role:           r.permission.GetRole(),
+			Creator:        r.permission.GetCreator(),
+			CanReshare:     r.permission.GetCanReshare(),
+			Message:        r.permission.GetMessage(),
+			Label:          r.permission.GetLabel(),
+			LastAccessedAt: r.permission.GetLastAccessedAt(),
+			ResourceType:   r.permission.GetResourceType(),
+			Labels:         r.permission.GetLabels(),
+			Source:         r.permission.GetSource(),
+		})
+	}
+
+	return response, nil
+}
+
+// DeleteFilePermissions deletes the permissions to a file that match the request's selector, and returns them.
+func (s *Server) DeleteFilePermissions(
+	ctx context.Context,
+	req *pb.DeleteFilePermissionsRequest,
+) (*pb.DeleteFilePermissionsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resourceType := orDefault(req.GetResourceType(), service.DefaultResourceType)
+	deleted := s.delete(func(r *record) bool {
+		return r.permission.GetResourceType() == resourceType &&
+			r.permission.GetFileID() == req.GetFileID() &&
+			matches(r.permission, req.GetLabelSelector(), req.GetSource())
+	})
+
+	return &pb.DeleteFilePermissionsResponse{Permissions: deleted}, nil
+}
+
+// CopyPermissions copies the permissions to a file to another file. The permissions of the users that
+// already have a permission to the other file are skipped, unless overwrite is set.
+func (s *Server) CopyPermissions(
+	ctx context.Context,
+	req *pb.CopyPermissionsRequest,
+) (*pb.CopyPermissionsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resourceType := orDefault(req.GetResourceType(), service.DefaultResourceType)
+	sources := s.find(func(r *record) bool {
+		return r.permission.GetResourceType() == resourceType && r.permission.GetFileID() == req.GetSourceFileID()
+	}, pb.PermissionsOrder_DEFAULT)
+
+	response := &pb.CopyPermissionsResponse{}
+	for _, source := range sources {
+		key := keyOf(resourceType, req.GetDestFileID(), source.permission.GetUserID())
+		existing, ok := s.permissions[key]
+		if ok && !req.GetOverwrite() {
+			response.Skipped++
+			continue
+		}
+
+		permission := proto.Clone(source.permission).(*pb.PermissionObject)
+		permission.FileID, permission.LastAccessedAt = req.GetDestFileID(), nil
+		if ok {
+			permission.Id = existing.permission.GetId()
+			existing.permission, existing.sharingChain = permission, source.sharingChain
+			existing.version++
+		} else {
+			s.sequence++
+			permission.Id = fmt.Sprintf("%024x", s.sequence)
+			s.permissions[key] = &record{
+				permission:   permission,
+				sharingChain: source.sharingChain,
+				createdAt:    s.now(),
+				sequence:     s.sequence,
+				version:      1,
+			}
+		}
+
+		response.Copied++
+	}
+
+	return response, nil
+}
+
+// RevokeCascade deletes the permission of a user to a file, and the permissions to the file that were reshared
+// from it, directly or through the users it was reshared to, and returns them.
+func (s *Server) RevokeCascade(
+	ctx context.Context,
+	req *pb.RevokeCascadeRequest,
+) (*pb.RevokeCascadeResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := keyOf(req.GetResourceType(), req.GetFileID(), req.GetUserID())
+	if _, ok := s.permissions[key]; !ok {
+		return nil, status.Error(codes.NotFound, "permission not found")
+	}
+
+	revoked := s.delete(func(r *record) bool {
+		if r.permission.GetResourceType() != key.resourceType || r.permission.GetFileID() != key.fileID {
+			return false
+		}
+
+		if r.permission.GetUserID() == key.userID {
+			return true
+		}
+
+		for _, sharer := range r.sharingChain {
+			if sharer == key.userID {
+				return true
+			}
+		}
+
+		return false
+	})
+
+	return &pb.RevokeCascadeResponse{Permissions: revoked}, nil
+}
+
+// RevokeAllExceptOwner deletes every permission to a file other than its owner's, and returns them.
+// The owner must be set, since the fake has no file service to read it from.
+func (s *Server) RevokeAllExceptOwner(
+	ctx context.Context,
+	req *pb.RevokeAllExceptOwnerRequest,
+) (*pb.RevokeAllExceptOwnerResponse, error) {
+	if req.GetOwnerID() == "" {
+		return nil, status.Error(codes.FailedPrecondition, "ownerID is required without a file service")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resourceType := orDefault(req.GetResourceType(), service.DefaultResourceType)
+	revoked := s.delete(func(r *record) bool {
+		return r.permission.GetResourceType() == resourceType &&
+			r.permission.GetFileID() == req.GetFileID() &&
+			r.permission.GetUserID() != req.GetOwnerID()
+	})
+
+	return &pb.RevokeAllExceptOwnerResponse{Permissions: revoked}, nil
+}
+
+// GetSharedFiles returns the files that both users have a permission to, ordered by their IDs.
+// If grantedByA is set, only the files that were shared with userB by userA are returned.
+func (s *Server) GetSharedFiles(
+	ctx context.Context,
+	req *pb.GetSharedFilesRequest,
+) (*pb.GetSharedFilesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resourceType := orDefault(req.GetResourceType(), service.DefaultResourceType)
+	files := []*pb.GetSharedFilesResponse_SharedFile{}
+	for key, r := range s.permissions {
+		if key.resourceType != resourceType || key.userID != req.GetUserA() {
+			continue
+		}
+
+		userB, ok := s.permissions[keyOf(resourceType, key.fileID, req.GetUserB())]
+		if !ok || (req.GetGrantedByA() && userB.permission.GetCreator() != req.GetUserA()) {
+			continue
+		}
+
+		files = append(files, &pb.GetSharedFilesResponse_SharedFile{
+			FileID:    key.fileID,
+			UserARole: r.permission.GetRole(),
+			UserBRole: userB.permission.GetRole(),
+		})
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].GetFileID() < files[j].GetFileID() })
+	return &pb.GetSharedFilesResponse{Files: files}, nil
+}
+
+// ListSharedWithMe returns the files that other users shared with a user, most recently shared first.
+func (s *Server) ListSharedWithMe(
+	ctx context.Context,
+	req *pb.ListSharedWithMeRequest,
+) (*pb.ListSharedWithMeResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resourceType := orDefault(req.GetResourceType(), service.DefaultResourceType)
+	records := s.find(func(r *record) bool {
+		return r.permission.GetResourceType() == resourceType &&
+			r.permission.GetUserID() == req.GetUserID() &&
+			r.permission.GetCreator() != req.GetUserID()
+	}, pb.PermissionsOrder_DEFAULT)
+
+	sort.SliceStable(records, func(i, j int) bool { return records[i].sequence > records[j].sequence })
+	pageSize := req.GetPageSize()
+	if pageSize == 0 {
+		pageSize = service.DefaultPageSize
+	}
+
+	page, nextPageToken, err := paginate(records, pageSize, req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.ListSharedWithMeResponse{
+		Files:         make([]*pb.ListSharedWithMeResponse_SharedFile, 0, len(page)),
+		NextPageToken: nextPageToken,
+	}
+	for _, r := range page {
+		sharedAt, err := ptypes.TimestampProto(r.createdAt)
+		if err != nil {
+			return nil, err
+		}
+
+		response.Files = append(response.Files, &pb.ListSharedWithMeResponse_SharedFile{
+			FileID:   r.permission.GetFileID(),
+			Role:     r.permission.GetRole(),
+			SharedBy: r.permission.GetCreator(),
+			SharedAt: sharedAt,
+		})
+	}
+
+	return response, nil
+}
+
+// PermissionsExist returns whether each of the request's permissions exists by its ID.
+func (s *Server) PermissionsExist(
+	ctx context.Context,
+	req *pb.PermissionsExistRequest,
+) (*pb.PermissionsExistResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := map[string]bool{}
+	for _, r := range s.permissions {
+		ids[r.permission.GetId()] = true
+	}
+
+	response := &pb.PermissionsExistResponse{Exists: make([]bool, 0, len(req.GetIds()))}
+	for _, id := range req.GetIds() {
+		response.Exists = append(response.Exists, ids[id])
+	}
+
+	return response, nil
+}
+
+// find returns the permissions that match, in the order they were created,
+// or most recently accessed first if order is RECENTLY_ACCESSED.
+func (s *Server) find(match func(r *record) bool, order pb.PermissionsOrder) []*record {
+	found := []*record{}
+	for _, r := range s.permissions {
+		if match(r) {
+			found = append(found, r)
+		}
+	}
+
+	sort.Slice(found, func(i, j int) bool { return found[i].sequence < found[j].sequence })
+	if order == pb.PermissionsOrder_RECENTLY_ACCESSED {
+		sort.SliceStable(found, func(i, j int) bool {
+			return accessedAt(found[i]).After(accessedAt(found[j]))
+		})
+	}
+
+	return found
+}
+
+// delete deletes the permissions that match, and returns them in the order they were created.
+func (s *Server) delete(match func(r *record) bool) []*pb.PermissionObject {
+	deleted := []*pb.PermissionObject{}
+	for _, r := range s.find(match, pb.PermissionsOrder_DEFAULT) {
+		key := keyOf(r.permission.GetResourceType(), r.permission.GetFileID(), r.permission.GetUserID())
+		delete(s.permissions, key)
+		deleted = append(deleted, s.marshal(r))
+	}
+
+	return deleted
+}
+
+// marshal returns a copy of the permission of r, with its etag and capabilities.
+func (s *Server) marshal(r *record) *pb.PermissionObject {
+	permission := proto.Clone(r.permission).(*pb.PermissionObject)
+	permission.Etag = r.etag()
+	permission.SharingChain = append([]string(nil), r.sharingChain...)
+	permission.Capabilities = service.Capabilities(permission.GetResourceKind(), permission.GetRole())
+	return permission
+}
+
+// etag returns the etag of the current version of r.
+func (r *record) etag() string {
+	return fmt.Sprintf("%s-%d", r.permission.GetId(), r.version)
+}
+
+// paginate returns the page of records of up to pageSize records after pageToken, and the token of the
+// next page, which is empty if there are no more pages. All the records are returned if pageSize and
+// pageToken are empty, as by the permission service.
+func paginate(records []*record, pageSize int32, pageToken string) ([]*record, string, error) {
+	if pageSize < 0 {
+		return nil, "", status.Error(codes.InvalidArgument, "pageSize must not be negative")
+	}
+
+	offset := 0
+	if pageToken != "" {
+		var err error
+		if offset, err = strconv.Atoi(pageToken); err != nil || offset < 0 {
+			return nil, "", status.Errorf(codes.InvalidArgument, "invalid page token %q", pageToken)
+		}
+
+		if pageSize == 0 {
+			pageSize = service.DefaultPageSize
+		}
+	}
+
+	if pageSize > service.MaxPageSize {
+		pageSize = service.MaxPageSize
+	}
+
+	if offset > len(records) {
+		offset = len(records)
+	}
+
+	records = records[offset:]
+	if pageSize == 0 || int(pageSize) >= len(records) {
+		return records, "", nil
+	}
+
+	return records[:pageSize], strconv.Itoa(offset + int(pageSize)), nil
+}
+
+// matches returns whether permission has all of labels and was created by source, if it's set.
+func matches(permission *pb.PermissionObject, labels map[string]string, source string) bool {
+	if source != "" && permission.GetSource() != source {
+		return false
+	}
+
+	for key, value := range labels {
+		if permission.GetLabels()[key] != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// accessedAt returns the last access of the permission of r, or its creation if it was never accessed.
+func accessedAt(r *record) time.Time {
+	if lastAccessedAt, err := ptypes.Timestamp(r.permission.GetLastAccessedAt()); err == nil {
+		return lastAccessedAt
+	}
+
+	return r.createdAt
+}
+
+// keyOf returns the key of the permission of userID to fileID, of the default resource type if resourceType
+// is empty.
+func keyOf(resourceType string, fileID string, userID string) permissionKey {
+	return permissionKey{
+		resourceType: orDefault(resourceType, service.DefaultResourceType),
+		fileID:       fileID,
+		userID:       userID,
+	}
+}
+
+// orDefault returns value, or defaultValue if value is empty.
+func orDefault(value string, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+
+	return value
+}
@@ -0,0 +1,290 @@
+// Command loadgen drives a configurable mix of CreatePermission, GetPermission and DeletePermission
+// requests against a permission service for a duration, and reports the latency percentiles of each.
+//
+//	loadgen -target localhost:8080 -duration 1m -concurrency 20 -mix create=1,get=8,delete=1
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/meateam/permission-service/proto"
+	"google.golang.org/grpc"
+)
+
+const (
+	opCreate = "create"
+	opGet    = "get"
+	opDelete = "delete"
+)
+
+// ops are the operations of the mix, in the order they're reported.
+var ops = []string{opCreate, opGet, opDelete}
+
+// mix is the relative weights of the operations.
+type mix map[string]int
+
+// parseMix parses a mix such as "create=1,get=8,delete=1". Operations that aren't specified aren't run.
+func parseMix(s string) (mix, error) {
+	m := make(mix)
+	total := 0
+	for _, entry := range strings.Split(s, ",") {
+		parts := strings.SplitN(strings.TrimSpace(entry), "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid mix entry %q, expected op=weight", entry)
+		}
+
+		weight, err := strconv.Atoi(parts[1])
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("invalid weight of %s: %q", parts[0], parts[1])
+		}
+
+		switch parts[0] {
+		case opCreate, opGet, opDelete:
+			m[parts[0]] = weight
+			total += weight
+		default:
+			return nil, fmt.Errorf("unknown operation %q", parts[0])
+		}
+	}
+
+	if total == 0 {
+		return nil, fmt.Errorf("mix %q has no weight", s)
+	}
+
+	return m, nil
+}
+
+// pick returns a random operation by the weights of m.
+func (m mix) pick(r *rand.Rand) string {
+	total := 0
+	for _, weight := range m {
+		total += weight
+	}
+
+	n := r.Intn(total)
+	for _, op := range ops {
+		if n < m[op] {
+			return op
+		}
+
+		n -= m[op]
+	}
+
+	return opCreate
+}
+
+// grant is a permission that was created by the load generator.
+type grant struct {
+	fileID string
+	userID string
+}
+
+// grants is the pool of created permissions that are read and deleted.
+type grants struct {
+	mu     sync.Mutex
+	grants []grant
+}
+
+// add adds g to the pool.
+func (p *grants) add(g grant) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.grants = append(p.grants, g)
+}
+
+// sample returns a random permission of the pool, or false if it's empty.
+// If remove is true then the permission is removed from the pool.
+func (p *grants) sample(r *rand.Rand, remove bool) (grant, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.grants) == 0 {
+		return grant{}, false
+	}
+
+	i := r.Intn(len(p.grants))
+	g := p.grants[i]
+	if remove {
+		last := len(p.grants) - 1
+		p.grants[i] = p.grants[last]
+		p.grants = p.grants[:last]
+	}
+
+	return g, true
+}
+
+// result is the latencies and errors of an operation.
+type result struct {
+	latencies []time.Duration
+	errors    int
+}
+
+// loadgen is a load generator of a permission service.
+type loadgen struct {
+	client  pb.PermissionClient
+	mix     mix
+	files   int
+	timeout time.Duration
+	grants  *grants
+}
+
+// run runs operations until ctx is done and returns their results by operation.
+func (l loadgen) run(ctx context.Context, worker int) map[string]*result {
+	r := rand.New(rand.NewSource(time.Now().UnixNano() + int64(worker)))
+	results := make(map[string]*result, len(ops))
+	for _, op := range ops {
+		results[op] = &result{}
+	}
+
+	for i := 0; ctx.Err() == nil; i++ {
+		op := l.mix.pick(r)
+		reqCtx, cancel := context.WithTimeout(ctx, l.timeout)
+		start := time.Now()
+		op, err := l.do(reqCtx, r, op, fmt.Sprintf("loadgen-%d-%d", worker, i))
+		latency := time.Since(start)
+		cancel()
+
+		// Requests that were cut off by the end of the run aren't measured.
+		if ctx.Err() != nil {
+			break
+		}
+
+		if err != nil {
+			results[op].errors++
+			continue
+		}
+
+		results[op].latencies = append(results[op].latencies, latency)
+	}
+
+	return results
+}
+
+// do runs op and returns the operation that ran, which is a create if there are no permissions
+// to read or delete, and any error if occurred.
+func (l loadgen) do(ctx context.Context, r *rand.Rand, op string, userID string) (string, error) {
+	if op != opCreate {
+		if g, ok := l.grants.sample(r, op == opDelete); ok {
+			if op == opGet {
+				_, err := l.client.GetPermission(ctx, &pb.GetPermissionRequest{FileID: g.fileID, UserID: g.userID})
+				return op, err
+			}
+
+			_, err := l.client.DeletePermission(ctx, &pb.DeletePermissionRequest{FileID: g.fileID, UserID: g.userID})
+			return op, err
+		}
+	}
+
+	g := grant{fileID: fmt.Sprintf("loadgen-file-%d", r.Intn(l.files)), userID: userID}
+	_, err := l.client.CreatePermission(ctx, &pb.CreatePermissionRequest{
+		FileID:  g.fileID,
+		UserID:  g.userID,
+		Role:    pb.Role_READ,
+		Creator: g.userID,
+	})
+	if err == nil {
+		l.grants.add(g)
+	}
+
+	return opCreate, err
+}
+
+// percentile returns the p-th percentile of the sorted latencies.
+func percentile(latencies []time.Duration, p float64) time.Duration {
+	if len(latencies) == 0 {
+		return 0
+	}
+
+	i := int(float64(len(latencies)-1) * p / 100)
+	return latencies[i]
+}
+
+func main() {
+	target := flag.String("target", "localhost:8080", "address of the permission service")
+	duration := flag.Duration("duration", 30*time.Second, "duration of the load")
+	concurrency := flag.Int("concurrency", 10, "number of concurrent workers")
+	mixFlag := flag.String("mix", "create=1,get=8,delete=1", "relative weights of the operations")
+	files := flag.Int("files", 100, "number of files that permissions are created to")
+	timeout := flag.Duration("timeout", 5*time.Second, "timeout of each request")
+	flag.Parse()
+
+	m, err := parseMix(*mixFlag)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	if *concurrency < 1 || *files < 1 {
+		fmt.Fprintln(os.Stderr, "concurrency and files must be positive")
+		os.Exit(2)
+	}
+
+	conn, err := grpc.Dial(*target, grpc.WithInsecure())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed dialing %s: %v\n", *target, err)
+		os.Exit(1)
+	}
+	defer conn.Close()
+
+	l := loadgen{
+		client:  pb.NewPermissionClient(conn),
+		mix:     m,
+		files:   *files,
+		timeout: *timeout,
+		grants:  &grants{},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *duration)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	workerResults := make([]map[string]*result, *concurrency)
+	for worker := 0; worker < *concurrency; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			workerResults[worker] = l.run(ctx, worker)
+		}(worker)
+	}
+
+	wg.Wait()
+	report(workerResults, *duration)
+}
+
+// report prints the throughput, errors and latency percentiles of each operation.
+func report(workerResults []map[string]*result, duration time.Duration) {
+	fmt.Printf(
+		"%-8s %10s %8s %10s %10s %10s %10s %10s\n",
+		"op", "requests", "errors", "rps", "p50", "p90", "p99", "max",
+	)
+	for _, op := range ops {
+		total := &result{}
+		for _, results := range workerResults {
+			total.latencies = append(total.latencies, results[op].latencies...)
+			total.errors += results[op].errors
+		}
+
+		sort.Slice(total.latencies, func(i, j int) bool { return total.latencies[i] < total.latencies[j] })
+		requests := len(total.latencies) + total.errors
+		fmt.Printf(
+			"%-8s %10d %8d %10.1f %10s %10s %10s %10s\n",
+			op,
+			requests,
+			total.errors,
+			float64(requests)/duration.Seconds(),
+			percentile(total.latencies, 50),
+			percentile(total.latencies, 90),
+			percentile(total.latencies, 99),
+			percentile(total.latencies, 100),
+		)
+	}
+}
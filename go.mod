@@ -3,13 +3,17 @@ module github.com/meateam/permission-service
 go 1.13
 
 require (
+	github.com/DataDog/zstd v1.5.7 // indirect
 	github.com/golang/protobuf v1.3.2
 	github.com/grpc-ecosystem/go-grpc-middleware v1.1.0
 	github.com/meateam/elasticsearch-logger v1.1.3-0.20190901111807-4e8b84fb9fda
 	github.com/sirupsen/logrus v1.4.2
 	github.com/spf13/viper v1.4.0
+	go.elastic.co/apm/module/apmgrpc v1.5.0
 	go.elastic.co/apm/module/apmmongo v1.5.0
-	go.mongodb.org/mongo-driver v1.1.0
+	go.mongodb.org/mongo-driver v1.2.0
+	golang.org/x/sync v0.0.0-20190423024810-112230192c58
+	google.golang.org/genproto v0.0.0-20190819201941-24fa4b261c55
 	google.golang.org/grpc v1.23.1
 )
 
@@ -1,9 +1,19 @@
 package main
 
 import (
+	"flag"
+
 	"github.com/meateam/permission-service/server"
 )
 
+// selfTest is whether the server runs its self-test after startup, like `SELF_TEST`.
+var selfTest = flag.Bool("self-test", false, "run a create, get, update and delete cycle on startup")
+
 func main() {
+	flag.Parse()
+	if *selfTest {
+		server.EnableSelfTest()
+	}
+
 	server.NewServer(nil).Serve(nil)
 }
@@ -0,0 +1,389 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: file.proto
+
+package file
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type GetByFileByIDRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetByFileByIDRequest) Reset()         { *m = GetByFileByIDRequest{} }
+func (m *GetByFileByIDRequest) String() string { return proto.CompactTextString(m) }
+func (*GetByFileByIDRequest) ProtoMessage()    {}
+func (*GetByFileByIDRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9188e3b7e55e1162, []int{0}
+}
+
+func (m *GetByFileByIDRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetByFileByIDRequest.Unmarshal(m, b)
+}
+func (m *GetByFileByIDRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetByFileByIDRequest.Marshal(b, m, deterministic)
+}
+func (m *GetByFileByIDRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetByFileByIDRequest.Merge(m, src)
+}
+func (m *GetByFileByIDRequest) XXX_Size() int {
+	return xxx_messageInfo_GetByFileByIDRequest.Size(m)
+}
+func (m *GetByFileByIDRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetByFileByIDRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetByFileByIDRequest proto.InternalMessageInfo
+
+func (m *GetByFileByIDRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type File struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type                 string   `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	OwnerID              string   `protobuf:"bytes,7,opt,name=ownerID,proto3" json:"ownerID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *File) Reset()         { *m = File{} }
+func (m *File) String() string { return proto.CompactTextString(m) }
+func (*File) ProtoMessage()    {}
+func (*File) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9188e3b7e55e1162, []int{1}
+}
+
+func (m *File) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_File.Unmarshal(m, b)
+}
+func (m *File) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_File.Marshal(b, m, deterministic)
+}
+func (m *File) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_File.Merge(m, src)
+}
+func (m *File) XXX_Size() int {
+	return xxx_messageInfo_File.Size(m)
+}
+func (m *File) XXX_DiscardUnknown() {
+	xxx_messageInfo_File.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_File proto.InternalMessageInfo
+
+func (m *File) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *File) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *File) GetOwnerID() string {
+	if m != nil {
+		return m.OwnerID
+	}
+	return ""
+}
+
+type GetDescendantsByIDRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetDescendantsByIDRequest) Reset()         { *m = GetDescendantsByIDRequest{} }
+func (m *GetDescendantsByIDRequest) String() string { return proto.CompactTextString(m) }
+func (*GetDescendantsByIDRequest) ProtoMessage()    {}
+func (*GetDescendantsByIDRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9188e3b7e55e1162, []int{2}
+}
+
+func (m *GetDescendantsByIDRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDescendantsByIDRequest.Unmarshal(m, b)
+}
+func (m *GetDescendantsByIDRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDescendantsByIDRequest.Marshal(b, m, deterministic)
+}
+func (m *GetDescendantsByIDRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDescendantsByIDRequest.Merge(m, src)
+}
+func (m *GetDescendantsByIDRequest) XXX_Size() int {
+	return xxx_messageInfo_GetDescendantsByIDRequest.Size(m)
+}
+func (m *GetDescendantsByIDRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDescendantsByIDRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDescendantsByIDRequest proto.InternalMessageInfo
+
+func (m *GetDescendantsByIDRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type GetDescendantsByIDResponse struct {
+	Descendants          []*Descendant `protobuf:"bytes,1,rep,name=descendants,proto3" json:"descendants,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *GetDescendantsByIDResponse) Reset()         { *m = GetDescendantsByIDResponse{} }
+func (m *GetDescendantsByIDResponse) String() string { return proto.CompactTextString(m) }
+func (*GetDescendantsByIDResponse) ProtoMessage()    {}
+func (*GetDescendantsByIDResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9188e3b7e55e1162, []int{3}
+}
+
+func (m *GetDescendantsByIDResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDescendantsByIDResponse.Unmarshal(m, b)
+}
+func (m *GetDescendantsByIDResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDescendantsByIDResponse.Marshal(b, m, deterministic)
+}
+func (m *GetDescendantsByIDResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDescendantsByIDResponse.Merge(m, src)
+}
+func (m *GetDescendantsByIDResponse) XXX_Size() int {
+	return xxx_messageInfo_GetDescendantsByIDResponse.Size(m)
+}
+func (m *GetDescendantsByIDResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDescendantsByIDResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDescendantsByIDResponse proto.InternalMessageInfo
+
+func (m *GetDescendantsByIDResponse) GetDescendants() []*Descendant {
+	if m != nil {
+		return m.Descendants
+	}
+	return nil
+}
+
+type Descendant struct {
+	File                 *File    `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Descendant) Reset()         { *m = Descendant{} }
+func (m *Descendant) String() string { return proto.CompactTextString(m) }
+func (*Descendant) ProtoMessage()    {}
+func (*Descendant) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9188e3b7e55e1162, []int{4}
+}
+
+func (m *Descendant) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Descendant.Unmarshal(m, b)
+}
+func (m *Descendant) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Descendant.Marshal(b, m, deterministic)
+}
+func (m *Descendant) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Descendant.Merge(m, src)
+}
+func (m *Descendant) XXX_Size() int {
+	return xxx_messageInfo_Descendant.Size(m)
+}
+func (m *Descendant) XXX_DiscardUnknown() {
+	xxx_messageInfo_Descendant.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Descendant proto.InternalMessageInfo
+
+func (m *Descendant) GetFile() *File {
+	if m != nil {
+		return m.File
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*GetByFileByIDRequest)(nil), "file.GetByFileByIDRequest")
+	proto.RegisterType((*File)(nil), "file.File")
+	proto.RegisterType((*GetDescendantsByIDRequest)(nil), "file.GetDescendantsByIDRequest")
+	proto.RegisterType((*GetDescendantsByIDResponse)(nil), "file.GetDescendantsByIDResponse")
+	proto.RegisterType((*Descendant)(nil), "file.Descendant")
+}
+
+func init() { proto.RegisterFile("file.proto", fileDescriptor_9188e3b7e55e1162) }
+
+var fileDescriptor_9188e3b7e55e1162 = []byte{
+	// 291 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x51, 0x41, 0x4f, 0xb3, 0x40,
+	0x10, 0x2d, 0xfd, 0xf8, 0x6c, 0x1c, 0x12, 0x63, 0x26, 0x1e, 0x90, 0x83, 0x92, 0x3d, 0x98, 0x26,
+	0x2a, 0x24, 0x18, 0xd3, 0x83, 0x37, 0x42, 0x24, 0xbd, 0x19, 0x3c, 0xe9, 0x8d, 0xc2, 0xa8, 0x9b,
+	0x14, 0x16, 0xd9, 0xad, 0x86, 0x9f, 0xe3, 0x3f, 0x35, 0xbb, 0xa4, 0xd2, 0x28, 0x7a, 0x21, 0x33,
+	0x6f, 0xde, 0x9b, 0xe1, 0xbd, 0x05, 0x78, 0xe2, 0x6b, 0x0a, 0x9a, 0x56, 0x28, 0x81, 0xb6, 0xae,
+	0xd9, 0x19, 0x1c, 0xa5, 0xa4, 0xe2, 0xee, 0x96, 0xaf, 0x29, 0xee, 0x96, 0x49, 0x46, 0xaf, 0x1b,
+	0x92, 0x0a, 0x0f, 0x60, 0xca, 0x4b, 0xd7, 0xf2, 0xad, 0xf9, 0x7e, 0x36, 0xe5, 0x25, 0x4b, 0xc0,
+	0xd6, 0x94, 0xef, 0x38, 0x22, 0xd8, 0xaa, 0x6b, 0xc8, 0xfd, 0x6f, 0x10, 0x53, 0xa3, 0x0b, 0x33,
+	0xf1, 0x5e, 0x53, 0xbb, 0x4c, 0xdc, 0x99, 0x81, 0xb7, 0x2d, 0x3b, 0x87, 0xe3, 0x94, 0x54, 0x42,
+	0xb2, 0xa0, 0xba, 0xcc, 0x6b, 0x25, 0xff, 0x3a, 0x79, 0x07, 0xde, 0x18, 0x59, 0x36, 0xa2, 0x96,
+	0x84, 0x11, 0x38, 0xe5, 0x30, 0x72, 0x2d, 0xff, 0xdf, 0xdc, 0x89, 0x0e, 0x03, 0x63, 0x70, 0xd0,
+	0x64, 0xbb, 0x24, 0x76, 0x01, 0x30, 0x8c, 0xf0, 0x04, 0x4c, 0x04, 0xe6, 0xa2, 0x13, 0x41, 0x2f,
+	0xd5, 0x26, 0x33, 0x83, 0x47, 0x1f, 0x16, 0x38, 0xba, 0xbd, 0xa7, 0xf6, 0x8d, 0x17, 0x84, 0x0b,
+	0x70, 0x52, 0x52, 0xdb, 0xa0, 0xd0, 0xeb, 0x05, 0x63, 0xe9, 0x79, 0x3b, 0xcb, 0xd8, 0x04, 0x1f,
+	0x00, 0x7f, 0x1a, 0xc1, 0xd3, 0x2f, 0xfd, 0x78, 0x1e, 0x9e, 0xff, 0x3b, 0xa1, 0xcf, 0x80, 0x4d,
+	0xe2, 0xc5, 0xe3, 0xf5, 0x33, 0x57, 0x2f, 0x9b, 0x55, 0x50, 0x88, 0x2a, 0xac, 0x28, 0x57, 0x94,
+	0x57, 0x61, 0x43, 0x6d, 0xc5, 0xa5, 0xe4, 0xa2, 0xbe, 0x94, 0xfd, 0xcf, 0x87, 0xe6, 0xcd, 0x43,
+	0xbd, 0xf0, 0x46, 0x7f, 0x56, 0x7b, 0x06, 0xb8, 0xfa, 0x1c, 0x00, 0x66, 0x68, 0x36, 0x07, 0x12,
+	0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// FileServiceClient is the client API for FileService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type FileServiceClient interface {
+	// GetFileByID returns the file's metadata by its ID, fails with NOT_FOUND if it doesn't exist.
+	GetFileByID(ctx context.Context, in *GetByFileByIDRequest, opts ...grpc.CallOption) (*File, error)
+	// GetDescendantsByID returns the descendants of a folder, at every depth.
+	GetDescendantsByID(ctx context.Context, in *GetDescendantsByIDRequest, opts ...grpc.CallOption) (*GetDescendantsByIDResponse, error)
+}
+
+type fileServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewFileServiceClient(cc *grpc.ClientConn) FileServiceClient {
+	return &fileServiceClient{cc}
+}
+
+func (c *fileServiceClient) GetFileByID(ctx context.Context, in *GetByFileByIDRequest, opts ...grpc.CallOption) (*File, error) {
+	out := new(File)
+	err := c.cc.Invoke(ctx, "/file.FileService/GetFileByID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) GetDescendantsByID(ctx context.Context, in *GetDescendantsByIDRequest, opts ...grpc.CallOption) (*GetDescendantsByIDResponse, error) {
+	out := new(GetDescendantsByIDResponse)
+	err := c.cc.Invoke(ctx, "/file.FileService/GetDescendantsByID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FileServiceServer is the server API for FileService service.
+type FileServiceServer interface {
+	// GetFileByID returns the file's metadata by its ID, fails with NOT_FOUND if it doesn't exist.
+	GetFileByID(context.Context, *GetByFileByIDRequest) (*File, error)
+	// GetDescendantsByID returns the descendants of a folder, at every depth.
+	GetDescendantsByID(context.Context, *GetDescendantsByIDRequest) (*GetDescendantsByIDResponse, error)
+}
+
+// UnimplementedFileServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedFileServiceServer struct {
+}
+
+func (*UnimplementedFileServiceServer) GetFileByID(ctx context.Context, req *GetByFileByIDRequest) (*File, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFileByID not implemented")
+}
+func (*UnimplementedFileServiceServer) GetDescendantsByID(ctx context.Context, req *GetDescendantsByIDRequest) (*GetDescendantsByIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDescendantsByID not implemented")
+}
+
+func RegisterFileServiceServer(s *grpc.Server, srv FileServiceServer) {
+	s.RegisterService(&_FileService_serviceDesc, srv)
+}
+
+func _FileService_GetFileByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByFileByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).GetFileByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/file.FileService/GetFileByID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).GetFileByID(ctx, req.(*GetByFileByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_GetDescendantsByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDescendantsByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).GetDescendantsByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/file.FileService/GetDescendantsByID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).GetDescendantsByID(ctx, req.(*GetDescendantsByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _FileService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "file.FileService",
+	HandlerType: (*FileServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetFileByID",
+			Handler:    _FileService_GetFileByID_Handler,
+		},
+		{
+			MethodName: "GetDescendantsByID",
+			Handler:    _FileService_GetDescendantsByID_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "file.proto",
+}
@@ -7,6 +7,10 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	wrappers "github.com/golang/protobuf/ptypes/wrappers"
+	_ "github.com/meateam/permission-service/proto/validate"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -30,18 +34,30 @@ const (
 	Role_NONE  Role = 0
 	Role_WRITE Role = 1
 	Role_READ  Role = 2
+	// View and comment on the resource.
+	Role_COMMENTER Role = 3
+	// Add content to the resource without viewing it, such as uploading files into a drop-box folder.
+	Role_UPLOADER Role = 4
+	// View the resource's permissions, without access to its content, such as for compliance reviews.
+	Role_AUDITOR Role = 5
 )
 
 var Role_name = map[int32]string{
 	0: "NONE",
 	1: "WRITE",
 	2: "READ",
+	3: "COMMENTER",
+	4: "UPLOADER",
+	5: "AUDITOR",
 }
 
 var Role_value = map[string]int32{
-	"NONE":  0,
-	"WRITE": 1,
-	"READ":  2,
+	"NONE":      0,
+	"WRITE":     1,
+	"READ":      2,
+	"COMMENTER": 3,
+	"UPLOADER":  4,
+	"AUDITOR":   5,
 }
 
 func (x Role) String() string {
@@ -52,6 +68,116 @@ func (Role) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_c837ef01cbda0ad8, []int{0}
 }
 
+type PermissionsOrder int32
+
+const (
+	// The default order of the store.
+	PermissionsOrder_DEFAULT PermissionsOrder = 0
+	// Most recently accessed permissions first.
+	PermissionsOrder_RECENTLY_ACCESSED PermissionsOrder = 1
+)
+
+var PermissionsOrder_name = map[int32]string{
+	0: "DEFAULT",
+	1: "RECENTLY_ACCESSED",
+}
+
+var PermissionsOrder_value = map[string]int32{
+	"DEFAULT":           0,
+	"RECENTLY_ACCESSED": 1,
+}
+
+func (x PermissionsOrder) String() string {
+	return proto.EnumName(PermissionsOrder_name, int32(x))
+}
+
+func (PermissionsOrder) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_c837ef01cbda0ad8, []int{1}
+}
+
+// The capabilities that a permission grants, which depend on its role and resource kind.
+// A READ permission grants VIEW, a COMMENTER permission grants VIEW and COMMENT,
+// and a WRITE permission grants VIEW, COMMENT and EDIT. Permissions to folders that grant VIEW
+// also grant LIST, and WRITE permissions to folders also grant CREATE_CHILD.
+// An UPLOADER permission grants EDIT to files and only CREATE_CHILD to folders.
+// An AUDITOR permission only grants VIEW_PERMISSIONS, which WRITE permissions also grant.
+type Capability int32
+
+const (
+	Capability_NO_CAPABILITY Capability = 0
+	// View the resource's content.
+	Capability_VIEW Capability = 1
+	// Edit the resource's content.
+	Capability_EDIT Capability = 2
+	// List the folder's children.
+	Capability_LIST Capability = 3
+	// Create children in the folder, such as by uploading files into it.
+	Capability_CREATE_CHILD Capability = 4
+	// Comment on the resource.
+	Capability_COMMENT Capability = 5
+	// View the resource's permissions.
+	Capability_VIEW_PERMISSIONS Capability = 6
+)
+
+var Capability_name = map[int32]string{
+	0: "NO_CAPABILITY",
+	1: "VIEW",
+	2: "EDIT",
+	3: "LIST",
+	4: "CREATE_CHILD",
+	5: "COMMENT",
+	6: "VIEW_PERMISSIONS",
+}
+
+var Capability_value = map[string]int32{
+	"NO_CAPABILITY":    0,
+	"VIEW":             1,
+	"EDIT":             2,
+	"LIST":             3,
+	"CREATE_CHILD":     4,
+	"COMMENT":          5,
+	"VIEW_PERMISSIONS": 6,
+}
+
+func (x Capability) String() string {
+	return proto.EnumName(Capability_name, int32(x))
+}
+
+func (Capability) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_c837ef01cbda0ad8, []int{2}
+}
+
+type ChangeType int32
+
+const (
+	ChangeType_UNKNOWN ChangeType = 0
+	ChangeType_CREATED ChangeType = 1
+	ChangeType_UPDATED ChangeType = 2
+	ChangeType_DELETED ChangeType = 3
+)
+
+var ChangeType_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "CREATED",
+	2: "UPDATED",
+	3: "DELETED",
+}
+
+var ChangeType_value = map[string]int32{
+	"UNKNOWN": 0,
+	"CREATED": 1,
+	"UPDATED": 2,
+	"DELETED": 3,
+}
+
+func (x ChangeType) String() string {
+	return proto.EnumName(ChangeType_name, int32(x))
+}
+
+func (ChangeType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_c837ef01cbda0ad8, []int{3}
+}
+
 type CreatePermissionRequest struct {
 	// The ID of the file which is being permitted.
 	FileID string `protobuf:"bytes,1,opt,name=fileID,proto3" json:"fileID,omitempty"`
@@ -61,8 +187,36 @@ type CreatePermissionRequest struct {
 	Role Role `protobuf:"varint,3,opt,name=role,proto3,enum=permission.Role" json:"role,omitempty"`
 	// The ID of the user that created the permission.
 	Creator string `protobuf:"bytes,4,opt,name=creator,proto3" json:"creator,omitempty"`
-	// Signoifies wether or not to override the permission if already exists.
-	Override             bool     `protobuf:"varint,5,opt,name=override,proto3" json:"override,omitempty"`
+	// Signifies wether or not to override the permission if already exists.
+	Override bool `protobuf:"varint,5,opt,name=override,proto3" json:"override,omitempty"`
+	// Signifies wether or not the user may share the file further.
+	// Defaults to true when not set.
+	CanReshare *wrappers.BoolValue `protobuf:"bytes,6,opt,name=canReshare,proto3" json:"canReshare,omitempty"`
+	// An optional message of the creator to the user about the permission.
+	Message string `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
+	// An optional label describing the permission.
+	// Deprecated: use labels, which the permissions can be filtered by.
+	Label string `protobuf:"bytes,8,opt,name=label,proto3" json:"label,omitempty"` // Deprecated: Do not use.
+	// The type of the resource which is being permitted, defaults to "file".
+	ResourceType string `protobuf:"bytes,9,opt,name=resourceType,proto3" json:"resourceType,omitempty"`
+	// The case-insensitive name of the role of the permission, or one of its aliases, such as "viewer".
+	// An alternative to role, if both are set then they must be the same role.
+	// If neither is set then the permission is given the default role.
+	RoleName string `protobuf:"bytes,10,opt,name=roleName,proto3" json:"roleName,omitempty"`
+	// The kind of the resource which is being permitted, "file" or "folder", defaults to "file".
+	ResourceKind string `protobuf:"bytes,11,opt,name=resourceKind,proto3" json:"resourceKind,omitempty"`
+	// The type of the grantee, "user" or "domain", defaults to "user". The userID of a permission
+	// given to everyone in an organization is the organization's domain, which must be allowed
+	// to be given permissions by the service's configuration.
+	GranteeType string `protobuf:"bytes,12,opt,name=granteeType,proto3" json:"granteeType,omitempty"`
+	// Optional key-value labels of the permission, such as project=apollo or source=sync-job,
+	// that the permissions can be filtered by when they're listed and deleted.
+	// Keys are lowercase letters, digits, '-' and '_', starting with a letter, of up to 63 characters,
+	// values are of up to 63 characters, and there may be up to 16 labels.
+	Labels map[string]string `protobuf:"bytes,13,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The system that creates the permission, such as "ui", "sync-job" or "template", defaults to "api".
+	// It's lowercase letters, digits, '-' and '_', starting with a letter, of up to 63 characters.
+	Source               string   `protobuf:"bytes,14,opt,name=source,proto3" json:"source,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -128,11 +282,79 @@ func (m *CreatePermissionRequest) GetOverride() bool {
 	return false
 }
 
+func (m *CreatePermissionRequest) GetCanReshare() *wrappers.BoolValue {
+	if m != nil {
+		return m.CanReshare
+	}
+	return nil
+}
+
+func (m *CreatePermissionRequest) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+// Deprecated: Do not use.
+func (m *CreatePermissionRequest) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *CreatePermissionRequest) GetResourceType() string {
+	if m != nil {
+		return m.ResourceType
+	}
+	return ""
+}
+
+func (m *CreatePermissionRequest) GetRoleName() string {
+	if m != nil {
+		return m.RoleName
+	}
+	return ""
+}
+
+func (m *CreatePermissionRequest) GetResourceKind() string {
+	if m != nil {
+		return m.ResourceKind
+	}
+	return ""
+}
+
+func (m *CreatePermissionRequest) GetGranteeType() string {
+	if m != nil {
+		return m.GranteeType
+	}
+	return ""
+}
+
+func (m *CreatePermissionRequest) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *CreatePermissionRequest) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
 type DeletePermissionRequest struct {
 	// The ID of the file which is being permitted.
 	FileID string `protobuf:"bytes,1,opt,name=fileID,proto3" json:"fileID,omitempty"`
 	// The ID of the user that's given the permission.
-	UserID               string   `protobuf:"bytes,2,opt,name=userID,proto3" json:"userID,omitempty"`
+	UserID string `protobuf:"bytes,2,opt,name=userID,proto3" json:"userID,omitempty"`
+	// If set, the permission is deleted only if its current etag matches it.
+	Etag string `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	// The type of the resource which is being permitted, defaults to "file".
+	ResourceType         string   `protobuf:"bytes,4,opt,name=resourceType,proto3" json:"resourceType,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -177,6 +399,20 @@ func (m *DeletePermissionRequest) GetUserID() string {
 	return ""
 }
 
+func (m *DeletePermissionRequest) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+func (m *DeletePermissionRequest) GetResourceType() string {
+	if m != nil {
+		return m.ResourceType
+	}
+	return ""
+}
+
 type PermissionObject struct {
 	// The ID of the permission.
 	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -187,7 +423,34 @@ type PermissionObject struct {
 	// The role of the permission.
 	Role Role `protobuf:"varint,4,opt,name=role,proto3,enum=permission.Role" json:"role,omitempty"`
 	// The ID of the user that created the permission.
-	Creator              string   `protobuf:"bytes,5,opt,name=creator,proto3" json:"creator,omitempty"`
+	Creator string `protobuf:"bytes,5,opt,name=creator,proto3" json:"creator,omitempty"`
+	// Signifies wether or not the user may share the file further.
+	CanReshare bool `protobuf:"varint,6,opt,name=canReshare,proto3" json:"canReshare,omitempty"`
+	// The message of the creator to the user about the permission.
+	Message string `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
+	// The label describing the permission.
+	Label string `protobuf:"bytes,8,opt,name=label,proto3" json:"label,omitempty"`
+	// The last time the user accessed the file with the permission.
+	LastAccessedAt *timestamp.Timestamp `protobuf:"bytes,9,opt,name=lastAccessedAt,proto3" json:"lastAccessedAt,omitempty"`
+	// The etag of the permission, changes whenever the permission is modified.
+	Etag string `protobuf:"bytes,10,opt,name=etag,proto3" json:"etag,omitempty"`
+	// The type of the resource which is being permitted.
+	ResourceType string `protobuf:"bytes,11,opt,name=resourceType,proto3" json:"resourceType,omitempty"`
+	// The users through which the file was shared to the user, starting from the user that shared it first
+	// and ending with the creator of the permission.
+	SharingChain []string `protobuf:"bytes,12,rep,name=sharingChain,proto3" json:"sharingChain,omitempty"`
+	// The kind of the resource which is being permitted, "file" or "folder".
+	ResourceKind string `protobuf:"bytes,13,opt,name=resourceKind,proto3" json:"resourceKind,omitempty"`
+	// The capabilities that the permission grants.
+	Capabilities []Capability `protobuf:"varint,14,rep,packed,name=capabilities,proto3,enum=permission.Capability" json:"capabilities,omitempty"`
+	// The type of the grantee, "user" or "domain".
+	GranteeType string `protobuf:"bytes,15,opt,name=granteeType,proto3" json:"granteeType,omitempty"`
+	// The key-value labels of the permission.
+	Labels map[string]string `protobuf:"bytes,16,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The system that created the permission, empty if it was created before sources were recorded.
+	Source string `protobuf:"bytes,17,opt,name=source,proto3" json:"source,omitempty"`
+	// The ID of the folder that the permission is inherited from, empty if it was given to the file directly.
+	InheritedFrom        string   `protobuf:"bytes,18,opt,name=inheritedFrom,proto3" json:"inheritedFrom,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -253,9 +516,102 @@ func (m *PermissionObject) GetCreator() string {
 	return ""
 }
 
+func (m *PermissionObject) GetCanReshare() bool {
+	if m != nil {
+		return m.CanReshare
+	}
+	return false
+}
+
+func (m *PermissionObject) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *PermissionObject) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *PermissionObject) GetLastAccessedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastAccessedAt
+	}
+	return nil
+}
+
+func (m *PermissionObject) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+func (m *PermissionObject) GetResourceType() string {
+	if m != nil {
+		return m.ResourceType
+	}
+	return ""
+}
+
+func (m *PermissionObject) GetSharingChain() []string {
+	if m != nil {
+		return m.SharingChain
+	}
+	return nil
+}
+
+func (m *PermissionObject) GetResourceKind() string {
+	if m != nil {
+		return m.ResourceKind
+	}
+	return ""
+}
+
+func (m *PermissionObject) GetCapabilities() []Capability {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+func (m *PermissionObject) GetGranteeType() string {
+	if m != nil {
+		return m.GranteeType
+	}
+	return ""
+}
+
+func (m *PermissionObject) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *PermissionObject) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *PermissionObject) GetInheritedFrom() string {
+	if m != nil {
+		return m.InheritedFrom
+	}
+	return ""
+}
+
 type GetPermissionRequest struct {
-	FileID               string   `protobuf:"bytes,1,opt,name=fileID,proto3" json:"fileID,omitempty"`
-	UserID               string   `protobuf:"bytes,2,opt,name=userID,proto3" json:"userID,omitempty"`
+	FileID string `protobuf:"bytes,1,opt,name=fileID,proto3" json:"fileID,omitempty"`
+	UserID string `protobuf:"bytes,2,opt,name=userID,proto3" json:"userID,omitempty"`
+	// The type of the resource which is being permitted, defaults to "file".
+	ResourceType         string   `protobuf:"bytes,3,opt,name=resourceType,proto3" json:"resourceType,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -300,9 +656,30 @@ func (m *GetPermissionRequest) GetUserID() string {
 	return ""
 }
 
+func (m *GetPermissionRequest) GetResourceType() string {
+	if m != nil {
+		return m.ResourceType
+	}
+	return ""
+}
+
 type GetFilePermissionsRequest struct {
 	// The ID of the file which is being permitted.
-	FileID               string   `protobuf:"bytes,1,opt,name=fileID,proto3" json:"fileID,omitempty"`
+	FileID string `protobuf:"bytes,1,opt,name=fileID,proto3" json:"fileID,omitempty"`
+	// The order of the returned permissions.
+	Order PermissionsOrder `protobuf:"varint,2,opt,name=order,proto3,enum=permission.PermissionsOrder" json:"order,omitempty"`
+	// The type of the resource which is being permitted, defaults to "file".
+	ResourceType string `protobuf:"bytes,3,opt,name=resourceType,proto3" json:"resourceType,omitempty"`
+	// The maximum number of permissions to return, limited to 1000. If both pageSize
+	// and pageToken are empty then all of the permissions are returned in a single page.
+	PageSize int32 `protobuf:"varint,4,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	// The page token returned by the previous request, which continues the listing
+	// after its last permission in the same order.
+	PageToken string `protobuf:"bytes,5,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	// If set, only the permissions that have all of these labels are returned.
+	LabelSelector map[string]string `protobuf:"bytes,6,rep,name=labelSelector,proto3" json:"labelSelector,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// If set, only the permissions that were created by this system are returned.
+	Source               string   `protobuf:"bytes,7,opt,name=source,proto3" json:"source,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -340,12 +717,56 @@ func (m *GetFilePermissionsRequest) GetFileID() string {
 	return ""
 }
 
+func (m *GetFilePermissionsRequest) GetOrder() PermissionsOrder {
+	if m != nil {
+		return m.Order
+	}
+	return PermissionsOrder_DEFAULT
+}
+
+func (m *GetFilePermissionsRequest) GetResourceType() string {
+	if m != nil {
+		return m.ResourceType
+	}
+	return ""
+}
+
+func (m *GetFilePermissionsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *GetFilePermissionsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *GetFilePermissionsRequest) GetLabelSelector() map[string]string {
+	if m != nil {
+		return m.LabelSelector
+	}
+	return nil
+}
+
+func (m *GetFilePermissionsRequest) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
 type GetFilePermissionsResponse struct {
 	// Array of user roles.
-	Permissions          []*GetFilePermissionsResponse_UserRole `protobuf:"bytes,1,rep,name=permissions,proto3" json:"permissions,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                               `json:"-"`
-	XXX_unrecognized     []byte                                 `json:"-"`
-	XXX_sizecache        int32                                  `json:"-"`
+	Permissions []*GetFilePermissionsResponse_UserRole `protobuf:"bytes,1,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	// The token of the next page, empty if there are no more pages.
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *GetFilePermissionsResponse) Reset()         { *m = GetFilePermissionsResponse{} }
@@ -380,6 +801,13 @@ func (m *GetFilePermissionsResponse) GetPermissions() []*GetFilePermissionsRespo
 	return nil
 }
 
+func (m *GetFilePermissionsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
 // The role of a user.
 type GetFilePermissionsResponse_UserRole struct {
 	// The user ID.
@@ -387,7 +815,19 @@ type GetFilePermissionsResponse_UserRole struct {
 	// The role of the user.
 	Role Role `protobuf:"varint,2,opt,name=role,proto3,enum=permission.Role" json:"role,omitempty"`
 	// The creator of the permission.
-	Creator              string   `protobuf:"bytes,3,opt,name=creator,proto3" json:"creator,omitempty"`
+	Creator string `protobuf:"bytes,3,opt,name=creator,proto3" json:"creator,omitempty"`
+	// Signifies wether or not the user may share the file further.
+	CanReshare bool `protobuf:"varint,4,opt,name=canReshare,proto3" json:"canReshare,omitempty"`
+	// The message of the creator to the user about the permission.
+	Message string `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	// The label describing the permission.
+	Label string `protobuf:"bytes,6,opt,name=label,proto3" json:"label,omitempty"`
+	// The last time the user accessed the file with the permission.
+	LastAccessedAt *timestamp.Timestamp `protobuf:"bytes,7,opt,name=lastAccessedAt,proto3" json:"lastAccessedAt,omitempty"`
+	// The key-value labels of the permission.
+	Labels map[string]string `protobuf:"bytes,8,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The system that created the permission.
+	Source               string   `protobuf:"bytes,9,opt,name=source,proto3" json:"source,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -439,16 +879,65 @@ func (m *GetFilePermissionsResponse_UserRole) GetCreator() string {
 	return ""
 }
 
+func (m *GetFilePermissionsResponse_UserRole) GetCanReshare() bool {
+	if m != nil {
+		return m.CanReshare
+	}
+	return false
+}
+
+func (m *GetFilePermissionsResponse_UserRole) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *GetFilePermissionsResponse_UserRole) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *GetFilePermissionsResponse_UserRole) GetLastAccessedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastAccessedAt
+	}
+	return nil
+}
+
+func (m *GetFilePermissionsResponse_UserRole) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *GetFilePermissionsResponse_UserRole) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
 type IsPermittedRequest struct {
 	// The ID of the file which is being permitted.
 	FileID string `protobuf:"bytes,1,opt,name=fileID,proto3" json:"fileID,omitempty"`
 	// The ID of the user that's given the permission.
 	UserID string `protobuf:"bytes,2,opt,name=userID,proto3" json:"userID,omitempty"`
 	// The role of the permission.
-	Role                 Role     `protobuf:"varint,3,opt,name=role,proto3,enum=permission.Role" json:"role,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Role Role `protobuf:"varint,3,opt,name=role,proto3,enum=permission.Role" json:"role,omitempty"`
+	// The type of the resource which is being permitted, defaults to "file".
+	ResourceType string `protobuf:"bytes,4,opt,name=resourceType,proto3" json:"resourceType,omitempty"`
+	// The case-insensitive name of the role of the permission, or one of its aliases, such as "viewer".
+	// An alternative to role, if both are set then they must be the same role.
+	RoleName string `protobuf:"bytes,5,opt,name=roleName,proto3" json:"roleName,omitempty"`
+	// If set, the user is permitted if its permission grants the capability, and role is ignored.
+	Capability           Capability `protobuf:"varint,6,opt,name=capability,proto3,enum=permission.Capability" json:"capability,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
 func (m *IsPermittedRequest) Reset()         { *m = IsPermittedRequest{} }
@@ -497,8 +986,34 @@ func (m *IsPermittedRequest) GetRole() Role {
 	return Role_NONE
 }
 
+func (m *IsPermittedRequest) GetResourceType() string {
+	if m != nil {
+		return m.ResourceType
+	}
+	return ""
+}
+
+func (m *IsPermittedRequest) GetRoleName() string {
+	if m != nil {
+		return m.RoleName
+	}
+	return ""
+}
+
+func (m *IsPermittedRequest) GetCapability() Capability {
+	if m != nil {
+		return m.Capability
+	}
+	return Capability_NO_CAPABILITY
+}
+
 type IsPermittedResponse struct {
-	Permitted            bool     `protobuf:"varint,1,opt,name=permitted,proto3" json:"permitted,omitempty"`
+	Permitted bool `protobuf:"varint,1,opt,name=permitted,proto3" json:"permitted,omitempty"`
+	// maxAge is how long the response may be cached by the caller.
+	MaxAge *duration.Duration `protobuf:"bytes,2,opt,name=maxAge,proto3" json:"maxAge,omitempty"`
+	// Whether the check was served from the last-known permissions because the store was unavailable,
+	// it's then not cached.
+	Stale                bool     `protobuf:"varint,3,opt,name=stale,proto3" json:"stale,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -536,11 +1051,196 @@ func (m *IsPermittedResponse) GetPermitted() bool {
 	return false
 }
 
-type GetUserPermissionsRequest struct {
-	// The ID of the user to get its permissions.
-	UserID               string   `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
+func (m *IsPermittedResponse) GetMaxAge() *duration.Duration {
+	if m != nil {
+		return m.MaxAge
+	}
+	return nil
+}
+
+func (m *IsPermittedResponse) GetStale() bool {
+	if m != nil {
+		return m.Stale
+	}
+	return false
+}
+
+type CheckPermissionsMatrixRequest struct {
+	// The checks, each is checked as IsPermitted checks its request.
+	Checks               []*IsPermittedRequest `protobuf:"bytes,1,rep,name=checks,proto3" json:"checks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *CheckPermissionsMatrixRequest) Reset()         { *m = CheckPermissionsMatrixRequest{} }
+func (m *CheckPermissionsMatrixRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckPermissionsMatrixRequest) ProtoMessage()    {}
+func (*CheckPermissionsMatrixRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c837ef01cbda0ad8, []int{8}
+}
+
+func (m *CheckPermissionsMatrixRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckPermissionsMatrixRequest.Unmarshal(m, b)
+}
+func (m *CheckPermissionsMatrixRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckPermissionsMatrixRequest.Marshal(b, m, deterministic)
+}
+func (m *CheckPermissionsMatrixRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckPermissionsMatrixRequest.Merge(m, src)
+}
+func (m *CheckPermissionsMatrixRequest) XXX_Size() int {
+	return xxx_messageInfo_CheckPermissionsMatrixRequest.Size(m)
+}
+func (m *CheckPermissionsMatrixRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckPermissionsMatrixRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckPermissionsMatrixRequest proto.InternalMessageInfo
+
+func (m *CheckPermissionsMatrixRequest) GetChecks() []*IsPermittedRequest {
+	if m != nil {
+		return m.Checks
+	}
+	return nil
+}
+
+type CheckPermissionsMatrixResponse struct {
+	// The results of the checks, in the order of the checks.
+	Results              []*CheckPermissionsMatrixResponse_Result `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                 `json:"-"`
+	XXX_unrecognized     []byte                                   `json:"-"`
+	XXX_sizecache        int32                                    `json:"-"`
+}
+
+func (m *CheckPermissionsMatrixResponse) Reset()         { *m = CheckPermissionsMatrixResponse{} }
+func (m *CheckPermissionsMatrixResponse) String() string { return proto.CompactTextString(m) }
+func (*CheckPermissionsMatrixResponse) ProtoMessage()    {}
+func (*CheckPermissionsMatrixResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c837ef01cbda0ad8, []int{9}
+}
+
+func (m *CheckPermissionsMatrixResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckPermissionsMatrixResponse.Unmarshal(m, b)
+}
+func (m *CheckPermissionsMatrixResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckPermissionsMatrixResponse.Marshal(b, m, deterministic)
+}
+func (m *CheckPermissionsMatrixResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckPermissionsMatrixResponse.Merge(m, src)
+}
+func (m *CheckPermissionsMatrixResponse) XXX_Size() int {
+	return xxx_messageInfo_CheckPermissionsMatrixResponse.Size(m)
+}
+func (m *CheckPermissionsMatrixResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckPermissionsMatrixResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckPermissionsMatrixResponse proto.InternalMessageInfo
+
+func (m *CheckPermissionsMatrixResponse) GetResults() []*CheckPermissionsMatrixResponse_Result {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+// The result of a check.
+type CheckPermissionsMatrixResponse_Result struct {
+	// The gRPC status code of the check, such as OK if it was checked,
+	// or NOT_FOUND if the user has no permission to the file.
+	Code    int32  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// Whether the user is permitted, if the check succeeded.
+	Permitted bool `protobuf:"varint,3,opt,name=permitted,proto3" json:"permitted,omitempty"`
+	// maxAge is how long the result may be cached by the caller.
+	MaxAge *duration.Duration `protobuf:"bytes,4,opt,name=maxAge,proto3" json:"maxAge,omitempty"`
+	// Whether the check was served from the last-known permissions because the store was unavailable.
+	Stale                bool     `protobuf:"varint,5,opt,name=stale,proto3" json:"stale,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckPermissionsMatrixResponse_Result) Reset()         { *m = CheckPermissionsMatrixResponse_Result{} }
+func (m *CheckPermissionsMatrixResponse_Result) String() string { return proto.CompactTextString(m) }
+func (*CheckPermissionsMatrixResponse_Result) ProtoMessage()    {}
+func (*CheckPermissionsMatrixResponse_Result) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c837ef01cbda0ad8, []int{9, 0}
+}
+
+func (m *CheckPermissionsMatrixResponse_Result) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckPermissionsMatrixResponse_Result.Unmarshal(m, b)
+}
+func (m *CheckPermissionsMatrixResponse_Result) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckPermissionsMatrixResponse_Result.Marshal(b, m, deterministic)
+}
+func (m *CheckPermissionsMatrixResponse_Result) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckPermissionsMatrixResponse_Result.Merge(m, src)
+}
+func (m *CheckPermissionsMatrixResponse_Result) XXX_Size() int {
+	return xxx_messageInfo_CheckPermissionsMatrixResponse_Result.Size(m)
+}
+func (m *CheckPermissionsMatrixResponse_Result) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckPermissionsMatrixResponse_Result.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckPermissionsMatrixResponse_Result proto.InternalMessageInfo
+
+func (m *CheckPermissionsMatrixResponse_Result) GetCode() int32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *CheckPermissionsMatrixResponse_Result) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *CheckPermissionsMatrixResponse_Result) GetPermitted() bool {
+	if m != nil {
+		return m.Permitted
+	}
+	return false
+}
+
+func (m *CheckPermissionsMatrixResponse_Result) GetMaxAge() *duration.Duration {
+	if m != nil {
+		return m.MaxAge
+	}
+	return nil
+}
+
+func (m *CheckPermissionsMatrixResponse_Result) GetStale() bool {
+	if m != nil {
+		return m.Stale
+	}
+	return false
+}
+
+type GetUserPermissionsRequest struct {
+	// The ID of the user to get its permissions.
+	UserID string `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	// The order of the returned permissions.
+	Order PermissionsOrder `protobuf:"varint,2,opt,name=order,proto3,enum=permission.PermissionsOrder" json:"order,omitempty"`
+	// The type of the resources to get the permissions to, defaults to "file".
+	ResourceType string `protobuf:"bytes,3,opt,name=resourceType,proto3" json:"resourceType,omitempty"`
+	// The maximum number of permissions to return, limited to 1000. If both pageSize
+	// and pageToken are empty then all of the permissions are returned in a single page.
+	PageSize int32 `protobuf:"varint,4,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	// The page token returned by the previous request, which continues the listing
+	// after its last permission in the same order.
+	PageToken string `protobuf:"bytes,5,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	// If set, only the permissions that have all of these labels are returned.
+	LabelSelector map[string]string `protobuf:"bytes,6,rep,name=labelSelector,proto3" json:"labelSelector,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// If set, only the permissions that were created by this system are returned.
+	Source               string   `protobuf:"bytes,7,opt,name=source,proto3" json:"source,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
@@ -548,7 +1248,7 @@ func (m *GetUserPermissionsRequest) Reset()         { *m = GetUserPermissionsReq
 func (m *GetUserPermissionsRequest) String() string { return proto.CompactTextString(m) }
 func (*GetUserPermissionsRequest) ProtoMessage()    {}
 func (*GetUserPermissionsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c837ef01cbda0ad8, []int{8}
+	return fileDescriptor_c837ef01cbda0ad8, []int{10}
 }
 
 func (m *GetUserPermissionsRequest) XXX_Unmarshal(b []byte) error {
@@ -576,19 +1276,63 @@ func (m *GetUserPermissionsRequest) GetUserID() string {
 	return ""
 }
 
+func (m *GetUserPermissionsRequest) GetOrder() PermissionsOrder {
+	if m != nil {
+		return m.Order
+	}
+	return PermissionsOrder_DEFAULT
+}
+
+func (m *GetUserPermissionsRequest) GetResourceType() string {
+	if m != nil {
+		return m.ResourceType
+	}
+	return ""
+}
+
+func (m *GetUserPermissionsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *GetUserPermissionsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *GetUserPermissionsRequest) GetLabelSelector() map[string]string {
+	if m != nil {
+		return m.LabelSelector
+	}
+	return nil
+}
+
+func (m *GetUserPermissionsRequest) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
 type GetUserPermissionsResponse struct {
 	// Array of files and their role.
-	Permissions          []*GetUserPermissionsResponse_FileRole `protobuf:"bytes,1,rep,name=permissions,proto3" json:"permissions,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                               `json:"-"`
-	XXX_unrecognized     []byte                                 `json:"-"`
-	XXX_sizecache        int32                                  `json:"-"`
+	Permissions []*GetUserPermissionsResponse_FileRole `protobuf:"bytes,1,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	// The token of the next page, empty if there are no more pages.
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *GetUserPermissionsResponse) Reset()         { *m = GetUserPermissionsResponse{} }
 func (m *GetUserPermissionsResponse) String() string { return proto.CompactTextString(m) }
 func (*GetUserPermissionsResponse) ProtoMessage()    {}
 func (*GetUserPermissionsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c837ef01cbda0ad8, []int{9}
+	return fileDescriptor_c837ef01cbda0ad8, []int{11}
 }
 
 func (m *GetUserPermissionsResponse) XXX_Unmarshal(b []byte) error {
@@ -616,6 +1360,13 @@ func (m *GetUserPermissionsResponse) GetPermissions() []*GetUserPermissionsRespo
 	return nil
 }
 
+func (m *GetUserPermissionsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
 // The file of the permission and its role.
 type GetUserPermissionsResponse_FileRole struct {
 	// The file ID.
@@ -623,7 +1374,21 @@ type GetUserPermissionsResponse_FileRole struct {
 	// The role of the file permission.
 	Role Role `protobuf:"varint,2,opt,name=role,proto3,enum=permission.Role" json:"role,omitempty"`
 	// The creator of the permission.
-	Creator              string   `protobuf:"bytes,3,opt,name=creator,proto3" json:"creator,omitempty"`
+	Creator string `protobuf:"bytes,3,opt,name=creator,proto3" json:"creator,omitempty"`
+	// Signifies wether or not the user may share the file further.
+	CanReshare bool `protobuf:"varint,4,opt,name=canReshare,proto3" json:"canReshare,omitempty"`
+	// The message of the creator to the user about the permission.
+	Message string `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	// The label describing the permission.
+	Label string `protobuf:"bytes,6,opt,name=label,proto3" json:"label,omitempty"`
+	// The last time the user accessed the file with the permission.
+	LastAccessedAt *timestamp.Timestamp `protobuf:"bytes,7,opt,name=lastAccessedAt,proto3" json:"lastAccessedAt,omitempty"`
+	// The type of the resource.
+	ResourceType string `protobuf:"bytes,8,opt,name=resourceType,proto3" json:"resourceType,omitempty"`
+	// The key-value labels of the permission.
+	Labels map[string]string `protobuf:"bytes,9,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The system that created the permission.
+	Source               string   `protobuf:"bytes,10,opt,name=source,proto3" json:"source,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -633,7 +1398,7 @@ func (m *GetUserPermissionsResponse_FileRole) Reset()         { *m = GetUserPerm
 func (m *GetUserPermissionsResponse_FileRole) String() string { return proto.CompactTextString(m) }
 func (*GetUserPermissionsResponse_FileRole) ProtoMessage()    {}
 func (*GetUserPermissionsResponse_FileRole) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c837ef01cbda0ad8, []int{9, 0}
+	return fileDescriptor_c837ef01cbda0ad8, []int{11, 0}
 }
 
 func (m *GetUserPermissionsResponse_FileRole) XXX_Unmarshal(b []byte) error {
@@ -675,8 +1440,65 @@ func (m *GetUserPermissionsResponse_FileRole) GetCreator() string {
 	return ""
 }
 
+func (m *GetUserPermissionsResponse_FileRole) GetCanReshare() bool {
+	if m != nil {
+		return m.CanReshare
+	}
+	return false
+}
+
+func (m *GetUserPermissionsResponse_FileRole) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *GetUserPermissionsResponse_FileRole) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *GetUserPermissionsResponse_FileRole) GetLastAccessedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastAccessedAt
+	}
+	return nil
+}
+
+func (m *GetUserPermissionsResponse_FileRole) GetResourceType() string {
+	if m != nil {
+		return m.ResourceType
+	}
+	return ""
+}
+
+func (m *GetUserPermissionsResponse_FileRole) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *GetUserPermissionsResponse_FileRole) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
 type DeleteFilePermissionsRequest struct {
-	FileID               string   `protobuf:"bytes,1,opt,name=fileID,proto3" json:"fileID,omitempty"`
+	FileID string `protobuf:"bytes,1,opt,name=fileID,proto3" json:"fileID,omitempty"`
+	// The type of the resource which is being permitted, defaults to "file".
+	ResourceType string `protobuf:"bytes,2,opt,name=resourceType,proto3" json:"resourceType,omitempty"`
+	// If set, only the permissions that have all of these labels are deleted,
+	// so that automation deletes only the permissions that it created.
+	LabelSelector map[string]string `protobuf:"bytes,3,rep,name=labelSelector,proto3" json:"labelSelector,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// If set, only the permissions that were created by this system are deleted,
+	// so that cleanup jobs don't delete the permissions that users created.
+	Source               string   `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -686,7 +1508,7 @@ func (m *DeleteFilePermissionsRequest) Reset()         { *m = DeleteFilePermissi
 func (m *DeleteFilePermissionsRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteFilePermissionsRequest) ProtoMessage()    {}
 func (*DeleteFilePermissionsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c837ef01cbda0ad8, []int{10}
+	return fileDescriptor_c837ef01cbda0ad8, []int{12}
 }
 
 func (m *DeleteFilePermissionsRequest) XXX_Unmarshal(b []byte) error {
@@ -698,117 +1520,1393 @@ func (m *DeleteFilePermissionsRequest) XXX_Marshal(b []byte, deterministic bool)
 func (m *DeleteFilePermissionsRequest) XXX_Merge(src proto.Message) {
 	xxx_messageInfo_DeleteFilePermissionsRequest.Merge(m, src)
 }
-func (m *DeleteFilePermissionsRequest) XXX_Size() int {
-	return xxx_messageInfo_DeleteFilePermissionsRequest.Size(m)
+func (m *DeleteFilePermissionsRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteFilePermissionsRequest.Size(m)
+}
+func (m *DeleteFilePermissionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteFilePermissionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteFilePermissionsRequest proto.InternalMessageInfo
+
+func (m *DeleteFilePermissionsRequest) GetFileID() string {
+	if m != nil {
+		return m.FileID
+	}
+	return ""
+}
+
+func (m *DeleteFilePermissionsRequest) GetResourceType() string {
+	if m != nil {
+		return m.ResourceType
+	}
+	return ""
+}
+
+func (m *DeleteFilePermissionsRequest) GetLabelSelector() map[string]string {
+	if m != nil {
+		return m.LabelSelector
+	}
+	return nil
+}
+
+func (m *DeleteFilePermissionsRequest) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+type DeleteFilePermissionsResponse struct {
+	Permissions          []*PermissionObject `protobuf:"bytes,1,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *DeleteFilePermissionsResponse) Reset()         { *m = DeleteFilePermissionsResponse{} }
+func (m *DeleteFilePermissionsResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteFilePermissionsResponse) ProtoMessage()    {}
+func (*DeleteFilePermissionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c837ef01cbda0ad8, []int{13}
+}
+
+func (m *DeleteFilePermissionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteFilePermissionsResponse.Unmarshal(m, b)
+}
+func (m *DeleteFilePermissionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteFilePermissionsResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteFilePermissionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteFilePermissionsResponse.Merge(m, src)
+}
+func (m *DeleteFilePermissionsResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteFilePermissionsResponse.Size(m)
+}
+func (m *DeleteFilePermissionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteFilePermissionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteFilePermissionsResponse proto.InternalMessageInfo
+
+func (m *DeleteFilePermissionsResponse) GetPermissions() []*PermissionObject {
+	if m != nil {
+		return m.Permissions
+	}
+	return nil
+}
+
+type CopyPermissionsRequest struct {
+	// The ID of the file whose permissions are copied.
+	SourceFileID string `protobuf:"bytes,1,opt,name=sourceFileID,proto3" json:"sourceFileID,omitempty"`
+	// The ID of the file the permissions are copied to.
+	DestFileID string `protobuf:"bytes,2,opt,name=destFileID,proto3" json:"destFileID,omitempty"`
+	// Whether the permissions of users that already have a permission to the destination file
+	// override them, otherwise the existing permissions are kept.
+	Overwrite bool `protobuf:"varint,3,opt,name=overwrite,proto3" json:"overwrite,omitempty"`
+	// The type of the resources, defaults to "file".
+	ResourceType         string   `protobuf:"bytes,4,opt,name=resourceType,proto3" json:"resourceType,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CopyPermissionsRequest) Reset()         { *m = CopyPermissionsRequest{} }
+func (m *CopyPermissionsRequest) String() string { return proto.CompactTextString(m) }
+func (*CopyPermissionsRequest) ProtoMessage()    {}
+func (*CopyPermissionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c837ef01cbda0ad8, []int{14}
+}
+
+func (m *CopyPermissionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CopyPermissionsRequest.Unmarshal(m, b)
+}
+func (m *CopyPermissionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CopyPermissionsRequest.Marshal(b, m, deterministic)
+}
+func (m *CopyPermissionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CopyPermissionsRequest.Merge(m, src)
+}
+func (m *CopyPermissionsRequest) XXX_Size() int {
+	return xxx_messageInfo_CopyPermissionsRequest.Size(m)
+}
+func (m *CopyPermissionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CopyPermissionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CopyPermissionsRequest proto.InternalMessageInfo
+
+func (m *CopyPermissionsRequest) GetSourceFileID() string {
+	if m != nil {
+		return m.SourceFileID
+	}
+	return ""
+}
+
+func (m *CopyPermissionsRequest) GetDestFileID() string {
+	if m != nil {
+		return m.DestFileID
+	}
+	return ""
+}
+
+func (m *CopyPermissionsRequest) GetOverwrite() bool {
+	if m != nil {
+		return m.Overwrite
+	}
+	return false
+}
+
+func (m *CopyPermissionsRequest) GetResourceType() string {
+	if m != nil {
+		return m.ResourceType
+	}
+	return ""
+}
+
+type CopyPermissionsResponse struct {
+	// The number of permissions that were copied.
+	Copied int64 `protobuf:"varint,1,opt,name=copied,proto3" json:"copied,omitempty"`
+	// The number of permissions that weren't copied since their users already have a permission
+	// to the destination file and overwrite wasn't set.
+	Skipped              int64    `protobuf:"varint,2,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CopyPermissionsResponse) Reset()         { *m = CopyPermissionsResponse{} }
+func (m *CopyPermissionsResponse) String() string { return proto.CompactTextString(m) }
+func (*CopyPermissionsResponse) ProtoMessage()    {}
+func (*CopyPermissionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c837ef01cbda0ad8, []int{15}
+}
+
+func (m *CopyPermissionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CopyPermissionsResponse.Unmarshal(m, b)
+}
+func (m *CopyPermissionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CopyPermissionsResponse.Marshal(b, m, deterministic)
+}
+func (m *CopyPermissionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CopyPermissionsResponse.Merge(m, src)
+}
+func (m *CopyPermissionsResponse) XXX_Size() int {
+	return xxx_messageInfo_CopyPermissionsResponse.Size(m)
+}
+func (m *CopyPermissionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CopyPermissionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CopyPermissionsResponse proto.InternalMessageInfo
+
+func (m *CopyPermissionsResponse) GetCopied() int64 {
+	if m != nil {
+		return m.Copied
+	}
+	return 0
+}
+
+func (m *CopyPermissionsResponse) GetSkipped() int64 {
+	if m != nil {
+		return m.Skipped
+	}
+	return 0
+}
+
+type TouchPermissionRequest struct {
+	// The ID of the file which is being accessed.
+	FileID string `protobuf:"bytes,1,opt,name=fileID,proto3" json:"fileID,omitempty"`
+	// The ID of the user that accessed the file.
+	UserID string `protobuf:"bytes,2,opt,name=userID,proto3" json:"userID,omitempty"`
+	// The type of the resource which is being permitted, defaults to "file".
+	ResourceType         string   `protobuf:"bytes,3,opt,name=resourceType,proto3" json:"resourceType,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TouchPermissionRequest) Reset()         { *m = TouchPermissionRequest{} }
+func (m *TouchPermissionRequest) String() string { return proto.CompactTextString(m) }
+func (*TouchPermissionRequest) ProtoMessage()    {}
+func (*TouchPermissionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c837ef01cbda0ad8, []int{16}
+}
+
+func (m *TouchPermissionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TouchPermissionRequest.Unmarshal(m, b)
+}
+func (m *TouchPermissionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TouchPermissionRequest.Marshal(b, m, deterministic)
+}
+func (m *TouchPermissionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TouchPermissionRequest.Merge(m, src)
+}
+func (m *TouchPermissionRequest) XXX_Size() int {
+	return xxx_messageInfo_TouchPermissionRequest.Size(m)
+}
+func (m *TouchPermissionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TouchPermissionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TouchPermissionRequest proto.InternalMessageInfo
+
+func (m *TouchPermissionRequest) GetFileID() string {
+	if m != nil {
+		return m.FileID
+	}
+	return ""
+}
+
+func (m *TouchPermissionRequest) GetUserID() string {
+	if m != nil {
+		return m.UserID
+	}
+	return ""
+}
+
+func (m *TouchPermissionRequest) GetResourceType() string {
+	if m != nil {
+		return m.ResourceType
+	}
+	return ""
+}
+
+type RevokeCascadeRequest struct {
+	// The ID of the file which is being revoked.
+	FileID string `protobuf:"bytes,1,opt,name=fileID,proto3" json:"fileID,omitempty"`
+	// The ID of the user whose permission, and the permissions it reshared, are revoked.
+	UserID string `protobuf:"bytes,2,opt,name=userID,proto3" json:"userID,omitempty"`
+	// The type of the resource which is being revoked, defaults to "file".
+	ResourceType         string   `protobuf:"bytes,3,opt,name=resourceType,proto3" json:"resourceType,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevokeCascadeRequest) Reset()         { *m = RevokeCascadeRequest{} }
+func (m *RevokeCascadeRequest) String() string { return proto.CompactTextString(m) }
+func (*RevokeCascadeRequest) ProtoMessage()    {}
+func (*RevokeCascadeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c837ef01cbda0ad8, []int{17}
+}
+
+func (m *RevokeCascadeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeCascadeRequest.Unmarshal(m, b)
+}
+func (m *RevokeCascadeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeCascadeRequest.Marshal(b, m, deterministic)
+}
+func (m *RevokeCascadeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeCascadeRequest.Merge(m, src)
+}
+func (m *RevokeCascadeRequest) XXX_Size() int {
+	return xxx_messageInfo_RevokeCascadeRequest.Size(m)
+}
+func (m *RevokeCascadeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeCascadeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeCascadeRequest proto.InternalMessageInfo
+
+func (m *RevokeCascadeRequest) GetFileID() string {
+	if m != nil {
+		return m.FileID
+	}
+	return ""
+}
+
+func (m *RevokeCascadeRequest) GetUserID() string {
+	if m != nil {
+		return m.UserID
+	}
+	return ""
+}
+
+func (m *RevokeCascadeRequest) GetResourceType() string {
+	if m != nil {
+		return m.ResourceType
+	}
+	return ""
+}
+
+type RevokeCascadeResponse struct {
+	Permissions          []*PermissionObject `protobuf:"bytes,1,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *RevokeCascadeResponse) Reset()         { *m = RevokeCascadeResponse{} }
+func (m *RevokeCascadeResponse) String() string { return proto.CompactTextString(m) }
+func (*RevokeCascadeResponse) ProtoMessage()    {}
+func (*RevokeCascadeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c837ef01cbda0ad8, []int{18}
+}
+
+func (m *RevokeCascadeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeCascadeResponse.Unmarshal(m, b)
+}
+func (m *RevokeCascadeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeCascadeResponse.Marshal(b, m, deterministic)
+}
+func (m *RevokeCascadeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeCascadeResponse.Merge(m, src)
+}
+func (m *RevokeCascadeResponse) XXX_Size() int {
+	return xxx_messageInfo_RevokeCascadeResponse.Size(m)
+}
+func (m *RevokeCascadeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeCascadeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeCascadeResponse proto.InternalMessageInfo
+
+func (m *RevokeCascadeResponse) GetPermissions() []*PermissionObject {
+	if m != nil {
+		return m.Permissions
+	}
+	return nil
+}
+
+type GetSharedFilesRequest struct {
+	// The ID of the first user.
+	UserA string `protobuf:"bytes,1,opt,name=userA,proto3" json:"userA,omitempty"`
+	// The ID of the second user.
+	UserB string `protobuf:"bytes,2,opt,name=userB,proto3" json:"userB,omitempty"`
+	// If true, only the files that userA shared with userB are returned.
+	GrantedByA bool `protobuf:"varint,3,opt,name=grantedByA,proto3" json:"grantedByA,omitempty"`
+	// The type of the resources to get, defaults to "file".
+	ResourceType         string   `protobuf:"bytes,4,opt,name=resourceType,proto3" json:"resourceType,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetSharedFilesRequest) Reset()         { *m = GetSharedFilesRequest{} }
+func (m *GetSharedFilesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetSharedFilesRequest) ProtoMessage()    {}
+func (*GetSharedFilesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c837ef01cbda0ad8, []int{19}
+}
+
+func (m *GetSharedFilesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSharedFilesRequest.Unmarshal(m, b)
+}
+func (m *GetSharedFilesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSharedFilesRequest.Marshal(b, m, deterministic)
+}
+func (m *GetSharedFilesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSharedFilesRequest.Merge(m, src)
+}
+func (m *GetSharedFilesRequest) XXX_Size() int {
+	return xxx_messageInfo_GetSharedFilesRequest.Size(m)
+}
+func (m *GetSharedFilesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSharedFilesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSharedFilesRequest proto.InternalMessageInfo
+
+func (m *GetSharedFilesRequest) GetUserA() string {
+	if m != nil {
+		return m.UserA
+	}
+	return ""
+}
+
+func (m *GetSharedFilesRequest) GetUserB() string {
+	if m != nil {
+		return m.UserB
+	}
+	return ""
+}
+
+func (m *GetSharedFilesRequest) GetGrantedByA() bool {
+	if m != nil {
+		return m.GrantedByA
+	}
+	return false
+}
+
+func (m *GetSharedFilesRequest) GetResourceType() string {
+	if m != nil {
+		return m.ResourceType
+	}
+	return ""
+}
+
+type GetSharedFilesResponse struct {
+	Files                []*GetSharedFilesResponse_SharedFile `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                             `json:"-"`
+	XXX_unrecognized     []byte                               `json:"-"`
+	XXX_sizecache        int32                                `json:"-"`
+}
+
+func (m *GetSharedFilesResponse) Reset()         { *m = GetSharedFilesResponse{} }
+func (m *GetSharedFilesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetSharedFilesResponse) ProtoMessage()    {}
+func (*GetSharedFilesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c837ef01cbda0ad8, []int{20}
+}
+
+func (m *GetSharedFilesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSharedFilesResponse.Unmarshal(m, b)
+}
+func (m *GetSharedFilesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSharedFilesResponse.Marshal(b, m, deterministic)
+}
+func (m *GetSharedFilesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSharedFilesResponse.Merge(m, src)
+}
+func (m *GetSharedFilesResponse) XXX_Size() int {
+	return xxx_messageInfo_GetSharedFilesResponse.Size(m)
+}
+func (m *GetSharedFilesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSharedFilesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSharedFilesResponse proto.InternalMessageInfo
+
+func (m *GetSharedFilesResponse) GetFiles() []*GetSharedFilesResponse_SharedFile {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+// A file that both users have a permission to, and their roles.
+type GetSharedFilesResponse_SharedFile struct {
+	// The file ID.
+	FileID string `protobuf:"bytes,1,opt,name=fileID,proto3" json:"fileID,omitempty"`
+	// The role of userA's permission to the file.
+	UserARole Role `protobuf:"varint,2,opt,name=userARole,proto3,enum=permission.Role" json:"userARole,omitempty"`
+	// The role of userB's permission to the file.
+	UserBRole            Role     `protobuf:"varint,3,opt,name=userBRole,proto3,enum=permission.Role" json:"userBRole,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetSharedFilesResponse_SharedFile) Reset()         { *m = GetSharedFilesResponse_SharedFile{} }
+func (m *GetSharedFilesResponse_SharedFile) String() string { return proto.CompactTextString(m) }
+func (*GetSharedFilesResponse_SharedFile) ProtoMessage()    {}
+func (*GetSharedFilesResponse_SharedFile) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c837ef01cbda0ad8, []int{20, 0}
+}
+
+func (m *GetSharedFilesResponse_SharedFile) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSharedFilesResponse_SharedFile.Unmarshal(m, b)
+}
+func (m *GetSharedFilesResponse_SharedFile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSharedFilesResponse_SharedFile.Marshal(b, m, deterministic)
+}
+func (m *GetSharedFilesResponse_SharedFile) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSharedFilesResponse_SharedFile.Merge(m, src)
+}
+func (m *GetSharedFilesResponse_SharedFile) XXX_Size() int {
+	return xxx_messageInfo_GetSharedFilesResponse_SharedFile.Size(m)
+}
+func (m *GetSharedFilesResponse_SharedFile) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSharedFilesResponse_SharedFile.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSharedFilesResponse_SharedFile proto.InternalMessageInfo
+
+func (m *GetSharedFilesResponse_SharedFile) GetFileID() string {
+	if m != nil {
+		return m.FileID
+	}
+	return ""
+}
+
+func (m *GetSharedFilesResponse_SharedFile) GetUserARole() Role {
+	if m != nil {
+		return m.UserARole
+	}
+	return Role_NONE
+}
+
+func (m *GetSharedFilesResponse_SharedFile) GetUserBRole() Role {
+	if m != nil {
+		return m.UserBRole
+	}
+	return Role_NONE
+}
+
+type ListSharedWithMeRequest struct {
+	// The ID of the user whose shared files are listed.
+	UserID string `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	// The type of the resources to list, defaults to "file".
+	ResourceType string `protobuf:"bytes,2,opt,name=resourceType,proto3" json:"resourceType,omitempty"`
+	// The maximum number of files to return, defaults to 50 and is limited to 1000.
+	// The server may return fewer, even if there are more files.
+	PageSize int32 `protobuf:"varint,3,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	// The page token returned by the previous request, which continues the listing after its last file.
+	PageToken            string   `protobuf:"bytes,4,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListSharedWithMeRequest) Reset()         { *m = ListSharedWithMeRequest{} }
+func (m *ListSharedWithMeRequest) String() string { return proto.CompactTextString(m) }
+func (*ListSharedWithMeRequest) ProtoMessage()    {}
+func (*ListSharedWithMeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c837ef01cbda0ad8, []int{21}
+}
+
+func (m *ListSharedWithMeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListSharedWithMeRequest.Unmarshal(m, b)
+}
+func (m *ListSharedWithMeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListSharedWithMeRequest.Marshal(b, m, deterministic)
+}
+func (m *ListSharedWithMeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSharedWithMeRequest.Merge(m, src)
+}
+func (m *ListSharedWithMeRequest) XXX_Size() int {
+	return xxx_messageInfo_ListSharedWithMeRequest.Size(m)
+}
+func (m *ListSharedWithMeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSharedWithMeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSharedWithMeRequest proto.InternalMessageInfo
+
+func (m *ListSharedWithMeRequest) GetUserID() string {
+	if m != nil {
+		return m.UserID
+	}
+	return ""
+}
+
+func (m *ListSharedWithMeRequest) GetResourceType() string {
+	if m != nil {
+		return m.ResourceType
+	}
+	return ""
+}
+
+func (m *ListSharedWithMeRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListSharedWithMeRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type ListSharedWithMeResponse struct {
+	Files []*ListSharedWithMeResponse_SharedFile `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	// The token of the next page, empty if there are no more files.
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListSharedWithMeResponse) Reset()         { *m = ListSharedWithMeResponse{} }
+func (m *ListSharedWithMeResponse) String() string { return proto.CompactTextString(m) }
+func (*ListSharedWithMeResponse) ProtoMessage()    {}
+func (*ListSharedWithMeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c837ef01cbda0ad8, []int{22}
+}
+
+func (m *ListSharedWithMeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListSharedWithMeResponse.Unmarshal(m, b)
+}
+func (m *ListSharedWithMeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListSharedWithMeResponse.Marshal(b, m, deterministic)
+}
+func (m *ListSharedWithMeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSharedWithMeResponse.Merge(m, src)
+}
+func (m *ListSharedWithMeResponse) XXX_Size() int {
+	return xxx_messageInfo_ListSharedWithMeResponse.Size(m)
+}
+func (m *ListSharedWithMeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSharedWithMeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSharedWithMeResponse proto.InternalMessageInfo
+
+func (m *ListSharedWithMeResponse) GetFiles() []*ListSharedWithMeResponse_SharedFile {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+func (m *ListSharedWithMeResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+// A file that another user shared with the user.
+type ListSharedWithMeResponse_SharedFile struct {
+	// The file ID.
+	FileID string `protobuf:"bytes,1,opt,name=fileID,proto3" json:"fileID,omitempty"`
+	// The role of the user's permission to the file.
+	Role Role `protobuf:"varint,2,opt,name=role,proto3,enum=permission.Role" json:"role,omitempty"`
+	// The user that shared the file, the creator of the permission.
+	SharedBy string `protobuf:"bytes,3,opt,name=sharedBy,proto3" json:"sharedBy,omitempty"`
+	// The last time the file was shared with the user.
+	SharedAt             *timestamp.Timestamp `protobuf:"bytes,4,opt,name=sharedAt,proto3" json:"sharedAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ListSharedWithMeResponse_SharedFile) Reset()         { *m = ListSharedWithMeResponse_SharedFile{} }
+func (m *ListSharedWithMeResponse_SharedFile) String() string { return proto.CompactTextString(m) }
+func (*ListSharedWithMeResponse_SharedFile) ProtoMessage()    {}
+func (*ListSharedWithMeResponse_SharedFile) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c837ef01cbda0ad8, []int{22, 0}
+}
+
+func (m *ListSharedWithMeResponse_SharedFile) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListSharedWithMeResponse_SharedFile.Unmarshal(m, b)
+}
+func (m *ListSharedWithMeResponse_SharedFile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListSharedWithMeResponse_SharedFile.Marshal(b, m, deterministic)
+}
+func (m *ListSharedWithMeResponse_SharedFile) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSharedWithMeResponse_SharedFile.Merge(m, src)
+}
+func (m *ListSharedWithMeResponse_SharedFile) XXX_Size() int {
+	return xxx_messageInfo_ListSharedWithMeResponse_SharedFile.Size(m)
+}
+func (m *ListSharedWithMeResponse_SharedFile) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSharedWithMeResponse_SharedFile.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSharedWithMeResponse_SharedFile proto.InternalMessageInfo
+
+func (m *ListSharedWithMeResponse_SharedFile) GetFileID() string {
+	if m != nil {
+		return m.FileID
+	}
+	return ""
+}
+
+func (m *ListSharedWithMeResponse_SharedFile) GetRole() Role {
+	if m != nil {
+		return m.Role
+	}
+	return Role_NONE
+}
+
+func (m *ListSharedWithMeResponse_SharedFile) GetSharedBy() string {
+	if m != nil {
+		return m.SharedBy
+	}
+	return ""
+}
+
+func (m *ListSharedWithMeResponse_SharedFile) GetSharedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.SharedAt
+	}
+	return nil
+}
+
+type ListPermissionChangesRequest struct {
+	// The ID of the user whose permissions changes are listed.
+	UserID string `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	// The sync token returned by the previous request. If empty, no changes are returned
+	// and the token of the current state is returned, so a client should get it before
+	// downloading the user's permissions and then list the changes since it.
+	SyncToken string `protobuf:"bytes,2,opt,name=syncToken,proto3" json:"syncToken,omitempty"`
+	// The maximum number of changes to return, defaults to 100 and is limited to 1000.
+	PageSize int32 `protobuf:"varint,3,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	// The type of the resources whose permissions changes are listed, defaults to "file".
+	ResourceType         string   `protobuf:"bytes,4,opt,name=resourceType,proto3" json:"resourceType,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListPermissionChangesRequest) Reset()         { *m = ListPermissionChangesRequest{} }
+func (m *ListPermissionChangesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListPermissionChangesRequest) ProtoMessage()    {}
+func (*ListPermissionChangesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c837ef01cbda0ad8, []int{23}
+}
+
+func (m *ListPermissionChangesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPermissionChangesRequest.Unmarshal(m, b)
+}
+func (m *ListPermissionChangesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPermissionChangesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListPermissionChangesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPermissionChangesRequest.Merge(m, src)
+}
+func (m *ListPermissionChangesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListPermissionChangesRequest.Size(m)
+}
+func (m *ListPermissionChangesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPermissionChangesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPermissionChangesRequest proto.InternalMessageInfo
+
+func (m *ListPermissionChangesRequest) GetUserID() string {
+	if m != nil {
+		return m.UserID
+	}
+	return ""
+}
+
+func (m *ListPermissionChangesRequest) GetSyncToken() string {
+	if m != nil {
+		return m.SyncToken
+	}
+	return ""
+}
+
+func (m *ListPermissionChangesRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListPermissionChangesRequest) GetResourceType() string {
+	if m != nil {
+		return m.ResourceType
+	}
+	return ""
+}
+
+type ListPermissionChangesResponse struct {
+	// The changes in the order they occurred.
+	Changes []*ListPermissionChangesResponse_PermissionChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	// The sync token to list the following changes with.
+	SyncToken string `protobuf:"bytes,2,opt,name=syncToken,proto3" json:"syncToken,omitempty"`
+	// Whether there are more changes after syncToken, that should be listed right away.
+	HasMore              bool     `protobuf:"varint,3,opt,name=hasMore,proto3" json:"hasMore,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListPermissionChangesResponse) Reset()         { *m = ListPermissionChangesResponse{} }
+func (m *ListPermissionChangesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListPermissionChangesResponse) ProtoMessage()    {}
+func (*ListPermissionChangesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c837ef01cbda0ad8, []int{24}
+}
+
+func (m *ListPermissionChangesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPermissionChangesResponse.Unmarshal(m, b)
+}
+func (m *ListPermissionChangesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPermissionChangesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListPermissionChangesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPermissionChangesResponse.Merge(m, src)
+}
+func (m *ListPermissionChangesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListPermissionChangesResponse.Size(m)
+}
+func (m *ListPermissionChangesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPermissionChangesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPermissionChangesResponse proto.InternalMessageInfo
+
+func (m *ListPermissionChangesResponse) GetChanges() []*ListPermissionChangesResponse_PermissionChange {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+func (m *ListPermissionChangesResponse) GetSyncToken() string {
+	if m != nil {
+		return m.SyncToken
+	}
+	return ""
+}
+
+func (m *ListPermissionChangesResponse) GetHasMore() bool {
+	if m != nil {
+		return m.HasMore
+	}
+	return false
+}
+
+// A change to a permission.
+type ListPermissionChangesResponse_PermissionChange struct {
+	// The ID of the change.
+	Id   string     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type ChangeType `protobuf:"varint,2,opt,name=type,proto3,enum=permission.ChangeType" json:"type,omitempty"`
+	// The permission after the change, or before it if it was deleted.
+	Permission           *PermissionObject    `protobuf:"bytes,3,opt,name=permission,proto3" json:"permission,omitempty"`
+	OccurredAt           *timestamp.Timestamp `protobuf:"bytes,4,opt,name=occurredAt,proto3" json:"occurredAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ListPermissionChangesResponse_PermissionChange) Reset() {
+	*m = ListPermissionChangesResponse_PermissionChange{}
+}
+func (m *ListPermissionChangesResponse_PermissionChange) String() string {
+	return proto.CompactTextString(m)
+}
+func (*ListPermissionChangesResponse_PermissionChange) ProtoMessage() {}
+func (*ListPermissionChangesResponse_PermissionChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c837ef01cbda0ad8, []int{24, 0}
+}
+
+func (m *ListPermissionChangesResponse_PermissionChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPermissionChangesResponse_PermissionChange.Unmarshal(m, b)
+}
+func (m *ListPermissionChangesResponse_PermissionChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPermissionChangesResponse_PermissionChange.Marshal(b, m, deterministic)
+}
+func (m *ListPermissionChangesResponse_PermissionChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPermissionChangesResponse_PermissionChange.Merge(m, src)
+}
+func (m *ListPermissionChangesResponse_PermissionChange) XXX_Size() int {
+	return xxx_messageInfo_ListPermissionChangesResponse_PermissionChange.Size(m)
+}
+func (m *ListPermissionChangesResponse_PermissionChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPermissionChangesResponse_PermissionChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPermissionChangesResponse_PermissionChange proto.InternalMessageInfo
+
+func (m *ListPermissionChangesResponse_PermissionChange) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ListPermissionChangesResponse_PermissionChange) GetType() ChangeType {
+	if m != nil {
+		return m.Type
+	}
+	return ChangeType_UNKNOWN
+}
+
+func (m *ListPermissionChangesResponse_PermissionChange) GetPermission() *PermissionObject {
+	if m != nil {
+		return m.Permission
+	}
+	return nil
+}
+
+func (m *ListPermissionChangesResponse_PermissionChange) GetOccurredAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return nil
+}
+
+type HandleFileMovedRequest struct {
+	// The ID of the file that was moved.
+	FileID string `protobuf:"bytes,1,opt,name=fileID,proto3" json:"fileID,omitempty"`
+	// The ID of the folder the file was moved from, empty if it was at the root.
+	OldParent string `protobuf:"bytes,2,opt,name=oldParent,proto3" json:"oldParent,omitempty"`
+	// The ID of the folder the file was moved to, empty if it's at the root.
+	NewParent string `protobuf:"bytes,3,opt,name=newParent,proto3" json:"newParent,omitempty"`
+	// The type of the resource that was moved, defaults to "file".
+	ResourceType         string   `protobuf:"bytes,4,opt,name=resourceType,proto3" json:"resourceType,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HandleFileMovedRequest) Reset()         { *m = HandleFileMovedRequest{} }
+func (m *HandleFileMovedRequest) String() string { return proto.CompactTextString(m) }
+func (*HandleFileMovedRequest) ProtoMessage()    {}
+func (*HandleFileMovedRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c837ef01cbda0ad8, []int{25}
+}
+
+func (m *HandleFileMovedRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HandleFileMovedRequest.Unmarshal(m, b)
+}
+func (m *HandleFileMovedRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HandleFileMovedRequest.Marshal(b, m, deterministic)
+}
+func (m *HandleFileMovedRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HandleFileMovedRequest.Merge(m, src)
+}
+func (m *HandleFileMovedRequest) XXX_Size() int {
+	return xxx_messageInfo_HandleFileMovedRequest.Size(m)
+}
+func (m *HandleFileMovedRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HandleFileMovedRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HandleFileMovedRequest proto.InternalMessageInfo
+
+func (m *HandleFileMovedRequest) GetFileID() string {
+	if m != nil {
+		return m.FileID
+	}
+	return ""
+}
+
+func (m *HandleFileMovedRequest) GetOldParent() string {
+	if m != nil {
+		return m.OldParent
+	}
+	return ""
+}
+
+func (m *HandleFileMovedRequest) GetNewParent() string {
+	if m != nil {
+		return m.NewParent
+	}
+	return ""
+}
+
+func (m *HandleFileMovedRequest) GetResourceType() string {
+	if m != nil {
+		return m.ResourceType
+	}
+	return ""
+}
+
+type HandleFileMovedResponse struct {
+	// The number of inherited permissions that were created.
+	Created int64 `protobuf:"varint,1,opt,name=created,proto3" json:"created,omitempty"`
+	// The number of inherited permissions that were deleted.
+	Deleted              int64    `protobuf:"varint,2,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HandleFileMovedResponse) Reset()         { *m = HandleFileMovedResponse{} }
+func (m *HandleFileMovedResponse) String() string { return proto.CompactTextString(m) }
+func (*HandleFileMovedResponse) ProtoMessage()    {}
+func (*HandleFileMovedResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c837ef01cbda0ad8, []int{26}
+}
+
+func (m *HandleFileMovedResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HandleFileMovedResponse.Unmarshal(m, b)
+}
+func (m *HandleFileMovedResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HandleFileMovedResponse.Marshal(b, m, deterministic)
+}
+func (m *HandleFileMovedResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HandleFileMovedResponse.Merge(m, src)
+}
+func (m *HandleFileMovedResponse) XXX_Size() int {
+	return xxx_messageInfo_HandleFileMovedResponse.Size(m)
+}
+func (m *HandleFileMovedResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_HandleFileMovedResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HandleFileMovedResponse proto.InternalMessageInfo
+
+func (m *HandleFileMovedResponse) GetCreated() int64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+func (m *HandleFileMovedResponse) GetDeleted() int64 {
+	if m != nil {
+		return m.Deleted
+	}
+	return 0
+}
+
+type RevokeAllExceptOwnerRequest struct {
+	// The ID of the file whose sharing is stopped.
+	FileID string `protobuf:"bytes,1,opt,name=fileID,proto3" json:"fileID,omitempty"`
+	// The type of the resource whose sharing is stopped, defaults to "file".
+	ResourceType string `protobuf:"bytes,2,opt,name=resourceType,proto3" json:"resourceType,omitempty"`
+	// The ID of the owner of the file, whose permission is kept. If empty then it's read from the file service.
+	OwnerID              string   `protobuf:"bytes,3,opt,name=ownerID,proto3" json:"ownerID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevokeAllExceptOwnerRequest) Reset()         { *m = RevokeAllExceptOwnerRequest{} }
+func (m *RevokeAllExceptOwnerRequest) String() string { return proto.CompactTextString(m) }
+func (*RevokeAllExceptOwnerRequest) ProtoMessage()    {}
+func (*RevokeAllExceptOwnerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c837ef01cbda0ad8, []int{27}
+}
+
+func (m *RevokeAllExceptOwnerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeAllExceptOwnerRequest.Unmarshal(m, b)
+}
+func (m *RevokeAllExceptOwnerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeAllExceptOwnerRequest.Marshal(b, m, deterministic)
+}
+func (m *RevokeAllExceptOwnerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeAllExceptOwnerRequest.Merge(m, src)
+}
+func (m *RevokeAllExceptOwnerRequest) XXX_Size() int {
+	return xxx_messageInfo_RevokeAllExceptOwnerRequest.Size(m)
+}
+func (m *RevokeAllExceptOwnerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeAllExceptOwnerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeAllExceptOwnerRequest proto.InternalMessageInfo
+
+func (m *RevokeAllExceptOwnerRequest) GetFileID() string {
+	if m != nil {
+		return m.FileID
+	}
+	return ""
+}
+
+func (m *RevokeAllExceptOwnerRequest) GetResourceType() string {
+	if m != nil {
+		return m.ResourceType
+	}
+	return ""
+}
+
+func (m *RevokeAllExceptOwnerRequest) GetOwnerID() string {
+	if m != nil {
+		return m.OwnerID
+	}
+	return ""
+}
+
+type RevokeAllExceptOwnerResponse struct {
+	// The permissions that were revoked.
+	Permissions          []*PermissionObject `protobuf:"bytes,1,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *RevokeAllExceptOwnerResponse) Reset()         { *m = RevokeAllExceptOwnerResponse{} }
+func (m *RevokeAllExceptOwnerResponse) String() string { return proto.CompactTextString(m) }
+func (*RevokeAllExceptOwnerResponse) ProtoMessage()    {}
+func (*RevokeAllExceptOwnerResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c837ef01cbda0ad8, []int{28}
+}
+
+func (m *RevokeAllExceptOwnerResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeAllExceptOwnerResponse.Unmarshal(m, b)
+}
+func (m *RevokeAllExceptOwnerResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeAllExceptOwnerResponse.Marshal(b, m, deterministic)
+}
+func (m *RevokeAllExceptOwnerResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeAllExceptOwnerResponse.Merge(m, src)
+}
+func (m *RevokeAllExceptOwnerResponse) XXX_Size() int {
+	return xxx_messageInfo_RevokeAllExceptOwnerResponse.Size(m)
+}
+func (m *RevokeAllExceptOwnerResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeAllExceptOwnerResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeAllExceptOwnerResponse proto.InternalMessageInfo
+
+func (m *RevokeAllExceptOwnerResponse) GetPermissions() []*PermissionObject {
+	if m != nil {
+		return m.Permissions
+	}
+	return nil
+}
+
+type PermissionsExistRequest struct {
+	// The IDs of the permissions.
+	Ids                  []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PermissionsExistRequest) Reset()         { *m = PermissionsExistRequest{} }
+func (m *PermissionsExistRequest) String() string { return proto.CompactTextString(m) }
+func (*PermissionsExistRequest) ProtoMessage()    {}
+func (*PermissionsExistRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c837ef01cbda0ad8, []int{29}
+}
+
+func (m *PermissionsExistRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PermissionsExistRequest.Unmarshal(m, b)
+}
+func (m *PermissionsExistRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PermissionsExistRequest.Marshal(b, m, deterministic)
+}
+func (m *PermissionsExistRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PermissionsExistRequest.Merge(m, src)
+}
+func (m *PermissionsExistRequest) XXX_Size() int {
+	return xxx_messageInfo_PermissionsExistRequest.Size(m)
 }
-func (m *DeleteFilePermissionsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteFilePermissionsRequest.DiscardUnknown(m)
+func (m *PermissionsExistRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PermissionsExistRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteFilePermissionsRequest proto.InternalMessageInfo
+var xxx_messageInfo_PermissionsExistRequest proto.InternalMessageInfo
 
-func (m *DeleteFilePermissionsRequest) GetFileID() string {
+func (m *PermissionsExistRequest) GetIds() []string {
 	if m != nil {
-		return m.FileID
+		return m.Ids
 	}
-	return ""
+	return nil
 }
 
-type DeleteFilePermissionsResponse struct {
-	Permissions          []*PermissionObject `protobuf:"bytes,1,rep,name=permissions,proto3" json:"permissions,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
-	XXX_unrecognized     []byte              `json:"-"`
-	XXX_sizecache        int32               `json:"-"`
+type PermissionsExistResponse struct {
+	// Whether each of the permissions exists, in the order of the IDs. Malformed IDs don't exist.
+	Exists               []bool   `protobuf:"varint,1,rep,packed,name=exists,proto3" json:"exists,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DeleteFilePermissionsResponse) Reset()         { *m = DeleteFilePermissionsResponse{} }
-func (m *DeleteFilePermissionsResponse) String() string { return proto.CompactTextString(m) }
-func (*DeleteFilePermissionsResponse) ProtoMessage()    {}
-func (*DeleteFilePermissionsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c837ef01cbda0ad8, []int{11}
+func (m *PermissionsExistResponse) Reset()         { *m = PermissionsExistResponse{} }
+func (m *PermissionsExistResponse) String() string { return proto.CompactTextString(m) }
+func (*PermissionsExistResponse) ProtoMessage()    {}
+func (*PermissionsExistResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c837ef01cbda0ad8, []int{30}
 }
 
-func (m *DeleteFilePermissionsResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DeleteFilePermissionsResponse.Unmarshal(m, b)
+func (m *PermissionsExistResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PermissionsExistResponse.Unmarshal(m, b)
 }
-func (m *DeleteFilePermissionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DeleteFilePermissionsResponse.Marshal(b, m, deterministic)
+func (m *PermissionsExistResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PermissionsExistResponse.Marshal(b, m, deterministic)
 }
-func (m *DeleteFilePermissionsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteFilePermissionsResponse.Merge(m, src)
+func (m *PermissionsExistResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PermissionsExistResponse.Merge(m, src)
 }
-func (m *DeleteFilePermissionsResponse) XXX_Size() int {
-	return xxx_messageInfo_DeleteFilePermissionsResponse.Size(m)
+func (m *PermissionsExistResponse) XXX_Size() int {
+	return xxx_messageInfo_PermissionsExistResponse.Size(m)
 }
-func (m *DeleteFilePermissionsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteFilePermissionsResponse.DiscardUnknown(m)
+func (m *PermissionsExistResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PermissionsExistResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteFilePermissionsResponse proto.InternalMessageInfo
+var xxx_messageInfo_PermissionsExistResponse proto.InternalMessageInfo
 
-func (m *DeleteFilePermissionsResponse) GetPermissions() []*PermissionObject {
+func (m *PermissionsExistResponse) GetExists() []bool {
 	if m != nil {
-		return m.Permissions
+		return m.Exists
 	}
 	return nil
 }
 
 func init() {
 	proto.RegisterEnum("permission.Role", Role_name, Role_value)
+	proto.RegisterEnum("permission.PermissionsOrder", PermissionsOrder_name, PermissionsOrder_value)
+	proto.RegisterEnum("permission.Capability", Capability_name, Capability_value)
+	proto.RegisterEnum("permission.ChangeType", ChangeType_name, ChangeType_value)
 	proto.RegisterType((*CreatePermissionRequest)(nil), "permission.CreatePermissionRequest")
+	proto.RegisterMapType((map[string]string)(nil), "permission.CreatePermissionRequest.LabelsEntry")
 	proto.RegisterType((*DeletePermissionRequest)(nil), "permission.DeletePermissionRequest")
 	proto.RegisterType((*PermissionObject)(nil), "permission.PermissionObject")
+	proto.RegisterMapType((map[string]string)(nil), "permission.PermissionObject.LabelsEntry")
 	proto.RegisterType((*GetPermissionRequest)(nil), "permission.GetPermissionRequest")
 	proto.RegisterType((*GetFilePermissionsRequest)(nil), "permission.GetFilePermissionsRequest")
+	proto.RegisterMapType((map[string]string)(nil), "permission.GetFilePermissionsRequest.LabelSelectorEntry")
 	proto.RegisterType((*GetFilePermissionsResponse)(nil), "permission.GetFilePermissionsResponse")
 	proto.RegisterType((*GetFilePermissionsResponse_UserRole)(nil), "permission.GetFilePermissionsResponse.UserRole")
+	proto.RegisterMapType((map[string]string)(nil), "permission.GetFilePermissionsResponse.UserRole.LabelsEntry")
 	proto.RegisterType((*IsPermittedRequest)(nil), "permission.IsPermittedRequest")
 	proto.RegisterType((*IsPermittedResponse)(nil), "permission.IsPermittedResponse")
+	proto.RegisterType((*CheckPermissionsMatrixRequest)(nil), "permission.CheckPermissionsMatrixRequest")
+	proto.RegisterType((*CheckPermissionsMatrixResponse)(nil), "permission.CheckPermissionsMatrixResponse")
+	proto.RegisterType((*CheckPermissionsMatrixResponse_Result)(nil), "permission.CheckPermissionsMatrixResponse.Result")
 	proto.RegisterType((*GetUserPermissionsRequest)(nil), "permission.GetUserPermissionsRequest")
+	proto.RegisterMapType((map[string]string)(nil), "permission.GetUserPermissionsRequest.LabelSelectorEntry")
 	proto.RegisterType((*GetUserPermissionsResponse)(nil), "permission.GetUserPermissionsResponse")
 	proto.RegisterType((*GetUserPermissionsResponse_FileRole)(nil), "permission.GetUserPermissionsResponse.FileRole")
+	proto.RegisterMapType((map[string]string)(nil), "permission.GetUserPermissionsResponse.FileRole.LabelsEntry")
 	proto.RegisterType((*DeleteFilePermissionsRequest)(nil), "permission.DeleteFilePermissionsRequest")
+	proto.RegisterMapType((map[string]string)(nil), "permission.DeleteFilePermissionsRequest.LabelSelectorEntry")
 	proto.RegisterType((*DeleteFilePermissionsResponse)(nil), "permission.DeleteFilePermissionsResponse")
+	proto.RegisterType((*CopyPermissionsRequest)(nil), "permission.CopyPermissionsRequest")
+	proto.RegisterType((*CopyPermissionsResponse)(nil), "permission.CopyPermissionsResponse")
+	proto.RegisterType((*TouchPermissionRequest)(nil), "permission.TouchPermissionRequest")
+	proto.RegisterType((*RevokeCascadeRequest)(nil), "permission.RevokeCascadeRequest")
+	proto.RegisterType((*RevokeCascadeResponse)(nil), "permission.RevokeCascadeResponse")
+	proto.RegisterType((*GetSharedFilesRequest)(nil), "permission.GetSharedFilesRequest")
+	proto.RegisterType((*GetSharedFilesResponse)(nil), "permission.GetSharedFilesResponse")
+	proto.RegisterType((*GetSharedFilesResponse_SharedFile)(nil), "permission.GetSharedFilesResponse.SharedFile")
+	proto.RegisterType((*ListSharedWithMeRequest)(nil), "permission.ListSharedWithMeRequest")
+	proto.RegisterType((*ListSharedWithMeResponse)(nil), "permission.ListSharedWithMeResponse")
+	proto.RegisterType((*ListSharedWithMeResponse_SharedFile)(nil), "permission.ListSharedWithMeResponse.SharedFile")
+	proto.RegisterType((*ListPermissionChangesRequest)(nil), "permission.ListPermissionChangesRequest")
+	proto.RegisterType((*ListPermissionChangesResponse)(nil), "permission.ListPermissionChangesResponse")
+	proto.RegisterType((*ListPermissionChangesResponse_PermissionChange)(nil), "permission.ListPermissionChangesResponse.PermissionChange")
+	proto.RegisterType((*HandleFileMovedRequest)(nil), "permission.HandleFileMovedRequest")
+	proto.RegisterType((*HandleFileMovedResponse)(nil), "permission.HandleFileMovedResponse")
+	proto.RegisterType((*RevokeAllExceptOwnerRequest)(nil), "permission.RevokeAllExceptOwnerRequest")
+	proto.RegisterType((*RevokeAllExceptOwnerResponse)(nil), "permission.RevokeAllExceptOwnerResponse")
+	proto.RegisterType((*PermissionsExistRequest)(nil), "permission.PermissionsExistRequest")
+	proto.RegisterType((*PermissionsExistResponse)(nil), "permission.PermissionsExistResponse")
 }
 
 func init() { proto.RegisterFile("permission.proto", fileDescriptor_c837ef01cbda0ad8) }
 
 var fileDescriptor_c837ef01cbda0ad8 = []byte{
-	// 530 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x55, 0xcb, 0x6e, 0xd3, 0x40,
-	0x14, 0xcd, 0x38, 0x4e, 0x71, 0x6e, 0x44, 0x65, 0x0d, 0x8f, 0x1a, 0x2b, 0x80, 0x65, 0x28, 0x32,
-	0x2c, 0x82, 0x94, 0x48, 0x2c, 0x91, 0x10, 0x49, 0x2b, 0x6f, 0xfa, 0xb0, 0x40, 0x5d, 0x22, 0xd2,
-	0x5c, 0x24, 0x57, 0x26, 0x0e, 0x33, 0x2e, 0x1f, 0xc2, 0x1f, 0xb0, 0xe0, 0x8b, 0xf8, 0x21, 0x64,
-	0xc7, 0x8f, 0xb1, 0x3d, 0x43, 0x12, 0x05, 0x96, 0x73, 0xc7, 0xf7, 0x9c, 0x39, 0xe7, 0x3e, 0x0c,
-	0xe6, 0x0a, 0xd9, 0xd7, 0x90, 0xf3, 0x30, 0x5e, 0x8e, 0x56, 0x2c, 0x4e, 0x62, 0x0a, 0x55, 0xc4,
-	0xfd, 0x45, 0xe0, 0xe8, 0x3d, 0xc3, 0xcf, 0x09, 0x5e, 0x94, 0xc1, 0x00, 0xbf, 0xdd, 0x22, 0x4f,
-	0xe8, 0x43, 0x38, 0xf8, 0x12, 0x46, 0xe8, 0x4f, 0x2d, 0xe2, 0x10, 0xaf, 0x1f, 0xe4, 0xa7, 0x34,
-	0x7e, 0xcb, 0x91, 0xf9, 0x53, 0x4b, 0x5b, 0xc7, 0xd7, 0x27, 0xfa, 0x1c, 0x74, 0x16, 0x47, 0x68,
-	0x75, 0x1d, 0xe2, 0x1d, 0x8e, 0xcd, 0x91, 0x40, 0x1c, 0xc4, 0x11, 0x06, 0xd9, 0x2d, 0xb5, 0xe0,
-	0xce, 0x75, 0x4a, 0x18, 0x33, 0x4b, 0xcf, 0xd2, 0x8b, 0x23, 0xb5, 0xc1, 0x88, 0xbf, 0x23, 0x63,
-	0xe1, 0x02, 0xad, 0x9e, 0x43, 0x3c, 0x23, 0x28, 0xcf, 0xae, 0x0f, 0x47, 0x53, 0x8c, 0xf0, 0x1f,
-	0x3c, 0xd3, 0xfd, 0x41, 0xc0, 0xac, 0x50, 0xce, 0xe7, 0x37, 0x78, 0x9d, 0xd0, 0x43, 0xd0, 0xc2,
-	0x45, 0x0e, 0xa0, 0x85, 0x0b, 0x01, 0x54, 0x53, 0x80, 0x76, 0xa5, 0xda, 0xf5, 0x6d, 0xb5, 0xf7,
-	0x6a, 0xda, 0xdd, 0x13, 0xb8, 0x7f, 0x8a, 0xc9, 0xfe, 0xe2, 0x26, 0xf0, 0xe8, 0x14, 0x93, 0x93,
-	0x30, 0x12, 0x8c, 0xe2, 0x1b, 0xc0, 0xdc, 0xdf, 0x04, 0x6c, 0x59, 0x16, 0x5f, 0xc5, 0x4b, 0x8e,
-	0xf4, 0x12, 0x06, 0x95, 0x1c, 0x6e, 0x11, 0xa7, 0xeb, 0x0d, 0xc6, 0xaf, 0x45, 0x89, 0xea, 0xe4,
-	0xd1, 0x47, 0x8e, 0x2c, 0x73, 0x40, 0xc4, 0xb0, 0xe7, 0x60, 0x14, 0x17, 0x82, 0x14, 0x22, 0xb5,
-	0x54, 0xdb, 0xd6, 0xd2, 0x6e, 0xdd, 0xd2, 0x1b, 0xa0, 0x3e, 0xcf, 0x9e, 0x94, 0x24, 0xb8, 0xf8,
-	0xaf, 0x4d, 0xed, 0x4e, 0xe0, 0x5e, 0x8d, 0x2b, 0x77, 0x6e, 0x08, 0xfd, 0x55, 0x11, 0xcc, 0xf8,
-	0x8c, 0xa0, 0x0a, 0xe4, 0xb5, 0x4a, 0x7d, 0x90, 0xd7, 0x4a, 0xe6, 0x4a, 0x51, 0xab, 0x56, 0xd6,
-	0x2e, 0xb5, 0x52, 0x24, 0x8f, 0xd2, 0x1a, 0x4a, 0x6b, 0x55, 0x5c, 0x28, 0xdd, 0xdb, 0xb7, 0x56,
-	0x6f, 0x60, 0xb8, 0x1e, 0xef, 0x1d, 0x3b, 0xf7, 0x13, 0x3c, 0x56, 0xe4, 0xe5, 0x7e, 0xbc, 0x95,
-	0xf9, 0x31, 0x14, 0xdf, 0xd7, 0x5c, 0x05, 0x35, 0xf1, 0xaf, 0x8e, 0x41, 0xcf, 0x84, 0x1b, 0xa0,
-	0x9f, 0x9d, 0x9f, 0xcd, 0xcc, 0x0e, 0xed, 0x43, 0xef, 0x2a, 0xf0, 0x3f, 0xcc, 0x4c, 0x92, 0x06,
-	0x83, 0xd9, 0xbb, 0xa9, 0xa9, 0x8d, 0x7f, 0xf6, 0x00, 0x2a, 0x20, 0x7a, 0x05, 0x66, 0x73, 0xa9,
-	0xd2, 0x67, 0x22, 0xa9, 0x62, 0xe5, 0xda, 0x7f, 0x7d, 0x99, 0xdb, 0x49, 0x81, 0x9b, 0x6b, 0xb0,
-	0x0e, 0xac, 0x58, 0x92, 0x1b, 0x81, 0x11, 0x68, 0x7b, 0x88, 0xe9, 0xf1, 0xa6, 0x21, 0x5f, 0x83,
-	0xbf, 0xd8, 0x6e, 0x17, 0x94, 0x34, 0x8d, 0xfe, 0x6b, 0xd1, 0xc8, 0x47, 0xa2, 0x45, 0xa3, 0x68,
-	0x63, 0xb7, 0x43, 0x2f, 0x60, 0x20, 0x8c, 0x23, 0x7d, 0x22, 0x26, 0xb6, 0x77, 0x82, 0xfd, 0x54,
-	0x79, 0x5f, 0x22, 0x2e, 0xe1, 0x81, 0xb4, 0xd1, 0xa8, 0xd7, 0x76, 0x5f, 0xe1, 0xd2, 0xcb, 0x2d,
-	0xbe, 0x2c, 0xf9, 0x2e, 0xe1, 0x6e, 0xed, 0x7f, 0x40, 0x9d, 0x86, 0xf8, 0x9d, 0x4b, 0x3c, 0x3f,
-	0xc8, 0xfe, 0xfe, 0x93, 0x3f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x23, 0xaf, 0x7e, 0xd2, 0x11, 0x08,
-	0x00, 0x00,
+	// 2282 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x19, 0x4b, 0x6f, 0x1c, 0x49,
+	0xd9, 0x3d, 0xef, 0xf9, 0xfc, 0x48, 0xa7, 0x70, 0xec, 0x4e, 0xe3, 0x78, 0x9d, 0x4e, 0x08, 0x8e,
+	0x81, 0x09, 0x6b, 0xa4, 0x68, 0x09, 0x08, 0x65, 0x3c, 0xd3, 0xc9, 0x8e, 0x62, 0xcf, 0x98, 0x9a,
+	0x71, 0xac, 0x95, 0x56, 0x1b, 0xb5, 0xa7, 0x6b, 0xed, 0xc6, 0xe3, 0xe9, 0xd9, 0xee, 0x76, 0x12,
+	0x87, 0x3d, 0x70, 0x40, 0xe2, 0x84, 0xb4, 0x48, 0x48, 0x9c, 0x60, 0x2f, 0x1c, 0xd8, 0x0b, 0x47,
+	0xae, 0x48, 0x9c, 0xf8, 0x0d, 0x9c, 0x11, 0x3f, 0x80, 0x13, 0xda, 0x13, 0xaa, 0xaa, 0x7e, 0x3f,
+	0x66, 0xda, 0x8b, 0x61, 0xc5, 0xde, 0xba, 0xbe, 0xfa, 0xbe, 0xaa, 0xef, 0xfd, 0xa8, 0x06, 0x71,
+	0x42, 0xac, 0x33, 0xc3, 0xb6, 0x0d, 0x73, 0xdc, 0x98, 0x58, 0xa6, 0x63, 0x22, 0x08, 0x20, 0xf2,
+	0xfa, 0xb1, 0x69, 0x1e, 0x8f, 0xc8, 0x03, 0xb6, 0x73, 0x74, 0xfe, 0xe1, 0x03, 0xfd, 0xdc, 0xd2,
+	0x1c, 0x1f, 0x57, 0x7e, 0x2b, 0xbe, 0xef, 0x18, 0x67, 0xc4, 0x76, 0xb4, 0xb3, 0x89, 0x8b, 0x90,
+	0x38, 0xe0, 0x95, 0xa5, 0x4d, 0x26, 0xc4, 0xb2, 0xdd, 0xfd, 0xd5, 0x97, 0xda, 0xc8, 0xd0, 0x35,
+	0x87, 0x3c, 0xf0, 0x3e, 0xf8, 0x86, 0xf2, 0x8f, 0x12, 0xac, 0xb6, 0x2c, 0xa2, 0x39, 0x64, 0xdf,
+	0x67, 0x07, 0x93, 0x8f, 0xce, 0x89, 0xed, 0xa0, 0x75, 0xa8, 0x7c, 0x68, 0x8c, 0x48, 0xa7, 0x2d,
+	0x09, 0x1b, 0xc2, 0x66, 0x7d, 0xa7, 0xf2, 0xf9, 0x67, 0x37, 0x0b, 0x35, 0x01, 0xbb, 0x50, 0xba,
+	0x7f, 0x6e, 0x13, 0xab, 0xd3, 0x96, 0x0a, 0xd1, 0x7d, 0x0e, 0x45, 0xdf, 0x86, 0x92, 0x65, 0x8e,
+	0x88, 0x54, 0xdc, 0x10, 0x36, 0x97, 0xb6, 0xc5, 0x46, 0x48, 0x05, 0xd8, 0x1c, 0x11, 0x8e, 0xff,
+	0x58, 0xc0, 0x0c, 0x0b, 0x6d, 0x40, 0x75, 0x48, 0x19, 0x31, 0x2d, 0xa9, 0x14, 0x39, 0xce, 0x03,
+	0x23, 0x19, 0x6a, 0xe6, 0x4b, 0x62, 0x59, 0x86, 0x4e, 0xa4, 0xf2, 0x86, 0xb0, 0x59, 0xc3, 0xfe,
+	0x1a, 0x3d, 0x02, 0x18, 0x6a, 0x63, 0x4c, 0xec, 0x13, 0xcd, 0x22, 0x52, 0x65, 0x43, 0xd8, 0x9c,
+	0xdf, 0x96, 0x1b, 0x5c, 0x2b, 0x0d, 0x4f, 0x2b, 0x8d, 0x1d, 0xd3, 0x1c, 0x3d, 0xd7, 0x46, 0xe7,
+	0x04, 0x87, 0xb0, 0xd1, 0x6d, 0xa8, 0x9e, 0x11, 0xdb, 0xd6, 0x8e, 0x89, 0x54, 0x65, 0x37, 0x57,
+	0x3f, 0xff, 0xec, 0x66, 0x51, 0xfa, 0x59, 0x0d, 0x7b, 0x70, 0xb4, 0x0e, 0xe5, 0x91, 0x76, 0x44,
+	0x46, 0x52, 0x8d, 0x21, 0xd4, 0x28, 0x6b, 0xd2, 0x63, 0x49, 0xc0, 0x1c, 0x8c, 0x14, 0x58, 0xb0,
+	0x88, 0x6d, 0x9e, 0x5b, 0x43, 0x32, 0xb8, 0x98, 0x10, 0xa9, 0x4e, 0xd1, 0x70, 0x04, 0x46, 0xd9,
+	0xa7, 0x82, 0x76, 0xb5, 0x33, 0x22, 0x01, 0xdb, 0xf7, 0xd7, 0x61, 0xfa, 0x67, 0xc6, 0x58, 0x97,
+	0xe6, 0xa3, 0xf4, 0x14, 0x86, 0x36, 0x60, 0xfe, 0xd8, 0xd2, 0xc6, 0x0e, 0xe1, 0x57, 0x2c, 0x30,
+	0x94, 0x30, 0x08, 0x3d, 0x85, 0x0a, 0x63, 0xc7, 0x96, 0x16, 0x37, 0x8a, 0x9b, 0xf3, 0xdb, 0x0f,
+	0xc2, 0x2a, 0xcf, 0xb0, 0x72, 0x63, 0x97, 0x51, 0xa8, 0x63, 0xc7, 0xba, 0xc0, 0x2e, 0x39, 0x5a,
+	0x81, 0x0a, 0xbf, 0x58, 0x5a, 0x62, 0xb7, 0xb8, 0x2b, 0xf9, 0xfb, 0x30, 0x1f, 0x42, 0x47, 0x22,
+	0x14, 0x4f, 0xc9, 0x05, 0xf7, 0x0e, 0x4c, 0x3f, 0xd1, 0x32, 0x94, 0x5f, 0x52, 0xfd, 0x72, 0x8f,
+	0xc0, 0x7c, 0xf1, 0xa8, 0xf0, 0x8e, 0xa0, 0xfc, 0x4a, 0x80, 0xd5, 0x36, 0x19, 0x91, 0xff, 0x86,
+	0xa3, 0x21, 0x28, 0x11, 0x47, 0x3b, 0x66, 0x8e, 0x56, 0xc7, 0xec, 0x3b, 0x61, 0x91, 0x52, 0xd2,
+	0x22, 0xca, 0x9f, 0xcb, 0x20, 0x06, 0xdc, 0xf4, 0x8e, 0x7e, 0x42, 0x86, 0x0e, 0x5a, 0x82, 0x82,
+	0xa1, 0xbb, 0x32, 0x15, 0x0c, 0x9d, 0xea, 0xc2, 0x65, 0x8e, 0xcb, 0xe4, 0x31, 0xb5, 0xe2, 0x33,
+	0xc5, 0xaf, 0xf5, 0x98, 0xb9, 0xeb, 0x7a, 0x7d, 0x29, 0xdd, 0xeb, 0x5d, 0x6f, 0x97, 0x02, 0x6f,
+	0x2f, 0x33, 0x72, 0x6f, 0x89, 0xd6, 0x13, 0x9e, 0x5c, 0x8b, 0x78, 0xab, 0x14, 0xf3, 0xd6, 0xc0,
+	0x49, 0x97, 0x23, 0x4e, 0xea, 0xb9, 0xe6, 0x0e, 0x2c, 0x8d, 0x34, 0xdb, 0x69, 0x0e, 0x87, 0xc4,
+	0xb6, 0x89, 0xde, 0x74, 0xa4, 0x7a, 0x46, 0x74, 0x0c, 0xbc, 0xa4, 0x82, 0x63, 0x14, 0xbe, 0x82,
+	0x61, 0x8a, 0x82, 0xe7, 0x53, 0x5c, 0x5e, 0x81, 0x05, 0xca, 0xb4, 0x31, 0x3e, 0x6e, 0x9d, 0x68,
+	0xc6, 0x58, 0x5a, 0xd8, 0x28, 0x52, 0x9c, 0x30, 0x2c, 0xe1, 0xfa, 0x8b, 0x29, 0xae, 0xff, 0x08,
+	0x16, 0x86, 0xda, 0x44, 0x3b, 0x32, 0x46, 0x86, 0x63, 0x10, 0x5b, 0x5a, 0xda, 0x28, 0x6e, 0x2e,
+	0x6d, 0xaf, 0x44, 0xdc, 0xdb, 0xdb, 0xbf, 0xc0, 0x11, 0xdc, 0x78, 0xd8, 0x5c, 0x4b, 0x86, 0xcd,
+	0x63, 0x3f, 0x6c, 0x44, 0x16, 0x36, 0x9b, 0xe1, 0x73, 0xe3, 0xfe, 0x31, 0x23, 0x5e, 0xae, 0x87,
+	0xe3, 0x05, 0xdd, 0x85, 0x45, 0x63, 0x7c, 0x42, 0x2c, 0xc3, 0x21, 0xfa, 0x13, 0xcb, 0x3c, 0x93,
+	0x10, 0xdb, 0x8e, 0x02, 0xff, 0x93, 0xa8, 0x7a, 0x03, 0xcb, 0x4f, 0x89, 0x73, 0xf5, 0x11, 0x15,
+	0x37, 0x6e, 0x31, 0x25, 0x7a, 0x7e, 0x51, 0x84, 0x9b, 0x4f, 0x89, 0xf3, 0xc4, 0x18, 0x85, 0x42,
+	0xda, 0xce, 0xcb, 0xc1, 0x36, 0x94, 0x4d, 0x4b, 0x27, 0x16, 0x63, 0x60, 0x69, 0x7b, 0x2d, 0x5d,
+	0xe7, 0x76, 0x8f, 0xe2, 0x60, 0x8e, 0x9a, 0x87, 0x2b, 0x9a, 0x65, 0x27, 0xda, 0x31, 0xe9, 0x1b,
+	0x6f, 0x78, 0x08, 0x96, 0xb1, 0xbf, 0x46, 0x6b, 0x50, 0xa7, 0xdf, 0x03, 0xf3, 0x94, 0x8c, 0xdd,
+	0xb0, 0x0b, 0x00, 0xe8, 0x03, 0x58, 0x64, 0xe6, 0xec, 0x93, 0x11, 0x19, 0xd2, 0xc0, 0xac, 0x30,
+	0x6f, 0x78, 0x27, 0xcc, 0x59, 0xa6, 0xbc, 0x8d, 0xdd, 0x30, 0x29, 0xf7, 0x8e, 0xe8, 0x71, 0x21,
+	0x27, 0xa9, 0x46, 0x92, 0xea, 0x63, 0x40, 0x49, 0xe2, 0x4b, 0x79, 0xc1, 0x9f, 0x4a, 0x20, 0xa7,
+	0x71, 0x66, 0x4f, 0xcc, 0xb1, 0x4d, 0xd0, 0x8f, 0x61, 0x3e, 0x10, 0xc1, 0x96, 0x84, 0x64, 0x6d,
+	0xc8, 0x26, 0x6e, 0x1c, 0xd8, 0xc4, 0x62, 0x79, 0x2b, 0x7c, 0x06, 0x75, 0xec, 0x31, 0x79, 0xed,
+	0xec, 0xfb, 0xda, 0xe4, 0x3c, 0x45, 0x81, 0xf2, 0xef, 0x8a, 0x50, 0xf3, 0xe8, 0x43, 0xf9, 0x52,
+	0x48, 0xcd, 0x97, 0x85, 0xbc, 0xf9, 0xb2, 0x38, 0x2d, 0x5f, 0x96, 0xa6, 0xe5, 0xcb, 0x72, 0x46,
+	0xbe, 0xac, 0x4c, 0xcf, 0x97, 0xd5, 0x4b, 0xe7, 0xcb, 0xbe, 0x9f, 0x51, 0x6a, 0x4c, 0xd9, 0x3f,
+	0xb8, 0xa4, 0xb2, 0x67, 0x24, 0x99, 0xfa, 0x55, 0x15, 0xe5, 0x7f, 0x0a, 0x80, 0x3a, 0x36, 0xe3,
+	0xc4, 0x71, 0x88, 0x7e, 0x55, 0xd9, 0xe3, 0xee, 0xf4, 0xc6, 0xcf, 0x35, 0x69, 0x8e, 0x0a, 0x1d,
+	0xe9, 0x99, 0xca, 0xb1, 0x9e, 0xe9, 0x21, 0x80, 0x9f, 0xe8, 0x2f, 0x98, 0x0d, 0xb3, 0x4b, 0x42,
+	0x08, 0x53, 0xf9, 0x18, 0xbe, 0x16, 0x91, 0xd9, 0x8d, 0x12, 0x9a, 0x1c, 0x3c, 0x20, 0x93, 0xbb,
+	0x86, 0x03, 0x00, 0x7a, 0x1b, 0x2a, 0x67, 0xda, 0xeb, 0xe6, 0x31, 0x57, 0xe2, 0xfc, 0xf6, 0xcd,
+	0x84, 0x37, 0xb4, 0xdd, 0x96, 0x1d, 0xbb, 0x88, 0x54, 0xed, 0xb6, 0xa3, 0xb9, 0x6a, 0xa8, 0x61,
+	0xbe, 0x50, 0x0e, 0xe1, 0x56, 0xeb, 0x84, 0x0c, 0x4f, 0x43, 0xe6, 0xdf, 0xd3, 0x1c, 0xcb, 0x78,
+	0xed, 0x29, 0xff, 0x21, 0x54, 0x86, 0x14, 0xc1, 0x0b, 0xd4, 0xf5, 0xb0, 0x48, 0x49, 0x63, 0x61,
+	0x17, 0x5b, 0xf9, 0x65, 0x01, 0xd6, 0xb3, 0x4e, 0x76, 0x45, 0x7c, 0x06, 0x55, 0x8b, 0xd8, 0xe7,
+	0x23, 0xc7, 0x3b, 0xfb, 0xed, 0x88, 0xba, 0xa6, 0x12, 0x37, 0x30, 0xa3, 0xc4, 0xde, 0x09, 0xf2,
+	0x6f, 0x05, 0xa8, 0x70, 0x18, 0x6d, 0x0f, 0x86, 0xa6, 0x4e, 0x98, 0xd6, 0xca, 0x98, 0x7d, 0x87,
+	0xc3, 0xae, 0x10, 0x0d, 0xbb, 0x88, 0xa2, 0x8b, 0xd9, 0x8a, 0x2e, 0x5d, 0x5a, 0xd1, 0xe5, 0xb0,
+	0xa2, 0xdd, 0xf2, 0x44, 0x43, 0x2a, 0xbd, 0x3c, 0x85, 0xb3, 0x51, 0xc2, 0x85, 0xff, 0x6f, 0xcb,
+	0x53, 0xba, 0xbc, 0x5f, 0x6a, 0x79, 0xfa, 0x1b, 0x2f, 0x4f, 0x09, 0xce, 0x2e, 0x53, 0x9e, 0x32,
+	0x88, 0x1b, 0x34, 0x93, 0x7e, 0xd1, 0xf2, 0xf4, 0x97, 0x22, 0xd4, 0x3c, 0xfa, 0x50, 0x9b, 0x2f,
+	0x44, 0xda, 0xfc, 0xaf, 0x62, 0x79, 0x8a, 0x3b, 0x6a, 0x2d, 0xc5, 0x51, 0x83, 0x12, 0x56, 0x4f,
+	0x2d, 0x61, 0xb3, 0x0c, 0x32, 0xa3, 0x84, 0xc1, 0x55, 0x95, 0xb0, 0x4f, 0x0b, 0xb0, 0xc6, 0xe7,
+	0xca, 0x2f, 0xd8, 0x88, 0xc6, 0x95, 0x51, 0x48, 0x51, 0x86, 0x16, 0x8f, 0xbd, 0x62, 0x52, 0x27,
+	0xd3, 0x98, 0xb8, 0x54, 0xf8, 0x95, 0xae, 0x38, 0xfc, 0x5e, 0xc0, 0xad, 0x0c, 0xde, 0xdc, 0x00,
+	0xfc, 0x51, 0x5a, 0x00, 0xae, 0x4d, 0x1b, 0x82, 0x22, 0xd1, 0xa6, 0xfc, 0x51, 0x80, 0x95, 0x96,
+	0x39, 0xb9, 0x48, 0x51, 0xfe, 0x16, 0x2c, 0x70, 0x39, 0x9e, 0xa4, 0x99, 0x20, 0xb2, 0x87, 0xee,
+	0x01, 0xe8, 0xc4, 0x76, 0x9e, 0x84, 0x86, 0x6d, 0x1f, 0x33, 0xb4, 0x43, 0xd3, 0x24, 0x7d, 0xf6,
+	0x79, 0x65, 0x19, 0x8e, 0x57, 0x5b, 0x03, 0x40, 0xae, 0xb9, 0xff, 0x19, 0xac, 0x26, 0xf8, 0x75,
+	0x75, 0xb1, 0x02, 0x95, 0xa1, 0x39, 0x31, 0xdc, 0x16, 0xa0, 0x88, 0xdd, 0x15, 0x0d, 0x53, 0xfb,
+	0xd4, 0x98, 0x4c, 0x88, 0xce, 0x38, 0x2b, 0x62, 0x6f, 0xa9, 0x7c, 0x0c, 0x2b, 0x03, 0xf3, 0x7c,
+	0x78, 0xf2, 0xe5, 0x0c, 0x61, 0x6f, 0x60, 0x19, 0x93, 0x97, 0xe6, 0x29, 0x69, 0x69, 0xf6, 0x50,
+	0xd3, 0xc9, 0xff, 0xf2, 0xee, 0x43, 0xb8, 0x11, 0xbb, 0xfb, 0x8a, 0x1c, 0xea, 0x37, 0x02, 0xdc,
+	0x78, 0x4a, 0x9c, 0x3e, 0x4d, 0x90, 0x3a, 0xb5, 0xba, 0xef, 0x4f, 0x6b, 0x50, 0xa6, 0x0c, 0x36,
+	0x63, 0x52, 0x71, 0xa0, 0xb7, 0xbb, 0x13, 0x93, 0x89, 0x03, 0x69, 0x26, 0xe6, 0x53, 0xbf, 0xbe,
+	0x73, 0xd1, 0x74, 0x1d, 0x27, 0x04, 0xc9, 0xe5, 0x39, 0x7f, 0x17, 0x60, 0x25, 0xce, 0x99, 0x2b,
+	0x74, 0x0b, 0xca, 0x54, 0xb7, 0x9e, 0xb8, 0xdf, 0x89, 0xe5, 0xcb, 0x14, 0x92, 0x46, 0x00, 0xc3,
+	0x9c, 0x56, 0xfe, 0xb9, 0x00, 0x10, 0x40, 0x33, 0x8b, 0x52, 0x03, 0xea, 0x4c, 0x62, 0x3c, 0xad,
+	0x32, 0x05, 0x28, 0x1e, 0xfe, 0x0e, 0x9e, 0xd6, 0x95, 0x07, 0x28, 0xca, 0xa7, 0x02, 0xac, 0xee,
+	0x1a, 0xb6, 0xcb, 0xf4, 0xa1, 0xe1, 0x9c, 0xec, 0x91, 0xbc, 0x9d, 0x53, 0x9e, 0x7c, 0xaa, 0x84,
+	0xba, 0x20, 0xca, 0x4e, 0x99, 0x9f, 0xf2, 0xdd, 0xb9, 0xac, 0x6e, 0xa8, 0x14, 0xeb, 0x86, 0x94,
+	0x3f, 0x14, 0x40, 0x4a, 0x72, 0xe8, 0x9a, 0x42, 0x8d, 0x9a, 0x22, 0xd2, 0x4b, 0x64, 0x11, 0x25,
+	0x8d, 0x91, 0x77, 0xc8, 0xcd, 0x67, 0xb2, 0x7c, 0x7d, 0x84, 0x0c, 0x35, 0xd6, 0x16, 0xe8, 0x3b,
+	0x17, 0x6e, 0xc8, 0xf9, 0x6b, 0xf4, 0xd0, 0xdb, 0x6b, 0x3a, 0x52, 0x69, 0x66, 0xcd, 0xf7, 0x71,
+	0x95, 0xdf, 0x0b, 0xb0, 0x46, 0xa5, 0x0e, 0x62, 0xae, 0x75, 0xa2, 0x8d, 0x8f, 0x49, 0xee, 0x5e,
+	0x78, 0x0d, 0xea, 0xf6, 0xc5, 0x78, 0x18, 0xd6, 0x41, 0x00, 0xc8, 0x65, 0xcb, 0x3c, 0xa1, 0xf5,
+	0xaf, 0x02, 0xdc, 0xca, 0x60, 0xd3, 0x35, 0xeb, 0x00, 0xaa, 0x43, 0x0e, 0x72, 0x0d, 0xfb, 0x28,
+	0x6e, 0xd8, 0x4c, 0xda, 0x46, 0x7c, 0x07, 0x7b, 0x47, 0xcd, 0x90, 0x4e, 0x82, 0xea, 0x89, 0x66,
+	0xef, 0x99, 0x96, 0x57, 0x6a, 0xbc, 0xa5, 0xfc, 0x57, 0x01, 0xc4, 0xf8, 0xa9, 0x89, 0xc7, 0xe3,
+	0x2d, 0x28, 0x39, 0x5e, 0x10, 0xc4, 0xa7, 0x53, 0x46, 0x41, 0x45, 0xc7, 0x0c, 0x07, 0xfd, 0x10,
+	0x42, 0xbf, 0x84, 0xd8, 0x6d, 0xb3, 0x92, 0x66, 0x08, 0x9f, 0xfe, 0x00, 0x31, 0x87, 0xc3, 0x73,
+	0x2b, 0xaf, 0x7f, 0x84, 0xb0, 0x95, 0x4f, 0x04, 0x58, 0x79, 0x57, 0x1b, 0xeb, 0x23, 0x56, 0x8a,
+	0xf7, 0xcc, 0x97, 0xc1, 0x53, 0x40, 0x96, 0x3b, 0xd3, 0x22, 0x3c, 0xd2, 0xf7, 0x35, 0x8b, 0x8c,
+	0x1d, 0x4f, 0x6b, 0x3e, 0x80, 0xee, 0x8e, 0xc9, 0x2b, 0x77, 0x97, 0xfb, 0x71, 0x00, 0xc8, 0xe5,
+	0x0d, 0x7b, 0xb0, 0x9a, 0xe0, 0xc8, 0x75, 0x03, 0xaf, 0xd7, 0xf6, 0x6b, 0xb4, 0xb7, 0xa4, 0x3b,
+	0x3a, 0xeb, 0x74, 0xfc, 0x22, 0xed, 0x2e, 0x95, 0x9f, 0xc2, 0xd7, 0x79, 0xa9, 0x6a, 0x8e, 0x46,
+	0xea, 0xeb, 0x21, 0x99, 0x38, 0xbd, 0x57, 0x63, 0x62, 0x5d, 0x65, 0x8f, 0x28, 0x41, 0xd5, 0x7c,
+	0x35, 0x0e, 0xfd, 0x10, 0xf0, 0x96, 0xca, 0x07, 0xb0, 0x96, 0x7e, 0xf9, 0x15, 0x95, 0xcb, 0x6f,
+	0xc1, 0x6a, 0x80, 0x60, 0xab, 0xaf, 0x0d, 0xdb, 0xf1, 0x04, 0x13, 0xa1, 0x68, 0xe8, 0xfc, 0xc8,
+	0x3a, 0xa6, 0x9f, 0xca, 0x36, 0x48, 0x49, 0xe4, 0xa0, 0xf9, 0x21, 0x14, 0xc0, 0x09, 0x6a, 0xd8,
+	0x5d, 0x6d, 0xf5, 0xa0, 0xc4, 0xca, 0x48, 0x0d, 0x4a, 0xdd, 0x5e, 0x57, 0x15, 0xe7, 0x50, 0x1d,
+	0xca, 0x87, 0xb8, 0x33, 0x50, 0x45, 0x81, 0x02, 0xb1, 0xda, 0x6c, 0x8b, 0x05, 0xb4, 0x08, 0xf5,
+	0x56, 0x6f, 0x6f, 0x4f, 0xed, 0x0e, 0x54, 0x2c, 0x16, 0xd1, 0x02, 0xd4, 0x0e, 0xf6, 0x77, 0x7b,
+	0xcd, 0xb6, 0x8a, 0xc5, 0x12, 0x9a, 0x87, 0x6a, 0xf3, 0xa0, 0xdd, 0x19, 0xf4, 0xb0, 0x58, 0xde,
+	0x7a, 0x08, 0x62, 0x7c, 0x88, 0xa6, 0x08, 0x6d, 0xf5, 0x49, 0xf3, 0x60, 0x77, 0x20, 0xce, 0xa1,
+	0x1b, 0x70, 0x1d, 0xab, 0x2d, 0xb5, 0x3b, 0xd8, 0x7d, 0xef, 0x45, 0xb3, 0xd5, 0x52, 0xfb, 0x7d,
+	0xb5, 0x2d, 0x0a, 0x5b, 0x16, 0x40, 0xf0, 0xa8, 0x83, 0xae, 0xc3, 0x62, 0xb7, 0xf7, 0xa2, 0xd5,
+	0xdc, 0x6f, 0xee, 0x74, 0x76, 0x3b, 0x83, 0xf7, 0xc4, 0x39, 0xca, 0xcc, 0xf3, 0x8e, 0x7a, 0xc8,
+	0xd9, 0x52, 0xdb, 0x9d, 0x81, 0x58, 0xa0, 0x5f, 0xbb, 0x9d, 0xfe, 0x40, 0x2c, 0x22, 0x11, 0x16,
+	0x5a, 0x58, 0x6d, 0x0e, 0xd4, 0x17, 0xad, 0x77, 0x3b, 0xbb, 0x6d, 0xce, 0x95, 0xcb, 0xb2, 0x58,
+	0x46, 0xcb, 0x20, 0x52, 0xe2, 0x17, 0xfb, 0x2a, 0xde, 0xeb, 0xf4, 0xfb, 0x9d, 0x5e, 0xb7, 0x2f,
+	0x56, 0xb6, 0x1e, 0x03, 0x04, 0xa1, 0x4a, 0x09, 0x0e, 0xba, 0xcf, 0xba, 0xbd, 0xc3, 0xae, 0x38,
+	0xc7, 0xa8, 0xd9, 0x79, 0x6d, 0x51, 0x60, 0x3b, 0xfb, 0x6d, 0xb6, 0x28, 0x70, 0x61, 0x76, 0x55,
+	0xba, 0x28, 0x6e, 0xff, 0x7a, 0x11, 0x20, 0x10, 0x17, 0x1d, 0x82, 0x18, 0xff, 0x17, 0x87, 0xee,
+	0xe4, 0xf8, 0x53, 0x27, 0x4f, 0x75, 0x09, 0x65, 0x8e, 0x1e, 0x1c, 0xff, 0xc3, 0x16, 0x3d, 0x38,
+	0xe3, 0xff, 0xdb, 0xcc, 0x83, 0x4f, 0x00, 0x25, 0x1f, 0x2d, 0xd1, 0x37, 0x72, 0x3d, 0x8c, 0xcb,
+	0xf7, 0xf2, 0xbd, 0x7d, 0x2a, 0xc5, 0x4f, 0x0a, 0x82, 0x7b, 0x53, 0x6c, 0xb6, 0x4c, 0xdc, 0x94,
+	0xfe, 0xc6, 0x21, 0xdf, 0x9b, 0x85, 0x16, 0xbe, 0xa9, 0x0f, 0xf3, 0xa1, 0xc7, 0x34, 0x34, 0xe3,
+	0x95, 0x4d, 0x7e, 0x2b, 0x73, 0x3f, 0x7c, 0xa8, 0x03, 0x2b, 0xe9, 0xaf, 0x68, 0xe8, 0x7e, 0x9e,
+	0x97, 0x36, 0x7e, 0xd5, 0x56, 0xfe, 0x47, 0x39, 0x7e, 0xeb, 0x18, 0x6e, 0xa4, 0x0e, 0x78, 0x68,
+	0x33, 0xef, 0x7c, 0x2a, 0xdf, 0xcf, 0x81, 0xe9, 0x5e, 0x39, 0x87, 0xde, 0x87, 0x6b, 0xb1, 0xf1,
+	0x09, 0x29, 0x11, 0x9e, 0x53, 0x67, 0x41, 0xf9, 0xce, 0x54, 0x1c, 0xff, 0xf4, 0x01, 0x2c, 0x46,
+	0x7e, 0x69, 0xa1, 0x8d, 0x98, 0x59, 0x2f, 0xeb, 0xbf, 0x4c, 0x47, 0x07, 0x70, 0x2d, 0x36, 0xa5,
+	0x45, 0x79, 0x4e, 0x1f, 0xe1, 0x66, 0x46, 0xc6, 0x73, 0x58, 0x8c, 0x8c, 0x40, 0x51, 0x66, 0xd3,
+	0x26, 0x33, 0xf9, 0xf6, 0x14, 0x8c, 0x90, 0x8a, 0x97, 0xa2, 0x33, 0x03, 0xba, 0x3d, 0x6d, 0x9e,
+	0xe0, 0x27, 0x2b, 0xb3, 0x47, 0x0e, 0xae, 0x8c, 0x8f, 0xe0, 0x46, 0x6a, 0xb7, 0x14, 0x75, 0x98,
+	0x69, 0x3d, 0xa3, 0x7c, 0x3f, 0x07, 0x66, 0xf8, 0xca, 0xf7, 0xe1, 0x5a, 0xac, 0x9e, 0x47, 0xf5,
+	0x9f, 0xde, 0x7e, 0xc8, 0x77, 0xa6, 0xe2, 0xf8, 0xea, 0x3a, 0x02, 0x31, 0xde, 0xd7, 0x47, 0x33,
+	0x5f, 0xc6, 0x30, 0x23, 0xdf, 0xcd, 0x33, 0x1a, 0x70, 0x09, 0x4e, 0x61, 0x39, 0xad, 0x8a, 0xa3,
+	0x6f, 0x26, 0xed, 0x99, 0xda, 0x64, 0xc8, 0x9b, 0xb3, 0x11, 0xc3, 0x02, 0xc5, 0xab, 0x74, 0x54,
+	0xa0, 0x8c, 0x82, 0x2f, 0xdf, 0x9d, 0x8e, 0x14, 0x12, 0xe8, 0xa8, 0xc2, 0xba, 0xc2, 0xef, 0xfd,
+	0x7b, 0x00, 0x40, 0xa4, 0xdc, 0xbb, 0x9b, 0x24, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -833,10 +2931,40 @@ type PermissionClient interface {
 	GetUserPermissions(ctx context.Context, in *GetUserPermissionsRequest, opts ...grpc.CallOption) (*GetUserPermissionsResponse, error)
 	// IsPermitted returns true if userID is permitted to a fileID with the wanted role.
 	IsPermitted(ctx context.Context, in *IsPermittedRequest, opts ...grpc.CallOption) (*IsPermittedResponse, error)
+	// CheckPermissionsMatrix makes a batch of IsPermitted checks in a single call,
+	// and returns the result of each of them, such as of the files of a folder that's listed.
+	CheckPermissionsMatrix(ctx context.Context, in *CheckPermissionsMatrixRequest, opts ...grpc.CallOption) (*CheckPermissionsMatrixResponse, error)
 	// DeleteFilePermissions deletes all permissions of a file and returns them.
 	DeleteFilePermissions(ctx context.Context, in *DeleteFilePermissionsRequest, opts ...grpc.CallOption) (*DeleteFilePermissionsResponse, error)
+	// CopyPermissions copies the permissions that were given directly to a file to another file,
+	// such as a copy of it, in a single transaction.
+	CopyPermissions(ctx context.Context, in *CopyPermissionsRequest, opts ...grpc.CallOption) (*CopyPermissionsResponse, error)
 	// GetPermission returns a permission of the user to a file.
 	GetPermission(ctx context.Context, in *GetPermissionRequest, opts ...grpc.CallOption) (*PermissionObject, error)
+	// TouchPermission updates the last time the user accessed the file with the permission and returns it.
+	TouchPermission(ctx context.Context, in *TouchPermissionRequest, opts ...grpc.CallOption) (*PermissionObject, error)
+	// RevokeCascade deletes the permission of the user to a file, and every permission to the file
+	// that the user reshared, directly or through the users it was reshared to, and returns them.
+	RevokeCascade(ctx context.Context, in *RevokeCascadeRequest, opts ...grpc.CallOption) (*RevokeCascadeResponse, error)
+	// GetSharedFiles returns the files that both users have a permission to.
+	GetSharedFiles(ctx context.Context, in *GetSharedFilesRequest, opts ...grpc.CallOption) (*GetSharedFilesResponse, error)
+	// ListPermissionChanges returns the changes to the permissions of a user since a sync token,
+	// so that clients can keep a local copy of the permissions up to date without downloading them again.
+	ListPermissionChanges(ctx context.Context, in *ListPermissionChangesRequest, opts ...grpc.CallOption) (*ListPermissionChangesResponse, error)
+	// HandleFileMoved recomputes the inherited permissions of a file, and of its descendants if it's a folder,
+	// after it was moved from one folder to another. The permissions they inherited from the old folder are
+	// deleted, and the permissions of the new folder are inherited, in a single transaction.
+	HandleFileMoved(ctx context.Context, in *HandleFileMovedRequest, opts ...grpc.CallOption) (*HandleFileMovedResponse, error)
+	// ListSharedWithMe returns the files that other users shared with a user, most recently shared first,
+	// from a projection of the shares of each user that's maintained as permissions change.
+	ListSharedWithMe(ctx context.Context, in *ListSharedWithMeRequest, opts ...grpc.CallOption) (*ListSharedWithMeResponse, error)
+	// RevokeAllExceptOwner deletes every permission to a file other than its owner's, in a single transaction,
+	// and returns them, such as for stopping sharing the file. A single summary event of the revocation is written,
+	// rather than an event of each permission.
+	RevokeAllExceptOwner(ctx context.Context, in *RevokeAllExceptOwnerRequest, opts ...grpc.CallOption) (*RevokeAllExceptOwnerResponse, error)
+	// PermissionsExist returns whether each of a batch of permissions exists by its ID, such as for validating
+	// the permissions that a trashed file references before restoring them, without fetching them.
+	PermissionsExist(ctx context.Context, in *PermissionsExistRequest, opts ...grpc.CallOption) (*PermissionsExistResponse, error)
 }
 
 type permissionClient struct {
@@ -892,6 +3020,15 @@ func (c *permissionClient) IsPermitted(ctx context.Context, in *IsPermittedReque
 	return out, nil
 }
 
+func (c *permissionClient) CheckPermissionsMatrix(ctx context.Context, in *CheckPermissionsMatrixRequest, opts ...grpc.CallOption) (*CheckPermissionsMatrixResponse, error) {
+	out := new(CheckPermissionsMatrixResponse)
+	err := c.cc.Invoke(ctx, "/permission.Permission/CheckPermissionsMatrix", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *permissionClient) DeleteFilePermissions(ctx context.Context, in *DeleteFilePermissionsRequest, opts ...grpc.CallOption) (*DeleteFilePermissionsResponse, error) {
 	out := new(DeleteFilePermissionsResponse)
 	err := c.cc.Invoke(ctx, "/permission.Permission/DeleteFilePermissions", in, out, opts...)
@@ -901,6 +3038,15 @@ func (c *permissionClient) DeleteFilePermissions(ctx context.Context, in *Delete
 	return out, nil
 }
 
+func (c *permissionClient) CopyPermissions(ctx context.Context, in *CopyPermissionsRequest, opts ...grpc.CallOption) (*CopyPermissionsResponse, error) {
+	out := new(CopyPermissionsResponse)
+	err := c.cc.Invoke(ctx, "/permission.Permission/CopyPermissions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *permissionClient) GetPermission(ctx context.Context, in *GetPermissionRequest, opts ...grpc.CallOption) (*PermissionObject, error) {
 	out := new(PermissionObject)
 	err := c.cc.Invoke(ctx, "/permission.Permission/GetPermission", in, out, opts...)
@@ -910,6 +3056,78 @@ func (c *permissionClient) GetPermission(ctx context.Context, in *GetPermissionR
 	return out, nil
 }
 
+func (c *permissionClient) TouchPermission(ctx context.Context, in *TouchPermissionRequest, opts ...grpc.CallOption) (*PermissionObject, error) {
+	out := new(PermissionObject)
+	err := c.cc.Invoke(ctx, "/permission.Permission/TouchPermission", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionClient) RevokeCascade(ctx context.Context, in *RevokeCascadeRequest, opts ...grpc.CallOption) (*RevokeCascadeResponse, error) {
+	out := new(RevokeCascadeResponse)
+	err := c.cc.Invoke(ctx, "/permission.Permission/RevokeCascade", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionClient) GetSharedFiles(ctx context.Context, in *GetSharedFilesRequest, opts ...grpc.CallOption) (*GetSharedFilesResponse, error) {
+	out := new(GetSharedFilesResponse)
+	err := c.cc.Invoke(ctx, "/permission.Permission/GetSharedFiles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionClient) ListPermissionChanges(ctx context.Context, in *ListPermissionChangesRequest, opts ...grpc.CallOption) (*ListPermissionChangesResponse, error) {
+	out := new(ListPermissionChangesResponse)
+	err := c.cc.Invoke(ctx, "/permission.Permission/ListPermissionChanges", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionClient) HandleFileMoved(ctx context.Context, in *HandleFileMovedRequest, opts ...grpc.CallOption) (*HandleFileMovedResponse, error) {
+	out := new(HandleFileMovedResponse)
+	err := c.cc.Invoke(ctx, "/permission.Permission/HandleFileMoved", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionClient) ListSharedWithMe(ctx context.Context, in *ListSharedWithMeRequest, opts ...grpc.CallOption) (*ListSharedWithMeResponse, error) {
+	out := new(ListSharedWithMeResponse)
+	err := c.cc.Invoke(ctx, "/permission.Permission/ListSharedWithMe", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionClient) RevokeAllExceptOwner(ctx context.Context, in *RevokeAllExceptOwnerRequest, opts ...grpc.CallOption) (*RevokeAllExceptOwnerResponse, error) {
+	out := new(RevokeAllExceptOwnerResponse)
+	err := c.cc.Invoke(ctx, "/permission.Permission/RevokeAllExceptOwner", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionClient) PermissionsExist(ctx context.Context, in *PermissionsExistRequest, opts ...grpc.CallOption) (*PermissionsExistResponse, error) {
+	out := new(PermissionsExistResponse)
+	err := c.cc.Invoke(ctx, "/permission.Permission/PermissionsExist", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PermissionServer is the server API for Permission service.
 type PermissionServer interface {
 	// CreatePermission creates a new permission and returns it, if permission already exists, update it.
@@ -922,10 +3140,40 @@ type PermissionServer interface {
 	GetUserPermissions(context.Context, *GetUserPermissionsRequest) (*GetUserPermissionsResponse, error)
 	// IsPermitted returns true if userID is permitted to a fileID with the wanted role.
 	IsPermitted(context.Context, *IsPermittedRequest) (*IsPermittedResponse, error)
+	// CheckPermissionsMatrix makes a batch of IsPermitted checks in a single call,
+	// and returns the result of each of them, such as of the files of a folder that's listed.
+	CheckPermissionsMatrix(context.Context, *CheckPermissionsMatrixRequest) (*CheckPermissionsMatrixResponse, error)
 	// DeleteFilePermissions deletes all permissions of a file and returns them.
 	DeleteFilePermissions(context.Context, *DeleteFilePermissionsRequest) (*DeleteFilePermissionsResponse, error)
+	// CopyPermissions copies the permissions that were given directly to a file to another file,
+	// such as a copy of it, in a single transaction.
+	CopyPermissions(context.Context, *CopyPermissionsRequest) (*CopyPermissionsResponse, error)
 	// GetPermission returns a permission of the user to a file.
 	GetPermission(context.Context, *GetPermissionRequest) (*PermissionObject, error)
+	// TouchPermission updates the last time the user accessed the file with the permission and returns it.
+	TouchPermission(context.Context, *TouchPermissionRequest) (*PermissionObject, error)
+	// RevokeCascade deletes the permission of the user to a file, and every permission to the file
+	// that the user reshared, directly or through the users it was reshared to, and returns them.
+	RevokeCascade(context.Context, *RevokeCascadeRequest) (*RevokeCascadeResponse, error)
+	// GetSharedFiles returns the files that both users have a permission to.
+	GetSharedFiles(context.Context, *GetSharedFilesRequest) (*GetSharedFilesResponse, error)
+	// ListPermissionChanges returns the changes to the permissions of a user since a sync token,
+	// so that clients can keep a local copy of the permissions up to date without downloading them again.
+	ListPermissionChanges(context.Context, *ListPermissionChangesRequest) (*ListPermissionChangesResponse, error)
+	// HandleFileMoved recomputes the inherited permissions of a file, and of its descendants if it's a folder,
+	// after it was moved from one folder to another. The permissions they inherited from the old folder are
+	// deleted, and the permissions of the new folder are inherited, in a single transaction.
+	HandleFileMoved(context.Context, *HandleFileMovedRequest) (*HandleFileMovedResponse, error)
+	// ListSharedWithMe returns the files that other users shared with a user, most recently shared first,
+	// from a projection of the shares of each user that's maintained as permissions change.
+	ListSharedWithMe(context.Context, *ListSharedWithMeRequest) (*ListSharedWithMeResponse, error)
+	// RevokeAllExceptOwner deletes every permission to a file other than its owner's, in a single transaction,
+	// and returns them, such as for stopping sharing the file. A single summary event of the revocation is written,
+	// rather than an event of each permission.
+	RevokeAllExceptOwner(context.Context, *RevokeAllExceptOwnerRequest) (*RevokeAllExceptOwnerResponse, error)
+	// PermissionsExist returns whether each of a batch of permissions exists by its ID, such as for validating
+	// the permissions that a trashed file references before restoring them, without fetching them.
+	PermissionsExist(context.Context, *PermissionsExistRequest) (*PermissionsExistResponse, error)
 }
 
 // UnimplementedPermissionServer can be embedded to have forward compatible implementations.
@@ -947,12 +3195,42 @@ func (*UnimplementedPermissionServer) GetUserPermissions(ctx context.Context, re
 func (*UnimplementedPermissionServer) IsPermitted(ctx context.Context, req *IsPermittedRequest) (*IsPermittedResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IsPermitted not implemented")
 }
+func (*UnimplementedPermissionServer) CheckPermissionsMatrix(ctx context.Context, req *CheckPermissionsMatrixRequest) (*CheckPermissionsMatrixResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckPermissionsMatrix not implemented")
+}
 func (*UnimplementedPermissionServer) DeleteFilePermissions(ctx context.Context, req *DeleteFilePermissionsRequest) (*DeleteFilePermissionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteFilePermissions not implemented")
 }
+func (*UnimplementedPermissionServer) CopyPermissions(ctx context.Context, req *CopyPermissionsRequest) (*CopyPermissionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CopyPermissions not implemented")
+}
 func (*UnimplementedPermissionServer) GetPermission(ctx context.Context, req *GetPermissionRequest) (*PermissionObject, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPermission not implemented")
 }
+func (*UnimplementedPermissionServer) TouchPermission(ctx context.Context, req *TouchPermissionRequest) (*PermissionObject, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TouchPermission not implemented")
+}
+func (*UnimplementedPermissionServer) RevokeCascade(ctx context.Context, req *RevokeCascadeRequest) (*RevokeCascadeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeCascade not implemented")
+}
+func (*UnimplementedPermissionServer) GetSharedFiles(ctx context.Context, req *GetSharedFilesRequest) (*GetSharedFilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSharedFiles not implemented")
+}
+func (*UnimplementedPermissionServer) ListPermissionChanges(ctx context.Context, req *ListPermissionChangesRequest) (*ListPermissionChangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPermissionChanges not implemented")
+}
+func (*UnimplementedPermissionServer) HandleFileMoved(ctx context.Context, req *HandleFileMovedRequest) (*HandleFileMovedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HandleFileMoved not implemented")
+}
+func (*UnimplementedPermissionServer) ListSharedWithMe(ctx context.Context, req *ListSharedWithMeRequest) (*ListSharedWithMeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSharedWithMe not implemented")
+}
+func (*UnimplementedPermissionServer) RevokeAllExceptOwner(ctx context.Context, req *RevokeAllExceptOwnerRequest) (*RevokeAllExceptOwnerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAllExceptOwner not implemented")
+}
+func (*UnimplementedPermissionServer) PermissionsExist(ctx context.Context, req *PermissionsExistRequest) (*PermissionsExistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PermissionsExist not implemented")
+}
 
 func RegisterPermissionServer(s *grpc.Server, srv PermissionServer) {
 	s.RegisterService(&_Permission_serviceDesc, srv)
@@ -1048,6 +3326,24 @@ func _Permission_IsPermitted_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Permission_CheckPermissionsMatrix_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckPermissionsMatrixRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionServer).CheckPermissionsMatrix(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permission.Permission/CheckPermissionsMatrix",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionServer).CheckPermissionsMatrix(ctx, req.(*CheckPermissionsMatrixRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Permission_DeleteFilePermissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteFilePermissionsRequest)
 	if err := dec(in); err != nil {
@@ -1066,6 +3362,24 @@ func _Permission_DeleteFilePermissions_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Permission_CopyPermissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CopyPermissionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionServer).CopyPermissions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permission.Permission/CopyPermissions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionServer).CopyPermissions(ctx, req.(*CopyPermissionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Permission_GetPermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetPermissionRequest)
 	if err := dec(in); err != nil {
@@ -1084,6 +3398,150 @@ func _Permission_GetPermission_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Permission_TouchPermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TouchPermissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionServer).TouchPermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permission.Permission/TouchPermission",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionServer).TouchPermission(ctx, req.(*TouchPermissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Permission_RevokeCascade_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeCascadeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionServer).RevokeCascade(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permission.Permission/RevokeCascade",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionServer).RevokeCascade(ctx, req.(*RevokeCascadeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Permission_GetSharedFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSharedFilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionServer).GetSharedFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permission.Permission/GetSharedFiles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionServer).GetSharedFiles(ctx, req.(*GetSharedFilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Permission_ListPermissionChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPermissionChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionServer).ListPermissionChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permission.Permission/ListPermissionChanges",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionServer).ListPermissionChanges(ctx, req.(*ListPermissionChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Permission_HandleFileMoved_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandleFileMovedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionServer).HandleFileMoved(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permission.Permission/HandleFileMoved",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionServer).HandleFileMoved(ctx, req.(*HandleFileMovedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Permission_ListSharedWithMe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSharedWithMeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionServer).ListSharedWithMe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permission.Permission/ListSharedWithMe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionServer).ListSharedWithMe(ctx, req.(*ListSharedWithMeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Permission_RevokeAllExceptOwner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeAllExceptOwnerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionServer).RevokeAllExceptOwner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permission.Permission/RevokeAllExceptOwner",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionServer).RevokeAllExceptOwner(ctx, req.(*RevokeAllExceptOwnerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Permission_PermissionsExist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PermissionsExistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionServer).PermissionsExist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permission.Permission/PermissionsExist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionServer).PermissionsExist(ctx, req.(*PermissionsExistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Permission_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "permission.Permission",
 	HandlerType: (*PermissionServer)(nil),
@@ -1108,14 +3566,54 @@ var _Permission_serviceDesc = grpc.ServiceDesc{
 			MethodName: "IsPermitted",
 			Handler:    _Permission_IsPermitted_Handler,
 		},
+		{
+			MethodName: "CheckPermissionsMatrix",
+			Handler:    _Permission_CheckPermissionsMatrix_Handler,
+		},
 		{
 			MethodName: "DeleteFilePermissions",
 			Handler:    _Permission_DeleteFilePermissions_Handler,
 		},
+		{
+			MethodName: "CopyPermissions",
+			Handler:    _Permission_CopyPermissions_Handler,
+		},
 		{
 			MethodName: "GetPermission",
 			Handler:    _Permission_GetPermission_Handler,
 		},
+		{
+			MethodName: "TouchPermission",
+			Handler:    _Permission_TouchPermission_Handler,
+		},
+		{
+			MethodName: "RevokeCascade",
+			Handler:    _Permission_RevokeCascade_Handler,
+		},
+		{
+			MethodName: "GetSharedFiles",
+			Handler:    _Permission_GetSharedFiles_Handler,
+		},
+		{
+			MethodName: "ListPermissionChanges",
+			Handler:    _Permission_ListPermissionChanges_Handler,
+		},
+		{
+			MethodName: "HandleFileMoved",
+			Handler:    _Permission_HandleFileMoved_Handler,
+		},
+		{
+			MethodName: "ListSharedWithMe",
+			Handler:    _Permission_ListSharedWithMe_Handler,
+		},
+		{
+			MethodName: "RevokeAllExceptOwner",
+			Handler:    _Permission_RevokeAllExceptOwner_Handler,
+		},
+		{
+			MethodName: "PermissionsExist",
+			Handler:    _Permission_PermissionsExist_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "permission.proto",
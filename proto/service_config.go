@@ -0,0 +1,28 @@
+package permission
+
+// ServiceConfig is the grpc service config that clients of the permission service should use,
+// i.e with grpc.WithDefaultServiceConfig. It marks the read methods as safe to retry.
+// CreatePermission is safe to retry only when the request carries an idempotency key header,
+// so it is retried by clients that set one, and is not listed here.
+const ServiceConfig = `{
+	"methodConfig": [{
+		"name": [
+			{"service": "permission.Permission", "method": "GetFilePermissions"},
+			{"service": "permission.Permission", "method": "GetUserPermissions"},
+			{"service": "permission.Permission", "method": "IsPermitted"},
+			{"service": "permission.Permission", "method": "CheckPermissionsMatrix"},
+			{"service": "permission.Permission", "method": "GetPermission"},
+			{"service": "permissions.v2.Permissions", "method": "ListPermissions"},
+			{"service": "permissions.v2.Permissions", "method": "GetPermission"},
+			{"service": "permissions.v2.Permissions", "method": "ListRoles"}
+		],
+		"waitForReady": true,
+		"retryPolicy": {
+			"maxAttempts": 4,
+			"initialBackoff": "0.1s",
+			"maxBackoff": "1s",
+			"backoffMultiplier": 2,
+			"retryableStatusCodes": ["UNAVAILABLE"]
+		}
+	}]
+}`
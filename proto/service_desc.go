@@ -0,0 +1,9 @@
+package permission
+
+import "google.golang.org/grpc"
+
+// PermissionServiceDesc returns a copy of the grpc service description of the Permission service,
+// for registering it with grpc.Server.RegisterService with wrapped handlers.
+func PermissionServiceDesc() grpc.ServiceDesc {
+	return _Permission_serviceDesc
+}
@@ -0,0 +1,8847 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: permissions.proto
+
+package permissions
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "github.com/meateam/permission-service/proto/validate"
+	field_mask "google.golang.org/genproto/protobuf/field_mask"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type Role int32
+
+const (
+	Role_ROLE_UNSPECIFIED Role = 0
+	Role_WRITE            Role = 1
+	Role_READ             Role = 2
+	// View and comment on the resource.
+	Role_COMMENTER Role = 3
+	// Add content to the resource without viewing it, such as uploading files into a drop-box folder.
+	Role_UPLOADER Role = 4
+	// View the resource's permissions, without access to its content, such as for compliance reviews.
+	Role_AUDITOR Role = 5
+)
+
+var Role_name = map[int32]string{
+	0: "ROLE_UNSPECIFIED",
+	1: "WRITE",
+	2: "READ",
+	3: "COMMENTER",
+	4: "UPLOADER",
+	5: "AUDITOR",
+}
+
+var Role_value = map[string]int32{
+	"ROLE_UNSPECIFIED": 0,
+	"WRITE":            1,
+	"READ":             2,
+	"COMMENTER":        3,
+	"UPLOADER":         4,
+	"AUDITOR":          5,
+}
+
+func (x Role) String() string {
+	return proto.EnumName(Role_name, int32(x))
+}
+
+func (Role) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{0}
+}
+
+// The capabilities that a permission grants, which depend on its role and resource kind.
+// A READ permission grants VIEW, a COMMENTER permission grants VIEW and COMMENT,
+// and a WRITE permission grants VIEW, COMMENT and EDIT. Permissions to folders that grant VIEW
+// also grant LIST, and WRITE permissions to folders also grant CREATE_CHILD.
+// An UPLOADER permission grants EDIT to files and only CREATE_CHILD to folders.
+// An AUDITOR permission only grants VIEW_PERMISSIONS, which WRITE permissions also grant.
+type Capability int32
+
+const (
+	Capability_CAPABILITY_UNSPECIFIED Capability = 0
+	Capability_VIEW                   Capability = 1
+	Capability_EDIT                   Capability = 2
+	Capability_LIST                   Capability = 3
+	Capability_CREATE_CHILD           Capability = 4
+	Capability_COMMENT                Capability = 5
+	Capability_VIEW_PERMISSIONS       Capability = 6
+)
+
+var Capability_name = map[int32]string{
+	0: "CAPABILITY_UNSPECIFIED",
+	1: "VIEW",
+	2: "EDIT",
+	3: "LIST",
+	4: "CREATE_CHILD",
+	5: "COMMENT",
+	6: "VIEW_PERMISSIONS",
+}
+
+var Capability_value = map[string]int32{
+	"CAPABILITY_UNSPECIFIED": 0,
+	"VIEW":                   1,
+	"EDIT":                   2,
+	"LIST":                   3,
+	"CREATE_CHILD":           4,
+	"COMMENT":                5,
+	"VIEW_PERMISSIONS":       6,
+}
+
+func (x Capability) String() string {
+	return proto.EnumName(Capability_name, int32(x))
+}
+
+func (Capability) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{1}
+}
+
+// ConflictPolicy is what's done when an imported permission already exists.
+type ConflictPolicy int32
+
+const (
+	ConflictPolicy_CONFLICT_POLICY_UNSPECIFIED ConflictPolicy = 0
+	// The existing permission is kept.
+	ConflictPolicy_SKIP ConflictPolicy = 1
+	// The existing permission is replaced by the imported permission.
+	ConflictPolicy_REPLACE ConflictPolicy = 2
+	// The existing permission is replaced by the imported permission if the imported role grants
+	// the existing role, and is kept otherwise, so that the user's access is never lowered.
+	ConflictPolicy_MERGE_HIGHEST_ROLE ConflictPolicy = 3
+)
+
+var ConflictPolicy_name = map[int32]string{
+	0: "CONFLICT_POLICY_UNSPECIFIED",
+	1: "SKIP",
+	2: "REPLACE",
+	3: "MERGE_HIGHEST_ROLE",
+}
+
+var ConflictPolicy_value = map[string]int32{
+	"CONFLICT_POLICY_UNSPECIFIED": 0,
+	"SKIP":                        1,
+	"REPLACE":                     2,
+	"MERGE_HIGHEST_ROLE":          3,
+}
+
+func (x ConflictPolicy) String() string {
+	return proto.EnumName(ConflictPolicy_name, int32(x))
+}
+
+func (ConflictPolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{2}
+}
+
+type JobState int32
+
+const (
+	JobState_JOB_STATE_UNSPECIFIED JobState = 0
+	JobState_RUNNING               JobState = 1
+	JobState_SUCCEEDED             JobState = 2
+	JobState_FAILED                JobState = 3
+	JobState_CANCELLED             JobState = 4
+)
+
+var JobState_name = map[int32]string{
+	0: "JOB_STATE_UNSPECIFIED",
+	1: "RUNNING",
+	2: "SUCCEEDED",
+	3: "FAILED",
+	4: "CANCELLED",
+}
+
+var JobState_value = map[string]int32{
+	"JOB_STATE_UNSPECIFIED": 0,
+	"RUNNING":               1,
+	"SUCCEEDED":             2,
+	"FAILED":                3,
+	"CANCELLED":             4,
+}
+
+func (x JobState) String() string {
+	return proto.EnumName(JobState_name, int32(x))
+}
+
+func (JobState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{3}
+}
+
+// What was done with an imported permission.
+type ImportPermissionsProgress_Outcome int32
+
+const (
+	ImportPermissionsProgress_OUTCOME_UNSPECIFIED ImportPermissionsProgress_Outcome = 0
+	// The permission didn't exist and was created.
+	ImportPermissionsProgress_CREATED ImportPermissionsProgress_Outcome = 1
+	// The existing permission was replaced.
+	ImportPermissionsProgress_REPLACED ImportPermissionsProgress_Outcome = 2
+	// The existing permission was replaced by the imported permission, whose role is higher.
+	ImportPermissionsProgress_MERGED ImportPermissionsProgress_Outcome = 3
+	// The existing permission was kept.
+	ImportPermissionsProgress_SKIPPED ImportPermissionsProgress_Outcome = 4
+)
+
+var ImportPermissionsProgress_Outcome_name = map[int32]string{
+	0: "OUTCOME_UNSPECIFIED",
+	1: "CREATED",
+	2: "REPLACED",
+	3: "MERGED",
+	4: "SKIPPED",
+}
+
+var ImportPermissionsProgress_Outcome_value = map[string]int32{
+	"OUTCOME_UNSPECIFIED": 0,
+	"CREATED":             1,
+	"REPLACED":            2,
+	"MERGED":              3,
+	"SKIPPED":             4,
+}
+
+func (x ImportPermissionsProgress_Outcome) String() string {
+	return proto.EnumName(ImportPermissionsProgress_Outcome_name, int32(x))
+}
+
+func (ImportPermissionsProgress_Outcome) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{25, 0}
+}
+
+type AccessTraceStep_Effect int32
+
+const (
+	// The step was consulted without affecting the decision.
+	AccessTraceStep_EFFECT_UNSPECIFIED AccessTraceStep_Effect = 0
+	// The step allows the access.
+	AccessTraceStep_ALLOW AccessTraceStep_Effect = 1
+	// The step denies the access, and the evaluation stops at it.
+	AccessTraceStep_DENY AccessTraceStep_Effect = 2
+)
+
+var AccessTraceStep_Effect_name = map[int32]string{
+	0: "EFFECT_UNSPECIFIED",
+	1: "ALLOW",
+	2: "DENY",
+}
+
+var AccessTraceStep_Effect_value = map[string]int32{
+	"EFFECT_UNSPECIFIED": 0,
+	"ALLOW":              1,
+	"DENY":               2,
+}
+
+func (x AccessTraceStep_Effect) String() string {
+	return proto.EnumName(AccessTraceStep_Effect_name, int32(x))
+}
+
+func (AccessTraceStep_Effect) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{55, 0}
+}
+
+type RepairPermissionsRequest_Action int32
+
+const (
+	// The malformed documents are reported and aren't changed.
+	RepairPermissionsRequest_REPORT RepairPermissionsRequest_Action = 0
+	// The malformed documents that may be fixed are fixed, such as a role stored by its name,
+	// and the rest are reported. The duplicates of a permission are quarantined, keeping the
+	// one that was updated last.
+	RepairPermissionsRequest_FIX RepairPermissionsRequest_Action = 1
+	// The malformed documents that may be fixed are fixed, and the rest are quarantined.
+	RepairPermissionsRequest_QUARANTINE RepairPermissionsRequest_Action = 2
+)
+
+var RepairPermissionsRequest_Action_name = map[int32]string{
+	0: "REPORT",
+	1: "FIX",
+	2: "QUARANTINE",
+}
+
+var RepairPermissionsRequest_Action_value = map[string]int32{
+	"REPORT":     0,
+	"FIX":        1,
+	"QUARANTINE": 2,
+}
+
+func (x RepairPermissionsRequest_Action) String() string {
+	return proto.EnumName(RepairPermissionsRequest_Action_name, int32(x))
+}
+
+func (RepairPermissionsRequest_Action) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{57, 0}
+}
+
+type MalformedPermission_Problem int32
+
+const (
+	MalformedPermission_PROBLEM_UNSPECIFIED MalformedPermission_Problem = 0
+	// A required field, such as user_id, is missing or empty.
+	MalformedPermission_MISSING_FIELD MalformedPermission_Problem = 1
+	// The role isn't one of the roles.
+	MalformedPermission_UNKNOWN_ROLE MalformedPermission_Problem = 2
+	// A field has an invalid value or type.
+	MalformedPermission_INVALID_FIELD MalformedPermission_Problem = 3
+	// Another document is of the same user and resource.
+	MalformedPermission_DUPLICATE MalformedPermission_Problem = 4
+)
+
+var MalformedPermission_Problem_name = map[int32]string{
+	0: "PROBLEM_UNSPECIFIED",
+	1: "MISSING_FIELD",
+	2: "UNKNOWN_ROLE",
+	3: "INVALID_FIELD",
+	4: "DUPLICATE",
+}
+
+var MalformedPermission_Problem_value = map[string]int32{
+	"PROBLEM_UNSPECIFIED": 0,
+	"MISSING_FIELD":       1,
+	"UNKNOWN_ROLE":        2,
+	"INVALID_FIELD":       3,
+	"DUPLICATE":           4,
+}
+
+func (x MalformedPermission_Problem) String() string {
+	return proto.EnumName(MalformedPermission_Problem_name, int32(x))
+}
+
+func (MalformedPermission_Problem) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{58, 0}
+}
+
+type MalformedPermission_Resolution int32
+
+const (
+	// The document was reported and wasn't changed.
+	MalformedPermission_REPORTED MalformedPermission_Resolution = 0
+	// The document was fixed in place.
+	MalformedPermission_FIXED MalformedPermission_Resolution = 1
+	// The document was moved out of the permissions into the quarantine.
+	MalformedPermission_QUARANTINED MalformedPermission_Resolution = 2
+)
+
+var MalformedPermission_Resolution_name = map[int32]string{
+	0: "REPORTED",
+	1: "FIXED",
+	2: "QUARANTINED",
+}
+
+var MalformedPermission_Resolution_value = map[string]int32{
+	"REPORTED":    0,
+	"FIXED":       1,
+	"QUARANTINED": 2,
+}
+
+func (x MalformedPermission_Resolution) String() string {
+	return proto.EnumName(MalformedPermission_Resolution_name, int32(x))
+}
+
+func (MalformedPermission_Resolution) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{58, 1}
+}
+
+type SharingActivity_Kind int32
+
+const (
+	SharingActivity_KIND_UNSPECIFIED SharingActivity_Kind = 0
+	// The grantee was given a permission, or its permission was overridden.
+	SharingActivity_GRANTED SharingActivity_Kind = 1
+	// The role of the grantee's permission was changed from previous_role.
+	SharingActivity_ROLE_CHANGED SharingActivity_Kind = 2
+	// Other fields of the grantee's permission were updated, such as whether it may be reshared.
+	SharingActivity_UPDATED SharingActivity_Kind = 3
+	// The grantee's permission was revoked.
+	SharingActivity_REVOKED SharingActivity_Kind = 4
+	// The file stopped being shared: the permissions of revoked_grantee_ids were revoked together,
+	// and the grantee is the file's owner.
+	SharingActivity_SHARING_STOPPED SharingActivity_Kind = 5
+)
+
+var SharingActivity_Kind_name = map[int32]string{
+	0: "KIND_UNSPECIFIED",
+	1: "GRANTED",
+	2: "ROLE_CHANGED",
+	3: "UPDATED",
+	4: "REVOKED",
+	5: "SHARING_STOPPED",
+}
+
+var SharingActivity_Kind_value = map[string]int32{
+	"KIND_UNSPECIFIED": 0,
+	"GRANTED":          1,
+	"ROLE_CHANGED":     2,
+	"UPDATED":          3,
+	"REVOKED":          4,
+	"SHARING_STOPPED":  5,
+}
+
+func (x SharingActivity_Kind) String() string {
+	return proto.EnumName(SharingActivity_Kind_name, int32(x))
+}
+
+func (SharingActivity_Kind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{74, 0}
+}
+
+type AccessReview_State int32
+
+const (
+	AccessReview_STATE_UNSPECIFIED AccessReview_State = 0
+	// The reviewers decide the campaign's items.
+	AccessReview_OPEN AccessReview_State = 1
+	// The campaign is being closed, and its revocations are applied.
+	AccessReview_CLOSING AccessReview_State = 2
+	// The campaign was closed, and its revocations were applied.
+	AccessReview_CLOSED AccessReview_State = 3
+)
+
+var AccessReview_State_name = map[int32]string{
+	0: "STATE_UNSPECIFIED",
+	1: "OPEN",
+	2: "CLOSING",
+	3: "CLOSED",
+}
+
+var AccessReview_State_value = map[string]int32{
+	"STATE_UNSPECIFIED": 0,
+	"OPEN":              1,
+	"CLOSING":           2,
+	"CLOSED":            3,
+}
+
+func (x AccessReview_State) String() string {
+	return proto.EnumName(AccessReview_State_name, int32(x))
+}
+
+func (AccessReview_State) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{77, 0}
+}
+
+type AccessReviewItem_Decision int32
+
+const (
+	// The grant wasn't decided yet.
+	AccessReviewItem_DECISION_UNSPECIFIED AccessReviewItem_Decision = 0
+	// The grant is kept.
+	AccessReviewItem_CERTIFIED AccessReviewItem_Decision = 1
+	// The grant is revoked when the campaign is closed.
+	AccessReviewItem_REVOKED AccessReviewItem_Decision = 2
+)
+
+var AccessReviewItem_Decision_name = map[int32]string{
+	0: "DECISION_UNSPECIFIED",
+	1: "CERTIFIED",
+	2: "REVOKED",
+}
+
+var AccessReviewItem_Decision_value = map[string]int32{
+	"DECISION_UNSPECIFIED": 0,
+	"CERTIFIED":            1,
+	"REVOKED":              2,
+}
+
+func (x AccessReviewItem_Decision) String() string {
+	return proto.EnumName(AccessReviewItem_Decision_name, int32(x))
+}
+
+func (AccessReviewItem_Decision) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{82, 0}
+}
+
+type Permission struct {
+	// The resource name of the permission, such as `files/{file}/permissions/{permission}`.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The ID of the user that's given the permission.
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// The role of the permission.
+	Role Role `protobuf:"varint,3,opt,name=role,proto3,enum=permissions.v2.Role" json:"role,omitempty"`
+	// The ID of the user that created the permission.
+	Creator string `protobuf:"bytes,4,opt,name=creator,proto3" json:"creator,omitempty"`
+	// Signifies wether or not the user may share the file further.
+	CanReshare bool `protobuf:"varint,5,opt,name=can_reshare,json=canReshare,proto3" json:"can_reshare,omitempty"`
+	// The message of the creator to the user about the permission.
+	Message string `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	// The label describing the permission.
+	Label string `protobuf:"bytes,7,opt,name=label,proto3" json:"label,omitempty"`
+	// The last time the user accessed the file with the permission. Output only.
+	LastAccessedAt *timestamp.Timestamp `protobuf:"bytes,8,opt,name=last_accessed_at,json=lastAccessedAt,proto3" json:"last_accessed_at,omitempty"`
+	// The etag of the permission, changes whenever the permission is modified.
+	// If set on update, the permission is updated only if its current etag matches it.
+	Etag string `protobuf:"bytes,9,opt,name=etag,proto3" json:"etag,omitempty"`
+	// The users through which the file was shared to the user, starting from the user that shared it first
+	// and ending with the creator of the permission. Output only.
+	SharingChain []string `protobuf:"bytes,10,rep,name=sharing_chain,json=sharingChain,proto3" json:"sharing_chain,omitempty"`
+	// The case-insensitive name of the role of the permission, or one of its aliases, such as "viewer".
+	// An alternative to role on create and update, if both are set then they must be the same role.
+	// If neither is set on create then the permission is given the default role. Input only.
+	RoleName string `protobuf:"bytes,11,opt,name=role_name,json=roleName,proto3" json:"role_name,omitempty"`
+	// The kind of the resource which is being permitted, "file" or "folder", defaults to "file".
+	// Set on create only.
+	ResourceKind string `protobuf:"bytes,12,opt,name=resource_kind,json=resourceKind,proto3" json:"resource_kind,omitempty"`
+	// The capabilities that the permission grants. Output only.
+	Capabilities []Capability `protobuf:"varint,13,rep,packed,name=capabilities,proto3,enum=permissions.v2.Capability" json:"capabilities,omitempty"`
+	// The type of the grantee, "user" or "domain", defaults to "user". The user_id of a permission
+	// given to everyone in an organization is the organization's domain. Set on create only.
+	GranteeType string `protobuf:"bytes,14,opt,name=grantee_type,json=granteeType,proto3" json:"grantee_type,omitempty"`
+	// Key-value labels of the permission, such as project=apollo or source=sync-job,
+	// that the permissions can be filtered by when they're listed.
+	// Keys are lowercase letters, digits, '-' and '_', starting with a letter, of up to 63 characters,
+	// values are of up to 63 characters, and there may be up to 16 labels.
+	Labels map[string]string `protobuf:"bytes,15,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The system that created the permission, such as "ui", "sync-job" or "template", defaults to "api".
+	// It's lowercase letters, digits, '-' and '_', starting with a letter, of up to 63 characters.
+	// It's empty for permissions that were created before sources were recorded. Set on create only.
+	Source string `protobuf:"bytes,16,opt,name=source,proto3" json:"source,omitempty"`
+	// The resource name of the folder that the permission is inherited from, such as `files/{file}`,
+	// empty if it was given to the resource directly.
+	InheritedFrom        string   `protobuf:"bytes,17,opt,name=inherited_from,json=inheritedFrom,proto3" json:"inherited_from,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Permission) Reset()         { *m = Permission{} }
+func (m *Permission) String() string { return proto.CompactTextString(m) }
+func (*Permission) ProtoMessage()    {}
+func (*Permission) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{0}
+}
+
+func (m *Permission) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Permission.Unmarshal(m, b)
+}
+func (m *Permission) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Permission.Marshal(b, m, deterministic)
+}
+func (m *Permission) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Permission.Merge(m, src)
+}
+func (m *Permission) XXX_Size() int {
+	return xxx_messageInfo_Permission.Size(m)
+}
+func (m *Permission) XXX_DiscardUnknown() {
+	xxx_messageInfo_Permission.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Permission proto.InternalMessageInfo
+
+func (m *Permission) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Permission) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *Permission) GetRole() Role {
+	if m != nil {
+		return m.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (m *Permission) GetCreator() string {
+	if m != nil {
+		return m.Creator
+	}
+	return ""
+}
+
+func (m *Permission) GetCanReshare() bool {
+	if m != nil {
+		return m.CanReshare
+	}
+	return false
+}
+
+func (m *Permission) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *Permission) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *Permission) GetLastAccessedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastAccessedAt
+	}
+	return nil
+}
+
+func (m *Permission) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+func (m *Permission) GetSharingChain() []string {
+	if m != nil {
+		return m.SharingChain
+	}
+	return nil
+}
+
+func (m *Permission) GetRoleName() string {
+	if m != nil {
+		return m.RoleName
+	}
+	return ""
+}
+
+func (m *Permission) GetResourceKind() string {
+	if m != nil {
+		return m.ResourceKind
+	}
+	return ""
+}
+
+func (m *Permission) GetCapabilities() []Capability {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+func (m *Permission) GetGranteeType() string {
+	if m != nil {
+		return m.GranteeType
+	}
+	return ""
+}
+
+func (m *Permission) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *Permission) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *Permission) GetInheritedFrom() string {
+	if m != nil {
+		return m.InheritedFrom
+	}
+	return ""
+}
+
+type ListPermissionsRequest struct {
+	// The resource which owns the permissions, such as `files/{file}`.
+	Parent string `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	// The maximum number of permissions to return, the server may return fewer.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// The next_page_token of a previous ListPermissions call.
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// The fields of the permissions to return, all fields are returned if empty.
+	ReadMask *field_mask.FieldMask `protobuf:"bytes,4,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
+	// If set, only the permissions that have all of these labels are returned.
+	LabelSelector map[string]string `protobuf:"bytes,5,rep,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// If set, only the permissions that were created by this system are returned.
+	Source               string   `protobuf:"bytes,6,opt,name=source,proto3" json:"source,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListPermissionsRequest) Reset()         { *m = ListPermissionsRequest{} }
+func (m *ListPermissionsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListPermissionsRequest) ProtoMessage()    {}
+func (*ListPermissionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{1}
+}
+
+func (m *ListPermissionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPermissionsRequest.Unmarshal(m, b)
+}
+func (m *ListPermissionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPermissionsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListPermissionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPermissionsRequest.Merge(m, src)
+}
+func (m *ListPermissionsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListPermissionsRequest.Size(m)
+}
+func (m *ListPermissionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPermissionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPermissionsRequest proto.InternalMessageInfo
+
+func (m *ListPermissionsRequest) GetParent() string {
+	if m != nil {
+		return m.Parent
+	}
+	return ""
+}
+
+func (m *ListPermissionsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListPermissionsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *ListPermissionsRequest) GetReadMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.ReadMask
+	}
+	return nil
+}
+
+func (m *ListPermissionsRequest) GetLabelSelector() map[string]string {
+	if m != nil {
+		return m.LabelSelector
+	}
+	return nil
+}
+
+func (m *ListPermissionsRequest) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+type ListPermissionsResponse struct {
+	// The permissions of the file.
+	Permissions []*Permission `protobuf:"bytes,1,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	// A token to retrieve the next page, empty if there are no more pages.
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListPermissionsResponse) Reset()         { *m = ListPermissionsResponse{} }
+func (m *ListPermissionsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListPermissionsResponse) ProtoMessage()    {}
+func (*ListPermissionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{2}
+}
+
+func (m *ListPermissionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPermissionsResponse.Unmarshal(m, b)
+}
+func (m *ListPermissionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPermissionsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListPermissionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPermissionsResponse.Merge(m, src)
+}
+func (m *ListPermissionsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListPermissionsResponse.Size(m)
+}
+func (m *ListPermissionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPermissionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPermissionsResponse proto.InternalMessageInfo
+
+func (m *ListPermissionsResponse) GetPermissions() []*Permission {
+	if m != nil {
+		return m.Permissions
+	}
+	return nil
+}
+
+func (m *ListPermissionsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type GetFolderSharingSummaryRequest struct {
+	// The resource name of the folder, such as `files/{file}`.
+	Folder string `protobuf:"bytes,1,opt,name=folder,proto3" json:"folder,omitempty"`
+	// The IDs of the folder's descendants, such as of the file service's hierarchy.
+	// The files that inherit the folder's permissions are summarized if it's empty.
+	DescendantIds        []string `protobuf:"bytes,2,rep,name=descendant_ids,json=descendantIds,proto3" json:"descendant_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetFolderSharingSummaryRequest) Reset()         { *m = GetFolderSharingSummaryRequest{} }
+func (m *GetFolderSharingSummaryRequest) String() string { return proto.CompactTextString(m) }
+func (*GetFolderSharingSummaryRequest) ProtoMessage()    {}
+func (*GetFolderSharingSummaryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{3}
+}
+
+func (m *GetFolderSharingSummaryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetFolderSharingSummaryRequest.Unmarshal(m, b)
+}
+func (m *GetFolderSharingSummaryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetFolderSharingSummaryRequest.Marshal(b, m, deterministic)
+}
+func (m *GetFolderSharingSummaryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetFolderSharingSummaryRequest.Merge(m, src)
+}
+func (m *GetFolderSharingSummaryRequest) XXX_Size() int {
+	return xxx_messageInfo_GetFolderSharingSummaryRequest.Size(m)
+}
+func (m *GetFolderSharingSummaryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetFolderSharingSummaryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetFolderSharingSummaryRequest proto.InternalMessageInfo
+
+func (m *GetFolderSharingSummaryRequest) GetFolder() string {
+	if m != nil {
+		return m.Folder
+	}
+	return ""
+}
+
+func (m *GetFolderSharingSummaryRequest) GetDescendantIds() []string {
+	if m != nil {
+		return m.DescendantIds
+	}
+	return nil
+}
+
+type FolderSharingSummary struct {
+	// The resource name of the folder.
+	Folder string `protobuf:"bytes,1,opt,name=folder,proto3" json:"folder,omitempty"`
+	// The number of files of the tree that have permissions.
+	Files int64 `protobuf:"varint,2,opt,name=files,proto3" json:"files,omitempty"`
+	// The number of permissions to the files of the tree.
+	Permissions int64 `protobuf:"varint,3,opt,name=permissions,proto3" json:"permissions,omitempty"`
+	// The number of distinct grantees that have a permission to any of the files of the tree.
+	Grantees int64 `protobuf:"varint,4,opt,name=grantees,proto3" json:"grantees,omitempty"`
+	// The distinct grantees of each role, ordered by role.
+	Roles []*FolderSharingSummary_RoleGrantees `protobuf:"bytes,5,rep,name=roles,proto3" json:"roles,omitempty"`
+	// The grantees outside of the tenant, such as organizations, that have a permission to any of the files
+	// of the tree, ordered by their IDs.
+	ExternalGrantees []string `protobuf:"bytes,6,rep,name=external_grantees,json=externalGrantees,proto3" json:"external_grantees,omitempty"`
+	// The number of files of the tree that are accessible outside of the tenant.
+	ExternalFiles        int64    `protobuf:"varint,7,opt,name=external_files,json=externalFiles,proto3" json:"external_files,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FolderSharingSummary) Reset()         { *m = FolderSharingSummary{} }
+func (m *FolderSharingSummary) String() string { return proto.CompactTextString(m) }
+func (*FolderSharingSummary) ProtoMessage()    {}
+func (*FolderSharingSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{4}
+}
+
+func (m *FolderSharingSummary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FolderSharingSummary.Unmarshal(m, b)
+}
+func (m *FolderSharingSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FolderSharingSummary.Marshal(b, m, deterministic)
+}
+func (m *FolderSharingSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FolderSharingSummary.Merge(m, src)
+}
+func (m *FolderSharingSummary) XXX_Size() int {
+	return xxx_messageInfo_FolderSharingSummary.Size(m)
+}
+func (m *FolderSharingSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_FolderSharingSummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FolderSharingSummary proto.InternalMessageInfo
+
+func (m *FolderSharingSummary) GetFolder() string {
+	if m != nil {
+		return m.Folder
+	}
+	return ""
+}
+
+func (m *FolderSharingSummary) GetFiles() int64 {
+	if m != nil {
+		return m.Files
+	}
+	return 0
+}
+
+func (m *FolderSharingSummary) GetPermissions() int64 {
+	if m != nil {
+		return m.Permissions
+	}
+	return 0
+}
+
+func (m *FolderSharingSummary) GetGrantees() int64 {
+	if m != nil {
+		return m.Grantees
+	}
+	return 0
+}
+
+func (m *FolderSharingSummary) GetRoles() []*FolderSharingSummary_RoleGrantees {
+	if m != nil {
+		return m.Roles
+	}
+	return nil
+}
+
+func (m *FolderSharingSummary) GetExternalGrantees() []string {
+	if m != nil {
+		return m.ExternalGrantees
+	}
+	return nil
+}
+
+func (m *FolderSharingSummary) GetExternalFiles() int64 {
+	if m != nil {
+		return m.ExternalFiles
+	}
+	return 0
+}
+
+// The distinct grantees of a role.
+type FolderSharingSummary_RoleGrantees struct {
+	Role Role `protobuf:"varint,1,opt,name=role,proto3,enum=permissions.v2.Role" json:"role,omitempty"`
+	// The number of distinct grantees that have the role to any of the files of the tree.
+	Grantees             int64    `protobuf:"varint,2,opt,name=grantees,proto3" json:"grantees,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FolderSharingSummary_RoleGrantees) Reset()         { *m = FolderSharingSummary_RoleGrantees{} }
+func (m *FolderSharingSummary_RoleGrantees) String() string { return proto.CompactTextString(m) }
+func (*FolderSharingSummary_RoleGrantees) ProtoMessage()    {}
+func (*FolderSharingSummary_RoleGrantees) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{4, 0}
+}
+
+func (m *FolderSharingSummary_RoleGrantees) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FolderSharingSummary_RoleGrantees.Unmarshal(m, b)
+}
+func (m *FolderSharingSummary_RoleGrantees) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FolderSharingSummary_RoleGrantees.Marshal(b, m, deterministic)
+}
+func (m *FolderSharingSummary_RoleGrantees) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FolderSharingSummary_RoleGrantees.Merge(m, src)
+}
+func (m *FolderSharingSummary_RoleGrantees) XXX_Size() int {
+	return xxx_messageInfo_FolderSharingSummary_RoleGrantees.Size(m)
+}
+func (m *FolderSharingSummary_RoleGrantees) XXX_DiscardUnknown() {
+	xxx_messageInfo_FolderSharingSummary_RoleGrantees.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FolderSharingSummary_RoleGrantees proto.InternalMessageInfo
+
+func (m *FolderSharingSummary_RoleGrantees) GetRole() Role {
+	if m != nil {
+		return m.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (m *FolderSharingSummary_RoleGrantees) GetGrantees() int64 {
+	if m != nil {
+		return m.Grantees
+	}
+	return 0
+}
+
+type ListRolesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListRolesRequest) Reset()         { *m = ListRolesRequest{} }
+func (m *ListRolesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListRolesRequest) ProtoMessage()    {}
+func (*ListRolesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{5}
+}
+
+func (m *ListRolesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRolesRequest.Unmarshal(m, b)
+}
+func (m *ListRolesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRolesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListRolesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRolesRequest.Merge(m, src)
+}
+func (m *ListRolesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListRolesRequest.Size(m)
+}
+func (m *ListRolesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRolesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRolesRequest proto.InternalMessageInfo
+
+type ListRolesResponse struct {
+	// The roles, by their order.
+	Roles                []*RoleInfo `protobuf:"bytes,1,rep,name=roles,proto3" json:"roles,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ListRolesResponse) Reset()         { *m = ListRolesResponse{} }
+func (m *ListRolesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListRolesResponse) ProtoMessage()    {}
+func (*ListRolesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{6}
+}
+
+func (m *ListRolesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRolesResponse.Unmarshal(m, b)
+}
+func (m *ListRolesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRolesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListRolesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRolesResponse.Merge(m, src)
+}
+func (m *ListRolesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListRolesResponse.Size(m)
+}
+func (m *ListRolesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRolesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRolesResponse proto.InternalMessageInfo
+
+func (m *ListRolesResponse) GetRoles() []*RoleInfo {
+	if m != nil {
+		return m.Roles
+	}
+	return nil
+}
+
+// RoleInfo is the metadata of a role, which doesn't depend on the locale of the client.
+type RoleInfo struct {
+	Role Role `protobuf:"varint,1,opt,name=role,proto3,enum=permissions.v2.Role" json:"role,omitempty"`
+	// The name of the role, such as "READ", that requests may use instead of the role.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// The position of the role in role pickers, from the least to the most privileged,
+	// followed by the roles of special purposes, such as UPLOADER.
+	Order int32 `protobuf:"varint,3,opt,name=order,proto3" json:"order,omitempty"`
+	// The configured aliases of the role, such as "viewer", that requests may use instead of its name.
+	Aliases []string `protobuf:"bytes,4,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	// The capabilities that the role grants to the resources of each kind.
+	Capabilities []*RoleInfo_KindCapabilities `protobuf:"bytes,5,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	// The roles that the role includes, a permission with the role is permitted as any of them.
+	Includes []Role `protobuf:"varint,6,rep,packed,name=includes,proto3,enum=permissions.v2.Role" json:"includes,omitempty"`
+	// The keys of the role's display name and description, such as "role.read.name",
+	// that clients translate to the locale of their users.
+	DisplayNameKey string `protobuf:"bytes,7,opt,name=display_name_key,json=displayNameKey,proto3" json:"display_name_key,omitempty"`
+	DescriptionKey string `protobuf:"bytes,8,opt,name=description_key,json=descriptionKey,proto3" json:"description_key,omitempty"`
+	// Signifies whether the role is the role of the permissions that are created without one.
+	IsDefault bool `protobuf:"varint,9,opt,name=is_default,json=isDefault,proto3" json:"is_default,omitempty"`
+	// Signifies whether the role may only be granted by an approved permission request.
+	RequiresApproval     bool     `protobuf:"varint,10,opt,name=requires_approval,json=requiresApproval,proto3" json:"requires_approval,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RoleInfo) Reset()         { *m = RoleInfo{} }
+func (m *RoleInfo) String() string { return proto.CompactTextString(m) }
+func (*RoleInfo) ProtoMessage()    {}
+func (*RoleInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{7}
+}
+
+func (m *RoleInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RoleInfo.Unmarshal(m, b)
+}
+func (m *RoleInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RoleInfo.Marshal(b, m, deterministic)
+}
+func (m *RoleInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoleInfo.Merge(m, src)
+}
+func (m *RoleInfo) XXX_Size() int {
+	return xxx_messageInfo_RoleInfo.Size(m)
+}
+func (m *RoleInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoleInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RoleInfo proto.InternalMessageInfo
+
+func (m *RoleInfo) GetRole() Role {
+	if m != nil {
+		return m.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (m *RoleInfo) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RoleInfo) GetOrder() int32 {
+	if m != nil {
+		return m.Order
+	}
+	return 0
+}
+
+func (m *RoleInfo) GetAliases() []string {
+	if m != nil {
+		return m.Aliases
+	}
+	return nil
+}
+
+func (m *RoleInfo) GetCapabilities() []*RoleInfo_KindCapabilities {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+func (m *RoleInfo) GetIncludes() []Role {
+	if m != nil {
+		return m.Includes
+	}
+	return nil
+}
+
+func (m *RoleInfo) GetDisplayNameKey() string {
+	if m != nil {
+		return m.DisplayNameKey
+	}
+	return ""
+}
+
+func (m *RoleInfo) GetDescriptionKey() string {
+	if m != nil {
+		return m.DescriptionKey
+	}
+	return ""
+}
+
+func (m *RoleInfo) GetIsDefault() bool {
+	if m != nil {
+		return m.IsDefault
+	}
+	return false
+}
+
+func (m *RoleInfo) GetRequiresApproval() bool {
+	if m != nil {
+		return m.RequiresApproval
+	}
+	return false
+}
+
+// The capabilities that a role grants to the resources of a kind.
+type RoleInfo_KindCapabilities struct {
+	// The kind of the resources, "file" or "folder".
+	ResourceKind         string       `protobuf:"bytes,1,opt,name=resource_kind,json=resourceKind,proto3" json:"resource_kind,omitempty"`
+	Capabilities         []Capability `protobuf:"varint,2,rep,packed,name=capabilities,proto3,enum=permissions.v2.Capability" json:"capabilities,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *RoleInfo_KindCapabilities) Reset()         { *m = RoleInfo_KindCapabilities{} }
+func (m *RoleInfo_KindCapabilities) String() string { return proto.CompactTextString(m) }
+func (*RoleInfo_KindCapabilities) ProtoMessage()    {}
+func (*RoleInfo_KindCapabilities) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{7, 0}
+}
+
+func (m *RoleInfo_KindCapabilities) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RoleInfo_KindCapabilities.Unmarshal(m, b)
+}
+func (m *RoleInfo_KindCapabilities) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RoleInfo_KindCapabilities.Marshal(b, m, deterministic)
+}
+func (m *RoleInfo_KindCapabilities) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoleInfo_KindCapabilities.Merge(m, src)
+}
+func (m *RoleInfo_KindCapabilities) XXX_Size() int {
+	return xxx_messageInfo_RoleInfo_KindCapabilities.Size(m)
+}
+func (m *RoleInfo_KindCapabilities) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoleInfo_KindCapabilities.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RoleInfo_KindCapabilities proto.InternalMessageInfo
+
+func (m *RoleInfo_KindCapabilities) GetResourceKind() string {
+	if m != nil {
+		return m.ResourceKind
+	}
+	return ""
+}
+
+func (m *RoleInfo_KindCapabilities) GetCapabilities() []Capability {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+type GetPermissionRequest struct {
+	// The resource name of the permission.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The fields of the permission to return, all fields are returned if empty.
+	ReadMask             *field_mask.FieldMask `protobuf:"bytes,2,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *GetPermissionRequest) Reset()         { *m = GetPermissionRequest{} }
+func (m *GetPermissionRequest) String() string { return proto.CompactTextString(m) }
+func (*GetPermissionRequest) ProtoMessage()    {}
+func (*GetPermissionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{8}
+}
+
+func (m *GetPermissionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPermissionRequest.Unmarshal(m, b)
+}
+func (m *GetPermissionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPermissionRequest.Marshal(b, m, deterministic)
+}
+func (m *GetPermissionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPermissionRequest.Merge(m, src)
+}
+func (m *GetPermissionRequest) XXX_Size() int {
+	return xxx_messageInfo_GetPermissionRequest.Size(m)
+}
+func (m *GetPermissionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPermissionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPermissionRequest proto.InternalMessageInfo
+
+func (m *GetPermissionRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GetPermissionRequest) GetReadMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.ReadMask
+	}
+	return nil
+}
+
+type CreatePermissionRequest struct {
+	// The resource which owns the permission, such as `files/{file}`.
+	Parent string `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	// The permission to create.
+	Permission           *Permission `protobuf:"bytes,2,opt,name=permission,proto3" json:"permission,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *CreatePermissionRequest) Reset()         { *m = CreatePermissionRequest{} }
+func (m *CreatePermissionRequest) String() string { return proto.CompactTextString(m) }
+func (*CreatePermissionRequest) ProtoMessage()    {}
+func (*CreatePermissionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{9}
+}
+
+func (m *CreatePermissionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreatePermissionRequest.Unmarshal(m, b)
+}
+func (m *CreatePermissionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreatePermissionRequest.Marshal(b, m, deterministic)
+}
+func (m *CreatePermissionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreatePermissionRequest.Merge(m, src)
+}
+func (m *CreatePermissionRequest) XXX_Size() int {
+	return xxx_messageInfo_CreatePermissionRequest.Size(m)
+}
+func (m *CreatePermissionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreatePermissionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreatePermissionRequest proto.InternalMessageInfo
+
+func (m *CreatePermissionRequest) GetParent() string {
+	if m != nil {
+		return m.Parent
+	}
+	return ""
+}
+
+func (m *CreatePermissionRequest) GetPermission() *Permission {
+	if m != nil {
+		return m.Permission
+	}
+	return nil
+}
+
+type UpdatePermissionRequest struct {
+	// The permission to update, found by its name.
+	Permission *Permission `protobuf:"bytes,1,opt,name=permission,proto3" json:"permission,omitempty"`
+	// The fields of the permission to update.
+	UpdateMask *field_mask.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// If set to a future time, the update is scheduled to be applied at that time, regardless of the
+	// permission's etag then, and the current permission is returned.
+	ScheduleAt           *timestamp.Timestamp `protobuf:"bytes,3,opt,name=schedule_at,json=scheduleAt,proto3" json:"schedule_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *UpdatePermissionRequest) Reset()         { *m = UpdatePermissionRequest{} }
+func (m *UpdatePermissionRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdatePermissionRequest) ProtoMessage()    {}
+func (*UpdatePermissionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{10}
+}
+
+func (m *UpdatePermissionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdatePermissionRequest.Unmarshal(m, b)
+}
+func (m *UpdatePermissionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdatePermissionRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdatePermissionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdatePermissionRequest.Merge(m, src)
+}
+func (m *UpdatePermissionRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdatePermissionRequest.Size(m)
+}
+func (m *UpdatePermissionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdatePermissionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdatePermissionRequest proto.InternalMessageInfo
+
+func (m *UpdatePermissionRequest) GetPermission() *Permission {
+	if m != nil {
+		return m.Permission
+	}
+	return nil
+}
+
+func (m *UpdatePermissionRequest) GetUpdateMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
+}
+
+func (m *UpdatePermissionRequest) GetScheduleAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ScheduleAt
+	}
+	return nil
+}
+
+type UpdatePermissionsRequest struct {
+	// The updates, each permission may be updated once.
+	Updates              []*UpdatePermissionsRequest_RoleUpdate `protobuf:"bytes,1,rep,name=updates,proto3" json:"updates,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                               `json:"-"`
+	XXX_unrecognized     []byte                                 `json:"-"`
+	XXX_sizecache        int32                                  `json:"-"`
+}
+
+func (m *UpdatePermissionsRequest) Reset()         { *m = UpdatePermissionsRequest{} }
+func (m *UpdatePermissionsRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdatePermissionsRequest) ProtoMessage()    {}
+func (*UpdatePermissionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{11}
+}
+
+func (m *UpdatePermissionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdatePermissionsRequest.Unmarshal(m, b)
+}
+func (m *UpdatePermissionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdatePermissionsRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdatePermissionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdatePermissionsRequest.Merge(m, src)
+}
+func (m *UpdatePermissionsRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdatePermissionsRequest.Size(m)
+}
+func (m *UpdatePermissionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdatePermissionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdatePermissionsRequest proto.InternalMessageInfo
+
+func (m *UpdatePermissionsRequest) GetUpdates() []*UpdatePermissionsRequest_RoleUpdate {
+	if m != nil {
+		return m.Updates
+	}
+	return nil
+}
+
+// A change of the role of a permission.
+type UpdatePermissionsRequest_RoleUpdate struct {
+	// The resource name of the permission, such as `files/{file}/permissions/{permission}`.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The new role of the permission.
+	Role Role `protobuf:"varint,2,opt,name=role,proto3,enum=permissions.v2.Role" json:"role,omitempty"`
+	// The name of the new role or one of its aliases, such as "viewer", if role isn't set.
+	RoleName string `protobuf:"bytes,3,opt,name=role_name,json=roleName,proto3" json:"role_name,omitempty"`
+	// The permission's current etag, the permission is updated regardless of its etag if not set.
+	Etag                 string   `protobuf:"bytes,4,opt,name=etag,proto3" json:"etag,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdatePermissionsRequest_RoleUpdate) Reset()         { *m = UpdatePermissionsRequest_RoleUpdate{} }
+func (m *UpdatePermissionsRequest_RoleUpdate) String() string { return proto.CompactTextString(m) }
+func (*UpdatePermissionsRequest_RoleUpdate) ProtoMessage()    {}
+func (*UpdatePermissionsRequest_RoleUpdate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{11, 0}
+}
+
+func (m *UpdatePermissionsRequest_RoleUpdate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdatePermissionsRequest_RoleUpdate.Unmarshal(m, b)
+}
+func (m *UpdatePermissionsRequest_RoleUpdate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdatePermissionsRequest_RoleUpdate.Marshal(b, m, deterministic)
+}
+func (m *UpdatePermissionsRequest_RoleUpdate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdatePermissionsRequest_RoleUpdate.Merge(m, src)
+}
+func (m *UpdatePermissionsRequest_RoleUpdate) XXX_Size() int {
+	return xxx_messageInfo_UpdatePermissionsRequest_RoleUpdate.Size(m)
+}
+func (m *UpdatePermissionsRequest_RoleUpdate) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdatePermissionsRequest_RoleUpdate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdatePermissionsRequest_RoleUpdate proto.InternalMessageInfo
+
+func (m *UpdatePermissionsRequest_RoleUpdate) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *UpdatePermissionsRequest_RoleUpdate) GetRole() Role {
+	if m != nil {
+		return m.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (m *UpdatePermissionsRequest_RoleUpdate) GetRoleName() string {
+	if m != nil {
+		return m.RoleName
+	}
+	return ""
+}
+
+func (m *UpdatePermissionsRequest_RoleUpdate) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+type UpdatePermissionsResponse struct {
+	// The results of the updates, in the order of the updates.
+	Results              []*UpdatePermissionsResponse_Result `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                            `json:"-"`
+	XXX_unrecognized     []byte                              `json:"-"`
+	XXX_sizecache        int32                               `json:"-"`
+}
+
+func (m *UpdatePermissionsResponse) Reset()         { *m = UpdatePermissionsResponse{} }
+func (m *UpdatePermissionsResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdatePermissionsResponse) ProtoMessage()    {}
+func (*UpdatePermissionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{12}
+}
+
+func (m *UpdatePermissionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdatePermissionsResponse.Unmarshal(m, b)
+}
+func (m *UpdatePermissionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdatePermissionsResponse.Marshal(b, m, deterministic)
+}
+func (m *UpdatePermissionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdatePermissionsResponse.Merge(m, src)
+}
+func (m *UpdatePermissionsResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdatePermissionsResponse.Size(m)
+}
+func (m *UpdatePermissionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdatePermissionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdatePermissionsResponse proto.InternalMessageInfo
+
+func (m *UpdatePermissionsResponse) GetResults() []*UpdatePermissionsResponse_Result {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+// The result of an update.
+type UpdatePermissionsResponse_Result struct {
+	// The resource name of the updated permission.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The gRPC status code of the update, such as OK if the permission was updated,
+	// NOT_FOUND if it doesn't exist, ABORTED if its etag doesn't match the current etag,
+	// or INVALID_ARGUMENT, PERMISSION_DENIED or FAILED_PRECONDITION if the update violates
+	// the invariants of the permission's roles.
+	Code    int32  `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// The updated permission if the update succeeded.
+	Permission           *Permission `protobuf:"bytes,4,opt,name=permission,proto3" json:"permission,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *UpdatePermissionsResponse_Result) Reset()         { *m = UpdatePermissionsResponse_Result{} }
+func (m *UpdatePermissionsResponse_Result) String() string { return proto.CompactTextString(m) }
+func (*UpdatePermissionsResponse_Result) ProtoMessage()    {}
+func (*UpdatePermissionsResponse_Result) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{12, 0}
+}
+
+func (m *UpdatePermissionsResponse_Result) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdatePermissionsResponse_Result.Unmarshal(m, b)
+}
+func (m *UpdatePermissionsResponse_Result) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdatePermissionsResponse_Result.Marshal(b, m, deterministic)
+}
+func (m *UpdatePermissionsResponse_Result) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdatePermissionsResponse_Result.Merge(m, src)
+}
+func (m *UpdatePermissionsResponse_Result) XXX_Size() int {
+	return xxx_messageInfo_UpdatePermissionsResponse_Result.Size(m)
+}
+func (m *UpdatePermissionsResponse_Result) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdatePermissionsResponse_Result.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdatePermissionsResponse_Result proto.InternalMessageInfo
+
+func (m *UpdatePermissionsResponse_Result) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *UpdatePermissionsResponse_Result) GetCode() int32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *UpdatePermissionsResponse_Result) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *UpdatePermissionsResponse_Result) GetPermission() *Permission {
+	if m != nil {
+		return m.Permission
+	}
+	return nil
+}
+
+// A request of a permission that's created once it's approved by a second user.
+// Its resource name is `{resources}/{resource}/permissionRequests/{request}`.
+type PermissionRequest struct {
+	// The resource name of the request.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The requested permission, whose creator is the requester.
+	Permission *Permission `protobuf:"bytes,2,opt,name=permission,proto3" json:"permission,omitempty"`
+	// The time the permission was requested.
+	CreateTime *timestamp.Timestamp `protobuf:"bytes,3,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// The user that approved the request, empty while it's pending.
+	Approver string `protobuf:"bytes,4,opt,name=approver,proto3" json:"approver,omitempty"`
+	// The time the request was approved, unset while it's pending.
+	ApproveTime *timestamp.Timestamp `protobuf:"bytes,5,opt,name=approve_time,json=approveTime,proto3" json:"approve_time,omitempty"`
+	// The resource name of the permission that was created by the approval, empty while it's pending.
+	ApprovedPermission   string   `protobuf:"bytes,6,opt,name=approved_permission,json=approvedPermission,proto3" json:"approved_permission,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PermissionRequest) Reset()         { *m = PermissionRequest{} }
+func (m *PermissionRequest) String() string { return proto.CompactTextString(m) }
+func (*PermissionRequest) ProtoMessage()    {}
+func (*PermissionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{13}
+}
+
+func (m *PermissionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PermissionRequest.Unmarshal(m, b)
+}
+func (m *PermissionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PermissionRequest.Marshal(b, m, deterministic)
+}
+func (m *PermissionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PermissionRequest.Merge(m, src)
+}
+func (m *PermissionRequest) XXX_Size() int {
+	return xxx_messageInfo_PermissionRequest.Size(m)
+}
+func (m *PermissionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PermissionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PermissionRequest proto.InternalMessageInfo
+
+func (m *PermissionRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PermissionRequest) GetPermission() *Permission {
+	if m != nil {
+		return m.Permission
+	}
+	return nil
+}
+
+func (m *PermissionRequest) GetCreateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreateTime
+	}
+	return nil
+}
+
+func (m *PermissionRequest) GetApprover() string {
+	if m != nil {
+		return m.Approver
+	}
+	return ""
+}
+
+func (m *PermissionRequest) GetApproveTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.ApproveTime
+	}
+	return nil
+}
+
+func (m *PermissionRequest) GetApprovedPermission() string {
+	if m != nil {
+		return m.ApprovedPermission
+	}
+	return ""
+}
+
+type RequestPermissionRequest struct {
+	// The resource which owns the permission, such as `files/{file}`.
+	Parent string `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	// The requested permission, its creator is the requester.
+	Permission           *Permission `protobuf:"bytes,2,opt,name=permission,proto3" json:"permission,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *RequestPermissionRequest) Reset()         { *m = RequestPermissionRequest{} }
+func (m *RequestPermissionRequest) String() string { return proto.CompactTextString(m) }
+func (*RequestPermissionRequest) ProtoMessage()    {}
+func (*RequestPermissionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{14}
+}
+
+func (m *RequestPermissionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RequestPermissionRequest.Unmarshal(m, b)
+}
+func (m *RequestPermissionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RequestPermissionRequest.Marshal(b, m, deterministic)
+}
+func (m *RequestPermissionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestPermissionRequest.Merge(m, src)
+}
+func (m *RequestPermissionRequest) XXX_Size() int {
+	return xxx_messageInfo_RequestPermissionRequest.Size(m)
+}
+func (m *RequestPermissionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestPermissionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestPermissionRequest proto.InternalMessageInfo
+
+func (m *RequestPermissionRequest) GetParent() string {
+	if m != nil {
+		return m.Parent
+	}
+	return ""
+}
+
+func (m *RequestPermissionRequest) GetPermission() *Permission {
+	if m != nil {
+		return m.Permission
+	}
+	return nil
+}
+
+type ApprovePermissionRequestRequest struct {
+	// The resource name of the request.
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApprovePermissionRequestRequest) Reset()         { *m = ApprovePermissionRequestRequest{} }
+func (m *ApprovePermissionRequestRequest) String() string { return proto.CompactTextString(m) }
+func (*ApprovePermissionRequestRequest) ProtoMessage()    {}
+func (*ApprovePermissionRequestRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{15}
+}
+
+func (m *ApprovePermissionRequestRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApprovePermissionRequestRequest.Unmarshal(m, b)
+}
+func (m *ApprovePermissionRequestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApprovePermissionRequestRequest.Marshal(b, m, deterministic)
+}
+func (m *ApprovePermissionRequestRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApprovePermissionRequestRequest.Merge(m, src)
+}
+func (m *ApprovePermissionRequestRequest) XXX_Size() int {
+	return xxx_messageInfo_ApprovePermissionRequestRequest.Size(m)
+}
+func (m *ApprovePermissionRequestRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApprovePermissionRequestRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApprovePermissionRequestRequest proto.InternalMessageInfo
+
+func (m *ApprovePermissionRequestRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type DeletePermissionRequest struct {
+	// The resource name of the permission.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// If set, the permission is deleted only if its current etag matches it.
+	Etag                 string   `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeletePermissionRequest) Reset()         { *m = DeletePermissionRequest{} }
+func (m *DeletePermissionRequest) String() string { return proto.CompactTextString(m) }
+func (*DeletePermissionRequest) ProtoMessage()    {}
+func (*DeletePermissionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{16}
+}
+
+func (m *DeletePermissionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeletePermissionRequest.Unmarshal(m, b)
+}
+func (m *DeletePermissionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeletePermissionRequest.Marshal(b, m, deterministic)
+}
+func (m *DeletePermissionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeletePermissionRequest.Merge(m, src)
+}
+func (m *DeletePermissionRequest) XXX_Size() int {
+	return xxx_messageInfo_DeletePermissionRequest.Size(m)
+}
+func (m *DeletePermissionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeletePermissionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeletePermissionRequest proto.InternalMessageInfo
+
+func (m *DeletePermissionRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DeletePermissionRequest) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+type AccessChange struct {
+	// The ID of the user whose permission is changed.
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// The role the user would be granted, ignored if revoke is set.
+	Role Role `protobuf:"varint,2,opt,name=role,proto3,enum=permissions.v2.Role" json:"role,omitempty"`
+	// Signifies wether the permission of the user would be revoked.
+	Revoke               bool     `protobuf:"varint,3,opt,name=revoke,proto3" json:"revoke,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AccessChange) Reset()         { *m = AccessChange{} }
+func (m *AccessChange) String() string { return proto.CompactTextString(m) }
+func (*AccessChange) ProtoMessage()    {}
+func (*AccessChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{17}
+}
+
+func (m *AccessChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccessChange.Unmarshal(m, b)
+}
+func (m *AccessChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccessChange.Marshal(b, m, deterministic)
+}
+func (m *AccessChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccessChange.Merge(m, src)
+}
+func (m *AccessChange) XXX_Size() int {
+	return xxx_messageInfo_AccessChange.Size(m)
+}
+func (m *AccessChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccessChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccessChange proto.InternalMessageInfo
+
+func (m *AccessChange) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *AccessChange) GetRole() Role {
+	if m != nil {
+		return m.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (m *AccessChange) GetRevoke() bool {
+	if m != nil {
+		return m.Revoke
+	}
+	return false
+}
+
+type SimulateAccessRequest struct {
+	// The resource to simulate the access to, such as `files/{file}`.
+	Parent string `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	// The ID of a user to simulate the access of, if empty the access of every user
+	// that has a permission to the file, or is affected by the changes, is simulated.
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// The changes to the permissions of the file to simulate.
+	Changes              []*AccessChange `protobuf:"bytes,3,rep,name=changes,proto3" json:"changes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *SimulateAccessRequest) Reset()         { *m = SimulateAccessRequest{} }
+func (m *SimulateAccessRequest) String() string { return proto.CompactTextString(m) }
+func (*SimulateAccessRequest) ProtoMessage()    {}
+func (*SimulateAccessRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{18}
+}
+
+func (m *SimulateAccessRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SimulateAccessRequest.Unmarshal(m, b)
+}
+func (m *SimulateAccessRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SimulateAccessRequest.Marshal(b, m, deterministic)
+}
+func (m *SimulateAccessRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SimulateAccessRequest.Merge(m, src)
+}
+func (m *SimulateAccessRequest) XXX_Size() int {
+	return xxx_messageInfo_SimulateAccessRequest.Size(m)
+}
+func (m *SimulateAccessRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SimulateAccessRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SimulateAccessRequest proto.InternalMessageInfo
+
+func (m *SimulateAccessRequest) GetParent() string {
+	if m != nil {
+		return m.Parent
+	}
+	return ""
+}
+
+func (m *SimulateAccessRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *SimulateAccessRequest) GetChanges() []*AccessChange {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+type SimulatedAccess struct {
+	// The ID of the user.
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// The current role of the user, ROLE_UNSPECIFIED if the user has no access.
+	CurrentRole Role `protobuf:"varint,2,opt,name=current_role,json=currentRole,proto3,enum=permissions.v2.Role" json:"current_role,omitempty"`
+	// The role the user would have after the changes, ROLE_UNSPECIFIED if the user would have no access.
+	SimulatedRole        Role     `protobuf:"varint,3,opt,name=simulated_role,json=simulatedRole,proto3,enum=permissions.v2.Role" json:"simulated_role,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SimulatedAccess) Reset()         { *m = SimulatedAccess{} }
+func (m *SimulatedAccess) String() string { return proto.CompactTextString(m) }
+func (*SimulatedAccess) ProtoMessage()    {}
+func (*SimulatedAccess) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{19}
+}
+
+func (m *SimulatedAccess) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SimulatedAccess.Unmarshal(m, b)
+}
+func (m *SimulatedAccess) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SimulatedAccess.Marshal(b, m, deterministic)
+}
+func (m *SimulatedAccess) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SimulatedAccess.Merge(m, src)
+}
+func (m *SimulatedAccess) XXX_Size() int {
+	return xxx_messageInfo_SimulatedAccess.Size(m)
+}
+func (m *SimulatedAccess) XXX_DiscardUnknown() {
+	xxx_messageInfo_SimulatedAccess.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SimulatedAccess proto.InternalMessageInfo
+
+func (m *SimulatedAccess) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *SimulatedAccess) GetCurrentRole() Role {
+	if m != nil {
+		return m.CurrentRole
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (m *SimulatedAccess) GetSimulatedRole() Role {
+	if m != nil {
+		return m.SimulatedRole
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+type SimulateAccessResponse struct {
+	// The simulated access of the users, ordered by user ID.
+	Accesses             []*SimulatedAccess `protobuf:"bytes,1,rep,name=accesses,proto3" json:"accesses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *SimulateAccessResponse) Reset()         { *m = SimulateAccessResponse{} }
+func (m *SimulateAccessResponse) String() string { return proto.CompactTextString(m) }
+func (*SimulateAccessResponse) ProtoMessage()    {}
+func (*SimulateAccessResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{20}
+}
+
+func (m *SimulateAccessResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SimulateAccessResponse.Unmarshal(m, b)
+}
+func (m *SimulateAccessResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SimulateAccessResponse.Marshal(b, m, deterministic)
+}
+func (m *SimulateAccessResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SimulateAccessResponse.Merge(m, src)
+}
+func (m *SimulateAccessResponse) XXX_Size() int {
+	return xxx_messageInfo_SimulateAccessResponse.Size(m)
+}
+func (m *SimulateAccessResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SimulateAccessResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SimulateAccessResponse proto.InternalMessageInfo
+
+func (m *SimulateAccessResponse) GetAccesses() []*SimulatedAccess {
+	if m != nil {
+		return m.Accesses
+	}
+	return nil
+}
+
+type PermissionsFilter struct {
+	// If set, only permissions of these files match.
+	FileIds []string `protobuf:"bytes,1,rep,name=file_ids,json=fileIds,proto3" json:"file_ids,omitempty"`
+	// If set, only permissions of these users match.
+	UserIds []string `protobuf:"bytes,2,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	// If set, only permissions created by this user match.
+	Creator string `protobuf:"bytes,3,opt,name=creator,proto3" json:"creator,omitempty"`
+	// If set, only permissions to resources of this type, such as "file", match.
+	ResourceType string `protobuf:"bytes,4,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"`
+	// If set, only permissions that have all of these labels match.
+	Labels map[string]string `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// If set, only permissions that were created by this system match.
+	Source               string   `protobuf:"bytes,6,opt,name=source,proto3" json:"source,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PermissionsFilter) Reset()         { *m = PermissionsFilter{} }
+func (m *PermissionsFilter) String() string { return proto.CompactTextString(m) }
+func (*PermissionsFilter) ProtoMessage()    {}
+func (*PermissionsFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{21}
+}
+
+func (m *PermissionsFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PermissionsFilter.Unmarshal(m, b)
+}
+func (m *PermissionsFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PermissionsFilter.Marshal(b, m, deterministic)
+}
+func (m *PermissionsFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PermissionsFilter.Merge(m, src)
+}
+func (m *PermissionsFilter) XXX_Size() int {
+	return xxx_messageInfo_PermissionsFilter.Size(m)
+}
+func (m *PermissionsFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_PermissionsFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PermissionsFilter proto.InternalMessageInfo
+
+func (m *PermissionsFilter) GetFileIds() []string {
+	if m != nil {
+		return m.FileIds
+	}
+	return nil
+}
+
+func (m *PermissionsFilter) GetUserIds() []string {
+	if m != nil {
+		return m.UserIds
+	}
+	return nil
+}
+
+func (m *PermissionsFilter) GetCreator() string {
+	if m != nil {
+		return m.Creator
+	}
+	return ""
+}
+
+func (m *PermissionsFilter) GetResourceType() string {
+	if m != nil {
+		return m.ResourceType
+	}
+	return ""
+}
+
+func (m *PermissionsFilter) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *PermissionsFilter) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+type MigrateRoleRequest struct {
+	// The role of the permissions to migrate.
+	FromRole Role `protobuf:"varint,1,opt,name=from_role,json=fromRole,proto3,enum=permissions.v2.Role" json:"from_role,omitempty"`
+	// The role to migrate the permissions to.
+	ToRole Role `protobuf:"varint,2,opt,name=to_role,json=toRole,proto3,enum=permissions.v2.Role" json:"to_role,omitempty"`
+	// Filters the permissions to migrate, all permissions with from_role are migrated if not set.
+	Filter *PermissionsFilter `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	// The number of permissions to migrate in each batch, the server chooses a default if not set.
+	BatchSize            int32    `protobuf:"varint,4,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MigrateRoleRequest) Reset()         { *m = MigrateRoleRequest{} }
+func (m *MigrateRoleRequest) String() string { return proto.CompactTextString(m) }
+func (*MigrateRoleRequest) ProtoMessage()    {}
+func (*MigrateRoleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{22}
+}
+
+func (m *MigrateRoleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MigrateRoleRequest.Unmarshal(m, b)
+}
+func (m *MigrateRoleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MigrateRoleRequest.Marshal(b, m, deterministic)
+}
+func (m *MigrateRoleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MigrateRoleRequest.Merge(m, src)
+}
+func (m *MigrateRoleRequest) XXX_Size() int {
+	return xxx_messageInfo_MigrateRoleRequest.Size(m)
+}
+func (m *MigrateRoleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MigrateRoleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MigrateRoleRequest proto.InternalMessageInfo
+
+func (m *MigrateRoleRequest) GetFromRole() Role {
+	if m != nil {
+		return m.FromRole
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (m *MigrateRoleRequest) GetToRole() Role {
+	if m != nil {
+		return m.ToRole
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (m *MigrateRoleRequest) GetFilter() *PermissionsFilter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+func (m *MigrateRoleRequest) GetBatchSize() int32 {
+	if m != nil {
+		return m.BatchSize
+	}
+	return 0
+}
+
+type MigrateRoleProgress struct {
+	// The number of permissions migrated so far.
+	Migrated int64 `protobuf:"varint,1,opt,name=migrated,proto3" json:"migrated,omitempty"`
+	// The number of permissions that matched the migration when it started.
+	Total                int64    `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MigrateRoleProgress) Reset()         { *m = MigrateRoleProgress{} }
+func (m *MigrateRoleProgress) String() string { return proto.CompactTextString(m) }
+func (*MigrateRoleProgress) ProtoMessage()    {}
+func (*MigrateRoleProgress) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{23}
+}
+
+func (m *MigrateRoleProgress) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MigrateRoleProgress.Unmarshal(m, b)
+}
+func (m *MigrateRoleProgress) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MigrateRoleProgress.Marshal(b, m, deterministic)
+}
+func (m *MigrateRoleProgress) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MigrateRoleProgress.Merge(m, src)
+}
+func (m *MigrateRoleProgress) XXX_Size() int {
+	return xxx_messageInfo_MigrateRoleProgress.Size(m)
+}
+func (m *MigrateRoleProgress) XXX_DiscardUnknown() {
+	xxx_messageInfo_MigrateRoleProgress.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MigrateRoleProgress proto.InternalMessageInfo
+
+func (m *MigrateRoleProgress) GetMigrated() int64 {
+	if m != nil {
+		return m.Migrated
+	}
+	return 0
+}
+
+func (m *MigrateRoleProgress) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+type ImportPermissionsRequest struct {
+	// The resource which owns the permission, such as `files/{file}`.
+	Parent string `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	// The permission to import, its user_id, role and creator are required.
+	Permission *Permission `protobuf:"bytes,2,opt,name=permission,proto3" json:"permission,omitempty"`
+	// Whether to override the permission if it already exists, otherwise the existing permission is kept.
+	// It's ignored if conflict_policy is set.
+	Override bool `protobuf:"varint,3,opt,name=override,proto3" json:"override,omitempty"`
+	// What's done if the permission already exists, it defaults to the policy of the job the request
+	// is imported by, if any, and otherwise to REPLACE if override is set and to SKIP if it isn't.
+	ConflictPolicy       ConflictPolicy `protobuf:"varint,4,opt,name=conflict_policy,json=conflictPolicy,proto3,enum=permissions.v2.ConflictPolicy" json:"conflict_policy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ImportPermissionsRequest) Reset()         { *m = ImportPermissionsRequest{} }
+func (m *ImportPermissionsRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportPermissionsRequest) ProtoMessage()    {}
+func (*ImportPermissionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{24}
+}
+
+func (m *ImportPermissionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportPermissionsRequest.Unmarshal(m, b)
+}
+func (m *ImportPermissionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportPermissionsRequest.Marshal(b, m, deterministic)
+}
+func (m *ImportPermissionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportPermissionsRequest.Merge(m, src)
+}
+func (m *ImportPermissionsRequest) XXX_Size() int {
+	return xxx_messageInfo_ImportPermissionsRequest.Size(m)
+}
+func (m *ImportPermissionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportPermissionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportPermissionsRequest proto.InternalMessageInfo
+
+func (m *ImportPermissionsRequest) GetParent() string {
+	if m != nil {
+		return m.Parent
+	}
+	return ""
+}
+
+func (m *ImportPermissionsRequest) GetPermission() *Permission {
+	if m != nil {
+		return m.Permission
+	}
+	return nil
+}
+
+func (m *ImportPermissionsRequest) GetOverride() bool {
+	if m != nil {
+		return m.Override
+	}
+	return false
+}
+
+func (m *ImportPermissionsRequest) GetConflictPolicy() ConflictPolicy {
+	if m != nil {
+		return m.ConflictPolicy
+	}
+	return ConflictPolicy_CONFLICT_POLICY_UNSPECIFIED
+}
+
+type ImportPermissionsProgress struct {
+	// The number of permissions imported so far.
+	Accepted int64 `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	// The number of permissions that failed to import so far.
+	Rejected int64 `protobuf:"varint,2,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	// The errors of the permissions that failed to import since the previous progress.
+	Errors []*ImportPermissionsProgress_RecordError `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`
+	// The results of the permissions that were imported since the previous progress.
+	Results              []*ImportPermissionsProgress_RecordResult `protobuf:"bytes,4,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                  `json:"-"`
+	XXX_unrecognized     []byte                                    `json:"-"`
+	XXX_sizecache        int32                                     `json:"-"`
+}
+
+func (m *ImportPermissionsProgress) Reset()         { *m = ImportPermissionsProgress{} }
+func (m *ImportPermissionsProgress) String() string { return proto.CompactTextString(m) }
+func (*ImportPermissionsProgress) ProtoMessage()    {}
+func (*ImportPermissionsProgress) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{25}
+}
+
+func (m *ImportPermissionsProgress) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportPermissionsProgress.Unmarshal(m, b)
+}
+func (m *ImportPermissionsProgress) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportPermissionsProgress.Marshal(b, m, deterministic)
+}
+func (m *ImportPermissionsProgress) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportPermissionsProgress.Merge(m, src)
+}
+func (m *ImportPermissionsProgress) XXX_Size() int {
+	return xxx_messageInfo_ImportPermissionsProgress.Size(m)
+}
+func (m *ImportPermissionsProgress) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportPermissionsProgress.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportPermissionsProgress proto.InternalMessageInfo
+
+func (m *ImportPermissionsProgress) GetAccepted() int64 {
+	if m != nil {
+		return m.Accepted
+	}
+	return 0
+}
+
+func (m *ImportPermissionsProgress) GetRejected() int64 {
+	if m != nil {
+		return m.Rejected
+	}
+	return 0
+}
+
+func (m *ImportPermissionsProgress) GetErrors() []*ImportPermissionsProgress_RecordError {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
+}
+
+func (m *ImportPermissionsProgress) GetResults() []*ImportPermissionsProgress_RecordResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+// The error of a permission that wasn't imported.
+type ImportPermissionsProgress_RecordError struct {
+	// The index of the permission's request in the stream, starting from 0.
+	Index int64 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// The gRPC status code of the error.
+	Code                 int32    `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
+	Message              string   `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportPermissionsProgress_RecordError) Reset()         { *m = ImportPermissionsProgress_RecordError{} }
+func (m *ImportPermissionsProgress_RecordError) String() string { return proto.CompactTextString(m) }
+func (*ImportPermissionsProgress_RecordError) ProtoMessage()    {}
+func (*ImportPermissionsProgress_RecordError) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{25, 0}
+}
+
+func (m *ImportPermissionsProgress_RecordError) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportPermissionsProgress_RecordError.Unmarshal(m, b)
+}
+func (m *ImportPermissionsProgress_RecordError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportPermissionsProgress_RecordError.Marshal(b, m, deterministic)
+}
+func (m *ImportPermissionsProgress_RecordError) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportPermissionsProgress_RecordError.Merge(m, src)
+}
+func (m *ImportPermissionsProgress_RecordError) XXX_Size() int {
+	return xxx_messageInfo_ImportPermissionsProgress_RecordError.Size(m)
+}
+func (m *ImportPermissionsProgress_RecordError) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportPermissionsProgress_RecordError.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportPermissionsProgress_RecordError proto.InternalMessageInfo
+
+func (m *ImportPermissionsProgress_RecordError) GetIndex() int64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *ImportPermissionsProgress_RecordError) GetCode() int32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *ImportPermissionsProgress_RecordError) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+// The result of a permission that was imported.
+type ImportPermissionsProgress_RecordResult struct {
+	// The index of the permission's request in the stream, starting from 0.
+	Index                int64                             `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Outcome              ImportPermissionsProgress_Outcome `protobuf:"varint,2,opt,name=outcome,proto3,enum=permissions.v2.ImportPermissionsProgress_Outcome" json:"outcome,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
+	XXX_unrecognized     []byte                            `json:"-"`
+	XXX_sizecache        int32                             `json:"-"`
+}
+
+func (m *ImportPermissionsProgress_RecordResult) Reset() {
+	*m = ImportPermissionsProgress_RecordResult{}
+}
+func (m *ImportPermissionsProgress_RecordResult) String() string { return proto.CompactTextString(m) }
+func (*ImportPermissionsProgress_RecordResult) ProtoMessage()    {}
+func (*ImportPermissionsProgress_RecordResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{25, 1}
+}
+
+func (m *ImportPermissionsProgress_RecordResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportPermissionsProgress_RecordResult.Unmarshal(m, b)
+}
+func (m *ImportPermissionsProgress_RecordResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportPermissionsProgress_RecordResult.Marshal(b, m, deterministic)
+}
+func (m *ImportPermissionsProgress_RecordResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportPermissionsProgress_RecordResult.Merge(m, src)
+}
+func (m *ImportPermissionsProgress_RecordResult) XXX_Size() int {
+	return xxx_messageInfo_ImportPermissionsProgress_RecordResult.Size(m)
+}
+func (m *ImportPermissionsProgress_RecordResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportPermissionsProgress_RecordResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportPermissionsProgress_RecordResult proto.InternalMessageInfo
+
+func (m *ImportPermissionsProgress_RecordResult) GetIndex() int64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *ImportPermissionsProgress_RecordResult) GetOutcome() ImportPermissionsProgress_Outcome {
+	if m != nil {
+		return m.Outcome
+	}
+	return ImportPermissionsProgress_OUTCOME_UNSPECIFIED
+}
+
+type GenerateUserDataReportRequest struct {
+	// The ID of the user whose data is reported.
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GenerateUserDataReportRequest) Reset()         { *m = GenerateUserDataReportRequest{} }
+func (m *GenerateUserDataReportRequest) String() string { return proto.CompactTextString(m) }
+func (*GenerateUserDataReportRequest) ProtoMessage()    {}
+func (*GenerateUserDataReportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{26}
+}
+
+func (m *GenerateUserDataReportRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GenerateUserDataReportRequest.Unmarshal(m, b)
+}
+func (m *GenerateUserDataReportRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GenerateUserDataReportRequest.Marshal(b, m, deterministic)
+}
+func (m *GenerateUserDataReportRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GenerateUserDataReportRequest.Merge(m, src)
+}
+func (m *GenerateUserDataReportRequest) XXX_Size() int {
+	return xxx_messageInfo_GenerateUserDataReportRequest.Size(m)
+}
+func (m *GenerateUserDataReportRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GenerateUserDataReportRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GenerateUserDataReportRequest proto.InternalMessageInfo
+
+func (m *GenerateUserDataReportRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+type UserDataRecord struct {
+	// Types that are valid to be assigned to Record:
+	//	*UserDataRecord_HeldPermission
+	//	*UserDataRecord_CreatedPermission
+	//	*UserDataRecord_AuditEntry
+	Record               isUserDataRecord_Record `protobuf_oneof:"record"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *UserDataRecord) Reset()         { *m = UserDataRecord{} }
+func (m *UserDataRecord) String() string { return proto.CompactTextString(m) }
+func (*UserDataRecord) ProtoMessage()    {}
+func (*UserDataRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{27}
+}
+
+func (m *UserDataRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UserDataRecord.Unmarshal(m, b)
+}
+func (m *UserDataRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UserDataRecord.Marshal(b, m, deterministic)
+}
+func (m *UserDataRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UserDataRecord.Merge(m, src)
+}
+func (m *UserDataRecord) XXX_Size() int {
+	return xxx_messageInfo_UserDataRecord.Size(m)
+}
+func (m *UserDataRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_UserDataRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UserDataRecord proto.InternalMessageInfo
+
+type isUserDataRecord_Record interface {
+	isUserDataRecord_Record()
+}
+
+type UserDataRecord_HeldPermission struct {
+	HeldPermission *Permission `protobuf:"bytes,1,opt,name=held_permission,json=heldPermission,proto3,oneof"`
+}
+
+type UserDataRecord_CreatedPermission struct {
+	CreatedPermission *Permission `protobuf:"bytes,2,opt,name=created_permission,json=createdPermission,proto3,oneof"`
+}
+
+type UserDataRecord_AuditEntry struct {
+	AuditEntry *AuditEntry `protobuf:"bytes,3,opt,name=audit_entry,json=auditEntry,proto3,oneof"`
+}
+
+func (*UserDataRecord_HeldPermission) isUserDataRecord_Record() {}
+
+func (*UserDataRecord_CreatedPermission) isUserDataRecord_Record() {}
+
+func (*UserDataRecord_AuditEntry) isUserDataRecord_Record() {}
+
+func (m *UserDataRecord) GetRecord() isUserDataRecord_Record {
+	if m != nil {
+		return m.Record
+	}
+	return nil
+}
+
+func (m *UserDataRecord) GetHeldPermission() *Permission {
+	if x, ok := m.GetRecord().(*UserDataRecord_HeldPermission); ok {
+		return x.HeldPermission
+	}
+	return nil
+}
+
+func (m *UserDataRecord) GetCreatedPermission() *Permission {
+	if x, ok := m.GetRecord().(*UserDataRecord_CreatedPermission); ok {
+		return x.CreatedPermission
+	}
+	return nil
+}
+
+func (m *UserDataRecord) GetAuditEntry() *AuditEntry {
+	if x, ok := m.GetRecord().(*UserDataRecord_AuditEntry); ok {
+		return x.AuditEntry
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*UserDataRecord) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*UserDataRecord_HeldPermission)(nil),
+		(*UserDataRecord_CreatedPermission)(nil),
+		(*UserDataRecord_AuditEntry)(nil),
+	}
+}
+
+type AuditEntry struct {
+	// The ID of the audit entry.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The type of the change, one of "created", "updated" and "deleted".
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// The permission after the change, or before it if it was deleted.
+	Permission           *Permission          `protobuf:"bytes,3,opt,name=permission,proto3" json:"permission,omitempty"`
+	OccurredAt           *timestamp.Timestamp `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *AuditEntry) Reset()         { *m = AuditEntry{} }
+func (m *AuditEntry) String() string { return proto.CompactTextString(m) }
+func (*AuditEntry) ProtoMessage()    {}
+func (*AuditEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{28}
+}
+
+func (m *AuditEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditEntry.Unmarshal(m, b)
+}
+func (m *AuditEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditEntry.Marshal(b, m, deterministic)
+}
+func (m *AuditEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditEntry.Merge(m, src)
+}
+func (m *AuditEntry) XXX_Size() int {
+	return xxx_messageInfo_AuditEntry.Size(m)
+}
+func (m *AuditEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditEntry proto.InternalMessageInfo
+
+func (m *AuditEntry) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *AuditEntry) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *AuditEntry) GetPermission() *Permission {
+	if m != nil {
+		return m.Permission
+	}
+	return nil
+}
+
+func (m *AuditEntry) GetOccurredAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return nil
+}
+
+type EraseUserDataRequest struct {
+	// The ID of the user whose data is erased.
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EraseUserDataRequest) Reset()         { *m = EraseUserDataRequest{} }
+func (m *EraseUserDataRequest) String() string { return proto.CompactTextString(m) }
+func (*EraseUserDataRequest) ProtoMessage()    {}
+func (*EraseUserDataRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{29}
+}
+
+func (m *EraseUserDataRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EraseUserDataRequest.Unmarshal(m, b)
+}
+func (m *EraseUserDataRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EraseUserDataRequest.Marshal(b, m, deterministic)
+}
+func (m *EraseUserDataRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EraseUserDataRequest.Merge(m, src)
+}
+func (m *EraseUserDataRequest) XXX_Size() int {
+	return xxx_messageInfo_EraseUserDataRequest.Size(m)
+}
+func (m *EraseUserDataRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EraseUserDataRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EraseUserDataRequest proto.InternalMessageInfo
+
+func (m *EraseUserDataRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+type EraseUserDataResponse struct {
+	// The number of permissions of the user that were deleted.
+	DeletedPermissions int64 `protobuf:"varint,1,opt,name=deleted_permissions,json=deletedPermissions,proto3" json:"deleted_permissions,omitempty"`
+	// The number of permissions that the user was replaced in, as their creator or in their sharing chain.
+	AnonymizedPermissions int64 `protobuf:"varint,2,opt,name=anonymized_permissions,json=anonymizedPermissions,proto3" json:"anonymized_permissions,omitempty"`
+	// The number of audit entries that reference the user and are retained until their retention expires.
+	RetainedAuditEntries int64    `protobuf:"varint,3,opt,name=retained_audit_entries,json=retainedAuditEntries,proto3" json:"retained_audit_entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EraseUserDataResponse) Reset()         { *m = EraseUserDataResponse{} }
+func (m *EraseUserDataResponse) String() string { return proto.CompactTextString(m) }
+func (*EraseUserDataResponse) ProtoMessage()    {}
+func (*EraseUserDataResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{30}
+}
+
+func (m *EraseUserDataResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EraseUserDataResponse.Unmarshal(m, b)
+}
+func (m *EraseUserDataResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EraseUserDataResponse.Marshal(b, m, deterministic)
+}
+func (m *EraseUserDataResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EraseUserDataResponse.Merge(m, src)
+}
+func (m *EraseUserDataResponse) XXX_Size() int {
+	return xxx_messageInfo_EraseUserDataResponse.Size(m)
+}
+func (m *EraseUserDataResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EraseUserDataResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EraseUserDataResponse proto.InternalMessageInfo
+
+func (m *EraseUserDataResponse) GetDeletedPermissions() int64 {
+	if m != nil {
+		return m.DeletedPermissions
+	}
+	return 0
+}
+
+func (m *EraseUserDataResponse) GetAnonymizedPermissions() int64 {
+	if m != nil {
+		return m.AnonymizedPermissions
+	}
+	return 0
+}
+
+func (m *EraseUserDataResponse) GetRetainedAuditEntries() int64 {
+	if m != nil {
+		return m.RetainedAuditEntries
+	}
+	return 0
+}
+
+type ListDomainPermissionsRequest struct {
+	// The domain of the organization whose permissions are listed, all domains are listed if not set.
+	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	// The maximum number of permissions to return, the server may return fewer.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// The next_page_token of a previous ListDomainPermissions call.
+	PageToken            string   `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListDomainPermissionsRequest) Reset()         { *m = ListDomainPermissionsRequest{} }
+func (m *ListDomainPermissionsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListDomainPermissionsRequest) ProtoMessage()    {}
+func (*ListDomainPermissionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{31}
+}
+
+func (m *ListDomainPermissionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDomainPermissionsRequest.Unmarshal(m, b)
+}
+func (m *ListDomainPermissionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDomainPermissionsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListDomainPermissionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDomainPermissionsRequest.Merge(m, src)
+}
+func (m *ListDomainPermissionsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListDomainPermissionsRequest.Size(m)
+}
+func (m *ListDomainPermissionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDomainPermissionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDomainPermissionsRequest proto.InternalMessageInfo
+
+func (m *ListDomainPermissionsRequest) GetDomain() string {
+	if m != nil {
+		return m.Domain
+	}
+	return ""
+}
+
+func (m *ListDomainPermissionsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListDomainPermissionsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type ListDomainPermissionsResponse struct {
+	// The permissions that were given to organizations.
+	Permissions []*Permission `protobuf:"bytes,1,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	// A token to retrieve the next page, empty if there are no more pages.
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListDomainPermissionsResponse) Reset()         { *m = ListDomainPermissionsResponse{} }
+func (m *ListDomainPermissionsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListDomainPermissionsResponse) ProtoMessage()    {}
+func (*ListDomainPermissionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{32}
+}
+
+func (m *ListDomainPermissionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDomainPermissionsResponse.Unmarshal(m, b)
+}
+func (m *ListDomainPermissionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDomainPermissionsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListDomainPermissionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDomainPermissionsResponse.Merge(m, src)
+}
+func (m *ListDomainPermissionsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListDomainPermissionsResponse.Size(m)
+}
+func (m *ListDomainPermissionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDomainPermissionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDomainPermissionsResponse proto.InternalMessageInfo
+
+func (m *ListDomainPermissionsResponse) GetPermissions() []*Permission {
+	if m != nil {
+		return m.Permissions
+	}
+	return nil
+}
+
+func (m *ListDomainPermissionsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type ListAnomalyAlertsRequest struct {
+	// Only the alerts of kind are listed if set: "mass_grants", "mass_revocations" or "external_sharing".
+	Kind                 string   `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAnomalyAlertsRequest) Reset()         { *m = ListAnomalyAlertsRequest{} }
+func (m *ListAnomalyAlertsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListAnomalyAlertsRequest) ProtoMessage()    {}
+func (*ListAnomalyAlertsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{33}
+}
+
+func (m *ListAnomalyAlertsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAnomalyAlertsRequest.Unmarshal(m, b)
+}
+func (m *ListAnomalyAlertsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAnomalyAlertsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListAnomalyAlertsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAnomalyAlertsRequest.Merge(m, src)
+}
+func (m *ListAnomalyAlertsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListAnomalyAlertsRequest.Size(m)
+}
+func (m *ListAnomalyAlertsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAnomalyAlertsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAnomalyAlertsRequest proto.InternalMessageInfo
+
+func (m *ListAnomalyAlertsRequest) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+type AnomalyAlert struct {
+	// The kind of the pattern: "mass_grants", "mass_revocations" or "external_sharing".
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	// The actor that made the changes, or "*" for the changes of all actors.
+	Subject string `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	// The number of changes that were made within the window when the alert was raised.
+	Count int64 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	// The window of the sliding-window counter of the changes.
+	Window *duration.Duration `protobuf:"bytes,4,opt,name=window,proto3" json:"window,omitempty"`
+	// The time at which the alert was raised.
+	DetectTime           *timestamp.Timestamp `protobuf:"bytes,5,opt,name=detect_time,json=detectTime,proto3" json:"detect_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *AnomalyAlert) Reset()         { *m = AnomalyAlert{} }
+func (m *AnomalyAlert) String() string { return proto.CompactTextString(m) }
+func (*AnomalyAlert) ProtoMessage()    {}
+func (*AnomalyAlert) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{34}
+}
+
+func (m *AnomalyAlert) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AnomalyAlert.Unmarshal(m, b)
+}
+func (m *AnomalyAlert) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AnomalyAlert.Marshal(b, m, deterministic)
+}
+func (m *AnomalyAlert) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AnomalyAlert.Merge(m, src)
+}
+func (m *AnomalyAlert) XXX_Size() int {
+	return xxx_messageInfo_AnomalyAlert.Size(m)
+}
+func (m *AnomalyAlert) XXX_DiscardUnknown() {
+	xxx_messageInfo_AnomalyAlert.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AnomalyAlert proto.InternalMessageInfo
+
+func (m *AnomalyAlert) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *AnomalyAlert) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+func (m *AnomalyAlert) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *AnomalyAlert) GetWindow() *duration.Duration {
+	if m != nil {
+		return m.Window
+	}
+	return nil
+}
+
+func (m *AnomalyAlert) GetDetectTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.DetectTime
+	}
+	return nil
+}
+
+type ListAnomalyAlertsResponse struct {
+	// The alerts, newest first.
+	Alerts               []*AnomalyAlert `protobuf:"bytes,1,rep,name=alerts,proto3" json:"alerts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ListAnomalyAlertsResponse) Reset()         { *m = ListAnomalyAlertsResponse{} }
+func (m *ListAnomalyAlertsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListAnomalyAlertsResponse) ProtoMessage()    {}
+func (*ListAnomalyAlertsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{35}
+}
+
+func (m *ListAnomalyAlertsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAnomalyAlertsResponse.Unmarshal(m, b)
+}
+func (m *ListAnomalyAlertsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAnomalyAlertsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListAnomalyAlertsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAnomalyAlertsResponse.Merge(m, src)
+}
+func (m *ListAnomalyAlertsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListAnomalyAlertsResponse.Size(m)
+}
+func (m *ListAnomalyAlertsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAnomalyAlertsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAnomalyAlertsResponse proto.InternalMessageInfo
+
+func (m *ListAnomalyAlertsResponse) GetAlerts() []*AnomalyAlert {
+	if m != nil {
+		return m.Alerts
+	}
+	return nil
+}
+
+type LockFileRequest struct {
+	// The resource to lock down, such as `files/{file}`.
+	Resource string `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	// The user whose access to the resource isn't suspended, the resource's owner according
+	// to the file service if not set.
+	OwnerId string `protobuf:"bytes,2,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	// Why the resource is locked down, such as the incident's ID.
+	Reason               string   `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LockFileRequest) Reset()         { *m = LockFileRequest{} }
+func (m *LockFileRequest) String() string { return proto.CompactTextString(m) }
+func (*LockFileRequest) ProtoMessage()    {}
+func (*LockFileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{36}
+}
+
+func (m *LockFileRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LockFileRequest.Unmarshal(m, b)
+}
+func (m *LockFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LockFileRequest.Marshal(b, m, deterministic)
+}
+func (m *LockFileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LockFileRequest.Merge(m, src)
+}
+func (m *LockFileRequest) XXX_Size() int {
+	return xxx_messageInfo_LockFileRequest.Size(m)
+}
+func (m *LockFileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LockFileRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LockFileRequest proto.InternalMessageInfo
+
+func (m *LockFileRequest) GetResource() string {
+	if m != nil {
+		return m.Resource
+	}
+	return ""
+}
+
+func (m *LockFileRequest) GetOwnerId() string {
+	if m != nil {
+		return m.OwnerId
+	}
+	return ""
+}
+
+func (m *LockFileRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type UnlockFileRequest struct {
+	// The locked resource, such as `files/{file}`.
+	Resource             string   `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnlockFileRequest) Reset()         { *m = UnlockFileRequest{} }
+func (m *UnlockFileRequest) String() string { return proto.CompactTextString(m) }
+func (*UnlockFileRequest) ProtoMessage()    {}
+func (*UnlockFileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{37}
+}
+
+func (m *UnlockFileRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnlockFileRequest.Unmarshal(m, b)
+}
+func (m *UnlockFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnlockFileRequest.Marshal(b, m, deterministic)
+}
+func (m *UnlockFileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnlockFileRequest.Merge(m, src)
+}
+func (m *UnlockFileRequest) XXX_Size() int {
+	return xxx_messageInfo_UnlockFileRequest.Size(m)
+}
+func (m *UnlockFileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnlockFileRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnlockFileRequest proto.InternalMessageInfo
+
+func (m *UnlockFileRequest) GetResource() string {
+	if m != nil {
+		return m.Resource
+	}
+	return ""
+}
+
+type FileLock struct {
+	// The locked resource, such as `files/{file}`.
+	Resource string `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	// The user whose access to the resource isn't suspended.
+	OwnerId string `protobuf:"bytes,2,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	// Why the resource is locked down.
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	// The actor that locked the resource down.
+	LockedBy string `protobuf:"bytes,4,opt,name=locked_by,json=lockedBy,proto3" json:"locked_by,omitempty"`
+	// The time at which the resource was locked down.
+	LockTime             *timestamp.Timestamp `protobuf:"bytes,5,opt,name=lock_time,json=lockTime,proto3" json:"lock_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *FileLock) Reset()         { *m = FileLock{} }
+func (m *FileLock) String() string { return proto.CompactTextString(m) }
+func (*FileLock) ProtoMessage()    {}
+func (*FileLock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{38}
+}
+
+func (m *FileLock) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileLock.Unmarshal(m, b)
+}
+func (m *FileLock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileLock.Marshal(b, m, deterministic)
+}
+func (m *FileLock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileLock.Merge(m, src)
+}
+func (m *FileLock) XXX_Size() int {
+	return xxx_messageInfo_FileLock.Size(m)
+}
+func (m *FileLock) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileLock.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileLock proto.InternalMessageInfo
+
+func (m *FileLock) GetResource() string {
+	if m != nil {
+		return m.Resource
+	}
+	return ""
+}
+
+func (m *FileLock) GetOwnerId() string {
+	if m != nil {
+		return m.OwnerId
+	}
+	return ""
+}
+
+func (m *FileLock) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *FileLock) GetLockedBy() string {
+	if m != nil {
+		return m.LockedBy
+	}
+	return ""
+}
+
+func (m *FileLock) GetLockTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.LockTime
+	}
+	return nil
+}
+
+type PlaceLegalHoldRequest struct {
+	// The resource to hold, such as `files/{file}`.
+	Resource string `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	// Why the resource is held, such as the litigation's case number.
+	Reason               string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PlaceLegalHoldRequest) Reset()         { *m = PlaceLegalHoldRequest{} }
+func (m *PlaceLegalHoldRequest) String() string { return proto.CompactTextString(m) }
+func (*PlaceLegalHoldRequest) ProtoMessage()    {}
+func (*PlaceLegalHoldRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{39}
+}
+
+func (m *PlaceLegalHoldRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlaceLegalHoldRequest.Unmarshal(m, b)
+}
+func (m *PlaceLegalHoldRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlaceLegalHoldRequest.Marshal(b, m, deterministic)
+}
+func (m *PlaceLegalHoldRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlaceLegalHoldRequest.Merge(m, src)
+}
+func (m *PlaceLegalHoldRequest) XXX_Size() int {
+	return xxx_messageInfo_PlaceLegalHoldRequest.Size(m)
+}
+func (m *PlaceLegalHoldRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlaceLegalHoldRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlaceLegalHoldRequest proto.InternalMessageInfo
+
+func (m *PlaceLegalHoldRequest) GetResource() string {
+	if m != nil {
+		return m.Resource
+	}
+	return ""
+}
+
+func (m *PlaceLegalHoldRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type ReleaseLegalHoldRequest struct {
+	// The held resource, such as `files/{file}`.
+	Resource             string   `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReleaseLegalHoldRequest) Reset()         { *m = ReleaseLegalHoldRequest{} }
+func (m *ReleaseLegalHoldRequest) String() string { return proto.CompactTextString(m) }
+func (*ReleaseLegalHoldRequest) ProtoMessage()    {}
+func (*ReleaseLegalHoldRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{40}
+}
+
+func (m *ReleaseLegalHoldRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReleaseLegalHoldRequest.Unmarshal(m, b)
+}
+func (m *ReleaseLegalHoldRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReleaseLegalHoldRequest.Marshal(b, m, deterministic)
+}
+func (m *ReleaseLegalHoldRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReleaseLegalHoldRequest.Merge(m, src)
+}
+func (m *ReleaseLegalHoldRequest) XXX_Size() int {
+	return xxx_messageInfo_ReleaseLegalHoldRequest.Size(m)
+}
+func (m *ReleaseLegalHoldRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReleaseLegalHoldRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReleaseLegalHoldRequest proto.InternalMessageInfo
+
+func (m *ReleaseLegalHoldRequest) GetResource() string {
+	if m != nil {
+		return m.Resource
+	}
+	return ""
+}
+
+type LegalHold struct {
+	// The held resource, such as `files/{file}`.
+	Resource string `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	// Why the resource is held.
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// The actor that placed the hold.
+	PlacedBy string `protobuf:"bytes,3,opt,name=placed_by,json=placedBy,proto3" json:"placed_by,omitempty"`
+	// The time at which the hold was placed.
+	PlaceTime            *timestamp.Timestamp `protobuf:"bytes,4,opt,name=place_time,json=placeTime,proto3" json:"place_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *LegalHold) Reset()         { *m = LegalHold{} }
+func (m *LegalHold) String() string { return proto.CompactTextString(m) }
+func (*LegalHold) ProtoMessage()    {}
+func (*LegalHold) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{41}
+}
+
+func (m *LegalHold) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LegalHold.Unmarshal(m, b)
+}
+func (m *LegalHold) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LegalHold.Marshal(b, m, deterministic)
+}
+func (m *LegalHold) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LegalHold.Merge(m, src)
+}
+func (m *LegalHold) XXX_Size() int {
+	return xxx_messageInfo_LegalHold.Size(m)
+}
+func (m *LegalHold) XXX_DiscardUnknown() {
+	xxx_messageInfo_LegalHold.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LegalHold proto.InternalMessageInfo
+
+func (m *LegalHold) GetResource() string {
+	if m != nil {
+		return m.Resource
+	}
+	return ""
+}
+
+func (m *LegalHold) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *LegalHold) GetPlacedBy() string {
+	if m != nil {
+		return m.PlacedBy
+	}
+	return ""
+}
+
+func (m *LegalHold) GetPlaceTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.PlaceTime
+	}
+	return nil
+}
+
+type GetServerInfoRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetServerInfoRequest) Reset()         { *m = GetServerInfoRequest{} }
+func (m *GetServerInfoRequest) String() string { return proto.CompactTextString(m) }
+func (*GetServerInfoRequest) ProtoMessage()    {}
+func (*GetServerInfoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{42}
+}
+
+func (m *GetServerInfoRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetServerInfoRequest.Unmarshal(m, b)
+}
+func (m *GetServerInfoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetServerInfoRequest.Marshal(b, m, deterministic)
+}
+func (m *GetServerInfoRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetServerInfoRequest.Merge(m, src)
+}
+func (m *GetServerInfoRequest) XXX_Size() int {
+	return xxx_messageInfo_GetServerInfoRequest.Size(m)
+}
+func (m *GetServerInfoRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetServerInfoRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetServerInfoRequest proto.InternalMessageInfo
+
+type DeletePermissionsJob struct {
+	// The resources whose permissions are deleted, such as `files/{file}`.
+	Resources            []string `protobuf:"bytes,1,rep,name=resources,proto3" json:"resources,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeletePermissionsJob) Reset()         { *m = DeletePermissionsJob{} }
+func (m *DeletePermissionsJob) String() string { return proto.CompactTextString(m) }
+func (*DeletePermissionsJob) ProtoMessage()    {}
+func (*DeletePermissionsJob) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{43}
+}
+
+func (m *DeletePermissionsJob) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeletePermissionsJob.Unmarshal(m, b)
+}
+func (m *DeletePermissionsJob) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeletePermissionsJob.Marshal(b, m, deterministic)
+}
+func (m *DeletePermissionsJob) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeletePermissionsJob.Merge(m, src)
+}
+func (m *DeletePermissionsJob) XXX_Size() int {
+	return xxx_messageInfo_DeletePermissionsJob.Size(m)
+}
+func (m *DeletePermissionsJob) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeletePermissionsJob.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeletePermissionsJob proto.InternalMessageInfo
+
+func (m *DeletePermissionsJob) GetResources() []string {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
+type ImportPermissionsJob struct {
+	// The permissions to import, each is imported as ImportPermissions imports its request.
+	Records []*ImportPermissionsRequest `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	// What's done with the records that already exist and don't set their own conflict_policy.
+	ConflictPolicy       ConflictPolicy `protobuf:"varint,2,opt,name=conflict_policy,json=conflictPolicy,proto3,enum=permissions.v2.ConflictPolicy" json:"conflict_policy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ImportPermissionsJob) Reset()         { *m = ImportPermissionsJob{} }
+func (m *ImportPermissionsJob) String() string { return proto.CompactTextString(m) }
+func (*ImportPermissionsJob) ProtoMessage()    {}
+func (*ImportPermissionsJob) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{44}
+}
+
+func (m *ImportPermissionsJob) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportPermissionsJob.Unmarshal(m, b)
+}
+func (m *ImportPermissionsJob) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportPermissionsJob.Marshal(b, m, deterministic)
+}
+func (m *ImportPermissionsJob) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportPermissionsJob.Merge(m, src)
+}
+func (m *ImportPermissionsJob) XXX_Size() int {
+	return xxx_messageInfo_ImportPermissionsJob.Size(m)
+}
+func (m *ImportPermissionsJob) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportPermissionsJob.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportPermissionsJob proto.InternalMessageInfo
+
+func (m *ImportPermissionsJob) GetRecords() []*ImportPermissionsRequest {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
+func (m *ImportPermissionsJob) GetConflictPolicy() ConflictPolicy {
+	if m != nil {
+		return m.ConflictPolicy
+	}
+	return ConflictPolicy_CONFLICT_POLICY_UNSPECIFIED
+}
+
+type CollectGarbageJob struct {
+	// The files whose permissions are deleted if they no longer exist in the file service.
+	FileIds              []string `protobuf:"bytes,1,rep,name=file_ids,json=fileIds,proto3" json:"file_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CollectGarbageJob) Reset()         { *m = CollectGarbageJob{} }
+func (m *CollectGarbageJob) String() string { return proto.CompactTextString(m) }
+func (*CollectGarbageJob) ProtoMessage()    {}
+func (*CollectGarbageJob) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{45}
+}
+
+func (m *CollectGarbageJob) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CollectGarbageJob.Unmarshal(m, b)
+}
+func (m *CollectGarbageJob) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CollectGarbageJob.Marshal(b, m, deterministic)
+}
+func (m *CollectGarbageJob) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CollectGarbageJob.Merge(m, src)
+}
+func (m *CollectGarbageJob) XXX_Size() int {
+	return xxx_messageInfo_CollectGarbageJob.Size(m)
+}
+func (m *CollectGarbageJob) XXX_DiscardUnknown() {
+	xxx_messageInfo_CollectGarbageJob.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CollectGarbageJob proto.InternalMessageInfo
+
+func (m *CollectGarbageJob) GetFileIds() []string {
+	if m != nil {
+		return m.FileIds
+	}
+	return nil
+}
+
+type UpgradeSchemaJob struct {
+	// The number of permissions to upgrade in each batch, the server chooses a default if not set.
+	BatchSize            int32    `protobuf:"varint,1,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpgradeSchemaJob) Reset()         { *m = UpgradeSchemaJob{} }
+func (m *UpgradeSchemaJob) String() string { return proto.CompactTextString(m) }
+func (*UpgradeSchemaJob) ProtoMessage()    {}
+func (*UpgradeSchemaJob) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{46}
+}
+
+func (m *UpgradeSchemaJob) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpgradeSchemaJob.Unmarshal(m, b)
+}
+func (m *UpgradeSchemaJob) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpgradeSchemaJob.Marshal(b, m, deterministic)
+}
+func (m *UpgradeSchemaJob) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpgradeSchemaJob.Merge(m, src)
+}
+func (m *UpgradeSchemaJob) XXX_Size() int {
+	return xxx_messageInfo_UpgradeSchemaJob.Size(m)
+}
+func (m *UpgradeSchemaJob) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpgradeSchemaJob.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpgradeSchemaJob proto.InternalMessageInfo
+
+func (m *UpgradeSchemaJob) GetBatchSize() int32 {
+	if m != nil {
+		return m.BatchSize
+	}
+	return 0
+}
+
+type CreateJobRequest struct {
+	// The operation of the job.
+	//
+	// Types that are valid to be assigned to Operation:
+	//	*CreateJobRequest_DeletePermissions
+	//	*CreateJobRequest_ImportPermissions
+	//	*CreateJobRequest_MigrateRole
+	//	*CreateJobRequest_CollectGarbage
+	//	*CreateJobRequest_UpgradeSchema
+	Operation            isCreateJobRequest_Operation `protobuf_oneof:"operation"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *CreateJobRequest) Reset()         { *m = CreateJobRequest{} }
+func (m *CreateJobRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateJobRequest) ProtoMessage()    {}
+func (*CreateJobRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{47}
+}
+
+func (m *CreateJobRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateJobRequest.Unmarshal(m, b)
+}
+func (m *CreateJobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateJobRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateJobRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateJobRequest.Merge(m, src)
+}
+func (m *CreateJobRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateJobRequest.Size(m)
+}
+func (m *CreateJobRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateJobRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateJobRequest proto.InternalMessageInfo
+
+type isCreateJobRequest_Operation interface {
+	isCreateJobRequest_Operation()
+}
+
+type CreateJobRequest_DeletePermissions struct {
+	DeletePermissions *DeletePermissionsJob `protobuf:"bytes,1,opt,name=delete_permissions,json=deletePermissions,proto3,oneof"`
+}
+
+type CreateJobRequest_ImportPermissions struct {
+	ImportPermissions *ImportPermissionsJob `protobuf:"bytes,2,opt,name=import_permissions,json=importPermissions,proto3,oneof"`
+}
+
+type CreateJobRequest_MigrateRole struct {
+	MigrateRole *MigrateRoleRequest `protobuf:"bytes,3,opt,name=migrate_role,json=migrateRole,proto3,oneof"`
+}
+
+type CreateJobRequest_CollectGarbage struct {
+	CollectGarbage *CollectGarbageJob `protobuf:"bytes,4,opt,name=collect_garbage,json=collectGarbage,proto3,oneof"`
+}
+
+type CreateJobRequest_UpgradeSchema struct {
+	UpgradeSchema *UpgradeSchemaJob `protobuf:"bytes,5,opt,name=upgrade_schema,json=upgradeSchema,proto3,oneof"`
+}
+
+func (*CreateJobRequest_DeletePermissions) isCreateJobRequest_Operation() {}
+
+func (*CreateJobRequest_ImportPermissions) isCreateJobRequest_Operation() {}
+
+func (*CreateJobRequest_MigrateRole) isCreateJobRequest_Operation() {}
+
+func (*CreateJobRequest_CollectGarbage) isCreateJobRequest_Operation() {}
+
+func (*CreateJobRequest_UpgradeSchema) isCreateJobRequest_Operation() {}
+
+func (m *CreateJobRequest) GetOperation() isCreateJobRequest_Operation {
+	if m != nil {
+		return m.Operation
+	}
+	return nil
+}
+
+func (m *CreateJobRequest) GetDeletePermissions() *DeletePermissionsJob {
+	if x, ok := m.GetOperation().(*CreateJobRequest_DeletePermissions); ok {
+		return x.DeletePermissions
+	}
+	return nil
+}
+
+func (m *CreateJobRequest) GetImportPermissions() *ImportPermissionsJob {
+	if x, ok := m.GetOperation().(*CreateJobRequest_ImportPermissions); ok {
+		return x.ImportPermissions
+	}
+	return nil
+}
+
+func (m *CreateJobRequest) GetMigrateRole() *MigrateRoleRequest {
+	if x, ok := m.GetOperation().(*CreateJobRequest_MigrateRole); ok {
+		return x.MigrateRole
+	}
+	return nil
+}
+
+func (m *CreateJobRequest) GetCollectGarbage() *CollectGarbageJob {
+	if x, ok := m.GetOperation().(*CreateJobRequest_CollectGarbage); ok {
+		return x.CollectGarbage
+	}
+	return nil
+}
+
+func (m *CreateJobRequest) GetUpgradeSchema() *UpgradeSchemaJob {
+	if x, ok := m.GetOperation().(*CreateJobRequest_UpgradeSchema); ok {
+		return x.UpgradeSchema
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*CreateJobRequest) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*CreateJobRequest_DeletePermissions)(nil),
+		(*CreateJobRequest_ImportPermissions)(nil),
+		(*CreateJobRequest_MigrateRole)(nil),
+		(*CreateJobRequest_CollectGarbage)(nil),
+		(*CreateJobRequest_UpgradeSchema)(nil),
+	}
+}
+
+type Job struct {
+	// The resource name of the job, `jobs/{job}`.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The kind of the job's operation: "delete_permissions", "import_permissions",
+	// "migrate_role", "collect_garbage" or "upgrade_schema".
+	Kind  string   `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	State JobState `protobuf:"varint,3,opt,name=state,proto3,enum=permissions.v2.JobState" json:"state,omitempty"`
+	// The number of items, such as resources or records, that the job processed so far.
+	Done int64 `protobuf:"varint,4,opt,name=done,proto3" json:"done,omitempty"`
+	// The number of the processed items that failed, which don't fail the job.
+	Failed int64 `protobuf:"varint,5,opt,name=failed,proto3" json:"failed,omitempty"`
+	// The number of items that the job processes, if known.
+	Total int64 `protobuf:"varint,6,opt,name=total,proto3" json:"total,omitempty"`
+	// The error that failed the job, if it failed.
+	Error string `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	// The actor that created the job, or its calling service if there's no actor.
+	Creator string `protobuf:"bytes,8,opt,name=creator,proto3" json:"creator,omitempty"`
+	// Whether the job's cancellation was requested.
+	CancelRequested bool                 `protobuf:"varint,9,opt,name=cancel_requested,json=cancelRequested,proto3" json:"cancel_requested,omitempty"`
+	CreateTime      *timestamp.Timestamp `protobuf:"bytes,10,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// The time of the job's last progress update.
+	UpdateTime *timestamp.Timestamp `protobuf:"bytes,11,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	// The time at which the job finished, if it did.
+	EndTime              *timestamp.Timestamp `protobuf:"bytes,12,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Job) Reset()         { *m = Job{} }
+func (m *Job) String() string { return proto.CompactTextString(m) }
+func (*Job) ProtoMessage()    {}
+func (*Job) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{48}
+}
+
+func (m *Job) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Job.Unmarshal(m, b)
+}
+func (m *Job) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Job.Marshal(b, m, deterministic)
+}
+func (m *Job) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Job.Merge(m, src)
+}
+func (m *Job) XXX_Size() int {
+	return xxx_messageInfo_Job.Size(m)
+}
+func (m *Job) XXX_DiscardUnknown() {
+	xxx_messageInfo_Job.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Job proto.InternalMessageInfo
+
+func (m *Job) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Job) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *Job) GetState() JobState {
+	if m != nil {
+		return m.State
+	}
+	return JobState_JOB_STATE_UNSPECIFIED
+}
+
+func (m *Job) GetDone() int64 {
+	if m != nil {
+		return m.Done
+	}
+	return 0
+}
+
+func (m *Job) GetFailed() int64 {
+	if m != nil {
+		return m.Failed
+	}
+	return 0
+}
+
+func (m *Job) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *Job) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *Job) GetCreator() string {
+	if m != nil {
+		return m.Creator
+	}
+	return ""
+}
+
+func (m *Job) GetCancelRequested() bool {
+	if m != nil {
+		return m.CancelRequested
+	}
+	return false
+}
+
+func (m *Job) GetCreateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreateTime
+	}
+	return nil
+}
+
+func (m *Job) GetUpdateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdateTime
+	}
+	return nil
+}
+
+func (m *Job) GetEndTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.EndTime
+	}
+	return nil
+}
+
+type GetJobRequest struct {
+	// The resource name of the job, `jobs/{job}`.
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetJobRequest) Reset()         { *m = GetJobRequest{} }
+func (m *GetJobRequest) String() string { return proto.CompactTextString(m) }
+func (*GetJobRequest) ProtoMessage()    {}
+func (*GetJobRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{49}
+}
+
+func (m *GetJobRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetJobRequest.Unmarshal(m, b)
+}
+func (m *GetJobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetJobRequest.Marshal(b, m, deterministic)
+}
+func (m *GetJobRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetJobRequest.Merge(m, src)
+}
+func (m *GetJobRequest) XXX_Size() int {
+	return xxx_messageInfo_GetJobRequest.Size(m)
+}
+func (m *GetJobRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetJobRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetJobRequest proto.InternalMessageInfo
+
+func (m *GetJobRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type ListJobsRequest struct {
+	// Only the jobs of kind are listed if set.
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	// The maximum number of jobs to return, the server may return fewer.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// The next_page_token of a previous ListJobs call.
+	PageToken            string   `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListJobsRequest) Reset()         { *m = ListJobsRequest{} }
+func (m *ListJobsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListJobsRequest) ProtoMessage()    {}
+func (*ListJobsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{50}
+}
+
+func (m *ListJobsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListJobsRequest.Unmarshal(m, b)
+}
+func (m *ListJobsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListJobsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListJobsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListJobsRequest.Merge(m, src)
+}
+func (m *ListJobsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListJobsRequest.Size(m)
+}
+func (m *ListJobsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListJobsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListJobsRequest proto.InternalMessageInfo
+
+func (m *ListJobsRequest) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *ListJobsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListJobsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type ListJobsResponse struct {
+	// The jobs, newest first.
+	Jobs []*Job `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	// A token to retrieve the next page, empty if there are no more pages.
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListJobsResponse) Reset()         { *m = ListJobsResponse{} }
+func (m *ListJobsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListJobsResponse) ProtoMessage()    {}
+func (*ListJobsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{51}
+}
+
+func (m *ListJobsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListJobsResponse.Unmarshal(m, b)
+}
+func (m *ListJobsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListJobsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListJobsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListJobsResponse.Merge(m, src)
+}
+func (m *ListJobsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListJobsResponse.Size(m)
+}
+func (m *ListJobsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListJobsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListJobsResponse proto.InternalMessageInfo
+
+func (m *ListJobsResponse) GetJobs() []*Job {
+	if m != nil {
+		return m.Jobs
+	}
+	return nil
+}
+
+func (m *ListJobsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type CancelJobRequest struct {
+	// The resource name of the job, `jobs/{job}`.
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelJobRequest) Reset()         { *m = CancelJobRequest{} }
+func (m *CancelJobRequest) String() string { return proto.CompactTextString(m) }
+func (*CancelJobRequest) ProtoMessage()    {}
+func (*CancelJobRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{52}
+}
+
+func (m *CancelJobRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelJobRequest.Unmarshal(m, b)
+}
+func (m *CancelJobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelJobRequest.Marshal(b, m, deterministic)
+}
+func (m *CancelJobRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelJobRequest.Merge(m, src)
+}
+func (m *CancelJobRequest) XXX_Size() int {
+	return xxx_messageInfo_CancelJobRequest.Size(m)
+}
+func (m *CancelJobRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelJobRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelJobRequest proto.InternalMessageInfo
+
+func (m *CancelJobRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type ServerInfo struct {
+	// The version of the build, such as a git tag, "dev" if it wasn't set at build time.
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// The git commit of the build, if it was set at build time.
+	Commit string `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`
+	// The time the server was built at, if it was set at build time.
+	BuildTime *timestamp.Timestamp `protobuf:"bytes,3,opt,name=build_time,json=buildTime,proto3" json:"build_time,omitempty"`
+	// The version of Go that the server was built with.
+	GoVersion string `protobuf:"bytes,4,opt,name=go_version,json=goVersion,proto3" json:"go_version,omitempty"`
+	// The time the server was started at.
+	StartTime            *timestamp.Timestamp `protobuf:"bytes,5,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ServerInfo) Reset()         { *m = ServerInfo{} }
+func (m *ServerInfo) String() string { return proto.CompactTextString(m) }
+func (*ServerInfo) ProtoMessage()    {}
+func (*ServerInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{53}
+}
+
+func (m *ServerInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ServerInfo.Unmarshal(m, b)
+}
+func (m *ServerInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ServerInfo.Marshal(b, m, deterministic)
+}
+func (m *ServerInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServerInfo.Merge(m, src)
+}
+func (m *ServerInfo) XXX_Size() int {
+	return xxx_messageInfo_ServerInfo.Size(m)
+}
+func (m *ServerInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServerInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServerInfo proto.InternalMessageInfo
+
+func (m *ServerInfo) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *ServerInfo) GetCommit() string {
+	if m != nil {
+		return m.Commit
+	}
+	return ""
+}
+
+func (m *ServerInfo) GetBuildTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.BuildTime
+	}
+	return nil
+}
+
+func (m *ServerInfo) GetGoVersion() string {
+	if m != nil {
+		return m.GoVersion
+	}
+	return ""
+}
+
+func (m *ServerInfo) GetStartTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
+type ExplainAccessRequest struct {
+	// The resource to explain the access to, such as `files/{file}`.
+	Resource string `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	// The ID of the user whose access is explained.
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// The role that the access is checked with, READ if neither it nor the capability is set.
+	Role Role `protobuf:"varint,3,opt,name=role,proto3,enum=permissions.v2.Role" json:"role,omitempty"`
+	// The capability that the access is checked with instead of the role, if set.
+	Capability           Capability `protobuf:"varint,4,opt,name=capability,proto3,enum=permissions.v2.Capability" json:"capability,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ExplainAccessRequest) Reset()         { *m = ExplainAccessRequest{} }
+func (m *ExplainAccessRequest) String() string { return proto.CompactTextString(m) }
+func (*ExplainAccessRequest) ProtoMessage()    {}
+func (*ExplainAccessRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{54}
+}
+
+func (m *ExplainAccessRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExplainAccessRequest.Unmarshal(m, b)
+}
+func (m *ExplainAccessRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExplainAccessRequest.Marshal(b, m, deterministic)
+}
+func (m *ExplainAccessRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExplainAccessRequest.Merge(m, src)
+}
+func (m *ExplainAccessRequest) XXX_Size() int {
+	return xxx_messageInfo_ExplainAccessRequest.Size(m)
+}
+func (m *ExplainAccessRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExplainAccessRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExplainAccessRequest proto.InternalMessageInfo
+
+func (m *ExplainAccessRequest) GetResource() string {
+	if m != nil {
+		return m.Resource
+	}
+	return ""
+}
+
+func (m *ExplainAccessRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *ExplainAccessRequest) GetRole() Role {
+	if m != nil {
+		return m.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (m *ExplainAccessRequest) GetCapability() Capability {
+	if m != nil {
+		return m.Capability
+	}
+	return Capability_CAPABILITY_UNSPECIFIED
+}
+
+type AccessTraceStep struct {
+	// What was consulted: "lockdown", "grant", "inheritance", "reshare", "role" or "capability".
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	// The effect of the step on the decision.
+	Effect AccessTraceStep_Effect `protobuf:"varint,2,opt,name=effect,proto3,enum=permissions.v2.AccessTraceStep_Effect" json:"effect,omitempty"`
+	// A human-readable description of what was found and why it had its effect.
+	Detail               string   `protobuf:"bytes,3,opt,name=detail,proto3" json:"detail,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AccessTraceStep) Reset()         { *m = AccessTraceStep{} }
+func (m *AccessTraceStep) String() string { return proto.CompactTextString(m) }
+func (*AccessTraceStep) ProtoMessage()    {}
+func (*AccessTraceStep) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{55}
+}
+
+func (m *AccessTraceStep) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccessTraceStep.Unmarshal(m, b)
+}
+func (m *AccessTraceStep) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccessTraceStep.Marshal(b, m, deterministic)
+}
+func (m *AccessTraceStep) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccessTraceStep.Merge(m, src)
+}
+func (m *AccessTraceStep) XXX_Size() int {
+	return xxx_messageInfo_AccessTraceStep.Size(m)
+}
+func (m *AccessTraceStep) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccessTraceStep.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccessTraceStep proto.InternalMessageInfo
+
+func (m *AccessTraceStep) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *AccessTraceStep) GetEffect() AccessTraceStep_Effect {
+	if m != nil {
+		return m.Effect
+	}
+	return AccessTraceStep_EFFECT_UNSPECIFIED
+}
+
+func (m *AccessTraceStep) GetDetail() string {
+	if m != nil {
+		return m.Detail
+	}
+	return ""
+}
+
+type AccessExplanation struct {
+	// Whether the access is permitted.
+	Permitted bool `protobuf:"varint,1,opt,name=permitted,proto3" json:"permitted,omitempty"`
+	// The steps of the evaluation, in the order they were consulted.
+	Steps                []*AccessTraceStep `protobuf:"bytes,2,rep,name=steps,proto3" json:"steps,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *AccessExplanation) Reset()         { *m = AccessExplanation{} }
+func (m *AccessExplanation) String() string { return proto.CompactTextString(m) }
+func (*AccessExplanation) ProtoMessage()    {}
+func (*AccessExplanation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{56}
+}
+
+func (m *AccessExplanation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccessExplanation.Unmarshal(m, b)
+}
+func (m *AccessExplanation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccessExplanation.Marshal(b, m, deterministic)
+}
+func (m *AccessExplanation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccessExplanation.Merge(m, src)
+}
+func (m *AccessExplanation) XXX_Size() int {
+	return xxx_messageInfo_AccessExplanation.Size(m)
+}
+func (m *AccessExplanation) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccessExplanation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccessExplanation proto.InternalMessageInfo
+
+func (m *AccessExplanation) GetPermitted() bool {
+	if m != nil {
+		return m.Permitted
+	}
+	return false
+}
+
+func (m *AccessExplanation) GetSteps() []*AccessTraceStep {
+	if m != nil {
+		return m.Steps
+	}
+	return nil
+}
+
+type RepairPermissionsRequest struct {
+	Action RepairPermissionsRequest_Action `protobuf:"varint,1,opt,name=action,proto3,enum=permissions.v2.RepairPermissionsRequest_Action" json:"action,omitempty"`
+	// The number of documents to repair in each batch, the server chooses a default if not set.
+	BatchSize            int32    `protobuf:"varint,2,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RepairPermissionsRequest) Reset()         { *m = RepairPermissionsRequest{} }
+func (m *RepairPermissionsRequest) String() string { return proto.CompactTextString(m) }
+func (*RepairPermissionsRequest) ProtoMessage()    {}
+func (*RepairPermissionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{57}
+}
+
+func (m *RepairPermissionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RepairPermissionsRequest.Unmarshal(m, b)
+}
+func (m *RepairPermissionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RepairPermissionsRequest.Marshal(b, m, deterministic)
+}
+func (m *RepairPermissionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RepairPermissionsRequest.Merge(m, src)
+}
+func (m *RepairPermissionsRequest) XXX_Size() int {
+	return xxx_messageInfo_RepairPermissionsRequest.Size(m)
+}
+func (m *RepairPermissionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RepairPermissionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RepairPermissionsRequest proto.InternalMessageInfo
+
+func (m *RepairPermissionsRequest) GetAction() RepairPermissionsRequest_Action {
+	if m != nil {
+		return m.Action
+	}
+	return RepairPermissionsRequest_REPORT
+}
+
+func (m *RepairPermissionsRequest) GetBatchSize() int32 {
+	if m != nil {
+		return m.BatchSize
+	}
+	return 0
+}
+
+type MalformedPermission struct {
+	// The ID of the malformed document.
+	Id      string                      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Problem MalformedPermission_Problem `protobuf:"varint,2,opt,name=problem,proto3,enum=permissions.v2.MalformedPermission_Problem" json:"problem,omitempty"`
+	// A human-readable description of the problem.
+	Detail               string                         `protobuf:"bytes,3,opt,name=detail,proto3" json:"detail,omitempty"`
+	Resolution           MalformedPermission_Resolution `protobuf:"varint,4,opt,name=resolution,proto3,enum=permissions.v2.MalformedPermission_Resolution" json:"resolution,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
+	XXX_unrecognized     []byte                         `json:"-"`
+	XXX_sizecache        int32                          `json:"-"`
+}
+
+func (m *MalformedPermission) Reset()         { *m = MalformedPermission{} }
+func (m *MalformedPermission) String() string { return proto.CompactTextString(m) }
+func (*MalformedPermission) ProtoMessage()    {}
+func (*MalformedPermission) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{58}
+}
+
+func (m *MalformedPermission) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MalformedPermission.Unmarshal(m, b)
+}
+func (m *MalformedPermission) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MalformedPermission.Marshal(b, m, deterministic)
+}
+func (m *MalformedPermission) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MalformedPermission.Merge(m, src)
+}
+func (m *MalformedPermission) XXX_Size() int {
+	return xxx_messageInfo_MalformedPermission.Size(m)
+}
+func (m *MalformedPermission) XXX_DiscardUnknown() {
+	xxx_messageInfo_MalformedPermission.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MalformedPermission proto.InternalMessageInfo
+
+func (m *MalformedPermission) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *MalformedPermission) GetProblem() MalformedPermission_Problem {
+	if m != nil {
+		return m.Problem
+	}
+	return MalformedPermission_PROBLEM_UNSPECIFIED
+}
+
+func (m *MalformedPermission) GetDetail() string {
+	if m != nil {
+		return m.Detail
+	}
+	return ""
+}
+
+func (m *MalformedPermission) GetResolution() MalformedPermission_Resolution {
+	if m != nil {
+		return m.Resolution
+	}
+	return MalformedPermission_REPORTED
+}
+
+type RepairPermissionsProgress struct {
+	// The number of malformed documents found so far.
+	Malformed int64 `protobuf:"varint,1,opt,name=malformed,proto3" json:"malformed,omitempty"`
+	// The number of malformed documents fixed so far.
+	Fixed int64 `protobuf:"varint,2,opt,name=fixed,proto3" json:"fixed,omitempty"`
+	// The number of malformed documents quarantined so far.
+	Quarantined int64 `protobuf:"varint,3,opt,name=quarantined,proto3" json:"quarantined,omitempty"`
+	// The malformed documents found since the previous progress.
+	Permissions          []*MalformedPermission `protobuf:"bytes,4,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *RepairPermissionsProgress) Reset()         { *m = RepairPermissionsProgress{} }
+func (m *RepairPermissionsProgress) String() string { return proto.CompactTextString(m) }
+func (*RepairPermissionsProgress) ProtoMessage()    {}
+func (*RepairPermissionsProgress) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{59}
+}
+
+func (m *RepairPermissionsProgress) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RepairPermissionsProgress.Unmarshal(m, b)
+}
+func (m *RepairPermissionsProgress) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RepairPermissionsProgress.Marshal(b, m, deterministic)
+}
+func (m *RepairPermissionsProgress) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RepairPermissionsProgress.Merge(m, src)
+}
+func (m *RepairPermissionsProgress) XXX_Size() int {
+	return xxx_messageInfo_RepairPermissionsProgress.Size(m)
+}
+func (m *RepairPermissionsProgress) XXX_DiscardUnknown() {
+	xxx_messageInfo_RepairPermissionsProgress.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RepairPermissionsProgress proto.InternalMessageInfo
+
+func (m *RepairPermissionsProgress) GetMalformed() int64 {
+	if m != nil {
+		return m.Malformed
+	}
+	return 0
+}
+
+func (m *RepairPermissionsProgress) GetFixed() int64 {
+	if m != nil {
+		return m.Fixed
+	}
+	return 0
+}
+
+func (m *RepairPermissionsProgress) GetQuarantined() int64 {
+	if m != nil {
+		return m.Quarantined
+	}
+	return 0
+}
+
+func (m *RepairPermissionsProgress) GetPermissions() []*MalformedPermission {
+	if m != nil {
+		return m.Permissions
+	}
+	return nil
+}
+
+type ExportTenantDataRequest struct {
+	// The ID of the tenant whose data is exported.
+	TenantId             string   `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportTenantDataRequest) Reset()         { *m = ExportTenantDataRequest{} }
+func (m *ExportTenantDataRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportTenantDataRequest) ProtoMessage()    {}
+func (*ExportTenantDataRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{60}
+}
+
+func (m *ExportTenantDataRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportTenantDataRequest.Unmarshal(m, b)
+}
+func (m *ExportTenantDataRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportTenantDataRequest.Marshal(b, m, deterministic)
+}
+func (m *ExportTenantDataRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportTenantDataRequest.Merge(m, src)
+}
+func (m *ExportTenantDataRequest) XXX_Size() int {
+	return xxx_messageInfo_ExportTenantDataRequest.Size(m)
+}
+func (m *ExportTenantDataRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportTenantDataRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportTenantDataRequest proto.InternalMessageInfo
+
+func (m *ExportTenantDataRequest) GetTenantId() string {
+	if m != nil {
+		return m.TenantId
+	}
+	return ""
+}
+
+type TenantDataRecord struct {
+	// The name of the collection of the document, without the tenant's prefix, such as "permissions".
+	Collection string `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
+	// The document as it's stored, in canonical extended JSON.
+	Document             string   `protobuf:"bytes,2,opt,name=document,proto3" json:"document,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TenantDataRecord) Reset()         { *m = TenantDataRecord{} }
+func (m *TenantDataRecord) String() string { return proto.CompactTextString(m) }
+func (*TenantDataRecord) ProtoMessage()    {}
+func (*TenantDataRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{61}
+}
+
+func (m *TenantDataRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TenantDataRecord.Unmarshal(m, b)
+}
+func (m *TenantDataRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TenantDataRecord.Marshal(b, m, deterministic)
+}
+func (m *TenantDataRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TenantDataRecord.Merge(m, src)
+}
+func (m *TenantDataRecord) XXX_Size() int {
+	return xxx_messageInfo_TenantDataRecord.Size(m)
+}
+func (m *TenantDataRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_TenantDataRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TenantDataRecord proto.InternalMessageInfo
+
+func (m *TenantDataRecord) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+func (m *TenantDataRecord) GetDocument() string {
+	if m != nil {
+		return m.Document
+	}
+	return ""
+}
+
+type PurgeTenantRequest struct {
+	// The ID of the tenant whose data is purged.
+	TenantId string `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// The token that a previous request of the purge returned, the purge is only previewed if it's not set.
+	ConfirmationToken    string   `protobuf:"bytes,2,opt,name=confirmation_token,json=confirmationToken,proto3" json:"confirmation_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PurgeTenantRequest) Reset()         { *m = PurgeTenantRequest{} }
+func (m *PurgeTenantRequest) String() string { return proto.CompactTextString(m) }
+func (*PurgeTenantRequest) ProtoMessage()    {}
+func (*PurgeTenantRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{62}
+}
+
+func (m *PurgeTenantRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PurgeTenantRequest.Unmarshal(m, b)
+}
+func (m *PurgeTenantRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PurgeTenantRequest.Marshal(b, m, deterministic)
+}
+func (m *PurgeTenantRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PurgeTenantRequest.Merge(m, src)
+}
+func (m *PurgeTenantRequest) XXX_Size() int {
+	return xxx_messageInfo_PurgeTenantRequest.Size(m)
+}
+func (m *PurgeTenantRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PurgeTenantRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PurgeTenantRequest proto.InternalMessageInfo
+
+func (m *PurgeTenantRequest) GetTenantId() string {
+	if m != nil {
+		return m.TenantId
+	}
+	return ""
+}
+
+func (m *PurgeTenantRequest) GetConfirmationToken() string {
+	if m != nil {
+		return m.ConfirmationToken
+	}
+	return ""
+}
+
+type PurgeTenantResponse struct {
+	// Whether the tenant's data was purged, rather than previewed.
+	Purged bool `protobuf:"varint,1,opt,name=purged,proto3" json:"purged,omitempty"`
+	// The token that confirms the purge, if it was previewed.
+	ConfirmationToken string `protobuf:"bytes,2,opt,name=confirmation_token,json=confirmationToken,proto3" json:"confirmation_token,omitempty"`
+	// The time that the confirmation token expires at, if the purge was previewed.
+	ExpireTime *timestamp.Timestamp `protobuf:"bytes,3,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
+	// The names of the tenant's collections, without the tenant's prefix.
+	Collections []string `protobuf:"bytes,4,rep,name=collections,proto3" json:"collections,omitempty"`
+	// The number of the tenant's documents that were, or would be, deleted.
+	Documents            int64    `protobuf:"varint,5,opt,name=documents,proto3" json:"documents,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PurgeTenantResponse) Reset()         { *m = PurgeTenantResponse{} }
+func (m *PurgeTenantResponse) String() string { return proto.CompactTextString(m) }
+func (*PurgeTenantResponse) ProtoMessage()    {}
+func (*PurgeTenantResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{63}
+}
+
+func (m *PurgeTenantResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PurgeTenantResponse.Unmarshal(m, b)
+}
+func (m *PurgeTenantResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PurgeTenantResponse.Marshal(b, m, deterministic)
+}
+func (m *PurgeTenantResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PurgeTenantResponse.Merge(m, src)
+}
+func (m *PurgeTenantResponse) XXX_Size() int {
+	return xxx_messageInfo_PurgeTenantResponse.Size(m)
+}
+func (m *PurgeTenantResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PurgeTenantResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PurgeTenantResponse proto.InternalMessageInfo
+
+func (m *PurgeTenantResponse) GetPurged() bool {
+	if m != nil {
+		return m.Purged
+	}
+	return false
+}
+
+func (m *PurgeTenantResponse) GetConfirmationToken() string {
+	if m != nil {
+		return m.ConfirmationToken
+	}
+	return ""
+}
+
+func (m *PurgeTenantResponse) GetExpireTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.ExpireTime
+	}
+	return nil
+}
+
+func (m *PurgeTenantResponse) GetCollections() []string {
+	if m != nil {
+		return m.Collections
+	}
+	return nil
+}
+
+func (m *PurgeTenantResponse) GetDocuments() int64 {
+	if m != nil {
+		return m.Documents
+	}
+	return 0
+}
+
+type GetTenantRateLimitsRequest struct {
+	// The ID of the tenant, the tenant of the server itself if not set.
+	TenantId             string   `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTenantRateLimitsRequest) Reset()         { *m = GetTenantRateLimitsRequest{} }
+func (m *GetTenantRateLimitsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTenantRateLimitsRequest) ProtoMessage()    {}
+func (*GetTenantRateLimitsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{64}
+}
+
+func (m *GetTenantRateLimitsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTenantRateLimitsRequest.Unmarshal(m, b)
+}
+func (m *GetTenantRateLimitsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTenantRateLimitsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetTenantRateLimitsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTenantRateLimitsRequest.Merge(m, src)
+}
+func (m *GetTenantRateLimitsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTenantRateLimitsRequest.Size(m)
+}
+func (m *GetTenantRateLimitsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTenantRateLimitsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTenantRateLimitsRequest proto.InternalMessageInfo
+
+func (m *GetTenantRateLimitsRequest) GetTenantId() string {
+	if m != nil {
+		return m.TenantId
+	}
+	return ""
+}
+
+type SetTenantRateLimitsRequest struct {
+	// The ID of the tenant, the tenant of the server itself if not set.
+	TenantId string `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// The maximum number of reads per second of each instance, unlimited if 0.
+	ReadsPerSecond int64 `protobuf:"varint,2,opt,name=reads_per_second,json=readsPerSecond,proto3" json:"reads_per_second,omitempty"`
+	// The maximum number of writes per second of each instance, unlimited if 0.
+	WritesPerSecond      int64    `protobuf:"varint,3,opt,name=writes_per_second,json=writesPerSecond,proto3" json:"writes_per_second,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetTenantRateLimitsRequest) Reset()         { *m = SetTenantRateLimitsRequest{} }
+func (m *SetTenantRateLimitsRequest) String() string { return proto.CompactTextString(m) }
+func (*SetTenantRateLimitsRequest) ProtoMessage()    {}
+func (*SetTenantRateLimitsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{65}
+}
+
+func (m *SetTenantRateLimitsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetTenantRateLimitsRequest.Unmarshal(m, b)
+}
+func (m *SetTenantRateLimitsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetTenantRateLimitsRequest.Marshal(b, m, deterministic)
+}
+func (m *SetTenantRateLimitsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetTenantRateLimitsRequest.Merge(m, src)
+}
+func (m *SetTenantRateLimitsRequest) XXX_Size() int {
+	return xxx_messageInfo_SetTenantRateLimitsRequest.Size(m)
+}
+func (m *SetTenantRateLimitsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetTenantRateLimitsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetTenantRateLimitsRequest proto.InternalMessageInfo
+
+func (m *SetTenantRateLimitsRequest) GetTenantId() string {
+	if m != nil {
+		return m.TenantId
+	}
+	return ""
+}
+
+func (m *SetTenantRateLimitsRequest) GetReadsPerSecond() int64 {
+	if m != nil {
+		return m.ReadsPerSecond
+	}
+	return 0
+}
+
+func (m *SetTenantRateLimitsRequest) GetWritesPerSecond() int64 {
+	if m != nil {
+		return m.WritesPerSecond
+	}
+	return 0
+}
+
+type TenantRateLimits struct {
+	// The ID of the tenant, empty for the tenant of the server itself.
+	TenantId string `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// The maximum number of reads per second of each instance, unlimited if 0.
+	ReadsPerSecond int64 `protobuf:"varint,2,opt,name=reads_per_second,json=readsPerSecond,proto3" json:"reads_per_second,omitempty"`
+	// The maximum number of writes per second of each instance, unlimited if 0.
+	WritesPerSecond int64 `protobuf:"varint,3,opt,name=writes_per_second,json=writesPerSecond,proto3" json:"writes_per_second,omitempty"`
+	// The actor that last set the limits, and the time it set them at, unset if they were never set.
+	UpdatedBy  string               `protobuf:"bytes,4,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	UpdateTime *timestamp.Timestamp `protobuf:"bytes,5,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	// The reads and writes that the serving instance admitted in the last second, of the tenant of the server itself.
+	CurrentReadsPerSecond  int64    `protobuf:"varint,6,opt,name=current_reads_per_second,json=currentReadsPerSecond,proto3" json:"current_reads_per_second,omitempty"`
+	CurrentWritesPerSecond int64    `protobuf:"varint,7,opt,name=current_writes_per_second,json=currentWritesPerSecond,proto3" json:"current_writes_per_second,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
+	XXX_unrecognized       []byte   `json:"-"`
+	XXX_sizecache          int32    `json:"-"`
+}
+
+func (m *TenantRateLimits) Reset()         { *m = TenantRateLimits{} }
+func (m *TenantRateLimits) String() string { return proto.CompactTextString(m) }
+func (*TenantRateLimits) ProtoMessage()    {}
+func (*TenantRateLimits) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{66}
+}
+
+func (m *TenantRateLimits) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TenantRateLimits.Unmarshal(m, b)
+}
+func (m *TenantRateLimits) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TenantRateLimits.Marshal(b, m, deterministic)
+}
+func (m *TenantRateLimits) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TenantRateLimits.Merge(m, src)
+}
+func (m *TenantRateLimits) XXX_Size() int {
+	return xxx_messageInfo_TenantRateLimits.Size(m)
+}
+func (m *TenantRateLimits) XXX_DiscardUnknown() {
+	xxx_messageInfo_TenantRateLimits.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TenantRateLimits proto.InternalMessageInfo
+
+func (m *TenantRateLimits) GetTenantId() string {
+	if m != nil {
+		return m.TenantId
+	}
+	return ""
+}
+
+func (m *TenantRateLimits) GetReadsPerSecond() int64 {
+	if m != nil {
+		return m.ReadsPerSecond
+	}
+	return 0
+}
+
+func (m *TenantRateLimits) GetWritesPerSecond() int64 {
+	if m != nil {
+		return m.WritesPerSecond
+	}
+	return 0
+}
+
+func (m *TenantRateLimits) GetUpdatedBy() string {
+	if m != nil {
+		return m.UpdatedBy
+	}
+	return ""
+}
+
+func (m *TenantRateLimits) GetUpdateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdateTime
+	}
+	return nil
+}
+
+func (m *TenantRateLimits) GetCurrentReadsPerSecond() int64 {
+	if m != nil {
+		return m.CurrentReadsPerSecond
+	}
+	return 0
+}
+
+func (m *TenantRateLimits) GetCurrentWritesPerSecond() int64 {
+	if m != nil {
+		return m.CurrentWritesPerSecond
+	}
+	return 0
+}
+
+// RejectionInfo is a detail of the errors of the requests that a quota, a policy or an invariant rejected,
+// of both the v1 and the v2 APIs, so that clients can tell their users why and what they can do about it.
+// The errors also have a google.rpc.QuotaFailure or google.rpc.PreconditionFailure detail, and
+// a google.rpc.RetryInfo detail if the request may succeed if it's retried later.
+type RejectionInfo struct {
+	// The kind of the check that rejected the request, "quota", "policy" or "invariant".
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	// The name of the rule that rejected the request, such as "max_reshare_depth" or "legal_hold".
+	Rule string `protobuf:"bytes,2,opt,name=rule,proto3" json:"rule,omitempty"`
+	// The subject of the rule, such as the resource name of a file, `files/{file}`, or the name of a caller.
+	Subject string `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	// The value that the request would have brought a quota to, and the quota's limit. Both are 0 for
+	// the rules that aren't quotas.
+	Current              int64    `protobuf:"varint,4,opt,name=current,proto3" json:"current,omitempty"`
+	Limit                int64    `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RejectionInfo) Reset()         { *m = RejectionInfo{} }
+func (m *RejectionInfo) String() string { return proto.CompactTextString(m) }
+func (*RejectionInfo) ProtoMessage()    {}
+func (*RejectionInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{67}
+}
+
+func (m *RejectionInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RejectionInfo.Unmarshal(m, b)
+}
+func (m *RejectionInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RejectionInfo.Marshal(b, m, deterministic)
+}
+func (m *RejectionInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RejectionInfo.Merge(m, src)
+}
+func (m *RejectionInfo) XXX_Size() int {
+	return xxx_messageInfo_RejectionInfo.Size(m)
+}
+func (m *RejectionInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_RejectionInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RejectionInfo proto.InternalMessageInfo
+
+func (m *RejectionInfo) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *RejectionInfo) GetRule() string {
+	if m != nil {
+		return m.Rule
+	}
+	return ""
+}
+
+func (m *RejectionInfo) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+func (m *RejectionInfo) GetCurrent() int64 {
+	if m != nil {
+		return m.Current
+	}
+	return 0
+}
+
+func (m *RejectionInfo) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type PrewarmFilesRequest struct {
+	// The resource names of the files, such as `files/{file}`.
+	Files                []string `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PrewarmFilesRequest) Reset()         { *m = PrewarmFilesRequest{} }
+func (m *PrewarmFilesRequest) String() string { return proto.CompactTextString(m) }
+func (*PrewarmFilesRequest) ProtoMessage()    {}
+func (*PrewarmFilesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{68}
+}
+
+func (m *PrewarmFilesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrewarmFilesRequest.Unmarshal(m, b)
+}
+func (m *PrewarmFilesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrewarmFilesRequest.Marshal(b, m, deterministic)
+}
+func (m *PrewarmFilesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrewarmFilesRequest.Merge(m, src)
+}
+func (m *PrewarmFilesRequest) XXX_Size() int {
+	return xxx_messageInfo_PrewarmFilesRequest.Size(m)
+}
+func (m *PrewarmFilesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrewarmFilesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrewarmFilesRequest proto.InternalMessageInfo
+
+func (m *PrewarmFilesRequest) GetFiles() []string {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+type PrewarmFilesResponse struct {
+	// The number of the files that were prewarmed, and of their permissions.
+	Files                int64    `protobuf:"varint,1,opt,name=files,proto3" json:"files,omitempty"`
+	Permissions          int64    `protobuf:"varint,2,opt,name=permissions,proto3" json:"permissions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PrewarmFilesResponse) Reset()         { *m = PrewarmFilesResponse{} }
+func (m *PrewarmFilesResponse) String() string { return proto.CompactTextString(m) }
+func (*PrewarmFilesResponse) ProtoMessage()    {}
+func (*PrewarmFilesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{69}
+}
+
+func (m *PrewarmFilesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrewarmFilesResponse.Unmarshal(m, b)
+}
+func (m *PrewarmFilesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrewarmFilesResponse.Marshal(b, m, deterministic)
+}
+func (m *PrewarmFilesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrewarmFilesResponse.Merge(m, src)
+}
+func (m *PrewarmFilesResponse) XXX_Size() int {
+	return xxx_messageInfo_PrewarmFilesResponse.Size(m)
+}
+func (m *PrewarmFilesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrewarmFilesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrewarmFilesResponse proto.InternalMessageInfo
+
+func (m *PrewarmFilesResponse) GetFiles() int64 {
+	if m != nil {
+		return m.Files
+	}
+	return 0
+}
+
+func (m *PrewarmFilesResponse) GetPermissions() int64 {
+	if m != nil {
+		return m.Permissions
+	}
+	return 0
+}
+
+type AssignOwnerRequest struct {
+	// The orphaned resource, such as `files/{file}`.
+	Resource string `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	// The user that's made the owner of the resource.
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Why the user is made the owner, such as the ticket of the claim.
+	Reason               string   `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AssignOwnerRequest) Reset()         { *m = AssignOwnerRequest{} }
+func (m *AssignOwnerRequest) String() string { return proto.CompactTextString(m) }
+func (*AssignOwnerRequest) ProtoMessage()    {}
+func (*AssignOwnerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{70}
+}
+
+func (m *AssignOwnerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AssignOwnerRequest.Unmarshal(m, b)
+}
+func (m *AssignOwnerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AssignOwnerRequest.Marshal(b, m, deterministic)
+}
+func (m *AssignOwnerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AssignOwnerRequest.Merge(m, src)
+}
+func (m *AssignOwnerRequest) XXX_Size() int {
+	return xxx_messageInfo_AssignOwnerRequest.Size(m)
+}
+func (m *AssignOwnerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AssignOwnerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AssignOwnerRequest proto.InternalMessageInfo
+
+func (m *AssignOwnerRequest) GetResource() string {
+	if m != nil {
+		return m.Resource
+	}
+	return ""
+}
+
+func (m *AssignOwnerRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *AssignOwnerRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type GetFrequentCollaboratorsRequest struct {
+	// The user whose collaborators are returned.
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// The maximum number of collaborators to return, defaults to 10 and at most 100.
+	Limit                int32    `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetFrequentCollaboratorsRequest) Reset()         { *m = GetFrequentCollaboratorsRequest{} }
+func (m *GetFrequentCollaboratorsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetFrequentCollaboratorsRequest) ProtoMessage()    {}
+func (*GetFrequentCollaboratorsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{71}
+}
+
+func (m *GetFrequentCollaboratorsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetFrequentCollaboratorsRequest.Unmarshal(m, b)
+}
+func (m *GetFrequentCollaboratorsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetFrequentCollaboratorsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetFrequentCollaboratorsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetFrequentCollaboratorsRequest.Merge(m, src)
+}
+func (m *GetFrequentCollaboratorsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetFrequentCollaboratorsRequest.Size(m)
+}
+func (m *GetFrequentCollaboratorsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetFrequentCollaboratorsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetFrequentCollaboratorsRequest proto.InternalMessageInfo
+
+func (m *GetFrequentCollaboratorsRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *GetFrequentCollaboratorsRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type GetFrequentCollaboratorsResponse struct {
+	// The collaborators, most frequent first.
+	Collaborators        []*GetFrequentCollaboratorsResponse_Collaborator `protobuf:"bytes,1,rep,name=collaborators,proto3" json:"collaborators,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                         `json:"-"`
+	XXX_unrecognized     []byte                                           `json:"-"`
+	XXX_sizecache        int32                                            `json:"-"`
+}
+
+func (m *GetFrequentCollaboratorsResponse) Reset()         { *m = GetFrequentCollaboratorsResponse{} }
+func (m *GetFrequentCollaboratorsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetFrequentCollaboratorsResponse) ProtoMessage()    {}
+func (*GetFrequentCollaboratorsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{72}
+}
+
+func (m *GetFrequentCollaboratorsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetFrequentCollaboratorsResponse.Unmarshal(m, b)
+}
+func (m *GetFrequentCollaboratorsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetFrequentCollaboratorsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetFrequentCollaboratorsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetFrequentCollaboratorsResponse.Merge(m, src)
+}
+func (m *GetFrequentCollaboratorsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetFrequentCollaboratorsResponse.Size(m)
+}
+func (m *GetFrequentCollaboratorsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetFrequentCollaboratorsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetFrequentCollaboratorsResponse proto.InternalMessageInfo
+
+func (m *GetFrequentCollaboratorsResponse) GetCollaborators() []*GetFrequentCollaboratorsResponse_Collaborator {
+	if m != nil {
+		return m.Collaborators
+	}
+	return nil
+}
+
+// A user that the user shares files with, or receives them from.
+type GetFrequentCollaboratorsResponse_Collaborator struct {
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// The number of the permissions that the user gave the collaborator.
+	SharedWith int64 `protobuf:"varint,2,opt,name=shared_with,json=sharedWith,proto3" json:"shared_with,omitempty"`
+	// The number of the permissions that the collaborator gave the user.
+	ReceivedFrom         int64    `protobuf:"varint,3,opt,name=received_from,json=receivedFrom,proto3" json:"received_from,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetFrequentCollaboratorsResponse_Collaborator) Reset() {
+	*m = GetFrequentCollaboratorsResponse_Collaborator{}
+}
+func (m *GetFrequentCollaboratorsResponse_Collaborator) String() string {
+	return proto.CompactTextString(m)
+}
+func (*GetFrequentCollaboratorsResponse_Collaborator) ProtoMessage() {}
+func (*GetFrequentCollaboratorsResponse_Collaborator) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{72, 0}
+}
+
+func (m *GetFrequentCollaboratorsResponse_Collaborator) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetFrequentCollaboratorsResponse_Collaborator.Unmarshal(m, b)
+}
+func (m *GetFrequentCollaboratorsResponse_Collaborator) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetFrequentCollaboratorsResponse_Collaborator.Marshal(b, m, deterministic)
+}
+func (m *GetFrequentCollaboratorsResponse_Collaborator) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetFrequentCollaboratorsResponse_Collaborator.Merge(m, src)
+}
+func (m *GetFrequentCollaboratorsResponse_Collaborator) XXX_Size() int {
+	return xxx_messageInfo_GetFrequentCollaboratorsResponse_Collaborator.Size(m)
+}
+func (m *GetFrequentCollaboratorsResponse_Collaborator) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetFrequentCollaboratorsResponse_Collaborator.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetFrequentCollaboratorsResponse_Collaborator proto.InternalMessageInfo
+
+func (m *GetFrequentCollaboratorsResponse_Collaborator) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *GetFrequentCollaboratorsResponse_Collaborator) GetSharedWith() int64 {
+	if m != nil {
+		return m.SharedWith
+	}
+	return 0
+}
+
+func (m *GetFrequentCollaboratorsResponse_Collaborator) GetReceivedFrom() int64 {
+	if m != nil {
+		return m.ReceivedFrom
+	}
+	return 0
+}
+
+type GetFileSharingActivityRequest struct {
+	// The resource name of the file, such as `files/{file}`.
+	Resource string `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	// If set, only the activity since this time is returned.
+	Since *timestamp.Timestamp `protobuf:"bytes,2,opt,name=since,proto3" json:"since,omitempty"`
+	// The maximum number of activities to return, the server may return fewer.
+	PageSize int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// The next_page_token of a previous GetFileSharingActivity call.
+	PageToken            string   `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetFileSharingActivityRequest) Reset()         { *m = GetFileSharingActivityRequest{} }
+func (m *GetFileSharingActivityRequest) String() string { return proto.CompactTextString(m) }
+func (*GetFileSharingActivityRequest) ProtoMessage()    {}
+func (*GetFileSharingActivityRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{73}
+}
+
+func (m *GetFileSharingActivityRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetFileSharingActivityRequest.Unmarshal(m, b)
+}
+func (m *GetFileSharingActivityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetFileSharingActivityRequest.Marshal(b, m, deterministic)
+}
+func (m *GetFileSharingActivityRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetFileSharingActivityRequest.Merge(m, src)
+}
+func (m *GetFileSharingActivityRequest) XXX_Size() int {
+	return xxx_messageInfo_GetFileSharingActivityRequest.Size(m)
+}
+func (m *GetFileSharingActivityRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetFileSharingActivityRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetFileSharingActivityRequest proto.InternalMessageInfo
+
+func (m *GetFileSharingActivityRequest) GetResource() string {
+	if m != nil {
+		return m.Resource
+	}
+	return ""
+}
+
+func (m *GetFileSharingActivityRequest) GetSince() *timestamp.Timestamp {
+	if m != nil {
+		return m.Since
+	}
+	return nil
+}
+
+func (m *GetFileSharingActivityRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *GetFileSharingActivityRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+// A change to the sharing of a file.
+type SharingActivity struct {
+	// The ID of the activity, which is the ID of its permission event.
+	Id         string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind       SharingActivity_Kind `protobuf:"varint,2,opt,name=kind,proto3,enum=permissions.v2.SharingActivity_Kind" json:"kind,omitempty"`
+	OccurredAt *timestamp.Timestamp `protobuf:"bytes,3,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	// The user that gave the grantee its permission.
+	ActorId string `protobuf:"bytes,4,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	// The ID of the grantee, such as a user ID, or a domain of an organization.
+	GranteeId string `protobuf:"bytes,5,opt,name=grantee_id,json=granteeId,proto3" json:"grantee_id,omitempty"`
+	// The type of the grantee, such as "user" or "domain".
+	GranteeType string `protobuf:"bytes,6,opt,name=grantee_type,json=granteeType,proto3" json:"grantee_type,omitempty"`
+	// The role of the grantee's permission after the change, or before it was revoked.
+	Role Role `protobuf:"varint,7,opt,name=role,proto3,enum=permissions.v2.Role" json:"role,omitempty"`
+	// The role of the grantee's permission before the change, of ROLE_CHANGED and UPDATED activities,
+	// unless the change that gave it is no longer retained.
+	PreviousRole Role `protobuf:"varint,8,opt,name=previous_role,json=previousRole,proto3,enum=permissions.v2.Role" json:"previous_role,omitempty"`
+	// The grantees whose permissions were revoked, of SHARING_STOPPED activities.
+	RevokedGranteeIds    []string `protobuf:"bytes,9,rep,name=revoked_grantee_ids,json=revokedGranteeIds,proto3" json:"revoked_grantee_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SharingActivity) Reset()         { *m = SharingActivity{} }
+func (m *SharingActivity) String() string { return proto.CompactTextString(m) }
+func (*SharingActivity) ProtoMessage()    {}
+func (*SharingActivity) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{74}
+}
+
+func (m *SharingActivity) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SharingActivity.Unmarshal(m, b)
+}
+func (m *SharingActivity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SharingActivity.Marshal(b, m, deterministic)
+}
+func (m *SharingActivity) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SharingActivity.Merge(m, src)
+}
+func (m *SharingActivity) XXX_Size() int {
+	return xxx_messageInfo_SharingActivity.Size(m)
+}
+func (m *SharingActivity) XXX_DiscardUnknown() {
+	xxx_messageInfo_SharingActivity.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SharingActivity proto.InternalMessageInfo
+
+func (m *SharingActivity) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *SharingActivity) GetKind() SharingActivity_Kind {
+	if m != nil {
+		return m.Kind
+	}
+	return SharingActivity_KIND_UNSPECIFIED
+}
+
+func (m *SharingActivity) GetOccurredAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return nil
+}
+
+func (m *SharingActivity) GetActorId() string {
+	if m != nil {
+		return m.ActorId
+	}
+	return ""
+}
+
+func (m *SharingActivity) GetGranteeId() string {
+	if m != nil {
+		return m.GranteeId
+	}
+	return ""
+}
+
+func (m *SharingActivity) GetGranteeType() string {
+	if m != nil {
+		return m.GranteeType
+	}
+	return ""
+}
+
+func (m *SharingActivity) GetRole() Role {
+	if m != nil {
+		return m.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (m *SharingActivity) GetPreviousRole() Role {
+	if m != nil {
+		return m.PreviousRole
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (m *SharingActivity) GetRevokedGranteeIds() []string {
+	if m != nil {
+		return m.RevokedGranteeIds
+	}
+	return nil
+}
+
+type GetFileSharingActivityResponse struct {
+	// The activities, newest first.
+	Activities []*SharingActivity `protobuf:"bytes,1,rep,name=activities,proto3" json:"activities,omitempty"`
+	// A token to retrieve the next page, empty if there are no more pages.
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetFileSharingActivityResponse) Reset()         { *m = GetFileSharingActivityResponse{} }
+func (m *GetFileSharingActivityResponse) String() string { return proto.CompactTextString(m) }
+func (*GetFileSharingActivityResponse) ProtoMessage()    {}
+func (*GetFileSharingActivityResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{75}
+}
+
+func (m *GetFileSharingActivityResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetFileSharingActivityResponse.Unmarshal(m, b)
+}
+func (m *GetFileSharingActivityResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetFileSharingActivityResponse.Marshal(b, m, deterministic)
+}
+func (m *GetFileSharingActivityResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetFileSharingActivityResponse.Merge(m, src)
+}
+func (m *GetFileSharingActivityResponse) XXX_Size() int {
+	return xxx_messageInfo_GetFileSharingActivityResponse.Size(m)
+}
+func (m *GetFileSharingActivityResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetFileSharingActivityResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetFileSharingActivityResponse proto.InternalMessageInfo
+
+func (m *GetFileSharingActivityResponse) GetActivities() []*SharingActivity {
+	if m != nil {
+		return m.Activities
+	}
+	return nil
+}
+
+func (m *GetFileSharingActivityResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type CreateAccessReviewRequest struct {
+	// The title of the campaign, such as "Q3 2026 finance review".
+	Title string `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	// The resources whose grants are reviewed, such as `files/{file}`, all of the same collection.
+	// Either resources or resource_collection must be set.
+	Resources []string `protobuf:"bytes,2,rep,name=resources,proto3" json:"resources,omitempty"`
+	// The collection of the resources whose grants are reviewed, such as `files` for every file of the tenant.
+	ResourceCollection string `protobuf:"bytes,3,opt,name=resource_collection,json=resourceCollection,proto3" json:"resource_collection,omitempty"`
+	// The reviewer of the grants of the resources that have no owner.
+	DefaultReviewerId    string   `protobuf:"bytes,4,opt,name=default_reviewer_id,json=defaultReviewerId,proto3" json:"default_reviewer_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateAccessReviewRequest) Reset()         { *m = CreateAccessReviewRequest{} }
+func (m *CreateAccessReviewRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateAccessReviewRequest) ProtoMessage()    {}
+func (*CreateAccessReviewRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{76}
+}
+
+func (m *CreateAccessReviewRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateAccessReviewRequest.Unmarshal(m, b)
+}
+func (m *CreateAccessReviewRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateAccessReviewRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateAccessReviewRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateAccessReviewRequest.Merge(m, src)
+}
+func (m *CreateAccessReviewRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateAccessReviewRequest.Size(m)
+}
+func (m *CreateAccessReviewRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateAccessReviewRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateAccessReviewRequest proto.InternalMessageInfo
+
+func (m *CreateAccessReviewRequest) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *CreateAccessReviewRequest) GetResources() []string {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
+func (m *CreateAccessReviewRequest) GetResourceCollection() string {
+	if m != nil {
+		return m.ResourceCollection
+	}
+	return ""
+}
+
+func (m *CreateAccessReviewRequest) GetDefaultReviewerId() string {
+	if m != nil {
+		return m.DefaultReviewerId
+	}
+	return ""
+}
+
+// An access review campaign, in which the grants of a set of resources are certified or revoked by their reviewers.
+type AccessReview struct {
+	// The resource name of the campaign, `accessReviews/{review}`.
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Title string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	// The reviewed resources, if the campaign isn't of a whole collection.
+	Resources []string `protobuf:"bytes,3,rep,name=resources,proto3" json:"resources,omitempty"`
+	// The collection of the reviewed resources, such as `files`.
+	ResourceCollection string             `protobuf:"bytes,4,opt,name=resource_collection,json=resourceCollection,proto3" json:"resource_collection,omitempty"`
+	DefaultReviewerId  string             `protobuf:"bytes,5,opt,name=default_reviewer_id,json=defaultReviewerId,proto3" json:"default_reviewer_id,omitempty"`
+	State              AccessReview_State `protobuf:"varint,6,opt,name=state,proto3,enum=permissions.v2.AccessReview_State" json:"state,omitempty"`
+	// The number of the grants that are reviewed.
+	ItemCount int64 `protobuf:"varint,7,opt,name=item_count,json=itemCount,proto3" json:"item_count,omitempty"`
+	// The number of the grants that were decided.
+	DecidedCount int64 `protobuf:"varint,8,opt,name=decided_count,json=decidedCount,proto3" json:"decided_count,omitempty"`
+	// The number of the grants that were revoked when the campaign was closed.
+	RevokedCount int64 `protobuf:"varint,9,opt,name=revoked_count,json=revokedCount,proto3" json:"revoked_count,omitempty"`
+	// The number of the grants that weren't revoked when the campaign was closed, since their resources are held.
+	SkippedCount int64 `protobuf:"varint,10,opt,name=skipped_count,json=skippedCount,proto3" json:"skipped_count,omitempty"`
+	// Whether the undecided grants are revoked when the campaign is closed.
+	RevokeUndecided bool `protobuf:"varint,11,opt,name=revoke_undecided,json=revokeUndecided,proto3" json:"revoke_undecided,omitempty"`
+	// The actor that created the campaign, or its calling service if there's no actor.
+	Creator    string               `protobuf:"bytes,12,opt,name=creator,proto3" json:"creator,omitempty"`
+	CreateTime *timestamp.Timestamp `protobuf:"bytes,13,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// The actor that closed the campaign, or its calling service if there's no actor.
+	ClosedBy             string               `protobuf:"bytes,14,opt,name=closed_by,json=closedBy,proto3" json:"closed_by,omitempty"`
+	CloseTime            *timestamp.Timestamp `protobuf:"bytes,15,opt,name=close_time,json=closeTime,proto3" json:"close_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *AccessReview) Reset()         { *m = AccessReview{} }
+func (m *AccessReview) String() string { return proto.CompactTextString(m) }
+func (*AccessReview) ProtoMessage()    {}
+func (*AccessReview) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{77}
+}
+
+func (m *AccessReview) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccessReview.Unmarshal(m, b)
+}
+func (m *AccessReview) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccessReview.Marshal(b, m, deterministic)
+}
+func (m *AccessReview) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccessReview.Merge(m, src)
+}
+func (m *AccessReview) XXX_Size() int {
+	return xxx_messageInfo_AccessReview.Size(m)
+}
+func (m *AccessReview) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccessReview.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccessReview proto.InternalMessageInfo
+
+func (m *AccessReview) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *AccessReview) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *AccessReview) GetResources() []string {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
+func (m *AccessReview) GetResourceCollection() string {
+	if m != nil {
+		return m.ResourceCollection
+	}
+	return ""
+}
+
+func (m *AccessReview) GetDefaultReviewerId() string {
+	if m != nil {
+		return m.DefaultReviewerId
+	}
+	return ""
+}
+
+func (m *AccessReview) GetState() AccessReview_State {
+	if m != nil {
+		return m.State
+	}
+	return AccessReview_STATE_UNSPECIFIED
+}
+
+func (m *AccessReview) GetItemCount() int64 {
+	if m != nil {
+		return m.ItemCount
+	}
+	return 0
+}
+
+func (m *AccessReview) GetDecidedCount() int64 {
+	if m != nil {
+		return m.DecidedCount
+	}
+	return 0
+}
+
+func (m *AccessReview) GetRevokedCount() int64 {
+	if m != nil {
+		return m.RevokedCount
+	}
+	return 0
+}
+
+func (m *AccessReview) GetSkippedCount() int64 {
+	if m != nil {
+		return m.SkippedCount
+	}
+	return 0
+}
+
+func (m *AccessReview) GetRevokeUndecided() bool {
+	if m != nil {
+		return m.RevokeUndecided
+	}
+	return false
+}
+
+func (m *AccessReview) GetCreator() string {
+	if m != nil {
+		return m.Creator
+	}
+	return ""
+}
+
+func (m *AccessReview) GetCreateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreateTime
+	}
+	return nil
+}
+
+func (m *AccessReview) GetClosedBy() string {
+	if m != nil {
+		return m.ClosedBy
+	}
+	return ""
+}
+
+func (m *AccessReview) GetCloseTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.CloseTime
+	}
+	return nil
+}
+
+type GetAccessReviewRequest struct {
+	// The resource name of the campaign, `accessReviews/{review}`.
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetAccessReviewRequest) Reset()         { *m = GetAccessReviewRequest{} }
+func (m *GetAccessReviewRequest) String() string { return proto.CompactTextString(m) }
+func (*GetAccessReviewRequest) ProtoMessage()    {}
+func (*GetAccessReviewRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{78}
+}
+
+func (m *GetAccessReviewRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAccessReviewRequest.Unmarshal(m, b)
+}
+func (m *GetAccessReviewRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAccessReviewRequest.Marshal(b, m, deterministic)
+}
+func (m *GetAccessReviewRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAccessReviewRequest.Merge(m, src)
+}
+func (m *GetAccessReviewRequest) XXX_Size() int {
+	return xxx_messageInfo_GetAccessReviewRequest.Size(m)
+}
+func (m *GetAccessReviewRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAccessReviewRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAccessReviewRequest proto.InternalMessageInfo
+
+func (m *GetAccessReviewRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type ListAccessReviewsRequest struct {
+	// The maximum number of campaigns to return, the server may return fewer.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// The next_page_token of a previous ListAccessReviews call.
+	PageToken            string   `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAccessReviewsRequest) Reset()         { *m = ListAccessReviewsRequest{} }
+func (m *ListAccessReviewsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListAccessReviewsRequest) ProtoMessage()    {}
+func (*ListAccessReviewsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{79}
+}
+
+func (m *ListAccessReviewsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAccessReviewsRequest.Unmarshal(m, b)
+}
+func (m *ListAccessReviewsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAccessReviewsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListAccessReviewsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAccessReviewsRequest.Merge(m, src)
+}
+func (m *ListAccessReviewsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListAccessReviewsRequest.Size(m)
+}
+func (m *ListAccessReviewsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAccessReviewsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAccessReviewsRequest proto.InternalMessageInfo
+
+func (m *ListAccessReviewsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListAccessReviewsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type ListAccessReviewsResponse struct {
+	// The campaigns, newest first.
+	AccessReviews []*AccessReview `protobuf:"bytes,1,rep,name=access_reviews,json=accessReviews,proto3" json:"access_reviews,omitempty"`
+	// A token to retrieve the next page, empty if there are no more pages.
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAccessReviewsResponse) Reset()         { *m = ListAccessReviewsResponse{} }
+func (m *ListAccessReviewsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListAccessReviewsResponse) ProtoMessage()    {}
+func (*ListAccessReviewsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{80}
+}
+
+func (m *ListAccessReviewsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAccessReviewsResponse.Unmarshal(m, b)
+}
+func (m *ListAccessReviewsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAccessReviewsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListAccessReviewsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAccessReviewsResponse.Merge(m, src)
+}
+func (m *ListAccessReviewsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListAccessReviewsResponse.Size(m)
+}
+func (m *ListAccessReviewsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAccessReviewsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAccessReviewsResponse proto.InternalMessageInfo
+
+func (m *ListAccessReviewsResponse) GetAccessReviews() []*AccessReview {
+	if m != nil {
+		return m.AccessReviews
+	}
+	return nil
+}
+
+func (m *ListAccessReviewsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type CloseAccessReviewRequest struct {
+	// The resource name of the campaign, `accessReviews/{review}`.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Whether the grants that weren't decided are revoked too.
+	RevokeUndecided      bool     `protobuf:"varint,2,opt,name=revoke_undecided,json=revokeUndecided,proto3" json:"revoke_undecided,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CloseAccessReviewRequest) Reset()         { *m = CloseAccessReviewRequest{} }
+func (m *CloseAccessReviewRequest) String() string { return proto.CompactTextString(m) }
+func (*CloseAccessReviewRequest) ProtoMessage()    {}
+func (*CloseAccessReviewRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{81}
+}
+
+func (m *CloseAccessReviewRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CloseAccessReviewRequest.Unmarshal(m, b)
+}
+func (m *CloseAccessReviewRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CloseAccessReviewRequest.Marshal(b, m, deterministic)
+}
+func (m *CloseAccessReviewRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CloseAccessReviewRequest.Merge(m, src)
+}
+func (m *CloseAccessReviewRequest) XXX_Size() int {
+	return xxx_messageInfo_CloseAccessReviewRequest.Size(m)
+}
+func (m *CloseAccessReviewRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CloseAccessReviewRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CloseAccessReviewRequest proto.InternalMessageInfo
+
+func (m *CloseAccessReviewRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CloseAccessReviewRequest) GetRevokeUndecided() bool {
+	if m != nil {
+		return m.RevokeUndecided
+	}
+	return false
+}
+
+// A grant that's reviewed in an access review campaign.
+type AccessReviewItem struct {
+	// The resource name of the reviewed permission, such as `files/{file}/permissions/{permission}`.
+	Permission string `protobuf:"bytes,1,opt,name=permission,proto3" json:"permission,omitempty"`
+	// The role of the permission when the campaign was created.
+	Role Role `protobuf:"varint,2,opt,name=role,proto3,enum=permissions.v2.Role" json:"role,omitempty"`
+	// The user that gave the permission.
+	Creator    string                    `protobuf:"bytes,3,opt,name=creator,proto3" json:"creator,omitempty"`
+	ReviewerId string                    `protobuf:"bytes,4,opt,name=reviewer_id,json=reviewerId,proto3" json:"reviewer_id,omitempty"`
+	Decision   AccessReviewItem_Decision `protobuf:"varint,5,opt,name=decision,proto3,enum=permissions.v2.AccessReviewItem_Decision" json:"decision,omitempty"`
+	// The actor that decided the grant.
+	DecidedBy            string               `protobuf:"bytes,6,opt,name=decided_by,json=decidedBy,proto3" json:"decided_by,omitempty"`
+	DecideTime           *timestamp.Timestamp `protobuf:"bytes,7,opt,name=decide_time,json=decideTime,proto3" json:"decide_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *AccessReviewItem) Reset()         { *m = AccessReviewItem{} }
+func (m *AccessReviewItem) String() string { return proto.CompactTextString(m) }
+func (*AccessReviewItem) ProtoMessage()    {}
+func (*AccessReviewItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{82}
+}
+
+func (m *AccessReviewItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccessReviewItem.Unmarshal(m, b)
+}
+func (m *AccessReviewItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccessReviewItem.Marshal(b, m, deterministic)
+}
+func (m *AccessReviewItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccessReviewItem.Merge(m, src)
+}
+func (m *AccessReviewItem) XXX_Size() int {
+	return xxx_messageInfo_AccessReviewItem.Size(m)
+}
+func (m *AccessReviewItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccessReviewItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccessReviewItem proto.InternalMessageInfo
+
+func (m *AccessReviewItem) GetPermission() string {
+	if m != nil {
+		return m.Permission
+	}
+	return ""
+}
+
+func (m *AccessReviewItem) GetRole() Role {
+	if m != nil {
+		return m.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (m *AccessReviewItem) GetCreator() string {
+	if m != nil {
+		return m.Creator
+	}
+	return ""
+}
+
+func (m *AccessReviewItem) GetReviewerId() string {
+	if m != nil {
+		return m.ReviewerId
+	}
+	return ""
+}
+
+func (m *AccessReviewItem) GetDecision() AccessReviewItem_Decision {
+	if m != nil {
+		return m.Decision
+	}
+	return AccessReviewItem_DECISION_UNSPECIFIED
+}
+
+func (m *AccessReviewItem) GetDecidedBy() string {
+	if m != nil {
+		return m.DecidedBy
+	}
+	return ""
+}
+
+func (m *AccessReviewItem) GetDecideTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.DecideTime
+	}
+	return nil
+}
+
+type ListAccessReviewItemsRequest struct {
+	// The resource name of the campaign, `accessReviews/{review}`.
+	AccessReview string `protobuf:"bytes,1,opt,name=access_review,json=accessReview,proto3" json:"access_review,omitempty"`
+	// Only the items of the reviewer are listed if set, it defaults to the actor if there's one.
+	ReviewerId string `protobuf:"bytes,2,opt,name=reviewer_id,json=reviewerId,proto3" json:"reviewer_id,omitempty"`
+	// Whether only the items that weren't decided yet are listed.
+	PendingOnly bool `protobuf:"varint,3,opt,name=pending_only,json=pendingOnly,proto3" json:"pending_only,omitempty"`
+	// The maximum number of items to return, the server may return fewer.
+	PageSize int32 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// The next_page_token of a previous ListAccessReviewItems call.
+	PageToken            string   `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAccessReviewItemsRequest) Reset()         { *m = ListAccessReviewItemsRequest{} }
+func (m *ListAccessReviewItemsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListAccessReviewItemsRequest) ProtoMessage()    {}
+func (*ListAccessReviewItemsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{83}
+}
+
+func (m *ListAccessReviewItemsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAccessReviewItemsRequest.Unmarshal(m, b)
+}
+func (m *ListAccessReviewItemsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAccessReviewItemsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListAccessReviewItemsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAccessReviewItemsRequest.Merge(m, src)
+}
+func (m *ListAccessReviewItemsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListAccessReviewItemsRequest.Size(m)
+}
+func (m *ListAccessReviewItemsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAccessReviewItemsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAccessReviewItemsRequest proto.InternalMessageInfo
+
+func (m *ListAccessReviewItemsRequest) GetAccessReview() string {
+	if m != nil {
+		return m.AccessReview
+	}
+	return ""
+}
+
+func (m *ListAccessReviewItemsRequest) GetReviewerId() string {
+	if m != nil {
+		return m.ReviewerId
+	}
+	return ""
+}
+
+func (m *ListAccessReviewItemsRequest) GetPendingOnly() bool {
+	if m != nil {
+		return m.PendingOnly
+	}
+	return false
+}
+
+func (m *ListAccessReviewItemsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListAccessReviewItemsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type ListAccessReviewItemsResponse struct {
+	Items []*AccessReviewItem `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	// A token to retrieve the next page, empty if there are no more pages.
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAccessReviewItemsResponse) Reset()         { *m = ListAccessReviewItemsResponse{} }
+func (m *ListAccessReviewItemsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListAccessReviewItemsResponse) ProtoMessage()    {}
+func (*ListAccessReviewItemsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{84}
+}
+
+func (m *ListAccessReviewItemsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAccessReviewItemsResponse.Unmarshal(m, b)
+}
+func (m *ListAccessReviewItemsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAccessReviewItemsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListAccessReviewItemsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAccessReviewItemsResponse.Merge(m, src)
+}
+func (m *ListAccessReviewItemsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListAccessReviewItemsResponse.Size(m)
+}
+func (m *ListAccessReviewItemsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAccessReviewItemsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAccessReviewItemsResponse proto.InternalMessageInfo
+
+func (m *ListAccessReviewItemsResponse) GetItems() []*AccessReviewItem {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func (m *ListAccessReviewItemsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type DecideAccessReviewItemsRequest struct {
+	// The resource name of the campaign, `accessReviews/{review}`.
+	AccessReview         string                                     `protobuf:"bytes,1,opt,name=access_review,json=accessReview,proto3" json:"access_review,omitempty"`
+	Decisions            []*DecideAccessReviewItemsRequest_Decision `protobuf:"bytes,2,rep,name=decisions,proto3" json:"decisions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                   `json:"-"`
+	XXX_unrecognized     []byte                                     `json:"-"`
+	XXX_sizecache        int32                                      `json:"-"`
+}
+
+func (m *DecideAccessReviewItemsRequest) Reset()         { *m = DecideAccessReviewItemsRequest{} }
+func (m *DecideAccessReviewItemsRequest) String() string { return proto.CompactTextString(m) }
+func (*DecideAccessReviewItemsRequest) ProtoMessage()    {}
+func (*DecideAccessReviewItemsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{85}
+}
+
+func (m *DecideAccessReviewItemsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DecideAccessReviewItemsRequest.Unmarshal(m, b)
+}
+func (m *DecideAccessReviewItemsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DecideAccessReviewItemsRequest.Marshal(b, m, deterministic)
+}
+func (m *DecideAccessReviewItemsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DecideAccessReviewItemsRequest.Merge(m, src)
+}
+func (m *DecideAccessReviewItemsRequest) XXX_Size() int {
+	return xxx_messageInfo_DecideAccessReviewItemsRequest.Size(m)
+}
+func (m *DecideAccessReviewItemsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DecideAccessReviewItemsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DecideAccessReviewItemsRequest proto.InternalMessageInfo
+
+func (m *DecideAccessReviewItemsRequest) GetAccessReview() string {
+	if m != nil {
+		return m.AccessReview
+	}
+	return ""
+}
+
+func (m *DecideAccessReviewItemsRequest) GetDecisions() []*DecideAccessReviewItemsRequest_Decision {
+	if m != nil {
+		return m.Decisions
+	}
+	return nil
+}
+
+// The decision of a reviewed grant.
+type DecideAccessReviewItemsRequest_Decision struct {
+	// The resource name of the reviewed permission, such as `files/{file}/permissions/{permission}`.
+	Permission string `protobuf:"bytes,1,opt,name=permission,proto3" json:"permission,omitempty"`
+	// Whether the grant is certified or revoked, it may not be DECISION_UNSPECIFIED.
+	Decision             AccessReviewItem_Decision `protobuf:"varint,2,opt,name=decision,proto3,enum=permissions.v2.AccessReviewItem_Decision" json:"decision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *DecideAccessReviewItemsRequest_Decision) Reset() {
+	*m = DecideAccessReviewItemsRequest_Decision{}
+}
+func (m *DecideAccessReviewItemsRequest_Decision) String() string { return proto.CompactTextString(m) }
+func (*DecideAccessReviewItemsRequest_Decision) ProtoMessage()    {}
+func (*DecideAccessReviewItemsRequest_Decision) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{85, 0}
+}
+
+func (m *DecideAccessReviewItemsRequest_Decision) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DecideAccessReviewItemsRequest_Decision.Unmarshal(m, b)
+}
+func (m *DecideAccessReviewItemsRequest_Decision) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DecideAccessReviewItemsRequest_Decision.Marshal(b, m, deterministic)
+}
+func (m *DecideAccessReviewItemsRequest_Decision) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DecideAccessReviewItemsRequest_Decision.Merge(m, src)
+}
+func (m *DecideAccessReviewItemsRequest_Decision) XXX_Size() int {
+	return xxx_messageInfo_DecideAccessReviewItemsRequest_Decision.Size(m)
+}
+func (m *DecideAccessReviewItemsRequest_Decision) XXX_DiscardUnknown() {
+	xxx_messageInfo_DecideAccessReviewItemsRequest_Decision.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DecideAccessReviewItemsRequest_Decision proto.InternalMessageInfo
+
+func (m *DecideAccessReviewItemsRequest_Decision) GetPermission() string {
+	if m != nil {
+		return m.Permission
+	}
+	return ""
+}
+
+func (m *DecideAccessReviewItemsRequest_Decision) GetDecision() AccessReviewItem_Decision {
+	if m != nil {
+		return m.Decision
+	}
+	return AccessReviewItem_DECISION_UNSPECIFIED
+}
+
+type DecideAccessReviewItemsResponse struct {
+	// The decided items, in the order of the decisions.
+	Items                []*AccessReviewItem `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *DecideAccessReviewItemsResponse) Reset()         { *m = DecideAccessReviewItemsResponse{} }
+func (m *DecideAccessReviewItemsResponse) String() string { return proto.CompactTextString(m) }
+func (*DecideAccessReviewItemsResponse) ProtoMessage()    {}
+func (*DecideAccessReviewItemsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{86}
+}
+
+func (m *DecideAccessReviewItemsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DecideAccessReviewItemsResponse.Unmarshal(m, b)
+}
+func (m *DecideAccessReviewItemsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DecideAccessReviewItemsResponse.Marshal(b, m, deterministic)
+}
+func (m *DecideAccessReviewItemsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DecideAccessReviewItemsResponse.Merge(m, src)
+}
+func (m *DecideAccessReviewItemsResponse) XXX_Size() int {
+	return xxx_messageInfo_DecideAccessReviewItemsResponse.Size(m)
+}
+func (m *DecideAccessReviewItemsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DecideAccessReviewItemsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DecideAccessReviewItemsResponse proto.InternalMessageInfo
+
+func (m *DecideAccessReviewItemsResponse) GetItems() []*AccessReviewItem {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+type ListStaleGrantsRequest struct {
+	// The duration without use after which a grant is stale, such as a year, it must be positive.
+	OlderThan *duration.Duration `protobuf:"bytes,1,opt,name=older_than,json=olderThan,proto3" json:"older_than,omitempty"`
+	// The collection of the resources whose grants are listed, such as `files`, all resources if not set.
+	ResourceCollection string `protobuf:"bytes,2,opt,name=resource_collection,json=resourceCollection,proto3" json:"resource_collection,omitempty"`
+	// The maximum number of grants to return, the server may return fewer.
+	PageSize int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// The next_page_token of a previous ListStaleGrants call.
+	PageToken            string   `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListStaleGrantsRequest) Reset()         { *m = ListStaleGrantsRequest{} }
+func (m *ListStaleGrantsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListStaleGrantsRequest) ProtoMessage()    {}
+func (*ListStaleGrantsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{87}
+}
+
+func (m *ListStaleGrantsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListStaleGrantsRequest.Unmarshal(m, b)
+}
+func (m *ListStaleGrantsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListStaleGrantsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListStaleGrantsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListStaleGrantsRequest.Merge(m, src)
+}
+func (m *ListStaleGrantsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListStaleGrantsRequest.Size(m)
+}
+func (m *ListStaleGrantsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListStaleGrantsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListStaleGrantsRequest proto.InternalMessageInfo
+
+func (m *ListStaleGrantsRequest) GetOlderThan() *duration.Duration {
+	if m != nil {
+		return m.OlderThan
+	}
+	return nil
+}
+
+func (m *ListStaleGrantsRequest) GetResourceCollection() string {
+	if m != nil {
+		return m.ResourceCollection
+	}
+	return ""
+}
+
+func (m *ListStaleGrantsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListStaleGrantsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type ListStaleGrantsResponse struct {
+	// The stale grants, with their last access times.
+	Permissions []*Permission `protobuf:"bytes,1,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	// A token to retrieve the next page, empty if there are no more pages.
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListStaleGrantsResponse) Reset()         { *m = ListStaleGrantsResponse{} }
+func (m *ListStaleGrantsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListStaleGrantsResponse) ProtoMessage()    {}
+func (*ListStaleGrantsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_46cca66312ac1c30, []int{88}
+}
+
+func (m *ListStaleGrantsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListStaleGrantsResponse.Unmarshal(m, b)
+}
+func (m *ListStaleGrantsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListStaleGrantsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListStaleGrantsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListStaleGrantsResponse.Merge(m, src)
+}
+func (m *ListStaleGrantsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListStaleGrantsResponse.Size(m)
+}
+func (m *ListStaleGrantsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListStaleGrantsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListStaleGrantsResponse proto.InternalMessageInfo
+
+func (m *ListStaleGrantsResponse) GetPermissions() []*Permission {
+	if m != nil {
+		return m.Permissions
+	}
+	return nil
+}
+
+func (m *ListStaleGrantsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterEnum("permissions.v2.Role", Role_name, Role_value)
+	proto.RegisterEnum("permissions.v2.Capability", Capability_name, Capability_value)
+	proto.RegisterEnum("permissions.v2.ConflictPolicy", ConflictPolicy_name, ConflictPolicy_value)
+	proto.RegisterEnum("permissions.v2.JobState", JobState_name, JobState_value)
+	proto.RegisterEnum("permissions.v2.ImportPermissionsProgress_Outcome", ImportPermissionsProgress_Outcome_name, ImportPermissionsProgress_Outcome_value)
+	proto.RegisterEnum("permissions.v2.AccessTraceStep_Effect", AccessTraceStep_Effect_name, AccessTraceStep_Effect_value)
+	proto.RegisterEnum("permissions.v2.RepairPermissionsRequest_Action", RepairPermissionsRequest_Action_name, RepairPermissionsRequest_Action_value)
+	proto.RegisterEnum("permissions.v2.MalformedPermission_Problem", MalformedPermission_Problem_name, MalformedPermission_Problem_value)
+	proto.RegisterEnum("permissions.v2.MalformedPermission_Resolution", MalformedPermission_Resolution_name, MalformedPermission_Resolution_value)
+	proto.RegisterEnum("permissions.v2.SharingActivity_Kind", SharingActivity_Kind_name, SharingActivity_Kind_value)
+	proto.RegisterEnum("permissions.v2.AccessReview_State", AccessReview_State_name, AccessReview_State_value)
+	proto.RegisterEnum("permissions.v2.AccessReviewItem_Decision", AccessReviewItem_Decision_name, AccessReviewItem_Decision_value)
+	proto.RegisterType((*Permission)(nil), "permissions.v2.Permission")
+	proto.RegisterMapType((map[string]string)(nil), "permissions.v2.Permission.LabelsEntry")
+	proto.RegisterType((*ListPermissionsRequest)(nil), "permissions.v2.ListPermissionsRequest")
+	proto.RegisterMapType((map[string]string)(nil), "permissions.v2.ListPermissionsRequest.LabelSelectorEntry")
+	proto.RegisterType((*ListPermissionsResponse)(nil), "permissions.v2.ListPermissionsResponse")
+	proto.RegisterType((*GetFolderSharingSummaryRequest)(nil), "permissions.v2.GetFolderSharingSummaryRequest")
+	proto.RegisterType((*FolderSharingSummary)(nil), "permissions.v2.FolderSharingSummary")
+	proto.RegisterType((*FolderSharingSummary_RoleGrantees)(nil), "permissions.v2.FolderSharingSummary.RoleGrantees")
+	proto.RegisterType((*ListRolesRequest)(nil), "permissions.v2.ListRolesRequest")
+	proto.RegisterType((*ListRolesResponse)(nil), "permissions.v2.ListRolesResponse")
+	proto.RegisterType((*RoleInfo)(nil), "permissions.v2.RoleInfo")
+	proto.RegisterType((*RoleInfo_KindCapabilities)(nil), "permissions.v2.RoleInfo.KindCapabilities")
+	proto.RegisterType((*GetPermissionRequest)(nil), "permissions.v2.GetPermissionRequest")
+	proto.RegisterType((*CreatePermissionRequest)(nil), "permissions.v2.CreatePermissionRequest")
+	proto.RegisterType((*UpdatePermissionRequest)(nil), "permissions.v2.UpdatePermissionRequest")
+	proto.RegisterType((*UpdatePermissionsRequest)(nil), "permissions.v2.UpdatePermissionsRequest")
+	proto.RegisterType((*UpdatePermissionsRequest_RoleUpdate)(nil), "permissions.v2.UpdatePermissionsRequest.RoleUpdate")
+	proto.RegisterType((*UpdatePermissionsResponse)(nil), "permissions.v2.UpdatePermissionsResponse")
+	proto.RegisterType((*UpdatePermissionsResponse_Result)(nil), "permissions.v2.UpdatePermissionsResponse.Result")
+	proto.RegisterType((*PermissionRequest)(nil), "permissions.v2.PermissionRequest")
+	proto.RegisterType((*RequestPermissionRequest)(nil), "permissions.v2.RequestPermissionRequest")
+	proto.RegisterType((*ApprovePermissionRequestRequest)(nil), "permissions.v2.ApprovePermissionRequestRequest")
+	proto.RegisterType((*DeletePermissionRequest)(nil), "permissions.v2.DeletePermissionRequest")
+	proto.RegisterType((*AccessChange)(nil), "permissions.v2.AccessChange")
+	proto.RegisterType((*SimulateAccessRequest)(nil), "permissions.v2.SimulateAccessRequest")
+	proto.RegisterType((*SimulatedAccess)(nil), "permissions.v2.SimulatedAccess")
+	proto.RegisterType((*SimulateAccessResponse)(nil), "permissions.v2.SimulateAccessResponse")
+	proto.RegisterType((*PermissionsFilter)(nil), "permissions.v2.PermissionsFilter")
+	proto.RegisterMapType((map[string]string)(nil), "permissions.v2.PermissionsFilter.LabelsEntry")
+	proto.RegisterType((*MigrateRoleRequest)(nil), "permissions.v2.MigrateRoleRequest")
+	proto.RegisterType((*MigrateRoleProgress)(nil), "permissions.v2.MigrateRoleProgress")
+	proto.RegisterType((*ImportPermissionsRequest)(nil), "permissions.v2.ImportPermissionsRequest")
+	proto.RegisterType((*ImportPermissionsProgress)(nil), "permissions.v2.ImportPermissionsProgress")
+	proto.RegisterType((*ImportPermissionsProgress_RecordError)(nil), "permissions.v2.ImportPermissionsProgress.RecordError")
+	proto.RegisterType((*ImportPermissionsProgress_RecordResult)(nil), "permissions.v2.ImportPermissionsProgress.RecordResult")
+	proto.RegisterType((*GenerateUserDataReportRequest)(nil), "permissions.v2.GenerateUserDataReportRequest")
+	proto.RegisterType((*UserDataRecord)(nil), "permissions.v2.UserDataRecord")
+	proto.RegisterType((*AuditEntry)(nil), "permissions.v2.AuditEntry")
+	proto.RegisterType((*EraseUserDataRequest)(nil), "permissions.v2.EraseUserDataRequest")
+	proto.RegisterType((*EraseUserDataResponse)(nil), "permissions.v2.EraseUserDataResponse")
+	proto.RegisterType((*ListDomainPermissionsRequest)(nil), "permissions.v2.ListDomainPermissionsRequest")
+	proto.RegisterType((*ListDomainPermissionsResponse)(nil), "permissions.v2.ListDomainPermissionsResponse")
+	proto.RegisterType((*ListAnomalyAlertsRequest)(nil), "permissions.v2.ListAnomalyAlertsRequest")
+	proto.RegisterType((*AnomalyAlert)(nil), "permissions.v2.AnomalyAlert")
+	proto.RegisterType((*ListAnomalyAlertsResponse)(nil), "permissions.v2.ListAnomalyAlertsResponse")
+	proto.RegisterType((*LockFileRequest)(nil), "permissions.v2.LockFileRequest")
+	proto.RegisterType((*UnlockFileRequest)(nil), "permissions.v2.UnlockFileRequest")
+	proto.RegisterType((*FileLock)(nil), "permissions.v2.FileLock")
+	proto.RegisterType((*PlaceLegalHoldRequest)(nil), "permissions.v2.PlaceLegalHoldRequest")
+	proto.RegisterType((*ReleaseLegalHoldRequest)(nil), "permissions.v2.ReleaseLegalHoldRequest")
+	proto.RegisterType((*LegalHold)(nil), "permissions.v2.LegalHold")
+	proto.RegisterType((*GetServerInfoRequest)(nil), "permissions.v2.GetServerInfoRequest")
+	proto.RegisterType((*DeletePermissionsJob)(nil), "permissions.v2.DeletePermissionsJob")
+	proto.RegisterType((*ImportPermissionsJob)(nil), "permissions.v2.ImportPermissionsJob")
+	proto.RegisterType((*CollectGarbageJob)(nil), "permissions.v2.CollectGarbageJob")
+	proto.RegisterType((*UpgradeSchemaJob)(nil), "permissions.v2.UpgradeSchemaJob")
+	proto.RegisterType((*CreateJobRequest)(nil), "permissions.v2.CreateJobRequest")
+	proto.RegisterType((*Job)(nil), "permissions.v2.Job")
+	proto.RegisterType((*GetJobRequest)(nil), "permissions.v2.GetJobRequest")
+	proto.RegisterType((*ListJobsRequest)(nil), "permissions.v2.ListJobsRequest")
+	proto.RegisterType((*ListJobsResponse)(nil), "permissions.v2.ListJobsResponse")
+	proto.RegisterType((*CancelJobRequest)(nil), "permissions.v2.CancelJobRequest")
+	proto.RegisterType((*ServerInfo)(nil), "permissions.v2.ServerInfo")
+	proto.RegisterType((*ExplainAccessRequest)(nil), "permissions.v2.ExplainAccessRequest")
+	proto.RegisterType((*AccessTraceStep)(nil), "permissions.v2.AccessTraceStep")
+	proto.RegisterType((*AccessExplanation)(nil), "permissions.v2.AccessExplanation")
+	proto.RegisterType((*RepairPermissionsRequest)(nil), "permissions.v2.RepairPermissionsRequest")
+	proto.RegisterType((*MalformedPermission)(nil), "permissions.v2.MalformedPermission")
+	proto.RegisterType((*RepairPermissionsProgress)(nil), "permissions.v2.RepairPermissionsProgress")
+	proto.RegisterType((*ExportTenantDataRequest)(nil), "permissions.v2.ExportTenantDataRequest")
+	proto.RegisterType((*TenantDataRecord)(nil), "permissions.v2.TenantDataRecord")
+	proto.RegisterType((*PurgeTenantRequest)(nil), "permissions.v2.PurgeTenantRequest")
+	proto.RegisterType((*PurgeTenantResponse)(nil), "permissions.v2.PurgeTenantResponse")
+	proto.RegisterType((*GetTenantRateLimitsRequest)(nil), "permissions.v2.GetTenantRateLimitsRequest")
+	proto.RegisterType((*SetTenantRateLimitsRequest)(nil), "permissions.v2.SetTenantRateLimitsRequest")
+	proto.RegisterType((*TenantRateLimits)(nil), "permissions.v2.TenantRateLimits")
+	proto.RegisterType((*RejectionInfo)(nil), "permissions.v2.RejectionInfo")
+	proto.RegisterType((*PrewarmFilesRequest)(nil), "permissions.v2.PrewarmFilesRequest")
+	proto.RegisterType((*PrewarmFilesResponse)(nil), "permissions.v2.PrewarmFilesResponse")
+	proto.RegisterType((*AssignOwnerRequest)(nil), "permissions.v2.AssignOwnerRequest")
+	proto.RegisterType((*GetFrequentCollaboratorsRequest)(nil), "permissions.v2.GetFrequentCollaboratorsRequest")
+	proto.RegisterType((*GetFrequentCollaboratorsResponse)(nil), "permissions.v2.GetFrequentCollaboratorsResponse")
+	proto.RegisterType((*GetFrequentCollaboratorsResponse_Collaborator)(nil), "permissions.v2.GetFrequentCollaboratorsResponse.Collaborator")
+	proto.RegisterType((*GetFileSharingActivityRequest)(nil), "permissions.v2.GetFileSharingActivityRequest")
+	proto.RegisterType((*SharingActivity)(nil), "permissions.v2.SharingActivity")
+	proto.RegisterType((*GetFileSharingActivityResponse)(nil), "permissions.v2.GetFileSharingActivityResponse")
+	proto.RegisterType((*CreateAccessReviewRequest)(nil), "permissions.v2.CreateAccessReviewRequest")
+	proto.RegisterType((*AccessReview)(nil), "permissions.v2.AccessReview")
+	proto.RegisterType((*GetAccessReviewRequest)(nil), "permissions.v2.GetAccessReviewRequest")
+	proto.RegisterType((*ListAccessReviewsRequest)(nil), "permissions.v2.ListAccessReviewsRequest")
+	proto.RegisterType((*ListAccessReviewsResponse)(nil), "permissions.v2.ListAccessReviewsResponse")
+	proto.RegisterType((*CloseAccessReviewRequest)(nil), "permissions.v2.CloseAccessReviewRequest")
+	proto.RegisterType((*AccessReviewItem)(nil), "permissions.v2.AccessReviewItem")
+	proto.RegisterType((*ListAccessReviewItemsRequest)(nil), "permissions.v2.ListAccessReviewItemsRequest")
+	proto.RegisterType((*ListAccessReviewItemsResponse)(nil), "permissions.v2.ListAccessReviewItemsResponse")
+	proto.RegisterType((*DecideAccessReviewItemsRequest)(nil), "permissions.v2.DecideAccessReviewItemsRequest")
+	proto.RegisterType((*DecideAccessReviewItemsRequest_Decision)(nil), "permissions.v2.DecideAccessReviewItemsRequest.Decision")
+	proto.RegisterType((*DecideAccessReviewItemsResponse)(nil), "permissions.v2.DecideAccessReviewItemsResponse")
+	proto.RegisterType((*ListStaleGrantsRequest)(nil), "permissions.v2.ListStaleGrantsRequest")
+	proto.RegisterType((*ListStaleGrantsResponse)(nil), "permissions.v2.ListStaleGrantsResponse")
+}
+
+func init() { proto.RegisterFile("permissions.proto", fileDescriptor_46cca66312ac1c30) }
+
+var fileDescriptor_46cca66312ac1c30 = []byte{
+	// 5901 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x7c, 0x4b, 0x6c, 0x23, 0x57,
+	0x76, 0xa8, 0x8a, 0xa4, 0x28, 0xf2, 0x50, 0xa2, 0xa8, 0xdb, 0x6a, 0x35, 0x45, 0xbb, 0xbb, 0xe5,
+	0x6a, 0x7f, 0xd4, 0xed, 0x27, 0x76, 0x5b, 0xe3, 0xb6, 0xdd, 0xf6, 0x78, 0x9e, 0x29, 0xb2, 0xa4,
+	0xa6, 0x5b, 0x2d, 0x69, 0x8a, 0x94, 0x3f, 0xf3, 0x31, 0xa7, 0xc4, 0xba, 0x92, 0xca, 0x5d, 0xac,
+	0xa2, 0xab, 0x8a, 0xea, 0x96, 0x67, 0x92, 0x41, 0x02, 0x24, 0x98, 0xec, 0x92, 0x6c, 0xb2, 0x09,
+	0x90, 0x20, 0x59, 0x19, 0x19, 0x60, 0x10, 0x20, 0x01, 0x92, 0x55, 0x82, 0xac, 0xb3, 0x08, 0x30,
+	0xc8, 0x22, 0x9b, 0x64, 0x97, 0x5d, 0x80, 0x64, 0x91, 0x09, 0x60, 0x64, 0x11, 0xdc, 0x5f, 0xb1,
+	0xbe, 0x22, 0x65, 0x4f, 0x26, 0x3b, 0xde, 0x73, 0xcf, 0xfd, 0x9d, 0x73, 0xee, 0xf9, 0xdd, 0x53,
+	0x84, 0xa5, 0x21, 0x76, 0x06, 0x86, 0xeb, 0x1a, 0xb6, 0xe5, 0xd6, 0x87, 0x8e, 0xed, 0xd9, 0xa8,
+	0x1c, 0x04, 0x9d, 0x6d, 0xd6, 0x6e, 0x9c, 0xd8, 0xf6, 0x89, 0x89, 0xef, 0xd2, 0xde, 0xa3, 0xd1,
+	0xf1, 0x5d, 0x7d, 0xe4, 0x68, 0x9e, 0x61, 0x5b, 0x0c, 0xbf, 0xf6, 0x5c, 0xb4, 0x1f, 0x0f, 0x86,
+	0xde, 0x39, 0xef, 0x5c, 0x8b, 0x76, 0x1e, 0x1b, 0xd8, 0xd4, 0x7b, 0x03, 0xcd, 0x7d, 0xc2, 0x31,
+	0x6e, 0x46, 0x31, 0x3c, 0x63, 0x80, 0x5d, 0x4f, 0x1b, 0x0c, 0x39, 0xc2, 0xb5, 0x33, 0xcd, 0x34,
+	0x74, 0xcd, 0xc3, 0x77, 0xc5, 0x0f, 0xd6, 0x21, 0xff, 0x74, 0x16, 0xe0, 0xc0, 0xdf, 0x2b, 0x42,
+	0x90, 0xb3, 0xb4, 0x01, 0xae, 0x4a, 0x6b, 0xd2, 0x7a, 0x51, 0xa5, 0xbf, 0xd1, 0x35, 0x98, 0x1b,
+	0xb9, 0xd8, 0xe9, 0x19, 0x7a, 0x35, 0x43, 0xc1, 0x79, 0xd2, 0x6c, 0xeb, 0x68, 0x1d, 0x72, 0x8e,
+	0x6d, 0xe2, 0x6a, 0x76, 0x4d, 0x5a, 0x2f, 0x6f, 0x2e, 0xd7, 0xc3, 0x67, 0xae, 0xab, 0xb6, 0x89,
+	0x55, 0x8a, 0x81, 0xaa, 0x30, 0xd7, 0x77, 0xb0, 0xe6, 0xd9, 0x4e, 0x35, 0x47, 0xa7, 0x10, 0x4d,
+	0x74, 0x13, 0x4a, 0x7d, 0xcd, 0xea, 0x39, 0xd8, 0x3d, 0xd5, 0x1c, 0x5c, 0x9d, 0x5d, 0x93, 0xd6,
+	0x0b, 0x2a, 0xf4, 0x35, 0x4b, 0x65, 0x10, 0x32, 0x74, 0x80, 0x5d, 0x57, 0x3b, 0xc1, 0xd5, 0x3c,
+	0x1b, 0xca, 0x9b, 0x68, 0x19, 0x66, 0x4d, 0xed, 0x08, 0x9b, 0xd5, 0x39, 0x0a, 0x67, 0x0d, 0xd4,
+	0x82, 0x8a, 0xa9, 0xb9, 0x5e, 0x4f, 0xeb, 0xf7, 0xb1, 0xeb, 0x62, 0xbd, 0xa7, 0x79, 0xd5, 0xc2,
+	0x9a, 0xb4, 0x5e, 0xda, 0xac, 0xd5, 0x19, 0x95, 0xea, 0x82, 0x4a, 0xf5, 0xae, 0xa0, 0x92, 0x5a,
+	0x26, 0x63, 0x1a, 0x7c, 0x48, 0xc3, 0x23, 0x74, 0xc0, 0x9e, 0x76, 0x52, 0x2d, 0x32, 0x3a, 0x90,
+	0xdf, 0xe8, 0x16, 0x2c, 0x90, 0x2d, 0x19, 0xd6, 0x49, 0xaf, 0x7f, 0xaa, 0x19, 0x56, 0x15, 0xd6,
+	0xb2, 0xeb, 0x45, 0x75, 0x9e, 0x03, 0x9b, 0x04, 0x86, 0x9e, 0x83, 0x22, 0x39, 0x71, 0x8f, 0x52,
+	0xb1, 0x44, 0x47, 0x17, 0x08, 0x60, 0x8f, 0x50, 0xf2, 0x16, 0x2c, 0x38, 0xd8, 0xb5, 0x47, 0x4e,
+	0x1f, 0xf7, 0x9e, 0x18, 0x96, 0x5e, 0x9d, 0xa7, 0x08, 0xf3, 0x02, 0xf8, 0xc8, 0xb0, 0x74, 0xf4,
+	0x2d, 0x98, 0xef, 0x6b, 0x43, 0xed, 0xc8, 0x30, 0x0d, 0xcf, 0xc0, 0x6e, 0x75, 0x61, 0x2d, 0xbb,
+	0x5e, 0xde, 0xac, 0x45, 0xa9, 0xdb, 0x14, 0x38, 0xe7, 0x6a, 0x08, 0x1f, 0xbd, 0x00, 0xf3, 0x27,
+	0x8e, 0x66, 0x79, 0x18, 0xf7, 0xbc, 0xf3, 0x21, 0xae, 0x96, 0xe9, 0x1a, 0x25, 0x0e, 0xeb, 0x9e,
+	0x0f, 0x31, 0xfa, 0x16, 0xe4, 0x29, 0xb1, 0xdc, 0xea, 0xe2, 0x5a, 0x76, 0xbd, 0xb4, 0xf9, 0x72,
+	0x74, 0xf2, 0xb1, 0x44, 0xd4, 0x77, 0x29, 0xa2, 0x62, 0x79, 0xce, 0xb9, 0xca, 0x47, 0xa1, 0x15,
+	0xc8, 0xb3, 0x0d, 0x57, 0x2b, 0x4c, 0x20, 0x58, 0x0b, 0xbd, 0x04, 0x65, 0xc3, 0x3a, 0xc5, 0x8e,
+	0xe1, 0x61, 0xbd, 0x77, 0xec, 0xd8, 0x83, 0xea, 0x12, 0xed, 0x5f, 0xf0, 0xa1, 0xdb, 0x8e, 0x3d,
+	0xa8, 0x3d, 0x80, 0x52, 0x60, 0x56, 0x54, 0x81, 0xec, 0x13, 0x7c, 0xce, 0x45, 0x8e, 0xfc, 0x24,
+	0x9c, 0x3d, 0xd3, 0xcc, 0x11, 0xe6, 0xf2, 0xc6, 0x1a, 0x6f, 0x67, 0xde, 0x92, 0xe4, 0xff, 0xc8,
+	0xc0, 0xca, 0xae, 0xe1, 0x7a, 0xe3, 0x0d, 0xba, 0x2a, 0xfe, 0x6c, 0x84, 0x5d, 0x0f, 0xdd, 0x80,
+	0xfc, 0x50, 0x73, 0xb0, 0xe5, 0xb1, 0x99, 0xb6, 0xf2, 0x5f, 0x7e, 0xb1, 0x9a, 0x29, 0x48, 0x2a,
+	0x87, 0xa2, 0x5b, 0x50, 0x1c, 0x6a, 0x27, 0xb8, 0xe7, 0x1a, 0x9f, 0xb3, 0x89, 0x67, 0x19, 0xca,
+	0xbd, 0x19, 0xb5, 0x40, 0x3a, 0x3a, 0xc6, 0xe7, 0x18, 0x5d, 0x07, 0xa0, 0x48, 0x9e, 0xfd, 0x04,
+	0x5b, 0x54, 0xb0, 0x8b, 0x2a, 0x1d, 0xd6, 0x25, 0x00, 0xf4, 0x26, 0x14, 0x1d, 0xac, 0xb1, 0xab,
+	0x57, 0xcd, 0xa5, 0x48, 0xd5, 0x36, 0xb9, 0x9d, 0x8f, 0x35, 0xf7, 0x89, 0x5a, 0x20, 0xc8, 0xe4,
+	0x17, 0xfa, 0x01, 0x94, 0x29, 0xed, 0x7a, 0x2e, 0x36, 0x71, 0x9f, 0xdc, 0x83, 0x59, 0x4a, 0xf9,
+	0x07, 0x51, 0xca, 0x27, 0x1f, 0x8e, 0x71, 0xa1, 0xc3, 0xc7, 0x32, 0x66, 0x2c, 0x98, 0x41, 0x58,
+	0x80, 0x27, 0xf9, 0x20, 0x4f, 0x6a, 0xef, 0x01, 0x8a, 0x0f, 0xbe, 0x14, 0xcd, 0x7f, 0x0c, 0xd7,
+	0x62, 0xbb, 0x72, 0x87, 0xb6, 0xe5, 0x62, 0xf4, 0x4d, 0x28, 0x05, 0xf6, 0x5f, 0x95, 0xe8, 0x99,
+	0x6a, 0xe9, 0xd2, 0xa4, 0x06, 0xd1, 0xd1, 0xcb, 0xb0, 0x68, 0xe1, 0x67, 0x5e, 0x2f, 0x40, 0x71,
+	0xb6, 0xf8, 0x02, 0x01, 0x1f, 0x08, 0xaa, 0xcb, 0x36, 0xdc, 0xd8, 0xc1, 0xde, 0xb6, 0x6d, 0xea,
+	0xd8, 0xe9, 0xb0, 0xcb, 0xd6, 0x19, 0x0d, 0x06, 0x9a, 0x73, 0x1e, 0xe0, 0xfd, 0x31, 0xed, 0x8e,
+	0xf2, 0x9e, 0x41, 0xd1, 0x06, 0x94, 0x75, 0xec, 0xf6, 0xb1, 0xa5, 0x6b, 0x96, 0xd7, 0x33, 0x74,
+	0xb7, 0x9a, 0x59, 0xcb, 0x0a, 0xbc, 0x8a, 0xa4, 0x2e, 0x8c, 0x7b, 0xdb, 0xba, 0x2b, 0xff, 0x67,
+	0x06, 0x96, 0x93, 0x96, 0x23, 0x44, 0x0e, 0xae, 0xe3, 0xcf, 0xbf, 0x0c, 0xb3, 0xc7, 0x86, 0x89,
+	0x5d, 0xba, 0xff, 0xac, 0xca, 0x1a, 0x68, 0x2d, 0x4c, 0x9d, 0x2c, 0xed, 0x0b, 0x51, 0xa0, 0x06,
+	0x05, 0x7e, 0x2f, 0x5d, 0x2a, 0x4e, 0x59, 0xd5, 0x6f, 0xa3, 0x1d, 0x98, 0x25, 0x8a, 0xc3, 0xe5,
+	0x92, 0xf2, 0x5a, 0x94, 0xaa, 0x49, 0x1b, 0xa4, 0x3a, 0x77, 0x87, 0xcf, 0xa0, 0xb2, 0xf1, 0xe8,
+	0x55, 0x58, 0xc2, 0xcf, 0x3c, 0xec, 0x58, 0x9a, 0xd9, 0xf3, 0x57, 0xcb, 0x53, 0xdd, 0x55, 0x11,
+	0x1d, 0x62, 0x0c, 0xb9, 0xc2, 0x3e, 0x32, 0x3b, 0xd2, 0x1c, 0xdd, 0xd7, 0x82, 0x80, 0x6e, 0x13,
+	0x60, 0xad, 0x0b, 0xf3, 0xc1, 0xa5, 0x7c, 0x53, 0x20, 0x4d, 0x34, 0x05, 0xc1, 0x23, 0x67, 0xc2,
+	0x47, 0x96, 0x11, 0x54, 0x88, 0xa4, 0x11, 0x6c, 0x21, 0xf9, 0x72, 0x13, 0x96, 0x02, 0x30, 0x2e,
+	0x77, 0x75, 0x41, 0x1b, 0x26, 0x71, 0xd5, 0xa4, 0xf5, 0xda, 0xd6, 0xb1, 0xcd, 0x49, 0x20, 0xff,
+	0x5e, 0x0e, 0x0a, 0x02, 0x76, 0x89, 0xbd, 0x0a, 0x6b, 0x98, 0x09, 0x58, 0xc3, 0x65, 0x98, 0xb5,
+	0x1d, 0x22, 0x01, 0x84, 0x9d, 0xb3, 0x2a, 0x6b, 0x10, 0x2b, 0xa5, 0x99, 0x86, 0xe6, 0x52, 0x3e,
+	0x12, 0xca, 0x8a, 0x26, 0x7a, 0x1c, 0x51, 0xe7, 0x8c, 0x9b, 0xb7, 0xd3, 0x76, 0x5c, 0x27, 0x36,
+	0xa0, 0x19, 0x18, 0x10, 0xd1, 0xee, 0xf7, 0xa0, 0x60, 0x58, 0x7d, 0x73, 0xa4, 0x73, 0x1e, 0xa6,
+	0x1d, 0xc0, 0xc7, 0x42, 0xeb, 0x50, 0xd1, 0x0d, 0x77, 0x68, 0x6a, 0xe7, 0xd4, 0x28, 0xf5, 0xc8,
+	0xbd, 0x67, 0x16, 0xb3, 0xcc, 0xe1, 0xc4, 0x36, 0x3d, 0xc2, 0xe7, 0xe8, 0x15, 0x58, 0x24, 0xf7,
+	0xc0, 0x31, 0x86, 0xc4, 0x33, 0xa1, 0x88, 0x05, 0x8e, 0x38, 0x06, 0x13, 0xc4, 0xeb, 0x00, 0x86,
+	0xdb, 0xd3, 0xf1, 0xb1, 0x36, 0x32, 0x3d, 0x6a, 0x23, 0x0b, 0x6a, 0xd1, 0x70, 0x5b, 0x0c, 0x40,
+	0x04, 0xce, 0xc1, 0x9f, 0x8d, 0x0c, 0x07, 0xbb, 0x3d, 0x6d, 0x38, 0x74, 0xec, 0x33, 0xcd, 0xac,
+	0x02, 0xc5, 0xaa, 0x88, 0x8e, 0x06, 0x87, 0xd7, 0x9e, 0x42, 0x25, 0x7a, 0xe4, 0xb8, 0x9d, 0x94,
+	0xa6, 0xb0, 0x93, 0x99, 0xcb, 0xd9, 0x49, 0xf9, 0x09, 0x2c, 0xef, 0xe0, 0x80, 0x56, 0x13, 0xba,
+	0xa4, 0x16, 0x74, 0x81, 0x7c, 0x4d, 0xc2, 0x98, 0x1f, 0xd2, 0xff, 0x99, 0xe9, 0xf5, 0xbf, 0xfc,
+	0x6b, 0x70, 0xad, 0x49, 0x3c, 0x1e, 0x1c, 0x5f, 0x6f, 0x92, 0xdd, 0xda, 0x02, 0x18, 0x1f, 0xc9,
+	0x5f, 0x34, 0x55, 0xc5, 0xfa, 0xe3, 0x03, 0xa3, 0xe4, 0x7f, 0x91, 0xe0, 0xda, 0xe1, 0x50, 0x4f,
+	0x5c, 0x3f, 0x3c, 0xbf, 0xf4, 0x55, 0xe6, 0x47, 0x4d, 0x28, 0x8d, 0xe8, 0xf4, 0x53, 0x52, 0x66,
+	0x3c, 0x09, 0x1b, 0x46, 0x60, 0xe8, 0x1d, 0x28, 0xb9, 0xfd, 0x53, 0xac, 0x8f, 0x4c, 0x4c, 0x9c,
+	0xb6, 0xec, 0x44, 0xa7, 0x0d, 0x04, 0x7a, 0xc3, 0x93, 0xff, 0x55, 0x82, 0x6a, 0xf4, 0x84, 0xbe,
+	0x6b, 0xf0, 0x18, 0xe6, 0xd8, 0x3a, 0x42, 0x61, 0x7c, 0x23, 0x7a, 0xbe, 0xb4, 0xa1, 0xf4, 0x32,
+	0xb1, 0x4e, 0x55, 0xcc, 0x51, 0xfb, 0x21, 0xc0, 0x18, 0x9c, 0xe8, 0x32, 0x0b, 0x15, 0x93, 0x99,
+	0xa8, 0x62, 0x42, 0xfe, 0x62, 0x36, 0xe2, 0x2f, 0x0a, 0x2f, 0x34, 0x37, 0xf6, 0x42, 0xe5, 0x7f,
+	0x97, 0x60, 0x35, 0x61, 0xb7, 0x5c, 0x31, 0xbe, 0x0f, 0x73, 0x0e, 0x76, 0x47, 0xa6, 0x27, 0x4e,
+	0x7a, 0x6f, 0x8a, 0x93, 0xb2, 0xb1, 0x75, 0x95, 0x0e, 0x54, 0xc5, 0x04, 0xb5, 0xdf, 0x96, 0x20,
+	0xcf, 0x60, 0x89, 0x67, 0x44, 0x90, 0xeb, 0xdb, 0x3a, 0x77, 0xa5, 0x54, 0xfa, 0x3b, 0xe8, 0xac,
+	0x67, 0xc3, 0xce, 0xfa, 0xdb, 0x21, 0x29, 0xcb, 0x4d, 0x92, 0xb2, 0x90, 0xf4, 0xfe, 0x34, 0x03,
+	0x4b, 0x71, 0xb9, 0x4d, 0xda, 0xd3, 0xdb, 0x97, 0xbb, 0x2b, 0x21, 0x19, 0x7e, 0x07, 0x4a, 0x34,
+	0x28, 0xc1, 0x3d, 0xcf, 0xe0, 0xbc, 0x98, 0x20, 0x7e, 0x0c, 0x9d, 0x00, 0x88, 0x55, 0x63, 0x9a,
+	0x0e, 0x8b, 0x08, 0xc7, 0x6f, 0xa3, 0x77, 0x61, 0x9e, 0xff, 0x66, 0x33, 0xcf, 0x4e, 0x9c, 0xb9,
+	0xc4, 0xf1, 0xe9, 0xd4, 0x77, 0xe1, 0x0a, 0x6f, 0xea, 0xbd, 0xc0, 0xe1, 0x98, 0x97, 0x87, 0x44,
+	0xd7, 0xf8, 0x50, 0xf2, 0xaf, 0x43, 0x95, 0xd3, 0xe8, 0xff, 0x46, 0xd9, 0xbc, 0x0b, 0x37, 0x99,
+	0x76, 0x8f, 0x2b, 0x9b, 0x29, 0x74, 0xac, 0xdc, 0x86, 0x6b, 0x2d, 0x6c, 0xe2, 0x24, 0x55, 0x75,
+	0xc1, 0x30, 0xff, 0xae, 0x64, 0x02, 0x77, 0xe5, 0x33, 0x98, 0x67, 0x31, 0x5d, 0xf3, 0x54, 0xb3,
+	0x4e, 0x30, 0xba, 0x39, 0x8e, 0x64, 0x23, 0xc7, 0x8f, 0x44, 0xb4, 0x93, 0xef, 0xed, 0x0a, 0xe4,
+	0x1d, 0x7c, 0x66, 0x3f, 0x61, 0x82, 0x52, 0x50, 0x79, 0x4b, 0xfe, 0x89, 0x04, 0x57, 0x3b, 0xc6,
+	0x60, 0x64, 0x6a, 0x1e, 0x66, 0x6b, 0x4f, 0x4b, 0xfa, 0xd4, 0x30, 0xfb, 0x0d, 0x98, 0xeb, 0xd3,
+	0xfd, 0x13, 0x17, 0x92, 0xdc, 0xe9, 0xe7, 0xa3, 0xfb, 0x0a, 0x1e, 0x52, 0x15, 0xc8, 0xf2, 0x1f,
+	0x4b, 0xb0, 0x28, 0xb6, 0xa2, 0x33, 0x94, 0xe0, 0x22, 0x52, 0x68, 0x91, 0x37, 0x61, 0xbe, 0x3f,
+	0x72, 0xc8, 0x46, 0x7a, 0x13, 0x29, 0x50, 0xe2, 0x98, 0xa4, 0x81, 0xde, 0x81, 0xb2, 0x2b, 0x16,
+	0xe9, 0x4d, 0x4c, 0x07, 0x2c, 0xf8, 0xb8, 0xa4, 0x29, 0x1f, 0xc2, 0x4a, 0x94, 0x58, 0x5c, 0x91,
+	0xbd, 0x03, 0x05, 0x1e, 0xc1, 0x0b, 0x4d, 0x76, 0x33, 0x3a, 0x61, 0xe4, 0x6c, 0xaa, 0x3f, 0x40,
+	0xfe, 0x93, 0x90, 0xc2, 0x70, 0xb7, 0x0d, 0xd3, 0xc3, 0x0e, 0x5a, 0x85, 0x02, 0xf1, 0x68, 0xa9,
+	0xfb, 0x2f, 0x31, 0x27, 0x8d, 0xb4, 0xdb, 0xba, 0x4b, 0xba, 0x38, 0x59, 0x78, 0x64, 0xa0, 0xce,
+	0x31, 0xba, 0xb8, 0xc1, 0xd4, 0x45, 0x36, 0x9c, 0xba, 0x08, 0x7a, 0x29, 0x34, 0xd2, 0xce, 0x85,
+	0xbd, 0x14, 0x1a, 0x6a, 0x2b, 0x7e, 0xa8, 0xcd, 0x1c, 0xbf, 0x8d, 0xf4, 0xcb, 0xc4, 0xf7, 0x39,
+	0x21, 0xe2, 0x0e, 0x47, 0x77, 0x5f, 0x23, 0x94, 0xfe, 0x07, 0x09, 0xd0, 0x63, 0xe3, 0xc4, 0x21,
+	0xa6, 0x8d, 0xb0, 0x86, 0x8b, 0xe9, 0x6b, 0x50, 0x24, 0x91, 0x7b, 0x6f, 0xa2, 0x8b, 0x5c, 0x20,
+	0x68, 0xe4, 0x17, 0xda, 0x80, 0x39, 0xcf, 0x9e, 0x2c, 0x36, 0x79, 0xcf, 0xa6, 0xe8, 0x0f, 0x20,
+	0x7f, 0x4c, 0x4f, 0xca, 0x75, 0xec, 0x0b, 0x13, 0x49, 0xa2, 0xf2, 0x01, 0xc4, 0xf1, 0x3c, 0xd2,
+	0xbc, 0xfe, 0x29, 0x0b, 0xe2, 0x73, 0xd4, 0xf2, 0x14, 0x29, 0x84, 0x44, 0xef, 0xf2, 0x0e, 0x5c,
+	0x09, 0x9c, 0xe8, 0xc0, 0xb1, 0x4f, 0x1c, 0x22, 0xf4, 0x35, 0x28, 0x0c, 0x18, 0x98, 0x49, 0x7d,
+	0x56, 0xf5, 0xdb, 0x84, 0x3e, 0x9e, 0xed, 0x69, 0xa6, 0x88, 0xdc, 0x68, 0x43, 0xfe, 0xb9, 0x04,
+	0xd5, 0xf6, 0x60, 0x68, 0x3b, 0x49, 0x89, 0x86, 0x95, 0xf0, 0x45, 0xf6, 0x2f, 0xf0, 0xd7, 0x31,
+	0x3e, 0x35, 0x28, 0x10, 0x5b, 0xe1, 0x18, 0xba, 0x50, 0x28, 0x7e, 0x1b, 0xed, 0xc0, 0x62, 0xdf,
+	0xb6, 0x8e, 0x4d, 0xa3, 0xef, 0xf5, 0x86, 0xb6, 0x69, 0xf4, 0xcf, 0xe9, 0xc9, 0xcb, 0x9b, 0x37,
+	0x62, 0xbe, 0x2e, 0x47, 0x3b, 0xa0, 0x58, 0x6a, 0xb9, 0x1f, 0x6a, 0xcb, 0xbf, 0x9f, 0x83, 0xd5,
+	0xd8, 0xa9, 0x82, 0x54, 0x22, 0x17, 0x68, 0x18, 0xa0, 0x92, 0x68, 0x93, 0x3e, 0x07, 0x7f, 0x8a,
+	0xfb, 0xa4, 0x8f, 0x07, 0x6d, 0xa2, 0x8d, 0x1e, 0x43, 0x1e, 0x3b, 0x8e, 0xed, 0x08, 0xed, 0x74,
+	0x3f, 0xba, 0xab, 0xd4, 0x25, 0xeb, 0x2a, 0xee, 0xdb, 0x8e, 0xae, 0x90, 0xd1, 0x2a, 0x9f, 0x04,
+	0x1d, 0x8c, 0x3d, 0x98, 0x1c, 0x9d, 0xef, 0x8d, 0xcb, 0xce, 0x17, 0xf5, 0x63, 0xbe, 0x0d, 0xa5,
+	0xc0, 0x42, 0x84, 0xe3, 0x86, 0xa5, 0xe3, 0x67, 0xfc, 0x90, 0xac, 0x71, 0x39, 0x6f, 0xa6, 0xf6,
+	0x19, 0xcc, 0x07, 0xd7, 0x4a, 0x99, 0xf3, 0x11, 0xcc, 0xd9, 0x23, 0xaf, 0x6f, 0x0f, 0xc4, 0xbd,
+	0x78, 0x6d, 0xfa, 0xa3, 0xec, 0xb3, 0x81, 0xaa, 0x98, 0x41, 0xfe, 0x00, 0xe6, 0x38, 0x0c, 0x5d,
+	0x83, 0x2b, 0xfb, 0x87, 0xdd, 0xe6, 0xfe, 0x63, 0xa5, 0x77, 0xb8, 0xd7, 0x39, 0x50, 0x9a, 0xed,
+	0xed, 0xb6, 0xd2, 0xaa, 0xcc, 0xa0, 0x12, 0xcc, 0x35, 0x55, 0xa5, 0xd1, 0x55, 0x5a, 0x15, 0x09,
+	0xcd, 0x43, 0x41, 0x55, 0x0e, 0x76, 0x1b, 0x4d, 0xa5, 0x55, 0xc9, 0x20, 0x80, 0xfc, 0x63, 0x45,
+	0xdd, 0x51, 0x5a, 0x95, 0x2c, 0x41, 0xeb, 0x3c, 0x6a, 0x1f, 0x1c, 0x28, 0xad, 0x4a, 0x4e, 0x7e,
+	0x0b, 0xae, 0xef, 0x60, 0x0b, 0x93, 0xdb, 0x70, 0xe8, 0x62, 0xa7, 0xa5, 0x79, 0x9a, 0x8a, 0xc9,
+	0xae, 0x84, 0xb8, 0xa7, 0x99, 0x0c, 0xf9, 0xdf, 0x24, 0x28, 0x8f, 0x87, 0x10, 0x6a, 0x20, 0x05,
+	0x16, 0x4f, 0x49, 0x6a, 0xfa, 0x32, 0x01, 0xc5, 0xc3, 0x19, 0xb5, 0x4c, 0x06, 0x8d, 0x21, 0xe8,
+	0x11, 0x20, 0xe6, 0x5b, 0x85, 0x66, 0xca, 0x4c, 0x31, 0xd3, 0x12, 0x1f, 0x17, 0x98, 0xec, 0x5d,
+	0x28, 0x69, 0x23, 0xdd, 0xf0, 0x7a, 0x98, 0xa8, 0xc8, 0x6a, 0x36, 0x79, 0x96, 0x06, 0x41, 0xa1,
+	0x4a, 0xf4, 0xe1, 0x8c, 0x0a, 0x9a, 0xdf, 0xda, 0x2a, 0x10, 0x43, 0x4f, 0x0e, 0x27, 0x7f, 0x21,
+	0x01, 0x8c, 0xd1, 0x50, 0x19, 0x32, 0x3e, 0x49, 0x32, 0x86, 0x4e, 0x24, 0x88, 0x5a, 0x01, 0xee,
+	0x80, 0x90, 0xdf, 0x11, 0x95, 0x90, 0xbd, 0xac, 0x3f, 0x6a, 0xf7, 0xa9, 0xa5, 0xa5, 0x39, 0xec,
+	0xdc, 0x64, 0x7f, 0x54, 0xa0, 0x37, 0x3c, 0xf9, 0x2e, 0x2c, 0x2b, 0x8e, 0xe6, 0x06, 0x58, 0x3a,
+	0x81, 0x99, 0x7f, 0x29, 0xc1, 0xd5, 0xc8, 0x08, 0x6e, 0x89, 0xef, 0xc2, 0x15, 0x9d, 0xfa, 0x63,
+	0x41, 0x66, 0xb8, 0x5c, 0xd2, 0x11, 0xef, 0x0a, 0x88, 0x30, 0xba, 0x0f, 0x2b, 0x9a, 0x65, 0x5b,
+	0xe7, 0x03, 0xe3, 0xf3, 0xc8, 0x18, 0xa6, 0x3a, 0xae, 0x8e, 0x7b, 0x83, 0xc3, 0x5e, 0x87, 0x15,
+	0x07, 0x7b, 0x9a, 0x61, 0x91, 0xf3, 0xfa, 0x0c, 0x33, 0xb0, 0x48, 0x9c, 0x2d, 0x8b, 0x5e, 0x9f,
+	0x07, 0x24, 0x8a, 0x77, 0xe0, 0x79, 0x92, 0x1e, 0x6a, 0xd9, 0x03, 0xcd, 0xb0, 0x92, 0x95, 0xb5,
+	0x4e, 0xfb, 0xc4, 0x79, 0x59, 0x8b, 0xc4, 0x5d, 0x91, 0x6c, 0xf0, 0xd4, 0x59, 0x60, 0xf9, 0xb7,
+	0x24, 0xb8, 0x9e, 0xb2, 0xe8, 0xaf, 0x34, 0x2f, 0x5a, 0x87, 0x2a, 0xd9, 0x46, 0xc3, 0xb2, 0x07,
+	0x9a, 0x79, 0xde, 0x30, 0xb1, 0xe3, 0xb9, 0x81, 0xe8, 0x28, 0x90, 0x39, 0xa1, 0xbf, 0xe5, 0xbf,
+	0x93, 0x60, 0x3e, 0x88, 0x9c, 0x84, 0x44, 0x94, 0x9e, 0x3b, 0x3a, 0x22, 0xba, 0x9d, 0x2f, 0x2a,
+	0x9a, 0x44, 0xc9, 0xf5, 0xed, 0x91, 0xe5, 0x71, 0x7e, 0xb0, 0x06, 0x7a, 0x0d, 0xf2, 0x4f, 0x0d,
+	0x4b, 0xb7, 0x9f, 0x72, 0x09, 0x5d, 0x8d, 0x49, 0x68, 0x8b, 0x3f, 0x75, 0xa9, 0x1c, 0x91, 0x48,
+	0xb6, 0x8e, 0x3d, 0xdc, 0xf7, 0xa6, 0x8d, 0x87, 0x80, 0xa1, 0x13, 0x80, 0xfc, 0x6d, 0x58, 0x4d,
+	0x38, 0x34, 0xa7, 0xfb, 0xeb, 0x90, 0xd7, 0x28, 0xa4, 0x2a, 0xa5, 0x78, 0xca, 0x81, 0x61, 0x2a,
+	0xc7, 0x95, 0x7f, 0x00, 0x8b, 0xbb, 0x76, 0xff, 0x09, 0xc9, 0x6c, 0x8e, 0x23, 0x8d, 0x82, 0x70,
+	0xe3, 0x38, 0x75, 0xfc, 0x36, 0x71, 0x16, 0xed, 0xa7, 0x56, 0xd0, 0x53, 0x9f, 0xa3, 0xed, 0xb6,
+	0xce, 0xa2, 0x02, 0xcd, 0xb5, 0x85, 0xd0, 0xf0, 0x96, 0x7c, 0x17, 0x96, 0x0e, 0x2d, 0x73, 0xfa,
+	0x35, 0xe4, 0x9f, 0x49, 0x50, 0x20, 0xb8, 0x64, 0x5f, 0xbf, 0xe4, 0xcd, 0x10, 0xd1, 0x27, 0x5b,
+	0xc1, 0x7a, 0xef, 0xe8, 0x5c, 0x04, 0xab, 0x0c, 0xb0, 0x75, 0x4e, 0x32, 0x5c, 0xe4, 0xf7, 0xb4,
+	0x9c, 0xa1, 0x03, 0x29, 0x5f, 0x1e, 0xc1, 0xd5, 0x03, 0x53, 0xeb, 0xe3, 0x5d, 0x7c, 0xa2, 0x99,
+	0x0f, 0x6d, 0x53, 0x9f, 0x86, 0x94, 0xe3, 0x2d, 0x66, 0x42, 0xf4, 0xba, 0x0f, 0xd7, 0x54, 0x6c,
+	0x62, 0xcd, 0xbd, 0xd4, 0x74, 0xf2, 0x1f, 0x48, 0x50, 0xf4, 0x07, 0x7c, 0x95, 0x85, 0xa9, 0x5a,
+	0x20, 0xa7, 0xa0, 0xb4, 0xe1, 0xe9, 0x18, 0x06, 0xd8, 0x3a, 0x47, 0x0f, 0x00, 0xe8, 0x6f, 0x46,
+	0x9c, 0xc9, 0x0a, 0x99, 0x4d, 0x45, 0xa9, 0xb3, 0x42, 0x93, 0x8d, 0x1d, 0xec, 0x9c, 0x61, 0x87,
+	0x26, 0xa6, 0x79, 0x76, 0xfb, 0x75, 0x58, 0x8e, 0x06, 0xbb, 0xee, 0xfb, 0xf6, 0x11, 0x7a, 0x1e,
+	0x8a, 0x62, 0xaf, 0x22, 0x58, 0x19, 0x03, 0xe4, 0x3f, 0x95, 0x60, 0x39, 0xe6, 0x3a, 0x90, 0x61,
+	0x5b, 0x30, 0xc7, 0x8c, 0x95, 0xb8, 0x00, 0xeb, 0x13, 0x3d, 0x0e, 0x11, 0x99, 0x8b, 0x81, 0x49,
+	0xee, 0x66, 0xe6, 0x2b, 0xb9, 0x9b, 0x75, 0x58, 0x6a, 0xda, 0x26, 0x79, 0x75, 0xda, 0xd1, 0x9c,
+	0x23, 0xed, 0x04, 0x93, 0x1d, 0xa6, 0x07, 0x61, 0xf2, 0x03, 0xa8, 0x1c, 0x0e, 0x4f, 0x1c, 0x4d,
+	0xc7, 0x9d, 0xfe, 0x29, 0x1e, 0x68, 0x04, 0xfd, 0xa5, 0x90, 0xc3, 0x2f, 0x85, 0x5e, 0xed, 0x02,
+	0x8e, 0xff, 0xcf, 0xb2, 0x50, 0x61, 0xf9, 0xd5, 0xf7, 0xed, 0x23, 0x21, 0x29, 0x87, 0xc0, 0xad,
+	0x53, 0xcc, 0x6e, 0x95, 0x36, 0x5f, 0x8c, 0x9e, 0x25, 0x89, 0x0b, 0xc4, 0x9f, 0xd0, 0xa3, 0x70,
+	0x32, 0xad, 0x41, 0x89, 0x18, 0x33, 0x6d, 0x09, 0xd3, 0x26, 0x71, 0x89, 0x4c, 0x6b, 0x44, 0xe1,
+	0x68, 0x07, 0xe6, 0x79, 0x50, 0x32, 0x8e, 0xa2, 0x4b, 0x9b, 0x72, 0x74, 0xc2, 0x78, 0xc4, 0xf6,
+	0x70, 0x46, 0x2d, 0x0d, 0xc6, 0x50, 0xb4, 0x4b, 0xf8, 0x47, 0xc9, 0xde, 0x3b, 0x61, 0x74, 0xaf,
+	0xe6, 0x92, 0xe3, 0xac, 0x18, 0x77, 0x88, 0x2b, 0xd6, 0x0f, 0x01, 0x51, 0x1b, 0xca, 0x23, 0xc6,
+	0x94, 0x9e, 0x4b, 0xb9, 0xc2, 0x95, 0xc2, 0x5a, 0x3c, 0xaf, 0x18, 0x66, 0xdd, 0xc3, 0x19, 0x75,
+	0x61, 0x14, 0x84, 0x6d, 0x95, 0xa0, 0x68, 0x0f, 0x31, 0xb3, 0x05, 0xf2, 0x9f, 0x65, 0x21, 0x4b,
+	0x18, 0x9c, 0x92, 0x59, 0xa4, 0x66, 0x29, 0x13, 0x30, 0x4b, 0x75, 0x98, 0x75, 0x3d, 0xcd, 0x13,
+	0xd9, 0x85, 0xd8, 0x8b, 0xcf, 0xfb, 0xf6, 0x51, 0x87, 0xf4, 0xab, 0x0c, 0x8d, 0xcc, 0xa1, 0xdb,
+	0x16, 0xe6, 0xaf, 0x6a, 0xf4, 0x37, 0x7d, 0xbd, 0xd3, 0x0c, 0x13, 0xeb, 0xf4, 0x0c, 0x59, 0x95,
+	0xb7, 0xc6, 0x31, 0x60, 0x3e, 0x10, 0x03, 0x12, 0x28, 0x0d, 0x49, 0x44, 0x79, 0x01, 0x6d, 0x04,
+	0xd3, 0x01, 0x85, 0x70, 0x3a, 0xe0, 0x36, 0x54, 0xfa, 0x9a, 0xd5, 0xc7, 0x66, 0xcf, 0x61, 0x8c,
+	0xc1, 0x3a, 0x7f, 0x1a, 0x59, 0x64, 0x70, 0x55, 0x80, 0xa3, 0xa9, 0x46, 0xb8, 0x54, 0xaa, 0xf1,
+	0x1d, 0x3f, 0xd7, 0xee, 0x19, 0xbc, 0xc6, 0x60, 0xc2, 0x60, 0x86, 0x4e, 0x07, 0xdf, 0x87, 0x02,
+	0xb6, 0x74, 0x36, 0x72, 0x7e, 0xe2, 0xc8, 0x39, 0x6c, 0xe9, 0xa4, 0x25, 0xdf, 0x82, 0x85, 0x1d,
+	0xec, 0x05, 0xee, 0x56, 0x02, 0xdb, 0x64, 0x0d, 0x16, 0x89, 0x65, 0x7e, 0xdf, 0x3e, 0xba, 0xc8,
+	0x0b, 0xf9, 0x5a, 0x9e, 0x57, 0x1f, 0x2a, 0xe3, 0x25, 0xb8, 0xcd, 0x7f, 0x05, 0x72, 0x9f, 0xda,
+	0x47, 0x42, 0xe1, 0x5d, 0x49, 0x10, 0x0c, 0x95, 0x22, 0x4c, 0xed, 0x56, 0xbd, 0x0c, 0x95, 0x26,
+	0x65, 0xd8, 0x84, 0xf3, 0xfe, 0x5c, 0x02, 0x18, 0x6b, 0x74, 0x22, 0x19, 0x67, 0xd8, 0xf1, 0x63,
+	0x9e, 0xa2, 0x2a, 0x9a, 0x44, 0xee, 0xfa, 0xf6, 0x60, 0x60, 0x08, 0x8f, 0x8a, 0xb7, 0x88, 0x3d,
+	0x39, 0x1a, 0x19, 0xa6, 0x3e, 0x6d, 0xc2, 0xb9, 0x48, 0xb1, 0x29, 0x1f, 0xaf, 0x03, 0x9c, 0xd8,
+	0x3d, 0xb1, 0x1e, 0x33, 0xe2, 0xc5, 0x13, 0xfb, 0x03, 0xbe, 0xe2, 0x03, 0x00, 0xd7, 0xd3, 0x9c,
+	0xa9, 0x1d, 0xac, 0x22, 0xc5, 0xa6, 0xac, 0xfe, 0x73, 0x09, 0x96, 0x95, 0x67, 0x43, 0x53, 0x33,
+	0xac, 0x70, 0xfe, 0xf2, 0x22, 0x73, 0xfa, 0x4b, 0x28, 0x11, 0x7a, 0x1b, 0xc0, 0x7f, 0x9e, 0x13,
+	0x09, 0x8e, 0x8b, 0x1e, 0xf3, 0x02, 0xd8, 0xf2, 0x5f, 0x48, 0xb0, 0xc8, 0x36, 0xdb, 0x75, 0xb4,
+	0x3e, 0xee, 0x78, 0x78, 0x98, 0x28, 0x7a, 0xdf, 0x82, 0x3c, 0x3e, 0x3e, 0x16, 0xae, 0x6d, 0x39,
+	0x5e, 0xf7, 0x12, 0x99, 0xa4, 0xae, 0x50, 0x6c, 0x95, 0x8f, 0xa2, 0xc1, 0x04, 0xf6, 0x34, 0xc3,
+	0x14, 0x1e, 0x15, 0x6b, 0xc9, 0xf7, 0x21, 0xaf, 0x08, 0x0c, 0xa4, 0x6c, 0x6f, 0x2b, 0xcd, 0x6e,
+	0x24, 0x32, 0x2f, 0xc2, 0x6c, 0x63, 0x77, 0x77, 0xff, 0xc3, 0x8a, 0x84, 0x0a, 0x90, 0x6b, 0x29,
+	0x7b, 0x1f, 0x57, 0x32, 0xf2, 0x29, 0x2c, 0xb1, 0x05, 0x29, 0xbd, 0x2d, 0xaa, 0x18, 0x89, 0xe5,
+	0xa7, 0x9b, 0xf2, 0x44, 0x1e, 0xa6, 0xa0, 0x8e, 0x01, 0xe8, 0x3e, 0x51, 0x83, 0x78, 0xc8, 0xb2,
+	0x94, 0x09, 0x39, 0xd1, 0xc8, 0x01, 0x54, 0x86, 0x4d, 0x98, 0x5a, 0x55, 0xf1, 0x50, 0x33, 0x9c,
+	0x84, 0x10, 0x69, 0x07, 0xf2, 0x5a, 0xdf, 0x13, 0x72, 0x5b, 0xde, 0xbc, 0x1b, 0xe3, 0x52, 0xca,
+	0xc8, 0x7a, 0xa3, 0xcf, 0xfc, 0x7a, 0x36, 0x3c, 0x92, 0x9d, 0xcb, 0x44, 0xb3, 0x73, 0x1b, 0x90,
+	0x67, 0x03, 0x48, 0x32, 0x42, 0x55, 0x0e, 0xf6, 0xd5, 0x6e, 0x65, 0x06, 0xcd, 0x41, 0x76, 0xbb,
+	0xfd, 0x51, 0x45, 0x42, 0x65, 0x80, 0x6f, 0x1f, 0x36, 0xd4, 0xc6, 0x5e, 0xb7, 0xbd, 0xa7, 0x54,
+	0x32, 0xf2, 0x7f, 0x65, 0xe0, 0xca, 0x63, 0xcd, 0x3c, 0xb6, 0x9d, 0x41, 0x28, 0x9e, 0x8f, 0xc6,
+	0xdd, 0x0a, 0xcc, 0x0d, 0x1d, 0xfb, 0xc8, 0xc4, 0x03, 0xce, 0xd5, 0x57, 0x63, 0x36, 0x33, 0x3e,
+	0x4b, 0xfd, 0x80, 0x0d, 0x51, 0xc5, 0xd8, 0x34, 0xde, 0xa2, 0x3d, 0x00, 0x22, 0xe6, 0xe6, 0xc8,
+	0x13, 0x37, 0xad, 0xbc, 0x59, 0x9f, 0x66, 0x05, 0xd5, 0x1f, 0xa5, 0x06, 0x66, 0x90, 0x0d, 0x98,
+	0xe3, 0x6b, 0x93, 0x3c, 0xce, 0x81, 0xba, 0xbf, 0xb5, 0xab, 0x3c, 0x8e, 0x48, 0xcb, 0x12, 0x2c,
+	0x3c, 0x6e, 0x77, 0x3a, 0xed, 0xbd, 0x9d, 0xde, 0x76, 0x5b, 0xd9, 0x25, 0xd9, 0x9c, 0x0a, 0xcc,
+	0x1f, 0xee, 0x3d, 0xda, 0xdb, 0xff, 0x70, 0xaf, 0xa7, 0xee, 0xef, 0x2a, 0x95, 0x0c, 0x41, 0x6a,
+	0xef, 0x7d, 0xd0, 0xd8, 0x6d, 0xb7, 0x38, 0x52, 0x16, 0x2d, 0x40, 0xb1, 0x75, 0x78, 0xb0, 0xdb,
+	0x6e, 0x36, 0xba, 0x4a, 0x25, 0x27, 0xbf, 0x01, 0x30, 0xde, 0x04, 0xcf, 0x07, 0xed, 0xab, 0x5d,
+	0x21, 0x90, 0xdb, 0xed, 0x8f, 0x68, 0xa2, 0x68, 0x11, 0x4a, 0x63, 0xc2, 0xb7, 0x2a, 0x19, 0xf9,
+	0xaf, 0x24, 0x58, 0x8d, 0xf1, 0xdc, 0xcf, 0x13, 0x3e, 0x0f, 0xc5, 0x81, 0x38, 0x2e, 0xcf, 0x02,
+	0x8c, 0x01, 0xac, 0x12, 0xe6, 0x99, 0x9f, 0x26, 0x64, 0x0d, 0x52, 0x09, 0xf3, 0xd9, 0x48, 0x23,
+	0x65, 0x1e, 0x24, 0x80, 0x17, 0x95, 0x30, 0x01, 0x10, 0x52, 0xc2, 0x11, 0x33, 0x4b, 0xfd, 0xdd,
+	0x9a, 0x82, 0xce, 0xa1, 0xd0, 0x59, 0x56, 0xe1, 0x9a, 0xf2, 0x8c, 0xb8, 0x56, 0x5d, 0x6c, 0x69,
+	0x96, 0x17, 0x4c, 0x7d, 0xbc, 0x09, 0x45, 0x8f, 0x02, 0xc7, 0xcf, 0x3f, 0xb5, 0x2f, 0xbf, 0x58,
+	0x5d, 0x29, 0x48, 0xd5, 0xf7, 0xe4, 0xca, 0x27, 0xdf, 0x6d, 0x6c, 0x7c, 0x47, 0xdb, 0xf8, 0xfc,
+	0xde, 0xc6, 0x83, 0xde, 0xc6, 0xf7, 0x5f, 0x7d, 0x51, 0x2d, 0x30, 0xe4, 0xb6, 0x2e, 0xef, 0x41,
+	0x25, 0x38, 0x1b, 0x4d, 0x74, 0xdd, 0x00, 0xe0, 0x8e, 0xd2, 0x58, 0xdf, 0x07, 0x20, 0x44, 0x59,
+	0xea, 0x76, 0x7f, 0x34, 0x20, 0x59, 0x62, 0xa6, 0x11, 0xfd, 0xb6, 0xfc, 0x23, 0x40, 0x07, 0x23,
+	0xe7, 0x04, 0xb3, 0x49, 0x27, 0x6d, 0x2f, 0x69, 0x73, 0x05, 0x69, 0xbc, 0x3d, 0xb4, 0x01, 0x88,
+	0x38, 0xde, 0x86, 0x33, 0xa0, 0x0a, 0x24, 0x64, 0xd9, 0x96, 0x82, 0x3d, 0xcc, 0xba, 0xfd, 0x93,
+	0x04, 0x57, 0x42, 0xcb, 0x73, 0x33, 0x4a, 0xb2, 0xda, 0x04, 0x2c, 0x94, 0x0e, 0x6f, 0x5d, 0x72,
+	0x7a, 0xe2, 0x9d, 0xe0, 0x67, 0x43, 0xc3, 0x99, 0xfe, 0x15, 0x95, 0xa1, 0x13, 0x00, 0x11, 0x93,
+	0x31, 0x0d, 0x45, 0x25, 0x4d, 0x10, 0x44, 0x84, 0x4f, 0xd0, 0xd1, 0xe5, 0x5e, 0xdc, 0x18, 0x20,
+	0x77, 0xa0, 0xb6, 0x83, 0x39, 0xeb, 0x55, 0xcd, 0xc3, 0xbb, 0xc6, 0xc0, 0x18, 0xa7, 0x44, 0xee,
+	0xc7, 0x29, 0x5c, 0xfd, 0xf2, 0x8b, 0xd5, 0xe5, 0x28, 0x7d, 0xef, 0xbc, 0x58, 0x7d, 0x2f, 0xc0,
+	0xfe, 0x2f, 0x24, 0xa8, 0x75, 0x2e, 0x3f, 0x6b, 0x9c, 0x6f, 0x77, 0x02, 0x42, 0x45, 0xaa, 0x72,
+	0x1c, 0xac, 0xe9, 0x2e, 0x09, 0x22, 0x7a, 0x2e, 0xee, 0xdb, 0x96, 0xb8, 0x32, 0x65, 0x0a, 0x3f,
+	0xc0, 0x4e, 0x87, 0x42, 0xd1, 0x1d, 0x58, 0x7a, 0xea, 0x18, 0x1e, 0x0e, 0xa1, 0xb2, 0x1b, 0xb4,
+	0xc8, 0x3a, 0x7c, 0x5c, 0xf9, 0x1f, 0x33, 0x50, 0x89, 0x6e, 0x94, 0x38, 0x5c, 0x91, 0x1d, 0xfe,
+	0x6f, 0xef, 0x83, 0x58, 0x02, 0xe6, 0x74, 0x06, 0x72, 0x0c, 0x45, 0x0e, 0xd9, 0x3a, 0x8f, 0xba,
+	0xb0, 0xb3, 0x97, 0x72, 0x61, 0xdf, 0x84, 0xaa, 0xff, 0x52, 0x19, 0xdd, 0x39, 0x73, 0xe0, 0xaf,
+	0xf2, 0x7e, 0x35, 0x7c, 0x80, 0x07, 0xb0, 0x2a, 0x06, 0xc6, 0x0f, 0xc2, 0xaa, 0xdc, 0x56, 0x38,
+	0xc2, 0x87, 0x11, 0xba, 0xfe, 0x86, 0x04, 0x0b, 0x2a, 0x7d, 0xf0, 0x30, 0x6c, 0x8b, 0x7a, 0x7b,
+	0x49, 0xee, 0x05, 0x82, 0x9c, 0x33, 0x32, 0xfd, 0x0c, 0x30, 0xf9, 0x1d, 0x4c, 0xa7, 0x65, 0xc3,
+	0xe9, 0x34, 0x12, 0x49, 0xb0, 0xd5, 0x78, 0x90, 0x22, 0x9a, 0xb4, 0xb0, 0x99, 0xb0, 0x8e, 0x0b,
+	0x38, 0x6b, 0xc8, 0xf7, 0xe1, 0xca, 0x81, 0x83, 0x9f, 0x6a, 0xce, 0x80, 0x96, 0xe0, 0x8d, 0x9f,
+	0x95, 0x79, 0xe9, 0x21, 0x8d, 0xa6, 0xb7, 0x0a, 0x5f, 0x7e, 0xb1, 0x9a, 0x2b, 0x48, 0x15, 0x89,
+	0x17, 0x21, 0xca, 0x7b, 0xb0, 0x1c, 0x1e, 0xc6, 0xef, 0xfb, 0xf2, 0x78, 0x5c, 0x7a, 0xc9, 0x62,
+	0x26, 0x56, 0xb2, 0x28, 0x9f, 0x03, 0x6a, 0xb8, 0xae, 0x71, 0x62, 0xed, 0x93, 0x34, 0x93, 0xd8,
+	0x85, 0x1c, 0x75, 0x0e, 0xfd, 0xe7, 0x6d, 0x1f, 0x1e, 0x7c, 0x7d, 0xcf, 0x24, 0xbe, 0xbe, 0xdf,
+	0x08, 0x27, 0xac, 0xc6, 0xfd, 0x0c, 0x2a, 0x7f, 0x04, 0x37, 0x49, 0x1d, 0x28, 0x0d, 0xaf, 0x2c,
+	0x8f, 0x44, 0xaf, 0xda, 0x91, 0xed, 0x90, 0xe0, 0xcb, 0xa7, 0xc6, 0xc4, 0x17, 0x7e, 0x9f, 0xb6,
+	0xcc, 0x3d, 0xe1, 0xb4, 0xfd, 0x85, 0x04, 0x6b, 0xe9, 0x53, 0x73, 0x8a, 0xf5, 0x61, 0xa1, 0x1f,
+	0xec, 0xe0, 0x11, 0xc7, 0xbb, 0x51, 0x23, 0x35, 0x69, 0xa2, 0x7a, 0x10, 0xaa, 0x86, 0xe7, 0xac,
+	0x0d, 0x60, 0x3e, 0xd8, 0x9d, 0xfe, 0x60, 0x7f, 0x13, 0x4a, 0xb4, 0x40, 0x5e, 0xef, 0x3d, 0x35,
+	0xbc, 0x53, 0xce, 0x29, 0x60, 0xa0, 0x0f, 0x0d, 0xef, 0x94, 0x3d, 0x4f, 0xf7, 0xb1, 0x71, 0x26,
+	0x6a, 0xb1, 0xd9, 0x5d, 0x9d, 0x17, 0x40, 0x52, 0x8a, 0x2d, 0xff, 0xb5, 0x44, 0x9e, 0x7f, 0x3c,
+	0x22, 0x1a, 0xbc, 0x92, 0x94, 0xb8, 0x68, 0x67, 0xc4, 0xc1, 0xbe, 0x04, 0x67, 0xef, 0xc1, 0xac,
+	0x6b, 0x58, 0x7d, 0x9c, 0x5a, 0xf8, 0x35, 0xbe, 0xc9, 0x0c, 0x31, 0x5c, 0x8c, 0x9d, 0x9d, 0xaa,
+	0x18, 0x3b, 0x17, 0x0d, 0x06, 0x7f, 0x91, 0x85, 0xc5, 0xc8, 0xa6, 0x63, 0xce, 0xe1, 0x5b, 0x81,
+	0x54, 0x42, 0x39, 0x9e, 0x9e, 0x89, 0x0c, 0xa7, 0xc5, 0x97, 0xfc, 0x32, 0x47, 0x9e, 0x5f, 0xb2,
+	0x97, 0x79, 0x7e, 0x21, 0x59, 0x2e, 0x8d, 0x14, 0x5b, 0x13, 0xb6, 0xf1, 0x0f, 0x1e, 0x68, 0xbb,
+	0x4d, 0x55, 0xa3, 0x28, 0xcf, 0x37, 0x58, 0x22, 0x82, 0x44, 0x6e, 0x0c, 0xd2, 0xd6, 0x63, 0xd5,
+	0xfb, 0xf9, 0x78, 0xf5, 0xbe, 0x88, 0xa9, 0xe6, 0x26, 0xc6, 0x54, 0x0f, 0x60, 0x61, 0xe8, 0xe0,
+	0x33, 0xc3, 0x1e, 0xb9, 0x2c, 0xa9, 0x54, 0xb8, 0x60, 0xc8, 0xbc, 0x40, 0x25, 0x2d, 0x54, 0x87,
+	0x2b, 0xac, 0xa2, 0x45, 0xef, 0x8d, 0xb7, 0xeb, 0x56, 0x8b, 0xd4, 0x24, 0x2f, 0xf1, 0xae, 0x1d,
+	0xb1, 0x6d, 0x57, 0xfe, 0x14, 0x72, 0x84, 0x78, 0x68, 0x19, 0x2a, 0x8f, 0xda, 0x7b, 0xad, 0xf8,
+	0xc3, 0xe4, 0x0e, 0x71, 0x30, 0x15, 0xee, 0xca, 0x12, 0x17, 0xb6, 0xd7, 0x7c, 0xd8, 0xd8, 0xdb,
+	0xa1, 0x8f, 0x93, 0x25, 0x98, 0x3b, 0x3c, 0x68, 0x35, 0xba, 0xe2, 0x75, 0x52, 0x55, 0x3e, 0xd8,
+	0x7f, 0x44, 0x5e, 0x27, 0xd1, 0x15, 0x58, 0xec, 0x3c, 0x6c, 0xa8, 0xc4, 0x13, 0xee, 0x74, 0xf7,
+	0xe9, 0x93, 0xe5, 0xac, 0xfc, 0x3b, 0x12, 0xdc, 0x48, 0x13, 0x5a, 0x7e, 0x57, 0xff, 0x3f, 0x80,
+	0xc6, 0x60, 0xc6, 0x05, 0x05, 0x24, 0x91, 0xc1, 0x81, 0x21, 0x53, 0x27, 0x0b, 0xfe, 0x56, 0x82,
+	0x55, 0x96, 0x79, 0x14, 0xd1, 0xf2, 0x99, 0x81, 0x9f, 0x8a, 0xcb, 0xf3, 0x3c, 0xcc, 0x7a, 0x86,
+	0x67, 0x46, 0x6f, 0x0e, 0x03, 0x86, 0x93, 0xbc, 0x99, 0x48, 0x92, 0x97, 0xbc, 0xbb, 0x89, 0x46,
+	0x2f, 0xe0, 0x6b, 0x32, 0x2b, 0x82, 0x44, 0x57, 0xd3, 0xef, 0x41, 0x6f, 0x90, 0x87, 0x3a, 0x5a,
+	0x81, 0xdb, 0x73, 0xe8, 0x2e, 0xf0, 0x58, 0xfe, 0xfc, 0xa5, 0x97, 0x38, 0x8a, 0xca, 0x31, 0xda,
+	0xba, 0xfc, 0x87, 0xb3, 0xa2, 0x4c, 0x8a, 0x01, 0x13, 0x73, 0x72, 0xcb, 0xe2, 0x24, 0xbc, 0x8e,
+	0x24, 0xe1, 0x04, 0xd9, 0x29, 0x4f, 0x90, 0x4b, 0x3d, 0x41, 0x3d, 0xf9, 0x04, 0xec, 0x92, 0xc4,
+	0x77, 0x8e, 0xde, 0x12, 0x49, 0xc1, 0x3c, 0x95, 0x6b, 0x39, 0x39, 0x1a, 0x66, 0x03, 0xea, 0xa1,
+	0xf4, 0x20, 0xa9, 0x60, 0xf6, 0xf0, 0xa0, 0xc7, 0x1e, 0xb4, 0x98, 0xf1, 0x2f, 0x12, 0x48, 0x93,
+	0x00, 0x88, 0xee, 0xd4, 0x71, 0xdf, 0xd0, 0xb1, 0xce, 0x31, 0x0a, 0x4c, 0x77, 0x72, 0xa0, 0x8f,
+	0x24, 0xae, 0x08, 0x43, 0x2a, 0x0a, 0x05, 0x4b, 0x81, 0x3e, 0x92, 0xfb, 0xc4, 0x18, 0x0e, 0x7d,
+	0x24, 0x60, 0x48, 0x1c, 0xc8, 0x90, 0x6e, 0x43, 0x85, 0x0d, 0xea, 0x8d, 0x2c, 0xbe, 0x04, 0xcd,
+	0xeb, 0x15, 0xd4, 0x45, 0x06, 0x3f, 0x14, 0xe0, 0x60, 0xfe, 0x71, 0x3e, 0x9c, 0x7f, 0x8c, 0x24,
+	0x15, 0x17, 0x2e, 0x95, 0x54, 0x7c, 0x0e, 0x8a, 0x7d, 0xd3, 0x76, 0x99, 0xbf, 0xc6, 0xbe, 0x18,
+	0x2a, 0x30, 0x00, 0x7b, 0xf7, 0xa0, 0xbf, 0xd9, 0xc4, 0x8b, 0x93, 0xb3, 0x49, 0x14, 0x9b, 0xb4,
+	0xe5, 0x06, 0xcc, 0x52, 0xba, 0xa3, 0xab, 0xb0, 0xd4, 0xe9, 0x36, 0xba, 0xd1, 0x8a, 0x85, 0x02,
+	0xe4, 0xf6, 0x0f, 0x94, 0xbd, 0x8a, 0x44, 0x6b, 0x17, 0x76, 0xf7, 0x49, 0xcc, 0xcb, 0xaa, 0x15,
+	0x48, 0x83, 0xe8, 0x03, 0xf9, 0x75, 0x58, 0xd9, 0xc1, 0x5e, 0xd2, 0xed, 0xba, 0xa8, 0x8a, 0xf0,
+	0x13, 0xfe, 0x36, 0x1a, 0x18, 0xe6, 0x3b, 0x09, 0x21, 0xe3, 0x23, 0x4d, 0x65, 0x7c, 0x32, 0x51,
+	0xe3, 0xf3, 0x13, 0x09, 0x56, 0x13, 0x16, 0xe0, 0xea, 0xa7, 0x09, 0x65, 0x56, 0x8c, 0xc6, 0xe5,
+	0x38, 0xfd, 0x3d, 0x32, 0x78, 0xac, 0x05, 0x2d, 0x38, 0xd9, 0xd4, 0x2a, 0x48, 0x83, 0x6a, 0x93,
+	0x10, 0xfc, 0x92, 0x24, 0x4a, 0x94, 0xba, 0x4c, 0xa2, 0xd4, 0xc9, 0xff, 0x9d, 0x81, 0x4a, 0x70,
+	0xfa, 0xb6, 0x87, 0x07, 0x24, 0x06, 0x8e, 0xd4, 0x79, 0x14, 0x43, 0x05, 0x0c, 0xd3, 0x17, 0x53,
+	0xa6, 0xd7, 0xd8, 0xdd, 0x84, 0x52, 0x4c, 0x97, 0xa9, 0xe0, 0x8c, 0x55, 0x80, 0x02, 0x05, 0xb2,
+	0x49, 0xba, 0x85, 0x59, 0xba, 0xd0, 0xed, 0x8b, 0x68, 0x4c, 0x36, 0x5e, 0x6f, 0xf1, 0x01, 0xaa,
+	0x3f, 0x94, 0x70, 0x5b, 0x5c, 0xf8, 0xa3, 0x73, 0x6e, 0x74, 0x8b, 0x1c, 0xc2, 0x02, 0x16, 0xd6,
+	0x60, 0x57, 0x60, 0x6e, 0x9a, 0x17, 0x6b, 0x82, 0x4e, 0xef, 0xc0, 0x7b, 0x50, 0x10, 0x2b, 0xa2,
+	0x2a, 0x2c, 0xb7, 0x94, 0x66, 0xbb, 0xd3, 0xde, 0xdf, 0x8b, 0xdc, 0x84, 0x05, 0x28, 0x36, 0x15,
+	0xb5, 0xcb, 0x9a, 0x52, 0xd0, 0x0a, 0x66, 0x48, 0xa6, 0xf9, 0xf9, 0xa8, 0xb0, 0x91, 0x93, 0xf8,
+	0x12, 0xfd, 0x2a, 0x2c, 0x84, 0xe4, 0x2d, 0xc2, 0xef, 0xf9, 0xa0, 0x60, 0x45, 0x69, 0x9a, 0x89,
+	0xd1, 0xf4, 0x05, 0x98, 0x1f, 0x62, 0x4b, 0x27, 0x1f, 0x3a, 0xda, 0x96, 0x79, 0xce, 0x0b, 0xd2,
+	0x4a, 0x1c, 0xb6, 0x6f, 0x99, 0xe7, 0xe1, 0x2b, 0x94, 0x9b, 0xea, 0x0a, 0xcd, 0x46, 0xaf, 0xd0,
+	0x8f, 0xe1, 0x7a, 0xca, 0xa1, 0xf8, 0x2d, 0x7a, 0x03, 0x66, 0x89, 0x4a, 0x16, 0x97, 0x67, 0x6d,
+	0x12, 0x63, 0x55, 0x86, 0x3e, 0xf5, 0xc5, 0xf9, 0xa3, 0x0c, 0xdc, 0x68, 0x51, 0x3e, 0xfd, 0x72,
+	0x08, 0x7b, 0x08, 0x45, 0x21, 0x50, 0x22, 0x41, 0xfb, 0x66, 0xfc, 0x9d, 0xf1, 0xa2, 0xf5, 0xc6,
+	0xa2, 0x39, 0x9e, 0xa9, 0x76, 0x1e, 0x90, 0x9f, 0x97, 0xe3, 0x77, 0x2e, 0xf1, 0x83, 0x8c, 0xe0,
+	0xb5, 0xc8, 0x7c, 0xe5, 0x6b, 0x21, 0x7f, 0x0c, 0x37, 0x53, 0x37, 0xfc, 0xf5, 0x98, 0x24, 0xff,
+	0x8d, 0xc4, 0xbe, 0xe4, 0xec, 0x78, 0x1a, 0xff, 0x8e, 0xcc, 0x27, 0xfa, 0x5b, 0x00, 0xf4, 0xdb,
+	0xb6, 0x9e, 0x77, 0xaa, 0x89, 0x02, 0xb2, 0x0b, 0xca, 0x4a, 0x8a, 0x14, 0xb9, 0x7b, 0xaa, 0x59,
+	0x69, 0x1e, 0x47, 0x26, 0xd5, 0xe3, 0x78, 0x2e, 0x16, 0x87, 0x4c, 0x1f, 0x7f, 0xf0, 0xef, 0x22,
+	0x43, 0x07, 0xf8, 0x55, 0xd6, 0xff, 0xdc, 0xf9, 0x2e, 0xe4, 0xa8, 0xaf, 0xbe, 0x0c, 0x15, 0xea,
+	0x50, 0xc7, 0x9e, 0x1c, 0x3e, 0x54, 0xdb, 0x5d, 0x85, 0x3d, 0x39, 0xa8, 0x4a, 0x83, 0x78, 0xda,
+	0x44, 0xcb, 0xec, 0x3f, 0x7e, 0xac, 0xec, 0x75, 0x15, 0xb5, 0x92, 0x25, 0x39, 0xe1, 0xc3, 0x83,
+	0xdd, 0xfd, 0x46, 0x4b, 0x51, 0x2b, 0x39, 0xa2, 0x73, 0x1a, 0x87, 0xad, 0x76, 0x77, 0x5f, 0xad,
+	0xcc, 0xde, 0xf9, 0x21, 0xc0, 0xf8, 0xb5, 0x05, 0xd5, 0x60, 0xa5, 0xd9, 0x38, 0x68, 0x6c, 0xb5,
+	0x77, 0xdb, 0xdd, 0x8f, 0xe3, 0x36, 0xfc, 0x83, 0xb6, 0xc2, 0x9f, 0x36, 0x94, 0x56, 0xbb, 0x5b,
+	0xc9, 0x90, 0x5f, 0xbb, 0xed, 0x4e, 0xb7, 0x92, 0x25, 0xde, 0x3e, 0xab, 0x49, 0xec, 0x35, 0x1f,
+	0xb6, 0x77, 0x5b, 0x6c, 0x19, 0xbe, 0x87, 0xca, 0x2c, 0xd9, 0x3b, 0x19, 0xdc, 0x3b, 0x50, 0x54,
+	0x9a, 0xf2, 0xde, 0xdf, 0xeb, 0x54, 0xf2, 0x77, 0x7e, 0x00, 0xe5, 0x70, 0x71, 0x01, 0xba, 0x09,
+	0xcf, 0x35, 0xf7, 0xf7, 0xb6, 0x77, 0xdb, 0xcd, 0x6e, 0xef, 0x60, 0x7f, 0xb7, 0xdd, 0x4c, 0xd8,
+	0x05, 0x29, 0x6a, 0x14, 0xaa, 0x93, 0x16, 0x3e, 0x56, 0x32, 0xe4, 0x41, 0x86, 0xd6, 0x3d, 0xf6,
+	0x1e, 0xb6, 0x77, 0x1e, 0x2a, 0x9d, 0x2e, 0xcb, 0x9e, 0x67, 0xef, 0x7c, 0x0f, 0x0a, 0xe2, 0xc9,
+	0x18, 0xad, 0xc2, 0xd5, 0xf7, 0xf7, 0xb7, 0x7a, 0x49, 0xfe, 0x09, 0x99, 0xeb, 0x70, 0x6f, 0x8f,
+	0x78, 0x25, 0x12, 0x21, 0x5e, 0xe7, 0xb0, 0xd9, 0x54, 0x94, 0x96, 0x28, 0xa9, 0xdc, 0x6e, 0xb4,
+	0x77, 0x15, 0x9e, 0x79, 0x6f, 0x36, 0xf6, 0x9a, 0xca, 0x2e, 0x69, 0xe6, 0x36, 0xff, 0x7e, 0x1e,
+	0x4a, 0xc1, 0xc7, 0xfd, 0x13, 0xf6, 0x34, 0x1a, 0x04, 0xbd, 0x3c, 0xdd, 0x97, 0xbf, 0xb5, 0x57,
+	0x26, 0xe2, 0x31, 0x99, 0x93, 0xb3, 0xbf, 0x9b, 0x91, 0xd0, 0x07, 0xf4, 0xa1, 0x76, 0xdc, 0x8d,
+	0x5e, 0x4c, 0xc8, 0x4e, 0xc4, 0x3e, 0xac, 0xa8, 0x5d, 0x20, 0x9a, 0x6c, 0xde, 0x8f, 0x45, 0x7d,
+	0x45, 0x60, 0xea, 0xd8, 0xce, 0x52, 0xbe, 0x70, 0xbb, 0x70, 0xf6, 0x19, 0x32, 0x75, 0xf4, 0x9b,
+	0xa4, 0xf8, 0xd4, 0x29, 0x1f, 0xaf, 0x4d, 0x98, 0xfa, 0x53, 0x58, 0x8a, 0x0e, 0x74, 0xd1, 0xfa,
+	0xb4, 0xdf, 0x7e, 0xd5, 0x6e, 0x4f, 0xfd, 0xed, 0x94, 0x3c, 0x83, 0x0e, 0xa1, 0x12, 0xad, 0x21,
+	0x89, 0x1f, 0x23, 0xe5, 0xc3, 0x96, 0xda, 0x4a, 0x4c, 0xbb, 0x29, 0xe4, 0xff, 0x1f, 0xe4, 0x19,
+	0xa4, 0x43, 0x39, 0xfc, 0x85, 0x04, 0x7a, 0x29, 0xed, 0x3b, 0x88, 0xd0, 0x73, 0x6d, 0xed, 0xe5,
+	0x49, 0x68, 0x41, 0xb1, 0x39, 0x82, 0xa5, 0xd8, 0x27, 0x43, 0x71, 0x42, 0xa5, 0x7d, 0x55, 0x54,
+	0xbb, 0xa0, 0x82, 0x9f, 0xa3, 0xc8, 0x33, 0x68, 0x08, 0xd5, 0xb4, 0xcf, 0x82, 0x50, 0xec, 0xc9,
+	0x71, 0xc2, 0x07, 0x44, 0xd3, 0xad, 0xe8, 0xc1, 0xb5, 0x94, 0xef, 0xc6, 0x51, 0x3d, 0x29, 0x69,
+	0x97, 0xfe, 0x81, 0x79, 0xed, 0xc5, 0x69, 0xbe, 0xbe, 0x66, 0xb4, 0x3c, 0x84, 0xa2, 0xff, 0xc1,
+	0x32, 0x5a, 0x4b, 0xba, 0xbd, 0xc1, 0xef, 0x9b, 0x6b, 0x2f, 0x5c, 0x80, 0x11, 0x64, 0xd1, 0x6f,
+	0x4a, 0x50, 0x4d, 0xcb, 0x2c, 0xc6, 0xe9, 0x37, 0x21, 0x4f, 0x5a, 0xbb, 0x77, 0xd9, 0xa4, 0x25,
+	0xdb, 0xc4, 0x8f, 0x60, 0x25, 0x39, 0xf1, 0x82, 0x36, 0x92, 0x26, 0x4c, 0xcd, 0x2a, 0xd6, 0xea,
+	0xd3, 0xa2, 0x07, 0x57, 0x3f, 0x87, 0xab, 0x89, 0x0e, 0x23, 0xfa, 0x7f, 0x49, 0x34, 0x4c, 0xf3,
+	0xb1, 0x6a, 0x1b, 0x53, 0x62, 0x87, 0x0f, 0x7e, 0x2d, 0xc5, 0x11, 0x8a, 0x8b, 0xd2, 0xc5, 0x2e,
+	0x5e, 0xed, 0xee, 0xd4, 0xf8, 0x42, 0xb7, 0x6c, 0xfe, 0xf3, 0x32, 0x54, 0x02, 0x5a, 0xa7, 0xa1,
+	0x0f, 0x0c, 0x0b, 0x7d, 0x07, 0x4a, 0x81, 0x62, 0x30, 0x34, 0x45, 0xa5, 0x58, 0xed, 0xd6, 0x05,
+	0x38, 0xe2, 0x7d, 0x57, 0x9e, 0xb9, 0x27, 0x21, 0x0b, 0x96, 0x62, 0x95, 0x6b, 0x68, 0xea, 0x5a,
+	0xc2, 0xda, 0xed, 0x89, 0x98, 0xe3, 0xd5, 0xd6, 0x25, 0xba, 0xde, 0x4a, 0xf2, 0x47, 0x08, 0x49,
+	0x72, 0x75, 0xc1, 0xc7, 0x0a, 0xb5, 0x58, 0x8d, 0x62, 0xf8, 0x03, 0x05, 0xca, 0xcc, 0x7b, 0x12,
+	0xfa, 0x04, 0x16, 0x42, 0xc5, 0xee, 0x71, 0x33, 0x99, 0x54, 0x3d, 0x5f, 0x7b, 0x69, 0x02, 0x96,
+	0x6f, 0x0c, 0xb8, 0xa4, 0xc6, 0x0a, 0xc4, 0x93, 0x25, 0x35, 0xad, 0x78, 0xbd, 0xb6, 0x31, 0x25,
+	0x76, 0x50, 0x52, 0x07, 0xec, 0xff, 0x12, 0x42, 0xf5, 0xd1, 0x71, 0xd6, 0xa5, 0xd5, 0x8d, 0xd7,
+	0x6e, 0x4f, 0x81, 0x19, 0x5c, 0x6e, 0x07, 0x0a, 0xa2, 0x76, 0x1a, 0xc5, 0x12, 0xac, 0x91, 0xaa,
+	0xea, 0x5a, 0xac, 0x6a, 0x4f, 0x94, 0x38, 0xcb, 0x33, 0xe8, 0x11, 0xc0, 0xb8, 0x44, 0x1a, 0xc5,
+	0xb4, 0x62, 0xac, 0x7c, 0xfa, 0xc2, 0xc9, 0xba, 0x50, 0x0e, 0x17, 0x23, 0xc7, 0xad, 0x66, 0x62,
+	0xb1, 0x72, 0x6d, 0x35, 0x76, 0x04, 0x81, 0x21, 0xcf, 0xa0, 0x8f, 0xa0, 0x12, 0xad, 0x4a, 0x8e,
+	0x9b, 0xf8, 0x94, 0xba, 0xe5, 0x8b, 0x67, 0x66, 0x6e, 0x5b, 0xa0, 0x98, 0x2c, 0xc9, 0x6d, 0x8b,
+	0x55, 0x0f, 0xc7, 0xbd, 0x9f, 0x31, 0x0a, 0xe3, 0x4e, 0x0b, 0x8a, 0x7e, 0x59, 0x6c, 0xdc, 0x16,
+	0x45, 0x2b, 0x66, 0x6b, 0x49, 0xc5, 0x73, 0xf2, 0x0c, 0x6a, 0x40, 0x9e, 0x55, 0xff, 0xa1, 0xeb,
+	0x09, 0xdb, 0x9a, 0x34, 0x9e, 0x6e, 0x44, 0x85, 0x82, 0x28, 0xdc, 0x4b, 0x10, 0x93, 0x70, 0xd5,
+	0x60, 0x6d, 0x2d, 0x1d, 0x21, 0x28, 0x7a, 0xe4, 0x70, 0xa2, 0x4e, 0x2f, 0xe1, 0x70, 0x91, 0x12,
+	0xbe, 0xb4, 0xc3, 0x7d, 0x1f, 0x16, 0x42, 0xe5, 0x6e, 0x09, 0xaa, 0x20, 0xa1, 0x1a, 0x2e, 0x6e,
+	0xb6, 0x63, 0x95, 0x5c, 0x6c, 0x93, 0x26, 0x2c, 0xc5, 0x4a, 0x69, 0x92, 0x3c, 0xab, 0xe4, 0x0a,
+	0xab, 0xda, 0xed, 0x89, 0x98, 0x21, 0xbd, 0xad, 0x43, 0x25, 0x5a, 0xfe, 0x12, 0x97, 0xd0, 0x94,
+	0x02, 0x99, 0x38, 0xd9, 0xa3, 0x55, 0x2f, 0x42, 0x7b, 0x7e, 0x04, 0xa5, 0x40, 0x05, 0x49, 0xdc,
+	0xf2, 0xc4, 0xab, 0x5b, 0x6a, 0xb7, 0x2e, 0xc4, 0xf1, 0xf5, 0xe6, 0x29, 0x5c, 0x49, 0x28, 0xe0,
+	0x40, 0x77, 0x12, 0xc4, 0x2e, 0xa5, 0x1e, 0x23, 0xed, 0x14, 0x63, 0x44, 0xc6, 0x17, 0x0c, 0x57,
+	0x3a, 0xd3, 0xac, 0xd4, 0xf9, 0x3a, 0x2b, 0xcd, 0xa0, 0x4f, 0x60, 0x3e, 0xf8, 0xfa, 0x8e, 0xe2,
+	0x74, 0x88, 0x3f, 0xe9, 0xd7, 0x5e, 0xbc, 0x18, 0x29, 0x78, 0x07, 0xf6, 0xa1, 0x14, 0x78, 0x8d,
+	0x8f, 0xb3, 0x22, 0xfe, 0x54, 0x3f, 0x21, 0x64, 0xea, 0x01, 0x8a, 0x3f, 0x67, 0xa1, 0xdb, 0xc9,
+	0xaa, 0x23, 0x21, 0xe3, 0x5c, 0xbb, 0x30, 0xc5, 0x2d, 0xcf, 0xa0, 0xef, 0xc1, 0x62, 0x24, 0x9d,
+	0x1f, 0x0f, 0x85, 0x93, 0xf3, 0xfd, 0x13, 0xa6, 0x0e, 0x59, 0xbf, 0x50, 0x22, 0x7d, 0x7d, 0x92,
+	0xc3, 0x37, 0xc1, 0xfa, 0x25, 0xa5, 0xf8, 0xd9, 0x72, 0xdf, 0x87, 0xa5, 0x58, 0xea, 0x3d, 0xbe,
+	0x5c, 0x5a, 0x76, 0x7e, 0x22, 0xad, 0x78, 0xda, 0x20, 0x90, 0x61, 0x4a, 0x4e, 0x1b, 0xc4, 0x73,
+	0x68, 0xb5, 0x57, 0x26, 0xe2, 0x05, 0xce, 0xb1, 0xf5, 0xcd, 0xef, 0xbc, 0x7d, 0x62, 0x78, 0xa7,
+	0xa3, 0xa3, 0x7a, 0xdf, 0x1e, 0xdc, 0x1d, 0x10, 0xde, 0x6a, 0x83, 0xbb, 0xe3, 0x19, 0x36, 0x5c,
+	0xec, 0x9c, 0x19, 0x7d, 0xfe, 0x07, 0x83, 0x77, 0xcf, 0x36, 0xdf, 0x09, 0xcc, 0x7e, 0x94, 0xa7,
+	0xd0, 0x6f, 0xfc, 0xcf, 0x00, 0x78, 0x94, 0x51, 0x8a, 0x08, 0x51, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// PermissionsClient is the client API for Permissions service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PermissionsClient interface {
+	// ListPermissions returns the permissions of a file, a page at a time.
+	ListPermissions(ctx context.Context, in *ListPermissionsRequest, opts ...grpc.CallOption) (*ListPermissionsResponse, error)
+	// GetPermission returns a permission by its resource name.
+	GetPermission(ctx context.Context, in *GetPermissionRequest, opts ...grpc.CallOption) (*Permission, error)
+	// CreatePermission creates a new permission and returns it, fails if the permission already exists.
+	CreatePermission(ctx context.Context, in *CreatePermissionRequest, opts ...grpc.CallOption) (*Permission, error)
+	// UpdatePermission updates the fields of a permission that are listed in the update mask and returns it.
+	// Fails with ABORTED if the permission's etag is set and doesn't match the current etag.
+	UpdatePermission(ctx context.Context, in *UpdatePermissionRequest, opts ...grpc.CallOption) (*Permission, error)
+	// UpdatePermissions changes the roles of a batch of permissions with a single bulk write,
+	// and returns the result of each update, such as changing everyone in a folder to READ.
+	// The updates succeed or fail independently, in no particular order.
+	UpdatePermissions(ctx context.Context, in *UpdatePermissionsRequest, opts ...grpc.CallOption) (*UpdatePermissionsResponse, error)
+	// DeletePermission deletes a permission by its resource name.
+	// Fails with ABORTED if the etag is set and doesn't match the current etag.
+	DeletePermission(ctx context.Context, in *DeletePermissionRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// SimulateAccess returns the access users would have to a file if a set of changes
+	// to its permissions were applied, without applying them.
+	SimulateAccess(ctx context.Context, in *SimulateAccessRequest, opts ...grpc.CallOption) (*SimulateAccessResponse, error)
+	// RequestPermission requests a permission whose role requires the approval of a second user,
+	// such as the roles that CreatePermission and UpdatePermission fail to grant with FAILED_PRECONDITION.
+	// The permission is created once the request is approved.
+	RequestPermission(ctx context.Context, in *RequestPermissionRequest, opts ...grpc.CallOption) (*PermissionRequest, error)
+	// ApprovePermissionRequest approves a pending permission request, by an approver other than its requester,
+	// creates its permission and returns the approved request.
+	ApprovePermissionRequest(ctx context.Context, in *ApprovePermissionRequestRequest, opts ...grpc.CallOption) (*PermissionRequest, error)
+	// GetFolderSharingSummary returns a summary of the sharing of a folder's tree, the folder and its
+	// descendants: its distinct grantees, the grantees of each role and its exposure outside of the tenant.
+	// The tree is made of the descendants of the request if they're set, otherwise of the files that inherit
+	// the folder's permissions, which doesn't include the permissions given to the descendants directly.
+	GetFolderSharingSummary(ctx context.Context, in *GetFolderSharingSummaryRequest, opts ...grpc.CallOption) (*FolderSharingSummary, error)
+	// ListRoles returns the roles that permissions may have, with the metadata that clients need to display
+	// them, such as in role pickers, so that new roles don't require new releases of the clients.
+	ListRoles(ctx context.Context, in *ListRolesRequest, opts ...grpc.CallOption) (*ListRolesResponse, error)
+	// GetFrequentCollaborators returns the users that a user most frequently shares files with, and receives
+	// them from, for the suggestions of the share dialog. They're computed from the permissions by the
+	// `refresh_collaborators` recurring job, so they're as fresh as its last run.
+	GetFrequentCollaborators(ctx context.Context, in *GetFrequentCollaboratorsRequest, opts ...grpc.CallOption) (*GetFrequentCollaboratorsResponse, error)
+	// GetFileSharingActivity returns the feed of the changes to the sharing of a file, newest first, for its
+	// activity panel: the permissions that were given, the changes of their roles and the revocations, including
+	// of domain grants. It's derived from the recorded permission events, so it only goes back as far as
+	// their retention, and fails with FAILED_PRECONDITION if they aren't recorded.
+	GetFileSharingActivity(ctx context.Context, in *GetFileSharingActivityRequest, opts ...grpc.CallOption) (*GetFileSharingActivityResponse, error)
+	// ListAccessReviewItems returns the grants of an access review campaign that a reviewer reviews, a page
+	// at a time. An actor may only list the items that it reviews.
+	ListAccessReviewItems(ctx context.Context, in *ListAccessReviewItemsRequest, opts ...grpc.CallOption) (*ListAccessReviewItemsResponse, error)
+	// DecideAccessReviewItems records the actor's decisions of the grants that it reviews in an open access review
+	// campaign, whether each is certified or revoked, and returns the decided items. A decision may be changed
+	// until the campaign is closed. It fails with FAILED_PRECONDITION if the campaign isn't open, and with
+	// NOT_FOUND if the actor doesn't review one of the grants, in which case none of the decisions is recorded.
+	DecideAccessReviewItems(ctx context.Context, in *DecideAccessReviewItemsRequest, opts ...grpc.CallOption) (*DecideAccessReviewItemsResponse, error)
+}
+
+type permissionsClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewPermissionsClient(cc *grpc.ClientConn) PermissionsClient {
+	return &permissionsClient{cc}
+}
+
+func (c *permissionsClient) ListPermissions(ctx context.Context, in *ListPermissionsRequest, opts ...grpc.CallOption) (*ListPermissionsResponse, error) {
+	out := new(ListPermissionsResponse)
+	err := c.cc.Invoke(ctx, "/permissions.v2.Permissions/ListPermissions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsClient) GetPermission(ctx context.Context, in *GetPermissionRequest, opts ...grpc.CallOption) (*Permission, error) {
+	out := new(Permission)
+	err := c.cc.Invoke(ctx, "/permissions.v2.Permissions/GetPermission", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsClient) CreatePermission(ctx context.Context, in *CreatePermissionRequest, opts ...grpc.CallOption) (*Permission, error) {
+	out := new(Permission)
+	err := c.cc.Invoke(ctx, "/permissions.v2.Permissions/CreatePermission", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsClient) UpdatePermission(ctx context.Context, in *UpdatePermissionRequest, opts ...grpc.CallOption) (*Permission, error) {
+	out := new(Permission)
+	err := c.cc.Invoke(ctx, "/permissions.v2.Permissions/UpdatePermission", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsClient) UpdatePermissions(ctx context.Context, in *UpdatePermissionsRequest, opts ...grpc.CallOption) (*UpdatePermissionsResponse, error) {
+	out := new(UpdatePermissionsResponse)
+	err := c.cc.Invoke(ctx, "/permissions.v2.Permissions/UpdatePermissions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsClient) DeletePermission(ctx context.Context, in *DeletePermissionRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/permissions.v2.Permissions/DeletePermission", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsClient) SimulateAccess(ctx context.Context, in *SimulateAccessRequest, opts ...grpc.CallOption) (*SimulateAccessResponse, error) {
+	out := new(SimulateAccessResponse)
+	err := c.cc.Invoke(ctx, "/permissions.v2.Permissions/SimulateAccess", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsClient) RequestPermission(ctx context.Context, in *RequestPermissionRequest, opts ...grpc.CallOption) (*PermissionRequest, error) {
+	out := new(PermissionRequest)
+	err := c.cc.Invoke(ctx, "/permissions.v2.Permissions/RequestPermission", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsClient) ApprovePermissionRequest(ctx context.Context, in *ApprovePermissionRequestRequest, opts ...grpc.CallOption) (*PermissionRequest, error) {
+	out := new(PermissionRequest)
+	err := c.cc.Invoke(ctx, "/permissions.v2.Permissions/ApprovePermissionRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsClient) GetFolderSharingSummary(ctx context.Context, in *GetFolderSharingSummaryRequest, opts ...grpc.CallOption) (*FolderSharingSummary, error) {
+	out := new(FolderSharingSummary)
+	err := c.cc.Invoke(ctx, "/permissions.v2.Permissions/GetFolderSharingSummary", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsClient) ListRoles(ctx context.Context, in *ListRolesRequest, opts ...grpc.CallOption) (*ListRolesResponse, error) {
+	out := new(ListRolesResponse)
+	err := c.cc.Invoke(ctx, "/permissions.v2.Permissions/ListRoles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsClient) GetFrequentCollaborators(ctx context.Context, in *GetFrequentCollaboratorsRequest, opts ...grpc.CallOption) (*GetFrequentCollaboratorsResponse, error) {
+	out := new(GetFrequentCollaboratorsResponse)
+	err := c.cc.Invoke(ctx, "/permissions.v2.Permissions/GetFrequentCollaborators", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsClient) GetFileSharingActivity(ctx context.Context, in *GetFileSharingActivityRequest, opts ...grpc.CallOption) (*GetFileSharingActivityResponse, error) {
+	out := new(GetFileSharingActivityResponse)
+	err := c.cc.Invoke(ctx, "/permissions.v2.Permissions/GetFileSharingActivity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsClient) ListAccessReviewItems(ctx context.Context, in *ListAccessReviewItemsRequest, opts ...grpc.CallOption) (*ListAccessReviewItemsResponse, error) {
+	out := new(ListAccessReviewItemsResponse)
+	err := c.cc.Invoke(ctx, "/permissions.v2.Permissions/ListAccessReviewItems", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsClient) DecideAccessReviewItems(ctx context.Context, in *DecideAccessReviewItemsRequest, opts ...grpc.CallOption) (*DecideAccessReviewItemsResponse, error) {
+	out := new(DecideAccessReviewItemsResponse)
+	err := c.cc.Invoke(ctx, "/permissions.v2.Permissions/DecideAccessReviewItems", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PermissionsServer is the server API for Permissions service.
+type PermissionsServer interface {
+	// ListPermissions returns the permissions of a file, a page at a time.
+	ListPermissions(context.Context, *ListPermissionsRequest) (*ListPermissionsResponse, error)
+	// GetPermission returns a permission by its resource name.
+	GetPermission(context.Context, *GetPermissionRequest) (*Permission, error)
+	// CreatePermission creates a new permission and returns it, fails if the permission already exists.
+	CreatePermission(context.Context, *CreatePermissionRequest) (*Permission, error)
+	// UpdatePermission updates the fields of a permission that are listed in the update mask and returns it.
+	// Fails with ABORTED if the permission's etag is set and doesn't match the current etag.
+	UpdatePermission(context.Context, *UpdatePermissionRequest) (*Permission, error)
+	// UpdatePermissions changes the roles of a batch of permissions with a single bulk write,
+	// and returns the result of each update, such as changing everyone in a folder to READ.
+	// The updates succeed or fail independently, in no particular order.
+	UpdatePermissions(context.Context, *UpdatePermissionsRequest) (*UpdatePermissionsResponse, error)
+	// DeletePermission deletes a permission by its resource name.
+	// Fails with ABORTED if the etag is set and doesn't match the current etag.
+	DeletePermission(context.Context, *DeletePermissionRequest) (*empty.Empty, error)
+	// SimulateAccess returns the access users would have to a file if a set of changes
+	// to its permissions were applied, without applying them.
+	SimulateAccess(context.Context, *SimulateAccessRequest) (*SimulateAccessResponse, error)
+	// RequestPermission requests a permission whose role requires the approval of a second user,
+	// such as the roles that CreatePermission and UpdatePermission fail to grant with FAILED_PRECONDITION.
+	// The permission is created once the request is approved.
+	RequestPermission(context.Context, *RequestPermissionRequest) (*PermissionRequest, error)
+	// ApprovePermissionRequest approves a pending permission request, by an approver other than its requester,
+	// creates its permission and returns the approved request.
+	ApprovePermissionRequest(context.Context, *ApprovePermissionRequestRequest) (*PermissionRequest, error)
+	// GetFolderSharingSummary returns a summary of the sharing of a folder's tree, the folder and its
+	// descendants: its distinct grantees, the grantees of each role and its exposure outside of the tenant.
+	// The tree is made of the descendants of the request if they're set, otherwise of the files that inherit
+	// the folder's permissions, which doesn't include the permissions given to the descendants directly.
+	GetFolderSharingSummary(context.Context, *GetFolderSharingSummaryRequest) (*FolderSharingSummary, error)
+	// ListRoles returns the roles that permissions may have, with the metadata that clients need to display
+	// them, such as in role pickers, so that new roles don't require new releases of the clients.
+	ListRoles(context.Context, *ListRolesRequest) (*ListRolesResponse, error)
+	// GetFrequentCollaborators returns the users that a user most frequently shares files with, and receives
+	// them from, for the suggestions of the share dialog. They're computed from the permissions by the
+	// `refresh_collaborators` recurring job, so they're as fresh as its last run.
+	GetFrequentCollaborators(context.Context, *GetFrequentCollaboratorsRequest) (*GetFrequentCollaboratorsResponse, error)
+	// GetFileSharingActivity returns the feed of the changes to the sharing of a file, newest first, for its
+	// activity panel: the permissions that were given, the changes of their roles and the revocations, including
+	// of domain grants. It's derived from the recorded permission events, so it only goes back as far as
+	// their retention, and fails with FAILED_PRECONDITION if they aren't recorded.
+	GetFileSharingActivity(context.Context, *GetFileSharingActivityRequest) (*GetFileSharingActivityResponse, error)
+	// ListAccessReviewItems returns the grants of an access review campaign that a reviewer reviews, a page
+	// at a time. An actor may only list the items that it reviews.
+	ListAccessReviewItems(context.Context, *ListAccessReviewItemsRequest) (*ListAccessReviewItemsResponse, error)
+	// DecideAccessReviewItems records the actor's decisions of the grants that it reviews in an open access review
+	// campaign, whether each is certified or revoked, and returns the decided items. A decision may be changed
+	// until the campaign is closed. It fails with FAILED_PRECONDITION if the campaign isn't open, and with
+	// NOT_FOUND if the actor doesn't review one of the grants, in which case none of the decisions is recorded.
+	DecideAccessReviewItems(context.Context, *DecideAccessReviewItemsRequest) (*DecideAccessReviewItemsResponse, error)
+}
+
+// UnimplementedPermissionsServer can be embedded to have forward compatible implementations.
+type UnimplementedPermissionsServer struct {
+}
+
+func (*UnimplementedPermissionsServer) ListPermissions(ctx context.Context, req *ListPermissionsRequest) (*ListPermissionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPermissions not implemented")
+}
+func (*UnimplementedPermissionsServer) GetPermission(ctx context.Context, req *GetPermissionRequest) (*Permission, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPermission not implemented")
+}
+func (*UnimplementedPermissionsServer) CreatePermission(ctx context.Context, req *CreatePermissionRequest) (*Permission, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePermission not implemented")
+}
+func (*UnimplementedPermissionsServer) UpdatePermission(ctx context.Context, req *UpdatePermissionRequest) (*Permission, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePermission not implemented")
+}
+func (*UnimplementedPermissionsServer) UpdatePermissions(ctx context.Context, req *UpdatePermissionsRequest) (*UpdatePermissionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePermissions not implemented")
+}
+func (*UnimplementedPermissionsServer) DeletePermission(ctx context.Context, req *DeletePermissionRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePermission not implemented")
+}
+func (*UnimplementedPermissionsServer) SimulateAccess(ctx context.Context, req *SimulateAccessRequest) (*SimulateAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SimulateAccess not implemented")
+}
+func (*UnimplementedPermissionsServer) RequestPermission(ctx context.Context, req *RequestPermissionRequest) (*PermissionRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestPermission not implemented")
+}
+func (*UnimplementedPermissionsServer) ApprovePermissionRequest(ctx context.Context, req *ApprovePermissionRequestRequest) (*PermissionRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApprovePermissionRequest not implemented")
+}
+func (*UnimplementedPermissionsServer) GetFolderSharingSummary(ctx context.Context, req *GetFolderSharingSummaryRequest) (*FolderSharingSummary, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFolderSharingSummary not implemented")
+}
+func (*UnimplementedPermissionsServer) ListRoles(ctx context.Context, req *ListRolesRequest) (*ListRolesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRoles not implemented")
+}
+func (*UnimplementedPermissionsServer) GetFrequentCollaborators(ctx context.Context, req *GetFrequentCollaboratorsRequest) (*GetFrequentCollaboratorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFrequentCollaborators not implemented")
+}
+func (*UnimplementedPermissionsServer) GetFileSharingActivity(ctx context.Context, req *GetFileSharingActivityRequest) (*GetFileSharingActivityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFileSharingActivity not implemented")
+}
+func (*UnimplementedPermissionsServer) ListAccessReviewItems(ctx context.Context, req *ListAccessReviewItemsRequest) (*ListAccessReviewItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccessReviewItems not implemented")
+}
+func (*UnimplementedPermissionsServer) DecideAccessReviewItems(ctx context.Context, req *DecideAccessReviewItemsRequest) (*DecideAccessReviewItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DecideAccessReviewItems not implemented")
+}
+
+func RegisterPermissionsServer(s *grpc.Server, srv PermissionsServer) {
+	s.RegisterService(&_Permissions_serviceDesc, srv)
+}
+
+func _Permissions_ListPermissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPermissionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsServer).ListPermissions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.Permissions/ListPermissions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsServer).ListPermissions(ctx, req.(*ListPermissionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Permissions_GetPermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPermissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsServer).GetPermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.Permissions/GetPermission",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsServer).GetPermission(ctx, req.(*GetPermissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Permissions_CreatePermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePermissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsServer).CreatePermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.Permissions/CreatePermission",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsServer).CreatePermission(ctx, req.(*CreatePermissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Permissions_UpdatePermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePermissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsServer).UpdatePermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.Permissions/UpdatePermission",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsServer).UpdatePermission(ctx, req.(*UpdatePermissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Permissions_UpdatePermissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePermissionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsServer).UpdatePermissions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.Permissions/UpdatePermissions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsServer).UpdatePermissions(ctx, req.(*UpdatePermissionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Permissions_DeletePermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePermissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsServer).DeletePermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.Permissions/DeletePermission",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsServer).DeletePermission(ctx, req.(*DeletePermissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Permissions_SimulateAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulateAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsServer).SimulateAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.Permissions/SimulateAccess",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsServer).SimulateAccess(ctx, req.(*SimulateAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Permissions_RequestPermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestPermissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsServer).RequestPermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.Permissions/RequestPermission",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsServer).RequestPermission(ctx, req.(*RequestPermissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Permissions_ApprovePermissionRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApprovePermissionRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsServer).ApprovePermissionRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.Permissions/ApprovePermissionRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsServer).ApprovePermissionRequest(ctx, req.(*ApprovePermissionRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Permissions_GetFolderSharingSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFolderSharingSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsServer).GetFolderSharingSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.Permissions/GetFolderSharingSummary",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsServer).GetFolderSharingSummary(ctx, req.(*GetFolderSharingSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Permissions_ListRoles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRolesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsServer).ListRoles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.Permissions/ListRoles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsServer).ListRoles(ctx, req.(*ListRolesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Permissions_GetFrequentCollaborators_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFrequentCollaboratorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsServer).GetFrequentCollaborators(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.Permissions/GetFrequentCollaborators",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsServer).GetFrequentCollaborators(ctx, req.(*GetFrequentCollaboratorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Permissions_GetFileSharingActivity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFileSharingActivityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsServer).GetFileSharingActivity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.Permissions/GetFileSharingActivity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsServer).GetFileSharingActivity(ctx, req.(*GetFileSharingActivityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Permissions_ListAccessReviewItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccessReviewItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsServer).ListAccessReviewItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.Permissions/ListAccessReviewItems",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsServer).ListAccessReviewItems(ctx, req.(*ListAccessReviewItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Permissions_DecideAccessReviewItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecideAccessReviewItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsServer).DecideAccessReviewItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.Permissions/DecideAccessReviewItems",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsServer).DecideAccessReviewItems(ctx, req.(*DecideAccessReviewItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Permissions_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "permissions.v2.Permissions",
+	HandlerType: (*PermissionsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListPermissions",
+			Handler:    _Permissions_ListPermissions_Handler,
+		},
+		{
+			MethodName: "GetPermission",
+			Handler:    _Permissions_GetPermission_Handler,
+		},
+		{
+			MethodName: "CreatePermission",
+			Handler:    _Permissions_CreatePermission_Handler,
+		},
+		{
+			MethodName: "UpdatePermission",
+			Handler:    _Permissions_UpdatePermission_Handler,
+		},
+		{
+			MethodName: "UpdatePermissions",
+			Handler:    _Permissions_UpdatePermissions_Handler,
+		},
+		{
+			MethodName: "DeletePermission",
+			Handler:    _Permissions_DeletePermission_Handler,
+		},
+		{
+			MethodName: "SimulateAccess",
+			Handler:    _Permissions_SimulateAccess_Handler,
+		},
+		{
+			MethodName: "RequestPermission",
+			Handler:    _Permissions_RequestPermission_Handler,
+		},
+		{
+			MethodName: "ApprovePermissionRequest",
+			Handler:    _Permissions_ApprovePermissionRequest_Handler,
+		},
+		{
+			MethodName: "GetFolderSharingSummary",
+			Handler:    _Permissions_GetFolderSharingSummary_Handler,
+		},
+		{
+			MethodName: "ListRoles",
+			Handler:    _Permissions_ListRoles_Handler,
+		},
+		{
+			MethodName: "GetFrequentCollaborators",
+			Handler:    _Permissions_GetFrequentCollaborators_Handler,
+		},
+		{
+			MethodName: "GetFileSharingActivity",
+			Handler:    _Permissions_GetFileSharingActivity_Handler,
+		},
+		{
+			MethodName: "ListAccessReviewItems",
+			Handler:    _Permissions_ListAccessReviewItems_Handler,
+		},
+		{
+			MethodName: "DecideAccessReviewItems",
+			Handler:    _Permissions_DecideAccessReviewItems_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "permissions.proto",
+}
+
+// PermissionsAdminClient is the client API for PermissionsAdmin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PermissionsAdminClient interface {
+	// MigrateRole changes the role of the permissions that match a filter from one role to another,
+	// in batches, and streams the progress of the migration.
+	MigrateRole(ctx context.Context, in *MigrateRoleRequest, opts ...grpc.CallOption) (PermissionsAdmin_MigrateRoleClient, error)
+	// ImportPermissions creates the permissions that are streamed to it and streams the progress of the import.
+	// The permissions are written at a rate limited by the server so that imports don't starve other requests,
+	// and the next permission isn't received until the previous one is written.
+	ImportPermissions(ctx context.Context, opts ...grpc.CallOption) (PermissionsAdmin_ImportPermissionsClient, error)
+	// GenerateUserDataReport streams the data that's stored about a user: the permissions the user holds,
+	// the permissions the user created for other users and the audit entries that reference the user.
+	GenerateUserDataReport(ctx context.Context, in *GenerateUserDataReportRequest, opts ...grpc.CallOption) (PermissionsAdmin_GenerateUserDataReportClient, error)
+	// EraseUserData deletes the permissions a user holds and replaces the user as the creator
+	// of the permissions the user created, and in their sharing chains. The audit entries that reference
+	// the user are retained until their retention expires.
+	EraseUserData(ctx context.Context, in *EraseUserDataRequest, opts ...grpc.CallOption) (*EraseUserDataResponse, error)
+	// ListDomainPermissions returns the permissions that were given to everyone in an organization,
+	// a page at a time, ordered by their creation.
+	ListDomainPermissions(ctx context.Context, in *ListDomainPermissionsRequest, opts ...grpc.CallOption) (*ListDomainPermissionsResponse, error)
+	// ListAnomalyAlerts returns the most recent alerts of unusual patterns of changes to permissions,
+	// such as an actor giving thousands of permissions, newest first.
+	ListAnomalyAlerts(ctx context.Context, in *ListAnomalyAlertsRequest, opts ...grpc.CallOption) (*ListAnomalyAlertsResponse, error)
+	// LockFile locks a resource down for incident response, such as of a leaked file: until it's unlocked,
+	// the access of every user other than its owner is denied, without deleting their permissions.
+	// Locking a locked resource replaces its lock.
+	LockFile(ctx context.Context, in *LockFileRequest, opts ...grpc.CallOption) (*FileLock, error)
+	// UnlockFile lifts the lockdown of a resource and returns the lifted lock,
+	// fails with NOT_FOUND if the resource isn't locked.
+	UnlockFile(ctx context.Context, in *UnlockFileRequest, opts ...grpc.CallOption) (*FileLock, error)
+	// PlaceLegalHold places a resource under legal hold: until it's released, changes to the resource's
+	// permissions other than new permissions fail with FAILED_PRECONDITION, and their audit records
+	// don't expire. Placing the hold of a held resource replaces its hold.
+	PlaceLegalHold(ctx context.Context, in *PlaceLegalHoldRequest, opts ...grpc.CallOption) (*LegalHold, error)
+	// ReleaseLegalHold releases the legal hold of a resource and returns the released hold,
+	// fails with NOT_FOUND if the resource isn't held.
+	ReleaseLegalHold(ctx context.Context, in *ReleaseLegalHoldRequest, opts ...grpc.CallOption) (*LegalHold, error)
+	// GetServerInfo returns the version of the running build of the server,
+	// so that behavior changes can be correlated with the deployed versions.
+	GetServerInfo(ctx context.Context, in *GetServerInfoRequest, opts ...grpc.CallOption) (*ServerInfo, error)
+	// CreateJob starts a long-running bulk operation, such as a bulk delete, an import or a role migration,
+	// as a persisted job and returns it without waiting for it to finish. Its progress is tracked
+	// with GetJob. A job's resource name is `jobs/{job}`.
+	CreateJob(ctx context.Context, in *CreateJobRequest, opts ...grpc.CallOption) (*Job, error)
+	// GetJob returns a job and its progress.
+	GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*Job, error)
+	// ListJobs returns the jobs, newest first.
+	ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error)
+	// CancelJob requests the cancellation of a running job and returns it, the job stops at its next
+	// progress update. Fails with FAILED_PRECONDITION if the job already finished.
+	CancelJob(ctx context.Context, in *CancelJobRequest, opts ...grpc.CallOption) (*Job, error)
+	// ExplainAccess returns the trace of the evaluation of a user's access to a resource, which lockdowns,
+	// grants, inheritance steps and roles were consulted and why the decision was reached, for support and
+	// debugging. The decision is the one IsPermitted makes, without recording it in the decision log.
+	ExplainAccess(ctx context.Context, in *ExplainAccessRequest, opts ...grpc.CallOption) (*AccessExplanation, error)
+	// RepairPermissions scans the stored permissions for malformed documents, ones that violate the schema
+	// of the permissions, such as of a missing user_id or an unknown role, or that duplicate the permission
+	// of a user to a resource. It streams the malformed documents in batches, and fixes or quarantines them
+	// if requested. Repairs aren't published as events.
+	RepairPermissions(ctx context.Context, in *RepairPermissionsRequest, opts ...grpc.CallOption) (PermissionsAdmin_RepairPermissionsClient, error)
+	// ExportTenantData streams every document that's stored for a tenant, for offboarding it. A tenant's data
+	// is stored in the collections of the service whose names start with the tenant's ID and an underscore,
+	// the collection prefix of the tenant's deployment, such as "acme_permissions" of the tenant "acme".
+	ExportTenantData(ctx context.Context, in *ExportTenantDataRequest, opts ...grpc.CallOption) (PermissionsAdmin_ExportTenantDataClient, error)
+	// PurgeTenant deletes every document that's stored for a tenant, in two steps: a request without
+	// a confirmation token returns the number of documents that would be deleted and a confirmation token,
+	// and a request with the token deletes them, before the token expires. A token may be used once.
+	// Purges are audited with the actors that requested and confirmed them. The tenant of the server
+	// itself may not be purged.
+	PurgeTenant(ctx context.Context, in *PurgeTenantRequest, opts ...grpc.CallOption) (*PurgeTenantResponse, error)
+	// GetTenantRateLimits returns the rate limits of a tenant's reads and writes, which are persisted in its settings,
+	// and for the tenant of the server itself the current rates of the requests that the serving instance admitted.
+	GetTenantRateLimits(ctx context.Context, in *GetTenantRateLimitsRequest, opts ...grpc.CallOption) (*TenantRateLimits, error)
+	// SetTenantRateLimits sets the rate limits of a tenant's reads and writes, which are the RPCs of the Permission
+	// and Permissions services that are annotated with the NO_SIDE_EFFECTS idempotency level and the rest of them.
+	// Each instance of the tenant's deployment rejects the requests over its limits with RESOURCE_EXHAUSTED and
+	// the delay after which they may be retried, and applies changed limits within its refresh interval.
+	SetTenantRateLimits(ctx context.Context, in *SetTenantRateLimitsRequest, opts ...grpc.CallOption) (*TenantRateLimits, error)
+	// PrewarmFiles loads the permissions of hot files ahead of an anticipated load, such as of an organization-wide
+	// announcement file, into the store's cache and into the last-known permissions of the instance that serves it,
+	// which the checks are served from while the store is unavailable. It's called by the gateways or by the
+	// admin tooling, in each region, since it only reads.
+	PrewarmFiles(ctx context.Context, in *PrewarmFilesRequest, opts ...grpc.CallOption) (*PrewarmFilesResponse, error)
+	// AssignOwner makes a user the owner of an orphaned resource, whose owner's account was deleted, by giving
+	// the user a WRITE permission of its own, in place of direct edits of the store. It fails with FAILED_PRECONDITION
+	// if the resource still has an owner, or is locked down. The assignment is audited with its actor and reason,
+	// and writes an `owner_assigned` event in addition to the event of the owner's permission.
+	AssignOwner(ctx context.Context, in *AssignOwnerRequest, opts ...grpc.CallOption) (*Permission, error)
+	// CreateAccessReview starts an access review campaign over the direct grants of a set of resources, or of
+	// every resource of a collection, and returns it. The current grants are snapshotted as the campaign's items,
+	// each reviewed by the owner of its resource, or by the campaign's default reviewer if the resource has no
+	// owner. The owners' own grants aren't reviewed. A campaign's resource name is `accessReviews/{review}`.
+	CreateAccessReview(ctx context.Context, in *CreateAccessReviewRequest, opts ...grpc.CallOption) (*AccessReview, error)
+	// GetAccessReview returns an access review campaign and its progress.
+	GetAccessReview(ctx context.Context, in *GetAccessReviewRequest, opts ...grpc.CallOption) (*AccessReview, error)
+	// ListAccessReviews returns the access review campaigns, newest first.
+	ListAccessReviews(ctx context.Context, in *ListAccessReviewsRequest, opts ...grpc.CallOption) (*ListAccessReviewsResponse, error)
+	// CloseAccessReview closes an access review campaign, so that its decisions may no longer change, and revokes
+	// the grants that were decided to be revoked, and the undecided ones if requested, in bulk. The grants of
+	// resources under legal hold aren't revoked. A campaign whose closing was interrupted is closed again by
+	// another call. Fails with FAILED_PRECONDITION if the campaign is already closed.
+	CloseAccessReview(ctx context.Context, in *CloseAccessReviewRequest, opts ...grpc.CallOption) (*AccessReview, error)
+	// ListStaleGrants returns the direct grants that weren't used within a duration, by their last access time,
+	// or by their creation if they were never used, which are candidates for expiry, a page at a time, ordered
+	// by their creation. The owners' own grants are never stale.
+	ListStaleGrants(ctx context.Context, in *ListStaleGrantsRequest, opts ...grpc.CallOption) (*ListStaleGrantsResponse, error)
+}
+
+type permissionsAdminClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewPermissionsAdminClient(cc *grpc.ClientConn) PermissionsAdminClient {
+	return &permissionsAdminClient{cc}
+}
+
+func (c *permissionsAdminClient) MigrateRole(ctx context.Context, in *MigrateRoleRequest, opts ...grpc.CallOption) (PermissionsAdmin_MigrateRoleClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_PermissionsAdmin_serviceDesc.Streams[0], "/permissions.v2.PermissionsAdmin/MigrateRole", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &permissionsAdminMigrateRoleClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PermissionsAdmin_MigrateRoleClient interface {
+	Recv() (*MigrateRoleProgress, error)
+	grpc.ClientStream
+}
+
+type permissionsAdminMigrateRoleClient struct {
+	grpc.ClientStream
+}
+
+func (x *permissionsAdminMigrateRoleClient) Recv() (*MigrateRoleProgress, error) {
+	m := new(MigrateRoleProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *permissionsAdminClient) ImportPermissions(ctx context.Context, opts ...grpc.CallOption) (PermissionsAdmin_ImportPermissionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_PermissionsAdmin_serviceDesc.Streams[1], "/permissions.v2.PermissionsAdmin/ImportPermissions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &permissionsAdminImportPermissionsClient{stream}
+	return x, nil
+}
+
+type PermissionsAdmin_ImportPermissionsClient interface {
+	Send(*ImportPermissionsRequest) error
+	Recv() (*ImportPermissionsProgress, error)
+	grpc.ClientStream
+}
+
+type permissionsAdminImportPermissionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *permissionsAdminImportPermissionsClient) Send(m *ImportPermissionsRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *permissionsAdminImportPermissionsClient) Recv() (*ImportPermissionsProgress, error) {
+	m := new(ImportPermissionsProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *permissionsAdminClient) GenerateUserDataReport(ctx context.Context, in *GenerateUserDataReportRequest, opts ...grpc.CallOption) (PermissionsAdmin_GenerateUserDataReportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_PermissionsAdmin_serviceDesc.Streams[2], "/permissions.v2.PermissionsAdmin/GenerateUserDataReport", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &permissionsAdminGenerateUserDataReportClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PermissionsAdmin_GenerateUserDataReportClient interface {
+	Recv() (*UserDataRecord, error)
+	grpc.ClientStream
+}
+
+type permissionsAdminGenerateUserDataReportClient struct {
+	grpc.ClientStream
+}
+
+func (x *permissionsAdminGenerateUserDataReportClient) Recv() (*UserDataRecord, error) {
+	m := new(UserDataRecord)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *permissionsAdminClient) EraseUserData(ctx context.Context, in *EraseUserDataRequest, opts ...grpc.CallOption) (*EraseUserDataResponse, error) {
+	out := new(EraseUserDataResponse)
+	err := c.cc.Invoke(ctx, "/permissions.v2.PermissionsAdmin/EraseUserData", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsAdminClient) ListDomainPermissions(ctx context.Context, in *ListDomainPermissionsRequest, opts ...grpc.CallOption) (*ListDomainPermissionsResponse, error) {
+	out := new(ListDomainPermissionsResponse)
+	err := c.cc.Invoke(ctx, "/permissions.v2.PermissionsAdmin/ListDomainPermissions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsAdminClient) ListAnomalyAlerts(ctx context.Context, in *ListAnomalyAlertsRequest, opts ...grpc.CallOption) (*ListAnomalyAlertsResponse, error) {
+	out := new(ListAnomalyAlertsResponse)
+	err := c.cc.Invoke(ctx, "/permissions.v2.PermissionsAdmin/ListAnomalyAlerts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsAdminClient) LockFile(ctx context.Context, in *LockFileRequest, opts ...grpc.CallOption) (*FileLock, error) {
+	out := new(FileLock)
+	err := c.cc.Invoke(ctx, "/permissions.v2.PermissionsAdmin/LockFile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsAdminClient) UnlockFile(ctx context.Context, in *UnlockFileRequest, opts ...grpc.CallOption) (*FileLock, error) {
+	out := new(FileLock)
+	err := c.cc.Invoke(ctx, "/permissions.v2.PermissionsAdmin/UnlockFile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsAdminClient) PlaceLegalHold(ctx context.Context, in *PlaceLegalHoldRequest, opts ...grpc.CallOption) (*LegalHold, error) {
+	out := new(LegalHold)
+	err := c.cc.Invoke(ctx, "/permissions.v2.PermissionsAdmin/PlaceLegalHold", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsAdminClient) ReleaseLegalHold(ctx context.Context, in *ReleaseLegalHoldRequest, opts ...grpc.CallOption) (*LegalHold, error) {
+	out := new(LegalHold)
+	err := c.cc.Invoke(ctx, "/permissions.v2.PermissionsAdmin/ReleaseLegalHold", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsAdminClient) GetServerInfo(ctx context.Context, in *GetServerInfoRequest, opts ...grpc.CallOption) (*ServerInfo, error) {
+	out := new(ServerInfo)
+	err := c.cc.Invoke(ctx, "/permissions.v2.PermissionsAdmin/GetServerInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsAdminClient) CreateJob(ctx context.Context, in *CreateJobRequest, opts ...grpc.CallOption) (*Job, error) {
+	out := new(Job)
+	err := c.cc.Invoke(ctx, "/permissions.v2.PermissionsAdmin/CreateJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsAdminClient) GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*Job, error) {
+	out := new(Job)
+	err := c.cc.Invoke(ctx, "/permissions.v2.PermissionsAdmin/GetJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsAdminClient) ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error) {
+	out := new(ListJobsResponse)
+	err := c.cc.Invoke(ctx, "/permissions.v2.PermissionsAdmin/ListJobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsAdminClient) CancelJob(ctx context.Context, in *CancelJobRequest, opts ...grpc.CallOption) (*Job, error) {
+	out := new(Job)
+	err := c.cc.Invoke(ctx, "/permissions.v2.PermissionsAdmin/CancelJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsAdminClient) ExplainAccess(ctx context.Context, in *ExplainAccessRequest, opts ...grpc.CallOption) (*AccessExplanation, error) {
+	out := new(AccessExplanation)
+	err := c.cc.Invoke(ctx, "/permissions.v2.PermissionsAdmin/ExplainAccess", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsAdminClient) RepairPermissions(ctx context.Context, in *RepairPermissionsRequest, opts ...grpc.CallOption) (PermissionsAdmin_RepairPermissionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_PermissionsAdmin_serviceDesc.Streams[3], "/permissions.v2.PermissionsAdmin/RepairPermissions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &permissionsAdminRepairPermissionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PermissionsAdmin_RepairPermissionsClient interface {
+	Recv() (*RepairPermissionsProgress, error)
+	grpc.ClientStream
+}
+
+type permissionsAdminRepairPermissionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *permissionsAdminRepairPermissionsClient) Recv() (*RepairPermissionsProgress, error) {
+	m := new(RepairPermissionsProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *permissionsAdminClient) ExportTenantData(ctx context.Context, in *ExportTenantDataRequest, opts ...grpc.CallOption) (PermissionsAdmin_ExportTenantDataClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_PermissionsAdmin_serviceDesc.Streams[4], "/permissions.v2.PermissionsAdmin/ExportTenantData", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &permissionsAdminExportTenantDataClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PermissionsAdmin_ExportTenantDataClient interface {
+	Recv() (*TenantDataRecord, error)
+	grpc.ClientStream
+}
+
+type permissionsAdminExportTenantDataClient struct {
+	grpc.ClientStream
+}
+
+func (x *permissionsAdminExportTenantDataClient) Recv() (*TenantDataRecord, error) {
+	m := new(TenantDataRecord)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *permissionsAdminClient) PurgeTenant(ctx context.Context, in *PurgeTenantRequest, opts ...grpc.CallOption) (*PurgeTenantResponse, error) {
+	out := new(PurgeTenantResponse)
+	err := c.cc.Invoke(ctx, "/permissions.v2.PermissionsAdmin/PurgeTenant", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsAdminClient) GetTenantRateLimits(ctx context.Context, in *GetTenantRateLimitsRequest, opts ...grpc.CallOption) (*TenantRateLimits, error) {
+	out := new(TenantRateLimits)
+	err := c.cc.Invoke(ctx, "/permissions.v2.PermissionsAdmin/GetTenantRateLimits", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsAdminClient) SetTenantRateLimits(ctx context.Context, in *SetTenantRateLimitsRequest, opts ...grpc.CallOption) (*TenantRateLimits, error) {
+	out := new(TenantRateLimits)
+	err := c.cc.Invoke(ctx, "/permissions.v2.PermissionsAdmin/SetTenantRateLimits", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsAdminClient) PrewarmFiles(ctx context.Context, in *PrewarmFilesRequest, opts ...grpc.CallOption) (*PrewarmFilesResponse, error) {
+	out := new(PrewarmFilesResponse)
+	err := c.cc.Invoke(ctx, "/permissions.v2.PermissionsAdmin/PrewarmFiles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsAdminClient) AssignOwner(ctx context.Context, in *AssignOwnerRequest, opts ...grpc.CallOption) (*Permission, error) {
+	out := new(Permission)
+	err := c.cc.Invoke(ctx, "/permissions.v2.PermissionsAdmin/AssignOwner", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsAdminClient) CreateAccessReview(ctx context.Context, in *CreateAccessReviewRequest, opts ...grpc.CallOption) (*AccessReview, error) {
+	out := new(AccessReview)
+	err := c.cc.Invoke(ctx, "/permissions.v2.PermissionsAdmin/CreateAccessReview", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsAdminClient) GetAccessReview(ctx context.Context, in *GetAccessReviewRequest, opts ...grpc.CallOption) (*AccessReview, error) {
+	out := new(AccessReview)
+	err := c.cc.Invoke(ctx, "/permissions.v2.PermissionsAdmin/GetAccessReview", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsAdminClient) ListAccessReviews(ctx context.Context, in *ListAccessReviewsRequest, opts ...grpc.CallOption) (*ListAccessReviewsResponse, error) {
+	out := new(ListAccessReviewsResponse)
+	err := c.cc.Invoke(ctx, "/permissions.v2.PermissionsAdmin/ListAccessReviews", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsAdminClient) CloseAccessReview(ctx context.Context, in *CloseAccessReviewRequest, opts ...grpc.CallOption) (*AccessReview, error) {
+	out := new(AccessReview)
+	err := c.cc.Invoke(ctx, "/permissions.v2.PermissionsAdmin/CloseAccessReview", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsAdminClient) ListStaleGrants(ctx context.Context, in *ListStaleGrantsRequest, opts ...grpc.CallOption) (*ListStaleGrantsResponse, error) {
+	out := new(ListStaleGrantsResponse)
+	err := c.cc.Invoke(ctx, "/permissions.v2.PermissionsAdmin/ListStaleGrants", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PermissionsAdminServer is the server API for PermissionsAdmin service.
+type PermissionsAdminServer interface {
+	// MigrateRole changes the role of the permissions that match a filter from one role to another,
+	// in batches, and streams the progress of the migration.
+	MigrateRole(*MigrateRoleRequest, PermissionsAdmin_MigrateRoleServer) error
+	// ImportPermissions creates the permissions that are streamed to it and streams the progress of the import.
+	// The permissions are written at a rate limited by the server so that imports don't starve other requests,
+	// and the next permission isn't received until the previous one is written.
+	ImportPermissions(PermissionsAdmin_ImportPermissionsServer) error
+	// GenerateUserDataReport streams the data that's stored about a user: the permissions the user holds,
+	// the permissions the user created for other users and the audit entries that reference the user.
+	GenerateUserDataReport(*GenerateUserDataReportRequest, PermissionsAdmin_GenerateUserDataReportServer) error
+	// EraseUserData deletes the permissions a user holds and replaces the user as the creator
+	// of the permissions the user created, and in their sharing chains. The audit entries that reference
+	// the user are retained until their retention expires.
+	EraseUserData(context.Context, *EraseUserDataRequest) (*EraseUserDataResponse, error)
+	// ListDomainPermissions returns the permissions that were given to everyone in an organization,
+	// a page at a time, ordered by their creation.
+	ListDomainPermissions(context.Context, *ListDomainPermissionsRequest) (*ListDomainPermissionsResponse, error)
+	// ListAnomalyAlerts returns the most recent alerts of unusual patterns of changes to permissions,
+	// such as an actor giving thousands of permissions, newest first.
+	ListAnomalyAlerts(context.Context, *ListAnomalyAlertsRequest) (*ListAnomalyAlertsResponse, error)
+	// LockFile locks a resource down for incident response, such as of a leaked file: until it's unlocked,
+	// the access of every user other than its owner is denied, without deleting their permissions.
+	// Locking a locked resource replaces its lock.
+	LockFile(context.Context, *LockFileRequest) (*FileLock, error)
+	// UnlockFile lifts the lockdown of a resource and returns the lifted lock,
+	// fails with NOT_FOUND if the resource isn't locked.
+	UnlockFile(context.Context, *UnlockFileRequest) (*FileLock, error)
+	// PlaceLegalHold places a resource under legal hold: until it's released, changes to the resource's
+	// permissions other than new permissions fail with FAILED_PRECONDITION, and their audit records
+	// don't expire. Placing the hold of a held resource replaces its hold.
+	PlaceLegalHold(context.Context, *PlaceLegalHoldRequest) (*LegalHold, error)
+	// ReleaseLegalHold releases the legal hold of a resource and returns the released hold,
+	// fails with NOT_FOUND if the resource isn't held.
+	ReleaseLegalHold(context.Context, *ReleaseLegalHoldRequest) (*LegalHold, error)
+	// GetServerInfo returns the version of the running build of the server,
+	// so that behavior changes can be correlated with the deployed versions.
+	GetServerInfo(context.Context, *GetServerInfoRequest) (*ServerInfo, error)
+	// CreateJob starts a long-running bulk operation, such as a bulk delete, an import or a role migration,
+	// as a persisted job and returns it without waiting for it to finish. Its progress is tracked
+	// with GetJob. A job's resource name is `jobs/{job}`.
+	CreateJob(context.Context, *CreateJobRequest) (*Job, error)
+	// GetJob returns a job and its progress.
+	GetJob(context.Context, *GetJobRequest) (*Job, error)
+	// ListJobs returns the jobs, newest first.
+	ListJobs(context.Context, *ListJobsRequest) (*ListJobsResponse, error)
+	// CancelJob requests the cancellation of a running job and returns it, the job stops at its next
+	// progress update. Fails with FAILED_PRECONDITION if the job already finished.
+	CancelJob(context.Context, *CancelJobRequest) (*Job, error)
+	// ExplainAccess returns the trace of the evaluation of a user's access to a resource, which lockdowns,
+	// grants, inheritance steps and roles were consulted and why the decision was reached, for support and
+	// debugging. The decision is the one IsPermitted makes, without recording it in the decision log.
+	ExplainAccess(context.Context, *ExplainAccessRequest) (*AccessExplanation, error)
+	// RepairPermissions scans the stored permissions for malformed documents, ones that violate the schema
+	// of the permissions, such as of a missing user_id or an unknown role, or that duplicate the permission
+	// of a user to a resource. It streams the malformed documents in batches, and fixes or quarantines them
+	// if requested. Repairs aren't published as events.
+	RepairPermissions(*RepairPermissionsRequest, PermissionsAdmin_RepairPermissionsServer) error
+	// ExportTenantData streams every document that's stored for a tenant, for offboarding it. A tenant's data
+	// is stored in the collections of the service whose names start with the tenant's ID and an underscore,
+	// the collection prefix of the tenant's deployment, such as "acme_permissions" of the tenant "acme".
+	ExportTenantData(*ExportTenantDataRequest, PermissionsAdmin_ExportTenantDataServer) error
+	// PurgeTenant deletes every document that's stored for a tenant, in two steps: a request without
+	// a confirmation token returns the number of documents that would be deleted and a confirmation token,
+	// and a request with the token deletes them, before the token expires. A token may be used once.
+	// Purges are audited with the actors that requested and confirmed them. The tenant of the server
+	// itself may not be purged.
+	PurgeTenant(context.Context, *PurgeTenantRequest) (*PurgeTenantResponse, error)
+	// GetTenantRateLimits returns the rate limits of a tenant's reads and writes, which are persisted in its settings,
+	// and for the tenant of the server itself the current rates of the requests that the serving instance admitted.
+	GetTenantRateLimits(context.Context, *GetTenantRateLimitsRequest) (*TenantRateLimits, error)
+	// SetTenantRateLimits sets the rate limits of a tenant's reads and writes, which are the RPCs of the Permission
+	// and Permissions services that are annotated with the NO_SIDE_EFFECTS idempotency level and the rest of them.
+	// Each instance of the tenant's deployment rejects the requests over its limits with RESOURCE_EXHAUSTED and
+	// the delay after which they may be retried, and applies changed limits within its refresh interval.
+	SetTenantRateLimits(context.Context, *SetTenantRateLimitsRequest) (*TenantRateLimits, error)
+	// PrewarmFiles loads the permissions of hot files ahead of an anticipated load, such as of an organization-wide
+	// announcement file, into the store's cache and into the last-known permissions of the instance that serves it,
+	// which the checks are served from while the store is unavailable. It's called by the gateways or by the
+	// admin tooling, in each region, since it only reads.
+	PrewarmFiles(context.Context, *PrewarmFilesRequest) (*PrewarmFilesResponse, error)
+	// AssignOwner makes a user the owner of an orphaned resource, whose owner's account was deleted, by giving
+	// the user a WRITE permission of its own, in place of direct edits of the store. It fails with FAILED_PRECONDITION
+	// if the resource still has an owner, or is locked down. The assignment is audited with its actor and reason,
+	// and writes an `owner_assigned` event in addition to the event of the owner's permission.
+	AssignOwner(context.Context, *AssignOwnerRequest) (*Permission, error)
+	// CreateAccessReview starts an access review campaign over the direct grants of a set of resources, or of
+	// every resource of a collection, and returns it. The current grants are snapshotted as the campaign's items,
+	// each reviewed by the owner of its resource, or by the campaign's default reviewer if the resource has no
+	// owner. The owners' own grants aren't reviewed. A campaign's resource name is `accessReviews/{review}`.
+	CreateAccessReview(context.Context, *CreateAccessReviewRequest) (*AccessReview, error)
+	// GetAccessReview returns an access review campaign and its progress.
+	GetAccessReview(context.Context, *GetAccessReviewRequest) (*AccessReview, error)
+	// ListAccessReviews returns the access review campaigns, newest first.
+	ListAccessReviews(context.Context, *ListAccessReviewsRequest) (*ListAccessReviewsResponse, error)
+	// CloseAccessReview closes an access review campaign, so that its decisions may no longer change, and revokes
+	// the grants that were decided to be revoked, and the undecided ones if requested, in bulk. The grants of
+	// resources under legal hold aren't revoked. A campaign whose closing was interrupted is closed again by
+	// another call. Fails with FAILED_PRECONDITION if the campaign is already closed.
+	CloseAccessReview(context.Context, *CloseAccessReviewRequest) (*AccessReview, error)
+	// ListStaleGrants returns the direct grants that weren't used within a duration, by their last access time,
+	// or by their creation if they were never used, which are candidates for expiry, a page at a time, ordered
+	// by their creation. The owners' own grants are never stale.
+	ListStaleGrants(context.Context, *ListStaleGrantsRequest) (*ListStaleGrantsResponse, error)
+}
+
+// UnimplementedPermissionsAdminServer can be embedded to have forward compatible implementations.
+type UnimplementedPermissionsAdminServer struct {
+}
+
+func (*UnimplementedPermissionsAdminServer) MigrateRole(req *MigrateRoleRequest, srv PermissionsAdmin_MigrateRoleServer) error {
+	return status.Errorf(codes.Unimplemented, "method MigrateRole not implemented")
+}
+func (*UnimplementedPermissionsAdminServer) ImportPermissions(srv PermissionsAdmin_ImportPermissionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportPermissions not implemented")
+}
+func (*UnimplementedPermissionsAdminServer) GenerateUserDataReport(req *GenerateUserDataReportRequest, srv PermissionsAdmin_GenerateUserDataReportServer) error {
+	return status.Errorf(codes.Unimplemented, "method GenerateUserDataReport not implemented")
+}
+func (*UnimplementedPermissionsAdminServer) EraseUserData(ctx context.Context, req *EraseUserDataRequest) (*EraseUserDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EraseUserData not implemented")
+}
+func (*UnimplementedPermissionsAdminServer) ListDomainPermissions(ctx context.Context, req *ListDomainPermissionsRequest) (*ListDomainPermissionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDomainPermissions not implemented")
+}
+func (*UnimplementedPermissionsAdminServer) ListAnomalyAlerts(ctx context.Context, req *ListAnomalyAlertsRequest) (*ListAnomalyAlertsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAnomalyAlerts not implemented")
+}
+func (*UnimplementedPermissionsAdminServer) LockFile(ctx context.Context, req *LockFileRequest) (*FileLock, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LockFile not implemented")
+}
+func (*UnimplementedPermissionsAdminServer) UnlockFile(ctx context.Context, req *UnlockFileRequest) (*FileLock, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnlockFile not implemented")
+}
+func (*UnimplementedPermissionsAdminServer) PlaceLegalHold(ctx context.Context, req *PlaceLegalHoldRequest) (*LegalHold, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlaceLegalHold not implemented")
+}
+func (*UnimplementedPermissionsAdminServer) ReleaseLegalHold(ctx context.Context, req *ReleaseLegalHoldRequest) (*LegalHold, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseLegalHold not implemented")
+}
+func (*UnimplementedPermissionsAdminServer) GetServerInfo(ctx context.Context, req *GetServerInfoRequest) (*ServerInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServerInfo not implemented")
+}
+func (*UnimplementedPermissionsAdminServer) CreateJob(ctx context.Context, req *CreateJobRequest) (*Job, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateJob not implemented")
+}
+func (*UnimplementedPermissionsAdminServer) GetJob(ctx context.Context, req *GetJobRequest) (*Job, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJob not implemented")
+}
+func (*UnimplementedPermissionsAdminServer) ListJobs(ctx context.Context, req *ListJobsRequest) (*ListJobsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJobs not implemented")
+}
+func (*UnimplementedPermissionsAdminServer) CancelJob(ctx context.Context, req *CancelJobRequest) (*Job, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelJob not implemented")
+}
+func (*UnimplementedPermissionsAdminServer) ExplainAccess(ctx context.Context, req *ExplainAccessRequest) (*AccessExplanation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExplainAccess not implemented")
+}
+func (*UnimplementedPermissionsAdminServer) RepairPermissions(req *RepairPermissionsRequest, srv PermissionsAdmin_RepairPermissionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method RepairPermissions not implemented")
+}
+func (*UnimplementedPermissionsAdminServer) ExportTenantData(req *ExportTenantDataRequest, srv PermissionsAdmin_ExportTenantDataServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportTenantData not implemented")
+}
+func (*UnimplementedPermissionsAdminServer) PurgeTenant(ctx context.Context, req *PurgeTenantRequest) (*PurgeTenantResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeTenant not implemented")
+}
+func (*UnimplementedPermissionsAdminServer) GetTenantRateLimits(ctx context.Context, req *GetTenantRateLimitsRequest) (*TenantRateLimits, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTenantRateLimits not implemented")
+}
+func (*UnimplementedPermissionsAdminServer) SetTenantRateLimits(ctx context.Context, req *SetTenantRateLimitsRequest) (*TenantRateLimits, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTenantRateLimits not implemented")
+}
+func (*UnimplementedPermissionsAdminServer) PrewarmFiles(ctx context.Context, req *PrewarmFilesRequest) (*PrewarmFilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PrewarmFiles not implemented")
+}
+func (*UnimplementedPermissionsAdminServer) AssignOwner(ctx context.Context, req *AssignOwnerRequest) (*Permission, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignOwner not implemented")
+}
+func (*UnimplementedPermissionsAdminServer) CreateAccessReview(ctx context.Context, req *CreateAccessReviewRequest) (*AccessReview, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAccessReview not implemented")
+}
+func (*UnimplementedPermissionsAdminServer) GetAccessReview(ctx context.Context, req *GetAccessReviewRequest) (*AccessReview, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccessReview not implemented")
+}
+func (*UnimplementedPermissionsAdminServer) ListAccessReviews(ctx context.Context, req *ListAccessReviewsRequest) (*ListAccessReviewsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccessReviews not implemented")
+}
+func (*UnimplementedPermissionsAdminServer) CloseAccessReview(ctx context.Context, req *CloseAccessReviewRequest) (*AccessReview, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseAccessReview not implemented")
+}
+func (*UnimplementedPermissionsAdminServer) ListStaleGrants(ctx context.Context, req *ListStaleGrantsRequest) (*ListStaleGrantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStaleGrants not implemented")
+}
+
+func RegisterPermissionsAdminServer(s *grpc.Server, srv PermissionsAdminServer) {
+	s.RegisterService(&_PermissionsAdmin_serviceDesc, srv)
+}
+
+func _PermissionsAdmin_MigrateRole_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(MigrateRoleRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PermissionsAdminServer).MigrateRole(m, &permissionsAdminMigrateRoleServer{stream})
+}
+
+type PermissionsAdmin_MigrateRoleServer interface {
+	Send(*MigrateRoleProgress) error
+	grpc.ServerStream
+}
+
+type permissionsAdminMigrateRoleServer struct {
+	grpc.ServerStream
+}
+
+func (x *permissionsAdminMigrateRoleServer) Send(m *MigrateRoleProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _PermissionsAdmin_ImportPermissions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PermissionsAdminServer).ImportPermissions(&permissionsAdminImportPermissionsServer{stream})
+}
+
+type PermissionsAdmin_ImportPermissionsServer interface {
+	Send(*ImportPermissionsProgress) error
+	Recv() (*ImportPermissionsRequest, error)
+	grpc.ServerStream
+}
+
+type permissionsAdminImportPermissionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *permissionsAdminImportPermissionsServer) Send(m *ImportPermissionsProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *permissionsAdminImportPermissionsServer) Recv() (*ImportPermissionsRequest, error) {
+	m := new(ImportPermissionsRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _PermissionsAdmin_GenerateUserDataReport_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GenerateUserDataReportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PermissionsAdminServer).GenerateUserDataReport(m, &permissionsAdminGenerateUserDataReportServer{stream})
+}
+
+type PermissionsAdmin_GenerateUserDataReportServer interface {
+	Send(*UserDataRecord) error
+	grpc.ServerStream
+}
+
+type permissionsAdminGenerateUserDataReportServer struct {
+	grpc.ServerStream
+}
+
+func (x *permissionsAdminGenerateUserDataReportServer) Send(m *UserDataRecord) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _PermissionsAdmin_EraseUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EraseUserDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsAdminServer).EraseUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.PermissionsAdmin/EraseUserData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsAdminServer).EraseUserData(ctx, req.(*EraseUserDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PermissionsAdmin_ListDomainPermissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDomainPermissionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsAdminServer).ListDomainPermissions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.PermissionsAdmin/ListDomainPermissions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsAdminServer).ListDomainPermissions(ctx, req.(*ListDomainPermissionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PermissionsAdmin_ListAnomalyAlerts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAnomalyAlertsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsAdminServer).ListAnomalyAlerts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.PermissionsAdmin/ListAnomalyAlerts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsAdminServer).ListAnomalyAlerts(ctx, req.(*ListAnomalyAlertsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PermissionsAdmin_LockFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsAdminServer).LockFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.PermissionsAdmin/LockFile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsAdminServer).LockFile(ctx, req.(*LockFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PermissionsAdmin_UnlockFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsAdminServer).UnlockFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.PermissionsAdmin/UnlockFile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsAdminServer).UnlockFile(ctx, req.(*UnlockFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PermissionsAdmin_PlaceLegalHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaceLegalHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsAdminServer).PlaceLegalHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.PermissionsAdmin/PlaceLegalHold",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsAdminServer).PlaceLegalHold(ctx, req.(*PlaceLegalHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PermissionsAdmin_ReleaseLegalHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseLegalHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsAdminServer).ReleaseLegalHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.PermissionsAdmin/ReleaseLegalHold",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsAdminServer).ReleaseLegalHold(ctx, req.(*ReleaseLegalHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PermissionsAdmin_GetServerInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServerInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsAdminServer).GetServerInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.PermissionsAdmin/GetServerInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsAdminServer).GetServerInfo(ctx, req.(*GetServerInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PermissionsAdmin_CreateJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsAdminServer).CreateJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.PermissionsAdmin/CreateJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsAdminServer).CreateJob(ctx, req.(*CreateJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PermissionsAdmin_GetJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsAdminServer).GetJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.PermissionsAdmin/GetJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsAdminServer).GetJob(ctx, req.(*GetJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PermissionsAdmin_ListJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsAdminServer).ListJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.PermissionsAdmin/ListJobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsAdminServer).ListJobs(ctx, req.(*ListJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PermissionsAdmin_CancelJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsAdminServer).CancelJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.PermissionsAdmin/CancelJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsAdminServer).CancelJob(ctx, req.(*CancelJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PermissionsAdmin_ExplainAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExplainAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsAdminServer).ExplainAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.PermissionsAdmin/ExplainAccess",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsAdminServer).ExplainAccess(ctx, req.(*ExplainAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PermissionsAdmin_RepairPermissions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RepairPermissionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PermissionsAdminServer).RepairPermissions(m, &permissionsAdminRepairPermissionsServer{stream})
+}
+
+type PermissionsAdmin_RepairPermissionsServer interface {
+	Send(*RepairPermissionsProgress) error
+	grpc.ServerStream
+}
+
+type permissionsAdminRepairPermissionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *permissionsAdminRepairPermissionsServer) Send(m *RepairPermissionsProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _PermissionsAdmin_ExportTenantData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportTenantDataRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PermissionsAdminServer).ExportTenantData(m, &permissionsAdminExportTenantDataServer{stream})
+}
+
+type PermissionsAdmin_ExportTenantDataServer interface {
+	Send(*TenantDataRecord) error
+	grpc.ServerStream
+}
+
+type permissionsAdminExportTenantDataServer struct {
+	grpc.ServerStream
+}
+
+func (x *permissionsAdminExportTenantDataServer) Send(m *TenantDataRecord) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _PermissionsAdmin_PurgeTenant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeTenantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsAdminServer).PurgeTenant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.PermissionsAdmin/PurgeTenant",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsAdminServer).PurgeTenant(ctx, req.(*PurgeTenantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PermissionsAdmin_GetTenantRateLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTenantRateLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsAdminServer).GetTenantRateLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.PermissionsAdmin/GetTenantRateLimits",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsAdminServer).GetTenantRateLimits(ctx, req.(*GetTenantRateLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PermissionsAdmin_SetTenantRateLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTenantRateLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsAdminServer).SetTenantRateLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.PermissionsAdmin/SetTenantRateLimits",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsAdminServer).SetTenantRateLimits(ctx, req.(*SetTenantRateLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PermissionsAdmin_PrewarmFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrewarmFilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsAdminServer).PrewarmFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.PermissionsAdmin/PrewarmFiles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsAdminServer).PrewarmFiles(ctx, req.(*PrewarmFilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PermissionsAdmin_AssignOwner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignOwnerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsAdminServer).AssignOwner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.PermissionsAdmin/AssignOwner",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsAdminServer).AssignOwner(ctx, req.(*AssignOwnerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PermissionsAdmin_CreateAccessReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAccessReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsAdminServer).CreateAccessReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.PermissionsAdmin/CreateAccessReview",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsAdminServer).CreateAccessReview(ctx, req.(*CreateAccessReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PermissionsAdmin_GetAccessReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccessReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsAdminServer).GetAccessReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.PermissionsAdmin/GetAccessReview",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsAdminServer).GetAccessReview(ctx, req.(*GetAccessReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PermissionsAdmin_ListAccessReviews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccessReviewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsAdminServer).ListAccessReviews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.PermissionsAdmin/ListAccessReviews",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsAdminServer).ListAccessReviews(ctx, req.(*ListAccessReviewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PermissionsAdmin_CloseAccessReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseAccessReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsAdminServer).CloseAccessReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.PermissionsAdmin/CloseAccessReview",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsAdminServer).CloseAccessReview(ctx, req.(*CloseAccessReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PermissionsAdmin_ListStaleGrants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStaleGrantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsAdminServer).ListStaleGrants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permissions.v2.PermissionsAdmin/ListStaleGrants",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsAdminServer).ListStaleGrants(ctx, req.(*ListStaleGrantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _PermissionsAdmin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "permissions.v2.PermissionsAdmin",
+	HandlerType: (*PermissionsAdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "EraseUserData",
+			Handler:    _PermissionsAdmin_EraseUserData_Handler,
+		},
+		{
+			MethodName: "ListDomainPermissions",
+			Handler:    _PermissionsAdmin_ListDomainPermissions_Handler,
+		},
+		{
+			MethodName: "ListAnomalyAlerts",
+			Handler:    _PermissionsAdmin_ListAnomalyAlerts_Handler,
+		},
+		{
+			MethodName: "LockFile",
+			Handler:    _PermissionsAdmin_LockFile_Handler,
+		},
+		{
+			MethodName: "UnlockFile",
+			Handler:    _PermissionsAdmin_UnlockFile_Handler,
+		},
+		{
+			MethodName: "PlaceLegalHold",
+			Handler:    _PermissionsAdmin_PlaceLegalHold_Handler,
+		},
+		{
+			MethodName: "ReleaseLegalHold",
+			Handler:    _PermissionsAdmin_ReleaseLegalHold_Handler,
+		},
+		{
+			MethodName: "GetServerInfo",
+			Handler:    _PermissionsAdmin_GetServerInfo_Handler,
+		},
+		{
+			MethodName: "CreateJob",
+			Handler:    _PermissionsAdmin_CreateJob_Handler,
+		},
+		{
+			MethodName: "GetJob",
+			Handler:    _PermissionsAdmin_GetJob_Handler,
+		},
+		{
+			MethodName: "ListJobs",
+			Handler:    _PermissionsAdmin_ListJobs_Handler,
+		},
+		{
+			MethodName: "CancelJob",
+			Handler:    _PermissionsAdmin_CancelJob_Handler,
+		},
+		{
+			MethodName: "ExplainAccess",
+			Handler:    _PermissionsAdmin_ExplainAccess_Handler,
+		},
+		{
+			MethodName: "PurgeTenant",
+			Handler:    _PermissionsAdmin_PurgeTenant_Handler,
+		},
+		{
+			MethodName: "GetTenantRateLimits",
+			Handler:    _PermissionsAdmin_GetTenantRateLimits_Handler,
+		},
+		{
+			MethodName: "SetTenantRateLimits",
+			Handler:    _PermissionsAdmin_SetTenantRateLimits_Handler,
+		},
+		{
+			MethodName: "PrewarmFiles",
+			Handler:    _PermissionsAdmin_PrewarmFiles_Handler,
+		},
+		{
+			MethodName: "AssignOwner",
+			Handler:    _PermissionsAdmin_AssignOwner_Handler,
+		},
+		{
+			MethodName: "CreateAccessReview",
+			Handler:    _PermissionsAdmin_CreateAccessReview_Handler,
+		},
+		{
+			MethodName: "GetAccessReview",
+			Handler:    _PermissionsAdmin_GetAccessReview_Handler,
+		},
+		{
+			MethodName: "ListAccessReviews",
+			Handler:    _PermissionsAdmin_ListAccessReviews_Handler,
+		},
+		{
+			MethodName: "CloseAccessReview",
+			Handler:    _PermissionsAdmin_CloseAccessReview_Handler,
+		},
+		{
+			MethodName: "ListStaleGrants",
+			Handler:    _PermissionsAdmin_ListStaleGrants_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "MigrateRole",
+			Handler:       _PermissionsAdmin_MigrateRole_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportPermissions",
+			Handler:       _PermissionsAdmin_ImportPermissions_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "GenerateUserDataReport",
+			Handler:       _PermissionsAdmin_GenerateUserDataReport_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "RepairPermissions",
+			Handler:       _PermissionsAdmin_RepairPermissions_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportTenantData",
+			Handler:       _PermissionsAdmin_ExportTenantData_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "permissions.proto",
+}
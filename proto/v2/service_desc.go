@@ -0,0 +1,15 @@
+package permissions
+
+import "google.golang.org/grpc"
+
+// PermissionsServiceDesc returns a copy of the grpc service description of the Permissions service,
+// for registering it with grpc.Server.RegisterService with wrapped handlers.
+func PermissionsServiceDesc() grpc.ServiceDesc {
+	return _Permissions_serviceDesc
+}
+
+// PermissionsAdminServiceDesc returns a copy of the grpc service description of the PermissionsAdmin service,
+// for registering it with grpc.Server.RegisterService with wrapped handlers.
+func PermissionsAdminServiceDesc() grpc.ServiceDesc {
+	return _PermissionsAdmin_serviceDesc
+}
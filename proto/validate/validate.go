@@ -0,0 +1,272 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/descriptor"
+	"github.com/golang/protobuf/proto"
+	pbdescriptor "github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fieldValidator validates a field of a message by the rules of its annotation.
+type fieldValidator struct {
+	// name is the name of the field in the proto, and index the index of its field in the message's struct.
+	name  string
+	index int
+
+	// rules are the rules of the field, which are empty if it isn't annotated
+	// and is only validated for the rules of its messages.
+	rules   *FieldRules
+	pattern *regexp.Regexp
+
+	// enumValues are the values of the field's enum, if it's an enum that must be defined.
+	enumValues map[int32]bool
+}
+
+// validators caches the field validators of each type of message, by its reflect.Type.
+var validators sync.Map
+
+// Message returns an InvalidArgument error describing the first field of msg, or of the messages
+// in it, that violates the rules of its annotation, otherwise returns nil. The messages that
+// aren't generated from a proto file have no rules.
+func Message(msg proto.Message) error {
+	return validateMessage(msg, "")
+}
+
+// UnaryClientInterceptor returns a grpc unary client interceptor that validates the requests of its calls
+// before they're sent, so that invalid requests fail without a round trip to the server.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req interface{},
+		reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if msg, ok := req.(proto.Message); ok {
+			if err := Message(msg); err != nil {
+				return err
+			}
+		}
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// validateMessage validates msg, whose fields are named with prefix.
+func validateMessage(msg proto.Message, prefix string) error {
+	value := reflect.ValueOf(msg)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return nil
+	}
+
+	fields, err := messageValidators(msg)
+	if err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+		if err := field.validate(value.Elem().Field(field.index), prefix+field.name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// messageValidators returns the validators of the fields of msg.
+func messageValidators(msg proto.Message) ([]fieldValidator, error) {
+	messageType := reflect.TypeOf(msg)
+	if cached, ok := validators.Load(messageType); ok {
+		return cached.([]fieldValidator), nil
+	}
+
+	described, ok := msg.(descriptor.Message)
+	if !ok {
+		validators.Store(messageType, []fieldValidator(nil))
+		return nil, nil
+	}
+
+	_, messageDescriptor := descriptor.ForMessage(described)
+	indexes := fieldIndexes(messageType.Elem())
+	fields := make([]fieldValidator, 0, len(messageDescriptor.GetField()))
+	for _, field := range messageDescriptor.GetField() {
+		index, ok := indexes[field.GetNumber()]
+		if !ok {
+			// The fields of oneofs are validated by their own messages.
+			continue
+		}
+
+		validator := fieldValidator{name: field.GetName(), index: index}
+		if field.GetOptions() != nil && proto.HasExtension(field.GetOptions(), E_Rules) {
+			extension, err := proto.GetExtension(field.GetOptions(), E_Rules)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "invalid rules of %s: %v", field.GetName(), err)
+			}
+
+			validator.rules = extension.(*FieldRules)
+		}
+
+		if validator.rules == nil {
+			if field.GetType() != pbdescriptor.FieldDescriptorProto_TYPE_MESSAGE {
+				continue
+			}
+
+			validator.rules = &FieldRules{}
+		}
+
+		if validator.rules.GetPattern() != "" {
+			pattern, err := regexp.Compile(validator.rules.GetPattern())
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "invalid pattern of %s: %v", field.GetName(), err)
+			}
+
+			validator.pattern = pattern
+		}
+
+		if validator.rules.GetDefinedOnly() {
+			validator.enumValues = map[int32]bool{}
+			for _, value := range proto.EnumValueMap(strings.TrimPrefix(field.GetTypeName(), ".")) {
+				validator.enumValues[value] = true
+			}
+		}
+
+		fields = append(fields, validator)
+	}
+
+	validators.Store(messageType, fields)
+	return fields, nil
+}
+
+// fieldIndexes returns the indexes of the fields of the struct of a message by their field numbers.
+func fieldIndexes(structType reflect.Type) map[int32]int {
+	indexes := map[int32]int{}
+	for i := 0; i < structType.NumField(); i++ {
+		tag := strings.Split(structType.Field(i).Tag.Get("protobuf"), ",")
+		if len(tag) < 2 {
+			continue
+		}
+
+		if number, err := strconv.Atoi(tag[1]); err == nil {
+			indexes[int32(number)] = i
+		}
+	}
+
+	return indexes
+}
+
+// validate validates value, the value of the field v named name.
+func (v fieldValidator) validate(value reflect.Value, name string) error {
+	rules := v.rules
+	switch value.Kind() {
+	case reflect.String:
+		if rules.GetRequired() && value.Len() == 0 {
+			return status.Errorf(codes.InvalidArgument, "%s is required", name)
+		}
+
+		return v.validateString(value.String(), name)
+	case reflect.Ptr:
+		if value.IsNil() {
+			if rules.GetRequired() {
+				return status.Errorf(codes.InvalidArgument, "%s is required", name)
+			}
+
+			return nil
+		}
+
+		if msg, ok := value.Interface().(proto.Message); ok {
+			return validateMessage(msg, name+".")
+		}
+	case reflect.Slice, reflect.Map:
+		if value.Kind() == reflect.Slice && value.Type().Elem().Kind() == reflect.Uint8 {
+			// A bytes field.
+			if rules.GetRequired() && value.Len() == 0 {
+				return status.Errorf(codes.InvalidArgument, "%s is required", name)
+			}
+
+			return nil
+		}
+
+		if rules.GetRequired() && value.Len() == 0 {
+			return status.Errorf(codes.InvalidArgument, "%s is required", name)
+		}
+
+		if rules.MaxItems != nil && value.Len() > int(rules.GetMaxItems()) {
+			return status.Errorf(codes.InvalidArgument, "%s exceeds %d items", name, rules.GetMaxItems())
+		}
+
+		if value.Kind() == reflect.Map {
+			return nil
+		}
+
+		for i := 0; i < value.Len(); i++ {
+			item, itemName := value.Index(i), fmt.Sprintf("%s[%d]", name, i)
+			if item.Kind() == reflect.String {
+				if err := v.validateString(item.String(), itemName); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			if err := v.validate(item, itemName); err != nil {
+				return err
+			}
+		}
+	case reflect.Int32, reflect.Int64:
+		return v.validateInt(value.Int(), name)
+	case reflect.Uint32, reflect.Uint64:
+		return v.validateInt(int64(value.Uint()), name)
+	}
+
+	return nil
+}
+
+// validateString validates s, a string value of the field v named name.
+func (v fieldValidator) validateString(s string, name string) error {
+	rules := v.rules
+	length := utf8.RuneCountInString(s)
+	if rules.MinLen != nil && length < int(rules.GetMinLen()) {
+		return status.Errorf(codes.InvalidArgument, "%s must be at least %d characters", name, rules.GetMinLen())
+	}
+
+	if rules.MaxLen != nil && length > int(rules.GetMaxLen()) {
+		return status.Errorf(codes.InvalidArgument, "%s exceeds %d characters", name, rules.GetMaxLen())
+	}
+
+	if v.pattern != nil && s != "" && !v.pattern.MatchString(s) {
+		return status.Errorf(codes.InvalidArgument, "%s must match %q", name, rules.GetPattern())
+	}
+
+	return nil
+}
+
+// validateInt validates n, an integer or enum value of the field v named name.
+func (v fieldValidator) validateInt(n int64, name string) error {
+	rules := v.rules
+	if rules.Gte != nil && n < rules.GetGte() {
+		return status.Errorf(codes.InvalidArgument, "%s must be at least %d", name, rules.GetGte())
+	}
+
+	if rules.Lte != nil && n > rules.GetLte() {
+		return status.Errorf(codes.InvalidArgument, "%s must be at most %d", name, rules.GetLte())
+	}
+
+	if v.enumValues != nil && !v.enumValues[int32(n)] {
+		return status.Errorf(codes.InvalidArgument, "%s %d does not exist", name, n)
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/meateam/permission-service/service"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// componentHealth is the name of the health check worker, which may not be disabled,
+	// since the server isn't serving without it.
+	componentHealth = "health"
+
+	componentMetrics       = "metrics"
+	componentScheduler     = "scheduler"
+	componentReconciler    = "reconciler"
+	componentJobReaper     = "job_reaper"
+	componentRecurringJobs = "recurring_jobs"
+	componentOutboxRelay   = "outbox_relay"
+	componentRegionFence   = "region_fence"
+	componentSelfTest      = "self_test"
+	componentRateLimits    = "rate_limits"
+)
+
+// knownComponents are the names of the components that may be disabled.
+var knownComponents = map[string]bool{
+	componentMetrics:       true,
+	componentScheduler:     true,
+	componentReconciler:    true,
+	componentJobReaper:     true,
+	componentRecurringJobs: true,
+	componentOutboxRelay:   true,
+	componentRegionFence:   true,
+	componentSelfTest:      true,
+	componentRateLimits:    true,
+}
+
+// component is a background goroutine worker of the server, that runs until its context is done.
+// An error that it returns is fatal, and stops the server.
+type component struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// components runs the background goroutine workers of the server, such as the health check worker,
+// the schedulers and the outbox relay, which are started and stopped together in an errgroup.
+// The first component that fails stops the rest, and its error is returned by err.
+type components struct {
+	logger   *logrus.Logger
+	disabled map[string]bool
+	added    []component
+
+	// group runs the components with ctx, which is done once stop is called or a component failed.
+	group  *errgroup.Group
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// newComponents creates the components of the server, other than the ones of disabled,
+// of the form "name,name", and returns it, or an error if disabled names unknown components.
+func newComponents(logger *logrus.Logger, disabled string) (*components, error) {
+	skipped := map[string]bool{}
+	for _, name := range strings.Split(disabled, ",") {
+		name = strings.TrimSpace(name)
+		switch {
+		case name == "":
+		case name == componentHealth:
+			return nil, fmt.Errorf("component %q may not be disabled", name)
+		case !knownComponents[name]:
+			return nil, fmt.Errorf("unknown component %q", name)
+		default:
+			skipped[name] = true
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	group, ctx := errgroup.WithContext(ctx)
+	return &components{
+		logger:   logger,
+		disabled: skipped,
+		group:    group,
+		ctx:      ctx,
+		cancel:   cancel,
+	}, nil
+}
+
+// add adds the component of name that's run by run, unless it's disabled.
+func (c *components) add(name string, run func(ctx context.Context) error) {
+	if c.disabled[name] {
+		c.logger.WithField("component", name).Warn("the component is disabled")
+		return
+	}
+
+	c.added = append(c.added, component{name: name, run: run})
+}
+
+// addElected adds the component of name that's run by fn whenever the instance is elected
+// its leader by leaders, unless it's disabled.
+func (c *components) addElected(leaders service.LeaderElector, name string, fn func(ctx context.Context)) {
+	c.add(name, func(ctx context.Context) error {
+		leaders.Run(ctx, name, fn)
+		return nil
+	})
+}
+
+// start starts running the components that were added, each in its own goroutine.
+func (c *components) start() {
+	for _, added := range c.added {
+		added := added
+		c.group.Go(func() error {
+			if err := added.run(c.ctx); err != nil {
+				err = fmt.Errorf("component %s failed: %v", added.name, err)
+				c.logger.Error(err)
+				return err
+			}
+
+			return nil
+		})
+	}
+}
+
+// done returns a channel that's closed once the components are stopped, or one of them failed.
+func (c *components) done() <-chan struct{} {
+	return c.ctx.Done()
+}
+
+// err waits for the components to return, and returns the error of the first one that failed,
+// or nil if none did. It must be called only once they're done, or stopped.
+func (c *components) err() error {
+	return c.group.Wait()
+}
+
+// stop stops the components and waits for them to return.
+func (c *components) stop() {
+	c.cancel()
+	c.group.Wait()
+}
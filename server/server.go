@@ -2,45 +2,260 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"expvar"
 	"fmt"
+	"io/ioutil"
 	"net"
+	"net/http"
+	"os"
+	"regexp"
 	"strings"
 	"time"
 
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_logrus "github.com/grpc-ecosystem/go-grpc-middleware/logging/logrus"
+	grpc_ctxtags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
 	ilogger "github.com/meateam/elasticsearch-logger"
 	pb "github.com/meateam/permission-service/proto"
+	pbv2 "github.com/meateam/permission-service/proto/v2"
 	"github.com/meateam/permission-service/service"
+	"github.com/meateam/permission-service/service/controller"
+	"github.com/meateam/permission-service/service/encryption"
+	"github.com/meateam/permission-service/service/faults"
+	"github.com/meateam/permission-service/service/fileservice"
+	"github.com/meateam/permission-service/service/jwt"
+	"github.com/meateam/permission-service/service/memo"
 	"github.com/meateam/permission-service/service/mongodb"
+	"github.com/meateam/permission-service/service/redact"
+	"github.com/meateam/permission-service/service/shadow"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
+	"go.elastic.co/apm/module/apmgrpc"
 	"go.elastic.co/apm/module/apmmongo"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/connstring"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
 )
 
 const (
 	envPrefix                          = "PS"
 	configPort                         = "port"
 	configHealthCheckInterval          = "health_check_interval"
+	configHealthCheckJitter            = "health_check_jitter"
+	configHealthCheckFailureThreshold  = "health_check_failure_threshold"
 	configMongoConnectionString        = "mongo_host"
 	configMongoClientConnectionTimeout = "mongo_client_connection_timeout"
 	configMongoClientPingTimeout       = "mongo_client_ping_timeout"
+	configMongoMaxPoolSize             = "mongo_max_pool_size"
+	configMongoMinPoolSize             = "mongo_min_pool_size"
+	configMongoMaxConnIdleTime         = "mongo_max_conn_idle_time"
+	configMongoServerSelectionTimeout  = "mongo_server_selection_timeout"
+	configMongoReadConnectionString    = "mongo_read_host"
+	configMongoReadPreference          = "mongo_read_preference"
+	configMongoDatabase                = "mongo_database"
+	configMongoCollectionPrefix        = "mongo_collection_prefix"
+	configMongoSchemaValidation        = "mongo_schema_validation"
+	configMongoWriteConcern            = "mongo_write_concern"
+	configMongoWriteJournal            = "mongo_write_journal"
+	configMongoWriteTimeout            = "mongo_write_timeout"
+	configMongoProjectionWriteConcern  = "mongo_projection_write_concern"
+	configMongoReadConcern             = "mongo_read_concern"
+	configMongoRetryWrites             = "mongo_retry_writes"
+	configHedgeDelay                   = "hedge_delay_ms"
 	configElasticAPMIgnoreURLS         = "elastic_apm_ignore_urls"
+	configCallerRolePolicy             = "caller_role_policy"
+	configTLSCertFile                  = "tls_cert_file"
+	configTLSKeyFile                   = "tls_key_file"
+	configTLSClientCAFile              = "tls_client_ca_file"
+	configIdempotencyWindow            = "idempotency_window"
+	configFileServiceURL               = "file_service_url"
+	configReconcileInterval            = "reconcile_interval"
+	configReconcileSampleSize          = "reconcile_sample_size"
+	configMetricsPort                  = "metrics_port"
+	configShadowMongoConnectionString  = "shadow_mongo_host"
+	configShadowReadTimeout            = "shadow_read_timeout"
+	configRoleAliases                  = "role_aliases"
+	configDefaultRole                  = "default_role"
+	configOutboxEnabled                = "outbox_enabled"
+	configOutboxRetention              = "outbox_retention"
+	configOutboxRelayInterval          = "outbox_relay_interval"
+	configOutboxRelayBatchSize         = "outbox_relay_batch_size"
+	configSharedWithMeProjection       = "shared_with_me_projection"
+	configImportRateLimit              = "import_rate_limit"
+	configUserIDEncryptionKeyFile      = "user_id_encryption_key_file"
+	configLogRedaction                 = "log_redaction"
+	configLogRedactionSalt             = "log_redaction_salt"
+	configSchedulerInterval            = "scheduler_interval"
+	configSchedulerLease               = "scheduler_lease"
+	configJobHeartbeatInterval         = "job_heartbeat_interval"
+	configJobRetention                 = "job_retention"
+	configRecurringJobs                = "recurring_jobs"
+	configLeaderLease                  = "leader_lease"
+	configExternalAccessWebhookURL     = "external_access_webhook_url"
+	configExternalAccessWebhookTimeout = "external_access_webhook_timeout"
+	configMaxReshareDepth              = "max_reshare_depth"
+	configMaxReshareGrants             = "max_reshare_grants"
+	configRoleMergeStrategy            = "role_merge_strategy"
+	configRoleMergeStrategies          = "role_merge_strategies"
+	configDomainGrants                 = "domain_grants"
+	configCompressionLevel             = "compression_level"
+	configWarmUpFiles                  = "warm_up_files"
+	configWarmUpTimeout                = "warm_up_timeout"
+	configDecisionLog                  = "decision_log"
+	configRequireActor                 = "require_actor"
+	configActorGateways                = "actor_gateways"
+	configAuthorizePermissionReads     = "authorize_permission_reads"
+	configCacheMinTTL                  = "cache_min_ttl"
+	configCacheMaxTTL                  = "cache_max_ttl"
+	configCacheNotFoundTTL             = "cache_not_found_ttl"
+	configCacheTTLs                    = "cache_ttls"
+	configJWTJWKSURL                   = "jwt_jwks_url"
+	configJWTJWKSRefreshInterval       = "jwt_jwks_refresh_interval"
+	configJWTIssuer                    = "jwt_issuer"
+	configJWTAudience                  = "jwt_audience"
+	configJWTClockSkew                 = "jwt_clock_skew"
+	configApprovalRoles                = "approval_roles"
+	configMaxRequestBytes              = "max_request_bytes"
+	configRequestByteLimits            = "request_byte_limits"
+	configMaxListLength                = "max_list_length"
+	configAnomalyWindow                = "anomaly_window"
+	configAnomalyGrantsPerActor        = "anomaly_grants_per_actor"
+	configAnomalyRevocationsPerActor   = "anomaly_revocations_per_actor"
+	configAnomalyExternalShares        = "anomaly_external_shares"
+	configDeprecationSunsets           = "deprecation_sunsets"
+	configRegion                       = "region"
+	configRegionRole                   = "region_role"
+	configPrimaryRegionAddress         = "primary_region_address"
+	configRegionPeers                  = "region_peers"
+	configStaleReadMaxAge              = "stale_read_max_age"
+	configStaleReadMaxEntries          = "stale_read_max_entries"
+	configDisabledComponents           = "disabled_components"
+	configMaxConnectionAge             = "max_connection_age"
+	configSelfTest                     = "self_test"
+	configSelfTestFileID               = "self_test_file_id"
+	configSelfTestTimeout              = "self_test_timeout"
+	configFaultInjection               = "fault_injection"
+	configStaleGrantExpiry             = "stale_grant_expiry"
+	configPreShareHookURL              = "pre_share_hook_url"
+	configPreShareHookTimeout          = "pre_share_hook_timeout"
+	configPreShareHookFailOpen         = "pre_share_hook_fail_open"
+	configRateLimitsRefreshInterval    = "rate_limits_refresh_interval"
+	configMiddlewareOrder              = "middleware_order"
 )
 
 func init() {
 	viper.SetDefault(configPort, "8080")
 	viper.SetDefault(configHealthCheckInterval, 3)
+	viper.SetDefault(configHealthCheckJitter, 0.2)
+	viper.SetDefault(configHealthCheckFailureThreshold, 3)
 	viper.SetDefault(configElasticAPMIgnoreURLS, "/grpc.health.v1.Health/Check")
 	viper.SetDefault(configMongoConnectionString, "mongodb://localhost:27017/permission")
 	viper.SetDefault(configMongoClientConnectionTimeout, 10)
 	viper.SetDefault(configMongoClientPingTimeout, 10)
+	viper.SetDefault(configMongoMaxPoolSize, 0)
+	viper.SetDefault(configMongoMinPoolSize, 0)
+	viper.SetDefault(configMongoMaxConnIdleTime, 0)
+	viper.SetDefault(configMongoServerSelectionTimeout, 0)
+	viper.SetDefault(configMongoReadConnectionString, "")
+	viper.SetDefault(configMongoReadPreference, "")
+	viper.SetDefault(configMongoDatabase, "")
+	viper.SetDefault(configMongoCollectionPrefix, "")
+	viper.SetDefault(configMongoSchemaValidation, mongodb.SchemaValidationError)
+	viper.SetDefault(configMongoWriteConcern, mongodb.WriteConcernMajority)
+	viper.SetDefault(configMongoWriteJournal, true)
+	viper.SetDefault(configMongoWriteTimeout, 0)
+	viper.SetDefault(configMongoProjectionWriteConcern, "")
+	viper.SetDefault(configMongoReadConcern, "")
+	viper.SetDefault(configMongoRetryWrites, true)
+	viper.SetDefault(configHedgeDelay, 0)
+	viper.SetDefault(configCallerRolePolicy, "")
+	viper.SetDefault(configTLSCertFile, "")
+	viper.SetDefault(configTLSKeyFile, "")
+	viper.SetDefault(configTLSClientCAFile, "")
+	viper.SetDefault(configIdempotencyWindow, 86400)
+	viper.SetDefault(configFileServiceURL, "")
+	viper.SetDefault(configReconcileInterval, 3600)
+	viper.SetDefault(configReconcileSampleSize, 100)
+	viper.SetDefault(configMetricsPort, "")
+	viper.SetDefault(configShadowMongoConnectionString, "")
+	viper.SetDefault(configShadowReadTimeout, 5)
+	viper.SetDefault(configRoleAliases, "")
+	viper.SetDefault(configDefaultRole, "")
+	viper.SetDefault(configOutboxEnabled, false)
+	viper.SetDefault(configOutboxRetention, 604800)
+	viper.SetDefault(configOutboxRelayInterval, 1)
+	viper.SetDefault(configOutboxRelayBatchSize, 100)
+	viper.SetDefault(configSharedWithMeProjection, false)
+	viper.SetDefault(configImportRateLimit, 100)
+	viper.SetDefault(configUserIDEncryptionKeyFile, "")
+	viper.SetDefault(configLogRedaction, "")
+	viper.SetDefault(configLogRedactionSalt, "")
+	viper.SetDefault(configSchedulerInterval, 10)
+	viper.SetDefault(configSchedulerLease, 60)
+	viper.SetDefault(configJobHeartbeatInterval, 5)
+	viper.SetDefault(configJobRetention, 2592000)
+	viper.SetDefault(configRecurringJobs, "")
+	viper.SetDefault(configLeaderLease, 15)
+	viper.SetDefault(configExternalAccessWebhookURL, "")
+	viper.SetDefault(configExternalAccessWebhookTimeout, 10)
+	viper.SetDefault(configMaxReshareDepth, 0)
+	viper.SetDefault(configMaxReshareGrants, 0)
+	viper.SetDefault(configRoleMergeStrategy, string(service.MergeMostSpecific))
+	viper.SetDefault(configRoleMergeStrategies, "")
+	viper.SetDefault(configDomainGrants, "")
+	viper.SetDefault(configCompressionLevel, 0)
+	viper.SetDefault(configWarmUpFiles, "")
+	viper.SetDefault(configWarmUpTimeout, 30)
+	viper.SetDefault(configDecisionLog, "")
+	viper.SetDefault(configRequireActor, false)
+	viper.SetDefault(configActorGateways, "")
+	viper.SetDefault(configAuthorizePermissionReads, false)
+	viper.SetDefault(configCacheMinTTL, 5)
+	viper.SetDefault(configCacheMaxTTL, 300)
+	viper.SetDefault(configCacheNotFoundTTL, 5)
+	viper.SetDefault(configCacheTTLs, "")
+	viper.SetDefault(configJWTJWKSURL, "")
+	viper.SetDefault(configJWTJWKSRefreshInterval, 3600)
+	viper.SetDefault(configJWTIssuer, "")
+	viper.SetDefault(configJWTAudience, "")
+	viper.SetDefault(configJWTClockSkew, 60)
+	viper.SetDefault(configApprovalRoles, "")
+	viper.SetDefault(configMaxRequestBytes, service.DefaultMaxRequestBytes)
+	viper.SetDefault(configRequestByteLimits, "")
+	viper.SetDefault(configMaxListLength, service.DefaultMaxListLength)
+	viper.SetDefault(configAnomalyWindow, 600)
+	viper.SetDefault(configAnomalyGrantsPerActor, 1000)
+	viper.SetDefault(configAnomalyRevocationsPerActor, 500)
+	viper.SetDefault(configAnomalyExternalShares, 100)
+	viper.SetDefault(configDeprecationSunsets, "")
+	viper.SetDefault(configRegion, "")
+	viper.SetDefault(configRegionRole, service.RegionPrimary)
+	viper.SetDefault(configPrimaryRegionAddress, "")
+	viper.SetDefault(configRegionPeers, "")
+	viper.SetDefault(configStaleReadMaxAge, 0)
+	viper.SetDefault(configStaleReadMaxEntries, 100000)
+	viper.SetDefault(configDisabledComponents, "")
+	viper.SetDefault(configMaxConnectionAge, 0)
+	viper.SetDefault(configSelfTest, false)
+	viper.SetDefault(configSelfTestFileID, service.DefaultSelfTestFileID)
+	viper.SetDefault(configSelfTestTimeout, 30)
+	viper.SetDefault(configFaultInjection, "")
+	viper.SetDefault(configStaleGrantExpiry, 0)
+	viper.SetDefault(configPreShareHookURL, "")
+	viper.SetDefault(configPreShareHookTimeout, 5)
+	viper.SetDefault(configPreShareHookFailOpen, false)
+	viper.SetDefault(configRateLimitsRefreshInterval, 10)
+	viper.SetDefault(configMiddlewareOrder, service.DefaultMiddlewareOrder)
 	viper.SetEnvPrefix(envPrefix)
 	viper.AutomaticEnv()
 }
@@ -53,6 +268,8 @@ type PermissionServer struct {
 	port                string
 	healthCheckInterval int
 	permissionService   service.Service
+	healthChecker       service.HealthChecker
+	components          *components
 }
 
 // Serve accepts incoming connections on the listener `lis`, creating a new
@@ -74,45 +291,460 @@ func (s PermissionServer) Serve(lis net.Listener) {
 		listener = l
 	}
 
+	// The server is stopped once a background worker fails.
+	go func() {
+		<-s.components.done()
+		if s.components.err() != nil {
+			s.Server.Stop()
+		}
+	}()
+
 	s.logger.Infof("listening and serving grpc server on port %s", s.port)
 	if err := s.Server.Serve(listener); err != nil {
 		s.logger.Fatalf(err.Error())
 	}
+
+	if err := s.components.err(); err != nil {
+		s.logger.Fatalf("%v", err)
+	}
+}
+
+// Stop stops the background workers of the server, and then stops the grpc server,
+// closing all of its connections and listeners.
+func (s PermissionServer) Stop() {
+	s.components.stop()
+	s.Server.Stop()
+}
+
+// GracefulStop stops the background workers of the server, and then stops the grpc server gracefully,
+// once the pending RPCs are finished.
+func (s PermissionServer) GracefulStop() {
+	s.components.stop()
+	s.Server.GracefulStop()
 }
 
 // NewServer configures and creates a grpc.Server instance with the download service
 // health check service.
 // Configure using environment variables.
 // `HEALTH_CHECK_INTERVAL`: Interval to update serving state of the health check server.
+// `HEALTH_CHECK_JITTER`: The fraction of `HEALTH_CHECK_INTERVAL` by which each interval is randomized, i.e 0.2,
+// so that the instances don't ping mongodb in lockstep. Each check times out after `MONGO_CLIENT_PING_TIMEOUT`,
+// or after `HEALTH_CHECK_INTERVAL` if it's shorter.
+// `HEALTH_CHECK_FAILURE_THRESHOLD`: The number of consecutive failed checks before the server isn't serving.
+// An instance that flapped must pass more consecutive checks each time before it's serving again.
+// The checks are counted in the "health_checks" metric.
 // `PORT`: TCP port on which the grpc server would serve on.
+// `MONGO_MAX_POOL_SIZE`, `MONGO_MIN_POOL_SIZE`: The maximum and minimum number of connections in the mongodb pool.
+// `MONGO_MAX_CONN_IDLE_TIME`: Seconds a pooled mongodb connection may stay idle before it's closed.
+// `MONGO_SERVER_SELECTION_TIMEOUT`: Seconds to wait for a suitable mongodb server before an operation fails.
+// The mongodb options that are not set, or set to 0, default to the ones of `MONGO_HOST` or of the driver.
+// `MONGO_READ_HOST`: The connection string of a separate client that the list and check reads are served from,
+// such as of analytics secondaries, reads are served from `MONGO_HOST` if not set.
+// Writes, and reads whose results are written back, are always served from `MONGO_HOST`.
+// `MONGO_READ_PREFERENCE`: The read preference of the reads, i.e "secondaryPreferred",
+// the one of the read connection string if not set.
+// `MONGO_DATABASE`: The database of the store, the one of `MONGO_HOST` if not set.
+// `MONGO_COLLECTION_PREFIX`: The prefix of the names of the store's collections, i.e "staging_",
+// so that several environments, or both sides of a blue/green data migration, may share a cluster.
+// Both apply to `MONGO_HOST` and to `MONGO_READ_HOST`, unless it names its own database.
+// `MONGO_SCHEMA_VALIDATION`: How the writes of permissions that don't match their schema, such as of manual
+// edits, are handled by mongodb, "error" rejects them, "warn" logs them, and "off" removes the schema validator.
+// `MONGO_WRITE_CONCERN`: The write concern of the writes of permissions, and of the state that guards them,
+// "majority" by default so that acknowledged grants aren't lost in a failover, or a number of members.
+// `MONGO_WRITE_JOURNAL`: Whether the writes are acknowledged once they're journaled, true by default.
+// `MONGO_WRITE_TIMEOUT`: Seconds to wait for the acknowledgements of a write, indefinitely if 0.
+// `MONGO_PROJECTION_WRITE_CONCERN`: The write concern of the writes of the projections of the outbox, which are
+// rebuilt from it, such as "1" to trade their durability for latency, the one of `MONGO_WRITE_CONCERN` if not set.
+// `MONGO_READ_CONCERN`: The read concern of the reads, i.e "majority", the one of `MONGO_HOST` if not set.
+// `MONGO_RETRY_WRITES`: Whether the writes that fail on a transient error, such as a failover, are retried once.
+// `HEDGE_DELAY_MS`: Milliseconds after which a point permission check that didn't return is retried
+// concurrently, and the first attempt to return is used, checks aren't hedged if 0.
+// The outcomes of the hedged checks are counted in the "hedged_reads" metric.
+// `CALLER_ROLE_POLICY`: The maximum role each calling service may grant, i.e "preview-service=READ,*=WRITE".
+// Once it's set, only the callers that are verified by `TLS_CLIENT_CA_FILE` and that have a policy,
+// or that "*" is set for, may grant roles. Every caller may grant any role if it's not set.
+// `TLS_CERT_FILE`, `TLS_KEY_FILE`: The TLS key pair of the server, TLS is disabled if not set.
+// `TLS_CLIENT_CA_FILE`: The CA that verifies the client certificates that identify the calling services.
+// `IDEMPOTENCY_WINDOW`: Seconds in which CreatePermission requests with the same idempotency key are deduplicated.
+// `FILE_SERVICE_URL`: The address of the file service to reconcile permissions with, and that the subtrees
+// of moved files are read from, reconciliation and HandleFileMoved are disabled if not set.
+// `RECONCILE_INTERVAL`: Seconds between reconciliations of sampled permissions with the file service.
+// `RECONCILE_SAMPLE_SIZE`: The number of permissions sampled on each reconciliation.
+// `METRICS_PORT`: TCP port on which the metrics are served on /debug/vars, metrics are not served if not set.
+// The version of the build, which is set at link time, is served on /version and is the "build_info" metric.
+// `SHADOW_MONGO_HOST`: The connection string of a secondary store that reads are shadowed to, disabled if not set.
+// `SHADOW_READ_TIMEOUT`: Seconds after which a shadow read is cancelled.
+// `ROLE_ALIASES`: Aliases of role names in requests, i.e "viewer=READ,editor=WRITE".
+// `DEFAULT_ROLE`: The role, or role alias, of created permissions that don't specify one.
+// `OUTBOX_ENABLED`: Whether changes to permissions write events to the outbox,
+// requires mongodb to be a replica set.
+// `OUTBOX_RETENTION`: Seconds after which published events are deleted from the outbox,
+// which is also how long the sync tokens of ListPermissionChanges are valid.
+// `OUTBOX_RELAY_INTERVAL`, `OUTBOX_RELAY_BATCH_SIZE`: How often, and how many, outbox events are published.
+// `SHARED_WITH_ME_PROJECTION`: Whether the files shared with each user are projected for ListSharedWithMe,
+// requires the outbox. The projection is backfilled from the permissions on startup if it's empty.
+// `EXTERNAL_ACCESS_WEBHOOK_URL`: The URL that the "external_access" events of the outbox, of permissions that make
+// their files accessible outside of the tenant such as to organizations, are posted to as JSON, such as of the DLP
+// service. They're retried until it responds with a 2xx status, and aren't posted if not set.
+// `EXTERNAL_ACCESS_WEBHOOK_TIMEOUT`: Seconds in which a post to the webhook should complete.
+// `PRE_SHARE_HOOK_URL`: The URL that the shares of CreatePermission are posted to as JSON before their permissions
+// are created, such as of an antivirus or a DLP service, which responds with a verdict, i.e {"allowed": false,
+// "reason": "infected"}, and vetoes the shares that aren't allowed. Shares aren't checked if not set.
+// `PRE_SHARE_HOOK_TIMEOUT`: Seconds in which the hook should respond, defaults to 5.
+// `PRE_SHARE_HOOK_FAIL_OPEN`: Whether shares are allowed when the hook fails or doesn't respond in time,
+// otherwise they're rejected with UNAVAILABLE, which is the default.
+// `MAX_RESHARE_DEPTH`: The maximum number of users a permission may be shared through, including the user that
+// shared the file first, such as its owner, i.e 3 allows the owner's grantees to reshare and theirs to reshare
+// once more. Unlimited if 0.
+// `MAX_RESHARE_GRANTS`: The maximum number of permissions that may be reshared, transitively, from a single
+// permission given by the user that shared the file first. Unlimited if 0.
+// `ROLE_MERGE_STRATEGY`: How a user's permission to a file is resolved with the permission it would inherit from
+// the folder the file is moved into, "most_specific" keeps the file's permission, and "highest_role" keeps the
+// permission whose role grants the other's role, or the file's permission if neither role grants the other.
+// `ROLE_MERGE_STRATEGIES`: The ROLE_MERGE_STRATEGY of resource types, of the form
+// "resourceType=strategy,resourceType=strategy", such as "folder=highest_role".
+// `IMPORT_RATE_LIMIT`: The maximum number of permissions written per second by each import, unlimited if 0.
+// `USER_ID_ENCRYPTION_KEY_FILE`: Path to a file with a base64 encoded 32 byte key, such as a secret of the KMS,
+// that the user identifiers of the stored permissions are encrypted with, they're not encrypted if not set.
+// The permissions stored in plaintext before it was set aren't migrated: the lookups of the permissions of a user
+// fall back to its plaintext identifier, and granting such a user again updates its plaintext permission.
+// `LOG_REDACTION`: How user identifiers are redacted in the logs, including the logged payloads and events,
+// "mask" replaces them, "hash" replaces them with their salted hash, they're not redacted if not set.
+// `LOG_REDACTION_SALT`: The salt of the hashes of redacted identifiers.
+// `SCHEDULER_INTERVAL`: Seconds between applying the scheduled updates of permissions that are due.
+// `SCHEDULER_LEASE`: Seconds in which a scheduled update should be applied before another instance may retry it.
+// `JOB_HEARTBEAT_INTERVAL`: Seconds between the progress updates of the running jobs, in which their cancellation
+// is checked. A running job that misses 3 heartbeats, such as of an instance that was restarted, is failed.
+// `JOB_RETENTION`: Seconds after which finished jobs and failed scheduled updates are purged by "purge_retention".
+// `RECURRING_JOBS`: The maintenance jobs that are run on a schedule, by a single instance each time, as semicolon
+// separated kind=schedule, i.e "purge_retention=0 3 * * *;compute_stats=@every 10m". The kinds are
+// "purge_retention", "compute_stats" of the "permission_stats" metric, "reconcile" and "collect_garbage" of
+// sampled permissions, which require `FILE_SERVICE_URL`, "refresh_collaborators" of GetFrequentCollaborators,
+// "upgrade_schema", which backfills the upgrade of the permissions of older shapes, and "expire_stale_grants",
+// which requires `STALE_GRANT_EXPIRY`.
+// The schedules are cron expressions in UTC, "@hourly", "@daily", "@weekly", "@monthly" or "@every {duration}".
+// `LEADER_LEASE`: Seconds of the leases that elect the single instance that runs each background worker,
+// the outbox relay, the schedulers, the reconciler and the reaper of interrupted jobs, which is how long
+// a worker pauses when its leader is stopped. Every instance runs every worker if 0.
+// `DOMAIN_GRANTS`: The domains of the organizations that may be given permissions, i.e "example.org",
+// "*" allows every domain, organizations may not be given permissions if not set.
+// `COMPRESSION_LEVEL`: The gzip level, from 1 (fastest) to 9 (smallest), of the responses to clients that
+// compress their requests with gzip, i.e with client.Compression, gzip's default level if not set.
+// `WARM_UP_FILES`: The hot files whose permissions are read before serving, i.e "file1,file2".
+// `WARM_UP_TIMEOUT`: Seconds in which the indexes should be verified and the warm-up should complete,
+// the server isn't SERVING until they do, and they're retried every `HEALTH_CHECK_INTERVAL`.
+// `DECISION_LOG`: Where the allow/deny decisions of permission checks are logged as JSON lines, separately
+// from the service logs, "stdout" or the path of a file that's appended to, they're not logged if not set.
+// `REQUIRE_ACTOR`: Whether mutations are rejected if the API gateway didn't forward the end-user
+// they're made on behalf of in the "x-forwarded-user" header.
+// `ACTOR_GATEWAYS`: The common names of the verified callers, such as the API gateway, that may forward
+// end-users in the "x-forwarded-user" header, "*" allows every caller, it may not be forwarded if not set.
+// The creators of the permissions that are created on behalf of an end-user must be the end-user.
+// `AUTHORIZE_PERMISSION_READS`: Whether the permissions of a file are only listed on behalf of actors
+// whose permission to the file grants VIEW_PERMISSIONS, such as its writers and AUDITOR permissions.
+// `CACHE_MIN_TTL`, `CACHE_MAX_TTL`: The bounds in seconds of the "cache-control: max-age" hint of
+// permission checks, which is a tenth of the permission's age, so that recent grants are cached briefly.
+// `CACHE_NOT_FOUND_TTL`: Seconds for which checks of users without a permission may be cached.
+// `CACHE_TTLS`: The maximum TTLs in seconds of resource types that override `CACHE_MAX_TTL`,
+// i.e "folder=60,file=600".
+// `JWT_JWKS_URL`: The URL of the key set of the identity provider, that the bearer tokens of end-users
+// are verified with, the actor is then the token's subject, tokens aren't verified if not set.
+// `JWT_JWKS_REFRESH_INTERVAL`: Seconds between fetches of the key set, it's also fetched when
+// a token is signed by a key that it doesn't have, so that rotated keys are picked up.
+// `JWT_ISSUER`, `JWT_AUDIENCE`: The issuer and audience of the tokens, they're not verified if not set.
+// `JWT_CLOCK_SKEW`: Seconds of tolerance of the tokens' expiration and not before times.
+// `APPROVAL_ROLES`: Comma separated sensitive roles, such as "WRITE", that may only be granted by
+// a v2 RequestPermission that's approved by an actor other than its requester, imports and
+// migrations of the admin service aren't subject to it.
+// `MAX_CONNECTION_AGE`: Seconds after which a client connection is gracefully closed, so that the clients
+// that balance their calls across the replicas re-resolve them and spread to the new ones, such as after
+// a scale-up, the connections aren't closed if 0.
+// `MAX_REQUEST_BYTES`: The maximum size of a request of an RPC, larger requests fail with ResourceExhausted.
+// `REQUEST_BYTE_LIMITS`: Comma separated RPC=bytes limits of the RPCs whose requests may be larger, or
+// must be smaller, than `MAX_REQUEST_BYTES`, such as "SimulateAccess=4194304", by the RPC's name or full method.
+// `MAX_LIST_LENGTH`: The maximum number of items of a list in a request, such as the changes of
+// SimulateAccess, longer lists fail with InvalidArgument.
+// `ANOMALY_WINDOW`: Seconds of the sliding window in which unusual changes to permissions are counted,
+// their alerts are counted in the "anomaly_alerts" metric, logged and listed by ListAnomalyAlerts.
+// `ANOMALY_GRANTS_PER_ACTOR`, `ANOMALY_REVOCATIONS_PER_ACTOR`: The number of permissions given, or deleted,
+// by a single actor within the window that raises an alert, it's not detected if 0.
+// `ANOMALY_EXTERNAL_SHARES`: The number of permissions given to organizations by all actors
+// within the window that raises an alert, it's not detected if 0.
+// `DEPRECATION_SUNSETS`: Comma separated name=YYYY-MM-DD dates after which the RPCs and fields that are
+// deprecated in the protos are rejected with FailedPrecondition, by their full names, such as
+// "permission.CreatePermissionRequest.label=2027-01-01", or "*" for all of them. Until then their uses
+// are logged and counted in the "deprecated_usage" metric by caller.
+// `REGION`: The name of the region of the instance in a multi-region deployment, of a MongoDB replica set
+// whose primary is in the primary region, the deployment is of a single region if not set.
+// `REGION_ROLE`: "primary", whose instances serve the writes while the region holds the write fence,
+// which is a lease that another region may only acquire once it expires, or "replica", whose instances
+// serve the reads from the nearest MongoDB members, unless `MONGO_READ_PREFERENCE` is set, and forward
+// the writes to the primary region. Defaults to "primary".
+// `PRIMARY_REGION_ADDRESS`: The grpc address of the primary region that a replica region forwards the writes to,
+// with the TLS key pair of the server, verified with the CA of `TLS_CLIENT_CA_FILE`, if it's set.
+// `REGION_PEERS`: Comma separated callers, the replica regions' servers, whose forwarded callers the primary
+// region trusts, so that the caller policies apply to the callers of the forwarded writes.
+// `STALE_READ_MAX_AGE`: Seconds for which the last-known permission of a user to a file is served by
+// the permission checks while MongoDB is unavailable, marked by the "x-stale: true" header and not cached,
+// so that downloads degrade gracefully during short outages, they fail if it's 0, which is the default.
+// It's set per tenant by the configuration of its deployment, and is best paired with a short
+// `MONGO_SERVER_SELECTION_TIMEOUT`, so that the checks don't wait long for an unavailable MongoDB.
+// `STALE_READ_MAX_ENTRIES`: The number of last-known permissions that are kept, the least recently read
+// are evicted.
+// `DISABLED_COMPONENTS`: Comma separated background workers that aren't run by the instance, of "metrics",
+// "scheduler", "reconciler", "job_reaper", "recurring_jobs", "outbox_relay", "region_fence", "self_test"
+// and "rate_limits",
+// such as to run them in dedicated instances. The workers are started and stopped with the server, and the first
+// worker that fails stops the server.
+// `SELF_TEST`: Whether the instance runs a create, get, update and delete cycle of a permission to a reserved
+// file once it's serving, and reports its outcome in the logs and as the health of the "permission.SelfTest"
+// service, also set by the --self-test flag. It isn't run in a replica region, which doesn't serve the writes.
+// `SELF_TEST_FILE_ID`: The reserved file of the self-test, defaults to "permission-service-self-test".
+// `SELF_TEST_TIMEOUT`: Seconds in which the self-test's cycle should complete, defaults to 30.
+// `FAULT_INJECTION`: Comma separated method=fault@probability faults that are injected into the operations
+// of the store, for resilience testing in test environments only, such as "Get=200ms@0.5,Create=unavailable@0.1",
+// where a fault is either a latency or the grpc code of an error, and "*" is every method. Disabled if not set.
+// `STALE_GRANT_EXPIRY`: Seconds without use, by their last access time or else by their creation, after which
+// the direct grants other than the owners' are deleted by the "expire_stale_grants" recurring job, except on held
+// files. It's set per tenant by the configuration of its deployment, grants don't expire if it's 0, the default.
+// `RATE_LIMITS_REFRESH_INTERVAL`: Seconds between the refreshes of the tenant's read and write rate limits,
+// which are set by SetTenantRateLimits and enforced by each instance, defaults to 10.
+// `MIDDLEWARE_ORDER`: Comma separated order of the middlewares that the handlers of the services run within,
+// from the outermost to the innermost, of "logging", "metrics", "recovery", "memoization", "region_fence",
+// "deprecations", "validation", "rate_limits" and "auth", each ordered once, with only "logging" and "metrics"
+// outside of "recovery", and "auth" within "region_fence". Defaults to the order of that list.
 func NewServer(logger *logrus.Logger) *PermissionServer {
 	// If no logger is given, create a new default logger for the server.
 	if logger == nil {
 		logger = ilogger.NewLogger()
 	}
 
-	// Set up grpc server opts with logger interceptor.
-	serverOpts := append(
-		serverLoggerInterceptor(logger),
-		grpc.MaxRecvMsgSize(16<<20),
+	buildInfo := service.CurrentBuildInfo()
+	logger.WithFields(logrus.Fields{
+		"version":   buildInfo.Version,
+		"commit":    buildInfo.Commit,
+		"buildTime": buildInfo.BuildTime,
+		"goVersion": buildInfo.GoVersion,
+	}).Info("starting permission service")
+
+	// Redact the user identifiers of every entry before it's written.
+	redactionMode := redact.Mode(viper.GetString(configLogRedaction))
+	if redactionMode != redact.ModeNone {
+		redactor, err := redact.NewRedactor(redactionMode, viper.GetString(configLogRedactionSalt))
+		if err != nil {
+			logger.Fatalf("%v", err)
+		}
+
+		logger.AddHook(redact.NewHook(redactor))
+	}
+
+	// Registering the gzip compressor advertises it to clients, and the responses to
+	// compressed requests are compressed with it.
+	if compressionLevel := viper.GetInt(configCompressionLevel); compressionLevel != 0 {
+		if err := gzip.SetLevel(compressionLevel); err != nil {
+			logger.Fatalf("invalid %s: %v", configCompressionLevel, err)
+		}
+	}
+
+	limits, err := service.ParseRequestLimits(
+		viper.GetString(configRequestByteLimits),
+		viper.GetInt(configMaxRequestBytes),
+		viper.GetInt(configMaxListLength),
 	)
+	if err != nil {
+		logger.Fatalf("%v", err)
+	}
 
-	// Create a new grpc server.
-	grpcServer := grpc.NewServer(
-		serverOpts...,
+	// Set up grpc server opts. The transport rejects the messages that are larger than every RPC's limit,
+	// the handlers enforce the limit of each RPC.
+	serverOpts := []grpc.ServerOption{grpc.MaxRecvMsgSize(limits.MaxMessageBytes())}
+
+	tlsOpts, err := serverTLSOptions(
+		viper.GetString(configTLSCertFile),
+		viper.GetString(configTLSKeyFile),
+		viper.GetString(configTLSClientCAFile),
 	)
+	if err != nil {
+		logger.Fatalf("%v", err)
+	}
+
+	serverOpts = append(serverOpts, tlsOpts...)
+	if maxConnectionAge := viper.GetDuration(configMaxConnectionAge) * time.Second; maxConnectionAge > 0 {
+		serverOpts = append(serverOpts, grpc.KeepaliveParams(keepalive.ServerParameters{
+			MaxConnectionAge:      maxConnectionAge,
+			MaxConnectionAgeGrace: maxConnectionAge,
+		}))
+	}
+
+	rolePolicy, err := service.ParseRolePolicy(viper.GetString(configCallerRolePolicy))
+	if err != nil {
+		logger.Fatalf("%v", err)
+	}
+
+	roles, err := initRoleRegistry()
+	if err != nil {
+		logger.Fatalf("%v", err)
+	}
+
+	workers, err := newComponents(logger, viper.GetString(configDisabledComponents))
+	if err != nil {
+		logger.Fatalf("invalid %s: %v", configDisabledComponents, err)
+	}
+
+	controller, leaders, err := initMongoDBController(
+		logger,
+		workers,
+		viper.GetString(configMongoConnectionString),
+	)
+	if err != nil {
+		logger.Fatalf("%v", err)
+	}
+
+	// Detect unusual changes to permissions made through the controller.
+	anomalies := service.NewAnomalyDetector(service.AnomalyThresholds{
+		Window:              viper.GetDuration(configAnomalyWindow) * time.Second,
+		GrantsPerActor:      viper.GetInt64(configAnomalyGrantsPerActor),
+		RevocationsPerActor: viper.GetInt64(configAnomalyRevocationsPerActor),
+		ExternalShares:      viper.GetInt64(configAnomalyExternalShares),
+	}, logger)
+	controller = service.DetectAnomalies(controller, anomalies)
 
-	controller, err := initMongoDBController(viper.GetString(configMongoConnectionString))
+	regions, err := initRegions(leaders, workers)
 	if err != nil {
 		logger.Fatalf("%v", err)
 	}
 
 	// Create a permission service and register it on the grpc server.
-	permissionService := service.NewService(controller, logger)
+	domainGrants := service.ParseDomainGrantPolicy(viper.GetString(configDomainGrants))
+	decisions, err := initDecisionSink()
+	if err != nil {
+		logger.Fatalf("%v", err)
+	}
+
+	actors := service.ActorPolicy{
+		Required:                 viper.GetBool(configRequireActor),
+		Gateways:                 service.ParseActorGateways(viper.GetString(configActorGateways)),
+		AuthorizePermissionReads: viper.GetBool(configAuthorizePermissionReads),
+	}
+	if jwksURL := viper.GetString(configJWTJWKSURL); jwksURL != "" {
+		actors.Tokens = jwt.NewVerifier(
+			jwt.NewKeySet(jwksURL, viper.GetDuration(configJWTJWKSRefreshInterval)*time.Second),
+			viper.GetString(configJWTIssuer),
+			viper.GetString(configJWTAudience),
+			viper.GetDuration(configJWTClockSkew)*time.Second,
+		)
+	}
+
+	approvalPolicy, err := service.ParseApprovalPolicy(viper.GetString(configApprovalRoles))
+	if err != nil {
+		logger.Fatalf("%v", err)
+	}
+
+	cachePolicy, err := initCachePolicy()
+	if err != nil {
+		logger.Fatalf("%v", err)
+	}
+
+	preSharePolicy := service.PreSharePolicy{FailOpen: viper.GetBool(configPreShareHookFailOpen), Logger: logger}
+	if hookURL := viper.GetString(configPreShareHookURL); hookURL != "" {
+		preSharePolicy.Hook = service.NewHTTPPreShareHook(
+			hookURL,
+			viper.GetDuration(configPreShareHookTimeout)*time.Second,
+		)
+	}
+
+	// Connect to the file service, that the subtrees of moved files are read from
+	// and that permissions are reconciled with.
+	var fileService *fileservice.Client
+	if fileServiceURL := viper.GetString(configFileServiceURL); fileServiceURL != "" {
+		fileServiceConn, err := grpc.Dial(fileServiceURL, grpc.WithInsecure())
+		if err != nil {
+			logger.Fatalf("failed dialing file service %s: %v", fileServiceURL, err)
+		}
+
+		fileServiceClient := fileservice.NewClient(fileServiceConn)
+		fileService = &fileServiceClient
+	}
+
+	lastKnown := service.NewLastKnownPermissions(
+		viper.GetDuration(configStaleReadMaxAge)*time.Second,
+		viper.GetInt(configStaleReadMaxEntries),
+	)
+
+	permissionService := service.NewService(controller, logger, rolePolicy, roles, domainGrants).
+		WithDecisionSink(decisions).
+		WithActorPolicy(actors).
+		WithApprovalPolicy(approvalPolicy).
+		WithRequestLimits(limits).
+		WithCachePolicy(cachePolicy).
+		WithLastKnownPermissions(lastKnown).
+		WithPreSharePolicy(preSharePolicy)
+	if fileService != nil {
+		permissionService = permissionService.WithFileTree(fileService).WithFileMetadata(fileService)
+	}
+
+	// The reads and the writes of the permission services are limited by the tenant's rate limits.
+	rateLimiter := service.NewRateLimiter(controller, logger)
+	workers.add(componentRateLimits, func(ctx context.Context) error {
+		rateLimiter.Run(ctx, viper.GetDuration(configRateLimitsRefreshInterval)*time.Second)
+		return nil
+	})
+
+	// The handlers of the services run within the middlewares in their configured order.
+	middlewares, err := initMiddlewares(logger, actors, regions, rateLimiter)
+	if err != nil {
+		logger.Fatalf("%v", err)
+	}
+
+	chain, err := middlewares.Chain()
+	if err != nil {
+		logger.Fatalf("%v", err)
+	}
+
+	serverOpts = append(serverOpts, grpc.UnaryInterceptor(chain.Unary), grpc.StreamInterceptor(chain.Stream))
+
+	// Create a new grpc server.
+	grpcServer := grpc.NewServer(
+		serverOpts...,
+	)
+
 	pb.RegisterPermissionServer(grpcServer, permissionService)
 
+	// Create a v2 permission service sharing the controller and register it on the grpc server.
+	serviceV2 := service.NewServiceV2(controller, logger, rolePolicy, roles, domainGrants).
+		WithDecisionSink(decisions).
+		WithActorPolicy(actors).
+		WithApprovalPolicy(approvalPolicy).
+		WithRequestLimits(limits).
+		WithCachePolicy(cachePolicy).
+		WithLastKnownPermissions(lastKnown).
+		WithPreSharePolicy(preSharePolicy)
+	pbv2.RegisterPermissionsServer(grpcServer, serviceV2)
+
+	// Jobs of bulk operations goroutine worker, which fails the jobs that were interrupted.
+	jobs := service.NewJobRunner(controller, logger, viper.GetDuration(configJobHeartbeatInterval)*time.Second)
+	workers.addElected(leaders, componentJobReaper, jobs.Run)
+
+	// Create an admin service and register it on the grpc server.
+	adminService := service.NewAdminService(
+		controller,
+		logger,
+		viper.GetInt(configImportRateLimit),
+		domainGrants,
+	).WithRequestLimits(limits).
+		WithAnomalyDetector(anomalies).
+		WithJobRunner(jobs).
+		WithLastKnownPermissions(lastKnown).
+		WithRateLimiter(rateLimiter)
+	if fileService != nil {
+		adminService = adminService.WithFileMetadata(fileService)
+	}
+	pbv2.RegisterPermissionsAdminServer(grpcServer, adminService)
+
 	// Create a health server and register it on the grpc server.
+	// It isn't serving until the health check worker warms up the service.
 	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
 	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
 
 	permissionServer := &PermissionServer{
@@ -121,10 +753,87 @@ func NewServer(logger *logrus.Logger) *PermissionServer {
 		port:                viper.GetString(configPort),
 		healthCheckInterval: viper.GetInt(configHealthCheckInterval),
 		permissionService:   permissionService,
+		components:          workers,
+		healthChecker: service.NewHealthChecker(
+			controller,
+			logger,
+			viper.GetDuration(configHealthCheckInterval)*time.Second,
+			viper.GetFloat64(configHealthCheckJitter),
+			viper.GetDuration(configMongoClientPingTimeout)*time.Second,
+			viper.GetInt(configHealthCheckFailureThreshold),
+		),
 	}
 
 	// Health check validation goroutine worker.
-	go permissionServer.healthCheckWorker(healthServer)
+	workers.add(componentHealth, func(ctx context.Context) error {
+		permissionServer.healthCheckWorker(ctx, healthServer)
+		return nil
+	})
+
+	// Self-test goroutine worker.
+	if viper.GetBool(configSelfTest) {
+		if isReplicaRegion() {
+			logger.Warn("the self-test isn't run in a replica region")
+		} else {
+			selfTest := service.NewSelfTest(
+				controller,
+				logger,
+				viper.GetString(configSelfTestFileID),
+				viper.GetDuration(configSelfTestTimeout)*time.Second,
+			)
+			healthServer.SetServingStatus(service.SelfTestHealthService, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+			workers.add(componentSelfTest, func(ctx context.Context) error {
+				permissionServer.selfTestWorker(ctx, healthServer, selfTest)
+				return nil
+			})
+		}
+	}
+
+	// Metrics http server goroutine worker.
+	if metricsPort := viper.GetString(configMetricsPort); metricsPort != "" {
+		workers.add(componentMetrics, func(ctx context.Context) error {
+			return serveMetrics(ctx, logger, metricsPort)
+		})
+	}
+
+	// Scheduled updates goroutine worker.
+	scheduler := service.NewScheduler(controller, logger, viper.GetDuration(configSchedulerLease)*time.Second)
+	workers.addElected(leaders, componentScheduler, func(ctx context.Context) {
+		scheduler.Run(ctx, viper.GetDuration(configSchedulerInterval)*time.Second)
+	})
+
+	// Reconciliation with the file service goroutine worker.
+	maintenance := service.NewMaintenanceJobs(controller, logger, viper.GetDuration(configJobRetention)*time.Second)
+	if fileService != nil {
+		reconciler := service.NewReconciler(
+			controller,
+			fileService,
+			logger,
+			viper.GetInt(configReconcileSampleSize),
+		)
+		workers.addElected(leaders, componentReconciler, func(ctx context.Context) {
+			reconciler.Run(ctx, viper.GetDuration(configReconcileInterval)*time.Second)
+		})
+		maintenance = maintenance.WithReconciler(reconciler)
+	}
+
+	if staleGrantExpiry := viper.GetDuration(configStaleGrantExpiry) * time.Second; staleGrantExpiry > 0 {
+		maintenance = maintenance.WithStaleGrantExpiry(staleGrantExpiry)
+	}
+
+	// Recurring maintenance jobs goroutine worker.
+	recurringJobs, err := service.ParseRecurringJobs(viper.GetString(configRecurringJobs))
+	if err != nil {
+		logger.Fatalf("invalid %s: %v", configRecurringJobs, err)
+	}
+
+	recurring, err := service.NewRecurringScheduler(controller, jobs, logger, recurringJobs, maintenance)
+	if err != nil {
+		logger.Fatalf("invalid %s: %v", configRecurringJobs, err)
+	}
+
+	workers.addElected(leaders, componentRecurringJobs, recurring.Run)
+	workers.start()
 
 	return permissionServer
 }
@@ -132,6 +841,7 @@ func NewServer(logger *logrus.Logger) *PermissionServer {
 func connectToMongoDB(connectionString string) (*mongo.Client, error) {
 	// Create mongodb client.
 	mongoOptions := options.Client().ApplyURI(connectionString).SetMonitor(apmmongo.CommandMonitor())
+	applyMongoPoolOptions(mongoOptions)
 	mongoClient, err := mongo.NewClient(mongoOptions)
 	if err != nil {
 		return nil, fmt.Errorf("failed creating mongodb client with connection string %s: %v", connectionString, err)
@@ -158,7 +868,63 @@ func connectToMongoDB(connectionString string) (*mongo.Client, error) {
 	return mongoClient, nil
 }
 
-func getMongoDatabaseName(mongoClient *mongo.Client, connectionString string) (*mongo.Database, error) {
+// applyMongoPoolOptions applies the configured connection pool options that are set, and whether the writes
+// are retried, to mongoOptions.
+func applyMongoPoolOptions(mongoOptions *options.ClientOptions) {
+	if maxPoolSize := viper.GetInt64(configMongoMaxPoolSize); maxPoolSize > 0 {
+		mongoOptions.SetMaxPoolSize(uint64(maxPoolSize))
+	}
+
+	if minPoolSize := viper.GetInt64(configMongoMinPoolSize); minPoolSize > 0 {
+		mongoOptions.SetMinPoolSize(uint64(minPoolSize))
+	}
+
+	if maxConnIdleTime := viper.GetDuration(configMongoMaxConnIdleTime); maxConnIdleTime > 0 {
+		mongoOptions.SetMaxConnIdleTime(maxConnIdleTime * time.Second)
+	}
+
+	if timeout := viper.GetDuration(configMongoServerSelectionTimeout); timeout > 0 {
+		mongoOptions.SetServerSelectionTimeout(timeout * time.Second)
+	}
+
+	mongoOptions.SetRetryWrites(viper.GetBool(configMongoRetryWrites))
+}
+
+// initMongoDBConcerns returns the configured read and write concerns of the classes of the store's operations.
+func initMongoDBConcerns() (mongodb.Concerns, error) {
+	journal := viper.GetBool(configMongoWriteJournal)
+	timeout := viper.GetDuration(configMongoWriteTimeout) * time.Second
+	writes, err := mongodb.ParseWriteConcern(viper.GetString(configMongoWriteConcern), journal, timeout)
+	if err != nil {
+		return mongodb.Concerns{}, fmt.Errorf("invalid %s: %v", configMongoWriteConcern, err)
+	}
+
+	projectionWrites, err := mongodb.ParseWriteConcern(
+		viper.GetString(configMongoProjectionWriteConcern),
+		journal,
+		timeout,
+	)
+	if err != nil {
+		return mongodb.Concerns{}, fmt.Errorf("invalid %s: %v", configMongoProjectionWriteConcern, err)
+	}
+
+	reads, err := mongodb.ParseReadConcern(viper.GetString(configMongoReadConcern))
+	if err != nil {
+		return mongodb.Concerns{}, fmt.Errorf("invalid %s: %v", configMongoReadConcern, err)
+	}
+
+	return mongodb.Concerns{Writes: writes, ProjectionWrites: projectionWrites, Reads: reads}, nil
+}
+
+// getMongoDatabaseName returns the database named database, or the one of connectionString if empty.
+func getMongoDatabaseName(
+	mongoClient *mongo.Client,
+	connectionString string,
+	database string) (*mongo.Database, error) {
+	if database != "" {
+		return mongoClient.Database(database), nil
+	}
+
 	connString, err := connstring.Parse(connectionString)
 	if err != nil {
 		return nil, fmt.Errorf("failed parsing connection string %s: %v", connectionString, err)
@@ -167,37 +933,558 @@ func getMongoDatabaseName(mongoClient *mongo.Client, connectionString string) (*
 	return mongoClient.Database(connString.Database), nil
 }
 
-func initMongoDBController(connectionString string) (service.Controller, error) {
+// initMongoDBStore returns a store of the collections whose names start with collectionPrefix,
+// in database, or in the database of connectionString if database is empty.
+func initMongoDBStore(
+	connectionString string,
+	database string,
+	collectionPrefix string) (mongodb.MongoStore, error) {
 	mongoClient, err := connectToMongoDB(connectionString)
 	if err != nil {
-		return nil, err
+		return mongodb.MongoStore{}, err
+	}
+
+	db, err := getMongoDatabaseName(mongoClient, connectionString, database)
+	if err != nil {
+		return mongodb.MongoStore{}, err
 	}
 
-	db, err := getMongoDatabaseName(mongoClient, connectionString)
+	concerns, err := initMongoDBConcerns()
+	if err != nil {
+		return mongodb.MongoStore{}, err
+	}
+
+	idempotencyWindow := viper.GetDuration(configIdempotencyWindow) * time.Second
+	store, err := mongodb.NewMongoStore(db, collectionPrefix, idempotencyWindow)
+	if err != nil {
+		return mongodb.MongoStore{}, fmt.Errorf("failed creating mongo store: %v", err)
+	}
+
+	store = store.WithConcerns(concerns)
+
+	if err := store.InstallSchema(context.Background(), viper.GetString(configMongoSchemaValidation)); err != nil {
+		return mongodb.MongoStore{}, err
+	}
+
+	return store, nil
+}
+
+// initMongoDBReadDB returns the database that the reads of the store of db are served from,
+// by the configured read connection string and read preference, or nil if neither is set.
+func initMongoDBReadDB(db *mongo.Database) (*mongo.Database, error) {
+	readConnectionString := viper.GetString(configMongoReadConnectionString)
+	readPreference := viper.GetString(configMongoReadPreference)
+	if readPreference == "" && isReplicaRegion() {
+		// The reads of a replica region are served by its local secondaries.
+		readPreference = "nearest"
+	}
+
+	if readConnectionString == "" && readPreference == "" {
+		return nil, nil
+	}
+
+	dbOptions := options.Database()
+	if readPreference != "" {
+		mode, err := readpref.ModeFromString(readPreference)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %v", configMongoReadPreference, readPreference, err)
+		}
+
+		rp, err := readpref.New(mode)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %v", configMongoReadPreference, readPreference, err)
+		}
+
+		dbOptions.SetReadPreference(rp)
+	}
+
+	if readConnectionString == "" {
+		return db.Client().Database(db.Name(), dbOptions), nil
+	}
+
+	readClient, err := connectToMongoDB(readConnectionString)
 	if err != nil {
 		return nil, err
 	}
 
-	controller, err := mongodb.NewMongoController(db)
+	// The reads are of the store's database, unless the read connection string names another.
+	connString, err := connstring.Parse(readConnectionString)
 	if err != nil {
-		return nil, fmt.Errorf("failed creating mongo store: %v", err)
+		return nil, fmt.Errorf("failed parsing connection string %s: %v", readConnectionString, err)
+	}
+
+	dbName := connString.Database
+	if dbName == "" {
+		dbName = db.Name()
 	}
 
-	return controller, nil
+	return readClient.Database(dbName, dbOptions), nil
 }
 
-// serverLoggerInterceptor configures the logger interceptor for the permission server.
-func serverLoggerInterceptor(logger *logrus.Logger) []grpc.ServerOption {
-	// Create new logrus entry for logger interceptor.
-	logrusEntry := logrus.NewEntry(logger)
+// leaseHolder returns the identity of the instance in the leases of the leader election,
+// which is unique among the instances of the service.
+func leaseHolder() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
 
-	ignorePayload := ilogger.IgnoreServerMethodsDecider(
-		strings.Split(viper.GetString(configElasticAPMIgnoreURLS), ",")...,
-	)
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
 
-	ignoreInitialRequest := ilogger.IgnoreServerMethodsDecider(
-		strings.Split(viper.GetString(configElasticAPMIgnoreURLS), ",")...,
+// initMongoDBController returns the controller of the store of connectionString, and the leader elector of
+// the background workers, whose leases are stored in it. The workers of the store are added to workers.
+func initMongoDBController(
+	logger *logrus.Logger,
+	workers *components,
+	connectionString string,
+) (service.Controller, service.LeaderElector, error) {
+	store, err := initMongoDBStore(
+		connectionString,
+		viper.GetString(configMongoDatabase),
+		viper.GetString(configMongoCollectionPrefix),
 	)
+	if err != nil {
+		return nil, service.LeaderElector{}, err
+	}
+
+	readDB, err := initMongoDBReadDB(store.DB)
+	if err != nil {
+		return nil, service.LeaderElector{}, err
+	}
+
+	if readDB != nil {
+		store = store.WithReadDB(readDB)
+	}
+
+	store = store.WithHedging(viper.GetDuration(configHedgeDelay) * time.Millisecond)
+	leaderLease := viper.GetDuration(configLeaderLease) * time.Second
+	leaders := service.NewLeaderElector(store, logger, leaseHolder(), leaderLease)
+
+	cipher, err := initIdentifierCipher()
+	if err != nil {
+		return nil, service.LeaderElector{}, err
+	}
+
+	if viper.GetBool(configOutboxEnabled) {
+		store, err = store.WithOutbox(context.Background(), viper.GetDuration(configOutboxRetention)*time.Second)
+		if err != nil {
+			return nil, service.LeaderElector{}, fmt.Errorf("failed creating outbox: %v", err)
+		}
+
+		if viper.GetBool(configSharedWithMeProjection) {
+			if store, err = store.WithSharedWithMe(context.Background()); err != nil {
+				return nil, service.LeaderElector{}, err
+			}
+		}
+
+		cachePolicy, err := initCachePolicy()
+		if err != nil {
+			return nil, service.LeaderElector{}, err
+		}
+
+		// Count the changes that invalidate the checks cached by the gateways, with the changes' log.
+		var publisher service.EventPublisher = service.Publishers{
+			service.NewLogPublisher(logger),
+			service.NewCacheInvalidationPublisher(cachePolicy),
+		}
+		if webhookURL := viper.GetString(configExternalAccessWebhookURL); webhookURL != "" {
+			publisher = service.Publishers{
+				publisher,
+				service.NewWebhookPublisher(
+					webhookURL,
+					viper.GetDuration(configExternalAccessWebhookTimeout)*time.Second,
+					service.EventExternalAccess,
+				),
+			}
+		}
+
+		if cipher != nil {
+			publisher = encryption.NewPublisher(publisher, *cipher)
+		}
+
+		// Outbox relay goroutine worker.
+		workers.addElected(leaders, componentOutboxRelay, func(ctx context.Context) {
+			store.RelayOutbox(
+				ctx,
+				publisher,
+				logger,
+				viper.GetDuration(configOutboxRelayInterval)*time.Second,
+				viper.GetInt(configOutboxRelayBatchSize),
+			)
+		})
+	}
+
+	var permissions service.PermissionRepository = store
+	var requests service.RequestRepository = store
+	var schedules service.ScheduleRepository = store
+	var approvals service.ApprovalRepository = store
+	var locks service.LockRepository = store
+	var holds service.HoldRepository = store
+	var jobs service.JobRepository = store
+	var tenants service.TenantRepository = store
+	var reviews service.ReviewRepository = store
+
+	// Inject faults into the operations of the store, as if they were of the store itself.
+	injected, err := faults.Parse(viper.GetString(configFaultInjection), faults.Methods)
+	if err != nil {
+		return nil, service.LeaderElector{}, fmt.Errorf("invalid %s: %v", configFaultInjection, err)
+	}
+
+	if !injected.Empty() {
+		logger.WithField("faults", viper.GetString(configFaultInjection)).
+			Warn("faults are injected into the operations of the store")
+		permissions = faults.NewRepository(permissions, injected)
+	}
+
+	// Serve from the store, and shadow the reads to the secondary store to compare their results.
+	if shadowConnectionString := viper.GetString(configShadowMongoConnectionString); shadowConnectionString != "" {
+		// The secondary store is of the database of its connection string, with the default collection names.
+		shadowStore, err := initMongoDBStore(shadowConnectionString, "", "")
+		if err != nil {
+			return nil, service.LeaderElector{}, err
+		}
+
+		shadowTimeout := viper.GetDuration(configShadowReadTimeout) * time.Second
+		permissions = shadow.NewRepository(permissions, shadowStore, logger, shadowTimeout)
+	}
+
+	if cipher != nil {
+		permissions = encryption.NewRepository(permissions, *cipher)
+		requests = encryption.NewRequestRepository(requests, *cipher)
+		schedules = encryption.NewScheduleRepository(schedules, *cipher)
+		approvals = encryption.NewApprovalRepository(approvals, *cipher)
+		locks = encryption.NewLockRepository(locks, *cipher)
+		holds = encryption.NewHoldRepository(holds, *cipher)
+		jobs = encryption.NewJobRepository(jobs, *cipher)
+		reviews = encryption.NewReviewRepository(reviews, *cipher)
+	}
+
+	// The point reads of the read RPCs are memoized within each request.
+	permissions = memo.NewRepository(permissions)
+	locks = memo.NewLockRepository(locks)
+
+	reshareLimits := service.ReshareLimits{
+		MaxDepth:  viper.GetInt(configMaxReshareDepth),
+		MaxGrants: viper.GetInt(configMaxReshareGrants),
+	}
+
+	mergeStrategy, err := service.ParseMergeStrategy(viper.GetString(configRoleMergeStrategy))
+	if err != nil {
+		return nil, service.LeaderElector{}, err
+	}
+
+	mergeStrategies, err := service.ParseMergeStrategies(viper.GetString(configRoleMergeStrategies))
+	if err != nil {
+		return nil, service.LeaderElector{}, err
+	}
+
+	strategies := service.MergeStrategies{Default: mergeStrategy, ByResourceType: mergeStrategies}
+	return controller.New(permissions, requests, schedules, approvals, locks, holds, jobs).
+		WithReshareLimits(reshareLimits).
+		WithMergeStrategies(strategies).
+		WithTenants(tenants).
+		WithReviews(reviews), leaders, nil
+}
+
+// initIdentifierCipher creates the cipher of the user identifiers with the configured key,
+// returns nil if the identifiers aren't encrypted.
+func initIdentifierCipher() (*encryption.IdentifierCipher, error) {
+	keyFile := viper.GetString(configUserIDEncryptionKeyFile)
+	if keyFile == "" {
+		return nil, nil
+	}
+
+	encodedKey, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading encryption key: %v", err)
+	}
+
+	key, err := encryption.ParseKey(string(encodedKey))
+	if err != nil {
+		return nil, err
+	}
+
+	cipher, err := encryption.NewIdentifierCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cipher, nil
+}
+
+// initDecisionSink creates the sink of the decision log by the configured destination,
+// returns nil if decisions aren't logged.
+func initDecisionSink() (service.DecisionSink, error) {
+	switch destination := viper.GetString(configDecisionLog); destination {
+	case "":
+		return nil, nil
+	case "stdout":
+		return service.NewWriterDecisionSink(os.Stdout), nil
+	default:
+		file, err := os.OpenFile(destination, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0640)
+		if err != nil {
+			return nil, fmt.Errorf("failed opening decision log %s: %v", destination, err)
+		}
+
+		return service.NewWriterDecisionSink(file), nil
+	}
+}
+
+// initRoleRegistry creates the role registry of the configured role aliases and default role.
+func initRoleRegistry() (service.RoleRegistry, error) {
+	aliases, err := service.ParseRoleAliases(viper.GetString(configRoleAliases))
+	if err != nil {
+		return service.RoleRegistry{}, err
+	}
+
+	roles := service.NewRoleRegistry(aliases, pb.Role_NONE)
+	defaultRoleName := viper.GetString(configDefaultRole)
+	if defaultRoleName == "" {
+		return roles, nil
+	}
+
+	defaultRole, ok := roles.Lookup(defaultRoleName)
+	if !ok {
+		return service.RoleRegistry{}, fmt.Errorf("default role %q does not exist", defaultRoleName)
+	}
+
+	return service.NewRoleRegistry(aliases, defaultRole), nil
+}
+
+// initCachePolicy creates the caching policy of the configured TTLs of permission checks.
+func initCachePolicy() (service.CachePolicy, error) {
+	cacheTTLs, err := service.ParseCacheTTLs(viper.GetString(configCacheTTLs))
+	if err != nil {
+		return service.CachePolicy{}, err
+	}
+
+	return service.CachePolicy{
+		MinTTL:         viper.GetDuration(configCacheMinTTL) * time.Second,
+		MaxTTL:         viper.GetDuration(configCacheMaxTTL) * time.Second,
+		NotFoundTTL:    viper.GetDuration(configCacheNotFoundTTL) * time.Second,
+		ByResourceType: cacheTTLs,
+	}, nil
+}
+
+// initMiddlewares creates the registry of the middlewares of the services in the configured order.
+// The handlers log their requests, count them, recover their panics into Internal errors rather than crash
+// the service, memoize the concurrent identical reads, fence the writes of a replica region, record the uses
+// of the deprecated RPCs and fields of their protos, rejecting them after their sunsets, reject the requests
+// that violate the validation rules of their protos, enforce the tenant's rate limits with rateLimiter,
+// and authenticate the actors of the requests with actors.
+func initMiddlewares(
+	logger *logrus.Logger,
+	actors service.ActorPolicy,
+	regions service.Regions,
+	rateLimiter *service.RateLimiter,
+) (*service.Middlewares, error) {
+	order, err := service.ParseMiddlewareOrder(viper.GetString(configMiddlewareOrder))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", configMiddlewareOrder, err)
+	}
+
+	sunsets, err := service.ParseDeprecationSunsets(viper.GetString(configDeprecationSunsets))
+	if err != nil {
+		return nil, err
+	}
+
+	recoverer := service.NewRecoverer(logger)
+	deprecations := service.NewDeprecations(logger, sunsets)
+
+	// The middlewares that depend on the RPCs handle the RPCs of the permission services, and the admin service
+	// is exempt from the rate limits, so that they may always be changed.
+	permissionDescs := []grpc.ServiceDesc{pb.PermissionServiceDesc(), pbv2.PermissionsServiceDesc()}
+	descs := []grpc.ServiceDesc{
+		pb.PermissionServiceDesc(),
+		pbv2.PermissionsServiceDesc(),
+		pbv2.PermissionsAdminServiceDesc(),
+	}
+
+	memoization, err := service.MemoizeReads(descs...)
+	if err != nil {
+		return nil, err
+	}
+
+	regionFence, err := regions.Middleware(descs...)
+	if err != nil {
+		return nil, err
+	}
+
+	deprecationChecks, err := deprecations.Middleware(descs...)
+	if err != nil {
+		return nil, err
+	}
+
+	rateLimits, err := rateLimiter.Middleware(permissionDescs...)
+	if err != nil {
+		return nil, err
+	}
+
+	middlewares := service.NewMiddlewares(order)
+	middlewares.Register(service.MiddlewareLogging, loggerMiddleware(logger))
+	middlewares.Register(service.MiddlewareMetrics, service.CountRequests())
+	middlewares.Register(service.MiddlewareRecovery, recoverer.Middleware())
+	middlewares.Register(service.MiddlewareMemoization, memoization)
+	middlewares.Register(service.MiddlewareRegionFence, regionFence)
+	middlewares.Register(service.MiddlewareDeprecations, deprecationChecks)
+	middlewares.Register(service.MiddlewareValidation, service.ValidateRequests())
+	middlewares.Register(service.MiddlewareRateLimits, rateLimits)
+	middlewares.Register(service.MiddlewareAuth, actors.Middleware())
+
+	return middlewares, nil
+}
+
+// initRegions creates the policy of the region of the instance, and adds the write fence worker of
+// a primary region, whose fence is stored with the leases of leaders, to workers.
+func initRegions(leaders service.LeaderElector, workers *components) (service.Regions, error) {
+	region := viper.GetString(configRegion)
+	if region == "" {
+		return service.Regions{}, nil
+	}
+
+	switch role := viper.GetString(configRegionRole); role {
+	case service.RegionPrimary:
+		// Write fence goroutine worker.
+		fence := leaders.RegionFence(region)
+		workers.add(componentRegionFence, func(ctx context.Context) error {
+			fence.Run(ctx)
+			return nil
+		})
+
+		peers := service.ParseRegionPeers(viper.GetString(configRegionPeers))
+		return service.NewPrimaryRegion(region, fence, peers), nil
+	case service.RegionReplica:
+		address := viper.GetString(configPrimaryRegionAddress)
+		if address == "" {
+			return service.Regions{}, fmt.Errorf("%s is required in a replica region", configPrimaryRegionAddress)
+		}
+
+		dialOpts, err := clientTLSOptions(
+			viper.GetString(configTLSCertFile),
+			viper.GetString(configTLSKeyFile),
+			viper.GetString(configTLSClientCAFile),
+		)
+		if err != nil {
+			return service.Regions{}, err
+		}
+
+		primary, err := grpc.Dial(address, dialOpts...)
+		if err != nil {
+			return service.Regions{}, fmt.Errorf("failed dialing the primary region %s: %v", address, err)
+		}
+
+		return service.NewReplicaRegion(region, primary), nil
+	default:
+		return service.Regions{}, fmt.Errorf("invalid %s %q", configRegionRole, role)
+	}
+}
+
+// isReplicaRegion returns whether the instance is of a replica region.
+func isReplicaRegion() bool {
+	return viper.GetString(configRegion) != "" && viper.GetString(configRegionRole) == service.RegionReplica
+}
+
+// clientTLSOptions returns the dial options that present the key pair of certFile and keyFile,
+// and verify the server's certificate using the CA of caFile, if set.
+// Returns an insecure dial option if certFile is empty.
+func clientTLSOptions(certFile string, keyFile string, caFile string) ([]grpc.DialOption, error) {
+	if certFile == "" {
+		return []grpc.DialOption{grpc.WithInsecure()}, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed loading tls key pair %s, %s: %v", certFile, keyFile, err)
+	}
+
+	tlsConfig := &tls.Config{Certificates: []tls.Certificate{cert}}
+	if caFile != "" {
+		ca, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed reading tls ca %s: %v", caFile, err)
+		}
+
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("failed parsing tls ca %s", caFile)
+		}
+
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	return []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))}, nil
+}
+
+// serverTLSOptions returns the server options that serve TLS with the key pair of certFile and keyFile,
+// and verify client certificates using the CA of clientCAFile, if set.
+// Returns no options if certFile is empty.
+func serverTLSOptions(certFile string, keyFile string, clientCAFile string) ([]grpc.ServerOption, error) {
+	if certFile == "" {
+		return nil, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed loading tls key pair %s, %s: %v", certFile, keyFile, err)
+	}
+
+	tlsConfig := &tls.Config{Certificates: []tls.Certificate{cert}}
+	if clientCAFile != "" {
+		clientCA, err := ioutil.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed reading tls client ca %s: %v", clientCAFile, err)
+		}
+
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(clientCA) {
+			return nil, fmt.Errorf("failed parsing tls client ca %s", clientCAFile)
+		}
+
+		tlsConfig.ClientCAs = clientCAs
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return []grpc.ServerOption{grpc.Creds(credentials.NewTLS(tlsConfig))}, nil
+}
+
+// serveMetrics serves the expvar metrics over http on /debug/vars of port, which are every expvar variable
+// that the packages of the service publish, such as the counters of the service package,
+// and the version of the running build on /version, until ctx is done, or returns an error if it fails.
+func serveMetrics(ctx context.Context, logger *logrus.Logger, port string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/debug/vars", expvar.Handler())
+	mux.HandleFunc("/version", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(service.CurrentBuildInfo()); err != nil {
+			logger.Errorf("failed writing the build info: %v", err)
+		}
+	})
+
+	metricsServer := &http.Server{Addr: ":" + port, Handler: mux}
+	go func() {
+		<-ctx.Done()
+		_ = metricsServer.Close()
+	}()
+
+	logger.Infof("serving metrics on port %s", port)
+	if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return fmt.Errorf("failed serving metrics: %v", err)
+	}
+
+	return nil
+}
+
+// loggerMiddleware returns the middleware of the logger's interceptors, which trace the unary requests
+// to the elastic APM, and log the requests with their trace ids, and the payloads of the methods
+// that aren't ignored by ELASTIC_APM_IGNORE_URLS.
+func loggerMiddleware(logger *logrus.Logger) service.Middleware {
+	logrusEntry := logrus.NewEntry(logger)
+	ignoredMethods := viper.GetString(configElasticAPMIgnoreURLS)
+	ignorePayload := ilogger.IgnoreServerMethodsDecider(strings.Split(ignoredMethods, ",")...)
+	logPayload := func(ctx context.Context, fullMethodName string, servingObject interface{}) bool {
+		return ignorePayload(fullMethodName)
+	}
 
 	// Shared options for the logger, with a custom gRPC code to log level function.
 	loggerOpts := []grpc_logrus.Option{
@@ -207,25 +1494,119 @@ func serverLoggerInterceptor(logger *logrus.Logger) []grpc.ServerOption {
 		grpc_logrus.WithLevels(grpc_logrus.DefaultCodeToLevel),
 	}
 
-	return ilogger.ElasticsearchLoggerServerInterceptor(
-		logrusEntry,
-		ignorePayload,
-		ignoreInitialRequest,
-		loggerOpts...,
+	// The entries of each request are logged with its trace id.
+	traceEntry := func(ctx context.Context) *logrus.Entry {
+		return logrusEntry.WithField("trace.id", ilogger.ExtractTraceParent(ctx))
+	}
+
+	unary := grpc_middleware.ChainUnaryServer(
+		apmgrpc.NewUnaryServerInterceptor(
+			apmgrpc.WithRecovery(),
+			apmgrpc.WithServerRequestIgnorer(apmgrpc.NewRegexpRequestIgnorer(regexp.MustCompile(ignoredMethods))),
+		),
+		func(
+			ctx context.Context,
+			req interface{},
+			info *grpc.UnaryServerInfo,
+			handler grpc.UnaryHandler,
+		) (interface{}, error) {
+			entry := traceEntry(ctx)
+			return grpc_middleware.ChainUnaryServer(
+				grpc_logrus.UnaryServerInterceptor(entry, loggerOpts...),
+				grpc_logrus.PayloadUnaryServerInterceptor(entry, logPayload),
+			)(ctx, req, info, handler)
+		},
 	)
+
+	stream := grpc_middleware.ChainStreamServer(
+		grpc_ctxtags.StreamServerInterceptor(
+			grpc_ctxtags.WithFieldExtractorForInitialReq(ilogger.RequestExtractor(logrusEntry, ignorePayload)),
+		),
+		func(
+			srv interface{},
+			stream grpc.ServerStream,
+			info *grpc.StreamServerInfo,
+			handler grpc.StreamHandler,
+		) error {
+			entry := traceEntry(stream.Context())
+			return grpc_middleware.ChainStreamServer(
+				grpc_logrus.StreamServerInterceptor(entry, loggerOpts...),
+				grpc_logrus.PayloadStreamServerInterceptor(entry, logPayload),
+			)(srv, stream, info, handler)
+		},
+	)
+
+	return service.Middleware{Unary: unary, Stream: stream}
 }
 
-// healthCheckWorker is running an infinite loop that sets the serving status once
-// in s.healthCheckInterval seconds.
-func (s PermissionServer) healthCheckWorker(healthServer *health.Server) {
-	mongoClientPingTimeout := viper.GetDuration(configMongoClientPingTimeout)
-	for {
-		if s.permissionService.HealthCheck(mongoClientPingTimeout * time.Second) {
+// healthCheckWorker is running an infinite loop that sets the serving status whenever the dampened
+// health checks of s.healthChecker change it, after the service is warmed up, until ctx is done.
+func (s PermissionServer) healthCheckWorker(ctx context.Context, healthServer *health.Server) {
+	if !s.warmUp(ctx) {
+		return
+	}
+
+	s.healthChecker.Run(ctx, func(serving bool) {
+		if serving {
 			healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
 		} else {
 			healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
 		}
+	})
+}
+
+// selfTestWorker runs selfTest once the instance is serving, and sets its outcome as the serving status
+// of service.SelfTestHealthService. A failed self-test doesn't stop the server, so that it can be investigated.
+func (s PermissionServer) selfTestWorker(
+	ctx context.Context,
+	healthServer *health.Server,
+	selfTest service.SelfTest,
+) {
+	for {
+		response, err := healthServer.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+		if err == nil && response.GetStatus() == grpc_health_v1.HealthCheckResponse_SERVING {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second * time.Duration(s.healthCheckInterval)):
+		}
+	}
+
+	if err := selfTest.Run(ctx); err != nil {
+		return
+	}
+
+	healthServer.SetServingStatus(service.SelfTestHealthService, grpc_health_v1.HealthCheckResponse_SERVING)
+}
+
+// EnableSelfTest enables the self-test of the servers that are created, as if `SELF_TEST` was set.
+func EnableSelfTest() {
+	viper.Set(configSelfTest, true)
+}
+
+// warmUp verifies the indexes and warms up the permissions of the configured hot files,
+// retrying once in s.healthCheckInterval seconds until it succeeds, and returns true,
+// or false if ctx is done first.
+func (s PermissionServer) warmUp(ctx context.Context) bool {
+	fileIDs := []string{}
+	for _, fileID := range strings.Split(viper.GetString(configWarmUpFiles), ",") {
+		if fileID = strings.TrimSpace(fileID); fileID != "" {
+			fileIDs = append(fileIDs, fileID)
+		}
+	}
 
-		time.Sleep(time.Second * time.Duration(s.healthCheckInterval))
+	timeout := viper.GetDuration(configWarmUpTimeout) * time.Second
+	for !s.permissionService.WarmUp(timeout, fileIDs) {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(time.Second * time.Duration(s.healthCheckInterval)):
+		}
 	}
+
+	s.logger.Infof("warmed up the permissions of %d files", len(fileIDs))
+	return true
 }
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	pbv2 "github.com/meateam/permission-service/proto/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetFileSharingActivity is the request handler for the feed of the changes to the sharing of a file.
+func (s ServiceV2) GetFileSharingActivity(
+	ctx context.Context,
+	req *pbv2.GetFileSharingActivityRequest,
+) (*pbv2.GetFileSharingActivityResponse, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	resourceType, fileID, err := parseResourceName(req.GetResource())
+	if err != nil {
+		return nil, err
+	}
+
+	var since time.Time
+	if req.GetSince() != nil {
+		if since, err = ptypes.Timestamp(req.GetSince()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid since: %v", err)
+		}
+	}
+
+	pageSize := int(req.GetPageSize())
+	if pageSize < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page_size must not be negative")
+	}
+
+	if pageSize == 0 {
+		pageSize = DefaultPageSize
+	}
+
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	if err := s.actors.AuthorizePermissionRead(ctx, s.controller, resourceType, fileID); err != nil {
+		return nil, err
+	}
+
+	events, nextPageToken, err := s.controller.GetFileSharingActivity(
+		ctx,
+		resourceType,
+		fileID,
+		since,
+		pageSize,
+		req.GetPageToken(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pbv2.GetFileSharingActivityResponse{
+		Activities:    make([]*pbv2.SharingActivity, 0, len(events)),
+		NextPageToken: nextPageToken,
+	}
+	for _, event := range events {
+		activity, err := sharingActivity(event)
+		if err != nil {
+			return nil, err
+		}
+
+		if activity != nil {
+			response.Activities = append(response.Activities, activity)
+		}
+	}
+
+	return response, nil
+}
+
+// sharingActivity returns the activity that event renders as, or nil if it isn't a change
+// to the sharing of its file.
+func sharingActivity(event PermissionEvent) (*pbv2.SharingActivity, error) {
+	occurredAt, err := TimestampProto(event.OccurredAt)
+	if err != nil {
+		return nil, err
+	}
+
+	granteeType, _ := granteeTypeOrDefault(event.Permission.GetGranteeType())
+	activity := &pbv2.SharingActivity{
+		Id:          event.ID,
+		OccurredAt:  occurredAt,
+		ActorId:     event.Permission.GetCreator(),
+		GranteeId:   event.Permission.GetUserID(),
+		GranteeType: granteeType,
+		Role:        pbv2.Role(event.Permission.GetRole()),
+	}
+
+	switch event.Type {
+	case EventCreated:
+		activity.Kind = pbv2.SharingActivity_GRANTED
+	case EventUpdated:
+		activity.Kind = pbv2.SharingActivity_UPDATED
+		if event.Previous != nil {
+			activity.PreviousRole = pbv2.Role(event.Previous.GetRole())
+			if event.Previous.GetRole() != event.Permission.GetRole() {
+				activity.Kind = pbv2.SharingActivity_ROLE_CHANGED
+			}
+		}
+	case EventDeleted:
+		activity.Kind = pbv2.SharingActivity_REVOKED
+	case EventSharingStopped:
+		activity.Kind = pbv2.SharingActivity_SHARING_STOPPED
+		activity.RevokedGranteeIds = make([]string, 0, len(event.Revoked))
+		for _, revoked := range event.Revoked {
+			activity.RevokedGranteeIds = append(activity.RevokedGranteeIds, revoked.GetUserID())
+		}
+	default:
+		return nil, nil
+	}
+
+	return activity, nil
+}
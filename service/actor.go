@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	pb "github.com/meateam/permission-service/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// ActorHeader is the grpc metadata key of the identity of the end-user that the API gateway
+	// forwards the request on behalf of, which is the actor of the request.
+	ActorHeader = "x-forwarded-user"
+
+	// MaxActorLength is the maximum length of a forwarded actor.
+	MaxActorLength = 256
+
+	// authorizationHeader is the grpc metadata key of the bearer token of the end-user.
+	authorizationHeader = "authorization"
+
+	// bearerPrefix is the prefix of a bearer token in the authorization header.
+	bearerPrefix = "Bearer "
+)
+
+// TokenVerifier verifies the tokens of end-users.
+type TokenVerifier interface {
+	// Verify verifies token and returns the subject it was issued to, or an error if it's invalid.
+	Verify(ctx context.Context, token string) (string, error)
+}
+
+// actorKey is the context key of the actor of a request.
+type actorKey struct{}
+
+// ActorPolicy extracts and validates the forwarded actors of requests. Its middleware authenticates
+// the requests, and the handlers of the mutations require their actors with it.
+type ActorPolicy struct {
+	// Required is whether mutations without an actor are rejected.
+	Required bool
+
+	// Gateways are the common names of the verified callers that may forward actors in ActorHeader,
+	// or AnyCaller to accept the actors that every caller forwards. The actors of verified bearer tokens
+	// are accepted from every caller.
+	Gateways map[string]bool
+
+	// Tokens verifies the bearer tokens of the end-users, if set. The actor is then the subject
+	// of the verified token, and a forwarded actor is only accepted if it's the same.
+	Tokens TokenVerifier
+
+	// AuthorizePermissionReads is whether the permissions of a file are only listed to actors
+	// whose permission to the file grants VIEW_PERMISSIONS, such as its writers and auditors.
+	// Requests without an actor aren't checked.
+	AuthorizePermissionReads bool
+}
+
+// ParseActorGateways parses the gateways of an ActorPolicy of the form "caller,caller", such as "api-gateway",
+// an empty list is valid.
+func ParseActorGateways(gateways string) map[string]bool {
+	parsed := map[string]bool{}
+	for _, gateway := range strings.Split(gateways, ",") {
+		if gateway = strings.TrimSpace(gateway); gateway != "" {
+			parsed[gateway] = true
+		}
+	}
+
+	return parsed
+}
+
+// Middleware returns the middleware that authenticates the requests with Authenticate, whose actors
+// are available to the handlers with ActorFromContext.
+func (p ActorPolicy) Middleware() Middleware {
+	unary := func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		ctx, err := p.Authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+
+	stream := func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, err := p.Authenticate(stream.Context())
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, contextServerStream{ServerStream: stream, ctx: ctx})
+	}
+
+	return Middleware{Unary: unary, Stream: stream}
+}
+
+// Authenticate returns ctx with the actor that's forwarded in its metadata, or that its bearer token
+// is verified to be issued to if p.Tokens is set, if it has one.
+// Returns an InvalidArgument error if the forwarded actor is invalid, a PermissionDenied error
+// if it's forwarded by a caller that isn't a gateway, or an Unauthenticated error if the token is invalid.
+func (p ActorPolicy) Authenticate(ctx context.Context) (context.Context, error) {
+	actor, err := forwardedActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if caller := CallerFromContext(ctx); actor != "" && !p.Gateways[caller] && !p.Gateways[AnyCaller] {
+		return nil, RejectionError(
+			codes.PermissionDenied,
+			Rejection{Kind: RejectionPolicy, Rule: "actor_gateways", Subject: caller},
+			"caller %q may not forward %s",
+			caller,
+			ActorHeader,
+		)
+	}
+
+	if p.Tokens != nil {
+		if actor, err = p.verifiedActor(ctx, actor); err != nil {
+			return nil, err
+		}
+	}
+
+	if actor == "" {
+		return ctx, nil
+	}
+
+	return context.WithValue(ctx, actorKey{}, actor), nil
+}
+
+// RequireActor returns an Unauthenticated error if actors are required and ctx has none,
+// for the mutations, otherwise returns nil.
+func (p ActorPolicy) RequireActor(ctx context.Context) error {
+	if p.Required && ActorFromContext(ctx) == "" {
+		return status.Errorf(codes.Unauthenticated, "%s is required", ActorHeader)
+	}
+
+	return nil
+}
+
+// AuthorizePermissionRead returns a PermissionDenied error if p.AuthorizePermissionReads is set
+// and the actor of ctx has no permission to fileID that grants VIEW_PERMISSIONS, otherwise returns nil.
+func (p ActorPolicy) AuthorizePermissionRead(
+	ctx context.Context,
+	controller Controller,
+	resourceType string,
+	fileID string,
+) error {
+	actor := ActorFromContext(ctx)
+	if !p.AuthorizePermissionReads || actor == "" {
+		return nil
+	}
+
+	permission, err := controller.GetByFileAndUser(ctx, resourceType, fileID, actor)
+	if status.Code(err) == codes.NotFound {
+		return status.Errorf(codes.PermissionDenied, "%s may not view the permissions of %s", actor, fileID)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if !HasCapability(permission.GetResourceKind(), permission.GetRole(), pb.Capability_VIEW_PERMISSIONS) {
+		return status.Errorf(codes.PermissionDenied, "%s may not view the permissions of %s", actor, fileID)
+	}
+
+	return nil
+}
+
+// verifiedActor returns the subject of the verified bearer token of ctx, or an empty string if there's none.
+// Returns an Unauthenticated error if the token is invalid, or if forwarded isn't empty and isn't its subject.
+func (p ActorPolicy) verifiedActor(ctx context.Context, forwarded string) (string, error) {
+	var token string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(authorizationHeader); len(values) > 0 {
+			if !strings.HasPrefix(values[0], bearerPrefix) {
+				return "", status.Error(codes.Unauthenticated, "authorization must be a bearer token")
+			}
+
+			token = strings.TrimPrefix(values[0], bearerPrefix)
+		}
+	}
+
+	if token == "" {
+		return "", nil
+	}
+
+	subject, err := p.Tokens.Verify(ctx, token)
+	if err != nil {
+		return "", status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
+	}
+
+	if forwarded != "" && forwarded != subject {
+		return "", status.Errorf(codes.Unauthenticated, "%s isn't the subject of the token", ActorHeader)
+	}
+
+	return subject, nil
+}
+
+// forwardedActor returns the actor in the metadata of ctx, an empty string if it's not forwarded,
+// or an InvalidArgument error if it's invalid.
+func forwardedActor(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", nil
+	}
+
+	values := md.Get(ActorHeader)
+	switch {
+	case len(values) == 0:
+		return "", nil
+	case len(values) > 1:
+		return "", status.Errorf(codes.InvalidArgument, "%s must be forwarded once", ActorHeader)
+	}
+
+	actor := values[0]
+	if actor == "" || len(actor) > MaxActorLength {
+		return "", status.Errorf(codes.InvalidArgument, "%s must be 1 to %d characters", ActorHeader, MaxActorLength)
+	}
+
+	for _, r := range actor {
+		if unicode.IsSpace(r) || !unicode.IsPrint(r) {
+			return "", status.Errorf(codes.InvalidArgument, "%s has invalid characters", ActorHeader)
+		}
+	}
+
+	return actor, nil
+}
+
+// authorizeCreator returns a PermissionDenied error if ctx has an actor that isn't creator, so that
+// permissions are only created on behalf of the actor that the request is authenticated as, otherwise returns nil.
+func authorizeCreator(ctx context.Context, creator string) error {
+	if actor := ActorFromContext(ctx); actor != "" && actor != creator {
+		return RejectionError(
+			codes.PermissionDenied,
+			Rejection{Kind: RejectionPolicy, Rule: "creator_actor", Subject: actor},
+			"%s may not create permissions on behalf of %s",
+			actor,
+			creator,
+		)
+	}
+
+	return nil
+}
+
+// ActorFromContext returns the actor of the request of ctx, or an empty string if it has none.
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
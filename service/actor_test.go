@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestActorPolicyMiddleware(t *testing.T) {
+	forwarded := metadata.NewIncomingContext(context.Background(), metadata.Pairs(ActorHeader, "user"))
+	tests := []struct {
+		name   string
+		policy ActorPolicy
+		ctx    context.Context
+		actor  string
+		code   codes.Code
+	}{
+		{name: "no actor", policy: ActorPolicy{}, ctx: context.Background()},
+		{name: "forwarded by a gateway", policy: ActorPolicy{Gateways: ParseActorGateways("*")}, ctx: forwarded,
+			actor: "user"},
+		{name: "forwarded by another caller", policy: ActorPolicy{Gateways: ParseActorGateways("api-gateway")},
+			ctx: forwarded, code: codes.PermissionDenied},
+		{
+			name:   "invalid actor",
+			policy: ActorPolicy{Gateways: ParseActorGateways("*")},
+			ctx:    metadata.NewIncomingContext(context.Background(), metadata.Pairs(ActorHeader, "an actor")),
+			code:   codes.InvalidArgument,
+		},
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+	for _, test := range tests {
+		var actor string
+		_, err := test.policy.Middleware().Unary(test.ctx, nil, info, func(ctx context.Context, _ interface{}) (
+			interface{},
+			error,
+		) {
+			actor = ActorFromContext(ctx)
+			return nil, nil
+		})
+
+		if status.Code(err) != test.code {
+			t.Errorf("%s: expected the code %s, got %v", test.name, test.code, err)
+		}
+
+		if actor != test.actor {
+			t.Errorf("%s: expected the handler's actor to be %q, got %q", test.name, test.actor, actor)
+		}
+	}
+}
+
+func TestActorPolicyRequireActor(t *testing.T) {
+	withActor := context.WithValue(context.Background(), actorKey{}, "user")
+	required := ActorPolicy{Required: true}
+
+	if err := required.RequireActor(withActor); err != nil {
+		t.Errorf("expected a request with an actor to be allowed, got %v", err)
+	}
+
+	if err := required.RequireActor(context.Background()); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected a request without an actor to be Unauthenticated, got %v", err)
+	}
+
+	if err := (ActorPolicy{}).RequireActor(context.Background()); err != nil {
+		t.Errorf("expected a request without an actor to be allowed if actors aren't required, got %v", err)
+	}
+}
@@ -0,0 +1,1100 @@
+package service
+
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	pb "github.com/meateam/permission-service/proto"
+	pbv2 "github.com/meateam/permission-service/proto/v2"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultMigrationBatchSize is the batch size of MigrateRole if not specified.
+	DefaultMigrationBatchSize = 1000
+
+	// ImportProgressInterval is the number of permissions that ImportPermissions receives between progresses.
+	ImportProgressInterval = 100
+)
+
+// AdminService is a structure used for handling the Permissions Admin grpc requests.
+type AdminService struct {
+	controller Controller
+	logger     *logrus.Logger
+
+	// importRateLimit is the maximum number of permissions written per second by each import,
+	// imports aren't limited if it's 0.
+	importRateLimit int
+
+	// domainGrants limits the organizations that imported permissions may be given to.
+	domainGrants DomainGrantPolicy
+
+	// limits limits the size of the requests, and of each imported record.
+	limits RequestLimits
+
+	// anomalies is the detector whose alerts are listed, none are listed if it's nil.
+	anomalies *AnomalyDetector
+
+	// files is the file service that the owners of locked files are read from, if set.
+	files FileMetadata
+
+	// jobs runs the jobs of bulk operations, jobs may not be created if it's nil.
+	jobs *JobRunner
+
+	// lastKnown holds the last-known permissions of the prewarmed files.
+	lastKnown LastKnownPermissions
+
+	// rates enforces the rate limits of the service's tenant, which are applied as soon as they're set if it's set.
+	rates *RateLimiter
+}
+
+// WithJobRunner returns a copy of the service that runs the jobs it creates with jobs.
+func (s AdminService) WithJobRunner(jobs *JobRunner) AdminService {
+	s.jobs = jobs
+	return s
+}
+
+// WithFileMetadata returns a copy of the service that reads the owners of the files it locks down from files.
+func (s AdminService) WithFileMetadata(files FileMetadata) AdminService {
+	s.files = files
+	return s
+}
+
+// WithAnomalyDetector returns a copy of the service that lists the alerts of anomalies.
+func (s AdminService) WithAnomalyDetector(anomalies *AnomalyDetector) AdminService {
+	s.anomalies = anomalies
+	return s
+}
+
+// WithRequestLimits returns a copy of the service that rejects requests that exceed limits.
+func (s AdminService) WithRequestLimits(limits RequestLimits) AdminService {
+	s.limits = limits
+	return s
+}
+
+// NewAdminService creates an AdminService and returns it. importRateLimit is the maximum
+// number of permissions written per second by each import, imports aren't limited if it's 0.
+// domainGrants limits the organizations that imported permissions may be given to.
+func NewAdminService(
+	controller Controller,
+	logger *logrus.Logger,
+	importRateLimit int,
+	domainGrants DomainGrantPolicy,
+) AdminService {
+	return AdminService{
+		controller:      controller,
+		logger:          logger,
+		importRateLimit: importRateLimit,
+		domainGrants:    domainGrants,
+	}
+}
+
+// MigrateRole is the request handler for migrating the role of permissions, it streams the
+// progress of the migration after each batch.
+func (s AdminService) MigrateRole(
+	req *pbv2.MigrateRoleRequest,
+	stream pbv2.PermissionsAdmin_MigrateRoleServer,
+) error {
+	if err := s.limits.Check(stream.Context(), req); err != nil {
+		return err
+	}
+
+	migration, err := s.parseRoleMigration(req)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Infof("migrating role %s to %s", migration.fromRole, migration.toRole)
+	return s.controller.MigrateRole(
+		stream.Context(),
+		migration.fromRole,
+		migration.toRole,
+		migration.filter,
+		migration.batchSize,
+		func(migrated int64, total int64) error {
+			return stream.Send(&pbv2.MigrateRoleProgress{Migrated: migrated, Total: total})
+		},
+	)
+}
+
+// roleMigration is a migration of the role of the permissions that match filter from fromRole to toRole,
+// batchSize permissions at a time.
+type roleMigration struct {
+	fromRole  pb.Role
+	toRole    pb.Role
+	filter    PermissionsFilter
+	batchSize int
+}
+
+// parseRoleMigration returns the roleMigration of req, or an InvalidArgument error if it's invalid.
+func (s AdminService) parseRoleMigration(req *pbv2.MigrateRoleRequest) (roleMigration, error) {
+	if err := s.limits.CheckList("filter.file_ids", len(req.GetFilter().GetFileIds())); err != nil {
+		return roleMigration{}, err
+	}
+
+	if err := s.limits.CheckList("filter.user_ids", len(req.GetFilter().GetUserIds())); err != nil {
+		return roleMigration{}, err
+	}
+
+	fromRole := pb.Role(req.GetFromRole())
+	toRole := pb.Role(req.GetToRole())
+	if pb.Role_name[int32(fromRole)] == "" || fromRole == pb.Role_NONE {
+		return roleMigration{}, status.Error(codes.InvalidArgument, "from_role does not exist")
+	}
+
+	if pb.Role_name[int32(toRole)] == "" || toRole == pb.Role_NONE {
+		return roleMigration{}, status.Error(codes.InvalidArgument, "to_role does not exist")
+	}
+
+	if fromRole == toRole {
+		return roleMigration{}, status.Error(codes.InvalidArgument, "from_role and to_role must be different")
+	}
+
+	batchSize := int(req.GetBatchSize())
+	if batchSize < 0 {
+		return roleMigration{}, status.Error(codes.InvalidArgument, "batch_size must not be negative")
+	}
+
+	if batchSize == 0 {
+		batchSize = DefaultMigrationBatchSize
+	}
+
+	selector, err := parseSelector(req.GetFilter().GetLabels(), req.GetFilter().GetSource())
+	if err != nil {
+		return roleMigration{}, status.Errorf(codes.InvalidArgument, "filter.%v", err)
+	}
+
+	return roleMigration{
+		fromRole: fromRole,
+		toRole:   toRole,
+		filter: PermissionsFilter{
+			ResourceType: req.GetFilter().GetResourceType(),
+			FileIDs:      req.GetFilter().GetFileIds(),
+			UserIDs:      req.GetFilter().GetUserIds(),
+			Creator:      req.GetFilter().GetCreator(),
+			Labels:       selector.Labels,
+			Source:       selector.Source,
+		},
+		batchSize: batchSize,
+	}, nil
+}
+
+// ImportPermissions is the request handler for importing permissions. It creates the permission of each
+// request it receives, at up to s.importRateLimit permissions per second, and streams the progress of the
+// import after every ImportProgressInterval requests and when the client closes the stream.
+// The outcome of each permission is reported in the progress, and a permission that fails to import
+// is reported in the progress and doesn't stop the import.
+func (s AdminService) ImportPermissions(stream pbv2.PermissionsAdmin_ImportPermissionsServer) error {
+	ctx := stream.Context()
+
+	// A nil limiter never blocks the writes.
+	var limiter <-chan time.Time
+	if s.importRateLimit > 0 {
+		ticker := time.NewTicker(time.Second / time.Duration(s.importRateLimit))
+		defer ticker.Stop()
+		limiter = ticker.C
+	}
+
+	progress := &pbv2.ImportPermissionsProgress{}
+	for index := int64(0); ; index++ {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			s.logger.Infof("imported %d permissions, %d rejected", progress.GetAccepted(), progress.GetRejected())
+			return stream.Send(progress)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if limiter != nil {
+			select {
+			case <-ctx.Done():
+				return status.Error(codes.Canceled, ctx.Err().Error())
+			case <-limiter:
+			}
+		}
+
+		outcome, err := s.importPermission(ctx, req, pbv2.ConflictPolicy_CONFLICT_POLICY_UNSPECIFIED)
+		if err != nil {
+			progress.Rejected++
+			progress.Errors = append(progress.Errors, &pbv2.ImportPermissionsProgress_RecordError{
+				Index:   index,
+				Code:    int32(status.Code(err)),
+				Message: status.Convert(err).Message(),
+			})
+		} else {
+			progress.Accepted++
+			progress.Results = append(progress.Results, &pbv2.ImportPermissionsProgress_RecordResult{
+				Index:   index,
+				Outcome: outcome,
+			})
+		}
+
+		if (index+1)%ImportProgressInterval == 0 {
+			if err := stream.Send(progress); err != nil {
+				return err
+			}
+
+			progress.Errors = nil
+			progress.Results = nil
+		}
+	}
+}
+
+// importPermission creates the permission of req, resolves its conflict with an existing permission by
+// its conflict policy, or by defaultPolicy if it has none, and returns what was done with it.
+func (s AdminService) importPermission(
+	ctx context.Context,
+	req *pbv2.ImportPermissionsRequest,
+	defaultPolicy pbv2.ConflictPolicy,
+) (pbv2.ImportPermissionsProgress_Outcome, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return 0, err
+	}
+
+	resourceType, fileID, err := parseResourceName(req.GetParent())
+	if err != nil {
+		return 0, err
+	}
+
+	permission := req.GetPermission()
+	if permission == nil {
+		return 0, status.Error(codes.InvalidArgument, "permission is required")
+	}
+
+	if permission.GetUserId() == "" {
+		return 0, status.Error(codes.InvalidArgument, "permission.user_id is required")
+	}
+
+	if err := validatePermissionV2(permission); err != nil {
+		return 0, err
+	}
+
+	if permission.GetCreator() == "" {
+		return 0, status.Error(codes.InvalidArgument, "permission.creator is required")
+	}
+
+	resourceKind, ok := resourceKindOrDefault(permission.GetResourceKind())
+	if !ok {
+		return 0, status.Error(codes.InvalidArgument, "permission.resource_kind does not exist")
+	}
+
+	granteeType, ok := granteeTypeOrDefault(permission.GetGranteeType())
+	if !ok {
+		return 0, status.Error(codes.InvalidArgument, "permission.grantee_type does not exist")
+	}
+
+	if granteeType == GranteeTypeDomain {
+		if err := s.domainGrants.Authorize(permission.GetUserId()); err != nil {
+			return 0, err
+		}
+	}
+
+	source, err := sourceOrDefault(permission.GetSource(), SourceImport)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "permission.%v", err)
+	}
+
+	policy := req.GetConflictPolicy()
+	if pbv2.ConflictPolicy_name[int32(policy)] == "" {
+		return 0, status.Error(codes.InvalidArgument, "conflict_policy does not exist")
+	}
+
+	role := pb.Role(permission.GetRole())
+	outcome, err := s.importOutcome(ctx, resourceType, fileID, permission.GetUserId(), role,
+		conflictPolicyOrDefault(policy, defaultPolicy, req.GetOverride()))
+	if err != nil || outcome == pbv2.ImportPermissionsProgress_SKIPPED {
+		return outcome, err
+	}
+
+	_, err = s.controller.CreatePermission(
+		ctx,
+		resourceType,
+		fileID,
+		permission.GetUserId(),
+		role,
+		permission.GetCreator(),
+		outcome != pbv2.ImportPermissionsProgress_CREATED,
+		permission.GetCanReshare(),
+		permission.GetMessage(),
+		permission.GetLabel(),
+		resourceKind,
+		granteeType,
+		permission.GetLabels(),
+		source,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return outcome, nil
+}
+
+// conflictPolicyOrDefault returns policy, or defaultPolicy if policy is unspecified, or else REPLACE
+// if override is set and SKIP if it isn't, as the override flag preceded the conflict policies.
+func conflictPolicyOrDefault(
+	policy pbv2.ConflictPolicy,
+	defaultPolicy pbv2.ConflictPolicy,
+	override bool,
+) pbv2.ConflictPolicy {
+	switch {
+	case policy != pbv2.ConflictPolicy_CONFLICT_POLICY_UNSPECIFIED:
+		return policy
+	case defaultPolicy != pbv2.ConflictPolicy_CONFLICT_POLICY_UNSPECIFIED:
+		return defaultPolicy
+	case override:
+		return pbv2.ConflictPolicy_REPLACE
+	default:
+		return pbv2.ConflictPolicy_SKIP
+	}
+}
+
+// importOutcome returns what importing a permission of userID to fileID with role does by policy,
+// given the permission userID already has, if any.
+func (s AdminService) importOutcome(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+	role pb.Role,
+	policy pbv2.ConflictPolicy,
+) (pbv2.ImportPermissionsProgress_Outcome, error) {
+	existing, err := s.controller.GetByFileAndUser(ctx, resourceType, fileID, userID)
+	if status.Code(err) == codes.NotFound {
+		return pbv2.ImportPermissionsProgress_CREATED, nil
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	switch policy {
+	case pbv2.ConflictPolicy_REPLACE:
+		return pbv2.ImportPermissionsProgress_REPLACED, nil
+	case pbv2.ConflictPolicy_MERGE_HIGHEST_ROLE:
+		// Roles that don't grant each other, such as READ and UPLOADER, keep the existing role.
+		if existing.GetRole() != role && IsSubRole(role, existing.GetRole()) {
+			return pbv2.ImportPermissionsProgress_MERGED, nil
+		}
+
+		return pbv2.ImportPermissionsProgress_SKIPPED, nil
+	default:
+		return pbv2.ImportPermissionsProgress_SKIPPED, nil
+	}
+}
+
+// GenerateUserDataReport is the request handler for reporting the data that's stored about a user,
+// it streams the permissions the user holds, then the permissions the user created and then the audit
+// entries that reference the user.
+func (s AdminService) GenerateUserDataReport(
+	req *pbv2.GenerateUserDataReportRequest,
+	stream pbv2.PermissionsAdmin_GenerateUserDataReportServer,
+) error {
+	if err := s.limits.Check(stream.Context(), req); err != nil {
+		return err
+	}
+
+	userID := req.GetUserId()
+	if userID == "" {
+		return status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	userData, err := s.controller.GetUserData(stream.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	for _, permission := range userData.Held {
+		permissionV2, err := marshalPermissionV2(permission)
+		if err != nil {
+			return err
+		}
+
+		record := &pbv2.UserDataRecord{Record: &pbv2.UserDataRecord_HeldPermission{HeldPermission: permissionV2}}
+		if err := stream.Send(record); err != nil {
+			return err
+		}
+	}
+
+	for _, permission := range userData.Created {
+		permissionV2, err := marshalPermissionV2(permission)
+		if err != nil {
+			return err
+		}
+
+		record := &pbv2.UserDataRecord{
+			Record: &pbv2.UserDataRecord_CreatedPermission{CreatedPermission: permissionV2},
+		}
+		if err := stream.Send(record); err != nil {
+			return err
+		}
+	}
+
+	for _, event := range userData.Events {
+		occurredAt, err := TimestampProto(event.OccurredAt)
+		if err != nil {
+			return err
+		}
+
+		auditEntry := &pbv2.AuditEntry{
+			Id:         event.ID,
+			Type:       string(event.Type),
+			Permission: convertPermissionV2(event.Permission),
+			OccurredAt: occurredAt,
+		}
+
+		record := &pbv2.UserDataRecord{Record: &pbv2.UserDataRecord_AuditEntry{AuditEntry: auditEntry}}
+		if err := stream.Send(record); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// EraseUserData is the request handler for erasing the data that's stored about a user.
+func (s AdminService) EraseUserData(
+	ctx context.Context,
+	req *pbv2.EraseUserDataRequest,
+) (*pbv2.EraseUserDataResponse, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	userID := req.GetUserId()
+	if userID == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	report, err := s.controller.EraseUserData(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Infof(
+		"erased user data, %d permissions deleted, %d anonymized, %d audit entries retained",
+		report.Deleted,
+		report.Anonymized,
+		report.RetainedEvents,
+	)
+
+	return &pbv2.EraseUserDataResponse{
+		DeletedPermissions:    report.Deleted,
+		AnonymizedPermissions: report.Anonymized,
+		RetainedAuditEntries:  report.RetainedEvents,
+	}, nil
+}
+
+// ListDomainPermissions is the request handler for listing the permissions that were given to organizations.
+func (s AdminService) ListDomainPermissions(
+	ctx context.Context,
+	req *pbv2.ListDomainPermissionsRequest,
+) (*pbv2.ListDomainPermissionsResponse, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	pageSize := int(req.GetPageSize())
+	if pageSize < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page_size must not be negative")
+	}
+
+	if pageSize == 0 {
+		pageSize = DefaultPageSize
+	}
+
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	permissions, nextPageToken, err := s.controller.ListDomainPermissions(
+		ctx,
+		req.GetDomain(),
+		pageSize,
+		req.GetPageToken(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pbv2.ListDomainPermissionsResponse{
+		Permissions:   make([]*pbv2.Permission, 0, len(permissions)),
+		NextPageToken: nextPageToken,
+	}
+	for _, permission := range permissions {
+		permissionV2, err := marshalPermissionV2(permission)
+		if err != nil {
+			return nil, err
+		}
+
+		response.Permissions = append(response.Permissions, permissionV2)
+	}
+
+	return response, nil
+}
+
+// ListAnomalyAlerts is the request handler for listing the most recent alerts of unusual changes to permissions.
+func (s AdminService) ListAnomalyAlerts(
+	ctx context.Context,
+	req *pbv2.ListAnomalyAlertsRequest,
+) (*pbv2.ListAnomalyAlertsResponse, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	kind := AnomalyKind(req.GetKind())
+	switch kind {
+	case "", AnomalyMassGrants, AnomalyMassRevocations, AnomalyExternalSharing:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown anomaly kind %q", kind)
+	}
+
+	response := &pbv2.ListAnomalyAlertsResponse{}
+	if s.anomalies == nil {
+		return response, nil
+	}
+
+	for _, alert := range s.anomalies.Alerts(kind) {
+		detectTime, err := ptypes.TimestampProto(alert.DetectedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		response.Alerts = append(response.Alerts, &pbv2.AnomalyAlert{
+			Kind:       string(alert.Kind),
+			Subject:    alert.Subject,
+			Count:      alert.Count,
+			Window:     ptypes.DurationProto(alert.Window),
+			DetectTime: detectTime,
+		})
+	}
+
+	return response, nil
+}
+
+// LockFile is the request handler for locking a resource down, which suspends the access of every user
+// other than its owner to it.
+func (s AdminService) LockFile(ctx context.Context, req *pbv2.LockFileRequest) (*pbv2.FileLock, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	resourceType, fileID, err := parseResourceName(req.GetResource())
+	if err != nil {
+		return nil, err
+	}
+
+	owner := req.GetOwnerId()
+	if owner == "" {
+		if s.files == nil {
+			return nil, status.Error(codes.InvalidArgument, "owner_id is required without a file service")
+		}
+
+		if owner, err = s.files.GetFileOwner(ctx, fileID); err != nil {
+			return nil, err
+		}
+	}
+
+	lock, err := s.controller.LockFile(ctx, FileLock{
+		ResourceType: resourceType,
+		FileID:       fileID,
+		Owner:        owner,
+		Reason:       req.GetReason(),
+		LockedBy:     actorOrCaller(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"resourceType": resourceType,
+		"fileID":       fileID,
+		"reason":       lock.Reason,
+		"lockedBy":     lock.LockedBy,
+	}).Warn("file locked down")
+
+	return marshalFileLock(lock)
+}
+
+// UnlockFile is the request handler for lifting the lockdown of a resource.
+func (s AdminService) UnlockFile(ctx context.Context, req *pbv2.UnlockFileRequest) (*pbv2.FileLock, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	resourceType, fileID, err := parseResourceName(req.GetResource())
+	if err != nil {
+		return nil, err
+	}
+
+	lock, err := s.controller.UnlockFile(ctx, resourceType, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"resourceType": resourceType,
+		"fileID":       fileID,
+		"unlockedBy":   actorOrCaller(ctx),
+	}).Info("file unlocked")
+
+	return marshalFileLock(lock)
+}
+
+// marshalFileLock marshals lock into a v2 file lock.
+func marshalFileLock(lock FileLock) (*pbv2.FileLock, error) {
+	lockTime, err := ptypes.TimestampProto(lock.LockedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbv2.FileLock{
+		Resource: resourceCollection(lock.ResourceType) + "/" + lock.FileID,
+		OwnerId:  lock.Owner,
+		Reason:   lock.Reason,
+		LockedBy: lock.LockedBy,
+		LockTime: lockTime,
+	}, nil
+}
+
+// PlaceLegalHold is the request handler for placing a resource under legal hold, which blocks the deletion
+// and modification of its permissions and of their audit records.
+func (s AdminService) PlaceLegalHold(
+	ctx context.Context,
+	req *pbv2.PlaceLegalHoldRequest,
+) (*pbv2.LegalHold, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	resourceType, fileID, err := parseResourceName(req.GetResource())
+	if err != nil {
+		return nil, err
+	}
+
+	hold, err := s.controller.PlaceLegalHold(ctx, LegalHold{
+		ResourceType: resourceType,
+		FileID:       fileID,
+		Reason:       req.GetReason(),
+		PlacedBy:     actorOrCaller(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"resourceType": resourceType,
+		"fileID":       fileID,
+		"reason":       hold.Reason,
+		"placedBy":     hold.PlacedBy,
+	}).Info("legal hold placed")
+
+	return marshalLegalHold(hold)
+}
+
+// ReleaseLegalHold is the request handler for releasing the legal hold of a resource.
+func (s AdminService) ReleaseLegalHold(
+	ctx context.Context,
+	req *pbv2.ReleaseLegalHoldRequest,
+) (*pbv2.LegalHold, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	resourceType, fileID, err := parseResourceName(req.GetResource())
+	if err != nil {
+		return nil, err
+	}
+
+	hold, err := s.controller.ReleaseLegalHold(ctx, resourceType, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"resourceType": resourceType,
+		"fileID":       fileID,
+		"releasedBy":   actorOrCaller(ctx),
+	}).Info("legal hold released")
+
+	return marshalLegalHold(hold)
+}
+
+// marshalLegalHold marshals hold into a v2 legal hold.
+func marshalLegalHold(hold LegalHold) (*pbv2.LegalHold, error) {
+	placeTime, err := ptypes.TimestampProto(hold.PlacedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbv2.LegalHold{
+		Resource:  resourceCollection(hold.ResourceType) + "/" + hold.FileID,
+		Reason:    hold.Reason,
+		PlacedBy:  hold.PlacedBy,
+		PlaceTime: placeTime,
+	}, nil
+}
+
+// GetServerInfo is the request handler for retrieving the version of the running build of the server.
+func (s AdminService) GetServerInfo(
+	ctx context.Context,
+	req *pbv2.GetServerInfoRequest,
+) (*pbv2.ServerInfo, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	info := CurrentBuildInfo()
+	started, err := ptypes.TimestampProto(startTime)
+	if err != nil {
+		return nil, err
+	}
+
+	serverInfo := &pbv2.ServerInfo{
+		Version:   info.Version,
+		Commit:    info.Commit,
+		GoVersion: info.GoVersion,
+		StartTime: started,
+	}
+
+	// The build time is omitted if it's not set, or not an RFC 3339 time.
+	if builtAt, err := time.Parse(time.RFC3339, info.BuildTime); err == nil {
+		if serverInfo.BuildTime, err = ptypes.TimestampProto(builtAt); err != nil {
+			return nil, err
+		}
+	}
+
+	return serverInfo, nil
+}
+
+// CreateJob is the request handler for starting a job of a long-running bulk operation.
+func (s AdminService) CreateJob(ctx context.Context, req *pbv2.CreateJobRequest) (*pbv2.Job, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	if s.jobs == nil {
+		return nil, status.Error(codes.FailedPrecondition, "jobs are disabled")
+	}
+
+	var kind string
+	var operation JobFunc
+	switch op := req.GetOperation().(type) {
+	case *pbv2.CreateJobRequest_DeletePermissions:
+		kind = JobDeletePermissions
+		resources := op.DeletePermissions.GetResources()
+		if err := s.limits.CheckList("delete_permissions.resources", len(resources)); err != nil {
+			return nil, err
+		}
+
+		for _, resource := range resources {
+			if _, _, err := parseResourceName(resource); err != nil {
+				return nil, err
+			}
+		}
+
+		operation = s.deletePermissionsJob(resources)
+	case *pbv2.CreateJobRequest_ImportPermissions:
+		kind = JobImportPermissions
+		records := op.ImportPermissions.GetRecords()
+		if err := s.limits.CheckList("import_permissions.records", len(records)); err != nil {
+			return nil, err
+		}
+
+		policy := op.ImportPermissions.GetConflictPolicy()
+		if pbv2.ConflictPolicy_name[int32(policy)] == "" {
+			return nil, status.Error(codes.InvalidArgument, "import_permissions.conflict_policy does not exist")
+		}
+
+		operation = s.importPermissionsJob(records, policy)
+	case *pbv2.CreateJobRequest_MigrateRole:
+		kind = JobMigrateRole
+		migration, err := s.parseRoleMigration(op.MigrateRole)
+		if err != nil {
+			return nil, err
+		}
+
+		operation = func(ctx context.Context, progress func(JobProgress) error) error {
+			return s.controller.MigrateRole(
+				ctx,
+				migration.fromRole,
+				migration.toRole,
+				migration.filter,
+				migration.batchSize,
+				func(migrated int64, total int64) error {
+					return progress(JobProgress{Done: migrated, Total: total})
+				},
+			)
+		}
+	case *pbv2.CreateJobRequest_CollectGarbage:
+		kind = JobCollectGarbage
+		fileIDs := op.CollectGarbage.GetFileIds()
+		if err := s.limits.CheckList("collect_garbage.file_ids", len(fileIDs)); err != nil {
+			return nil, err
+		}
+
+		if s.files == nil {
+			return nil, status.Error(codes.FailedPrecondition, "garbage may not be collected without a file service")
+		}
+
+		operation = s.collectGarbageJob(fileIDs)
+	case *pbv2.CreateJobRequest_UpgradeSchema:
+		kind = JobUpgradeSchema
+		batchSize := int(op.UpgradeSchema.GetBatchSize())
+		if batchSize < 0 {
+			return nil, status.Error(codes.InvalidArgument, "upgrade_schema.batch_size must not be negative")
+		}
+
+		if batchSize == 0 {
+			batchSize = DefaultMigrationBatchSize
+		}
+
+		operation = upgradeSchemaJob(s.controller, batchSize)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "operation is required")
+	}
+
+	job, err := s.jobs.Start(ctx, kind, operation)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalJob(job)
+}
+
+// deletePermissionsJob returns the operation of a job that deletes the permissions of resources.
+// A resource whose permissions fail to be deleted is counted as failed and doesn't stop the job.
+func (s AdminService) deletePermissionsJob(resources []string) JobFunc {
+	return func(ctx context.Context, progress func(JobProgress) error) error {
+		return eachJobItem(len(resources), progress, func(i int) error {
+			resourceType, fileID, err := parseResourceName(resources[i])
+			if err != nil {
+				return err
+			}
+
+			_, err = s.controller.DeleteFilePermissions(ctx, resourceType, fileID, PermissionSelector{})
+			if err != nil {
+				s.logger.Errorf("failed deleting the permissions of %s: %v", resources[i], err)
+			}
+
+			return err
+		})
+	}
+}
+
+// importPermissionsJob returns the operation of a job that imports the permissions of records, at up to
+// s.importRateLimit permissions per second, by the conflict policy of each record or else by policy.
+// A permission that fails to import is counted as failed and doesn't stop the job.
+func (s AdminService) importPermissionsJob(
+	records []*pbv2.ImportPermissionsRequest,
+	policy pbv2.ConflictPolicy,
+) JobFunc {
+	return func(ctx context.Context, progress func(JobProgress) error) error {
+		// A nil limiter never blocks the writes.
+		var limiter <-chan time.Time
+		if s.importRateLimit > 0 {
+			ticker := time.NewTicker(time.Second / time.Duration(s.importRateLimit))
+			defer ticker.Stop()
+			limiter = ticker.C
+		}
+
+		return eachJobItem(len(records), progress, func(i int) error {
+			if limiter != nil {
+				select {
+				case <-ctx.Done():
+					return status.Error(codes.Canceled, ctx.Err().Error())
+				case <-limiter:
+				}
+			}
+
+			_, err := s.importPermission(ctx, records[i], policy)
+			return err
+		})
+	}
+}
+
+// collectGarbageJob returns the operation of a job that deletes the permissions of the files of fileIDs
+// that no longer exist in the file service. A file that fails to be checked or collected is counted
+// as failed and doesn't stop the job.
+func (s AdminService) collectGarbageJob(fileIDs []string) JobFunc {
+	return func(ctx context.Context, progress func(JobProgress) error) error {
+		return eachJobItem(len(fileIDs), progress, func(i int) error {
+			_, err := s.files.GetFileOwner(ctx, fileIDs[i])
+			if status.Code(err) == codes.NotFound {
+				_, err = s.controller.DeleteFilePermissions(ctx, DefaultResourceType, fileIDs[i], PermissionSelector{})
+			}
+
+			if err != nil {
+				s.logger.Errorf("failed collecting the permissions of file %s: %v", fileIDs[i], err)
+			}
+
+			return err
+		})
+	}
+}
+
+// eachJobItem calls process with the index of each of count items, and reports the progress after each.
+// An item whose process fails is counted as failed, the items are processed until progress fails.
+func eachJobItem(count int, progress func(JobProgress) error, process func(i int) error) error {
+	current := JobProgress{Total: int64(count)}
+	if err := progress(current); err != nil {
+		return err
+	}
+
+	for i := 0; i < count; i++ {
+		if err := process(i); err != nil {
+			current.Failed++
+		}
+
+		current.Done++
+		if err := progress(current); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetJob is the request handler for retrieving a job and its progress.
+func (s AdminService) GetJob(ctx context.Context, req *pbv2.GetJobRequest) (*pbv2.Job, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	id, err := parseJobName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := s.controller.GetJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalJob(job)
+}
+
+// ListJobs is the request handler for listing the jobs, newest first.
+func (s AdminService) ListJobs(ctx context.Context, req *pbv2.ListJobsRequest) (*pbv2.ListJobsResponse, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	pageSize := int(req.GetPageSize())
+	if pageSize < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page_size must not be negative")
+	}
+
+	if pageSize == 0 {
+		pageSize = DefaultPageSize
+	}
+
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	jobs, nextPageToken, err := s.controller.ListJobs(ctx, req.GetKind(), pageSize, req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pbv2.ListJobsResponse{Jobs: make([]*pbv2.Job, 0, len(jobs)), NextPageToken: nextPageToken}
+	for _, job := range jobs {
+		jobV2, err := marshalJob(job)
+		if err != nil {
+			return nil, err
+		}
+
+		response.Jobs = append(response.Jobs, jobV2)
+	}
+
+	return response, nil
+}
+
+// CancelJob is the request handler for requesting the cancellation of a running job.
+func (s AdminService) CancelJob(ctx context.Context, req *pbv2.CancelJobRequest) (*pbv2.Job, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	id, err := parseJobName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	var job Job
+	if s.jobs != nil {
+		job, err = s.jobs.Cancel(ctx, id)
+	} else {
+		job, err = s.controller.CancelJob(ctx, id)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"job":         id,
+		"cancelledBy": actorOrCaller(ctx),
+	}).Info("job cancellation requested")
+	return marshalJob(job)
+}
+
+// parseJobName parses a job resource name, `jobs/{job}`, and returns the job ID.
+func parseJobName(name string) (string, error) {
+	parts := strings.Split(name, "/")
+	if len(parts) != 2 || parts[0] != jobsCollection || parts[1] == "" {
+		return "", status.Errorf(codes.InvalidArgument, "invalid job name %q", name)
+	}
+
+	return parts[1], nil
+}
+
+// jobStates maps the states of jobs to their states in the API.
+var jobStates = map[JobState]pbv2.JobState{
+	JobRunning:   pbv2.JobState_RUNNING,
+	JobSucceeded: pbv2.JobState_SUCCEEDED,
+	JobFailed:    pbv2.JobState_FAILED,
+	JobCancelled: pbv2.JobState_CANCELLED,
+}
+
+// marshalJob returns the API representation of job.
+func marshalJob(job Job) (*pbv2.Job, error) {
+	createTime, err := ptypes.TimestampProto(job.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	updateTime, err := ptypes.TimestampProto(job.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	jobV2 := &pbv2.Job{
+		Name:            jobsCollection + "/" + job.ID,
+		Kind:            job.Kind,
+		State:           jobStates[job.State],
+		Done:            job.Progress.Done,
+		Failed:          job.Progress.Failed,
+		Total:           job.Progress.Total,
+		Error:           job.Error,
+		Creator:         job.Creator,
+		CancelRequested: job.CancelRequested,
+		CreateTime:      createTime,
+		UpdateTime:      updateTime,
+	}
+
+	if job.FinishedAt != nil {
+		if jobV2.EndTime, err = ptypes.TimestampProto(*job.FinishedAt); err != nil {
+			return nil, err
+		}
+	}
+
+	return jobV2, nil
+}
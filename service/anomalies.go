@@ -0,0 +1,369 @@
+package service
+
+import (
+	"context"
+	"expvar"
+	"sync"
+	"time"
+
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/sirupsen/logrus"
+)
+
+// AnomalyKind is the kind of an unusual pattern of changes to permissions.
+type AnomalyKind string
+
+const (
+	// AnomalyMassGrants is the kind of the alert of an actor that gave many permissions within the window.
+	AnomalyMassGrants AnomalyKind = "mass_grants"
+
+	// AnomalyMassRevocations is the kind of the alert of an actor that deleted many permissions within the window.
+	AnomalyMassRevocations AnomalyKind = "mass_revocations"
+
+	// AnomalyExternalSharing is the kind of the alert of many permissions given to organizations
+	// within the window, by all actors.
+	AnomalyExternalSharing AnomalyKind = "external_sharing"
+
+	// MaxAnomalyAlerts is the number of the most recent alerts that are kept by an AnomalyDetector.
+	MaxAnomalyAlerts = 100
+
+	// anomalyBuckets is the number of buckets the window of the sliding-window counters is divided to.
+	anomalyBuckets = 10
+
+	// maxAnomalySubjects is the number of subjects whose counters are kept before the stale ones are pruned.
+	maxAnomalySubjects = 10000
+
+	// externalSharingSubject is the subject of the alerts of AnomalyExternalSharing, which are of all actors.
+	externalSharingSubject = "*"
+)
+
+// anomalyAlerts counts the raised alerts, keyed by their kind.
+var anomalyAlerts = expvar.NewMap("anomaly_alerts")
+
+// AnomalyThresholds is how many changes of each kind within Window are unusual, a kind isn't detected if it's 0.
+type AnomalyThresholds struct {
+	Window time.Duration
+
+	// GrantsPerActor is the number of permissions given by a single actor.
+	GrantsPerActor int64
+
+	// RevocationsPerActor is the number of permissions deleted by a single actor.
+	RevocationsPerActor int64
+
+	// ExternalShares is the number of permissions given to organizations by all actors.
+	ExternalShares int64
+}
+
+// AnomalyAlert is an unusual pattern of changes to permissions, which was detected at DetectedAt
+// when Subject made Count changes of Kind within Window.
+type AnomalyAlert struct {
+	Kind       AnomalyKind
+	Subject    string
+	Count      int64
+	Window     time.Duration
+	DetectedAt time.Time
+}
+
+// slidingCounter counts the events of the last anomalyBuckets buckets, bucket i holds the count of epochs[i].
+type slidingCounter struct {
+	counts [anomalyBuckets]int64
+	epochs [anomalyBuckets]int64
+
+	// alertedAt is when the counter's last alert was raised.
+	alertedAt time.Time
+}
+
+// add counts n events at epoch and returns the count within the window that ends at epoch.
+func (c *slidingCounter) add(epoch int64, n int64) int64 {
+	i := epoch % anomalyBuckets
+	if c.epochs[i] != epoch {
+		c.epochs[i], c.counts[i] = epoch, 0
+	}
+
+	c.counts[i] += n
+
+	var total int64
+	for i := range c.counts {
+		if c.epochs[i] > epoch-anomalyBuckets {
+			total += c.counts[i]
+		}
+	}
+
+	return total
+}
+
+// stale returns true if all of the counter's events are out of the window that ends at epoch.
+func (c *slidingCounter) stale(epoch int64) bool {
+	for i := range c.epochs {
+		if c.epochs[i] > epoch-anomalyBuckets {
+			return false
+		}
+	}
+
+	return true
+}
+
+// anomalyKey identifies the counter of a subject's changes of a kind.
+type anomalyKey struct {
+	kind    AnomalyKind
+	subject string
+}
+
+// AnomalyDetector detects unusual patterns of changes to permissions with sliding-window counters,
+// such as an actor giving thousands of permissions, and keeps the most recent alerts.
+// A subject's alert is raised at most once per window.
+type AnomalyDetector struct {
+	mu         sync.Mutex
+	thresholds AnomalyThresholds
+	logger     *logrus.Logger
+	counters   map[anomalyKey]*slidingCounter
+	alerts     []AnomalyAlert
+	now        func() time.Time
+}
+
+// NewAnomalyDetector creates an AnomalyDetector that detects the patterns of thresholds,
+// and logs its alerts to logger, and returns it.
+func NewAnomalyDetector(thresholds AnomalyThresholds, logger *logrus.Logger) *AnomalyDetector {
+	return &AnomalyDetector{
+		thresholds: thresholds,
+		logger:     logger,
+		counters:   map[anomalyKey]*slidingCounter{},
+		now:        time.Now,
+	}
+}
+
+// threshold returns the threshold of kind, 0 if kind isn't detected.
+func (d *AnomalyDetector) threshold(kind AnomalyKind) int64 {
+	if d.thresholds.Window < anomalyBuckets {
+		return 0
+	}
+
+	switch kind {
+	case AnomalyMassGrants:
+		return d.thresholds.GrantsPerActor
+	case AnomalyMassRevocations:
+		return d.thresholds.RevocationsPerActor
+	case AnomalyExternalSharing:
+		return d.thresholds.ExternalShares
+	default:
+		return 0
+	}
+}
+
+// Record counts n changes of kind by subject, and raises an alert if they exceed the threshold of kind.
+func (d *AnomalyDetector) Record(kind AnomalyKind, subject string, n int64) {
+	threshold := d.threshold(kind)
+	if threshold <= 0 || n <= 0 {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	epoch := now.UnixNano() / int64(d.thresholds.Window/anomalyBuckets)
+	key := anomalyKey{kind: kind, subject: subject}
+	counter, ok := d.counters[key]
+	if !ok {
+		if len(d.counters) >= maxAnomalySubjects {
+			d.prune(epoch)
+		}
+
+		counter = &slidingCounter{}
+		d.counters[key] = counter
+	}
+
+	count := counter.add(epoch, n)
+	if count < threshold || now.Sub(counter.alertedAt) < d.thresholds.Window {
+		return
+	}
+
+	counter.alertedAt = now
+	alert := AnomalyAlert{Kind: kind, Subject: subject, Count: count, Window: d.thresholds.Window, DetectedAt: now}
+	d.alerts = append(d.alerts, alert)
+	if len(d.alerts) > MaxAnomalyAlerts {
+		d.alerts = d.alerts[len(d.alerts)-MaxAnomalyAlerts:]
+	}
+
+	anomalyAlerts.Add(string(kind), 1)
+	if d.logger != nil {
+		d.logger.WithFields(logrus.Fields{
+			"kind":    kind,
+			"subject": subject,
+			"count":   count,
+			"window":  d.thresholds.Window.String(),
+		}).Warn("unusual changes to permissions detected")
+	}
+}
+
+// prune deletes the counters whose events are all out of the window that ends at epoch.
+func (d *AnomalyDetector) prune(epoch int64) {
+	for key, counter := range d.counters {
+		if counter.stale(epoch) && d.now().Sub(counter.alertedAt) >= d.thresholds.Window {
+			delete(d.counters, key)
+		}
+	}
+}
+
+// Alerts returns the most recent alerts of kind, newest first, or of all kinds if kind is empty.
+func (d *AnomalyDetector) Alerts(kind AnomalyKind) []AnomalyAlert {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	alerts := make([]AnomalyAlert, 0, len(d.alerts))
+	for i := len(d.alerts) - 1; i >= 0; i-- {
+		if kind == "" || d.alerts[i].Kind == kind {
+			alerts = append(alerts, d.alerts[i])
+		}
+	}
+
+	return alerts
+}
+
+// anomalyController is a Controller that records the changes to permissions made through it in a detector.
+type anomalyController struct {
+	Controller
+	detector *AnomalyDetector
+}
+
+// DetectAnomalies returns a Controller that wraps controller and records the permissions
+// that are given and deleted through it in detector, by the actors of their requests.
+func DetectAnomalies(controller Controller, detector *AnomalyDetector) Controller {
+	return anomalyController{Controller: controller, detector: detector}
+}
+
+// actorOrCaller returns the actor of ctx, or its caller if there's no actor.
+func actorOrCaller(ctx context.Context) string {
+	if actor := ActorFromContext(ctx); actor != "" {
+		return actor
+	}
+
+	return callerOrUnknown(ctx)
+}
+
+// CreatePermission creates the permission and records it as a grant of the actor of ctx, or of creator
+// if there's no actor, and as an external share if it's given to an organization.
+func (c anomalyController) CreatePermission(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+	role pb.Role,
+	creator string,
+	override bool,
+	canReshare bool,
+	message string,
+	label string,
+	resourceKind string,
+	granteeType string,
+	labels map[string]string,
+	source string) (Permission, error) {
+	permission, err := c.Controller.CreatePermission(
+		ctx,
+		resourceType,
+		fileID,
+		userID,
+		role,
+		creator,
+		override,
+		canReshare,
+		message,
+		label,
+		resourceKind,
+		granteeType,
+		labels,
+		source,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	actor := ActorFromContext(ctx)
+	if actor == "" {
+		actor = creator
+	}
+
+	c.detector.Record(AnomalyMassGrants, actor, 1)
+	if granteeType == GranteeTypeDomain {
+		c.detector.Record(AnomalyExternalSharing, externalSharingSubject, 1)
+	}
+
+	return permission, nil
+}
+
+// CopyPermissions copies the permissions and records the copied ones as grants of the actor of ctx.
+func (c anomalyController) CopyPermissions(
+	ctx context.Context,
+	resourceType string,
+	sourceFileID string,
+	destFileID string,
+	overwrite bool) (CopyReport, error) {
+	report, err := c.Controller.CopyPermissions(ctx, resourceType, sourceFileID, destFileID, overwrite)
+	if err != nil {
+		return report, err
+	}
+
+	c.detector.Record(AnomalyMassGrants, actorOrCaller(ctx), report.Copied)
+	return report, nil
+}
+
+// DeletePermission deletes the permission and records it as a revocation of the actor of ctx.
+func (c anomalyController) DeletePermission(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+	etag string) (Permission, error) {
+	permission, err := c.Controller.DeletePermission(ctx, resourceType, fileID, userID, etag)
+	if err != nil {
+		return nil, err
+	}
+
+	c.detector.Record(AnomalyMassRevocations, actorOrCaller(ctx), 1)
+	return permission, nil
+}
+
+// DeleteFilePermissions deletes the permissions and records them as revocations of the actor of ctx.
+func (c anomalyController) DeleteFilePermissions(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	selector PermissionSelector) ([]*pb.PermissionObject, error) {
+	permissions, err := c.Controller.DeleteFilePermissions(ctx, resourceType, fileID, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	c.detector.Record(AnomalyMassRevocations, actorOrCaller(ctx), int64(len(permissions)))
+	return permissions, nil
+}
+
+// RevokeCascade revokes the permissions and records them as revocations of the actor of ctx.
+func (c anomalyController) RevokeCascade(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string) ([]*pb.PermissionObject, error) {
+	permissions, err := c.Controller.RevokeCascade(ctx, resourceType, fileID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.detector.Record(AnomalyMassRevocations, actorOrCaller(ctx), int64(len(permissions)))
+	return permissions, nil
+}
+
+// RevokeAllExceptOwner revokes the permissions and records them as revocations of the actor of ctx.
+func (c anomalyController) RevokeAllExceptOwner(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	owner string) ([]*pb.PermissionObject, error) {
+	permissions, err := c.Controller.RevokeAllExceptOwner(ctx, resourceType, fileID, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	c.detector.Record(AnomalyMassRevocations, actorOrCaller(ctx), int64(len(permissions)))
+	return permissions, nil
+}
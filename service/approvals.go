@@ -0,0 +1,132 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	pb "github.com/meateam/permission-service/proto"
+	pbv2 "github.com/meateam/permission-service/proto/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PermissionRequest is a request by Creator of the permission of UserID to FileID with the values
+// of Update, which is created once the request is approved by an Approver other than its Creator.
+type PermissionRequest struct {
+	ID           string
+	ResourceType string
+	FileID       string
+	UserID       string
+	Creator      string
+	Update       PermissionUpdate
+	RequestedAt  time.Time
+
+	// Approver, ApprovedAt and PermissionID are set once the request is approved,
+	// PermissionID is the ID of the permission that was created by the approval.
+	Approver     string
+	ApprovedAt   *time.Time
+	PermissionID string
+}
+
+const permissionRequestsCollection = "permissionRequests"
+
+// ApprovalPolicy is the set of sensitive roles that may only be granted by an approved permission request.
+type ApprovalPolicy map[pb.Role]bool
+
+// ParseApprovalPolicy parses a comma separated list of roles, such as "WRITE", an empty policy is valid.
+func ParseApprovalPolicy(policy string) (ApprovalPolicy, error) {
+	approvalPolicy := ApprovalPolicy{}
+	for _, roleName := range strings.Split(policy, ",") {
+		roleName = strings.TrimSpace(roleName)
+		if roleName == "" {
+			continue
+		}
+
+		role, ok := pb.Role_value[roleName]
+		if !ok || pb.Role(role) == pb.Role_NONE {
+			return nil, fmt.Errorf("invalid approval policy role %q", roleName)
+		}
+
+		approvalPolicy[pb.Role(role)] = true
+	}
+
+	return approvalPolicy, nil
+}
+
+// Authorize returns a FailedPrecondition error if role may only be granted by an approved
+// permission request, otherwise returns nil.
+func (p ApprovalPolicy) Authorize(role pb.Role) error {
+	if !p[role] {
+		return nil
+	}
+
+	return RejectionError(
+		codes.FailedPrecondition,
+		Rejection{Kind: RejectionPolicy, Rule: "approval_roles", Subject: role.String()},
+		"role %s requires approval, request it with RequestPermission",
+		role,
+	)
+}
+
+// permissionRequestName returns the resource name of the permission request id to fileID.
+func permissionRequestName(resourceType string, fileID string, id string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", resourceCollection(resourceType), fileID, permissionRequestsCollection, id)
+}
+
+// parsePermissionRequestName parses a permission request resource name, such as
+// `files/{file}/permissionRequests/{request}`, and returns the resource type, the resource ID and the request ID.
+func parsePermissionRequestName(name string) (string, string, string, error) {
+	parts := strings.Split(name, "/")
+	if len(parts) != 4 ||
+		parts[1] == "" ||
+		parts[2] != permissionRequestsCollection ||
+		parts[3] == "" {
+		return "", "", "", status.Errorf(codes.InvalidArgument, "invalid permission request name %q", name)
+	}
+
+	resourceType, ok := parseResourceCollection(parts[0])
+	if !ok {
+		return "", "", "", status.Errorf(codes.InvalidArgument, "invalid permission request name %q", name)
+	}
+
+	return resourceType, parts[1], parts[3], nil
+}
+
+// marshalPermissionRequest marshals request into a v2 permission request.
+func marshalPermissionRequest(request PermissionRequest) (*pbv2.PermissionRequest, error) {
+	createTime, err := TimestampProto(request.RequestedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	requestV2 := &pbv2.PermissionRequest{
+		Name: permissionRequestName(request.ResourceType, request.FileID, request.ID),
+		Permission: &pbv2.Permission{
+			Name:         permissionName(request.ResourceType, request.FileID, request.UserID),
+			UserId:       request.UserID,
+			Role:         pbv2.Role(request.Update.Role),
+			Creator:      request.Creator,
+			CanReshare:   request.Update.CanReshare,
+			Message:      request.Update.Message,
+			Label:        request.Update.Label,
+			Labels:       request.Update.Labels,
+			ResourceKind: request.Update.ResourceKind,
+			GranteeType:  request.Update.GranteeType,
+			Source:       request.Update.Source,
+		},
+		CreateTime: createTime,
+		Approver:   request.Approver,
+	}
+
+	if request.ApprovedAt != nil {
+		requestV2.ApproveTime, err = TimestampProto(*request.ApprovedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		requestV2.ApprovedPermission = requestV2.GetPermission().GetName()
+	}
+
+	return requestV2, nil
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"expvar"
+	"runtime"
+	"time"
+)
+
+// The build's version, commit and time, which are set at link time, i.e with
+// -ldflags "-X github.com/meateam/permission-service/service.version=v1.2.3".
+var (
+	version   = "dev"
+	commit    = ""
+	buildTime = ""
+)
+
+// startTime is the time the service was started at.
+var startTime = time.Now()
+
+// BuildInfo is the version of the running build of the service.
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	BuildTime string `json:"buildTime,omitempty"`
+	GoVersion string `json:"goVersion"`
+	StartTime string `json:"startTime"`
+}
+
+// CurrentBuildInfo returns the version of the running build of the service.
+func CurrentBuildInfo() BuildInfo {
+	return BuildInfo{
+		Version:   version,
+		Commit:    commit,
+		BuildTime: buildTime,
+		GoVersion: runtime.Version(),
+		StartTime: startTime.UTC().Format(time.RFC3339),
+	}
+}
+
+func init() {
+	// The build info is published as a metric so that changes of the metrics can be correlated
+	// with the deployed versions.
+	expvar.Publish("build_info", expvar.Func(func() interface{} {
+		return CurrentBuildInfo()
+	}))
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// CacheHeader is the grpc header metadata key of the caching hint of the response of a permission check,
+	// such as "max-age=60", which is how many seconds the gateway may cache the response.
+	CacheHeader = "cache-control"
+
+	// cacheAgeDivisor is the fraction of a permission's age that its checks may be cached for.
+	cacheAgeDivisor = 10
+
+	// CacheHintFound is the outcome of the hints of the checks of users that have a permission.
+	CacheHintFound = "found"
+
+	// CacheHintNotFound is the outcome of the hints of the checks of users that have no permission.
+	CacheHintNotFound = "not_found"
+
+	// CacheHintUncacheable is the outcome of the checks that failed, which aren't hinted.
+	CacheHintUncacheable = "uncacheable"
+)
+
+var (
+	// cacheHints counts the caching hints of permission checks, keyed by "resourceType/outcome",
+	// and cacheHintSeconds sums their TTLs, so that the average TTL of each key space is their ratio.
+	cacheHints       = expvar.NewMap("cache_hints")
+	cacheHintSeconds = expvar.NewMap("cache_hint_seconds")
+
+	// cacheHintBounds counts the hints whose TTL was bounded by the policy, keyed by "resourceType/min"
+	// or "resourceType/max". A key space whose hints are mostly bounded by max is limited by its TTL.
+	cacheHintBounds = expvar.NewMap("cache_hint_bounds")
+
+	// cacheInvalidations counts the changes to permissions that invalidate the cached checks of their users,
+	// keyed by "resourceType/eventType", and cacheStaleSeconds sums the longest time, keyed by resource type,
+	// that the gateways may keep serving the stale checks, which is the TTL they may have been hinted with.
+	cacheInvalidations = expvar.NewMap("cache_invalidations")
+	cacheStaleSeconds  = expvar.NewMap("cache_stale_seconds")
+)
+
+// CachePolicy is how long the responses of permission checks may be cached by the gateways.
+// Recently given permissions are the most likely to be changed or revoked, so a check of
+// a permission may be cached for a tenth of its age, between MinTTL and its resource type's maximum TTL.
+type CachePolicy struct {
+	// MinTTL is the TTL of the checks of permissions that were just given.
+	MinTTL time.Duration
+
+	// MaxTTL is the maximum TTL of the checks of permissions of resource types that aren't in ByResourceType.
+	MaxTTL time.Duration
+
+	// NotFoundTTL is the TTL of the checks of users that have no permission.
+	NotFoundTTL time.Duration
+
+	// ByResourceType maps resource types to the maximum TTL of the checks of their permissions.
+	ByResourceType map[string]time.Duration
+}
+
+// ParseCacheTTLs parses the maximum TTLs of resource types of the form
+// "resourceType=seconds,resourceType=seconds", such as "folder=30", empty ttls are valid.
+func ParseCacheTTLs(ttls string) (map[string]time.Duration, error) {
+	ttlsByResourceType := map[string]time.Duration{}
+	for _, entry := range strings.Split(ttls, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("invalid cache TTL entry %q", entry)
+		}
+
+		seconds, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil || seconds < 0 {
+			return nil, fmt.Errorf("invalid cache TTL entry %q: seconds must be a non-negative integer", entry)
+		}
+
+		ttlsByResourceType[strings.TrimSpace(parts[0])] = time.Duration(seconds) * time.Second
+	}
+
+	return ttlsByResourceType, nil
+}
+
+// MaxAge returns how long a check of permission, to a resource of resourceType, may be cached at now,
+// or of a user without a permission if permission is nil.
+func (p CachePolicy) MaxAge(resourceType string, permission Permission, now time.Time) time.Duration {
+	if permission == nil {
+		return p.NotFoundTTL
+	}
+
+	maxTTL := p.maxTTL(resourceType)
+	ttl := p.MinTTL
+	if createdAt := permission.GetCreatedAt(); !createdAt.IsZero() {
+		ttl = now.Sub(createdAt) / cacheAgeDivisor
+	}
+
+	if ttl < p.MinTTL {
+		ttl = p.MinTTL
+	}
+
+	if ttl > maxTTL {
+		ttl = maxTTL
+	}
+
+	return ttl
+}
+
+// maxTTL returns the maximum TTL of the checks of permissions to resources of resourceType.
+func (p CachePolicy) maxTTL(resourceType string) time.Duration {
+	if ttl, ok := p.ByResourceType[resourceType]; ok {
+		return ttl
+	}
+
+	return p.MaxTTL
+}
+
+// checkMaxAge returns how long a check of a resource of resourceType may be cached, whose permission
+// is permission or whose err is err, which is 0 if err isn't codes.NotFound. The hint is counted
+// in the cache metrics of resourceType.
+func (p CachePolicy) checkMaxAge(resourceType string, permission Permission, err error) time.Duration {
+	if err != nil {
+		if status.Code(err) != codes.NotFound {
+			cacheHints.Add(resourceType+"/"+CacheHintUncacheable, 1)
+			return 0
+		}
+
+		permission = nil
+	}
+
+	maxAge := p.MaxAge(resourceType, permission, time.Now())
+	outcome := CacheHintFound
+	if permission == nil {
+		outcome = CacheHintNotFound
+	}
+
+	cacheHints.Add(resourceType+"/"+outcome, 1)
+	cacheHintSeconds.Add(resourceType+"/"+outcome, int64(maxAge/time.Second))
+	if permission != nil {
+		switch maxAge {
+		case p.maxTTL(resourceType):
+			cacheHintBounds.Add(resourceType+"/max", 1)
+		case p.MinTTL:
+			cacheHintBounds.Add(resourceType+"/min", 1)
+		}
+	}
+
+	return maxAge
+}
+
+// staleWindow returns the longest time that the gateways may serve the cached checks of the user
+// of a permission to a resource of resourceType after a change of eventType to it. A created
+// permission invalidates the checks that found no permission, and otherwise the checks that found it.
+func (p CachePolicy) staleWindow(resourceType string, eventType EventType) time.Duration {
+	if eventType == EventCreated {
+		return p.NotFoundTTL
+	}
+
+	return p.maxTTL(resourceType)
+}
+
+// CacheInvalidationPublisher is an EventPublisher that counts the changes to permissions that invalidate
+// the checks that the gateways may have cached by the hints of its policy, in the cache metrics.
+type CacheInvalidationPublisher struct {
+	policy CachePolicy
+}
+
+// NewCacheInvalidationPublisher creates a CacheInvalidationPublisher of policy and returns it.
+func NewCacheInvalidationPublisher(policy CachePolicy) CacheInvalidationPublisher {
+	return CacheInvalidationPublisher{policy: policy}
+}
+
+// Publish counts the invalidation of event, events of EventExternalAccess and EventOwnerAssigned duplicate
+// their EventCreated events and aren't counted.
+func (p CacheInvalidationPublisher) Publish(ctx context.Context, event PermissionEvent) error {
+	if event.Type == EventExternalAccess || event.Type == EventOwnerAssigned {
+		return nil
+	}
+
+	resourceType := resourceTypeOrDefault(event.Permission.GetResourceType())
+	cacheInvalidations.Add(resourceType+"/"+string(event.Type), 1)
+	cacheStaleSeconds.Add(resourceType, int64(p.policy.staleWindow(resourceType, event.Type)/time.Second))
+	return nil
+}
+
+// setCacheHeader sends the caching hint of maxAge in the header of the response of ctx, unless it's 0.
+// The hint is best-effort, so it's not sent if ctx isn't of a grpc request.
+func setCacheHeader(ctx context.Context, maxAge time.Duration) {
+	if maxAge <= 0 {
+		return
+	}
+
+	_ = grpc.SetHeader(ctx, metadata.Pairs(CacheHeader, fmt.Sprintf("max-age=%d", int64(maxAge/time.Second))))
+}
+
+// WithCachePolicy returns a copy of the service that hints the caching of its permission checks with cache.
+func (s Service) WithCachePolicy(cache CachePolicy) Service {
+	s.cache = cache
+	return s
+}
+
+// WithCachePolicy returns a copy of the service that hints the caching of its permission checks with cache.
+func (s ServiceV2) WithCachePolicy(cache CachePolicy) ServiceV2 {
+	s.cache = cache
+	return s
+}
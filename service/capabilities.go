@@ -0,0 +1,72 @@
+package service
+
+import (
+	pb "github.com/meateam/permission-service/proto"
+)
+
+const (
+	// ResourceKindFile is the kind of a resource that's a file, and the kind of a permission if not specified.
+	ResourceKindFile = "file"
+
+	// ResourceKindFolder is the kind of a resource that's a folder, which contains other resources.
+	ResourceKindFolder = "folder"
+)
+
+// capabilitiesByKind is the capability matrix of the resource kinds,
+// it maps each kind to the capabilities that each role grants to resources of that kind.
+var capabilitiesByKind = map[string]map[pb.Role][]pb.Capability{
+	ResourceKindFile: {
+		pb.Role_READ:      {pb.Capability_VIEW},
+		pb.Role_COMMENTER: {pb.Capability_VIEW, pb.Capability_COMMENT},
+		pb.Role_WRITE: {
+			pb.Capability_VIEW,
+			pb.Capability_COMMENT,
+			pb.Capability_EDIT,
+			pb.Capability_VIEW_PERMISSIONS,
+		},
+		pb.Role_UPLOADER: {pb.Capability_EDIT},
+		pb.Role_AUDITOR:  {pb.Capability_VIEW_PERMISSIONS},
+	},
+	ResourceKindFolder: {
+		pb.Role_READ:      {pb.Capability_VIEW, pb.Capability_LIST},
+		pb.Role_COMMENTER: {pb.Capability_VIEW, pb.Capability_LIST, pb.Capability_COMMENT},
+		pb.Role_WRITE: {
+			pb.Capability_VIEW,
+			pb.Capability_LIST,
+			pb.Capability_COMMENT,
+			pb.Capability_EDIT,
+			pb.Capability_CREATE_CHILD,
+			pb.Capability_VIEW_PERMISSIONS,
+		},
+		// An uploader of a folder may upload files into it without seeing its children.
+		pb.Role_UPLOADER: {pb.Capability_CREATE_CHILD},
+		pb.Role_AUDITOR:  {pb.Capability_VIEW_PERMISSIONS},
+	},
+}
+
+// Capabilities returns the capabilities that a permission with role to a resource of resourceKind grants.
+func Capabilities(resourceKind string, role pb.Role) []pb.Capability {
+	return capabilitiesByKind[resourceKind][role]
+}
+
+// HasCapability returns true if a permission with role to a resource of resourceKind grants capability.
+func HasCapability(resourceKind string, role pb.Role, capability pb.Capability) bool {
+	for _, granted := range Capabilities(resourceKind, role) {
+		if granted == capability {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resourceKindOrDefault returns resourceKind, or ResourceKindFile if it's empty,
+// and whether it's a kind of resource.
+func resourceKindOrDefault(resourceKind string) (string, bool) {
+	if resourceKind == "" {
+		return ResourceKindFile, true
+	}
+
+	_, ok := capabilitiesByKind[resourceKind]
+	return resourceKind, ok
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+
+	pbv2 "github.com/meateam/permission-service/proto/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// JobRefreshCollaborators is the kind of the recurring jobs that recompute the frequent collaborators
+	// of the users from their permissions.
+	JobRefreshCollaborators = "refresh_collaborators"
+
+	// DefaultCollaboratorsLimit is the number of collaborators that are returned if the limit isn't set,
+	// and MaxCollaboratorsLimit is the maximum number.
+	DefaultCollaboratorsLimit = 10
+	MaxCollaboratorsLimit     = 100
+)
+
+// Collaborator is a user that another user shares files with, or receives them from.
+type Collaborator struct {
+	UserID string
+
+	// SharedWith is the number of the permissions that the user gave the collaborator,
+	// and ReceivedFrom is the number of the permissions that the collaborator gave the user.
+	SharedWith   int64
+	ReceivedFrom int64
+}
+
+// GetFrequentCollaborators is the request handler for the users that a user most frequently
+// shares files with, and receives them from.
+func (s ServiceV2) GetFrequentCollaborators(
+	ctx context.Context,
+	req *pbv2.GetFrequentCollaboratorsRequest,
+) (*pbv2.GetFrequentCollaboratorsResponse, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	userID := req.GetUserId()
+	limit := int(req.GetLimit())
+	switch {
+	case limit < 0:
+		return nil, status.Error(codes.InvalidArgument, "limit must not be negative")
+	case limit == 0:
+		limit = DefaultCollaboratorsLimit
+	case limit > MaxCollaboratorsLimit:
+		limit = MaxCollaboratorsLimit
+	}
+
+	// The collaborators of a user reveal who it shares files with, so an actor may only get its own.
+	if actor := ActorFromContext(ctx); actor != "" && actor != userID {
+		return nil, status.Errorf(codes.PermissionDenied, "%s may not get the collaborators of %s", actor, userID)
+	}
+
+	collaborators, err := s.controller.GetFrequentCollaborators(ctx, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pbv2.GetFrequentCollaboratorsResponse{
+		Collaborators: make([]*pbv2.GetFrequentCollaboratorsResponse_Collaborator, 0, len(collaborators)),
+	}
+	for _, collaborator := range collaborators {
+		response.Collaborators = append(response.Collaborators, &pbv2.GetFrequentCollaboratorsResponse_Collaborator{
+			UserId:       collaborator.UserID,
+			SharedWith:   collaborator.SharedWith,
+			ReceivedFrom: collaborator.ReceivedFrom,
+		})
+	}
+
+	return response, nil
+}
+
+// refreshCollaborators recomputes the frequent collaborators of the users from their permissions.
+func (m MaintenanceJobs) refreshCollaborators(ctx context.Context, progress func(JobProgress) error) error {
+	pairs, err := m.controller.RefreshCollaborators(ctx)
+	if err != nil {
+		return err
+	}
+
+	m.logger.WithField("pairs", pairs).Info("refreshed the frequent collaborators")
+	return progress(JobProgress{Done: pairs, Total: pairs})
+}
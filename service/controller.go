@@ -2,23 +2,231 @@ package service
 
 import (
 	"context"
+	"time"
 
 	pb "github.com/meateam/permission-service/proto"
 )
 
 // Controller is an interface for the business logic of the permission.Service which uses a Store.
+// Permissions are given to resources of resourceType, which are identified by fileID.
 type Controller interface {
 	CreatePermission(
 		ctx context.Context,
+		resourceType string,
 		fileID string,
 		userID string,
 		role pb.Role,
 		creator string,
-		override bool) (Permission, error)
-	DeletePermission(ctx context.Context, fileID string, userID string) (Permission, error)
-	GetFilePermissions(ctx context.Context, fileID string) ([]*pb.GetFilePermissionsResponse_UserRole, error)
-	GetByFileAndUser(ctx context.Context, fileID string, userID string) (Permission, error)
-	GetUserPermissions(ctx context.Context, userID string) ([]*pb.GetUserPermissionsResponse_FileRole, error)
-	DeleteFilePermissions(ctx context.Context, fileID string) ([]*pb.PermissionObject, error)
+		override bool,
+		canReshare bool,
+		message string,
+		label string,
+		resourceKind string,
+		granteeType string,
+		labels map[string]string,
+		source string) (Permission, error)
+	DeletePermission(
+		ctx context.Context,
+		resourceType string,
+		fileID string,
+		userID string,
+		etag string) (Permission, error)
+	GetFilePermissions(
+		ctx context.Context,
+		resourceType string,
+		fileID string,
+		order pb.PermissionsOrder,
+		pageSize int,
+		pageToken string,
+		selector PermissionSelector) ([]*pb.GetFilePermissionsResponse_UserRole, string, error)
+	GetByFileAndUser(
+		ctx context.Context,
+		resourceType string,
+		fileID string,
+		userID string,
+		fields ...PermissionField) (Permission, error)
+	GetUserPermissions(
+		ctx context.Context,
+		resourceType string,
+		userID string,
+		order pb.PermissionsOrder,
+		pageSize int,
+		pageToken string,
+		selector PermissionSelector) ([]*pb.GetUserPermissionsResponse_FileRole, string, error)
+	TouchPermission(ctx context.Context, resourceType string, fileID string, userID string) (Permission, error)
+	PermissionsExist(ctx context.Context, ids []string) ([]bool, error)
+	ListFilePermissions(
+		ctx context.Context,
+		resourceType string,
+		fileID string,
+		pageSize int,
+		pageToken string,
+		fields []PermissionField,
+		selector PermissionSelector) ([]Permission, string, error)
+	UpdatePermission(
+		ctx context.Context,
+		resourceType string,
+		fileID string,
+		userID string,
+		etag string,
+		update PermissionUpdate,
+		fields []PermissionField) (Permission, error)
+	UpdateRoles(ctx context.Context, updates []RoleUpdate) ([]RoleUpdateResult, error)
+	CopyPermissions(
+		ctx context.Context,
+		resourceType string,
+		sourceFileID string,
+		destFileID string,
+		overwrite bool) (CopyReport, error)
+	ReplaceInherited(
+		ctx context.Context,
+		resourceType string,
+		subtree []FileNode,
+		parentID string) (InheritanceReport, error)
+	MigrateRole(
+		ctx context.Context,
+		fromRole pb.Role,
+		toRole pb.Role,
+		filter PermissionsFilter,
+		batchSize int,
+		progress func(migrated int64, total int64) error) error
+	RepairPermissions(
+		ctx context.Context,
+		action RepairAction,
+		batchSize int,
+		progress func(malformed []MalformedPermission) error) error
+	ExportTenantData(ctx context.Context, tenantID string, export func(record TenantRecord) error) error
+	PreviewTenantPurge(ctx context.Context, purge TenantPurge) (TenantPurge, error)
+	PurgeTenant(ctx context.Context, tenantID string, token string, confirmedBy string) (TenantPurge, error)
+	GetTenantRateLimits(ctx context.Context, tenantID string) (TenantRateLimits, error)
+	SetTenantRateLimits(ctx context.Context, limits TenantRateLimits) (TenantRateLimits, error)
+	DeleteFilePermissions(
+		ctx context.Context,
+		resourceType string,
+		fileID string,
+		selector PermissionSelector) ([]*pb.PermissionObject, error)
+	GetSharedFiles(
+		ctx context.Context,
+		resourceType string,
+		userA string,
+		userB string,
+		grantedByA bool) ([]*pb.GetSharedFilesResponse_SharedFile, error)
+	ListPermissionChanges(
+		ctx context.Context,
+		resourceType string,
+		userID string,
+		syncToken string,
+		pageSize int) (PermissionChanges, error)
+	GetFolderSharingSummary(
+		ctx context.Context,
+		resourceType string,
+		folderID string,
+		descendantIDs []string) (SharingSummary, error)
+	ListSharedWithMe(
+		ctx context.Context,
+		resourceType string,
+		userID string,
+		pageSize int,
+		pageToken string) ([]SharedWithMe, string, error)
+	GetUserData(ctx context.Context, userID string) (UserData, error)
+	EraseUserData(ctx context.Context, userID string) (ErasureReport, error)
+	ScheduleUpdate(
+		ctx context.Context,
+		resourceType string,
+		fileID string,
+		userID string,
+		etag string,
+		update PermissionUpdate,
+		fields []PermissionField,
+		scheduledAt time.Time) (Permission, error)
+	ApplyDueUpdates(ctx context.Context, now time.Time, lease time.Duration) (int, error)
+	RequestPermission(ctx context.Context, request PermissionRequest) (PermissionRequest, error)
+	ApprovePermissionRequest(
+		ctx context.Context,
+		resourceType string,
+		fileID string,
+		id string,
+		approver string) (PermissionRequest, error)
+	ListDomainPermissions(
+		ctx context.Context,
+		domain string,
+		pageSize int,
+		pageToken string) ([]Permission, string, error)
+	RevokeCascade(
+		ctx context.Context,
+		resourceType string,
+		fileID string,
+		userID string) ([]*pb.PermissionObject, error)
+	RevokeAllExceptOwner(
+		ctx context.Context,
+		resourceType string,
+		fileID string,
+		owner string) ([]*pb.PermissionObject, error)
+	AssignOwner(ctx context.Context, resourceType string, fileID string, userID string) (Permission, error)
+	LockFile(ctx context.Context, lock FileLock) (FileLock, error)
+	UnlockFile(ctx context.Context, resourceType string, fileID string) (FileLock, error)
+	GetFileLock(ctx context.Context, resourceType string, fileID string) (*FileLock, error)
+	PlaceLegalHold(ctx context.Context, hold LegalHold) (LegalHold, error)
+	ReleaseLegalHold(ctx context.Context, resourceType string, fileID string) (LegalHold, error)
+	CreateJob(ctx context.Context, job Job) (Job, error)
+	GetJob(ctx context.Context, id string) (Job, error)
+	ListJobs(ctx context.Context, kind string, pageSize int, pageToken string) ([]Job, string, error)
+	UpdateJobProgress(ctx context.Context, id string, progress JobProgress, updatedAt time.Time) (Job, error)
+	FinishJob(
+		ctx context.Context,
+		id string,
+		state JobState,
+		progress JobProgress,
+		message string,
+		finishedAt time.Time) (Job, error)
+	CancelJob(ctx context.Context, id string) (Job, error)
+	FailStaleJobs(ctx context.Context, staleBefore time.Time, message string) (int64, error)
+	PurgeJobs(ctx context.Context, finishedBefore time.Time) (int64, error)
+	PurgeFailedUpdates(ctx context.Context, failedBefore time.Time) (int64, error)
+	ClaimRecurringRun(ctx context.Context, kind string, runAt time.Time) (bool, error)
+	CountPermissions(ctx context.Context) ([]PermissionCount, error)
+	RefreshCollaborators(ctx context.Context) (int64, error)
+	UpgradeSchema(ctx context.Context, batchSize int, progress func(upgraded int64, total int64) error) error
+	GetFileSharingActivity(
+		ctx context.Context,
+		resourceType string,
+		fileID string,
+		since time.Time,
+		pageSize int,
+		pageToken string) ([]PermissionEvent, string, error)
+	GetFrequentCollaborators(ctx context.Context, userID string, limit int) ([]Collaborator, error)
+	CreateAccessReview(ctx context.Context, review AccessReview) (AccessReview, error)
+	GetAccessReview(ctx context.Context, id string) (AccessReview, error)
+	ListAccessReviews(ctx context.Context, pageSize int, pageToken string) ([]AccessReview, string, error)
+	ListAccessReviewItems(
+		ctx context.Context,
+		reviewID string,
+		filter AccessReviewItemFilter,
+		pageSize int,
+		pageToken string) ([]AccessReviewItem, string, error)
+	DecideAccessReviewItems(
+		ctx context.Context,
+		reviewID string,
+		reviewer string,
+		decisions []AccessReviewDecision) ([]AccessReviewItem, error)
+	CloseAccessReview(
+		ctx context.Context,
+		id string,
+		closedBy string,
+		revokeUndecided bool) (AccessReview, error)
+	ListStaleGrants(
+		ctx context.Context,
+		resourceType string,
+		before time.Time,
+		pageSize int,
+		pageToken string) ([]Permission, string, error)
+	ExpireStaleGrants(
+		ctx context.Context,
+		before time.Time,
+		batchSize int,
+		progress func(expired int64, skipped int64) error) error
+	SamplePermissions(ctx context.Context, size int) ([]Permission, error)
 	HealthCheck(ctx context.Context) (bool, error)
+	WarmUp(ctx context.Context, resourceType string, fileIDs []string) error
+	PrewarmFiles(ctx context.Context, resourceType string, fileIDs []string) ([]Permission, error)
 }
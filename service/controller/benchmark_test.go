@@ -0,0 +1,151 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/meateam/permission-service/service"
+)
+
+// benchmarkFilePermissions is the number of permissions of the file whose permissions are listed.
+const benchmarkFilePermissions = 100
+
+// newBenchmarkController returns a controller of a memoryRepository with the permissions
+// of benchmarkFilePermissions users to the file "shared".
+func newBenchmarkController(b *testing.B) Controller {
+	b.Helper()
+
+	c := New(newMemoryRepository(), nil, nil, nil, nil, nil, nil)
+	for i := 0; i < benchmarkFilePermissions; i++ {
+		userID := fmt.Sprintf("user-%d", i)
+		if _, err := c.CreatePermission(
+			context.Background(),
+			service.DefaultResourceType,
+			"shared",
+			userID,
+			pb.Role_READ,
+			userID,
+			false,
+			true,
+			"",
+			"",
+			service.ResourceKindFile,
+			service.GranteeTypeUser,
+			nil,
+			"",
+		); err != nil {
+			b.Fatalf("failed creating permission: %v", err)
+		}
+	}
+
+	return c
+}
+
+func BenchmarkCreatePermission(b *testing.B) {
+	c := newBenchmarkController(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		// The permissions are reshared by a user that has a permission to the file.
+		if _, err := c.CreatePermission(
+			context.Background(),
+			service.DefaultResourceType,
+			"shared",
+			fmt.Sprintf("reshared-%d", i),
+			pb.Role_READ,
+			"user-0",
+			false,
+			true,
+			"",
+			"",
+			service.ResourceKindFile,
+			service.GranteeTypeUser,
+			nil,
+			"",
+		); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetByFileAndUser(b *testing.B) {
+	c := newBenchmarkController(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		userID := fmt.Sprintf("user-%d", i%benchmarkFilePermissions)
+		if _, err := c.GetByFileAndUser(
+			context.Background(),
+			service.DefaultResourceType,
+			"shared",
+			userID,
+			service.RoleField,
+		); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetFilePermissions(b *testing.B) {
+	c := newBenchmarkController(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, _, err := c.GetFilePermissions(
+			context.Background(),
+			service.DefaultResourceType,
+			"shared",
+			pb.PermissionsOrder_DEFAULT,
+			0,
+			"",
+			service.PermissionSelector{},
+		); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDeletePermission(b *testing.B) {
+	c := newBenchmarkController(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		userID := fmt.Sprintf("deleted-%d", i)
+		if _, err := c.CreatePermission(
+			context.Background(),
+			service.DefaultResourceType,
+			"shared",
+			userID,
+			pb.Role_READ,
+			userID,
+			false,
+			true,
+			"",
+			"",
+			service.ResourceKindFile,
+			service.GranteeTypeUser,
+			nil,
+			"",
+		); err != nil {
+			b.Fatal(err)
+		}
+		b.StartTimer()
+
+		if _, err := c.DeletePermission(
+			context.Background(),
+			service.DefaultResourceType,
+			"shared",
+			userID,
+			"",
+		); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
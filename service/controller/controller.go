@@ -0,0 +1,2029 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/meateam/permission-service/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// IdempotencyKeyHeader is the grpc metadata key of the idempotency key of a CreatePermission request.
+// Requests with the same idempotency key, within the idempotency window, create the permission once.
+const IdempotencyKeyHeader = "x-idempotency-key"
+
+// accessReviewRevocationBatchSize is the number of the revoked items of an access review campaign
+// that are read at a time when it's closed.
+const accessReviewRevocationBatchSize = 500
+
+// Controller is the permissions service business logic implementation using repositories,
+// it implements service.Controller independently of the storage backend.
+type Controller struct {
+	permissions service.PermissionRepository
+	requests    service.RequestRepository
+	schedules   service.ScheduleRepository
+	approvals   service.ApprovalRepository
+	locks       service.LockRepository
+	holds       service.HoldRepository
+	jobs        service.JobRepository
+
+	// tenants stores the data of the tenants, tenants may not be offboarded if it's nil.
+	tenants service.TenantRepository
+
+	// reviews stores the access review campaigns, campaigns may not be created if it's nil.
+	reviews service.ReviewRepository
+
+	// reshareLimits limit the sharing chains of the created permissions.
+	reshareLimits service.ReshareLimits
+
+	// mergeStrategies resolve the direct and inherited permissions of a user to the same file.
+	mergeStrategies service.MergeStrategies
+}
+
+// New returns a new controller that stores permissions in permissions, the idempotency keys
+// of requests in requests, the scheduled updates of permissions in schedules, the requests
+// of permissions that require approval in approvals, the lockdowns of files in locks, the legal holds
+// of files in holds and the jobs of bulk operations in jobs. Files aren't locked down if locks is nil,
+// aren't held if holds is nil, and jobs may not be created if jobs is nil.
+func New(
+	permissions service.PermissionRepository,
+	requests service.RequestRepository,
+	schedules service.ScheduleRepository,
+	approvals service.ApprovalRepository,
+	locks service.LockRepository,
+	holds service.HoldRepository,
+	jobs service.JobRepository,
+) Controller {
+	return Controller{
+		permissions: permissions,
+		requests:    requests,
+		schedules:   schedules,
+		approvals:   approvals,
+		locks:       locks,
+		holds:       holds,
+		jobs:        jobs,
+	}
+}
+
+// WithReshareLimits returns a copy of c that rejects the creation of permissions that exceed limits.
+func (c Controller) WithReshareLimits(limits service.ReshareLimits) Controller {
+	c.reshareLimits = limits
+	return c
+}
+
+// WithTenants returns a copy of c that exports and purges the data of tenants from tenants.
+func (c Controller) WithTenants(tenants service.TenantRepository) Controller {
+	c.tenants = tenants
+	return c
+}
+
+// WithReviews returns a copy of c that stores the access review campaigns in reviews.
+func (c Controller) WithReviews(reviews service.ReviewRepository) Controller {
+	c.reviews = reviews
+	return c
+}
+
+// WithMergeStrategies returns a copy of c that resolves the direct and inherited permissions of a user
+// to the same file by strategies.
+func (c Controller) WithMergeStrategies(strategies service.MergeStrategies) Controller {
+	c.mergeStrategies = strategies
+	return c
+}
+
+// CreatePermission creates a Permission in store and returns its unique ID.
+// An existing permission may not be overridden while its file is under legal hold.
+func (c Controller) CreatePermission(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+	role pb.Role,
+	creator string,
+	override bool,
+	canReshare bool,
+	message string,
+	label string,
+	resourceKind string,
+	granteeType string,
+	labels map[string]string,
+	source string) (service.Permission, error) {
+	values := service.PermissionUpdate{
+		Role:         role,
+		CanReshare:   canReshare,
+		Message:      message,
+		Label:        label,
+		Labels:       labels,
+		ResourceKind: resourceKind,
+		GranteeType:  granteeType,
+		Source:       source,
+	}
+
+	if override {
+		if err := c.checkLegalHold(ctx, resourceType, fileID); err != nil {
+			return nil, err
+		}
+	}
+
+	var createdPermission service.Permission
+	err := c.permissions.WithCausalConsistency(ctx, func(ctx context.Context) (err error) {
+		idempotencyKey := idempotencyKeyFromContext(ctx)
+		if idempotencyKey != "" {
+			permissionID, err := c.requests.ClaimIdempotencyKey(ctx, idempotencyKey, resourceType, fileID, userID)
+			if err != nil {
+				return err
+			}
+
+			// The permission was already created by a previous request with the same key.
+			if permissionID != "" {
+				createdPermission, err = c.permissions.GetByID(ctx, permissionID)
+				return err
+			}
+
+			// Release the key if the permission wasn't created so that it could be retried.
+			defer func() {
+				if err != nil {
+					if releaseErr := c.requests.ReleaseIdempotencyKey(ctx, idempotencyKey); releaseErr != nil {
+						err = fmt.Errorf("%v, failed releasing idempotency key: %v", err, releaseErr)
+					}
+				}
+			}()
+		}
+
+		var sharingChain []string
+		if creator != userID {
+			if sharingChain, err = c.reshareChain(ctx, resourceType, fileID, creator); err != nil {
+				return err
+			}
+		}
+
+		createdPermission, err = c.permissions.Create(
+			ctx,
+			resourceType,
+			fileID,
+			userID,
+			creator,
+			sharingChain,
+			values,
+			override,
+		)
+		if err != nil {
+			return fmt.Errorf("failed creating permission: %v", err)
+		}
+
+		if idempotencyKey != "" {
+			return c.requests.CompleteIdempotencyKey(ctx, idempotencyKey, createdPermission.GetID())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return createdPermission, nil
+}
+
+// reshareChain returns the sharing chain of a permission to fileID that's created by creator,
+// which is the sharing chain of creator's permission followed by creator.
+// Returns a PermissionDenied error if creator has no permission to fileID, or one that doesn't allow
+// sharing it further, or if the permission would exceed the reshare limits. Only the owner of a file
+// gives itself its permission, so every other permission is shared by a creator that has one.
+func (c Controller) reshareChain(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	creator string,
+) ([]string, error) {
+	creatorPermission, err := c.permissions.Get(ctx, resourceType, fileID, creator)
+	if status.Code(err) == codes.NotFound {
+		return nil, service.RejectionError(
+			codes.PermissionDenied,
+			service.Rejection{Kind: service.RejectionPolicy, Rule: "can_reshare", Subject: creator},
+			"user %s has no permission to share file %s",
+			creator,
+			fileID,
+		)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !creatorPermission.GetCanReshare() {
+		return nil, service.RejectionError(
+			codes.PermissionDenied,
+			service.Rejection{Kind: service.RejectionPolicy, Rule: "can_reshare", Subject: creator},
+			"user %s is not allowed to share file %s",
+			creator,
+			fileID,
+		)
+	}
+
+	creatorChain := creatorPermission.GetSharingChain()
+	sharingChain := make([]string, 0, len(creatorChain)+1)
+	sharingChain = append(append(sharingChain, creatorChain...), creator)
+	if err := c.checkReshareLimits(ctx, resourceType, fileID, sharingChain); err != nil {
+		return nil, err
+	}
+
+	return sharingChain, nil
+}
+
+// checkReshareLimits returns a PermissionDenied error if a permission to fileID with sharingChain would exceed
+// the reshare limits. The origin of the chain is the permission of its second user, which was given by the user
+// that shared the file first, and every permission that was reshared from it has the second user in its chain.
+func (c Controller) checkReshareLimits(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	sharingChain []string,
+) error {
+	limits := c.reshareLimits
+	if limits.MaxDepth > 0 && len(sharingChain) > limits.MaxDepth {
+		return service.RejectionError(
+			codes.PermissionDenied,
+			service.Rejection{
+				Kind:    service.RejectionQuota,
+				Rule:    "max_reshare_depth",
+				Subject: service.ResourceName(resourceType, fileID),
+				Current: int64(len(sharingChain)),
+				Limit:   int64(limits.MaxDepth),
+			},
+			"file %s may not be reshared through more than %d users",
+			fileID,
+			limits.MaxDepth,
+		)
+	}
+
+	if limits.MaxGrants <= 0 || len(sharingChain) < 2 {
+		return nil
+	}
+
+	origin := sharingChain[1]
+	reshared, err := c.permissions.GetBySharer(ctx, resourceType, fileID, origin)
+	if err != nil {
+		return err
+	}
+
+	if len(reshared) >= limits.MaxGrants {
+		return service.RejectionError(
+			codes.PermissionDenied,
+			service.Rejection{
+				Kind:    service.RejectionQuota,
+				Rule:    "max_reshare_grants",
+				Subject: origin,
+				Current: int64(len(reshared) + 1),
+				Limit:   int64(limits.MaxGrants),
+			},
+			"the permission of user %s to file %s may not be reshared to more than %d users",
+			origin,
+			fileID,
+			limits.MaxGrants,
+		)
+	}
+
+	return nil
+}
+
+// GetByFileAndUser retrieves the permissoin that matches fileID and userID, and any error if occurred.
+// If fields are given then only they are retrieved, along with the file and user IDs.
+func (c Controller) GetByFileAndUser(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+	fields ...service.PermissionField) (service.Permission, error) {
+	if err := c.checkFileLock(ctx, resourceType, fileID, userID); err != nil {
+		return nil, err
+	}
+
+	var permission service.Permission
+	err := c.permissions.WithCausalConsistency(ctx, func(ctx context.Context) (err error) {
+		permission, err = c.permissions.Get(ctx, resourceType, fileID, userID, fields...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return permission, nil
+}
+
+// DeletePermission deletes the permission in store that matches fileID and userID
+// and returns the deleted permission. If etag is not empty then the permission is
+// deleted only if etag is its current etag.
+func (c Controller) DeletePermission(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+	etag string,
+) (service.Permission, error) {
+	if err := c.checkLegalHold(ctx, resourceType, fileID); err != nil {
+		return nil, err
+	}
+
+	var permission service.Permission
+	err := c.permissions.WithCausalConsistency(ctx, func(ctx context.Context) (err error) {
+		permission, err = c.permissions.Delete(ctx, resourceType, fileID, userID, etag)
+		if status.Code(err) == codes.NotFound {
+			return c.notFoundError(ctx, resourceType, fileID, userID, etag)
+		}
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return permission, nil
+}
+
+// HealthCheck runs store's healthcheck and returns true if healthy, otherwise returns false
+// and any error if occurred.
+func (c Controller) HealthCheck(ctx context.Context) (bool, error) {
+	return c.permissions.HealthCheck(ctx)
+}
+
+// WarmUp verifies the store's indexes and reads the permissions of fileIDs so that the first
+// requests of them after a deploy aren't served from a cold store, returns any error if occurred.
+func (c Controller) WarmUp(ctx context.Context, resourceType string, fileIDs []string) error {
+	if err := c.permissions.VerifyIndexes(ctx); err != nil {
+		return fmt.Errorf("failed verifying indexes: %v", err)
+	}
+
+	_, err := c.PrewarmFiles(ctx, resourceType, fileIDs)
+	return err
+}
+
+// PrewarmFiles reads the permissions of the resources of resourceType with fileIDs so that the store's
+// cache holds them ahead of an anticipated load, and returns them, or any error if occurred.
+func (c Controller) PrewarmFiles(
+	ctx context.Context,
+	resourceType string,
+	fileIDs []string,
+) ([]service.Permission, error) {
+	var permissions []service.Permission
+	for _, fileID := range fileIDs {
+		filePermissions, err := c.permissions.GetByResource(
+			ctx,
+			resourceType,
+			fileID,
+			pb.PermissionsOrder_DEFAULT,
+			service.PermissionSelector{},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed warming up permissions of %s: %v", fileID, err)
+		}
+
+		permissions = append(permissions, filePermissions...)
+	}
+
+	return permissions, nil
+}
+
+// GetFilePermissions returns a slice of UserRole of up to pageSize permissions that match selector
+// and come after pageToken, or of all of them if pageSize is 0, and the token of the next page,
+// otherwise returns nil and any error if occurred.
+func (c Controller) GetFilePermissions(ctx context.Context,
+	resourceType string,
+	fileID string,
+	order pb.PermissionsOrder,
+	pageSize int,
+	pageToken string,
+	selector service.PermissionSelector) ([]*pb.GetFilePermissionsResponse_UserRole, string, error) {
+	var filePermissions []service.Permission
+	var nextPageToken string
+	err := c.permissions.WithCausalConsistency(ctx, func(ctx context.Context) (err error) {
+		if pageSize == 0 {
+			filePermissions, err = c.permissions.GetByResource(ctx, resourceType, fileID, order, selector)
+			return err
+		}
+
+		filePermissions, nextPageToken, err = c.permissions.ListByResource(
+			ctx,
+			resourceType,
+			fileID,
+			order,
+			pageSize,
+			pageToken,
+			nil,
+			selector,
+		)
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	returnedPermissions := make([]*pb.GetFilePermissionsResponse_UserRole, 0, len(filePermissions))
+	for _, permission := range filePermissions {
+		lastAccessedAt, err := service.TimestampProto(permission.GetLastAccessedAt())
+		if err != nil {
+			return nil, "", err
+		}
+
+		returnedPermissions = append(returnedPermissions, &pb.GetFilePermissionsResponse_UserRole{
+			UserID:         permission.GetUserID(),
+			Role:           permission.GetRole(),
+			Creator:        permission.GetCreator(),
+			CanReshare:     permission.GetCanReshare(),
+			Message:        permission.GetMessage(),
+			Label:          permission.GetLabel(),
+			LastAccessedAt: lastAccessedAt,
+			Labels:         permission.GetLabels(),
+			Source:         permission.GetSource(),
+		})
+	}
+	return returnedPermissions, nextPageToken, nil
+}
+
+// GetUserPermissions returns a slice of FileRole of up to pageSize permissions that match selector
+// and come after pageToken, or of all of them if pageSize is 0, and the token of the next page,
+// otherwise returns nil and any error if occurred.
+func (c Controller) GetUserPermissions(
+	ctx context.Context,
+	resourceType string,
+	userID string,
+	order pb.PermissionsOrder,
+	pageSize int,
+	pageToken string,
+	selector service.PermissionSelector) ([]*pb.GetUserPermissionsResponse_FileRole, string, error) {
+	var permissions []service.Permission
+	var nextPageToken string
+	err := c.permissions.WithCausalConsistency(ctx, func(ctx context.Context) (err error) {
+		if pageSize == 0 {
+			permissions, err = c.permissions.GetByUser(ctx, resourceType, userID, order, selector)
+			return err
+		}
+
+		permissions, nextPageToken, err = c.permissions.ListByUser(
+			ctx,
+			resourceType,
+			userID,
+			order,
+			pageSize,
+			pageToken,
+			selector,
+		)
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	filePermissions := make([]*pb.GetUserPermissionsResponse_FileRole, 0, len(permissions))
+	for _, permission := range permissions {
+		lastAccessedAt, err := service.TimestampProto(permission.GetLastAccessedAt())
+		if err != nil {
+			return nil, "", err
+		}
+
+		filePermissions = append(filePermissions, &pb.GetUserPermissionsResponse_FileRole{
+			FileID:         permission.GetFileID(),
+			Role:           permission.GetRole(),
+			Creator:        permission.GetCreator(),
+			CanReshare:     permission.GetCanReshare(),
+			Message:        permission.GetMessage(),
+			Label:          permission.GetLabel(),
+			LastAccessedAt: lastAccessedAt,
+			ResourceType:   permission.GetResourceType(),
+			Labels:         permission.GetLabels(),
+			Source:         permission.GetSource(),
+		})
+	}
+
+	return filePermissions, nextPageToken, nil
+}
+
+// GetSharedFiles returns the files that both userA and userB have a permission to.
+// If grantedByA is true then only the files that userA shared with userB are returned.
+func (c Controller) GetSharedFiles(
+	ctx context.Context,
+	resourceType string,
+	userA string,
+	userB string,
+	grantedByA bool,
+) ([]*pb.GetSharedFilesResponse_SharedFile, error) {
+	var sharedFiles []service.SharedFile
+	err := c.permissions.WithCausalConsistency(ctx, func(ctx context.Context) (err error) {
+		sharedFiles, err = c.permissions.GetShared(ctx, resourceType, userA, userB, grantedByA)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]*pb.GetSharedFilesResponse_SharedFile, 0, len(sharedFiles))
+	for _, sharedFile := range sharedFiles {
+		files = append(files, &pb.GetSharedFilesResponse_SharedFile{
+			FileID:    sharedFile.FileID,
+			UserARole: sharedFile.UserARole,
+			UserBRole: sharedFile.UserBRole,
+		})
+	}
+
+	return files, nil
+}
+
+// ListPermissionChanges returns up to pageSize changes to the permissions of userID
+// that occurred after syncToken, in the order they occurred.
+func (c Controller) ListPermissionChanges(
+	ctx context.Context,
+	resourceType string,
+	userID string,
+	syncToken string,
+	pageSize int,
+) (service.PermissionChanges, error) {
+	var changes service.PermissionChanges
+	err := c.permissions.WithCausalConsistency(ctx, func(ctx context.Context) (err error) {
+		changes, err = c.permissions.ListChanges(ctx, resourceType, userID, syncToken, pageSize)
+		return err
+	})
+	if err != nil {
+		return service.PermissionChanges{}, err
+	}
+
+	return changes, nil
+}
+
+// UpgradeSchema upgrades the stored permissions whose shape is of an older version, batchSize permissions
+// at a time, and calls progress after each batch.
+func (c Controller) UpgradeSchema(
+	ctx context.Context,
+	batchSize int,
+	progress func(upgraded int64, total int64) error,
+) error {
+	return c.permissions.UpgradeSchema(ctx, batchSize, progress)
+}
+
+// GetFileSharingActivity returns up to pageSize of the recorded events of the permissions to fileID
+// that occurred since, newest first, after the event of pageToken, and the token of the next page.
+func (c Controller) GetFileSharingActivity(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	since time.Time,
+	pageSize int,
+	pageToken string,
+) ([]service.PermissionEvent, string, error) {
+	var events []service.PermissionEvent
+	var nextPageToken string
+	err := c.permissions.WithCausalConsistency(ctx, func(ctx context.Context) (err error) {
+		events, nextPageToken, err = c.permissions.ListFileEvents(
+			ctx,
+			resourceType,
+			fileID,
+			since,
+			pageSize,
+			pageToken,
+		)
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return events, nextPageToken, nil
+}
+
+// GetFolderSharingSummary returns the summary of the sharing of the tree of folderID, which is made
+// of folderID and descendantIDs, or of folderID and the files that inherit its permissions if descendantIDs
+// is empty.
+func (c Controller) GetFolderSharingSummary(
+	ctx context.Context,
+	resourceType string,
+	folderID string,
+	descendantIDs []string,
+) (service.SharingSummary, error) {
+	var summary service.SharingSummary
+	err := c.permissions.WithCausalConsistency(ctx, func(ctx context.Context) (err error) {
+		summary, err = c.permissions.SummarizeSharing(ctx, resourceType, folderID, descendantIDs)
+		return err
+	})
+	if err != nil {
+		return service.SharingSummary{}, err
+	}
+
+	return summary, nil
+}
+
+// ListSharedWithMe returns up to pageSize of the files of resourceType that other users shared with userID,
+// most recently shared first, after the file of pageToken, and the token of the next page.
+func (c Controller) ListSharedWithMe(
+	ctx context.Context,
+	resourceType string,
+	userID string,
+	pageSize int,
+	pageToken string,
+) ([]service.SharedWithMe, string, error) {
+	var files []service.SharedWithMe
+	var nextPageToken string
+	err := c.permissions.WithCausalConsistency(ctx, func(ctx context.Context) (err error) {
+		files, nextPageToken, err = c.permissions.ListSharedWithMe(ctx, resourceType, userID, pageSize, pageToken)
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return files, nextPageToken, nil
+}
+
+// GetUserData returns the permissions that userID holds, the permissions of other users
+// that userID created and the recorded events that reference userID.
+func (c Controller) GetUserData(ctx context.Context, userID string) (service.UserData, error) {
+	var userData service.UserData
+	err := c.permissions.WithCausalConsistency(ctx, func(ctx context.Context) (err error) {
+		userData.Held, err = c.permissions.GetByFilter(ctx, service.PermissionsFilter{UserIDs: []string{userID}})
+		if err != nil {
+			return err
+		}
+
+		created, err := c.permissions.GetByFilter(ctx, service.PermissionsFilter{Creator: userID})
+		if err != nil {
+			return err
+		}
+
+		// The permissions that userID created for itself are held by it.
+		userData.Created = make([]service.Permission, 0, len(created))
+		for _, permission := range created {
+			if permission.GetUserID() != userID {
+				userData.Created = append(userData.Created, permission)
+			}
+		}
+
+		userData.Events, err = c.permissions.GetEventsByUser(ctx, userID)
+		return err
+	})
+	if err != nil {
+		return service.UserData{}, err
+	}
+
+	return userData, nil
+}
+
+// EraseUserData deletes the permissions that userID holds and replaces userID with service.ErasedUserID
+// as the creator, and in the sharing chains, of the other permissions. The recorded events that reference
+// userID are kept until their retention expires, including the events of the deleted permissions.
+// The data may not be erased while a file of a permission that userID holds or created is under legal hold.
+func (c Controller) EraseUserData(ctx context.Context, userID string) (service.ErasureReport, error) {
+	var report service.ErasureReport
+	err := c.permissions.WithCausalConsistency(ctx, func(ctx context.Context) error {
+		held, err := c.permissions.GetByFilter(ctx, service.PermissionsFilter{UserIDs: []string{userID}})
+		if err != nil {
+			return err
+		}
+
+		created, err := c.permissions.GetByFilter(ctx, service.PermissionsFilter{Creator: userID})
+		if err != nil {
+			return err
+		}
+
+		if err := c.checkLegalHolds(ctx, append(append([]service.Permission{}, held...), created...)); err != nil {
+			return err
+		}
+
+		for _, permission := range held {
+			_, err := c.permissions.DeleteByID(ctx, permission.GetID())
+			if err != nil && status.Code(err) != codes.NotFound {
+				return err
+			}
+
+			if err == nil {
+				report.Deleted++
+			}
+		}
+
+		if report.Anonymized, err = c.permissions.ReplaceUser(ctx, userID, service.ErasedUserID); err != nil {
+			return err
+		}
+
+		events, err := c.permissions.GetEventsByUser(ctx, userID)
+		report.RetainedEvents = int64(len(events))
+		return err
+	})
+	if err != nil {
+		return service.ErasureReport{}, err
+	}
+
+	return report, nil
+}
+
+// DeleteFilePermissions deletes all permissions that exist for fileID, or only the ones
+// that match selector, and returns a slice of Permissions that were deleted.
+func (c Controller) DeleteFilePermissions(ctx context.Context,
+	resourceType string,
+	fileID string,
+	selector service.PermissionSelector) ([]*pb.PermissionObject, error) {
+	if err := c.checkLegalHold(ctx, resourceType, fileID); err != nil {
+		return nil, err
+	}
+
+	var deletedPermissions []*pb.PermissionObject
+	err := c.permissions.WithCausalConsistency(ctx, func(ctx context.Context) error {
+		permissions, err := c.permissions.GetByResource(
+			ctx,
+			resourceType,
+			fileID,
+			pb.PermissionsOrder_DEFAULT,
+			selector,
+		)
+		if err != nil {
+			return err
+		}
+
+		deletedPermissions = make([]*pb.PermissionObject, 0, len(permissions))
+		for _, permission := range permissions {
+			deletedPermission, err := c.permissions.DeleteByID(ctx, permission.GetID())
+			if err != nil {
+				return err
+			}
+
+			protoDeletedPermission := &pb.PermissionObject{}
+			if err := deletedPermission.MarshalProto(protoDeletedPermission); err != nil {
+				return err
+			}
+
+			deletedPermissions = append(deletedPermissions, protoDeletedPermission)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deletedPermissions, nil
+}
+
+// RevokeCascade deletes the permission of userID to fileID, and all the permissions to fileID
+// that userID reshared, directly or through the users it was reshared to, and returns them.
+// Permissions that were created before sharing chains were recorded are revoked only if
+// userID reshared them directly.
+func (c Controller) RevokeCascade(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+) ([]*pb.PermissionObject, error) {
+	if err := c.checkLegalHold(ctx, resourceType, fileID); err != nil {
+		return nil, err
+	}
+
+	var revokedPermissions []*pb.PermissionObject
+	err := c.permissions.WithCausalConsistency(ctx, func(ctx context.Context) error {
+		permission, err := c.permissions.Delete(ctx, resourceType, fileID, userID, "")
+		if err != nil {
+			return err
+		}
+
+		reshared, err := c.permissions.GetBySharer(ctx, resourceType, fileID, userID)
+		if err != nil {
+			return err
+		}
+
+		// Legacy permissions have no sharing chain, but their creator is the user that reshared them.
+		filePermissions, err := c.permissions.GetByResource(
+			ctx,
+			resourceType,
+			fileID,
+			pb.PermissionsOrder_DEFAULT,
+			service.PermissionSelector{},
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, filePermission := range filePermissions {
+			if filePermission.GetCreator() == userID && len(filePermission.GetSharingChain()) == 0 {
+				reshared = append(reshared, filePermission)
+			}
+		}
+
+		revokedPermissions = make([]*pb.PermissionObject, 0, len(reshared)+1)
+		protoPermission := &pb.PermissionObject{}
+		if err := permission.MarshalProto(protoPermission); err != nil {
+			return err
+		}
+
+		revokedPermissions = append(revokedPermissions, protoPermission)
+		for _, resharedPermission := range reshared {
+			revokedPermission, err := c.permissions.DeleteByID(ctx, resharedPermission.GetID())
+			if status.Code(err) == codes.NotFound {
+				continue
+			}
+
+			if err != nil {
+				return err
+			}
+
+			protoRevokedPermission := &pb.PermissionObject{}
+			if err := revokedPermission.MarshalProto(protoRevokedPermission); err != nil {
+				return err
+			}
+
+			revokedPermissions = append(revokedPermissions, protoRevokedPermission)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return revokedPermissions, nil
+}
+
+// RevokeAllExceptOwner deletes every permission to fileID other than the permission of owner, in a single
+// transaction with a single summary event, and returns them.
+func (c Controller) RevokeAllExceptOwner(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	owner string,
+) ([]*pb.PermissionObject, error) {
+	if err := c.checkLegalHold(ctx, resourceType, fileID); err != nil {
+		return nil, err
+	}
+
+	permissions, err := c.permissions.DeleteAllExceptUser(ctx, resourceType, fileID, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	revokedPermissions := make([]*pb.PermissionObject, 0, len(permissions))
+	for _, permission := range permissions {
+		protoPermission := &pb.PermissionObject{}
+		if err := permission.MarshalProto(protoPermission); err != nil {
+			return nil, err
+		}
+
+		revokedPermissions = append(revokedPermissions, protoPermission)
+	}
+
+	return revokedPermissions, nil
+}
+
+// AssignOwner makes userID the owner of the orphaned fileID, whose owner's account was deleted,
+// and returns its permission. Fails with codes.FailedPrecondition if fileID is locked down.
+func (c Controller) AssignOwner(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+) (service.Permission, error) {
+	if err := c.checkLegalHold(ctx, resourceType, fileID); err != nil {
+		return nil, err
+	}
+
+	// The lock of a locked down file is of its previous owner, so the new owner would have no access.
+	lock, err := c.GetFileLock(ctx, resourceType, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	if lock != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s %s is locked down", resourceType, fileID)
+	}
+
+	return c.permissions.AssignOwner(ctx, resourceType, fileID, userID)
+}
+
+// PermissionsExist returns whether each of the permissions of ids exists, in their order.
+func (c Controller) PermissionsExist(ctx context.Context, ids []string) ([]bool, error) {
+	existing, err := c.permissions.ExistingIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	exist := make([]bool, 0, len(ids))
+	for _, id := range ids {
+		exist = append(exist, existing[id])
+	}
+
+	return exist, nil
+}
+
+// TouchPermission sets the last access time of the permission that matches fileID and userID
+// to the current time and returns the updated permission.
+func (c Controller) TouchPermission(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+) (service.Permission, error) {
+	var permission service.Permission
+	err := c.permissions.WithCausalConsistency(ctx, func(ctx context.Context) (err error) {
+		permission, err = c.permissions.Touch(ctx, resourceType, fileID, userID, time.Now())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return permission, nil
+}
+
+// ListFilePermissions returns up to pageSize permissions of fileID that match selector and come after
+// pageToken, ordered by their creation, and the token of the next page,
+// which is empty if there are no more pages.
+func (c Controller) ListFilePermissions(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	pageSize int,
+	pageToken string,
+	fields []service.PermissionField,
+	selector service.PermissionSelector,
+) ([]service.Permission, string, error) {
+	var permissions []service.Permission
+	var nextPageToken string
+	err := c.permissions.WithCausalConsistency(ctx, func(ctx context.Context) (err error) {
+		permissions, nextPageToken, err = c.permissions.ListByResource(
+			ctx,
+			resourceType,
+			fileID,
+			pb.PermissionsOrder_DEFAULT,
+			pageSize,
+			pageToken,
+			fields,
+			selector,
+		)
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return permissions, nextPageToken, nil
+}
+
+// ListDomainPermissions returns up to pageSize permissions that were given to the organization of domain,
+// or to every organization if domain is empty, that come after pageToken, ordered by their creation,
+// and the token of the next page, which is empty if there are no more pages.
+func (c Controller) ListDomainPermissions(
+	ctx context.Context,
+	domain string,
+	pageSize int,
+	pageToken string,
+) ([]service.Permission, string, error) {
+	var permissions []service.Permission
+	var nextPageToken string
+	err := c.permissions.WithCausalConsistency(ctx, func(ctx context.Context) (err error) {
+		permissions, nextPageToken, err = c.permissions.ListByGranteeType(
+			ctx,
+			service.GranteeTypeDomain,
+			domain,
+			pageSize,
+			pageToken,
+		)
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return permissions, nextPageToken, nil
+}
+
+// ListStaleGrants returns up to pageSize direct permissions of resourceType, or of every resource type
+// if it's empty, that weren't accessed since before, or were never accessed and were created before it,
+// other than the permissions of the owners, that come after pageToken, ordered by their creation,
+// and the token of the next page, which is empty if there are no more pages.
+func (c Controller) ListStaleGrants(
+	ctx context.Context,
+	resourceType string,
+	before time.Time,
+	pageSize int,
+	pageToken string,
+) ([]service.Permission, string, error) {
+	return c.permissions.ListStale(ctx, resourceType, before, pageSize, pageToken)
+}
+
+// ExpireStaleGrants deletes the permissions that are stale since before, as listed by ListStaleGrants,
+// batchSize permissions at a time, and calls progress after each batch with the numbers of the permissions
+// that were expired and of the ones that were skipped since their files are held.
+func (c Controller) ExpireStaleGrants(
+	ctx context.Context,
+	before time.Time,
+	batchSize int,
+	progress func(expired int64, skipped int64) error,
+) error {
+	var expired, skipped int64
+	pageToken := ""
+	for {
+		permissions, nextPageToken, err := c.permissions.ListStale(ctx, "", before, batchSize, pageToken)
+		if err != nil {
+			return err
+		}
+
+		fileIDsByType := map[string][]string{}
+		for _, permission := range permissions {
+			resourceType := permission.GetResourceType()
+			fileIDsByType[resourceType] = append(fileIDsByType[resourceType], permission.GetFileID())
+		}
+
+		held, err := c.heldFiles(ctx, fileIDsByType)
+		if err != nil {
+			return err
+		}
+
+		for _, permission := range permissions {
+			if held[heldFile{resourceType: permission.GetResourceType(), fileID: permission.GetFileID()}] {
+				skipped++
+				continue
+			}
+
+			_, err := c.permissions.DeleteByID(ctx, permission.GetID())
+			if status.Code(err) == codes.NotFound {
+				continue
+			}
+
+			if err != nil {
+				return err
+			}
+
+			expired++
+		}
+
+		if err := progress(expired, skipped); err != nil {
+			return err
+		}
+
+		if nextPageToken == "" {
+			return nil
+		}
+
+		pageToken = nextPageToken
+	}
+}
+
+// UpdatePermission updates the fields of the permission that matches fileID and userID
+// to their values in update and returns the updated permission.
+func (c Controller) UpdatePermission(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+	etag string,
+	update service.PermissionUpdate,
+	fields []service.PermissionField,
+) (service.Permission, error) {
+	if len(fields) == 0 {
+		return c.GetByFileAndUser(ctx, resourceType, fileID, userID)
+	}
+
+	if err := c.checkLegalHold(ctx, resourceType, fileID); err != nil {
+		return nil, err
+	}
+
+	var updatedPermission service.Permission
+	err := c.permissions.WithCausalConsistency(ctx, func(ctx context.Context) (err error) {
+		updatedPermission, err = c.permissions.Update(ctx, resourceType, fileID, userID, etag, update, fields)
+		if status.Code(err) == codes.NotFound {
+			return c.notFoundError(ctx, resourceType, fileID, userID, etag)
+		}
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedPermission, nil
+}
+
+// UpdateRoles changes the roles of the permissions of updates with a single bulk write, and returns
+// the result of each update in the order of updates. An update of a permission that doesn't exist fails
+// with codes.NotFound, an update whose etag isn't the permission's current etag fails with codes.Aborted,
+// and an update of a permission whose file is under legal hold fails with codes.FailedPrecondition.
+func (c Controller) UpdateRoles(
+	ctx context.Context,
+	updates []service.RoleUpdate,
+) ([]service.RoleUpdateResult, error) {
+	results := make([]service.RoleUpdateResult, len(updates))
+	err := c.permissions.WithCausalConsistency(ctx, func(ctx context.Context) error {
+		fileIDsByType := make(map[string][]string)
+		for _, update := range updates {
+			fileIDsByType[update.ResourceType] = append(fileIDsByType[update.ResourceType], update.FileID)
+		}
+
+		held, err := c.heldFiles(ctx, fileIDsByType)
+		if err != nil {
+			return err
+		}
+
+		pending := make([]service.RoleUpdate, 0, len(updates))
+		pendingIndexes := make([]int, 0, len(updates))
+		for i, update := range updates {
+			if held[heldFile{resourceType: update.ResourceType, fileID: update.FileID}] {
+				results[i].Err = legalHoldError(update.ResourceType, update.FileID)
+				continue
+			}
+
+			pending = append(pending, update)
+			pendingIndexes = append(pendingIndexes, i)
+		}
+
+		if len(pending) == 0 {
+			return nil
+		}
+
+		pendingResults, err := c.permissions.UpdateRoles(ctx, pending)
+		if err != nil {
+			return err
+		}
+
+		for i, result := range pendingResults {
+			results[pendingIndexes[i]] = result
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// CopyPermissions copies the permissions that were given directly to sourceFileID to destFileID.
+// The existing permissions of destFileID are overridden if overwrite is true, otherwise they're kept.
+// They may not be overridden while destFileID is under legal hold.
+func (c Controller) CopyPermissions(
+	ctx context.Context,
+	resourceType string,
+	sourceFileID string,
+	destFileID string,
+	overwrite bool,
+) (service.CopyReport, error) {
+	if overwrite {
+		if err := c.checkLegalHold(ctx, resourceType, destFileID); err != nil {
+			return service.CopyReport{}, err
+		}
+	}
+
+	var report service.CopyReport
+	err := c.permissions.WithCausalConsistency(ctx, func(ctx context.Context) (err error) {
+		report, err = c.permissions.CopyPermissions(ctx, resourceType, sourceFileID, destFileID, overwrite)
+		return err
+	})
+	if err != nil {
+		return service.CopyReport{}, err
+	}
+
+	return report, nil
+}
+
+// ReplaceInherited replaces the permissions of the files of subtree that are inherited from outside of it
+// with the permissions of parentID, that subtree was moved into, or deletes them if parentID is empty.
+// A permission that's kept, such as a direct permission, is replaced by an inherited one by the merge strategy
+// of resourceType.
+// Fails with codes.FailedPrecondition if a file of subtree is under legal hold.
+func (c Controller) ReplaceInherited(
+	ctx context.Context,
+	resourceType string,
+	subtree []service.FileNode,
+	parentID string,
+) (service.InheritanceReport, error) {
+	fileIDs := make([]string, 0, len(subtree))
+	for _, node := range subtree {
+		fileIDs = append(fileIDs, node.FileID)
+	}
+
+	if err := c.checkLegalHold(ctx, resourceType, fileIDs...); err != nil {
+		return service.InheritanceReport{}, err
+	}
+
+	var report service.InheritanceReport
+	err := c.permissions.WithCausalConsistency(ctx, func(ctx context.Context) (err error) {
+		strategy := c.mergeStrategies.For(resourceType)
+		report, err = c.permissions.ReplaceInherited(ctx, resourceType, subtree, parentID, strategy)
+		return err
+	})
+	if err != nil {
+		return service.InheritanceReport{}, err
+	}
+
+	return report, nil
+}
+
+// ScheduleUpdate schedules the update of the fields of the permission that matches fileID and userID
+// to their values in update at scheduledAt, and returns the current permission.
+// If etag is not empty then it must be the permission's current etag.
+func (c Controller) ScheduleUpdate(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+	etag string,
+	update service.PermissionUpdate,
+	fields []service.PermissionField,
+	scheduledAt time.Time,
+) (service.Permission, error) {
+	if err := c.checkLegalHold(ctx, resourceType, fileID); err != nil {
+		return nil, err
+	}
+
+	var permission service.Permission
+	err := c.permissions.WithCausalConsistency(ctx, func(ctx context.Context) (err error) {
+		permission, err = c.permissions.Get(ctx, resourceType, fileID, userID)
+		if err != nil {
+			return err
+		}
+
+		if etag != "" && permission.GetETag() != etag {
+			return status.Errorf(codes.Aborted, "permission etag %s does not match the current etag", etag)
+		}
+
+		_, err = c.schedules.ScheduleUpdate(ctx, service.ScheduledUpdate{
+			ResourceType: resourceType,
+			FileID:       fileID,
+			UserID:       userID,
+			Update:       update,
+			Fields:       fields,
+			ScheduledAt:  scheduledAt,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return permission, nil
+}
+
+// RequestPermission stores request, of a permission that requires approval, and returns it with its ID.
+func (c Controller) RequestPermission(
+	ctx context.Context,
+	request service.PermissionRequest,
+) (service.PermissionRequest, error) {
+	request.RequestedAt = time.Now()
+	return c.approvals.CreatePermissionRequest(ctx, request)
+}
+
+// ApprovePermissionRequest approves the pending request with id to fileID by approver,
+// who may not be its requester, creates its permission, or updates the existing permission to it,
+// and returns the approved request, which references the permission.
+func (c Controller) ApprovePermissionRequest(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	id string,
+	approver string,
+) (service.PermissionRequest, error) {
+	request, err := c.approvals.GetPermissionRequest(ctx, id)
+	if err != nil {
+		return service.PermissionRequest{}, err
+	}
+
+	if request.ResourceType != resourceType || request.FileID != fileID {
+		return service.PermissionRequest{}, status.Errorf(codes.NotFound, "permission request %s not found", id)
+	}
+
+	if request.Approver != "" {
+		return service.PermissionRequest{}, service.RejectionError(
+			codes.FailedPrecondition,
+			service.Rejection{Kind: service.RejectionInvariant, Rule: "single_approval", Subject: id},
+			"permission request %s was already approved",
+			id,
+		)
+	}
+
+	if approver == request.Creator {
+		return service.PermissionRequest{}, service.RejectionError(
+			codes.PermissionDenied,
+			service.Rejection{Kind: service.RejectionPolicy, Rule: "separate_approver", Subject: approver},
+			"permission request %s may not be approved by its requester",
+			id,
+		)
+	}
+
+	permission, err := c.CreatePermission(
+		ctx,
+		request.ResourceType,
+		request.FileID,
+		request.UserID,
+		request.Update.Role,
+		request.Creator,
+		true,
+		request.Update.CanReshare,
+		request.Update.Message,
+		request.Update.Label,
+		request.Update.ResourceKind,
+		request.Update.GranteeType,
+		request.Update.Labels,
+		request.Update.Source,
+	)
+	if err != nil {
+		return service.PermissionRequest{}, err
+	}
+
+	return c.approvals.ApprovePermissionRequest(ctx, id, approver, time.Now(), permission.GetID())
+}
+
+// ApplyDueUpdates applies the scheduled updates that are due at now, one at a time, claiming each
+// for lease, and returns the number of updates that were applied. An update of a permission that no
+// longer exists, or whose file is under legal hold, is marked as failed. It stops at the first update
+// that fails for another reason, which is retried once its claim expires.
+func (c Controller) ApplyDueUpdates(ctx context.Context, now time.Time, lease time.Duration) (int, error) {
+	applied := 0
+	for {
+		update, err := c.schedules.ClaimDueUpdate(ctx, now, lease)
+		if err != nil || update == nil {
+			return applied, err
+		}
+
+		_, err = c.UpdatePermission(
+			ctx,
+			update.ResourceType,
+			update.FileID,
+			update.UserID,
+			"",
+			update.Update,
+			update.Fields,
+		)
+		if code := status.Code(err); code == codes.NotFound || code == codes.FailedPrecondition {
+			if err := c.schedules.FailUpdate(ctx, update.ID, err.Error()); err != nil {
+				return applied, err
+			}
+
+			continue
+		}
+
+		if err != nil {
+			return applied, err
+		}
+
+		if err := c.schedules.CompleteUpdate(ctx, update.ID); err != nil {
+			return applied, err
+		}
+
+		applied++
+	}
+}
+
+// MigrateRole changes the role of the permissions that match filter from fromRole to toRole,
+// batchSize permissions at a time, and calls progress after each batch with the number of
+// permissions migrated so far and the number of permissions that matched when the migration started.
+// The migration stops if progress returns an error. Migrating again would continue where it stopped.
+// Fails with codes.FailedPrecondition if a permission that would be migrated is of a file under legal hold.
+func (c Controller) MigrateRole(
+	ctx context.Context,
+	fromRole pb.Role,
+	toRole pb.Role,
+	filter service.PermissionsFilter,
+	batchSize int,
+	progress func(migrated int64, total int64) error,
+) error {
+	if err := c.checkMigrationHolds(ctx, fromRole, filter); err != nil {
+		return err
+	}
+
+	return c.permissions.MigrateRole(ctx, fromRole, toRole, filter, batchSize, progress)
+}
+
+// RepairPermissions scans the permission documents for malformed ones, batchSize documents at a time,
+// fixes or quarantines them by action and calls progress after each batch that has malformed documents.
+func (c Controller) RepairPermissions(
+	ctx context.Context,
+	action service.RepairAction,
+	batchSize int,
+	progress func(malformed []service.MalformedPermission) error,
+) error {
+	return c.permissions.RepairPermissions(ctx, action, batchSize, progress)
+}
+
+// ExportTenantData calls export with each of the documents of tenantID.
+func (c Controller) ExportTenantData(
+	ctx context.Context,
+	tenantID string,
+	export func(record service.TenantRecord) error,
+) error {
+	if c.tenants == nil {
+		return status.Error(codes.FailedPrecondition, "tenants may not be offboarded")
+	}
+
+	return c.tenants.ExportTenantData(ctx, tenantID, export)
+}
+
+// PreviewTenantPurge counts the data of the tenant of purge, and stores the confirmation of purge
+// until service.TenantPurgeConfirmationTTL from now. It returns purge with the counted data.
+func (c Controller) PreviewTenantPurge(
+	ctx context.Context,
+	purge service.TenantPurge,
+) (service.TenantPurge, error) {
+	if c.tenants == nil {
+		return service.TenantPurge{}, status.Error(codes.FailedPrecondition, "tenants may not be offboarded")
+	}
+
+	counted, err := c.tenants.CountTenantData(ctx, purge.TenantID)
+	if err != nil {
+		return service.TenantPurge{}, err
+	}
+
+	purge.Collections, purge.Documents = counted.Collections, counted.Documents
+	purge.ExpiresAt = time.Now().Add(service.TenantPurgeConfirmationTTL)
+	if err := c.tenants.CreateTenantPurge(ctx, purge); err != nil {
+		return service.TenantPurge{}, err
+	}
+
+	return purge, nil
+}
+
+// PurgeTenant purges the data of tenantID once token confirms the purge, and returns the purge.
+// A token confirms a single purge, fails with codes.FailedPrecondition if token is invalid or expired.
+func (c Controller) PurgeTenant(
+	ctx context.Context,
+	tenantID string,
+	token string,
+	confirmedBy string,
+) (service.TenantPurge, error) {
+	if c.tenants == nil {
+		return service.TenantPurge{}, status.Error(codes.FailedPrecondition, "tenants may not be offboarded")
+	}
+
+	confirmation, err := c.tenants.ConfirmTenantPurge(ctx, tenantID, token, time.Now())
+	if err != nil {
+		return service.TenantPurge{}, err
+	}
+
+	purge, err := c.tenants.PurgeTenantData(ctx, tenantID)
+	if err != nil {
+		return service.TenantPurge{}, err
+	}
+
+	purge.RequestedBy, purge.ConfirmedBy = confirmation.RequestedBy, confirmedBy
+	return purge, nil
+}
+
+// GetTenantRateLimits returns the rate limits of tenantID, or of the controller's tenant if it's empty.
+func (c Controller) GetTenantRateLimits(ctx context.Context, tenantID string) (service.TenantRateLimits, error) {
+	if c.tenants == nil {
+		return service.TenantRateLimits{TenantID: tenantID}, nil
+	}
+
+	return c.tenants.GetTenantRateLimits(ctx, tenantID)
+}
+
+// SetTenantRateLimits replaces the rate limits of the tenant of limits, and returns them.
+func (c Controller) SetTenantRateLimits(
+	ctx context.Context,
+	limits service.TenantRateLimits,
+) (service.TenantRateLimits, error) {
+	if c.tenants == nil {
+		return service.TenantRateLimits{}, status.Error(codes.FailedPrecondition, "tenants may not be rate limited")
+	}
+
+	return c.tenants.SetTenantRateLimits(ctx, limits)
+}
+
+// LockFile locks down the file of lock, or replaces its lock, at the current time and returns the lock.
+// Until the file is unlocked, GetByFileAndUser fails with codes.NotFound for every user
+// other than the lock's owner.
+func (c Controller) LockFile(ctx context.Context, lock service.FileLock) (service.FileLock, error) {
+	if c.locks == nil {
+		return service.FileLock{}, status.Error(codes.FailedPrecondition, "files may not be locked down")
+	}
+
+	lock.LockedAt = time.Now()
+	return c.locks.SetFileLock(ctx, lock)
+}
+
+// UnlockFile lifts the lockdown of fileID and returns its lock, fails with codes.NotFound if it isn't locked.
+func (c Controller) UnlockFile(ctx context.Context, resourceType string, fileID string) (service.FileLock, error) {
+	if c.locks == nil {
+		return service.FileLock{}, status.Errorf(codes.NotFound, "%s %s is not locked", resourceType, fileID)
+	}
+
+	return c.locks.DeleteFileLock(ctx, resourceType, fileID)
+}
+
+// GetFileLock returns the lock of fileID, or nil if it isn't locked.
+func (c Controller) GetFileLock(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+) (*service.FileLock, error) {
+	if c.locks == nil {
+		return nil, nil
+	}
+
+	return c.locks.GetFileLock(ctx, resourceType, fileID)
+}
+
+// checkFileLock returns a codes.NotFound error if the access of userID to fileID is suspended by a lockdown,
+// so that it's denied like the access of a user without a permission, before the permission is read.
+func (c Controller) checkFileLock(ctx context.Context, resourceType string, fileID string, userID string) error {
+	if c.locks == nil {
+		return nil
+	}
+
+	lock, err := c.locks.GetFileLock(ctx, resourceType, fileID)
+	if err != nil {
+		return err
+	}
+
+	if !lock.Permits(userID) {
+		return status.Errorf(codes.NotFound, "access to %s %s is suspended by a lockdown", resourceType, fileID)
+	}
+
+	return nil
+}
+
+// PlaceLegalHold places the legal hold of hold's file, or replaces its hold, at the current time and returns it.
+// Until the hold is released, the file's permissions may not be deleted or modified, and their audit records
+// don't expire.
+func (c Controller) PlaceLegalHold(ctx context.Context, hold service.LegalHold) (service.LegalHold, error) {
+	if c.holds == nil {
+		return service.LegalHold{}, status.Error(codes.FailedPrecondition, "files may not be held")
+	}
+
+	hold.PlacedAt = time.Now()
+	return c.holds.SetLegalHold(ctx, hold)
+}
+
+// ReleaseLegalHold releases the legal hold of fileID and returns it, fails with codes.NotFound if it isn't held.
+func (c Controller) ReleaseLegalHold(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+) (service.LegalHold, error) {
+	if c.holds == nil {
+		return service.LegalHold{}, status.Errorf(
+			codes.NotFound,
+			"%s %s is not under legal hold",
+			resourceType,
+			fileID,
+		)
+	}
+
+	return c.holds.DeleteLegalHold(ctx, resourceType, fileID)
+}
+
+// CreateJob stores job and returns it with its ID.
+func (c Controller) CreateJob(ctx context.Context, job service.Job) (service.Job, error) {
+	if c.jobs == nil {
+		return service.Job{}, status.Error(codes.FailedPrecondition, "jobs may not be created")
+	}
+
+	return c.jobs.CreateJob(ctx, job)
+}
+
+// GetJob returns the job with id, fails with codes.NotFound if it doesn't exist.
+func (c Controller) GetJob(ctx context.Context, id string) (service.Job, error) {
+	if c.jobs == nil {
+		return service.Job{}, status.Errorf(codes.NotFound, "job %s not found", id)
+	}
+
+	return c.jobs.GetJob(ctx, id)
+}
+
+// ListJobs returns up to pageSize jobs of kind, or of every kind if it's empty, that come after pageToken,
+// newest first, and the token of the next page, which is empty if there are no more pages.
+func (c Controller) ListJobs(
+	ctx context.Context,
+	kind string,
+	pageSize int,
+	pageToken string,
+) ([]service.Job, string, error) {
+	if c.jobs == nil {
+		return nil, "", nil
+	}
+
+	return c.jobs.ListJobs(ctx, kind, pageSize, pageToken)
+}
+
+// UpdateJobProgress sets the progress of the running job with id, at updatedAt, and returns it.
+func (c Controller) UpdateJobProgress(
+	ctx context.Context,
+	id string,
+	progress service.JobProgress,
+	updatedAt time.Time,
+) (service.Job, error) {
+	if c.jobs == nil {
+		return service.Job{}, status.Errorf(codes.NotFound, "job %s not found", id)
+	}
+
+	return c.jobs.UpdateJobProgress(ctx, id, progress, updatedAt)
+}
+
+// FinishJob sets the state of the running job with id to state, with progress, and message if it failed,
+// at finishedAt, and returns it.
+func (c Controller) FinishJob(
+	ctx context.Context,
+	id string,
+	state service.JobState,
+	progress service.JobProgress,
+	message string,
+	finishedAt time.Time,
+) (service.Job, error) {
+	if c.jobs == nil {
+		return service.Job{}, status.Errorf(codes.NotFound, "job %s not found", id)
+	}
+
+	return c.jobs.FinishJob(ctx, id, state, progress, message, finishedAt)
+}
+
+// CancelJob requests the cancellation of the running job with id and returns it,
+// fails with codes.FailedPrecondition if the job already finished.
+func (c Controller) CancelJob(ctx context.Context, id string) (service.Job, error) {
+	if c.jobs == nil {
+		return service.Job{}, status.Errorf(codes.NotFound, "job %s not found", id)
+	}
+
+	return c.jobs.CancelJob(ctx, id)
+}
+
+// FailStaleJobs fails the running jobs that weren't updated since staleBefore with message,
+// and returns their number.
+func (c Controller) FailStaleJobs(ctx context.Context, staleBefore time.Time, message string) (int64, error) {
+	if c.jobs == nil {
+		return 0, nil
+	}
+
+	return c.jobs.FailStaleJobs(ctx, staleBefore, message)
+}
+
+// PurgeJobs deletes the jobs that finished before finishedBefore and returns their number.
+func (c Controller) PurgeJobs(ctx context.Context, finishedBefore time.Time) (int64, error) {
+	if c.jobs == nil {
+		return 0, nil
+	}
+
+	return c.jobs.PurgeJobs(ctx, finishedBefore)
+}
+
+// ClaimRecurringRun claims the run of the recurring job of kind at runAt, and returns true if it was
+// claimed, or false if it was already claimed, such as by another instance of the service.
+func (c Controller) ClaimRecurringRun(ctx context.Context, kind string, runAt time.Time) (bool, error) {
+	if c.jobs == nil {
+		return false, status.Error(codes.FailedPrecondition, "jobs may not be created")
+	}
+
+	return c.jobs.ClaimRecurringRun(ctx, kind, runAt)
+}
+
+// PurgeFailedUpdates deletes the scheduled updates that failed before failedBefore and returns their number.
+func (c Controller) PurgeFailedUpdates(ctx context.Context, failedBefore time.Time) (int64, error) {
+	return c.schedules.PurgeFailedUpdates(ctx, failedBefore)
+}
+
+// heldFile identifies a file under legal hold.
+type heldFile struct {
+	resourceType string
+	fileID       string
+}
+
+// legalHoldError returns the error of a change to the permissions of fileID, which is under legal hold.
+func legalHoldError(resourceType string, fileID string) error {
+	return service.RejectionError(
+		codes.FailedPrecondition,
+		service.Rejection{
+			Kind:    service.RejectionInvariant,
+			Rule:    "legal_hold",
+			Subject: service.ResourceName(resourceType, fileID),
+		},
+		"%s %s is under legal hold",
+		resourceType,
+		fileID,
+	)
+}
+
+// heldFiles returns the files of fileIDsByType, which maps resource types to file IDs, that are under legal hold.
+func (c Controller) heldFiles(ctx context.Context, fileIDsByType map[string][]string) (map[heldFile]bool, error) {
+	held := make(map[heldFile]bool)
+	if c.holds == nil {
+		return held, nil
+	}
+
+	for resourceType, fileIDs := range fileIDsByType {
+		holds, err := c.holds.GetLegalHolds(ctx, resourceType, fileIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, hold := range holds {
+			held[heldFile{resourceType: hold.ResourceType, fileID: hold.FileID}] = true
+		}
+	}
+
+	return held, nil
+}
+
+// checkLegalHold returns a codes.FailedPrecondition error if any of fileIDs is under legal hold.
+func (c Controller) checkLegalHold(ctx context.Context, resourceType string, fileIDs ...string) error {
+	if c.holds == nil || len(fileIDs) == 0 {
+		return nil
+	}
+
+	holds, err := c.holds.GetLegalHolds(ctx, resourceType, fileIDs)
+	if err != nil {
+		return err
+	}
+
+	if len(holds) > 0 {
+		return legalHoldError(holds[0].ResourceType, holds[0].FileID)
+	}
+
+	return nil
+}
+
+// checkLegalHolds returns a codes.FailedPrecondition error if the file of any of permissions is under legal hold.
+func (c Controller) checkLegalHolds(ctx context.Context, permissions []service.Permission) error {
+	fileIDsByType := make(map[string][]string)
+	for _, permission := range permissions {
+		resourceType := permission.GetResourceType()
+		fileIDsByType[resourceType] = append(fileIDsByType[resourceType], permission.GetFileID())
+	}
+
+	held, err := c.heldFiles(ctx, fileIDsByType)
+	if err != nil {
+		return err
+	}
+
+	for _, permission := range permissions {
+		file := heldFile{resourceType: permission.GetResourceType(), fileID: permission.GetFileID()}
+		if held[file] {
+			return legalHoldError(file.resourceType, file.fileID)
+		}
+	}
+
+	return nil
+}
+
+// checkMigrationHolds returns a codes.FailedPrecondition error if a permission of fromRole that matches filter
+// is of a file under legal hold. Holds are rare, so the held files are listed rather than
+// the migrated permissions.
+func (c Controller) checkMigrationHolds(
+	ctx context.Context,
+	fromRole pb.Role,
+	filter service.PermissionsFilter,
+) error {
+	if c.holds == nil {
+		return nil
+	}
+
+	holds, err := c.holds.ListLegalHolds(ctx, filter.ResourceType)
+	if err != nil {
+		return err
+	}
+
+	migrated := make(map[string]bool, len(filter.FileIDs))
+	for _, fileID := range filter.FileIDs {
+		migrated[fileID] = true
+	}
+
+	held := make(map[heldFile]bool, len(holds))
+	heldFilter := filter
+	heldFilter.FileIDs = nil
+	for _, hold := range holds {
+		if len(filter.FileIDs) == 0 || migrated[hold.FileID] {
+			held[heldFile{resourceType: hold.ResourceType, fileID: hold.FileID}] = true
+			heldFilter.FileIDs = append(heldFilter.FileIDs, hold.FileID)
+		}
+	}
+
+	if len(heldFilter.FileIDs) == 0 {
+		return nil
+	}
+
+	permissions, err := c.permissions.GetByFilter(ctx, heldFilter)
+	if err != nil {
+		return err
+	}
+
+	for _, permission := range permissions {
+		file := heldFile{resourceType: permission.GetResourceType(), fileID: permission.GetFileID()}
+		if permission.GetRole() == fromRole && held[file] {
+			return legalHoldError(file.resourceType, file.fileID)
+		}
+	}
+
+	return nil
+}
+
+// SamplePermissions returns up to size permissions chosen at random.
+func (c Controller) SamplePermissions(ctx context.Context, size int) ([]service.Permission, error) {
+	var permissions []service.Permission
+	err := c.permissions.WithCausalConsistency(ctx, func(ctx context.Context) (err error) {
+		permissions, err = c.permissions.Sample(ctx, size)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return permissions, nil
+}
+
+// CountPermissions returns the number of permissions of each resource type and role.
+func (c Controller) CountPermissions(ctx context.Context) ([]service.PermissionCount, error) {
+	return c.permissions.CountPermissions(ctx)
+}
+
+// RefreshCollaborators recomputes the frequent collaborators of the users and returns the number of
+// the pairs of users.
+func (c Controller) RefreshCollaborators(ctx context.Context) (int64, error) {
+	return c.permissions.RefreshCollaborators(ctx)
+}
+
+// GetFrequentCollaborators returns up to limit of the frequent collaborators of userID,
+// most frequent first.
+func (c Controller) GetFrequentCollaborators(
+	ctx context.Context,
+	userID string,
+	limit int,
+) ([]service.Collaborator, error) {
+	return c.permissions.GetFrequentCollaborators(ctx, userID, limit)
+}
+
+// CreateAccessReview starts review at the current time, with its items snapshotted from the current
+// permissions, and returns it.
+func (c Controller) CreateAccessReview(
+	ctx context.Context,
+	review service.AccessReview,
+) (service.AccessReview, error) {
+	if c.reviews == nil {
+		return service.AccessReview{}, status.Error(codes.FailedPrecondition, "access reviews may not be created")
+	}
+
+	review.CreatedAt = time.Now()
+	review.State = service.AccessReviewOpen
+	return c.reviews.CreateAccessReview(ctx, review)
+}
+
+// GetAccessReview returns the access review campaign with id.
+func (c Controller) GetAccessReview(ctx context.Context, id string) (service.AccessReview, error) {
+	if c.reviews == nil {
+		return service.AccessReview{}, status.Error(codes.FailedPrecondition, "access reviews may not be created")
+	}
+
+	return c.reviews.GetAccessReview(ctx, id)
+}
+
+// ListAccessReviews returns up to pageSize access review campaigns that come after pageToken, newest first,
+// and the token of the next page.
+func (c Controller) ListAccessReviews(
+	ctx context.Context,
+	pageSize int,
+	pageToken string,
+) ([]service.AccessReview, string, error) {
+	if c.reviews == nil {
+		return nil, "", status.Error(codes.FailedPrecondition, "access reviews may not be created")
+	}
+
+	return c.reviews.ListAccessReviews(ctx, pageSize, pageToken)
+}
+
+// ListAccessReviewItems returns up to pageSize items of the access review campaign with reviewID that match
+// filter and come after pageToken, and the token of the next page.
+func (c Controller) ListAccessReviewItems(
+	ctx context.Context,
+	reviewID string,
+	filter service.AccessReviewItemFilter,
+	pageSize int,
+	pageToken string,
+) ([]service.AccessReviewItem, string, error) {
+	if c.reviews == nil {
+		return nil, "", status.Error(codes.FailedPrecondition, "access reviews may not be created")
+	}
+
+	// The campaign's existence is verified so that an unknown campaign isn't listed as empty.
+	if _, err := c.reviews.GetAccessReview(ctx, reviewID); err != nil {
+		return nil, "", err
+	}
+
+	return c.reviews.ListAccessReviewItems(ctx, reviewID, filter, pageSize, pageToken)
+}
+
+// DecideAccessReviewItems records the decisions of reviewer of its items of the open access review campaign
+// with reviewID at the current time, and returns the decided items.
+func (c Controller) DecideAccessReviewItems(
+	ctx context.Context,
+	reviewID string,
+	reviewer string,
+	decisions []service.AccessReviewDecision,
+) ([]service.AccessReviewItem, error) {
+	if c.reviews == nil {
+		return nil, status.Error(codes.FailedPrecondition, "access reviews may not be created")
+	}
+
+	return c.reviews.DecideAccessReviewItems(ctx, reviewID, reviewer, decisions, time.Now())
+}
+
+// CloseAccessReview closes the access review campaign with id, so that its items may no longer be decided,
+// then revokes the permissions that were decided to be revoked, and the undecided ones if revokeUndecided
+// is true, in batches, and returns the closed campaign. The permissions of held files aren't revoked, and
+// neither are the ones that were already deleted. A campaign whose closing was interrupted is closed again.
+func (c Controller) CloseAccessReview(
+	ctx context.Context,
+	id string,
+	closedBy string,
+	revokeUndecided bool,
+) (service.AccessReview, error) {
+	if c.reviews == nil {
+		return service.AccessReview{}, status.Error(codes.FailedPrecondition, "access reviews may not be created")
+	}
+
+	review, err := c.reviews.StartClosingAccessReview(ctx, id, closedBy, revokeUndecided)
+	if err != nil {
+		return service.AccessReview{}, err
+	}
+
+	filter := service.AccessReviewItemFilter{Decisions: []service.ReviewDecision{service.ReviewRevoked}}
+	if review.RevokeUndecided {
+		filter.Decisions = append(filter.Decisions, service.ReviewPending)
+	}
+
+	var revoked, skipped int64
+	pageToken := ""
+	for {
+		items, nextPageToken, err := c.reviews.ListAccessReviewItems(
+			ctx,
+			id,
+			filter,
+			accessReviewRevocationBatchSize,
+			pageToken,
+		)
+		if err != nil {
+			return service.AccessReview{}, err
+		}
+
+		fileIDs := make([]string, 0, len(items))
+		for _, item := range items {
+			fileIDs = append(fileIDs, item.FileID)
+		}
+
+		held, err := c.heldFiles(ctx, map[string][]string{review.ResourceType: fileIDs})
+		if err != nil {
+			return service.AccessReview{}, err
+		}
+
+		for _, item := range items {
+			if held[heldFile{resourceType: item.ResourceType, fileID: item.FileID}] {
+				skipped++
+				continue
+			}
+
+			_, err := c.permissions.DeleteByID(ctx, item.PermissionID)
+			if status.Code(err) == codes.NotFound {
+				continue
+			}
+
+			if err != nil {
+				return service.AccessReview{}, err
+			}
+
+			revoked++
+		}
+
+		if nextPageToken == "" {
+			break
+		}
+
+		pageToken = nextPageToken
+	}
+
+	return c.reviews.FinishClosingAccessReview(ctx, id, revoked, skipped, time.Now())
+}
+
+// notFoundError returns the error of a permission that matches fileID and userID that was not found.
+// If etag is not empty and the permission exists then its etag didn't match, and an Aborted error is returned.
+func (c Controller) notFoundError(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+	etag string,
+) error {
+	if etag == "" {
+		return status.Error(codes.NotFound, "permission not found")
+	}
+
+	_, err := c.permissions.Get(ctx, resourceType, fileID, userID)
+	if err != nil {
+		return err
+	}
+
+	return status.Errorf(codes.Aborted, "permission etag %s does not match the current etag", etag)
+}
+
+// idempotencyKeyFromContext returns the idempotency key of ctx's incoming metadata, or an empty string.
+func idempotencyKeyFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	if keys := md.Get(IdempotencyKeyHeader); len(keys) > 0 {
+		return keys[0]
+	}
+
+	return ""
+}
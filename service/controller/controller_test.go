@@ -0,0 +1,388 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/meateam/permission-service/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// testFileID is the file whose permissions are tested.
+const testFileID = "file"
+
+// share creates the permission of userID to testFileID by creator.
+func share(c Controller, userID string, creator string, canReshare bool) (service.Permission, error) {
+	return c.CreatePermission(
+		context.Background(),
+		service.DefaultResourceType,
+		testFileID,
+		userID,
+		pb.Role_READ,
+		creator,
+		false,
+		canReshare,
+		"",
+		"",
+		service.ResourceKindFile,
+		service.GranteeTypeUser,
+		nil,
+		"",
+	)
+}
+
+// mustShare creates the permission of userID to testFileID by creator, and fails t if it fails.
+func mustShare(t *testing.T, c Controller, userID string, creator string, canReshare bool) service.Permission {
+	t.Helper()
+
+	permission, err := share(c, userID, creator, canReshare)
+	if err != nil {
+		t.Fatalf("failed sharing %s with %s by %s: %v", testFileID, userID, creator, err)
+	}
+
+	return permission
+}
+
+// rejectionRule returns the rule of the rejection of err, or an empty string if err isn't a rejection.
+func rejectionRule(err error) string {
+	rejection, _ := service.RejectionFromError(err)
+	return rejection.Rule
+}
+
+// warmUpRepository is a memoryRepository whose indexes are verified with verifyErr,
+// and that records the files whose permissions are read.
+type warmUpRepository struct {
+	*memoryRepository
+
+	verifyErr error
+	read      []string
+}
+
+// VerifyIndexes returns r.verifyErr.
+func (r *warmUpRepository) VerifyIndexes(ctx context.Context) error {
+	return r.verifyErr
+}
+
+// GetByResource records that the permissions of fileID are read, and returns them.
+func (r *warmUpRepository) GetByResource(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	order pb.PermissionsOrder,
+	selector service.PermissionSelector,
+) ([]service.Permission, error) {
+	r.read = append(r.read, fileID)
+	return r.memoryRepository.GetByResource(ctx, resourceType, fileID, order, selector)
+}
+
+func TestWarmUp(t *testing.T) {
+	tests := []struct {
+		name      string
+		verifyErr error
+		read      []string
+	}{
+		{name: "warmed up", read: []string{testFileID, "missing"}},
+		{name: "indexes not verified", verifyErr: errors.New("store unavailable")},
+	}
+
+	for _, test := range tests {
+		repository := &warmUpRepository{memoryRepository: newMemoryRepository(), verifyErr: test.verifyErr}
+		c := New(repository, nil, nil, nil, nil, nil, nil)
+		mustShare(t, c, "user", "user", true)
+
+		// The files are read only once the indexes are verified, and a file without permissions is warmed up too.
+		err := c.WarmUp(context.Background(), service.DefaultResourceType, []string{testFileID, "missing"})
+		if (err != nil) != (test.verifyErr != nil) {
+			t.Errorf("%s: expected WarmUp to fail with %v, got %v", test.name, test.verifyErr, err)
+		}
+
+		if !reflect.DeepEqual(repository.read, test.read) {
+			t.Errorf("%s: expected the permissions of %v to be read, got %v", test.name, test.read, repository.read)
+		}
+	}
+}
+
+func TestPrewarmFiles(t *testing.T) {
+	c := New(newMemoryRepository(), nil, nil, nil, nil, nil, nil)
+	mustShare(t, c, "owner", "owner", true)
+	mustShare(t, c, "user", "owner", false)
+
+	fileIDs := []string{testFileID, "missing"}
+	permissions, err := c.PrewarmFiles(context.Background(), service.DefaultResourceType, fileIDs)
+	if err != nil {
+		t.Fatalf("PrewarmFiles failed: %v", err)
+	}
+
+	if len(permissions) != 2 {
+		t.Errorf("expected the 2 permissions of the hot files, got %v", permissions)
+	}
+}
+
+func TestCreatePermissionReshare(t *testing.T) {
+	c := New(newMemoryRepository(), nil, nil, nil, nil, nil, nil)
+	mustShare(t, c, "owner", "owner", true)
+	mustShare(t, c, "editor", "owner", true)
+	mustShare(t, c, "viewer", "owner", false)
+
+	tests := []struct {
+		name    string
+		creator string
+		chain   []string
+		rule    string
+	}{
+		{name: "shared by the owner", creator: "owner", chain: []string{"owner"}},
+		{name: "reshared", creator: "editor", chain: []string{"owner", "editor"}},
+		{name: "reshare not allowed", creator: "viewer", rule: "can_reshare"},
+		{name: "no permission", creator: "stranger", rule: "can_reshare"},
+	}
+
+	for _, test := range tests {
+		userID := "shared-by-" + test.creator
+		permission, err := share(c, userID, test.creator, false)
+		if test.rule != "" {
+			if status.Code(err) != codes.PermissionDenied || rejectionRule(err) != test.rule {
+				t.Errorf("%s: expected a PermissionDenied rejection of %s, got %v", test.name, test.rule, err)
+			}
+
+			if _, err := c.GetByFileAndUser(
+				context.Background(),
+				service.DefaultResourceType,
+				testFileID,
+				userID,
+			); status.Code(err) != codes.NotFound {
+				t.Errorf("%s: expected the rejected permission not to be created, got %v", test.name, err)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%s: CreatePermission failed: %v", test.name, err)
+			continue
+		}
+
+		if chain := permission.GetSharingChain(); !reflect.DeepEqual(chain, test.chain) {
+			t.Errorf("%s: expected the sharing chain %v, got %v", test.name, test.chain, chain)
+		}
+	}
+}
+
+func TestCreatePermissionReshareLimits(t *testing.T) {
+	tests := []struct {
+		name   string
+		limits service.ReshareLimits
+		rule   string
+	}{
+		{name: "unlimited", limits: service.ReshareLimits{}},
+		{name: "depth", limits: service.ReshareLimits{MaxDepth: 2}, rule: "max_reshare_depth"},
+		{name: "grants", limits: service.ReshareLimits{MaxGrants: 1}, rule: "max_reshare_grants"},
+	}
+
+	for _, test := range tests {
+		c := New(newMemoryRepository(), nil, nil, nil, nil, nil, nil).WithReshareLimits(test.limits)
+		mustShare(t, c, "owner", "owner", true)
+		mustShare(t, c, "a", "owner", true)
+
+		// The permission of b is the first one that's reshared from the permission of a,
+		// with a sharing chain of 2 users.
+		mustShare(t, c, "b", "a", true)
+
+		// The permission of c is the second one that's reshared from the permission of a,
+		// with a sharing chain of 3 users.
+		_, err := share(c, "c", "b", false)
+		if test.rule == "" && err != nil {
+			t.Errorf("%s: CreatePermission failed: %v", test.name, err)
+		}
+
+		if test.rule != "" && (status.Code(err) != codes.PermissionDenied || rejectionRule(err) != test.rule) {
+			t.Errorf("%s: expected a PermissionDenied rejection of %s, got %v", test.name, test.rule, err)
+		}
+	}
+}
+
+func TestUpdatePermissionETag(t *testing.T) {
+	c := New(newMemoryRepository(), nil, nil, nil, nil, nil, nil)
+	shared := mustShare(t, c, "user", "user", true)
+	update := service.PermissionUpdate{Role: pb.Role_WRITE}
+	fields := []service.PermissionField{service.RoleField}
+
+	updated, err := c.UpdatePermission(
+		context.Background(),
+		service.DefaultResourceType,
+		testFileID,
+		"user",
+		shared.GetETag(),
+		update,
+		fields,
+	)
+	if err != nil {
+		t.Fatalf("UpdatePermission failed with the current etag: %v", err)
+	}
+
+	if updated.GetRole() != pb.Role_WRITE || updated.GetETag() == shared.GetETag() {
+		t.Errorf("expected the permission to be updated with a new etag, got %v", updated)
+	}
+
+	tests := []struct {
+		name   string
+		userID string
+		etag   string
+		code   codes.Code
+	}{
+		{name: "stale etag", userID: "user", etag: shared.GetETag(), code: codes.Aborted},
+		{name: "missing permission", userID: "missing", etag: shared.GetETag(), code: codes.NotFound},
+		{name: "missing permission without etag", userID: "missing", code: codes.NotFound},
+	}
+
+	for _, test := range tests {
+		_, err := c.UpdatePermission(
+			context.Background(),
+			service.DefaultResourceType,
+			testFileID,
+			test.userID,
+			test.etag,
+			update,
+			fields,
+		)
+		if status.Code(err) != test.code {
+			t.Errorf("%s: expected UpdatePermission to fail with %v, got %v", test.name, test.code, err)
+		}
+	}
+}
+
+func TestDeletePermissionLegalHold(t *testing.T) {
+	holds := newMemoryHolds()
+	c := New(newMemoryRepository(), nil, nil, nil, nil, holds, nil)
+	mustShare(t, c, "user", "user", true)
+
+	ctx := context.Background()
+	if _, err := c.PlaceLegalHold(ctx, service.LegalHold{
+		ResourceType: service.DefaultResourceType,
+		FileID:       testFileID,
+	}); err != nil {
+		t.Fatalf("PlaceLegalHold failed: %v", err)
+	}
+
+	_, err := c.DeletePermission(ctx, service.DefaultResourceType, testFileID, "user", "")
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected the deletion of a held permission to fail with FailedPrecondition, got %v", err)
+	}
+
+	if _, err := c.ReleaseLegalHold(ctx, service.DefaultResourceType, testFileID); err != nil {
+		t.Fatalf("ReleaseLegalHold failed: %v", err)
+	}
+
+	if _, err := c.DeletePermission(ctx, service.DefaultResourceType, testFileID, "user", ""); err != nil {
+		t.Errorf("DeletePermission failed after the hold was released: %v", err)
+	}
+
+	_, err = c.DeletePermission(ctx, service.DefaultResourceType, testFileID, "user", "")
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected the deletion of a deleted permission to fail with NotFound, got %v", err)
+	}
+}
+
+func TestUpdateRoles(t *testing.T) {
+	holds := newMemoryHolds()
+	c := New(newMemoryRepository(), nil, nil, nil, nil, holds, nil)
+	ctx := context.Background()
+	shared := mustShare(t, c, "user", "user", true)
+	if _, err := holds.SetLegalHold(ctx, service.LegalHold{
+		ResourceType: service.DefaultResourceType,
+		FileID:       "held",
+	}); err != nil {
+		t.Fatalf("SetLegalHold failed: %v", err)
+	}
+
+	updates := []service.RoleUpdate{
+		{ResourceType: service.DefaultResourceType, FileID: testFileID, UserID: "user", ETag: "stale"},
+		{ResourceType: service.DefaultResourceType, FileID: testFileID, UserID: "missing"},
+		{ResourceType: service.DefaultResourceType, FileID: "held", UserID: "user"},
+		{ResourceType: service.DefaultResourceType, FileID: testFileID, UserID: "user", ETag: shared.GetETag()},
+	}
+	for i := range updates {
+		updates[i].Role = pb.Role_WRITE
+	}
+
+	results, err := c.UpdateRoles(ctx, updates)
+	if err != nil {
+		t.Fatalf("UpdateRoles failed: %v", err)
+	}
+
+	codesByUpdate := []codes.Code{codes.Aborted, codes.NotFound, codes.FailedPrecondition, codes.OK}
+	for i, code := range codesByUpdate {
+		if status.Code(results[i].Err) != code {
+			t.Errorf("expected update %d to fail with %v, got %v", i, code, results[i].Err)
+		}
+	}
+
+	if permission := results[3].Permission; permission == nil || permission.GetRole() != pb.Role_WRITE {
+		t.Errorf("expected the role of the permission to be updated, got %v", permission)
+	}
+}
+
+func TestExpireStaleGrants(t *testing.T) {
+	holds := newMemoryHolds()
+	c := New(newMemoryRepository(), nil, nil, nil, nil, holds, nil)
+	ctx := context.Background()
+	if _, err := c.AssignOwner(ctx, service.DefaultResourceType, testFileID, "owner"); err != nil {
+		t.Fatalf("AssignOwner failed: %v", err)
+	}
+
+	mustShare(t, c, "a", "owner", false)
+	mustShare(t, c, "b", "owner", false)
+
+	if _, err := c.CreatePermission(
+		ctx,
+		service.DefaultResourceType,
+		"held",
+		"c",
+		pb.Role_READ,
+		"c",
+		false,
+		false,
+		"",
+		"",
+		service.ResourceKindFile,
+		service.GranteeTypeUser,
+		nil,
+		"",
+	); err != nil {
+		t.Fatalf("CreatePermission failed: %v", err)
+	}
+
+	if _, err := holds.SetLegalHold(ctx, service.LegalHold{
+		ResourceType: service.DefaultResourceType,
+		FileID:       "held",
+	}); err != nil {
+		t.Fatalf("SetLegalHold failed: %v", err)
+	}
+
+	// Every permission was created before the next hour, but the owner's permission isn't stale.
+	var expired, skipped int64
+	if err := c.ExpireStaleGrants(ctx, time.Now().Add(time.Hour), 1, func(e int64, s int64) error {
+		expired, skipped = e, s
+		return nil
+	}); err != nil {
+		t.Fatalf("ExpireStaleGrants failed: %v", err)
+	}
+
+	if expired != 2 || skipped != 1 {
+		t.Errorf("expected 2 expired and 1 skipped permissions, got %d expired and %d skipped", expired, skipped)
+	}
+
+	remaining, _, err := c.ListFilePermissions(ctx, service.DefaultResourceType, testFileID, 10, "", nil,
+		service.PermissionSelector{})
+	if err != nil {
+		t.Fatalf("ListFilePermissions failed: %v", err)
+	}
+
+	if len(remaining) != 1 || remaining[0].GetUserID() != "owner" {
+		t.Errorf("expected only the owner's permission to remain, got %v", remaining)
+	}
+}
@@ -0,0 +1,653 @@
+package controller
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/meateam/permission-service/service"
+	"github.com/meateam/permission-service/service/mongodb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errNotFound is the error of a permission that doesn't exist in a memoryRepository.
+var errNotFound = status.Error(codes.NotFound, "permission not found")
+
+// memoryRepository is an in-memory service.PermissionRepository of the service.PermissionStore aggregate,
+// so that the controller is tested and benchmarked without a store. The permissions are listed by their
+// creation regardless of the order that's asked for, and the token of a page is the ID of its last permission.
+// The other aggregates of the repository aren't stored, and their methods panic.
+type memoryRepository struct {
+	service.TreeRepository
+	service.EventRepository
+	service.SharingRepository
+	service.MaintenanceRepository
+
+	mu          sync.RWMutex
+	permissions map[string]*mongodb.BSON
+}
+
+// newMemoryRepository returns an empty memoryRepository.
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{permissions: make(map[string]*mongodb.BSON)}
+}
+
+// permissionKey returns the key of the permission of userID to fileID in r.permissions.
+func permissionKey(resourceType string, fileID string, userID string) string {
+	return resourceType + "/" + fileID + "/" + userID
+}
+
+// keyOf returns the key of permission in r.permissions.
+func keyOf(permission *mongodb.BSON) string {
+	return permissionKey(permission.ResourceType, permission.FileID, permission.UserID)
+}
+
+// copyOf returns a copy of permission, so that the permissions that are returned aren't changed by later writes.
+func copyOf(permission *mongodb.BSON) service.Permission {
+	copied := *permission
+	return &copied
+}
+
+// matchesSelector returns whether permission is selected by selector.
+func matchesSelector(permission *mongodb.BSON, selector service.PermissionSelector) bool {
+	for key, value := range selector.Labels {
+		if permission.Labels[key] != value {
+			return false
+		}
+	}
+
+	return selector.Source == "" || permission.Source == selector.Source
+}
+
+// contains returns whether values contains value.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// find returns copies of the permissions that match, ordered by their creation. r.mu must be held.
+func (r *memoryRepository) find(match func(permission *mongodb.BSON) bool) []service.Permission {
+	matched := []*mongodb.BSON{}
+	for _, permission := range r.permissions {
+		if match(permission) {
+			matched = append(matched, permission)
+		}
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].ID.Hex() < matched[j].ID.Hex()
+	})
+
+	permissions := make([]service.Permission, 0, len(matched))
+	for _, permission := range matched {
+		permissions = append(permissions, copyOf(permission))
+	}
+
+	return permissions
+}
+
+// findPage returns up to pageSize of the permissions that match and come after pageToken,
+// ordered by their creation, and the token of the next page, which is empty if there are no more pages.
+func (r *memoryRepository) findPage(
+	match func(permission *mongodb.BSON) bool,
+	pageSize int,
+	pageToken string,
+) ([]service.Permission, string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	permissions := r.find(func(permission *mongodb.BSON) bool {
+		return permission.ID.Hex() > pageToken && match(permission)
+	})
+
+	if len(permissions) <= pageSize {
+		return permissions, "", nil
+	}
+
+	permissions = permissions[:pageSize]
+	if pageSize == 0 {
+		return permissions, pageToken, nil
+	}
+
+	return permissions, permissions[pageSize-1].GetID(), nil
+}
+
+// getETag returns the permission of userID to fileID if etag is empty or is its current etag. r.mu must be held.
+func (r *memoryRepository) getETag(
+	resourceType string,
+	fileID string,
+	userID string,
+	etag string,
+) (*mongodb.BSON, error) {
+	permission, ok := r.permissions[permissionKey(resourceType, fileID, userID)]
+	if !ok || (etag != "" && permission.GetETag() != etag) {
+		return nil, errNotFound
+	}
+
+	return permission, nil
+}
+
+// WithCausalConsistency calls fn with ctx, the writes of a memoryRepository are always observed.
+func (r *memoryRepository) WithCausalConsistency(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// Create creates the permission of userID to fileID with values, or returns the existing permission
+// unless override is true, in which case it's updated to values.
+func (r *memoryRepository) Create(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+	creator string,
+	sharingChain []string,
+	values service.PermissionUpdate,
+	override bool,
+) (service.Permission, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := permissionKey(resourceType, fileID, userID)
+	existing, exists := r.permissions[key]
+	if exists && !override {
+		return copyOf(existing), nil
+	}
+
+	canReshare := values.CanReshare
+	permission := &mongodb.BSON{
+		ID:           primitive.NewObjectID(),
+		ResourceType: resourceType,
+		FileID:       fileID,
+		UserID:       userID,
+		Role:         values.Role,
+		Creator:      creator,
+		CanReshare:   &canReshare,
+		Message:      values.Message,
+		Label:        values.Label,
+		Labels:       values.Labels,
+		Source:       values.Source,
+		SharingChain: sharingChain,
+		ResourceKind: values.ResourceKind,
+		GranteeType:  values.GranteeType,
+	}
+
+	if exists {
+		permission.ID = existing.ID
+		permission.Version = existing.Version + 1
+	}
+
+	r.permissions[key] = permission
+	return copyOf(permission), nil
+}
+
+// GetByID returns the permission with id.
+func (r *memoryRepository) GetByID(ctx context.Context, id string) (service.Permission, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	permissions := r.find(func(permission *mongodb.BSON) bool {
+		return permission.GetID() == id
+	})
+	if len(permissions) == 0 {
+		return nil, errNotFound
+	}
+
+	return permissions[0], nil
+}
+
+// ExistingIDs returns the set of ids of the permissions that exist.
+func (r *memoryRepository) ExistingIDs(ctx context.Context, ids []string) (map[string]bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	existing := map[string]bool{}
+	for _, permission := range r.permissions {
+		if contains(ids, permission.GetID()) {
+			existing[permission.GetID()] = true
+		}
+	}
+
+	return existing, nil
+}
+
+// Get returns the permission of userID to fileID, with all of its fields.
+func (r *memoryRepository) Get(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+	fields ...service.PermissionField,
+) (service.Permission, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	permission, ok := r.permissions[permissionKey(resourceType, fileID, userID)]
+	if !ok {
+		return nil, errNotFound
+	}
+
+	return copyOf(permission), nil
+}
+
+// GetByResource returns the permissions of fileID that match selector.
+func (r *memoryRepository) GetByResource(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	order pb.PermissionsOrder,
+	selector service.PermissionSelector,
+) ([]service.Permission, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.find(func(permission *mongodb.BSON) bool {
+		return permission.ResourceType == resourceType && permission.FileID == fileID &&
+			matchesSelector(permission, selector)
+	}), nil
+}
+
+// GetByUser returns the permissions of userID that match selector.
+func (r *memoryRepository) GetByUser(
+	ctx context.Context,
+	resourceType string,
+	userID string,
+	order pb.PermissionsOrder,
+	selector service.PermissionSelector,
+) ([]service.Permission, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.find(func(permission *mongodb.BSON) bool {
+		return permission.ResourceType == resourceType && permission.UserID == userID &&
+			matchesSelector(permission, selector)
+	}), nil
+}
+
+// GetBySharer returns the permissions of fileID whose sharing chain includes sharerID.
+func (r *memoryRepository) GetBySharer(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	sharerID string,
+) ([]service.Permission, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.find(func(permission *mongodb.BSON) bool {
+		return permission.ResourceType == resourceType && permission.FileID == fileID &&
+			contains(permission.SharingChain, sharerID)
+	}), nil
+}
+
+// GetByFilter returns the permissions that match filter.
+func (r *memoryRepository) GetByFilter(
+	ctx context.Context,
+	filter service.PermissionsFilter,
+) ([]service.Permission, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	selector := service.PermissionSelector{Labels: filter.Labels, Source: filter.Source}
+	return r.find(func(permission *mongodb.BSON) bool {
+		return (filter.ResourceType == "" || permission.ResourceType == filter.ResourceType) &&
+			(len(filter.FileIDs) == 0 || contains(filter.FileIDs, permission.FileID)) &&
+			(len(filter.UserIDs) == 0 || contains(filter.UserIDs, permission.UserID)) &&
+			(filter.Creator == "" || permission.Creator == filter.Creator) &&
+			matchesSelector(permission, selector)
+	}), nil
+}
+
+// ListByResource returns a page of the permissions of fileID that match selector.
+func (r *memoryRepository) ListByResource(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	order pb.PermissionsOrder,
+	pageSize int,
+	pageToken string,
+	fields []service.PermissionField,
+	selector service.PermissionSelector,
+) ([]service.Permission, string, error) {
+	return r.findPage(func(permission *mongodb.BSON) bool {
+		return permission.ResourceType == resourceType && permission.FileID == fileID &&
+			matchesSelector(permission, selector)
+	}, pageSize, pageToken)
+}
+
+// ListByUser returns a page of the permissions of userID that match selector.
+func (r *memoryRepository) ListByUser(
+	ctx context.Context,
+	resourceType string,
+	userID string,
+	order pb.PermissionsOrder,
+	pageSize int,
+	pageToken string,
+	selector service.PermissionSelector,
+) ([]service.Permission, string, error) {
+	return r.findPage(func(permission *mongodb.BSON) bool {
+		return permission.ResourceType == resourceType && permission.UserID == userID &&
+			matchesSelector(permission, selector)
+	}, pageSize, pageToken)
+}
+
+// ListByGranteeType returns a page of the permissions of granteeType, or only of granteeID if it's set.
+func (r *memoryRepository) ListByGranteeType(
+	ctx context.Context,
+	granteeType string,
+	granteeID string,
+	pageSize int,
+	pageToken string,
+) ([]service.Permission, string, error) {
+	return r.findPage(func(permission *mongodb.BSON) bool {
+		return permission.GetGranteeType() == granteeType && (granteeID == "" || permission.UserID == granteeID)
+	}, pageSize, pageToken)
+}
+
+// ListStale returns a page of the direct permissions of resourceType, or of every resource type if it's empty,
+// that weren't accessed since before, or were never accessed and were created before it, other than
+// the permissions of the owners.
+func (r *memoryRepository) ListStale(
+	ctx context.Context,
+	resourceType string,
+	before time.Time,
+	pageSize int,
+	pageToken string,
+) ([]service.Permission, string, error) {
+	return r.findPage(func(permission *mongodb.BSON) bool {
+		lastAccessedAt := permission.LastAccessedAt
+		if lastAccessedAt.IsZero() {
+			lastAccessedAt = permission.GetCreatedAt()
+		}
+
+		isOwner := permission.Role == pb.Role_WRITE && permission.Creator == permission.UserID
+		return (resourceType == "" || permission.ResourceType == resourceType) &&
+			permission.InheritedFrom == "" && lastAccessedAt.Before(before) && !isOwner
+	}, pageSize, pageToken)
+}
+
+// Update updates the fields of the permission of userID to fileID to their values in update,
+// if etag is empty or is the permission's current etag.
+func (r *memoryRepository) Update(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+	etag string,
+	update service.PermissionUpdate,
+	fields []service.PermissionField,
+) (service.Permission, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	permission, err := r.getETag(resourceType, fileID, userID, etag)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := *permission
+	for _, field := range fields {
+		switch field {
+		case service.RoleField:
+			updated.Role = update.Role
+		case service.CanReshareField:
+			canReshare := update.CanReshare
+			updated.CanReshare = &canReshare
+		case service.MessageField:
+			updated.Message = update.Message
+		case service.LabelField:
+			updated.Label = update.Label
+		case service.LabelsField:
+			updated.Labels = update.Labels
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "field %s cannot be updated", field)
+		}
+	}
+
+	updated.Version++
+	r.permissions[keyOf(permission)] = &updated
+	return copyOf(&updated), nil
+}
+
+// Touch sets the last access time of the permission of userID to fileID to accessedAt.
+func (r *memoryRepository) Touch(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+	accessedAt time.Time,
+) (service.Permission, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	permission, err := r.getETag(resourceType, fileID, userID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	permission.LastAccessedAt = accessedAt
+	return copyOf(permission), nil
+}
+
+// Delete deletes the permission of userID to fileID, if etag is empty or is the permission's current etag.
+func (r *memoryRepository) Delete(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+	etag string,
+) (service.Permission, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	permission, err := r.getETag(resourceType, fileID, userID, etag)
+	if err != nil {
+		return nil, err
+	}
+
+	delete(r.permissions, keyOf(permission))
+	return permission, nil
+}
+
+// DeleteByID deletes the permission with id.
+func (r *memoryRepository) DeleteByID(ctx context.Context, id string) (service.Permission, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, permission := range r.permissions {
+		if permission.GetID() == id {
+			delete(r.permissions, key)
+			return permission, nil
+		}
+	}
+
+	return nil, errNotFound
+}
+
+// DeleteAllExceptUser deletes every permission to fileID other than the permission of userID.
+func (r *memoryRepository) DeleteAllExceptUser(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+) ([]service.Permission, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deleted := r.find(func(permission *mongodb.BSON) bool {
+		return permission.ResourceType == resourceType && permission.FileID == fileID && permission.UserID != userID
+	})
+
+	for _, permission := range deleted {
+		delete(r.permissions, permissionKey(resourceType, fileID, permission.GetUserID()))
+	}
+
+	return deleted, nil
+}
+
+// AssignOwner makes userID the owner of fileID, which has no owner other than userID.
+func (r *memoryRepository) AssignOwner(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+) (service.Permission, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	resourceKind := service.ResourceKindFile
+	var existing *mongodb.BSON
+	for _, permission := range r.permissions {
+		if permission.ResourceType != resourceType || permission.FileID != fileID {
+			continue
+		}
+
+		resourceKind = permission.GetResourceKind()
+		if permission.UserID == userID {
+			if permission.GetGranteeType() != service.GranteeTypeUser {
+				return nil, status.Errorf(codes.FailedPrecondition, "the owner of %s must be a user", fileID)
+			}
+
+			existing = permission
+		}
+
+		if permission.Role == pb.Role_WRITE && permission.Creator == permission.UserID && permission.UserID != userID {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s %s already has an owner", resourceType, fileID)
+		}
+	}
+
+	canReshare := true
+	owner := &mongodb.BSON{
+		ID:           primitive.NewObjectID(),
+		ResourceType: resourceType,
+		FileID:       fileID,
+		UserID:       userID,
+		Role:         pb.Role_WRITE,
+		Creator:      userID,
+		CanReshare:   &canReshare,
+		ResourceKind: resourceKind,
+		GranteeType:  service.GranteeTypeUser,
+	}
+
+	if existing != nil {
+		owner.ID = existing.ID
+		owner.Version = existing.Version + 1
+	}
+
+	r.permissions[keyOf(owner)] = owner
+	return copyOf(owner), nil
+}
+
+// UpdateRoles changes the roles of the permissions of updates, and returns the result of each update.
+func (r *memoryRepository) UpdateRoles(
+	ctx context.Context,
+	updates []service.RoleUpdate,
+) ([]service.RoleUpdateResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	results := make([]service.RoleUpdateResult, len(updates))
+	for i, update := range updates {
+		permission, ok := r.permissions[permissionKey(update.ResourceType, update.FileID, update.UserID)]
+		if !ok {
+			results[i].Err = errNotFound
+			continue
+		}
+
+		if update.ETag != "" && update.ETag != permission.GetETag() {
+			results[i].Err = status.Errorf(
+				codes.Aborted,
+				"permission etag %s does not match the current etag",
+				update.ETag,
+			)
+			continue
+		}
+
+		permission.Role = update.Role
+		permission.Version++
+		results[i].Permission = copyOf(permission)
+	}
+
+	return results, nil
+}
+
+// memoryHolds is an in-memory service.HoldRepository.
+type memoryHolds struct {
+	mu    sync.Mutex
+	holds map[string]service.LegalHold
+}
+
+// newMemoryHolds returns a memoryHolds without holds.
+func newMemoryHolds() *memoryHolds {
+	return &memoryHolds{holds: make(map[string]service.LegalHold)}
+}
+
+// SetLegalHold places the hold of hold's file, or replaces its hold.
+func (h *memoryHolds) SetLegalHold(ctx context.Context, hold service.LegalHold) (service.LegalHold, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.holds[hold.ResourceType+"/"+hold.FileID] = hold
+	return hold, nil
+}
+
+// DeleteLegalHold releases the hold of fileID.
+func (h *memoryHolds) DeleteLegalHold(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+) (service.LegalHold, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	key := resourceType + "/" + fileID
+	hold, ok := h.holds[key]
+	if !ok {
+		return service.LegalHold{}, status.Errorf(codes.NotFound, "%s %s isn't held", resourceType, fileID)
+	}
+
+	delete(h.holds, key)
+	return hold, nil
+}
+
+// GetLegalHolds returns the holds of the files of fileIDs that are held.
+func (h *memoryHolds) GetLegalHolds(
+	ctx context.Context,
+	resourceType string,
+	fileIDs []string,
+) ([]service.LegalHold, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	holds := []service.LegalHold{}
+	for _, fileID := range fileIDs {
+		if hold, ok := h.holds[resourceType+"/"+fileID]; ok {
+			holds = append(holds, hold)
+		}
+	}
+
+	return holds, nil
+}
+
+// ListLegalHolds returns the holds of the files of resourceType, or of every type if it's empty.
+func (h *memoryHolds) ListLegalHolds(ctx context.Context, resourceType string) ([]service.LegalHold, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	holds := []service.LegalHold{}
+	for _, hold := range h.holds {
+		if resourceType == "" || hold.ResourceType == resourceType {
+			holds = append(holds, hold)
+		}
+	}
+
+	return holds, nil
+}
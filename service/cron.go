@@ -0,0 +1,186 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is the schedule of a recurring job.
+type Schedule interface {
+	// Next returns the first time of the schedule after t.
+	Next(t time.Time) time.Time
+}
+
+// cronDescriptors maps the descriptors of cron schedules to their expressions.
+var cronDescriptors = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+}
+
+// cronField is the range of the values of a field of a cron expression.
+type cronField struct {
+	name string
+	min  int
+	max  int
+}
+
+// cronFields are the fields of a cron expression, in order.
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	{name: "day of week", min: 0, max: 6},
+}
+
+// cronSchedule is the schedule of a cron expression, each field is the set of its allowed values.
+type cronSchedule struct {
+	minutes  map[int]bool
+	hours    map[int]bool
+	days     map[int]bool
+	months   map[int]bool
+	weekdays map[int]bool
+
+	// anyDay and anyWeekday are whether the day of month and day of week fields are "*".
+	// If both are restricted then a day matches if either of them does, as in cron.
+	anyDay     bool
+	anyWeekday bool
+}
+
+// everySchedule is the schedule of a fixed interval, aligned to the zero time so that
+// every instance of the service computes the same times.
+type everySchedule struct {
+	interval time.Duration
+}
+
+// ParseSchedule parses a schedule: a cron expression of 5 fields, "minute hour day-of-month month day-of-week",
+// such as "30 3 * * 1-5", whose fields are "*", values, ranges and steps, such as "*/15" or "1,15",
+// one of the descriptors "@hourly", "@daily", "@weekly" and "@monthly", or "@every {duration}", such as
+// "@every 10m". Schedules are in UTC.
+func ParseSchedule(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if strings.HasPrefix(spec, "@every ") {
+		interval, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(spec, "@every ")))
+		if err != nil || interval < time.Second {
+			return nil, fmt.Errorf("invalid schedule %q: the interval must be a duration of at least 1s", spec)
+		}
+
+		return everySchedule{interval: interval}, nil
+	}
+
+	if expression, ok := cronDescriptors[spec]; ok {
+		spec = expression
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("invalid schedule %q: expected %d fields", spec, len(cronFields))
+	}
+
+	values := make([]map[int]bool, len(fields))
+	for i, field := range fields {
+		parsed, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule %q: %v", spec, err)
+		}
+
+		values[i] = parsed
+	}
+
+	return cronSchedule{
+		minutes:    values[0],
+		hours:      values[1],
+		days:       values[2],
+		months:     values[3],
+		weekdays:   values[4],
+		anyDay:     fields[2] == "*",
+		anyWeekday: fields[4] == "*",
+	}, nil
+}
+
+// parseCronField parses field, a comma separated list of "*", values and ranges with optional steps,
+// and returns the set of its values.
+func parseCronField(field string, bounds cronField) (map[int]bool, error) {
+	values := map[int]bool{}
+	for _, item := range strings.Split(field, ",") {
+		rangeSpec, step := item, 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			var err error
+			if step, err = strconv.Atoi(item[i+1:]); err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid step in %s %q", bounds.name, item)
+			}
+
+			rangeSpec = item[:i]
+		}
+
+		low, high := bounds.min, bounds.max
+		if rangeSpec != "*" {
+			parts := strings.SplitN(rangeSpec, "-", 2)
+			var err error
+			if low, err = strconv.Atoi(parts[0]); err != nil {
+				return nil, fmt.Errorf("invalid %s %q", bounds.name, item)
+			}
+
+			high = low
+			if len(parts) == 2 {
+				if high, err = strconv.Atoi(parts[1]); err != nil {
+					return nil, fmt.Errorf("invalid %s %q", bounds.name, item)
+				}
+			} else if step > 1 {
+				high = bounds.max
+			}
+		}
+
+		if low < bounds.min || high > bounds.max || low > high {
+			return nil, fmt.Errorf("%s %q is out of range %d-%d", bounds.name, item, bounds.min, bounds.max)
+		}
+
+		for value := low; value <= high; value += step {
+			values[value] = true
+		}
+	}
+
+	return values, nil
+}
+
+// Next returns the first minute of the schedule after t, or the zero time if there's none within 5 years,
+// such as of February 30th.
+func (s cronSchedule) Next(t time.Time) time.Time {
+	next := t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := next.AddDate(5, 0, 0)
+	for next.Before(limit) {
+		switch {
+		case !s.months[int(next.Month())]:
+			next = time.Date(next.Year(), next.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 1, 0)
+		case !s.matchesDay(next):
+			next = time.Date(next.Year(), next.Month(), next.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+		case !s.hours[next.Hour()]:
+			next = next.Truncate(time.Hour).Add(time.Hour)
+		case !s.minutes[next.Minute()]:
+			next = next.Add(time.Minute)
+		default:
+			return next
+		}
+	}
+
+	return time.Time{}
+}
+
+// matchesDay returns true if the day of t matches the day of month and day of week fields of s.
+func (s cronSchedule) matchesDay(t time.Time) bool {
+	day, weekday := s.days[t.Day()], s.weekdays[int(t.Weekday())]
+	if s.anyDay || s.anyWeekday {
+		return day && weekday
+	}
+
+	return day || weekday
+}
+
+// Next returns the first multiple of the interval since the zero time after t.
+func (s everySchedule) Next(t time.Time) time.Time {
+	return t.Truncate(s.interval).Add(s.interval)
+}
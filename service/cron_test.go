@@ -0,0 +1,56 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSchedule(t *testing.T) {
+	from := time.Date(2021, time.January, 30, 10, 20, 30, 0, time.UTC) // A Saturday.
+	tests := []struct {
+		spec string
+		next time.Time
+		err  bool
+	}{
+		{spec: "*/15 * * * *", next: time.Date(2021, time.January, 30, 10, 30, 0, 0, time.UTC)},
+		{spec: "30 3 * * 1-5", next: time.Date(2021, time.February, 1, 3, 30, 0, 0, time.UTC)},
+		{spec: "0 0 1,15 * *", next: time.Date(2021, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{spec: " @hourly ", next: time.Date(2021, time.January, 30, 11, 0, 0, 0, time.UTC)},
+		{spec: "@daily", next: time.Date(2021, time.January, 31, 0, 0, 0, 0, time.UTC)},
+		{spec: "@weekly", next: time.Date(2021, time.January, 31, 0, 0, 0, 0, time.UTC)},
+		{spec: "@monthly", next: time.Date(2021, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{spec: "@every 10m", next: time.Date(2021, time.January, 30, 10, 30, 0, 0, time.UTC)},
+
+		// Either the day of month or the day of week matches if both are restricted.
+		{spec: "0 12 31 * 1", next: time.Date(2021, time.January, 31, 12, 0, 0, 0, time.UTC)},
+		{spec: "0 12 31 2 *", next: time.Time{}},
+
+		{spec: "* * * *", err: true},
+		{spec: "60 * * * *", err: true},
+		{spec: "5-1 * * * *", err: true},
+		{spec: "*/0 * * * *", err: true},
+		{spec: "a * * * *", err: true},
+		{spec: "@every 10", err: true},
+		{spec: "@every 500ms", err: true},
+	}
+
+	for _, test := range tests {
+		schedule, err := ParseSchedule(test.spec)
+		if test.err {
+			if err == nil {
+				t.Errorf("%q: expected ParseSchedule to fail", test.spec)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%q: ParseSchedule failed: %v", test.spec, err)
+			continue
+		}
+
+		if next := schedule.Next(from); !next.Equal(test.next) {
+			t.Errorf("%q: expected the next time after %v to be %v, got %v", test.spec, from, test.next, next)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DecisionSchemaVersion is the version of the schema of the logged decisions,
+// it's incremented only on changes that aren't backward compatible.
+const DecisionSchemaVersion = 1
+
+// Decision is an authorization decision of a permission check, in the stable schema of the decision log.
+type Decision struct {
+	SchemaVersion int       `json:"schemaVersion"`
+	Time          time.Time `json:"time"`
+	Method        string    `json:"method"`
+	Caller        string    `json:"caller"`
+	Actor         string    `json:"actor,omitempty"`
+	ResourceType  string    `json:"resourceType"`
+	FileID        string    `json:"fileID"`
+	UserID        string    `json:"userID"`
+	Role          string    `json:"role,omitempty"`
+	Capability    string    `json:"capability,omitempty"`
+	Outcome       string    `json:"outcome"`
+	LatencyMs     float64   `json:"latencyMs"`
+}
+
+// DecisionSink is a destination of the decision log, such as a file, or an exporter to a log pipeline.
+type DecisionSink interface {
+	LogDecision(ctx context.Context, decision Decision) error
+}
+
+// WriterDecisionSink is a DecisionSink that writes the decisions as JSON lines to a writer.
+type WriterDecisionSink struct {
+	mu     *sync.Mutex
+	writer io.Writer
+}
+
+// NewWriterDecisionSink creates a WriterDecisionSink that writes to w and returns it.
+func NewWriterDecisionSink(w io.Writer) WriterDecisionSink {
+	return WriterDecisionSink{mu: &sync.Mutex{}, writer: w}
+}
+
+// LogDecision writes decision as a JSON line, returns any error if occurred.
+func (s WriterDecisionSink) LogDecision(ctx context.Context, decision Decision) error {
+	line, err := json.Marshal(decision)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.writer.Write(append(line, '\n'))
+	return err
+}
+
+// recordDecision counts the outcome of a permission check of method like recordOutcome, and logs
+// decision to sink, if set, with the outcome, caller and latency since start. Failing to log the
+// decision doesn't fail the check, and is logged to logger.
+func recordDecision(
+	ctx context.Context,
+	sink DecisionSink,
+	logger *logrus.Logger,
+	method string,
+	decision Decision,
+	start time.Time,
+	allowed bool,
+	err error,
+) {
+	recordOutcome(ctx, method, allowed, err)
+	if sink == nil {
+		return
+	}
+
+	decision.SchemaVersion = DecisionSchemaVersion
+	decision.Time = start.UTC()
+	decision.Method = method
+	decision.Caller = callerOrUnknown(ctx)
+	decision.Actor = ActorFromContext(ctx)
+	decision.Outcome = outcomeOf(allowed, err)
+	decision.LatencyMs = float64(time.Since(start)) / float64(time.Millisecond)
+	if err := sink.LogDecision(ctx, decision); err != nil {
+		logger.Errorf("failed logging decision of %s: %v", method, err)
+	}
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// failingDecisionSink is a DecisionSink whose logging always fails.
+type failingDecisionSink struct{}
+
+// LogDecision returns an error.
+func (failingDecisionSink) LogDecision(ctx context.Context, decision Decision) error {
+	return errors.New("sink unavailable")
+}
+
+func TestRecordDecision(t *testing.T) {
+	tests := []struct {
+		name    string
+		allowed bool
+		err     error
+		outcome string
+	}{
+		{name: "allowed", allowed: true, outcome: OutcomeAllow},
+		{name: "not permitted", outcome: OutcomeDeny},
+		{name: "no permission", err: status.Error(codes.NotFound, "not found"), outcome: OutcomeDeny},
+		{name: "failed", err: status.Error(codes.Unavailable, "unavailable"), outcome: OutcomeError},
+	}
+
+	for _, test := range tests {
+		var log bytes.Buffer
+		start := time.Now().Add(-time.Second)
+		decision := Decision{ResourceType: DefaultResourceType, FileID: "file", UserID: "user", Role: "READ"}
+		recordDecision(
+			context.Background(),
+			NewWriterDecisionSink(&log),
+			logrus.New(),
+			"IsPermitted",
+			decision,
+			start,
+			test.allowed,
+			test.err,
+		)
+
+		var logged Decision
+		if err := json.Unmarshal(log.Bytes(), &logged); err != nil {
+			t.Errorf("%s: expected a JSON line of the decision, got %q: %v", test.name, log.String(), err)
+			continue
+		}
+
+		expected := decision
+		expected.SchemaVersion = DecisionSchemaVersion
+		expected.Time = start.UTC()
+		expected.Method = "IsPermitted"
+		expected.Caller = unknownCaller
+		expected.Outcome = test.outcome
+		expected.LatencyMs = logged.LatencyMs
+		if !logged.Time.Equal(expected.Time) || logged.LatencyMs < 1000 {
+			t.Errorf("%s: expected the decision to be timed from its start, got %v", test.name, logged)
+		}
+
+		logged.Time = expected.Time
+		if logged != expected {
+			t.Errorf("%s: expected the decision %v, got %v", test.name, expected, logged)
+		}
+	}
+}
+
+func TestRecordDecisionSinks(t *testing.T) {
+	var out bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&out)
+	decision := Decision{ResourceType: DefaultResourceType, FileID: "file", UserID: "user"}
+
+	// Decisions aren't logged without a sink, and a sink that fails doesn't fail the check, but is logged.
+	ctx := context.Background()
+	recordDecision(ctx, nil, logger, "IsPermitted", decision, time.Now(), true, nil)
+	if out.Len() != 0 {
+		t.Errorf("expected no logs without a sink, got %q", out.String())
+	}
+
+	recordDecision(ctx, failingDecisionSink{}, logger, "IsPermitted", decision, time.Now(), true, nil)
+	if !strings.Contains(out.String(), "level=error") || !strings.Contains(out.String(), "sink unavailable") {
+		t.Errorf("expected the failure of the sink to be logged as an error, got %q", out.String())
+	}
+}
+
+func TestWriterDecisionSink(t *testing.T) {
+	var log bytes.Buffer
+	sink := NewWriterDecisionSink(&log)
+	for _, fileID := range []string{"a", "b"} {
+		if err := sink.LogDecision(context.Background(), Decision{FileID: fileID}); err != nil {
+			t.Fatalf("LogDecision failed: %v", err)
+		}
+	}
+
+	// Each decision is a line of its own, without the optional fields that aren't set.
+	lines := bytes.Split(bytes.TrimSuffix(log.Bytes(), []byte("\n")), []byte("\n"))
+	if len(lines) != 2 || bytes.Contains(lines[0], []byte(`"role"`)) {
+		t.Errorf("expected 2 JSON lines without a role, got %q", log.String())
+	}
+}
@@ -0,0 +1,386 @@
+package service
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"expvar"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/descriptor"
+	"github.com/golang/protobuf/proto"
+	pbdescriptor "github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// AllDeprecations is the name of the sunset of the deprecations that don't have a sunset of their own.
+	AllDeprecations = "*"
+
+	// sunsetDateLayout is the layout of the dates of the sunsets.
+	sunsetDateLayout = "2006-01-02"
+)
+
+// deprecatedUsage counts the uses of deprecated RPCs and fields, keyed by "name/caller".
+var deprecatedUsage = expvar.NewMap("deprecated_usage")
+
+// deprecatedField is a field of a message that's either deprecated or of a message type,
+// whose fields may be deprecated.
+type deprecatedField struct {
+	// name is the full name of the field, such as "permission.CreatePermissionRequest.label",
+	// and index the index of its field in the message's struct.
+	name  string
+	index int
+
+	deprecated bool
+}
+
+// Deprecations is the policy of the RPCs and the fields of requests that are marked deprecated
+// in the protos. Their uses are logged, on the first use by each caller, and counted in the
+// deprecated_usage metric, so that their callers can be found before they're retired.
+// Once its sunset passes, a deprecation's RPC, or the requests that set its field, are rejected.
+type Deprecations struct {
+	logger  *logrus.Logger
+	sunsets map[string]time.Time
+	now     func() time.Time
+
+	// logged holds the "name/caller" uses that were logged.
+	logged *sync.Map
+
+	// fields caches the fields of each type of message that are deprecated or may hold deprecated fields.
+	fields *sync.Map
+}
+
+// ParseDeprecationSunsets parses sunsets of the form "name=date,name=date", such as
+// "permission.CreatePermissionRequest.label=2027-01-01", where a name is the full name of
+// an RPC, such as "permission.Permission.GetSharedFiles", or of a field, or AllDeprecations,
+// and a date is of the form YYYY-MM-DD, in UTC. An empty sunsets string is valid.
+func ParseDeprecationSunsets(sunsets string) (map[string]time.Time, error) {
+	parsed := map[string]time.Time{}
+	for _, entry := range strings.Split(sunsets, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("invalid deprecation sunset entry %q", entry)
+		}
+
+		sunset, err := time.Parse(sunsetDateLayout, strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid deprecation sunset entry %q: date must be YYYY-MM-DD", entry)
+		}
+
+		parsed[strings.TrimSpace(parts[0])] = sunset
+	}
+
+	return parsed, nil
+}
+
+// NewDeprecations creates the policy of the deprecations with their sunsets, which may be nil,
+// that logs their uses to logger, and returns it.
+func NewDeprecations(logger *logrus.Logger, sunsets map[string]time.Time) Deprecations {
+	return Deprecations{
+		logger:  logger,
+		sunsets: sunsets,
+		now:     time.Now,
+		logged:  &sync.Map{},
+		fields:  &sync.Map{},
+	}
+}
+
+// Middleware returns the middleware that records the uses of the deprecated RPCs and fields of the protos
+// of the services of descs, and rejects them after their sunsets. The requests of other services aren't checked.
+// It fails if the proto of one of descs isn't registered.
+func (d Deprecations) Middleware(descs ...grpc.ServiceDesc) (Middleware, error) {
+	methods, err := deprecatedMethods(descs)
+	if err != nil {
+		return Middleware{}, err
+	}
+
+	unary := func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		method, ok := methods[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		if err := d.check(ctx, method, req); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+
+	stream := func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		method, ok := methods[info.FullMethod]
+		if !ok {
+			return handler(srv, stream)
+		}
+
+		if err := d.check(stream.Context(), method, nil); err != nil {
+			return err
+		}
+
+		return handler(srv, deprecationServerStream{ServerStream: stream, deprecations: d})
+	}
+
+	return Middleware{Unary: unary, Stream: stream}, nil
+}
+
+// check records the uses by the caller of ctx of method, if it's the name of a deprecated RPC,
+// and of the deprecated fields that are set in req, and returns a FailedPrecondition error if
+// the sunset of any of them passed.
+func (d Deprecations) check(ctx context.Context, method string, req interface{}) error {
+	var names []string
+	if method != "" {
+		names = append(names, method)
+	}
+
+	if msg, ok := req.(proto.Message); ok {
+		names = append(names, d.deprecatedFieldsOf(msg)...)
+	}
+
+	for _, name := range names {
+		if err := d.use(ctx, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// use records the use of the deprecation name by the caller of ctx, and returns a FailedPrecondition
+// error if its sunset passed.
+func (d Deprecations) use(ctx context.Context, name string) error {
+	caller := actorOrCaller(ctx)
+	deprecatedUsage.Add(name+"/"+caller, 1)
+
+	sunset, hasSunset := d.sunsets[name]
+	if !hasSunset {
+		sunset, hasSunset = d.sunsets[AllDeprecations]
+	}
+
+	if hasSunset && !d.now().Before(sunset) {
+		return RejectionError(
+			codes.FailedPrecondition,
+			Rejection{Kind: RejectionPolicy, Rule: "deprecation_sunset", Subject: name},
+			"%s is deprecated and was retired on %s",
+			name,
+			sunset.Format(sunsetDateLayout),
+		)
+	}
+
+	if _, logged := d.logged.LoadOrStore(name+"/"+caller, true); !logged {
+		fields := logrus.Fields{"deprecation": name, "caller": caller}
+		if hasSunset {
+			fields["sunset"] = sunset.Format(sunsetDateLayout)
+		}
+
+		d.logger.WithFields(fields).Warn("deprecated API used")
+	}
+
+	return nil
+}
+
+// deprecatedFieldsOf returns the full names of the deprecated fields that are set in msg,
+// or in the messages in it.
+func (d Deprecations) deprecatedFieldsOf(msg proto.Message) []string {
+	value := reflect.ValueOf(msg)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return nil
+	}
+
+	var names []string
+	for _, field := range d.messageFields(msg) {
+		fieldValue := value.Elem().Field(field.index)
+		if field.deprecated {
+			if !isZero(fieldValue) {
+				names = append(names, field.name)
+			}
+
+			continue
+		}
+
+		switch fieldValue.Kind() {
+		case reflect.Ptr:
+			if nested, ok := fieldValue.Interface().(proto.Message); ok {
+				names = append(names, d.deprecatedFieldsOf(nested)...)
+			}
+		case reflect.Slice:
+			for i := 0; i < fieldValue.Len(); i++ {
+				if nested, ok := fieldValue.Index(i).Interface().(proto.Message); ok {
+					names = append(names, d.deprecatedFieldsOf(nested)...)
+				}
+			}
+		}
+	}
+
+	return names
+}
+
+// messageFields returns the fields of msg that are deprecated or of a message type.
+func (d Deprecations) messageFields(msg proto.Message) []deprecatedField {
+	messageType := reflect.TypeOf(msg)
+	if cached, ok := d.fields.Load(messageType); ok {
+		return cached.([]deprecatedField)
+	}
+
+	var fields []deprecatedField
+	if described, ok := msg.(descriptor.Message); ok {
+		fileDescriptor, messageDescriptor := descriptor.ForMessage(described)
+		messageName := messageFullName(fileDescriptor, described)
+		indexes := protoFieldIndexes(messageType.Elem())
+		for _, field := range messageDescriptor.GetField() {
+			index, ok := indexes[field.GetNumber()]
+			isMessage := field.GetType() == pbdescriptor.FieldDescriptorProto_TYPE_MESSAGE
+			if !ok || (!field.GetOptions().GetDeprecated() && !isMessage) {
+				continue
+			}
+
+			fields = append(fields, deprecatedField{
+				name:       messageName + "." + field.GetName(),
+				index:      index,
+				deprecated: field.GetOptions().GetDeprecated(),
+			})
+		}
+	}
+
+	d.fields.Store(messageType, fields)
+	return fields
+}
+
+// deprecationServerStream is a grpc.ServerStream that records the uses of the deprecated fields
+// of the messages it receives.
+type deprecationServerStream struct {
+	grpc.ServerStream
+	deprecations Deprecations
+}
+
+// RecvMsg receives a message into m and records the uses of its deprecated fields.
+func (s deprecationServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	return s.deprecations.check(s.Context(), "", m)
+}
+
+// deprecatedMethods returns the full names of the deprecated RPCs of descs, such as
+// "permission.Permission.GetSharedFiles", by their grpc full methods, and an empty name for the other RPCs.
+func deprecatedMethods(descs []grpc.ServiceDesc) (map[string]string, error) {
+	methods := map[string]string{}
+	for _, desc := range descs {
+		serviceMethods, err := serviceMethods(desc)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, method := range serviceMethods {
+			name := ""
+			if method.GetOptions().GetDeprecated() {
+				name = desc.ServiceName + "." + method.GetName()
+			}
+
+			methods["/"+desc.ServiceName+"/"+method.GetName()] = name
+		}
+	}
+
+	return methods, nil
+}
+
+// serviceMethods returns the descriptors of the RPCs of desc from its registered proto,
+// which are annotated with their options. It fails if the proto of desc isn't registered.
+func serviceMethods(desc grpc.ServiceDesc) ([]*pbdescriptor.MethodDescriptorProto, error) {
+	fileName, _ := desc.Metadata.(string)
+	compressed := proto.FileDescriptor(fileName)
+	if compressed == nil {
+		return nil, fmt.Errorf("the proto %q of service %s isn't registered", fileName, desc.ServiceName)
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("failed decompressing the proto %q: %v", fileName, err)
+	}
+	defer reader.Close()
+
+	raw, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed decompressing the proto %q: %v", fileName, err)
+	}
+
+	var fileDescriptor pbdescriptor.FileDescriptorProto
+	if err := proto.Unmarshal(raw, &fileDescriptor); err != nil {
+		return nil, fmt.Errorf("failed parsing the proto %q: %v", fileName, err)
+	}
+
+	for _, service := range fileDescriptor.GetService() {
+		if fileDescriptor.GetPackage()+"."+service.GetName() == desc.ServiceName {
+			return service.GetMethod(), nil
+		}
+	}
+
+	return nil, fmt.Errorf("the proto %q doesn't describe service %s", fileName, desc.ServiceName)
+}
+
+// messageFullName returns the full name of the message msg of the proto fileDescriptor,
+// such as "permission.CreatePermissionRequest".
+func messageFullName(fileDescriptor *pbdescriptor.FileDescriptorProto, msg descriptor.Message) string {
+	_, path := msg.Descriptor()
+	name := fileDescriptor.GetPackage()
+	messages := fileDescriptor.GetMessageType()
+	for _, i := range path {
+		name += "." + messages[i].GetName()
+		messages = messages[i].GetNestedType()
+	}
+
+	return name
+}
+
+// protoFieldIndexes returns the indexes of the fields of the struct of a message by their field numbers.
+func protoFieldIndexes(structType reflect.Type) map[int32]int {
+	indexes := map[int32]int{}
+	for i := 0; i < structType.NumField(); i++ {
+		tag := strings.Split(structType.Field(i).Tag.Get("protobuf"), ",")
+		if len(tag) < 2 {
+			continue
+		}
+
+		if number, err := strconv.Atoi(tag[1]); err == nil {
+			indexes[int32(number)] = i
+		}
+	}
+
+	return indexes
+}
+
+// isZero returns whether value is the zero value of its field, which is the value of a field that isn't set.
+func isZero(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return value.IsNil()
+	case reflect.Slice, reflect.Map, reflect.String:
+		return value.Len() == 0
+	default:
+		return value.IsZero()
+	}
+}
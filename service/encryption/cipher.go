@@ -0,0 +1,160 @@
+// Package encryption encrypts the user identifiers of permissions before they're stored.
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	pb "github.com/meateam/permission-service/proto"
+)
+
+const (
+	// KeySize is the size in bytes of the key that identifiers are encrypted with.
+	KeySize = 32
+
+	// encryptedPrefix prefixes the encrypted identifiers, to tell them apart from plaintext identifiers
+	// that were stored before encryption was enabled.
+	encryptedPrefix = "enc1:"
+)
+
+// IdentifierCipher encrypts and decrypts identifiers deterministically, an identifier is always
+// encrypted to the same ciphertext so that permissions can be looked up by their encrypted identifiers.
+// The IV of each identifier is derived from the identifier with HMAC-SHA256, and the identifier is
+// encrypted with AES-256-GCM.
+type IdentifierCipher struct {
+	aead  cipher.AEAD
+	ivKey []byte
+}
+
+// NewIdentifierCipher creates an IdentifierCipher that's keyed by key, which must be KeySize bytes long.
+func NewIdentifierCipher(key []byte) (IdentifierCipher, error) {
+	if len(key) != KeySize {
+		return IdentifierCipher{}, fmt.Errorf("encryption key must be %d bytes long, got %d", KeySize, len(key))
+	}
+
+	// Derive independent keys for the encryption and the IVs from key.
+	block, err := aes.NewCipher(deriveKey(key, "encryption"))
+	if err != nil {
+		return IdentifierCipher{}, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return IdentifierCipher{}, err
+	}
+
+	return IdentifierCipher{aead: aead, ivKey: deriveKey(key, "iv")}, nil
+}
+
+// ParseKey decodes the base64 encoded key, surrounding whitespace is ignored.
+func ParseKey(encodedKey string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encodedKey))
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key: %v", err)
+	}
+
+	return key, nil
+}
+
+// Encrypt returns the ciphertext of id, an empty id is returned as is.
+func (c IdentifierCipher) Encrypt(id string) string {
+	if id == "" {
+		return ""
+	}
+
+	mac := hmac.New(sha256.New, c.ivKey)
+	mac.Write([]byte(id))
+	iv := mac.Sum(nil)[:c.aead.NonceSize()]
+
+	sealed := c.aead.Seal(iv, iv, []byte(id), nil)
+	return encryptedPrefix + base64.RawURLEncoding.EncodeToString(sealed)
+}
+
+// Decrypt returns the identifier of ciphertext. A ciphertext that wasn't encrypted
+// is returned as is, so that identifiers stored before encryption was enabled are readable.
+func (c IdentifierCipher) Decrypt(ciphertext string) (string, error) {
+	if !strings.HasPrefix(ciphertext, encryptedPrefix) {
+		return ciphertext, nil
+	}
+
+	sealed, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(ciphertext, encryptedPrefix))
+	if err != nil || len(sealed) < c.aead.NonceSize() {
+		return "", fmt.Errorf("invalid encrypted identifier")
+	}
+
+	nonceSize := c.aead.NonceSize()
+	id, err := c.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], nil)
+	if err != nil {
+		return "", fmt.Errorf("failed decrypting identifier: %v", err)
+	}
+
+	return string(id), nil
+}
+
+// EncryptAll returns the ciphertexts of ids.
+func (c IdentifierCipher) EncryptAll(ids []string) []string {
+	if ids == nil {
+		return nil
+	}
+
+	ciphertexts := make([]string, 0, len(ids))
+	for _, id := range ids {
+		ciphertexts = append(ciphertexts, c.Encrypt(id))
+	}
+
+	return ciphertexts
+}
+
+// DecryptAll returns the identifiers of ciphertexts.
+func (c IdentifierCipher) DecryptAll(ciphertexts []string) ([]string, error) {
+	if ciphertexts == nil {
+		return nil, nil
+	}
+
+	ids := make([]string, 0, len(ciphertexts))
+	for _, ciphertext := range ciphertexts {
+		id, err := c.Decrypt(ciphertext)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// deriveKey derives a key for purpose from key.
+func deriveKey(key []byte, purpose string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
+
+// decryptProto decrypts the user identifiers of permission in place.
+func (c IdentifierCipher) decryptProto(permission *pb.PermissionObject) error {
+	userID, err := c.Decrypt(permission.GetUserID())
+	if err != nil {
+		return err
+	}
+
+	creator, err := c.Decrypt(permission.GetCreator())
+	if err != nil {
+		return err
+	}
+
+	sharingChain, err := c.DecryptAll(permission.GetSharingChain())
+	if err != nil {
+		return err
+	}
+
+	permission.UserID = userID
+	permission.Creator = creator
+	permission.SharingChain = sharingChain
+	return nil
+}
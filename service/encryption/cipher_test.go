@@ -0,0 +1,90 @@
+package encryption
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+// newTestCipher returns an IdentifierCipher keyed by a key of KeySize bytes of b.
+func newTestCipher(t *testing.T, b byte) IdentifierCipher {
+	t.Helper()
+
+	identifierCipher, err := NewIdentifierCipher(bytes.Repeat([]byte{b}, KeySize))
+	if err != nil {
+		t.Fatalf("NewIdentifierCipher failed: %v", err)
+	}
+
+	return identifierCipher
+}
+
+func TestIdentifierCipher(t *testing.T) {
+	identifierCipher := newTestCipher(t, 1)
+
+	ciphertext := identifierCipher.Encrypt("user-1")
+	if !strings.HasPrefix(ciphertext, encryptedPrefix) || strings.Contains(ciphertext, "user-1") {
+		t.Errorf("expected an encrypted identifier, got %q", ciphertext)
+	}
+
+	if again := identifierCipher.Encrypt("user-1"); again != ciphertext {
+		t.Errorf("expected the encryption to be deterministic, got %q and %q", ciphertext, again)
+	}
+
+	if other := identifierCipher.Encrypt("user-2"); other == ciphertext {
+		t.Errorf("expected different identifiers to have different ciphertexts")
+	}
+
+	if other := newTestCipher(t, 2).Encrypt("user-1"); other == ciphertext {
+		t.Errorf("expected different keys to have different ciphertexts")
+	}
+
+	id, err := identifierCipher.Decrypt(ciphertext)
+	if err != nil || id != "user-1" {
+		t.Errorf("expected the ciphertext to be decrypted to user-1, got %q, %v", id, err)
+	}
+
+	if id, err := identifierCipher.Decrypt("user-3"); err != nil || id != "user-3" {
+		t.Errorf("expected a plaintext identifier to be returned as is, got %q, %v", id, err)
+	}
+
+	if _, err := newTestCipher(t, 2).Decrypt(ciphertext); err == nil {
+		t.Errorf("expected the decryption with another key to fail")
+	}
+
+	sealed, _ := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(ciphertext, encryptedPrefix))
+	sealed[len(sealed)-1] ^= 1
+	tampered := encryptedPrefix + base64.RawURLEncoding.EncodeToString(sealed)
+	if _, err := identifierCipher.Decrypt(tampered); err == nil {
+		t.Errorf("expected the decryption of a tampered ciphertext to fail")
+	}
+
+	if _, err := identifierCipher.Decrypt(encryptedPrefix + "!"); err == nil {
+		t.Errorf("expected the decryption of a malformed ciphertext to fail")
+	}
+
+	if identifierCipher.Encrypt("") != "" || identifierCipher.EncryptAll(nil) != nil {
+		t.Errorf("expected empty identifiers to be returned as is")
+	}
+
+	ids, err := identifierCipher.DecryptAll(identifierCipher.EncryptAll([]string{"user-1", "user-2"}))
+	if err != nil || len(ids) != 2 || ids[0] != "user-1" || ids[1] != "user-2" {
+		t.Errorf("expected the identifiers to be decrypted in order, got %v, %v", ids, err)
+	}
+}
+
+func TestNewIdentifierCipherKey(t *testing.T) {
+	if _, err := NewIdentifierCipher(make([]byte, 16)); err == nil {
+		t.Errorf("expected NewIdentifierCipher to fail with a key of 16 bytes")
+	}
+
+	encodedKey := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, KeySize))
+	key, err := ParseKey(" " + encodedKey + "\n")
+	if err != nil || !bytes.Equal(key, bytes.Repeat([]byte{1}, KeySize)) {
+		t.Errorf("expected the key to be decoded, got %v, %v", key, err)
+	}
+
+	if _, err := ParseKey("not base64!"); err == nil {
+		t.Errorf("expected ParseKey to fail with a malformed key")
+	}
+}
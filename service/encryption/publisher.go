@@ -0,0 +1,34 @@
+package encryption
+
+import (
+	"context"
+
+	"github.com/meateam/permission-service/service"
+)
+
+// Publisher is a service.EventPublisher that decrypts the user identifiers of the permissions
+// of the events before they're published by the underlying publisher.
+type Publisher struct {
+	publisher service.EventPublisher
+	cipher    IdentifierCipher
+}
+
+// NewPublisher returns a Publisher that publishes the decrypted events with publisher.
+func NewPublisher(publisher service.EventPublisher, cipher IdentifierCipher) Publisher {
+	return Publisher{publisher: publisher, cipher: cipher}
+}
+
+// Publish decrypts the user identifiers of event's permission, and of its revoked permissions, and publishes it.
+func (p Publisher) Publish(ctx context.Context, event service.PermissionEvent) error {
+	if err := p.cipher.decryptProto(event.Permission); err != nil {
+		return err
+	}
+
+	for _, revoked := range event.Revoked {
+		if err := p.cipher.decryptProto(revoked); err != nil {
+			return err
+		}
+	}
+
+	return p.publisher.Publish(ctx, event)
+}
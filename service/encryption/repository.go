@@ -0,0 +1,1323 @@
+package encryption
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/meateam/permission-service/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Repository is a service.PermissionRepository that encrypts the user identifiers of the permissions,
+// their user IDs, creators and sharing chains, before they're passed to the underlying repository,
+// and decrypts them in the permissions it returns. It implements each method of the interface, rather than
+// embedding the underlying repository, so that a new method can't pass user identifiers through unencrypted.
+//
+// The permissions that were stored in plaintext before encryption was enabled aren't migrated, they're read
+// as is. The lookups of the permissions of a user, Get, GetByUser, ListByUser, Update, Touch and Delete,
+// fall back to its plaintext identifier, and Create updates the plaintext permission of the user to the file,
+// if there's one, rather than storing another, encrypted, permission. The other lookups of user identifiers,
+// such as of creators and sharers, only match encrypted identifiers.
+type Repository struct {
+	permissions service.PermissionRepository
+	cipher      IdentifierCipher
+}
+
+// plaintextPageTokenPrefix prefixes the page tokens of the plaintext permissions of a user,
+// which ListByUser lists after its encrypted permissions.
+const plaintextPageTokenPrefix = "plaintext:"
+
+// NewRepository returns a Repository that stores the permissions in permissions,
+// with their user identifiers encrypted by cipher.
+func NewRepository(permissions service.PermissionRepository, cipher IdentifierCipher) Repository {
+	return Repository{permissions: permissions, cipher: cipher}
+}
+
+// Create creates the permission with encrypted user identifiers and returns it decrypted. If a plaintext
+// permission of userID to fileID exists then it's returned, or updated to values if override is true, instead.
+func (r Repository) Create(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+	creator string,
+	sharingChain []string,
+	values service.PermissionUpdate,
+	override bool,
+) (service.Permission, error) {
+	permission, err := r.permissions.Get(ctx, resourceType, fileID, userID)
+	if err == nil {
+		if !override {
+			return r.decrypt(permission, nil)
+		}
+
+		fields := []service.PermissionField{
+			service.RoleField,
+			service.CanReshareField,
+			service.MessageField,
+			service.LabelField,
+			service.LabelsField,
+		}
+		return r.decrypt(r.permissions.Update(ctx, resourceType, fileID, userID, "", values, fields))
+	}
+
+	if status.Code(err) != codes.NotFound {
+		return nil, err
+	}
+
+	return r.decrypt(r.permissions.Create(
+		ctx,
+		resourceType,
+		fileID,
+		r.cipher.Encrypt(userID),
+		r.cipher.Encrypt(creator),
+		r.cipher.EncryptAll(sharingChain),
+		values,
+		override,
+	))
+}
+
+// GetByID returns the permission with id decrypted.
+func (r Repository) GetByID(ctx context.Context, id string) (service.Permission, error) {
+	return r.decrypt(r.permissions.GetByID(ctx, id))
+}
+
+// Get returns the permission of userID to fileID decrypted.
+func (r Repository) Get(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+	fields ...service.PermissionField,
+) (service.Permission, error) {
+	return r.withPlaintextFallback(userID, func(userID string) (service.Permission, error) {
+		return r.permissions.Get(ctx, resourceType, fileID, userID, fields...)
+	})
+}
+
+// GetByResource returns the permissions of fileID decrypted.
+func (r Repository) GetByResource(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	order pb.PermissionsOrder,
+	selector service.PermissionSelector,
+) ([]service.Permission, error) {
+	return r.decryptAll(r.permissions.GetByResource(ctx, resourceType, fileID, order, selector))
+}
+
+// GetByUser returns the permissions of userID, both encrypted and plaintext, decrypted.
+func (r Repository) GetByUser(
+	ctx context.Context,
+	resourceType string,
+	userID string,
+	order pb.PermissionsOrder,
+	selector service.PermissionSelector,
+) ([]service.Permission, error) {
+	permissions, err := r.permissions.GetByUser(ctx, resourceType, r.cipher.Encrypt(userID), order, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := r.permissions.GetByUser(ctx, resourceType, userID, order, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions = append(permissions, plaintext...)
+	if order == pb.PermissionsOrder_RECENTLY_ACCESSED {
+		sort.SliceStable(permissions, func(i, j int) bool {
+			return permissions[i].GetLastAccessedAt().After(permissions[j].GetLastAccessedAt())
+		})
+	}
+
+	return r.decryptAll(permissions, nil)
+}
+
+// GetBySharer returns the permissions of fileID that sharerID reshared decrypted.
+func (r Repository) GetBySharer(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	sharerID string,
+) ([]service.Permission, error) {
+	return r.decryptAll(r.permissions.GetBySharer(ctx, resourceType, fileID, r.cipher.Encrypt(sharerID)))
+}
+
+// GetByFilter returns the permissions that match filter, whose user identifiers are encrypted, decrypted.
+func (r Repository) GetByFilter(
+	ctx context.Context,
+	filter service.PermissionsFilter,
+) ([]service.Permission, error) {
+	filter.UserIDs = r.cipher.EncryptAll(filter.UserIDs)
+	filter.Creator = r.cipher.Encrypt(filter.Creator)
+	return r.decryptAll(r.permissions.GetByFilter(ctx, filter))
+}
+
+// GetShared returns the resources that both userA and userB have a permission to.
+func (r Repository) GetShared(
+	ctx context.Context,
+	resourceType string,
+	userA string,
+	userB string,
+	grantedByA bool,
+) ([]service.SharedFile, error) {
+	return r.permissions.GetShared(
+		ctx,
+		resourceType,
+		r.cipher.Encrypt(userA),
+		r.cipher.Encrypt(userB),
+		grantedByA,
+	)
+}
+
+// ListByResource returns a page of the permissions of fileID decrypted.
+func (r Repository) ListByResource(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	order pb.PermissionsOrder,
+	pageSize int,
+	pageToken string,
+	fields []service.PermissionField,
+	selector service.PermissionSelector,
+) ([]service.Permission, string, error) {
+	permissions, nextPageToken, err := r.permissions.ListByResource(
+		ctx,
+		resourceType,
+		fileID,
+		order,
+		pageSize,
+		pageToken,
+		fields,
+		selector,
+	)
+
+	permissions, err = r.decryptAll(permissions, err)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return permissions, nextPageToken, nil
+}
+
+// ListByUser returns a page of the permissions of userID decrypted. The encrypted permissions are listed first,
+// followed by the plaintext ones, whose page tokens are prefixed by plaintextPageTokenPrefix.
+func (r Repository) ListByUser(
+	ctx context.Context,
+	resourceType string,
+	userID string,
+	order pb.PermissionsOrder,
+	pageSize int,
+	pageToken string,
+	selector service.PermissionSelector,
+) ([]service.Permission, string, error) {
+	if strings.HasPrefix(pageToken, plaintextPageTokenPrefix) {
+		return r.listPlaintextByUser(
+			ctx,
+			resourceType,
+			userID,
+			order,
+			pageSize,
+			strings.TrimPrefix(pageToken, plaintextPageTokenPrefix),
+			selector,
+		)
+	}
+
+	permissions, nextPageToken, err := r.permissions.ListByUser(
+		ctx,
+		resourceType,
+		r.cipher.Encrypt(userID),
+		order,
+		pageSize,
+		pageToken,
+		selector,
+	)
+
+	permissions, err = r.decryptAll(permissions, err)
+	if err != nil || nextPageToken != "" {
+		return permissions, nextPageToken, err
+	}
+
+	// The rest of the page is filled with the first plaintext permissions, and if it's full then
+	// the next page starts with them if there are any.
+	if len(permissions) == pageSize {
+		plaintext, _, err := r.permissions.ListByUser(ctx, resourceType, userID, order, 1, "", selector)
+		if err != nil || len(plaintext) == 0 {
+			return permissions, "", err
+		}
+
+		return permissions, plaintextPageTokenPrefix, nil
+	}
+
+	plaintext, nextPageToken, err := r.listPlaintextByUser(
+		ctx,
+		resourceType,
+		userID,
+		order,
+		pageSize-len(permissions),
+		"",
+		selector,
+	)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return append(permissions, plaintext...), nextPageToken, nil
+}
+
+// listPlaintextByUser returns a page of the plaintext permissions of userID decrypted,
+// with a next page token that's prefixed by plaintextPageTokenPrefix.
+func (r Repository) listPlaintextByUser(
+	ctx context.Context,
+	resourceType string,
+	userID string,
+	order pb.PermissionsOrder,
+	pageSize int,
+	pageToken string,
+	selector service.PermissionSelector,
+) ([]service.Permission, string, error) {
+	permissions, nextPageToken, err := r.permissions.ListByUser(
+		ctx,
+		resourceType,
+		userID,
+		order,
+		pageSize,
+		pageToken,
+		selector,
+	)
+
+	permissions, err = r.decryptAll(permissions, err)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if nextPageToken != "" {
+		nextPageToken = plaintextPageTokenPrefix + nextPageToken
+	}
+
+	return permissions, nextPageToken, nil
+}
+
+// ListByGranteeType returns a page of the permissions of granteeType, and of the encrypted granteeID
+// if it's set, decrypted.
+func (r Repository) ListByGranteeType(
+	ctx context.Context,
+	granteeType string,
+	granteeID string,
+	pageSize int,
+	pageToken string,
+) ([]service.Permission, string, error) {
+	permissions, nextPageToken, err := r.permissions.ListByGranteeType(
+		ctx,
+		granteeType,
+		r.cipher.Encrypt(granteeID),
+		pageSize,
+		pageToken,
+	)
+
+	permissions, err = r.decryptAll(permissions, err)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return permissions, nextPageToken, nil
+}
+
+// ListStale returns a page of the stale permissions of resourceType decrypted.
+func (r Repository) ListStale(
+	ctx context.Context,
+	resourceType string,
+	before time.Time,
+	pageSize int,
+	pageToken string,
+) ([]service.Permission, string, error) {
+	permissions, nextPageToken, err := r.permissions.ListStale(
+		ctx,
+		resourceType,
+		before,
+		pageSize,
+		pageToken,
+	)
+
+	permissions, err = r.decryptAll(permissions, err)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return permissions, nextPageToken, nil
+}
+
+// Update updates the permission of userID to fileID and returns it decrypted.
+func (r Repository) Update(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+	etag string,
+	update service.PermissionUpdate,
+	fields []service.PermissionField,
+) (service.Permission, error) {
+	return r.withPlaintextFallback(userID, func(userID string) (service.Permission, error) {
+		return r.permissions.Update(ctx, resourceType, fileID, userID, etag, update, fields)
+	})
+}
+
+// Touch sets the last access time of the permission of userID to fileID and returns it decrypted.
+func (r Repository) Touch(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+	accessedAt time.Time,
+) (service.Permission, error) {
+	return r.withPlaintextFallback(userID, func(userID string) (service.Permission, error) {
+		return r.permissions.Touch(ctx, resourceType, fileID, userID, accessedAt)
+	})
+}
+
+// Delete deletes the permission of userID to fileID and returns it decrypted.
+func (r Repository) Delete(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+	etag string,
+) (service.Permission, error) {
+	return r.withPlaintextFallback(userID, func(userID string) (service.Permission, error) {
+		return r.permissions.Delete(ctx, resourceType, fileID, userID, etag)
+	})
+}
+
+// DeleteByID deletes the permission with id and returns it decrypted.
+func (r Repository) DeleteByID(ctx context.Context, id string) (service.Permission, error) {
+	return r.decrypt(r.permissions.DeleteByID(ctx, id))
+}
+
+// DeleteAllExceptUser deletes every permission to fileID other than the permission of userID
+// and returns them decrypted.
+func (r Repository) DeleteAllExceptUser(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+) ([]service.Permission, error) {
+	return r.decryptAll(
+		r.permissions.DeleteAllExceptUser(ctx, resourceType, fileID, r.cipher.Encrypt(userID)),
+	)
+}
+
+// AssignOwner makes userID the owner of fileID and returns its permission decrypted.
+func (r Repository) AssignOwner(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+) (service.Permission, error) {
+	return r.decrypt(r.permissions.AssignOwner(ctx, resourceType, fileID, r.cipher.Encrypt(userID)))
+}
+
+// UpdateRoles changes the roles of the permissions of updates, whose user identifiers are encrypted,
+// and returns the results with the permissions decrypted.
+func (r Repository) UpdateRoles(
+	ctx context.Context,
+	updates []service.RoleUpdate,
+) ([]service.RoleUpdateResult, error) {
+	encryptedUpdates := make([]service.RoleUpdate, len(updates))
+	for i, update := range updates {
+		update.UserID = r.cipher.Encrypt(update.UserID)
+		encryptedUpdates[i] = update
+	}
+
+	results, err := r.permissions.UpdateRoles(ctx, encryptedUpdates)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, result := range results {
+		if result.Err != nil {
+			continue
+		}
+
+		if results[i].Permission, err = r.decrypt(result.Permission, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+// MigrateRole migrates the role of the permissions that match filter, whose user identifiers are encrypted.
+func (r Repository) MigrateRole(
+	ctx context.Context,
+	fromRole pb.Role,
+	toRole pb.Role,
+	filter service.PermissionsFilter,
+	batchSize int,
+	progress func(migrated int64, total int64) error,
+) error {
+	filter.UserIDs = r.cipher.EncryptAll(filter.UserIDs)
+	filter.Creator = r.cipher.Encrypt(filter.Creator)
+	return r.permissions.MigrateRole(ctx, fromRole, toRole, filter, batchSize, progress)
+}
+
+// ListChanges returns the changes to the permissions of userID with the permissions decrypted.
+func (r Repository) ListChanges(
+	ctx context.Context,
+	resourceType string,
+	userID string,
+	syncToken string,
+	pageSize int,
+) (service.PermissionChanges, error) {
+	changes, err := r.permissions.ListChanges(
+		ctx,
+		resourceType,
+		r.cipher.Encrypt(userID),
+		syncToken,
+		pageSize,
+	)
+	if err != nil {
+		return service.PermissionChanges{}, err
+	}
+
+	for _, event := range changes.Events {
+		if err := r.cipher.decryptProto(event.Permission); err != nil {
+			return service.PermissionChanges{}, err
+		}
+	}
+
+	return changes, nil
+}
+
+// SummarizeSharing returns the summary of the sharing of the tree of folderID,
+// with its external grantees decrypted.
+func (r Repository) SummarizeSharing(
+	ctx context.Context,
+	resourceType string,
+	folderID string,
+	descendantIDs []string,
+) (service.SharingSummary, error) {
+	summary, err := r.permissions.SummarizeSharing(ctx, resourceType, folderID, descendantIDs)
+	if err != nil {
+		return service.SharingSummary{}, err
+	}
+
+	if summary.ExternalGrantees, err = r.cipher.DecryptAll(summary.ExternalGrantees); err != nil {
+		return service.SharingSummary{}, err
+	}
+
+	sort.Strings(summary.ExternalGrantees)
+	return summary, nil
+}
+
+// ListSharedWithMe returns a page of the files that other users shared with userID, with their sharers decrypted.
+func (r Repository) ListSharedWithMe(
+	ctx context.Context,
+	resourceType string,
+	userID string,
+	pageSize int,
+	pageToken string,
+) ([]service.SharedWithMe, string, error) {
+	files, nextPageToken, err := r.permissions.ListSharedWithMe(
+		ctx,
+		resourceType,
+		r.cipher.Encrypt(userID),
+		pageSize,
+		pageToken,
+	)
+	if err != nil {
+		return nil, "", err
+	}
+
+	for i := range files {
+		if files[i].SharedBy, err = r.cipher.Decrypt(files[i].SharedBy); err != nil {
+			return nil, "", err
+		}
+	}
+
+	return files, nextPageToken, nil
+}
+
+// ListFileEvents returns a page of the recorded events of the permissions to fileID
+// with the permissions decrypted.
+func (r Repository) ListFileEvents(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	since time.Time,
+	pageSize int,
+	pageToken string,
+) ([]service.PermissionEvent, string, error) {
+	events, nextPageToken, err := r.permissions.ListFileEvents(
+		ctx,
+		resourceType,
+		fileID,
+		since,
+		pageSize,
+		pageToken,
+	)
+	if err != nil {
+		return nil, "", err
+	}
+
+	for _, event := range events {
+		permissions := append([]*pb.PermissionObject{event.Permission}, event.Revoked...)
+		if event.Previous != nil {
+			permissions = append(permissions, event.Previous)
+		}
+
+		for _, permission := range permissions {
+			if err := r.cipher.decryptProto(permission); err != nil {
+				return nil, "", err
+			}
+		}
+	}
+
+	return events, nextPageToken, nil
+}
+
+// GetEventsByUser returns the recorded events that reference userID with the permissions decrypted.
+func (r Repository) GetEventsByUser(ctx context.Context, userID string) ([]service.PermissionEvent, error) {
+	events, err := r.permissions.GetEventsByUser(ctx, r.cipher.Encrypt(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, event := range events {
+		if err := r.cipher.decryptProto(event.Permission); err != nil {
+			return nil, err
+		}
+
+		for _, revoked := range event.Revoked {
+			if err := r.cipher.decryptProto(revoked); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return events, nil
+}
+
+// GetFrequentCollaborators returns the frequent collaborators of userID decrypted.
+func (r Repository) GetFrequentCollaborators(
+	ctx context.Context,
+	userID string,
+	limit int,
+) ([]service.Collaborator, error) {
+	collaborators, err := r.permissions.GetFrequentCollaborators(ctx, r.cipher.Encrypt(userID), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range collaborators {
+		if collaborators[i].UserID, err = r.cipher.Decrypt(collaborators[i].UserID); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return collaborators, nil
+}
+
+// ReplaceUser replaces the encrypted userID with the encrypted replacement in the permissions.
+func (r Repository) ReplaceUser(ctx context.Context, userID string, replacement string) (int64, error) {
+	return r.permissions.ReplaceUser(ctx, r.cipher.Encrypt(userID), r.cipher.Encrypt(replacement))
+}
+
+// Sample returns up to size permissions chosen at random decrypted.
+func (r Repository) Sample(ctx context.Context, size int) ([]service.Permission, error) {
+	return r.decryptAll(r.permissions.Sample(ctx, size))
+}
+
+// ExistingIDs returns the set of ids of the permissions that exist, which have no user identifiers.
+func (r Repository) ExistingIDs(ctx context.Context, ids []string) (map[string]bool, error) {
+	return r.permissions.ExistingIDs(ctx, ids)
+}
+
+// CopyPermissions copies the permissions of sourceFileID to destFileID, whose stored user identifiers
+// are already encrypted.
+func (r Repository) CopyPermissions(
+	ctx context.Context,
+	resourceType string,
+	sourceFileID string,
+	destFileID string,
+	overwrite bool,
+) (service.CopyReport, error) {
+	return r.permissions.CopyPermissions(ctx, resourceType, sourceFileID, destFileID, overwrite)
+}
+
+// ReplaceInherited replaces the inherited permissions of subtree with the permissions of parentID,
+// whose stored user identifiers are already encrypted.
+func (r Repository) ReplaceInherited(
+	ctx context.Context,
+	resourceType string,
+	subtree []service.FileNode,
+	parentID string,
+	strategy service.MergeStrategy,
+) (service.InheritanceReport, error) {
+	return r.permissions.ReplaceInherited(ctx, resourceType, subtree, parentID, strategy)
+}
+
+// RepairPermissions repairs the malformed permission documents, which are reported by their IDs.
+func (r Repository) RepairPermissions(
+	ctx context.Context,
+	action service.RepairAction,
+	batchSize int,
+	progress func(malformed []service.MalformedPermission) error,
+) error {
+	return r.permissions.RepairPermissions(ctx, action, batchSize, progress)
+}
+
+// UpgradeSchema upgrades the stored permissions of older versions, whose user identifiers are kept as they are.
+func (r Repository) UpgradeSchema(
+	ctx context.Context,
+	batchSize int,
+	progress func(upgraded int64, total int64) error,
+) error {
+	return r.permissions.UpgradeSchema(ctx, batchSize, progress)
+}
+
+// CountPermissions returns the number of permissions of each resource type and role.
+func (r Repository) CountPermissions(ctx context.Context) ([]service.PermissionCount, error) {
+	return r.permissions.CountPermissions(ctx)
+}
+
+// RefreshCollaborators recomputes the frequent collaborators from the encrypted user identifiers,
+// which GetFrequentCollaborators decrypts.
+func (r Repository) RefreshCollaborators(ctx context.Context) (int64, error) {
+	return r.permissions.RefreshCollaborators(ctx)
+}
+
+// WithCausalConsistency runs fn so that the repository operations in it observe the writes that preceded them.
+func (r Repository) WithCausalConsistency(ctx context.Context, fn func(ctx context.Context) error) error {
+	return r.permissions.WithCausalConsistency(ctx, fn)
+}
+
+// HealthCheck checks the health of the underlying repository.
+func (r Repository) HealthCheck(ctx context.Context) (bool, error) {
+	return r.permissions.HealthCheck(ctx)
+}
+
+// VerifyIndexes verifies the indexes of the underlying repository.
+func (r Repository) VerifyIndexes(ctx context.Context) error {
+	return r.permissions.VerifyIndexes(ctx)
+}
+
+// withPlaintextFallback calls lookup with the encrypted userID, and with the plaintext userID if the permission
+// of the encrypted one isn't found, so that the permissions stored before encryption was enabled are found too.
+// It returns the permission that was found decrypted.
+func (r Repository) withPlaintextFallback(
+	userID string,
+	lookup func(userID string) (service.Permission, error),
+) (service.Permission, error) {
+	permission, err := lookup(r.cipher.Encrypt(userID))
+	if status.Code(err) == codes.NotFound && userID != "" {
+		permission, err = lookup(userID)
+	}
+
+	return r.decrypt(permission, err)
+}
+
+// decrypt decrypts the user identifiers of permission, if err is nil.
+func (r Repository) decrypt(permission service.Permission, err error) (service.Permission, error) {
+	if err != nil {
+		return permission, err
+	}
+
+	userID, err := r.cipher.Decrypt(permission.GetUserID())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	creator, err := r.cipher.Decrypt(permission.GetCreator())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	sharingChain, err := r.cipher.DecryptAll(permission.GetSharingChain())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	// Permissions retrieved with a subset of their fields may not have a creator.
+	if creator != "" {
+		if err := permission.SetCreator(creator); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := permission.SetUserID(userID); err != nil {
+		return nil, err
+	}
+
+	if err := permission.SetSharingChain(sharingChain); err != nil {
+		return nil, err
+	}
+
+	return permission, nil
+}
+
+// decryptAll decrypts the user identifiers of permissions, if err is nil.
+func (r Repository) decryptAll(permissions []service.Permission, err error) ([]service.Permission, error) {
+	if err != nil {
+		return permissions, err
+	}
+
+	for _, permission := range permissions {
+		if _, err := r.decrypt(permission, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return permissions, nil
+}
+
+// RequestRepository is a service.RequestRepository that encrypts the user IDs
+// of the requests before they're passed to the underlying repository.
+type RequestRepository struct {
+	service.RequestRepository
+	cipher IdentifierCipher
+}
+
+// NewRequestRepository returns a RequestRepository that stores the requests in requests,
+// with their user IDs encrypted by cipher.
+func NewRequestRepository(requests service.RequestRepository, cipher IdentifierCipher) RequestRepository {
+	return RequestRepository{RequestRepository: requests, cipher: cipher}
+}
+
+// ClaimIdempotencyKey claims key for creating the permission of the encrypted userID to fileID.
+func (r RequestRepository) ClaimIdempotencyKey(
+	ctx context.Context,
+	key string,
+	resourceType string,
+	fileID string,
+	userID string,
+) (string, error) {
+	return r.RequestRepository.ClaimIdempotencyKey(ctx, key, resourceType, fileID, r.cipher.Encrypt(userID))
+}
+
+// ScheduleRepository is a service.ScheduleRepository that encrypts the user IDs of the scheduled
+// updates before they're passed to the underlying repository, and decrypts them in the updates it returns.
+type ScheduleRepository struct {
+	service.ScheduleRepository
+	cipher IdentifierCipher
+}
+
+// NewScheduleRepository returns a ScheduleRepository that stores the scheduled updates in schedules,
+// with their user IDs encrypted by cipher.
+func NewScheduleRepository(schedules service.ScheduleRepository, cipher IdentifierCipher) ScheduleRepository {
+	return ScheduleRepository{ScheduleRepository: schedules, cipher: cipher}
+}
+
+// ScheduleUpdate stores update with its user ID encrypted and returns it.
+func (r ScheduleRepository) ScheduleUpdate(
+	ctx context.Context,
+	update service.ScheduledUpdate,
+) (service.ScheduledUpdate, error) {
+	userID := update.UserID
+	update.UserID = r.cipher.Encrypt(userID)
+	scheduledUpdate, err := r.ScheduleRepository.ScheduleUpdate(ctx, update)
+	if err != nil {
+		return service.ScheduledUpdate{}, err
+	}
+
+	scheduledUpdate.UserID = userID
+	return scheduledUpdate, nil
+}
+
+// ClaimDueUpdate claims the earliest due update and returns it with its user ID decrypted.
+func (r ScheduleRepository) ClaimDueUpdate(
+	ctx context.Context,
+	now time.Time,
+	lease time.Duration,
+) (*service.ScheduledUpdate, error) {
+	update, err := r.ScheduleRepository.ClaimDueUpdate(ctx, now, lease)
+	if err != nil || update == nil {
+		return update, err
+	}
+
+	if update.UserID, err = r.cipher.Decrypt(update.UserID); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return update, nil
+}
+
+// ApprovalRepository is a service.ApprovalRepository that encrypts the user identifiers of the permission
+// requests, their user IDs, creators and approvers, before they're passed to the underlying repository,
+// and decrypts them in the requests it returns.
+type ApprovalRepository struct {
+	service.ApprovalRepository
+	cipher IdentifierCipher
+}
+
+// NewApprovalRepository returns an ApprovalRepository that stores the permission requests in approvals,
+// with their user identifiers encrypted by cipher.
+func NewApprovalRepository(approvals service.ApprovalRepository, cipher IdentifierCipher) ApprovalRepository {
+	return ApprovalRepository{ApprovalRepository: approvals, cipher: cipher}
+}
+
+// CreatePermissionRequest stores request with encrypted user identifiers and returns it decrypted.
+func (r ApprovalRepository) CreatePermissionRequest(
+	ctx context.Context,
+	request service.PermissionRequest,
+) (service.PermissionRequest, error) {
+	request.UserID = r.cipher.Encrypt(request.UserID)
+	request.Creator = r.cipher.Encrypt(request.Creator)
+	return r.decrypt(r.ApprovalRepository.CreatePermissionRequest(ctx, request))
+}
+
+// GetPermissionRequest returns the request with id decrypted.
+func (r ApprovalRepository) GetPermissionRequest(
+	ctx context.Context,
+	id string,
+) (service.PermissionRequest, error) {
+	return r.decrypt(r.ApprovalRepository.GetPermissionRequest(ctx, id))
+}
+
+// ApprovePermissionRequest approves the request with id by the encrypted approver and returns it decrypted.
+func (r ApprovalRepository) ApprovePermissionRequest(
+	ctx context.Context,
+	id string,
+	approver string,
+	approvedAt time.Time,
+	permissionID string,
+) (service.PermissionRequest, error) {
+	return r.decrypt(r.ApprovalRepository.ApprovePermissionRequest(
+		ctx,
+		id,
+		r.cipher.Encrypt(approver),
+		approvedAt,
+		permissionID,
+	))
+}
+
+// decrypt decrypts the user identifiers of request, or returns err if it's not nil.
+func (r ApprovalRepository) decrypt(
+	request service.PermissionRequest,
+	err error,
+) (service.PermissionRequest, error) {
+	if err != nil {
+		return service.PermissionRequest{}, err
+	}
+
+	for _, id := range []*string{&request.UserID, &request.Creator, &request.Approver} {
+		if *id == "" {
+			continue
+		}
+
+		if *id, err = r.cipher.Decrypt(*id); err != nil {
+			return service.PermissionRequest{}, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return request, nil
+}
+
+// LockRepository is a service.LockRepository that encrypts the user identifiers of the locks of files,
+// their owners and the users that locked them, before they're passed to the underlying repository,
+// and decrypts them in the locks it returns.
+type LockRepository struct {
+	service.LockRepository
+	cipher IdentifierCipher
+}
+
+// NewLockRepository returns a LockRepository that stores the locks of files in locks,
+// with their user identifiers encrypted by cipher.
+func NewLockRepository(locks service.LockRepository, cipher IdentifierCipher) LockRepository {
+	return LockRepository{LockRepository: locks, cipher: cipher}
+}
+
+// SetFileLock stores lock with encrypted user identifiers and returns it decrypted.
+func (r LockRepository) SetFileLock(ctx context.Context, lock service.FileLock) (service.FileLock, error) {
+	lock.Owner = r.cipher.Encrypt(lock.Owner)
+	if lock.LockedBy != "" {
+		lock.LockedBy = r.cipher.Encrypt(lock.LockedBy)
+	}
+
+	return r.decrypt(r.LockRepository.SetFileLock(ctx, lock))
+}
+
+// DeleteFileLock unlocks fileID and returns its lock decrypted.
+func (r LockRepository) DeleteFileLock(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+) (service.FileLock, error) {
+	return r.decrypt(r.LockRepository.DeleteFileLock(ctx, resourceType, fileID))
+}
+
+// GetFileLock returns the lock of fileID decrypted, or nil if it isn't locked.
+func (r LockRepository) GetFileLock(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+) (*service.FileLock, error) {
+	lock, err := r.LockRepository.GetFileLock(ctx, resourceType, fileID)
+	if err != nil || lock == nil {
+		return lock, err
+	}
+
+	decryptedLock, err := r.decrypt(*lock, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &decryptedLock, nil
+}
+
+// decrypt decrypts the user identifiers of lock, or returns err if it's not nil.
+func (r LockRepository) decrypt(lock service.FileLock, err error) (service.FileLock, error) {
+	if err != nil {
+		return service.FileLock{}, err
+	}
+
+	for _, id := range []*string{&lock.Owner, &lock.LockedBy} {
+		if *id == "" {
+			continue
+		}
+
+		if *id, err = r.cipher.Decrypt(*id); err != nil {
+			return service.FileLock{}, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return lock, nil
+}
+
+// HoldRepository is a service.HoldRepository that encrypts the users that placed the legal holds of files
+// before they're passed to the underlying repository, and decrypts them in the holds it returns.
+type HoldRepository struct {
+	service.HoldRepository
+	cipher IdentifierCipher
+}
+
+// NewHoldRepository returns a HoldRepository that stores the legal holds of files in holds,
+// with the users that placed them encrypted by cipher.
+func NewHoldRepository(holds service.HoldRepository, cipher IdentifierCipher) HoldRepository {
+	return HoldRepository{HoldRepository: holds, cipher: cipher}
+}
+
+// SetLegalHold stores hold with an encrypted PlacedBy and returns it decrypted.
+func (r HoldRepository) SetLegalHold(ctx context.Context, hold service.LegalHold) (service.LegalHold, error) {
+	if hold.PlacedBy != "" {
+		hold.PlacedBy = r.cipher.Encrypt(hold.PlacedBy)
+	}
+
+	return r.decrypt(r.HoldRepository.SetLegalHold(ctx, hold))
+}
+
+// DeleteLegalHold releases the hold of fileID and returns it decrypted.
+func (r HoldRepository) DeleteLegalHold(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+) (service.LegalHold, error) {
+	return r.decrypt(r.HoldRepository.DeleteLegalHold(ctx, resourceType, fileID))
+}
+
+// GetLegalHolds returns the holds of the files of fileIDs that are held, decrypted.
+func (r HoldRepository) GetLegalHolds(
+	ctx context.Context,
+	resourceType string,
+	fileIDs []string,
+) ([]service.LegalHold, error) {
+	return r.decryptAll(r.HoldRepository.GetLegalHolds(ctx, resourceType, fileIDs))
+}
+
+// ListLegalHolds returns the holds of the files of resourceType decrypted.
+func (r HoldRepository) ListLegalHolds(ctx context.Context, resourceType string) ([]service.LegalHold, error) {
+	return r.decryptAll(r.HoldRepository.ListLegalHolds(ctx, resourceType))
+}
+
+// decryptAll decrypts the users that placed holds, or returns err if it's not nil.
+func (r HoldRepository) decryptAll(holds []service.LegalHold, err error) ([]service.LegalHold, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range holds {
+		if holds[i], err = r.decrypt(holds[i], nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return holds, nil
+}
+
+// decrypt decrypts the user that placed hold, or returns err if it's not nil.
+func (r HoldRepository) decrypt(hold service.LegalHold, err error) (service.LegalHold, error) {
+	if err != nil {
+		return service.LegalHold{}, err
+	}
+
+	if hold.PlacedBy == "" {
+		return hold, nil
+	}
+
+	if hold.PlacedBy, err = r.cipher.Decrypt(hold.PlacedBy); err != nil {
+		return service.LegalHold{}, status.Error(codes.Internal, err.Error())
+	}
+
+	return hold, nil
+}
+
+// JobRepository is a service.JobRepository that encrypts the creators of the jobs
+// before they're passed to the underlying repository, and decrypts them in the jobs it returns.
+type JobRepository struct {
+	service.JobRepository
+	cipher IdentifierCipher
+}
+
+// NewJobRepository returns a JobRepository that stores the jobs in jobs, with their creators encrypted by cipher.
+func NewJobRepository(jobs service.JobRepository, cipher IdentifierCipher) JobRepository {
+	return JobRepository{JobRepository: jobs, cipher: cipher}
+}
+
+// CreateJob stores job with its creator encrypted and returns it decrypted.
+func (r JobRepository) CreateJob(ctx context.Context, job service.Job) (service.Job, error) {
+	if job.Creator != "" {
+		job.Creator = r.cipher.Encrypt(job.Creator)
+	}
+
+	return r.decrypt(r.JobRepository.CreateJob(ctx, job))
+}
+
+// GetJob returns the job with id decrypted.
+func (r JobRepository) GetJob(ctx context.Context, id string) (service.Job, error) {
+	return r.decrypt(r.JobRepository.GetJob(ctx, id))
+}
+
+// ListJobs returns the jobs of kind decrypted.
+func (r JobRepository) ListJobs(
+	ctx context.Context,
+	kind string,
+	pageSize int,
+	pageToken string,
+) ([]service.Job, string, error) {
+	jobs, nextPageToken, err := r.JobRepository.ListJobs(ctx, kind, pageSize, pageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	for i := range jobs {
+		if jobs[i], err = r.decrypt(jobs[i], nil); err != nil {
+			return nil, "", err
+		}
+	}
+
+	return jobs, nextPageToken, nil
+}
+
+// UpdateJobProgress sets the progress of the job with id and returns it decrypted.
+func (r JobRepository) UpdateJobProgress(
+	ctx context.Context,
+	id string,
+	progress service.JobProgress,
+	updatedAt time.Time,
+) (service.Job, error) {
+	return r.decrypt(r.JobRepository.UpdateJobProgress(ctx, id, progress, updatedAt))
+}
+
+// FinishJob sets the state of the job with id and returns it decrypted.
+func (r JobRepository) FinishJob(
+	ctx context.Context,
+	id string,
+	state service.JobState,
+	progress service.JobProgress,
+	message string,
+	finishedAt time.Time,
+) (service.Job, error) {
+	return r.decrypt(r.JobRepository.FinishJob(ctx, id, state, progress, message, finishedAt))
+}
+
+// CancelJob requests the cancellation of the job with id and returns it decrypted.
+func (r JobRepository) CancelJob(ctx context.Context, id string) (service.Job, error) {
+	return r.decrypt(r.JobRepository.CancelJob(ctx, id))
+}
+
+// decrypt decrypts the creator of job, or returns err if it's not nil.
+func (r JobRepository) decrypt(job service.Job, err error) (service.Job, error) {
+	if err != nil {
+		return service.Job{}, err
+	}
+
+	if job.Creator != "" {
+		if job.Creator, err = r.cipher.Decrypt(job.Creator); err != nil {
+			return service.Job{}, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return job, nil
+}
+
+// ReviewRepository is a service.ReviewRepository that encrypts the user identifiers of the access review
+// campaigns and their items before they're passed to the underlying repository, and decrypts them
+// in the campaigns and items it returns.
+type ReviewRepository struct {
+	service.ReviewRepository
+	cipher IdentifierCipher
+}
+
+// NewReviewRepository returns a ReviewRepository that stores the access review campaigns in reviews,
+// with their user identifiers encrypted by cipher.
+func NewReviewRepository(reviews service.ReviewRepository, cipher IdentifierCipher) ReviewRepository {
+	return ReviewRepository{ReviewRepository: reviews, cipher: cipher}
+}
+
+// CreateAccessReview stores review with its user identifiers encrypted and returns it decrypted.
+func (r ReviewRepository) CreateAccessReview(
+	ctx context.Context,
+	review service.AccessReview,
+) (service.AccessReview, error) {
+	review.DefaultReviewer = r.encrypt(review.DefaultReviewer)
+	review.CreatedBy = r.encrypt(review.CreatedBy)
+
+	return r.decryptReview(r.ReviewRepository.CreateAccessReview(ctx, review))
+}
+
+// GetAccessReview returns the campaign with id decrypted.
+func (r ReviewRepository) GetAccessReview(ctx context.Context, id string) (service.AccessReview, error) {
+	return r.decryptReview(r.ReviewRepository.GetAccessReview(ctx, id))
+}
+
+// ListAccessReviews returns a page of the campaigns decrypted.
+func (r ReviewRepository) ListAccessReviews(
+	ctx context.Context,
+	pageSize int,
+	pageToken string,
+) ([]service.AccessReview, string, error) {
+	reviews, nextPageToken, err := r.ReviewRepository.ListAccessReviews(ctx, pageSize, pageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	for i := range reviews {
+		if reviews[i], err = r.decryptReview(reviews[i], nil); err != nil {
+			return nil, "", err
+		}
+	}
+
+	return reviews, nextPageToken, nil
+}
+
+// ListAccessReviewItems returns a page of the items of the campaign with reviewID that match filter,
+// whose reviewer is encrypted, decrypted.
+func (r ReviewRepository) ListAccessReviewItems(
+	ctx context.Context,
+	reviewID string,
+	filter service.AccessReviewItemFilter,
+	pageSize int,
+	pageToken string,
+) ([]service.AccessReviewItem, string, error) {
+	filter.Reviewer = r.encrypt(filter.Reviewer)
+	items, nextPageToken, err := r.ReviewRepository.ListAccessReviewItems(ctx, reviewID, filter, pageSize, pageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if items, err = r.decryptItems(items, nil); err != nil {
+		return nil, "", err
+	}
+
+	return items, nextPageToken, nil
+}
+
+// DecideAccessReviewItems records decisions, whose users are encrypted, by the encrypted reviewer,
+// and returns the decided items decrypted.
+func (r ReviewRepository) DecideAccessReviewItems(
+	ctx context.Context,
+	reviewID string,
+	reviewer string,
+	decisions []service.AccessReviewDecision,
+	decidedAt time.Time,
+) ([]service.AccessReviewItem, error) {
+	encrypted := make([]service.AccessReviewDecision, len(decisions))
+	for i, decision := range decisions {
+		decision.UserID = r.encrypt(decision.UserID)
+		encrypted[i] = decision
+	}
+
+	return r.decryptItems(
+		r.ReviewRepository.DecideAccessReviewItems(ctx, reviewID, r.encrypt(reviewer), encrypted, decidedAt),
+	)
+}
+
+// StartClosingAccessReview starts closing the campaign with id by the encrypted closedBy and returns it decrypted.
+func (r ReviewRepository) StartClosingAccessReview(
+	ctx context.Context,
+	id string,
+	closedBy string,
+	revokeUndecided bool,
+) (service.AccessReview, error) {
+	return r.decryptReview(
+		r.ReviewRepository.StartClosingAccessReview(ctx, id, r.encrypt(closedBy), revokeUndecided),
+	)
+}
+
+// FinishClosingAccessReview closes the campaign with id and returns it decrypted.
+func (r ReviewRepository) FinishClosingAccessReview(
+	ctx context.Context,
+	id string,
+	revoked int64,
+	skipped int64,
+	closedAt time.Time,
+) (service.AccessReview, error) {
+	return r.decryptReview(r.ReviewRepository.FinishClosingAccessReview(ctx, id, revoked, skipped, closedAt))
+}
+
+// encrypt encrypts the user identifier id, unless it's empty.
+func (r ReviewRepository) encrypt(id string) string {
+	if id == "" {
+		return ""
+	}
+
+	return r.cipher.Encrypt(id)
+}
+
+// decryptReview decrypts the user identifiers of review, or returns err if it's not nil.
+func (r ReviewRepository) decryptReview(review service.AccessReview, err error) (service.AccessReview, error) {
+	if err != nil {
+		return service.AccessReview{}, err
+	}
+
+	for _, id := range []*string{&review.DefaultReviewer, &review.CreatedBy, &review.ClosedBy} {
+		if *id == "" {
+			continue
+		}
+
+		if *id, err = r.cipher.Decrypt(*id); err != nil {
+			return service.AccessReview{}, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return review, nil
+}
+
+// decryptItems decrypts the user identifiers of items, or returns err if it's not nil.
+func (r ReviewRepository) decryptItems(
+	items []service.AccessReviewItem,
+	err error,
+) ([]service.AccessReviewItem, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range items {
+		item := &items[i]
+		for _, id := range []*string{&item.UserID, &item.Creator, &item.Reviewer, &item.DecidedBy} {
+			if *id == "" {
+				continue
+			}
+
+			if *id, err = r.cipher.Decrypt(*id); err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+		}
+	}
+
+	return items, nil
+}
@@ -0,0 +1,296 @@
+package encryption
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/meateam/permission-service/service"
+	"github.com/meateam/permission-service/service/mongodb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// memoryPermissions is a service.PermissionRepository of the permissions of a single resource type in memory,
+// which implements the lookups of the permissions of users. Its other methods panic.
+type memoryPermissions struct {
+	service.PermissionRepository
+
+	// permissions are the stored permissions in the order they were created.
+	permissions []*mongodb.BSON
+}
+
+// copyOf returns a copy of permission, since the repository decrypts the permissions that it reads in place.
+func copyOf(permission *mongodb.BSON) *mongodb.BSON {
+	permissionCopy := *permission
+	return &permissionCopy
+}
+
+// find returns the index of the permission of userID to fileID, or -1 if it doesn't exist.
+func (m *memoryPermissions) find(fileID string, userID string) int {
+	for i, permission := range m.permissions {
+		if permission.FileID == fileID && permission.UserID == userID {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Create creates the permission of userID to fileID, or returns the existing one.
+func (m *memoryPermissions) Create(
+	_ context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+	creator string,
+	sharingChain []string,
+	values service.PermissionUpdate,
+	_ bool,
+) (service.Permission, error) {
+	if i := m.find(fileID, userID); i >= 0 {
+		return copyOf(m.permissions[i]), nil
+	}
+
+	permission := &mongodb.BSON{
+		ResourceType: resourceType,
+		FileID:       fileID,
+		UserID:       userID,
+		Role:         values.Role,
+		Creator:      creator,
+		SharingChain: sharingChain,
+	}
+	m.permissions = append(m.permissions, permission)
+	return copyOf(permission), nil
+}
+
+// Get returns the permission of userID to fileID.
+func (m *memoryPermissions) Get(
+	_ context.Context,
+	_ string,
+	fileID string,
+	userID string,
+	_ ...service.PermissionField,
+) (service.Permission, error) {
+	if i := m.find(fileID, userID); i >= 0 {
+		return copyOf(m.permissions[i]), nil
+	}
+
+	return nil, status.Error(codes.NotFound, "permission not found")
+}
+
+// Update updates the role of the permission of userID to fileID.
+func (m *memoryPermissions) Update(
+	_ context.Context,
+	_ string,
+	fileID string,
+	userID string,
+	_ string,
+	update service.PermissionUpdate,
+	_ []service.PermissionField,
+) (service.Permission, error) {
+	i := m.find(fileID, userID)
+	if i < 0 {
+		return nil, status.Error(codes.NotFound, "permission not found")
+	}
+
+	m.permissions[i].Role = update.Role
+	return copyOf(m.permissions[i]), nil
+}
+
+// Delete deletes the permission of userID to fileID.
+func (m *memoryPermissions) Delete(
+	_ context.Context,
+	_ string,
+	fileID string,
+	userID string,
+	_ string,
+) (service.Permission, error) {
+	i := m.find(fileID, userID)
+	if i < 0 {
+		return nil, status.Error(codes.NotFound, "permission not found")
+	}
+
+	permission := m.permissions[i]
+	m.permissions = append(m.permissions[:i], m.permissions[i+1:]...)
+	return copyOf(permission), nil
+}
+
+// GetByUser returns the permissions of userID.
+func (m *memoryPermissions) GetByUser(
+	_ context.Context,
+	_ string,
+	userID string,
+	_ pb.PermissionsOrder,
+	_ service.PermissionSelector,
+) ([]service.Permission, error) {
+	permissions := []service.Permission{}
+	for _, permission := range m.permissions {
+		if permission.UserID == userID {
+			permissions = append(permissions, copyOf(permission))
+		}
+	}
+
+	return permissions, nil
+}
+
+// ListByUser returns up to pageSize permissions of userID, whose page tokens are the offsets of the pages.
+func (m *memoryPermissions) ListByUser(
+	ctx context.Context,
+	resourceType string,
+	userID string,
+	order pb.PermissionsOrder,
+	pageSize int,
+	pageToken string,
+	selector service.PermissionSelector,
+) ([]service.Permission, string, error) {
+	permissions, _ := m.GetByUser(ctx, resourceType, userID, order, selector)
+	offset, _ := strconv.Atoi(pageToken)
+	end := offset + pageSize
+	if end >= len(permissions) {
+		return permissions[offset:], "", nil
+	}
+
+	return permissions[offset:end], strconv.Itoa(end), nil
+}
+
+// newPlaintextRepository returns a Repository over a store of the permissions of userID to fileIDs,
+// which were stored in plaintext, and the store.
+func newPlaintextRepository(t *testing.T, userID string, fileIDs ...string) (Repository, *memoryPermissions) {
+	t.Helper()
+
+	identifierCipher, err := NewIdentifierCipher(make([]byte, KeySize))
+	if err != nil {
+		t.Fatalf("NewIdentifierCipher failed: %v", err)
+	}
+
+	store := &memoryPermissions{}
+	for _, fileID := range fileIDs {
+		permission := &mongodb.BSON{FileID: fileID, UserID: userID, Role: pb.Role_READ}
+		store.permissions = append(store.permissions, permission)
+	}
+
+	return NewRepository(store, identifierCipher), store
+}
+
+func TestRepositoryPlaintextFallback(t *testing.T) {
+	ctx := context.Background()
+	repository, store := newPlaintextRepository(t, "user-1", "file-1", "file-2")
+
+	permission, err := repository.Get(ctx, "", "file-1", "user-1")
+	if err != nil || permission.GetUserID() != "user-1" {
+		t.Fatalf("expected Get to find the plaintext permission, got %v, %v", permission, err)
+	}
+
+	// Granting the user again without overriding returns the plaintext permission,
+	// and overriding updates it, rather than storing another permission.
+	values := service.PermissionUpdate{Role: pb.Role_WRITE}
+	permission, err = repository.Create(ctx, "", "file-1", "user-1", "user-1", nil, values, false)
+	if err != nil || permission.GetRole() != pb.Role_READ {
+		t.Fatalf("expected Create to return the plaintext READ permission, got %v, %v", permission, err)
+	}
+
+	permission, err = repository.Create(ctx, "", "file-1", "user-1", "user-1", nil, values, true)
+	if err != nil || permission.GetRole() != pb.Role_WRITE {
+		t.Fatalf("expected Create to update the plaintext permission to WRITE, got %v, %v", permission, err)
+	}
+
+	if len(store.permissions) != 2 || store.permissions[0].Role != pb.Role_WRITE {
+		t.Fatalf("expected the plaintext permission to be updated in place, got %v", store.permissions)
+	}
+
+	if _, err := repository.Create(ctx, "", "file-3", "user-1", "user-1", nil, values, false); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	created := store.permissions[2]
+	if !strings.HasPrefix(created.UserID, encryptedPrefix) || !strings.HasPrefix(created.Creator, encryptedPrefix) {
+		t.Errorf("expected the new permission to be stored encrypted, got %s, %s", created.UserID, created.Creator)
+	}
+
+	order, selector := pb.PermissionsOrder_DEFAULT, service.PermissionSelector{}
+	permissions, err := repository.GetByUser(ctx, "", "user-1", order, selector)
+	if err != nil || len(permissions) != 3 {
+		t.Fatalf("expected GetByUser to return the 3 permissions of the user, got %v, %v", permissions, err)
+	}
+
+	for _, permission := range permissions {
+		if permission.GetUserID() != "user-1" {
+			t.Errorf("expected the permissions of GetByUser to be decrypted, got %s", permission.GetUserID())
+		}
+	}
+
+	if _, err := repository.Delete(ctx, "", "file-2", "user-1", ""); err != nil {
+		t.Fatalf("expected Delete to delete the plaintext permission, got %v", err)
+	}
+
+	_, err = repository.Get(ctx, "", "file-2", "user-1")
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected the deleted permission to be NotFound, got %v", err)
+	}
+}
+
+func TestRepositoryListByUserPlaintext(t *testing.T) {
+	ctx := context.Background()
+	repository, _ := newPlaintextRepository(t, "user-1", "file-1", "file-2", "file-3")
+	for _, fileID := range []string{"file-4", "file-5"} {
+		values := service.PermissionUpdate{Role: pb.Role_READ}
+		if _, err := repository.Create(ctx, "", fileID, "user-1", "user-1", nil, values, false); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		pageSize int
+	}{
+		{name: "pages that end with the encrypted permissions", pageSize: 1},
+		{name: "a page of both encrypted and plaintext permissions", pageSize: 3},
+		{name: "a single page", pageSize: 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fileIDs := []string{}
+			pageToken := ""
+			for pages := 0; ; pages++ {
+				if pages > 5 {
+					t.Fatalf("expected the listing to end, got the files %v", fileIDs)
+				}
+
+				permissions, nextPageToken, err := repository.ListByUser(
+					ctx,
+					"",
+					"user-1",
+					pb.PermissionsOrder_DEFAULT,
+					tt.pageSize,
+					pageToken,
+					service.PermissionSelector{},
+				)
+				if err != nil {
+					t.Fatalf("ListByUser failed: %v", err)
+				}
+
+				if len(permissions) == 0 || len(permissions) > tt.pageSize {
+					t.Fatalf("expected a page of 1 to %d permissions, got %d", tt.pageSize, len(permissions))
+				}
+
+				for _, permission := range permissions {
+					fileIDs = append(fileIDs, permission.GetFileID())
+				}
+
+				if nextPageToken == "" {
+					break
+				}
+
+				pageToken = nextPageToken
+			}
+
+			expected := "file-4,file-5,file-1,file-2,file-3"
+			if strings.Join(fileIDs, ",") != expected {
+				t.Errorf("expected the files %s, got %v", expected, fileIDs)
+			}
+		})
+	}
+}
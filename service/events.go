@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/sirupsen/logrus"
+)
+
+// EventType is the type of a change to a permission.
+type EventType string
+
+const (
+	// EventCreated is the type of the event of a permission that was created, or overridden by a create.
+	EventCreated EventType = "created"
+
+	// EventUpdated is the type of the event of a permission whose fields were updated.
+	EventUpdated EventType = "updated"
+
+	// EventDeleted is the type of the event of a permission that was deleted.
+	EventDeleted EventType = "deleted"
+
+	// EventExternalAccess is the type of the event of a permission that was created, like EventCreated, and
+	// makes its file accessible outside of the tenant, such as to an organization. It's written in addition to
+	// the permission's EventCreated event, so that its consumers, such as DLP, don't filter the full stream.
+	EventExternalAccess EventType = "external_access"
+
+	// EventSharingStopped is the type of the single summary event of the permissions to a file, other than its
+	// owner's, that were revoked together to stop sharing it. Its permission is of the file's owner, with only
+	// its resource type, file ID and user ID, and the revoked permissions are in its Revoked.
+	EventSharingStopped EventType = "sharing_stopped"
+
+	// EventOwnerAssigned is the type of the event of the permission of a user that was made the owner of
+	// an orphaned file. Like EventExternalAccess, it's written in addition to the permission's EventCreated event.
+	EventOwnerAssigned EventType = "owner_assigned"
+)
+
+// PermissionEvent is a change to a permission. Permission is the permission after
+// the change, or before it if the permission was deleted.
+type PermissionEvent struct {
+	ID         string
+	Type       EventType
+	Permission *pb.PermissionObject
+	OccurredAt time.Time
+
+	// Revoked are the permissions that were revoked together, of events of EventSharingStopped.
+	Revoked []*pb.PermissionObject
+
+	// Previous is the permission before the change, of events of EventUpdated in the activity of a file,
+	// or nil if the event of the change that preceded it is no longer recorded.
+	Previous *pb.PermissionObject
+}
+
+// PermissionChanges is a page of the changes to permissions.
+type PermissionChanges struct {
+	Events []PermissionEvent
+
+	// SyncToken is the token to list the changes that follow Events with.
+	SyncToken string
+
+	// HasMore is whether there are more changes after SyncToken.
+	HasMore bool
+}
+
+// EventPublisher is an interface for publishing permission events.
+// Events are published at least once, so consumers should deduplicate them by their ID.
+type EventPublisher interface {
+	Publish(ctx context.Context, event PermissionEvent) error
+}
+
+// Publishers is an EventPublisher that publishes each event with each of its publishers, in order.
+// It stops at the first publisher that fails, so the event is published again by all of them once
+// it's retried.
+type Publishers []EventPublisher
+
+// Publish publishes event with each of p.
+func (p Publishers) Publish(ctx context.Context, event PermissionEvent) error {
+	for _, publisher := range p {
+		if err := publisher.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// LogPublisher is an EventPublisher that publishes the events to a logger.
+type LogPublisher struct {
+	logger *logrus.Logger
+}
+
+// NewLogPublisher creates a LogPublisher that publishes to logger and returns it.
+func NewLogPublisher(logger *logrus.Logger) LogPublisher {
+	return LogPublisher{logger: logger}
+}
+
+// Publish logs event.
+func (p LogPublisher) Publish(ctx context.Context, event PermissionEvent) error {
+	p.logger.WithFields(logrus.Fields{
+		"eventID":      event.ID,
+		"eventType":    event.Type,
+		"resourceType": event.Permission.GetResourceType(),
+		"fileID":       event.Permission.GetFileID(),
+		"userID":       event.Permission.GetUserID(),
+		"role":         event.Permission.GetRole().String(),
+		"occurredAt":   event.OccurredAt,
+	}).Info("permission changed")
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+
+	pb "github.com/meateam/permission-service/proto"
+)
+
+// PermissionsExist is the request handler for checking whether a batch of permissions exist by their IDs,
+// such as the references of a trashed file that's restored, which is cheaper than fetching each of them.
+func (s Service) PermissionsExist(
+	ctx context.Context,
+	req *pb.PermissionsExistRequest,
+) (*pb.PermissionsExistResponse, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.limits.CheckList("ids", len(req.GetIds())); err != nil {
+		return nil, err
+	}
+
+	exist, err := s.controller.PermissionsExist(ctx, req.GetIds())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.PermissionsExistResponse{Exists: exist}, nil
+}
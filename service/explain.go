@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	pb "github.com/meateam/permission-service/proto"
+	pbv2 "github.com/meateam/permission-service/proto/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// TraceLockdown is the kind of the step that consults the lockdown of the resource.
+	TraceLockdown = "lockdown"
+
+	// TraceGrant is the kind of the step that consults the user's permission to the resource.
+	TraceGrant = "grant"
+
+	// TraceInheritance is the kind of the step that consults the folder the permission is inherited from.
+	TraceInheritance = "inheritance"
+
+	// TraceReshare is the kind of the step that consults the users the permission was reshared through.
+	TraceReshare = "reshare"
+
+	// TraceRole is the kind of the step that checks the permission's role against the wanted role.
+	TraceRole = "role"
+
+	// TraceCapability is the kind of the step that checks the permission's role against the wanted capability.
+	TraceCapability = "capability"
+)
+
+// accessTrace is the steps of an evaluation of access, in the order they were consulted.
+type accessTrace []*pbv2.AccessTraceStep
+
+// add adds a step of kind with effect and the detail formatted with args.
+func (t *accessTrace) add(kind string, effect pbv2.AccessTraceStep_Effect, format string, args ...interface{}) {
+	*t = append(*t, &pbv2.AccessTraceStep{Kind: kind, Effect: effect, Detail: fmt.Sprintf(format, args...)})
+}
+
+// ExplainAccess is the request handler for explaining a user's access to a resource, it evaluates
+// the access as IsPermitted does and returns every step that was consulted.
+func (s AdminService) ExplainAccess(
+	ctx context.Context,
+	req *pbv2.ExplainAccessRequest,
+) (*pbv2.AccessExplanation, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	resourceType, fileID, err := parseResourceName(req.GetResource())
+	if err != nil {
+		return nil, err
+	}
+
+	userID := req.GetUserId()
+	if userID == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	if pbv2.Role_name[int32(req.GetRole())] == "" {
+		return nil, status.Error(codes.InvalidArgument, "role does not exist")
+	}
+
+	if pbv2.Capability_name[int32(req.GetCapability())] == "" {
+		return nil, status.Error(codes.InvalidArgument, "capability does not exist")
+	}
+
+	role, capability := pb.Role(req.GetRole()), pb.Capability(req.GetCapability())
+	if role == pb.Role_NONE && capability == pb.Capability_NO_CAPABILITY {
+		role = pb.Role_READ
+	}
+
+	var trace accessTrace
+	permitted, err := s.explainAccess(ctx, &trace, resourceType, fileID, userID, role, capability)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbv2.AccessExplanation{Permitted: permitted, Steps: trace}, nil
+}
+
+// explainAccess evaluates the access of userID to fileID with role, or with capability if it's set,
+// adds the steps of the evaluation to trace, and returns whether the access is permitted.
+func (s AdminService) explainAccess(
+	ctx context.Context,
+	trace *accessTrace,
+	resourceType string,
+	fileID string,
+	userID string,
+	role pb.Role,
+	capability pb.Capability,
+) (bool, error) {
+	resource := resourceCollection(resourceType) + "/" + fileID
+	lock, err := s.controller.GetFileLock(ctx, resourceType, fileID)
+	if err != nil {
+		return false, err
+	}
+
+	switch {
+	case lock == nil:
+		trace.add(TraceLockdown, pbv2.AccessTraceStep_EFFECT_UNSPECIFIED, "%s isn't locked down", resource)
+	case lock.Permits(userID):
+		trace.add(TraceLockdown, pbv2.AccessTraceStep_EFFECT_UNSPECIFIED,
+			"%s was locked down by %s (%s), the user is its owner so their access isn't suspended",
+			resource, lock.LockedBy, lock.Reason)
+	default:
+		trace.add(TraceLockdown, pbv2.AccessTraceStep_DENY,
+			"%s was locked down by %s (%s), the access of everyone but its owner %s is suspended",
+			resource, lock.LockedBy, lock.Reason, lock.Owner)
+		return false, nil
+	}
+
+	permission, err := s.controller.GetByFileAndUser(ctx, resourceType, fileID, userID)
+	if status.Code(err) == codes.NotFound {
+		trace.add(TraceGrant, pbv2.AccessTraceStep_DENY, "the user has no permission to %s", resource)
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	granteeType, _ := granteeTypeOrDefault(permission.GetGranteeType())
+	trace.add(TraceGrant, pbv2.AccessTraceStep_EFFECT_UNSPECIFIED,
+		"the user has a %s permission to %s as a %s grantee, created by %s at %s",
+		permission.GetRole(),
+		resource,
+		granteeType,
+		permission.GetCreator(),
+		permission.GetCreatedAt().UTC().Format(time.RFC3339))
+
+	if folderID := permission.GetInheritedFrom(); folderID != "" {
+		if err := s.explainInheritance(ctx, trace, resourceType, folderID, userID); err != nil {
+			return false, err
+		}
+	}
+
+	if chain := permission.GetSharingChain(); len(chain) > 0 {
+		trace.add(TraceReshare, pbv2.AccessTraceStep_EFFECT_UNSPECIFIED,
+			"the permission was reshared through %s", strings.Join(chain, ", then "))
+	}
+
+	if capability != pb.Capability_NO_CAPABILITY {
+		resourceKind := permission.GetResourceKind()
+		if HasCapability(resourceKind, permission.GetRole(), capability) {
+			trace.add(TraceCapability, pbv2.AccessTraceStep_ALLOW,
+				"the %s role grants %s of a %s", permission.GetRole(), capability, resourceKind)
+			return true, nil
+		}
+
+		trace.add(TraceCapability, pbv2.AccessTraceStep_DENY,
+			"the %s role doesn't grant %s of a %s", permission.GetRole(), capability, resourceKind)
+		return false, nil
+	}
+
+	if IsSubRole(permission.GetRole(), role) {
+		trace.add(TraceRole, pbv2.AccessTraceStep_ALLOW, "the %s role grants %s", permission.GetRole(), role)
+		return true, nil
+	}
+
+	trace.add(TraceRole, pbv2.AccessTraceStep_DENY, "the %s role doesn't grant %s", permission.GetRole(), role)
+	return false, nil
+}
+
+// explainInheritance adds the step of the folder with folderID that a permission of userID is inherited from.
+func (s AdminService) explainInheritance(
+	ctx context.Context,
+	trace *accessTrace,
+	resourceType string,
+	folderID string,
+	userID string,
+) error {
+	folder := resourceCollection(resourceType) + "/" + folderID
+	permission, err := s.controller.GetByFileAndUser(ctx, resourceType, folderID, userID)
+	if status.Code(err) == codes.NotFound {
+		trace.add(TraceInheritance, pbv2.AccessTraceStep_EFFECT_UNSPECIFIED,
+			"the permission is inherited from %s, where the user no longer has a permission", folder)
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	trace.add(TraceInheritance, pbv2.AccessTraceStep_EFFECT_UNSPECIFIED,
+		"the permission is inherited from %s, where the user has a %s permission", folder, permission.GetRole())
+	return nil
+}
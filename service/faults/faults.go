@@ -0,0 +1,142 @@
+package faults
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AllMethods is the method of the faults that are injected into every method of a Repository.
+const AllMethods = "*"
+
+// injectedFaults counts the injected faults, keyed by "method/latency" or "method/error".
+var injectedFaults = expvar.NewMap("injected_faults")
+
+// Fault is a fault that's injected into an operation with a probability, either latency or an error.
+type Fault struct {
+	// Latency is the delay of the operation, if it's a latency fault.
+	Latency time.Duration
+
+	// Code is the code of the error that the operation fails with instead of running, if it's an error fault.
+	Code codes.Code
+
+	// Probability is the probability, between 0 and 1, that the fault is injected into an operation.
+	Probability float64
+}
+
+// Faults are the faults that are injected into the operations by their method names, such as "Get",
+// or AllMethods for every method.
+type Faults map[string][]Fault
+
+// Parse parses the comma separated faults of spec, of the form "method=fault@probability", where the fault is
+// either a latency, such as "200ms", or the name of a grpc code that the operation fails with, such as
+// "unavailable", for example "Get=200ms@0.5,Create=unavailable@0.1,*=20ms@1". A method may have several
+// faults, and a probability of 1 is the default. The methods must be ones of methods, or AllMethods.
+func Parse(spec string, methods []string) (Faults, error) {
+	known := map[string]bool{AllMethods: true}
+	for _, method := range methods {
+		known[method] = true
+	}
+
+	faults := Faults{}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.SplitN(entry, "=", 2)
+		method := strings.TrimSpace(parts[0])
+		if len(parts) != 2 || method == "" {
+			return nil, fmt.Errorf("invalid fault %q: expected method=fault@probability", entry)
+		}
+
+		if !known[method] {
+			return nil, fmt.Errorf("invalid fault %q: faults may not be injected into %s", entry, method)
+		}
+
+		fault, err := parseFault(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid fault %q: %v", entry, err)
+		}
+
+		faults[method] = append(faults[method], fault)
+	}
+
+	return faults, nil
+}
+
+// parseFault parses a fault of the form "fault@probability".
+func parseFault(spec string) (Fault, error) {
+	fault := Fault{Probability: 1}
+	if i := strings.LastIndex(spec, "@"); i >= 0 {
+		probability, err := strconv.ParseFloat(spec[i+1:], 64)
+		if err != nil || probability < 0 || probability > 1 {
+			return Fault{}, fmt.Errorf("the probability must be between 0 and 1")
+		}
+
+		fault.Probability = probability
+		spec = spec[:i]
+	}
+
+	if latency, err := time.ParseDuration(spec); err == nil {
+		if latency <= 0 {
+			return Fault{}, fmt.Errorf("the latency must be positive")
+		}
+
+		fault.Latency = latency
+		return fault, nil
+	}
+
+	// The codes are unmarshaled from their upper case names, such as "UNAVAILABLE".
+	if err := fault.Code.UnmarshalJSON([]byte(strconv.Quote(strings.ToUpper(spec)))); err != nil {
+		return Fault{}, fmt.Errorf("%q is neither a latency nor a grpc code", spec)
+	}
+
+	if fault.Code == codes.OK {
+		return Fault{}, fmt.Errorf("the code of an error must not be OK")
+	}
+
+	return fault, nil
+}
+
+// Empty returns whether there are no faults.
+func (f Faults) Empty() bool {
+	return len(f) == 0
+}
+
+// inject injects the faults of method, and of AllMethods, into an operation of ctx: it delays the operation
+// by the latency faults, and returns the error of the first error fault, or the error of ctx if it's done
+// during the delay. It returns nil if the operation should run.
+func (f Faults) inject(ctx context.Context, method string) error {
+	for _, name := range []string{method, AllMethods} {
+		for _, fault := range f[name] {
+			if rand.Float64() >= fault.Probability {
+				continue
+			}
+
+			if fault.Latency == 0 {
+				injectedFaults.Add(method+"/error", 1)
+				return status.Errorf(fault.Code, "injected fault of %s", method)
+			}
+
+			injectedFaults.Add(method+"/latency", 1)
+			timer := time.NewTimer(fault.Latency)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,230 @@
+package faults
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/meateam/permission-service/service"
+)
+
+// Methods are the methods of a Repository that faults may be injected into, the store operations of
+// the RPCs that create, read, update and delete permissions, and of the health checks.
+var Methods = []string{
+	"Create",
+	"GetByID",
+	"ExistingIDs",
+	"Get",
+	"GetByResource",
+	"GetByUser",
+	"ListByResource",
+	"ListByUser",
+	"Update",
+	"Touch",
+	"Delete",
+	"DeleteByID",
+	"HealthCheck",
+}
+
+// Repository is a service.PermissionRepository that injects faults into the operations of its underlying
+// repository, for verifying the timeouts, retries and circuit breakers of the service and its clients
+// in test environments. It must never be used in production.
+type Repository struct {
+	service.PermissionRepository
+	faults Faults
+}
+
+// NewRepository returns a Repository that injects faults into the operations of permissions.
+func NewRepository(permissions service.PermissionRepository, faults Faults) Repository {
+	return Repository{PermissionRepository: permissions, faults: faults}
+}
+
+// Create creates the permission of userID to fileID with values, unless a fault is injected.
+func (r Repository) Create(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+	creator string,
+	sharingChain []string,
+	values service.PermissionUpdate,
+	override bool,
+) (service.Permission, error) {
+	if err := r.faults.inject(ctx, "Create"); err != nil {
+		return nil, err
+	}
+
+	return r.PermissionRepository.Create(ctx, resourceType, fileID, userID, creator, sharingChain, values, override)
+}
+
+// GetByID returns the permission with id, unless a fault is injected.
+func (r Repository) GetByID(ctx context.Context, id string) (service.Permission, error) {
+	if err := r.faults.inject(ctx, "GetByID"); err != nil {
+		return nil, err
+	}
+
+	return r.PermissionRepository.GetByID(ctx, id)
+}
+
+// ExistingIDs returns the set of ids of the permissions that exist, unless a fault is injected.
+func (r Repository) ExistingIDs(ctx context.Context, ids []string) (map[string]bool, error) {
+	if err := r.faults.inject(ctx, "ExistingIDs"); err != nil {
+		return nil, err
+	}
+
+	return r.PermissionRepository.ExistingIDs(ctx, ids)
+}
+
+// Get returns the permission of userID to fileID, unless a fault is injected.
+func (r Repository) Get(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+	fields ...service.PermissionField,
+) (service.Permission, error) {
+	if err := r.faults.inject(ctx, "Get"); err != nil {
+		return nil, err
+	}
+
+	return r.PermissionRepository.Get(ctx, resourceType, fileID, userID, fields...)
+}
+
+// GetByResource returns the permissions of fileID, unless a fault is injected.
+func (r Repository) GetByResource(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	order pb.PermissionsOrder,
+	selector service.PermissionSelector,
+) ([]service.Permission, error) {
+	if err := r.faults.inject(ctx, "GetByResource"); err != nil {
+		return nil, err
+	}
+
+	return r.PermissionRepository.GetByResource(ctx, resourceType, fileID, order, selector)
+}
+
+// GetByUser returns the permissions of userID, unless a fault is injected.
+func (r Repository) GetByUser(
+	ctx context.Context,
+	resourceType string,
+	userID string,
+	order pb.PermissionsOrder,
+	selector service.PermissionSelector,
+) ([]service.Permission, error) {
+	if err := r.faults.inject(ctx, "GetByUser"); err != nil {
+		return nil, err
+	}
+
+	return r.PermissionRepository.GetByUser(ctx, resourceType, userID, order, selector)
+}
+
+// ListByResource returns a page of the permissions of fileID, unless a fault is injected.
+func (r Repository) ListByResource(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	order pb.PermissionsOrder,
+	pageSize int,
+	pageToken string,
+	fields []service.PermissionField,
+	selector service.PermissionSelector,
+) ([]service.Permission, string, error) {
+	if err := r.faults.inject(ctx, "ListByResource"); err != nil {
+		return nil, "", err
+	}
+
+	return r.PermissionRepository.ListByResource(
+		ctx,
+		resourceType,
+		fileID,
+		order,
+		pageSize,
+		pageToken,
+		fields,
+		selector,
+	)
+}
+
+// ListByUser returns a page of the permissions of userID, unless a fault is injected.
+func (r Repository) ListByUser(
+	ctx context.Context,
+	resourceType string,
+	userID string,
+	order pb.PermissionsOrder,
+	pageSize int,
+	pageToken string,
+	selector service.PermissionSelector,
+) ([]service.Permission, string, error) {
+	if err := r.faults.inject(ctx, "ListByUser"); err != nil {
+		return nil, "", err
+	}
+
+	return r.PermissionRepository.ListByUser(ctx, resourceType, userID, order, pageSize, pageToken, selector)
+}
+
+// Update updates the permission of userID to fileID, unless a fault is injected.
+func (r Repository) Update(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+	etag string,
+	update service.PermissionUpdate,
+	fields []service.PermissionField,
+) (service.Permission, error) {
+	if err := r.faults.inject(ctx, "Update"); err != nil {
+		return nil, err
+	}
+
+	return r.PermissionRepository.Update(ctx, resourceType, fileID, userID, etag, update, fields)
+}
+
+// Touch sets the last access time of the permission of userID to fileID, unless a fault is injected.
+func (r Repository) Touch(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+	accessedAt time.Time,
+) (service.Permission, error) {
+	if err := r.faults.inject(ctx, "Touch"); err != nil {
+		return nil, err
+	}
+
+	return r.PermissionRepository.Touch(ctx, resourceType, fileID, userID, accessedAt)
+}
+
+// Delete deletes the permission of userID to fileID, unless a fault is injected.
+func (r Repository) Delete(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+	etag string,
+) (service.Permission, error) {
+	if err := r.faults.inject(ctx, "Delete"); err != nil {
+		return nil, err
+	}
+
+	return r.PermissionRepository.Delete(ctx, resourceType, fileID, userID, etag)
+}
+
+// DeleteByID deletes the permission with id, unless a fault is injected.
+func (r Repository) DeleteByID(ctx context.Context, id string) (service.Permission, error) {
+	if err := r.faults.inject(ctx, "DeleteByID"); err != nil {
+		return nil, err
+	}
+
+	return r.PermissionRepository.DeleteByID(ctx, id)
+}
+
+// HealthCheck checks the health of the underlying repository, which is unhealthy if a fault is injected.
+func (r Repository) HealthCheck(ctx context.Context) (bool, error) {
+	if err := r.faults.inject(ctx, "HealthCheck"); err != nil {
+		return false, err
+	}
+
+	return r.PermissionRepository.HealthCheck(ctx)
+}
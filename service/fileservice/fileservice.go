@@ -0,0 +1,68 @@
+package fileservice
+
+import (
+	"context"
+
+	pbf "github.com/meateam/permission-service/proto/file"
+	"github.com/meateam/permission-service/service"
+	"google.golang.org/grpc"
+)
+
+// FolderType is the type of the files that are folders.
+const FolderType = "application/vnd.drive.folder"
+
+// Client is a structure used for fetching the metadata of files from the file service,
+// it implements service.FileMetadata and service.FileTree.
+type Client struct {
+	client pbf.FileServiceClient
+}
+
+// NewClient creates a Client of the file service that's served on conn and returns it.
+func NewClient(conn *grpc.ClientConn) Client {
+	return Client{client: pbf.NewFileServiceClient(conn)}
+}
+
+// GetFileOwner returns the ID of the owner of fileID, fails with codes.NotFound if the file doesn't exist.
+func (c Client) GetFileOwner(ctx context.Context, fileID string) (string, error) {
+	file, err := c.client.GetFileByID(ctx, &pbf.GetByFileByIDRequest{Id: fileID})
+	if err != nil {
+		return "", err
+	}
+
+	return file.GetOwnerID(), nil
+}
+
+// GetSubtree returns fileID followed by its descendants, if it's a folder,
+// fails with codes.NotFound if fileID doesn't exist.
+func (c Client) GetSubtree(ctx context.Context, fileID string) ([]service.FileNode, error) {
+	file, err := c.client.GetFileByID(ctx, &pbf.GetByFileByIDRequest{Id: fileID})
+	if err != nil {
+		return nil, err
+	}
+
+	subtree := []service.FileNode{fileNode(file)}
+	if file.GetType() != FolderType {
+		return subtree, nil
+	}
+
+	res, err := c.client.GetDescendantsByID(ctx, &pbf.GetDescendantsByIDRequest{Id: fileID})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, descendant := range res.GetDescendants() {
+		subtree = append(subtree, fileNode(descendant.GetFile()))
+	}
+
+	return subtree, nil
+}
+
+// fileNode returns the node of file in the hierarchy of files.
+func fileNode(file *pbf.File) service.FileNode {
+	resourceKind := service.ResourceKindFile
+	if file.GetType() == FolderType {
+		resourceKind = service.ResourceKindFolder
+	}
+
+	return service.FileNode{FileID: file.GetId(), ResourceKind: resourceKind}
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"strings"
+
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// GranteeTypeUser is the type of a permission that's given to a user, and the type of a permission
+	// if not specified.
+	GranteeTypeUser = "user"
+
+	// GranteeTypeDomain is the type of a permission that's given to everyone in an organization,
+	// whose user ID is the organization's domain.
+	GranteeTypeDomain = "domain"
+
+	// AnyDomain is the DomainGrantPolicy entry that allows permissions to be given to every domain.
+	AnyDomain = "*"
+)
+
+// DomainGrantPolicy is the set of the domains whose organizations may be given permissions.
+// Permissions may not be given to domains if the policy is empty.
+type DomainGrantPolicy map[string]bool
+
+// ParseDomainGrantPolicy parses a policy of the form "domain,domain", such as "example.org",
+// an empty policy is valid.
+func ParseDomainGrantPolicy(policy string) DomainGrantPolicy {
+	domainGrantPolicy := DomainGrantPolicy{}
+	for _, domain := range strings.Split(policy, ",") {
+		if domain = strings.TrimSpace(domain); domain != "" {
+			domainGrantPolicy[domain] = true
+		}
+	}
+
+	return domainGrantPolicy
+}
+
+// Authorize returns a PermissionDenied error if domain may not be given permissions, otherwise returns nil.
+func (p DomainGrantPolicy) Authorize(domain string) error {
+	if p[domain] || p[AnyDomain] {
+		return nil
+	}
+
+	return RejectionError(
+		codes.PermissionDenied,
+		Rejection{Kind: RejectionPolicy, Rule: "domain_grants", Subject: domain},
+		"domain %q may not be given permissions",
+		domain,
+	)
+}
+
+// IsExternalGrantee returns true if a permission of granteeType makes its file accessible outside of the tenant.
+func IsExternalGrantee(granteeType string) bool {
+	return granteeType == GranteeTypeDomain
+}
+
+// granteeTypeOrDefault returns granteeType, or GranteeTypeUser if it's empty,
+// and whether it's a type of grantee.
+func granteeTypeOrDefault(granteeType string) (string, bool) {
+	switch granteeType {
+	case "":
+		return GranteeTypeUser, true
+	case GranteeTypeUser, GranteeTypeDomain:
+		return granteeType, true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"expvar"
+	"math/rand"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// HealthCheckHealthy is the outcome of a health check that found the store healthy.
+	HealthCheckHealthy = "healthy"
+
+	// HealthCheckUnhealthy is the outcome of a health check that found the store unhealthy.
+	HealthCheckUnhealthy = "unhealthy"
+
+	// HealthCheckTimeout is the outcome of a health check that didn't return within its timeout.
+	HealthCheckTimeout = "timeout"
+
+	// maxHealthRecovery is the maximum number of consecutive healthy checks that an instance that flapped
+	// must pass before it's serving again.
+	maxHealthRecovery = 16
+)
+
+var (
+	// healthChecks counts the health checks by their outcome, and healthCheckSeconds sums their durations.
+	healthChecks       = expvar.NewMap("health_checks")
+	healthCheckSeconds = expvar.NewMap("health_check_seconds")
+
+	// healthServing is whether the instance is serving, 1 if it is and 0 if it isn't, and healthTransitions
+	// counts the changes of its serving status, keyed by the status it changed to.
+	healthServing     = expvar.NewInt("health_serving")
+	healthTransitions = expvar.NewMap("health_transitions")
+)
+
+// HealthChecker checks the health of the store once in an interval, with jitter so that the instances of
+// the service don't ping it in lockstep, and sets the serving status of the instance. The status is dampened:
+// it's only set to not serving after consecutive failed checks, so that a single slow ping doesn't flap it,
+// and an instance that flapped must pass twice as many consecutive checks as the last time before it's
+// serving again, which decays as it keeps serving.
+type HealthChecker struct {
+	controller Controller
+	logger     *logrus.Logger
+
+	// interval is the mean interval between checks, which is randomized by up to jitter of it,
+	// and timeout is the timeout of a check, which is at most interval.
+	interval time.Duration
+	jitter   float64
+	timeout  time.Duration
+
+	// failureThreshold is the number of consecutive failed checks before the instance isn't serving.
+	failureThreshold int
+}
+
+// NewHealthChecker creates a HealthChecker of the store of controller that checks it once in interval,
+// randomized by up to jitter of it, such as 0.2, with timeout, and sets the instance as not serving after
+// failureThreshold consecutive failed checks, and returns it.
+func NewHealthChecker(
+	controller Controller,
+	logger *logrus.Logger,
+	interval time.Duration,
+	jitter float64,
+	timeout time.Duration,
+	failureThreshold int,
+) HealthChecker {
+	if timeout <= 0 || timeout > interval {
+		timeout = interval
+	}
+
+	if failureThreshold < 1 {
+		failureThreshold = 1
+	}
+
+	return HealthChecker{
+		controller:       controller,
+		logger:           logger,
+		interval:         interval,
+		jitter:           jitter,
+		timeout:          timeout,
+		failureThreshold: failureThreshold,
+	}
+}
+
+// Run is running an infinite loop that checks the health of the store and calls setServing with the serving
+// status of the instance whenever it changes, until ctx is done. The instance is initially not serving.
+func (h HealthChecker) Run(ctx context.Context, setServing func(serving bool)) {
+	random := rand.New(rand.NewSource(time.Now().UnixNano()))
+	dampener := healthDampener{failureThreshold: h.failureThreshold, recovery: 1}
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		if serving, changed := dampener.observe(h.check(ctx)); changed {
+			h.setServing(serving, dampener, setServing)
+		}
+
+		timer.Reset(h.nextInterval(random))
+	}
+}
+
+// check checks the health of the store within the checker's timeout, records its outcome,
+// and returns whether it's healthy.
+func (h HealthChecker) check(ctx context.Context) bool {
+	checkCtx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	start := time.Now()
+	healthy, err := h.controller.HealthCheck(checkCtx)
+	outcome := HealthCheckHealthy
+	switch {
+	case checkCtx.Err() == context.DeadlineExceeded:
+		outcome = HealthCheckTimeout
+	case err != nil || !healthy:
+		outcome = HealthCheckUnhealthy
+	}
+
+	healthChecks.Add(outcome, 1)
+	healthCheckSeconds.AddFloat(outcome, time.Since(start).Seconds())
+	if outcome != HealthCheckHealthy {
+		h.logger.WithField("outcome", outcome).Warnf("health check failed: %v", err)
+		return false
+	}
+
+	return true
+}
+
+// setServing records the serving status of the instance, and sets it with setServing.
+func (h HealthChecker) setServing(serving bool, dampener healthDampener, setServing func(serving bool)) {
+	if serving {
+		healthServing.Set(1)
+		healthTransitions.Add("serving", 1)
+		h.logger.Info("the service is serving")
+	} else {
+		healthServing.Set(0)
+		healthTransitions.Add("not_serving", 1)
+		h.logger.WithField("recovery", dampener.recovery).Error("the service isn't serving")
+	}
+
+	setServing(serving)
+}
+
+// nextInterval returns the interval until the next check, randomized by up to the checker's jitter of it.
+func (h HealthChecker) nextInterval(random *rand.Rand) time.Duration {
+	if h.jitter <= 0 {
+		return h.interval
+	}
+
+	spread := float64(h.interval) * h.jitter
+	return h.interval + time.Duration(spread*(2*random.Float64()-1))
+}
+
+// healthDampener dampens the serving status of the outcomes of consecutive health checks.
+type healthDampener struct {
+	serving bool
+
+	// failures and successes are the numbers of the consecutive failed and healthy checks.
+	failures  int
+	successes int
+
+	// failureThreshold is the number of consecutive failed checks before the status is not serving,
+	// and recovery is the number of consecutive healthy checks before it's serving again.
+	failureThreshold int
+	recovery         int
+}
+
+// observe observes the outcome of a check, healthy or not, and returns the serving status and whether it changed.
+func (d *healthDampener) observe(healthy bool) (bool, bool) {
+	if !healthy {
+		d.failures++
+		d.successes = 0
+		if !d.serving || d.failures < d.failureThreshold {
+			return d.serving, false
+		}
+
+		// The instance flapped, so it must stay healthy for longer before it's serving again.
+		d.serving = false
+		if d.recovery *= 2; d.recovery > maxHealthRecovery {
+			d.recovery = maxHealthRecovery
+		}
+
+		return false, true
+	}
+
+	d.failures = 0
+	d.successes++
+	if d.serving {
+		// The penalty of past flaps decays as the instance keeps serving.
+		if d.successes%maxHealthRecovery == 0 && d.recovery > 1 {
+			d.recovery /= 2
+		}
+
+		return true, false
+	}
+
+	if d.successes < d.recovery {
+		return false, false
+	}
+
+	d.serving = true
+	return true, true
+}
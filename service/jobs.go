@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// JobDeletePermissions is the kind of the jobs that delete the permissions of resources.
+	JobDeletePermissions = "delete_permissions"
+
+	// JobImportPermissions is the kind of the jobs that import permissions.
+	JobImportPermissions = "import_permissions"
+
+	// JobMigrateRole is the kind of the jobs that migrate the role of permissions.
+	JobMigrateRole = "migrate_role"
+
+	// JobCollectGarbage is the kind of the jobs that delete the permissions of files that no longer exist.
+	JobCollectGarbage = "collect_garbage"
+
+	// jobStaleHeartbeats is the number of heartbeats that a running job may miss before it's considered
+	// interrupted, such as by a restart of the server that ran it.
+	jobStaleHeartbeats = 3
+
+	// jobFinishTimeout is the timeout of recording the end of a job.
+	jobFinishTimeout = 10 * time.Second
+)
+
+// JobState is the state of a job.
+type JobState string
+
+const (
+	// JobRunning is the state of a job that's running.
+	JobRunning JobState = "running"
+
+	// JobSucceeded is the state of a job that finished processing its items.
+	JobSucceeded JobState = "succeeded"
+
+	// JobFailed is the state of a job that stopped because of an error.
+	JobFailed JobState = "failed"
+
+	// JobCancelled is the state of a job that stopped because its cancellation was requested.
+	JobCancelled JobState = "cancelled"
+)
+
+// JobProgress is the progress of a job: Done of its Total items were processed, and Failed of them failed.
+type JobProgress struct {
+	Done   int64
+	Failed int64
+	Total  int64
+}
+
+// Job is a long-running bulk operation of Kind that was created by Creator at CreatedAt.
+// UpdatedAt is the time of its last progress update, which running jobs make at least once a heartbeat.
+type Job struct {
+	ID              string
+	Kind            string
+	State           JobState
+	Progress        JobProgress
+	Error           string
+	Creator         string
+	CancelRequested bool
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	FinishedAt      *time.Time
+}
+
+// JobFunc is the operation of a job, it reports its progress with progress and stops when ctx is done.
+// progress returns an error if the job was cancelled, which the operation should return.
+type JobFunc func(ctx context.Context, progress func(JobProgress) error) error
+
+// JobRunner runs the jobs of the server, and records their progress once a heartbeat.
+// The cancellation of a job is requested in its record, so it's cancelled by the server that runs it
+// at its next heartbeat.
+type JobRunner struct {
+	controller Controller
+	logger     *logrus.Logger
+
+	// heartbeat is the interval of the progress updates of the running jobs.
+	heartbeat time.Duration
+
+	mu      sync.Mutex
+	cancels map[string]context.CancelFunc
+}
+
+// NewJobRunner creates a JobRunner that stores its jobs with controller and records their progress
+// once in heartbeat, and returns it.
+func NewJobRunner(controller Controller, logger *logrus.Logger, heartbeat time.Duration) *JobRunner {
+	return &JobRunner{
+		controller: controller,
+		logger:     logger,
+		heartbeat:  heartbeat,
+		cancels:    map[string]context.CancelFunc{},
+	}
+}
+
+// Start creates a job of kind that runs fn, and returns it without waiting for it to finish.
+// The job outlives ctx, and is run with its actor and caller.
+func (r *JobRunner) Start(ctx context.Context, kind string, fn JobFunc) (Job, error) {
+	now := time.Now()
+	job, err := r.controller.CreateJob(ctx, Job{
+		Kind:      kind,
+		State:     JobRunning,
+		Creator:   actorOrCaller(ctx),
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		return Job{}, err
+	}
+
+	jobCtx, cancel := context.WithCancel(detachedContext(ctx))
+	r.mu.Lock()
+	r.cancels[job.ID] = cancel
+	r.mu.Unlock()
+
+	r.logger.WithFields(logrus.Fields{"job": job.ID, "kind": kind, "creator": job.Creator}).Info("job started")
+	go r.run(jobCtx, job, fn)
+
+	return job, nil
+}
+
+// Cancel requests the cancellation of the job with id and returns it, the job is cancelled immediately
+// if it's run by r, otherwise at the next heartbeat of the server that runs it.
+func (r *JobRunner) Cancel(ctx context.Context, id string) (Job, error) {
+	job, err := r.controller.CancelJob(ctx, id)
+	if err != nil {
+		return Job{}, err
+	}
+
+	r.mu.Lock()
+	if cancel, ok := r.cancels[id]; ok {
+		cancel()
+	}
+	r.mu.Unlock()
+
+	return job, nil
+}
+
+// run runs fn as job, records its progress once a heartbeat, and records its end.
+func (r *JobRunner) run(ctx context.Context, job Job, fn JobFunc) {
+	defer func() {
+		r.mu.Lock()
+		r.cancels[job.ID]()
+		delete(r.cancels, job.ID)
+		r.mu.Unlock()
+	}()
+
+	var mu sync.Mutex
+	var progress JobProgress
+	errs := make(chan error, 1)
+	go func() {
+		errs <- fn(ctx, func(p JobProgress) error {
+			mu.Lock()
+			progress = p
+			mu.Unlock()
+
+			if ctx.Err() != nil {
+				return status.Errorf(codes.Canceled, "job %s was cancelled", job.ID)
+			}
+
+			return nil
+		})
+	}()
+
+	ticker := time.NewTicker(r.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case err := <-errs:
+			mu.Lock()
+			final := progress
+			mu.Unlock()
+			r.finish(ctx, job, final, err)
+			return
+		case <-ticker.C:
+		}
+
+		mu.Lock()
+		current := progress
+		mu.Unlock()
+
+		updated, err := r.controller.UpdateJobProgress(ctx, job.ID, current, time.Now())
+		if err != nil {
+			r.logger.Errorf("failed updating the progress of job %s: %v", job.ID, err)
+			continue
+		}
+
+		if updated.CancelRequested {
+			r.mu.Lock()
+			r.cancels[job.ID]()
+			r.mu.Unlock()
+		}
+	}
+}
+
+// finish records the end of job with progress, by err, which is nil if the job succeeded.
+func (r *JobRunner) finish(ctx context.Context, job Job, progress JobProgress, err error) {
+	state, message := JobSucceeded, ""
+	if err != nil {
+		state, message = JobFailed, status.Convert(err).Message()
+		if ctx.Err() != nil {
+			state = JobCancelled
+		}
+	}
+
+	finishCtx, cancel := context.WithTimeout(context.Background(), jobFinishTimeout)
+	defer cancel()
+
+	if _, err := r.controller.FinishJob(finishCtx, job.ID, state, progress, message, time.Now()); err != nil {
+		r.logger.Errorf("failed recording the end of job %s: %v", job.ID, err)
+		return
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"job":    job.ID,
+		"kind":   job.Kind,
+		"state":  state,
+		"done":   progress.Done,
+		"failed": progress.Failed,
+		"error":  message,
+	}).Info("job finished")
+}
+
+// Run is running an infinite loop that fails the running jobs that missed their heartbeats, such as
+// the jobs of a server that was restarted, once a heartbeat until ctx is done.
+func (r *JobRunner) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		staleBefore := time.Now().Add(-jobStaleHeartbeats * r.heartbeat)
+		failed, err := r.controller.FailStaleJobs(ctx, staleBefore, "the job was interrupted")
+		if err != nil {
+			r.logger.Errorf("failed failing interrupted jobs: %v", err)
+		}
+
+		if failed > 0 {
+			r.logger.Warnf("failed %d interrupted jobs", failed)
+		}
+	}
+}
+
+// detachedContext returns a context that isn't cancelled with ctx, with the actor and the caller of ctx.
+func detachedContext(ctx context.Context) context.Context {
+	detached := context.Background()
+	if actor := ActorFromContext(ctx); actor != "" {
+		detached = context.WithValue(detached, actorKey{}, actor)
+	}
+
+	if p, ok := peer.FromContext(ctx); ok {
+		detached = peer.NewContext(detached, p)
+	}
+
+	return detached
+}
@@ -0,0 +1,251 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// MinRefreshInterval is the minimum interval between fetches of a key set for keys it doesn't have,
+// so that tokens with unknown key IDs can't make the verifier fetch the key set on every request.
+const MinRefreshInterval = time.Minute
+
+// jwk is a JSON web key.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// jwks is a JSON web key set.
+type jwks struct {
+	Keys []jwk `json:"keys"`
+}
+
+// publicKey returns the RSA or EC public key of k.
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus: %v", err)
+		}
+
+		e, err := decodeBigInt(k.E)
+		if err != nil || !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("invalid exponent")
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("curve %q is not supported", k.Crv)
+		}
+
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid x coordinate: %v", err)
+		}
+
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid y coordinate: %v", err)
+		}
+
+		if !curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf("point is not on curve %s", k.Crv)
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("key type %q is not supported", k.Kty)
+	}
+}
+
+// decodeBigInt decodes a base64url encoded big-endian integer.
+func decodeBigInt(encoded string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b) == 0 {
+		return nil, fmt.Errorf("empty integer")
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
+
+// KeySet is a JSON web key set that's fetched from a URL, such as of the identity provider,
+// cached, and refetched periodically and when a token is signed by a key it doesn't have,
+// so that rotated keys are picked up. The key set is fetched in the background, once at a time,
+// and the cached keys are served while it's fetched. It's safe for concurrent use.
+type KeySet struct {
+	url                string
+	client             *http.Client
+	refreshInterval    time.Duration
+	minRefreshInterval time.Duration
+
+	mu   sync.Mutex
+	keys map[string]crypto.PublicKey
+
+	// fetchedAt is the time of the last successful fetch, and attemptedAt of the last fetch.
+	fetchedAt   time.Time
+	attemptedAt time.Time
+
+	// fetching is closed once the fetch in progress completes, and is nil if none is.
+	// fetchErr is the error of the last fetch.
+	fetching chan struct{}
+	fetchErr error
+}
+
+// NewKeySet returns a KeySet of the key set of url, that's refetched every refreshInterval.
+func NewKeySet(url string, refreshInterval time.Duration) *KeySet {
+	return &KeySet{
+		url:                url,
+		client:             &http.Client{Timeout: 10 * time.Second},
+		refreshInterval:    refreshInterval,
+		minRefreshInterval: MinRefreshInterval,
+	}
+}
+
+// Key returns the key with kid. A cached key is returned at once, and the key set is refetched
+// in the background if it's older than the refresh interval. Otherwise the key set is refetched
+// unless it was fetched in the last MinRefreshInterval, and Key waits for it until ctx is done.
+// Failed fetches keep the cached keys.
+func (s *KeySet) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	s.mu.Lock()
+	now := time.Now()
+	key, ok := s.keys[kid]
+	if ok {
+		if now.Sub(s.fetchedAt) >= s.refreshInterval && now.Sub(s.attemptedAt) >= s.refreshInterval {
+			s.startFetch()
+		}
+
+		s.mu.Unlock()
+		return key, nil
+	}
+
+	if s.fetching == nil && now.Sub(s.attemptedAt) < s.minRefreshInterval {
+		err := s.missingKeyError(kid)
+		s.mu.Unlock()
+		return nil, err
+	}
+
+	fetching := s.startFetch()
+	s.mu.Unlock()
+
+	select {
+	case <-fetching:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if key, ok = s.keys[kid]; !ok {
+		return nil, s.missingKeyError(kid)
+	}
+
+	return key, nil
+}
+
+// missingKeyError returns the error of a kid that isn't in the cached keys, which is the error
+// of the last fetch if it failed. s.mu must be held.
+func (s *KeySet) missingKeyError(kid string) error {
+	if s.fetchErr != nil {
+		return fmt.Errorf("failed fetching key set: %v", s.fetchErr)
+	}
+
+	return fmt.Errorf("key %q is not in the key set", kid)
+}
+
+// startFetch starts fetching the key set in the background, unless it's already fetched,
+// and returns a channel that's closed once the fetch completes. s.mu must be held.
+func (s *KeySet) startFetch() <-chan struct{} {
+	if s.fetching != nil {
+		return s.fetching
+	}
+
+	fetching := make(chan struct{})
+	s.fetching, s.attemptedAt = fetching, time.Now()
+	go func() {
+		// The fetch isn't of the context of the request that started it, which other requests wait for.
+		keys, err := s.fetch(context.Background())
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if err == nil {
+			s.keys, s.fetchedAt = keys, time.Now()
+		}
+
+		s.fetchErr, s.fetching = err, nil
+		close(fetching)
+	}()
+
+	return fetching
+}
+
+// fetch fetches the key set and returns its signing keys by their IDs.
+// Keys that aren't supported are skipped.
+func (s *KeySet) fetch(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	req, err := http.NewRequest(http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", res.Status)
+	}
+
+	var set jwks
+	if err := json.NewDecoder(res.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("invalid key set: %v", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		key, err := k.publicKey()
+		if err != nil {
+			continue
+		}
+
+		keys[k.Kid] = key
+	}
+
+	return keys, nil
+}
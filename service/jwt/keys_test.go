@@ -0,0 +1,214 @@
+package jwt
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// keyServer serves a key set that may be replaced, failed or blocked, and counts its fetches.
+type keyServer struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	set     jwks
+	status  int
+	block   chan struct{}
+	fetches int
+}
+
+// newKeyServer serves keys, it must be closed once it's no longer used.
+func newKeyServer(keys ...jwk) *keyServer {
+	s := &keyServer{set: jwks{Keys: keys}, status: http.StatusOK}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.fetches++
+		set, status, block := s.set, s.status, s.block
+		s.mu.Unlock()
+
+		if block != nil {
+			<-block
+		}
+
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(set)
+	}))
+
+	return s
+}
+
+// serve replaces the served keys, and the status they're served with.
+func (s *keyServer) serve(status int, keys ...jwk) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.set, s.status = jwks{Keys: keys}, status
+}
+
+// blockFetches blocks the fetches until the returned channel is closed.
+func (s *keyServer) blockFetches() chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.block = make(chan struct{})
+	return s.block
+}
+
+// fetchCount returns the number of the fetches of the key set.
+func (s *keyServer) fetchCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.fetches
+}
+
+// encodeBigInt encodes n as a base64url encoded big-endian integer.
+func encodeBigInt(n *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(n.Bytes())
+}
+
+// rsaJWK returns the JSON web key of key with kid.
+func rsaJWK(kid string, key *rsa.PublicKey) jwk {
+	return jwk{Kty: "RSA", Kid: kid, Use: "sig", N: encodeBigInt(key.N), E: encodeBigInt(big.NewInt(int64(key.E)))}
+}
+
+// ecJWK returns the JSON web key of key with kid.
+func ecJWK(kid string, key *ecdsa.PublicKey) jwk {
+	return jwk{
+		Kty: "EC",
+		Kid: kid,
+		Crv: key.Curve.Params().Name,
+		X:   encodeBigInt(key.X),
+		Y:   encodeBigInt(key.Y),
+	}
+}
+
+func TestKeySetRotation(t *testing.T) {
+	first, second := testRSAKey(t, 0), testRSAKey(t, 1)
+	server := newKeyServer(rsaJWK("first", &first.PublicKey))
+	defer server.Close()
+
+	keys := NewKeySet(server.URL, time.Hour)
+	keys.minRefreshInterval = 0
+
+	if _, err := keys.Key(context.Background(), "first"); err != nil {
+		t.Fatalf("Key(first) failed: %v", err)
+	}
+
+	// A key that isn't cached is fetched, and the keys that were rotated out are dropped.
+	server.serve(http.StatusOK, rsaJWK("second", &second.PublicKey))
+	key, err := keys.Key(context.Background(), "second")
+	if err != nil {
+		t.Fatalf("Key(second) failed after the rotation: %v", err)
+	}
+
+	if key.(*rsa.PublicKey).N.Cmp(second.N) != 0 {
+		t.Errorf("Key(second) returned another key")
+	}
+
+	if _, err := keys.Key(context.Background(), "first"); err == nil || !strings.Contains(err.Error(), "not in") {
+		t.Errorf("expected the rotated out key to be missing, got %v", err)
+	}
+}
+
+func TestKeySetLimitsFetchesOfUnknownKeys(t *testing.T) {
+	key := testRSAKey(t, 0)
+	server := newKeyServer(rsaJWK("known", &key.PublicKey))
+	defer server.Close()
+
+	keys := NewKeySet(server.URL, time.Hour)
+
+	for i := 0; i < 3; i++ {
+		if _, err := keys.Key(context.Background(), "unknown"); err == nil {
+			t.Fatalf("expected Key(unknown) to fail")
+		}
+	}
+
+	if _, err := keys.Key(context.Background(), "known"); err != nil {
+		t.Fatalf("Key(known) failed: %v", err)
+	}
+
+	if count := server.fetchCount(); count != 1 {
+		t.Errorf("expected the key set to be fetched once, got %d fetches", count)
+	}
+}
+
+func TestKeySetServesCachedKeysWhileFetching(t *testing.T) {
+	key := testRSAKey(t, 0)
+	server := newKeyServer(rsaJWK("cached", &key.PublicKey))
+	defer server.Close()
+
+	keys := NewKeySet(server.URL, 0)
+
+	if _, err := keys.Key(context.Background(), "cached"); err != nil {
+		t.Fatalf("Key(cached) failed: %v", err)
+	}
+
+	// The cached keys are stale, so they're refetched in the background, and served meanwhile.
+	unblock := server.blockFetches()
+	defer close(unblock)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := keys.Key(context.Background(), "cached")
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Key(cached) failed while the key set is fetched: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Key(cached) waited for the fetch of the key set")
+	}
+
+	// The requests that wait for the fetch stop waiting once their contexts are done.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := keys.Key(ctx, "uncached"); err != context.DeadlineExceeded {
+		t.Errorf("expected Key(uncached) to fail with the deadline of its context, got %v", err)
+	}
+}
+
+func TestKeySetKeepsKeysOnFailedFetch(t *testing.T) {
+	key := testRSAKey(t, 0)
+	server := newKeyServer(rsaJWK("cached", &key.PublicKey))
+	defer server.Close()
+
+	keys := NewKeySet(server.URL, time.Hour)
+	keys.minRefreshInterval = 0
+
+	if _, err := keys.Key(context.Background(), "cached"); err != nil {
+		t.Fatalf("Key(cached) failed: %v", err)
+	}
+
+	fetchedAt := keys.fetchedAt
+	server.serve(http.StatusInternalServerError)
+	_, err := keys.Key(context.Background(), "uncached")
+	if err == nil || !strings.Contains(err.Error(), "failed fetching") {
+		t.Errorf("expected Key(uncached) to fail with the error of the fetch, got %v", err)
+	}
+
+	if _, err := keys.Key(context.Background(), "cached"); err != nil {
+		t.Errorf("expected the cached key to be kept after the failed fetch, got %v", err)
+	}
+
+	// The keys are as old as the last successful fetch, so they're refreshed after a failed one.
+	keys.mu.Lock()
+	defer keys.mu.Unlock()
+
+	if !keys.fetchedAt.Equal(fetchedAt) {
+		t.Errorf("expected the failed fetch to keep the time of the last successful fetch")
+	}
+}
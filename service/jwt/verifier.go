@@ -0,0 +1,216 @@
+// Package jwt verifies the JSON web tokens of end-users, signed by the keys of the identity provider's
+// key set, so that the service authenticates them independently of the API gateway.
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	_ "crypto/sha256" // Registers the SHA-256 hash of the algorithms.
+	_ "crypto/sha512" // Registers the SHA-384 and SHA-512 hashes of the algorithms.
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// algorithm is a signature algorithm of tokens.
+type algorithm struct {
+	hash crypto.Hash
+	ec   bool
+}
+
+// algorithms are the supported signature algorithms, symmetric algorithms and "none" are rejected.
+var algorithms = map[string]algorithm{
+	"RS256": {hash: crypto.SHA256},
+	"RS384": {hash: crypto.SHA384},
+	"RS512": {hash: crypto.SHA512},
+	"ES256": {hash: crypto.SHA256, ec: true},
+	"ES384": {hash: crypto.SHA384, ec: true},
+	"ES512": {hash: crypto.SHA512, ec: true},
+}
+
+// header is the header of a token.
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// audience is the audience claim, which is either a string or an array of strings.
+type audience []string
+
+// UnmarshalJSON unmarshals a string or an array of strings into a.
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return fmt.Errorf("invalid audience: %v", err)
+	}
+
+	*a = multiple
+	return nil
+}
+
+// claims are the registered claims of a token that are verified.
+type claims struct {
+	Issuer    string   `json:"iss"`
+	Subject   string   `json:"sub"`
+	Audience  audience `json:"aud"`
+	ExpiresAt *float64 `json:"exp"`
+	NotBefore *float64 `json:"nbf"`
+}
+
+// Verifier verifies tokens signed by the keys of a KeySet, it implements service.TokenVerifier.
+type Verifier struct {
+	keys      *KeySet
+	issuer    string
+	audience  string
+	clockSkew time.Duration
+}
+
+// NewVerifier returns a Verifier of the tokens signed by keys, issued by issuer to audience.
+// The issuer and audience aren't verified if empty. clockSkew is the tolerance of the
+// expiration and not before times to the difference between the clocks of the issuer and the service.
+func NewVerifier(keys *KeySet, issuer string, audience string, clockSkew time.Duration) Verifier {
+	return Verifier{keys: keys, issuer: issuer, audience: audience, clockSkew: clockSkew}
+}
+
+// Verify verifies the signature and claims of token, and returns the subject it was issued to,
+// or an error if the token is invalid.
+func (v Verifier) Verify(ctx context.Context, token string) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("malformed token")
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return "", fmt.Errorf("invalid header: %v", err)
+	}
+
+	alg, ok := algorithms[h.Alg]
+	if !ok {
+		return "", fmt.Errorf("algorithm %q is not supported", h.Alg)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", fmt.Errorf("invalid signature: %v", err)
+	}
+
+	key, err := v.keys.Key(ctx, h.Kid)
+	if err != nil {
+		return "", err
+	}
+
+	if err := verifySignature(alg, key, parts[0]+"."+parts[1], signature); err != nil {
+		return "", err
+	}
+
+	var c claims
+	if err := decodeSegment(parts[1], &c); err != nil {
+		return "", fmt.Errorf("invalid claims: %v", err)
+	}
+
+	if err := v.verifyClaims(c, time.Now()); err != nil {
+		return "", err
+	}
+
+	return c.Subject, nil
+}
+
+// verifyClaims verifies the issuer, audience, subject and validity period of c at now.
+func (v Verifier) verifyClaims(c claims, now time.Time) error {
+	if v.issuer != "" && c.Issuer != v.issuer {
+		return fmt.Errorf("unexpected issuer %q", c.Issuer)
+	}
+
+	if v.audience != "" && !c.Audience.contains(v.audience) {
+		return fmt.Errorf("token isn't issued to %q", v.audience)
+	}
+
+	if c.Subject == "" {
+		return fmt.Errorf("token has no subject")
+	}
+
+	if c.ExpiresAt == nil {
+		return fmt.Errorf("token has no expiration time")
+	}
+
+	if now.Add(-v.clockSkew).After(numericDate(*c.ExpiresAt)) {
+		return fmt.Errorf("token is expired")
+	}
+
+	if c.NotBefore != nil && now.Add(v.clockSkew).Before(numericDate(*c.NotBefore)) {
+		return fmt.Errorf("token isn't valid yet")
+	}
+
+	return nil
+}
+
+// contains returns true if aud is one of a.
+func (a audience) contains(aud string) bool {
+	for _, value := range a {
+		if value == aud {
+			return true
+		}
+	}
+
+	return false
+}
+
+// numericDate returns the time of a numeric date, which is seconds since the epoch.
+func numericDate(seconds float64) time.Time {
+	return time.Unix(0, int64(seconds*float64(time.Second)))
+}
+
+// decodeSegment decodes the base64url encoded JSON segment of a token into v.
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// verifySignature verifies that signature is the signature of signed by key with alg.
+func verifySignature(alg algorithm, key crypto.PublicKey, signed string, signature []byte) error {
+	hasher := alg.hash.New()
+	hasher.Write([]byte(signed))
+	digest := hasher.Sum(nil)
+
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		if alg.ec {
+			return fmt.Errorf("key type doesn't match the algorithm")
+		}
+
+		if err := rsa.VerifyPKCS1v15(key, alg.hash, digest, signature); err != nil {
+			return fmt.Errorf("invalid signature")
+		}
+	case *ecdsa.PublicKey:
+		size := (key.Curve.Params().BitSize + 7) / 8
+		if !alg.ec || len(signature) != 2*size {
+			return fmt.Errorf("invalid signature")
+		}
+
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(key, digest, r, s) {
+			return fmt.Errorf("invalid signature")
+		}
+	default:
+		return fmt.Errorf("key type is not supported")
+	}
+
+	return nil
+}
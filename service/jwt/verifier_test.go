@@ -0,0 +1,315 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testIssuer   = "https://idp.example.org"
+	testAudience = "permission-service"
+)
+
+var (
+	testRSAKeysOnce sync.Once
+	testRSAKeys     [2]*rsa.PrivateKey
+)
+
+// testRSAKey returns the i-th of the RSA keys of the tests, which are generated once.
+func testRSAKey(t *testing.T, i int) *rsa.PrivateKey {
+	t.Helper()
+
+	testRSAKeysOnce.Do(func() {
+		for j := range testRSAKeys {
+			key, err := rsa.GenerateKey(rand.Reader, 2048)
+			if err != nil {
+				t.Fatalf("failed generating an RSA key: %v", err)
+			}
+
+			testRSAKeys[j] = key
+		}
+	})
+
+	return testRSAKeys[i]
+}
+
+// encodeSegment encodes v as a base64url encoded JSON segment of a token.
+func encodeSegment(t *testing.T, v interface{}) string {
+	t.Helper()
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("failed encoding segment: %v", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// signToken returns a token of claims with a header of alg and kid, signed by sign.
+func signToken(
+	t *testing.T,
+	alg string,
+	kid string,
+	claims map[string]interface{},
+	sign func(signed []byte) []byte,
+) string {
+	t.Helper()
+
+	signed := encodeSegment(t, header{Alg: alg, Kid: kid}) + "." + encodeSegment(t, claims)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sign([]byte(signed)))
+}
+
+// signRS256 returns a function that signs with key by RS256.
+func signRS256(t *testing.T, key *rsa.PrivateKey) func(signed []byte) []byte {
+	return func(signed []byte) []byte {
+		digest := sha256.Sum256(signed)
+		signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+		if err != nil {
+			t.Fatalf("failed signing: %v", err)
+		}
+
+		return signature
+	}
+}
+
+// signES256 returns a function that signs with key by ES256.
+func signES256(t *testing.T, key *ecdsa.PrivateKey) func(signed []byte) []byte {
+	return func(signed []byte) []byte {
+		digest := sha256.Sum256(signed)
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+		if err != nil {
+			t.Fatalf("failed signing: %v", err)
+		}
+
+		// The signature is r and s, each padded to the size of the curve.
+		signature := make([]byte, 64)
+		rBytes, sBytes := r.Bytes(), s.Bytes()
+		copy(signature[32-len(rBytes):32], rBytes)
+		copy(signature[64-len(sBytes):], sBytes)
+		return signature
+	}
+}
+
+// validClaims returns the claims of a valid token of subject.
+func validClaims(subject string) map[string]interface{} {
+	return map[string]interface{}{
+		"iss": testIssuer,
+		"sub": subject,
+		"aud": []string{"other-service", testAudience},
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func TestVerify(t *testing.T) {
+	rsaKey := testRSAKey(t, 0)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed generating an EC key: %v", err)
+	}
+
+	server := newKeyServer(rsaJWK("rsa", &rsaKey.PublicKey), ecJWK("ec", &ecKey.PublicKey))
+	defer server.Close()
+
+	verifier := NewVerifier(NewKeySet(server.URL, time.Hour), testIssuer, testAudience, time.Minute)
+	tampered := signToken(t, "RS256", "rsa", validClaims("user"), signRS256(t, rsaKey))
+	tampered = tampered[:len(tampered)-4] + "AAAA"
+
+	tests := []struct {
+		name    string
+		token   string
+		subject string
+		err     string
+	}{
+		{
+			name:    "RS256",
+			token:   signToken(t, "RS256", "rsa", validClaims("rsa-user"), signRS256(t, rsaKey)),
+			subject: "rsa-user",
+		},
+		{
+			name:    "ES256",
+			token:   signToken(t, "ES256", "ec", validClaims("ec-user"), signES256(t, ecKey)),
+			subject: "ec-user",
+		},
+		{
+			name:  "tampered signature",
+			token: tampered,
+			err:   "invalid signature",
+		},
+		{
+			name:  "algorithm of another key type",
+			token: signToken(t, "ES256", "rsa", validClaims("user"), signES256(t, ecKey)),
+			err:   "doesn't match",
+		},
+		{
+			name: "alg none",
+			token: signToken(t, "none", "rsa", validClaims("user"), func([]byte) []byte {
+				return nil
+			}),
+			err: "not supported",
+		},
+		{
+			// A token that's signed with the public key as an HMAC secret mustn't be verified by it.
+			name: "HS256 with the public key",
+			token: signToken(t, "HS256", "rsa", validClaims("user"), func(signed []byte) []byte {
+				mac := hmac.New(sha256.New, []byte(rsaJWK("rsa", &rsaKey.PublicKey).N))
+				mac.Write(signed)
+				return mac.Sum(nil)
+			}),
+			err: "not supported",
+		},
+		{
+			name:  "malformed",
+			token: "header.claims",
+			err:   "malformed",
+		},
+	}
+
+	for _, test := range tests {
+		subject, err := verifier.Verify(context.Background(), test.token)
+		switch {
+		case test.err == "" && err != nil:
+			t.Errorf("%s: Verify failed: %v", test.name, err)
+		case test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)):
+			t.Errorf("%s: expected Verify to fail with %q, got %v", test.name, test.err, err)
+		case subject != test.subject:
+			t.Errorf("%s: Verify returned the subject %q, expected %q", test.name, subject, test.subject)
+		}
+	}
+}
+
+func TestVerifyClaims(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	at := func(offset time.Duration) *float64 {
+		seconds := float64(now.Add(offset).Unix())
+		return &seconds
+	}
+
+	verifier := NewVerifier(nil, testIssuer, testAudience, time.Minute)
+	tests := []struct {
+		name   string
+		claims claims
+		err    string
+	}{
+		{
+			name: "valid",
+			claims: claims{
+				Issuer:    testIssuer,
+				Subject:   "user",
+				Audience:  audience{testAudience},
+				ExpiresAt: at(time.Hour),
+			},
+		},
+		{
+			name: "audience array",
+			claims: claims{
+				Issuer:    testIssuer,
+				Subject:   "user",
+				Audience:  audience{"other-service", testAudience},
+				ExpiresAt: at(time.Hour),
+			},
+		},
+		{
+			name: "other audiences",
+			claims: claims{
+				Issuer:    testIssuer,
+				Subject:   "user",
+				Audience:  audience{"other-service"},
+				ExpiresAt: at(time.Hour),
+			},
+			err: "isn't issued to",
+		},
+		{
+			name:   "other issuer",
+			claims: claims{Issuer: "other", Subject: "user", Audience: audience{testAudience}, ExpiresAt: at(time.Hour)},
+			err:    "unexpected issuer",
+		},
+		{
+			name:   "no subject",
+			claims: claims{Issuer: testIssuer, Audience: audience{testAudience}, ExpiresAt: at(time.Hour)},
+			err:    "no subject",
+		},
+		{
+			name:   "no expiration",
+			claims: claims{Issuer: testIssuer, Subject: "user", Audience: audience{testAudience}},
+			err:    "no expiration",
+		},
+		{
+			name: "expired within the clock skew",
+			claims: claims{
+				Issuer:    testIssuer,
+				Subject:   "user",
+				Audience:  audience{testAudience},
+				ExpiresAt: at(-30 * time.Second),
+			},
+		},
+		{
+			name: "expired beyond the clock skew",
+			claims: claims{
+				Issuer:    testIssuer,
+				Subject:   "user",
+				Audience:  audience{testAudience},
+				ExpiresAt: at(-2 * time.Minute),
+			},
+			err: "expired",
+		},
+		{
+			name: "not before within the clock skew",
+			claims: claims{
+				Issuer:    testIssuer,
+				Subject:   "user",
+				Audience:  audience{testAudience},
+				ExpiresAt: at(time.Hour),
+				NotBefore: at(30 * time.Second),
+			},
+		},
+		{
+			name: "not before beyond the clock skew",
+			claims: claims{
+				Issuer:    testIssuer,
+				Subject:   "user",
+				Audience:  audience{testAudience},
+				ExpiresAt: at(time.Hour),
+				NotBefore: at(2 * time.Minute),
+			},
+			err: "isn't valid yet",
+		},
+	}
+
+	for _, test := range tests {
+		err := verifier.verifyClaims(test.claims, now)
+		switch {
+		case test.err == "" && err != nil:
+			t.Errorf("%s: verifyClaims failed: %v", test.name, err)
+		case test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)):
+			t.Errorf("%s: expected verifyClaims to fail with %q, got %v", test.name, test.err, err)
+		}
+	}
+}
+
+func TestAudienceUnmarshalsStringsAndArrays(t *testing.T) {
+	var c claims
+	if err := json.Unmarshal([]byte(`{"aud": "single"}`), &c); err != nil || !c.Audience.contains("single") {
+		t.Errorf("expected a single audience, got %v, %v", c.Audience, err)
+	}
+
+	err := json.Unmarshal([]byte(`{"aud": ["first", "second"]}`), &c)
+	if err != nil || !c.Audience.contains("second") {
+		t.Errorf("expected an array of audiences, got %v, %v", c.Audience, err)
+	}
+
+	if err := json.Unmarshal([]byte(`{"aud": 1}`), &c); err == nil {
+		t.Errorf("expected an audience that isn't a string or an array of strings to be invalid")
+	}
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+)
+
+const (
+	// MaxLabels is the maximum number of labels of a permission.
+	MaxLabels = 16
+
+	// MaxLabelValueLength is the maximum length in characters of the value of a permission's label.
+	MaxLabelValueLength = 63
+)
+
+// labelKeyPattern is the pattern of the keys of labels, which are also the keys of the stored
+// labels, so they may not contain characters that have a meaning in queries, such as '.' and '$'.
+var labelKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,62}$`)
+
+// ValidateLabels returns an error if labels, of a permission or a selector of permissions,
+// has too many labels or a label whose key or value is invalid, otherwise returns nil.
+func ValidateLabels(labels map[string]string) error {
+	if len(labels) > MaxLabels {
+		return fmt.Errorf("labels exceed %d labels", MaxLabels)
+	}
+
+	for key, value := range labels {
+		if !labelKeyPattern.MatchString(key) {
+			return fmt.Errorf(
+				"label key %q must be lowercase letters, digits, '-' and '_', starting with a letter, "+
+					"of up to 63 characters",
+				key,
+			)
+		}
+
+		if utf8.RuneCountInString(value) > MaxLabelValueLength {
+			return fmt.Errorf("value of label %s exceeds %d characters", key, MaxLabelValueLength)
+		}
+	}
+
+	return nil
+}
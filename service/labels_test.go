@@ -0,0 +1,52 @@
+package service
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestValidateLabels(t *testing.T) {
+	tooMany := map[string]string{}
+	for i := 0; i <= MaxLabels; i++ {
+		tooMany["label"+strconv.Itoa(i)] = "value"
+	}
+
+	tests := []struct {
+		name    string
+		labels  map[string]string
+		wantErr bool
+	}{
+		{name: "no labels", labels: nil},
+		{name: "valid labels", labels: map[string]string{"project": "apollo", "source": "sync-job"}},
+		{name: "too many labels", labels: tooMany, wantErr: true},
+		{name: "uppercase key", labels: map[string]string{"Project": "apollo"}, wantErr: true},
+		{name: "key with a dot", labels: map[string]string{"project.name": "apollo"}, wantErr: true},
+		{
+			name:   "value of the maximum length",
+			labels: map[string]string{"project": strings.Repeat("a", MaxLabelValueLength)},
+		},
+		{
+			name:    "too long value",
+			labels:  map[string]string{"project": strings.Repeat("a", MaxLabelValueLength+1)},
+			wantErr: true,
+		},
+		{
+			// Each of the characters is 2 bytes long, so the value is longer than the maximum in bytes.
+			name:   "multi-byte value of the maximum length",
+			labels: map[string]string{"project": strings.Repeat("ש", MaxLabelValueLength)},
+		},
+		{
+			name:    "too long multi-byte value",
+			labels:  map[string]string{"project": strings.Repeat("ש", MaxLabelValueLength+1)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateLabels(tt.labels); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateLabels() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"expvar"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// leaseRenewals is the number of times a leader renews its lease within a lease.
+	leaseRenewals = 3
+
+	// leaseReleaseTimeout is the timeout of releasing a lease when its worker stops.
+	leaseReleaseTimeout = 5 * time.Second
+)
+
+// leadership is whether the instance is the leader of each of the singleton background workers,
+// 1 if it is and 0 if it isn't, keyed by the worker's name.
+var leadership = expvar.NewMap("leadership")
+
+// LeaderElector elects a single instance of the service, among the instances that share its store,
+// to run each of the singleton background workers, such as the outbox relay and the schedulers, so that
+// scaling the service to N instances doesn't run N copies of each worker. A worker is run by the holder
+// of its lease, who renews it a few times within a lease, and is run by another instance once its lease
+// expires, such as when its holder is stopped.
+type LeaderElector struct {
+	leases LeaseRepository
+	logger *logrus.Logger
+
+	// holder identifies the instance in the leases it holds.
+	holder string
+
+	// lease is the duration of a lease, which is how long a worker isn't run after its leader is lost.
+	lease time.Duration
+}
+
+// NewLeaderElector creates a LeaderElector that stores the leases of holder, of duration lease,
+// in leases, and returns it. The election is disabled if leases is nil or lease isn't positive,
+// and then every instance runs every worker.
+func NewLeaderElector(
+	leases LeaseRepository,
+	logger *logrus.Logger,
+	holder string,
+	lease time.Duration,
+) LeaderElector {
+	return LeaderElector{leases: leases, logger: logger, holder: holder, lease: lease}
+}
+
+// Run is running an infinite loop that runs the worker of name with fn whenever the instance is elected
+// its leader, until ctx is done. fn is run with a context that's done once the instance is no longer
+// the leader, and should return once it's done. The lease is released once fn returns.
+func (e LeaderElector) Run(ctx context.Context, name string, fn func(ctx context.Context)) {
+	ctx = WithWorker(ctx, name)
+	if e.leases == nil || e.lease <= 0 {
+		fn(ctx)
+		return
+	}
+
+	renewal := e.lease / leaseRenewals
+	ticker := time.NewTicker(renewal)
+	defer ticker.Stop()
+
+	var stop context.CancelFunc
+	var done chan struct{}
+	var heldUntil time.Time
+	defer func() {
+		if stop != nil {
+			stop()
+			<-done
+		}
+
+		e.setLeadership(name, 0)
+		e.release(name)
+	}()
+
+	for {
+		now := time.Now()
+		held, err := e.leases.AcquireLease(ctx, name, e.holder, now, now.Add(e.lease))
+		if err != nil {
+			e.logger.Errorf("failed renewing the lease of %s: %v", name, err)
+
+			// The leader keeps leading until its lease is about to expire.
+			held = stop != nil && now.Add(renewal).Before(heldUntil)
+		} else if held {
+			heldUntil = now.Add(e.lease)
+		}
+
+		switch {
+		case held && stop == nil:
+			stop, done = runWorker(ctx, fn)
+			e.setLeadership(name, 1)
+			e.logger.WithFields(logrus.Fields{"worker": name, "holder": e.holder}).Info("elected leader")
+		case !held && stop != nil:
+			stop()
+			<-done
+			stop, done = nil, nil
+
+			e.setLeadership(name, 0)
+			e.logger.WithFields(logrus.Fields{"worker": name, "holder": e.holder}).Warn("lost leadership")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runWorker runs fn in a goroutine with a context of ctx, and returns the function that stops it
+// and a channel that's closed once it returns.
+func runWorker(ctx context.Context, fn func(ctx context.Context)) (context.CancelFunc, chan struct{}) {
+	workerCtx, stop := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(workerCtx)
+	}()
+
+	return stop, done
+}
+
+// setLeadership sets the leadership metric of the worker of name to value.
+func (e LeaderElector) setLeadership(name string, value int64) {
+	metric := new(expvar.Int)
+	metric.Set(value)
+	leadership.Set(name, metric)
+}
+
+// release releases the lease of the worker of name, so that another instance may run it without
+// waiting for the lease to expire.
+func (e LeaderElector) release(name string) {
+	ctx, cancel := context.WithTimeout(context.Background(), leaseReleaseTimeout)
+	defer cancel()
+
+	if err := e.leases.ReleaseLease(ctx, name, e.holder); err != nil {
+		e.logger.Errorf("failed releasing the lease of %s: %v", name, err)
+	}
+}
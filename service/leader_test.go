@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// testLease is the duration of the leases of the tests, whose renewals are a third of it.
+const testLease = 300 * time.Millisecond
+
+// memoryLease is the holder of a lease in memoryLeases and when it expires.
+type memoryLease struct {
+	holder    string
+	expiresAt time.Time
+}
+
+// memoryLeases is an in-memory LeaseRepository whose operations of the holders in failing fail.
+type memoryLeases struct {
+	mu      sync.Mutex
+	leases  map[string]memoryLease
+	failing map[string]bool
+}
+
+// newMemoryLeases returns a memoryLeases without leases.
+func newMemoryLeases() *memoryLeases {
+	return &memoryLeases{leases: map[string]memoryLease{}, failing: map[string]bool{}}
+}
+
+// AcquireLease acquires the lease of name for holder, if it isn't held by another holder at now.
+func (l *memoryLeases) AcquireLease(
+	ctx context.Context,
+	name string,
+	holder string,
+	now time.Time,
+	expiresAt time.Time,
+) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.failing[holder] {
+		return false, errors.New("store unavailable")
+	}
+
+	if lease, ok := l.leases[name]; ok && lease.holder != holder && !lease.expiresAt.Before(now) {
+		return false, nil
+	}
+
+	l.leases[name] = memoryLease{holder: holder, expiresAt: expiresAt}
+	return true, nil
+}
+
+// ReleaseLease releases the lease of name if it's held by holder.
+func (l *memoryLeases) ReleaseLease(ctx context.Context, name string, holder string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.failing[holder] {
+		return errors.New("store unavailable")
+	}
+
+	if l.leases[name].holder == holder {
+		delete(l.leases, name)
+	}
+
+	return nil
+}
+
+// setFailing sets whether the operations of holder fail.
+func (l *memoryLeases) setFailing(holder string, failing bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.failing[holder] = failing
+}
+
+// workers records which of the contenders runs the worker, and whether two of them ever ran it at once.
+type workers struct {
+	mu         sync.Mutex
+	running    map[string]bool
+	overlapped bool
+}
+
+// run returns the worker of holder, which runs until its context is done.
+func (w *workers) run(holder string) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		w.mu.Lock()
+		if len(w.running) > 0 {
+			w.overlapped = true
+		}
+		w.running[holder] = true
+		w.mu.Unlock()
+
+		<-ctx.Done()
+
+		w.mu.Lock()
+		delete(w.running, holder)
+		w.mu.Unlock()
+	}
+}
+
+// leader returns the holder that runs the worker, or an empty string if none does.
+func (w *workers) leader() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for holder := range w.running {
+		return holder
+	}
+
+	return ""
+}
+
+// didOverlap returns whether two of the contenders ever ran the worker at once.
+func (w *workers) didOverlap() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.overlapped
+}
+
+// waitForLeader waits until a holder other than previous runs the worker, and returns it.
+func (w *workers) waitForLeader(t *testing.T, previous string, timeout time.Duration) string {
+	t.Helper()
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if leader := w.leader(); leader != "" && leader != previous {
+			return leader
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	t.Fatalf("no contender other than %q was elected within %v", previous, timeout)
+	return ""
+}
+
+// contend runs the worker of holder with an elector of leases, until the returned function is called,
+// which returns once the elector stopped.
+func contend(leases LeaseRepository, w *workers, holder string) func() {
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+	elector := NewLeaderElector(leases, logger, holder, testLease)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		elector.Run(ctx, "worker", w.run(holder))
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func TestLeaderElectorHandover(t *testing.T) {
+	leases := newMemoryLeases()
+	w := &workers{running: map[string]bool{}}
+	stops := map[string]func(){"a": contend(leases, w, "a"), "b": contend(leases, w, "b")}
+	defer func() {
+		for _, stop := range stops {
+			stop()
+		}
+	}()
+
+	leader := w.waitForLeader(t, "", testLease)
+
+	// The leader keeps its lease by renewing it, so the other contender isn't elected.
+	time.Sleep(2 * testLease)
+	if current := w.leader(); current != leader {
+		t.Errorf("expected %q to keep leading, got %q", leader, current)
+	}
+
+	// A stopped leader releases its lease, so the other contender is elected without waiting for it to expire.
+	stopped := time.Now()
+	stops[leader]()
+	delete(stops, leader)
+
+	w.waitForLeader(t, leader, testLease)
+	if elapsed := time.Since(stopped); elapsed >= testLease {
+		t.Errorf("expected the handover to take less than a lease, took %v", elapsed)
+	}
+
+	if w.didOverlap() {
+		t.Errorf("expected the worker to never run on both contenders at once")
+	}
+}
+
+func TestLeaderElectorLeaseExpiry(t *testing.T) {
+	leases := newMemoryLeases()
+	w := &workers{running: map[string]bool{}}
+	stopA := contend(leases, w, "a")
+	defer stopA()
+
+	w.waitForLeader(t, "", testLease)
+	stopB := contend(leases, w, "b")
+	defer stopB()
+
+	// A leader that can't renew its lease stops its worker before the lease expires,
+	// and the other contender is elected once it does.
+	leases.setFailing("a", true)
+	failed := time.Now()
+	w.waitForLeader(t, "a", 3*testLease)
+
+	// The lease was renewed at most a renewal before the renewals started failing.
+	if elapsed := time.Since(failed); elapsed < testLease/2 {
+		t.Errorf("expected the lease to be taken over only once it expired, took %v", elapsed)
+	}
+
+	if w.didOverlap() {
+		t.Errorf("expected the worker to never run on both contenders at once")
+	}
+}
+
+func TestLeaderElectorDisabled(t *testing.T) {
+	ran := false
+	elector := NewLeaderElector(nil, logrus.New(), "a", testLease)
+	elector.Run(context.Background(), "worker", func(ctx context.Context) {
+		ran = true
+	})
+
+	if !ran {
+		t.Errorf("expected the worker to run without an election")
+	}
+}
@@ -0,0 +1,13 @@
+package service
+
+import "time"
+
+// LegalHold is a litigation hold of FileID, placed by PlacedBy at PlacedAt, that blocks the deletion and
+// modification of the permissions of the file, and of their audit records, until it's released.
+type LegalHold struct {
+	ResourceType string
+	FileID       string
+	Reason       string
+	PlacedBy     string
+	PlacedAt     time.Time
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// DefaultMaxRequestBytes is the default maximum size of a request of an RPC without a limit of its own.
+	DefaultMaxRequestBytes = 1 << 20
+
+	// DefaultMaxListLength is the default maximum number of items of a list in a request,
+	// such as the permissions of a batch or the file IDs of a filter.
+	DefaultMaxListLength = 1000
+)
+
+// RequestLimits limits the size of the requests of each RPC and the length of the lists in them.
+// The handlers check their requests with it, along with the lengths of the lists that only they know.
+type RequestLimits struct {
+	// MaxRequestBytes is the maximum size of a request of an RPC that's not in ByMethod.
+	MaxRequestBytes int
+
+	// ByMethod maps the name of an RPC, such as "SimulateAccess", or its full method name,
+	// such as "/permissions.v2.Permissions/SimulateAccess", to the maximum size of its requests.
+	ByMethod map[string]int
+
+	// MaxListLength is the maximum number of items of a list in a request.
+	MaxListLength int
+}
+
+// ParseRequestLimits parses limits of the form "method=bytes,method=bytes", such as
+// "SimulateAccess=4194304", of the RPCs whose requests may be larger or must be smaller
+// than maxRequestBytes, an empty limits string is valid.
+func ParseRequestLimits(limits string, maxRequestBytes int, maxListLength int) (RequestLimits, error) {
+	if maxRequestBytes <= 0 {
+		return RequestLimits{}, fmt.Errorf("invalid maximum request size %d", maxRequestBytes)
+	}
+
+	if maxListLength <= 0 {
+		return RequestLimits{}, fmt.Errorf("invalid maximum list length %d", maxListLength)
+	}
+
+	requestLimits := RequestLimits{
+		MaxRequestBytes: maxRequestBytes,
+		ByMethod:        map[string]int{},
+		MaxListLength:   maxListLength,
+	}
+	for _, entry := range strings.Split(limits, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return RequestLimits{}, fmt.Errorf("invalid request limit entry %q", entry)
+		}
+
+		size, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil || size <= 0 {
+			return RequestLimits{}, fmt.Errorf("invalid request limit entry %q: size must be a positive integer", entry)
+		}
+
+		requestLimits.ByMethod[strings.TrimSpace(parts[0])] = size
+	}
+
+	return requestLimits, nil
+}
+
+// MaxMessageBytes returns the size of the largest request that any RPC may receive, which is
+// the limit of the server's transport, so that larger messages are rejected before they're decoded.
+func (l RequestLimits) MaxMessageBytes() int {
+	maxBytes := l.MaxRequestBytes
+	for _, size := range l.ByMethod {
+		if size > maxBytes {
+			maxBytes = size
+		}
+	}
+
+	return maxBytes
+}
+
+// Check returns a ResourceExhausted error if req is larger than the limit of the RPC of ctx,
+// otherwise returns nil. Requests aren't limited if l isn't configured.
+func (l RequestLimits) Check(ctx context.Context, req proto.Message) error {
+	maxBytes := l.maxRequestBytes(ctx)
+	if maxBytes == 0 {
+		return nil
+	}
+
+	if size := proto.Size(req); size > maxBytes {
+		method, _ := grpc.Method(ctx)
+		return RejectionError(
+			codes.ResourceExhausted,
+			Rejection{
+				Kind:    RejectionQuota,
+				Rule:    "max_request_bytes",
+				Subject: method,
+				Current: int64(size),
+				Limit:   int64(maxBytes),
+			},
+			"request of %d bytes exceeds the limit of %d bytes",
+			size,
+			maxBytes,
+		)
+	}
+
+	return nil
+}
+
+// CheckList returns an InvalidArgument error if the list field of a request has more than
+// l.MaxListLength items, otherwise returns nil. Lists aren't limited if l isn't configured.
+func (l RequestLimits) CheckList(field string, length int) error {
+	if l.MaxListLength != 0 && length > l.MaxListLength {
+		return RejectionError(
+			codes.InvalidArgument,
+			Rejection{
+				Kind:    RejectionQuota,
+				Rule:    "max_list_length",
+				Subject: field,
+				Current: int64(length),
+				Limit:   int64(l.MaxListLength),
+			},
+			"%s exceeds %d items",
+			field,
+			l.MaxListLength,
+		)
+	}
+
+	return nil
+}
+
+// maxRequestBytes returns the maximum size of the requests of the RPC of ctx.
+func (l RequestLimits) maxRequestBytes(ctx context.Context) int {
+	method, ok := grpc.Method(ctx)
+	if !ok {
+		return l.MaxRequestBytes
+	}
+
+	if size, ok := l.ByMethod[method]; ok {
+		return size
+	}
+
+	if size, ok := l.ByMethod[method[strings.LastIndex(method, "/")+1:]]; ok {
+		return size
+	}
+
+	return l.MaxRequestBytes
+}
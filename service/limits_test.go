@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	pb "github.com/meateam/permission-service/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// methodStream is a grpc.ServerTransportStream of an RPC of method, so that contexts are of its RPC.
+type methodStream struct {
+	grpc.ServerTransportStream
+	method string
+}
+
+// Method returns the full method name of the RPC.
+func (s methodStream) Method() string {
+	return s.method
+}
+
+// methodContext returns a context of the RPC of method.
+func methodContext(method string) context.Context {
+	return grpc.NewContextWithServerTransportStream(context.Background(), methodStream{method: method})
+}
+
+func TestParseRequestLimits(t *testing.T) {
+	tests := []struct {
+		name     string
+		limits   string
+		maxBytes int
+		maxList  int
+		byMethod map[string]int
+		valid    bool
+	}{
+		{name: "empty", maxBytes: 1024, maxList: 10, byMethod: map[string]int{}, valid: true},
+		{
+			name:     "entries",
+			limits:   " SimulateAccess = 4096, ,/permission.Permission/IsPermitted=512",
+			maxBytes: 1024,
+			maxList:  10,
+			byMethod: map[string]int{"SimulateAccess": 4096, "/permission.Permission/IsPermitted": 512},
+			valid:    true,
+		},
+		{name: "missing size", limits: "SimulateAccess", maxBytes: 1024, maxList: 10},
+		{name: "missing method", limits: "=4096", maxBytes: 1024, maxList: 10},
+		{name: "invalid size", limits: "SimulateAccess=4KB", maxBytes: 1024, maxList: 10},
+		{name: "zero size", limits: "SimulateAccess=0", maxBytes: 1024, maxList: 10},
+		{name: "zero default size", maxBytes: 0, maxList: 10},
+		{name: "zero list length", maxBytes: 1024, maxList: 0},
+	}
+
+	for _, test := range tests {
+		limits, err := ParseRequestLimits(test.limits, test.maxBytes, test.maxList)
+		if (err == nil) != test.valid {
+			t.Errorf("%s: expected the validity of %q to be %v, got %v", test.name, test.limits, test.valid, err)
+			continue
+		}
+
+		if test.valid && !reflect.DeepEqual(limits.ByMethod, test.byMethod) {
+			t.Errorf("%s: expected the limits %v, got %v", test.name, test.byMethod, limits.ByMethod)
+		}
+	}
+}
+
+func TestRequestLimitsCheck(t *testing.T) {
+	limits, err := ParseRequestLimits("IsPermitted=64,/permission.Permission/GetPermission=32", 128, 10)
+	if err != nil {
+		t.Fatalf("ParseRequestLimits failed: %v", err)
+	}
+
+	if maxBytes := limits.MaxMessageBytes(); maxBytes != 128 {
+		t.Errorf("expected the transport limit to be the largest limit, 128, got %d", maxBytes)
+	}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		fileID   string
+		exceeded bool
+	}{
+		{
+			name:   "default",
+			ctx:    methodContext("/permission.Permission/GetFilePermissions"),
+			fileID: strings.Repeat("a", 100),
+		},
+		{
+			name:     "default exceeded",
+			ctx:      methodContext("/permission.Permission/GetFilePermissions"),
+			fileID:   strings.Repeat("a", 200),
+			exceeded: true,
+		},
+		{
+			name:     "by name",
+			ctx:      methodContext("/permission.Permission/IsPermitted"),
+			fileID:   strings.Repeat("a", 100),
+			exceeded: true,
+		},
+		{
+			name:     "by full method",
+			ctx:      methodContext("/permission.Permission/GetPermission"),
+			fileID:   strings.Repeat("a", 50),
+			exceeded: true,
+		},
+		{name: "without a method", ctx: context.Background(), fileID: strings.Repeat("a", 100)},
+	}
+
+	for _, test := range tests {
+		err := limits.Check(test.ctx, &pb.IsPermittedRequest{FileID: test.fileID})
+		if !test.exceeded {
+			if err != nil {
+				t.Errorf("%s: expected the request to be within the limit, got %v", test.name, err)
+			}
+
+			continue
+		}
+
+		rejection, _ := RejectionFromError(err)
+		if status.Code(err) != codes.ResourceExhausted || rejection.Rule != "max_request_bytes" {
+			t.Errorf("%s: expected a ResourceExhausted rejection of max_request_bytes, got %v", test.name, err)
+		}
+	}
+
+	if err := (RequestLimits{}).Check(context.Background(), &pb.IsPermittedRequest{FileID: "a"}); err != nil {
+		t.Errorf("expected requests not to be limited without limits, got %v", err)
+	}
+}
+
+func TestRequestLimitsCheckList(t *testing.T) {
+	limits := RequestLimits{MaxListLength: 2}
+	if err := limits.CheckList("changes", 2); err != nil {
+		t.Errorf("expected a list of the maximum length to be valid, got %v", err)
+	}
+
+	err := limits.CheckList("changes", 3)
+	if rejection, _ := RejectionFromError(err); status.Code(err) != codes.InvalidArgument ||
+		rejection.Rule != "max_list_length" || rejection.Subject != "changes" || rejection.Current != 3 {
+		t.Errorf("expected an InvalidArgument rejection of the length of changes, got %v", err)
+	}
+
+	if err := (RequestLimits{}).CheckList("changes", 3); err != nil {
+		t.Errorf("expected lists not to be limited without limits, got %v", err)
+	}
+}
@@ -0,0 +1,20 @@
+package service
+
+import "time"
+
+// FileLock is a lockdown of FileID, by LockedBy at LockedAt, that suspends the access of every user
+// other than Owner to it, without deleting their permissions, until it's unlocked.
+type FileLock struct {
+	ResourceType string
+	FileID       string
+	Owner        string
+	Reason       string
+	LockedBy     string
+	LockedAt     time.Time
+}
+
+// Permits returns true if the access of userID to the file isn't suspended by the lock,
+// which is true for every user if the lock is nil.
+func (l *FileLock) Permits(userID string) bool {
+	return l == nil || l.Owner == userID
+}
@@ -0,0 +1,75 @@
+package memo
+
+import (
+	"context"
+	"strings"
+
+	"github.com/meateam/permission-service/service"
+)
+
+// Repository is a service.PermissionRepository that memoizes the point reads of permissions in the
+// service.RequestMemo of their request, if it has one, so that a composite operation that consults
+// the same permission several times, such as a matrix of checks or the explanation of an access,
+// only fetches it from the underlying repository once.
+type Repository struct {
+	service.PermissionRepository
+}
+
+// NewRepository returns a Repository that reads the permissions from permissions.
+func NewRepository(permissions service.PermissionRepository) Repository {
+	return Repository{PermissionRepository: permissions}
+}
+
+// Get returns the permission of userID to fileID, with only fields if any are given, from the memo
+// of the request of ctx.
+func (r Repository) Get(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+	fields ...service.PermissionField,
+) (service.Permission, error) {
+	key := []string{resourceType, fileID, userID}
+	for _, field := range fields {
+		key = append(key, string(field))
+	}
+
+	permission, err := service.RequestMemoFromContext(ctx).Do(
+		"permission",
+		strings.Join(key, "\x00"),
+		func() (interface{}, error) {
+			return r.PermissionRepository.Get(ctx, resourceType, fileID, userID, fields...)
+		},
+	)
+	memoized, _ := permission.(service.Permission)
+	return memoized, err
+}
+
+// LockRepository is a service.LockRepository that memoizes the reads of the locks of files in the
+// service.RequestMemo of their request, if it has one.
+type LockRepository struct {
+	service.LockRepository
+}
+
+// NewLockRepository returns a LockRepository that reads the locks from locks.
+func NewLockRepository(locks service.LockRepository) LockRepository {
+	return LockRepository{LockRepository: locks}
+}
+
+// GetFileLock returns the lock of fileID, or nil if it isn't locked, from the memo of the request of ctx.
+func (r LockRepository) GetFileLock(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+) (*service.FileLock, error) {
+	lock, err := service.RequestMemoFromContext(ctx).Do(
+		"file_lock",
+		resourceType+"\x00"+fileID,
+		func() (interface{}, error) {
+			return r.LockRepository.GetFileLock(ctx, resourceType, fileID)
+		},
+	)
+
+	fileLock, _ := lock.(*service.FileLock)
+	return fileLock, err
+}
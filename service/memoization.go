@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"expvar"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// memoizedReads counts the reads of the request memos, keyed by "name/hit" or "name/miss".
+var memoizedReads = expvar.NewMap("memoized_reads")
+
+// requestMemoKey is the context key of the memo of a request.
+type requestMemoKey struct{}
+
+// memoResult is the memoized result of a read.
+type memoResult struct {
+	value interface{}
+	err   error
+}
+
+// RequestMemo memoizes the documents that are read within a single RPC, such as the permissions and the locks
+// that a composite operation consults several times, so that each is only fetched from the store once.
+// It lives as long as its request, so it's never invalidated.
+type RequestMemo struct {
+	mu      sync.Mutex
+	results map[string]memoResult
+}
+
+// WithRequestMemo returns a copy of ctx with a new, empty, RequestMemo.
+func WithRequestMemo(ctx context.Context) context.Context {
+	return context.WithValue(ctx, requestMemoKey{}, &RequestMemo{results: map[string]memoResult{}})
+}
+
+// RequestMemoFromContext returns the memo of the request of ctx, or nil if it has none.
+func RequestMemoFromContext(ctx context.Context) *RequestMemo {
+	memo, _ := ctx.Value(requestMemoKey{}).(*RequestMemo)
+	return memo
+}
+
+// Do returns the memoized result of the read of name with key, or reads it with read and memoizes it
+// if the read succeeded or didn't find it, so that the other errors are retried. A nil memo always reads.
+func (m *RequestMemo) Do(
+	name string,
+	key string,
+	read func() (interface{}, error),
+) (interface{}, error) {
+	if m == nil {
+		return read()
+	}
+
+	key = name + "\x00" + key
+	m.mu.Lock()
+	result, ok := m.results[key]
+	m.mu.Unlock()
+	if ok {
+		memoizedReads.Add(name+"/hit", 1)
+		return result.value, result.err
+	}
+
+	memoizedReads.Add(name+"/miss", 1)
+	value, err := read()
+	if err == nil || status.Code(err) == codes.NotFound {
+		m.mu.Lock()
+		m.results[key] = memoResult{value: value, err: err}
+		m.mu.Unlock()
+	}
+
+	return value, err
+}
+
+// MemoizeReads returns the middleware that handles the unary reads of the services of descs, the RPCs that
+// are annotated with the NO_SIDE_EFFECTS idempotency level in the protos, with a RequestMemo.
+// The writes aren't memoized, so that they read their own writes, and neither are the streams, whose memos
+// would grow with them. It fails if the proto of one of descs isn't registered.
+func MemoizeReads(descs ...grpc.ServiceDesc) (Middleware, error) {
+	reads, err := readMethods(descs)
+	if err != nil {
+		return Middleware{}, err
+	}
+
+	unary := func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if reads[info.FullMethod] {
+			ctx = WithRequestMemo(ctx)
+		}
+
+		return handler(ctx, req)
+	}
+
+	stream := func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		return handler(srv, stream)
+	}
+
+	return Middleware{Unary: unary, Stream: stream}, nil
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"expvar"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// OutcomeAllow is the outcome of a permission check that found a sufficient permission.
+	OutcomeAllow = "allow"
+
+	// OutcomeDeny is the outcome of a permission check that found no sufficient permission.
+	OutcomeDeny = "deny"
+
+	// OutcomeError is the outcome of a permission check that failed.
+	OutcomeError = "error"
+
+	// unknownCaller labels the outcomes of callers that are not verified.
+	unknownCaller = "unknown"
+)
+
+// permissionOutcomes counts the outcomes of permission checks, keyed by "method/caller/outcome".
+var permissionOutcomes = expvar.NewMap("permission_outcomes")
+
+// handledRequests counts the handled requests, keyed by "full method/code".
+var handledRequests = expvar.NewMap("handled_requests")
+
+// CountRequests returns the middleware that counts the handled requests by their full methods and codes.
+func CountRequests() Middleware {
+	unary := func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		res, err := handler(ctx, req)
+		handledRequests.Add(info.FullMethod+"/"+status.Code(err).String(), 1)
+		return res, err
+	}
+
+	stream := func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		err := handler(srv, stream)
+		handledRequests.Add(info.FullMethod+"/"+status.Code(err).String(), 1)
+		return err
+	}
+
+	return Middleware{Unary: unary, Stream: stream}
+}
+
+// recordOutcome counts the outcome of a permission check of method by the caller of ctx.
+// A nil err is counted as allowed if allowed is true, a NotFound err is counted as denied.
+func recordOutcome(ctx context.Context, method string, allowed bool, err error) {
+	permissionOutcomes.Add(strings.Join([]string{method, callerOrUnknown(ctx), outcomeOf(allowed, err)}, "/"), 1)
+}
+
+// outcomeOf returns the outcome of a permission check, a nil err is allowed if allowed is true,
+// a NotFound err is denied.
+func outcomeOf(allowed bool, err error) string {
+	switch {
+	case err == nil && allowed:
+		return OutcomeAllow
+	case err != nil && status.Code(err) != codes.NotFound:
+		return OutcomeError
+	default:
+		return OutcomeDeny
+	}
+}
+
+// callerOrUnknown returns the verified caller of ctx, or unknownCaller if it's not verified.
+func callerOrUnknown(ctx context.Context) string {
+	if caller := CallerFromContext(ctx); caller != "" {
+		return caller
+	}
+
+	return unknownCaller
+}
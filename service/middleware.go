@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"google.golang.org/grpc"
+)
+
+const (
+	// MiddlewareLogging is the name of the middleware that traces and logs the requests.
+	MiddlewareLogging = "logging"
+
+	// MiddlewareMetrics is the name of the middleware that counts the handled requests by their codes.
+	MiddlewareMetrics = "metrics"
+
+	// MiddlewareRecovery is the name of the middleware that recovers the panics of the handlers.
+	MiddlewareRecovery = "recovery"
+
+	// MiddlewareMemoization is the name of the middleware that memoizes the concurrent identical reads.
+	MiddlewareMemoization = "memoization"
+
+	// MiddlewareRegionFence is the name of the middleware that forwards or rejects the writes in a replica region.
+	MiddlewareRegionFence = "region_fence"
+
+	// MiddlewareDeprecations is the name of the middleware that records the uses of the deprecated RPCs
+	// and fields, and rejects them after their sunsets.
+	MiddlewareDeprecations = "deprecations"
+
+	// MiddlewareValidation is the name of the middleware that validates the requests by their protos.
+	MiddlewareValidation = "validation"
+
+	// MiddlewareRateLimits is the name of the middleware that enforces the rate limits of the tenant.
+	MiddlewareRateLimits = "rate_limits"
+
+	// MiddlewareAuth is the name of the middleware that authenticates the forwarded actors of the requests.
+	MiddlewareAuth = "auth"
+
+	// DefaultMiddlewareOrder is the order of the middlewares of the services, from the outermost
+	// to the innermost.
+	DefaultMiddlewareOrder = "logging,metrics,recovery,memoization,region_fence,deprecations,validation," +
+		"rate_limits,auth"
+)
+
+// knownMiddlewares are the names of the middlewares that may be ordered.
+var knownMiddlewares = map[string]bool{
+	MiddlewareLogging:      true,
+	MiddlewareMetrics:      true,
+	MiddlewareRecovery:     true,
+	MiddlewareMemoization:  true,
+	MiddlewareRegionFence:  true,
+	MiddlewareDeprecations: true,
+	MiddlewareValidation:   true,
+	MiddlewareRateLimits:   true,
+	MiddlewareAuth:         true,
+}
+
+// outsideRecovery are the names of the middlewares that may run outside of the recovery middleware.
+var outsideRecovery = map[string]bool{
+	MiddlewareLogging:  true,
+	MiddlewareMetrics:  true,
+	MiddlewareRecovery: true,
+}
+
+// Middleware is the pair of grpc server interceptors that the unary and the stream handlers run within.
+type Middleware struct {
+	Unary  grpc.UnaryServerInterceptor
+	Stream grpc.StreamServerInterceptor
+}
+
+// passThrough is the middleware of disabled middlewares, which runs the handlers as they are.
+var passThrough = Middleware{
+	Unary: func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		return handler(ctx, req)
+	},
+	Stream: func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		return handler(srv, stream)
+	},
+}
+
+// ParseMiddlewareOrder parses a comma separated order of the middlewares, from the outermost to
+// the innermost, or the default order if order is empty. Each known middleware must
+// be ordered once, only the logging and the metrics middlewares may run outside of the recovery middleware,
+// so that the panics of the rest are recovered, and the auth middleware must run within the region fence,
+// which sets the callers of the writes that are forwarded from replica regions.
+func ParseMiddlewareOrder(order string) ([]string, error) {
+	if strings.TrimSpace(order) == "" {
+		order = DefaultMiddlewareOrder
+	}
+
+	parsed := []string{}
+	ordered := map[string]bool{}
+	for _, name := range strings.Split(order, ",") {
+		name = strings.TrimSpace(name)
+		switch {
+		case !knownMiddlewares[name]:
+			return nil, fmt.Errorf("unknown middleware %q", name)
+		case ordered[name]:
+			return nil, fmt.Errorf("middleware %q is ordered more than once", name)
+		case !outsideRecovery[name] && !ordered[MiddlewareRecovery]:
+			return nil, fmt.Errorf("middleware %q must run within the %q middleware", name, MiddlewareRecovery)
+		case name == MiddlewareAuth && !ordered[MiddlewareRegionFence]:
+			return nil, fmt.Errorf("middleware %q must run within the %q middleware", name, MiddlewareRegionFence)
+		}
+
+		ordered[name] = true
+		parsed = append(parsed, name)
+	}
+
+	for name := range knownMiddlewares {
+		if !ordered[name] {
+			return nil, fmt.Errorf("middleware %q is not ordered", name)
+		}
+	}
+
+	return parsed, nil
+}
+
+// Middlewares is a registry of the middlewares of the server, which chains their interceptors
+// in an explicit order as the interceptors of the server. The middlewares whose behavior depends on the RPCs,
+// such as whether they're reads, only handle the RPCs of the services they're created for,
+// and pass the rest, such as health checks, through.
+type Middlewares struct {
+	order      []string
+	registered map[string]Middleware
+}
+
+// NewMiddlewares creates a registry of middlewares that chains them in order,
+// from the outermost to the innermost, and returns it.
+func NewMiddlewares(order []string) *Middlewares {
+	return &Middlewares{order: order, registered: map[string]Middleware{}}
+}
+
+// Register registers middleware by name, replacing the middleware that's registered by it.
+func (m *Middlewares) Register(name string, middleware Middleware) {
+	m.registered[name] = middleware
+}
+
+// Chain returns the middleware that chains the interceptors of the middlewares in their order,
+// for the interceptors of a grpc.Server. It fails if an ordered middleware isn't registered.
+func (m *Middlewares) Chain() (Middleware, error) {
+	unary := make([]grpc.UnaryServerInterceptor, 0, len(m.order))
+	stream := make([]grpc.StreamServerInterceptor, 0, len(m.order))
+	for _, name := range m.order {
+		middleware, ok := m.registered[name]
+		if !ok {
+			return Middleware{}, fmt.Errorf("middleware %q is not registered", name)
+		}
+
+		unary = append(unary, middleware.Unary)
+		stream = append(stream, middleware.Stream)
+	}
+
+	return Middleware{
+		Unary:  grpc_middleware.ChainUnaryServer(unary...),
+		Stream: grpc_middleware.ChainStreamServer(stream...),
+	}, nil
+}
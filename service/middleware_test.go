@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+func TestParseMiddlewareOrder(t *testing.T) {
+	defaultOrder := strings.Split(DefaultMiddlewareOrder, ",")
+	tests := []struct {
+		name  string
+		order string
+		want  []string
+		err   string
+	}{
+		{name: "default", order: "", want: defaultOrder},
+		{name: "blank", order: "  ", want: defaultOrder},
+		{
+			name:  "auth outside of the region fence",
+			order: " metrics, logging,recovery,auth,region_fence,memoization,validation,deprecations,rate_limits",
+			err:   `middleware "auth" must run within the "region_fence" middleware`,
+		},
+		{
+			name:  "custom",
+			order: "metrics,logging,recovery,region_fence,auth,rate_limits,validation,deprecations,memoization",
+			want: []string{
+				"metrics", "logging", "recovery", "region_fence", "auth", "rate_limits", "validation", "deprecations",
+				"memoization",
+			},
+		},
+		{
+			name:  "unknown",
+			order: DefaultMiddlewareOrder + ",tracing",
+			err:   `unknown middleware "tracing"`,
+		},
+		{
+			name:  "ordered twice",
+			order: DefaultMiddlewareOrder + ",metrics",
+			err:   `middleware "metrics" is ordered more than once`,
+		},
+		{
+			name:  "outside of the recovery",
+			order: "logging,metrics,validation,recovery,memoization,region_fence,deprecations,rate_limits,auth",
+			err:   `middleware "validation" must run within the "recovery" middleware`,
+		},
+		{
+			name:  "not ordered",
+			order: "logging,metrics,recovery,memoization,region_fence,deprecations,validation,auth",
+			err:   `middleware "rate_limits" is not ordered`,
+		},
+	}
+
+	for _, test := range tests {
+		order, err := ParseMiddlewareOrder(test.order)
+		switch {
+		case test.err == "" && err != nil:
+			t.Errorf("%s: ParseMiddlewareOrder failed: %v", test.name, err)
+		case test.err != "" && (err == nil || err.Error() != test.err):
+			t.Errorf("%s: expected ParseMiddlewareOrder to fail with %q, got %v", test.name, test.err, err)
+		case test.err == "" && !reflect.DeepEqual(order, test.want):
+			t.Errorf("%s: ParseMiddlewareOrder returned %v, expected %v", test.name, order, test.want)
+		}
+	}
+}
+
+// recordingMiddleware returns a middleware that appends name to calls when it runs.
+func recordingMiddleware(name string, calls *[]string) Middleware {
+	return Middleware{
+		Unary: func(
+			ctx context.Context,
+			req interface{},
+			info *grpc.UnaryServerInfo,
+			handler grpc.UnaryHandler,
+		) (interface{}, error) {
+			*calls = append(*calls, name)
+			return handler(ctx, req)
+		},
+		Stream: func(
+			srv interface{},
+			stream grpc.ServerStream,
+			info *grpc.StreamServerInfo,
+			handler grpc.StreamHandler,
+		) error {
+			*calls = append(*calls, name)
+			return handler(srv, stream)
+		},
+	}
+}
+
+func TestMiddlewaresChain(t *testing.T) {
+	var calls []string
+	middlewares := NewMiddlewares([]string{"outer", "inner"})
+	middlewares.Register("inner", recordingMiddleware("inner", &calls))
+	middlewares.Register("outer", recordingMiddleware("outer", &calls))
+	middlewares.Register("unordered", recordingMiddleware("unordered", &calls))
+
+	chain, err := middlewares.Chain()
+	if err != nil {
+		t.Fatalf("Chain failed: %v", err)
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+	_, err = chain.Unary(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) {
+		calls = append(calls, "handler")
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("the chained interceptor failed: %v", err)
+	}
+
+	if want := []string{"outer", "inner", "handler"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("expected the calls %v, got %v", want, calls)
+	}
+
+	middlewares = NewMiddlewares([]string{"outer", "missing"})
+	middlewares.Register("outer", recordingMiddleware("outer", &calls))
+	if _, err := middlewares.Chain(); err == nil {
+		t.Errorf("expected Chain to fail with an ordered middleware that isn't registered")
+	}
+}
@@ -0,0 +1,144 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/meateam/permission-service/service"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListFileEvents returns up to pageSize of the events of the outbox of the permissions to fileID that occurred
+// since, if it isn't zero, newest first, after the event of pageToken, and the token of the next page, which is
+// empty if there are no more events. The events of service.EventUpdated have the permissions of the events
+// that preceded them as their Previous. Fails with codes.FailedPrecondition if the outbox is disabled.
+func (s MongoStore) ListFileEvents(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	since time.Time,
+	pageSize int,
+	pageToken string,
+) ([]service.PermissionEvent, string, error) {
+	if !s.outbox {
+		return nil, "", status.Error(codes.FailedPrecondition, "permission changes aren't recorded")
+	}
+
+	idFilter := bson.D{}
+	if !since.IsZero() {
+		idFilter = append(idFilter, bson.E{Key: "$gte", Value: primitive.NewObjectIDFromTimestamp(since)})
+	}
+
+	if pageToken != "" {
+		lastID, err := decodePageToken(pageToken)
+		if err != nil {
+			return nil, "", err
+		}
+
+		idFilter = append(idFilter, bson.E{Key: "$lt", Value: lastID})
+	}
+
+	filter := fileEventsFilter(resourceType, fileID)
+	filter = append(filter, notDuplicateEventFilter)
+	if len(idFilter) > 0 {
+		filter = append(filter, bson.E{Key: MongoObjectIDField, Value: idFilter})
+	}
+
+	// Fetch one more event than needed to know whether there are more events.
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: MongoObjectIDField, Value: -1}}).
+		SetLimit(int64(pageSize) + 1)
+
+	cur, err := s.collection(OutboxCollectionName).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, "", err
+	}
+	defer cur.Close(ctx)
+
+	events := []service.PermissionEvent{}
+	nextPageToken := ""
+	for cur.Next(ctx) {
+		if len(events) == pageSize {
+			nextPageToken = encodePageToken(events[len(events)-1].ID)
+			break
+		}
+
+		var record outboxRecord
+		if err := cur.Decode(&record); err != nil {
+			return nil, "", err
+		}
+
+		event, err := record.event()
+		if err != nil {
+			return nil, "", err
+		}
+
+		if record.Type == service.EventUpdated {
+			if event.Previous, err = s.previousPermission(ctx, resourceType, record); err != nil {
+				return nil, "", err
+			}
+		}
+
+		events = append(events, event)
+	}
+
+	if err := cur.Err(); err != nil {
+		return nil, "", err
+	}
+
+	return events, nextPageToken, nil
+}
+
+// fileEventsFilter returns the filter of the events of the outbox of the permissions to fileID.
+func fileEventsFilter(resourceType string, fileID string) bson.D {
+	resourceTypeValue := interface{}(resourceType)
+	if resourceType == service.DefaultResourceType {
+		resourceTypeValue = bson.D{bson.E{Key: "$in", Value: bson.A{resourceType, nil, ""}}}
+	}
+
+	return bson.D{
+		bson.E{Key: OutboxBSONPermissionField + "." + PermissionBSONFileIDField, Value: fileID},
+		bson.E{Key: OutboxBSONPermissionField + "." + PermissionBSONResourceTypeField, Value: resourceTypeValue},
+	}
+}
+
+// previousPermission returns the permission of the event of the outbox that created or updated the permission
+// of record, of resourceType, before it, or nil if it's no longer retained.
+func (s MongoStore) previousPermission(
+	ctx context.Context,
+	resourceType string,
+	record outboxRecord,
+) (*pb.PermissionObject, error) {
+	filter := fileEventsFilter(resourceType, record.Permission.FileID)
+	filter = append(filter,
+		bson.E{Key: OutboxBSONPermissionField + "." + PermissionBSONUserIDField, Value: record.Permission.UserID},
+		bson.E{Key: OutboxBSONTypeField, Value: bson.D{
+			bson.E{Key: "$in", Value: bson.A{service.EventCreated, service.EventUpdated}},
+		}},
+		bson.E{Key: MongoObjectIDField, Value: bson.D{bson.E{Key: "$lt", Value: record.ID}}},
+	)
+
+	opts := options.FindOne().SetSort(bson.D{bson.E{Key: MongoObjectIDField, Value: -1}})
+	var previous outboxRecord
+	err := s.collection(OutboxCollectionName).FindOne(ctx, filter, opts).Decode(&previous)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	permission := &pb.PermissionObject{}
+	if err := previous.Permission.MarshalProto(permission); err != nil {
+		return nil, err
+	}
+
+	return permission, nil
+}
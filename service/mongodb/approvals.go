@@ -0,0 +1,151 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/meateam/permission-service/service"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// PermissionRequestCollectionName is the name of the permission requests collection.
+	PermissionRequestCollectionName = "permissionRequests"
+
+	// PermissionRequestBSONApproverField is the name of the approver field in the permission request BSON.
+	PermissionRequestBSONApproverField = "approver"
+
+	// PermissionRequestBSONApprovedAtField is the name of the approvedAt field in the permission request BSON.
+	PermissionRequestBSONApprovedAtField = "approvedAt"
+
+	// PermissionRequestBSONPermissionIDField is the name of the permissionID field in the permission request BSON.
+	PermissionRequestBSONPermissionIDField = "permissionID"
+)
+
+// permissionRequestRecord is the structure that represents a permission request as it's stored.
+type permissionRequestRecord struct {
+	ID           primitive.ObjectID       `bson:"_id"`
+	ResourceType string                   `bson:"resourceType"`
+	FileID       string                   `bson:"fileID"`
+	UserID       string                   `bson:"userID"`
+	Creator      string                   `bson:"creator"`
+	Update       service.PermissionUpdate `bson:"update"`
+	RequestedAt  time.Time                `bson:"requestedAt"`
+	Approver     string                   `bson:"approver,omitempty"`
+	ApprovedAt   *time.Time               `bson:"approvedAt,omitempty"`
+	PermissionID string                   `bson:"permissionID,omitempty"`
+}
+
+// permissionRequest returns the service.PermissionRequest of r.
+func (r permissionRequestRecord) permissionRequest() service.PermissionRequest {
+	return service.PermissionRequest{
+		ID:           r.ID.Hex(),
+		ResourceType: r.ResourceType,
+		FileID:       r.FileID,
+		UserID:       r.UserID,
+		Creator:      r.Creator,
+		Update:       r.Update,
+		RequestedAt:  r.RequestedAt,
+		Approver:     r.Approver,
+		ApprovedAt:   r.ApprovedAt,
+		PermissionID: r.PermissionID,
+	}
+}
+
+// CreatePermissionRequest stores request and returns it with its ID.
+func (s MongoStore) CreatePermissionRequest(
+	ctx context.Context,
+	request service.PermissionRequest,
+) (service.PermissionRequest, error) {
+	record := permissionRequestRecord{
+		ID:           primitive.NewObjectID(),
+		ResourceType: request.ResourceType,
+		FileID:       request.FileID,
+		UserID:       request.UserID,
+		Creator:      request.Creator,
+		Update:       request.Update,
+		RequestedAt:  request.RequestedAt,
+	}
+
+	if _, err := s.collection(PermissionRequestCollectionName).InsertOne(ctx, record); err != nil {
+		return service.PermissionRequest{}, err
+	}
+
+	return record.permissionRequest(), nil
+}
+
+// GetPermissionRequest returns the request with id, fails with codes.NotFound if it doesn't exist.
+func (s MongoStore) GetPermissionRequest(ctx context.Context, id string) (service.PermissionRequest, error) {
+	filter, err := idFilter(id)
+	if err != nil {
+		return service.PermissionRequest{}, status.Errorf(codes.NotFound, "permission request %s not found", id)
+	}
+
+	var record permissionRequestRecord
+	err = s.collection(PermissionRequestCollectionName).FindOne(ctx, filter).Decode(&record)
+	if err == mongo.ErrNoDocuments {
+		return service.PermissionRequest{}, status.Errorf(codes.NotFound, "permission request %s not found", id)
+	}
+
+	if err != nil {
+		return service.PermissionRequest{}, err
+	}
+
+	return record.permissionRequest(), nil
+}
+
+// ApprovePermissionRequest records that the pending request with id was approved by approver at approvedAt,
+// and created the permission with permissionID, and returns it. Fails with codes.FailedPrecondition
+// if the request was already approved, or with codes.NotFound if it doesn't exist.
+func (s MongoStore) ApprovePermissionRequest(
+	ctx context.Context,
+	id string,
+	approver string,
+	approvedAt time.Time,
+	permissionID string,
+) (service.PermissionRequest, error) {
+	filter, err := idFilter(id)
+	if err != nil {
+		return service.PermissionRequest{}, status.Errorf(codes.NotFound, "permission request %s not found", id)
+	}
+
+	pendingFilter := append(filter, bson.E{Key: PermissionRequestBSONApproverField, Value: nil})
+	update := bson.D{
+		bson.E{
+			Key: "$set",
+			Value: bson.D{
+				bson.E{Key: PermissionRequestBSONApproverField, Value: approver},
+				bson.E{Key: PermissionRequestBSONApprovedAtField, Value: approvedAt},
+				bson.E{Key: PermissionRequestBSONPermissionIDField, Value: permissionID},
+			},
+		},
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var record permissionRequestRecord
+	err = s.collection(PermissionRequestCollectionName).
+		FindOneAndUpdate(ctx, pendingFilter, update, opts).
+		Decode(&record)
+	if err == mongo.ErrNoDocuments {
+		if _, err := s.GetPermissionRequest(ctx, id); err != nil {
+			return service.PermissionRequest{}, err
+		}
+
+		return service.PermissionRequest{}, status.Errorf(
+			codes.FailedPrecondition,
+			"permission request %s was already approved",
+			id,
+		)
+	}
+
+	if err != nil {
+		return service.PermissionRequest{}, err
+	}
+
+	return record.permissionRequest(), nil
+}
@@ -0,0 +1,185 @@
+package mongodb
+
+import (
+	"context"
+	"sort"
+
+	"github.com/meateam/permission-service/service"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// CollaboratorsCollectionName is the name of the collection of the number of the permissions that each user
+	// gave each other user, which is recomputed from the permissions by RefreshCollaborators.
+	CollaboratorsCollectionName = "collaborators"
+
+	// CollaboratorsBSONSharerField and CollaboratorsBSONRecipientField are the names of the fields of the user
+	// that gave the permissions and of the user that was given them, in the _id of the collaborators BSON.
+	CollaboratorsBSONSharerField    = "sharer"
+	CollaboratorsBSONRecipientField = "recipient"
+
+	// CollaboratorsBSONCountField is the name of the field of the number of the permissions
+	// in the collaborators BSON.
+	CollaboratorsBSONCountField = "count"
+)
+
+// collaboratorsRecord is the structure that represents the number of the permissions
+// that a user gave another user, as it's stored.
+type collaboratorsRecord struct {
+	ID struct {
+		Sharer    string `bson:"sharer"`
+		Recipient string `bson:"recipient"`
+	} `bson:"_id"`
+	Count int64 `bson:"count"`
+}
+
+// createCollaboratorsIndexes creates the indexes of the collaborators of each user, which are kept
+// when the collection is replaced by RefreshCollaborators.
+func (s MongoStore) createCollaboratorsIndexes(ctx context.Context) error {
+	_, err := s.collection(CollaboratorsCollectionName).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				bson.E{Key: MongoObjectIDField + "." + CollaboratorsBSONSharerField, Value: 1},
+				bson.E{Key: CollaboratorsBSONCountField, Value: -1},
+			},
+		},
+		{
+			Keys: bson.D{
+				bson.E{Key: MongoObjectIDField + "." + CollaboratorsBSONRecipientField, Value: 1},
+				bson.E{Key: CollaboratorsBSONCountField, Value: -1},
+			},
+		},
+	})
+
+	return err
+}
+
+// RefreshCollaborators replaces the collaborators collection with the number of the permissions that each user
+// gave each other user directly, with a single aggregation, and returns the number of the pairs of users.
+// The permissions of erased users, and of grantees that aren't users, aren't counted.
+func (s MongoStore) RefreshCollaborators(ctx context.Context) (int64, error) {
+	pipeline := mongo.Pipeline{
+		bson.D{bson.E{Key: "$match", Value: bson.D{
+			bson.E{Key: PermissionBSONCreatorField, Value: bson.D{
+				bson.E{Key: "$nin", Value: bson.A{nil, "", service.ErasedUserID}},
+			}},
+			bson.E{Key: PermissionBSONGranteeTypeField, Value: bson.D{
+				bson.E{Key: "$in", Value: bson.A{nil, service.GranteeTypeUser}},
+			}},
+			bson.E{Key: PermissionBSONInheritedFromField, Value: bson.D{bson.E{Key: "$exists", Value: false}}},
+		}}},
+		bson.D{bson.E{Key: "$group", Value: bson.D{
+			bson.E{Key: MongoObjectIDField, Value: bson.D{
+				bson.E{Key: CollaboratorsBSONSharerField, Value: "$" + PermissionBSONCreatorField},
+				bson.E{Key: CollaboratorsBSONRecipientField, Value: "$" + PermissionBSONUserIDField},
+			}},
+			bson.E{Key: CollaboratorsBSONCountField, Value: bson.D{bson.E{Key: "$sum", Value: 1}}},
+		}}},
+
+		// The permissions that users gave themselves, such as of the files they created, aren't collaborations.
+		bson.D{bson.E{Key: "$match", Value: bson.D{bson.E{Key: "$expr", Value: bson.D{bson.E{Key: "$ne", Value: bson.A{
+			"$" + MongoObjectIDField + "." + CollaboratorsBSONSharerField,
+			"$" + MongoObjectIDField + "." + CollaboratorsBSONRecipientField,
+		}}}}}}},
+		bson.D{bson.E{Key: "$out", Value: s.collectionPrefix + CollaboratorsCollectionName}},
+	}
+
+	opts := options.Aggregate().SetAllowDiskUse(true)
+	cur, err := s.collection(PermissionCollectionName).Aggregate(ctx, pipeline, opts)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := cur.Close(ctx); err != nil {
+		return 0, err
+	}
+
+	return s.collection(CollaboratorsCollectionName).EstimatedDocumentCount(ctx)
+}
+
+// GetFrequentCollaborators returns up to limit of the users that userID gave the most permissions to,
+// or was given the most permissions by, as of the last refresh of the collaborators, most frequent first.
+func (s MongoStore) GetFrequentCollaborators(
+	ctx context.Context,
+	userID string,
+	limit int,
+) ([]service.Collaborator, error) {
+	// The most frequent collaborators are among the most frequent of each direction.
+	collaborators := map[string]*service.Collaborator{}
+	for _, field := range []string{CollaboratorsBSONSharerField, CollaboratorsBSONRecipientField} {
+		records, err := s.findCollaborators(ctx, field, userID, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, record := range records {
+			collaboratorID := record.ID.Recipient
+			if field == CollaboratorsBSONRecipientField {
+				collaboratorID = record.ID.Sharer
+			}
+
+			collaborator, ok := collaborators[collaboratorID]
+			if !ok {
+				collaborator = &service.Collaborator{UserID: collaboratorID}
+				collaborators[collaboratorID] = collaborator
+			}
+
+			if field == CollaboratorsBSONSharerField {
+				collaborator.SharedWith = record.Count
+			} else {
+				collaborator.ReceivedFrom = record.Count
+			}
+		}
+	}
+
+	frequent := make([]service.Collaborator, 0, len(collaborators))
+	for _, collaborator := range collaborators {
+		frequent = append(frequent, *collaborator)
+	}
+
+	sort.Slice(frequent, func(i, j int) bool {
+		countI := frequent[i].SharedWith + frequent[i].ReceivedFrom
+		countJ := frequent[j].SharedWith + frequent[j].ReceivedFrom
+		if countI != countJ {
+			return countI > countJ
+		}
+
+		return frequent[i].UserID < frequent[j].UserID
+	})
+
+	if len(frequent) > limit {
+		frequent = frequent[:limit]
+	}
+
+	return frequent, nil
+}
+
+// findCollaborators returns up to limit of the records of the collaborators whose field, the sharer
+// or the recipient, is userID, with the most permissions first.
+func (s MongoStore) findCollaborators(
+	ctx context.Context,
+	field string,
+	userID string,
+	limit int,
+) ([]collaboratorsRecord, error) {
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: CollaboratorsBSONCountField, Value: -1}}).
+		SetLimit(int64(limit))
+	cur, err := s.readCollection(ctx, CollaboratorsCollectionName).Find(
+		ctx,
+		bson.D{bson.E{Key: MongoObjectIDField + "." + field, Value: userID}},
+		opts,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	records := []collaboratorsRecord{}
+	if err := cur.All(ctx, &records); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
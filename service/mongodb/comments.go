@@ -0,0 +1,162 @@
+package mongodb
+
+import (
+	"context"
+
+	"github.com/meateam/permission-service/service"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// taggedCollection is a collection whose operations are tagged with the comment of the RPC or the worker of
+// their context, service.OperationComment, so that the slow-query logs and the profiler of the database can
+// be traced back to the operation of the service that made them. The reads are tagged with their comment
+// option, and the writes with a $comment operator in their filters. The inserts and the bulk writes
+// aren't tagged, since they have neither.
+type taggedCollection struct {
+	*mongo.Collection
+}
+
+// withComment returns a copy of filter with a $comment operator of comment, or filter if comment is empty
+// or filter is neither a document nor nil.
+func withComment(filter interface{}, comment string) interface{} {
+	if comment == "" {
+		return filter
+	}
+
+	switch filter := filter.(type) {
+	case nil:
+		return bson.D{{Key: "$comment", Value: comment}}
+	case bson.D:
+		tagged := make(bson.D, 0, len(filter)+1)
+		return append(append(tagged, filter...), bson.E{Key: "$comment", Value: comment})
+	case bson.M:
+		tagged := make(bson.M, len(filter)+1)
+		for key, value := range filter {
+			tagged[key] = value
+		}
+
+		tagged["$comment"] = comment
+		return tagged
+	default:
+		return filter
+	}
+}
+
+// Find finds the documents of the collection that match filter, tagged with the comment of ctx.
+func (c taggedCollection) Find(
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOptions,
+) (*mongo.Cursor, error) {
+	if comment := service.OperationComment(ctx); comment != "" {
+		opts = append(opts[:len(opts):len(opts)], options.Find().SetComment(comment))
+	}
+
+	return c.Collection.Find(ctx, filter, opts...)
+}
+
+// FindOne finds a document of the collection that matches filter, tagged with the comment of ctx.
+func (c taggedCollection) FindOne(
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOneOptions,
+) *mongo.SingleResult {
+	if comment := service.OperationComment(ctx); comment != "" {
+		opts = append(opts[:len(opts):len(opts)], options.FindOne().SetComment(comment))
+	}
+
+	return c.Collection.FindOne(ctx, filter, opts...)
+}
+
+// Aggregate runs pipeline on the collection, tagged with the comment of ctx.
+func (c taggedCollection) Aggregate(
+	ctx context.Context,
+	pipeline interface{},
+	opts ...*options.AggregateOptions,
+) (*mongo.Cursor, error) {
+	if comment := service.OperationComment(ctx); comment != "" {
+		opts = append(opts[:len(opts):len(opts)], options.Aggregate().SetComment(comment))
+	}
+
+	return c.Collection.Aggregate(ctx, pipeline, opts...)
+}
+
+// CountDocuments counts the documents of the collection that match filter, tagged with the comment of ctx.
+func (c taggedCollection) CountDocuments(
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.CountOptions,
+) (int64, error) {
+	return c.Collection.CountDocuments(ctx, withComment(filter, service.OperationComment(ctx)), opts...)
+}
+
+// UpdateOne updates a document of the collection that matches filter, tagged with the comment of ctx.
+func (c taggedCollection) UpdateOne(
+	ctx context.Context,
+	filter interface{},
+	update interface{},
+	opts ...*options.UpdateOptions,
+) (*mongo.UpdateResult, error) {
+	return c.Collection.UpdateOne(ctx, withComment(filter, service.OperationComment(ctx)), update, opts...)
+}
+
+// UpdateMany updates the documents of the collection that match filter, tagged with the comment of ctx.
+func (c taggedCollection) UpdateMany(
+	ctx context.Context,
+	filter interface{},
+	update interface{},
+	opts ...*options.UpdateOptions,
+) (*mongo.UpdateResult, error) {
+	return c.Collection.UpdateMany(ctx, withComment(filter, service.OperationComment(ctx)), update, opts...)
+}
+
+// ReplaceOne replaces a document of the collection that matches filter, tagged with the comment of ctx.
+func (c taggedCollection) ReplaceOne(
+	ctx context.Context,
+	filter interface{},
+	replacement interface{},
+	opts ...*options.ReplaceOptions,
+) (*mongo.UpdateResult, error) {
+	return c.Collection.ReplaceOne(ctx, withComment(filter, service.OperationComment(ctx)), replacement, opts...)
+}
+
+// DeleteOne deletes a document of the collection that matches filter, tagged with the comment of ctx.
+func (c taggedCollection) DeleteOne(
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.DeleteOptions,
+) (*mongo.DeleteResult, error) {
+	return c.Collection.DeleteOne(ctx, withComment(filter, service.OperationComment(ctx)), opts...)
+}
+
+// DeleteMany deletes the documents of the collection that match filter, tagged with the comment of ctx.
+func (c taggedCollection) DeleteMany(
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.DeleteOptions,
+) (*mongo.DeleteResult, error) {
+	return c.Collection.DeleteMany(ctx, withComment(filter, service.OperationComment(ctx)), opts...)
+}
+
+// FindOneAndUpdate updates a document of the collection that matches filter and returns it,
+// tagged with the comment of ctx.
+func (c taggedCollection) FindOneAndUpdate(
+	ctx context.Context,
+	filter interface{},
+	update interface{},
+	opts ...*options.FindOneAndUpdateOptions,
+) *mongo.SingleResult {
+	return c.Collection.FindOneAndUpdate(ctx, withComment(filter, service.OperationComment(ctx)), update, opts...)
+}
+
+// FindOneAndDelete deletes a document of the collection that matches filter and returns it,
+// tagged with the comment of ctx.
+func (c taggedCollection) FindOneAndDelete(
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOneAndDeleteOptions,
+) *mongo.SingleResult {
+	return c.Collection.FindOneAndDelete(ctx, withComment(filter, service.OperationComment(ctx)), opts...)
+}
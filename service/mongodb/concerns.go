@@ -0,0 +1,111 @@
+package mongodb
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+// WriteConcernMajority is the write concern of the writes that are acknowledged once they're written
+// to a majority of the members of the replica set, so that they aren't rolled back in a failover.
+const WriteConcernMajority = "majority"
+
+// projectionCollections are the collections whose writes are of the projections of the outbox,
+// which are rebuilt from it.
+var projectionCollections = map[string]bool{
+	SharedWithMeCollectionName: true,
+}
+
+// Concerns are the read and write concerns of the classes of the store's operations. The operations of a class
+// whose concern is nil use the concerns of the client, which are the ones of its connection string.
+type Concerns struct {
+	// Writes is the write concern of the writes of permissions and of the state that guards them, such as
+	// the locks, the legal holds and the leases, and of the transactions, which mustn't be lost once acknowledged.
+	Writes *writeconcern.WriteConcern
+
+	// ProjectionWrites is the write concern of the writes of the projections of the outbox, such as of the files
+	// shared with each user, which are rebuilt from the outbox, and are of Writes if nil.
+	ProjectionWrites *writeconcern.WriteConcern
+
+	// Reads is the read concern of the reads that aren't in a transaction, such as of the permission checks.
+	Reads *readconcern.ReadConcern
+}
+
+// ParseWriteConcern returns the write concern of w, either WriteConcernMajority or the positive number of
+// members that acknowledge the writes, that's journaled if journal is true, and waits up to timeout for
+// the acknowledgements, or indefinitely if 0. It returns nil if w is empty.
+func ParseWriteConcern(w string, journal bool, timeout time.Duration) (*writeconcern.WriteConcern, error) {
+	if w == "" {
+		return nil, nil
+	}
+
+	opts := []writeconcern.Option{writeconcern.J(journal)}
+	if timeout > 0 {
+		opts = append(opts, writeconcern.WTimeout(timeout))
+	}
+
+	if w == WriteConcernMajority {
+		return writeconcern.New(append(opts, writeconcern.WMajority())...), nil
+	}
+
+	// Unacknowledged writes, of 0 members, may be lost without an error.
+	members, err := strconv.Atoi(w)
+	if err != nil || members < 1 {
+		return nil, fmt.Errorf("write concern %q must be %q or a positive number of members", w, WriteConcernMajority)
+	}
+
+	return writeconcern.New(append(opts, writeconcern.W(members))...), nil
+}
+
+// ParseReadConcern returns the read concern of level, either "local", "available", "majority",
+// "linearizable" or "snapshot". It returns nil if level is empty.
+func ParseReadConcern(level string) (*readconcern.ReadConcern, error) {
+	switch level {
+	case "":
+		return nil, nil
+	case "local", "available", "majority", "linearizable", "snapshot":
+		return readconcern.New(readconcern.Level(level)), nil
+	default:
+		return nil, fmt.Errorf("unknown read concern %q", level)
+	}
+}
+
+// WithConcerns returns a copy of the store whose operations are of concerns.
+func (s MongoStore) WithConcerns(concerns Concerns) MongoStore {
+	s.concerns = concerns
+	return s
+}
+
+// collectionOptions returns the options of the collection of name, with the concerns of its operations.
+func (c Concerns) collectionOptions(name string) *options.CollectionOptions {
+	opts := options.Collection()
+	writes := c.Writes
+	if projectionCollections[name] && c.ProjectionWrites != nil {
+		writes = c.ProjectionWrites
+	}
+
+	if writes != nil {
+		opts.SetWriteConcern(writes)
+	}
+
+	if c.Reads != nil {
+		opts.SetReadConcern(c.Reads)
+	}
+
+	return opts
+}
+
+// sessionOptions returns the options of the store's sessions, whose transactions are committed with the
+// write concern of Writes.
+func (c Concerns) sessionOptions() *options.SessionOptions {
+	opts := options.Session()
+	if c.Writes != nil {
+		opts.SetDefaultWriteConcern(c.Writes)
+	}
+
+	return opts
+}
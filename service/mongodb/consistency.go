@@ -0,0 +1,120 @@
+package mongodb
+
+import (
+	"context"
+	"encoding/base64"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ConsistencyTokenHeader is the grpc metadata key of the causal consistency token.
+// Responses carry the token of the session that handled the request, and requests that
+// carry a token are guaranteed to observe all the writes that preceded it. A response of
+// a request that was handled in several sessions carries a token of each, of which the last is the newest.
+const ConsistencyTokenHeader = "x-consistency-token"
+
+// consistencyToken is the session state that is encoded into a consistency token.
+type consistencyToken struct {
+	ClusterTime   bson.Raw             `bson:"clusterTime,omitempty"`
+	OperationTime *primitive.Timestamp `bson:"operationTime,omitempty"`
+}
+
+// encodeConsistencyToken encodes the cluster and operation times of sess into a token.
+func encodeConsistencyToken(sess mongo.Session) (string, error) {
+	token := consistencyToken{ClusterTime: sess.ClusterTime(), OperationTime: sess.OperationTime()}
+	raw, err := bson.Marshal(token)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// advanceSession advances the cluster and operation times of sess to the ones in the encoded token.
+func advanceSession(sess mongo.Session, encodedToken string) error {
+	raw, err := base64.RawURLEncoding.DecodeString(encodedToken)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid consistency token: %v", err)
+	}
+
+	var token consistencyToken
+	if err := bson.Unmarshal(raw, &token); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid consistency token: %v", err)
+	}
+
+	return advanceSessionTimes(sess, token.ClusterTime, token.OperationTime)
+}
+
+// advanceSessionTimes advances the cluster and operation times of sess to clusterTime and operationTime, if set.
+func advanceSessionTimes(sess mongo.Session, clusterTime bson.Raw, operationTime *primitive.Timestamp) error {
+	if clusterTime != nil {
+		if err := sess.AdvanceClusterTime(clusterTime); err != nil {
+			return err
+		}
+	}
+
+	if operationTime != nil {
+		if err := sess.AdvanceOperationTime(operationTime); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WithCausalConsistency runs fn in a causally consistent session. If ctx's incoming
+// metadata carries a consistency token then the session is advanced to it, and
+// the session's token is set in the response header once fn returns, even if it failed,
+// since the reads of a failed request, such as of a permission that's not found, advance the session too.
+// Of several tokens in the metadata the session is advanced to the last, which is the newest.
+// If ctx is already in a session then fn is run in it.
+func (s MongoStore) WithCausalConsistency(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.(mongo.SessionContext); ok {
+		return fn(ctx)
+	}
+
+	sess, err := s.DB.Client().StartSession(s.concerns.sessionOptions().SetCausalConsistency(true))
+	if err != nil {
+		return unavailableError(err)
+	}
+	defer sess.EndSession(ctx)
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if tokens := md.Get(ConsistencyTokenHeader); len(tokens) > 0 && tokens[len(tokens)-1] != "" {
+			if err := advanceSession(sess, tokens[len(tokens)-1]); err != nil {
+				return err
+			}
+		}
+	}
+
+	err = mongo.WithSession(ctx, sess, func(sessCtx mongo.SessionContext) error {
+		return fn(sessCtx)
+	})
+
+	setConsistencyToken(ctx, sess)
+
+	return err
+}
+
+// setConsistencyToken sets the token of sess in the response header of ctx, if it's of a grpc request.
+// The token is best effort, failing to set it doesn't fail the request: the header of a stream
+// is sent with its first message, after which point tokens of later sessions can't be set.
+func setConsistencyToken(ctx context.Context, sess mongo.Session) {
+	// Only grpc requests have a response header to set the token in.
+	if grpc.ServerTransportStreamFromContext(ctx) == nil {
+		return
+	}
+
+	token, err := encodeConsistencyToken(sess)
+	if err != nil {
+		return
+	}
+
+	_ = grpc.SetHeader(ctx, metadata.Pairs(ConsistencyTokenHeader, token))
+}
@@ -0,0 +1,138 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/meateam/permission-service/service"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CopyPermissions copies the permissions that were given directly to sourceFileID to destFileID,
+// with a single bulk write in a transaction. The existing permissions of destFileID are overridden
+// if overwrite is true, otherwise they're kept. The events of the copied permissions are written
+// to the outbox, if it's enabled, in the same transaction. Transactions require mongodb to be a replica set.
+func (s MongoStore) CopyPermissions(
+	ctx context.Context,
+	resourceType string,
+	sourceFileID string,
+	destFileID string,
+	overwrite bool,
+) (service.CopyReport, error) {
+	var report service.CopyReport
+	err := s.transaction(ctx, func(ctx context.Context) (err error) {
+		report, err = s.copyPermissions(ctx, resourceType, sourceFileID, destFileID, overwrite)
+		return err
+	})
+	if err != nil {
+		return service.CopyReport{}, err
+	}
+
+	return report, nil
+}
+
+// copyPermissions copies the permissions of sourceFileID to destFileID in the transaction of ctx.
+func (s MongoStore) copyPermissions(
+	ctx context.Context,
+	resourceType string,
+	sourceFileID string,
+	destFileID string,
+	overwrite bool,
+) (service.CopyReport, error) {
+	var report service.CopyReport
+
+	// Inherited permissions are of the source's folder, the destination inherits the permissions of its own.
+	sourceFilter := append(
+		resourceFilter(resourceType, sourceFileID),
+		bson.E{Key: PermissionBSONInheritedFromField, Value: bson.D{bson.E{Key: "$exists", Value: false}}},
+	)
+	source, err := s.find(ctx, sourceFilter)
+	if err != nil || len(source) == 0 {
+		return report, err
+	}
+
+	dest, err := s.find(ctx, resourceFilter(resourceType, destFileID))
+	if err != nil {
+		return report, err
+	}
+
+	destByUser := make(map[string]*BSON, len(dest))
+	for _, permission := range dest {
+		destByUser[permission.GetUserID()] = permission.(*BSON)
+	}
+
+	models := make([]mongo.WriteModel, 0, len(source))
+	events := make([]interface{}, 0, len(source))
+	for _, permission := range source {
+		permission := permission.(*BSON)
+		existing, ok := destByUser[permission.UserID]
+		if ok && !overwrite {
+			report.Skipped++
+			continue
+		}
+
+		copied := *permission
+		copied.FileID = destFileID
+		copied.LastAccessedAt = time.Time{}
+		if !ok {
+			copied.ID = primitive.NewObjectID()
+			copied.Version = 0
+			models = append(models, mongo.NewInsertOneModel().SetDocument(&copied))
+			events = append(events, eventRecords(service.EventCreated, &copied)...)
+			continue
+		}
+
+		copied.ID = existing.ID
+		copied.ResourceKind = existing.ResourceKind
+		copied.LastAccessedAt = existing.LastAccessedAt
+		copied.Version = existing.Version + 1
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.D{bson.E{Key: MongoObjectIDField, Value: existing.ID}}).
+			SetUpdate(bson.D{
+				bson.E{Key: "$set", Value: copiedFields(permission)},
+				bson.E{
+					Key:   "$unset",
+					Value: bson.D{bson.E{Key: PermissionBSONInheritedFromField, Value: ""}},
+				},
+				incVersion,
+			}))
+		events = append(events, eventRecords(service.EventCreated, &copied)...)
+	}
+
+	if len(models) == 0 {
+		return report, nil
+	}
+
+	collection := s.collection(PermissionCollectionName)
+	if _, err := collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+		return report, err
+	}
+
+	report.Copied = int64(len(models))
+	if s.outbox {
+		if err := s.writeEvents(ctx, events); err != nil {
+			return report, err
+		}
+	}
+
+	return report, nil
+}
+
+// copiedFields returns the fields of permission that override the existing permission of its user
+// when it's copied.
+func copiedFields(permission *BSON) bson.D {
+	return bson.D{
+		bson.E{Key: PermissionBSONRoleField, Value: permission.Role},
+		bson.E{Key: PermissionBSONCreatorField, Value: permission.Creator},
+		bson.E{Key: PermissionBSONCanReshareField, Value: permission.CanReshare},
+		bson.E{Key: PermissionBSONMessageField, Value: permission.Message},
+		bson.E{Key: PermissionBSONLabelField, Value: permission.Label},
+		bson.E{Key: PermissionBSONLabelsField, Value: permission.Labels},
+		bson.E{Key: PermissionBSONSharingChainField, Value: permission.SharingChain},
+		bson.E{Key: PermissionBSONGranteeTypeField, Value: permission.GranteeType},
+		bson.E{Key: PermissionBSONSourceField, Value: permission.Source},
+	}
+}
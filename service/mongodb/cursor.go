@@ -0,0 +1,138 @@
+package mongodb
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/meateam/permission-service/service"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// cursorSeparator separates the sort keys of a page cursor.
+const cursorSeparator = "."
+
+// errInvalidPageToken is the error of a page token that can't be decoded.
+var errInvalidPageToken = status.Error(codes.InvalidArgument, "invalid page token")
+
+// pageCursor is the position of the last permission of a page in the sort order of the listing,
+// which the next page continues after. Pages are ordered by a unique compound sort key that ends
+// with the permission's ID, so permissions that are created, updated or deleted while the listing
+// is paginated don't shift the permissions of the pages that follow.
+type pageCursor struct {
+	order pb.PermissionsOrder
+	id    primitive.ObjectID
+
+	// lastAccessedAt is only a part of the cursors of pages ordered by RECENTLY_ACCESSED,
+	// and is zero if the permission was never accessed.
+	lastAccessedAt time.Time
+}
+
+// newPageCursor returns the cursor of permission in order.
+func newPageCursor(permission service.Permission, order pb.PermissionsOrder) (pageCursor, error) {
+	id, err := primitive.ObjectIDFromHex(permission.GetID())
+	if err != nil {
+		return pageCursor{}, err
+	}
+
+	cursor := pageCursor{order: order, id: id}
+	if order == pb.PermissionsOrder_RECENTLY_ACCESSED {
+		cursor.lastAccessedAt = permission.GetLastAccessedAt()
+	}
+
+	return cursor, nil
+}
+
+// encode encodes c into an opaque page token.
+func (c pageCursor) encode() string {
+	if c.order != pb.PermissionsOrder_RECENTLY_ACCESSED {
+		return encodePageToken(c.id.Hex())
+	}
+
+	lastAccessedAt := ""
+	if !c.lastAccessedAt.IsZero() {
+		lastAccessedAt = strconv.FormatInt(c.lastAccessedAt.UnixNano()/int64(time.Millisecond), 10)
+	}
+
+	return encodePageToken(c.id.Hex() + cursorSeparator + lastAccessedAt)
+}
+
+// decodePageCursor decodes pageToken into the cursor of the last permission of the previous page in order.
+// A page token is only valid for the order of the listing that returned it.
+func decodePageCursor(pageToken string, order pb.PermissionsOrder) (pageCursor, error) {
+	if order != pb.PermissionsOrder_RECENTLY_ACCESSED {
+		id, err := decodePageToken(pageToken)
+		if err != nil {
+			return pageCursor{}, err
+		}
+
+		return pageCursor{order: order, id: id}, nil
+	}
+
+	token, err := base64.RawURLEncoding.DecodeString(pageToken)
+	if err != nil {
+		return pageCursor{}, errInvalidPageToken
+	}
+
+	keys := strings.Split(string(token), cursorSeparator)
+	if len(keys) != 2 {
+		return pageCursor{}, errInvalidPageToken
+	}
+
+	id, err := primitive.ObjectIDFromHex(keys[0])
+	if err != nil {
+		return pageCursor{}, errInvalidPageToken
+	}
+
+	cursor := pageCursor{order: order, id: id}
+	if keys[1] != "" {
+		lastAccessedAt, err := strconv.ParseInt(keys[1], 10, 64)
+		if err != nil {
+			return pageCursor{}, errInvalidPageToken
+		}
+
+		cursor.lastAccessedAt = time.Unix(0, lastAccessedAt*int64(time.Millisecond))
+	}
+
+	return cursor, nil
+}
+
+// filter returns the filter of the permissions that come after c in its order.
+func (c pageCursor) filter() bson.D {
+	afterID := bson.E{Key: MongoObjectIDField, Value: bson.D{bson.E{Key: "$gt", Value: c.id}}}
+	if c.order != pb.PermissionsOrder_RECENTLY_ACCESSED {
+		return bson.D{afterID}
+	}
+
+	// Permissions that were never accessed come last, ordered by their IDs.
+	if c.lastAccessedAt.IsZero() {
+		return bson.D{bson.E{Key: PermissionBSONLastAccessedAtField, Value: nil}, afterID}
+	}
+
+	return bson.D{bson.E{
+		Key: "$or",
+		Value: bson.A{
+			bson.D{bson.E{
+				Key:   PermissionBSONLastAccessedAtField,
+				Value: bson.D{bson.E{Key: "$lt", Value: c.lastAccessedAt}},
+			}},
+			bson.D{bson.E{Key: PermissionBSONLastAccessedAtField, Value: c.lastAccessedAt}, afterID},
+			bson.D{bson.E{Key: PermissionBSONLastAccessedAtField, Value: nil}},
+		},
+	}}
+}
+
+// sortByOrder returns the unique sort key of the pages of permissions in order.
+func sortByOrder(order pb.PermissionsOrder) bson.D {
+	byID := bson.E{Key: MongoObjectIDField, Value: 1}
+	if order != pb.PermissionsOrder_RECENTLY_ACCESSED {
+		return bson.D{byID}
+	}
+
+	return bson.D{bson.E{Key: PermissionBSONLastAccessedAtField, Value: -1}, byID}
+}
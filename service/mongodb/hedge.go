@@ -0,0 +1,161 @@
+package mongodb
+
+import (
+	"context"
+	"expvar"
+	"time"
+
+	"github.com/meateam/permission-service/service"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// HedgeIssued is the outcome of a read that didn't return within the hedge delay and was hedged.
+	HedgeIssued = "issued"
+
+	// HedgeWon is the outcome of a hedged read whose second attempt returned first.
+	HedgeWon = "won"
+
+	// HedgeWasted is the outcome of a hedged read whose first attempt returned first,
+	// so the work of the second attempt was wasted.
+	HedgeWasted = "wasted"
+)
+
+// hedgedReads counts the outcomes of hedged reads.
+var hedgedReads = expvar.NewMap("hedged_reads")
+
+// hedgeAttempt is the result of an attempt of a hedged read.
+type hedgeAttempt struct {
+	hedge         bool
+	permission    service.Permission
+	err           error
+	clusterTime   bson.Raw
+	operationTime *primitive.Timestamp
+}
+
+// succeeded returns true if the attempt found the permission or found that it doesn't exist.
+func (a hedgeAttempt) succeeded() bool {
+	return a.err == nil || a.err == mongo.ErrNoDocuments
+}
+
+// WithHedging returns a copy of the store whose point reads of permissions are hedged: if a read doesn't
+// return within delay then a second attempt is issued, and the result of the first to return is used.
+// Reads aren't hedged if delay is 0.
+func (s MongoStore) WithHedging(delay time.Duration) MongoStore {
+	s.hedgeDelay = delay
+	return s
+}
+
+// hedgedFindOne finds one permission that matches filter like findOne, and hedges the read
+// if it doesn't return within s.hedgeDelay. If ctx is in a session then each attempt is run
+// in a session of its own that observes the same writes, since a session mustn't be used concurrently,
+// and ctx's session is advanced to the session of the attempt that's used.
+func (s MongoStore) hedgedFindOne(
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOneOptions,
+) (service.Permission, error) {
+	if s.hedgeDelay <= 0 {
+		return s.findOne(ctx, filter, opts...)
+	}
+
+	sess, inSession := ctx.(mongo.SessionContext)
+	var clusterTime bson.Raw
+	var operationTime *primitive.Timestamp
+	if inSession {
+		clusterTime, operationTime = sess.ClusterTime(), sess.OperationTime()
+	}
+
+	result := hedge(ctx, s.hedgeDelay, func(ctx context.Context) (result hedgeAttempt) {
+		if inSession {
+			return s.findOneInSession(ctx, clusterTime, operationTime, filter, opts...)
+		}
+
+		result.permission, result.err = s.findOne(ctx, filter, opts...)
+		return result
+	})
+
+	if inSession && result.succeeded() {
+		if err := advanceSessionTimes(sess, result.clusterTime, result.operationTime); err != nil {
+			return nil, err
+		}
+	}
+
+	return result.permission, result.err
+}
+
+// hedge runs attempt, and runs it again as a hedge if it doesn't return within delay, and returns the result
+// of the attempt that returned first, unless it failed and the other attempt succeeded. The context of
+// the attempts is canceled once hedge returns, so that the attempt that's still running is abandoned.
+func hedge(ctx context.Context, delay time.Duration, attempt func(ctx context.Context) hedgeAttempt) hedgeAttempt {
+	attemptCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	attempts := make(chan hedgeAttempt, 2)
+	run := func(hedge bool) {
+		result := attempt(attemptCtx)
+		result.hedge = hedge
+		attempts <- result
+	}
+
+	go run(false)
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case result := <-attempts:
+		return result
+	case <-timer.C:
+	}
+
+	hedgedReads.Add(HedgeIssued, 1)
+	go run(true)
+
+	// An attempt that failed, such as of a node that's down, is superseded by the other attempt.
+	result := <-attempts
+	if !result.succeeded() {
+		if other := <-attempts; other.succeeded() {
+			result = other
+		}
+	}
+
+	if result.hedge {
+		hedgedReads.Add(HedgeWon, 1)
+	} else {
+		hedgedReads.Add(HedgeWasted, 1)
+	}
+
+	return result
+}
+
+// findOneInSession finds one permission that matches filter like findOne, in a new causally consistent
+// session that's advanced to clusterTime and operationTime, and returns the result with the session's times.
+func (s MongoStore) findOneInSession(
+	ctx context.Context,
+	clusterTime bson.Raw,
+	operationTime *primitive.Timestamp,
+	filter interface{},
+	opts ...*options.FindOneOptions,
+) hedgeAttempt {
+	sess, err := s.DB.Client().StartSession(options.Session().SetCausalConsistency(true))
+	if err != nil {
+		return hedgeAttempt{err: err}
+	}
+	defer sess.EndSession(ctx)
+
+	if err := advanceSessionTimes(sess, clusterTime, operationTime); err != nil {
+		return hedgeAttempt{err: err}
+	}
+
+	var result hedgeAttempt
+	result.err = mongo.WithSession(ctx, sess, func(sessCtx mongo.SessionContext) (err error) {
+		result.permission, err = s.findOne(sessCtx, filter, opts...)
+		return err
+	})
+
+	result.clusterTime, result.operationTime = sess.ClusterTime(), sess.OperationTime()
+	return result
+}
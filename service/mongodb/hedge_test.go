@@ -0,0 +1,202 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// testHedgeDelay is the hedge delay of the tests.
+const testHedgeDelay = 50 * time.Millisecond
+
+// errUnavailable is the error of an attempt of a node that's down.
+var errUnavailable = errors.New("node unavailable")
+
+// hedgeCall is how an attempt of a hedged read behaves.
+type hedgeCall struct {
+	// latency is how long the attempt takes, unless its context is canceled before.
+	latency time.Duration
+	err     error
+}
+
+// hedgeCalls runs the attempts of a hedged read by their calls, in order, and records whether
+// the context of each attempt was canceled before it returned, and when each attempt started.
+type hedgeCalls struct {
+	mu       sync.Mutex
+	calls    []hedgeCall
+	started  []time.Time
+	canceled []bool
+	returned int
+}
+
+// attempt runs the next call, and returns its result with the ID of the permission as its index.
+func (c *hedgeCalls) attempt(ctx context.Context) hedgeAttempt {
+	c.mu.Lock()
+	i := len(c.started)
+	call := c.calls[i]
+	c.started = append(c.started, time.Now())
+	c.canceled = append(c.canceled, false)
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		c.returned++
+		c.mu.Unlock()
+	}()
+
+	select {
+	case <-time.After(call.latency):
+	case <-ctx.Done():
+		c.mu.Lock()
+		c.canceled[i] = true
+		c.mu.Unlock()
+	}
+
+	result := hedgeAttempt{err: call.err}
+	if call.err == nil {
+		result.permission = &BSON{UserID: strconv.Itoa(i)}
+	}
+
+	return result
+}
+
+// wait waits until every attempt that was started, at least n of them, returned, or until timeout.
+func (c *hedgeCalls) wait(n int, timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		c.mu.Lock()
+		returned := len(c.started) >= n && c.returned == len(c.started)
+		c.mu.Unlock()
+		if returned {
+			return
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// hedgeCount returns the number of the hedged reads of outcome.
+func hedgeCount(outcome string) int64 {
+	if value, ok := hedgedReads.Get(outcome).(*expvar.Int); ok {
+		return value.Value()
+	}
+
+	return 0
+}
+
+func TestHedge(t *testing.T) {
+	tests := []struct {
+		name  string
+		calls []hedgeCall
+
+		// attempt is the index of the attempt whose result is returned, or -1 if an error is returned.
+		attempt  int
+		err      error
+		outcome  string
+		canceled []bool
+	}{
+		{
+			name:     "fast",
+			calls:    []hedgeCall{{latency: 0}},
+			attempt:  0,
+			canceled: []bool{false},
+		},
+		{
+			name:     "hedge won",
+			calls:    []hedgeCall{{latency: time.Minute}, {latency: 0}},
+			attempt:  1,
+			outcome:  HedgeWon,
+			canceled: []bool{true, false},
+		},
+		{
+			name:     "hedge wasted",
+			calls:    []hedgeCall{{latency: 2 * testHedgeDelay}, {latency: time.Minute}},
+			attempt:  0,
+			outcome:  HedgeWasted,
+			canceled: []bool{false, true},
+		},
+		{
+			name:     "failed attempt superseded",
+			calls:    []hedgeCall{{latency: 2 * testHedgeDelay, err: errUnavailable}, {latency: 2 * testHedgeDelay}},
+			attempt:  1,
+			outcome:  HedgeWon,
+			canceled: []bool{false, false},
+		},
+		{
+			name:     "not found isn't superseded",
+			calls:    []hedgeCall{{latency: 2 * testHedgeDelay, err: mongo.ErrNoDocuments}, {latency: time.Minute}},
+			attempt:  -1,
+			err:      mongo.ErrNoDocuments,
+			outcome:  HedgeWasted,
+			canceled: []bool{false, true},
+		},
+		{
+			name: "both failed",
+			calls: []hedgeCall{
+				{latency: 2 * testHedgeDelay, err: errUnavailable},
+				{latency: 0, err: errors.New("timeout")},
+			},
+			attempt:  -1,
+			err:      errors.New("timeout"),
+			outcome:  HedgeWon,
+			canceled: []bool{false, false},
+		},
+		{
+			name:     "failed fast",
+			calls:    []hedgeCall{{latency: 0, err: errUnavailable}},
+			attempt:  -1,
+			err:      errUnavailable,
+			canceled: []bool{false},
+		},
+	}
+
+	for _, test := range tests {
+		calls := &hedgeCalls{calls: test.calls}
+		issued, outcomes := hedgeCount(HedgeIssued), int64(0)
+		if test.outcome != "" {
+			outcomes = hedgeCount(test.outcome)
+		}
+
+		start := time.Now()
+		result := hedge(context.Background(), testHedgeDelay, calls.attempt)
+		calls.wait(len(test.calls), time.Second)
+
+		switch {
+		case test.attempt >= 0 && (result.err != nil || result.permission.GetUserID() != strconv.Itoa(test.attempt)):
+			t.Errorf("%s: expected the result of attempt %d, got %v", test.name, test.attempt, result.err)
+		case test.attempt < 0 && (result.err == nil || result.err.Error() != test.err.Error()):
+			t.Errorf("%s: expected the error %v, got %v", test.name, test.err, result.err)
+		}
+
+		if len(calls.started) != len(test.calls) {
+			t.Errorf("%s: expected %d attempts, got %d", test.name, len(test.calls), len(calls.started))
+			continue
+		}
+
+		// The hedge is issued only once the first attempt didn't return within the delay.
+		if len(calls.started) == 2 {
+			if delay := calls.started[1].Sub(start); delay < testHedgeDelay {
+				t.Errorf("%s: expected the hedge to be issued after %v, issued after %v", test.name, testHedgeDelay, delay)
+			}
+
+			if count := hedgeCount(HedgeIssued); count != issued+1 {
+				t.Errorf("%s: expected the hedge to be counted as issued", test.name)
+			}
+
+			if count := hedgeCount(test.outcome); count != outcomes+1 {
+				t.Errorf("%s: expected the hedge to be counted as %s", test.name, test.outcome)
+			}
+		}
+
+		for i, canceled := range test.canceled {
+			if calls.canceled[i] != canceled {
+				t.Errorf("%s: expected the cancellation of attempt %d to be %v", test.name, i, canceled)
+			}
+		}
+	}
+}
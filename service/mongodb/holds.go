@@ -0,0 +1,204 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/meateam/permission-service/service"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// LegalHoldCollectionName is the name of the collection of the legal holds of files.
+	LegalHoldCollectionName = "legalHolds"
+
+	// OutboxBSONHeldPublishedAtField is the name of the field of the outbox event BSON that holds the time
+	// an event of a held file was published at, instead of publishedAt, so that it doesn't expire.
+	OutboxBSONHeldPublishedAtField = "heldPublishedAt"
+)
+
+// legalHoldRecord is the structure that represents the legal hold of a file as it's stored.
+type legalHoldRecord struct {
+	ID       fileRecordID `bson:"_id"`
+	Reason   string       `bson:"reason,omitempty"`
+	PlacedBy string       `bson:"placedBy,omitempty"`
+	PlacedAt time.Time    `bson:"placedAt"`
+}
+
+// legalHold returns the service.LegalHold of r.
+func (r legalHoldRecord) legalHold() service.LegalHold {
+	return service.LegalHold{
+		ResourceType: r.ID.ResourceType,
+		FileID:       r.ID.FileID,
+		Reason:       r.Reason,
+		PlacedBy:     r.PlacedBy,
+		PlacedAt:     r.PlacedAt,
+	}
+}
+
+// SetLegalHold places the hold of hold's file, or replaces its hold, and returns it. The published events
+// of the file's permissions are moved from publishedAt to heldPublishedAt so that they don't expire.
+func (s MongoStore) SetLegalHold(ctx context.Context, hold service.LegalHold) (service.LegalHold, error) {
+	record := legalHoldRecord{
+		ID:       fileRecordID{ResourceType: hold.ResourceType, FileID: hold.FileID},
+		Reason:   hold.Reason,
+		PlacedBy: hold.PlacedBy,
+		PlacedAt: hold.PlacedAt,
+	}
+
+	err := s.inTransaction(ctx, func(ctx context.Context) error {
+		_, err := s.collection(LegalHoldCollectionName).ReplaceOne(
+			ctx,
+			fileRecordFilter(hold.ResourceType, hold.FileID),
+			record,
+			options.Replace().SetUpsert(true),
+		)
+		if err != nil {
+			return err
+		}
+
+		return s.renameEventsField(
+			ctx,
+			hold.ResourceType,
+			hold.FileID,
+			OutboxBSONPublishedAtField,
+			OutboxBSONHeldPublishedAtField,
+		)
+	})
+	if err != nil {
+		return service.LegalHold{}, err
+	}
+
+	return record.legalHold(), nil
+}
+
+// DeleteLegalHold releases the hold of fileID and returns it, fails with codes.NotFound if it isn't held.
+// The published events of the file's permissions are moved back to publishedAt, so that they expire
+// after the outbox's retention since they were published.
+func (s MongoStore) DeleteLegalHold(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+) (service.LegalHold, error) {
+	var record legalHoldRecord
+	err := s.inTransaction(ctx, func(ctx context.Context) error {
+		err := s.collection(LegalHoldCollectionName).
+			FindOneAndDelete(ctx, fileRecordFilter(resourceType, fileID)).
+			Decode(&record)
+		if err == mongo.ErrNoDocuments {
+			return status.Errorf(codes.NotFound, "%s %s is not under legal hold", resourceType, fileID)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		return s.renameEventsField(
+			ctx,
+			resourceType,
+			fileID,
+			OutboxBSONHeldPublishedAtField,
+			OutboxBSONPublishedAtField,
+		)
+	})
+	if err != nil {
+		return service.LegalHold{}, err
+	}
+
+	return record.legalHold(), nil
+}
+
+// GetLegalHolds returns the holds of the files of fileIDs that are held.
+func (s MongoStore) GetLegalHolds(
+	ctx context.Context,
+	resourceType string,
+	fileIDs []string,
+) ([]service.LegalHold, error) {
+	ids := make(bson.A, 0, len(fileIDs))
+	for _, fileID := range fileIDs {
+		ids = append(ids, fileRecordID{ResourceType: resourceType, FileID: fileID})
+	}
+
+	filter := bson.D{bson.E{Key: MongoObjectIDField, Value: bson.D{bson.E{Key: "$in", Value: ids}}}}
+	return s.findLegalHolds(ctx, filter)
+}
+
+// ListLegalHolds returns the holds of the files of resourceType, or of every type if it's empty.
+func (s MongoStore) ListLegalHolds(ctx context.Context, resourceType string) ([]service.LegalHold, error) {
+	filter := bson.D{}
+	if resourceType != "" {
+		filter = bson.D{bson.E{Key: MongoObjectIDField + ".resourceType", Value: resourceType}}
+	}
+
+	return s.findLegalHolds(ctx, filter)
+}
+
+// findLegalHolds returns the holds that match filter.
+func (s MongoStore) findLegalHolds(ctx context.Context, filter bson.D) ([]service.LegalHold, error) {
+	cur, err := s.collection(LegalHoldCollectionName).Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	holds := []service.LegalHold{}
+	for cur.Next(ctx) {
+		var record legalHoldRecord
+		if err := cur.Decode(&record); err != nil {
+			return nil, err
+		}
+
+		holds = append(holds, record.legalHold())
+	}
+
+	return holds, cur.Err()
+}
+
+// renameEventsField renames the from field of the events of the permissions of fileID in the outbox to to,
+// the events that don't have the field are left as is.
+func (s MongoStore) renameEventsField(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	from string,
+	to string,
+) error {
+	if !s.outbox {
+		return nil
+	}
+
+	filter := outboxResourceFilter(resourceType, fileID)
+	filter = append(filter, bson.E{Key: from, Value: bson.D{bson.E{Key: "$ne", Value: nil}}})
+	update := bson.D{bson.E{Key: "$rename", Value: bson.D{bson.E{Key: from, Value: to}}}}
+	_, err := s.collection(OutboxCollectionName).UpdateMany(ctx, filter, update)
+	return err
+}
+
+// outboxResourceFilter returns the filter of the outbox events of the permissions of fileID.
+func outboxResourceFilter(resourceType string, fileID string) bson.D {
+	filter := resourceFilter(resourceType, fileID)
+	for i := range filter {
+		filter[i].Key = OutboxBSONPermissionField + "." + filter[i].Key
+	}
+
+	return filter
+}
+
+// heldFiles returns the files that are under legal hold.
+func (s MongoStore) heldFiles(ctx context.Context) (map[fileRecordID]bool, error) {
+	holds, err := s.ListLegalHolds(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	held := make(map[fileRecordID]bool, len(holds))
+	for _, hold := range holds {
+		held[fileRecordID{ResourceType: hold.ResourceType, FileID: hold.FileID}] = true
+	}
+
+	return held, nil
+}
@@ -0,0 +1,161 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/meateam/permission-service/service"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// IdempotencyCollectionName is the name of the idempotency keys collection.
+	IdempotencyCollectionName = "idempotencyKeys"
+
+	// IdempotencyBSONPermissionIDField is the name of the permissionID field in the idempotency key BSON.
+	IdempotencyBSONPermissionIDField = "permissionID"
+
+	// IdempotencyBSONCreatedAtField is the name of the createdAt field in the idempotency key BSON.
+	IdempotencyBSONCreatedAtField = "createdAt"
+
+	// duplicateKeyErrorCode is the mongodb error code of a unique index violation.
+	duplicateKeyErrorCode = 11000
+
+	// idempotencyRetryDelay is the delay after which a request whose idempotency key is in progress
+	// should be retried, by when the request in progress has usually finished.
+	idempotencyRetryDelay = time.Second
+)
+
+// idempotencyRecord is the structure that represents an idempotency key as it's stored.
+type idempotencyRecord struct {
+	Key          string             `bson:"_id"`
+	ResourceType string             `bson:"resourceType"`
+	FileID       string             `bson:"fileID"`
+	UserID       string             `bson:"userID"`
+	PermissionID primitive.ObjectID `bson:"permissionID,omitempty"`
+	CreatedAt    time.Time          `bson:"createdAt"`
+}
+
+// createIdempotencyIndex creates the index that expires idempotency keys after window.
+func (s MongoStore) createIdempotencyIndex(ctx context.Context, window time.Duration) error {
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{
+			bson.E{
+				Key:   IdempotencyBSONCreatedAtField,
+				Value: 1,
+			},
+		},
+		Options: options.Index().SetExpireAfterSeconds(int32(window.Seconds())),
+	}
+
+	_, err := s.collection(IdempotencyCollectionName).Indexes().CreateOne(ctx, indexModel)
+	return err
+}
+
+// isDuplicateKeyError returns true if err is a unique index violation.
+func isDuplicateKeyError(err error) bool {
+	writeException, ok := err.(mongo.WriteException)
+	if !ok {
+		return false
+	}
+
+	for _, writeError := range writeException.WriteErrors {
+		if writeError.Code == duplicateKeyErrorCode {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ClaimIdempotencyKey claims key for creating the permission of userID to the resource
+// of resourceType with fileID.
+// If key was already used to create the permission then the ID of the created permission is returned,
+// otherwise an empty string is returned and the caller should create the permission.
+// Returns an error if key was used for a different permission, or if its permission is still being created.
+func (s MongoStore) ClaimIdempotencyKey(
+	ctx context.Context,
+	key string,
+	resourceType string,
+	fileID string,
+	userID string,
+) (string, error) {
+	collection := s.collection(IdempotencyCollectionName)
+	record := idempotencyRecord{
+		Key:          key,
+		ResourceType: resourceType,
+		FileID:       fileID,
+		UserID:       userID,
+		CreatedAt:    time.Now(),
+	}
+	_, err := collection.InsertOne(ctx, record)
+	if err == nil {
+		return "", nil
+	}
+
+	if !isDuplicateKeyError(err) {
+		return "", err
+	}
+
+	var existing idempotencyRecord
+	filter := bson.D{bson.E{Key: MongoObjectIDField, Value: key}}
+	if err := collection.FindOne(ctx, filter).Decode(&existing); err != nil {
+		return "", err
+	}
+
+	if existing.ResourceType != resourceType || existing.FileID != fileID || existing.UserID != userID {
+		return "", status.Errorf(codes.InvalidArgument, "idempotency key %s was used for a different permission", key)
+	}
+
+	if existing.PermissionID.IsZero() {
+		return "", service.RejectionError(
+			codes.Aborted,
+			service.Rejection{
+				Kind:       service.RejectionInvariant,
+				Rule:       "idempotency_key_in_progress",
+				Subject:    key,
+				RetryAfter: idempotencyRetryDelay,
+			},
+			"a request with idempotency key %s is in progress",
+			key,
+		)
+	}
+
+	return existing.PermissionID.Hex(), nil
+}
+
+// CompleteIdempotencyKey records that the permission with permissionID was created by key.
+func (s MongoStore) CompleteIdempotencyKey(ctx context.Context, key string, permissionID string) error {
+	objectID, err := primitive.ObjectIDFromHex(permissionID)
+	if err != nil {
+		return err
+	}
+
+	update := bson.D{
+		bson.E{
+			Key: "$set",
+			Value: bson.D{
+				bson.E{
+					Key:   IdempotencyBSONPermissionIDField,
+					Value: objectID,
+				},
+			},
+		},
+	}
+
+	collection := s.collection(IdempotencyCollectionName)
+	_, err = collection.UpdateOne(ctx, bson.D{bson.E{Key: MongoObjectIDField, Value: key}}, update)
+	return err
+}
+
+// ReleaseIdempotencyKey releases key so that a retry of a failed request may claim it.
+func (s MongoStore) ReleaseIdempotencyKey(ctx context.Context, key string) error {
+	collection := s.collection(IdempotencyCollectionName)
+	_, err := collection.DeleteOne(ctx, bson.D{bson.E{Key: MongoObjectIDField, Value: key}})
+	return err
+}
@@ -0,0 +1,139 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// permissionIndexModels returns the indexes that the permissions collection requires.
+func permissionIndexModels() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				bson.E{
+					Key:   PermissionBSONResourceTypeField,
+					Value: 1,
+				},
+				bson.E{
+					Key:   PermissionBSONFileIDField,
+					Value: 1,
+				},
+				bson.E{
+					Key:   PermissionBSONUserIDField,
+					Value: 1,
+				},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		// The sharing chain index finds the permissions that a user reshared.
+		{
+			Keys: bson.D{
+				bson.E{
+					Key:   PermissionBSONResourceTypeField,
+					Value: 1,
+				},
+				bson.E{
+					Key:   PermissionBSONFileIDField,
+					Value: 1,
+				},
+				bson.E{
+					Key:   PermissionBSONSharingChainField,
+					Value: 1,
+				},
+			},
+		},
+		// The grantee type index finds the permissions that were given to organizations.
+		{
+			Keys: bson.D{
+				bson.E{
+					Key:   PermissionBSONGranteeTypeField,
+					Value: 1,
+				},
+				bson.E{
+					Key:   PermissionBSONUserIDField,
+					Value: 1,
+				},
+			},
+		},
+		// The user index paginates the permissions of a user by their sort keys.
+		{
+			Keys: bson.D{
+				bson.E{
+					Key:   PermissionBSONResourceTypeField,
+					Value: 1,
+				},
+				bson.E{
+					Key:   PermissionBSONUserIDField,
+					Value: 1,
+				},
+				bson.E{
+					Key:   PermissionBSONLastAccessedAtField,
+					Value: -1,
+				},
+				bson.E{
+					Key:   MongoObjectIDField,
+					Value: 1,
+				},
+			},
+		},
+		// The labels index finds the permissions that have a label, whichever its key is.
+		{
+			Keys: bson.D{
+				bson.E{
+					Key:   PermissionBSONLabelsField + ".$**",
+					Value: 1,
+				},
+			},
+		},
+	}
+}
+
+// indexName returns the name that mongodb generates for an index of keys.
+func indexName(keys bson.D) string {
+	parts := make([]string, 0, len(keys)*2)
+	for _, key := range keys {
+		parts = append(parts, key.Key, fmt.Sprint(key.Value))
+	}
+
+	return strings.Join(parts, "_")
+}
+
+// VerifyIndexes verifies that the indexes that the permissions collection requires exist,
+// and creates the ones that are missing, such as after the collection was dropped.
+func (s MongoStore) VerifyIndexes(ctx context.Context) error {
+	indexes := s.collection(PermissionCollectionName).Indexes()
+	cursor, err := indexes.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	var existing []struct {
+		Name string `bson:"name"`
+	}
+	if err := cursor.All(ctx, &existing); err != nil {
+		return err
+	}
+
+	names := make(map[string]bool, len(existing))
+	for _, index := range existing {
+		names[index.Name] = true
+	}
+
+	for _, indexModel := range permissionIndexModels() {
+		name := indexName(indexModel.Keys.(bson.D))
+		if names[name] {
+			continue
+		}
+
+		if _, err := indexes.CreateOne(ctx, indexModel); err != nil {
+			return fmt.Errorf("failed creating missing index %s: %v", name, err)
+		}
+	}
+
+	return nil
+}
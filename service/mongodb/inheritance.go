@@ -0,0 +1,165 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/meateam/permission-service/service"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ReplaceInherited replaces the permissions of the files of subtree that are inherited from outside
+// of it with the permissions of parentID, or deletes them if parentID is empty, in a single transaction.
+// The permissions of parentID are inherited from the folder they're inherited from themselves, if any.
+// A file's permission of a user that's kept, such as a direct permission, is replaced by an inherited one
+// only if strategy prefers the inherited one. The events of the created and deleted permissions are written
+// to the outbox, if it's enabled, in the same transaction. Transactions require mongodb to be a replica set.
+func (s MongoStore) ReplaceInherited(
+	ctx context.Context,
+	resourceType string,
+	subtree []service.FileNode,
+	parentID string,
+	strategy service.MergeStrategy,
+) (service.InheritanceReport, error) {
+	if len(subtree) == 0 {
+		return service.InheritanceReport{}, nil
+	}
+
+	var report service.InheritanceReport
+	err := s.transaction(ctx, func(ctx context.Context) (err error) {
+		report, err = s.replaceInherited(ctx, resourceType, subtree, parentID, strategy)
+		return err
+	})
+	if err != nil {
+		return service.InheritanceReport{}, err
+	}
+
+	return report, nil
+}
+
+// replaceInherited replaces the inherited permissions of subtree in the transaction of ctx.
+func (s MongoStore) replaceInherited(
+	ctx context.Context,
+	resourceType string,
+	subtree []service.FileNode,
+	parentID string,
+	strategy service.MergeStrategy,
+) (service.InheritanceReport, error) {
+	var report service.InheritanceReport
+	inSubtree := make(map[string]bool, len(subtree))
+	fileIDs := make(bson.A, 0, len(subtree))
+	for _, node := range subtree {
+		inSubtree[node.FileID] = true
+		fileIDs = append(fileIDs, node.FileID)
+	}
+
+	current, err := s.find(ctx, bson.D{
+		resourceTypeFilter(resourceType),
+		bson.E{Key: PermissionBSONFileIDField, Value: bson.D{bson.E{Key: "$in", Value: fileIDs}}},
+	})
+	if err != nil {
+		return report, err
+	}
+
+	// The permissions that are inherited from folders in the subtree moved with it and are kept,
+	// unless strategy prefers the permissions inherited from parentID.
+	kept := make(map[permissionKey]*BSON, len(current))
+	staleIDs := make(bson.A, 0, len(current))
+	events := make([]interface{}, 0, len(current))
+	for _, permission := range current {
+		permission := permission.(*BSON)
+		if permission.InheritedFrom != "" && !inSubtree[permission.InheritedFrom] {
+			staleIDs = append(staleIDs, permission.ID)
+			events = append(events, newOutboxRecord(service.EventDeleted, permission))
+			continue
+		}
+
+		key := permissionKey{resourceType: resourceType, fileID: permission.FileID, userID: permission.UserID}
+		kept[key] = permission
+	}
+
+	var parentPermissions []service.Permission
+	if parentID != "" {
+		if parentPermissions, err = s.find(ctx, resourceFilter(resourceType, parentID)); err != nil {
+			return report, err
+		}
+	}
+
+	inherited := make([]interface{}, 0, len(subtree)*len(parentPermissions))
+	for _, node := range subtree {
+		for _, parentPermission := range parentPermissions {
+			parentPermission := parentPermission.(*BSON)
+			key := permissionKey{resourceType: resourceType, fileID: node.FileID, userID: parentPermission.UserID}
+			if keptPermission, ok := kept[key]; ok {
+				if strategy.Resolve([]service.Grant{grantOf(keptPermission), grantOf(parentPermission)}) == 0 {
+					continue
+				}
+
+				staleIDs = append(staleIDs, keptPermission.ID)
+				events = append(events, newOutboxRecord(service.EventDeleted, keptPermission))
+			}
+
+			permission := inheritPermission(parentPermission, node)
+			inherited = append(inherited, permission)
+			events = append(events, eventRecords(service.EventCreated, permission)...)
+		}
+	}
+
+	collection := s.collection(PermissionCollectionName)
+	if len(staleIDs) > 0 {
+		result, err := collection.DeleteMany(ctx, bson.D{
+			bson.E{Key: MongoObjectIDField, Value: bson.D{bson.E{Key: "$in", Value: staleIDs}}},
+		})
+		if err != nil {
+			return report, err
+		}
+
+		report.Deleted = result.DeletedCount
+	}
+
+	if len(inherited) > 0 {
+		result, err := collection.InsertMany(ctx, inherited)
+		if err != nil {
+			return report, err
+		}
+
+		report.Created = int64(len(result.InsertedIDs))
+	}
+
+	if s.outbox && len(events) > 0 {
+		if err := s.writeEvents(ctx, events); err != nil {
+			return report, err
+		}
+	}
+
+	return report, nil
+}
+
+// grantOf returns the grant of permission.
+func grantOf(permission *BSON) service.Grant {
+	return service.Grant{Role: permission.Role, Inherited: permission.InheritedFrom != ""}
+}
+
+// inheritPermission returns the permission of node that's inherited from parentPermission,
+// the permission of the folder that node was moved into.
+func inheritPermission(parentPermission *BSON, node service.FileNode) *BSON {
+	inheritedFrom := parentPermission.InheritedFrom
+	if inheritedFrom == "" {
+		inheritedFrom = parentPermission.FileID
+	}
+
+	resourceKind := node.ResourceKind
+	if resourceKind == "" {
+		resourceKind = service.ResourceKindFile
+	}
+
+	permission := *parentPermission
+	permission.ID = primitive.NewObjectID()
+	permission.FileID = node.FileID
+	permission.ResourceKind = resourceKind
+	permission.LastAccessedAt = time.Time{}
+	permission.Version = 0
+	permission.InheritedFrom = inheritedFrom
+	return &permission
+}
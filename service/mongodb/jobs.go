@@ -0,0 +1,344 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/meateam/permission-service/service"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// JobCollectionName is the name of the collection of the jobs of bulk operations.
+	JobCollectionName = "jobs"
+
+	// RecurringJobCollectionName is the name of the collection of the last runs of the recurring jobs.
+	RecurringJobCollectionName = "recurringJobs"
+
+	// RecurringJobBSONLastRunAtField is the name of the lastRunAt field in the recurring job BSON.
+	RecurringJobBSONLastRunAtField = "lastRunAt"
+
+	// JobBSONKindField is the name of the kind field in the job BSON.
+	JobBSONKindField = "kind"
+
+	// JobBSONStateField is the name of the state field in the job BSON.
+	JobBSONStateField = "state"
+
+	// JobBSONProgressField is the name of the progress field in the job BSON.
+	JobBSONProgressField = "progress"
+
+	// JobBSONErrorField is the name of the error field in the job BSON.
+	JobBSONErrorField = "error"
+
+	// JobBSONCancelRequestedField is the name of the cancelRequested field in the job BSON.
+	JobBSONCancelRequestedField = "cancelRequested"
+
+	// JobBSONUpdatedAtField is the name of the updatedAt field in the job BSON.
+	JobBSONUpdatedAtField = "updatedAt"
+
+	// JobBSONFinishedAtField is the name of the finishedAt field in the job BSON.
+	JobBSONFinishedAtField = "finishedAt"
+)
+
+// jobProgressRecord is the structure that represents the progress of a job as it's stored.
+type jobProgressRecord struct {
+	Done   int64 `bson:"done"`
+	Failed int64 `bson:"failed"`
+	Total  int64 `bson:"total"`
+}
+
+// jobRecord is the structure that represents a job as it's stored.
+type jobRecord struct {
+	ID              primitive.ObjectID `bson:"_id"`
+	Kind            string             `bson:"kind"`
+	State           string             `bson:"state"`
+	Progress        jobProgressRecord  `bson:"progress"`
+	Error           string             `bson:"error,omitempty"`
+	Creator         string             `bson:"creator,omitempty"`
+	CancelRequested bool               `bson:"cancelRequested,omitempty"`
+	CreatedAt       time.Time          `bson:"createdAt"`
+	UpdatedAt       time.Time          `bson:"updatedAt"`
+	FinishedAt      *time.Time         `bson:"finishedAt,omitempty"`
+}
+
+// job returns the service.Job of r.
+func (r jobRecord) job() service.Job {
+	return service.Job{
+		ID:    r.ID.Hex(),
+		Kind:  r.Kind,
+		State: service.JobState(r.State),
+		Progress: service.JobProgress{
+			Done:   r.Progress.Done,
+			Failed: r.Progress.Failed,
+			Total:  r.Progress.Total,
+		},
+		Error:           r.Error,
+		Creator:         r.Creator,
+		CancelRequested: r.CancelRequested,
+		CreatedAt:       r.CreatedAt,
+		UpdatedAt:       r.UpdatedAt,
+		FinishedAt:      r.FinishedAt,
+	}
+}
+
+// progressRecord returns the jobProgressRecord of progress.
+func progressRecord(progress service.JobProgress) jobProgressRecord {
+	return jobProgressRecord{Done: progress.Done, Failed: progress.Failed, Total: progress.Total}
+}
+
+// createJobIndexes creates the indexes that list the jobs of a kind, and find the stale running jobs.
+func (s MongoStore) createJobIndexes(ctx context.Context) error {
+	_, err := s.collection(JobCollectionName).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				bson.E{Key: JobBSONKindField, Value: 1},
+				bson.E{Key: MongoObjectIDField, Value: -1},
+			},
+		},
+		{
+			Keys: bson.D{
+				bson.E{Key: JobBSONStateField, Value: 1},
+				bson.E{Key: JobBSONUpdatedAtField, Value: 1},
+			},
+		},
+	})
+
+	return err
+}
+
+// CreateJob stores job and returns it with its ID.
+func (s MongoStore) CreateJob(ctx context.Context, job service.Job) (service.Job, error) {
+	record := jobRecord{
+		ID:        primitive.NewObjectID(),
+		Kind:      job.Kind,
+		State:     string(job.State),
+		Progress:  progressRecord(job.Progress),
+		Creator:   job.Creator,
+		CreatedAt: job.CreatedAt,
+		UpdatedAt: job.UpdatedAt,
+	}
+
+	if _, err := s.collection(JobCollectionName).InsertOne(ctx, record); err != nil {
+		return service.Job{}, err
+	}
+
+	return record.job(), nil
+}
+
+// GetJob returns the job with id, fails with codes.NotFound if it doesn't exist.
+func (s MongoStore) GetJob(ctx context.Context, id string) (service.Job, error) {
+	filter, err := idFilter(id)
+	if err != nil {
+		return service.Job{}, status.Errorf(codes.NotFound, "job %s not found", id)
+	}
+
+	var record jobRecord
+	err = s.collection(JobCollectionName).FindOne(ctx, filter).Decode(&record)
+	if err == mongo.ErrNoDocuments {
+		return service.Job{}, status.Errorf(codes.NotFound, "job %s not found", id)
+	}
+
+	if err != nil {
+		return service.Job{}, err
+	}
+
+	return record.job(), nil
+}
+
+// ListJobs returns up to pageSize jobs of kind, or of every kind if it's empty, that come after pageToken,
+// newest first, and the token of the next page, which is empty if there are no more pages.
+func (s MongoStore) ListJobs(
+	ctx context.Context,
+	kind string,
+	pageSize int,
+	pageToken string,
+) ([]service.Job, string, error) {
+	filter := bson.D{}
+	if kind != "" {
+		filter = append(filter, bson.E{Key: JobBSONKindField, Value: kind})
+	}
+
+	if pageToken != "" {
+		lastID, err := decodePageToken(pageToken)
+		if err != nil {
+			return nil, "", err
+		}
+
+		filter = append(filter, bson.E{Key: MongoObjectIDField, Value: bson.D{bson.E{Key: "$lt", Value: lastID}}})
+	}
+
+	// Fetch one more job than needed to know whether there's a next page.
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: MongoObjectIDField, Value: -1}}).
+		SetLimit(int64(pageSize) + 1)
+
+	cur, err := s.collection(JobCollectionName).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, "", err
+	}
+
+	defer cur.Close(ctx)
+
+	jobs := []service.Job{}
+	for cur.Next(ctx) {
+		var record jobRecord
+		if err := cur.Decode(&record); err != nil {
+			return nil, "", err
+		}
+
+		jobs = append(jobs, record.job())
+	}
+
+	if err := cur.Err(); err != nil {
+		return nil, "", err
+	}
+
+	if len(jobs) <= pageSize {
+		return jobs, "", nil
+	}
+
+	jobs = jobs[:pageSize]
+	return jobs, encodePageToken(jobs[pageSize-1].ID), nil
+}
+
+// UpdateJobProgress sets the progress of the running job with id, at updatedAt, and returns it.
+func (s MongoStore) UpdateJobProgress(
+	ctx context.Context,
+	id string,
+	progress service.JobProgress,
+	updatedAt time.Time,
+) (service.Job, error) {
+	return s.updateRunningJob(ctx, id, bson.D{
+		bson.E{Key: JobBSONProgressField, Value: progressRecord(progress)},
+		bson.E{Key: JobBSONUpdatedAtField, Value: updatedAt},
+	})
+}
+
+// FinishJob sets the state of the running job with id to state, with progress, and message if it failed,
+// at finishedAt, and returns it.
+func (s MongoStore) FinishJob(
+	ctx context.Context,
+	id string,
+	state service.JobState,
+	progress service.JobProgress,
+	message string,
+	finishedAt time.Time,
+) (service.Job, error) {
+	return s.updateRunningJob(ctx, id, bson.D{
+		bson.E{Key: JobBSONStateField, Value: string(state)},
+		bson.E{Key: JobBSONProgressField, Value: progressRecord(progress)},
+		bson.E{Key: JobBSONErrorField, Value: message},
+		bson.E{Key: JobBSONUpdatedAtField, Value: finishedAt},
+		bson.E{Key: JobBSONFinishedAtField, Value: finishedAt},
+	})
+}
+
+// CancelJob requests the cancellation of the running job with id and returns it,
+// fails with codes.FailedPrecondition if the job already finished.
+func (s MongoStore) CancelJob(ctx context.Context, id string) (service.Job, error) {
+	return s.updateRunningJob(ctx, id, bson.D{bson.E{Key: JobBSONCancelRequestedField, Value: true}})
+}
+
+// updateRunningJob sets the fields of set of the running job with id and returns it. Fails with
+// codes.FailedPrecondition if the job already finished, or with codes.NotFound if it doesn't exist.
+func (s MongoStore) updateRunningJob(ctx context.Context, id string, set bson.D) (service.Job, error) {
+	filter, err := idFilter(id)
+	if err != nil {
+		return service.Job{}, status.Errorf(codes.NotFound, "job %s not found", id)
+	}
+
+	runningFilter := append(filter, bson.E{Key: JobBSONStateField, Value: string(service.JobRunning)})
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var record jobRecord
+	err = s.collection(JobCollectionName).
+		FindOneAndUpdate(ctx, runningFilter, bson.D{bson.E{Key: "$set", Value: set}}, opts).
+		Decode(&record)
+	if err == mongo.ErrNoDocuments {
+		job, err := s.GetJob(ctx, id)
+		if err != nil {
+			return service.Job{}, err
+		}
+
+		return service.Job{}, status.Errorf(codes.FailedPrecondition, "job %s is already %s", id, job.State)
+	}
+
+	if err != nil {
+		return service.Job{}, err
+	}
+
+	return record.job(), nil
+}
+
+// FailStaleJobs fails the running jobs that weren't updated since staleBefore with message,
+// and returns their number.
+func (s MongoStore) FailStaleJobs(ctx context.Context, staleBefore time.Time, message string) (int64, error) {
+	filter := bson.D{
+		bson.E{Key: JobBSONStateField, Value: string(service.JobRunning)},
+		bson.E{Key: JobBSONUpdatedAtField, Value: bson.D{bson.E{Key: "$lt", Value: staleBefore}}},
+	}
+
+	now := time.Now()
+	update := bson.D{
+		bson.E{
+			Key: "$set",
+			Value: bson.D{
+				bson.E{Key: JobBSONStateField, Value: string(service.JobFailed)},
+				bson.E{Key: JobBSONErrorField, Value: message},
+				bson.E{Key: JobBSONUpdatedAtField, Value: now},
+				bson.E{Key: JobBSONFinishedAtField, Value: now},
+			},
+		},
+	}
+
+	res, err := s.collection(JobCollectionName).UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.ModifiedCount, nil
+}
+
+// PurgeJobs deletes the jobs that finished before finishedBefore and returns their number.
+func (s MongoStore) PurgeJobs(ctx context.Context, finishedBefore time.Time) (int64, error) {
+	filter := bson.D{
+		bson.E{Key: JobBSONFinishedAtField, Value: bson.D{bson.E{Key: "$lt", Value: finishedBefore}}},
+	}
+
+	res, err := s.collection(JobCollectionName).DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.DeletedCount, nil
+}
+
+// ClaimRecurringRun claims the run of the recurring job of kind at runAt, and returns true if it was
+// claimed, or false if a run at or after runAt was already claimed. The record of kind is created by
+// the first claim, so concurrent first claims are resolved by the uniqueness of its ID.
+func (s MongoStore) ClaimRecurringRun(ctx context.Context, kind string, runAt time.Time) (bool, error) {
+	filter := bson.D{
+		bson.E{Key: MongoObjectIDField, Value: kind},
+		bson.E{Key: RecurringJobBSONLastRunAtField, Value: bson.D{bson.E{Key: "$lt", Value: runAt}}},
+	}
+
+	update := bson.D{
+		bson.E{Key: "$set", Value: bson.D{bson.E{Key: RecurringJobBSONLastRunAtField, Value: runAt}}},
+	}
+
+	opts := options.Update().SetUpsert(true)
+	_, err := s.collection(RecurringJobCollectionName).UpdateOne(ctx, filter, update, opts)
+	if isDuplicateKeyError(err) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,74 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// LeaseCollectionName is the name of the collection of the leases of the singleton background workers.
+	LeaseCollectionName = "leases"
+
+	// LeaseBSONHolderField is the name of the holder field in the lease BSON.
+	LeaseBSONHolderField = "holder"
+
+	// LeaseBSONExpiresAtField is the name of the expiresAt field in the lease BSON.
+	LeaseBSONExpiresAtField = "expiresAt"
+)
+
+// AcquireLease acquires the lease of name for holder until expiresAt, or renews it if holder already holds it,
+// and returns true if holder holds it, or false if it's held by another holder whose lease hasn't expired at now.
+// The record of name is created by the first acquisition, so concurrent acquisitions of an expired or missing
+// lease are resolved by the uniqueness of its ID.
+func (s MongoStore) AcquireLease(
+	ctx context.Context,
+	name string,
+	holder string,
+	now time.Time,
+	expiresAt time.Time,
+) (bool, error) {
+	filter := bson.D{
+		bson.E{Key: MongoObjectIDField, Value: name},
+		bson.E{Key: "$or", Value: bson.A{
+			bson.D{bson.E{Key: LeaseBSONHolderField, Value: holder}},
+			bson.D{bson.E{Key: LeaseBSONExpiresAtField, Value: bson.D{bson.E{Key: "$lt", Value: now}}}},
+		}},
+	}
+
+	update := bson.D{
+		bson.E{
+			Key: "$set",
+			Value: bson.D{
+				bson.E{Key: LeaseBSONHolderField, Value: holder},
+				bson.E{Key: LeaseBSONExpiresAtField, Value: expiresAt},
+			},
+		},
+	}
+
+	opts := options.Update().SetUpsert(true)
+	_, err := s.collection(LeaseCollectionName).UpdateOne(ctx, filter, update, opts)
+	if isDuplicateKeyError(err) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ReleaseLease releases the lease of name if it's held by holder, so that another holder may acquire it
+// without waiting for it to expire.
+func (s MongoStore) ReleaseLease(ctx context.Context, name string, holder string) error {
+	filter := bson.D{
+		bson.E{Key: MongoObjectIDField, Value: name},
+		bson.E{Key: LeaseBSONHolderField, Value: holder},
+	}
+
+	_, err := s.collection(LeaseCollectionName).DeleteOne(ctx, filter)
+	return err
+}
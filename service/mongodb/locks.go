@@ -0,0 +1,115 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/meateam/permission-service/service"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FileLockCollectionName is the name of the collection of the lockdowns of files.
+const FileLockCollectionName = "fileLocks"
+
+// fileRecordID is the ID of a record of a file, such as its lock, of which a file has at most one.
+type fileRecordID struct {
+	ResourceType string `bson:"resourceType"`
+	FileID       string `bson:"fileID"`
+}
+
+// fileLockRecord is the structure that represents the lock of a file as it's stored.
+type fileLockRecord struct {
+	ID       fileRecordID `bson:"_id"`
+	Owner    string       `bson:"owner"`
+	Reason   string       `bson:"reason,omitempty"`
+	LockedBy string       `bson:"lockedBy,omitempty"`
+	LockedAt time.Time    `bson:"lockedAt"`
+}
+
+// fileLock returns the service.FileLock of r.
+func (r fileLockRecord) fileLock() service.FileLock {
+	return service.FileLock{
+		ResourceType: r.ID.ResourceType,
+		FileID:       r.ID.FileID,
+		Owner:        r.Owner,
+		Reason:       r.Reason,
+		LockedBy:     r.LockedBy,
+		LockedAt:     r.LockedAt,
+	}
+}
+
+// fileRecordFilter returns the filter of the record of fileID, such as its lock.
+func fileRecordFilter(resourceType string, fileID string) bson.D {
+	return bson.D{bson.E{Key: "_id", Value: fileRecordID{ResourceType: resourceType, FileID: fileID}}}
+}
+
+// SetFileLock locks the file of lock, or replaces its lock, and returns it.
+func (s MongoStore) SetFileLock(ctx context.Context, lock service.FileLock) (service.FileLock, error) {
+	record := fileLockRecord{
+		ID:       fileRecordID{ResourceType: lock.ResourceType, FileID: lock.FileID},
+		Owner:    lock.Owner,
+		Reason:   lock.Reason,
+		LockedBy: lock.LockedBy,
+		LockedAt: lock.LockedAt,
+	}
+
+	_, err := s.collection(FileLockCollectionName).ReplaceOne(
+		ctx,
+		fileRecordFilter(lock.ResourceType, lock.FileID),
+		record,
+		options.Replace().SetUpsert(true),
+	)
+	if err != nil {
+		return service.FileLock{}, err
+	}
+
+	return record.fileLock(), nil
+}
+
+// DeleteFileLock unlocks fileID and returns its lock, fails with codes.NotFound if it isn't locked.
+func (s MongoStore) DeleteFileLock(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+) (service.FileLock, error) {
+	var record fileLockRecord
+	err := s.collection(FileLockCollectionName).
+		FindOneAndDelete(ctx, fileRecordFilter(resourceType, fileID)).
+		Decode(&record)
+	if err == mongo.ErrNoDocuments {
+		return service.FileLock{}, status.Errorf(codes.NotFound, "%s %s is not locked", resourceType, fileID)
+	}
+
+	if err != nil {
+		return service.FileLock{}, err
+	}
+
+	return record.fileLock(), nil
+}
+
+// GetFileLock returns the lock of fileID, or nil if it isn't locked. The lock is read from the primary
+// so that a lockdown takes effect as soon as it's set.
+func (s MongoStore) GetFileLock(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+) (*service.FileLock, error) {
+	var record fileLockRecord
+	err := s.collection(FileLockCollectionName).
+		FindOne(ctx, fileRecordFilter(resourceType, fileID)).
+		Decode(&record)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, unavailableError(err)
+	}
+
+	lock := record.fileLock()
+	return &lock, nil
+}
@@ -0,0 +1,120 @@
+package mongodb
+
+import (
+	"context"
+
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/meateam/permission-service/service"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MigrateRole changes the role of the permissions that match filter from fromRole to toRole,
+// batchSize permissions at a time, and calls progress after each batch with the number of
+// permissions migrated so far and the number of permissions that matched when the migration started.
+// The migration stops if progress returns an error. Migrating again would continue where it stopped.
+// Migrations don't write events to the outbox, consumers should be notified of them separately.
+func (s MongoStore) MigrateRole(
+	ctx context.Context,
+	fromRole pb.Role,
+	toRole pb.Role,
+	filter service.PermissionsFilter,
+	batchSize int,
+	progress func(migrated int64, total int64) error,
+) error {
+	// The migrated batches are read from the primary so that they aren't migrated twice.
+	s = s.primary()
+	matchFilter := append(permissionsFilter(filter), bson.E{Key: PermissionBSONRoleField, Value: fromRole})
+	total, err := s.count(ctx, matchFilter)
+	if err != nil {
+		return err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: MongoObjectIDField, Value: 1}}).
+		SetLimit(int64(batchSize)).
+		SetProjection(bson.D{bson.E{Key: MongoObjectIDField, Value: 1}})
+
+	var migrated int64
+	var lastID primitive.ObjectID
+	for {
+		batchFilter := matchFilter
+		if !lastID.IsZero() {
+			batchFilter = append(batchFilter, bson.E{
+				Key:   MongoObjectIDField,
+				Value: bson.D{bson.E{Key: "$gt", Value: lastID}},
+			})
+		}
+
+		batch, err := s.find(ctx, batchFilter, opts)
+		if err != nil {
+			return err
+		}
+
+		if len(batch) == 0 {
+			return nil
+		}
+
+		batchIDs := make(bson.A, 0, len(batch))
+		for _, permission := range batch {
+			if lastID, err = primitive.ObjectIDFromHex(permission.GetID()); err != nil {
+				return err
+			}
+
+			batchIDs = append(batchIDs, lastID)
+		}
+
+		// Match the role again in case the permission was changed since the batch was read.
+		updateFilter := bson.D{
+			bson.E{Key: MongoObjectIDField, Value: bson.D{bson.E{Key: "$in", Value: batchIDs}}},
+			bson.E{Key: PermissionBSONRoleField, Value: fromRole},
+		}
+
+		update := bson.D{
+			bson.E{
+				Key:   "$set",
+				Value: bson.D{bson.E{Key: PermissionBSONRoleField, Value: toRole}},
+			},
+			incVersion,
+		}
+
+		modified, err := s.updateMany(ctx, updateFilter, update)
+		if err != nil {
+			return err
+		}
+
+		migrated += modified
+		if err := progress(migrated, total); err != nil {
+			return err
+		}
+	}
+}
+
+// permissionsFilter returns the mongodb filter of filter.
+func permissionsFilter(filter service.PermissionsFilter) bson.D {
+	mongoFilter := bson.D{}
+	if filter.ResourceType != "" {
+		mongoFilter = append(mongoFilter, resourceTypeFilter(filter.ResourceType))
+	}
+	if len(filter.FileIDs) > 0 {
+		mongoFilter = append(mongoFilter, bson.E{
+			Key:   PermissionBSONFileIDField,
+			Value: bson.D{bson.E{Key: "$in", Value: filter.FileIDs}},
+		})
+	}
+
+	if len(filter.UserIDs) > 0 {
+		mongoFilter = append(mongoFilter, bson.E{
+			Key:   PermissionBSONUserIDField,
+			Value: bson.D{bson.E{Key: "$in", Value: filter.UserIDs}},
+		})
+	}
+
+	if filter.Creator != "" {
+		mongoFilter = append(mongoFilter, bson.E{Key: PermissionBSONCreatorField, Value: filter.Creator})
+	}
+
+	selector := service.PermissionSelector{Labels: filter.Labels, Source: filter.Source}
+	return append(mongoFilter, selectorFilter(selector)...)
+}
@@ -2,18 +2,58 @@ package mongodb
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	pb "github.com/meateam/permission-service/proto"
+	"github.com/meateam/permission-service/service"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // BSON is the structure that represents a permission as it's stored.
 type BSON struct {
-	ID      primitive.ObjectID `bson:"_id,omitempty"`
-	FileID  string             `bson:"fileID,omitempty"`
-	UserID  string             `bson:"userID,omitempty"`
-	Role    pb.Role            `bson:"role"`
-	Creator string             `bson:"creator"`
+	ID           primitive.ObjectID `bson:"_id,omitempty"`
+	ResourceType string             `bson:"resourceType,omitempty"`
+	FileID       string             `bson:"fileID,omitempty"`
+	UserID       string             `bson:"userID,omitempty"`
+	Role         pb.Role            `bson:"role"`
+	Creator      string             `bson:"creator"`
+
+	// CanReshare is a pointer so permissions stored before it was introduced
+	// would be treated as reshareable.
+	CanReshare *bool  `bson:"canReshare,omitempty"`
+	Message    string `bson:"message,omitempty"`
+	Label      string `bson:"label,omitempty"`
+
+	LastAccessedAt time.Time `bson:"lastAccessedAt,omitempty"`
+
+	// Version is incremented whenever the permission is modified.
+	Version int64 `bson:"version,omitempty"`
+
+	// SharingChain is the users through which the file was shared to the user,
+	// starting from the user that shared it first and ending with the creator.
+	SharingChain []string `bson:"sharingChain,omitempty"`
+
+	// ResourceKind is the kind of the resource, permissions stored before it was introduced are to files.
+	ResourceKind string `bson:"resourceKind,omitempty"`
+
+	// GranteeType is the type of the grantee, permissions stored before it was introduced are to users.
+	GranteeType string `bson:"granteeType,omitempty"`
+
+	// Labels are the key-value labels of the permission, which are queried by their keys.
+	Labels map[string]string `bson:"labels,omitempty"`
+
+	// Source is the system that created the permission, it's empty for permissions stored before it was introduced.
+	Source string `bson:"source,omitempty"`
+
+	// InheritedFrom is the ID of the folder that the permission is inherited from,
+	// it's empty if the permission was given to the file directly.
+	InheritedFrom string `bson:"inheritedFrom,omitempty"`
+
+	// SchemaVersion is the version of the shape of the document, permissions stored before it was introduced
+	// are of version 0. Documents of older versions are upgraded to CurrentSchemaVersion when they're read.
+	SchemaVersion int `bson:"schemaVersion,omitempty"`
 }
 
 // GetID returns the string value of the b.ID.
@@ -40,6 +80,29 @@ func (b *BSON) SetID(id string) error {
 	return nil
 }
 
+// GetResourceType returns b.ResourceType, defaults to service.DefaultResourceType if not set.
+func (b BSON) GetResourceType() string {
+	if b.ResourceType == "" {
+		return service.DefaultResourceType
+	}
+
+	return b.ResourceType
+}
+
+// SetResourceType sets b.ResourceType to resourceType.
+func (b *BSON) SetResourceType(resourceType string) error {
+	if b == nil {
+		panic("b == nil")
+	}
+
+	if resourceType == "" {
+		return fmt.Errorf("ResourceType is required")
+	}
+
+	b.ResourceType = resourceType
+	return nil
+}
+
 // GetFileID returns b.FileID.
 func (b BSON) GetFileID() string {
 	return b.FileID
@@ -116,13 +179,244 @@ func (b *BSON) SetCreator(creator string) error {
 	return nil
 }
 
+// GetCanReshare returns b.CanReshare, defaults to true if not set.
+func (b BSON) GetCanReshare() bool {
+	if b.CanReshare == nil {
+		return true
+	}
+
+	return *b.CanReshare
+}
+
+// SetCanReshare sets b.CanReshare to canReshare.
+func (b *BSON) SetCanReshare(canReshare bool) error {
+	if b == nil {
+		panic("b == nil")
+	}
+
+	b.CanReshare = &canReshare
+	return nil
+}
+
+// GetMessage returns b.Message.
+func (b BSON) GetMessage() string {
+	return b.Message
+}
+
+// SetMessage sets b.Message to message.
+func (b *BSON) SetMessage(message string) error {
+	if b == nil {
+		panic("b == nil")
+	}
+
+	b.Message = message
+	return nil
+}
+
+// GetLabel returns b.Label.
+func (b BSON) GetLabel() string {
+	return b.Label
+}
+
+// SetLabel sets b.Label to label.
+func (b *BSON) SetLabel(label string) error {
+	if b == nil {
+		panic("b == nil")
+	}
+
+	b.Label = label
+	return nil
+}
+
+// GetLabels returns b.Labels.
+func (b BSON) GetLabels() map[string]string {
+	return b.Labels
+}
+
+// SetLabels sets b.Labels to labels.
+func (b *BSON) SetLabels(labels map[string]string) error {
+	if b == nil {
+		panic("b == nil")
+	}
+
+	b.Labels = labels
+	return nil
+}
+
+// GetSource returns b.Source.
+func (b BSON) GetSource() string {
+	return b.Source
+}
+
+// SetSource sets b.Source to source.
+func (b *BSON) SetSource(source string) error {
+	if b == nil {
+		panic("b == nil")
+	}
+
+	b.Source = source
+	return nil
+}
+
+// GetInheritedFrom returns b.InheritedFrom.
+func (b BSON) GetInheritedFrom() string {
+	return b.InheritedFrom
+}
+
+// SetInheritedFrom sets b.InheritedFrom to inheritedFrom.
+func (b *BSON) SetInheritedFrom(inheritedFrom string) error {
+	if b == nil {
+		panic("b == nil")
+	}
+
+	b.InheritedFrom = inheritedFrom
+	return nil
+}
+
+// GetLastAccessedAt returns b.LastAccessedAt.
+func (b BSON) GetLastAccessedAt() time.Time {
+	return b.LastAccessedAt
+}
+
+// SetLastAccessedAt sets b.LastAccessedAt to lastAccessedAt.
+func (b *BSON) SetLastAccessedAt(lastAccessedAt time.Time) error {
+	if b == nil {
+		panic("b == nil")
+	}
+
+	b.LastAccessedAt = lastAccessedAt
+	return nil
+}
+
+// GetSharingChain returns b.SharingChain.
+func (b BSON) GetSharingChain() []string {
+	return b.SharingChain
+}
+
+// SetSharingChain sets b.SharingChain to sharingChain.
+func (b *BSON) SetSharingChain(sharingChain []string) error {
+	if b == nil {
+		panic("b == nil")
+	}
+
+	b.SharingChain = sharingChain
+	return nil
+}
+
+// GetResourceKind returns b.ResourceKind, defaults to service.ResourceKindFile if not set.
+func (b BSON) GetResourceKind() string {
+	if b.ResourceKind == "" {
+		return service.ResourceKindFile
+	}
+
+	return b.ResourceKind
+}
+
+// SetResourceKind sets b.ResourceKind to resourceKind.
+func (b *BSON) SetResourceKind(resourceKind string) error {
+	if b == nil {
+		panic("b == nil")
+	}
+
+	if resourceKind == "" {
+		return fmt.Errorf("ResourceKind is required")
+	}
+
+	b.ResourceKind = resourceKind
+	return nil
+}
+
+// GetGranteeType returns b.GranteeType, defaults to service.GranteeTypeUser if not set.
+func (b BSON) GetGranteeType() string {
+	if b.GranteeType == "" {
+		return service.GranteeTypeUser
+	}
+
+	return b.GranteeType
+}
+
+// SetGranteeType sets b.GranteeType to granteeType.
+func (b *BSON) SetGranteeType(granteeType string) error {
+	if b == nil {
+		panic("b == nil")
+	}
+
+	if granteeType == "" {
+		return fmt.Errorf("GranteeType is required")
+	}
+
+	b.GranteeType = granteeType
+	return nil
+}
+
+// GetETag returns the etag of the permission, which is made of b.ID and b.Version.
+func (b BSON) GetETag() string {
+	if b.ID.IsZero() {
+		return ""
+	}
+
+	return formatETag(b.ID, b.Version)
+}
+
+// GetCreatedAt returns the time b was created at, which is the time of b.ID.
+func (b BSON) GetCreatedAt() time.Time {
+	if b.ID.IsZero() {
+		return time.Time{}
+	}
+
+	return b.ID.Timestamp()
+}
+
+// formatETag returns the etag of the permission with id at version.
+func formatETag(id primitive.ObjectID, version int64) string {
+	return fmt.Sprintf("%s-%d", id.Hex(), version)
+}
+
+// parseETag parses etag and returns the ID and version of the permission it was made of.
+func parseETag(etag string) (primitive.ObjectID, int64, error) {
+	separator := strings.LastIndex(etag, "-")
+	if separator == -1 {
+		return primitive.ObjectID{}, 0, fmt.Errorf("invalid etag %s", etag)
+	}
+
+	id, err := primitive.ObjectIDFromHex(etag[:separator])
+	if err != nil {
+		return primitive.ObjectID{}, 0, fmt.Errorf("invalid etag %s: %v", etag, err)
+	}
+
+	version, err := strconv.ParseInt(etag[separator+1:], 10, 64)
+	if err != nil {
+		return primitive.ObjectID{}, 0, fmt.Errorf("invalid etag %s: %v", etag, err)
+	}
+
+	return id, version, nil
+}
+
 // MarshalProto marshals b into a permission.
 func (b BSON) MarshalProto(permission *pb.PermissionObject) error {
+	lastAccessedAt, err := service.TimestampProto(b.GetLastAccessedAt())
+	if err != nil {
+		return err
+	}
+
 	permission.Id = b.GetID()
 	permission.FileID = b.GetFileID()
 	permission.UserID = b.GetUserID()
 	permission.Role = b.GetRole()
 	permission.Creator = b.GetCreator()
+	permission.CanReshare = b.GetCanReshare()
+	permission.Message = b.GetMessage()
+	permission.Label = b.GetLabel()
+	permission.LastAccessedAt = lastAccessedAt
+	permission.Etag = b.GetETag()
+	permission.ResourceType = b.GetResourceType()
+	permission.SharingChain = b.GetSharingChain()
+	permission.ResourceKind = b.GetResourceKind()
+	permission.Capabilities = service.Capabilities(b.GetResourceKind(), b.GetRole())
+	permission.GranteeType = b.GetGranteeType()
+	permission.Labels = b.GetLabels()
+	permission.Source = b.GetSource()
+	permission.InheritedFrom = b.GetInheritedFrom()
 
 	return nil
 }
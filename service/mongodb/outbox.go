@@ -0,0 +1,552 @@
+package mongodb
+
+import (
+	"context"
+	"expvar"
+	"time"
+
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/meateam/permission-service/service"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// OutboxCollectionName is the name of the outbox collection of permission events.
+	OutboxCollectionName = "outbox"
+
+	// OutboxBSONPublishedAtField is the name of the publishedAt field in the outbox event BSON.
+	OutboxBSONPublishedAtField = "publishedAt"
+
+	// OutboxBSONPermissionField is the name of the permission field in the outbox event BSON.
+	OutboxBSONPermissionField = "permission"
+
+	// OutboxBSONTypeField is the name of the type field in the outbox event BSON.
+	OutboxBSONTypeField = "type"
+)
+
+var (
+	// outboxBacklog is the number of the events of the outbox that weren't published yet, and outboxLagSeconds
+	// is the age of the oldest of them, which are the lag of the consumers of the events behind the changes.
+	// Both are measured by the relay of the outbox, so they're only set on the instance that relays it.
+	outboxBacklog    = expvar.NewInt("outbox_backlog")
+	outboxLagSeconds = expvar.NewFloat("outbox_lag_seconds")
+)
+
+// outboxRecord is the structure that represents a permission event as it's stored in the outbox.
+type outboxRecord struct {
+	ID          primitive.ObjectID `bson:"_id"`
+	Type        service.EventType  `bson:"type"`
+	Permission  BSON               `bson:"permission"`
+	CreatedAt   time.Time          `bson:"createdAt"`
+	PublishedAt *time.Time         `bson:"publishedAt"`
+
+	// Revoked are the revoked permissions of the events of service.EventSharingStopped.
+	Revoked []BSON `bson:"revoked,omitempty"`
+}
+
+// newOutboxRecord returns the outbox record of an event of eventType of permission.
+func newOutboxRecord(eventType service.EventType, permission *BSON) outboxRecord {
+	return outboxRecord{
+		ID:         primitive.NewObjectID(),
+		Type:       eventType,
+		Permission: *permission,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// notDuplicateEventFilter matches the events that aren't of type EventExternalAccess or EventOwnerAssigned,
+// which duplicate the EventCreated events of their permissions, for listing each change to a permission once.
+var notDuplicateEventFilter = bson.E{
+	Key:   OutboxBSONTypeField,
+	Value: bson.D{bson.E{Key: "$nin", Value: bson.A{service.EventExternalAccess, service.EventOwnerAssigned}}},
+}
+
+// eventRecords returns the outbox records of an event of eventType of permission, which are its record,
+// followed by the record of its EventExternalAccess event if it's a created permission of an external grantee.
+func eventRecords(eventType service.EventType, permission *BSON) []interface{} {
+	records := []interface{}{newOutboxRecord(eventType, permission)}
+	if eventType == service.EventCreated && service.IsExternalGrantee(permission.GranteeType) {
+		records = append(records, newOutboxRecord(service.EventExternalAccess, permission))
+	}
+
+	return records
+}
+
+// WithOutbox returns a copy of s that writes an event to the outbox in the same transaction
+// as each change to a permission. Published events are deleted after retention.
+// Transactions require mongodb to be a replica set.
+func (s MongoStore) WithOutbox(ctx context.Context, retention time.Duration) (MongoStore, error) {
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{
+			bson.E{
+				Key:   OutboxBSONPublishedAtField,
+				Value: 1,
+			},
+		},
+		Options: options.Index().SetExpireAfterSeconds(int32(retention.Seconds())),
+	}
+
+	// The user index lists the changes to the permissions of a user.
+	userIndexModel := mongo.IndexModel{
+		Keys: bson.D{
+			bson.E{
+				Key:   OutboxBSONPermissionField + "." + PermissionBSONUserIDField,
+				Value: 1,
+			},
+			bson.E{
+				Key:   MongoObjectIDField,
+				Value: 1,
+			},
+		},
+	}
+
+	// The revoked index lists the permissions of a user that were revoked by the summary events.
+	revokedIndexModel := mongo.IndexModel{
+		Keys: bson.D{
+			bson.E{
+				Key:   OutboxBSONRevokedField + "." + PermissionBSONUserIDField,
+				Value: 1,
+			},
+			bson.E{
+				Key:   MongoObjectIDField,
+				Value: 1,
+			},
+		},
+	}
+
+	// The file index lists the activity of the permissions to a file.
+	fileIndexModel := mongo.IndexModel{
+		Keys: bson.D{
+			bson.E{
+				Key:   OutboxBSONPermissionField + "." + PermissionBSONFileIDField,
+				Value: 1,
+			},
+			bson.E{
+				Key:   MongoObjectIDField,
+				Value: 1,
+			},
+		},
+	}
+
+	indexModels := []mongo.IndexModel{indexModel, userIndexModel, revokedIndexModel, fileIndexModel}
+	if _, err := s.collection(OutboxCollectionName).Indexes().CreateMany(ctx, indexModels); err != nil {
+		return MongoStore{}, err
+	}
+
+	s.outbox = true
+	s.outboxRetention = retention
+	return s, nil
+}
+
+// withEvent runs mutate and writes an event of eventType of the permission that it returns to the outbox,
+// in a single transaction. If the outbox is disabled then mutate is run as is.
+func (s MongoStore) withEvent(
+	ctx context.Context,
+	eventType service.EventType,
+	mutate func(ctx context.Context) (service.Permission, error),
+) (service.Permission, error) {
+	if !s.outbox {
+		return mutate(ctx)
+	}
+
+	if sessCtx, ok := ctx.(mongo.SessionContext); ok {
+		return s.mutateInTransaction(sessCtx, eventType, mutate)
+	}
+
+	sess, err := s.DB.Client().StartSession(s.concerns.sessionOptions())
+	if err != nil {
+		return nil, err
+	}
+	defer sess.EndSession(ctx)
+
+	var permission service.Permission
+	err = mongo.WithSession(ctx, sess, func(sessCtx mongo.SessionContext) (err error) {
+		permission, err = s.mutateInTransaction(sessCtx, eventType, mutate)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return permission, nil
+}
+
+// inTransaction runs fn in a transaction if the outbox is enabled, so that the events that fn writes
+// to the outbox are written with its changes, otherwise fn is run as is.
+func (s MongoStore) inTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if !s.outbox {
+		return fn(ctx)
+	}
+
+	return s.transaction(ctx, fn)
+}
+
+// transaction runs fn in a transaction, in the session of ctx if it has one.
+// Transactions require mongodb to be a replica set.
+func (s MongoStore) transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	transaction := func(sessCtx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessCtx)
+	}
+
+	if sessCtx, ok := ctx.(mongo.SessionContext); ok {
+		_, err := sessCtx.WithTransaction(sessCtx, transaction)
+		return err
+	}
+
+	sess, err := s.DB.Client().StartSession(s.concerns.sessionOptions())
+	if err != nil {
+		return err
+	}
+	defer sess.EndSession(ctx)
+
+	return mongo.WithSession(ctx, sess, func(sessCtx mongo.SessionContext) error {
+		_, err := sessCtx.WithTransaction(sessCtx, transaction)
+		return err
+	})
+}
+
+// mutateInTransaction runs mutate and writes an event of eventType of the permission that it returns
+// to the outbox, in a transaction of sessCtx.
+func (s MongoStore) mutateInTransaction(
+	sessCtx mongo.SessionContext,
+	eventType service.EventType,
+	mutate func(ctx context.Context) (service.Permission, error),
+) (service.Permission, error) {
+	result, err := sessCtx.WithTransaction(sessCtx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		permission, err := mutate(sessCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		records := eventRecords(eventType, permission.(*BSON))
+		if err := s.writeEvents(sessCtx, records); err != nil {
+			return nil, err
+		}
+
+		return permission, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	permission, _ := result.(service.Permission)
+	return permission, nil
+}
+
+// unpublishedEventsFilter matches the events of the outbox that weren't published yet.
+var unpublishedEventsFilter = bson.D{
+	bson.E{Key: OutboxBSONPublishedAtField, Value: nil},
+	bson.E{Key: OutboxBSONHeldPublishedAtField, Value: nil},
+}
+
+// RelayOutbox is running an infinite loop that publishes the unpublished events of the outbox
+// with publisher, in the order they were written, up to batchSize events once in interval,
+// until ctx is done. An event is marked as published only after it's published, so events are
+// published at least once. The marks are the relay's position in the outbox, which is persisted
+// with the events, so a relay that's restarted, or of another instance, resumes from the oldest event
+// that wasn't published, and the events that were written while no instance relayed them aren't dropped.
+func (s MongoStore) RelayOutbox(
+	ctx context.Context,
+	publisher service.EventPublisher,
+	logger *logrus.Logger,
+	interval time.Duration,
+	batchSize int,
+) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	if backlog, err := s.measureOutboxBacklog(ctx); err == nil && backlog > 0 {
+		logger.WithField("backlog", backlog).Info("resuming the outbox relay from its unpublished events")
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := s.relayOutboxBatch(ctx, publisher, batchSize); err != nil {
+			logger.Errorf("failed relaying outbox events: %v", err)
+		}
+
+		if _, err := s.measureOutboxBacklog(ctx); err != nil && ctx.Err() == nil {
+			logger.Errorf("failed measuring the outbox backlog: %v", err)
+		}
+	}
+}
+
+// measureOutboxBacklog counts the unpublished events of the outbox into the outbox_backlog metric,
+// and returns their number.
+func (s MongoStore) measureOutboxBacklog(ctx context.Context) (int64, error) {
+	backlog, err := s.collection(OutboxCollectionName).CountDocuments(ctx, unpublishedEventsFilter)
+	if err != nil {
+		return 0, err
+	}
+
+	outboxBacklog.Set(backlog)
+	return backlog, nil
+}
+
+// relayOutboxBatch publishes up to batchSize unpublished events of the outbox and marks them as published.
+// It stops at the first event that fails to publish so that events are published in order.
+// The events of files under legal hold are marked in heldPublishedAt so that they don't expire.
+func (s MongoStore) relayOutboxBatch(ctx context.Context, publisher service.EventPublisher, batchSize int) error {
+	held, err := s.heldFiles(ctx)
+	if err != nil {
+		return err
+	}
+
+	collection := s.collection(OutboxCollectionName)
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: MongoObjectIDField, Value: 1}}).
+		SetLimit(int64(batchSize))
+
+	cur, err := collection.Find(ctx, unpublishedEventsFilter, opts)
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	// The lag is the age of the oldest unpublished event, which is the first of the batch.
+	lag := time.Duration(0)
+	defer func() {
+		outboxLagSeconds.Set(lag.Seconds())
+	}()
+
+	for cur.Next(ctx) {
+		var record outboxRecord
+		if err := cur.Decode(&record); err != nil {
+			return err
+		}
+
+		if lag == 0 {
+			lag = time.Since(record.CreatedAt)
+		}
+
+		event, err := record.event()
+		if err != nil {
+			return err
+		}
+
+		if err := publisher.Publish(ctx, event); err != nil {
+			return err
+		}
+
+		publishedAtField := OutboxBSONPublishedAtField
+		file := fileRecordID{ResourceType: record.Permission.GetResourceType(), FileID: record.Permission.GetFileID()}
+		if held[file] {
+			publishedAtField = OutboxBSONHeldPublishedAtField
+		}
+
+		recordFilter := bson.D{bson.E{Key: MongoObjectIDField, Value: record.ID}}
+		update := bson.D{
+			bson.E{
+				Key:   "$set",
+				Value: bson.D{bson.E{Key: publishedAtField, Value: time.Now()}},
+			},
+		}
+
+		if _, err := collection.UpdateOne(ctx, recordFilter, update); err != nil {
+			return err
+		}
+	}
+
+	return cur.Err()
+}
+
+// ListChanges returns up to pageSize changes to the permissions of userID that occurred after syncToken,
+// in the order they occurred. If syncToken is empty then no changes are returned, only the sync token
+// of the current time. Changes around the time of syncToken may be returned more than once.
+// Fails with codes.FailedPrecondition if the outbox is disabled, and with codes.OutOfRange
+// if the changes since syncToken may no longer be retained.
+func (s MongoStore) ListChanges(
+	ctx context.Context,
+	resourceType string,
+	userID string,
+	syncToken string,
+	pageSize int,
+) (service.PermissionChanges, error) {
+	if !s.outbox {
+		err := status.Error(codes.FailedPrecondition, "permission changes aren't recorded")
+		return service.PermissionChanges{}, err
+	}
+
+	if syncToken == "" {
+		return service.PermissionChanges{
+			SyncToken: encodePageToken(primitive.NewObjectIDFromTimestamp(time.Now()).Hex()),
+		}, nil
+	}
+
+	lastID, err := decodePageToken(syncToken)
+	if err != nil {
+		return service.PermissionChanges{}, err
+	}
+
+	// Published events are retained for at least retention after they were created.
+	if lastID.Timestamp().Before(time.Now().Add(-s.outboxRetention)) {
+		return service.PermissionChanges{}, status.Error(codes.OutOfRange, "sync token expired")
+	}
+
+	resourceTypeValue := interface{}(resourceType)
+	if resourceType == service.DefaultResourceType {
+		resourceTypeValue = bson.D{bson.E{Key: "$in", Value: bson.A{resourceType, nil, ""}}}
+	}
+
+	// The summary events of revocations are listed as the deletions of the revoked permissions of userID.
+	filter := bson.D{
+		bson.E{Key: "$or", Value: bson.A{
+			bson.D{
+				bson.E{Key: OutboxBSONPermissionField + "." + PermissionBSONUserIDField, Value: userID},
+				bson.E{Key: OutboxBSONTypeField, Value: bson.D{
+					bson.E{Key: "$ne", Value: service.EventSharingStopped},
+				}},
+			},
+			bson.D{bson.E{Key: OutboxBSONRevokedField + "." + PermissionBSONUserIDField, Value: userID}},
+		}},
+		bson.E{Key: OutboxBSONPermissionField + "." + PermissionBSONResourceTypeField, Value: resourceTypeValue},
+		bson.E{Key: MongoObjectIDField, Value: bson.D{bson.E{Key: "$gt", Value: lastID}}},
+		notDuplicateEventFilter,
+	}
+
+	// Fetch one more change than needed to know whether there are more changes.
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: MongoObjectIDField, Value: 1}}).
+		SetLimit(int64(pageSize) + 1)
+
+	cur, err := s.collection(OutboxCollectionName).Find(ctx, filter, opts)
+	if err != nil {
+		return service.PermissionChanges{}, err
+	}
+	defer cur.Close(ctx)
+
+	changes := service.PermissionChanges{Events: []service.PermissionEvent{}, SyncToken: syncToken}
+	for cur.Next(ctx) {
+		if len(changes.Events) == pageSize {
+			changes.HasMore = true
+			break
+		}
+
+		var record outboxRecord
+		if err := cur.Decode(&record); err != nil {
+			return service.PermissionChanges{}, err
+		}
+
+		event, err := record.userEvent(userID)
+		if err != nil {
+			return service.PermissionChanges{}, err
+		}
+
+		changes.Events = append(changes.Events, event)
+		changes.SyncToken = encodePageToken(record.ID.Hex())
+	}
+
+	if err := cur.Err(); err != nil {
+		return service.PermissionChanges{}, err
+	}
+
+	return changes, nil
+}
+
+// event returns the permission event that r represents.
+func (r outboxRecord) event() (service.PermissionEvent, error) {
+	permission := &pb.PermissionObject{}
+	if err := r.Permission.MarshalProto(permission); err != nil {
+		return service.PermissionEvent{}, err
+	}
+
+	event := service.PermissionEvent{
+		ID:         r.ID.Hex(),
+		Type:       r.Type,
+		Permission: permission,
+		OccurredAt: r.CreatedAt,
+	}
+
+	for i := range r.Revoked {
+		revoked := &pb.PermissionObject{}
+		if err := r.Revoked[i].MarshalProto(revoked); err != nil {
+			return service.PermissionEvent{}, err
+		}
+
+		event.Revoked = append(event.Revoked, revoked)
+	}
+
+	return event, nil
+}
+
+// userEvent returns the permission event that r represents to userID, which is the EventDeleted event
+// of the revoked permission of userID if r is an event of service.EventSharingStopped.
+func (r outboxRecord) userEvent(userID string) (service.PermissionEvent, error) {
+	if r.Type != service.EventSharingStopped {
+		return r.event()
+	}
+
+	for i := range r.Revoked {
+		if r.Revoked[i].UserID == userID {
+			return outboxRecord{
+				ID:         r.ID,
+				Type:       service.EventDeleted,
+				CreatedAt:  r.CreatedAt,
+				Permission: r.Revoked[i],
+			}.event()
+		}
+	}
+
+	err := status.Errorf(codes.Internal, "event %s didn't revoke a permission of the user", r.ID.Hex())
+	return service.PermissionEvent{}, err
+}
+
+// GetEventsByUser retrieves the events of the outbox whose permissions are of userID, were created
+// by userID or include userID in their sharing chains, in the order they were written.
+// No events are retrieved if the outbox is disabled.
+func (s MongoStore) GetEventsByUser(ctx context.Context, userID string) ([]service.PermissionEvent, error) {
+	events := []service.PermissionEvent{}
+	if !s.outbox {
+		return events, nil
+	}
+
+	filter := bson.D{
+		bson.E{
+			Key: "$or",
+			Value: bson.A{
+				bson.D{bson.E{Key: OutboxBSONPermissionField + "." + PermissionBSONUserIDField, Value: userID}},
+				bson.D{bson.E{Key: OutboxBSONPermissionField + "." + PermissionBSONCreatorField, Value: userID}},
+				bson.D{bson.E{Key: OutboxBSONPermissionField + "." + PermissionBSONSharingChainField, Value: userID}},
+			},
+		},
+		notDuplicateEventFilter,
+	}
+
+	opts := options.Find().SetSort(bson.D{bson.E{Key: MongoObjectIDField, Value: 1}})
+	cur, err := s.collection(OutboxCollectionName).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var record outboxRecord
+		if err := cur.Decode(&record); err != nil {
+			return nil, err
+		}
+
+		event, err := record.event()
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, event)
+	}
+
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
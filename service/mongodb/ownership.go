@@ -0,0 +1,116 @@
+package mongodb
+
+import (
+	"context"
+
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/meateam/permission-service/service"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AssignOwner makes userID the owner of fileID, which has no owner, by giving it a WRITE permission that it
+// created, like the permission of the user that created the file, and returns it. An existing permission
+// of userID, such as one that the previous owner shared with it, is replaced. The permission's EventCreated event
+// and its EventOwnerAssigned event are written to the outbox, if it's enabled, in the same transaction.
+// Fails with codes.FailedPrecondition if fileID has an owner other than userID, or userID isn't a user.
+// Transactions require mongodb to be a replica set.
+func (s MongoStore) AssignOwner(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+) (service.Permission, error) {
+	var permission service.Permission
+	err := s.transaction(ctx, func(ctx context.Context) (err error) {
+		permission, err = s.assignOwner(ctx, resourceType, fileID, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return permission, nil
+}
+
+// assignOwner makes userID the owner of fileID in the transaction of ctx.
+func (s MongoStore) assignOwner(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+) (service.Permission, error) {
+	permissions, err := s.find(ctx, resourceFilter(resourceType, fileID))
+	if err != nil {
+		return nil, err
+	}
+
+	resourceKind := service.ResourceKindFile
+	for _, permission := range permissions {
+		permission := permission.(*BSON)
+		if permission.ResourceKind != "" {
+			resourceKind = permission.ResourceKind
+		}
+
+		if permission.UserID == userID && permission.GranteeType != "" &&
+			permission.GranteeType != service.GranteeTypeUser {
+			return nil, status.Errorf(codes.FailedPrecondition, "the owner of %s must be a user", fileID)
+		}
+
+		if !isOwnerPermission(permission) {
+			continue
+		}
+
+		if permission.UserID != userID {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s %s already has an owner", resourceType, fileID)
+		}
+
+		// The user already owns the file.
+		return permission, nil
+	}
+
+	update := bson.D{
+		bson.E{Key: "$set", Value: bson.D{
+			bson.E{Key: PermissionBSONResourceTypeField, Value: resourceType},
+			bson.E{Key: PermissionBSONRoleField, Value: pb.Role_WRITE},
+			bson.E{Key: PermissionBSONCreatorField, Value: userID},
+			bson.E{Key: PermissionBSONCanReshareField, Value: true},
+			bson.E{Key: PermissionBSONResourceKindField, Value: resourceKind},
+			bson.E{Key: PermissionBSONGranteeTypeField, Value: service.GranteeTypeUser},
+			bson.E{Key: PermissionBSONSchemaVersionField, Value: CurrentSchemaVersion},
+		}},
+		bson.E{Key: "$unset", Value: bson.D{
+			bson.E{Key: PermissionBSONSharingChainField, Value: ""},
+			bson.E{Key: PermissionBSONInheritedFromField, Value: ""},
+		}},
+		incVersion,
+	}
+
+	permission := &BSON{}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	err = s.collection(PermissionCollectionName).
+		FindOneAndUpdate(ctx, permissionFilter(resourceType, fileID, userID), update, opts).
+		Decode(permission)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.outbox {
+		records := eventRecords(service.EventCreated, permission)
+		records = append(records, newOutboxRecord(service.EventOwnerAssigned, permission))
+		if err := s.writeEvents(ctx, records); err != nil {
+			return nil, err
+		}
+	}
+
+	return permission, nil
+}
+
+// isOwnerPermission returns whether permission is of the owner of its file, which is a WRITE permission
+// that was given to the file directly by the user it's given to.
+func isOwnerPermission(permission *BSON) bool {
+	return permission.Role == pb.Role_WRITE && permission.Creator == permission.UserID &&
+		permission.InheritedFrom == ""
+}
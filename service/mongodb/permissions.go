@@ -0,0 +1,851 @@
+package mongodb
+
+import (
+	"context"
+	"encoding/base64"
+	"sort"
+	"time"
+
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/meateam/permission-service/service"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errNotFound is the error of a permission that was not found.
+var errNotFound = status.Error(codes.NotFound, "permission not found")
+
+// GetByID retrieves the permission with id, and any error if occurred.
+func (s MongoStore) GetByID(ctx context.Context, id string) (service.Permission, error) {
+	filter, err := idFilter(id)
+	if err != nil {
+		return nil, err
+	}
+
+	permission, err := s.findOne(ctx, filter)
+	if err == mongo.ErrNoDocuments {
+		return nil, errNotFound
+	}
+
+	return permission, err
+}
+
+// ExistingIDs returns the set of ids of the permissions that exist, with a single query that only reads
+// the index of the IDs. Malformed ids don't exist.
+func (s MongoStore) ExistingIDs(ctx context.Context, ids []string) (map[string]bool, error) {
+	existing := map[string]bool{}
+	objectIDs := bson.A{}
+	for _, id := range ids {
+		if objectID, err := primitive.ObjectIDFromHex(id); err == nil {
+			objectIDs = append(objectIDs, objectID)
+		}
+	}
+
+	if len(objectIDs) == 0 {
+		return existing, nil
+	}
+
+	cur, err := s.collection(PermissionCollectionName).Find(
+		ctx,
+		bson.D{bson.E{Key: MongoObjectIDField, Value: bson.D{bson.E{Key: "$in", Value: objectIDs}}}},
+		options.Find().SetProjection(bson.D{bson.E{Key: MongoObjectIDField, Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var permission BSON
+		if err := cur.Decode(&permission); err != nil {
+			return nil, err
+		}
+
+		existing[permission.ID.Hex()] = true
+	}
+
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+
+	return existing, nil
+}
+
+// Get retrieves the permissoin that matches fileID and userID, and any error if occurred.
+// If fields are given then only they are retrieved, along with the resource type, file and user IDs.
+func (s MongoStore) Get(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+	fields ...service.PermissionField,
+) (service.Permission, error) {
+	filter := permissionFilter(resourceType, fileID, userID)
+	permission, err := s.hedgedFindOne(ctx, filter, options.FindOne().SetProjection(projectionByFields(fields)))
+	if err == mongo.ErrNoDocuments {
+		return nil, errNotFound
+	}
+
+	return permission, unavailableError(err)
+}
+
+// GetByResource retrieves the permissions of fileID that match selector sorted by order.
+func (s MongoStore) GetByResource(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	order pb.PermissionsOrder,
+	selector service.PermissionSelector,
+) ([]service.Permission, error) {
+	filter := append(resourceFilter(resourceType, fileID), selectorFilter(selector)...)
+	return s.find(ctx, filter, findOptionsByOrder(order))
+}
+
+// GetByUser retrieves the permissions of userID that match selector sorted by order.
+func (s MongoStore) GetByUser(
+	ctx context.Context,
+	resourceType string,
+	userID string,
+	order pb.PermissionsOrder,
+	selector service.PermissionSelector,
+) ([]service.Permission, error) {
+	filter := bson.D{
+		resourceTypeFilter(resourceType),
+		bson.E{
+			Key:   PermissionBSONUserIDField,
+			Value: userID,
+		},
+	}
+
+	return s.find(ctx, append(filter, selectorFilter(selector)...), findOptionsByOrder(order))
+}
+
+// GetBySharer retrieves the permissions of fileID whose sharing chain includes sharerID,
+// which are the permissions that sharerID reshared, directly or through other users.
+func (s MongoStore) GetBySharer(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	sharerID string,
+) ([]service.Permission, error) {
+	filter := append(resourceFilter(resourceType, fileID), bson.E{
+		Key:   PermissionBSONSharingChainField,
+		Value: sharerID,
+	})
+
+	return s.find(ctx, filter)
+}
+
+// GetByFilter retrieves the permissions that match filter, to resources of every type
+// if filter.ResourceType isn't set.
+func (s MongoStore) GetByFilter(
+	ctx context.Context,
+	filter service.PermissionsFilter,
+) ([]service.Permission, error) {
+	return s.find(ctx, permissionsFilter(filter))
+}
+
+// ReplaceUser replaces userID as the creator, and in the sharing chains, of the permissions to resources
+// of every type with replacement, and returns the number of permissions that were changed.
+// The replacements don't write events to the outbox.
+func (s MongoStore) ReplaceUser(ctx context.Context, userID string, replacement string) (int64, error) {
+	filter := bson.D{
+		bson.E{
+			Key: "$or",
+			Value: bson.A{
+				bson.D{bson.E{Key: PermissionBSONCreatorField, Value: userID}},
+				bson.D{bson.E{Key: PermissionBSONSharingChainField, Value: userID}},
+			},
+		},
+	}
+
+	total, err := s.count(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+
+	creatorFilter := bson.D{bson.E{Key: PermissionBSONCreatorField, Value: userID}}
+	creatorUpdate := bson.D{
+		bson.E{
+			Key:   "$set",
+			Value: bson.D{bson.E{Key: PermissionBSONCreatorField, Value: replacement}},
+		},
+		incVersion,
+	}
+
+	if _, err := s.updateMany(ctx, creatorFilter, creatorUpdate); err != nil {
+		return 0, err
+	}
+
+	sharingChainFilter := bson.D{bson.E{Key: PermissionBSONSharingChainField, Value: userID}}
+	sharingChainUpdate := bson.D{
+		bson.E{
+			Key:   "$set",
+			Value: bson.D{bson.E{Key: PermissionBSONSharingChainField + ".$[sharer]", Value: replacement}},
+		},
+		incVersion,
+	}
+
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{
+		Filters: []interface{}{bson.D{bson.E{Key: "sharer", Value: userID}}},
+	})
+
+	if _, err := s.updateMany(ctx, sharingChainFilter, sharingChainUpdate, opts); err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// ListByResource returns up to pageSize permissions of fileID that match selector and come after
+// pageToken, sorted by order, and the token of the next page,
+// which is empty if there are no more pages.
+func (s MongoStore) ListByResource(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	order pb.PermissionsOrder,
+	pageSize int,
+	pageToken string,
+	fields []service.PermissionField,
+	selector service.PermissionSelector,
+) ([]service.Permission, string, error) {
+	// The cursor of the page needs the last access time of its last permission.
+	if order == pb.PermissionsOrder_RECENTLY_ACCESSED && len(fields) > 0 {
+		fields = append(fields[:len(fields):len(fields)], service.LastAccessedAtField)
+	}
+
+	filter := append(resourceFilter(resourceType, fileID), selectorFilter(selector)...)
+	return s.findPage(ctx, filter, order, pageSize, pageToken, projectionByFields(fields))
+}
+
+// ListByUser returns up to pageSize permissions of userID that match selector and come after pageToken,
+// sorted by order, and the token of the next page, which is empty if there are no more pages.
+func (s MongoStore) ListByUser(
+	ctx context.Context,
+	resourceType string,
+	userID string,
+	order pb.PermissionsOrder,
+	pageSize int,
+	pageToken string,
+	selector service.PermissionSelector,
+) ([]service.Permission, string, error) {
+	filter := bson.D{
+		resourceTypeFilter(resourceType),
+		bson.E{
+			Key:   PermissionBSONUserIDField,
+			Value: userID,
+		},
+	}
+
+	return s.findPage(ctx, append(filter, selectorFilter(selector)...), order, pageSize, pageToken, nil)
+}
+
+// ListByGranteeType returns up to pageSize permissions of granteeType that come after pageToken, ordered
+// by their creation, and the token of the next page, which is empty if there are no more pages.
+// If granteeID is set then only its permissions are returned.
+func (s MongoStore) ListByGranteeType(
+	ctx context.Context,
+	granteeType string,
+	granteeID string,
+	pageSize int,
+	pageToken string,
+) ([]service.Permission, string, error) {
+	filter := bson.D{bson.E{Key: PermissionBSONGranteeTypeField, Value: granteeType}}
+	if granteeID != "" {
+		filter = append(filter, bson.E{Key: PermissionBSONUserIDField, Value: granteeID})
+	}
+
+	return s.findPage(ctx, filter, pb.PermissionsOrder_DEFAULT, pageSize, pageToken, nil)
+}
+
+// ListStale returns up to pageSize direct permissions of resourceType, or of every resource type if it's empty,
+// that were last accessed before before, or were never accessed and were created before it, other than
+// the permissions of the owners, that come after pageToken, ordered by their creation, and the token of
+// the next page, which is empty if there are no more pages. The creation time of a permission is of its ID.
+func (s MongoStore) ListStale(
+	ctx context.Context,
+	resourceType string,
+	before time.Time,
+	pageSize int,
+	pageToken string,
+) ([]service.Permission, string, error) {
+	filter := bson.D{
+		bson.E{Key: PermissionBSONInheritedFromField, Value: bson.D{bson.E{Key: "$exists", Value: false}}},
+		bson.E{Key: "$or", Value: bson.A{
+			bson.D{bson.E{Key: PermissionBSONLastAccessedAtField, Value: bson.D{bson.E{Key: "$lt", Value: before}}}},
+			bson.D{
+				bson.E{Key: PermissionBSONLastAccessedAtField, Value: bson.D{bson.E{Key: "$exists", Value: false}}},
+				bson.E{
+					Key:   MongoObjectIDField,
+					Value: bson.D{bson.E{Key: "$lt", Value: primitive.NewObjectIDFromTimestamp(before)}},
+				},
+			},
+		}},
+
+		// The owner of a file is the creator of its own WRITE permission.
+		bson.E{Key: "$nor", Value: bson.A{bson.D{
+			bson.E{Key: PermissionBSONRoleField, Value: pb.Role_WRITE},
+			bson.E{Key: "$expr", Value: bson.D{bson.E{
+				Key:   "$eq",
+				Value: bson.A{"$" + PermissionBSONCreatorField, "$" + PermissionBSONUserIDField},
+			}}},
+		}}},
+	}
+
+	if resourceType != "" {
+		filter = append(filter, bson.E{Key: PermissionBSONResourceTypeField, Value: resourceType})
+	}
+
+	return s.findPage(ctx, filter, pb.PermissionsOrder_DEFAULT, pageSize, pageToken, nil)
+}
+
+// findPage finds up to pageSize permissions that match filter and come after pageToken, sorted by order
+// with projection, and the token of the next page, which is empty if there are no more pages.
+// The DEFAULT order of pages is the creation of the permissions.
+func (s MongoStore) findPage(
+	ctx context.Context,
+	filter bson.D,
+	order pb.PermissionsOrder,
+	pageSize int,
+	pageToken string,
+	projection interface{},
+) ([]service.Permission, string, error) {
+	if pageToken != "" {
+		cursor, err := decodePageCursor(pageToken, order)
+		if err != nil {
+			return nil, "", err
+		}
+
+		filter = append(filter, cursor.filter()...)
+	}
+
+	// Fetch one more permission than needed to know whether there's a next page.
+	opts := options.Find().
+		SetSort(sortByOrder(order)).
+		SetLimit(int64(pageSize) + 1).
+		SetProjection(projection)
+
+	permissions, err := s.find(ctx, filter, opts)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(permissions) <= pageSize {
+		return permissions, "", nil
+	}
+
+	permissions = permissions[:pageSize]
+	cursor, err := newPageCursor(permissions[pageSize-1], order)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return permissions, cursor.encode(), nil
+}
+
+// Update updates the fields of the permission that matches fileID and userID
+// to their values in update and returns the updated permission.
+// If etag is not empty then the permission is updated only if etag is its current etag.
+func (s MongoStore) Update(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+	etag string,
+	update service.PermissionUpdate,
+	fields []service.PermissionField,
+) (service.Permission, error) {
+	filter, err := etagPermissionFilter(resourceType, fileID, userID, etag)
+	if err != nil {
+		return nil, err
+	}
+
+	set := bson.D{}
+	for _, field := range fields {
+		switch field {
+		case service.RoleField:
+			set = append(set, bson.E{Key: PermissionBSONRoleField, Value: update.Role})
+		case service.CanReshareField:
+			set = append(set, bson.E{Key: PermissionBSONCanReshareField, Value: update.CanReshare})
+		case service.MessageField:
+			set = append(set, bson.E{Key: PermissionBSONMessageField, Value: update.Message})
+		case service.LabelField:
+			set = append(set, bson.E{Key: PermissionBSONLabelField, Value: update.Label})
+		case service.LabelsField:
+			set = append(set, bson.E{Key: PermissionBSONLabelsField, Value: update.Labels})
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "field %s cannot be updated", field)
+		}
+	}
+
+	setUpdate := bson.D{
+		bson.E{
+			Key:   "$set",
+			Value: set,
+		},
+		incVersion,
+	}
+
+	permission, err := s.withEvent(ctx, service.EventUpdated, func(ctx context.Context) (service.Permission, error) {
+		return s.findOneAndUpdate(ctx, filter, setUpdate)
+	})
+	if err == mongo.ErrNoDocuments {
+		return nil, errNotFound
+	}
+
+	return permission, err
+}
+
+// UpdateRoles changes the roles of the permissions of updates with a single unordered bulk write,
+// and returns the result of each update in the order of updates. The permissions are read first
+// and each is updated only if it wasn't changed since, so that the results are of the updated permissions.
+// The events of the updated permissions are written to the outbox in the same transaction as the write.
+func (s MongoStore) UpdateRoles(
+	ctx context.Context,
+	updates []service.RoleUpdate,
+) ([]service.RoleUpdateResult, error) {
+	results := make([]service.RoleUpdateResult, len(updates))
+	if len(updates) == 0 {
+		return results, nil
+	}
+
+	// The permissions are read from the primary since their versions are written back.
+	s = s.primary()
+	matchAny := make(bson.A, 0, len(updates))
+	for _, update := range updates {
+		matchAny = append(matchAny, permissionFilter(update.ResourceType, update.FileID, update.UserID))
+	}
+
+	current, err := s.find(ctx, bson.D{bson.E{Key: "$or", Value: matchAny}})
+	if err != nil {
+		return nil, err
+	}
+
+	permissionsByKey := make(map[permissionKey]*BSON, len(current))
+	for _, permission := range current {
+		permission := permission.(*BSON)
+		key := permissionKey{
+			resourceType: permission.GetResourceType(),
+			fileID:       permission.FileID,
+			userID:       permission.UserID,
+		}
+		permissionsByKey[key] = permission
+	}
+
+	models := make([]mongo.WriteModel, 0, len(updates))
+	written := make([]int, 0, len(updates))
+	for i, update := range updates {
+		key := permissionKey{resourceType: update.ResourceType, fileID: update.FileID, userID: update.UserID}
+		permission, ok := permissionsByKey[key]
+		if !ok {
+			results[i].Err = errNotFound
+			continue
+		}
+
+		if update.ETag != "" && update.ETag != permission.GetETag() {
+			results[i].Err = status.Errorf(
+				codes.Aborted,
+				"permission etag %s does not match the current etag",
+				update.ETag,
+			)
+			continue
+		}
+
+		// Match the version that was read in case the permission was changed since.
+		filter, err := etagFilter(permission.GetETag())
+		if err != nil {
+			return nil, err
+		}
+
+		models = append(models, mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(bson.D{
+			bson.E{
+				Key:   "$set",
+				Value: bson.D{bson.E{Key: PermissionBSONRoleField, Value: update.Role}},
+			},
+			incVersion,
+		}))
+
+		updated := *permission
+		updated.Role = update.Role
+		updated.Version++
+		results[i].Permission = &updated
+		written = append(written, i)
+	}
+
+	if len(models) == 0 {
+		return results, nil
+	}
+
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		return s.bulkUpdateRoles(ctx, models, written, results)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// bulkUpdateRoles writes models, the updates of the permissions of results at the indexes written,
+// and fails the results of the permissions that weren't updated since they were changed after they were read.
+// It then writes the events of the updated permissions to the outbox, if it's enabled.
+func (s MongoStore) bulkUpdateRoles(
+	ctx context.Context,
+	models []mongo.WriteModel,
+	written []int,
+	results []service.RoleUpdateResult,
+) error {
+	collection := s.collection(PermissionCollectionName)
+	result, err := collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount < int64(len(models)) {
+		if err := s.failChangedRoleUpdates(ctx, written, results); err != nil {
+			return err
+		}
+	}
+
+	if !s.outbox {
+		return nil
+	}
+
+	records := make([]interface{}, 0, len(written))
+	for _, i := range written {
+		if results[i].Err != nil {
+			continue
+		}
+
+		records = append(records, newOutboxRecord(service.EventUpdated, results[i].Permission.(*BSON)))
+	}
+
+	if len(records) == 0 {
+		return nil
+	}
+
+	return s.writeEvents(ctx, records)
+}
+
+// failChangedRoleUpdates fails the results at the indexes written whose permissions weren't updated to
+// their expected version, since they were changed after they were read, with an Aborted error.
+func (s MongoStore) failChangedRoleUpdates(
+	ctx context.Context,
+	written []int,
+	results []service.RoleUpdateResult,
+) error {
+	ids := make(bson.A, 0, len(written))
+	for _, i := range written {
+		ids = append(ids, results[i].Permission.(*BSON).ID)
+	}
+
+	current, err := s.find(ctx, bson.D{bson.E{
+		Key:   MongoObjectIDField,
+		Value: bson.D{bson.E{Key: "$in", Value: ids}},
+	}})
+	if err != nil {
+		return err
+	}
+
+	currentETags := make(map[string]string, len(current))
+	for _, permission := range current {
+		currentETags[permission.GetID()] = permission.GetETag()
+	}
+
+	for _, i := range written {
+		if currentETags[results[i].Permission.GetID()] != results[i].Permission.GetETag() {
+			results[i] = service.RoleUpdateResult{
+				Err: status.Error(codes.Aborted, "permission was changed during the update"),
+			}
+		}
+	}
+
+	return nil
+}
+
+// Touch sets the last access time of the permission that matches fileID and userID
+// to accessedAt and returns the updated permission.
+func (s MongoStore) Touch(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+	accessedAt time.Time,
+) (service.Permission, error) {
+	filter := permissionFilter(resourceType, fileID, userID)
+
+	update := bson.D{
+		bson.E{
+			Key: "$set",
+			Value: bson.D{
+				bson.E{
+					Key:   PermissionBSONLastAccessedAtField,
+					Value: accessedAt,
+				},
+			},
+		},
+	}
+
+	permission, err := s.findOneAndUpdate(ctx, filter, update)
+	if err == mongo.ErrNoDocuments {
+		return nil, errNotFound
+	}
+
+	return permission, err
+}
+
+// Delete deletes the permission that matches fileID and userID and returns the deleted permission.
+// If etag is not empty then the permission is deleted only if etag is its current etag.
+func (s MongoStore) Delete(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+	etag string,
+) (service.Permission, error) {
+	filter, err := etagPermissionFilter(resourceType, fileID, userID, etag)
+	if err != nil {
+		return nil, err
+	}
+
+	permission, err := s.withEvent(ctx, service.EventDeleted, func(ctx context.Context) (service.Permission, error) {
+		return s.findOneAndDelete(ctx, filter)
+	})
+	if err == mongo.ErrNoDocuments {
+		return nil, errNotFound
+	}
+
+	return permission, err
+}
+
+// DeleteByID deletes the permission with id and returns the deleted permission.
+func (s MongoStore) DeleteByID(ctx context.Context, id string) (service.Permission, error) {
+	filter, err := idFilter(id)
+	if err != nil {
+		return nil, err
+	}
+
+	permission, err := s.withEvent(ctx, service.EventDeleted, func(ctx context.Context) (service.Permission, error) {
+		return s.findOneAndDelete(ctx, filter)
+	})
+	if err == mongo.ErrNoDocuments {
+		return nil, errNotFound
+	}
+
+	return permission, err
+}
+
+// idFilter returns the filter that matches the permission with id.
+func idFilter(id string) (bson.D, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return bson.D{
+		bson.E{
+			Key:   MongoObjectIDField,
+			Value: objectID,
+		},
+	}, nil
+}
+
+// resourceTypeFilter returns the filter element that matches the permissions of resources of resourceType.
+// Permissions that were stored before resource types were introduced have no resource type,
+// and are of the default resource type.
+func resourceTypeFilter(resourceType string) bson.E {
+	if resourceType != service.DefaultResourceType {
+		return bson.E{Key: PermissionBSONResourceTypeField, Value: resourceType}
+	}
+
+	return bson.E{
+		Key: PermissionBSONResourceTypeField,
+		Value: bson.D{
+			bson.E{
+				Key:   "$in",
+				Value: bson.A{resourceType, nil},
+			},
+		},
+	}
+}
+
+// resourceFilter returns the filter that matches the permissions of the resource of resourceType with fileID.
+func resourceFilter(resourceType string, fileID string) bson.D {
+	return bson.D{
+		resourceTypeFilter(resourceType),
+		bson.E{
+			Key:   PermissionBSONFileIDField,
+			Value: fileID,
+		},
+	}
+}
+
+// selectorFilter returns the filter that matches the permissions that match selector.
+func selectorFilter(selector service.PermissionSelector) bson.D {
+	filter := labelsFilter(selector.Labels)
+	if selector.Source != "" {
+		filter = append(filter, bson.E{Key: PermissionBSONSourceField, Value: selector.Source})
+	}
+
+	return filter
+}
+
+// labelsFilter returns the filter that matches the permissions that have all of labels,
+// sorted by their keys so that the same labels make the same query.
+func labelsFilter(labels map[string]string) bson.D {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	filter := make(bson.D, 0, len(keys))
+	for _, key := range keys {
+		filter = append(filter, bson.E{Key: PermissionBSONLabelsField + "." + key, Value: labels[key]})
+	}
+
+	return filter
+}
+
+// permissionFilter returns the filter that matches the permission of userID to the resource
+// of resourceType with fileID.
+func permissionFilter(resourceType string, fileID string, userID string) bson.D {
+	return append(resourceFilter(resourceType, fileID), bson.E{
+		Key:   PermissionBSONUserIDField,
+		Value: userID,
+	})
+}
+
+// permissionKey identifies a permission among the permissions of a batch.
+type permissionKey struct {
+	resourceType string
+	fileID       string
+	userID       string
+}
+
+// etagPermissionFilter returns the filter that matches the permission of userID to the resource
+// of resourceType with fileID, only if etag is its current etag, or regardless of its etag if etag is empty.
+func etagPermissionFilter(resourceType string, fileID string, userID string, etag string) (bson.D, error) {
+	filter := permissionFilter(resourceType, fileID, userID)
+	if etag == "" {
+		return filter, nil
+	}
+
+	matchETag, err := etagFilter(etag)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(filter, matchETag...), nil
+}
+
+// etagFilter returns the filter elements that match a permission only if etag is its current etag.
+func etagFilter(etag string) (bson.D, error) {
+	id, version, err := parseETag(etag)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var matchVersion interface{} = version
+
+	// Permissions that were never modified since versions were introduced have no version.
+	if version == 0 {
+		matchVersion = bson.D{
+			bson.E{
+				Key:   "$in",
+				Value: bson.A{0, nil},
+			},
+		}
+	}
+
+	return bson.D{
+		bson.E{
+			Key:   MongoObjectIDField,
+			Value: id,
+		},
+		bson.E{
+			Key:   PermissionBSONVersionField,
+			Value: matchVersion,
+		},
+	}, nil
+}
+
+// bsonFieldsByPermissionField maps the fields of a permission to their name in BSON.
+var bsonFieldsByPermissionField = map[service.PermissionField]string{
+	service.UserIDField:         PermissionBSONUserIDField,
+	service.RoleField:           PermissionBSONRoleField,
+	service.CreatorField:        PermissionBSONCreatorField,
+	service.CanReshareField:     PermissionBSONCanReshareField,
+	service.MessageField:        PermissionBSONMessageField,
+	service.LabelField:          PermissionBSONLabelField,
+	service.LastAccessedAtField: PermissionBSONLastAccessedAtField,
+	service.ETagField:           PermissionBSONVersionField,
+	service.SharingChainField:   PermissionBSONSharingChainField,
+	service.LabelsField:         PermissionBSONLabelsField,
+	service.SourceField:         PermissionBSONSourceField,
+	service.InheritedFromField:  PermissionBSONInheritedFromField,
+}
+
+// projectionByFields returns a projection of fields that always includes the resource type and kind,
+// the file and user IDs and the grantee type, returns nil if there are no fields so that the whole
+// permission is projected.
+func projectionByFields(fields []service.PermissionField) interface{} {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	projection := bson.D{
+		bson.E{Key: PermissionBSONResourceTypeField, Value: 1},
+		bson.E{Key: PermissionBSONResourceKindField, Value: 1},
+		bson.E{Key: PermissionBSONGranteeTypeField, Value: 1},
+		bson.E{Key: PermissionBSONFileIDField, Value: 1},
+		bson.E{Key: PermissionBSONUserIDField, Value: 1},
+	}
+	for _, field := range fields {
+		if bsonField, ok := bsonFieldsByPermissionField[field]; ok && bsonField != PermissionBSONUserIDField {
+			projection = append(projection, bson.E{Key: bsonField, Value: 1})
+		}
+	}
+
+	return projection
+}
+
+// encodePageToken encodes the ID of the last permission of a page into a page token.
+func encodePageToken(lastID string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(lastID))
+}
+
+// decodePageToken decodes pageToken into the ID of the last permission of the previous page.
+func decodePageToken(pageToken string) (primitive.ObjectID, error) {
+	lastID, err := base64.RawURLEncoding.DecodeString(pageToken)
+	if err != nil {
+		return primitive.ObjectID{}, errInvalidPageToken
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(string(lastID))
+	if err != nil {
+		return primitive.ObjectID{}, errInvalidPageToken
+	}
+
+	return objectID, nil
+}
+
+// findOptionsByOrder returns the find options that sort the permissions by order.
+func findOptionsByOrder(order pb.PermissionsOrder) *options.FindOptions {
+	opts := options.Find()
+	if order == pb.PermissionsOrder_RECENTLY_ACCESSED {
+		opts.SetSort(bson.D{
+			bson.E{
+				Key:   PermissionBSONLastAccessedAtField,
+				Value: -1,
+			},
+		})
+	}
+
+	return opts
+}
@@ -0,0 +1,469 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/meateam/permission-service/service"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// QuarantineCollectionName is the name of the collection of the quarantined permission documents.
+	QuarantineCollectionName = "quarantinedPermissions"
+
+	// QuarantineBSONDocumentField is the name of the field of the quarantined document in the quarantine BSON.
+	QuarantineBSONDocumentField = "document"
+
+	// QuarantineBSONProblemField is the name of the problem field in the quarantine BSON.
+	QuarantineBSONProblemField = "problem"
+
+	// QuarantineBSONDetailField is the name of the detail field in the quarantine BSON.
+	QuarantineBSONDetailField = "detail"
+
+	// QuarantineBSONQuarantinedAtField is the name of the quarantinedAt field in the quarantine BSON.
+	QuarantineBSONQuarantinedAtField = "quarantinedAt"
+)
+
+// errNotFixed aborts the transaction of a fix that doesn't fix its document.
+var errNotFixed = fmt.Errorf("the document doesn't match the schema once fixed")
+
+// documentProblem is a problem of a permission document, and the update that fixes it, if it may be fixed.
+type documentProblem struct {
+	problem string
+	detail  string
+
+	// set is the field that's set to fix the problem, and unset is the field that's unset to fix it.
+	set   *bson.E
+	unset string
+}
+
+// fixable returns whether the problem may be fixed.
+func (p documentProblem) fixable() bool {
+	return p.set != nil || p.unset != ""
+}
+
+// RepairPermissions scans the permission documents that don't match the schema of the permissions, and then
+// the documents of the same user and resource, batchSize documents at a time. It fixes or quarantines them
+// by action, and calls progress after each batch that has malformed documents. The quarantined documents are
+// moved to the quarantine collection. Repairs don't write events to the outbox.
+func (s MongoStore) RepairPermissions(
+	ctx context.Context,
+	action service.RepairAction,
+	batchSize int,
+	progress func(malformed []service.MalformedPermission) error,
+) error {
+	s = s.primary()
+	filter := bson.D{bson.E{Key: "$nor", Value: bson.A{
+		bson.D{bson.E{Key: "$jsonSchema", Value: permissionSchema()}},
+	}}}
+
+	opts := options.Find().SetBatchSize(int32(batchSize))
+	cursor, err := s.collection(PermissionCollectionName).Find(ctx, filter, opts)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	batch := make([]service.MalformedPermission, 0, batchSize)
+	for cursor.Next(ctx) {
+		malformed, err := s.repairDocument(ctx, action, append(bson.Raw(nil), cursor.Current...))
+		if err != nil {
+			return err
+		}
+
+		if batch = append(batch, malformed); len(batch) == batchSize {
+			if err := progress(batch); err != nil {
+				return err
+			}
+
+			batch = batch[:0]
+		}
+	}
+
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+
+	if len(batch) > 0 {
+		if err := progress(batch); err != nil {
+			return err
+		}
+	}
+
+	return s.repairDuplicates(ctx, action, batchSize, progress)
+}
+
+// repairDocument fixes or quarantines document, which doesn't match the schema, by action,
+// and returns it as a malformed permission.
+func (s MongoStore) repairDocument(
+	ctx context.Context,
+	action service.RepairAction,
+	document bson.Raw,
+) (service.MalformedPermission, error) {
+	id := document.Lookup(MongoObjectIDField)
+	problems := checkPermissionDocument(document)
+	if len(problems) == 0 {
+		problems = []documentProblem{{
+			problem: service.ProblemInvalidField,
+			detail:  "the document doesn't match the schema of the permissions",
+		}}
+	}
+
+	details := make([]string, 0, len(problems))
+	set, unset := bson.D{}, bson.D{}
+	fixable := true
+	for _, problem := range problems {
+		details = append(details, problem.detail)
+		fixable = fixable && problem.fixable()
+		if problem.set != nil {
+			set = append(set, *problem.set)
+		}
+
+		if problem.unset != "" {
+			unset = append(unset, bson.E{Key: problem.unset, Value: ""})
+		}
+	}
+
+	malformed := service.MalformedPermission{
+		ID:         rawID(id),
+		Problem:    problems[0].problem,
+		Detail:     strings.Join(details, "; "),
+		Resolution: service.ResolutionReported,
+	}
+
+	if action == service.RepairReport {
+		return malformed, nil
+	}
+
+	if fixable {
+		fixed, err := s.fixDocument(ctx, id, set, unset)
+		if err != nil {
+			return malformed, err
+		}
+
+		if fixed {
+			malformed.Resolution = service.ResolutionFixed
+			return malformed, nil
+		}
+
+		malformed.Detail += "; the document doesn't match the schema of the permissions once fixed"
+	}
+
+	if action == service.RepairQuarantine {
+		if err := s.quarantine(ctx, document, malformed); err != nil {
+			return malformed, err
+		}
+
+		malformed.Resolution = service.ResolutionQuarantined
+	}
+
+	return malformed, nil
+}
+
+// fixDocument sets the fields of set and unsets the fields of unset of the document of id,
+// and returns whether the document matches the schema of the permissions once it's fixed.
+// A document that doesn't match it once it's fixed is left as it was.
+func (s MongoStore) fixDocument(ctx context.Context, id bson.RawValue, set bson.D, unset bson.D) (bool, error) {
+	update := bson.D{}
+	if len(set) > 0 {
+		update = append(update, bson.E{Key: "$set", Value: set})
+	}
+
+	if len(unset) > 0 {
+		update = append(update, bson.E{Key: "$unset", Value: unset})
+	}
+
+	fixed := false
+	err := s.transaction(ctx, func(ctx context.Context) error {
+		collection := s.collection(PermissionCollectionName)
+		idFilter := bson.D{bson.E{Key: MongoObjectIDField, Value: id}}
+		if _, err := collection.UpdateOne(ctx, idFilter, append(update, incVersion)); err != nil {
+			return err
+		}
+
+		invalid, err := collection.CountDocuments(ctx, append(idFilter, bson.E{Key: "$nor", Value: bson.A{
+			bson.D{bson.E{Key: "$jsonSchema", Value: permissionSchema()}},
+		}}))
+		if err != nil {
+			return err
+		}
+
+		if fixed = invalid == 0; !fixed {
+			return errNotFixed
+		}
+
+		return nil
+	})
+	if err == errNotFixed {
+		return false, nil
+	}
+
+	return fixed, err
+}
+
+// repairDuplicates finds the permission documents of the same user and resource, and keeps the one
+// that was updated last of each. The rest are quarantined by action, batchSize documents at a time.
+func (s MongoStore) repairDuplicates(
+	ctx context.Context,
+	action service.RepairAction,
+	batchSize int,
+	progress func(malformed []service.MalformedPermission) error,
+) error {
+	pipeline := mongo.Pipeline{
+		bson.D{bson.E{Key: "$match", Value: bson.D{
+			bson.E{Key: PermissionBSONFileIDField, Value: bson.D{bson.E{Key: "$type", Value: "string"}}},
+			bson.E{Key: PermissionBSONUserIDField, Value: bson.D{bson.E{Key: "$type", Value: "string"}}},
+		}}},
+		bson.D{bson.E{Key: "$group", Value: bson.D{
+			bson.E{Key: MongoObjectIDField, Value: bson.D{
+				bson.E{Key: PermissionBSONResourceTypeField, Value: bson.D{bson.E{
+					Key:   "$ifNull",
+					Value: bson.A{"$" + PermissionBSONResourceTypeField, service.DefaultResourceType},
+				}}},
+				bson.E{Key: PermissionBSONFileIDField, Value: "$" + PermissionBSONFileIDField},
+				bson.E{Key: PermissionBSONUserIDField, Value: "$" + PermissionBSONUserIDField},
+			}},
+			bson.E{Key: "ids", Value: bson.D{bson.E{Key: "$push", Value: "$" + MongoObjectIDField}}},
+			bson.E{Key: "count", Value: bson.D{bson.E{Key: "$sum", Value: 1}}},
+		}}},
+		bson.D{bson.E{Key: "$match", Value: bson.D{
+			bson.E{Key: "count", Value: bson.D{bson.E{Key: "$gt", Value: 1}}},
+		}}},
+	}
+
+	collection := s.collection(PermissionCollectionName)
+	cursor, err := collection.Aggregate(ctx, pipeline, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	batch := make([]service.MalformedPermission, 0, batchSize)
+	for cursor.Next(ctx) {
+		var group struct {
+			IDs bson.A `bson:"ids"`
+		}
+		if err := cursor.Decode(&group); err != nil {
+			return err
+		}
+
+		// The document that was updated last is kept.
+		opts := options.Find().SetSort(bson.D{
+			bson.E{Key: PermissionBSONVersionField, Value: -1},
+			bson.E{Key: MongoObjectIDField, Value: -1},
+		})
+		documents, err := collection.Find(ctx, bson.D{
+			bson.E{Key: MongoObjectIDField, Value: bson.D{bson.E{Key: "$in", Value: group.IDs}}},
+		}, opts)
+		if err != nil {
+			return err
+		}
+
+		var kept string
+		for documents.Next(ctx) {
+			document := append(bson.Raw(nil), documents.Current...)
+			id := rawID(document.Lookup(MongoObjectIDField))
+			if kept == "" {
+				kept = id
+				continue
+			}
+
+			malformed := service.MalformedPermission{
+				ID:         id,
+				Problem:    service.ProblemDuplicate,
+				Detail:     fmt.Sprintf("the document is of the same user and resource as %s, which is kept", kept),
+				Resolution: service.ResolutionReported,
+			}
+
+			if action != service.RepairReport {
+				if err := s.quarantine(ctx, document, malformed); err != nil {
+					documents.Close(ctx)
+					return err
+				}
+
+				malformed.Resolution = service.ResolutionQuarantined
+			}
+
+			if batch = append(batch, malformed); len(batch) == batchSize {
+				if err := progress(batch); err != nil {
+					documents.Close(ctx)
+					return err
+				}
+
+				batch = batch[:0]
+			}
+		}
+
+		err = documents.Err()
+		documents.Close(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+
+	if len(batch) > 0 {
+		return progress(batch)
+	}
+
+	return nil
+}
+
+// quarantine moves document, a malformed permission, from the permissions to the quarantine collection.
+func (s MongoStore) quarantine(
+	ctx context.Context,
+	document bson.Raw,
+	malformed service.MalformedPermission,
+) error {
+	id := document.Lookup(MongoObjectIDField)
+	idFilter := bson.D{bson.E{Key: MongoObjectIDField, Value: id}}
+	record := bson.D{
+		bson.E{Key: MongoObjectIDField, Value: id},
+		bson.E{Key: QuarantineBSONDocumentField, Value: document},
+		bson.E{Key: QuarantineBSONProblemField, Value: malformed.Problem},
+		bson.E{Key: QuarantineBSONDetailField, Value: malformed.Detail},
+		bson.E{Key: QuarantineBSONQuarantinedAtField, Value: time.Now()},
+	}
+
+	return s.transaction(ctx, func(ctx context.Context) error {
+		opts := options.Replace().SetUpsert(true)
+		if _, err := s.collection(QuarantineCollectionName).ReplaceOne(ctx, idFilter, record, opts); err != nil {
+			return err
+		}
+
+		_, err := s.collection(PermissionCollectionName).DeleteOne(ctx, idFilter)
+		return err
+	})
+}
+
+// checkPermissionDocument returns the problems of the required fields, the role and the fields that have
+// defaults, of document, or no problems if they're valid.
+func checkPermissionDocument(document bson.Raw) []documentProblem {
+	problems := []documentProblem{}
+	for _, field := range []string{
+		PermissionBSONFileIDField,
+		PermissionBSONUserIDField,
+		PermissionBSONCreatorField,
+	} {
+		value, err := document.LookupErr(field)
+		switch {
+		case err != nil:
+			problems = append(problems, documentProblem{
+				problem: service.ProblemMissingField,
+				detail:  fmt.Sprintf("%s is missing", field),
+			})
+		case value.Type != bsontype.String:
+			problems = append(problems, documentProblem{
+				problem: service.ProblemInvalidField,
+				detail:  fmt.Sprintf("%s is a %s rather than a string", field, value.Type),
+			})
+		case value.StringValue() == "" && field != PermissionBSONCreatorField:
+			problems = append(problems, documentProblem{
+				problem: service.ProblemMissingField,
+				detail:  fmt.Sprintf("%s is empty", field),
+			})
+		}
+	}
+
+	if problem, ok := checkRole(document); !ok {
+		problems = append(problems, problem)
+	}
+
+	// The fields that have defaults are the defaults if they're empty.
+	for _, field := range []string{
+		PermissionBSONResourceTypeField,
+		PermissionBSONResourceKindField,
+		PermissionBSONGranteeTypeField,
+		PermissionBSONInheritedFromField,
+	} {
+		if value, ok := document.Lookup(field).StringValueOK(); ok && value == "" {
+			problems = append(problems, documentProblem{
+				problem: service.ProblemInvalidField,
+				detail:  fmt.Sprintf("%s is empty rather than missing", field),
+				unset:   field,
+			})
+		}
+	}
+
+	return problems
+}
+
+// checkRole returns the problem of the role of document, and false, if it isn't stored as the value of a role.
+// A role that's stored as the name of a role, or as another type of number, is fixed to its value.
+func checkRole(document bson.Raw) (documentProblem, bool) {
+	value, err := document.LookupErr(PermissionBSONRoleField)
+	if err != nil {
+		return documentProblem{problem: service.ProblemMissingField, detail: "role is missing"}, false
+	}
+
+	var role int64
+	var ok bool
+	switch value.Type {
+	case bsontype.Int32:
+		if pb.Role_name[value.Int32()] != "" {
+			return documentProblem{}, true
+		}
+
+		role, ok = int64(value.Int32()), false
+	case bsontype.Int64:
+		role = value.Int64()
+		ok = role >= math.MinInt32 && role <= math.MaxInt32 && pb.Role_name[int32(role)] != ""
+	case bsontype.Double:
+		number := value.Double()
+		role = int64(number)
+		ok = number == math.Trunc(number) && role >= math.MinInt32 && role <= math.MaxInt32 &&
+			pb.Role_name[int32(role)] != ""
+	case bsontype.String:
+		name := value.StringValue()
+		if roleValue, exists := pb.Role_value[name]; exists {
+			return documentProblem{
+				problem: service.ProblemInvalidField,
+				detail:  fmt.Sprintf("role is the name %q rather than the value of the role", name),
+				set:     &bson.E{Key: PermissionBSONRoleField, Value: roleValue},
+			}, false
+		}
+
+		return documentProblem{
+			problem: service.ProblemUnknownRole,
+			detail:  fmt.Sprintf("role %q isn't one of the roles", name),
+		}, false
+	default:
+		return documentProblem{
+			problem: service.ProblemUnknownRole,
+			detail:  fmt.Sprintf("role is a %s rather than a role", value.Type),
+		}, false
+	}
+
+	if !ok {
+		return documentProblem{
+			problem: service.ProblemUnknownRole,
+			detail:  fmt.Sprintf("role %v isn't one of the roles", value),
+		}, false
+	}
+
+	return documentProblem{
+		problem: service.ProblemInvalidField,
+		detail:  fmt.Sprintf("role is a %s rather than a 32-bit integer", value.Type),
+		set:     &bson.E{Key: PermissionBSONRoleField, Value: int32(role)},
+	}, false
+}
+
+// rawID returns the string of id, the hex of an ObjectID or the extended JSON of any other ID.
+func rawID(id bson.RawValue) string {
+	if objectID, ok := id.ObjectIDOK(); ok {
+		return objectID.Hex()
+	}
+
+	return id.String()
+}
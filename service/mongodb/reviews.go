@@ -0,0 +1,658 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/meateam/permission-service/service"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// AccessReviewCollectionName is the name of the access review campaigns collection.
+	AccessReviewCollectionName = "accessReviews"
+
+	// AccessReviewItemCollectionName is the name of the collection of the items of the access review campaigns.
+	AccessReviewItemCollectionName = "accessReviewItems"
+
+	// AccessReviewBSONStateField is the name of the state field in the access review BSON.
+	AccessReviewBSONStateField = "state"
+
+	// AccessReviewBSONDecidedField is the name of the decided field in the access review BSON.
+	AccessReviewBSONDecidedField = "decided"
+
+	// AccessReviewBSONClosedByField is the name of the closedBy field in the access review BSON.
+	AccessReviewBSONClosedByField = "closedBy"
+
+	// AccessReviewBSONClosedAtField is the name of the closedAt field in the access review BSON.
+	AccessReviewBSONClosedAtField = "closedAt"
+
+	// AccessReviewBSONRevokeUndecidedField is the name of the revokeUndecided field in the access review BSON.
+	AccessReviewBSONRevokeUndecidedField = "revokeUndecided"
+
+	// AccessReviewBSONRevokedField is the name of the revoked field in the access review BSON.
+	AccessReviewBSONRevokedField = "revoked"
+
+	// AccessReviewBSONSkippedField is the name of the skipped field in the access review BSON.
+	AccessReviewBSONSkippedField = "skipped"
+
+	// AccessReviewItemBSONReviewIDField is the name of the reviewID field in the access review item BSON.
+	AccessReviewItemBSONReviewIDField = "reviewID"
+
+	// AccessReviewItemBSONReviewerField is the name of the reviewer field in the access review item BSON.
+	AccessReviewItemBSONReviewerField = "reviewer"
+
+	// AccessReviewItemBSONDecisionField is the name of the decision field in the access review item BSON.
+	AccessReviewItemBSONDecisionField = "decision"
+
+	// AccessReviewItemBSONDecidedByField is the name of the decidedBy field in the access review item BSON.
+	AccessReviewItemBSONDecidedByField = "decidedBy"
+
+	// AccessReviewItemBSONDecidedAtField is the name of the decidedAt field in the access review item BSON.
+	AccessReviewItemBSONDecidedAtField = "decidedAt"
+
+	// accessReviewItemBatchSize is the number of the items of a campaign that are inserted at a time.
+	accessReviewItemBatchSize = 1000
+)
+
+// accessReviewRecord is the structure that represents an access review campaign as it's stored.
+type accessReviewRecord struct {
+	ID              primitive.ObjectID `bson:"_id"`
+	Title           string             `bson:"title"`
+	ResourceType    string             `bson:"resourceType"`
+	FileIDs         []string           `bson:"fileIDs,omitempty"`
+	DefaultReviewer string             `bson:"defaultReviewer"`
+	CreatedBy       string             `bson:"createdBy,omitempty"`
+	CreatedAt       time.Time          `bson:"createdAt"`
+	State           string             `bson:"state"`
+	Items           int64              `bson:"items"`
+	Decided         int64              `bson:"decided"`
+	RevokeUndecided bool               `bson:"revokeUndecided,omitempty"`
+	ClosedBy        string             `bson:"closedBy,omitempty"`
+	ClosedAt        *time.Time         `bson:"closedAt,omitempty"`
+	Revoked         int64              `bson:"revoked,omitempty"`
+	Skipped         int64              `bson:"skipped,omitempty"`
+}
+
+// accessReview returns the service.AccessReview of r.
+func (r accessReviewRecord) accessReview() service.AccessReview {
+	return service.AccessReview{
+		ID:              r.ID.Hex(),
+		Title:           r.Title,
+		ResourceType:    r.ResourceType,
+		FileIDs:         r.FileIDs,
+		DefaultReviewer: r.DefaultReviewer,
+		CreatedBy:       r.CreatedBy,
+		CreatedAt:       r.CreatedAt,
+		State:           service.AccessReviewState(r.State),
+		Items:           r.Items,
+		Decided:         r.Decided,
+		RevokeUndecided: r.RevokeUndecided,
+		ClosedBy:        r.ClosedBy,
+		ClosedAt:        r.ClosedAt,
+		Revoked:         r.Revoked,
+		Skipped:         r.Skipped,
+	}
+}
+
+// accessReviewItemRecord is the structure that represents an item of an access review campaign as it's stored.
+type accessReviewItemRecord struct {
+	ID           primitive.ObjectID `bson:"_id"`
+	ReviewID     primitive.ObjectID `bson:"reviewID"`
+	PermissionID primitive.ObjectID `bson:"permissionID"`
+	ResourceType string             `bson:"resourceType"`
+	FileID       string             `bson:"fileID"`
+	UserID       string             `bson:"userID"`
+	Role         pb.Role            `bson:"role"`
+	Creator      string             `bson:"creator"`
+	Reviewer     string             `bson:"reviewer"`
+	Decision     string             `bson:"decision,omitempty"`
+	DecidedBy    string             `bson:"decidedBy,omitempty"`
+	DecidedAt    *time.Time         `bson:"decidedAt,omitempty"`
+}
+
+// accessReviewItem returns the service.AccessReviewItem of r.
+func (r accessReviewItemRecord) accessReviewItem() service.AccessReviewItem {
+	return service.AccessReviewItem{
+		ReviewID:     r.ReviewID.Hex(),
+		PermissionID: r.PermissionID.Hex(),
+		ResourceType: r.ResourceType,
+		FileID:       r.FileID,
+		UserID:       r.UserID,
+		Role:         r.Role,
+		Creator:      r.Creator,
+		Reviewer:     r.Reviewer,
+		Decision:     service.ReviewDecision(r.Decision),
+		DecidedBy:    r.DecidedBy,
+		DecidedAt:    r.DecidedAt,
+	}
+}
+
+// createAccessReviewIndexes creates the indexes that list the items of a campaign by their reviewers and
+// by their decisions, and that find the item of a permission.
+func (s MongoStore) createAccessReviewIndexes(ctx context.Context) error {
+	_, err := s.collection(AccessReviewItemCollectionName).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				bson.E{Key: AccessReviewItemBSONReviewIDField, Value: 1},
+				bson.E{Key: PermissionBSONFileIDField, Value: 1},
+				bson.E{Key: PermissionBSONUserIDField, Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{
+				bson.E{Key: AccessReviewItemBSONReviewIDField, Value: 1},
+				bson.E{Key: AccessReviewItemBSONReviewerField, Value: 1},
+				bson.E{Key: MongoObjectIDField, Value: 1},
+			},
+		},
+		{
+			Keys: bson.D{
+				bson.E{Key: AccessReviewItemBSONReviewIDField, Value: 1},
+				bson.E{Key: AccessReviewItemBSONDecisionField, Value: 1},
+				bson.E{Key: MongoObjectIDField, Value: 1},
+			},
+		},
+	})
+
+	return err
+}
+
+// CreateAccessReview snapshots the direct permissions of the resources of review as its items, each reviewed
+// by the owner of its resource, the user whose WRITE permission it gave itself, or by review's DefaultReviewer,
+// then stores review with their number, and returns it with its ID. The owners' own permissions aren't items.
+// The items are stored before the campaign, so that a campaign is never listed with only some of its items.
+func (s MongoStore) CreateAccessReview(
+	ctx context.Context,
+	review service.AccessReview,
+) (service.AccessReview, error) {
+	record := accessReviewRecord{
+		ID:              primitive.NewObjectID(),
+		Title:           review.Title,
+		ResourceType:    review.ResourceType,
+		FileIDs:         review.FileIDs,
+		DefaultReviewer: review.DefaultReviewer,
+		CreatedBy:       review.CreatedBy,
+		CreatedAt:       review.CreatedAt,
+		State:           string(review.State),
+	}
+
+	filter := bson.D{
+		bson.E{Key: PermissionBSONResourceTypeField, Value: review.ResourceType},
+		bson.E{Key: PermissionBSONInheritedFromField, Value: bson.D{bson.E{Key: "$exists", Value: false}}},
+	}
+
+	if len(review.FileIDs) > 0 {
+		filter = append(filter, bson.E{Key: PermissionBSONFileIDField, Value: bson.D{
+			bson.E{Key: "$in", Value: review.FileIDs},
+		}})
+	}
+
+	// The permissions are read in the order of the unique index of the files and their users,
+	// so that the permissions of each file are read together.
+	opts := options.Find().SetSort(bson.D{
+		bson.E{Key: PermissionBSONResourceTypeField, Value: 1},
+		bson.E{Key: PermissionBSONFileIDField, Value: 1},
+		bson.E{Key: PermissionBSONUserIDField, Value: 1},
+	})
+
+	cur, err := s.collection(PermissionCollectionName).Find(ctx, filter, opts)
+	if err != nil {
+		return service.AccessReview{}, err
+	}
+
+	defer cur.Close(ctx)
+
+	items := s.accessReviewItemWriter(ctx, record)
+	var file []BSON
+	for cur.Next(ctx) {
+		var permission BSON
+		if err := cur.Decode(&permission); err != nil {
+			return service.AccessReview{}, err
+		}
+
+		if len(file) > 0 && file[0].FileID != permission.FileID {
+			if err := items.add(file); err != nil {
+				return service.AccessReview{}, err
+			}
+
+			file = nil
+		}
+
+		file = append(file, permission)
+	}
+
+	if err := cur.Err(); err != nil {
+		return service.AccessReview{}, err
+	}
+
+	if err := items.add(file); err != nil {
+		return service.AccessReview{}, err
+	}
+
+	if err := items.flush(); err != nil {
+		return service.AccessReview{}, err
+	}
+
+	record.Items = items.count
+	if _, err := s.collection(AccessReviewCollectionName).InsertOne(ctx, record); err != nil {
+		return service.AccessReview{}, err
+	}
+
+	return record.accessReview(), nil
+}
+
+// accessReviewItemWriter inserts the items of a campaign in batches.
+type accessReviewItemWriter struct {
+	ctx    context.Context
+	store  MongoStore
+	review accessReviewRecord
+	batch  []interface{}
+	count  int64
+}
+
+// accessReviewItemWriter returns a writer of the items of review.
+func (s MongoStore) accessReviewItemWriter(
+	ctx context.Context,
+	review accessReviewRecord,
+) *accessReviewItemWriter {
+	return &accessReviewItemWriter{ctx: ctx, store: s, review: review}
+}
+
+// add adds the items of the permissions of a single file, which are reviewed by the file's owner,
+// or by the campaign's default reviewer if it has none.
+func (w *accessReviewItemWriter) add(permissions []BSON) error {
+	reviewer := w.review.DefaultReviewer
+	for _, permission := range permissions {
+		if permission.Role == pb.Role_WRITE && permission.Creator == permission.UserID {
+			reviewer = permission.UserID
+			break
+		}
+	}
+
+	for _, permission := range permissions {
+		if permission.UserID == reviewer && permission.Creator == reviewer {
+			continue
+		}
+
+		w.batch = append(w.batch, accessReviewItemRecord{
+			ID:           primitive.NewObjectID(),
+			ReviewID:     w.review.ID,
+			PermissionID: permission.ID,
+			ResourceType: permission.ResourceType,
+			FileID:       permission.FileID,
+			UserID:       permission.UserID,
+			Role:         permission.Role,
+			Creator:      permission.Creator,
+			Reviewer:     reviewer,
+		})
+
+		if len(w.batch) >= accessReviewItemBatchSize {
+			if err := w.flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// flush inserts the batched items.
+func (w *accessReviewItemWriter) flush() error {
+	if len(w.batch) == 0 {
+		return nil
+	}
+
+	if _, err := w.store.collection(AccessReviewItemCollectionName).InsertMany(w.ctx, w.batch); err != nil {
+		return err
+	}
+
+	w.count += int64(len(w.batch))
+	w.batch = nil
+	return nil
+}
+
+// GetAccessReview returns the campaign with id, fails with codes.NotFound if it doesn't exist.
+func (s MongoStore) GetAccessReview(ctx context.Context, id string) (service.AccessReview, error) {
+	filter, err := idFilter(id)
+	if err != nil {
+		return service.AccessReview{}, status.Errorf(codes.NotFound, "access review %s not found", id)
+	}
+
+	var record accessReviewRecord
+	err = s.collection(AccessReviewCollectionName).FindOne(ctx, filter).Decode(&record)
+	if err == mongo.ErrNoDocuments {
+		return service.AccessReview{}, status.Errorf(codes.NotFound, "access review %s not found", id)
+	}
+
+	if err != nil {
+		return service.AccessReview{}, err
+	}
+
+	return record.accessReview(), nil
+}
+
+// ListAccessReviews returns up to pageSize campaigns that come after pageToken, newest first,
+// and the token of the next page, which is empty if there are no more pages.
+func (s MongoStore) ListAccessReviews(
+	ctx context.Context,
+	pageSize int,
+	pageToken string,
+) ([]service.AccessReview, string, error) {
+	filter := bson.D{}
+	if pageToken != "" {
+		lastID, err := decodePageToken(pageToken)
+		if err != nil {
+			return nil, "", err
+		}
+
+		filter = append(filter, bson.E{Key: MongoObjectIDField, Value: bson.D{bson.E{Key: "$lt", Value: lastID}}})
+	}
+
+	// Fetch one more campaign than needed to know whether there's a next page.
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: MongoObjectIDField, Value: -1}}).
+		SetLimit(int64(pageSize) + 1)
+
+	cur, err := s.collection(AccessReviewCollectionName).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, "", err
+	}
+
+	defer cur.Close(ctx)
+
+	reviews := []service.AccessReview{}
+	for cur.Next(ctx) {
+		var record accessReviewRecord
+		if err := cur.Decode(&record); err != nil {
+			return nil, "", err
+		}
+
+		reviews = append(reviews, record.accessReview())
+	}
+
+	if err := cur.Err(); err != nil {
+		return nil, "", err
+	}
+
+	if len(reviews) <= pageSize {
+		return reviews, "", nil
+	}
+
+	reviews = reviews[:pageSize]
+	return reviews, encodePageToken(reviews[pageSize-1].ID), nil
+}
+
+// ListAccessReviewItems returns up to pageSize items of the campaign with reviewID that match filter
+// and come after pageToken, in the order they were created, and the token of the next page,
+// which is empty if there are no more pages.
+func (s MongoStore) ListAccessReviewItems(
+	ctx context.Context,
+	reviewID string,
+	filter service.AccessReviewItemFilter,
+	pageSize int,
+	pageToken string,
+) ([]service.AccessReviewItem, string, error) {
+	objectID, err := primitive.ObjectIDFromHex(reviewID)
+	if err != nil {
+		return nil, "", status.Errorf(codes.NotFound, "access review %s not found", reviewID)
+	}
+
+	itemsFilter := bson.D{bson.E{Key: AccessReviewItemBSONReviewIDField, Value: objectID}}
+	if filter.Reviewer != "" {
+		itemsFilter = append(itemsFilter, bson.E{Key: AccessReviewItemBSONReviewerField, Value: filter.Reviewer})
+	}
+
+	if len(filter.Decisions) > 0 {
+		decisions := make(bson.A, 0, len(filter.Decisions))
+		for _, decision := range filter.Decisions {
+			if decision == service.ReviewPending {
+				// The pending items have no decision.
+				decisions = append(decisions, nil)
+				continue
+			}
+
+			decisions = append(decisions, string(decision))
+		}
+
+		itemsFilter = append(itemsFilter, bson.E{Key: AccessReviewItemBSONDecisionField, Value: bson.D{
+			bson.E{Key: "$in", Value: decisions},
+		}})
+	}
+
+	if pageToken != "" {
+		lastID, err := decodePageToken(pageToken)
+		if err != nil {
+			return nil, "", err
+		}
+
+		itemsFilter = append(itemsFilter, bson.E{Key: MongoObjectIDField, Value: bson.D{
+			bson.E{Key: "$gt", Value: lastID},
+		}})
+	}
+
+	// Fetch one more item than needed to know whether there's a next page.
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: MongoObjectIDField, Value: 1}}).
+		SetLimit(int64(pageSize) + 1)
+
+	cur, err := s.collection(AccessReviewItemCollectionName).Find(ctx, itemsFilter, opts)
+	if err != nil {
+		return nil, "", err
+	}
+
+	defer cur.Close(ctx)
+
+	records := []accessReviewItemRecord{}
+	for cur.Next(ctx) {
+		var record accessReviewItemRecord
+		if err := cur.Decode(&record); err != nil {
+			return nil, "", err
+		}
+
+		records = append(records, record)
+	}
+
+	if err := cur.Err(); err != nil {
+		return nil, "", err
+	}
+
+	nextPageToken := ""
+	if len(records) > pageSize {
+		records = records[:pageSize]
+		nextPageToken = encodePageToken(records[pageSize-1].ID.Hex())
+	}
+
+	items := make([]service.AccessReviewItem, 0, len(records))
+	for _, record := range records {
+		items = append(items, record.accessReviewItem())
+	}
+
+	return items, nextPageToken, nil
+}
+
+// DecideAccessReviewItems records decisions of the items of the open campaign with reviewID, by reviewer
+// at decidedAt, in a single transaction, and returns the decided items. If reviewer isn't empty then
+// only its items may be decided. The campaign's number of decided items is updated in the same transaction,
+// which conflicts with the transaction that starts closing it, so that no decision is recorded after
+// the campaign's revocations are read.
+func (s MongoStore) DecideAccessReviewItems(
+	ctx context.Context,
+	reviewID string,
+	reviewer string,
+	decisions []service.AccessReviewDecision,
+	decidedAt time.Time,
+) ([]service.AccessReviewItem, error) {
+	filter, err := idFilter(reviewID)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "access review %s not found", reviewID)
+	}
+
+	objectID := filter[0].Value.(primitive.ObjectID)
+	var items []service.AccessReviewItem
+	err = s.transaction(ctx, func(ctx context.Context) error {
+		items = make([]service.AccessReviewItem, 0, len(decisions))
+		var newlyDecided int64
+		for _, decision := range decisions {
+			itemFilter := bson.D{
+				bson.E{Key: AccessReviewItemBSONReviewIDField, Value: objectID},
+				bson.E{Key: PermissionBSONFileIDField, Value: decision.FileID},
+				bson.E{Key: PermissionBSONUserIDField, Value: decision.UserID},
+			}
+
+			if reviewer != "" {
+				itemFilter = append(itemFilter, bson.E{Key: AccessReviewItemBSONReviewerField, Value: reviewer})
+			}
+
+			update := bson.D{bson.E{Key: "$set", Value: bson.D{
+				bson.E{Key: AccessReviewItemBSONDecisionField, Value: string(decision.Decision)},
+				bson.E{Key: AccessReviewItemBSONDecidedByField, Value: reviewer},
+				bson.E{Key: AccessReviewItemBSONDecidedAtField, Value: decidedAt},
+			}}}
+
+			// The item is returned as it was before the update, to tell whether it was pending.
+			var previous accessReviewItemRecord
+			err := s.collection(AccessReviewItemCollectionName).
+				FindOneAndUpdate(ctx, itemFilter, update).
+				Decode(&previous)
+			if err == mongo.ErrNoDocuments {
+				return status.Errorf(
+					codes.NotFound,
+					"the permission of %s to %s isn't reviewed by %s in access review %s",
+					decision.UserID,
+					decision.FileID,
+					reviewer,
+					reviewID,
+				)
+			}
+
+			if err != nil {
+				return err
+			}
+
+			if previous.Decision == "" {
+				newlyDecided++
+			}
+
+			decided := previous
+			decided.Decision, decided.DecidedBy, decided.DecidedAt = string(decision.Decision), reviewer, &decidedAt
+			items = append(items, decided.accessReviewItem())
+		}
+
+		openFilter := append(filter, bson.E{Key: AccessReviewBSONStateField, Value: string(service.AccessReviewOpen)})
+		update := bson.D{bson.E{Key: "$inc", Value: bson.D{
+			bson.E{Key: AccessReviewBSONDecidedField, Value: newlyDecided},
+		}}}
+
+		result, err := s.collection(AccessReviewCollectionName).UpdateOne(ctx, openFilter, update)
+		if err != nil {
+			return err
+		}
+
+		if result.MatchedCount == 0 {
+			if _, err := s.GetAccessReview(ctx, reviewID); err != nil {
+				return err
+			}
+
+			return status.Errorf(codes.FailedPrecondition, "access review %s isn't open", reviewID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// StartClosingAccessReview sets the state of the open, or closing, campaign with id to AccessReviewClosing,
+// by closedBy, so that its items may no longer be decided, and returns it. Fails with codes.FailedPrecondition
+// if the campaign is already closed, or with codes.NotFound if it doesn't exist.
+func (s MongoStore) StartClosingAccessReview(
+	ctx context.Context,
+	id string,
+	closedBy string,
+	revokeUndecided bool,
+) (service.AccessReview, error) {
+	filter, err := idFilter(id)
+	if err != nil {
+		return service.AccessReview{}, status.Errorf(codes.NotFound, "access review %s not found", id)
+	}
+
+	notClosedFilter := append(filter, bson.E{Key: AccessReviewBSONStateField, Value: bson.D{
+		bson.E{Key: "$in", Value: bson.A{string(service.AccessReviewOpen), string(service.AccessReviewClosing)}},
+	}})
+	update := bson.D{bson.E{Key: "$set", Value: bson.D{
+		bson.E{Key: AccessReviewBSONStateField, Value: string(service.AccessReviewClosing)},
+		bson.E{Key: AccessReviewBSONClosedByField, Value: closedBy},
+		bson.E{Key: AccessReviewBSONRevokeUndecidedField, Value: revokeUndecided},
+	}}}
+
+	return s.updateAccessReview(ctx, id, notClosedFilter, update)
+}
+
+// FinishClosingAccessReview sets the state of the closing campaign with id to AccessReviewClosed,
+// with the numbers of the revoked and skipped items, at closedAt, and returns it.
+func (s MongoStore) FinishClosingAccessReview(
+	ctx context.Context,
+	id string,
+	revoked int64,
+	skipped int64,
+	closedAt time.Time,
+) (service.AccessReview, error) {
+	filter, err := idFilter(id)
+	if err != nil {
+		return service.AccessReview{}, status.Errorf(codes.NotFound, "access review %s not found", id)
+	}
+
+	closingFilter := append(filter, bson.E{
+		Key:   AccessReviewBSONStateField,
+		Value: string(service.AccessReviewClosing),
+	})
+	update := bson.D{bson.E{Key: "$set", Value: bson.D{
+		bson.E{Key: AccessReviewBSONStateField, Value: string(service.AccessReviewClosed)},
+		bson.E{Key: AccessReviewBSONRevokedField, Value: revoked},
+		bson.E{Key: AccessReviewBSONSkippedField, Value: skipped},
+		bson.E{Key: AccessReviewBSONClosedAtField, Value: closedAt},
+	}}}
+
+	return s.updateAccessReview(ctx, id, closingFilter, update)
+}
+
+// updateAccessReview applies update to the campaign with id if it matches filter, and returns it.
+// Fails with codes.FailedPrecondition if the campaign doesn't match filter, since it's already closed.
+func (s MongoStore) updateAccessReview(
+	ctx context.Context,
+	id string,
+	filter bson.D,
+	update bson.D,
+) (service.AccessReview, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var record accessReviewRecord
+	err := s.collection(AccessReviewCollectionName).FindOneAndUpdate(ctx, filter, update, opts).Decode(&record)
+	if err == mongo.ErrNoDocuments {
+		if _, err := s.GetAccessReview(ctx, id); err != nil {
+			return service.AccessReview{}, err
+		}
+
+		return service.AccessReview{}, status.Errorf(
+			codes.FailedPrecondition,
+			"access review %s is already closed",
+			id,
+		)
+	}
+
+	if err != nil {
+		return service.AccessReview{}, err
+	}
+
+	return record.accessReview(), nil
+}
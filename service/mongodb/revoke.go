@@ -0,0 +1,83 @@
+package mongodb
+
+import (
+	"context"
+
+	"github.com/meateam/permission-service/service"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// OutboxBSONRevokedField is the name of the field of the revoked permissions in the outbox event BSON,
+// of the events of service.EventSharingStopped.
+const OutboxBSONRevokedField = "revoked"
+
+// DeleteAllExceptUser deletes every permission to fileID other than the permission of userID, with a single
+// delete in a transaction, and returns them. A single event of service.EventSharingStopped, rather than an event
+// of each permission, is written to the outbox, if it's enabled, in the same transaction.
+// Transactions require mongodb to be a replica set.
+func (s MongoStore) DeleteAllExceptUser(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+) ([]service.Permission, error) {
+	var permissions []service.Permission
+	err := s.transaction(ctx, func(ctx context.Context) (err error) {
+		permissions, err = s.deleteAllExceptUser(ctx, resourceType, fileID, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return permissions, nil
+}
+
+// deleteAllExceptUser deletes the permissions to fileID other than the permission of userID
+// in the transaction of ctx.
+func (s MongoStore) deleteAllExceptUser(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+) ([]service.Permission, error) {
+	filter := append(
+		resourceFilter(resourceType, fileID),
+		bson.E{Key: PermissionBSONUserIDField, Value: bson.D{bson.E{Key: "$ne", Value: userID}}},
+	)
+	permissions, err := s.find(ctx, filter)
+	if err != nil || len(permissions) == 0 {
+		return permissions, err
+	}
+
+	ids := make(bson.A, 0, len(permissions))
+	revoked := make([]BSON, 0, len(permissions))
+	for _, permission := range permissions {
+		permission := permission.(*BSON)
+		ids = append(ids, permission.ID)
+		revoked = append(revoked, *permission)
+	}
+
+	_, err = s.collection(PermissionCollectionName).DeleteMany(ctx, bson.D{
+		bson.E{Key: MongoObjectIDField, Value: bson.D{bson.E{Key: "$in", Value: ids}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.outbox {
+		return permissions, nil
+	}
+
+	record := newOutboxRecord(service.EventSharingStopped, &BSON{
+		ResourceType: resourceType,
+		FileID:       fileID,
+		UserID:       userID,
+	})
+	record.Revoked = revoked
+	if err := s.writeEvents(ctx, []interface{}{record}); err != nil {
+		return nil, err
+	}
+
+	return permissions, nil
+}
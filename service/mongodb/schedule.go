@@ -0,0 +1,188 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/meateam/permission-service/service"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// ScheduleCollectionName is the name of the scheduled updates collection.
+	ScheduleCollectionName = "scheduledUpdates"
+
+	// ScheduleBSONScheduledAtField is the name of the scheduledAt field in the scheduled update BSON.
+	ScheduleBSONScheduledAtField = "scheduledAt"
+
+	// ScheduleBSONClaimedUntilField is the name of the claimedUntil field in the scheduled update BSON.
+	ScheduleBSONClaimedUntilField = "claimedUntil"
+
+	// ScheduleBSONFailedAtField is the name of the failedAt field in the scheduled update BSON.
+	ScheduleBSONFailedAtField = "failedAt"
+
+	// ScheduleBSONErrorField is the name of the error field in the scheduled update BSON.
+	ScheduleBSONErrorField = "error"
+)
+
+// scheduledUpdateRecord is the structure that represents a scheduled update as it's stored.
+type scheduledUpdateRecord struct {
+	ID           primitive.ObjectID        `bson:"_id"`
+	ResourceType string                    `bson:"resourceType"`
+	FileID       string                    `bson:"fileID"`
+	UserID       string                    `bson:"userID"`
+	Update       service.PermissionUpdate  `bson:"update"`
+	Fields       []service.PermissionField `bson:"fields"`
+	ScheduledAt  time.Time                 `bson:"scheduledAt"`
+
+	// ClaimedUntil is the time until which the update is being applied.
+	ClaimedUntil *time.Time `bson:"claimedUntil,omitempty"`
+
+	// FailedAt is the time the update failed to apply, failed updates aren't applied again.
+	FailedAt *time.Time `bson:"failedAt,omitempty"`
+	Error    string     `bson:"error,omitempty"`
+}
+
+// createScheduleIndex creates the index that finds the due scheduled updates.
+func (s MongoStore) createScheduleIndex(ctx context.Context) error {
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{
+			bson.E{
+				Key:   ScheduleBSONFailedAtField,
+				Value: 1,
+			},
+			bson.E{
+				Key:   ScheduleBSONScheduledAtField,
+				Value: 1,
+			},
+		},
+	}
+
+	_, err := s.collection(ScheduleCollectionName).Indexes().CreateOne(ctx, indexModel)
+	return err
+}
+
+// ScheduleUpdate stores update and returns it with its ID.
+func (s MongoStore) ScheduleUpdate(
+	ctx context.Context,
+	update service.ScheduledUpdate,
+) (service.ScheduledUpdate, error) {
+	record := scheduledUpdateRecord{
+		ID:           primitive.NewObjectID(),
+		ResourceType: update.ResourceType,
+		FileID:       update.FileID,
+		UserID:       update.UserID,
+		Update:       update.Update,
+		Fields:       update.Fields,
+		ScheduledAt:  update.ScheduledAt,
+	}
+
+	if _, err := s.collection(ScheduleCollectionName).InsertOne(ctx, record); err != nil {
+		return service.ScheduledUpdate{}, err
+	}
+
+	update.ID = record.ID.Hex()
+	return update, nil
+}
+
+// ClaimDueUpdate claims the earliest update that's scheduled at or before now, that didn't fail and isn't
+// claimed, until now plus lease, and returns it. An update whose claim expired, such as one that was
+// claimed by an instance that stopped before applying it, is claimed again.
+// Returns nil if there are no due updates.
+func (s MongoStore) ClaimDueUpdate(
+	ctx context.Context,
+	now time.Time,
+	lease time.Duration,
+) (*service.ScheduledUpdate, error) {
+	filter := bson.D{
+		bson.E{Key: ScheduleBSONFailedAtField, Value: nil},
+		bson.E{Key: ScheduleBSONScheduledAtField, Value: bson.D{bson.E{Key: "$lte", Value: now}}},
+		bson.E{
+			Key: "$or",
+			Value: bson.A{
+				bson.D{bson.E{Key: ScheduleBSONClaimedUntilField, Value: nil}},
+				bson.D{bson.E{Key: ScheduleBSONClaimedUntilField, Value: bson.D{bson.E{Key: "$lte", Value: now}}}},
+			},
+		},
+	}
+
+	update := bson.D{
+		bson.E{
+			Key:   "$set",
+			Value: bson.D{bson.E{Key: ScheduleBSONClaimedUntilField, Value: now.Add(lease)}},
+		},
+	}
+
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{bson.E{Key: ScheduleBSONScheduledAtField, Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var record scheduledUpdateRecord
+	err := s.collection(ScheduleCollectionName).FindOneAndUpdate(ctx, filter, update, opts).Decode(&record)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &service.ScheduledUpdate{
+		ID:           record.ID.Hex(),
+		ResourceType: record.ResourceType,
+		FileID:       record.FileID,
+		UserID:       record.UserID,
+		Update:       record.Update,
+		Fields:       record.Fields,
+		ScheduledAt:  record.ScheduledAt,
+	}, nil
+}
+
+// CompleteUpdate deletes the update with id after it was applied.
+func (s MongoStore) CompleteUpdate(ctx context.Context, id string) error {
+	filter, err := idFilter(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.collection(ScheduleCollectionName).DeleteOne(ctx, filter)
+	return err
+}
+
+// FailUpdate marks the update with id as failed with message, so that it isn't claimed again.
+func (s MongoStore) FailUpdate(ctx context.Context, id string, message string) error {
+	filter, err := idFilter(id)
+	if err != nil {
+		return err
+	}
+
+	update := bson.D{
+		bson.E{
+			Key: "$set",
+			Value: bson.D{
+				bson.E{Key: ScheduleBSONFailedAtField, Value: time.Now()},
+				bson.E{Key: ScheduleBSONErrorField, Value: message},
+			},
+		},
+	}
+
+	_, err = s.collection(ScheduleCollectionName).UpdateOne(ctx, filter, update)
+	return err
+}
+
+// PurgeFailedUpdates deletes the updates that failed before failedBefore and returns their number.
+func (s MongoStore) PurgeFailedUpdates(ctx context.Context, failedBefore time.Time) (int64, error) {
+	filter := bson.D{
+		bson.E{Key: ScheduleBSONFailedAtField, Value: bson.D{bson.E{Key: "$lt", Value: failedBefore}}},
+	}
+
+	res, err := s.collection(ScheduleCollectionName).DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.DeletedCount, nil
+}
@@ -0,0 +1,134 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/meateam/permission-service/service"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	// SchemaValidationError rejects the writes of permissions that don't match the schema.
+	SchemaValidationError = "error"
+
+	// SchemaValidationWarn logs the writes of permissions that don't match the schema in the mongodb log,
+	// and allows them.
+	SchemaValidationWarn = "warn"
+
+	// SchemaValidationOff removes the schema validator of the permissions collection.
+	SchemaValidationOff = "off"
+
+	// maxIDLength is the maximum length of the identifiers of a stored permission, such as its fileID
+	// and userID, which is long enough for the identifiers to be encrypted.
+	maxIDLength = 1024
+
+	// maxTextLength is the maximum length of the free text of a stored permission, such as its message.
+	maxTextLength = 65536
+)
+
+// InstallSchema installs the JSON schema validator of the permissions collection, which matches
+// the permissions of the store, with validation, either SchemaValidationError or SchemaValidationWarn,
+// or removes the validator if validation is SchemaValidationOff. The validator catches corrupt writes,
+// such as of manual edits. Permissions that were stored before it was installed and don't match it
+// may still be updated, but a permission that matches it may not be updated to not match it.
+func (s MongoStore) InstallSchema(ctx context.Context, validation string) error {
+	validator := bson.D{}
+	switch validation {
+	case SchemaValidationError, SchemaValidationWarn:
+		validator = bson.D{bson.E{Key: "$jsonSchema", Value: permissionSchema()}}
+	case SchemaValidationOff:
+		validation = SchemaValidationError
+	default:
+		return fmt.Errorf("unknown schema validation %q", validation)
+	}
+
+	command := bson.D{
+		bson.E{Key: "collMod", Value: s.collectionPrefix + PermissionCollectionName},
+		bson.E{Key: "validator", Value: validator},
+		bson.E{Key: "validationLevel", Value: "moderate"},
+		bson.E{Key: "validationAction", Value: validation},
+	}
+
+	if err := s.DB.RunCommand(ctx, command).Err(); err != nil {
+		return fmt.Errorf("failed installing the schema of the permissions collection: %v", err)
+	}
+
+	return nil
+}
+
+// permissionSchema returns the JSON schema of the stored permissions, see BSON.
+// Fields that were introduced after permissions were stored without them aren't required.
+func permissionSchema() bson.D {
+	roles := make([]int, 0, len(pb.Role_name))
+	for role := range pb.Role_name {
+		roles = append(roles, int(role))
+	}
+
+	sort.Ints(roles)
+	roleValues := make(bson.A, 0, len(roles))
+	for _, role := range roles {
+		roleValues = append(roleValues, int32(role))
+	}
+
+	return bson.D{
+		bson.E{Key: "bsonType", Value: "object"},
+		bson.E{Key: "required", Value: bson.A{
+			PermissionBSONFileIDField,
+			PermissionBSONUserIDField,
+			PermissionBSONRoleField,
+			PermissionBSONCreatorField,
+		}},
+		bson.E{Key: "properties", Value: bson.D{
+			bson.E{Key: MongoObjectIDField, Value: bson.D{bson.E{Key: "bsonType", Value: "objectId"}}},
+			bson.E{Key: PermissionBSONResourceTypeField, Value: stringSchema(1, maxIDLength)},
+			bson.E{Key: PermissionBSONFileIDField, Value: stringSchema(1, maxIDLength)},
+			bson.E{Key: PermissionBSONUserIDField, Value: stringSchema(1, maxIDLength)},
+			bson.E{Key: PermissionBSONRoleField, Value: bson.D{
+				bson.E{Key: "bsonType", Value: bson.A{"int", "long"}},
+				bson.E{Key: "enum", Value: roleValues},
+			}},
+			bson.E{Key: PermissionBSONCreatorField, Value: stringSchema(0, maxIDLength)},
+			bson.E{Key: PermissionBSONCanReshareField, Value: bson.D{bson.E{Key: "bsonType", Value: "bool"}}},
+			bson.E{Key: PermissionBSONMessageField, Value: stringSchema(0, maxTextLength)},
+			bson.E{Key: PermissionBSONLabelField, Value: stringSchema(0, maxTextLength)},
+			bson.E{Key: PermissionBSONLastAccessedAtField, Value: bson.D{bson.E{Key: "bsonType", Value: "date"}}},
+			bson.E{Key: PermissionBSONVersionField, Value: bson.D{
+				bson.E{Key: "bsonType", Value: bson.A{"int", "long"}},
+				bson.E{Key: "minimum", Value: 0},
+			}},
+			bson.E{Key: PermissionBSONSharingChainField, Value: bson.D{
+				bson.E{Key: "bsonType", Value: "array"},
+				bson.E{Key: "items", Value: stringSchema(1, maxIDLength)},
+			}},
+			bson.E{Key: PermissionBSONResourceKindField, Value: bson.D{
+				bson.E{Key: "enum", Value: bson.A{service.ResourceKindFile, service.ResourceKindFolder}},
+			}},
+			bson.E{Key: PermissionBSONGranteeTypeField, Value: bson.D{
+				bson.E{Key: "enum", Value: bson.A{service.GranteeTypeUser, service.GranteeTypeDomain}},
+			}},
+			bson.E{Key: PermissionBSONLabelsField, Value: bson.D{
+				bson.E{Key: "bsonType", Value: "object"},
+				bson.E{Key: "maxProperties", Value: service.MaxLabels},
+				bson.E{Key: "additionalProperties", Value: stringSchema(0, service.MaxLabelValueLength)},
+			}},
+			bson.E{Key: PermissionBSONSourceField, Value: stringSchema(0, maxIDLength)},
+			bson.E{Key: PermissionBSONInheritedFromField, Value: stringSchema(1, maxIDLength)},
+			bson.E{Key: PermissionBSONSchemaVersionField, Value: bson.D{
+				bson.E{Key: "bsonType", Value: bson.A{"int", "long"}},
+				bson.E{Key: "minimum", Value: 0},
+			}},
+		}},
+	}
+}
+
+// stringSchema returns the JSON schema of a string of minLength to maxLength characters.
+func stringSchema(minLength int, maxLength int) bson.D {
+	return bson.D{
+		bson.E{Key: "bsonType", Value: "string"},
+		bson.E{Key: "minLength", Value: minLength},
+		bson.E{Key: "maxLength", Value: maxLength},
+	}
+}
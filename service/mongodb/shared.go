@@ -0,0 +1,91 @@
+package mongodb
+
+import (
+	"context"
+
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/meateam/permission-service/service"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// sharedFileResult is the structure of a file that's returned by the shared files aggregation.
+type sharedFileResult struct {
+	FileID string `bson:"_id"`
+	Roles  []struct {
+		UserID string  `bson:"userID"`
+		Role   pb.Role `bson:"role"`
+	} `bson:"roles"`
+}
+
+// GetShared retrieves the resources of resourceType that both userA and userB have a permission to,
+// sorted by their IDs. If grantedByA is true then only the resources that userA shared with userB are retrieved.
+func (s MongoStore) GetShared(
+	ctx context.Context,
+	resourceType string,
+	userA string,
+	userB string,
+	grantedByA bool,
+) ([]service.SharedFile, error) {
+	userBFilter := bson.D{bson.E{Key: PermissionBSONUserIDField, Value: userB}}
+	if grantedByA {
+		userBFilter = append(userBFilter, bson.E{Key: PermissionBSONCreatorField, Value: userA})
+	}
+
+	match := bson.D{
+		resourceTypeFilter(resourceType),
+		bson.E{
+			Key: "$or",
+			Value: bson.A{
+				bson.D{bson.E{Key: PermissionBSONUserIDField, Value: userA}},
+				userBFilter,
+			},
+		},
+	}
+
+	// Group the permissions of both users by their file, and keep the files that both have a permission to.
+	pipeline := bson.A{
+		bson.D{bson.E{Key: "$match", Value: match}},
+		bson.D{bson.E{Key: "$group", Value: bson.D{
+			bson.E{Key: MongoObjectIDField, Value: "$" + PermissionBSONFileIDField},
+			bson.E{Key: "roles", Value: bson.D{bson.E{Key: "$push", Value: bson.D{
+				bson.E{Key: PermissionBSONUserIDField, Value: "$" + PermissionBSONUserIDField},
+				bson.E{Key: PermissionBSONRoleField, Value: "$" + PermissionBSONRoleField},
+			}}}},
+		}}},
+		bson.D{bson.E{Key: "$match", Value: bson.D{
+			bson.E{Key: "roles.1", Value: bson.D{bson.E{Key: "$exists", Value: true}}},
+		}}},
+		bson.D{bson.E{Key: "$sort", Value: bson.D{bson.E{Key: MongoObjectIDField, Value: 1}}}},
+	}
+
+	cur, err := s.readCollection(ctx, PermissionCollectionName).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	sharedFiles := []service.SharedFile{}
+	for cur.Next(ctx) {
+		var result sharedFileResult
+		if err := cur.Decode(&result); err != nil {
+			return nil, err
+		}
+
+		sharedFile := service.SharedFile{FileID: result.FileID}
+		for _, role := range result.Roles {
+			if role.UserID == userA {
+				sharedFile.UserARole = role.Role
+			} else {
+				sharedFile.UserBRole = role.Role
+			}
+		}
+
+		sharedFiles = append(sharedFiles, sharedFile)
+	}
+
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+
+	return sharedFiles, nil
+}
@@ -0,0 +1,316 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/meateam/permission-service/service"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// SharedWithMeCollectionName is the name of the collection of the projection of the files
+	// that were shared with each user.
+	SharedWithMeCollectionName = "sharedWithMe"
+
+	// SharedWithMeBSONSharedByField is the name of the sharedBy field of the shared with me BSON.
+	SharedWithMeBSONSharedByField = "sharedBy"
+
+	// SharedWithMeBSONSharedAtField is the name of the sharedAt field of the shared with me BSON.
+	SharedWithMeBSONSharedAtField = "sharedAt"
+
+	// SharedWithMeBSONSequenceField is the name of the sequence field of the shared with me BSON,
+	// which orders the files that were shared with a user by the time they were shared.
+	SharedWithMeBSONSequenceField = "sequence"
+)
+
+// sharedWithMeRecord is the structure that represents a file that was shared with a user as it's projected.
+// Its ID is the ID of the permission of the user to the file.
+type sharedWithMeRecord struct {
+	ID           primitive.ObjectID `bson:"_id"`
+	ResourceType string             `bson:"resourceType"`
+	FileID       string             `bson:"fileID"`
+	UserID       string             `bson:"userID"`
+	Role         pb.Role            `bson:"role"`
+	SharedBy     string             `bson:"sharedBy"`
+	SharedAt     time.Time          `bson:"sharedAt"`
+
+	// Sequence is the ID of the event that last shared the file with the user.
+	Sequence primitive.ObjectID `bson:"sequence"`
+}
+
+// WithSharedWithMe returns a copy of s that projects the files that were shared with each user as their
+// permissions change, in the same transaction as the events of the changes. The projection requires the
+// outbox, and is backfilled from the permissions if it's empty.
+func (s MongoStore) WithSharedWithMe(ctx context.Context) (MongoStore, error) {
+	if !s.outbox {
+		return MongoStore{}, fmt.Errorf("the shared with me projection requires the outbox")
+	}
+
+	collection := s.collection(SharedWithMeCollectionName)
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			bson.E{Key: PermissionBSONUserIDField, Value: 1},
+			bson.E{Key: PermissionBSONResourceTypeField, Value: 1},
+			bson.E{Key: SharedWithMeBSONSequenceField, Value: -1},
+		},
+	})
+	if err != nil {
+		return MongoStore{}, err
+	}
+
+	projected, err := collection.EstimatedDocumentCount(ctx)
+	if err != nil {
+		return MongoStore{}, err
+	}
+
+	if projected == 0 {
+		if err := s.backfillSharedWithMe(ctx); err != nil {
+			return MongoStore{}, fmt.Errorf("failed backfilling the shared with me projection: %v", err)
+		}
+	}
+
+	s.sharedWithMe = true
+	return s, nil
+}
+
+// backfillSharedWithMe projects the permissions that were shared with their users, as of their creation.
+func (s MongoStore) backfillSharedWithMe(ctx context.Context) error {
+	pipeline := mongo.Pipeline{
+		bson.D{bson.E{Key: "$match", Value: bson.D{
+			bson.E{Key: PermissionBSONInheritedFromField, Value: nil},
+			bson.E{Key: PermissionBSONCreatorField, Value: bson.D{bson.E{Key: "$nin", Value: bson.A{"", nil}}}},
+			bson.E{Key: "$expr", Value: bson.D{bson.E{
+				Key:   "$ne",
+				Value: bson.A{"$" + PermissionBSONCreatorField, "$" + PermissionBSONUserIDField},
+			}}},
+		}}},
+		bson.D{bson.E{Key: "$project", Value: bson.D{
+			bson.E{Key: PermissionBSONResourceTypeField, Value: bson.D{bson.E{
+				Key:   "$ifNull",
+				Value: bson.A{"$" + PermissionBSONResourceTypeField, service.DefaultResourceType},
+			}}},
+			bson.E{Key: PermissionBSONFileIDField, Value: 1},
+			bson.E{Key: PermissionBSONUserIDField, Value: 1},
+			bson.E{Key: PermissionBSONRoleField, Value: 1},
+			bson.E{Key: SharedWithMeBSONSharedByField, Value: "$" + PermissionBSONCreatorField},
+			bson.E{Key: SharedWithMeBSONSharedAtField, Value: bson.D{bson.E{Key: "$toDate", Value: "$_id"}}},
+			bson.E{Key: SharedWithMeBSONSequenceField, Value: "$_id"},
+		}}},
+		bson.D{bson.E{Key: "$merge", Value: bson.D{
+			bson.E{Key: "into", Value: s.collectionPrefix + SharedWithMeCollectionName},
+			bson.E{Key: "whenMatched", Value: "keepExisting"},
+			bson.E{Key: "whenNotMatched", Value: "insert"},
+		}}},
+	}
+
+	cursor, err := s.collection(PermissionCollectionName).Aggregate(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+
+	return cursor.Close(ctx)
+}
+
+// writeEvents writes records, the outbox records of events, to the outbox,
+// and projects them to the files that were shared with their users if the projection is enabled.
+func (s MongoStore) writeEvents(ctx context.Context, records []interface{}) error {
+	if _, err := s.collection(OutboxCollectionName).InsertMany(ctx, records); err != nil {
+		return err
+	}
+
+	if !s.sharedWithMe {
+		return nil
+	}
+
+	models := make([]mongo.WriteModel, 0, len(records))
+	for _, record := range records {
+		if model := sharedWithMeModel(record.(outboxRecord)); model != nil {
+			models = append(models, model)
+		}
+	}
+
+	if len(models) == 0 {
+		return nil
+	}
+
+	_, err := s.collection(SharedWithMeCollectionName).BulkWrite(ctx, models)
+	return err
+}
+
+// sharedWithMeModel returns the write of the event of record to the projection, or nil if it doesn't
+// change the projection. A permission is projected if it was given to its user directly by another user.
+// Updates don't change the time that the permission's file was shared.
+func sharedWithMeModel(record outboxRecord) mongo.WriteModel {
+	if record.Type == service.EventExternalAccess || record.Type == service.EventOwnerAssigned {
+		return nil
+	}
+
+	if record.Type == service.EventSharingStopped {
+		ids := make(bson.A, 0, len(record.Revoked))
+		for _, revoked := range record.Revoked {
+			ids = append(ids, revoked.ID)
+		}
+
+		return mongo.NewDeleteManyModel().SetFilter(bson.D{
+			bson.E{Key: MongoObjectIDField, Value: bson.D{bson.E{Key: "$in", Value: ids}}},
+		})
+	}
+
+	permission := record.Permission
+	filter := bson.D{bson.E{Key: MongoObjectIDField, Value: permission.ID}}
+	shared := permission.Creator != "" && permission.Creator != permission.UserID && permission.InheritedFrom == ""
+	if record.Type == service.EventDeleted || !shared {
+		return mongo.NewDeleteOneModel().SetFilter(filter)
+	}
+
+	resourceType := permission.ResourceType
+	if resourceType == "" {
+		resourceType = service.DefaultResourceType
+	}
+
+	set := bson.D{
+		bson.E{Key: PermissionBSONResourceTypeField, Value: resourceType},
+		bson.E{Key: PermissionBSONFileIDField, Value: permission.FileID},
+		bson.E{Key: PermissionBSONUserIDField, Value: permission.UserID},
+		bson.E{Key: PermissionBSONRoleField, Value: permission.Role},
+		bson.E{Key: SharedWithMeBSONSharedByField, Value: permission.Creator},
+	}
+	sharedAt := bson.D{
+		bson.E{Key: SharedWithMeBSONSharedAtField, Value: record.CreatedAt},
+		bson.E{Key: SharedWithMeBSONSequenceField, Value: record.ID},
+	}
+
+	update := bson.D{bson.E{Key: "$set", Value: set}, bson.E{Key: "$setOnInsert", Value: sharedAt}}
+	if record.Type == service.EventCreated {
+		update = bson.D{bson.E{Key: "$set", Value: append(set, sharedAt...)}}
+	}
+
+	return mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(update).SetUpsert(true)
+}
+
+// ListSharedWithMe returns up to pageSize of the files of resourceType that other users shared with userID,
+// most recently shared first, after the file of pageToken, and the token of the next page, which is empty
+// if there are no more files. The projected files are checked against their permissions, since permissions
+// that are changed in bulk, such as by role migrations, don't write events. The files whose permissions
+// no longer exist are skipped and removed from the projection, so a page may have fewer than pageSize files.
+func (s MongoStore) ListSharedWithMe(
+	ctx context.Context,
+	resourceType string,
+	userID string,
+	pageSize int,
+	pageToken string,
+) ([]service.SharedWithMe, string, error) {
+	if !s.sharedWithMe {
+		return nil, "", status.Error(codes.FailedPrecondition, "the files shared with users aren't projected")
+	}
+
+	filter := bson.D{
+		bson.E{Key: PermissionBSONUserIDField, Value: userID},
+		bson.E{Key: PermissionBSONResourceTypeField, Value: resourceType},
+	}
+
+	if pageToken != "" {
+		lastSequence, err := decodePageToken(pageToken)
+		if err != nil {
+			return nil, "", err
+		}
+
+		filter = append(filter, bson.E{
+			Key:   SharedWithMeBSONSequenceField,
+			Value: bson.D{bson.E{Key: "$lt", Value: lastSequence}},
+		})
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: SharedWithMeBSONSequenceField, Value: -1}}).
+		SetLimit(int64(pageSize))
+	cursor, err := s.readCollection(ctx, SharedWithMeCollectionName).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var records []sharedWithMeRecord
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, "", err
+	}
+
+	permissions, err := s.projectedPermissions(ctx, records)
+	if err != nil {
+		return nil, "", err
+	}
+
+	files := make([]service.SharedWithMe, 0, len(records))
+	stale := bson.A{}
+	for _, record := range records {
+		permission, ok := permissions[record.ID]
+		if !ok || permission.UserID != userID || permission.Creator == userID {
+			stale = append(stale, record.ID)
+			continue
+		}
+
+		files = append(files, service.SharedWithMe{
+			FileID:   record.FileID,
+			Role:     permission.Role,
+			SharedBy: permission.Creator,
+			SharedAt: record.SharedAt,
+		})
+	}
+
+	if len(stale) > 0 {
+		_, err := s.collection(SharedWithMeCollectionName).DeleteMany(ctx, bson.D{
+			bson.E{Key: MongoObjectIDField, Value: bson.D{bson.E{Key: "$in", Value: stale}}},
+		})
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	nextPageToken := ""
+	if len(records) == pageSize {
+		nextPageToken = encodePageToken(records[len(records)-1].Sequence.Hex())
+	}
+
+	return files, nextPageToken, nil
+}
+
+// projectedPermissions returns the permissions of records that exist, by their IDs.
+func (s MongoStore) projectedPermissions(
+	ctx context.Context,
+	records []sharedWithMeRecord,
+) (map[primitive.ObjectID]BSON, error) {
+	permissions := make(map[primitive.ObjectID]BSON, len(records))
+	if len(records) == 0 {
+		return permissions, nil
+	}
+
+	ids := make(bson.A, 0, len(records))
+	for _, record := range records {
+		ids = append(ids, record.ID)
+	}
+
+	cursor, err := s.readCollection(ctx, PermissionCollectionName).Find(ctx, bson.D{
+		bson.E{Key: MongoObjectIDField, Value: bson.D{bson.E{Key: "$in", Value: ids}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var found []BSON
+	if err := cursor.All(ctx, &found); err != nil {
+		return nil, err
+	}
+
+	for _, permission := range found {
+		permissions[permission.ID] = permission
+	}
+
+	return permissions, nil
+}
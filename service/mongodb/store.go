@@ -3,6 +3,8 @@ package mongodb
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	pb "github.com/meateam/permission-service/proto"
 	"github.com/meateam/permission-service/service"
@@ -10,6 +12,8 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -22,6 +26,22 @@ const (
 	// PermissionBSONFileIDField is the name of the fileID field in BSON.
 	PermissionBSONFileIDField = "fileID"
 
+	// PermissionBSONResourceTypeField is the name of the resourceType field in BSON.
+	PermissionBSONResourceTypeField = "resourceType"
+
+	// legacyPermissionIndexName is the name of the unique index of permissions
+	// before resource types were introduced.
+	legacyPermissionIndexName = "fileID_1_userID_1"
+
+	// indexNotFoundErrorCode is the mongodb error code of dropping an index that doesn't exist.
+	indexNotFoundErrorCode = 27
+
+	// networkErrorLabel is the label of the mongodb errors of the network.
+	networkErrorLabel = "NetworkError"
+
+	// serverSelectionErrorPrefix is the prefix of the errors of selecting a server of the replica set.
+	serverSelectionErrorPrefix = "server selection error"
+
 	// PermissionBSONUserIDField is the name of the userID field in BSON.
 	PermissionBSONUserIDField = "userID"
 
@@ -30,37 +50,154 @@ const (
 
 	// PermissionBSONCreatorField is the name of the creator field in BSON.
 	PermissionBSONCreatorField = "creator"
+
+	// PermissionBSONCanReshareField is the name of the canReshare field in BSON.
+	PermissionBSONCanReshareField = "canReshare"
+
+	// PermissionBSONMessageField is the name of the message field in BSON.
+	PermissionBSONMessageField = "message"
+
+	// PermissionBSONLabelField is the name of the label field in BSON.
+	PermissionBSONLabelField = "label"
+
+	// PermissionBSONLabelsField is the name of the key-value labels field in BSON.
+	PermissionBSONLabelsField = "labels"
+
+	// PermissionBSONSourceField is the name of the source field in BSON.
+	PermissionBSONSourceField = "source"
+
+	// PermissionBSONInheritedFromField is the name of the inheritedFrom field in BSON.
+	PermissionBSONInheritedFromField = "inheritedFrom"
+
+	// PermissionBSONLastAccessedAtField is the name of the lastAccessedAt field in BSON.
+	PermissionBSONLastAccessedAtField = "lastAccessedAt"
+
+	// PermissionBSONVersionField is the name of the version field in BSON.
+	PermissionBSONVersionField = "version"
+
+	// PermissionBSONSharingChainField is the name of the sharingChain field in BSON.
+	PermissionBSONSharingChainField = "sharingChain"
+
+	// PermissionBSONResourceKindField is the name of the resourceKind field in BSON.
+	PermissionBSONResourceKindField = "resourceKind"
+
+	// PermissionBSONGranteeTypeField is the name of the granteeType field in BSON.
+	PermissionBSONGranteeTypeField = "granteeType"
+
+	// PermissionBSONSchemaVersionField is the name of the schemaVersion field in BSON.
+	PermissionBSONSchemaVersionField = "schemaVersion"
 )
 
-// MongoStore holds the mongodb database and implements Store interface.
+// incVersion is the update operator that increments the version of a modified permission.
+var incVersion = bson.E{
+	Key: "$inc",
+	Value: bson.D{
+		bson.E{
+			Key:   PermissionBSONVersionField,
+			Value: 1,
+		},
+	},
+}
+
+// MongoStore holds the mongodb database and implements the service.PermissionRepository,
+// service.RequestRepository, service.ScheduleRepository and service.ApprovalRepository interfaces.
 type MongoStore struct {
 	DB *mongo.Database
+
+	// readDB is the database that reads are served from, such as of a client of secondaries,
+	// the reads are served from DB if nil.
+	readDB *mongo.Database
+
+	// hedgeDelay is the delay after which point reads are hedged, they're not hedged if 0.
+	hedgeDelay time.Duration
+
+	// outbox is whether changes to permissions write events to the outbox.
+	outbox bool
+
+	// outboxRetention is the duration that published events are kept in the outbox.
+	outboxRetention time.Duration
+
+	// sharedWithMe is whether the events of the outbox are projected to the files shared with each user.
+	sharedWithMe bool
+
+	// collectionPrefix is prepended to the names of the store's collections, so that stores of
+	// several environments, or of both sides of a data migration, may share a database.
+	collectionPrefix string
+
+	// concerns are the read and write concerns of the classes of the store's operations.
+	concerns Concerns
 }
 
-// newMongoStore returns a new store.
-func newMongoStore(db *mongo.Database) (MongoStore, error) {
-	collection := db.Collection(PermissionCollectionName)
-	indexes := collection.Indexes()
-	indexModel := mongo.IndexModel{
-		Keys: bson.D{
-			bson.E{
-				Key:   PermissionBSONFileIDField,
-				Value: 1,
-			},
-			bson.E{
-				Key:   PermissionBSONUserIDField,
-				Value: 1,
-			},
-		},
-		Options: options.Index().SetUnique(true),
+// NewMongoStore returns a new store of the collections of db whose names start with collectionPrefix.
+// idempotencyWindow is the duration in which idempotency keys are kept.
+func NewMongoStore(
+	db *mongo.Database,
+	collectionPrefix string,
+	idempotencyWindow time.Duration) (MongoStore, error) {
+	store := MongoStore{DB: db, collectionPrefix: collectionPrefix}
+	indexes := store.collection(PermissionCollectionName).Indexes()
+	for _, indexModel := range permissionIndexModels() {
+		if _, err := indexes.CreateOne(context.Background(), indexModel); err != nil {
+			return MongoStore{}, err
+		}
 	}
 
-	_, err := indexes.CreateOne(context.Background(), indexModel)
-	if err != nil {
+	// The legacy index prevents permissions to resources of different types with the same ID.
+	_, err := indexes.DropOne(context.Background(), legacyPermissionIndexName)
+	if cmdErr, ok := err.(mongo.CommandError); err != nil && !(ok && cmdErr.Code == indexNotFoundErrorCode) {
+		return MongoStore{}, err
+	}
+
+	if err := store.createIdempotencyIndex(context.Background(), idempotencyWindow); err != nil {
+		return MongoStore{}, err
+	}
+
+	if err := store.createScheduleIndex(context.Background()); err != nil {
+		return MongoStore{}, err
+	}
+
+	if err := store.createJobIndexes(context.Background()); err != nil {
+		return MongoStore{}, err
+	}
+
+	if err := store.createCollaboratorsIndexes(context.Background()); err != nil {
+		return MongoStore{}, err
+	}
+
+	if err := store.createAccessReviewIndexes(context.Background()); err != nil {
 		return MongoStore{}, err
 	}
 
-	return MongoStore{DB: db}, nil
+	return store, nil
+}
+
+// collection returns the collection of the store with name, with the store's prefix.
+func (s MongoStore) collection(name string) taggedCollection {
+	return taggedCollection{s.DB.Collection(s.collectionPrefix+name, s.concerns.collectionOptions(name))}
+}
+
+// WithReadDB returns a copy of the store whose reads are served from readDB, such as a database of
+// a client that prefers secondaries, while writes, and reads that precede writes, use the primary.
+func (s MongoStore) WithReadDB(readDB *mongo.Database) MongoStore {
+	s.readDB = readDB
+	return s
+}
+
+// primary returns a copy of the store whose reads are served from the primary,
+// for reads whose results are written back and mustn't be stale.
+func (s MongoStore) primary() MongoStore {
+	s.readDB = nil
+	return s
+}
+
+// readCollection returns the collection of name that reads of ctx are served from.
+// Reads in a session are served from DB, since sessions may only be used with the client that started them.
+func (s MongoStore) readCollection(ctx context.Context, name string) taggedCollection {
+	if _, ok := ctx.(mongo.SessionContext); ok || s.readDB == nil {
+		return s.collection(name)
+	}
+
+	return taggedCollection{s.readDB.Collection(s.collectionPrefix+name, s.concerns.collectionOptions(name))}
 }
 
 // HealthCheck checks the health of the service, returns true if healthy, or false otherwise.
@@ -69,6 +206,13 @@ func (s MongoStore) HealthCheck(ctx context.Context) (bool, error) {
 		return false, err
 	}
 
+	// The read client is pinged with its own read preference.
+	if s.readDB != nil {
+		if err := s.readDB.Client().Ping(ctx, nil); err != nil {
+			return false, err
+		}
+	}
+
 	return true, nil
 }
 
@@ -79,42 +223,53 @@ func (s MongoStore) HealthCheck(ctx context.Context) (bool, error) {
 // otherwise returns empty string and non-nil error if any occurred.
 func (s MongoStore) Create(
 	ctx context.Context,
-	permission service.Permission,
+	resourceType string,
+	fileID string,
+	userID string,
+	creator string,
+	sharingChain []string,
+	values service.PermissionUpdate,
 	override bool,
 ) (service.Permission, error) {
-	collection := s.DB.Collection(PermissionCollectionName)
-	fileID := permission.GetFileID()
+	collection := s.collection(PermissionCollectionName)
 	if fileID == "" {
 		return nil, fmt.Errorf("fileID is required")
 	}
 
-	userID := permission.GetUserID()
 	if userID == "" {
 		return nil, fmt.Errorf("userID is required")
 	}
 
-	role := permission.GetRole()
+	role := values.Role
 	if pb.Role_name[int32(role)] == "" {
 		return nil, fmt.Errorf("role does not exist")
 	}
 
-	creator := permission.GetCreator()
 	if userID == "" {
 		return nil, fmt.Errorf("creator is required")
 	}
 
-	filter := bson.D{
-		bson.E{
-			Key:   PermissionBSONFileIDField,
-			Value: fileID,
-		},
-		bson.E{
-			Key:   PermissionBSONUserIDField,
-			Value: userID,
-		},
+	if resourceType == "" {
+		resourceType = service.DefaultResourceType
 	}
 
+	resourceKind := values.ResourceKind
+	if resourceKind == "" {
+		resourceKind = service.ResourceKindFile
+	}
+
+	granteeType := values.GranteeType
+	if granteeType == "" {
+		granteeType = service.GranteeTypeUser
+	}
+
+	filter := permissionFilter(resourceType, fileID, userID)
+
 	newPermission := bson.D{
+		bson.E{
+			Key:   PermissionBSONResourceTypeField,
+			Value: resourceType,
+		},
 		bson.E{
 			Key:   PermissionBSONFileIDField,
 			Value: fileID,
@@ -131,18 +286,60 @@ func (s MongoStore) Create(
 			Key:   PermissionBSONCreatorField,
 			Value: creator,
 		},
+		bson.E{
+			Key:   PermissionBSONCanReshareField,
+			Value: values.CanReshare,
+		},
+		bson.E{
+			Key:   PermissionBSONMessageField,
+			Value: values.Message,
+		},
+		bson.E{
+			Key:   PermissionBSONLabelField,
+			Value: values.Label,
+		},
+		bson.E{
+			Key:   PermissionBSONLabelsField,
+			Value: values.Labels,
+		},
+		bson.E{
+			Key:   PermissionBSONSharingChainField,
+			Value: sharingChain,
+		},
+		bson.E{
+			Key:   PermissionBSONResourceKindField,
+			Value: resourceKind,
+		},
+		bson.E{
+			Key:   PermissionBSONGranteeTypeField,
+			Value: granteeType,
+		},
+		bson.E{
+			Key:   PermissionBSONSourceField,
+			Value: values.Source,
+		},
+		bson.E{
+			Key:   PermissionBSONSchemaVersionField,
+			Value: CurrentSchemaVersion,
+		},
 	}
 
+	// A created permission is given to the file directly, even if it overrides an inherited permission.
 	update := bson.D{
 		bson.E{
 			Key:   "$set",
 			Value: newPermission,
 		},
+		bson.E{
+			Key:   "$unset",
+			Value: bson.D{bson.E{Key: PermissionBSONInheritedFromField, Value: ""}},
+		},
+		incVersion,
 	}
 
 	// In case override is false, check if there is a permission, and if there is one, return it.
 	if !override {
-		existingPermission, err := s.Get(ctx, filter)
+		existingPermission, err := s.primary().findOne(ctx, filter)
 		if err != nil && err != mongo.ErrNoDocuments {
 			return nil, err
 		}
@@ -155,45 +352,63 @@ func (s MongoStore) Create(
 	// If override is true, or false and there is no permission existing,
 	// then update and allow to override the permission fields
 	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
-	result := collection.FindOneAndUpdate(ctx, filter, update, opts)
-
-	updatedPermission := &BSON{}
-	err := result.Decode(updatedPermission)
-	if err != nil {
-		return nil, err
-	}
+	return s.withEvent(ctx, service.EventCreated, func(ctx context.Context) (service.Permission, error) {
+		updatedPermission := &BSON{}
+		if err := collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(updatedPermission); err != nil {
+			return nil, err
+		}
 
-	return updatedPermission, nil
+		return updatedPermission, nil
+	})
 }
 
-// Get finds one permission that matches filter,
+// findOne finds one permission that matches filter,
 // if successful returns the permission, and a nil error,
 // if the permission is not found it would return nil and NotFound error,
 // otherwise returns nil and non-nil error if any occurred.
-func (s MongoStore) Get(ctx context.Context, filter interface{}) (service.Permission, error) {
-	collection := s.DB.Collection(PermissionCollectionName)
+func (s MongoStore) findOne(
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOneOptions,
+) (service.Permission, error) {
+	collection := s.readCollection(ctx, PermissionCollectionName)
 
 	permission := &BSON{}
-	err := collection.FindOne(ctx, filter).Decode(permission)
+	err := collection.FindOne(ctx, filter, opts...).Decode(permission)
 	if err != nil {
 		return nil, err
 	}
 
+	projected := false
+	for _, opt := range opts {
+		projected = projected || (opt != nil && opt.Projection != nil)
+	}
+
+	s.upgradeOnRead(ctx, permission, projected)
 	return permission, nil
 }
 
-// GetAll finds all permissions that matches filter,
+// find finds all permissions that matches filter,
 // if successful returns the permissions, and a nil error,
 // otherwise returns nil and non-nil error if any occurred.
-func (s MongoStore) GetAll(ctx context.Context, filter interface{}) ([]service.Permission, error) {
-	collection := s.DB.Collection(PermissionCollectionName)
+func (s MongoStore) find(
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOptions,
+) ([]service.Permission, error) {
+	collection := s.readCollection(ctx, PermissionCollectionName)
 
-	cur, err := collection.Find(ctx, filter)
+	cur, err := collection.Find(ctx, filter, opts...)
 	if err != nil {
 		return nil, err
 	}
 	defer cur.Close(ctx)
 
+	projected := false
+	for _, opt := range opts {
+		projected = projected || (opt != nil && opt.Projection != nil)
+	}
+
 	permissions := []service.Permission{}
 	for cur.Next(ctx) {
 		permission := &BSON{}
@@ -202,6 +417,7 @@ func (s MongoStore) GetAll(ctx context.Context, filter interface{}) ([]service.P
 			return nil, err
 		}
 
+		s.upgradeOnRead(ctx, permission, projected)
 		permissions = append(permissions, permission)
 	}
 
@@ -212,11 +428,11 @@ func (s MongoStore) GetAll(ctx context.Context, filter interface{}) ([]service.P
 	return permissions, nil
 }
 
-// Delete finds the first permission that matches filter and deletes it,
+// findOneAndDelete finds the first permission that matches filter and deletes it,
 // if successful returns the deleted permission, otherwise returns nil,
 // and non-nil error if any occurred.
-func (s MongoStore) Delete(ctx context.Context, filter interface{}) (service.Permission, error) {
-	collection := s.DB.Collection(PermissionCollectionName)
+func (s MongoStore) findOneAndDelete(ctx context.Context, filter interface{}) (service.Permission, error) {
+	collection := s.collection(PermissionCollectionName)
 	permission := &BSON{}
 	if err := collection.FindOneAndDelete(ctx, filter).Decode(permission); err != nil {
 		return nil, err
@@ -224,3 +440,147 @@ func (s MongoStore) Delete(ctx context.Context, filter interface{}) (service.Per
 
 	return permission, nil
 }
+
+// findOneAndUpdate finds the first permission that matches filter and applies update to it,
+// if successful returns the updated permission, otherwise returns nil,
+// and non-nil error if any occurred.
+func (s MongoStore) findOneAndUpdate(
+	ctx context.Context,
+	filter interface{},
+	update interface{},
+) (service.Permission, error) {
+	collection := s.collection(PermissionCollectionName)
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	permission := &BSON{}
+	if err := collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(permission); err != nil {
+		return nil, err
+	}
+
+	return permission, nil
+}
+
+// Sample returns up to size permissions chosen at random.
+func (s MongoStore) Sample(ctx context.Context, size int) ([]service.Permission, error) {
+	collection := s.readCollection(ctx, PermissionCollectionName)
+	pipeline := mongo.Pipeline{
+		bson.D{bson.E{Key: "$sample", Value: bson.D{bson.E{Key: "size", Value: size}}}},
+	}
+
+	cur, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	permissions := []service.Permission{}
+	for cur.Next(ctx) {
+		permission := &BSON{}
+		if err := cur.Decode(permission); err != nil {
+			return nil, err
+		}
+
+		permissions = append(permissions, permission)
+	}
+
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+
+	return permissions, nil
+}
+
+// CountPermissions returns the number of permissions of each resource type and role.
+func (s MongoStore) CountPermissions(ctx context.Context) ([]service.PermissionCount, error) {
+	pipeline := mongo.Pipeline{
+		bson.D{bson.E{Key: "$group", Value: bson.D{
+			bson.E{Key: MongoObjectIDField, Value: bson.D{
+				bson.E{Key: PermissionBSONResourceTypeField, Value: "$" + PermissionBSONResourceTypeField},
+				bson.E{Key: PermissionBSONRoleField, Value: "$" + PermissionBSONRoleField},
+			}},
+			bson.E{Key: "count", Value: bson.D{bson.E{Key: "$sum", Value: 1}}},
+		}}},
+	}
+
+	cur, err := s.readCollection(ctx, PermissionCollectionName).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	defer cur.Close(ctx)
+
+	counts := []service.PermissionCount{}
+	for cur.Next(ctx) {
+		var group struct {
+			ID struct {
+				ResourceType string  `bson:"resourceType"`
+				Role         pb.Role `bson:"role"`
+			} `bson:"_id"`
+			Count int64 `bson:"count"`
+		}
+
+		if err := cur.Decode(&group); err != nil {
+			return nil, err
+		}
+
+		// Permissions stored before resource types were introduced are to files.
+		resourceType := group.ID.ResourceType
+		if resourceType == "" {
+			resourceType = service.DefaultResourceType
+		}
+
+		counts = append(counts, service.PermissionCount{
+			ResourceType: resourceType,
+			Role:         group.ID.Role,
+			Count:        group.Count,
+		})
+	}
+
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// count returns the number of permissions that match filter, and any error if occurred.
+func (s MongoStore) count(ctx context.Context, filter interface{}) (int64, error) {
+	collection := s.readCollection(ctx, PermissionCollectionName)
+	return collection.CountDocuments(ctx, filter)
+}
+
+// updateMany applies update to all permissions that match filter,
+// if successful returns the number of modified permissions, otherwise returns 0,
+// and non-nil error if any occurred.
+func (s MongoStore) updateMany(
+	ctx context.Context,
+	filter interface{},
+	update interface{},
+	opts ...*options.UpdateOptions,
+) (int64, error) {
+	collection := s.collection(PermissionCollectionName)
+	result, err := collection.UpdateMany(ctx, filter, update, opts...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.ModifiedCount, nil
+}
+
+// unavailableError returns an Unavailable error of err if it's of the store being unreachable, such as
+// of a network error or of no server being selectable within the server selection timeout, otherwise err.
+func unavailableError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	commandErr, isCommandErr := err.(mongo.CommandError)
+	unavailable := err == mongo.ErrClientDisconnected ||
+		(isCommandErr && commandErr.HasErrorLabel(networkErrorLabel)) ||
+		// The driver's server selection errors have no type of their own.
+		strings.HasPrefix(err.Error(), serverSelectionErrorPrefix)
+	if !unavailable {
+		return err
+	}
+
+	return status.Errorf(codes.Unavailable, "the store is unavailable: %v", err)
+}
@@ -0,0 +1,58 @@
+package mongodb
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestStoreCollections(t *testing.T) {
+	// The client isn't connected, since the collections are only named.
+	client, err := mongo.NewClient()
+	if err != nil {
+		t.Fatalf("failed creating a mongodb client: %v", err)
+	}
+
+	db, readDB := client.Database("primary"), client.Database("reads")
+	tests := []struct {
+		name       string
+		store      MongoStore
+		database   string
+		collection string
+	}{
+		{name: "default", store: MongoStore{DB: db}, database: "primary", collection: "permissions"},
+		{
+			name:       "prefixed",
+			store:      MongoStore{DB: db, collectionPrefix: "staging_"},
+			database:   "primary",
+			collection: "staging_permissions",
+		},
+		{
+			name:       "read database",
+			store:      MongoStore{DB: db, collectionPrefix: "staging_"}.WithReadDB(readDB),
+			database:   "reads",
+			collection: "staging_permissions",
+		},
+		{
+			name:       "primary reads",
+			store:      MongoStore{DB: db, collectionPrefix: "staging_"}.WithReadDB(readDB).primary(),
+			database:   "primary",
+			collection: "staging_permissions",
+		},
+	}
+
+	for _, test := range tests {
+		if collection := test.store.collection(PermissionCollectionName); collection.Database().Name() != "primary" ||
+			collection.Name() != test.collection {
+			t.Errorf("%s: expected the writes to be of primary.%s, got %s.%s",
+				test.name, test.collection, collection.Database().Name(), collection.Name())
+		}
+
+		collection := test.store.readCollection(context.Background(), PermissionCollectionName)
+		if collection.Database().Name() != test.database || collection.Name() != test.collection {
+			t.Errorf("%s: expected the reads to be of %s.%s, got %s.%s",
+				test.name, test.database, test.collection, collection.Database().Name(), collection.Name())
+		}
+	}
+}
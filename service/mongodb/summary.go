@@ -0,0 +1,151 @@
+package mongodb
+
+import (
+	"context"
+
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/meateam/permission-service/service"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// sharingSummaryCount is the structure of a count of a facet of the sharing summary aggregation,
+// a facet whose count is zero has no counts.
+type sharingSummaryCount struct {
+	Count int64 `bson:"count"`
+}
+
+// sharingSummaryResult is the structure that's returned by the sharing summary aggregation.
+type sharingSummaryResult struct {
+	Files       []sharingSummaryCount `bson:"files"`
+	Permissions []sharingSummaryCount `bson:"permissions"`
+	Grantees    []sharingSummaryCount `bson:"grantees"`
+	Roles       []struct {
+		Role     pb.Role `bson:"_id"`
+		Grantees int64   `bson:"grantees"`
+	} `bson:"roles"`
+	External []struct {
+		UserID string `bson:"_id"`
+	} `bson:"external"`
+	ExternalFiles []sharingSummaryCount `bson:"externalFiles"`
+}
+
+// SummarizeSharing returns the summary of the sharing of the tree of folderID, which is made of folderID
+// and descendantIDs, or of folderID and the files that inherit its permissions if descendantIDs is empty.
+// The grantees outside of the tenant are the grantees of service.GranteeTypeDomain.
+func (s MongoStore) SummarizeSharing(
+	ctx context.Context,
+	resourceType string,
+	folderID string,
+	descendantIDs []string,
+) (service.SharingSummary, error) {
+	match := bson.D{resourceTypeFilter(resourceType)}
+	if len(descendantIDs) == 0 {
+		match = append(match, bson.E{Key: "$or", Value: bson.A{
+			bson.D{bson.E{Key: PermissionBSONFileIDField, Value: folderID}},
+			bson.D{bson.E{Key: PermissionBSONInheritedFromField, Value: folderID}},
+		}})
+	} else {
+		fileIDs := make(bson.A, 0, len(descendantIDs)+1)
+		fileIDs = append(fileIDs, folderID)
+		for _, descendantID := range descendantIDs {
+			fileIDs = append(fileIDs, descendantID)
+		}
+
+		match = append(match, bson.E{
+			Key:   PermissionBSONFileIDField,
+			Value: bson.D{bson.E{Key: "$in", Value: fileIDs}},
+		})
+	}
+
+	external := bson.D{bson.E{Key: "$match", Value: bson.D{
+		bson.E{Key: PermissionBSONGranteeTypeField, Value: service.GranteeTypeDomain},
+	}}}
+
+	// Summarize the matched permissions in a single pass, with a facet for each of the summary's parts.
+	pipeline := bson.A{
+		bson.D{bson.E{Key: "$match", Value: match}},
+		bson.D{bson.E{Key: "$facet", Value: bson.D{
+			bson.E{Key: "files", Value: bson.A{
+				groupBy(PermissionBSONFileIDField),
+				bson.D{bson.E{Key: "$count", Value: "count"}},
+			}},
+			bson.E{Key: "permissions", Value: bson.A{
+				bson.D{bson.E{Key: "$count", Value: "count"}},
+			}},
+			bson.E{Key: "grantees", Value: bson.A{
+				groupBy(PermissionBSONUserIDField),
+				bson.D{bson.E{Key: "$count", Value: "count"}},
+			}},
+			bson.E{Key: "roles", Value: bson.A{
+				bson.D{bson.E{Key: "$group", Value: bson.D{bson.E{Key: MongoObjectIDField, Value: bson.D{
+					bson.E{Key: PermissionBSONRoleField, Value: "$" + PermissionBSONRoleField},
+					bson.E{Key: PermissionBSONUserIDField, Value: "$" + PermissionBSONUserIDField},
+				}}}}},
+				bson.D{bson.E{Key: "$group", Value: bson.D{
+					bson.E{Key: MongoObjectIDField, Value: "$" + MongoObjectIDField + "." + PermissionBSONRoleField},
+					bson.E{Key: "grantees", Value: bson.D{bson.E{Key: "$sum", Value: 1}}},
+				}}},
+			}},
+			bson.E{Key: "external", Value: bson.A{
+				external,
+				groupBy(PermissionBSONUserIDField),
+				bson.D{bson.E{Key: "$sort", Value: bson.D{bson.E{Key: MongoObjectIDField, Value: 1}}}},
+			}},
+			bson.E{Key: "externalFiles", Value: bson.A{
+				external,
+				groupBy(PermissionBSONFileIDField),
+				bson.D{bson.E{Key: "$count", Value: "count"}},
+			}},
+		}}},
+	}
+
+	cur, err := s.readCollection(ctx, PermissionCollectionName).Aggregate(ctx, pipeline)
+	if err != nil {
+		return service.SharingSummary{}, err
+	}
+	defer cur.Close(ctx)
+
+	var result sharingSummaryResult
+	if cur.Next(ctx) {
+		if err := cur.Decode(&result); err != nil {
+			return service.SharingSummary{}, err
+		}
+	}
+
+	if err := cur.Err(); err != nil {
+		return service.SharingSummary{}, err
+	}
+
+	summary := service.SharingSummary{
+		Files:            facetCount(result.Files),
+		Permissions:      facetCount(result.Permissions),
+		Grantees:         facetCount(result.Grantees),
+		RoleGrantees:     make(map[pb.Role]int64, len(result.Roles)),
+		ExternalGrantees: make([]string, 0, len(result.External)),
+		ExternalFiles:    facetCount(result.ExternalFiles),
+	}
+
+	for _, role := range result.Roles {
+		summary.RoleGrantees[role.Role] = role.Grantees
+	}
+
+	for _, grantee := range result.External {
+		summary.ExternalGrantees = append(summary.ExternalGrantees, grantee.UserID)
+	}
+
+	return summary, nil
+}
+
+// groupBy returns a $group stage that groups documents by field.
+func groupBy(field string) bson.D {
+	return bson.D{bson.E{Key: "$group", Value: bson.D{bson.E{Key: MongoObjectIDField, Value: "$" + field}}}}
+}
+
+// facetCount returns the count of counts, the result of a facet's $count stage.
+func facetCount(counts []sharingSummaryCount) int64 {
+	if len(counts) == 0 {
+		return 0
+	}
+
+	return counts[0].Count
+}
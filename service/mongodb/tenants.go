@@ -0,0 +1,318 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/meateam/permission-service/service"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// TenantPurgeCollectionName is the name of the collection of the confirmations of the purges of tenants.
+	TenantPurgeCollectionName = "tenantPurges"
+
+	// TenantSettingsCollectionName is the name of the collection of the settings of a tenant.
+	TenantSettingsCollectionName = "tenantSettings"
+
+	// tenantRateLimitsID is the ID of the document of the rate limits in the settings of a tenant.
+	tenantRateLimitsID = "rateLimits"
+)
+
+// tenantCollectionNames are the names of the collections of a store, which are the collections
+// of a tenant whose collection prefix is its ID and an underscore.
+var tenantCollectionNames = []string{
+	PermissionCollectionName,
+	OutboxCollectionName,
+	IdempotencyCollectionName,
+	ScheduleCollectionName,
+	PermissionRequestCollectionName,
+	FileLockCollectionName,
+	LegalHoldCollectionName,
+	JobCollectionName,
+	RecurringJobCollectionName,
+	LeaseCollectionName,
+	QuarantineCollectionName,
+	SharedWithMeCollectionName,
+	TenantPurgeCollectionName,
+	TenantSettingsCollectionName,
+	CollaboratorsCollectionName,
+	AccessReviewCollectionName,
+	AccessReviewItemCollectionName,
+}
+
+// tenantPurgeRecord is the structure that represents the confirmation of the purge of a tenant as it's stored.
+type tenantPurgeRecord struct {
+	Token       string    `bson:"_id"`
+	TenantID    string    `bson:"tenantID"`
+	Collections []string  `bson:"collections"`
+	Documents   int64     `bson:"documents"`
+	RequestedBy string    `bson:"requestedBy,omitempty"`
+	ExpiresAt   time.Time `bson:"expiresAt"`
+}
+
+// ExportTenantData calls export with each of the documents of the collections of tenantID,
+// a collection at a time, as they're stored in canonical extended JSON.
+func (s MongoStore) ExportTenantData(
+	ctx context.Context,
+	tenantID string,
+	export func(record service.TenantRecord) error,
+) error {
+	collections, err := s.tenantCollections(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range collections {
+		cursor, err := s.tenantCollection(tenantID, name).Find(ctx, bson.D{})
+		if err != nil {
+			return err
+		}
+
+		for cursor.Next(ctx) {
+			document, err := bson.MarshalExtJSON(cursor.Current, true, false)
+			if err != nil {
+				cursor.Close(ctx)
+				return err
+			}
+
+			if err := export(service.TenantRecord{Collection: name, Document: string(document)}); err != nil {
+				cursor.Close(ctx)
+				return err
+			}
+		}
+
+		err = cursor.Err()
+		cursor.Close(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CountTenantData returns the purge of tenantID with its collections and number of documents,
+// fails with codes.FailedPrecondition if tenantID is the store's own tenant.
+func (s MongoStore) CountTenantData(ctx context.Context, tenantID string) (service.TenantPurge, error) {
+	if err := s.checkOtherTenant(tenantID); err != nil {
+		return service.TenantPurge{}, err
+	}
+
+	collections, err := s.tenantCollections(ctx, tenantID)
+	if err != nil {
+		return service.TenantPurge{}, err
+	}
+
+	purge := service.TenantPurge{TenantID: tenantID, Collections: collections}
+	for _, name := range collections {
+		count, err := s.tenantCollection(tenantID, name).CountDocuments(ctx, bson.D{})
+		if err != nil {
+			return service.TenantPurge{}, err
+		}
+
+		purge.Documents += count
+	}
+
+	return purge, nil
+}
+
+// CreateTenantPurge stores the confirmation of purge until its ExpiresAt,
+// and deletes the confirmations that expired.
+func (s MongoStore) CreateTenantPurge(ctx context.Context, purge service.TenantPurge) error {
+	collection := s.collection(TenantPurgeCollectionName)
+	_, err := collection.DeleteMany(ctx, bson.D{
+		bson.E{Key: "expiresAt", Value: bson.D{bson.E{Key: "$lte", Value: time.Now()}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = collection.InsertOne(ctx, tenantPurgeRecord{
+		Token:       purge.ConfirmationToken,
+		TenantID:    purge.TenantID,
+		Collections: purge.Collections,
+		Documents:   purge.Documents,
+		RequestedBy: purge.RequestedBy,
+		ExpiresAt:   purge.ExpiresAt,
+	})
+
+	return err
+}
+
+// ConfirmTenantPurge deletes the confirmation of the purge of tenantID with token and returns it,
+// fails with codes.FailedPrecondition if there's no such confirmation or it expired at or before now.
+func (s MongoStore) ConfirmTenantPurge(
+	ctx context.Context,
+	tenantID string,
+	token string,
+	now time.Time,
+) (service.TenantPurge, error) {
+	filter := bson.D{
+		bson.E{Key: MongoObjectIDField, Value: token},
+		bson.E{Key: "tenantID", Value: tenantID},
+	}
+
+	var record tenantPurgeRecord
+	err := s.collection(TenantPurgeCollectionName).FindOneAndDelete(ctx, filter).Decode(&record)
+	if err == mongo.ErrNoDocuments {
+		return service.TenantPurge{}, status.Errorf(
+			codes.FailedPrecondition,
+			"confirmation_token doesn't confirm a purge of tenant %s",
+			tenantID,
+		)
+	}
+
+	if err != nil {
+		return service.TenantPurge{}, err
+	}
+
+	if !record.ExpiresAt.After(now) {
+		return service.TenantPurge{}, status.Error(codes.FailedPrecondition, "confirmation_token expired")
+	}
+
+	return service.TenantPurge{
+		TenantID:          record.TenantID,
+		Collections:       record.Collections,
+		Documents:         record.Documents,
+		ConfirmationToken: record.Token,
+		ExpiresAt:         record.ExpiresAt,
+		RequestedBy:       record.RequestedBy,
+	}, nil
+}
+
+// PurgeTenantData drops the collections of tenantID and returns the purge with the collections
+// and the number of documents that were dropped, fails with codes.FailedPrecondition if tenantID
+// is the store's own tenant.
+func (s MongoStore) PurgeTenantData(ctx context.Context, tenantID string) (service.TenantPurge, error) {
+	purge, err := s.CountTenantData(ctx, tenantID)
+	if err != nil {
+		return service.TenantPurge{}, err
+	}
+
+	for _, name := range purge.Collections {
+		if err := s.tenantCollection(tenantID, name).Drop(ctx); err != nil {
+			return service.TenantPurge{}, err
+		}
+	}
+
+	return purge, nil
+}
+
+// tenantRateLimitsRecord is the structure that represents the rate limits of a tenant as they're stored.
+type tenantRateLimitsRecord struct {
+	ID              string    `bson:"_id"`
+	ReadsPerSecond  int64     `bson:"readsPerSecond"`
+	WritesPerSecond int64     `bson:"writesPerSecond"`
+	UpdatedBy       string    `bson:"updatedBy,omitempty"`
+	UpdatedAt       time.Time `bson:"updatedAt"`
+}
+
+// GetTenantRateLimits returns the rate limits in the settings of tenantID, or of the store's own tenant
+// if it's empty, which are unlimited if they were never set.
+func (s MongoStore) GetTenantRateLimits(ctx context.Context, tenantID string) (service.TenantRateLimits, error) {
+	var record tenantRateLimitsRecord
+	filter := bson.D{bson.E{Key: MongoObjectIDField, Value: tenantRateLimitsID}}
+	err := s.tenantSettings(tenantID).FindOne(ctx, filter).Decode(&record)
+	if err == mongo.ErrNoDocuments {
+		return service.TenantRateLimits{TenantID: tenantID}, nil
+	}
+
+	if err != nil {
+		return service.TenantRateLimits{}, err
+	}
+
+	return service.TenantRateLimits{
+		TenantID:        tenantID,
+		ReadsPerSecond:  record.ReadsPerSecond,
+		WritesPerSecond: record.WritesPerSecond,
+		UpdatedBy:       record.UpdatedBy,
+		UpdatedAt:       record.UpdatedAt,
+	}, nil
+}
+
+// SetTenantRateLimits stores limits in the settings of their tenant, or of the store's own tenant
+// if its ID is empty, and returns them.
+func (s MongoStore) SetTenantRateLimits(
+	ctx context.Context,
+	limits service.TenantRateLimits,
+) (service.TenantRateLimits, error) {
+	record := tenantRateLimitsRecord{
+		ID:              tenantRateLimitsID,
+		ReadsPerSecond:  limits.ReadsPerSecond,
+		WritesPerSecond: limits.WritesPerSecond,
+		UpdatedBy:       limits.UpdatedBy,
+		UpdatedAt:       limits.UpdatedAt,
+	}
+
+	filter := bson.D{bson.E{Key: MongoObjectIDField, Value: tenantRateLimitsID}}
+	opts := options.Replace().SetUpsert(true)
+	if _, err := s.tenantSettings(limits.TenantID).ReplaceOne(ctx, filter, record, opts); err != nil {
+		return service.TenantRateLimits{}, err
+	}
+
+	return limits, nil
+}
+
+// tenantSettings returns the collection of the settings of tenantID, or of the store's own tenant if it's empty.
+func (s MongoStore) tenantSettings(tenantID string) taggedCollection {
+	if tenantID == "" {
+		return s.collection(TenantSettingsCollectionName)
+	}
+
+	return s.tenantCollection(tenantID, TenantSettingsCollectionName)
+}
+
+// tenantCollections returns the names of the collections of tenantID that exist, without its prefix.
+// Only the collections of a store are the tenant's, so that the collections of a tenant whose ID
+// starts with tenantID and an underscore aren't.
+func (s MongoStore) tenantCollections(ctx context.Context, tenantID string) ([]string, error) {
+	names := make(bson.A, 0, len(tenantCollectionNames))
+	for _, name := range tenantCollectionNames {
+		names = append(names, tenantPrefix(tenantID)+name)
+	}
+
+	existing, err := s.DB.ListCollectionNames(ctx, bson.D{
+		bson.E{Key: "name", Value: bson.D{bson.E{Key: "$in", Value: names}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	exists := make(map[string]bool, len(existing))
+	for _, name := range existing {
+		exists[name] = true
+	}
+
+	collections := []string{}
+	for _, name := range tenantCollectionNames {
+		if exists[tenantPrefix(tenantID)+name] {
+			collections = append(collections, name)
+		}
+	}
+
+	return collections, nil
+}
+
+// checkOtherTenant returns a FailedPrecondition error if tenantID is the store's own tenant.
+func (s MongoStore) checkOtherTenant(tenantID string) error {
+	if tenantPrefix(tenantID) == s.collectionPrefix {
+		return status.Errorf(codes.FailedPrecondition, "tenant %s is the tenant of the service", tenantID)
+	}
+
+	return nil
+}
+
+// tenantPrefix returns the collection prefix of tenantID.
+func tenantPrefix(tenantID string) string {
+	return tenantID + "_"
+}
+
+// tenantCollection returns the collection of tenantID with name, without the tenant's prefix.
+func (s MongoStore) tenantCollection(tenantID string, name string) taggedCollection {
+	return taggedCollection{s.DB.Collection(tenantPrefix(tenantID)+name, s.concerns.collectionOptions(name))}
+}
@@ -0,0 +1,190 @@
+package mongodb
+
+import (
+	"context"
+	"expvar"
+
+	"github.com/meateam/permission-service/service"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CurrentSchemaVersion is the version of the shape of the permission documents that the store writes,
+// which is the number of schemaUpgrades.
+const CurrentSchemaVersion = 1
+
+// schemaUpgrades are the upgrades of the shape of the permission documents, the upgrade at index i upgrades
+// a permission of version i to version i+1, in place, and returns the fields that it set. An upgrade may
+// only set fields, so that upgrading a document again doesn't undo the writes that followed it.
+var schemaUpgrades = [CurrentSchemaVersion]func(permission *BSON) bson.D{
+	// Version 1 stores the defaults of the fields that were introduced after permissions were stored
+	// without them, so that the queries of the fields don't have to match their absence.
+	func(permission *BSON) bson.D {
+		set := bson.D{}
+		if permission.CanReshare == nil {
+			canReshare := true
+			permission.CanReshare = &canReshare
+			set = append(set, bson.E{Key: PermissionBSONCanReshareField, Value: canReshare})
+		}
+
+		if permission.ResourceKind == "" {
+			permission.ResourceKind = service.ResourceKindFile
+			set = append(set, bson.E{Key: PermissionBSONResourceKindField, Value: permission.ResourceKind})
+		}
+
+		if permission.GranteeType == "" {
+			permission.GranteeType = service.GranteeTypeUser
+			set = append(set, bson.E{Key: PermissionBSONGranteeTypeField, Value: permission.GranteeType})
+		}
+
+		return set
+	},
+}
+
+// schemaUpgradeCounts counts the permission documents that were upgraded, keyed by "read" for the documents
+// that were upgraded when they were read, "backfill" for the ones upgraded by UpgradeSchema, and "conflict"
+// for the upgrades that weren't stored because the document was modified since it was read.
+var schemaUpgradeCounts = expvar.NewMap("schema_upgrades")
+
+// upgradeSchema upgrades permission to CurrentSchemaVersion in place, and returns the fields that it set,
+// which are empty if it's already of the current version.
+func upgradeSchema(permission *BSON) bson.D {
+	set := bson.D{}
+	for version := permission.SchemaVersion; version < CurrentSchemaVersion; version++ {
+		set = append(set, schemaUpgrades[version](permission)...)
+	}
+
+	if permission.SchemaVersion >= CurrentSchemaVersion {
+		return set
+	}
+
+	permission.SchemaVersion = CurrentSchemaVersion
+	return append(set, bson.E{Key: PermissionBSONSchemaVersionField, Value: CurrentSchemaVersion})
+}
+
+// upgradeOnRead upgrades permission, which was read, to CurrentSchemaVersion, and stores the upgrade unless
+// permission was projected, in which case the fields that weren't read would be stored as their defaults,
+// or read in a transaction, so that the upgrade doesn't conflict with the writes of the transaction.
+// The upgrade is stored on a best-effort basis: the document is upgraded by its next read if it fails.
+func (s MongoStore) upgradeOnRead(ctx context.Context, permission *BSON, projected bool) {
+	version := permission.SchemaVersion
+	set := upgradeSchema(permission)
+	if len(set) == 0 || projected || permission.ID.IsZero() {
+		return
+	}
+
+	if _, ok := ctx.(mongo.SessionContext); ok {
+		return
+	}
+
+	if upgraded, err := s.storeUpgrade(ctx, permission, version, set); err == nil && upgraded {
+		schemaUpgradeCounts.Add("read", 1)
+	}
+}
+
+// storeUpgrade stores the fields of set of the upgrade of permission from version, unless the document
+// was modified since it was read, and returns whether it was stored.
+func (s MongoStore) storeUpgrade(ctx context.Context, permission *BSON, version int, set bson.D) (bool, error) {
+	// The fields that are omitted when they're empty are matched by their absence.
+	versionValue := interface{}(version)
+	if version == 0 {
+		versionValue = bson.D{bson.E{Key: "$in", Value: bson.A{nil, 0}}}
+	}
+
+	modificationValue := interface{}(permission.Version)
+	if permission.Version == 0 {
+		modificationValue = bson.D{bson.E{Key: "$in", Value: bson.A{nil, 0}}}
+	}
+
+	filter := bson.D{
+		bson.E{Key: MongoObjectIDField, Value: permission.ID},
+		bson.E{Key: PermissionBSONSchemaVersionField, Value: versionValue},
+		bson.E{Key: PermissionBSONVersionField, Value: modificationValue},
+	}
+
+	update := bson.D{bson.E{Key: "$set", Value: set}}
+	result, err := s.collection(PermissionCollectionName).UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	if result.ModifiedCount == 0 {
+		schemaUpgradeCounts.Add("conflict", 1)
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// UpgradeSchema upgrades the permission documents of schema versions older than CurrentSchemaVersion,
+// batchSize documents at a time, and calls progress after each batch with the number of documents upgraded
+// so far and the number of documents that were older when the upgrade started. The upgrade stops if progress
+// returns an error. The documents that are modified while they're upgraded are left to be upgraded when
+// they're read, or by the next upgrade. Upgrades don't write events to the outbox, since they don't change
+// the permissions, only the shape of their documents.
+func (s MongoStore) UpgradeSchema(
+	ctx context.Context,
+	batchSize int,
+	progress func(upgraded int64, total int64) error,
+) error {
+	s = s.primary()
+	filter := bson.D{bson.E{Key: PermissionBSONSchemaVersionField, Value: bson.D{
+		bson.E{Key: "$not", Value: bson.D{bson.E{Key: "$gte", Value: CurrentSchemaVersion}}},
+	}}}
+
+	total, err := s.count(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: MongoObjectIDField, Value: 1}}).
+		SetLimit(int64(batchSize))
+
+	var upgraded int64
+	var lastID primitive.ObjectID
+	for {
+		batchFilter := filter
+		if !lastID.IsZero() {
+			batchFilter = append(batchFilter, bson.E{
+				Key:   MongoObjectIDField,
+				Value: bson.D{bson.E{Key: "$gt", Value: lastID}},
+			})
+		}
+
+		// The batch is decoded as is, rather than by find, which would upgrade it when it's read.
+		cur, err := s.collection(PermissionCollectionName).Find(ctx, batchFilter, opts)
+		if err != nil {
+			return err
+		}
+
+		batch := []*BSON{}
+		if err := cur.All(ctx, &batch); err != nil {
+			return err
+		}
+
+		if len(batch) == 0 {
+			return nil
+		}
+
+		for _, permission := range batch {
+			lastID = permission.ID
+			version := permission.SchemaVersion
+			stored, err := s.storeUpgrade(ctx, permission, version, upgradeSchema(permission))
+			if err != nil {
+				return err
+			}
+
+			if stored {
+				upgraded++
+				schemaUpgradeCounts.Add("backfill", 1)
+			}
+		}
+
+		if err := progress(upgraded, total); err != nil {
+			return err
+		}
+	}
+}
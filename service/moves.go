@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FileNode is a file of the file service's hierarchy and the kind of resource it is.
+type FileNode struct {
+	FileID       string
+	ResourceKind string
+}
+
+// FileTree is an interface for the file service's hierarchy of files.
+type FileTree interface {
+	// GetSubtree returns fileID followed by its descendants, if it's a folder,
+	// fails with codes.NotFound if fileID doesn't exist.
+	GetSubtree(ctx context.Context, fileID string) ([]FileNode, error)
+}
+
+// InheritanceReport is the result of recomputing the inherited permissions of a subtree of files.
+type InheritanceReport struct {
+	// Created is the number of inherited permissions that were created.
+	Created int64
+
+	// Deleted is the number of inherited permissions that were deleted.
+	Deleted int64
+}
+
+// WithFileTree returns a copy of the service that reads the subtrees of moved files from files.
+func (s Service) WithFileTree(files FileTree) Service {
+	s.files = files
+	return s
+}
+
+// HandleFileMoved is the request handler for recomputing the inherited permissions of a file,
+// and of its descendants, after it was moved from one folder to another.
+func (s Service) HandleFileMoved(
+	ctx context.Context,
+	req *pb.HandleFileMovedRequest,
+) (*pb.HandleFileMovedResponse, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	resourceType := resourceTypeOrDefault(req.GetResourceType())
+	fileID := req.GetFileID()
+	if fileID == "" {
+		return nil, fmt.Errorf("fileID is required")
+	}
+
+	if req.GetNewParent() == fileID {
+		return nil, fmt.Errorf("file %s can't be moved into itself", fileID)
+	}
+
+	if req.GetOldParent() == req.GetNewParent() {
+		return &pb.HandleFileMovedResponse{}, nil
+	}
+
+	if s.files == nil {
+		return nil, status.Error(codes.FailedPrecondition, "the file service isn't configured")
+	}
+
+	subtree, err := s.files.GetSubtree(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, node := range subtree {
+		if node.FileID == req.GetNewParent() {
+			return nil, fmt.Errorf("file %s can't be moved into its descendant %s", fileID, node.FileID)
+		}
+	}
+
+	report, err := s.controller.ReplaceInherited(ctx, resourceType, subtree, req.GetNewParent())
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"fileID":    fileID,
+		"oldParent": req.GetOldParent(),
+		"newParent": req.GetNewParent(),
+		"files":     len(subtree),
+		"created":   report.Created,
+		"deleted":   report.Deleted,
+	}).Info("recomputed inherited permissions of moved file")
+
+	return &pb.HandleFileMovedResponse{Created: report.Created, Deleted: report.Deleted}, nil
+}
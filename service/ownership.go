@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+
+	pbv2 "github.com/meateam/permission-service/proto/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// AssignOwner is the request handler for making a user the owner of an orphaned resource,
+// whose owner's account was deleted.
+func (s AdminService) AssignOwner(ctx context.Context, req *pbv2.AssignOwnerRequest) (*pbv2.Permission, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	resourceType, fileID, err := parseResourceName(req.GetResource())
+	if err != nil {
+		return nil, err
+	}
+
+	// The file service verifies that the file exists.
+	if s.files != nil {
+		if _, err := s.files.GetFileOwner(ctx, fileID); err != nil {
+			return nil, err
+		}
+	}
+
+	permission, err := s.controller.AssignOwner(ctx, resourceType, fileID, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"resourceType": resourceType,
+		"fileID":       fileID,
+		"owner":        req.GetUserId(),
+		"reason":       req.GetReason(),
+		"assignedBy":   actorOrCaller(ctx),
+	}).Warn("owner assigned")
+
+	return marshalPermissionV2(permission)
+}
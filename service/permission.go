@@ -1,15 +1,164 @@
 package service
 
 import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	tspb "github.com/golang/protobuf/ptypes/timestamp"
 	pb "github.com/meateam/permission-service/proto"
 )
 
+// PermissionField is the name of a field of a Permission.
+type PermissionField string
+
+const (
+	// UserIDField is the userID of a Permission.
+	UserIDField PermissionField = "userID"
+
+	// CreatorField is the creator of a Permission.
+	CreatorField PermissionField = "creator"
+
+	// LastAccessedAtField is the lastAccessedAt of a Permission.
+	LastAccessedAtField PermissionField = "lastAccessedAt"
+
+	// ETagField is the etag of a Permission.
+	ETagField PermissionField = "etag"
+
+	// RoleField is the role of a Permission.
+	RoleField PermissionField = "role"
+
+	// CanReshareField is the canReshare of a Permission.
+	CanReshareField PermissionField = "canReshare"
+
+	// MessageField is the message of a Permission.
+	MessageField PermissionField = "message"
+
+	// LabelField is the label of a Permission.
+	LabelField PermissionField = "label"
+
+	// SharingChainField is the sharingChain of a Permission.
+	SharingChainField PermissionField = "sharingChain"
+
+	// LabelsField is the key-value labels of a Permission.
+	LabelsField PermissionField = "labels"
+
+	// SourceField is the source of a Permission.
+	SourceField PermissionField = "source"
+
+	// InheritedFromField is the inheritedFrom of a Permission.
+	InheritedFromField PermissionField = "inheritedFrom"
+)
+
+// PermissionsFilter filters permissions by the fields that are set.
+type PermissionsFilter struct {
+	ResourceType string
+	FileIDs      []string
+	UserIDs      []string
+	Creator      string
+
+	// Labels matches the permissions that have all of its labels.
+	Labels map[string]string
+	Source string
+}
+
+// PermissionSelector selects the permissions of a resource or a user by the fields that are set.
+type PermissionSelector struct {
+	// Labels selects the permissions that have all of its labels.
+	Labels map[string]string
+
+	// Source selects the permissions that were created by the system.
+	Source string
+}
+
+// RoleUpdate is a change of the role of the permission of UserID to FileID, in a batch of role updates.
+type RoleUpdate struct {
+	ResourceType string
+	FileID       string
+	UserID       string
+
+	// ETag is the permission's current etag, the permission is updated regardless of its etag if empty.
+	ETag string
+
+	Role pb.Role
+}
+
+// RoleUpdateResult is the result of a RoleUpdate in a batch, either the updated permission or the update's error.
+type RoleUpdateResult struct {
+	Permission Permission
+	Err        error
+}
+
+// CopyReport is the result of copying the permissions of a file to another file.
+type CopyReport struct {
+	// Copied is the number of permissions that were copied.
+	Copied int64
+
+	// Skipped is the number of permissions that weren't copied since their users already have a permission.
+	Skipped int64
+}
+
+// ReshareLimits contain the blast radius of viral sharing of a file, they're enforced when permissions
+// are created. A limit of 0 is unlimited.
+type ReshareLimits struct {
+	// MaxDepth is the maximum length of the sharing chain of a permission, which is the number of users
+	// it was shared through, starting with the user that shared it first, such as the file's owner.
+	MaxDepth int
+
+	// MaxGrants is the maximum number of permissions that are created transitively from a single reshareable
+	// permission, by its user and by the users it was reshared to.
+	MaxGrants int
+}
+
+// PermissionCount is the number of the permissions of ResourceType with Role.
+type PermissionCount struct {
+	ResourceType string
+	Role         pb.Role
+	Count        int64
+}
+
+// SharedFile is a file that two users have a permission to, and their roles.
+type SharedFile struct {
+	FileID    string
+	UserARole pb.Role
+	UserBRole pb.Role
+}
+
+// SharedWithMe is a file that another user shared with a user.
+type SharedWithMe struct {
+	FileID string
+	Role   pb.Role
+
+	// SharedBy is the user that shared the file, the creator of the permission.
+	SharedBy string
+
+	// SharedAt is the last time the file was shared with the user.
+	SharedAt time.Time
+}
+
+// PermissionUpdate holds the values of the updatable fields of a Permission, for creating or updating it.
+type PermissionUpdate struct {
+	Role       pb.Role
+	CanReshare bool
+	Message    string
+	Label      string
+	Labels     map[string]string
+
+	// ResourceKind, GranteeType and Source are set only when the permission is created.
+	ResourceKind string
+	GranteeType  string
+	Source       string
+}
+
 // Permission is an interface of a permission object.
 type Permission interface {
 	GetID() string
 
 	SetID(id string) error
 
+	GetResourceType() string
+
+	SetResourceType(resourceType string) error
+
 	GetFileID() string
 
 	SetFileID(fileID string) error
@@ -26,5 +175,58 @@ type Permission interface {
 
 	SetCreator(creator string) error
 
+	GetCanReshare() bool
+
+	SetCanReshare(canReshare bool) error
+
+	GetMessage() string
+
+	SetMessage(message string) error
+
+	GetLabel() string
+
+	SetLabel(label string) error
+
+	GetLabels() map[string]string
+
+	SetLabels(labels map[string]string) error
+
+	GetSource() string
+
+	SetSource(source string) error
+
+	GetInheritedFrom() string
+
+	SetInheritedFrom(inheritedFrom string) error
+
+	GetLastAccessedAt() time.Time
+
+	SetLastAccessedAt(lastAccessedAt time.Time) error
+
+	GetETag() string
+
+	GetCreatedAt() time.Time
+
+	GetSharingChain() []string
+
+	GetResourceKind() string
+
+	SetResourceKind(resourceKind string) error
+
+	GetGranteeType() string
+
+	SetGranteeType(granteeType string) error
+
+	SetSharingChain(sharingChain []string) error
+
 	MarshalProto(permission *pb.PermissionObject) error
 }
+
+// TimestampProto converts t to a proto timestamp, returns nil if t is zero.
+func TimestampProto(t time.Time) (*tspb.Timestamp, error) {
+	if t.IsZero() {
+		return nil, nil
+	}
+
+	return ptypes.TimestampProto(t)
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	pb "github.com/meateam/permission-service/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// AnyCaller is the RolePolicy key of the maximum role of callers that have no policy of their own.
+const AnyCaller = "*"
+
+// RolePolicy maps the identity of a calling service to the maximum role it may grant.
+// An empty policy allows every caller to grant any role. Otherwise the callers must be verified,
+// and the callers without a policy of their own may only grant the roles of the AnyCaller policy, if it's set.
+type RolePolicy map[string]pb.Role
+
+// ParseRolePolicy parses a policy of the form "caller=ROLE,caller=ROLE", such as
+// "preview-service=READ,*=WRITE", an empty policy is valid.
+func ParseRolePolicy(policy string) (RolePolicy, error) {
+	rolePolicy := RolePolicy{}
+	for _, entry := range strings.Split(policy, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("invalid role policy entry %q", entry)
+		}
+
+		role, ok := pb.Role_value[strings.TrimSpace(parts[1])]
+		if !ok {
+			return nil, fmt.Errorf("invalid role policy entry %q: role does not exist", entry)
+		}
+
+		rolePolicy[strings.TrimSpace(parts[0])] = pb.Role(role)
+	}
+
+	return rolePolicy, nil
+}
+
+// Authorize returns a PermissionDenied error if caller may not grant role, otherwise returns nil.
+// caller is the verified identity of the caller, or an empty string if it's not verified.
+func (p RolePolicy) Authorize(caller string, role pb.Role) error {
+	if len(p) == 0 {
+		return nil
+	}
+
+	if caller == "" {
+		return RejectionError(
+			codes.PermissionDenied,
+			Rejection{Kind: RejectionPolicy, Rule: "caller_role_policy"},
+			"unverified callers may not grant roles",
+		)
+	}
+
+	maxRole, ok := p[caller]
+	if !ok {
+		maxRole, ok = p[AnyCaller]
+	}
+
+	if ok && IsSubRole(maxRole, role) {
+		return nil
+	}
+
+	return RejectionError(
+		codes.PermissionDenied,
+		Rejection{Kind: RejectionPolicy, Rule: "caller_role_policy", Subject: caller},
+		"caller %q may not grant role %s",
+		caller,
+		role,
+	)
+}
+
+// CallerFromContext returns the verified identity of the calling service, which is the common name
+// of its verified TLS client certificate, or an empty string if the caller is not verified.
+// The caller of a write that a replica region forwarded is the caller that the replica verified.
+func CallerFromContext(ctx context.Context) string {
+	if caller, ok := ctx.Value(forwardedCallerKey{}).(string); ok {
+		return caller
+	}
+
+	return peerCaller(ctx)
+}
+
+// peerCaller returns the common name of the verified TLS client certificate of the peer of ctx,
+// or an empty string if it's not verified.
+func peerCaller(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return ""
+	}
+
+	return tlsInfo.State.VerifiedChains[0][0].Subject.CommonName
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"testing"
+
+	pb "github.com/meateam/permission-service/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRolePolicyAuthorize(t *testing.T) {
+	restricted, err := ParseRolePolicy("preview-service=READ")
+	if err != nil {
+		t.Fatalf("ParseRolePolicy failed: %v", err)
+	}
+
+	withDefault, err := ParseRolePolicy("preview-service=READ, *=WRITE")
+	if err != nil {
+		t.Fatalf("ParseRolePolicy failed: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		policy  RolePolicy
+		caller  string
+		role    pb.Role
+		allowed bool
+	}{
+		{name: "empty policy", policy: RolePolicy{}, caller: "", role: pb.Role_WRITE, allowed: true},
+		{name: "within the caller's role", policy: restricted, caller: "preview-service", role: pb.Role_READ,
+			allowed: true},
+		{name: "above the caller's role", policy: restricted, caller: "preview-service", role: pb.Role_WRITE},
+		{name: "unknown caller", policy: restricted, caller: "other-service", role: pb.Role_READ},
+		{name: "unverified caller", policy: restricted, caller: "", role: pb.Role_READ},
+		{name: "unknown caller by default", policy: withDefault, caller: "other-service", role: pb.Role_WRITE,
+			allowed: true},
+		{name: "unverified caller by default", policy: withDefault, caller: "", role: pb.Role_READ},
+	}
+
+	for _, test := range tests {
+		err := test.policy.Authorize(test.caller, test.role)
+		if test.allowed && err != nil {
+			t.Errorf("%s: expected %q to be allowed to grant %s, got %v", test.name, test.caller, test.role, err)
+		}
+
+		if !test.allowed && status.Code(err) != codes.PermissionDenied {
+			t.Errorf("%s: expected %q to be denied granting %s, got %v", test.name, test.caller, test.role, err)
+		}
+	}
+
+	if _, err := ParseRolePolicy("preview-service=OWNER"); err == nil {
+		t.Errorf("expected a policy of a role that doesn't exist to be invalid")
+	}
+}
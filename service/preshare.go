@@ -0,0 +1,181 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"net/http"
+	"time"
+
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// preShareVerdicts counts the outcomes of the pre-share checks, keyed by "allowed", "vetoed",
+// "failed_open" and "failed_closed", the latter two of the checks whose hook failed.
+var preShareVerdicts = expvar.NewMap("pre_share_verdicts")
+
+// ShareCheck is a share of a file that's checked before its permission is created.
+type ShareCheck struct {
+	ResourceType string
+	FileID       string
+	UserID       string
+	GranteeType  string
+	Role         pb.Role
+	Creator      string
+}
+
+// ShareVerdict is the verdict of a pre-share hook about a share.
+type ShareVerdict struct {
+	// Allowed is whether the file may be shared.
+	Allowed bool
+
+	// Reason is why the file may not be shared, such as "infected" or "dlp_flagged".
+	Reason string
+}
+
+// PreShareHook is an interface for an external authority on whether files may be shared,
+// such as an antivirus or a DLP service, that may veto the creation of permissions.
+type PreShareHook interface {
+	// CheckShare returns the verdict about check, or an error if there's none.
+	CheckShare(ctx context.Context, check ShareCheck) (ShareVerdict, error)
+}
+
+// PreSharePolicy vetoes the creation of permissions by the verdicts of a pre-share hook.
+type PreSharePolicy struct {
+	// Hook is the hook whose verdicts veto the shares, no share is vetoed if it's nil.
+	Hook PreShareHook
+
+	// FailOpen is whether shares are allowed when the hook fails, otherwise they're rejected
+	// with an Unavailable error, so that they may be retried.
+	FailOpen bool
+
+	// Logger logs the failures of the hook.
+	Logger *logrus.Logger
+}
+
+// Authorize returns a PermissionDenied error if the hook vetoes check, or an Unavailable error if the hook
+// fails and p doesn't fail open, otherwise returns nil.
+func (p PreSharePolicy) Authorize(ctx context.Context, check ShareCheck) error {
+	if p.Hook == nil {
+		return nil
+	}
+
+	name := ResourceName(check.ResourceType, check.FileID)
+	verdict, err := p.Hook.CheckShare(ctx, check)
+	if err != nil {
+		if p.Logger != nil {
+			p.Logger.WithField("resource", name).Errorf("pre-share hook failed: %v", err)
+		}
+
+		if p.FailOpen {
+			preShareVerdicts.Add("failed_open", 1)
+			return nil
+		}
+
+		preShareVerdicts.Add("failed_closed", 1)
+		return status.Errorf(codes.Unavailable, "the pre-share verdict of %s is unavailable", name)
+	}
+
+	if verdict.Allowed {
+		preShareVerdicts.Add("allowed", 1)
+		return nil
+	}
+
+	preShareVerdicts.Add("vetoed", 1)
+	reason := verdict.Reason
+	if reason == "" {
+		reason = "vetoed by the pre-share hook"
+	}
+
+	return RejectionError(
+		codes.PermissionDenied,
+		Rejection{Kind: RejectionPolicy, Rule: "pre_share_verdict", Subject: name},
+		"%s may not be shared: %s",
+		name,
+		reason,
+	)
+}
+
+// shareCheckRequest is the JSON body of a share that's posted to an HTTP pre-share hook.
+type shareCheckRequest struct {
+	ResourceType string `json:"resourceType"`
+	FileID       string `json:"fileID"`
+	UserID       string `json:"userID"`
+	GranteeType  string `json:"granteeType"`
+	Role         string `json:"role"`
+	Creator      string `json:"creator"`
+}
+
+// shareCheckResponse is the JSON body of the verdict of an HTTP pre-share hook.
+type shareCheckResponse struct {
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason"`
+}
+
+// HTTPPreShareHook is a PreShareHook that posts the shares to a URL as JSON, and reads the verdicts
+// from the JSON bodies of its 2xx responses, such as {"allowed": false, "reason": "infected"}.
+// A response of another status is a failure of the hook.
+type HTTPPreShareHook struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPPreShareHook creates an HTTPPreShareHook that posts the shares to url within timeout, and returns it.
+func NewHTTPPreShareHook(url string, timeout time.Duration) HTTPPreShareHook {
+	return HTTPPreShareHook{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+// CheckShare posts check to the hook and returns its verdict.
+func (h HTTPPreShareHook) CheckShare(ctx context.Context, check ShareCheck) (ShareVerdict, error) {
+	body, err := json.Marshal(shareCheckRequest{
+		ResourceType: resourceTypeOrDefault(check.ResourceType),
+		FileID:       check.FileID,
+		UserID:       check.UserID,
+		GranteeType:  check.GranteeType,
+		Role:         check.Role.String(),
+		Creator:      check.Creator,
+	})
+	if err != nil {
+		return ShareVerdict{}, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return ShareVerdict{}, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	res, err := h.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return ShareVerdict{}, fmt.Errorf("failed posting the share of %s: %v", check.FileID, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return ShareVerdict{}, fmt.Errorf("failed posting the share of %s: %s", check.FileID, res.Status)
+	}
+
+	var verdict shareCheckResponse
+	if err := json.NewDecoder(res.Body).Decode(&verdict); err != nil {
+		return ShareVerdict{}, fmt.Errorf("invalid verdict of the share of %s: %v", check.FileID, err)
+	}
+
+	return ShareVerdict{Allowed: verdict.Allowed, Reason: verdict.Reason}, nil
+}
+
+// WithPreSharePolicy returns a copy of the service that vetoes the creation of permissions with preShare.
+func (s Service) WithPreSharePolicy(preShare PreSharePolicy) Service {
+	s.preShare = preShare
+	return s
+}
+
+// WithPreSharePolicy returns a copy of the service that vetoes the creation of permissions with preShare.
+func (s ServiceV2) WithPreSharePolicy(preShare PreSharePolicy) ServiceV2 {
+	s.preShare = preShare
+	return s
+}
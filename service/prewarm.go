@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+
+	pbv2 "github.com/meateam/permission-service/proto/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// PrewarmFiles is the request handler for loading the permissions of hot files ahead of an anticipated load,
+// into the store's cache and into the last-known permissions of the service.
+func (s AdminService) PrewarmFiles(
+	ctx context.Context,
+	req *pbv2.PrewarmFilesRequest,
+) (*pbv2.PrewarmFilesResponse, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.limits.CheckList("files", len(req.GetFiles())); err != nil {
+		return nil, err
+	}
+
+	// The files are grouped by their resource type, in the order of the request, without duplicates.
+	var resourceTypes []string
+	fileIDs := map[string][]string{}
+	seen := map[string]bool{}
+	for _, name := range req.GetFiles() {
+		resourceType, fileID, err := parseResourceName(name)
+		if err != nil {
+			return nil, err
+		}
+
+		if seen[resourceType+"/"+fileID] {
+			continue
+		}
+
+		seen[resourceType+"/"+fileID] = true
+		if _, ok := fileIDs[resourceType]; !ok {
+			resourceTypes = append(resourceTypes, resourceType)
+		}
+
+		fileIDs[resourceType] = append(fileIDs[resourceType], fileID)
+	}
+
+	response := &pbv2.PrewarmFilesResponse{Files: int64(len(seen))}
+	for _, resourceType := range resourceTypes {
+		permissions, err := s.controller.PrewarmFiles(ctx, resourceType, fileIDs[resourceType])
+		if err != nil {
+			return nil, err
+		}
+
+		for _, permission := range permissions {
+			s.lastKnown.record(resourceType, permission.GetFileID(), permission.GetUserID(), permission)
+		}
+
+		response.Permissions += int64(len(permissions))
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"files":       response.GetFiles(),
+		"permissions": response.GetPermissions(),
+		"prewarmedBy": actorOrCaller(ctx),
+	}).Info("files prewarmed")
+
+	return response, nil
+}
@@ -0,0 +1,329 @@
+package service
+
+import (
+	"context"
+	"expvar"
+	"math"
+	"sync"
+	"time"
+
+	pbv2 "github.com/meateam/permission-service/proto/v2"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// rateLimitedRequests counts the requests that were rejected by the rate limits of the tenant,
+// keyed by "reads" or "writes".
+var rateLimitedRequests = expvar.NewMap("rate_limited_requests")
+
+// TenantRateLimits are the rate limits of the reads and the writes of a tenant, which each instance
+// of its deployment enforces. A limit of 0 is unlimited.
+type TenantRateLimits struct {
+	TenantID        string
+	ReadsPerSecond  int64
+	WritesPerSecond int64
+	UpdatedBy       string
+	UpdatedAt       time.Time
+}
+
+// rateBucket is a token bucket of a rate limit, whose burst is a second of requests,
+// and that counts the requests it admits in each second.
+type rateBucket struct {
+	limit  int64
+	tokens float64
+	last   time.Time
+
+	// admitted is the number of the requests admitted in the second that started at second,
+	// and previous the number of the ones admitted in the second before it.
+	second   time.Time
+	admitted int64
+	previous int64
+}
+
+// setLimit sets the limit of b, and fills it if the limit changed.
+func (b *rateBucket) setLimit(limit int64, now time.Time) {
+	if b.limit != limit {
+		b.limit, b.tokens, b.last = limit, float64(limit), now
+	}
+}
+
+// take takes a token of b at now, and returns whether there was one, or else the delay until there's one.
+func (b *rateBucket) take(now time.Time) (bool, time.Duration) {
+	if b.limit > 0 {
+		b.tokens = math.Min(float64(b.limit), b.tokens+now.Sub(b.last).Seconds()*float64(b.limit))
+		b.last = now
+		if b.tokens < 1 {
+			return false, time.Duration((1 - b.tokens) / float64(b.limit) * float64(time.Second))
+		}
+
+		b.tokens--
+	}
+
+	b.roll(now)
+	b.admitted++
+	return true, 0
+}
+
+// usage returns the number of the requests that b admitted in the last whole second before now.
+func (b *rateBucket) usage(now time.Time) int64 {
+	b.roll(now)
+	return b.previous
+}
+
+// roll starts the second of now, if it hasn't started yet.
+func (b *rateBucket) roll(now time.Time) {
+	second := now.Truncate(time.Second)
+	if !second.After(b.second) {
+		return
+	}
+
+	b.previous = 0
+	if second.Sub(b.second) == time.Second {
+		b.previous = b.admitted
+	}
+
+	b.second, b.admitted = second, 0
+}
+
+// RateLimiter enforces the rate limits of the tenant of the service on the reads and the writes of the instance,
+// which are the RPCs that are annotated with the NO_SIDE_EFFECTS idempotency level in the protos and the rest of
+// them, and rejects the requests over the limits. The limits are refreshed from the tenant's settings.
+type RateLimiter struct {
+	controller Controller
+	logger     *logrus.Logger
+
+	mu     sync.Mutex
+	limits TenantRateLimits
+	reads  rateBucket
+	writes rateBucket
+}
+
+// NewRateLimiter creates a RateLimiter of the rate limits of the tenant of controller, which are unlimited
+// until they're refreshed, and returns it.
+func NewRateLimiter(controller Controller, logger *logrus.Logger) *RateLimiter {
+	return &RateLimiter{controller: controller, logger: logger}
+}
+
+// Run is running an infinite loop that refreshes the rate limits from the tenant's settings once in interval,
+// until ctx is done. Failed refreshes are logged, and the limits are kept until the next one.
+func (l *RateLimiter) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		limits, err := l.controller.GetTenantRateLimits(ctx, "")
+		if err != nil && ctx.Err() == nil {
+			l.logger.Errorf("failed refreshing the rate limits: %v", err)
+		}
+
+		if err == nil {
+			l.Set(limits)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Set sets the rate limits of the tenant.
+func (l *RateLimiter) Set(limits TenantRateLimits) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.limits = limits
+	l.reads.setLimit(limits.ReadsPerSecond, now)
+	l.writes.setLimit(limits.WritesPerSecond, now)
+}
+
+// Usage returns the numbers of the reads and the writes that were admitted in the last second.
+func (l *RateLimiter) Usage() (int64, int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	return l.reads.usage(now), l.writes.usage(now)
+}
+
+// Middleware returns the middleware that rejects the requests of the services of descs over the rate limits.
+// The requests of other services aren't limited. It fails if the proto of one of descs isn't registered.
+func (l *RateLimiter) Middleware(descs ...grpc.ServiceDesc) (Middleware, error) {
+	reads, err := readMethods(descs)
+	if err != nil {
+		return Middleware{}, err
+	}
+
+	unary := func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if read, ok := reads[info.FullMethod]; ok {
+			if err := l.allow(read); err != nil {
+				return nil, err
+			}
+		}
+
+		return handler(ctx, req)
+	}
+
+	stream := func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if read, ok := reads[info.FullMethod]; ok {
+			if err := l.allow(read); err != nil {
+				return err
+			}
+		}
+
+		return handler(srv, stream)
+	}
+
+	return Middleware{Unary: unary, Stream: stream}, nil
+}
+
+// allow returns a ResourceExhausted error, with the delay after which it may be retried,
+// if a read, or a write if read is false, is over its rate limit, otherwise returns nil.
+func (l *RateLimiter) allow(read bool) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	bucket, kind, rule := &l.writes, "writes", "tenant_writes_per_second"
+	if read {
+		bucket, kind, rule = &l.reads, "reads", "tenant_reads_per_second"
+	}
+
+	now := time.Now()
+	ok, retryAfter := bucket.take(now)
+	if ok {
+		return nil
+	}
+
+	rateLimitedRequests.Add(kind, 1)
+	return RejectionError(
+		codes.ResourceExhausted,
+		Rejection{
+			Kind:       RejectionQuota,
+			Rule:       rule,
+			Subject:    l.limits.TenantID,
+			Current:    bucket.usage(now),
+			Limit:      bucket.limit,
+			RetryAfter: retryAfter,
+		},
+		"the rate limit of %d %s per second is exceeded",
+		bucket.limit,
+		kind,
+	)
+}
+
+// WithRateLimiter returns a copy of the service that applies the rate limits it sets for the tenant
+// of the service to rates, and reports its usage.
+func (s AdminService) WithRateLimiter(rates *RateLimiter) AdminService {
+	s.rates = rates
+	return s
+}
+
+// GetTenantRateLimits is the request handler for getting the rate limits of a tenant.
+func (s AdminService) GetTenantRateLimits(
+	ctx context.Context,
+	req *pbv2.GetTenantRateLimitsRequest,
+) (*pbv2.TenantRateLimits, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	tenantID := req.GetTenantId()
+	if tenantID != "" {
+		if err := ValidateTenantID(tenantID); err != nil {
+			return nil, err
+		}
+	}
+
+	limits, err := s.controller.GetTenantRateLimits(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.marshalTenantRateLimits(limits)
+}
+
+// SetTenantRateLimits is the request handler for setting the rate limits of a tenant.
+func (s AdminService) SetTenantRateLimits(
+	ctx context.Context,
+	req *pbv2.SetTenantRateLimitsRequest,
+) (*pbv2.TenantRateLimits, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	tenantID := req.GetTenantId()
+	if tenantID != "" {
+		if err := ValidateTenantID(tenantID); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.GetReadsPerSecond() < 0 || req.GetWritesPerSecond() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "reads_per_second and writes_per_second must not be negative")
+	}
+
+	limits, err := s.controller.SetTenantRateLimits(ctx, TenantRateLimits{
+		TenantID:        tenantID,
+		ReadsPerSecond:  req.GetReadsPerSecond(),
+		WritesPerSecond: req.GetWritesPerSecond(),
+		UpdatedBy:       actorOrCaller(ctx),
+		UpdatedAt:       time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The other instances apply the limits of the service's tenant once they refresh them.
+	if tenantID == "" && s.rates != nil {
+		s.rates.Set(limits)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"tenantID":        tenantID,
+		"readsPerSecond":  limits.ReadsPerSecond,
+		"writesPerSecond": limits.WritesPerSecond,
+		"updatedBy":       limits.UpdatedBy,
+	}).Info("tenant rate limits set")
+
+	return s.marshalTenantRateLimits(limits)
+}
+
+// marshalTenantRateLimits returns limits as a pbv2.TenantRateLimits, with the usage of the instance
+// if they're of the service's own tenant.
+func (s AdminService) marshalTenantRateLimits(limits TenantRateLimits) (*pbv2.TenantRateLimits, error) {
+	limitsV2 := &pbv2.TenantRateLimits{
+		TenantId:        limits.TenantID,
+		ReadsPerSecond:  limits.ReadsPerSecond,
+		WritesPerSecond: limits.WritesPerSecond,
+		UpdatedBy:       limits.UpdatedBy,
+	}
+
+	if !limits.UpdatedAt.IsZero() {
+		updateTime, err := TimestampProto(limits.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		limitsV2.UpdateTime = updateTime
+	}
+
+	if limits.TenantID == "" && s.rates != nil {
+		limitsV2.CurrentReadsPerSecond, limitsV2.CurrentWritesPerSecond = s.rates.Usage()
+	}
+
+	return limitsV2, nil
+}
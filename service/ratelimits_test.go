@@ -0,0 +1,96 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRateBucket(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	var bucket rateBucket
+	bucket.setLimit(2, start)
+
+	tests := []struct {
+		name       string
+		after      time.Duration
+		ok         bool
+		retryAfter time.Duration
+	}{
+		{name: "first token", ok: true},
+		{name: "second token", ok: true},
+		{name: "empty", retryAfter: 500 * time.Millisecond},
+		{name: "partly refilled", after: 250 * time.Millisecond, retryAfter: 250 * time.Millisecond},
+		{name: "refilled", after: 500 * time.Millisecond, ok: true},
+		{name: "burst of a second", after: 10 * time.Second, ok: true},
+		{name: "burst of a second, second token", after: 10 * time.Second, ok: true},
+		{name: "burst of a second, empty", after: 10 * time.Second, retryAfter: 500 * time.Millisecond},
+	}
+
+	for _, test := range tests {
+		ok, retryAfter := bucket.take(start.Add(test.after))
+		if ok != test.ok || retryAfter != test.retryAfter {
+			t.Errorf(
+				"%s: expected take to return %v, %v, got %v, %v",
+				test.name,
+				test.ok,
+				test.retryAfter,
+				ok,
+				retryAfter,
+			)
+		}
+	}
+}
+
+func TestRateBucketUsage(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	var bucket rateBucket
+	for i := 0; i < 3; i++ {
+		if ok, _ := bucket.take(start.Add(time.Duration(i) * time.Millisecond)); !ok {
+			t.Fatalf("expected an unlimited bucket to admit every request")
+		}
+	}
+
+	if usage := bucket.usage(start.Add(500 * time.Millisecond)); usage != 0 {
+		t.Errorf("expected no usage before the second is over, got %d", usage)
+	}
+
+	if usage := bucket.usage(start.Add(time.Second)); usage != 3 {
+		t.Errorf("expected the usage of the last second to be 3, got %d", usage)
+	}
+
+	if usage := bucket.usage(start.Add(3 * time.Second)); usage != 0 {
+		t.Errorf("expected no usage after an idle second, got %d", usage)
+	}
+}
+
+func TestRateLimiterAllow(t *testing.T) {
+	limiter := NewRateLimiter(nil, nil)
+	if err := limiter.allow(true); err != nil {
+		t.Fatalf("expected the reads to be unlimited until the limits are set, got %v", err)
+	}
+
+	limiter.Set(TenantRateLimits{TenantID: "tenant", ReadsPerSecond: 1})
+	if err := limiter.allow(true); err != nil {
+		t.Fatalf("expected the first read to be allowed, got %v", err)
+	}
+
+	err := limiter.allow(true)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected the second read to fail with ResourceExhausted, got %v", err)
+	}
+
+	rejection, ok := RejectionFromError(err)
+	if !ok || rejection.Rule != "tenant_reads_per_second" || rejection.Subject != "tenant" ||
+		rejection.Limit != 1 || rejection.RetryAfter <= 0 {
+		t.Errorf("unexpected rejection of the second read: %+v", rejection)
+	}
+
+	for i := 0; i < 10; i++ {
+		if err := limiter.allow(false); err != nil {
+			t.Fatalf("expected the writes to be unlimited, got %v", err)
+		}
+	}
+}
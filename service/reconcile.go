@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DiscrepancyMissingFile is the kind of a permission to a file that doesn't exist in the file service.
+	DiscrepancyMissingFile = "missing_file"
+
+	// DiscrepancyOwnerMismatch is the kind of a permission to a file whose owner,
+	// according to the file service, doesn't have a WRITE permission to it.
+	DiscrepancyOwnerMismatch = "owner_mismatch"
+)
+
+// FileMetadata is an interface for the file service's metadata of files.
+type FileMetadata interface {
+	// GetFileOwner returns the ID of the file's owner, fails with codes.NotFound if the file doesn't exist.
+	GetFileOwner(ctx context.Context, fileID string) (string, error)
+}
+
+// Discrepancy is a file whose permissions don't match the file service's metadata.
+type Discrepancy struct {
+	Kind   string
+	FileID string
+
+	// UserID is the owner of the file, if it exists.
+	UserID string
+}
+
+// ReconciliationReport is the result of a single reconciliation of sampled permissions.
+type ReconciliationReport struct {
+	// Sampled is the number of sampled permissions.
+	Sampled int
+
+	// Checked is the number of files of the sampled permissions that were verified against the file service.
+	Checked int
+
+	// Failed is the number of files of the sampled permissions that couldn't be verified.
+	Failed int
+
+	Discrepancies []Discrepancy
+}
+
+// Reconciler periodically verifies a sample of the permissions against the file service's metadata.
+type Reconciler struct {
+	controller Controller
+	files      FileMetadata
+	logger     *logrus.Logger
+	sampleSize int
+}
+
+// NewReconciler creates a Reconciler that samples sampleSize permissions on each run and returns it.
+func NewReconciler(controller Controller, files FileMetadata, logger *logrus.Logger, sampleSize int) Reconciler {
+	return Reconciler{controller: controller, files: files, logger: logger, sampleSize: sampleSize}
+}
+
+// Reconcile samples permissions and verifies that the files they refer to still exist
+// and that the owners of the files have a WRITE permission to them.
+func (r Reconciler) Reconcile(ctx context.Context) (ReconciliationReport, error) {
+	report := ReconciliationReport{}
+	permissions, err := r.controller.SamplePermissions(ctx, r.sampleSize)
+	if err != nil {
+		return report, err
+	}
+
+	report.Sampled = len(permissions)
+
+	// Files may be sampled more than once, each file is verified once per run.
+	reconciled := make(map[string]bool)
+	for _, permission := range permissions {
+		fileID := permission.GetFileID()
+		if permission.GetResourceType() != DefaultResourceType || reconciled[fileID] {
+			continue
+		}
+
+		reconciled[fileID] = true
+		discrepancy, err := r.reconcileFile(ctx, fileID)
+		if err != nil {
+			r.logger.Errorf("failed reconciling permissions of file %s: %v", fileID, err)
+			report.Failed++
+			continue
+		}
+
+		report.Checked++
+		if discrepancy != nil {
+			report.Discrepancies = append(report.Discrepancies, *discrepancy)
+		}
+	}
+
+	return report, nil
+}
+
+// reconcileFile verifies the permissions of fileID against the file service's metadata,
+// and returns the discrepancy that was found, if any.
+func (r Reconciler) reconcileFile(ctx context.Context, fileID string) (*Discrepancy, error) {
+	owner, err := r.files.GetFileOwner(ctx, fileID)
+	if status.Code(err) == codes.NotFound {
+		return &Discrepancy{Kind: DiscrepancyMissingFile, FileID: fileID}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	permission, err := r.controller.GetByFileAndUser(ctx, DefaultResourceType, fileID, owner, RoleField)
+	if err != nil && status.Code(err) != codes.NotFound {
+		return nil, err
+	}
+
+	if err != nil || permission.GetRole() != pb.Role_WRITE {
+		return &Discrepancy{Kind: DiscrepancyOwnerMismatch, FileID: fileID, UserID: owner}, nil
+	}
+
+	return nil, nil
+}
+
+// Run is running an infinite loop that reconciles the permissions once in interval
+// and logs the discrepancies that were found, until ctx is done.
+func (r Reconciler) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		report, err := r.Reconcile(ctx)
+		if err != nil {
+			r.logger.Errorf("failed reconciling permissions: %v", err)
+			continue
+		}
+
+		r.logReport(report)
+	}
+}
+
+// logReport logs the discrepancies of report and its summary.
+func (r Reconciler) logReport(report ReconciliationReport) {
+	discrepanciesByKind := map[string]int{DiscrepancyMissingFile: 0, DiscrepancyOwnerMismatch: 0}
+	for _, discrepancy := range report.Discrepancies {
+		discrepanciesByKind[discrepancy.Kind]++
+		r.logger.WithFields(logrus.Fields{
+			"discrepancy": discrepancy.Kind,
+			"fileID":      discrepancy.FileID,
+			"userID":      discrepancy.UserID,
+		}).Warn("permission doesn't match the file service")
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"sampled":                report.Sampled,
+		"checked":                report.Checked,
+		"failed":                 report.Failed,
+		DiscrepancyMissingFile:   discrepanciesByKind[DiscrepancyMissingFile],
+		DiscrepancyOwnerMismatch: discrepanciesByKind[DiscrepancyOwnerMismatch],
+	}).Info("reconciled permissions with the file service")
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"expvar"
+	"runtime/debug"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// handlerPanics counts the panics of the request handlers that were recovered, keyed by the full method.
+var handlerPanics = expvar.NewMap("handler_panics")
+
+// Recoverer recovers the panics of request handlers, such as of unmarshalling a malformed document,
+// into Internal errors, so that a panic fails its request rather than crashing the service.
+// The panics are logged with their stack traces and counted in the handler_panics metric.
+type Recoverer struct {
+	logger *logrus.Logger
+}
+
+// NewRecoverer creates a Recoverer that logs the panics it recovers to logger, and returns it.
+func NewRecoverer(logger *logrus.Logger) Recoverer {
+	return Recoverer{logger: logger}
+}
+
+// UnaryServerInterceptor returns a grpc unary server interceptor that recovers the panics of its handler.
+func (r Recoverer) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (_ interface{}, err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = r.recovered(info.FullMethod, p)
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns a grpc stream server interceptor that recovers the panics of its handler.
+func (r Recoverer) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) (err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = r.recovered(info.FullMethod, p)
+			}
+		}()
+
+		return handler(srv, stream)
+	}
+}
+
+// Middleware returns the middleware of the interceptors of r.
+func (r Recoverer) Middleware() Middleware {
+	return Middleware{Unary: r.UnaryServerInterceptor(), Stream: r.StreamServerInterceptor()}
+}
+
+// recovered logs and counts the panic p of the handler of method, and returns the error of its request.
+func (r Recoverer) recovered(method string, p interface{}) error {
+	handlerPanics.Add(method, 1)
+	r.logger.WithFields(logrus.Fields{
+		"grpc.method": method,
+		"panic":       p,
+		"stack":       string(debug.Stack()),
+	}).Error("recovered a panic of a request handler")
+
+	return status.Error(codes.Internal, "internal error")
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"expvar"
+	"io/ioutil"
+	"net"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// panickingHealthServer is a health server whose handlers panic, as on a malformed document.
+type panickingHealthServer struct{}
+
+// Check panics.
+func (panickingHealthServer) Check(
+	context.Context,
+	*grpc_health_v1.HealthCheckRequest,
+) (*grpc_health_v1.HealthCheckResponse, error) {
+	panic("malformed document")
+}
+
+// Watch panics.
+func (panickingHealthServer) Watch(*grpc_health_v1.HealthCheckRequest, grpc_health_v1.Health_WatchServer) error {
+	panic("malformed document")
+}
+
+// serveWithin serves a panicking health server whose handlers run within middlewares,
+// and returns a client connected to it, and a function that stops the server and closes the client.
+func serveWithin(t *testing.T, middlewares *Middlewares) (grpc_health_v1.HealthClient, func()) {
+	t.Helper()
+
+	chain, err := middlewares.Chain()
+	if err != nil {
+		t.Fatalf("Chain failed: %v", err)
+	}
+
+	server := grpc.NewServer(grpc.UnaryInterceptor(chain.Unary), grpc.StreamInterceptor(chain.Stream))
+	grpc_health_v1.RegisterHealthServer(server, panickingHealthServer{})
+
+	listener := bufconn.Listen(1 << 20)
+	go server.Serve(listener)
+
+	conn, err := grpc.DialContext(
+		context.Background(),
+		"bufconn",
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+	)
+	if err != nil {
+		server.Stop()
+		t.Fatalf("failed dialing the server: %v", err)
+	}
+
+	return grpc_health_v1.NewHealthClient(conn), func() {
+		conn.Close()
+		server.Stop()
+	}
+}
+
+// expvarInt returns the value of key in m, or 0 if it's not set.
+func expvarInt(m *expvar.Map, key string) int64 {
+	if value, ok := m.Get(key).(*expvar.Int); ok {
+		return value.Value()
+	}
+
+	return 0
+}
+
+func TestRecovererRecoversPanics(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+
+	middlewares := NewMiddlewares([]string{MiddlewareMetrics, MiddlewareRecovery})
+	middlewares.Register(MiddlewareMetrics, CountRequests())
+	middlewares.Register(MiddlewareRecovery, NewRecoverer(logger).Middleware())
+
+	client, stop := serveWithin(t, middlewares)
+	defer stop()
+
+	const checkMethod = "/grpc.health.v1.Health/Check"
+	panicsBefore := expvarInt(handlerPanics, checkMethod)
+
+	_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("expected the panic of Check to fail it with Internal, got %v", err)
+	}
+
+	if panics := expvarInt(handlerPanics, checkMethod); panics != panicsBefore+1 {
+		t.Errorf("expected the panic of Check to be counted, got %d panics after %d", panics, panicsBefore)
+	}
+
+	// The middlewares outside of the recovery see the recovered error.
+	if count := expvarInt(handledRequests, checkMethod+"/Internal"); count == 0 {
+		t.Errorf("expected the recovered request to be counted as Internal")
+	}
+
+	stream, err := client.Watch(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+
+	if _, err := stream.Recv(); status.Code(err) != codes.Internal {
+		t.Errorf("expected the panic of Watch to fail it with Internal, got %v", err)
+	}
+}
@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// JobPurgeRetention is the kind of the recurring jobs that delete the finished jobs and the failed
+	// scheduled updates that are older than the retention period.
+	JobPurgeRetention = "purge_retention"
+
+	// JobReconcile is the kind of the recurring jobs that reconcile sampled permissions with the file service.
+	JobReconcile = "reconcile"
+
+	// JobComputeStats is the kind of the recurring jobs that count the permissions of each resource type and role.
+	JobComputeStats = "compute_stats"
+
+	// recurringJobsActor is the actor, and so the creator, of the recurring jobs.
+	recurringJobsActor = "recurring-jobs"
+
+	// recurringJobsPollInterval is the interval in which the recurring jobs are checked for being due.
+	recurringJobsPollInterval = time.Second
+)
+
+// permissionStats is the number of permissions of each resource type and role, keyed by "{resourceType}/{role}",
+// as of the last JobComputeStats job.
+var permissionStats = expvar.NewMap("permission_stats")
+
+// RecurringJob is a maintenance job of Kind that's run on Schedule.
+type RecurringJob struct {
+	Kind     string
+	Schedule Schedule
+}
+
+// ParseRecurringJobs parses spec, semicolon separated "kind=schedule" jobs, such as
+// "purge_retention=0 3 * * *;reconcile=@hourly", and returns them. See ParseSchedule for the schedules.
+func ParseRecurringJobs(spec string) ([]RecurringJob, error) {
+	jobs := []RecurringJob{}
+	kinds := map[string]bool{}
+	for _, item := range strings.Split(spec, ";") {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+
+		parts := strings.SplitN(item, "=", 2)
+		kind := strings.TrimSpace(parts[0])
+		if len(parts) != 2 || kind == "" {
+			return nil, fmt.Errorf("invalid recurring job %q: expected kind=schedule", item)
+		}
+
+		if kinds[kind] {
+			return nil, fmt.Errorf("recurring job %s is scheduled more than once", kind)
+		}
+
+		schedule, err := ParseSchedule(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid recurring job %s: %v", kind, err)
+		}
+
+		kinds[kind] = true
+		jobs = append(jobs, RecurringJob{Kind: kind, Schedule: schedule})
+	}
+
+	return jobs, nil
+}
+
+// MaintenanceJobs are the operations of the recurring maintenance jobs.
+type MaintenanceJobs struct {
+	controller Controller
+	logger     *logrus.Logger
+
+	// retention is the age after which finished jobs and failed scheduled updates are purged.
+	retention time.Duration
+
+	// reconciler samples the permissions of files that no longer exist, it's nil if there's no file service.
+	reconciler *Reconciler
+
+	// staleGrantExpiry is the duration without use after which grants are expired, they aren't if it's 0.
+	staleGrantExpiry time.Duration
+}
+
+// NewMaintenanceJobs creates the MaintenanceJobs that purge what's older than retention, and returns them.
+func NewMaintenanceJobs(controller Controller, logger *logrus.Logger, retention time.Duration) MaintenanceJobs {
+	return MaintenanceJobs{controller: controller, logger: logger, retention: retention}
+}
+
+// WithReconciler returns a copy of m whose JobReconcile and JobCollectGarbage jobs run with reconciler.
+func (m MaintenanceJobs) WithReconciler(reconciler Reconciler) MaintenanceJobs {
+	m.reconciler = &reconciler
+	return m
+}
+
+// Operation returns the operation of the jobs of kind, or an error if kind isn't a maintenance job.
+func (m MaintenanceJobs) Operation(kind string) (JobFunc, error) {
+	switch kind {
+	case JobPurgeRetention:
+		return m.purgeRetention, nil
+	case JobComputeStats:
+		return m.computeStats, nil
+	case JobRefreshCollaborators:
+		return m.refreshCollaborators, nil
+	case JobUpgradeSchema:
+		return upgradeSchemaJob(m.controller, DefaultMigrationBatchSize), nil
+	case JobExpireStaleGrants:
+		if m.staleGrantExpiry <= 0 {
+			return nil, fmt.Errorf("recurring job %s requires a stale grant expiry", kind)
+		}
+
+		return m.expireStaleGrants, nil
+	case JobReconcile, JobCollectGarbage:
+		if m.reconciler == nil {
+			return nil, fmt.Errorf("recurring job %s requires the file service", kind)
+		}
+
+		if kind == JobReconcile {
+			return m.reconcile, nil
+		}
+
+		return m.collectGarbage, nil
+	default:
+		return nil, fmt.Errorf("unknown recurring job %s", kind)
+	}
+}
+
+// purgeRetention deletes the finished jobs and the failed scheduled updates that are older than the retention.
+func (m MaintenanceJobs) purgeRetention(ctx context.Context, progress func(JobProgress) error) error {
+	before := time.Now().Add(-m.retention)
+	jobs, err := m.controller.PurgeJobs(ctx, before)
+	if err != nil {
+		return err
+	}
+
+	updates, err := m.controller.PurgeFailedUpdates(ctx, before)
+	if err != nil {
+		return err
+	}
+
+	m.logger.WithFields(logrus.Fields{"jobs": jobs, "failedUpdates": updates}).Info("purged expired records")
+	return progress(JobProgress{Done: jobs + updates, Total: jobs + updates})
+}
+
+// computeStats counts the permissions of each resource type and role into the permission_stats metric.
+func (m MaintenanceJobs) computeStats(ctx context.Context, progress func(JobProgress) error) error {
+	counts, err := m.controller.CountPermissions(ctx)
+	if err != nil {
+		return err
+	}
+
+	permissionStats.Init()
+	for _, count := range counts {
+		stat := new(expvar.Int)
+		stat.Set(count.Count)
+		permissionStats.Set(count.ResourceType+"/"+count.Role.String(), stat)
+	}
+
+	return progress(JobProgress{Done: int64(len(counts)), Total: int64(len(counts))})
+}
+
+// reconcile reconciles sampled permissions with the file service and logs the discrepancies that were found.
+func (m MaintenanceJobs) reconcile(ctx context.Context, progress func(JobProgress) error) error {
+	report, err := m.reconciler.Reconcile(ctx)
+	if err != nil {
+		return err
+	}
+
+	m.reconciler.logReport(report)
+	return progress(JobProgress{
+		Done:   int64(report.Checked + report.Failed),
+		Failed: int64(report.Failed),
+		Total:  int64(report.Checked + report.Failed),
+	})
+}
+
+// collectGarbage deletes the permissions of the sampled files that no longer exist in the file service.
+func (m MaintenanceJobs) collectGarbage(ctx context.Context, progress func(JobProgress) error) error {
+	report, err := m.reconciler.Reconcile(ctx)
+	if err != nil {
+		return err
+	}
+
+	missing := []string{}
+	for _, discrepancy := range report.Discrepancies {
+		if discrepancy.Kind == DiscrepancyMissingFile {
+			missing = append(missing, discrepancy.FileID)
+		}
+	}
+
+	return eachJobItem(len(missing), progress, func(i int) error {
+		_, err := m.controller.DeleteFilePermissions(ctx, DefaultResourceType, missing[i], PermissionSelector{})
+		if err != nil {
+			m.logger.Errorf("failed collecting the permissions of file %s: %v", missing[i], err)
+		}
+
+		return err
+	})
+}
+
+// RecurringScheduler starts the recurring jobs on their schedules. Each run of a job is claimed in the store
+// before it's started, so it's run by a single instance of the service even if all of them schedule it.
+type RecurringScheduler struct {
+	controller Controller
+	runner     *JobRunner
+	logger     *logrus.Logger
+	jobs       []RecurringJob
+	operations map[string]JobFunc
+}
+
+// NewRecurringScheduler creates a RecurringScheduler that starts jobs with runner, with the operations
+// of maintenance, and returns it. Fails if the operation of any of the jobs is unavailable.
+func NewRecurringScheduler(
+	controller Controller,
+	runner *JobRunner,
+	logger *logrus.Logger,
+	jobs []RecurringJob,
+	maintenance MaintenanceJobs,
+) (RecurringScheduler, error) {
+	operations := make(map[string]JobFunc, len(jobs))
+	for _, job := range jobs {
+		operation, err := maintenance.Operation(job.Kind)
+		if err != nil {
+			return RecurringScheduler{}, err
+		}
+
+		operations[job.Kind] = operation
+	}
+
+	return RecurringScheduler{
+		controller: controller,
+		runner:     runner,
+		logger:     logger,
+		jobs:       jobs,
+		operations: operations,
+	}, nil
+}
+
+// Run is running an infinite loop that starts each of the recurring jobs once it's due, until ctx is done.
+// Runs that were due while the service was down are skipped.
+func (s RecurringScheduler) Run(ctx context.Context) {
+	if len(s.jobs) == 0 {
+		return
+	}
+
+	now := time.Now()
+	next := make([]time.Time, len(s.jobs))
+	for i, job := range s.jobs {
+		next[i] = job.Schedule.Next(now)
+	}
+
+	ticker := time.NewTicker(recurringJobsPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now = <-ticker.C:
+		}
+
+		for i, job := range s.jobs {
+			if next[i].IsZero() || now.Before(next[i]) {
+				continue
+			}
+
+			s.start(ctx, job.Kind, next[i])
+			next[i] = job.Schedule.Next(now)
+		}
+	}
+}
+
+// start claims the run of the job of kind at runAt, and starts it if it was claimed.
+func (s RecurringScheduler) start(ctx context.Context, kind string, runAt time.Time) {
+	claimed, err := s.controller.ClaimRecurringRun(ctx, kind, runAt)
+	if err != nil {
+		s.logger.Errorf("failed claiming the run of recurring job %s at %s: %v", kind, runAt, err)
+		return
+	}
+
+	if !claimed {
+		return
+	}
+
+	jobCtx := context.WithValue(ctx, actorKey{}, recurringJobsActor)
+	if _, err := s.runner.Start(jobCtx, kind, s.operations[kind]); err != nil {
+		s.logger.Errorf("failed starting recurring job %s: %v", kind, err)
+	}
+}
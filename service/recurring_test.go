@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/sirupsen/logrus"
+)
+
+// recurringController is a Controller of the recurring jobs, which claims the runs and stores the jobs in memory.
+// Its other methods aren't implemented, and panic.
+type recurringController struct {
+	Controller
+
+	mu       sync.Mutex
+	claims   map[string]bool
+	jobs     map[string]Job
+	counts   []PermissionCount
+	purgedAt time.Time
+}
+
+// newRecurringController returns a recurringController whose permissions are counted as counts.
+func newRecurringController(counts []PermissionCount) *recurringController {
+	return &recurringController{claims: map[string]bool{}, jobs: map[string]Job{}, counts: counts}
+}
+
+// ClaimRecurringRun claims the run of kind at runAt, returns false if it was already claimed.
+func (c *recurringController) ClaimRecurringRun(ctx context.Context, kind string, runAt time.Time) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := kind + "@" + runAt.UTC().String()
+	if c.claims[key] {
+		return false, nil
+	}
+
+	c.claims[key] = true
+	return true, nil
+}
+
+// CreateJob stores job with a new ID and returns it.
+func (c *recurringController) CreateJob(ctx context.Context, job Job) (Job, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	job.ID = fmt.Sprint(len(c.jobs))
+	c.jobs[job.ID] = job
+	return job, nil
+}
+
+// UpdateJobProgress stores the progress of the job with id and returns it.
+func (c *recurringController) UpdateJobProgress(
+	ctx context.Context,
+	id string,
+	progress JobProgress,
+	updatedAt time.Time,
+) (Job, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	job := c.jobs[id]
+	job.Progress, job.UpdatedAt = progress, updatedAt
+	c.jobs[id] = job
+	return job, nil
+}
+
+// FinishJob stores the end of the job with id and returns it.
+func (c *recurringController) FinishJob(
+	ctx context.Context,
+	id string,
+	state JobState,
+	progress JobProgress,
+	message string,
+	finishedAt time.Time,
+) (Job, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	job := c.jobs[id]
+	job.State, job.Progress, job.Error, job.FinishedAt = state, progress, message, &finishedAt
+	c.jobs[id] = job
+	return job, nil
+}
+
+// CountPermissions returns c.counts.
+func (c *recurringController) CountPermissions(ctx context.Context) ([]PermissionCount, error) {
+	return c.counts, nil
+}
+
+// PurgeJobs records the time before which the jobs are purged, and purges none of them.
+func (c *recurringController) PurgeJobs(ctx context.Context, finishedBefore time.Time) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.purgedAt = finishedBefore
+	return 0, nil
+}
+
+// PurgeFailedUpdates purges none of the failed updates.
+func (c *recurringController) PurgeFailedUpdates(ctx context.Context, failedBefore time.Time) (int64, error) {
+	return 0, nil
+}
+
+// finishedJobs returns the jobs that finished, by their states.
+func (c *recurringController) finishedJobs() map[JobState]int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	states := map[JobState]int{}
+	for _, job := range c.jobs {
+		if job.FinishedAt != nil {
+			states[job.State]++
+		}
+	}
+
+	return states
+}
+
+// discardLogger returns a logger whose logs are discarded.
+func discardLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+	return logger
+}
+
+func TestParseRecurringJobs(t *testing.T) {
+	tests := []struct {
+		spec  string
+		kinds []string
+		err   bool
+	}{
+		{spec: ""},
+		{spec: "purge_retention=0 3 * * *; reconcile=@hourly;", kinds: []string{JobPurgeRetention, JobReconcile}},
+		{spec: "compute_stats=@every 10m", kinds: []string{JobComputeStats}},
+		{spec: "compute_stats", err: true},
+		{spec: "=@hourly", err: true},
+		{spec: "compute_stats=@sometimes", err: true},
+		{spec: "compute_stats=@hourly;compute_stats=@daily", err: true},
+	}
+
+	for _, test := range tests {
+		jobs, err := ParseRecurringJobs(test.spec)
+		if (err != nil) != test.err {
+			t.Errorf("%q: expected an error to be %v, got %v", test.spec, test.err, err)
+			continue
+		}
+
+		if len(jobs) != len(test.kinds) {
+			t.Errorf("%q: expected the jobs %v, got %v", test.spec, test.kinds, jobs)
+			continue
+		}
+
+		for i, job := range jobs {
+			if job.Kind != test.kinds[i] {
+				t.Errorf("%q: expected job %d to be %s, got %s", test.spec, i, test.kinds[i], job.Kind)
+			}
+		}
+	}
+}
+
+func TestMaintenanceJobsOperation(t *testing.T) {
+	maintenance := NewMaintenanceJobs(nil, discardLogger(), time.Hour)
+	withDependencies := maintenance.
+		WithReconciler(NewReconciler(nil, nil, discardLogger(), 1)).
+		WithStaleGrantExpiry(time.Hour)
+
+	tests := []struct {
+		kind        string
+		maintenance MaintenanceJobs
+		err         bool
+	}{
+		{kind: JobPurgeRetention, maintenance: maintenance},
+		{kind: JobComputeStats, maintenance: maintenance},
+		{kind: JobReconcile, maintenance: maintenance, err: true},
+		{kind: JobReconcile, maintenance: withDependencies},
+		{kind: JobCollectGarbage, maintenance: maintenance, err: true},
+		{kind: JobCollectGarbage, maintenance: withDependencies},
+		{kind: JobExpireStaleGrants, maintenance: maintenance, err: true},
+		{kind: JobExpireStaleGrants, maintenance: withDependencies},
+		{kind: "unknown", maintenance: withDependencies, err: true},
+	}
+
+	for _, test := range tests {
+		operation, err := test.maintenance.Operation(test.kind)
+		if (err != nil) != test.err || (err == nil && operation == nil) {
+			t.Errorf("%s: expected an error to be %v, got %v", test.kind, test.err, err)
+		}
+	}
+}
+
+func TestMaintenanceJobs(t *testing.T) {
+	controller := newRecurringController([]PermissionCount{
+		{ResourceType: DefaultResourceType, Role: pb.Role_READ, Count: 3},
+		{ResourceType: DefaultResourceType, Role: pb.Role_WRITE, Count: 1},
+	})
+	maintenance := NewMaintenanceJobs(controller, discardLogger(), time.Hour)
+	noProgress := func(JobProgress) error { return nil }
+
+	if err := maintenance.computeStats(context.Background(), noProgress); err != nil {
+		t.Fatalf("computeStats failed: %v", err)
+	}
+
+	for _, count := range controller.counts {
+		key := count.ResourceType + "/" + count.Role.String()
+		if stat, ok := permissionStats.Get(key).(*expvar.Int); !ok || stat.Value() != count.Count {
+			t.Errorf("expected the stat of %s to be %d, got %v", key, count.Count, permissionStats.Get(key))
+		}
+	}
+
+	start := time.Now()
+	if err := maintenance.purgeRetention(context.Background(), noProgress); err != nil {
+		t.Fatalf("purgeRetention failed: %v", err)
+	}
+
+	end := time.Now()
+	if controller.purgedAt.Before(start.Add(-time.Hour)) || controller.purgedAt.After(end.Add(-time.Hour)) {
+		t.Errorf("expected the jobs to be purged before an hour ago, purged before %v", controller.purgedAt)
+	}
+}
+
+func TestRecurringSchedulerSingleRun(t *testing.T) {
+	jobs, err := ParseRecurringJobs("compute_stats=@every 1s")
+	if err != nil {
+		t.Fatalf("ParseRecurringJobs failed: %v", err)
+	}
+
+	// Every instance schedules the job, but each run is claimed, and so run, by a single one of them.
+	controller := newRecurringController(nil)
+	maintenance := NewMaintenanceJobs(controller, discardLogger(), time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		scheduler, err := NewRecurringScheduler(
+			controller,
+			NewJobRunner(controller, discardLogger(), time.Minute),
+			discardLogger(),
+			jobs,
+			maintenance,
+		)
+		if err != nil {
+			t.Fatalf("NewRecurringScheduler failed: %v", err)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			scheduler.Run(ctx)
+		}()
+	}
+
+	time.Sleep(3500 * time.Millisecond)
+	cancel()
+	wg.Wait()
+
+	// The runs finish right after they're started.
+	time.Sleep(100 * time.Millisecond)
+
+	controller.mu.Lock()
+	claims, created := len(controller.claims), len(controller.jobs)
+	controller.mu.Unlock()
+	if claims < 2 || created != claims {
+		t.Errorf("expected a job of each of at least 2 claimed runs, got %d jobs of %d runs", created, claims)
+	}
+
+	if states := controller.finishedJobs(); states[JobSucceeded] != created {
+		t.Errorf("expected the %d jobs to succeed, got %v", created, states)
+	}
+}
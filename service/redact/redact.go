@@ -0,0 +1,201 @@
+// Package redact redacts the user identifiers in log entries.
+package redact
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Mode is the way that identifiers are redacted.
+type Mode string
+
+const (
+	// ModeNone doesn't redact identifiers.
+	ModeNone Mode = ""
+
+	// ModeMask replaces identifiers with Masked.
+	ModeMask Mode = "mask"
+
+	// ModeHash replaces identifiers with their salted hash, so that the entries of an identifier
+	// can still be correlated without revealing it.
+	ModeHash Mode = "hash"
+
+	// Masked is the value that identifiers are replaced with in ModeMask.
+	Masked = "[REDACTED]"
+
+	// hashPrefix prefixes the hashed identifiers.
+	hashPrefix = "sha256:"
+)
+
+// identifierKeys are the keys of the fields and payload properties whose values are identifiers,
+// lowercased and without underscores.
+var identifierKeys = map[string]bool{
+	"userid":       true,
+	"creator":      true,
+	"usera":        true,
+	"userb":        true,
+	"sharingchain": true,
+	"owner":        true,
+	"email":        true,
+}
+
+// identifierPatterns match the identifiers in free text, such as messages and errors.
+// The identifier is the last submatch of each pattern.
+var identifierPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`()[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`),
+	regexp.MustCompile(`(permissions/)([^/\s"]+)`),
+	regexp.MustCompile(`(\buser )([^\s"]+)`),
+}
+
+// Redactor redacts identifiers by its mode.
+type Redactor struct {
+	mode Mode
+	salt []byte
+}
+
+// NewRedactor creates a Redactor that redacts identifiers by mode, salt is the salt of the hashes of ModeHash.
+func NewRedactor(mode Mode, salt string) (Redactor, error) {
+	switch mode {
+	case ModeNone, ModeMask, ModeHash:
+	default:
+		return Redactor{}, fmt.Errorf("redaction mode %q does not exist", mode)
+	}
+
+	return Redactor{mode: mode, salt: []byte(salt)}, nil
+}
+
+// Redact returns the redacted id. Redacting an already redacted id returns it as is.
+func (r Redactor) Redact(id string) string {
+	if id == "" || id == Masked || strings.HasPrefix(id, hashPrefix) {
+		return id
+	}
+
+	switch r.mode {
+	case ModeMask:
+		return Masked
+	case ModeHash:
+		mac := hmac.New(sha256.New, r.salt)
+		mac.Write([]byte(id))
+		return hashPrefix + hex.EncodeToString(mac.Sum(nil))[:16]
+	default:
+		return id
+	}
+}
+
+// RedactText returns text with the identifiers that it contains redacted.
+func (r Redactor) RedactText(text string) string {
+	if r.mode == ModeNone {
+		return text
+	}
+
+	for _, pattern := range identifierPatterns {
+		text = pattern.ReplaceAllStringFunc(text, func(match string) string {
+			submatches := pattern.FindStringSubmatch(match)
+			prefix := submatches[1]
+			return prefix + r.Redact(strings.TrimPrefix(match, prefix))
+		})
+	}
+
+	return text
+}
+
+// redactField returns value of the field with key redacted. Payloads, which are marshaled to JSON,
+// are returned as their JSON values with the identifiers redacted.
+func (r Redactor) redactField(key string, value interface{}) interface{} {
+	switch value := value.(type) {
+	case string:
+		if isIdentifierKey(key) {
+			return r.Redact(value)
+		}
+
+		return r.RedactText(value)
+	case []string:
+		redacted := make([]string, 0, len(value))
+		for _, element := range value {
+			redacted = append(redacted, r.redactField(key, element).(string))
+		}
+
+		return redacted
+	case error:
+		return r.RedactText(value.Error())
+	case time.Time:
+		return value
+	case json.Marshaler:
+		payload, err := value.MarshalJSON()
+		if err != nil {
+			return value
+		}
+
+		var decoded interface{}
+		if err := json.Unmarshal(payload, &decoded); err != nil {
+			return value
+		}
+
+		return r.redactJSON(key, decoded)
+	default:
+		return value
+	}
+}
+
+// redactJSON returns the decoded JSON value of the property with key redacted.
+func (r Redactor) redactJSON(key string, value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for property, propertyValue := range value {
+			value[property] = r.redactJSON(property, propertyValue)
+		}
+
+		return value
+	case []interface{}:
+		for i, element := range value {
+			value[i] = r.redactJSON(key, element)
+		}
+
+		return value
+	case string:
+		return r.redactField(key, value)
+	default:
+		return value
+	}
+}
+
+// isIdentifierKey returns whether key is the key of identifiers.
+func isIdentifierKey(key string) bool {
+	return identifierKeys[strings.ToLower(strings.Replace(key, "_", "", -1))]
+}
+
+// Hook is a logrus hook that redacts the identifiers in the fields and messages of the log entries.
+type Hook struct {
+	redactor Redactor
+}
+
+// NewHook creates a Hook that redacts with redactor and returns it.
+func NewHook(redactor Redactor) Hook {
+	return Hook{redactor: redactor}
+}
+
+// Levels returns the levels of the entries that h redacts, which are all levels.
+func (h Hook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire redacts entry. The fields of entry may be shared with other entries,
+// so they're replaced rather than modified.
+func (h Hook) Fire(entry *logrus.Entry) error {
+	data := make(logrus.Fields, len(entry.Data))
+	for key, value := range entry.Data {
+		data[key] = h.redactor.redactField(key, value)
+	}
+
+	entry.Data = data
+	entry.Message = h.redactor.RedactText(entry.Message)
+	return nil
+}
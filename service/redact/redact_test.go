@@ -0,0 +1,123 @@
+package redact
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestRedact(t *testing.T) {
+	hashed, err := NewRedactor(ModeHash, "salt")
+	if err != nil {
+		t.Fatalf("NewRedactor failed: %v", err)
+	}
+
+	otherSalt, err := NewRedactor(ModeHash, "pepper")
+	if err != nil {
+		t.Fatalf("NewRedactor failed: %v", err)
+	}
+
+	hash := hashed.Redact("user-1")
+	if !strings.HasPrefix(hash, hashPrefix) || strings.Contains(hash, "user-1") {
+		t.Errorf("expected a hash of the identifier, got %q", hash)
+	}
+
+	if again := hashed.Redact("user-1"); again != hash {
+		t.Errorf("expected the hash of an identifier to be stable, got %q and %q", hash, again)
+	}
+
+	if other := otherSalt.Redact("user-1"); other == hash {
+		t.Errorf("expected the hashes of different salts to differ")
+	}
+
+	if again := hashed.Redact(hash); again != hash {
+		t.Errorf("expected a redacted identifier to be returned as is, got %q", again)
+	}
+
+	masked, err := NewRedactor(ModeMask, "")
+	if err != nil {
+		t.Fatalf("NewRedactor failed: %v", err)
+	}
+
+	if redacted := masked.Redact("user-1"); redacted != Masked {
+		t.Errorf("expected the identifier to be masked, got %q", redacted)
+	}
+
+	none, err := NewRedactor(ModeNone, "")
+	if err != nil {
+		t.Fatalf("NewRedactor failed: %v", err)
+	}
+
+	if redacted := none.Redact("user-1"); redacted != "user-1" {
+		t.Errorf("expected the identifier not to be redacted, got %q", redacted)
+	}
+
+	if _, err := NewRedactor("encrypt", ""); err == nil {
+		t.Errorf("expected NewRedactor to fail with an unknown mode")
+	}
+}
+
+func TestRedactText(t *testing.T) {
+	redactor, err := NewRedactor(ModeMask, "")
+	if err != nil {
+		t.Fatalf("NewRedactor failed: %v", err)
+	}
+
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "shared with john@example.com", want: "shared with [REDACTED]"},
+		{text: "files/1/permissions/user-1 not found", want: "files/1/permissions/[REDACTED] not found"},
+		{text: "user user-1 is not allowed to share file 1", want: "user [REDACTED] is not allowed to share file 1"},
+		{text: "file 1 is under legal hold", want: "file 1 is under legal hold"},
+	}
+
+	for _, test := range tests {
+		if redacted := redactor.RedactText(test.text); redacted != test.want {
+			t.Errorf("expected %q to be redacted to %q, got %q", test.text, test.want, redacted)
+		}
+	}
+}
+
+func TestHookFire(t *testing.T) {
+	redactor, err := NewRedactor(ModeMask, "")
+	if err != nil {
+		t.Fatalf("NewRedactor failed: %v", err)
+	}
+
+	fields := logrus.Fields{
+		"user_id":      "user-1",
+		"sharingChain": []string{"user-2", "user-3"},
+		"fileID":       "file-1",
+		"error":        errors.New("user user-4 has no permission"),
+	}
+	entry := logrus.NewEntry(logrus.New()).WithFields(fields)
+	entry.Message = "shared by user user-5"
+
+	if err := NewHook(redactor).Fire(entry); err != nil {
+		t.Fatalf("Fire failed: %v", err)
+	}
+
+	if entry.Data["user_id"] != Masked || entry.Data["fileID"] != "file-1" {
+		t.Errorf("expected only the identifier fields to be redacted, got %v", entry.Data)
+	}
+
+	if chain := entry.Data["sharingChain"].([]string); chain[0] != Masked || chain[1] != Masked {
+		t.Errorf("expected the sharing chain to be redacted, got %v", chain)
+	}
+
+	if entry.Data["error"] != "user [REDACTED] has no permission" {
+		t.Errorf("expected the error to be redacted, got %v", entry.Data["error"])
+	}
+
+	if entry.Message != "shared by user [REDACTED]" {
+		t.Errorf("expected the message to be redacted, got %q", entry.Message)
+	}
+
+	if fields["user_id"] != "user-1" {
+		t.Errorf("expected the fields that the entry shares not to be modified")
+	}
+}
@@ -0,0 +1,387 @@
+package service
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	pbdescriptor "github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// RegionPrimary is the role of the region that serves the writes, from its instances that hold its fence.
+	RegionPrimary = "primary"
+
+	// RegionReplica is the role of a region that serves the reads from its local MongoDB secondaries
+	// and forwards the writes to the primary region.
+	RegionReplica = "replica"
+
+	// ForwardedRegionHeader is the grpc metadata key of the region that forwarded a write to the primary region.
+	ForwardedRegionHeader = "x-forwarded-region"
+
+	// ForwardedCallerHeader is the grpc metadata key of the verified caller of a write that a replica region
+	// forwarded, which the primary region only trusts from its peers.
+	ForwardedCallerHeader = "x-forwarded-caller"
+
+	// regionFenceName is the name of the lease of the write fence of the primary region.
+	regionFenceName = "region_primary"
+)
+
+var (
+	// regionFenceHeld is whether the instance's region holds the write fence, 1 if it does and 0 if it doesn't.
+	regionFenceHeld = expvar.NewInt("region_fence")
+
+	// forwardedWrites counts the writes that were forwarded to the primary region, keyed by "method/code".
+	forwardedWrites = expvar.NewMap("forwarded_writes")
+)
+
+// forwardedCallerKey is the context key of the caller of a write that a replica region forwarded.
+type forwardedCallerKey struct{}
+
+// RegionFence fences the writes of the primary region, so that two regions that are both configured
+// as primary, such as during a failover, don't both write. The fence is a lease that's held by the region,
+// which all of its instances renew. An instance accepts writes until a renewal before the lease expires,
+// so it stops before another region may acquire it, which is once it expires.
+type RegionFence struct {
+	leases LeaseRepository
+	logger *logrus.Logger
+	region string
+	lease  time.Duration
+
+	// heldUntil is the UnixNano until which the instance accepts writes.
+	heldUntil *int64
+}
+
+// RegionFence creates the write fence of region, whose lease is stored with the leases of e, and returns it.
+// The fence is always held if the election of e is disabled.
+func (e LeaderElector) RegionFence(region string) RegionFence {
+	return RegionFence{leases: e.leases, logger: e.logger, region: region, lease: e.lease, heldUntil: new(int64)}
+}
+
+// Run is running an infinite loop that acquires and renews the fence a few times within a lease,
+// until ctx is done. The lease isn't released when it's done, so that another region may only acquire
+// it once it expires.
+func (f RegionFence) Run(ctx context.Context) {
+	ctx = WithWorker(ctx, regionFenceName)
+	if f.disabled() {
+		regionFenceHeld.Set(1)
+		return
+	}
+
+	renewal := f.lease / leaseRenewals
+	ticker := time.NewTicker(renewal)
+	defer ticker.Stop()
+
+	for {
+		f.renew(ctx, renewal)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// renew acquires or renews the fence, and keeps accepting writes until a renewal, of renewal,
+// before the lease expires. The writes keep being accepted if it fails, until then.
+func (f RegionFence) renew(ctx context.Context, renewal time.Duration) {
+	wasHeld := f.Held()
+	now := time.Now()
+	held, err := f.leases.AcquireLease(ctx, regionFenceName, f.region, now, now.Add(f.lease))
+	switch {
+	case err != nil:
+		f.logger.Errorf("failed renewing the write fence of region %s: %v", f.region, err)
+	case held:
+		atomic.StoreInt64(f.heldUntil, now.Add(f.lease-renewal).UnixNano())
+	default:
+		atomic.StoreInt64(f.heldUntil, 0)
+	}
+
+	isHeld := f.Held()
+	switch {
+	case isHeld && !wasHeld:
+		regionFenceHeld.Set(1)
+		f.logger.WithField("region", f.region).Info("acquired the write fence of the primary region")
+	case !isHeld && wasHeld:
+		regionFenceHeld.Set(0)
+		f.logger.WithField("region", f.region).Warn("lost the write fence of the primary region")
+	}
+}
+
+// Held returns whether the instance may accept writes.
+func (f RegionFence) Held() bool {
+	if f.disabled() {
+		return true
+	}
+
+	return time.Now().UnixNano() < atomic.LoadInt64(f.heldUntil)
+}
+
+// disabled returns whether the fence isn't stored, and is then always held.
+func (f RegionFence) disabled() bool {
+	return f.leases == nil || f.lease <= 0
+}
+
+// Regions is the policy of the region of the instance in a multi-region deployment, of a single MongoDB
+// replica set whose primary is in the primary region. The RPCs that are annotated with the NO_SIDE_EFFECTS
+// idempotency level in the protos are reads, which every region serves, and the rest are writes.
+// A primary region serves the writes while it holds its fence, and a replica region forwards them to the
+// primary region, other than the streaming writes, which must be made in the primary region.
+// The zero value is of a single region deployment, which serves everything.
+type Regions struct {
+	region string
+	role   string
+
+	// fence is the write fence of a primary region, and peers are the callers that it trusts
+	// the forwarded callers of, which are the servers of the replica regions.
+	fence RegionFence
+	peers map[string]bool
+
+	// primary is the connection of a replica region to the primary region.
+	primary *grpc.ClientConn
+}
+
+// NewPrimaryRegion creates the policy of the primary region, region, that serves the writes while it
+// holds fence, and trusts the forwarded callers of the writes of peers, and returns it.
+func NewPrimaryRegion(region string, fence RegionFence, peers []string) Regions {
+	trusted := make(map[string]bool, len(peers))
+	for _, peer := range peers {
+		trusted[peer] = true
+	}
+
+	return Regions{region: region, role: RegionPrimary, fence: fence, peers: trusted}
+}
+
+// NewReplicaRegion creates the policy of the replica region, region, that forwards the writes
+// to the primary region through primary, and returns it.
+func NewReplicaRegion(region string, primary *grpc.ClientConn) Regions {
+	return Regions{region: region, role: RegionReplica, primary: primary}
+}
+
+// ParseRegionPeers parses the peers of the primary region of the form "caller,caller", empty peers are valid.
+func ParseRegionPeers(peers string) []string {
+	var parsed []string
+	for _, peer := range strings.Split(peers, ",") {
+		if peer = strings.TrimSpace(peer); peer != "" {
+			parsed = append(parsed, peer)
+		}
+	}
+
+	return parsed
+}
+
+// Middleware returns the middleware that fences the writes of the services of descs in a primary region,
+// or forwards them from a replica region. It fails if the proto of one of descs isn't registered.
+func (r Regions) Middleware(descs ...grpc.ServiceDesc) (Middleware, error) {
+	if r.role == "" {
+		return passThrough, nil
+	}
+
+	reads, err := readMethods(descs)
+	if err != nil {
+		return Middleware{}, err
+	}
+
+	replies := replyTypes(descs)
+	unary := func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if read, ok := reads[info.FullMethod]; read || !ok {
+			return handler(ctx, req)
+		}
+
+		if r.role == RegionReplica {
+			return r.forward(ctx, info.FullMethod, req, replies[info.FullMethod])
+		}
+
+		ctx, err := r.acceptWrite(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+
+	stream := func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if read, ok := reads[info.FullMethod]; read || !ok {
+			return handler(srv, stream)
+		}
+
+		if r.role == RegionReplica {
+			return RejectionError(
+				codes.FailedPrecondition,
+				Rejection{Kind: RejectionPolicy, Rule: "region_streaming_writes", Subject: r.region},
+				"%s streams writes, which must be made in the primary region",
+				info.FullMethod,
+			)
+		}
+
+		ctx, err := r.acceptWrite(stream.Context())
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, contextServerStream{ServerStream: stream, ctx: ctx})
+	}
+
+	return Middleware{Unary: unary, Stream: stream}, nil
+}
+
+// readMethods returns whether each of the RPCs of descs is a read by its grpc full method. The reads are
+// the RPCs that are annotated with the NO_SIDE_EFFECTS idempotency level in the protos, and the other RPCs
+// of descs are writes. It fails if the proto of one of descs isn't registered.
+func readMethods(descs []grpc.ServiceDesc) (map[string]bool, error) {
+	reads := map[string]bool{}
+	for _, desc := range descs {
+		methods, err := serviceMethods(desc)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, method := range methods {
+			reads["/"+desc.ServiceName+"/"+method.GetName()] =
+				method.GetOptions().GetIdempotencyLevel() == pbdescriptor.MethodOptions_NO_SIDE_EFFECTS
+		}
+	}
+
+	return reads, nil
+}
+
+// acceptWrite returns ctx with the forwarded caller of its write if it's forwarded by a peer,
+// or an Unavailable error if the region doesn't hold its fence.
+func (r Regions) acceptWrite(ctx context.Context) (context.Context, error) {
+	if !r.fence.Held() {
+		return nil, RejectionError(
+			codes.Unavailable,
+			Rejection{
+				Kind:       RejectionInvariant,
+				Rule:       "region_fence",
+				Subject:    r.region,
+				RetryAfter: r.fence.lease / leaseRenewals,
+			},
+			"region %s doesn't hold the write fence of the primary region",
+			r.region,
+		)
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	if callers := md.Get(ForwardedCallerHeader); len(callers) == 1 && r.peers[peerCaller(ctx)] {
+		ctx = context.WithValue(ctx, forwardedCallerKey{}, callers[0])
+	}
+
+	return ctx, nil
+}
+
+// forward forwards the write req of method to the primary region, whose response is of replyType,
+// with the metadata of ctx and its verified caller, and returns the primary region's response.
+func (r Regions) forward(
+	ctx context.Context,
+	method string,
+	req interface{},
+	replyType reflect.Type,
+) (interface{}, error) {
+	if replyType == nil {
+		return nil, status.Errorf(codes.Internal, "%s can't be forwarded to the primary region", method)
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	if forwardedBy := md.Get(ForwardedRegionHeader); len(forwardedBy) > 0 {
+		return nil, status.Errorf(
+			codes.FailedPrecondition,
+			"the write was forwarded by region %s to region %s, which isn't the primary region",
+			forwardedBy[0],
+			r.region,
+		)
+	}
+
+	md = forwardedMetadata(md)
+	md.Set(ForwardedRegionHeader, r.region)
+	if caller := CallerFromContext(ctx); caller != "" {
+		md.Set(ForwardedCallerHeader, caller)
+	}
+
+	var header, trailer metadata.MD
+	reply := reflect.New(replyType.Elem()).Interface()
+	err := r.primary.Invoke(
+		metadata.NewOutgoingContext(ctx, md),
+		method,
+		req,
+		reply,
+		grpc.Header(&header),
+		grpc.Trailer(&trailer),
+	)
+	forwardedWrites.Add(method+"/"+status.Code(err).String(), 1)
+
+	// The metadata of the response is best-effort, as the response's.
+	_ = grpc.SetHeader(ctx, header)
+	_ = grpc.SetTrailer(ctx, trailer)
+	if err != nil {
+		return nil, err
+	}
+
+	return reply, nil
+}
+
+// forwardedMetadata returns a copy of md without the metadata of its transport and of the forwarding,
+// which are set by the forwarding's.
+func forwardedMetadata(md metadata.MD) metadata.MD {
+	forwarded := metadata.MD{}
+	for key, values := range md {
+		switch {
+		case strings.HasPrefix(key, ":"), strings.HasPrefix(key, "grpc-"):
+		case key == "content-type", key == "user-agent", key == ForwardedCallerHeader:
+		default:
+			forwarded[key] = append([]string(nil), values...)
+		}
+	}
+
+	return forwarded
+}
+
+// replyTypes returns the types of the responses of the unary RPCs of descs by their grpc full methods.
+func replyTypes(descs []grpc.ServiceDesc) map[string]reflect.Type {
+	types := map[string]reflect.Type{}
+	for _, desc := range descs {
+		handlerType := reflect.TypeOf(desc.HandlerType)
+		if handlerType == nil || handlerType.Kind() != reflect.Ptr {
+			continue
+		}
+
+		for _, method := range desc.Methods {
+			if m, ok := handlerType.Elem().MethodByName(method.MethodName); ok && m.Type.NumOut() == 2 {
+				types[fmt.Sprintf("/%s/%s", desc.ServiceName, method.MethodName)] = m.Type.Out(0)
+			}
+		}
+	}
+
+	return types
+}
+
+// contextServerStream is a grpc.ServerStream whose context is ctx.
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context of the stream.
+func (s contextServerStream) Context() context.Context {
+	return s.ctx
+}
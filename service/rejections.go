@@ -0,0 +1,114 @@
+package service
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	pbv2 "github.com/meateam/permission-service/proto/v2"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// RejectionQuota is the kind of the rejections of quotas, such as the size of a request.
+	RejectionQuota = "quota"
+
+	// RejectionPolicy is the kind of the rejections of policies, such as of the roles a caller may grant.
+	RejectionPolicy = "policy"
+
+	// RejectionInvariant is the kind of the rejections of invariants, such as of the files under legal hold.
+	RejectionInvariant = "invariant"
+)
+
+// Rejection describes why a quota, a policy or an invariant rejected a request.
+type Rejection struct {
+	// Kind is RejectionQuota, RejectionPolicy or RejectionInvariant.
+	Kind string
+
+	// Rule is the name of the rule that rejected the request, such as "max_reshare_depth".
+	Rule string
+
+	// Subject is the subject of the rule, such as the resource name of a file.
+	Subject string
+
+	// Current is the value that the request would have brought a quota to, and Limit the quota's limit.
+	Current int64
+	Limit   int64
+
+	// RetryAfter is the time after which the request may succeed if it's retried, 0 if it may not.
+	RetryAfter time.Duration
+}
+
+// ResourceName returns the resource name of the resource of resourceType with fileID, such as `files/{file}`.
+func ResourceName(resourceType string, fileID string) string {
+	return resourceCollection(resourceTypeOrDefault(resourceType)) + "/" + fileID
+}
+
+// RejectionError returns an error of code with a message of format and args, whose details are
+// a pbv2.RejectionInfo of rejection, a google.rpc.QuotaFailure of a quota or a google.rpc.PreconditionFailure
+// otherwise, and a google.rpc.RetryInfo if rejection has a RetryAfter.
+func RejectionError(code codes.Code, rejection Rejection, format string, args ...interface{}) error {
+	st := status.Newf(code, format, args...)
+	details := []proto.Message{&pbv2.RejectionInfo{
+		Kind:    rejection.Kind,
+		Rule:    rejection.Rule,
+		Subject: rejection.Subject,
+		Current: rejection.Current,
+		Limit:   rejection.Limit,
+	}}
+
+	if rejection.Kind == RejectionQuota {
+		details = append(details, &errdetails.QuotaFailure{
+			Violations: []*errdetails.QuotaFailure_Violation{
+				{Subject: rejection.Subject, Description: st.Message()},
+			},
+		})
+	} else {
+		details = append(details, &errdetails.PreconditionFailure{
+			Violations: []*errdetails.PreconditionFailure_Violation{
+				{Type: rejection.Rule, Subject: rejection.Subject, Description: st.Message()},
+			},
+		})
+	}
+
+	if rejection.RetryAfter > 0 {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: ptypes.DurationProto(rejection.RetryAfter)})
+	}
+
+	detailed, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
+
+// RejectionFromError returns the rejection of the details of err, and false if err isn't a rejection.
+func RejectionFromError(err error) (Rejection, bool) {
+	st, ok := status.FromError(err)
+	if !ok {
+		return Rejection{}, false
+	}
+
+	var rejection Rejection
+	found := false
+	for _, detail := range st.Details() {
+		switch detail := detail.(type) {
+		case *pbv2.RejectionInfo:
+			rejection.Kind = detail.GetKind()
+			rejection.Rule = detail.GetRule()
+			rejection.Subject = detail.GetSubject()
+			rejection.Current = detail.GetCurrent()
+			rejection.Limit = detail.GetLimit()
+			found = true
+		case *errdetails.RetryInfo:
+			if delay, err := ptypes.Duration(detail.GetRetryDelay()); err == nil {
+				rejection.RetryAfter = delay
+			}
+		}
+	}
+
+	return rejection, found
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	pbv2 "github.com/meateam/permission-service/proto/v2"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultRepairBatchSize is the batch size of RepairPermissions if not specified.
+	DefaultRepairBatchSize = 100
+
+	// RepairReport reports the malformed permission documents and doesn't change them.
+	RepairReport RepairAction = "report"
+
+	// RepairFix fixes the malformed permission documents that may be fixed, and reports the rest.
+	// The duplicates of a permission are quarantined, keeping the one that was updated last.
+	RepairFix RepairAction = "fix"
+
+	// RepairQuarantine fixes the malformed permission documents that may be fixed, and quarantines the rest.
+	RepairQuarantine RepairAction = "quarantine"
+
+	// ProblemMissingField is the problem of a document that's missing a required field, or whose field is empty.
+	ProblemMissingField = "missing_field"
+
+	// ProblemUnknownRole is the problem of a document whose role isn't one of the roles.
+	ProblemUnknownRole = "unknown_role"
+
+	// ProblemInvalidField is the problem of a document whose field has an invalid value or type.
+	ProblemInvalidField = "invalid_field"
+
+	// ProblemDuplicate is the problem of a document of the same user and resource as another document.
+	ProblemDuplicate = "duplicate"
+
+	// ResolutionReported is the resolution of a malformed document that wasn't changed.
+	ResolutionReported = "reported"
+
+	// ResolutionFixed is the resolution of a malformed document that was fixed in place.
+	ResolutionFixed = "fixed"
+
+	// ResolutionQuarantined is the resolution of a malformed document that was moved to the quarantine.
+	ResolutionQuarantined = "quarantined"
+)
+
+// RepairAction is what's done with the malformed permission documents that are found.
+type RepairAction string
+
+// MalformedPermission is a stored permission document that violates the schema or the invariants
+// of the permissions, and so may fail to be read.
+type MalformedPermission struct {
+	// ID is the ID of the document.
+	ID string
+
+	// Problem is the kind of the violation, one of the Problem constants.
+	Problem string
+
+	// Detail is a human-readable description of the violation.
+	Detail string
+
+	// Resolution is what was done with the document, one of the Resolution constants.
+	Resolution string
+}
+
+// repairActions maps the actions of RepairPermissionsRequest to the RepairActions.
+var repairActions = map[pbv2.RepairPermissionsRequest_Action]RepairAction{
+	pbv2.RepairPermissionsRequest_REPORT:     RepairReport,
+	pbv2.RepairPermissionsRequest_FIX:        RepairFix,
+	pbv2.RepairPermissionsRequest_QUARANTINE: RepairQuarantine,
+}
+
+// repairProblems maps the Problem constants to the problems of MalformedPermission.
+var repairProblems = map[string]pbv2.MalformedPermission_Problem{
+	ProblemMissingField: pbv2.MalformedPermission_MISSING_FIELD,
+	ProblemUnknownRole:  pbv2.MalformedPermission_UNKNOWN_ROLE,
+	ProblemInvalidField: pbv2.MalformedPermission_INVALID_FIELD,
+	ProblemDuplicate:    pbv2.MalformedPermission_DUPLICATE,
+}
+
+// repairResolutions maps the Resolution constants to the resolutions of MalformedPermission.
+var repairResolutions = map[string]pbv2.MalformedPermission_Resolution{
+	ResolutionReported:    pbv2.MalformedPermission_REPORTED,
+	ResolutionFixed:       pbv2.MalformedPermission_FIXED,
+	ResolutionQuarantined: pbv2.MalformedPermission_QUARANTINED,
+}
+
+// RepairPermissions is the request handler for repairing the malformed permission documents, it scans
+// the documents in batches, streams the malformed documents of each batch, and fixes or quarantines them
+// by the requested action. The totals are streamed once more when the scan is done.
+func (s AdminService) RepairPermissions(
+	req *pbv2.RepairPermissionsRequest,
+	stream pbv2.PermissionsAdmin_RepairPermissionsServer,
+) error {
+	if err := s.limits.Check(stream.Context(), req); err != nil {
+		return err
+	}
+
+	action, ok := repairActions[req.GetAction()]
+	if !ok {
+		return status.Error(codes.InvalidArgument, "action does not exist")
+	}
+
+	batchSize := int(req.GetBatchSize())
+	if batchSize < 0 {
+		return status.Error(codes.InvalidArgument, "batch_size must not be negative")
+	}
+
+	if batchSize == 0 {
+		batchSize = DefaultRepairBatchSize
+	}
+
+	s.logger.Infof("repairing malformed permissions, action %s", action)
+	progress := &pbv2.RepairPermissionsProgress{}
+	err := s.controller.RepairPermissions(
+		stream.Context(),
+		action,
+		batchSize,
+		func(malformed []MalformedPermission) error {
+			progress.Permissions = make([]*pbv2.MalformedPermission, 0, len(malformed))
+			for _, permission := range malformed {
+				progress.Malformed++
+				switch permission.Resolution {
+				case ResolutionFixed:
+					progress.Fixed++
+				case ResolutionQuarantined:
+					progress.Quarantined++
+				}
+
+				progress.Permissions = append(progress.Permissions, &pbv2.MalformedPermission{
+					Id:         permission.ID,
+					Problem:    repairProblems[permission.Problem],
+					Detail:     permission.Detail,
+					Resolution: repairResolutions[permission.Resolution],
+				})
+			}
+
+			return stream.Send(progress)
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"malformed":   progress.GetMalformed(),
+		"fixed":       progress.GetFixed(),
+		"quarantined": progress.GetQuarantined(),
+	}).Info("repaired malformed permissions")
+
+	progress.Permissions = nil
+	return stream.Send(progress)
+}
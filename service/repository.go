@@ -0,0 +1,524 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/meateam/permission-service/proto"
+)
+
+// PermissionRepository is an interface for storing permissions, it's implemented by the storage backends.
+// It's made of the interfaces of the aggregates of the stored permissions.
+type PermissionRepository interface {
+	PermissionStore
+	TreeRepository
+	EventRepository
+	SharingRepository
+	MaintenanceRepository
+}
+
+// PermissionStore is an interface for storing permissions, the aggregate that the rest of the repositories
+// of permissions are built around. Permissions are given to resources of resourceType, which are identified
+// by fileID. Methods that look up a single permission fail with codes.NotFound if it doesn't exist.
+type PermissionStore interface {
+	// Create creates the permission of userID to fileID with values and returns it. If the permission
+	// already exists then it's updated to values if override is true, otherwise the existing permission is returned.
+	// sharingChain is the users through which fileID was shared to userID.
+	Create(
+		ctx context.Context,
+		resourceType string,
+		fileID string,
+		userID string,
+		creator string,
+		sharingChain []string,
+		values PermissionUpdate,
+		override bool) (Permission, error)
+
+	// GetByID returns the permission with id.
+	GetByID(ctx context.Context, id string) (Permission, error)
+
+	// ExistingIDs returns the set of ids of the permissions that exist, malformed ids don't exist.
+	ExistingIDs(ctx context.Context, ids []string) (map[string]bool, error)
+
+	// Get returns the permission of userID to fileID. If fields are given then only they are retrieved,
+	// along with the resource type and kind, file and user IDs and the grantee type.
+	Get(
+		ctx context.Context,
+		resourceType string,
+		fileID string,
+		userID string,
+		fields ...PermissionField) (Permission, error)
+
+	// GetByResource returns the permissions of fileID that match selector sorted by order.
+	GetByResource(
+		ctx context.Context,
+		resourceType string,
+		fileID string,
+		order pb.PermissionsOrder,
+		selector PermissionSelector) ([]Permission, error)
+
+	// GetByUser returns the permissions of userID that match selector sorted by order.
+	GetByUser(
+		ctx context.Context,
+		resourceType string,
+		userID string,
+		order pb.PermissionsOrder,
+		selector PermissionSelector) ([]Permission, error)
+
+	// GetBySharer returns the permissions of fileID whose sharing chain includes sharerID.
+	GetBySharer(ctx context.Context, resourceType string, fileID string, sharerID string) ([]Permission, error)
+
+	// GetByFilter returns the permissions that match filter, to resources of every type
+	// if filter.ResourceType isn't set.
+	GetByFilter(ctx context.Context, filter PermissionsFilter) ([]Permission, error)
+
+	// ListByResource returns up to pageSize permissions of fileID that come after pageToken, sorted by
+	// order, and the token of the next page, which is empty if there are no more pages.
+	// Only the permissions that match selector are returned.
+	ListByResource(
+		ctx context.Context,
+		resourceType string,
+		fileID string,
+		order pb.PermissionsOrder,
+		pageSize int,
+		pageToken string,
+		fields []PermissionField,
+		selector PermissionSelector) ([]Permission, string, error)
+
+	// ListByUser returns up to pageSize permissions of userID that come after pageToken, sorted by
+	// order, and the token of the next page, which is empty if there are no more pages.
+	// Only the permissions that match selector are returned.
+	ListByUser(
+		ctx context.Context,
+		resourceType string,
+		userID string,
+		order pb.PermissionsOrder,
+		pageSize int,
+		pageToken string,
+		selector PermissionSelector) ([]Permission, string, error)
+
+	// ListByGranteeType returns up to pageSize permissions of granteeType that come after pageToken,
+	// ordered by their creation, and the token of the next page, which is empty if there are no more pages.
+	// If granteeID is set then only its permissions are returned.
+	ListByGranteeType(
+		ctx context.Context,
+		granteeType string,
+		granteeID string,
+		pageSize int,
+		pageToken string) ([]Permission, string, error)
+
+	// ListStale returns up to pageSize direct permissions of resourceType, or of every resource type if it's empty,
+	// that were last accessed before before, or were never accessed and were created before it, other than
+	// the permissions of the owners, that come after pageToken, ordered by their creation, and the token
+	// of the next page, which is empty if there are no more pages.
+	ListStale(
+		ctx context.Context,
+		resourceType string,
+		before time.Time,
+		pageSize int,
+		pageToken string) ([]Permission, string, error)
+
+	// Update updates the fields of the permission of userID to fileID to their values in update,
+	// if etag is empty or is the permission's current etag, and returns the updated permission.
+	Update(
+		ctx context.Context,
+		resourceType string,
+		fileID string,
+		userID string,
+		etag string,
+		update PermissionUpdate,
+		fields []PermissionField) (Permission, error)
+
+	// Touch sets the last access time of the permission of userID to fileID to accessedAt.
+	Touch(
+		ctx context.Context,
+		resourceType string,
+		fileID string,
+		userID string,
+		accessedAt time.Time) (Permission, error)
+
+	// Delete deletes the permission of userID to fileID, if etag is empty or is the permission's
+	// current etag, and returns the deleted permission.
+	Delete(ctx context.Context, resourceType string, fileID string, userID string, etag string) (Permission, error)
+
+	// DeleteByID deletes the permission with id and returns it.
+	DeleteByID(ctx context.Context, id string) (Permission, error)
+
+	// DeleteAllExceptUser deletes every permission to fileID other than the permission of userID,
+	// in a single transaction with a single event of EventSharingStopped, and returns them.
+	DeleteAllExceptUser(ctx context.Context, resourceType string, fileID string, userID string) ([]Permission, error)
+
+	// AssignOwner makes userID the owner of fileID, which has no owner, by giving it a WRITE permission
+	// that it created, with an event of EventOwnerAssigned, and returns it. Fails with codes.FailedPrecondition
+	// if fileID has an owner other than userID, or userID isn't a user.
+	AssignOwner(ctx context.Context, resourceType string, fileID string, userID string) (Permission, error)
+
+	// UpdateRoles changes the roles of the permissions of updates with a single bulk write,
+	// and returns the result of each update in the order of updates.
+	UpdateRoles(ctx context.Context, updates []RoleUpdate) ([]RoleUpdateResult, error)
+
+	// WithCausalConsistency runs fn so that the repository operations in it observe
+	// the writes that preceded them, including ones of previous requests of the caller.
+	WithCausalConsistency(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// TreeRepository is an interface for changing the permissions of several files of a tree in a single transaction.
+type TreeRepository interface {
+	// CopyPermissions copies the permissions that were given directly to sourceFileID to destFileID,
+	// in a single transaction. The existing permissions of destFileID are overridden if overwrite is true,
+	// otherwise they're kept.
+	CopyPermissions(
+		ctx context.Context,
+		resourceType string,
+		sourceFileID string,
+		destFileID string,
+		overwrite bool) (CopyReport, error)
+
+	// ReplaceInherited replaces the permissions of the files of subtree that are inherited from outside
+	// of it with the permissions of parentID, or deletes them if parentID is empty, in a single transaction.
+	// A file's permission of a user that's kept, such as a direct permission, is replaced by an inherited one
+	// only if strategy prefers the inherited one.
+	ReplaceInherited(
+		ctx context.Context,
+		resourceType string,
+		subtree []FileNode,
+		parentID string,
+		strategy MergeStrategy) (InheritanceReport, error)
+}
+
+// EventRepository is an interface for reading the recorded events of the changes to permissions.
+type EventRepository interface {
+	// ListChanges returns up to pageSize changes to the permissions of userID that occurred after syncToken,
+	// in the order they occurred. If syncToken is empty then no changes are returned, only the sync token
+	// of the current state. Fails with codes.OutOfRange if the changes since syncToken are no longer kept.
+	ListChanges(
+		ctx context.Context,
+		resourceType string,
+		userID string,
+		syncToken string,
+		pageSize int) (PermissionChanges, error)
+
+	// ListFileEvents returns up to pageSize of the recorded events of the permissions to fileID that occurred
+	// since, if it isn't zero, newest first, after the event of pageToken, and the token of the next page,
+	// which is empty if there are no more events. The events of EventUpdated have their Previous permissions.
+	// Fails with codes.FailedPrecondition if the events aren't recorded.
+	ListFileEvents(
+		ctx context.Context,
+		resourceType string,
+		fileID string,
+		since time.Time,
+		pageSize int,
+		pageToken string) ([]PermissionEvent, string, error)
+
+	// GetEventsByUser returns the recorded events whose permissions are of userID, were created by userID
+	// or include userID in their sharing chains, in the order they occurred.
+	GetEventsByUser(ctx context.Context, userID string) ([]PermissionEvent, error)
+}
+
+// SharingRepository is an interface for reading the views of the sharing between users that are
+// derived from the stored permissions.
+type SharingRepository interface {
+	// GetShared returns the resources that both userA and userB have a permission to. If grantedByA
+	// is true then only the resources that userA shared with userB are returned.
+	GetShared(
+		ctx context.Context,
+		resourceType string,
+		userA string,
+		userB string,
+		grantedByA bool) ([]SharedFile, error)
+
+	// SummarizeSharing returns the summary of the sharing of the tree of folderID, which is made of folderID
+	// and descendantIDs, or of folderID and the files that inherit its permissions if descendantIDs is empty.
+	SummarizeSharing(
+		ctx context.Context,
+		resourceType string,
+		folderID string,
+		descendantIDs []string) (SharingSummary, error)
+
+	// ListSharedWithMe returns up to pageSize of the files of resourceType that other users shared with userID,
+	// most recently shared first, after the file of pageToken, and the token of the next page, which is empty
+	// if there are no more files. Fails with codes.FailedPrecondition if the shares aren't projected.
+	ListSharedWithMe(
+		ctx context.Context,
+		resourceType string,
+		userID string,
+		pageSize int,
+		pageToken string) ([]SharedWithMe, string, error)
+
+	// RefreshCollaborators recomputes the number of the permissions that each user gave each other user,
+	// which the frequent collaborators are read from, and returns the number of the pairs of users.
+	RefreshCollaborators(ctx context.Context) (int64, error)
+
+	// GetFrequentCollaborators returns up to limit of the users that userID gave the most permissions to,
+	// or was given the most permissions by, as of the last refresh, most frequent first.
+	GetFrequentCollaborators(ctx context.Context, userID string, limit int) ([]Collaborator, error)
+}
+
+// MaintenanceRepository is an interface for the administration of the stored permissions.
+type MaintenanceRepository interface {
+	// MigrateRole changes the role of the permissions that match filter from fromRole to toRole,
+	// batchSize permissions at a time, and calls progress after each batch.
+	MigrateRole(
+		ctx context.Context,
+		fromRole pb.Role,
+		toRole pb.Role,
+		filter PermissionsFilter,
+		batchSize int,
+		progress func(migrated int64, total int64) error) error
+
+	// RepairPermissions scans the permission documents for malformed ones, batchSize documents at a time,
+	// handles them by action and calls progress after each batch that has malformed documents.
+	RepairPermissions(
+		ctx context.Context,
+		action RepairAction,
+		batchSize int,
+		progress func(malformed []MalformedPermission) error) error
+
+	// UpgradeSchema upgrades the stored permissions whose shape is of an older version, batchSize permissions
+	// at a time, and calls progress after each batch with the number of permissions upgraded so far and the
+	// number of permissions that were older when the upgrade started. Permissions are also upgraded when
+	// they're read, so the upgrade only backfills the ones that aren't.
+	UpgradeSchema(ctx context.Context, batchSize int, progress func(upgraded int64, total int64) error) error
+
+	// ReplaceUser replaces userID as the creator, and in the sharing chains, of the permissions
+	// with replacement, and returns the number of permissions that were changed.
+	ReplaceUser(ctx context.Context, userID string, replacement string) (int64, error)
+
+	// Sample returns up to size permissions chosen at random.
+	Sample(ctx context.Context, size int) ([]Permission, error)
+
+	// CountPermissions returns the number of permissions of each resource type and role.
+	CountPermissions(ctx context.Context) ([]PermissionCount, error)
+
+	// HealthCheck returns whether the store of the repository is reachable.
+	HealthCheck(ctx context.Context) (bool, error)
+
+	// VerifyIndexes verifies that the indexes that the repository requires exist, and creates the missing ones.
+	VerifyIndexes(ctx context.Context) error
+}
+
+// RequestRepository is an interface for storing the idempotency keys of requests.
+type RequestRepository interface {
+	// ClaimIdempotencyKey claims key for creating the permission of userID to fileID.
+	// If key was already used to create the permission then the ID of the created permission is returned,
+	// otherwise an empty string is returned and the caller should create the permission.
+	ClaimIdempotencyKey(
+		ctx context.Context,
+		key string,
+		resourceType string,
+		fileID string,
+		userID string) (string, error)
+
+	// CompleteIdempotencyKey records that the permission with permissionID was created by key.
+	CompleteIdempotencyKey(ctx context.Context, key string, permissionID string) error
+
+	// ReleaseIdempotencyKey releases key so that a retry of a failed request may claim it.
+	ReleaseIdempotencyKey(ctx context.Context, key string) error
+}
+
+// ScheduleRepository is an interface for storing the scheduled updates of permissions.
+type ScheduleRepository interface {
+	// ScheduleUpdate stores update and returns it with its ID.
+	ScheduleUpdate(ctx context.Context, update ScheduledUpdate) (ScheduledUpdate, error)
+
+	// ClaimDueUpdate claims the earliest update that's scheduled at or before now for lease, and returns it.
+	// An update whose claim expired before it was completed or failed is claimed again.
+	// Returns nil if there are no due updates.
+	ClaimDueUpdate(ctx context.Context, now time.Time, lease time.Duration) (*ScheduledUpdate, error)
+
+	// CompleteUpdate deletes the update with id after it was applied.
+	CompleteUpdate(ctx context.Context, id string) error
+
+	// FailUpdate marks the update with id as failed with message, so that it isn't claimed again.
+	FailUpdate(ctx context.Context, id string, message string) error
+
+	// PurgeFailedUpdates deletes the updates that failed before failedBefore and returns their number.
+	PurgeFailedUpdates(ctx context.Context, failedBefore time.Time) (int64, error)
+}
+
+// ApprovalRepository is an interface for storing the requests of permissions that require approval.
+type ApprovalRepository interface {
+	// CreatePermissionRequest stores request and returns it with its ID.
+	CreatePermissionRequest(ctx context.Context, request PermissionRequest) (PermissionRequest, error)
+
+	// GetPermissionRequest returns the request with id, fails with codes.NotFound if it doesn't exist.
+	GetPermissionRequest(ctx context.Context, id string) (PermissionRequest, error)
+
+	// ApprovePermissionRequest records that the pending request with id was approved by approver at approvedAt,
+	// and created the permission with permissionID, and returns it. Fails with codes.FailedPrecondition
+	// if the request was already approved.
+	ApprovePermissionRequest(
+		ctx context.Context,
+		id string,
+		approver string,
+		approvedAt time.Time,
+		permissionID string,
+	) (PermissionRequest, error)
+}
+
+// LockRepository is an interface for storing the lockdowns of files.
+type LockRepository interface {
+	// SetFileLock locks the file of lock, or replaces its lock, and returns it.
+	SetFileLock(ctx context.Context, lock FileLock) (FileLock, error)
+
+	// DeleteFileLock unlocks fileID and returns its lock, fails with codes.NotFound if it isn't locked.
+	DeleteFileLock(ctx context.Context, resourceType string, fileID string) (FileLock, error)
+
+	// GetFileLock returns the lock of fileID, or nil if it isn't locked.
+	GetFileLock(ctx context.Context, resourceType string, fileID string) (*FileLock, error)
+}
+
+// HoldRepository is an interface for storing the legal holds of files.
+type HoldRepository interface {
+	// SetLegalHold places the hold of hold's file, or replaces its hold, and returns it.
+	// The audit records of the file's permissions are retained while it's held.
+	SetLegalHold(ctx context.Context, hold LegalHold) (LegalHold, error)
+
+	// DeleteLegalHold releases the hold of fileID and returns it, fails with codes.NotFound if it isn't held.
+	// The audit records of the file's permissions then expire as usual.
+	DeleteLegalHold(ctx context.Context, resourceType string, fileID string) (LegalHold, error)
+
+	// GetLegalHolds returns the holds of the files of fileIDs that are held.
+	GetLegalHolds(ctx context.Context, resourceType string, fileIDs []string) ([]LegalHold, error)
+
+	// ListLegalHolds returns the holds of the files of resourceType, or of every type if it's empty.
+	ListLegalHolds(ctx context.Context, resourceType string) ([]LegalHold, error)
+}
+
+// JobRepository is an interface for storing the jobs of long-running bulk operations.
+// Methods that look up a job fail with codes.NotFound if it doesn't exist.
+type JobRepository interface {
+	// CreateJob stores job and returns it with its ID.
+	CreateJob(ctx context.Context, job Job) (Job, error)
+
+	// GetJob returns the job with id.
+	GetJob(ctx context.Context, id string) (Job, error)
+
+	// ListJobs returns up to pageSize jobs of kind, or of every kind if it's empty, that come after
+	// pageToken, newest first, and the token of the next page, which is empty if there are no more pages.
+	ListJobs(ctx context.Context, kind string, pageSize int, pageToken string) ([]Job, string, error)
+
+	// UpdateJobProgress sets the progress of the running job with id, at updatedAt, and returns it.
+	UpdateJobProgress(ctx context.Context, id string, progress JobProgress, updatedAt time.Time) (Job, error)
+
+	// FinishJob sets the state of the running job with id to state, with progress, and message if it failed,
+	// at finishedAt, and returns it.
+	FinishJob(
+		ctx context.Context,
+		id string,
+		state JobState,
+		progress JobProgress,
+		message string,
+		finishedAt time.Time) (Job, error)
+
+	// CancelJob requests the cancellation of the running job with id and returns it,
+	// fails with codes.FailedPrecondition if the job already finished.
+	CancelJob(ctx context.Context, id string) (Job, error)
+
+	// FailStaleJobs fails the running jobs that weren't updated since staleBefore with message,
+	// and returns their number.
+	FailStaleJobs(ctx context.Context, staleBefore time.Time, message string) (int64, error)
+
+	// PurgeJobs deletes the jobs that finished before finishedBefore and returns their number.
+	PurgeJobs(ctx context.Context, finishedBefore time.Time) (int64, error)
+
+	// ClaimRecurringRun claims the run of the recurring job of kind at runAt, and returns true if it was
+	// claimed, or false if it was already claimed, such as by another instance of the service.
+	ClaimRecurringRun(ctx context.Context, kind string, runAt time.Time) (bool, error)
+}
+
+// LeaseRepository is an interface for storing the leases that elect the leaders of the singleton
+// background workers, such as the outbox relay, among the instances of the service.
+type LeaseRepository interface {
+	// AcquireLease acquires the lease of name for holder until expiresAt, or renews it if holder already
+	// holds it, and returns true if holder holds it, or false if it's held by another holder whose lease
+	// hasn't expired at now.
+	AcquireLease(ctx context.Context, name string, holder string, now time.Time, expiresAt time.Time) (bool, error)
+
+	// ReleaseLease releases the lease of name if it's held by holder.
+	ReleaseLease(ctx context.Context, name string, holder string) error
+}
+
+// TenantRepository is an interface for the data of tenants, whose stores are the collections of the service
+// whose names start with the tenant's ID and an underscore, for their settings, and for the confirmations
+// of their purges. Methods that change the data of a tenant, other than its settings, fail with
+// codes.FailedPrecondition for the repository's own tenant.
+type TenantRepository interface {
+	// ExportTenantData calls export with each of the documents of tenantID.
+	ExportTenantData(ctx context.Context, tenantID string, export func(record TenantRecord) error) error
+
+	// CountTenantData returns the purge of tenantID with its collections and number of documents.
+	CountTenantData(ctx context.Context, tenantID string) (TenantPurge, error)
+
+	// CreateTenantPurge stores the confirmation of purge until its ExpiresAt.
+	CreateTenantPurge(ctx context.Context, purge TenantPurge) error
+
+	// ConfirmTenantPurge deletes the confirmation of the purge of tenantID with token and returns it,
+	// fails with codes.FailedPrecondition if there's no such confirmation or it expired at or before now.
+	ConfirmTenantPurge(ctx context.Context, tenantID string, token string, now time.Time) (TenantPurge, error)
+
+	// PurgeTenantData drops the collections of tenantID and returns the purge with the collections
+	// and the number of documents that were dropped.
+	PurgeTenantData(ctx context.Context, tenantID string) (TenantPurge, error)
+
+	// GetTenantRateLimits returns the rate limits in the settings of tenantID, or of the repository's own
+	// tenant if it's empty, which are unlimited if they were never set.
+	GetTenantRateLimits(ctx context.Context, tenantID string) (TenantRateLimits, error)
+
+	// SetTenantRateLimits stores limits in the settings of their tenant and returns them.
+	SetTenantRateLimits(ctx context.Context, limits TenantRateLimits) (TenantRateLimits, error)
+}
+
+// ReviewRepository is an interface for storing the access review campaigns and their items, the grants that
+// are reviewed in them. Methods that look up a campaign fail with codes.NotFound if it doesn't exist.
+type ReviewRepository interface {
+	// CreateAccessReview snapshots the direct permissions of the resources of review as its items, each reviewed
+	// by the owner of its resource or by review's DefaultReviewer, then stores review with their number,
+	// and returns it with its ID.
+	CreateAccessReview(ctx context.Context, review AccessReview) (AccessReview, error)
+
+	// GetAccessReview returns the campaign with id.
+	GetAccessReview(ctx context.Context, id string) (AccessReview, error)
+
+	// ListAccessReviews returns up to pageSize campaigns that come after pageToken, newest first,
+	// and the token of the next page, which is empty if there are no more pages.
+	ListAccessReviews(ctx context.Context, pageSize int, pageToken string) ([]AccessReview, string, error)
+
+	// ListAccessReviewItems returns up to pageSize items of the campaign with reviewID that match filter
+	// and come after pageToken, and the token of the next page, which is empty if there are no more pages.
+	ListAccessReviewItems(
+		ctx context.Context,
+		reviewID string,
+		filter AccessReviewItemFilter,
+		pageSize int,
+		pageToken string) ([]AccessReviewItem, string, error)
+
+	// DecideAccessReviewItems records decisions of the items of the open campaign with reviewID, by reviewer
+	// at decidedAt, in a single transaction, and returns the decided items. If reviewer isn't empty then
+	// only its items may be decided. Fails with codes.NotFound if an item doesn't exist, or isn't reviewed
+	// by reviewer, and with codes.FailedPrecondition if the campaign isn't open.
+	DecideAccessReviewItems(
+		ctx context.Context,
+		reviewID string,
+		reviewer string,
+		decisions []AccessReviewDecision,
+		decidedAt time.Time) ([]AccessReviewItem, error)
+
+	// StartClosingAccessReview sets the state of the open, or closing, campaign with id to AccessReviewClosing,
+	// so that its items may no longer be decided, and returns it. Fails with codes.FailedPrecondition
+	// if the campaign is already closed.
+	StartClosingAccessReview(
+		ctx context.Context,
+		id string,
+		closedBy string,
+		revokeUndecided bool) (AccessReview, error)
+
+	// FinishClosingAccessReview sets the state of the closing campaign with id to AccessReviewClosed,
+	// with the numbers of the revoked and skipped items, at closedAt, and returns it.
+	FinishClosingAccessReview(
+		ctx context.Context,
+		id string,
+		revoked int64,
+		skipped int64,
+		closedAt time.Time) (AccessReview, error)
+}
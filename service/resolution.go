@@ -0,0 +1,119 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	pb "github.com/meateam/permission-service/proto"
+)
+
+const (
+	// MergeMostSpecific is the strategy that resolves a user's grants to the most specific of them,
+	// a grant that's given to the file directly over one that's inherited from a folder, and is the default.
+	MergeMostSpecific MergeStrategy = "most_specific"
+
+	// MergeHighestRole is the strategy that resolves a user's grants to the one whose role grants
+	// the roles of the others, and to the most specific of them if no role grants the others.
+	MergeHighestRole MergeStrategy = "highest_role"
+)
+
+// MergeStrategy is how the grants of a user that apply to the same file are resolved to the effective one.
+type MergeStrategy string
+
+// Grant is a role that's given to a user to a file, directly or by inheritance from a folder.
+type Grant struct {
+	Role pb.Role
+
+	// Inherited is whether the grant is inherited from a folder rather than given to the file directly.
+	Inherited bool
+}
+
+// ParseMergeStrategy parses strategy, and returns MergeMostSpecific if it's empty.
+func ParseMergeStrategy(strategy string) (MergeStrategy, error) {
+	switch MergeStrategy(strings.TrimSpace(strategy)) {
+	case "", MergeMostSpecific:
+		return MergeMostSpecific, nil
+	case MergeHighestRole:
+		return MergeHighestRole, nil
+	default:
+		return "", fmt.Errorf("unknown merge strategy %q", strategy)
+	}
+}
+
+// Resolve returns the index of the effective grant of grants by m, or -1 if grants is empty.
+// Under MergeHighestRole the grants whose role is granted by a higher role of another grant are ignored,
+// and the most specific of the rest is effective. Grants that are equally specific resolve to the first
+// of them, so that the grant a user already has is kept if it's passed first.
+func (m MergeStrategy) Resolve(grants []Grant) int {
+	effective := -1
+	for i, grant := range grants {
+		if m == MergeHighestRole && isOutranked(grant, grants) {
+			continue
+		}
+
+		if effective == -1 || (!grant.Inherited && grants[effective].Inherited) {
+			effective = i
+		}
+	}
+
+	return effective
+}
+
+// isOutranked returns true if the role of grant is granted by a higher role of any of grants.
+func isOutranked(grant Grant, grants []Grant) bool {
+	for _, other := range grants {
+		if other.Role != grant.Role && IsSubRole(other.Role, grant.Role) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MergeStrategies are the merge strategies of the grants to the resources of each resource type.
+type MergeStrategies struct {
+	// Default is the strategy of the resource types that aren't in ByResourceType.
+	Default MergeStrategy
+
+	// ByResourceType maps resource types to their strategies.
+	ByResourceType map[string]MergeStrategy
+}
+
+// ParseMergeStrategies parses the strategies of resource types of the form
+// "resourceType=strategy,resourceType=strategy", such as "folder=highest_role", an empty spec is valid.
+func ParseMergeStrategies(spec string) (map[string]MergeStrategy, error) {
+	strategies := map[string]MergeStrategy{}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("invalid merge strategy entry %q", entry)
+		}
+
+		strategy, err := ParseMergeStrategy(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid merge strategy entry %q: %v", entry, err)
+		}
+
+		strategies[strings.TrimSpace(parts[0])] = strategy
+	}
+
+	return strategies, nil
+}
+
+// For returns the strategy of the grants to the resources of resourceType.
+func (s MergeStrategies) For(resourceType string) MergeStrategy {
+	if strategy, ok := s.ByResourceType[resourceTypeOrDefault(resourceType)]; ok {
+		return strategy
+	}
+
+	if s.Default == "" {
+		return MergeMostSpecific
+	}
+
+	return s.Default
+}
@@ -0,0 +1,34 @@
+//go:build go1.18
+// +build go1.18
+
+package service
+
+import (
+	"testing"
+)
+
+// addScenarioSeeds adds the seed corpus of the scenario fuzz targets.
+func addScenarioSeeds(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{0, 1, 9})
+	f.Add([]byte{2, 0, 5, 8, 1, 0})
+	f.Add([]byte{4, 0, 1, 6, 7, 8, 9, 10, 11, 4})
+}
+
+func FuzzRevocationNeverIncreasesAccess(f *testing.F) {
+	addScenarioSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if err := checkRevocationNeverIncreasesAccess(decodeScenario(data)); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func FuzzSimulationDoesntChangeCurrent(f *testing.F) {
+	addScenarioSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if err := checkSimulationDoesntChangeCurrent(decodeScenario(data)); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
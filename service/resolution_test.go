@@ -0,0 +1,302 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"testing/quick"
+
+	pb "github.com/meateam/permission-service/proto"
+	pbv2 "github.com/meateam/permission-service/proto/v2"
+)
+
+// scenarioUsers are the users of the generated scenarios, few enough that changes collide.
+var scenarioUsers = []string{"user-0", "user-1", "user-2", "user-3"}
+
+// scenarioRoles are the roles that are given in the generated scenarios.
+var scenarioRoles = []pb.Role{
+	pb.Role_READ,
+	pb.Role_WRITE,
+	pb.Role_COMMENTER,
+	pb.Role_UPLOADER,
+	pb.Role_AUDITOR,
+}
+
+// wantedRoles are the roles that access is checked with, including roles that don't exist.
+var wantedRoles = []pb.Role{
+	pb.Role_NONE,
+	pb.Role_WRITE,
+	pb.Role_READ,
+	pb.Role_COMMENTER,
+	pb.Role_UPLOADER,
+	pb.Role_AUDITOR,
+	pb.Role(len(pb.Role_name)),
+}
+
+// decodeScenario decodes data into the current roles of users to a file and changes to them.
+// The first byte is the number of current roles, each following byte is a current role and then
+// a change, whose user is its lower bits and whose role, or whether it's a revocation, is its higher bits.
+func decodeScenario(data []byte) (map[string]pb.Role, []*pbv2.AccessChange) {
+	current := make(map[string]pb.Role)
+	if len(data) == 0 {
+		return current, nil
+	}
+
+	currentCount := int(data[0])
+	data = data[1:]
+	for ; currentCount > 0 && len(data) > 0; currentCount-- {
+		userID := scenarioUsers[int(data[0])%len(scenarioUsers)]
+		current[userID] = scenarioRoles[int(data[0])/len(scenarioUsers)%len(scenarioRoles)]
+		data = data[1:]
+	}
+
+	changes := make([]*pbv2.AccessChange, 0, len(data))
+	for _, b := range data {
+		change := &pbv2.AccessChange{UserId: scenarioUsers[int(b)%len(scenarioUsers)]}
+		kind := int(b) / len(scenarioUsers) % (len(scenarioRoles) + 1)
+		if kind == len(scenarioRoles) {
+			change.Revoke = true
+		} else {
+			change.Role = pbv2.Role(scenarioRoles[kind])
+		}
+
+		changes = append(changes, change)
+	}
+
+	return current, changes
+}
+
+// checkRevocationNeverIncreasesAccess verifies that revoking the role of any user after changes
+// leaves the revoked user without access, however it was granted before, and doesn't give
+// any user access that it didn't have before the revocation.
+func checkRevocationNeverIncreasesAccess(current map[string]pb.Role, changes []*pbv2.AccessChange) error {
+	before := simulateChanges(current, changes)
+	for _, revokedID := range scenarioUsers {
+		revoked := append(changes[:len(changes):len(changes)], &pbv2.AccessChange{UserId: revokedID, Revoke: true})
+		after := simulateChanges(current, revoked)
+		if role, ok := after[revokedID]; ok {
+			return fmt.Errorf("revoked user %s has role %s", revokedID, role)
+		}
+
+		for _, userID := range scenarioUsers {
+			for _, wanted := range wantedRoles {
+				if IsSubRole(after[userID], wanted) && !IsSubRole(before[userID], wanted) {
+					return fmt.Errorf("revoking %s gave %s access with role %s", revokedID, userID, wanted)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkSimulationDoesntChangeCurrent verifies that simulating changes doesn't modify the current roles.
+func checkSimulationDoesntChangeCurrent(current map[string]pb.Role, changes []*pbv2.AccessChange) error {
+	snapshot := make(map[string]pb.Role, len(current))
+	for userID, role := range current {
+		snapshot[userID] = role
+	}
+
+	simulateChanges(current, changes)
+	if len(current) != len(snapshot) {
+		return fmt.Errorf("simulation changed the current roles from %v to %v", snapshot, current)
+	}
+
+	for userID, role := range snapshot {
+		if current[userID] != role {
+			return fmt.Errorf("simulation changed the current roles from %v to %v", snapshot, current)
+		}
+	}
+
+	return nil
+}
+
+func TestRevocationNeverIncreasesAccess(t *testing.T) {
+	property := func(data []byte) bool {
+		if err := checkRevocationNeverIncreasesAccess(decodeScenario(data)); err != nil {
+			t.Log(err)
+			return false
+		}
+
+		return true
+	}
+
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSimulationDoesntChangeCurrent(t *testing.T) {
+	property := func(data []byte) bool {
+		if err := checkSimulationDoesntChangeCurrent(decodeScenario(data)); err != nil {
+			t.Log(err)
+			return false
+		}
+
+		return true
+	}
+
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSubRoleIsPartialOrder(t *testing.T) {
+	for _, a := range wantedRoles {
+		if a != pb.Role_NONE && pb.Role_name[int32(a)] != "" && !IsSubRole(a, a) {
+			t.Errorf("role %s doesn't include itself", a)
+		}
+
+		for _, b := range wantedRoles {
+			if a != b && IsSubRole(a, b) && IsSubRole(b, a) {
+				t.Errorf("roles %s and %s include each other", a, b)
+			}
+
+			for _, c := range wantedRoles {
+				if IsSubRole(a, b) && IsSubRole(b, c) && !IsSubRole(a, c) {
+					t.Errorf("role %s includes %s which includes %s, but doesn't include it", a, b, c)
+				}
+			}
+		}
+	}
+}
+
+func TestCapabilitiesFollowRoles(t *testing.T) {
+	for kind := range capabilitiesByKind {
+		if len(Capabilities(kind, pb.Role_NONE)) != 0 {
+			t.Errorf("role NONE grants capabilities to a %s", kind)
+		}
+
+		for _, role := range wantedRoles {
+			for _, included := range wantedRoles {
+				if !IsSubRole(role, included) {
+					continue
+				}
+
+				for _, capability := range Capabilities(kind, included) {
+					if !HasCapability(kind, role, capability) {
+						t.Errorf("role %s includes %s but doesn't grant %s to a %s", role, included, capability, kind)
+					}
+				}
+			}
+		}
+	}
+}
+
+// scenarioGrants are every grant of the scenario roles, directly and by inheritance.
+func scenarioGrants() []Grant {
+	grants := make([]Grant, 0, 2*len(scenarioRoles))
+	for _, role := range scenarioRoles {
+		grants = append(grants, Grant{Role: role}, Grant{Role: role, Inherited: true})
+	}
+
+	return grants
+}
+
+// grantSequences returns every sequence of up to maxLength scenario grants.
+func grantSequences(maxLength int) [][]Grant {
+	sequences := [][]Grant{}
+	previous := [][]Grant{{}}
+	for length := 1; length <= maxLength; length++ {
+		next := [][]Grant{}
+		for _, sequence := range previous {
+			for _, grant := range scenarioGrants() {
+				next = append(next, append(sequence[:len(sequence):len(sequence)], grant))
+			}
+		}
+
+		sequences = append(sequences, next...)
+		previous = next
+	}
+
+	return sequences
+}
+
+func TestResolveEmptyGrants(t *testing.T) {
+	for _, strategy := range []MergeStrategy{MergeMostSpecific, MergeHighestRole} {
+		if effective := strategy.Resolve(nil); effective != -1 {
+			t.Errorf("%s resolved no grants to %d", strategy, effective)
+		}
+	}
+}
+
+func TestMostSpecificPrefersFirstDirectGrant(t *testing.T) {
+	for _, grants := range grantSequences(3) {
+		want := 0
+		for i, grant := range grants {
+			if !grant.Inherited {
+				want = i
+				break
+			}
+		}
+
+		if effective := MergeMostSpecific.Resolve(grants); effective != want {
+			t.Errorf("%s resolved %v to %d, expected %d", MergeMostSpecific, grants, effective, want)
+		}
+	}
+}
+
+func TestHighestRoleNeverLowersAccess(t *testing.T) {
+	for _, grants := range grantSequences(3) {
+		effective := MergeHighestRole.Resolve(grants)
+		if effective < 0 || effective >= len(grants) {
+			t.Fatalf("%s resolved %v to %d", MergeHighestRole, grants, effective)
+		}
+
+		role := grants[effective].Role
+		for _, grant := range grants {
+			if grant.Role != role && IsSubRole(grant.Role, role) {
+				t.Errorf("%s resolved %v to %s, which %s grants", MergeHighestRole, grants, role, grant.Role)
+			}
+		}
+
+		specific := grants[MergeMostSpecific.Resolve(grants)].Role
+		if specific != role && IsSubRole(specific, role) {
+			t.Errorf("%s resolved %v to %s, lower than %s", MergeHighestRole, grants, role, specific)
+		}
+	}
+}
+
+func TestHighestRoleFallsBackToMostSpecific(t *testing.T) {
+	for _, grants := range grantSequences(2) {
+		a, b := grants[0], grants[len(grants)-1]
+		if a.Role != b.Role && (IsSubRole(a.Role, b.Role) || IsSubRole(b.Role, a.Role)) {
+			continue
+		}
+
+		highest, specific := MergeHighestRole.Resolve(grants), MergeMostSpecific.Resolve(grants)
+		if highest != specific {
+			t.Errorf("%s resolved %v to %d, expected %d", MergeHighestRole, grants, highest, specific)
+		}
+	}
+}
+
+func TestParseMergeStrategies(t *testing.T) {
+	strategies, err := ParseMergeStrategies(" folder=highest_role, file = most_specific ,")
+	if err != nil {
+		t.Fatalf("ParseMergeStrategies failed: %v", err)
+	}
+
+	if strategies["folder"] != MergeHighestRole || strategies["file"] != MergeMostSpecific || len(strategies) != 2 {
+		t.Errorf("unexpected strategies %v", strategies)
+	}
+
+	for _, spec := range []string{"folder", "=highest_role", "folder=lowest_role"} {
+		if _, err := ParseMergeStrategies(spec); err == nil {
+			t.Errorf("ParseMergeStrategies(%q) didn't fail", spec)
+		}
+	}
+
+	merge := MergeStrategies{Default: MergeHighestRole, ByResourceType: strategies}
+	// The empty resource type is the default resource type, whose strategy is configured.
+	if merge.For("folder") != MergeHighestRole || merge.For("") != MergeMostSpecific {
+		t.Errorf("unexpected strategies of resource types of %v", merge)
+	}
+
+	if merge.For("drive") != MergeHighestRole {
+		t.Errorf("expected the default strategy of resource types that aren't configured, got %s", merge.For("drive"))
+	}
+
+	if (MergeStrategies{}).For("folder") != MergeMostSpecific {
+		t.Errorf("expected %s to be the default strategy", MergeMostSpecific)
+	}
+}
@@ -0,0 +1,489 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	pb "github.com/meateam/permission-service/proto"
+	pbv2 "github.com/meateam/permission-service/proto/v2"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// accessReviewsCollection is the collection ID of the access review campaigns, `accessReviews/{review}`.
+const accessReviewsCollection = "accessReviews"
+
+// AccessReviewState is the state of an access review campaign.
+type AccessReviewState string
+
+const (
+	// AccessReviewOpen is the state of a campaign whose items are decided by their reviewers.
+	AccessReviewOpen AccessReviewState = "open"
+
+	// AccessReviewClosing is the state of a campaign that's being closed, whose revocations are applied.
+	AccessReviewClosing AccessReviewState = "closing"
+
+	// AccessReviewClosed is the state of a campaign that was closed, whose revocations were applied.
+	AccessReviewClosed AccessReviewState = "closed"
+)
+
+// ReviewDecision is the decision of a reviewed grant.
+type ReviewDecision string
+
+const (
+	// ReviewPending is the decision of a grant that wasn't decided yet.
+	ReviewPending ReviewDecision = ""
+
+	// ReviewCertified is the decision of a grant that's kept.
+	ReviewCertified ReviewDecision = "certified"
+
+	// ReviewRevoked is the decision of a grant that's revoked when its campaign is closed.
+	ReviewRevoked ReviewDecision = "revoked"
+)
+
+// AccessReview is a campaign in which the direct grants of a set of files, or of every file of ResourceType
+// if FileIDs is empty, are certified or revoked by their reviewers.
+type AccessReview struct {
+	ID              string
+	Title           string
+	ResourceType    string
+	FileIDs         []string
+	DefaultReviewer string
+	CreatedBy       string
+	CreatedAt       time.Time
+	State           AccessReviewState
+
+	// Items is the number of the reviewed grants, and Decided is the number of the ones that were decided.
+	Items   int64
+	Decided int64
+
+	// RevokeUndecided is whether the undecided grants are revoked when the campaign is closed.
+	RevokeUndecided bool
+
+	// ClosedBy and ClosedAt are set once the campaign is closed, Revoked is the number of the grants
+	// that were revoked, and Skipped the number of the ones that weren't since their files are held.
+	ClosedBy string
+	ClosedAt *time.Time
+	Revoked  int64
+	Skipped  int64
+}
+
+// AccessReviewItem is a grant that's reviewed in an access review campaign,
+// as it was when the campaign was created.
+type AccessReviewItem struct {
+	ReviewID     string
+	PermissionID string
+	ResourceType string
+	FileID       string
+	UserID       string
+	Role         pb.Role
+	Creator      string
+	Reviewer     string
+	Decision     ReviewDecision
+
+	// DecidedBy and DecidedAt are set once the grant is decided.
+	DecidedBy string
+	DecidedAt *time.Time
+}
+
+// AccessReviewItemFilter filters the items of an access review campaign.
+type AccessReviewItemFilter struct {
+	// Reviewer is the reviewer of the items, of every reviewer if it's empty.
+	Reviewer string
+
+	// Decisions are the decisions of the items, of every decision if it's empty.
+	Decisions []ReviewDecision
+}
+
+// AccessReviewDecision is the decision of the grant of UserID to FileID in an access review campaign.
+type AccessReviewDecision struct {
+	FileID   string
+	UserID   string
+	Decision ReviewDecision
+}
+
+// CreateAccessReview is the request handler for starting an access review campaign.
+func (s AdminService) CreateAccessReview(
+	ctx context.Context,
+	req *pbv2.CreateAccessReviewRequest,
+) (*pbv2.AccessReview, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.limits.CheckList("resources", len(req.GetResources())); err != nil {
+		return nil, err
+	}
+
+	review := AccessReview{
+		Title:           req.GetTitle(),
+		DefaultReviewer: req.GetDefaultReviewerId(),
+		CreatedBy:       actorOrCaller(ctx),
+	}
+
+	switch {
+	case len(req.GetResources()) > 0 && req.GetResourceCollection() != "":
+		return nil, status.Error(codes.InvalidArgument, "only one of resources and resource_collection may be set")
+	case req.GetResourceCollection() != "":
+		resourceType, ok := parseResourceCollection(req.GetResourceCollection())
+		if !ok {
+			return nil, status.Errorf(
+				codes.InvalidArgument,
+				"invalid resource collection %q",
+				req.GetResourceCollection(),
+			)
+		}
+
+		review.ResourceType = resourceType
+	case len(req.GetResources()) > 0:
+		for _, resource := range req.GetResources() {
+			resourceType, fileID, err := parseResourceName(resource)
+			if err != nil {
+				return nil, err
+			}
+
+			if review.ResourceType != "" && resourceType != review.ResourceType {
+				return nil, status.Error(codes.InvalidArgument, "resources must all be of the same collection")
+			}
+
+			review.ResourceType = resourceType
+			review.FileIDs = append(review.FileIDs, fileID)
+		}
+	default:
+		return nil, status.Error(codes.InvalidArgument, "either resources or resource_collection is required")
+	}
+
+	createdReview, err := s.controller.CreateAccessReview(ctx, review)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"accessReview": createdReview.ID,
+		"resourceType": createdReview.ResourceType,
+		"files":        len(createdReview.FileIDs),
+		"items":        createdReview.Items,
+		"createdBy":    createdReview.CreatedBy,
+	}).Info("access review created")
+
+	return marshalAccessReview(createdReview)
+}
+
+// GetAccessReview is the request handler for getting an access review campaign.
+func (s AdminService) GetAccessReview(
+	ctx context.Context,
+	req *pbv2.GetAccessReviewRequest,
+) (*pbv2.AccessReview, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	id, err := parseAccessReviewName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	review, err := s.controller.GetAccessReview(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalAccessReview(review)
+}
+
+// ListAccessReviews is the request handler for listing the access review campaigns, newest first.
+func (s AdminService) ListAccessReviews(
+	ctx context.Context,
+	req *pbv2.ListAccessReviewsRequest,
+) (*pbv2.ListAccessReviewsResponse, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	pageSize := int(req.GetPageSize())
+	if pageSize < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page_size must not be negative")
+	}
+
+	if pageSize == 0 {
+		pageSize = DefaultPageSize
+	}
+
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	reviews, nextPageToken, err := s.controller.ListAccessReviews(ctx, pageSize, req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pbv2.ListAccessReviewsResponse{
+		AccessReviews: make([]*pbv2.AccessReview, 0, len(reviews)),
+		NextPageToken: nextPageToken,
+	}
+
+	for _, review := range reviews {
+		reviewV2, err := marshalAccessReview(review)
+		if err != nil {
+			return nil, err
+		}
+
+		response.AccessReviews = append(response.AccessReviews, reviewV2)
+	}
+
+	return response, nil
+}
+
+// CloseAccessReview is the request handler for closing an access review campaign and applying its revocations.
+func (s AdminService) CloseAccessReview(
+	ctx context.Context,
+	req *pbv2.CloseAccessReviewRequest,
+) (*pbv2.AccessReview, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	id, err := parseAccessReviewName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	review, err := s.controller.CloseAccessReview(ctx, id, actorOrCaller(ctx), req.GetRevokeUndecided())
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"accessReview":    review.ID,
+		"revoked":         review.Revoked,
+		"skipped":         review.Skipped,
+		"revokeUndecided": review.RevokeUndecided,
+		"closedBy":        review.ClosedBy,
+	}).Warn("access review closed")
+
+	return marshalAccessReview(review)
+}
+
+// ListAccessReviewItems is the request handler for listing the grants that a reviewer reviews
+// in an access review campaign.
+func (s ServiceV2) ListAccessReviewItems(
+	ctx context.Context,
+	req *pbv2.ListAccessReviewItemsRequest,
+) (*pbv2.ListAccessReviewItemsResponse, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	id, err := parseAccessReviewName(req.GetAccessReview())
+	if err != nil {
+		return nil, err
+	}
+
+	filter := AccessReviewItemFilter{Reviewer: req.GetReviewerId()}
+	if actor := ActorFromContext(ctx); actor != "" {
+		if filter.Reviewer != "" && filter.Reviewer != actor {
+			return nil, status.Errorf(codes.PermissionDenied, "%s may not list the items of another reviewer", actor)
+		}
+
+		filter.Reviewer = actor
+	}
+
+	if req.GetPendingOnly() {
+		filter.Decisions = []ReviewDecision{ReviewPending}
+	}
+
+	pageSize := int(req.GetPageSize())
+	if pageSize < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page_size must not be negative")
+	}
+
+	if pageSize == 0 {
+		pageSize = DefaultPageSize
+	}
+
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	items, nextPageToken, err := s.controller.ListAccessReviewItems(ctx, id, filter, pageSize, req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pbv2.ListAccessReviewItemsResponse{
+		Items:         make([]*pbv2.AccessReviewItem, 0, len(items)),
+		NextPageToken: nextPageToken,
+	}
+
+	for _, item := range items {
+		itemV2, err := marshalAccessReviewItem(item)
+		if err != nil {
+			return nil, err
+		}
+
+		response.Items = append(response.Items, itemV2)
+	}
+
+	return response, nil
+}
+
+// DecideAccessReviewItems is the request handler for recording the decisions of the actor
+// of the grants that it reviews in an access review campaign.
+func (s ServiceV2) DecideAccessReviewItems(
+	ctx context.Context,
+	req *pbv2.DecideAccessReviewItemsRequest,
+) (*pbv2.DecideAccessReviewItemsResponse, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.limits.CheckList("decisions", len(req.GetDecisions())); err != nil {
+		return nil, err
+	}
+
+	if err := s.actors.RequireActor(ctx); err != nil {
+		return nil, err
+	}
+
+	reviewer := ActorFromContext(ctx)
+	if reviewer == "" {
+		return nil, status.Errorf(codes.Unauthenticated, "%s is required to decide an access review", ActorHeader)
+	}
+
+	id, err := parseAccessReviewName(req.GetAccessReview())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.GetDecisions()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "decisions are required")
+	}
+
+	decisions := make([]AccessReviewDecision, 0, len(req.GetDecisions()))
+	for _, decision := range req.GetDecisions() {
+		_, fileID, userID, err := parsePermissionName(decision.GetPermission())
+		if err != nil {
+			return nil, err
+		}
+
+		reviewDecision, ok := reviewDecisions[decision.GetDecision()]
+		if !ok || reviewDecision == ReviewPending {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid decision of %s", decision.GetPermission())
+		}
+
+		decisions = append(decisions, AccessReviewDecision{FileID: fileID, UserID: userID, Decision: reviewDecision})
+	}
+
+	items, err := s.controller.DecideAccessReviewItems(ctx, id, reviewer, decisions)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pbv2.DecideAccessReviewItemsResponse{Items: make([]*pbv2.AccessReviewItem, 0, len(items))}
+	for _, item := range items {
+		itemV2, err := marshalAccessReviewItem(item)
+		if err != nil {
+			return nil, err
+		}
+
+		response.Items = append(response.Items, itemV2)
+	}
+
+	return response, nil
+}
+
+// parseAccessReviewName parses the resource name of an access review campaign, `accessReviews/{review}`,
+// and returns the campaign's ID.
+func parseAccessReviewName(name string) (string, error) {
+	parts := strings.Split(name, "/")
+	if len(parts) != 2 || parts[0] != accessReviewsCollection || parts[1] == "" {
+		return "", status.Errorf(codes.InvalidArgument, "invalid access review name %q", name)
+	}
+
+	return parts[1], nil
+}
+
+// accessReviewStates maps the states of access review campaigns to their states in the API.
+var accessReviewStates = map[AccessReviewState]pbv2.AccessReview_State{
+	AccessReviewOpen:    pbv2.AccessReview_OPEN,
+	AccessReviewClosing: pbv2.AccessReview_CLOSING,
+	AccessReviewClosed:  pbv2.AccessReview_CLOSED,
+}
+
+// reviewDecisions maps the decisions of reviewed grants in the API to their decisions.
+var reviewDecisions = map[pbv2.AccessReviewItem_Decision]ReviewDecision{
+	pbv2.AccessReviewItem_DECISION_UNSPECIFIED: ReviewPending,
+	pbv2.AccessReviewItem_CERTIFIED:            ReviewCertified,
+	pbv2.AccessReviewItem_REVOKED:              ReviewRevoked,
+}
+
+// marshalAccessReview returns the API representation of review.
+func marshalAccessReview(review AccessReview) (*pbv2.AccessReview, error) {
+	createTime, err := ptypes.TimestampProto(review.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewV2 := &pbv2.AccessReview{
+		Name:              accessReviewsCollection + "/" + review.ID,
+		Title:             review.Title,
+		DefaultReviewerId: review.DefaultReviewer,
+		State:             accessReviewStates[review.State],
+		ItemCount:         review.Items,
+		DecidedCount:      review.Decided,
+		RevokedCount:      review.Revoked,
+		SkippedCount:      review.Skipped,
+		RevokeUndecided:   review.RevokeUndecided,
+		Creator:           review.CreatedBy,
+		CreateTime:        createTime,
+		ClosedBy:          review.ClosedBy,
+	}
+
+	if len(review.FileIDs) == 0 {
+		reviewV2.ResourceCollection = resourceCollection(review.ResourceType)
+	}
+
+	for _, fileID := range review.FileIDs {
+		reviewV2.Resources = append(reviewV2.Resources, resourceCollection(review.ResourceType)+"/"+fileID)
+	}
+
+	if review.ClosedAt != nil {
+		if reviewV2.CloseTime, err = ptypes.TimestampProto(*review.ClosedAt); err != nil {
+			return nil, err
+		}
+	}
+
+	return reviewV2, nil
+}
+
+// marshalAccessReviewItem returns the API representation of item.
+func marshalAccessReviewItem(item AccessReviewItem) (*pbv2.AccessReviewItem, error) {
+	itemV2 := &pbv2.AccessReviewItem{
+		Permission: permissionName(item.ResourceType, item.FileID, item.UserID),
+		Role:       pbv2.Role(item.Role),
+		Creator:    item.Creator,
+		ReviewerId: item.Reviewer,
+		DecidedBy:  item.DecidedBy,
+	}
+
+	for decisionV2, decision := range reviewDecisions {
+		if decision == item.Decision {
+			itemV2.Decision = decisionV2
+		}
+	}
+
+	if item.DecidedAt != nil {
+		decideTime, err := ptypes.TimestampProto(*item.DecidedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		itemV2.DecideTime = decideTime
+	}
+
+	return itemV2, nil
+}
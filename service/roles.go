@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	pb "github.com/meateam/permission-service/proto"
+	pbv2 "github.com/meateam/permission-service/proto/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RoleOrder is the order of the roles in role pickers, from the least to the most privileged,
+// followed by the roles of special purposes.
+var RoleOrder = []pb.Role{
+	pb.Role_READ,
+	pb.Role_COMMENTER,
+	pb.Role_WRITE,
+	pb.Role_UPLOADER,
+	pb.Role_AUDITOR,
+}
+
+// RoleRegistry resolves the names of roles, and their configured aliases, to roles.
+// Names are case-insensitive.
+type RoleRegistry struct {
+	aliases     map[string]pb.Role
+	defaultRole pb.Role
+}
+
+// NewRoleRegistry creates a RoleRegistry with aliases, such as "viewer" for READ, and returns it.
+// defaultRole is the role of created permissions that don't specify one.
+func NewRoleRegistry(aliases map[string]pb.Role, defaultRole pb.Role) RoleRegistry {
+	lowerAliases := make(map[string]pb.Role, len(aliases))
+	for alias, role := range aliases {
+		lowerAliases[strings.ToLower(alias)] = role
+	}
+
+	return RoleRegistry{aliases: lowerAliases, defaultRole: defaultRole}
+}
+
+// ParseRoleAliases parses aliases of the form "alias=ROLE,alias=ROLE", such as
+// "viewer=READ,editor=WRITE", empty aliases are valid.
+func ParseRoleAliases(aliases string) (map[string]pb.Role, error) {
+	roleAliases := map[string]pb.Role{}
+	for _, entry := range strings.Split(aliases, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("invalid role alias %q", entry)
+		}
+
+		role, ok := pb.Role_value[strings.ToUpper(strings.TrimSpace(parts[1]))]
+		if !ok || pb.Role(role) == pb.Role_NONE {
+			return nil, fmt.Errorf("invalid role alias %q: role does not exist", entry)
+		}
+
+		roleAliases[strings.TrimSpace(parts[0])] = pb.Role(role)
+	}
+
+	return roleAliases, nil
+}
+
+// Lookup returns the role of name, which is either the name of a role or an alias,
+// and false if there's no such role.
+func (r RoleRegistry) Lookup(name string) (pb.Role, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if role, ok := r.aliases[name]; ok {
+		return role, true
+	}
+
+	role, ok := pb.Role_value[strings.ToUpper(name)]
+	if !ok || pb.Role(role) == pb.Role_NONE {
+		return pb.Role_NONE, false
+	}
+
+	return pb.Role(role), true
+}
+
+// Resolve returns the role of a request that specifies role, name, both or neither.
+// Returns an InvalidArgument error if name is not a role, or if it's not the same role as role.
+// Returns NONE if neither is specified.
+func (r RoleRegistry) Resolve(role pb.Role, name string) (pb.Role, error) {
+	if name == "" {
+		return role, nil
+	}
+
+	namedRole, ok := r.Lookup(name)
+	if !ok {
+		return pb.Role_NONE, status.Errorf(codes.InvalidArgument, "role %q does not exist", name)
+	}
+
+	if role != pb.Role_NONE && role != namedRole {
+		return pb.Role_NONE, status.Errorf(codes.InvalidArgument, "role %q does not match role %s", name, role)
+	}
+
+	return namedRole, nil
+}
+
+// OrDefault returns role, or the default role if role is NONE.
+func (r RoleRegistry) OrDefault(role pb.Role) pb.Role {
+	if role == pb.Role_NONE {
+		return r.defaultRole
+	}
+
+	return role
+}
+
+// Default returns the role of created permissions that don't specify one, NONE if they must specify one.
+func (r RoleRegistry) Default() pb.Role {
+	return r.defaultRole
+}
+
+// Aliases returns the aliases of role, sorted.
+func (r RoleRegistry) Aliases(role pb.Role) []string {
+	aliases := []string{}
+	for alias, aliasRole := range r.aliases {
+		if aliasRole == role {
+			aliases = append(aliases, alias)
+		}
+	}
+
+	sort.Strings(aliases)
+	return aliases
+}
+
+// ListRoles is the request handler for listing the roles and their display metadata.
+func (s ServiceV2) ListRoles(ctx context.Context, req *pbv2.ListRolesRequest) (*pbv2.ListRolesResponse, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	kinds := make([]string, 0, len(capabilitiesByKind))
+	for kind := range capabilitiesByKind {
+		kinds = append(kinds, kind)
+	}
+
+	sort.Strings(kinds)
+	roles := make([]*pbv2.RoleInfo, 0, len(RoleOrder))
+	for i, role := range RoleOrder {
+		key := "role." + strings.ToLower(role.String())
+		info := &pbv2.RoleInfo{
+			Role:             pbv2.Role(role),
+			Name:             role.String(),
+			Order:            int32(i),
+			Aliases:          s.roles.Aliases(role),
+			DisplayNameKey:   key + ".name",
+			DescriptionKey:   key + ".description",
+			IsDefault:        role == s.roles.Default(),
+			RequiresApproval: s.approvals[role],
+		}
+
+		for _, kind := range kinds {
+			capabilities := Capabilities(kind, role)
+			kindCapabilities := &pbv2.RoleInfo_KindCapabilities{
+				ResourceKind: kind,
+				Capabilities: make([]pbv2.Capability, 0, len(capabilities)),
+			}
+			for _, capability := range capabilities {
+				kindCapabilities.Capabilities = append(kindCapabilities.Capabilities, pbv2.Capability(capability))
+			}
+
+			info.Capabilities = append(info.Capabilities, kindCapabilities)
+		}
+
+		for _, included := range RoleOrder {
+			if included != role && IsSubRole(role, included) {
+				info.Includes = append(info.Includes, pbv2.Role(included))
+			}
+		}
+
+		roles = append(roles, info)
+	}
+
+	return &pbv2.ListRolesResponse{Roles: roles}, nil
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ScheduledUpdate is an update of the fields of the permission of UserID to FileID
+// to their values in Update, that's applied at ScheduledAt.
+type ScheduledUpdate struct {
+	ID           string
+	ResourceType string
+	FileID       string
+	UserID       string
+	Update       PermissionUpdate
+	Fields       []PermissionField
+	ScheduledAt  time.Time
+}
+
+// Scheduler periodically applies the scheduled updates of permissions that are due.
+type Scheduler struct {
+	controller Controller
+	logger     *logrus.Logger
+
+	// lease is the duration in which a claimed update should be applied, before it may be claimed again.
+	lease time.Duration
+}
+
+// NewScheduler creates a Scheduler that claims each due update for lease and returns it.
+func NewScheduler(controller Controller, logger *logrus.Logger, lease time.Duration) Scheduler {
+	return Scheduler{controller: controller, logger: logger, lease: lease}
+}
+
+// Run is running an infinite loop that applies the due updates once in interval, until ctx is done.
+// Updates that were due while the service was down are applied on the first run.
+func (s Scheduler) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		applied, err := s.controller.ApplyDueUpdates(ctx, time.Now(), s.lease)
+		if err != nil {
+			s.logger.Errorf("failed applying scheduled updates: %v", err)
+		}
+
+		if applied > 0 {
+			s.logger.Infof("applied %d scheduled updates", applied)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"time"
+
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultSelfTestFileID is the reserved file that the self-test's permission is given to,
+	// unless another is configured. No real file should ever have it.
+	DefaultSelfTestFileID = "permission-service-self-test"
+
+	// SelfTestHealthService is the service name of the grpc health checks whose status is the outcome
+	// of the self-test: SERVING once it passed, and NOT_SERVING until then, or if it failed.
+	SelfTestHealthService = "permission.SelfTest"
+
+	// selfTestName is the name of the self-test as a background worker, and its user and creator.
+	selfTestName = "self_test"
+)
+
+// selfTestPassed is whether the last self-test of the instance passed, 1 if it did and 0 if it didn't.
+var selfTestPassed = expvar.NewInt("self_test_passed")
+
+// SelfTest runs a scripted create, get, update and delete cycle of a permission to a reserved file
+// through the controller, which verifies end-to-end that the instance can serve the permissions after
+// a deploy, against its actual MongoDB and with its actual configuration.
+type SelfTest struct {
+	controller Controller
+	logger     *logrus.Logger
+	fileID     string
+	timeout    time.Duration
+}
+
+// NewSelfTest creates a SelfTest of controller that gives its permission to fileID,
+// DefaultSelfTestFileID if it's empty, and fails if the cycle doesn't complete within timeout.
+func NewSelfTest(controller Controller, logger *logrus.Logger, fileID string, timeout time.Duration) SelfTest {
+	if fileID == "" {
+		fileID = DefaultSelfTestFileID
+	}
+
+	return SelfTest{controller: controller, logger: logger, fileID: fileID, timeout: timeout}
+}
+
+// Run runs the self-test, logs and records its outcome, and returns an error naming the step that failed, if any.
+// The permission is deleted both before the cycle, in case a previous self-test was interrupted, and by it.
+func (t SelfTest) Run(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(WithWorker(ctx, selfTestName), t.timeout)
+	defer cancel()
+
+	start := time.Now()
+	logger := t.logger.WithField("fileID", t.fileID)
+	if err := t.run(ctx); err != nil {
+		selfTestPassed.Set(0)
+		logger.Errorf("self-test failed: %v", err)
+		return err
+	}
+
+	selfTestPassed.Set(1)
+	logger.WithField("duration", time.Since(start).String()).Info("self-test passed")
+	return nil
+}
+
+// run runs the steps of the cycle in order, stopping at the first that fails.
+func (t SelfTest) run(ctx context.Context) error {
+	steps := []struct {
+		name string
+		run  func(ctx context.Context) error
+	}{
+		{name: "cleanup", run: t.delete},
+		{name: "create", run: t.create},
+		{name: "get", run: t.expectRole(pb.Role_READ)},
+		{name: "update", run: t.update},
+		{name: "get updated", run: t.expectRole(pb.Role_WRITE)},
+		{name: "delete", run: t.delete},
+		{name: "get deleted", run: t.expectDeleted},
+	}
+
+	for _, step := range steps {
+		if err := step.run(ctx); err != nil {
+			return fmt.Errorf("step %q: %v", step.name, err)
+		}
+	}
+
+	return nil
+}
+
+// create gives the self-test's user a READ permission to the reserved file.
+func (t SelfTest) create(ctx context.Context) error {
+	_, err := t.controller.CreatePermission(
+		ctx,
+		DefaultResourceType,
+		t.fileID,
+		selfTestName,
+		pb.Role_READ,
+		selfTestName,
+		false,
+		false,
+		"",
+		"",
+		"",
+		"",
+		nil,
+		selfTestName,
+	)
+
+	return err
+}
+
+// update changes the role of the self-test's permission to WRITE.
+func (t SelfTest) update(ctx context.Context) error {
+	_, err := t.controller.UpdatePermission(
+		ctx,
+		DefaultResourceType,
+		t.fileID,
+		selfTestName,
+		"",
+		PermissionUpdate{Role: pb.Role_WRITE},
+		nil,
+	)
+
+	return err
+}
+
+// delete deletes the self-test's permission, if it exists.
+func (t SelfTest) delete(ctx context.Context) error {
+	_, err := t.controller.DeletePermission(ctx, DefaultResourceType, t.fileID, selfTestName, "")
+	if status.Code(err) == codes.NotFound {
+		return nil
+	}
+
+	return err
+}
+
+// expectRole returns a step that verifies that the self-test's permission has role.
+func (t SelfTest) expectRole(role pb.Role) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		permission, err := t.controller.GetByFileAndUser(ctx, DefaultResourceType, t.fileID, selfTestName)
+		if err != nil {
+			return err
+		}
+
+		if permission.GetRole() != role {
+			return fmt.Errorf("the role is %s instead of %s", permission.GetRole(), role)
+		}
+
+		return nil
+	}
+}
+
+// expectDeleted verifies that the self-test's permission doesn't exist.
+func (t SelfTest) expectDeleted(ctx context.Context) error {
+	_, err := t.controller.GetByFileAndUser(ctx, DefaultResourceType, t.fileID, selfTestName)
+	switch status.Code(err) {
+	case codes.NotFound:
+		return nil
+	case codes.OK:
+		return fmt.Errorf("the permission still exists")
+	default:
+		return err
+	}
+}
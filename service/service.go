@@ -4,15 +4,47 @@ import (
 	"context"
 	"fmt"
 	"time"
+	"unicode/utf8"
 
+	"github.com/golang/protobuf/ptypes"
 	pb "github.com/meateam/permission-service/proto"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultResourceType is the type of the resource of a permission if not specified.
+	DefaultResourceType = "file"
+
+	// MaxMessageLength is the maximum length in characters of a permission's message.
+	MaxMessageLength = 1024
+
+	// MaxLabelLength is the maximum length in characters of a permission's label.
+	MaxLabelLength = 64
+
+	// DefaultChangesPageSize is the page size of ListPermissionChanges if not specified.
+	DefaultChangesPageSize = 100
+
+	// MaxChangesPageSize is the maximum page size of ListPermissionChanges.
+	MaxChangesPageSize = 1000
 )
 
 // Service is a structure used for handling Permission Service grpc requests.
 type Service struct {
-	controller Controller
-	logger     *logrus.Logger
+	controller   Controller
+	logger       *logrus.Logger
+	rolePolicy   RolePolicy
+	roles        RoleRegistry
+	domainGrants DomainGrantPolicy
+	decisions    DecisionSink
+	actors       ActorPolicy
+	approvals    ApprovalPolicy
+	limits       RequestLimits
+	files        FileTree
+	owners       FileMetadata
+	cache        CachePolicy
+	lastKnown    LastKnownPermissions
+	preShare     PreSharePolicy
 }
 
 // HealthCheck checks the health of the service, returns true if healthy, or false otherwise.
@@ -28,9 +60,62 @@ func (s Service) HealthCheck(mongoClientPingTimeout time.Duration) bool {
 	return healthy
 }
 
+// WarmUp verifies the store's indexes and warms up the permissions of fileIDs within timeout,
+// returns true if it succeeded, or false otherwise.
+func (s Service) WarmUp(timeout time.Duration, fileIDs []string) bool {
+	timeoutCtx, cancel := context.WithTimeout(context.TODO(), timeout)
+	defer cancel()
+	if err := s.controller.WarmUp(timeoutCtx, DefaultResourceType, fileIDs); err != nil {
+		s.logger.Errorf("warm-up failed: %v", err)
+		return false
+	}
+
+	return true
+}
+
+// WithDecisionSink returns a copy of the service that logs the decisions of its permission checks to sink.
+func (s Service) WithDecisionSink(sink DecisionSink) Service {
+	s.decisions = sink
+	return s
+}
+
+// WithActorPolicy returns a copy of the service that authenticates the forwarded actors of requests with actors.
+func (s Service) WithActorPolicy(actors ActorPolicy) Service {
+	s.actors = actors
+	return s
+}
+
+// WithApprovalPolicy returns a copy of the service that refuses granting the sensitive roles of approvals,
+// which may only be granted by approved permission requests of the v2 API.
+func (s Service) WithApprovalPolicy(approvals ApprovalPolicy) Service {
+	s.approvals = approvals
+	return s
+}
+
+// WithRequestLimits returns a copy of the service that rejects requests that exceed limits.
+func (s Service) WithRequestLimits(limits RequestLimits) Service {
+	s.limits = limits
+	return s
+}
+
 // NewService creates a Service and returns it.
-func NewService(controller Controller, logger *logrus.Logger) Service {
-	return Service{controller: controller, logger: logger}
+// rolePolicy limits the roles that each calling service may grant.
+// roles resolves the role names of requests.
+// domainGrants limits the organizations that may be given permissions.
+func NewService(
+	controller Controller,
+	logger *logrus.Logger,
+	rolePolicy RolePolicy,
+	roles RoleRegistry,
+	domainGrants DomainGrantPolicy,
+) Service {
+	return Service{
+		controller:   controller,
+		logger:       logger,
+		rolePolicy:   rolePolicy,
+		roles:        roles,
+		domainGrants: domainGrants,
+	}
 }
 
 // CreatePermission is the request handler for creating a permission of a file to user.
@@ -38,11 +123,28 @@ func (s Service) CreatePermission(
 	ctx context.Context,
 	req *pb.CreatePermissionRequest,
 ) (*pb.PermissionObject, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.actors.RequireActor(ctx); err != nil {
+		return nil, err
+	}
+
+	resourceType := resourceTypeOrDefault(req.GetResourceType())
 	fileID := req.GetFileID()
 	userID := req.GetUserID()
 	role := req.GetRole()
 	creator := req.GetCreator()
 	override := req.GetOverride()
+	message := req.GetMessage()
+	label := req.GetLabel()
+
+	// Users may reshare by default, unless explicitly stated otherwise.
+	canReshare := true
+	if req.GetCanReshare() != nil {
+		canReshare = req.GetCanReshare().GetValue()
+	}
 
 	if userID == "" {
 		return nil, fmt.Errorf("userID is required")
@@ -56,11 +158,89 @@ func (s Service) CreatePermission(
 		return nil, fmt.Errorf("role does not exist")
 	}
 
+	role, err := s.roles.Resolve(role, req.GetRoleName())
+	if err != nil {
+		return nil, err
+	}
+
+	role = s.roles.OrDefault(role)
 	if creator == "" {
 		return nil, fmt.Errorf("creator is required")
 	}
 
-	permission, err := s.controller.CreatePermission(ctx, fileID, userID, role, creator, override)
+	if err := authorizeCreator(ctx, creator); err != nil {
+		return nil, err
+	}
+
+	if utf8.RuneCountInString(message) > MaxMessageLength {
+		return nil, fmt.Errorf("message exceeds %d characters", MaxMessageLength)
+	}
+
+	if utf8.RuneCountInString(label) > MaxLabelLength {
+		return nil, fmt.Errorf("label exceeds %d characters", MaxLabelLength)
+	}
+
+	if err := ValidateLabels(req.GetLabels()); err != nil {
+		return nil, err
+	}
+
+	source, err := sourceOrDefault(req.GetSource(), SourceAPI)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceKind, ok := resourceKindOrDefault(req.GetResourceKind())
+	if !ok {
+		return nil, fmt.Errorf("resourceKind does not exist")
+	}
+
+	granteeType, ok := granteeTypeOrDefault(req.GetGranteeType())
+	if !ok {
+		return nil, fmt.Errorf("granteeType does not exist")
+	}
+
+	if granteeType == GranteeTypeDomain {
+		if err := s.domainGrants.Authorize(userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.rolePolicy.Authorize(CallerFromContext(ctx), role); err != nil {
+		return nil, err
+	}
+
+	if err := s.approvals.Authorize(role); err != nil {
+		return nil, err
+	}
+
+	err = s.preShare.Authorize(ctx, ShareCheck{
+		ResourceType: resourceType,
+		FileID:       fileID,
+		UserID:       userID,
+		GranteeType:  granteeType,
+		Role:         role,
+		Creator:      creator,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	permission, err := s.controller.CreatePermission(
+		ctx,
+		resourceType,
+		fileID,
+		userID,
+		role,
+		creator,
+		override,
+		canReshare,
+		message,
+		label,
+		resourceKind,
+		granteeType,
+		req.GetLabels(),
+		source,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -78,25 +258,67 @@ func (s Service) GetFilePermissions(
 	ctx context.Context,
 	req *pb.GetFilePermissionsRequest,
 ) (*pb.GetFilePermissionsResponse, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	resourceType := resourceTypeOrDefault(req.GetResourceType())
 	fileID := req.GetFileID()
+	order := req.GetOrder()
 	if fileID == "" {
 		return nil, fmt.Errorf("fileID is required")
 	}
 
-	filePermissions, err := s.controller.GetFilePermissions(ctx, fileID)
+	if pb.PermissionsOrder_name[int32(order)] == "" {
+		return nil, fmt.Errorf("order does not exist")
+	}
+
+	pageSize, err := pageSizeOrAll(req.GetPageSize(), req.GetPageToken())
 	if err != nil {
 		return nil, err
 	}
 
-	return &pb.GetFilePermissionsResponse{Permissions: filePermissions}, nil
+	selector, err := parseSelector(req.GetLabelSelector(), req.GetSource())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.actors.AuthorizePermissionRead(ctx, s.controller, resourceType, fileID); err != nil {
+		return nil, err
+	}
+
+	filePermissions, nextPageToken, err := s.controller.GetFilePermissions(
+		ctx,
+		resourceType,
+		fileID,
+		order,
+		pageSize,
+		req.GetPageToken(),
+		selector,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetFilePermissionsResponse{Permissions: filePermissions, NextPageToken: nextPageToken}, nil
 }
 
 // DeletePermission is the request handler for deleting permission by its ID.
 func (s Service) DeletePermission(
 	ctx context.Context, req *pb.DeletePermissionRequest,
 ) (*pb.PermissionObject, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.actors.RequireActor(ctx); err != nil {
+		return nil, err
+	}
+
+	resourceType := resourceTypeOrDefault(req.GetResourceType())
 	fileID := req.GetFileID()
 	userID := req.GetUserID()
+	etag := req.GetEtag()
 
 	if userID == "" {
 		return nil, fmt.Errorf("userID is required")
@@ -106,7 +328,7 @@ func (s Service) DeletePermission(
 		return nil, fmt.Errorf("fileID is required")
 	}
 
-	permission, err := s.controller.DeletePermission(ctx, fileID, userID)
+	permission, err := s.controller.DeletePermission(ctx, resourceType, fileID, userID, etag)
 	if err != nil {
 		return nil, err
 	}
@@ -121,6 +343,11 @@ func (s Service) DeletePermission(
 
 // GetPermission is the request handler for retrieving a permission by a user and file ids.
 func (s Service) GetPermission(ctx context.Context, req *pb.GetPermissionRequest) (*pb.PermissionObject, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	resourceType := resourceTypeOrDefault(req.GetResourceType())
 	fileID := req.GetFileID()
 	userID := req.GetUserID()
 	if userID == "" {
@@ -131,7 +358,14 @@ func (s Service) GetPermission(ctx context.Context, req *pb.GetPermissionRequest
 		return nil, fmt.Errorf("FileID is required")
 	}
 
-	permission, err := s.controller.GetByFileAndUser(ctx, fileID, userID)
+	start := time.Now()
+	permission, stale, err := s.lastKnown.getByFileAndUser(ctx, s.controller, resourceType, fileID, userID)
+	decision := Decision{ResourceType: resourceType, FileID: fileID, UserID: userID}
+	recordDecision(ctx, s.decisions, s.logger, "GetPermission", decision, start, true, err)
+	if !stale {
+		setCacheHeader(ctx, s.cache.checkMaxAge(resourceType, permission, err))
+	}
+
 	if err != nil {
 		return nil, err
 	}
@@ -146,45 +380,192 @@ func (s Service) GetPermission(ctx context.Context, req *pb.GetPermissionRequest
 
 // IsPermitted is the request handler for checking user permission by userID and fileID.
 func (s Service) IsPermitted(ctx context.Context, req *pb.IsPermittedRequest) (*pb.IsPermittedResponse, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	res, maxAge, err := s.isPermitted(ctx, "IsPermitted", req)
+	if res != nil {
+		setCacheHeader(ctx, maxAge)
+	}
+
+	return res, err
+}
+
+// CheckPermissionsMatrix is the request handler for checking a batch of user permissions by userID and fileID.
+// The checks are made one by one, and a check that fails is reported in its result and doesn't fail the batch.
+func (s Service) CheckPermissionsMatrix(
+	ctx context.Context,
+	req *pb.CheckPermissionsMatrixRequest) (*pb.CheckPermissionsMatrixResponse, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.limits.CheckList("checks", len(req.GetChecks())); err != nil {
+		return nil, err
+	}
+
+	response := &pb.CheckPermissionsMatrixResponse{
+		Results: make([]*pb.CheckPermissionsMatrixResponse_Result, 0, len(req.GetChecks())),
+	}
+	for _, check := range req.GetChecks() {
+		res, maxAge, err := s.isPermitted(ctx, "CheckPermissionsMatrix", check)
+		result := &pb.CheckPermissionsMatrixResponse_Result{
+			Permitted: res.GetPermitted(),
+			MaxAge:    ptypes.DurationProto(maxAge),
+			Stale:     res.GetStale(),
+		}
+		if err != nil {
+			errStatus := status.Convert(err)
+			result.Code, result.Message = int32(errStatus.Code()), errStatus.Message()
+		}
+
+		response.Results = append(response.Results, result)
+	}
+
+	return response, nil
+}
+
+// isPermitted checks whether the user of req is permitted to its file, the decision is recorded as of method.
+// It returns the response of the check and how long it may be cached, the response is nil if req is invalid.
+func (s Service) isPermitted(
+	ctx context.Context,
+	method string,
+	req *pb.IsPermittedRequest) (*pb.IsPermittedResponse, time.Duration, error) {
+	resourceType := resourceTypeOrDefault(req.GetResourceType())
 	fileID := req.GetFileID()
 	userID := req.GetUserID()
 	role := req.GetRole()
 	if userID == "" {
-		return nil, fmt.Errorf("UserID is required")
+		return nil, 0, fmt.Errorf("UserID is required")
 	}
 
 	if fileID == "" {
-		return nil, fmt.Errorf("FileID is required")
+		return nil, 0, fmt.Errorf("FileID is required")
 	}
 
 	if pb.Role_name[int32(role)] == "" {
-		return nil, fmt.Errorf("role does not exist")
+		return nil, 0, fmt.Errorf("role does not exist")
+	}
+
+	capability := req.GetCapability()
+	if pb.Capability_name[int32(capability)] == "" {
+		return nil, 0, fmt.Errorf("capability does not exist")
+	}
+
+	role, err := s.roles.Resolve(role, req.GetRoleName())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	start := time.Now()
+	decision := Decision{ResourceType: resourceType, FileID: fileID, UserID: userID, Role: role.String()}
+	if capability != pb.Capability_NO_CAPABILITY {
+		decision.Role, decision.Capability = "", capability.String()
+	}
+
+	// The stale checks aren't cached, so that they're checked again once the store is available.
+	permission, stale, err := s.lastKnown.getByFileAndUser(ctx, s.controller, resourceType, fileID, userID)
+	var maxAge time.Duration
+	if !stale {
+		maxAge = s.cache.checkMaxAge(resourceType, permission, err)
 	}
 
-	permission, err := s.controller.GetByFileAndUser(ctx, fileID, userID)
 	if err != nil {
-		return &pb.IsPermittedResponse{Permitted: false}, err
+		recordDecision(ctx, s.decisions, s.logger, method, decision, start, false, err)
+		res := &pb.IsPermittedResponse{Permitted: false, MaxAge: ptypes.DurationProto(maxAge), Stale: stale}
+		return res, maxAge, err
 	}
 
-	isPermitted := isSubRole(permission.GetRole(), role)
-	return &pb.IsPermittedResponse{Permitted: isPermitted}, nil
+	isPermitted := IsSubRole(permission.GetRole(), role)
+	if capability != pb.Capability_NO_CAPABILITY {
+		isPermitted = HasCapability(permission.GetResourceKind(), permission.GetRole(), capability)
+	}
+
+	recordDecision(ctx, s.decisions, s.logger, method, decision, start, isPermitted, nil)
+	res := &pb.IsPermittedResponse{Permitted: isPermitted, MaxAge: ptypes.DurationProto(maxAge), Stale: stale}
+	return res, maxAge, nil
 }
 
 // GetUserPermissions is the request handler for fetching the permissions that a user has.
 func (s Service) GetUserPermissions(
 	ctx context.Context,
 	req *pb.GetUserPermissionsRequest) (*pb.GetUserPermissionsResponse, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	resourceType := resourceTypeOrDefault(req.GetResourceType())
 	userID := req.GetUserID()
+	order := req.GetOrder()
 	if userID == "" {
 		return nil, fmt.Errorf("userID is required")
 	}
 
-	permissions, err := s.controller.GetUserPermissions(ctx, userID)
+	if pb.PermissionsOrder_name[int32(order)] == "" {
+		return nil, fmt.Errorf("order does not exist")
+	}
+
+	pageSize, err := pageSizeOrAll(req.GetPageSize(), req.GetPageToken())
 	if err != nil {
 		return nil, err
 	}
 
-	return &pb.GetUserPermissionsResponse{Permissions: permissions}, nil
+	selector, err := parseSelector(req.GetLabelSelector(), req.GetSource())
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, nextPageToken, err := s.controller.GetUserPermissions(
+		ctx,
+		resourceType,
+		userID,
+		order,
+		pageSize,
+		req.GetPageToken(),
+		selector,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetUserPermissionsResponse{Permissions: permissions, NextPageToken: nextPageToken}, nil
+}
+
+// CopyPermissions is the request handler for copying the permissions of a file to another file.
+func (s Service) CopyPermissions(
+	ctx context.Context,
+	req *pb.CopyPermissionsRequest,
+) (*pb.CopyPermissionsResponse, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.actors.RequireActor(ctx); err != nil {
+		return nil, err
+	}
+
+	resourceType := resourceTypeOrDefault(req.GetResourceType())
+	sourceFileID := req.GetSourceFileID()
+	destFileID := req.GetDestFileID()
+	if sourceFileID == "" {
+		return nil, fmt.Errorf("sourceFileID is required")
+	}
+
+	if destFileID == "" {
+		return nil, fmt.Errorf("destFileID is required")
+	}
+
+	if sourceFileID == destFileID {
+		return nil, fmt.Errorf("sourceFileID and destFileID must be different files")
+	}
+
+	report, err := s.controller.CopyPermissions(ctx, resourceType, sourceFileID, destFileID, req.GetOverwrite())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.CopyPermissionsResponse{Copied: report.Copied, Skipped: report.Skipped}, nil
 }
 
 // DeleteFilePermissions is the request handler for deleting all permissions that exist for a certain file.
@@ -192,12 +573,26 @@ func (s Service) DeleteFilePermissions(
 	ctx context.Context,
 	req *pb.DeleteFilePermissionsRequest,
 ) (*pb.DeleteFilePermissionsResponse, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.actors.RequireActor(ctx); err != nil {
+		return nil, err
+	}
+
+	resourceType := resourceTypeOrDefault(req.GetResourceType())
 	fileID := req.GetFileID()
 	if fileID == "" {
 		return nil, fmt.Errorf("fileID is required")
 	}
 
-	permissions, err := s.controller.DeleteFilePermissions(ctx, fileID)
+	selector, err := parseSelector(req.GetLabelSelector(), req.GetSource())
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := s.controller.DeleteFilePermissions(ctx, resourceType, fileID, selector)
 	if err != nil {
 		return nil, err
 	}
@@ -205,7 +600,259 @@ func (s Service) DeleteFilePermissions(
 	return &pb.DeleteFilePermissionsResponse{Permissions: permissions}, nil
 }
 
-func isSubRole(role pb.Role, wanted pb.Role) bool {
+// TouchPermission is the request handler for updating the last time a user accessed a file.
+func (s Service) TouchPermission(
+	ctx context.Context,
+	req *pb.TouchPermissionRequest,
+) (*pb.PermissionObject, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.actors.RequireActor(ctx); err != nil {
+		return nil, err
+	}
+
+	resourceType := resourceTypeOrDefault(req.GetResourceType())
+	fileID := req.GetFileID()
+	userID := req.GetUserID()
+	if userID == "" {
+		return nil, fmt.Errorf("userID is required")
+	}
+
+	if fileID == "" {
+		return nil, fmt.Errorf("fileID is required")
+	}
+
+	permission, err := s.controller.TouchPermission(ctx, resourceType, fileID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response pb.PermissionObject
+	if err = permission.MarshalProto(&response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// RevokeCascade is the request handler for revoking the permission of a user to a file,
+// along with the permissions to the file that the user reshared.
+func (s Service) RevokeCascade(
+	ctx context.Context,
+	req *pb.RevokeCascadeRequest,
+) (*pb.RevokeCascadeResponse, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.actors.RequireActor(ctx); err != nil {
+		return nil, err
+	}
+
+	resourceType := resourceTypeOrDefault(req.GetResourceType())
+	fileID := req.GetFileID()
+	userID := req.GetUserID()
+	if fileID == "" {
+		return nil, fmt.Errorf("fileID is required")
+	}
+
+	if userID == "" {
+		return nil, fmt.Errorf("userID is required")
+	}
+
+	permissions, err := s.controller.RevokeCascade(ctx, resourceType, fileID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RevokeCascadeResponse{Permissions: permissions}, nil
+}
+
+// GetSharedFiles is the request handler for fetching the files that two users have a permission to.
+func (s Service) GetSharedFiles(
+	ctx context.Context,
+	req *pb.GetSharedFilesRequest,
+) (*pb.GetSharedFilesResponse, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	resourceType := resourceTypeOrDefault(req.GetResourceType())
+	userA := req.GetUserA()
+	userB := req.GetUserB()
+	if userA == "" {
+		return nil, fmt.Errorf("userA is required")
+	}
+
+	if userB == "" {
+		return nil, fmt.Errorf("userB is required")
+	}
+
+	if userA == userB {
+		return nil, fmt.Errorf("userA and userB must be different")
+	}
+
+	files, err := s.controller.GetSharedFiles(ctx, resourceType, userA, userB, req.GetGrantedByA())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetSharedFilesResponse{Files: files}, nil
+}
+
+// ListPermissionChanges is the request handler for listing the changes to the permissions of a user.
+func (s Service) ListPermissionChanges(
+	ctx context.Context,
+	req *pb.ListPermissionChangesRequest,
+) (*pb.ListPermissionChangesResponse, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	resourceType := resourceTypeOrDefault(req.GetResourceType())
+	userID := req.GetUserID()
+	if userID == "" {
+		return nil, fmt.Errorf("userID is required")
+	}
+
+	pageSize := int(req.GetPageSize())
+	if pageSize < 0 {
+		return nil, fmt.Errorf("pageSize must not be negative")
+	}
+
+	if pageSize == 0 {
+		pageSize = DefaultChangesPageSize
+	}
+
+	if pageSize > MaxChangesPageSize {
+		pageSize = MaxChangesPageSize
+	}
+
+	changes, err := s.controller.ListPermissionChanges(ctx, resourceType, userID, req.GetSyncToken(), pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.ListPermissionChangesResponse{
+		Changes:   make([]*pb.ListPermissionChangesResponse_PermissionChange, 0, len(changes.Events)),
+		SyncToken: changes.SyncToken,
+		HasMore:   changes.HasMore,
+	}
+	for _, event := range changes.Events {
+		occurredAt, err := TimestampProto(event.OccurredAt)
+		if err != nil {
+			return nil, err
+		}
+
+		response.Changes = append(response.Changes, &pb.ListPermissionChangesResponse_PermissionChange{
+			Id:         event.ID,
+			Type:       changeTypeByEventType[event.Type],
+			Permission: event.Permission,
+			OccurredAt: occurredAt,
+		})
+	}
+
+	return response, nil
+}
+
+// ListSharedWithMe is the request handler for listing the files that other users shared with a user.
+func (s Service) ListSharedWithMe(
+	ctx context.Context,
+	req *pb.ListSharedWithMeRequest,
+) (*pb.ListSharedWithMeResponse, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	resourceType := resourceTypeOrDefault(req.GetResourceType())
+	userID := req.GetUserID()
+	if userID == "" {
+		return nil, fmt.Errorf("userID is required")
+	}
+
+	pageSize := int(req.GetPageSize())
+	if pageSize < 0 {
+		return nil, fmt.Errorf("pageSize must not be negative")
+	}
+
+	if pageSize == 0 {
+		pageSize = DefaultPageSize
+	}
+
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	files, nextPageToken, err := s.controller.ListSharedWithMe(
+		ctx,
+		resourceType,
+		userID,
+		pageSize,
+		req.GetPageToken(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.ListSharedWithMeResponse{
+		Files:         make([]*pb.ListSharedWithMeResponse_SharedFile, 0, len(files)),
+		NextPageToken: nextPageToken,
+	}
+	for _, file := range files {
+		sharedAt, err := TimestampProto(file.SharedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		response.Files = append(response.Files, &pb.ListSharedWithMeResponse_SharedFile{
+			FileID:   file.FileID,
+			Role:     file.Role,
+			SharedBy: file.SharedBy,
+			SharedAt: sharedAt,
+		})
+	}
+
+	return response, nil
+}
+
+// changeTypeByEventType maps the event types to their change types in the sync API.
+var changeTypeByEventType = map[EventType]pb.ChangeType{
+	EventCreated: pb.ChangeType_CREATED,
+	EventUpdated: pb.ChangeType_UPDATED,
+	EventDeleted: pb.ChangeType_DELETED,
+}
+
+// pageSizeOrAll returns pageSize limited to MaxPageSize, or DefaultPageSize if it's empty and pageToken
+// is set. It returns 0 if both are empty, which lists all of the permissions in a single page.
+func pageSizeOrAll(pageSize int32, pageToken string) (int, error) {
+	if pageSize < 0 {
+		return 0, fmt.Errorf("pageSize must not be negative")
+	}
+
+	if pageSize == 0 && pageToken != "" {
+		return DefaultPageSize, nil
+	}
+
+	if pageSize > MaxPageSize {
+		return MaxPageSize, nil
+	}
+
+	return int(pageSize), nil
+}
+
+// resourceTypeOrDefault returns resourceType, or DefaultResourceType if resourceType is empty.
+func resourceTypeOrDefault(resourceType string) string {
+	if resourceType == "" {
+		return DefaultResourceType
+	}
+
+	return resourceType
+}
+
+// IsSubRole returns whether a permission of role grants wanted, such as WRITE granting READ.
+func IsSubRole(role pb.Role, wanted pb.Role) bool {
 	if wanted == pb.Role_NONE {
 		return false
 	}
@@ -214,9 +861,19 @@ func isSubRole(role pb.Role, wanted pb.Role) bool {
 	case pb.Role_NONE:
 		return false
 	case pb.Role_WRITE:
-		return wanted == pb.Role_WRITE || wanted == pb.Role_READ
+		return wanted == pb.Role_WRITE ||
+			wanted == pb.Role_COMMENTER ||
+			wanted == pb.Role_READ ||
+			wanted == pb.Role_UPLOADER ||
+			wanted == pb.Role_AUDITOR
+	case pb.Role_COMMENTER:
+		return wanted == pb.Role_COMMENTER || wanted == pb.Role_READ
 	case pb.Role_READ:
 		return wanted == pb.Role_READ
+	case pb.Role_UPLOADER:
+		return wanted == pb.Role_UPLOADER
+	case pb.Role_AUDITOR:
+		return wanted == pb.Role_AUDITOR
 	default:
 		return false
 	}
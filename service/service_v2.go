@@ -0,0 +1,903 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	pb "github.com/meateam/permission-service/proto"
+	pbv2 "github.com/meateam/permission-service/proto/v2"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultPageSize is the page size of the list RPCs if not specified.
+	DefaultPageSize = 50
+
+	// MaxPageSize is the maximum page size of the list RPCs.
+	MaxPageSize = 1000
+
+	permissionsCollection = "permissions"
+
+	jobsCollection = "jobs"
+)
+
+// updatableFieldsV2 maps the update mask paths of a v2 permission to the fields they update.
+var updatableFieldsV2 = map[string]PermissionField{
+	"role":        RoleField,
+	"can_reshare": CanReshareField,
+	"message":     MessageField,
+	"label":       LabelField,
+	"labels":      LabelsField,
+}
+
+// readableFieldsV2 maps the read mask paths of a v2 permission to the fields they read,
+// the name, user_id, resource_kind and grantee_type paths are always read.
+var readableFieldsV2 = map[string]PermissionField{
+	"name":             UserIDField,
+	"user_id":          UserIDField,
+	"role":             RoleField,
+	"creator":          CreatorField,
+	"can_reshare":      CanReshareField,
+	"message":          MessageField,
+	"label":            LabelField,
+	"last_accessed_at": LastAccessedAtField,
+	"etag":             ETagField,
+	"sharing_chain":    SharingChainField,
+	"resource_kind":    UserIDField,
+	"capabilities":     RoleField,
+	"grantee_type":     UserIDField,
+	"labels":           LabelsField,
+	"source":           SourceField,
+	"inherited_from":   InheritedFromField,
+}
+
+// ServiceV2 is a structure used for handling the v2 Permission Service grpc requests,
+// it shares its controller with Service.
+type ServiceV2 struct {
+	controller   Controller
+	logger       *logrus.Logger
+	rolePolicy   RolePolicy
+	roles        RoleRegistry
+	domainGrants DomainGrantPolicy
+	decisions    DecisionSink
+	actors       ActorPolicy
+	approvals    ApprovalPolicy
+	limits       RequestLimits
+	cache        CachePolicy
+	lastKnown    LastKnownPermissions
+	preShare     PreSharePolicy
+}
+
+// WithDecisionSink returns a copy of the service that logs the decisions of its permission checks to sink.
+func (s ServiceV2) WithDecisionSink(sink DecisionSink) ServiceV2 {
+	s.decisions = sink
+	return s
+}
+
+// WithActorPolicy returns a copy of the service that authenticates the forwarded actors of requests with actors.
+func (s ServiceV2) WithActorPolicy(actors ActorPolicy) ServiceV2 {
+	s.actors = actors
+	return s
+}
+
+// WithApprovalPolicy returns a copy of the service that only grants the sensitive roles of approvals
+// by approved permission requests.
+func (s ServiceV2) WithApprovalPolicy(approvals ApprovalPolicy) ServiceV2 {
+	s.approvals = approvals
+	return s
+}
+
+// WithRequestLimits returns a copy of the service that rejects requests that exceed limits.
+func (s ServiceV2) WithRequestLimits(limits RequestLimits) ServiceV2 {
+	s.limits = limits
+	return s
+}
+
+// NewServiceV2 creates a ServiceV2 and returns it.
+// rolePolicy limits the roles that each calling service may grant.
+// roles resolves the role names of requests.
+// domainGrants limits the organizations that may be given permissions.
+func NewServiceV2(
+	controller Controller,
+	logger *logrus.Logger,
+	rolePolicy RolePolicy,
+	roles RoleRegistry,
+	domainGrants DomainGrantPolicy,
+) ServiceV2 {
+	return ServiceV2{
+		controller:   controller,
+		logger:       logger,
+		rolePolicy:   rolePolicy,
+		roles:        roles,
+		domainGrants: domainGrants,
+	}
+}
+
+// ListPermissions is the request handler for listing the permissions of a file.
+func (s ServiceV2) ListPermissions(
+	ctx context.Context,
+	req *pbv2.ListPermissionsRequest,
+) (*pbv2.ListPermissionsResponse, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	resourceType, fileID, err := parseResourceName(req.GetParent())
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := int(req.GetPageSize())
+	if pageSize < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page_size must not be negative")
+	}
+
+	if pageSize == 0 {
+		pageSize = DefaultPageSize
+	}
+
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	fields, err := parseReadMask(req.GetReadMask())
+	if err != nil {
+		return nil, err
+	}
+
+	selector, err := parseSelector(req.GetLabelSelector(), req.GetSource())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := s.actors.AuthorizePermissionRead(ctx, s.controller, resourceType, fileID); err != nil {
+		return nil, err
+	}
+
+	permissions, nextPageToken, err := s.controller.ListFilePermissions(
+		ctx,
+		resourceType,
+		fileID,
+		pageSize,
+		req.GetPageToken(),
+		fields,
+		selector,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pbv2.ListPermissionsResponse{
+		Permissions:   make([]*pbv2.Permission, 0, len(permissions)),
+		NextPageToken: nextPageToken,
+	}
+	for _, permission := range permissions {
+		permissionV2, err := marshalPermissionV2(permission)
+		if err != nil {
+			return nil, err
+		}
+
+		applyReadMask(permissionV2, req.GetReadMask())
+		response.Permissions = append(response.Permissions, permissionV2)
+	}
+
+	return response, nil
+}
+
+// GetPermission is the request handler for retrieving a permission by its name.
+func (s ServiceV2) GetPermission(ctx context.Context, req *pbv2.GetPermissionRequest) (*pbv2.Permission, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	resourceType, fileID, userID, err := parsePermissionName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := parseReadMask(req.GetReadMask())
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	permission, stale, err := s.lastKnown.getByFileAndUser(
+		ctx,
+		s.controller,
+		resourceType,
+		fileID,
+		userID,
+		fields...,
+	)
+	decision := Decision{ResourceType: resourceType, FileID: fileID, UserID: userID}
+	recordDecision(ctx, s.decisions, s.logger, "v2.GetPermission", decision, start, true, err)
+	if !stale {
+		setCacheHeader(ctx, s.cache.checkMaxAge(resourceType, permission, err))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	permissionV2, err := marshalPermissionV2(permission)
+	if err != nil {
+		return nil, err
+	}
+
+	applyReadMask(permissionV2, req.GetReadMask())
+	return permissionV2, nil
+}
+
+// CreatePermission is the request handler for creating a permission of a file to a user.
+func (s ServiceV2) CreatePermission(
+	ctx context.Context,
+	req *pbv2.CreatePermissionRequest,
+) (*pbv2.Permission, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.actors.RequireActor(ctx); err != nil {
+		return nil, err
+	}
+
+	request, err := s.parseNewPermission(ctx, req.GetParent(), req.GetPermission())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.approvals.Authorize(request.Update.Role); err != nil {
+		return nil, err
+	}
+
+	resourceType, fileID, userID := request.ResourceType, request.FileID, request.UserID
+	_, err = s.controller.GetByFileAndUser(ctx, resourceType, fileID, userID)
+	if err == nil {
+		name := permissionName(resourceType, fileID, userID)
+		return nil, status.Errorf(codes.AlreadyExists, "permission %s already exists", name)
+	}
+
+	if status.Code(err) != codes.NotFound {
+		return nil, err
+	}
+
+	err = s.preShare.Authorize(ctx, ShareCheck{
+		ResourceType: resourceType,
+		FileID:       fileID,
+		UserID:       userID,
+		GranteeType:  request.Update.GranteeType,
+		Role:         request.Update.Role,
+		Creator:      request.Creator,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	createdPermission, err := s.controller.CreatePermission(
+		ctx,
+		resourceType,
+		fileID,
+		userID,
+		request.Update.Role,
+		request.Creator,
+		false,
+		request.Update.CanReshare,
+		request.Update.Message,
+		request.Update.Label,
+		request.Update.ResourceKind,
+		request.Update.GranteeType,
+		request.Update.Labels,
+		request.Update.Source,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalPermissionV2(createdPermission)
+}
+
+// parseNewPermission validates permission, which is created, or requested, in the resource named parent,
+// and returns it as a request of the permission with its role resolved.
+func (s ServiceV2) parseNewPermission(
+	ctx context.Context,
+	parent string,
+	permission *pbv2.Permission,
+) (PermissionRequest, error) {
+	resourceType, fileID, err := parseResourceName(parent)
+	if err != nil {
+		return PermissionRequest{}, err
+	}
+
+	if permission == nil {
+		return PermissionRequest{}, status.Error(codes.InvalidArgument, "permission is required")
+	}
+
+	userID := permission.GetUserId()
+	if userID == "" {
+		return PermissionRequest{}, status.Error(codes.InvalidArgument, "permission.user_id is required")
+	}
+
+	role, err := s.roles.Resolve(pb.Role(permission.GetRole()), permission.GetRoleName())
+	if err != nil {
+		return PermissionRequest{}, err
+	}
+
+	permission.Role = pbv2.Role(s.roles.OrDefault(role))
+	if err := validatePermissionV2(permission); err != nil {
+		return PermissionRequest{}, err
+	}
+
+	if permission.GetCreator() == "" {
+		return PermissionRequest{}, status.Error(codes.InvalidArgument, "permission.creator is required")
+	}
+
+	if err := authorizeCreator(ctx, permission.GetCreator()); err != nil {
+		return PermissionRequest{}, err
+	}
+
+	resourceKind, ok := resourceKindOrDefault(permission.GetResourceKind())
+	if !ok {
+		return PermissionRequest{}, status.Error(codes.InvalidArgument, "permission.resource_kind does not exist")
+	}
+
+	granteeType, ok := granteeTypeOrDefault(permission.GetGranteeType())
+	if !ok {
+		return PermissionRequest{}, status.Error(codes.InvalidArgument, "permission.grantee_type does not exist")
+	}
+
+	if granteeType == GranteeTypeDomain {
+		if err := s.domainGrants.Authorize(userID); err != nil {
+			return PermissionRequest{}, err
+		}
+	}
+
+	source, err := sourceOrDefault(permission.GetSource(), SourceAPI)
+	if err != nil {
+		return PermissionRequest{}, status.Errorf(codes.InvalidArgument, "permission.%v", err)
+	}
+
+	if err := s.rolePolicy.Authorize(CallerFromContext(ctx), pb.Role(permission.GetRole())); err != nil {
+		return PermissionRequest{}, err
+	}
+
+	return PermissionRequest{
+		ResourceType: resourceType,
+		FileID:       fileID,
+		UserID:       userID,
+		Creator:      permission.GetCreator(),
+		Update: PermissionUpdate{
+			Role:         pb.Role(permission.GetRole()),
+			CanReshare:   permission.GetCanReshare(),
+			Message:      permission.GetMessage(),
+			Label:        permission.GetLabel(),
+			Labels:       permission.GetLabels(),
+			ResourceKind: resourceKind,
+			GranteeType:  granteeType,
+			Source:       source,
+		},
+	}, nil
+}
+
+// RequestPermission is the request handler for requesting a permission whose role requires approval.
+func (s ServiceV2) RequestPermission(
+	ctx context.Context,
+	req *pbv2.RequestPermissionRequest,
+) (*pbv2.PermissionRequest, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.actors.RequireActor(ctx); err != nil {
+		return nil, err
+	}
+
+	request, err := s.parseNewPermission(ctx, req.GetParent(), req.GetPermission())
+	if err != nil {
+		return nil, err
+	}
+
+	createdRequest, err := s.controller.RequestPermission(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalPermissionRequest(createdRequest)
+}
+
+// ApprovePermissionRequest is the request handler for approving a permission request by the request's actor.
+func (s ServiceV2) ApprovePermissionRequest(
+	ctx context.Context,
+	req *pbv2.ApprovePermissionRequestRequest,
+) (*pbv2.PermissionRequest, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.actors.RequireActor(ctx); err != nil {
+		return nil, err
+	}
+
+	resourceType, fileID, id, err := parsePermissionRequestName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	approver := ActorFromContext(ctx)
+	if approver == "" {
+		return nil, status.Errorf(codes.Unauthenticated, "%s is required to approve a permission request", ActorHeader)
+	}
+
+	request, err := s.controller.ApprovePermissionRequest(ctx, resourceType, fileID, id, approver)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"request":   req.GetName(),
+		"requester": request.Creator,
+		"approver":  request.Approver,
+		"role":      request.Update.Role.String(),
+	}).Info("permission request approved")
+
+	return marshalPermissionRequest(request)
+}
+
+// UpdatePermission is the request handler for updating the fields of a permission
+// that are listed in the update mask.
+func (s ServiceV2) UpdatePermission(
+	ctx context.Context,
+	req *pbv2.UpdatePermissionRequest,
+) (*pbv2.Permission, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.actors.RequireActor(ctx); err != nil {
+		return nil, err
+	}
+
+	permission := req.GetPermission()
+	if permission == nil {
+		return nil, status.Error(codes.InvalidArgument, "permission is required")
+	}
+
+	resourceType, fileID, userID, err := parsePermissionName(permission.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := parseUpdateMask(req.GetUpdateMask())
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := s.roles.Resolve(pb.Role(permission.GetRole()), permission.GetRoleName())
+	if err != nil {
+		return nil, err
+	}
+
+	permission.Role = pbv2.Role(role)
+	if err := validatePermissionV2(permission); err != nil {
+		return nil, err
+	}
+
+	for _, field := range fields {
+		if field != RoleField {
+			continue
+		}
+
+		if err := s.rolePolicy.Authorize(CallerFromContext(ctx), pb.Role(permission.GetRole())); err != nil {
+			return nil, err
+		}
+
+		if err := s.approvals.Authorize(pb.Role(permission.GetRole())); err != nil {
+			return nil, err
+		}
+	}
+
+	update := PermissionUpdate{
+		Role:       pb.Role(permission.GetRole()),
+		CanReshare: permission.GetCanReshare(),
+		Message:    permission.GetMessage(),
+		Label:      permission.GetLabel(),
+		Labels:     permission.GetLabels(),
+	}
+
+	if req.GetScheduleAt() != nil {
+		scheduleAt, err := ptypes.Timestamp(req.GetScheduleAt())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid schedule_at: %v", err)
+		}
+
+		if scheduleAt.After(time.Now()) {
+			currentPermission, err := s.controller.ScheduleUpdate(
+				ctx,
+				resourceType,
+				fileID,
+				userID,
+				permission.GetEtag(),
+				update,
+				fields,
+				scheduleAt,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			return marshalPermissionV2(currentPermission)
+		}
+	}
+
+	updatedPermission, err := s.controller.UpdatePermission(
+		ctx,
+		resourceType,
+		fileID,
+		userID,
+		permission.GetEtag(),
+		update,
+		fields,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalPermissionV2(updatedPermission)
+}
+
+// UpdatePermissions is the request handler for changing the roles of a batch of permissions.
+// Each update succeeds or fails on its own, and its result is returned in the order of the updates.
+func (s ServiceV2) UpdatePermissions(
+	ctx context.Context,
+	req *pbv2.UpdatePermissionsRequest,
+) (*pbv2.UpdatePermissionsResponse, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.actors.RequireActor(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := s.limits.CheckList("updates", len(req.GetUpdates())); err != nil {
+		return nil, err
+	}
+
+	results := make([]RoleUpdateResult, len(req.GetUpdates()))
+	updates := make([]RoleUpdate, 0, len(req.GetUpdates()))
+	indexes := make([]int, 0, len(req.GetUpdates()))
+	names := make(map[string]bool, len(req.GetUpdates()))
+	for i, roleUpdate := range req.GetUpdates() {
+		update, err := s.parseRoleUpdate(ctx, roleUpdate)
+		if err == nil && names[roleUpdate.GetName()] {
+			err = status.Errorf(codes.InvalidArgument, "permission %s is updated more than once", roleUpdate.GetName())
+		}
+
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		names[roleUpdate.GetName()] = true
+		updates = append(updates, update)
+		indexes = append(indexes, i)
+	}
+
+	if len(updates) > 0 {
+		updateResults, err := s.controller.UpdateRoles(ctx, updates)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, result := range updateResults {
+			results[indexes[i]] = result
+		}
+	}
+
+	response := &pbv2.UpdatePermissionsResponse{
+		Results: make([]*pbv2.UpdatePermissionsResponse_Result, 0, len(results)),
+	}
+	for i, result := range results {
+		responseResult := &pbv2.UpdatePermissionsResponse_Result{Name: req.GetUpdates()[i].GetName()}
+		if result.Err != nil {
+			errStatus := status.Convert(result.Err)
+			responseResult.Code, responseResult.Message = int32(errStatus.Code()), errStatus.Message()
+		} else {
+			permission, err := marshalPermissionV2(result.Permission)
+			if err != nil {
+				return nil, err
+			}
+
+			responseResult.Permission = permission
+		}
+
+		response.Results = append(response.Results, responseResult)
+	}
+
+	return response, nil
+}
+
+// parseRoleUpdate returns the RoleUpdate of update, or an error if its name or role is invalid,
+// or the caller of ctx may not grant its role.
+func (s ServiceV2) parseRoleUpdate(
+	ctx context.Context,
+	update *pbv2.UpdatePermissionsRequest_RoleUpdate,
+) (RoleUpdate, error) {
+	resourceType, fileID, userID, err := parsePermissionName(update.GetName())
+	if err != nil {
+		return RoleUpdate{}, err
+	}
+
+	role, err := s.roles.Resolve(pb.Role(update.GetRole()), update.GetRoleName())
+	if err != nil {
+		return RoleUpdate{}, err
+	}
+
+	if role == pb.Role_NONE || pb.Role_name[int32(role)] == "" {
+		return RoleUpdate{}, status.Error(codes.InvalidArgument, "role does not exist")
+	}
+
+	if err := s.rolePolicy.Authorize(CallerFromContext(ctx), role); err != nil {
+		return RoleUpdate{}, err
+	}
+
+	if err := s.approvals.Authorize(role); err != nil {
+		return RoleUpdate{}, err
+	}
+
+	return RoleUpdate{
+		ResourceType: resourceType,
+		FileID:       fileID,
+		UserID:       userID,
+		ETag:         update.GetEtag(),
+		Role:         role,
+	}, nil
+}
+
+// DeletePermission is the request handler for deleting a permission by its name.
+func (s ServiceV2) DeletePermission(ctx context.Context, req *pbv2.DeletePermissionRequest) (*empty.Empty, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.actors.RequireActor(ctx); err != nil {
+		return nil, err
+	}
+
+	resourceType, fileID, userID, err := parsePermissionName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.controller.DeletePermission(ctx, resourceType, fileID, userID, req.GetEtag()); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// validatePermissionV2 validates the values of the fields of permission.
+func validatePermissionV2(permission *pbv2.Permission) error {
+	if permission.GetRole() == pbv2.Role_ROLE_UNSPECIFIED || pbv2.Role_name[int32(permission.GetRole())] == "" {
+		return status.Error(codes.InvalidArgument, "permission.role does not exist")
+	}
+
+	if utf8.RuneCountInString(permission.GetMessage()) > MaxMessageLength {
+		return status.Errorf(codes.InvalidArgument, "permission.message exceeds %d characters", MaxMessageLength)
+	}
+
+	if utf8.RuneCountInString(permission.GetLabel()) > MaxLabelLength {
+		return status.Errorf(codes.InvalidArgument, "permission.label exceeds %d characters", MaxLabelLength)
+	}
+
+	if err := ValidateLabels(permission.GetLabels()); err != nil {
+		return status.Errorf(codes.InvalidArgument, "permission.labels: %v", err)
+	}
+
+	return nil
+}
+
+// parseUpdateMask returns the permission fields that are listed in mask.
+func parseUpdateMask(mask *field_mask.FieldMask) ([]PermissionField, error) {
+	if len(mask.GetPaths()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "update_mask is required")
+	}
+
+	fields := make([]PermissionField, 0, len(mask.GetPaths()))
+	for _, path := range mask.GetPaths() {
+		field, ok := updatableFieldsV2[path]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "update_mask path %s cannot be updated", path)
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// parseReadMask returns the permission fields that are listed in mask,
+// returns nil if mask is empty so that all fields are read.
+func parseReadMask(mask *field_mask.FieldMask) ([]PermissionField, error) {
+	fields := make([]PermissionField, 0, len(mask.GetPaths()))
+	for _, path := range mask.GetPaths() {
+		field, ok := readableFieldsV2[path]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "read_mask path %s does not exist", path)
+		}
+
+		fields = append(fields, field)
+	}
+
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	return fields, nil
+}
+
+// applyReadMask clears the fields of permission that aren't listed in mask, unless mask is empty.
+func applyReadMask(permission *pbv2.Permission, mask *field_mask.FieldMask) {
+	if len(mask.GetPaths()) == 0 {
+		return
+	}
+
+	masked := &pbv2.Permission{}
+	for _, path := range mask.GetPaths() {
+		switch path {
+		case "name":
+			masked.Name = permission.GetName()
+		case "user_id":
+			masked.UserId = permission.GetUserId()
+		case "role":
+			masked.Role = permission.GetRole()
+		case "creator":
+			masked.Creator = permission.GetCreator()
+		case "can_reshare":
+			masked.CanReshare = permission.GetCanReshare()
+		case "message":
+			masked.Message = permission.GetMessage()
+		case "label":
+			masked.Label = permission.GetLabel()
+		case "last_accessed_at":
+			masked.LastAccessedAt = permission.GetLastAccessedAt()
+		case "etag":
+			masked.Etag = permission.GetEtag()
+		case "sharing_chain":
+			masked.SharingChain = permission.GetSharingChain()
+		case "resource_kind":
+			masked.ResourceKind = permission.GetResourceKind()
+		case "capabilities":
+			masked.Capabilities = permission.GetCapabilities()
+		case "grantee_type":
+			masked.GranteeType = permission.GetGranteeType()
+		case "labels":
+			masked.Labels = permission.GetLabels()
+		case "source":
+			masked.Source = permission.GetSource()
+		case "inherited_from":
+			masked.InheritedFrom = permission.GetInheritedFrom()
+		}
+	}
+
+	*permission = *masked
+}
+
+// permissionName returns the resource name of the permission of userID to the resource
+// of resourceType with fileID.
+func permissionName(resourceType string, fileID string, userID string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", resourceCollection(resourceType), fileID, permissionsCollection, userID)
+}
+
+// resourceCollection returns the collection ID of the resources of resourceType, i.e "files" for "file".
+func resourceCollection(resourceType string) string {
+	return resourceType + "s"
+}
+
+// parseResourceCollection returns the resource type of the resources in collection, i.e "file" for "files".
+func parseResourceCollection(collection string) (string, bool) {
+	resourceType := strings.TrimSuffix(collection, "s")
+	return resourceType, resourceType != "" && resourceType != collection
+}
+
+// parseResourceName parses a resource name, such as `files/{file}`,
+// and returns the resource type and the resource ID.
+func parseResourceName(name string) (string, string, error) {
+	parts := strings.Split(name, "/")
+	if len(parts) != 2 || parts[1] == "" {
+		return "", "", status.Errorf(codes.InvalidArgument, "invalid resource name %q", name)
+	}
+
+	resourceType, ok := parseResourceCollection(parts[0])
+	if !ok {
+		return "", "", status.Errorf(codes.InvalidArgument, "invalid resource name %q", name)
+	}
+
+	return resourceType, parts[1], nil
+}
+
+// parsePermissionName parses a permission resource name, such as `files/{file}/permissions/{permission}`,
+// and returns the resource type, the resource ID and the user ID.
+func parsePermissionName(name string) (string, string, string, error) {
+	parts := strings.Split(name, "/")
+	if len(parts) != 4 ||
+		parts[1] == "" ||
+		parts[2] != permissionsCollection ||
+		parts[3] == "" {
+		return "", "", "", status.Errorf(codes.InvalidArgument, "invalid permission name %q", name)
+	}
+
+	resourceType, ok := parseResourceCollection(parts[0])
+	if !ok {
+		return "", "", "", status.Errorf(codes.InvalidArgument, "invalid permission name %q", name)
+	}
+
+	return resourceType, parts[1], parts[3], nil
+}
+
+// marshalPermissionV2 marshals permission into a v2 permission.
+func marshalPermissionV2(permission Permission) (*pbv2.Permission, error) {
+	var permissionV1 pb.PermissionObject
+	if err := permission.MarshalProto(&permissionV1); err != nil {
+		return nil, err
+	}
+
+	return convertPermissionV2(&permissionV1), nil
+}
+
+// convertPermissionV2 converts permissionV1 into a v2 permission.
+func convertPermissionV2(permissionV1 *pb.PermissionObject) *pbv2.Permission {
+	return &pbv2.Permission{
+		Name: permissionName(
+			permissionV1.GetResourceType(),
+			permissionV1.GetFileID(),
+			permissionV1.GetUserID(),
+		),
+		UserId:         permissionV1.GetUserID(),
+		Role:           pbv2.Role(permissionV1.GetRole()),
+		Creator:        permissionV1.GetCreator(),
+		CanReshare:     permissionV1.GetCanReshare(),
+		Message:        permissionV1.GetMessage(),
+		Label:          permissionV1.GetLabel(),
+		LastAccessedAt: permissionV1.GetLastAccessedAt(),
+		Etag:           permissionV1.GetEtag(),
+		SharingChain:   permissionV1.GetSharingChain(),
+		ResourceKind:   permissionV1.GetResourceKind(),
+		GranteeType:    permissionV1.GetGranteeType(),
+		Capabilities:   capabilitiesV2(permissionV1.GetCapabilities()),
+		Labels:         permissionV1.GetLabels(),
+		Source:         permissionV1.GetSource(),
+		InheritedFrom:  inheritedFromName(permissionV1.GetResourceType(), permissionV1.GetInheritedFrom()),
+	}
+}
+
+// inheritedFromName returns the resource name of the folder of resourceType with inheritedFrom,
+// that a permission is inherited from, or an empty string if it isn't inherited.
+func inheritedFromName(resourceType string, inheritedFrom string) string {
+	if inheritedFrom == "" {
+		return ""
+	}
+
+	return resourceCollection(resourceType) + "/" + inheritedFrom
+}
+
+// capabilitiesV2 converts capabilities into v2 capabilities.
+func capabilitiesV2(capabilities []pb.Capability) []pbv2.Capability {
+	if capabilities == nil {
+		return nil
+	}
+
+	capabilitiesV2 := make([]pbv2.Capability, 0, len(capabilities))
+	for _, capability := range capabilities {
+		capabilitiesV2 = append(capabilitiesV2, pbv2.Capability(capability))
+	}
+
+	return capabilitiesV2
+}
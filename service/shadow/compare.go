@@ -0,0 +1,57 @@
+package shadow
+
+import (
+	"fmt"
+
+	"github.com/meateam/permission-service/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// isNotFound returns true if err is the error of a permission that doesn't exist.
+func isNotFound(err error) bool {
+	return status.Code(err) == codes.NotFound
+}
+
+// permissionKey returns the identity and values of permission that are compared between
+// the primary and the secondary, the IDs, etags and access times of the stores may differ.
+func permissionKey(permission service.Permission) string {
+	if permission == nil {
+		return ""
+	}
+
+	return fmt.Sprintf(
+		"%s/%s/%s:%s,%s,%t,%q,%q",
+		permission.GetResourceType(),
+		permission.GetFileID(),
+		permission.GetUserID(),
+		permission.GetRole(),
+		permission.GetCreator(),
+		permission.GetCanReshare(),
+		permission.GetMessage(),
+		permission.GetLabel(),
+	)
+}
+
+// equalPermissions returns true if primary and secondary hold the same permissions, regardless of order.
+func equalPermissions(primary []service.Permission, secondary []service.Permission) bool {
+	if len(primary) != len(secondary) {
+		return false
+	}
+
+	counts := make(map[string]int, len(primary))
+	for _, permission := range primary {
+		counts[permissionKey(permission)]++
+	}
+
+	for _, permission := range secondary {
+		key := permissionKey(permission)
+		if counts[key] == 0 {
+			return false
+		}
+
+		counts[key]--
+	}
+
+	return true
+}
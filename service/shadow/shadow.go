@@ -0,0 +1,162 @@
+package shadow
+
+import (
+	"context"
+	"expvar"
+	"time"
+
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/meateam/permission-service/service"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// ResultMatch is the result of a shadow read that returned the same permissions as the primary.
+	ResultMatch = "match"
+
+	// ResultMismatch is the result of a shadow read that returned different permissions than the primary.
+	ResultMismatch = "mismatch"
+
+	// ResultError is the result of a shadow read that failed.
+	ResultError = "error"
+)
+
+// shadowReads counts the results of shadow reads, keyed by "method/result".
+var shadowReads = expvar.NewMap("shadow_reads")
+
+// Repository is a service.PermissionRepository that serves requests from its primary repository,
+// and also executes reads against its secondary repository, in the background, logging and counting
+// the reads whose results don't match the primary's. Writes are executed against the primary only.
+type Repository struct {
+	service.PermissionRepository
+	secondary service.PermissionRepository
+	logger    *logrus.Logger
+	timeout   time.Duration
+}
+
+// NewRepository returns a Repository that serves from primary and shadows reads to secondary,
+// each shadow read is cancelled after timeout.
+func NewRepository(
+	primary service.PermissionRepository,
+	secondary service.PermissionRepository,
+	logger *logrus.Logger,
+	timeout time.Duration,
+) Repository {
+	return Repository{PermissionRepository: primary, secondary: secondary, logger: logger, timeout: timeout}
+}
+
+// GetByID retrieves the permission with id from the primary and shadows the read.
+func (r Repository) GetByID(ctx context.Context, id string) (service.Permission, error) {
+	permission, err := r.PermissionRepository.GetByID(ctx, id)
+	r.shadow("GetByID", permission, err, func(ctx context.Context) (service.Permission, error) {
+		return r.secondary.GetByID(ctx, id)
+	})
+
+	return permission, err
+}
+
+// Get retrieves the permission of userID to fileID from the primary and shadows the read.
+func (r Repository) Get(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	userID string,
+	fields ...service.PermissionField,
+) (service.Permission, error) {
+	permission, err := r.PermissionRepository.Get(ctx, resourceType, fileID, userID, fields...)
+	r.shadow("Get", permission, err, func(ctx context.Context) (service.Permission, error) {
+		return r.secondary.Get(ctx, resourceType, fileID, userID, fields...)
+	})
+
+	return permission, err
+}
+
+// GetByResource retrieves the permissions of fileID from the primary and shadows the read.
+func (r Repository) GetByResource(
+	ctx context.Context,
+	resourceType string,
+	fileID string,
+	order pb.PermissionsOrder,
+	selector service.PermissionSelector,
+) ([]service.Permission, error) {
+	permissions, err := r.PermissionRepository.GetByResource(ctx, resourceType, fileID, order, selector)
+	r.shadowAll("GetByResource", permissions, err, func(ctx context.Context) ([]service.Permission, error) {
+		return r.secondary.GetByResource(ctx, resourceType, fileID, order, selector)
+	})
+
+	return permissions, err
+}
+
+// GetByUser retrieves the permissions of userID from the primary and shadows the read.
+func (r Repository) GetByUser(
+	ctx context.Context,
+	resourceType string,
+	userID string,
+	order pb.PermissionsOrder,
+	selector service.PermissionSelector,
+) ([]service.Permission, error) {
+	permissions, err := r.PermissionRepository.GetByUser(ctx, resourceType, userID, order, selector)
+	r.shadowAll("GetByUser", permissions, err, func(ctx context.Context) ([]service.Permission, error) {
+		return r.secondary.GetByUser(ctx, resourceType, userID, order, selector)
+	})
+
+	return permissions, err
+}
+
+// shadow executes read against the secondary in the background, and records whether its result
+// matches the primary's permission and err.
+func (r Repository) shadow(
+	method string,
+	permission service.Permission,
+	err error,
+	read func(ctx context.Context) (service.Permission, error),
+) {
+	if err != nil {
+		permission = nil
+	}
+
+	readAll := func(ctx context.Context) ([]service.Permission, error) {
+		secondaryPermission, err := read(ctx)
+		if err != nil && !isNotFound(err) {
+			return nil, err
+		}
+
+		return []service.Permission{secondaryPermission}, nil
+	}
+
+	r.shadowAll(method, []service.Permission{permission}, err, readAll)
+}
+
+// shadowAll executes read against the secondary in the background, and records whether its result
+// matches the primary's permissions. Reads that failed on the primary are not shadowed.
+func (r Repository) shadowAll(
+	method string,
+	permissions []service.Permission,
+	err error,
+	read func(ctx context.Context) ([]service.Permission, error),
+) {
+	if err != nil && !isNotFound(err) {
+		return
+	}
+
+	go func() {
+		// The request's context is done once it's served, the shadow read is independent of it.
+		ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+		defer cancel()
+
+		secondaryPermissions, err := read(ctx)
+		if err != nil && !isNotFound(err) {
+			r.logger.Warnf("shadow read %s failed: %v", method, err)
+			shadowReads.Add(method+"/"+ResultError, 1)
+			return
+		}
+
+		if !equalPermissions(permissions, secondaryPermissions) {
+			r.logger.WithField("method", method).Warn("shadow read doesn't match the primary")
+			shadowReads.Add(method+"/"+ResultMismatch, 1)
+			return
+		}
+
+		shadowReads.Add(method+"/"+ResultMatch, 1)
+	}()
+}
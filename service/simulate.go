@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	pb "github.com/meateam/permission-service/proto"
+	pbv2 "github.com/meateam/permission-service/proto/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SimulateAccess is the request handler for simulating the access users would have to a file
+// if changes to its permissions were applied. Nothing is written.
+func (s ServiceV2) SimulateAccess(
+	ctx context.Context,
+	req *pbv2.SimulateAccessRequest,
+) (*pbv2.SimulateAccessResponse, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	resourceType, fileID, err := parseResourceName(req.GetParent())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.limits.CheckList("changes", len(req.GetChanges())); err != nil {
+		return nil, err
+	}
+
+	for _, change := range req.GetChanges() {
+		if change.GetUserId() == "" {
+			return nil, status.Error(codes.InvalidArgument, "changes.user_id is required")
+		}
+
+		if !change.GetRevoke() &&
+			(change.GetRole() == pbv2.Role_ROLE_UNSPECIFIED || pbv2.Role_name[int32(change.GetRole())] == "") {
+			return nil, status.Error(codes.InvalidArgument, "changes.role does not exist")
+		}
+	}
+
+	// A page size of 0 gets all of the permissions of the file.
+	filePermissions, _, err := s.controller.GetFilePermissions(
+		ctx,
+		resourceType,
+		fileID,
+		pb.PermissionsOrder_DEFAULT,
+		0,
+		"",
+		PermissionSelector{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	current := make(map[string]pb.Role, len(filePermissions))
+	for _, permission := range filePermissions {
+		current[permission.GetUserID()] = permission.GetRole()
+	}
+
+	simulated := simulateChanges(current, req.GetChanges())
+
+	userIDs := []string{req.GetUserId()}
+	if req.GetUserId() == "" {
+		userIDs = affectedUsers(current, simulated)
+	}
+
+	response := &pbv2.SimulateAccessResponse{Accesses: make([]*pbv2.SimulatedAccess, 0, len(userIDs))}
+	for _, userID := range userIDs {
+		response.Accesses = append(response.Accesses, &pbv2.SimulatedAccess{
+			UserId:        userID,
+			CurrentRole:   pbv2.Role(current[userID]),
+			SimulatedRole: pbv2.Role(simulated[userID]),
+		})
+	}
+
+	return response, nil
+}
+
+// simulateChanges returns the roles of users after applying changes, in order, to their current roles.
+func simulateChanges(current map[string]pb.Role, changes []*pbv2.AccessChange) map[string]pb.Role {
+	simulated := make(map[string]pb.Role, len(current)+len(changes))
+	for userID, role := range current {
+		simulated[userID] = role
+	}
+
+	for _, change := range changes {
+		if change.GetRevoke() {
+			delete(simulated, change.GetUserId())
+			continue
+		}
+
+		simulated[change.GetUserId()] = pb.Role(change.GetRole())
+	}
+
+	return simulated
+}
+
+// affectedUsers returns the sorted IDs of the users that have a role in current or simulated.
+func affectedUsers(current map[string]pb.Role, simulated map[string]pb.Role) []string {
+	userIDs := make([]string, 0, len(current)+len(simulated))
+	for userID := range current {
+		userIDs = append(userIDs, userID)
+	}
+
+	for userID := range simulated {
+		if _, ok := current[userID]; !ok {
+			userIDs = append(userIDs, userID)
+		}
+	}
+
+	sort.Strings(userIDs)
+	return userIDs
+}
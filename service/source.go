@@ -0,0 +1,54 @@
+package service
+
+import (
+	"fmt"
+)
+
+const (
+	// SourceAPI is the source of the permissions that were created by a request that didn't set its source.
+	SourceAPI = "api"
+
+	// SourceImport is the source of the imported permissions that didn't set their source.
+	SourceImport = "import"
+)
+
+// sourceOrDefault returns source if set, otherwise returns defaultSource, and returns an error
+// if source isn't lowercase letters, digits, '-' and '_', starting with a letter, of up to 63 characters.
+func sourceOrDefault(source string, defaultSource string) (string, error) {
+	if source == "" {
+		return defaultSource, nil
+	}
+
+	if err := validateSource(source); err != nil {
+		return "", err
+	}
+
+	return source, nil
+}
+
+// validateSource returns an error if source, of a permission or a selector of permissions, is set
+// but isn't lowercase letters, digits, '-' and '_', starting with a letter, of up to 63 characters.
+func validateSource(source string) error {
+	if source != "" && !labelKeyPattern.MatchString(source) {
+		return fmt.Errorf(
+			"source %q must be lowercase letters, digits, '-' and '_', starting with a letter, of up to 63 characters",
+			source,
+		)
+	}
+
+	return nil
+}
+
+// parseSelector returns the selector of permissions that have all of labels and were created by source,
+// and returns an error if either is invalid.
+func parseSelector(labels map[string]string, source string) (PermissionSelector, error) {
+	if err := ValidateLabels(labels); err != nil {
+		return PermissionSelector{}, err
+	}
+
+	if err := validateSource(source); err != nil {
+		return PermissionSelector{}, err
+	}
+
+	return PermissionSelector{Labels: labels, Source: source}, nil
+}
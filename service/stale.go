@@ -0,0 +1,171 @@
+package service
+
+import (
+	"container/list"
+	"context"
+	"expvar"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// StaleHeader is the grpc header metadata key that's set to "true" in the responses of the permission checks
+// that were served from the last-known permissions, because the store was unavailable.
+const StaleHeader = "x-stale"
+
+// staleReads counts the permission checks that were served from the last-known permissions, keyed by
+// resource type.
+var staleReads = expvar.NewMap("stale_reads")
+
+// lastKnownEntry is the last-known permission of a user to a resource, nil if the user had none,
+// and the time it was read at.
+type lastKnownEntry struct {
+	key        string
+	permission Permission
+	readAt     time.Time
+}
+
+// LastKnownPermissions holds the last-known permissions of users to resources, and the absence of them,
+// that the permission checks are served from while the store is unavailable, such as during a short outage
+// of the database, so that file downloads degrade gracefully. Entries are served up to maxAge after they
+// were read, and the least recently read entries are evicted beyond maxEntries. Mutations are never served
+// from it. The zero value holds nothing.
+type LastKnownPermissions struct {
+	maxAge     time.Duration
+	maxEntries int
+
+	mu      *sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+// NewLastKnownPermissions creates the last-known permissions that are served up to maxAge after they were read,
+// of up to maxEntries permissions, and returns it. It holds nothing if maxAge or maxEntries aren't positive.
+func NewLastKnownPermissions(maxAge time.Duration, maxEntries int) LastKnownPermissions {
+	return LastKnownPermissions{
+		maxAge:     maxAge,
+		maxEntries: maxEntries,
+		mu:         &sync.Mutex{},
+		entries:    map[string]*list.Element{},
+		order:      list.New(),
+	}
+}
+
+// WithLastKnownPermissions returns a copy of the service that serves its permission checks from lastKnown
+// while the store is unavailable.
+func (s Service) WithLastKnownPermissions(lastKnown LastKnownPermissions) Service {
+	s.lastKnown = lastKnown
+	return s
+}
+
+// WithLastKnownPermissions returns a copy of the service that serves its permission checks from lastKnown
+// while the store is unavailable.
+func (s ServiceV2) WithLastKnownPermissions(lastKnown LastKnownPermissions) ServiceV2 {
+	s.lastKnown = lastKnown
+	return s
+}
+
+// WithLastKnownPermissions returns a copy of the service that records the permissions of the files
+// it prewarms in lastKnown.
+func (s AdminService) WithLastKnownPermissions(lastKnown LastKnownPermissions) AdminService {
+	s.lastKnown = lastKnown
+	return s
+}
+
+// getByFileAndUser returns the permission of userID to the resource of resourceType with fileID from controller,
+// with only fields if any are given. If the store is unavailable then the last-known permission is returned,
+// and whether it's stale, and the response of ctx is marked stale by StaleHeader.
+func (l LastKnownPermissions) getByFileAndUser(
+	ctx context.Context,
+	controller Controller,
+	resourceType string,
+	fileID string,
+	userID string,
+	fields ...PermissionField,
+) (Permission, bool, error) {
+	permission, err := controller.GetByFileAndUser(ctx, resourceType, fileID, userID, fields...)
+	if status.Code(err) != codes.Unavailable {
+		// Only whole permissions are recorded, so that they may be served to reads of any of their fields.
+		if len(fields) == 0 && (err == nil || status.Code(err) == codes.NotFound) {
+			l.record(resourceType, fileID, userID, permission)
+		}
+
+		return permission, false, err
+	}
+
+	lastKnown, ok := l.lookup(resourceType, fileID, userID)
+	if !ok {
+		return nil, false, err
+	}
+
+	staleReads.Add(resourceType, 1)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(StaleHeader, "true"))
+	if lastKnown == nil {
+		return nil, true, status.Errorf(codes.NotFound, "permission not found")
+	}
+
+	return lastKnown, true, nil
+}
+
+// record records permission as the last-known permission of userID to the resource, nil if there's none.
+func (l LastKnownPermissions) record(resourceType string, fileID string, userID string, permission Permission) {
+	if l.disabled() {
+		return
+	}
+
+	key := lastKnownKey(resourceType, fileID, userID)
+	entry := &lastKnownEntry{key: key, permission: permission, readAt: time.Now()}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if element, ok := l.entries[key]; ok {
+		element.Value = entry
+		l.order.MoveToFront(element)
+		return
+	}
+
+	l.entries[key] = l.order.PushFront(entry)
+	for l.order.Len() > l.maxEntries {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.entries, oldest.Value.(*lastKnownEntry).key)
+	}
+}
+
+// lookup returns the last-known permission of userID to the resource, nil if there was none,
+// and false if it isn't known or was read more than maxAge ago.
+func (l LastKnownPermissions) lookup(resourceType string, fileID string, userID string) (Permission, bool) {
+	if l.disabled() {
+		return nil, false
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	element, ok := l.entries[lastKnownKey(resourceType, fileID, userID)]
+	if !ok {
+		return nil, false
+	}
+
+	entry := element.Value.(*lastKnownEntry)
+	if time.Since(entry.readAt) > l.maxAge {
+		return nil, false
+	}
+
+	return entry.permission, true
+}
+
+// disabled returns whether the last-known permissions hold nothing.
+func (l LastKnownPermissions) disabled() bool {
+	return l.mu == nil || l.maxAge <= 0 || l.maxEntries <= 0
+}
+
+// lastKnownKey returns the key of the last-known permission of userID to the resource of resourceType with fileID.
+func lastKnownKey(resourceType string, fileID string, userID string) string {
+	return resourceType + "\x00" + fileID + "\x00" + userID
+}
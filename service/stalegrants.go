@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	pbv2 "github.com/meateam/permission-service/proto/v2"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// JobExpireStaleGrants is the kind of the recurring jobs that delete the grants that weren't used
+// within the stale grant expiry of the deployment.
+const JobExpireStaleGrants = "expire_stale_grants"
+
+// ListStaleGrants is the request handler for listing the direct grants that weren't used within a duration.
+func (s AdminService) ListStaleGrants(
+	ctx context.Context,
+	req *pbv2.ListStaleGrantsRequest,
+) (*pbv2.ListStaleGrantsResponse, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	olderThan, err := ptypes.Duration(req.GetOlderThan())
+	if err != nil || olderThan <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "older_than must be positive")
+	}
+
+	resourceType := ""
+	if collection := req.GetResourceCollection(); collection != "" {
+		var ok bool
+		if resourceType, ok = parseResourceCollection(collection); !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid resource collection %q", collection)
+		}
+	}
+
+	pageSize := int(req.GetPageSize())
+	if pageSize < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page_size must not be negative")
+	}
+
+	if pageSize == 0 {
+		pageSize = DefaultPageSize
+	}
+
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	permissions, nextPageToken, err := s.controller.ListStaleGrants(
+		ctx,
+		resourceType,
+		time.Now().Add(-olderThan),
+		pageSize,
+		req.GetPageToken(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pbv2.ListStaleGrantsResponse{
+		Permissions:   make([]*pbv2.Permission, 0, len(permissions)),
+		NextPageToken: nextPageToken,
+	}
+	for _, permission := range permissions {
+		permissionV2, err := marshalPermissionV2(permission)
+		if err != nil {
+			return nil, err
+		}
+
+		response.Permissions = append(response.Permissions, permissionV2)
+	}
+
+	return response, nil
+}
+
+// WithStaleGrantExpiry returns a copy of m whose JobExpireStaleGrants jobs delete the grants
+// that weren't used within expiry.
+func (m MaintenanceJobs) WithStaleGrantExpiry(expiry time.Duration) MaintenanceJobs {
+	m.staleGrantExpiry = expiry
+	return m
+}
+
+// expireStaleGrants deletes the grants that weren't used within the stale grant expiry, except on held files.
+func (m MaintenanceJobs) expireStaleGrants(ctx context.Context, progress func(JobProgress) error) error {
+	var expired, skipped int64
+	err := m.controller.ExpireStaleGrants(
+		ctx,
+		time.Now().Add(-m.staleGrantExpiry),
+		DefaultMigrationBatchSize,
+		func(batchExpired int64, batchSkipped int64) error {
+			expired, skipped = batchExpired, batchSkipped
+			return progress(JobProgress{Done: expired + skipped, Total: expired + skipped})
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	m.logger.WithFields(logrus.Fields{"expired": expired, "skipped": skipped}).Info("expired stale grants")
+	return nil
+}
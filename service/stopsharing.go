@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// WithFileMetadata returns a copy of the service that reads the owners of the files whose sharing is stopped
+// from files.
+func (s Service) WithFileMetadata(files FileMetadata) Service {
+	s.owners = files
+	return s
+}
+
+// RevokeAllExceptOwner is the request handler for stopping sharing a file, which revokes every permission
+// to it other than its owner's at once, so that it isn't left partially shared if the revocation fails midway.
+func (s Service) RevokeAllExceptOwner(
+	ctx context.Context,
+	req *pb.RevokeAllExceptOwnerRequest,
+) (*pb.RevokeAllExceptOwnerResponse, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.actors.RequireActor(ctx); err != nil {
+		return nil, err
+	}
+
+	resourceType := resourceTypeOrDefault(req.GetResourceType())
+	fileID := req.GetFileID()
+	if fileID == "" {
+		return nil, fmt.Errorf("fileID is required")
+	}
+
+	owner := req.GetOwnerID()
+	if owner == "" {
+		if s.owners == nil {
+			return nil, status.Error(codes.FailedPrecondition, "ownerID is required without a file service")
+		}
+
+		var err error
+		if owner, err = s.owners.GetFileOwner(ctx, fileID); err != nil {
+			return nil, err
+		}
+	}
+
+	permissions, err := s.controller.RevokeAllExceptOwner(ctx, resourceType, fileID, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"resourceType": resourceType,
+		"fileID":       fileID,
+		"revoked":      len(permissions),
+		"revokedBy":    actorOrCaller(ctx),
+	}).Info("stopped sharing file")
+
+	return &pb.RevokeAllExceptOwnerResponse{Permissions: permissions}, nil
+}
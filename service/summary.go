@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	pb "github.com/meateam/permission-service/proto"
+	pbv2 "github.com/meateam/permission-service/proto/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SharingSummary is the summary of the sharing of a tree of files.
+type SharingSummary struct {
+	// Files is the number of the files of the tree that have permissions.
+	Files int64
+
+	// Permissions is the number of the permissions to the files of the tree.
+	Permissions int64
+
+	// Grantees is the number of the distinct grantees that have a permission to any of the files.
+	Grantees int64
+
+	// RoleGrantees maps roles to the number of the distinct grantees that have them to any of the files.
+	RoleGrantees map[pb.Role]int64
+
+	// ExternalGrantees are the grantees outside of the tenant that have a permission to any of the files,
+	// sorted, and ExternalFiles is the number of the files that they have permissions to.
+	ExternalGrantees []string
+	ExternalFiles    int64
+}
+
+// GetFolderSharingSummary is the request handler for summarizing the sharing of a folder's tree.
+func (s ServiceV2) GetFolderSharingSummary(
+	ctx context.Context,
+	req *pbv2.GetFolderSharingSummaryRequest,
+) (*pbv2.FolderSharingSummary, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	resourceType, folderID, err := parseResourceName(req.GetFolder())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, descendantID := range req.GetDescendantIds() {
+		if descendantID == "" {
+			return nil, status.Error(codes.InvalidArgument, "descendant_ids must not be empty")
+		}
+	}
+
+	if err := s.actors.AuthorizePermissionRead(ctx, s.controller, resourceType, folderID); err != nil {
+		return nil, err
+	}
+
+	summary, err := s.controller.GetFolderSharingSummary(ctx, resourceType, folderID, req.GetDescendantIds())
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make([]int, 0, len(summary.RoleGrantees))
+	for role := range summary.RoleGrantees {
+		roles = append(roles, int(role))
+	}
+
+	sort.Ints(roles)
+	response := &pbv2.FolderSharingSummary{
+		Folder:           req.GetFolder(),
+		Files:            summary.Files,
+		Permissions:      summary.Permissions,
+		Grantees:         summary.Grantees,
+		Roles:            make([]*pbv2.FolderSharingSummary_RoleGrantees, 0, len(roles)),
+		ExternalGrantees: summary.ExternalGrantees,
+		ExternalFiles:    summary.ExternalFiles,
+	}
+	for _, role := range roles {
+		response.Roles = append(response.Roles, &pbv2.FolderSharingSummary_RoleGrantees{
+			Role:     pbv2.Role(role),
+			Grantees: summary.RoleGrantees[pb.Role(role)],
+		})
+	}
+
+	return response, nil
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// CorrelationIDHeader is the grpc metadata key of the ID that correlates the logs of a request
+	// across the services that handle it, which the API gateway sets.
+	CorrelationIDHeader = "x-request-id"
+
+	// maxCorrelationIDLength is the maximum length of a correlation ID in an operation comment,
+	// longer IDs are truncated, since the header is set by the callers.
+	maxCorrelationIDLength = 128
+)
+
+// workerKey is the context key of the name of the background worker of a context.
+type workerKey struct{}
+
+// WithWorker returns a copy of ctx of the background worker of name, such as "outbox_relay".
+func WithWorker(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, workerKey{}, name)
+}
+
+// OperationComment returns the comment that tags the store's operations of ctx, so that the slow-query
+// logs of the database can be traced back to the RPC that made them, and its caller, or to the background
+// worker, such as "method=/permission.Permission/IsPermitted request_id=abc caller=gateway".
+// It returns an empty string if ctx is of neither.
+func OperationComment(ctx context.Context) string {
+	var tags []string
+	if method, ok := grpc.Method(ctx); ok {
+		tags = append(tags, "method="+method)
+	}
+
+	if worker, ok := ctx.Value(workerKey{}).(string); ok {
+		tags = append(tags, "worker="+worker)
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	if ids := md.Get(CorrelationIDHeader); len(ids) > 0 && ids[0] != "" {
+		id := ids[0]
+		if len(id) > maxCorrelationIDLength {
+			id = id[:maxCorrelationIDLength]
+		}
+
+		tags = append(tags, "request_id="+id)
+	}
+
+	if len(tags) == 0 {
+		return ""
+	}
+
+	if caller := CallerFromContext(ctx); caller != "" {
+		tags = append(tags, "caller="+caller)
+	}
+
+	return strings.Join(tags, " ")
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	pbv2 "github.com/meateam/permission-service/proto/v2"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// TenantPurgeConfirmationTTL is the duration that the confirmation token of a previewed purge is valid for.
+	TenantPurgeConfirmationTTL = 10 * time.Minute
+
+	// MaxTenantIDLength is the maximum length of the ID of a tenant.
+	MaxTenantIDLength = 64
+
+	// confirmationTokenBytes is the number of random bytes of a confirmation token.
+	confirmationTokenBytes = 32
+)
+
+// TenantRecord is a document that's stored for a tenant.
+type TenantRecord struct {
+	// Collection is the name of the collection of the document, without the tenant's prefix.
+	Collection string
+
+	// Document is the document as it's stored, in canonical extended JSON.
+	Document string
+}
+
+// TenantPurge is a purge of the data of a tenant, previewed or done.
+type TenantPurge struct {
+	TenantID string
+
+	// Collections are the names of the tenant's collections, without the tenant's prefix.
+	Collections []string
+
+	// Documents is the number of the tenant's documents that are deleted by the purge.
+	Documents int64
+
+	// ConfirmationToken is the token that confirms the purge, and ExpiresAt the time it expires at.
+	ConfirmationToken string
+	ExpiresAt         time.Time
+
+	// RequestedBy is the actor that previewed the purge, and ConfirmedBy the actor that confirmed it.
+	RequestedBy string
+	ConfirmedBy string
+}
+
+// ValidateTenantID returns an InvalidArgument error if tenantID isn't the ID of a tenant, which is made of
+// letters, digits, dashes and underscores, otherwise returns nil.
+func ValidateTenantID(tenantID string) error {
+	if tenantID == "" {
+		return status.Error(codes.InvalidArgument, "tenant_id is required")
+	}
+
+	if len(tenantID) > MaxTenantIDLength {
+		return status.Errorf(codes.InvalidArgument, "tenant_id must be at most %d characters", MaxTenantIDLength)
+	}
+
+	for _, c := range tenantID {
+		isLetter := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+		if !isLetter && !(c >= '0' && c <= '9') && c != '-' && c != '_' {
+			return status.Error(codes.InvalidArgument, "tenant_id may only contain letters, digits, '-' and '_'")
+		}
+	}
+
+	return nil
+}
+
+// newConfirmationToken returns a random confirmation token.
+func newConfirmationToken() (string, error) {
+	token := make([]byte, confirmationTokenBytes)
+	if _, err := rand.Read(token); err != nil {
+		return "", status.Errorf(codes.Internal, "failed generating a confirmation token: %v", err)
+	}
+
+	return hex.EncodeToString(token), nil
+}
+
+// ExportTenantData is the request handler for exporting the data of a tenant, it streams
+// the documents of each of the tenant's collections.
+func (s AdminService) ExportTenantData(
+	req *pbv2.ExportTenantDataRequest,
+	stream pbv2.PermissionsAdmin_ExportTenantDataServer,
+) error {
+	if err := s.limits.Check(stream.Context(), req); err != nil {
+		return err
+	}
+
+	tenantID := req.GetTenantId()
+	if err := ValidateTenantID(tenantID); err != nil {
+		return err
+	}
+
+	exported := 0
+	err := s.controller.ExportTenantData(stream.Context(), tenantID, func(record TenantRecord) error {
+		exported++
+		return stream.Send(&pbv2.TenantDataRecord{Collection: record.Collection, Document: record.Document})
+	})
+	if err != nil {
+		return err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"tenantID":   tenantID,
+		"documents":  exported,
+		"exportedBy": actorOrCaller(stream.Context()),
+	}).Warn("tenant data exported")
+
+	return nil
+}
+
+// PurgeTenant is the request handler for purging the data of a tenant. A request without a confirmation
+// token previews the purge and returns its token, and a request with the token purges the data.
+func (s AdminService) PurgeTenant(
+	ctx context.Context,
+	req *pbv2.PurgeTenantRequest,
+) (*pbv2.PurgeTenantResponse, error) {
+	if err := s.limits.Check(ctx, req); err != nil {
+		return nil, err
+	}
+
+	tenantID := req.GetTenantId()
+	if err := ValidateTenantID(tenantID); err != nil {
+		return nil, err
+	}
+
+	if req.GetConfirmationToken() == "" {
+		token, err := newConfirmationToken()
+		if err != nil {
+			return nil, err
+		}
+
+		purge, err := s.controller.PreviewTenantPurge(ctx, TenantPurge{
+			TenantID:          tenantID,
+			ConfirmationToken: token,
+			RequestedBy:       actorOrCaller(ctx),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		s.logger.WithFields(logrus.Fields{
+			"tenantID":    tenantID,
+			"documents":   purge.Documents,
+			"requestedBy": purge.RequestedBy,
+		}).Warn("tenant purge requested")
+
+		expireTime, err := TimestampProto(purge.ExpiresAt)
+		if err != nil {
+			return nil, err
+		}
+
+		return &pbv2.PurgeTenantResponse{
+			ConfirmationToken: purge.ConfirmationToken,
+			ExpireTime:        expireTime,
+			Collections:       purge.Collections,
+			Documents:         purge.Documents,
+		}, nil
+	}
+
+	purge, err := s.controller.PurgeTenant(ctx, tenantID, req.GetConfirmationToken(), actorOrCaller(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"tenantID":    tenantID,
+		"collections": purge.Collections,
+		"documents":   purge.Documents,
+		"requestedBy": purge.RequestedBy,
+		"confirmedBy": purge.ConfirmedBy,
+	}).Warn("tenant purged")
+
+	return &pbv2.PurgeTenantResponse{
+		Purged:      true,
+		Collections: purge.Collections,
+		Documents:   purge.Documents,
+	}, nil
+}
@@ -0,0 +1,17 @@
+package service
+
+import "context"
+
+// JobUpgradeSchema is the kind of the jobs, and of the recurring jobs, that upgrade the stored permissions
+// whose documents are of an older shape, which are otherwise upgraded when they're read.
+const JobUpgradeSchema = "upgrade_schema"
+
+// upgradeSchemaJob returns the operation of a job that upgrades the stored permissions of controller
+// batchSize permissions at a time.
+func upgradeSchemaJob(controller Controller, batchSize int) JobFunc {
+	return func(ctx context.Context, progress func(JobProgress) error) error {
+		return controller.UpgradeSchema(ctx, batchSize, func(upgraded int64, total int64) error {
+			return progress(JobProgress{Done: upgraded, Total: total})
+		})
+	}
+}
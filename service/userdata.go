@@ -0,0 +1,29 @@
+package service
+
+// ErasedUserID replaces the ID of an erased user as the creator, and in the sharing chains, of permissions.
+const ErasedUserID = "erased-user"
+
+// UserData is the data that's stored about a user.
+type UserData struct {
+	// Held are the permissions of the user.
+	Held []Permission
+
+	// Created are the permissions of other users that the user created.
+	Created []Permission
+
+	// Events are the recorded events whose permissions reference the user.
+	Events []PermissionEvent
+}
+
+// ErasureReport is the result of erasing the data of a user.
+type ErasureReport struct {
+	// Deleted is the number of permissions of the user that were deleted.
+	Deleted int64
+
+	// Anonymized is the number of permissions that the user was replaced in by ErasedUserID.
+	Anonymized int64
+
+	// RetainedEvents is the number of recorded events whose permissions reference the user,
+	// which are kept until their retention expires.
+	RetainedEvents int64
+}
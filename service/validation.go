@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/meateam/permission-service/proto/validate"
+	"google.golang.org/grpc"
+)
+
+// ValidateRequests returns the middleware that rejects the requests that violate the validation rules
+// of the proto annotations of their fields with InvalidArgument errors, before they're handled.
+// The handlers still check the requirements that the annotations can't express.
+func ValidateRequests() Middleware {
+	return Middleware{Unary: validationUnaryServerInterceptor, Stream: validationStreamServerInterceptor}
+}
+
+// validationUnaryServerInterceptor is a grpc unary server interceptor that validates the requests of its handler.
+func validationUnaryServerInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if msg, ok := req.(proto.Message); ok {
+		if err := validate.Message(msg); err != nil {
+			return nil, err
+		}
+	}
+
+	return handler(ctx, req)
+}
+
+// validationStreamServerInterceptor is a grpc stream server interceptor that validates the messages
+// that its handler receives.
+func validationStreamServerInterceptor(
+	srv interface{},
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	return handler(srv, validatingServerStream{stream})
+}
+
+// validatingServerStream is a grpc.ServerStream that validates the messages it receives.
+type validatingServerStream struct {
+	grpc.ServerStream
+}
+
+// RecvMsg receives a message into m and validates it.
+func (s validatingServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	if msg, ok := m.(proto.Message); ok {
+		return validate.Message(msg)
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// webhookEvent is the JSON body of a permission event that's posted to a webhook.
+type webhookEvent struct {
+	ID           string    `json:"id"`
+	Type         EventType `json:"type"`
+	OccurredAt   time.Time `json:"occurredAt"`
+	ResourceType string    `json:"resourceType"`
+	FileID       string    `json:"fileID"`
+	UserID       string    `json:"userID"`
+	GranteeType  string    `json:"granteeType"`
+	Role         string    `json:"role"`
+	Creator      string    `json:"creator"`
+}
+
+// WebhookPublisher is an EventPublisher that posts the events of its types to a URL as JSON,
+// and skips the events of other types. An event whose post doesn't succeed with a 2xx status
+// fails to publish, so that it's retried.
+type WebhookPublisher struct {
+	url    string
+	client *http.Client
+	types  map[EventType]bool
+}
+
+// NewWebhookPublisher creates a WebhookPublisher that posts the events of types to url within timeout,
+// and returns it.
+func NewWebhookPublisher(url string, timeout time.Duration, types ...EventType) WebhookPublisher {
+	publisher := WebhookPublisher{url: url, client: &http.Client{Timeout: timeout}, types: map[EventType]bool{}}
+	for _, eventType := range types {
+		publisher.types[eventType] = true
+	}
+
+	return publisher
+}
+
+// Publish posts event to the webhook if it's of one of the publisher's types.
+func (p WebhookPublisher) Publish(ctx context.Context, event PermissionEvent) error {
+	if !p.types[event.Type] {
+		return nil
+	}
+
+	body, err := json.Marshal(webhookEvent{
+		ID:           event.ID,
+		Type:         event.Type,
+		OccurredAt:   event.OccurredAt,
+		ResourceType: resourceTypeOrDefault(event.Permission.GetResourceType()),
+		FileID:       event.Permission.GetFileID(),
+		UserID:       event.Permission.GetUserID(),
+		GranteeType:  event.Permission.GetGranteeType(),
+		Role:         event.Permission.GetRole().String(),
+		Creator:      event.Permission.GetCreator(),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	res, err := p.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed posting event %s to webhook: %v", event.ID, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("failed posting event %s to webhook: %s", event.ID, res.Status)
+	}
+
+	return nil
+}
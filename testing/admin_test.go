@@ -0,0 +1,792 @@
+//go:build integration
+// +build integration
+
+package testing_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	pb "github.com/meateam/permission-service/proto"
+	pbv2 "github.com/meateam/permission-service/proto/v2"
+	"github.com/meateam/permission-service/service"
+	pstesting "github.com/meateam/permission-service/testing"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc/codes"
+)
+
+func TestMigrateRole(t *testing.T) {
+	fileID, userID := newID("file"), newID("user")
+	createPermission(t, fileID, userID, pb.Role_READ, userID)
+
+	stream, err := srv.Admin.MigrateRole(context.Background(), &pbv2.MigrateRoleRequest{
+		FromRole: pbv2.Role_READ,
+		ToRole:   pbv2.Role_WRITE,
+		Filter:   &pbv2.PermissionsFilter{FileIds: []string{fileID}},
+	})
+	if err != nil {
+		t.Fatalf("MigrateRole failed: %v", err)
+	}
+
+	var progress *pbv2.MigrateRoleProgress
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			t.Fatalf("MigrateRole failed: %v", err)
+		}
+
+		progress = res
+	}
+
+	if progress.GetMigrated() != 1 || progress.GetTotal() != 1 {
+		t.Fatalf("expected 1 migrated permission, got %v", progress)
+	}
+
+	permission, err := srv.Permission.GetPermission(context.Background(), &pb.GetPermissionRequest{
+		FileID: fileID,
+		UserID: userID,
+	})
+	if err != nil {
+		t.Fatalf("GetPermission failed: %v", err)
+	}
+
+	if permission.GetRole() != pb.Role_WRITE {
+		t.Fatalf("expected role WRITE, got %s", permission.GetRole())
+	}
+}
+
+func TestImportPermissions(t *testing.T) {
+	parent, userID := "files/"+newID("file"), newID("user")
+	stream, err := srv.Admin.ImportPermissions(context.Background())
+	if err != nil {
+		t.Fatalf("ImportPermissions failed: %v", err)
+	}
+
+	requests := []*pbv2.ImportPermissionsRequest{
+		{Parent: parent, Permission: &pbv2.Permission{UserId: userID, Role: pbv2.Role_READ, Creator: userID}},
+		{Parent: parent, Permission: &pbv2.Permission{Role: pbv2.Role_READ, Creator: userID}},
+	}
+	for _, req := range requests {
+		if err := stream.Send(req); err != nil {
+			t.Fatalf("ImportPermissions failed: %v", err)
+		}
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		t.Fatalf("ImportPermissions failed: %v", err)
+	}
+
+	var progress *pbv2.ImportPermissionsProgress
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			t.Fatalf("ImportPermissions failed: %v", err)
+		}
+
+		progress = res
+	}
+
+	if progress.GetAccepted() != 1 || progress.GetRejected() != 1 {
+		t.Fatalf("expected 1 accepted and 1 rejected permission, got %v", progress)
+	}
+
+	if len(progress.GetErrors()) != 1 || progress.GetErrors()[0].GetIndex() != 1 {
+		t.Fatalf("expected the error of the second permission, got %v", progress.GetErrors())
+	}
+}
+
+func TestImportPermissionsProgress(t *testing.T) {
+	fileID := newID("file")
+	stream, err := srv.Admin.ImportPermissions(context.Background())
+	if err != nil {
+		t.Fatalf("ImportPermissions failed: %v", err)
+	}
+
+	// The records span several progresses, and every tenth record is rejected for missing its user.
+	const records = 2*service.ImportProgressInterval + 10
+	for i := 0; i < records; i++ {
+		userID := newID("user")
+		if i%10 == 0 {
+			userID = ""
+		}
+
+		err := stream.Send(&pbv2.ImportPermissionsRequest{
+			Parent:     "files/" + fileID,
+			Permission: &pbv2.Permission{UserId: userID, Role: pbv2.Role_READ, Creator: newID("user")},
+		})
+		if err != nil {
+			t.Fatalf("ImportPermissions failed: %v", err)
+		}
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		t.Fatalf("ImportPermissions failed: %v", err)
+	}
+
+	var progress *pbv2.ImportPermissionsProgress
+	progresses := 0
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			t.Fatalf("ImportPermissions failed: %v", err)
+		}
+
+		progress = res
+		progresses++
+	}
+
+	if progresses != 3 {
+		t.Errorf("expected 3 progresses of %d records, got %d", records, progresses)
+	}
+
+	const rejected = records / 10
+	if progress.GetAccepted() != records-rejected || progress.GetRejected() != rejected {
+		t.Fatalf("expected %d accepted and %d rejected permissions, got %v", records-rejected, rejected, progress)
+	}
+
+	permissions, err := srv.Permission.GetFilePermissions(context.Background(), &pb.GetFilePermissionsRequest{
+		FileID: fileID,
+	})
+	if err != nil {
+		t.Fatalf("GetFilePermissions failed: %v", err)
+	}
+
+	if int64(len(permissions.GetPermissions())) != progress.GetAccepted() {
+		t.Errorf(
+			"expected the %d accepted permissions to be stored, got %d",
+			progress.GetAccepted(),
+			len(permissions.GetPermissions()),
+		)
+	}
+}
+
+func TestImportPermissionsConflictPolicy(t *testing.T) {
+	fileID := newID("file")
+	skipped, merged, kept := newID("user"), newID("user"), newID("user")
+	replaced, created := newID("user"), newID("user")
+	createPermission(t, fileID, skipped, pb.Role_READ, skipped)
+	createPermission(t, fileID, merged, pb.Role_READ, merged)
+	createPermission(t, fileID, kept, pb.Role_WRITE, kept)
+	createPermission(t, fileID, replaced, pb.Role_WRITE, replaced)
+
+	stream, err := srv.Admin.ImportPermissions(context.Background())
+	if err != nil {
+		t.Fatalf("ImportPermissions failed: %v", err)
+	}
+
+	const (
+		skip    = pbv2.ConflictPolicy_SKIP
+		merge   = pbv2.ConflictPolicy_MERGE_HIGHEST_ROLE
+		replace = pbv2.ConflictPolicy_REPLACE
+	)
+
+	records := []struct {
+		userID  string
+		role    pbv2.Role
+		policy  pbv2.ConflictPolicy
+		outcome pbv2.ImportPermissionsProgress_Outcome
+		want    pb.Role
+	}{
+		{skipped, pbv2.Role_WRITE, skip, pbv2.ImportPermissionsProgress_SKIPPED, pb.Role_READ},
+		{merged, pbv2.Role_WRITE, merge, pbv2.ImportPermissionsProgress_MERGED, pb.Role_WRITE},
+		{kept, pbv2.Role_READ, merge, pbv2.ImportPermissionsProgress_SKIPPED, pb.Role_WRITE},
+		{replaced, pbv2.Role_READ, replace, pbv2.ImportPermissionsProgress_REPLACED, pb.Role_READ},
+		{created, pbv2.Role_READ, skip, pbv2.ImportPermissionsProgress_CREATED, pb.Role_READ},
+	}
+	for _, record := range records {
+		err := stream.Send(&pbv2.ImportPermissionsRequest{
+			Parent:         "files/" + fileID,
+			Permission:     &pbv2.Permission{UserId: record.userID, Role: record.role, Creator: record.userID},
+			ConflictPolicy: record.policy,
+		})
+		if err != nil {
+			t.Fatalf("ImportPermissions failed: %v", err)
+		}
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		t.Fatalf("ImportPermissions failed: %v", err)
+	}
+
+	results := []*pbv2.ImportPermissionsProgress_RecordResult{}
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			t.Fatalf("ImportPermissions failed: %v", err)
+		}
+
+		results = append(results, res.GetResults()...)
+	}
+
+	if len(results) != len(records) {
+		t.Fatalf("expected %d results, got %v", len(records), results)
+	}
+
+	for i, record := range records {
+		if results[i].GetIndex() != int64(i) || results[i].GetOutcome() != record.outcome {
+			t.Fatalf("expected record %d to be %s, got %v", i, record.outcome, results[i])
+		}
+
+		permission, err := srv.Permission.GetPermission(context.Background(), &pb.GetPermissionRequest{
+			FileID: fileID,
+			UserID: record.userID,
+		})
+		if err != nil {
+			t.Fatalf("GetPermission failed: %v", err)
+		}
+
+		if permission.GetRole() != record.want {
+			t.Fatalf("expected record %d to have role %s, got %s", i, record.want, permission.GetRole())
+		}
+	}
+}
+
+func TestGenerateUserDataReport(t *testing.T) {
+	fileID, userID, reader := newID("file"), newID("user"), newID("user")
+	createPermission(t, fileID, userID, pb.Role_WRITE, userID)
+	createPermission(t, fileID, reader, pb.Role_READ, userID)
+
+	stream, err := srv.Admin.GenerateUserDataReport(context.Background(), &pbv2.GenerateUserDataReportRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		t.Fatalf("GenerateUserDataReport failed: %v", err)
+	}
+
+	held, created := 0, 0
+	for {
+		record, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			t.Fatalf("GenerateUserDataReport failed: %v", err)
+		}
+
+		if record.GetHeldPermission() != nil {
+			held++
+		}
+
+		if record.GetCreatedPermission() != nil {
+			created++
+		}
+	}
+
+	// The permission that the user created for itself is only reported as held.
+	if held != 1 || created != 1 {
+		t.Fatalf("expected 1 held and 1 created permission, got %d held and %d created", held, created)
+	}
+}
+
+func TestEraseUserData(t *testing.T) {
+	fileID, userID, reader := newID("file"), newID("user"), newID("user")
+	createPermission(t, fileID, userID, pb.Role_WRITE, userID)
+	createPermission(t, fileID, reader, pb.Role_READ, userID)
+
+	res, err := srv.Admin.EraseUserData(context.Background(), &pbv2.EraseUserDataRequest{UserId: userID})
+	if err != nil {
+		t.Fatalf("EraseUserData failed: %v", err)
+	}
+
+	if res.GetDeletedPermissions() != 1 || res.GetAnonymizedPermissions() != 1 {
+		t.Fatalf("expected 1 deleted and 1 anonymized permission, got %v", res)
+	}
+
+	permission, err := srv.Permission.GetPermission(context.Background(), &pb.GetPermissionRequest{
+		FileID: fileID,
+		UserID: reader,
+	})
+	if err != nil {
+		t.Fatalf("GetPermission failed: %v", err)
+	}
+
+	if permission.GetCreator() == userID {
+		t.Fatalf("expected the creator %s to be erased, got %v", userID, permission)
+	}
+}
+
+func TestListDomainPermissions(t *testing.T) {
+	fileID, owner := newID("file"), newID("user")
+	createPermission(t, fileID, owner, pb.Role_WRITE, owner)
+	_, err := srv.Permission.CreatePermission(context.Background(), &pb.CreatePermissionRequest{
+		FileID:      fileID,
+		UserID:      testDomain,
+		Role:        pb.Role_READ,
+		Creator:     owner,
+		GranteeType: "domain",
+	})
+	if err != nil {
+		t.Fatalf("CreatePermission failed: %v", err)
+	}
+
+	found := false
+	pageToken := ""
+	for {
+		res, err := srv.Admin.ListDomainPermissions(context.Background(), &pbv2.ListDomainPermissionsRequest{
+			Domain:    testDomain,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			t.Fatalf("ListDomainPermissions failed: %v", err)
+		}
+
+		for _, permission := range res.GetPermissions() {
+			if permission.GetName() == "files/"+fileID+"/permissions/"+testDomain {
+				found = true
+			}
+		}
+
+		pageToken = res.GetNextPageToken()
+		if pageToken == "" {
+			break
+		}
+	}
+
+	if !found {
+		t.Fatalf("expected the permission of %s to %s to be listed", testDomain, fileID)
+	}
+}
+
+func TestExternalAccessWebhook(t *testing.T) {
+	fileID, owner := newID("file"), newID("user")
+	createPermission(t, fileID, owner, pb.Role_WRITE, owner)
+	_, err := srv.Permission.CreatePermission(context.Background(), &pb.CreatePermissionRequest{
+		FileID:      fileID,
+		UserID:      testDomain,
+		Role:        pb.Role_READ,
+		Creator:     owner,
+		GranteeType: "domain",
+	})
+	if err != nil {
+		t.Fatalf("CreatePermission failed: %v", err)
+	}
+
+	// The webhook only receives the events of permissions that make their files accessible externally,
+	// which are posted by the outbox relay.
+	timeout := time.After(30 * time.Second)
+	for {
+		select {
+		case postedFileID := <-webhookFileIDs:
+			if postedFileID == fileID {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("expected the external access to %s to be posted to the webhook", fileID)
+		}
+	}
+}
+
+func TestOutboxRelayResumes(t *testing.T) {
+	// The events are written by a server that doesn't relay them, of a collection prefix of its own,
+	// and must be relayed by the server that replaces it.
+	defer viper.Set("mongo_collection_prefix", "")
+	defer viper.Set("disabled_components", "")
+	stoppedServer, err := pstesting.NewServer(map[string]interface{}{
+		"mongo_collection_prefix": "outbox_resume_",
+		"disabled_components":     "outbox_relay",
+	})
+	if err != nil {
+		t.Fatalf("NewServer without the outbox relay failed: %v", err)
+	}
+
+	fileID, owner := newID("file"), newID("user")
+	for _, req := range []*pb.CreatePermissionRequest{
+		{FileID: fileID, UserID: owner, Role: pb.Role_WRITE, Creator: owner},
+		{FileID: fileID, UserID: testDomain, Role: pb.Role_READ, Creator: owner, GranteeType: "domain"},
+	} {
+		if _, err := stoppedServer.Permission.CreatePermission(context.Background(), req); err != nil {
+			stoppedServer.Close()
+			t.Fatalf("CreatePermission(%s, %s) failed: %v", fileID, req.GetUserID(), err)
+		}
+	}
+	stoppedServer.Close()
+
+	resumedServer, err := pstesting.NewServer(map[string]interface{}{"disabled_components": ""})
+	if err != nil {
+		t.Fatalf("NewServer with the outbox relay failed: %v", err)
+	}
+	defer resumedServer.Close()
+
+	timeout := time.After(30 * time.Second)
+	for {
+		select {
+		case postedFileID := <-webhookFileIDs:
+			if postedFileID == fileID {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("expected the event of %s to be relayed after the restart", fileID)
+		}
+	}
+}
+
+func TestListAnomalyAlerts(t *testing.T) {
+	fileID, creator := newID("file"), newID("user")
+	createPermission(t, fileID, creator, pb.Role_WRITE, creator)
+	for i := 1; i < testAnomalyGrants; i++ {
+		createPermission(t, fileID, newID("user"), pb.Role_READ, creator)
+	}
+
+	res, err := srv.Admin.ListAnomalyAlerts(context.Background(), &pbv2.ListAnomalyAlertsRequest{
+		Kind: "mass_grants",
+	})
+	if err != nil {
+		t.Fatalf("ListAnomalyAlerts failed: %v", err)
+	}
+
+	for _, alert := range res.GetAlerts() {
+		if alert.GetSubject() == creator {
+			if alert.GetCount() != testAnomalyGrants {
+				t.Errorf("expected the alert of %s to count %d grants, got %d",
+					creator, testAnomalyGrants, alert.GetCount())
+			}
+
+			return
+		}
+	}
+
+	t.Fatalf("expected an alert of the grants of %s, got %v", creator, res.GetAlerts())
+}
+
+func TestLockFile(t *testing.T) {
+	fileID, owner, userID := newID("file"), newID("user"), newID("user")
+	createPermission(t, fileID, owner, pb.Role_WRITE, owner)
+	createPermission(t, fileID, userID, pb.Role_READ, owner)
+
+	lock, err := srv.Admin.LockFile(context.Background(), &pbv2.LockFileRequest{
+		Resource: "files/" + fileID,
+		OwnerId:  owner,
+		Reason:   "leaked",
+	})
+	if err != nil {
+		t.Fatalf("LockFile failed: %v", err)
+	}
+
+	if lock.GetOwnerId() != owner || lock.GetReason() != "leaked" {
+		t.Errorf("expected the lock of %s by %s, got %v", fileID, owner, lock)
+	}
+
+	isPermitted := func(userID string) (*pb.IsPermittedResponse, error) {
+		return srv.Permission.IsPermitted(context.Background(), &pb.IsPermittedRequest{
+			FileID: fileID,
+			UserID: userID,
+			Role:   pb.Role_READ,
+		})
+	}
+
+	_, err = isPermitted(userID)
+	assertCode(t, err, codes.NotFound)
+
+	res, err := isPermitted(owner)
+	if err != nil || !res.GetPermitted() {
+		t.Fatalf("expected the owner to be permitted while locked, got %v, %v", res, err)
+	}
+
+	if _, err := srv.Admin.UnlockFile(context.Background(), &pbv2.UnlockFileRequest{
+		Resource: "files/" + fileID,
+	}); err != nil {
+		t.Fatalf("UnlockFile failed: %v", err)
+	}
+
+	res, err = isPermitted(userID)
+	if err != nil || !res.GetPermitted() {
+		t.Fatalf("expected the user to be permitted once unlocked, got %v, %v", res, err)
+	}
+
+	_, err = srv.Admin.UnlockFile(context.Background(), &pbv2.UnlockFileRequest{Resource: "files/" + fileID})
+	assertCode(t, err, codes.NotFound)
+}
+
+func TestPlaceLegalHold(t *testing.T) {
+	fileID, userID := newID("file"), newID("user")
+	createPermission(t, fileID, userID, pb.Role_READ, userID)
+
+	if _, err := srv.Admin.PlaceLegalHold(context.Background(), &pbv2.PlaceLegalHoldRequest{
+		Resource: "files/" + fileID,
+		Reason:   "case-1",
+	}); err != nil {
+		t.Fatalf("PlaceLegalHold failed: %v", err)
+	}
+
+	_, err := srv.Permission.DeletePermission(context.Background(), &pb.DeletePermissionRequest{
+		FileID: fileID,
+		UserID: userID,
+	})
+	assertCode(t, err, codes.FailedPrecondition)
+
+	_, err = srv.Permission.DeleteFilePermissions(context.Background(), &pb.DeleteFilePermissionsRequest{
+		FileID: fileID,
+	})
+	assertCode(t, err, codes.FailedPrecondition)
+
+	// New permissions may still be given while the file is held.
+	createPermission(t, fileID, newID("user"), pb.Role_READ, userID)
+
+	hold, err := srv.Admin.ReleaseLegalHold(context.Background(), &pbv2.ReleaseLegalHoldRequest{
+		Resource: "files/" + fileID,
+	})
+	if err != nil {
+		t.Fatalf("ReleaseLegalHold failed: %v", err)
+	}
+
+	if hold.GetReason() != "case-1" {
+		t.Errorf("expected the released hold of case-1, got %v", hold)
+	}
+
+	if _, err := srv.Permission.DeletePermission(context.Background(), &pb.DeletePermissionRequest{
+		FileID: fileID,
+		UserID: userID,
+	}); err != nil {
+		t.Fatalf("DeletePermission failed once released: %v", err)
+	}
+}
+
+func TestGetServerInfo(t *testing.T) {
+	info, err := srv.Admin.GetServerInfo(context.Background(), &pbv2.GetServerInfoRequest{})
+	if err != nil {
+		t.Fatalf("GetServerInfo failed: %v", err)
+	}
+
+	// The tests aren't built with the build info, so the version is the default.
+	if info.GetVersion() != "dev" || info.GetGoVersion() == "" || info.GetStartTime() == nil {
+		t.Errorf("expected the server info of a dev build, got %v", info)
+	}
+}
+
+func TestCreateJob(t *testing.T) {
+	fileID, owner := newID("file"), newID("user")
+	createPermission(t, fileID, owner, pb.Role_WRITE, owner)
+	createPermission(t, fileID, newID("user"), pb.Role_READ, owner)
+
+	job, err := srv.Admin.CreateJob(context.Background(), &pbv2.CreateJobRequest{
+		Operation: &pbv2.CreateJobRequest_DeletePermissions{
+			DeletePermissions: &pbv2.DeletePermissionsJob{Resources: []string{"files/" + fileID}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateJob failed: %v", err)
+	}
+
+	// The job runs in the background, poll it until it finishes.
+	deadline := time.Now().Add(10 * time.Second)
+	for job.GetState() == pbv2.JobState_RUNNING && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+		if job, err = srv.Admin.GetJob(context.Background(), &pbv2.GetJobRequest{Name: job.GetName()}); err != nil {
+			t.Fatalf("GetJob failed: %v", err)
+		}
+	}
+
+	if job.GetState() != pbv2.JobState_SUCCEEDED || job.GetDone() != 1 || job.GetFailed() != 0 {
+		t.Fatalf("expected the job to delete the permissions of 1 resource, got %v", job)
+	}
+
+	remaining, err := srv.Permission.GetFilePermissions(context.Background(), &pb.GetFilePermissionsRequest{
+		FileID: fileID,
+	})
+	if err != nil {
+		t.Fatalf("GetFilePermissions failed: %v", err)
+	}
+
+	if len(remaining.GetPermissions()) != 0 {
+		t.Fatalf("expected no permissions, got %v", remaining)
+	}
+
+	jobs, err := srv.Admin.ListJobs(context.Background(), &pbv2.ListJobsRequest{Kind: "delete_permissions"})
+	if err != nil {
+		t.Fatalf("ListJobs failed: %v", err)
+	}
+
+	if len(jobs.GetJobs()) == 0 || jobs.GetJobs()[0].GetName() != job.GetName() {
+		t.Errorf("expected the job to be listed first, got %v", jobs)
+	}
+
+	_, err = srv.Admin.CancelJob(context.Background(), &pbv2.CancelJobRequest{Name: job.GetName()})
+	assertCode(t, err, codes.FailedPrecondition)
+}
+
+func TestExplainAccess(t *testing.T) {
+	fileID, userID := newID("file"), newID("user")
+	createPermission(t, fileID, userID, pb.Role_READ, userID)
+
+	explain := func(userID string, role pbv2.Role) *pbv2.AccessExplanation {
+		explanation, err := srv.Admin.ExplainAccess(context.Background(), &pbv2.ExplainAccessRequest{
+			Resource: "files/" + fileID,
+			UserId:   userID,
+			Role:     role,
+		})
+		if err != nil {
+			t.Fatalf("ExplainAccess failed: %v", err)
+		}
+
+		return explanation
+	}
+
+	explanation := explain(userID, pbv2.Role_READ)
+	steps := explanation.GetSteps()
+	if !explanation.GetPermitted() || steps[len(steps)-1].GetEffect() != pbv2.AccessTraceStep_ALLOW {
+		t.Errorf("expected READ to be permitted by the user's role, got %v", explanation)
+	}
+
+	explanation = explain(userID, pbv2.Role_WRITE)
+	steps = explanation.GetSteps()
+	if explanation.GetPermitted() || steps[len(steps)-1].GetKind() != service.TraceRole {
+		t.Errorf("expected WRITE to be denied by the user's role, got %v", explanation)
+	}
+
+	explanation = explain(newID("user"), pbv2.Role_READ)
+	steps = explanation.GetSteps()
+	if explanation.GetPermitted() || steps[len(steps)-1].GetKind() != service.TraceGrant {
+		t.Errorf("expected a user without a permission to be denied, got %v", explanation)
+	}
+
+	_, err := srv.Admin.ExplainAccess(context.Background(), &pbv2.ExplainAccessRequest{Resource: "files/" + fileID})
+	assertCode(t, err, codes.InvalidArgument)
+}
+
+func TestPrewarmFiles(t *testing.T) {
+	fileID, otherFileID, userID := newID("file"), newID("file"), newID("user")
+	createPermission(t, fileID, userID, pb.Role_READ, userID)
+	createPermission(t, fileID, newID("user"), pb.Role_WRITE, userID)
+	createPermission(t, otherFileID, userID, pb.Role_READ, userID)
+
+	res, err := srv.Admin.PrewarmFiles(context.Background(), &pbv2.PrewarmFilesRequest{
+		Files: []string{"files/" + fileID, "files/" + otherFileID, "files/" + fileID},
+	})
+	if err != nil {
+		t.Fatalf("PrewarmFiles failed: %v", err)
+	}
+
+	if res.GetFiles() != 2 || res.GetPermissions() != 3 {
+		t.Errorf("PrewarmFiles = %v, expected 2 files of 3 permissions", res)
+	}
+
+	_, err = srv.Admin.PrewarmFiles(context.Background(), &pbv2.PrewarmFilesRequest{Files: []string{fileID}})
+	assertCode(t, err, codes.InvalidArgument)
+}
+
+func TestAssignOwner(t *testing.T) {
+	fileID, owner, userID := newID("file"), newID("user"), newID("user")
+	createPermission(t, fileID, owner, pb.Role_WRITE, owner)
+	createPermission(t, fileID, userID, pb.Role_READ, owner)
+
+	req := &pbv2.AssignOwnerRequest{Resource: "files/" + fileID, UserId: userID, Reason: "TICKET-1"}
+	_, err := srv.Admin.AssignOwner(context.Background(), req)
+	assertCode(t, err, codes.FailedPrecondition)
+
+	// The file is orphaned once the account of its owner is deleted.
+	_, err = srv.Admin.EraseUserData(context.Background(), &pbv2.EraseUserDataRequest{UserId: owner})
+	if err != nil {
+		t.Fatalf("EraseUserData failed: %v", err)
+	}
+
+	permission, err := srv.Admin.AssignOwner(context.Background(), req)
+	if err != nil {
+		t.Fatalf("AssignOwner failed: %v", err)
+	}
+
+	if permission.GetRole() != pbv2.Role_WRITE || permission.GetCreator() != userID {
+		t.Errorf("AssignOwner = %v, expected a WRITE permission created by %s", permission, userID)
+	}
+
+	// Assigning the owner again is a no-op.
+	again, err := srv.Admin.AssignOwner(context.Background(), req)
+	if err != nil {
+		t.Fatalf("AssignOwner failed: %v", err)
+	}
+
+	if again.GetEtag() != permission.GetEtag() {
+		t.Errorf("AssignOwner modified the permission of the owner, %v != %v", again, permission)
+	}
+
+	_, err = srv.Admin.AssignOwner(context.Background(), &pbv2.AssignOwnerRequest{
+		Resource: "files/" + fileID,
+		UserId:   newID("user"),
+		Reason:   "TICKET-2",
+	})
+	assertCode(t, err, codes.FailedPrecondition)
+}
+
+func TestListStaleGrants(t *testing.T) {
+	// The grants are of a collection prefix of their own, so that they're the only ones that may be stale.
+	defer viper.Set("mongo_collection_prefix", "")
+	staleServer, err := pstesting.NewServer(map[string]interface{}{"mongo_collection_prefix": "stale_grants_"})
+	if err != nil {
+		t.Fatalf("NewServer with a collection prefix failed: %v", err)
+	}
+	defer staleServer.Close()
+
+	// The owner's permission is created first, since the others are shared by it.
+	fileID, owner, unused, used := newID("file"), newID("user"), newID("user"), newID("user")
+	for _, userID := range []string{owner, unused, used} {
+		role := pb.Role_READ
+		if userID == owner {
+			role = pb.Role_WRITE
+		}
+
+		_, err := staleServer.Permission.CreatePermission(context.Background(), &pb.CreatePermissionRequest{
+			FileID:  fileID,
+			UserID:  userID,
+			Role:    role,
+			Creator: owner,
+		})
+		if err != nil {
+			t.Fatalf("CreatePermission(%s, %s) failed: %v", fileID, userID, err)
+		}
+	}
+
+	// The creation times of the grants are of a precision of a second.
+	time.Sleep(2 * time.Second)
+	_, err = staleServer.Permission.TouchPermission(context.Background(), &pb.TouchPermissionRequest{
+		FileID: fileID,
+		UserID: used,
+	})
+	if err != nil {
+		t.Fatalf("TouchPermission failed: %v", err)
+	}
+
+	res, err := staleServer.Admin.ListStaleGrants(context.Background(), &pbv2.ListStaleGrantsRequest{
+		OlderThan:          ptypes.DurationProto(500 * time.Millisecond),
+		ResourceCollection: "files",
+	})
+	if err != nil {
+		t.Fatalf("ListStaleGrants failed: %v", err)
+	}
+
+	if len(res.GetPermissions()) != 1 || res.GetPermissions()[0].GetUserId() != unused {
+		t.Errorf("ListStaleGrants = %v, expected only the grant of %s", res.GetPermissions(), unused)
+	}
+
+	res, err = staleServer.Admin.ListStaleGrants(context.Background(), &pbv2.ListStaleGrantsRequest{
+		OlderThan: ptypes.DurationProto(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("ListStaleGrants failed: %v", err)
+	}
+
+	if len(res.GetPermissions()) != 0 {
+		t.Errorf("ListStaleGrants = %v, expected no grants to be stale for an hour", res.GetPermissions())
+	}
+
+	_, err = staleServer.Admin.ListStaleGrants(context.Background(), &pbv2.ListStaleGrantsRequest{})
+	assertCode(t, err, codes.InvalidArgument)
+}
@@ -0,0 +1,96 @@
+//go:build integration
+// +build integration
+
+package testing_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	pb "github.com/meateam/permission-service/proto"
+	pstesting "github.com/meateam/permission-service/testing"
+	"github.com/spf13/viper"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+)
+
+// testCollectionPrefix is the prefix of the collections of the server of the database and collection names.
+const testCollectionPrefix = "staging_"
+
+func TestDatabaseAndCollectionNames(t *testing.T) {
+	ctx := context.Background()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoConnectionString))
+	if err != nil {
+		t.Fatalf("failed connecting to mongodb: %v", err)
+	}
+	defer client.Disconnect(ctx)
+
+	dbName := newID("staging")
+	db := client.Database(dbName)
+	defer db.Drop(ctx)
+
+	stagingServer, err := func() (*pstesting.Server, error) {
+		defer func() {
+			viper.Set("mongo_database", "")
+			viper.Set("mongo_collection_prefix", "")
+		}()
+
+		return pstesting.NewServer(map[string]interface{}{
+			"mongo_database":          dbName,
+			"mongo_collection_prefix": testCollectionPrefix,
+		})
+	}()
+	if err != nil {
+		t.Fatalf("creating the server of the database and collection names failed: %v", err)
+	}
+	defer stagingServer.Close()
+
+	fileID, userID := newID("file"), newID("user")
+	_, err = stagingServer.Permission.CreatePermission(ctx, &pb.CreatePermissionRequest{
+		FileID:  fileID,
+		UserID:  userID,
+		Role:    pb.Role_READ,
+		Creator: userID,
+	})
+	if err != nil {
+		t.Fatalf("CreatePermission failed: %v", err)
+	}
+
+	// The permission is stored in the prefixed collection of the configured database,
+	// and not in the database of the connection string, which the other servers use.
+	filter := bson.D{bson.E{Key: "fileID", Value: fileID}}
+	count, err := db.Collection(testCollectionPrefix+"permissions").CountDocuments(ctx, filter)
+	if err != nil || count != 1 {
+		t.Errorf("expected the permission in the prefixed collection, counted %d: %v", count, err)
+	}
+
+	count, err = client.Database(pstesting.DatabaseName).Collection("permissions").CountDocuments(ctx, filter)
+	if err != nil || count != 0 {
+		t.Errorf("expected the permission not to be in the default database, counted %d: %v", count, err)
+	}
+
+	_, err = srv.Permission.GetPermission(ctx, &pb.GetPermissionRequest{FileID: fileID, UserID: userID})
+	assertCode(t, err, codes.NotFound)
+
+	if _, err := stagingServer.Permission.GetPermission(ctx, &pb.GetPermissionRequest{
+		FileID: fileID,
+		UserID: userID,
+	}); err != nil {
+		t.Errorf("GetPermission failed: %v", err)
+	}
+
+	// Every collection of the store, including the ones of its indexes, is prefixed.
+	names, err := db.ListCollectionNames(ctx, bson.D{})
+	if err != nil {
+		t.Fatalf("failed listing the collections: %v", err)
+	}
+
+	for _, name := range names {
+		if !strings.HasPrefix(name, testCollectionPrefix) && !strings.HasPrefix(name, "system.") {
+			t.Errorf("expected the collection %s to be prefixed with %s", name, testCollectionPrefix)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+//go:build integration
+// +build integration
+
+package testing_test
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/meateam/permission-service/service/mongodb"
+	pstesting "github.com/meateam/permission-service/testing"
+	"github.com/spf13/viper"
+)
+
+func TestConcerns(t *testing.T) {
+	concernsServer, err := func() (*pstesting.Server, error) {
+		defer func() {
+			viper.Set("mongo_write_concern", mongodb.WriteConcernMajority)
+			viper.Set("mongo_write_timeout", 0)
+			viper.Set("mongo_projection_write_concern", "")
+			viper.Set("mongo_read_concern", "")
+		}()
+
+		return pstesting.NewServer(map[string]interface{}{
+			"mongo_write_concern":            "1",
+			"mongo_write_timeout":            5,
+			"mongo_projection_write_concern": mongodb.WriteConcernMajority,
+			"mongo_read_concern":             "majority",
+		})
+	}()
+	if err != nil {
+		t.Fatalf("creating the server of the concerns failed: %v", err)
+	}
+	defer concernsServer.Close()
+
+	fileID, userID := newID("file"), newID("user")
+	_, err = concernsServer.Permission.CreatePermission(context.Background(), &pb.CreatePermissionRequest{
+		FileID:  fileID,
+		UserID:  userID,
+		Role:    pb.Role_READ,
+		Creator: userID,
+	})
+	if err != nil {
+		t.Fatalf("CreatePermission failed: %v", err)
+	}
+
+	// The writes of the server are read back with its read concern.
+	res, err := concernsServer.Permission.IsPermitted(context.Background(), &pb.IsPermittedRequest{
+		FileID: fileID,
+		UserID: userID,
+		Role:   pb.Role_READ,
+	})
+	if err != nil {
+		t.Fatalf("IsPermitted failed: %v", err)
+	}
+
+	if !res.GetPermitted() {
+		t.Errorf("IsPermitted = false, expected true")
+	}
+}
@@ -0,0 +1,95 @@
+//go:build integration
+// +build integration
+
+package testing_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/meateam/permission-service/service"
+	pstesting "github.com/meateam/permission-service/testing"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDecisionLog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "decisions")
+	if err != nil {
+		t.Fatalf("failed creating the directory of the decision log: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "decisions.log")
+	decisionServer, err := func() (*pstesting.Server, error) {
+		defer viper.Set("decision_log", "")
+		return pstesting.NewServer(map[string]interface{}{"decision_log": path})
+	}()
+	if err != nil {
+		t.Fatalf("creating the server of the decision log failed: %v", err)
+	}
+	defer decisionServer.Close()
+
+	fileID, userID, otherUserID := newID("file"), newID("user"), newID("user")
+	createPermission(t, fileID, userID, pb.Role_READ, userID)
+
+	checks := []struct {
+		userID  string
+		role    pb.Role
+		outcome string
+	}{
+		{userID: userID, role: pb.Role_READ, outcome: service.OutcomeAllow},
+		{userID: userID, role: pb.Role_WRITE, outcome: service.OutcomeDeny},
+		{userID: otherUserID, role: pb.Role_READ, outcome: service.OutcomeDeny},
+	}
+	for _, check := range checks {
+		_, err := decisionServer.Permission.IsPermitted(context.Background(), &pb.IsPermittedRequest{
+			FileID: fileID,
+			UserID: check.userID,
+			Role:   check.role,
+		})
+		if err != nil && status.Code(err) != codes.NotFound {
+			t.Fatalf("IsPermitted failed: %v", err)
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed opening the decision log: %v", err)
+	}
+	defer file.Close()
+
+	// Each check is logged as a JSON line in the stable schema, apart from the service logs.
+	var decisions []service.Decision
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var decision service.Decision
+		if err := json.Unmarshal(scanner.Bytes(), &decision); err != nil {
+			t.Fatalf("expected a JSON line of a decision, got %q: %v", scanner.Text(), err)
+		}
+
+		if decision.FileID == fileID {
+			decisions = append(decisions, decision)
+		}
+	}
+
+	if len(decisions) != len(checks) {
+		t.Fatalf("expected %d decisions of %s, got %v", len(checks), fileID, decisions)
+	}
+
+	for i, check := range checks {
+		decision := decisions[i]
+		if decision.SchemaVersion != service.DecisionSchemaVersion || decision.Method != "IsPermitted" ||
+			decision.UserID != check.userID || decision.Role != check.role.String() ||
+			decision.Outcome != check.outcome {
+			t.Errorf("expected check %d to be %s, got %v", i, check.outcome, decision)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+//go:build integration
+// +build integration
+
+package testing_test
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/meateam/permission-service/proto"
+	"google.golang.org/grpc/codes"
+)
+
+func TestRetiredDeprecations(t *testing.T) {
+	userID := newID("user")
+	req := &pb.CreatePermissionRequest{
+		FileID:  newID("file"),
+		UserID:  userID,
+		Role:    pb.Role_READ,
+		Creator: userID,
+		Label:   "retired",
+	}
+
+	// The requests that set a deprecated field are rejected after its sunset.
+	_, err := srv.Permission.CreatePermission(context.Background(), req)
+	assertCode(t, err, codes.FailedPrecondition)
+
+	// The requests that don't set it aren't.
+	req.Label = ""
+	if _, err := srv.Permission.CreatePermission(context.Background(), req); err != nil {
+		t.Fatalf("CreatePermission failed: %v", err)
+	}
+}
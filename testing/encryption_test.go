@@ -0,0 +1,144 @@
+//go:build integration
+// +build integration
+
+package testing_test
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/meateam/permission-service/service/encryption"
+	pstesting "github.com/meateam/permission-service/testing"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc/codes"
+)
+
+// newEncryptionServer creates a server that encrypts the user identifiers with a new key,
+// over the permissions of srv, and the file of its key, which the caller should remove.
+func newEncryptionServer(t *testing.T) (*pstesting.Server, string) {
+	t.Helper()
+
+	key := make([]byte, encryption.KeySize)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatalf("failed generating the encryption key: %v", err)
+	}
+
+	keyFile, err := ioutil.TempFile("", "encryption-key")
+	if err != nil {
+		t.Fatalf("failed creating the encryption key file: %v", err)
+	}
+	defer keyFile.Close()
+
+	if _, err := keyFile.WriteString(base64.StdEncoding.EncodeToString(key)); err != nil {
+		os.Remove(keyFile.Name())
+		t.Fatalf("failed writing the encryption key: %v", err)
+	}
+
+	encryptionServer, err := func() (*pstesting.Server, error) {
+		defer viper.Set("user_id_encryption_key_file", "")
+
+		return pstesting.NewServer(map[string]interface{}{
+			"user_id_encryption_key_file": keyFile.Name(),
+		})
+	}()
+	if err != nil {
+		os.Remove(keyFile.Name())
+		t.Fatalf("creating the server of the encryption failed: %v", err)
+	}
+
+	return encryptionServer, keyFile.Name()
+}
+
+func TestEncryptionOverPlaintextPermissions(t *testing.T) {
+	fileID, userID, deletedUserID := newID("file"), newID("user"), newID("user")
+	createPermission(t, fileID, userID, pb.Role_READ, userID)
+	createPermission(t, fileID, deletedUserID, pb.Role_READ, deletedUserID)
+
+	encryptionServer, keyFile := newEncryptionServer(t)
+	defer os.Remove(keyFile)
+	defer encryptionServer.Close()
+
+	ctx := context.Background()
+	permission, err := encryptionServer.Permission.GetPermission(ctx, &pb.GetPermissionRequest{
+		FileID: fileID,
+		UserID: userID,
+	})
+	if err != nil {
+		t.Fatalf("GetPermission of the plaintext permission failed: %v", err)
+	}
+
+	if permission.GetUserID() != userID || permission.GetRole() != pb.Role_READ {
+		t.Errorf("expected the plaintext READ permission of %s, got %v", userID, permission)
+	}
+
+	userPermissions, err := encryptionServer.Permission.GetUserPermissions(ctx, &pb.GetUserPermissionsRequest{
+		UserID: userID,
+	})
+	if err != nil {
+		t.Fatalf("GetUserPermissions failed: %v", err)
+	}
+
+	if len(userPermissions.GetPermissions()) != 1 || userPermissions.GetPermissions()[0].GetFileID() != fileID {
+		t.Errorf("expected the plaintext permission of %s to be listed, got %v", fileID, userPermissions)
+	}
+
+	// Granting the user again updates its plaintext permission rather than storing another.
+	_, err = encryptionServer.Permission.CreatePermission(ctx, &pb.CreatePermissionRequest{
+		FileID:   fileID,
+		UserID:   userID,
+		Role:     pb.Role_WRITE,
+		Creator:  userID,
+		Override: true,
+	})
+	if err != nil {
+		t.Fatalf("CreatePermission over the plaintext permission failed: %v", err)
+	}
+
+	newUserID := newID("user")
+	if _, err := encryptionServer.Permission.CreatePermission(ctx, &pb.CreatePermissionRequest{
+		FileID:  fileID,
+		UserID:  newUserID,
+		Role:    pb.Role_READ,
+		Creator: userID,
+	}); err != nil {
+		t.Fatalf("CreatePermission failed: %v", err)
+	}
+
+	if _, err := encryptionServer.Permission.DeletePermission(ctx, &pb.DeletePermissionRequest{
+		FileID: fileID,
+		UserID: deletedUserID,
+	}); err != nil {
+		t.Fatalf("DeletePermission of the plaintext permission failed: %v", err)
+	}
+
+	// The stored permissions, as they're read without the key, are the updated plaintext permission
+	// and the encrypted permission of the new user.
+	stored, err := srv.Permission.GetFilePermissions(ctx, &pb.GetFilePermissionsRequest{FileID: fileID})
+	if err != nil {
+		t.Fatalf("GetFilePermissions failed: %v", err)
+	}
+
+	if len(stored.GetPermissions()) != 2 {
+		t.Fatalf("expected 2 stored permissions, got %v", stored.GetPermissions())
+	}
+
+	for _, permission := range stored.GetPermissions() {
+		switch {
+		case permission.GetUserID() == userID:
+			if permission.GetRole() != pb.Role_WRITE {
+				t.Errorf("expected the plaintext permission to be updated to WRITE, got %s", permission.GetRole())
+			}
+		case strings.Contains(permission.GetUserID(), newUserID):
+			t.Errorf("expected the permission of the new user to be encrypted, got %s", permission.GetUserID())
+		}
+	}
+
+	_, err = srv.Permission.GetPermission(ctx, &pb.GetPermissionRequest{FileID: fileID, UserID: deletedUserID})
+	assertCode(t, err, codes.NotFound)
+}
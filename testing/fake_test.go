@@ -0,0 +1,134 @@
+//go:build integration
+// +build integration
+
+package testing_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/meateam/permission-service/client/fake"
+	pb "github.com/meateam/permission-service/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// sharingFlow shares fileID through client as a downstream service would, and returns the outcome of each call,
+// so that the outcomes of the fake and of the permission service may be compared.
+func sharingFlow(client pb.PermissionClient, fileID string, owner string, reader string, other string) []string {
+	ctx := context.Background()
+	outcomes := []string{}
+	record := func(name string, result interface{}, err error) {
+		outcomes = append(outcomes, fmt.Sprintf("%s: %v %s", name, result, status.Code(err)))
+	}
+
+	created, err := client.CreatePermission(ctx, &pb.CreatePermissionRequest{
+		FileID:  fileID,
+		UserID:  owner,
+		Role:    pb.Role_WRITE,
+		Creator: owner,
+	})
+	record("create owner", created.GetRole(), err)
+
+	shared, err := client.CreatePermission(ctx, &pb.CreatePermissionRequest{
+		FileID:     fileID,
+		UserID:     reader,
+		Role:       pb.Role_READ,
+		Creator:    owner,
+		CanReshare: &wrappers.BoolValue{Value: false},
+	})
+	record("share", fmt.Sprint(shared.GetRole(), shared.GetCanReshare(), len(shared.GetSharingChain())), err)
+
+	_, err = client.CreatePermission(ctx, &pb.CreatePermissionRequest{
+		FileID:  fileID,
+		UserID:  other,
+		Role:    pb.Role_READ,
+		Creator: reader,
+	})
+	record("reshare", nil, err)
+
+	_, err = client.CreatePermission(ctx, &pb.CreatePermissionRequest{FileID: fileID, UserID: other})
+	record("invalid", nil, err)
+
+	for _, check := range []*pb.IsPermittedRequest{
+		{FileID: fileID, UserID: owner, Role: pb.Role_READ},
+		{FileID: fileID, UserID: reader, Role: pb.Role_READ},
+		{FileID: fileID, UserID: reader, Role: pb.Role_WRITE},
+		{FileID: fileID, UserID: reader, Capability: pb.Capability_EDIT},
+	} {
+		res, err := client.IsPermitted(ctx, check)
+		record("is permitted", res.GetPermitted(), err)
+	}
+
+	_, err = client.IsPermitted(ctx, &pb.IsPermittedRequest{FileID: fileID, UserID: other, Role: pb.Role_READ})
+	record("is permitted without permission", nil, err)
+
+	filePermissions, err := client.GetFilePermissions(ctx, &pb.GetFilePermissionsRequest{FileID: fileID})
+	record("file permissions", len(filePermissions.GetPermissions()), err)
+
+	updated, err := client.CreatePermission(ctx, &pb.CreatePermissionRequest{
+		FileID:   fileID,
+		UserID:   reader,
+		Role:     pb.Role_COMMENTER,
+		Creator:  owner,
+		Override: true,
+	})
+	record("override", fmt.Sprint(updated.GetRole(), updated.GetId() == shared.GetId()), err)
+
+	_, err = client.DeletePermission(ctx, &pb.DeletePermissionRequest{
+		FileID: fileID,
+		UserID: reader,
+		Etag:   shared.GetEtag(),
+	})
+	record("delete stale etag", nil, err)
+
+	deleted, err := client.DeletePermission(ctx, &pb.DeletePermissionRequest{
+		FileID: fileID,
+		UserID: reader,
+		Etag:   updated.GetEtag(),
+	})
+	record("delete", deleted.GetUserID() == reader, err)
+
+	_, err = client.GetPermission(ctx, &pb.GetPermissionRequest{FileID: fileID, UserID: reader})
+	record("get deleted", nil, err)
+
+	_, err = client.CreatePermission(ctx, &pb.CreatePermissionRequest{
+		FileID:  fileID,
+		UserID:  other,
+		Role:    pb.Role_READ,
+		Creator: owner,
+	})
+	record("share other", nil, err)
+
+	revoked, err := client.RevokeAllExceptOwner(ctx, &pb.RevokeAllExceptOwnerRequest{FileID: fileID, OwnerID: owner})
+	record("revoke all except owner", len(revoked.GetPermissions()), err)
+
+	return outcomes
+}
+
+func TestFakeParity(t *testing.T) {
+	client, err := fake.NewClient()
+	if err != nil {
+		t.Fatalf("failed creating the fake client: %v", err)
+	}
+	defer client.Close()
+
+	owner, reader, other := newID("user"), newID("user"), newID("user")
+	want := sharingFlow(srv.Permission, newID("file"), owner, reader, other)
+	got := sharingFlow(client, newID("file"), owner, reader, other)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected the fake's outcomes to be the service's:\n%v\ngot:\n%v", want, got)
+	}
+
+	// The failures that are injected into the fake fail the calls of their method until they're cleared.
+	client.Server.Fail("IsPermitted", status.Error(codes.Unavailable, "unavailable"))
+	_, err = client.IsPermitted(context.Background(), &pb.IsPermittedRequest{FileID: newID("file"), UserID: owner})
+	assertCode(t, err, codes.Unavailable)
+
+	client.Server.Fail("IsPermitted", nil)
+	_, err = client.IsPermitted(context.Background(), &pb.IsPermittedRequest{FileID: newID("file"), UserID: owner})
+	assertCode(t, err, codes.NotFound)
+}
@@ -0,0 +1,138 @@
+//go:build integration
+// +build integration
+
+package testing_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	pb "github.com/meateam/permission-service/proto"
+	pbv2 "github.com/meateam/permission-service/proto/v2"
+	"github.com/meateam/permission-service/service"
+	pstesting "github.com/meateam/permission-service/testing"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// testMaxRequestBytes is the maximum size of a request of the server of the limits.
+	testMaxRequestBytes = 4096
+
+	// testSimulateAccessBytes is the maximum size of a SimulateAccess request of the server of the limits,
+	// which is the largest limit, and so the limit of its transport.
+	testSimulateAccessBytes = 8192
+
+	// testIsPermittedBytes is the maximum size of an IsPermitted request of the server of the limits.
+	testIsPermittedBytes = 512
+
+	// testMaxListLength is the maximum number of items of a list of the server of the limits.
+	testMaxListLength = 50
+)
+
+// assertRejection fails t if err isn't of code and a rejection of rule.
+func assertRejection(t *testing.T, err error, code codes.Code, rule string) {
+	t.Helper()
+
+	assertCode(t, err, code)
+	if rejection, _ := service.RejectionFromError(err); rejection.Rule != rule {
+		t.Errorf("expected a rejection of %s, got %v", rule, err)
+	}
+}
+
+// simulateChanges returns count changes of the roles of users whose IDs are padded to userIDLength bytes.
+func simulateChanges(count int, userIDLength int) []*pbv2.AccessChange {
+	changes := make([]*pbv2.AccessChange, count)
+	for i := range changes {
+		userID := newID("user")
+		if len(userID) < userIDLength {
+			userID += strings.Repeat("x", userIDLength-len(userID))
+		}
+
+		changes[i] = &pbv2.AccessChange{UserId: userID, Role: pbv2.Role_READ}
+	}
+
+	return changes
+}
+
+func TestRequestLimits(t *testing.T) {
+	limitsServer, err := func() (*pstesting.Server, error) {
+		defer func() {
+			viper.Set("max_request_bytes", service.DefaultMaxRequestBytes)
+			viper.Set("request_byte_limits", "")
+			viper.Set("max_list_length", service.DefaultMaxListLength)
+		}()
+
+		return pstesting.NewServer(map[string]interface{}{
+			"max_request_bytes": testMaxRequestBytes,
+			"request_byte_limits": fmt.Sprintf(
+				"SimulateAccess=%d,/permission.Permission/IsPermitted=%d",
+				testSimulateAccessBytes,
+				testIsPermittedBytes,
+			),
+			"max_list_length": testMaxListLength,
+		})
+	}()
+	if err != nil {
+		t.Fatalf("creating the server of the limits failed: %v", err)
+	}
+	defer limitsServer.Close()
+
+	ctx := context.Background()
+	fileID, userID := newID("file"), newID("user")
+	createPermission(t, fileID, userID, pb.Role_READ, userID)
+
+	// The requests of the RPCs without limits of their own are limited by the default limit.
+	_, err = limitsServer.Permission.GetPermission(ctx, &pb.GetPermissionRequest{FileID: fileID, UserID: userID})
+	if err != nil {
+		t.Fatalf("GetPermission of a request within the limit failed: %v", err)
+	}
+
+	_, err = limitsServer.Permission.GetPermission(ctx, &pb.GetPermissionRequest{
+		FileID: fileID,
+		UserID: userID + strings.Repeat("x", testMaxRequestBytes),
+	})
+	assertRejection(t, err, codes.ResourceExhausted, "max_request_bytes")
+
+	// The requests of an RPC with a limit of its own may be smaller, or larger, than the default limit.
+	_, err = limitsServer.Permission.IsPermitted(ctx, &pb.IsPermittedRequest{
+		FileID: fileID,
+		UserID: userID,
+		Role:   pb.Role_READ,
+	})
+	if err != nil {
+		t.Errorf("IsPermitted of a request within its own limit failed: %v", err)
+	}
+
+	_, err = limitsServer.Permission.IsPermitted(ctx, &pb.IsPermittedRequest{
+		FileID: fileID,
+		UserID: userID + strings.Repeat("x", testIsPermittedBytes),
+		Role:   pb.Role_READ,
+	})
+	assertRejection(t, err, codes.ResourceExhausted, "max_request_bytes")
+
+	parent := "files/" + fileID
+	_, err = limitsServer.Permissions.SimulateAccess(ctx, &pbv2.SimulateAccessRequest{
+		Parent:  parent,
+		Changes: simulateChanges(testMaxListLength, 100),
+	})
+	if err != nil {
+		t.Errorf("SimulateAccess of a request larger than the default limit failed: %v", err)
+	}
+
+	// Messages larger than every limit are rejected by the transport before they're decoded.
+	_, err = limitsServer.Permission.GetPermission(ctx, &pb.GetPermissionRequest{
+		FileID: fileID,
+		UserID: userID + strings.Repeat("x", testSimulateAccessBytes),
+	})
+	assertCode(t, err, codes.ResourceExhausted)
+
+	// Lists longer than the limit are invalid, however small the request is.
+	_, err = limitsServer.Permissions.SimulateAccess(ctx, &pbv2.SimulateAccessRequest{
+		Parent:  parent,
+		Changes: simulateChanges(testMaxListLength+1, 0),
+	})
+	assertRejection(t, err, codes.InvalidArgument, "max_list_length")
+}
@@ -0,0 +1,154 @@
+//go:build integration
+// +build integration
+
+package testing_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	pb "github.com/meateam/permission-service/proto"
+	pstesting "github.com/meateam/permission-service/testing"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// mongoStartTimeout is the timeout of starting the MongoDB container of the tests.
+	mongoStartTimeout = 2 * time.Minute
+
+	// testDomain is the domain of the organization that may be given permissions in the tests.
+	testDomain = "example.org"
+
+	// testAnomalyGrants is the number of permissions given by an actor that raises an alert in the tests.
+	testAnomalyGrants = 10
+
+	// testMaxReshareDepth is the maximum number of users a permission may be shared through in the tests.
+	testMaxReshareDepth = 3
+
+	// testRetiredField is a deprecated field whose sunset passed in the tests.
+	testRetiredField = "permission.CreatePermissionRequest.label"
+
+	// requireDockerEnv is the environment variable that fails the tests, rather than skipping them,
+	// if docker isn't available, so that an environment without docker doesn't pass them silently.
+	requireDockerEnv = "INTEGRATION_REQUIRE_DOCKER"
+)
+
+// srv is the permission server that the tests share.
+var srv *pstesting.Server
+
+// mongoConnectionString is the connection string of the database of srv, for the tests of the stored documents.
+var mongoConnectionString string
+
+// webhookFileIDs receives the file IDs of the events that are posted to the external access webhook.
+var webhookFileIDs = make(chan string, 100)
+
+func TestMain(m *testing.M) {
+	os.Exit(run(m))
+}
+
+// run starts a MongoDB container and a permission server, runs the tests,
+// removes the container and returns the exit code of the tests.
+// The tests are skipped if docker isn't available, unless requireDockerEnv is set.
+func run(m *testing.M) int {
+	ctx, cancel := context.WithTimeout(context.Background(), mongoStartTimeout)
+	defer cancel()
+
+	if err := pstesting.CheckDocker(ctx); err != nil {
+		if os.Getenv(requireDockerEnv) != "" {
+			fmt.Fprintf(os.Stderr, "the integration tests require docker: %v\n", err)
+			return 1
+		}
+
+		fmt.Fprintf(os.Stderr, "SKIP: the integration tests require docker, which isn't available: %v\n", err)
+		return 0
+	}
+
+	mongo, err := pstesting.StartMongo(ctx)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer mongo.Close()
+	mongoConnectionString = mongo.ConnectionString
+
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event struct {
+			FileID string `json:"fileID"`
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		select {
+		case webhookFileIDs <- event.FileID:
+		default:
+		}
+	}))
+	defer webhook.Close()
+
+	srv, err = pstesting.NewServer(map[string]interface{}{
+		"mongo_host":                  mongo.ConnectionString,
+		"outbox_enabled":              true,
+		"domain_grants":               testDomain,
+		"anomaly_grants_per_actor":    testAnomalyGrants,
+		"external_access_webhook_url": webhook.URL,
+		"max_reshare_depth":           testMaxReshareDepth,
+		"shared_with_me_projection":   true,
+		"deprecation_sunsets":         testRetiredField + "=2000-01-01",
+		"actor_gateways":              "*",
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer srv.Close()
+
+	return m.Run()
+}
+
+// newID returns a unique ID, with prefix, of a file or a user so that the tests don't affect each other.
+func newID(prefix string) string {
+	return prefix + "-" + primitive.NewObjectID().Hex()
+}
+
+// createPermission creates a permission of userID to fileID with role by creator, and fails t if it fails.
+func createPermission(
+	t *testing.T,
+	fileID string,
+	userID string,
+	role pb.Role,
+	creator string,
+) *pb.PermissionObject {
+	t.Helper()
+
+	permission, err := srv.Permission.CreatePermission(context.Background(), &pb.CreatePermissionRequest{
+		FileID:  fileID,
+		UserID:  userID,
+		Role:    role,
+		Creator: creator,
+	})
+	if err != nil {
+		t.Fatalf("CreatePermission(%s, %s) failed: %v", fileID, userID, err)
+	}
+
+	return permission
+}
+
+// assertCode fails t if the code of err isn't code.
+func assertCode(t *testing.T, err error, code codes.Code) {
+	t.Helper()
+
+	if status.Code(err) != code {
+		t.Fatalf("expected error code %s, got %v", code, err)
+	}
+}
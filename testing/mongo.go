@@ -0,0 +1,125 @@
+// Package testing provides the helpers of the integration tests of the permission service,
+// which start a MongoDB container, serve the permission server on an in-memory listener
+// and connect its clients to it. The containers are started with the docker CLI,
+// which has to be installed wherever the integration tests run. They aren't started with
+// testcontainers-go, since its releases since v0.14.0 require upgrading grpc from 1.23 to 1.47,
+// and genproto along with it, which the generated protos and the middlewares of the service depend on.
+package testing
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const (
+	// MongoImage is the image of the MongoDB containers.
+	MongoImage = "mongo:4.2"
+
+	// DatabaseName is the name of the database of the permission server in the MongoDB containers.
+	DatabaseName = "permission"
+
+	// mongoPort is the port that MongoDB listens on in the containers.
+	mongoPort = "27017/tcp"
+
+	// replicaSetName is the name of the single member replica set of the containers,
+	// which the sessions, transactions and change streams of the outbox depend on.
+	replicaSetName = "rs0"
+
+	// mongoReadyInterval is the interval between checks whether a started container is ready.
+	mongoReadyInterval = 500 * time.Millisecond
+)
+
+// Mongo is a MongoDB container that's removed when it's closed.
+type Mongo struct {
+	containerID string
+
+	// ConnectionString is the connection string of the permission database in the container.
+	ConnectionString string
+}
+
+// StartMongo starts a MongoDB container and returns it once its replica set has a primary,
+// or any error if occurred. The container is removed if it doesn't get ready until ctx is done.
+func StartMongo(ctx context.Context) (*Mongo, error) {
+	containerID, err := docker(
+		ctx,
+		"run",
+		"--detach",
+		"--rm",
+		"--publish", "127.0.0.1::"+mongoPort,
+		MongoImage,
+		"--replSet", replicaSetName,
+		"--bind_ip_all",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed starting mongodb container: %v", err)
+	}
+
+	mongo := &Mongo{containerID: containerID}
+	address, err := docker(ctx, "port", containerID, mongoPort)
+	if err != nil {
+		mongo.Close()
+		return nil, fmt.Errorf("failed getting the address of mongodb container %s: %v", containerID, err)
+	}
+
+	// The port may be published on several addresses, each on its own line.
+	address = strings.SplitN(address, "\n", 2)[0]
+	mongo.ConnectionString = fmt.Sprintf("mongodb://%s/%s?connect=direct", address, DatabaseName)
+	if err := mongo.initiateReplicaSet(ctx); err != nil {
+		mongo.Close()
+		return nil, err
+	}
+
+	return mongo, nil
+}
+
+// initiateReplicaSet initiates the replica set of the container and waits until it has a primary.
+// The replica set is initiated once mongod accepts connections, which is retried until ctx is done.
+func (m *Mongo) initiateReplicaSet(ctx context.Context) error {
+	const script = "rs.initiate(); while (!db.isMaster().ismaster) { sleep(100); }"
+
+	ticker := time.NewTicker(mongoReadyInterval)
+	defer ticker.Stop()
+
+	for {
+		_, err := docker(ctx, "exec", m.containerID, "mongo", "--quiet", "--eval", script)
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("mongodb container %s is not ready: %v", m.containerID, err)
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckDocker returns an error if the docker CLI isn't installed or can't reach the docker daemon,
+// in which case the MongoDB containers can't be started.
+func CheckDocker(ctx context.Context) error {
+	_, err := docker(ctx, "version", "--format", "{{.Server.Version}}")
+	return err
+}
+
+// Close removes the container.
+func (m *Mongo) Close() error {
+	_, err := docker(context.Background(), "rm", "--force", m.containerID)
+	return err
+}
+
+// docker runs the docker CLI with args and returns its trimmed output, or any error if occurred.
+func docker(ctx context.Context, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("docker %s: %v: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,1072 @@
+//go:build integration
+// +build integration
+
+package testing_test
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/meateam/permission-service/client"
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/meateam/permission-service/service"
+	"github.com/meateam/permission-service/service/mongodb"
+	pstesting "github.com/meateam/permission-service/testing"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestHealthCheck(t *testing.T) {
+	res, err := srv.Health.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	if res.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+		t.Fatalf("expected status SERVING, got %s", res.GetStatus())
+	}
+
+	// The server is serving once a health check found it healthy.
+	if expvarCount("health_checks", service.HealthCheckHealthy) == 0 {
+		t.Errorf("expected the healthy checks to be counted in health_checks")
+	}
+
+	if serving := expvar.Get("health_serving").String(); serving != "1" {
+		t.Errorf("health_serving = %s, expected 1", serving)
+	}
+}
+
+func TestDisabledComponents(t *testing.T) {
+	defer viper.Set("disabled_components", "")
+
+	// The health check worker may not be disabled, since the server isn't serving without it.
+	for _, disabled := range []string{"health", "unknown"} {
+		if _, err := pstesting.NewServer(map[string]interface{}{"disabled_components": disabled}); err == nil {
+			t.Errorf("NewServer with disabled components %q succeeded, expected it to fail", disabled)
+		}
+	}
+
+	componentsServer, err := pstesting.NewServer(map[string]interface{}{
+		"disabled_components": "scheduler, recurring_jobs",
+	})
+	if err != nil {
+		t.Fatalf("NewServer with disabled components failed: %v", err)
+	}
+
+	if err := componentsServer.Close(); err != nil {
+		t.Errorf("Close failed: %v", err)
+	}
+}
+
+func TestSelfTest(t *testing.T) {
+	defer viper.Set("self_test", false)
+	defer viper.Set("self_test_file_id", service.DefaultSelfTestFileID)
+
+	fileID := newID("file")
+	selfTestServer, err := pstesting.NewServer(map[string]interface{}{
+		"self_test":         true,
+		"self_test_file_id": fileID,
+	})
+	if err != nil {
+		t.Fatalf("NewServer with self-test failed: %v", err)
+	}
+	defer selfTestServer.Close()
+
+	// The self-test is run once the server is serving.
+	request := &grpc_health_v1.HealthCheckRequest{Service: service.SelfTestHealthService}
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		res, err := selfTestServer.Health.Check(context.Background(), request)
+		if err != nil {
+			t.Fatalf("Check of the self-test failed: %v", err)
+		}
+
+		if res.GetStatus() == grpc_health_v1.HealthCheckResponse_SERVING {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the self-test to pass, its status is %s", res.GetStatus())
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	if passed := expvar.Get("self_test_passed").String(); passed != "1" {
+		t.Errorf("self_test_passed = %s, expected 1", passed)
+	}
+
+	// The self-test deletes its permission.
+	res, err := srv.Permission.GetFilePermissions(
+		context.Background(),
+		&pb.GetFilePermissionsRequest{FileID: fileID},
+	)
+	if err != nil {
+		t.Fatalf("GetFilePermissions failed: %v", err)
+	}
+
+	if len(res.GetPermissions()) != 0 {
+		t.Errorf("expected the self-test's permission to be deleted, got %v", res.GetPermissions())
+	}
+}
+
+func TestFaultInjection(t *testing.T) {
+	defer viper.Set("fault_injection", "")
+
+	if _, err := pstesting.NewServer(map[string]interface{}{"fault_injection": "Unknown=unavailable"}); err == nil {
+		t.Errorf("NewServer with a fault of an unknown method succeeded, expected it to fail")
+	}
+
+	faultsServer, err := pstesting.NewServer(map[string]interface{}{
+		"fault_injection": "ExistingIDs=unavailable@1,Get=2s",
+	})
+	if err != nil {
+		t.Fatalf("NewServer with fault injection failed: %v", err)
+	}
+	defer faultsServer.Close()
+
+	_, err = faultsServer.Permission.PermissionsExist(
+		context.Background(),
+		&pb.PermissionsExistRequest{Ids: []string{newID("permission")}},
+	)
+	assertCode(t, err, codes.Unavailable)
+
+	// The injected latency exceeds the deadline of the call.
+	fileID, userID := newID("file"), newID("user")
+	createPermission(t, fileID, userID, pb.Role_READ, userID)
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	_, err = faultsServer.Permission.GetPermission(ctx, &pb.GetPermissionRequest{FileID: fileID, UserID: userID})
+	assertCode(t, err, codes.DeadlineExceeded)
+
+	if expvarCount("injected_faults", "ExistingIDs/error") == 0 {
+		t.Errorf("expected the injected errors to be counted in injected_faults")
+	}
+}
+
+func TestCreatePermission(t *testing.T) {
+	fileID, userID, creator := newID("file"), newID("user"), newID("user")
+	createPermission(t, fileID, creator, pb.Role_WRITE, creator)
+	permission := createPermission(t, fileID, userID, pb.Role_READ, creator)
+	if permission.GetFileID() != fileID || permission.GetUserID() != userID {
+		t.Fatalf("expected permission of %s to %s, got %v", userID, fileID, permission)
+	}
+
+	if permission.GetRole() != pb.Role_READ || permission.GetCreator() != creator || !permission.GetCanReshare() {
+		t.Fatalf("unexpected permission %v", permission)
+	}
+
+	_, err := srv.Permission.CreatePermission(context.Background(), &pb.CreatePermissionRequest{
+		FileID:  fileID,
+		Role:    pb.Role_READ,
+		Creator: creator,
+	})
+	if err == nil {
+		t.Fatalf("expected CreatePermission without userID to fail")
+	}
+}
+
+func TestGetPermission(t *testing.T) {
+	fileID, userID := newID("file"), newID("user")
+	createPermission(t, fileID, userID, pb.Role_WRITE, userID)
+
+	permission, err := srv.Permission.GetPermission(context.Background(), &pb.GetPermissionRequest{
+		FileID: fileID,
+		UserID: userID,
+	})
+	if err != nil {
+		t.Fatalf("GetPermission failed: %v", err)
+	}
+
+	if permission.GetRole() != pb.Role_WRITE {
+		t.Fatalf("expected role WRITE, got %s", permission.GetRole())
+	}
+
+	_, err = srv.Permission.GetPermission(context.Background(), &pb.GetPermissionRequest{
+		FileID: fileID,
+		UserID: newID("user"),
+	})
+	assertCode(t, err, codes.NotFound)
+}
+
+func TestConsistencyToken(t *testing.T) {
+	fileID, userID := newID("file"), newID("user")
+	createPermission(t, fileID, userID, pb.Role_READ, userID)
+
+	var header metadata.MD
+	_, err := srv.Permission.GetPermission(
+		context.Background(),
+		&pb.GetPermissionRequest{FileID: fileID, UserID: userID},
+		grpc.Header(&header),
+	)
+	if err != nil {
+		t.Fatalf("GetPermission failed: %v", err)
+	}
+
+	if len(header.Get(mongodb.ConsistencyTokenHeader)) == 0 {
+		t.Errorf("expected the response of GetPermission to have a consistency token")
+	}
+
+	// The token of a request that failed is set too, since its reads advanced the session.
+	header = nil
+	_, err = srv.Permission.GetPermission(
+		context.Background(),
+		&pb.GetPermissionRequest{FileID: fileID, UserID: newID("user")},
+		grpc.Header(&header),
+	)
+	assertCode(t, err, codes.NotFound)
+
+	tokens := header.Get(mongodb.ConsistencyTokenHeader)
+	if len(tokens) == 0 {
+		t.Fatalf("expected the NotFound response of GetPermission to have a consistency token")
+	}
+
+	tokenCtx := metadata.AppendToOutgoingContext(
+		context.Background(),
+		mongodb.ConsistencyTokenHeader,
+		tokens[len(tokens)-1],
+	)
+	if _, err := srv.Permission.GetPermission(tokenCtx, &pb.GetPermissionRequest{
+		FileID: fileID,
+		UserID: userID,
+	}); err != nil {
+		t.Errorf("GetPermission with the token of a NotFound response failed: %v", err)
+	}
+}
+
+func TestDeletePermission(t *testing.T) {
+	fileID, userID := newID("file"), newID("user")
+	createPermission(t, fileID, userID, pb.Role_READ, userID)
+
+	deleted, err := srv.Permission.DeletePermission(context.Background(), &pb.DeletePermissionRequest{
+		FileID: fileID,
+		UserID: userID,
+	})
+	if err != nil {
+		t.Fatalf("DeletePermission failed: %v", err)
+	}
+
+	if deleted.GetUserID() != userID {
+		t.Fatalf("expected the deleted permission of %s, got %v", userID, deleted)
+	}
+
+	_, err = srv.Permission.GetPermission(context.Background(), &pb.GetPermissionRequest{
+		FileID: fileID,
+		UserID: userID,
+	})
+	assertCode(t, err, codes.NotFound)
+}
+
+func TestGetFilePermissions(t *testing.T) {
+	fileID := newID("file")
+	for i := 0; i < 3; i++ {
+		userID := newID("user")
+		createPermission(t, fileID, userID, pb.Role_READ, userID)
+	}
+
+	res, err := srv.Permission.GetFilePermissions(context.Background(), &pb.GetFilePermissionsRequest{
+		FileID: fileID,
+	})
+	if err != nil {
+		t.Fatalf("GetFilePermissions failed: %v", err)
+	}
+
+	if len(res.GetPermissions()) != 3 || res.GetNextPageToken() != "" {
+		t.Fatalf("expected all 3 permissions in a single page, got %v", res)
+	}
+
+	firstPage, err := srv.Permission.GetFilePermissions(context.Background(), &pb.GetFilePermissionsRequest{
+		FileID:   fileID,
+		PageSize: 2,
+	})
+	if err != nil {
+		t.Fatalf("GetFilePermissions failed: %v", err)
+	}
+
+	if len(firstPage.GetPermissions()) != 2 || firstPage.GetNextPageToken() == "" {
+		t.Fatalf("expected a first page of 2 permissions, got %v", firstPage)
+	}
+
+	secondPage, err := srv.Permission.GetFilePermissions(context.Background(), &pb.GetFilePermissionsRequest{
+		FileID:    fileID,
+		PageSize:  2,
+		PageToken: firstPage.GetNextPageToken(),
+	})
+	if err != nil {
+		t.Fatalf("GetFilePermissions failed: %v", err)
+	}
+
+	if len(secondPage.GetPermissions()) != 1 || secondPage.GetNextPageToken() != "" {
+		t.Fatalf("expected a last page of 1 permission, got %v", secondPage)
+	}
+
+	for _, permission := range firstPage.GetPermissions() {
+		if permission.GetUserID() == secondPage.GetPermissions()[0].GetUserID() {
+			t.Fatalf("permission of %s is in both pages", permission.GetUserID())
+		}
+	}
+}
+
+func TestGetUserPermissions(t *testing.T) {
+	userID := newID("user")
+	fileIDs := []string{newID("file"), newID("file"), newID("file")}
+	for _, fileID := range fileIDs {
+		createPermission(t, fileID, userID, pb.Role_READ, userID)
+	}
+
+	_, err := srv.Permission.TouchPermission(context.Background(), &pb.TouchPermissionRequest{
+		FileID: fileIDs[1],
+		UserID: userID,
+	})
+	if err != nil {
+		t.Fatalf("TouchPermission failed: %v", err)
+	}
+
+	firstPage, err := srv.Permission.GetUserPermissions(context.Background(), &pb.GetUserPermissionsRequest{
+		UserID:   userID,
+		Order:    pb.PermissionsOrder_RECENTLY_ACCESSED,
+		PageSize: 1,
+	})
+	if err != nil {
+		t.Fatalf("GetUserPermissions failed: %v", err)
+	}
+
+	if len(firstPage.GetPermissions()) != 1 || firstPage.GetPermissions()[0].GetFileID() != fileIDs[1] {
+		t.Fatalf("expected the recently accessed file %s first, got %v", fileIDs[1], firstPage)
+	}
+
+	nextPage, err := srv.Permission.GetUserPermissions(context.Background(), &pb.GetUserPermissionsRequest{
+		UserID:    userID,
+		Order:     pb.PermissionsOrder_RECENTLY_ACCESSED,
+		PageSize:  10,
+		PageToken: firstPage.GetNextPageToken(),
+	})
+	if err != nil {
+		t.Fatalf("GetUserPermissions failed: %v", err)
+	}
+
+	if len(nextPage.GetPermissions()) != 2 || nextPage.GetNextPageToken() != "" {
+		t.Fatalf("expected the 2 files that weren't accessed in the last page, got %v", nextPage)
+	}
+}
+
+func TestIsPermitted(t *testing.T) {
+	fileID, userID := newID("file"), newID("user")
+	createPermission(t, fileID, userID, pb.Role_READ, userID)
+
+	tests := []struct {
+		role      pb.Role
+		permitted bool
+	}{
+		{role: pb.Role_READ, permitted: true},
+		{role: pb.Role_WRITE, permitted: false},
+	}
+	for _, tt := range tests {
+		res, err := srv.Permission.IsPermitted(context.Background(), &pb.IsPermittedRequest{
+			FileID: fileID,
+			UserID: userID,
+			Role:   tt.role,
+		})
+		if err != nil {
+			t.Fatalf("IsPermitted(%s) failed: %v", tt.role, err)
+		}
+
+		if res.GetPermitted() != tt.permitted {
+			t.Errorf("IsPermitted(%s) = %v, expected %v", tt.role, res.GetPermitted(), tt.permitted)
+		}
+
+		// The permission was just created so its checks may only be cached for the minimum TTL.
+		if res.GetMaxAge().GetSeconds() != 5 {
+			t.Errorf("IsPermitted(%s) maxAge = %v, expected 5s", tt.role, res.GetMaxAge())
+		}
+	}
+}
+
+func TestCacheHintMetrics(t *testing.T) {
+	fileID, userID := newID("file"), newID("user")
+	createPermission(t, fileID, userID, pb.Role_READ, userID)
+
+	found := service.DefaultResourceType + "/" + service.CacheHintFound
+	notFound := service.DefaultResourceType + "/" + service.CacheHintNotFound
+	minBound := service.DefaultResourceType + "/min"
+	before := map[string]int64{
+		found:    expvarCount("cache_hints", found),
+		notFound: expvarCount("cache_hints", notFound),
+		minBound: expvarCount("cache_hint_bounds", minBound),
+	}
+
+	for _, checkedUserID := range []string{userID, newID("user")} {
+		req := &pb.IsPermittedRequest{FileID: fileID, UserID: checkedUserID, Role: pb.Role_READ}
+		if _, err := srv.Permission.IsPermitted(context.Background(), req); err != nil {
+			t.Fatalf("IsPermitted(%s) failed: %v", checkedUserID, err)
+		}
+	}
+
+	// The permission was just created so its check is bounded by the minimum TTL.
+	after := map[string]int64{
+		found:    expvarCount("cache_hints", found),
+		notFound: expvarCount("cache_hints", notFound),
+		minBound: expvarCount("cache_hint_bounds", minBound),
+	}
+	for key, count := range before {
+		if after[key] != count+1 {
+			t.Errorf("metric %s = %d, expected %d", key, after[key], count+1)
+		}
+	}
+}
+
+// expvarCount returns the count of key in the expvar map name, 0 if it wasn't counted.
+func expvarCount(name string, key string) int64 {
+	metric, ok := expvar.Get(name).(*expvar.Map)
+	if !ok {
+		return 0
+	}
+
+	count, ok := metric.Get(key).(*expvar.Int)
+	if !ok {
+		return 0
+	}
+
+	return count.Value()
+}
+
+func TestCheckPermissionsMatrix(t *testing.T) {
+	fileID, userID := newID("file"), newID("user")
+	createPermission(t, fileID, userID, pb.Role_READ, userID)
+
+	hits := expvarCount("memoized_reads", "permission/hit")
+	res, err := srv.Permission.CheckPermissionsMatrix(context.Background(), &pb.CheckPermissionsMatrixRequest{
+		Checks: []*pb.IsPermittedRequest{
+			{FileID: fileID, UserID: userID, Role: pb.Role_READ},
+			{FileID: fileID, UserID: userID, Role: pb.Role_WRITE},
+			{FileID: newID("file"), UserID: userID, Role: pb.Role_READ},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CheckPermissionsMatrix failed: %v", err)
+	}
+
+	results := res.GetResults()
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %v", res)
+	}
+
+	if codes.Code(results[0].GetCode()) != codes.OK || !results[0].GetPermitted() {
+		t.Errorf("expected the READ check to be permitted, got %v", results[0])
+	}
+
+	if codes.Code(results[1].GetCode()) != codes.OK || results[1].GetPermitted() {
+		t.Errorf("expected the WRITE check not to be permitted, got %v", results[1])
+	}
+
+	if codes.Code(results[2].GetCode()) != codes.NotFound || results[2].GetPermitted() {
+		t.Errorf("expected the check of a file without a permission to be not found, got %v", results[2])
+	}
+
+	// The permission of the first two checks is only fetched once.
+	if expvarCount("memoized_reads", "permission/hit") == hits {
+		t.Errorf("expected the repeated permission of the checks to be memoized")
+	}
+}
+
+func TestBatchingClient(t *testing.T) {
+	fileIDs, userID := []string{newID("file"), newID("file"), newID("file")}, newID("user")
+	for _, fileID := range fileIDs[:2] {
+		createPermission(t, fileID, userID, pb.Role_READ, userID)
+	}
+
+	batching := client.NewBatchingClient(srv.Permission, 20*time.Millisecond, 0)
+	responses := make([]*pb.IsPermittedResponse, len(fileIDs))
+	errs := make([]error, len(fileIDs))
+
+	var wg sync.WaitGroup
+	for i, fileID := range fileIDs {
+		wg.Add(1)
+		go func(i int, fileID string) {
+			defer wg.Done()
+			responses[i], errs[i] = batching.IsPermitted(context.Background(), &pb.IsPermittedRequest{
+				FileID: fileID,
+				UserID: userID,
+				Role:   pb.Role_READ,
+			})
+		}(i, fileID)
+	}
+
+	wg.Wait()
+
+	for i := range fileIDs[:2] {
+		if errs[i] != nil || !responses[i].GetPermitted() {
+			t.Errorf("expected the check of file %d to be permitted, got %v, %v", i, responses[i], errs[i])
+		}
+	}
+
+	assertCode(t, errs[2], codes.NotFound)
+}
+
+func TestLoadBalancing(t *testing.T) {
+	fileID, userID := newID("file"), newID("user")
+	createPermission(t, fileID, userID, pb.Role_READ, userID)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening failed: %v", err)
+	}
+
+	go srv.Serve(listener)
+
+	// The server's address is resolved by DNS, and the calls are only balanced to it once it's SERVING.
+	loadBalancing := client.LoadBalancing{HealthCheck: true}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(
+		ctx,
+		client.Target(listener.Addr().String()),
+		append(loadBalancing.DialOptions(), grpc.WithInsecure())...,
+	)
+	if err != nil {
+		t.Fatalf("dialing %s failed: %v", client.Target(listener.Addr().String()), err)
+	}
+	defer conn.Close()
+
+	res, err := pb.NewPermissionClient(conn).IsPermitted(ctx, &pb.IsPermittedRequest{
+		FileID: fileID,
+		UserID: userID,
+		Role:   pb.Role_READ,
+	}, grpc.WaitForReady(true))
+	if err != nil {
+		t.Fatalf("IsPermitted through the balanced connection failed: %v", err)
+	}
+
+	if !res.GetPermitted() {
+		t.Errorf("IsPermitted through the balanced connection = false, expected true")
+	}
+}
+
+func TestDeleteFilePermissions(t *testing.T) {
+	fileID, owner := newID("file"), newID("user")
+	createPermission(t, fileID, owner, pb.Role_WRITE, owner)
+	createPermission(t, fileID, newID("user"), pb.Role_READ, owner)
+
+	res, err := srv.Permission.DeleteFilePermissions(context.Background(), &pb.DeleteFilePermissionsRequest{
+		FileID: fileID,
+	})
+	if err != nil {
+		t.Fatalf("DeleteFilePermissions failed: %v", err)
+	}
+
+	if len(res.GetPermissions()) != 2 {
+		t.Fatalf("expected 2 deleted permissions, got %v", res)
+	}
+
+	remaining, err := srv.Permission.GetFilePermissions(context.Background(), &pb.GetFilePermissionsRequest{
+		FileID: fileID,
+	})
+	if err != nil {
+		t.Fatalf("GetFilePermissions failed: %v", err)
+	}
+
+	if len(remaining.GetPermissions()) != 0 {
+		t.Fatalf("expected no permissions, got %v", remaining)
+	}
+}
+
+func TestCopyPermissions(t *testing.T) {
+	sourceFileID, destFileID := newID("file"), newID("file")
+	reader, writer := newID("user"), newID("user")
+	createPermission(t, sourceFileID, reader, pb.Role_READ, reader)
+	createPermission(t, sourceFileID, writer, pb.Role_WRITE, writer)
+	createPermission(t, destFileID, writer, pb.Role_READ, writer)
+
+	res, err := srv.Permission.CopyPermissions(context.Background(), &pb.CopyPermissionsRequest{
+		SourceFileID: sourceFileID,
+		DestFileID:   destFileID,
+	})
+	if err != nil {
+		t.Fatalf("CopyPermissions failed: %v", err)
+	}
+
+	if res.GetCopied() != 1 || res.GetSkipped() != 1 {
+		t.Fatalf("expected 1 copied and 1 skipped permission, got %v", res)
+	}
+
+	permission, err := srv.Permission.GetPermission(context.Background(), &pb.GetPermissionRequest{
+		FileID: destFileID,
+		UserID: writer,
+	})
+	if err != nil {
+		t.Fatalf("GetPermission failed: %v", err)
+	}
+
+	if permission.GetRole() != pb.Role_READ {
+		t.Fatalf("expected the existing permission to be kept, got %v", permission)
+	}
+
+	res, err = srv.Permission.CopyPermissions(context.Background(), &pb.CopyPermissionsRequest{
+		SourceFileID: sourceFileID,
+		DestFileID:   destFileID,
+		Overwrite:    true,
+	})
+	if err != nil {
+		t.Fatalf("CopyPermissions failed: %v", err)
+	}
+
+	if res.GetCopied() != 2 || res.GetSkipped() != 0 {
+		t.Fatalf("expected 2 copied permissions, got %v", res)
+	}
+
+	permission, err = srv.Permission.GetPermission(context.Background(), &pb.GetPermissionRequest{
+		FileID: destFileID,
+		UserID: writer,
+	})
+	if err != nil {
+		t.Fatalf("GetPermission failed: %v", err)
+	}
+
+	if permission.GetRole() != pb.Role_WRITE {
+		t.Fatalf("expected the existing permission to be overwritten, got %v", permission)
+	}
+}
+
+func TestTouchPermission(t *testing.T) {
+	fileID, userID := newID("file"), newID("user")
+	createPermission(t, fileID, userID, pb.Role_READ, userID)
+
+	permission, err := srv.Permission.TouchPermission(context.Background(), &pb.TouchPermissionRequest{
+		FileID: fileID,
+		UserID: userID,
+	})
+	if err != nil {
+		t.Fatalf("TouchPermission failed: %v", err)
+	}
+
+	if permission.GetLastAccessedAt() == nil {
+		t.Fatalf("expected the last access time to be set, got %v", permission)
+	}
+}
+
+func TestRevokeCascade(t *testing.T) {
+	fileID, owner, sharer, reshared := newID("file"), newID("user"), newID("user"), newID("user")
+	createPermission(t, fileID, owner, pb.Role_WRITE, owner)
+	createPermission(t, fileID, sharer, pb.Role_READ, owner)
+	createPermission(t, fileID, reshared, pb.Role_READ, sharer)
+
+	res, err := srv.Permission.RevokeCascade(context.Background(), &pb.RevokeCascadeRequest{
+		FileID: fileID,
+		UserID: sharer,
+	})
+	if err != nil {
+		t.Fatalf("RevokeCascade failed: %v", err)
+	}
+
+	if len(res.GetPermissions()) != 2 {
+		t.Fatalf("expected the permissions of %s and %s to be revoked, got %v", sharer, reshared, res)
+	}
+
+	if _, err := srv.Permission.GetPermission(context.Background(), &pb.GetPermissionRequest{
+		FileID: fileID,
+		UserID: owner,
+	}); err != nil {
+		t.Fatalf("expected the permission of the owner to remain, got %v", err)
+	}
+}
+
+func TestRevokeAllExceptOwner(t *testing.T) {
+	fileID, owner, userID, reshared := newID("file"), newID("user"), newID("user"), newID("user")
+	createPermission(t, fileID, owner, pb.Role_WRITE, owner)
+	createPermission(t, fileID, userID, pb.Role_READ, owner)
+	createPermission(t, fileID, reshared, pb.Role_READ, userID)
+
+	initial, err := srv.Permission.ListPermissionChanges(context.Background(), &pb.ListPermissionChangesRequest{
+		UserID: userID,
+	})
+	if err != nil {
+		t.Fatalf("ListPermissionChanges failed: %v", err)
+	}
+
+	// The owner is read from the file service, which the test server doesn't have.
+	_, err = srv.Permission.RevokeAllExceptOwner(context.Background(), &pb.RevokeAllExceptOwnerRequest{
+		FileID: fileID,
+	})
+	assertCode(t, err, codes.FailedPrecondition)
+
+	res, err := srv.Permission.RevokeAllExceptOwner(context.Background(), &pb.RevokeAllExceptOwnerRequest{
+		FileID:  fileID,
+		OwnerID: owner,
+	})
+	if err != nil {
+		t.Fatalf("RevokeAllExceptOwner failed: %v", err)
+	}
+
+	if len(res.GetPermissions()) != 2 {
+		t.Fatalf("expected the permissions of %s and %s to be revoked, got %v", userID, reshared, res)
+	}
+
+	permissions, err := srv.Permission.GetFilePermissions(context.Background(), &pb.GetFilePermissionsRequest{
+		FileID: fileID,
+	})
+	if err != nil {
+		t.Fatalf("GetFilePermissions failed: %v", err)
+	}
+
+	if len(permissions.GetPermissions()) != 1 || permissions.GetPermissions()[0].GetUserID() != owner {
+		t.Fatalf("expected only the permission of the owner %s to remain, got %v", owner, permissions)
+	}
+
+	// The summary event of the revocation is listed as the deletion of the user's permission.
+	changes, err := srv.Permission.ListPermissionChanges(context.Background(), &pb.ListPermissionChangesRequest{
+		UserID:    userID,
+		SyncToken: initial.GetSyncToken(),
+	})
+	if err != nil {
+		t.Fatalf("ListPermissionChanges failed: %v", err)
+	}
+
+	if len(changes.GetChanges()) != 1 || changes.GetChanges()[0].GetType() != pb.ChangeType_DELETED {
+		t.Fatalf("expected the deletion of the permission, got %v", changes)
+	}
+
+	if changes.GetChanges()[0].GetPermission().GetUserID() != userID {
+		t.Fatalf("expected a change to the permission of %s, got %v", userID, changes.GetChanges()[0])
+	}
+}
+
+func TestReshareDepthLimit(t *testing.T) {
+	fileID, owner := newID("file"), newID("user")
+	createPermission(t, fileID, owner, pb.Role_WRITE, owner)
+
+	// Each user reshares the file to the next one, until the chain is as deep as allowed.
+	sharer := owner
+	for i := 0; i < testMaxReshareDepth; i++ {
+		userID := newID("user")
+		createPermission(t, fileID, userID, pb.Role_READ, sharer)
+		sharer = userID
+	}
+
+	_, err := srv.Permission.CreatePermission(context.Background(), &pb.CreatePermissionRequest{
+		FileID:  fileID,
+		UserID:  newID("user"),
+		Role:    pb.Role_READ,
+		Creator: sharer,
+	})
+	assertCode(t, err, codes.PermissionDenied)
+
+	// The error details which quota rejected the permission, so that clients can explain it.
+	rejection, ok := service.RejectionFromError(err)
+	expected := service.Rejection{
+		Kind:    service.RejectionQuota,
+		Rule:    "max_reshare_depth",
+		Subject: "files/" + fileID,
+		Current: testMaxReshareDepth + 1,
+		Limit:   testMaxReshareDepth,
+	}
+	if !ok || rejection != expected {
+		t.Fatalf("expected the rejection %+v, got %+v", expected, rejection)
+	}
+}
+
+func TestGetSharedFiles(t *testing.T) {
+	sharedFileID, otherFileID, userA, userB := newID("file"), newID("file"), newID("user"), newID("user")
+	createPermission(t, sharedFileID, userA, pb.Role_WRITE, userA)
+	createPermission(t, sharedFileID, userB, pb.Role_READ, userA)
+	createPermission(t, otherFileID, userA, pb.Role_WRITE, userA)
+
+	res, err := srv.Permission.GetSharedFiles(context.Background(), &pb.GetSharedFilesRequest{
+		UserA:      userA,
+		UserB:      userB,
+		GrantedByA: true,
+	})
+	if err != nil {
+		t.Fatalf("GetSharedFiles failed: %v", err)
+	}
+
+	if len(res.GetFiles()) != 1 || res.GetFiles()[0].GetFileID() != sharedFileID {
+		t.Fatalf("expected only the shared file %s, got %v", sharedFileID, res)
+	}
+
+	if res.GetFiles()[0].GetUserARole() != pb.Role_WRITE || res.GetFiles()[0].GetUserBRole() != pb.Role_READ {
+		t.Fatalf("unexpected roles of the shared file %v", res.GetFiles()[0])
+	}
+}
+
+func TestListSharedWithMe(t *testing.T) {
+	firstFileID, secondFileID, ownFileID := newID("file"), newID("file"), newID("file")
+	userID, sharer := newID("user"), newID("user")
+	createPermission(t, firstFileID, sharer, pb.Role_WRITE, sharer)
+	createPermission(t, secondFileID, sharer, pb.Role_WRITE, sharer)
+	createPermission(t, firstFileID, userID, pb.Role_READ, sharer)
+	createPermission(t, secondFileID, userID, pb.Role_WRITE, sharer)
+	createPermission(t, ownFileID, userID, pb.Role_WRITE, userID)
+
+	first, err := srv.Permission.ListSharedWithMe(context.Background(), &pb.ListSharedWithMeRequest{
+		UserID:   userID,
+		PageSize: 1,
+	})
+	if err != nil {
+		t.Fatalf("ListSharedWithMe failed: %v", err)
+	}
+
+	// The most recently shared file is listed first.
+	files := first.GetFiles()
+	if len(files) != 1 || files[0].GetFileID() != secondFileID || files[0].GetSharedBy() != sharer {
+		t.Fatalf("expected the file %s shared by %s, got %v", secondFileID, sharer, first)
+	}
+
+	second, err := srv.Permission.ListSharedWithMe(context.Background(), &pb.ListSharedWithMeRequest{
+		UserID:    userID,
+		PageSize:  10,
+		PageToken: first.GetNextPageToken(),
+	})
+	if err != nil {
+		t.Fatalf("ListSharedWithMe failed: %v", err)
+	}
+
+	files = second.GetFiles()
+	if len(files) != 1 || files[0].GetFileID() != firstFileID || files[0].GetRole() != pb.Role_READ {
+		t.Fatalf("expected only the file %s, got %v", firstFileID, second)
+	}
+
+	_, err = srv.Permission.DeletePermission(context.Background(), &pb.DeletePermissionRequest{
+		FileID: secondFileID,
+		UserID: userID,
+	})
+	if err != nil {
+		t.Fatalf("DeletePermission failed: %v", err)
+	}
+
+	res, err := srv.Permission.ListSharedWithMe(context.Background(), &pb.ListSharedWithMeRequest{UserID: userID})
+	if err != nil {
+		t.Fatalf("ListSharedWithMe failed: %v", err)
+	}
+
+	if len(res.GetFiles()) != 1 || res.GetFiles()[0].GetFileID() != firstFileID {
+		t.Fatalf("expected only the file %s once the other was unshared, got %v", firstFileID, res)
+	}
+}
+
+func TestListPermissionChanges(t *testing.T) {
+	fileID, userID := newID("file"), newID("user")
+	initial, err := srv.Permission.ListPermissionChanges(context.Background(), &pb.ListPermissionChangesRequest{
+		UserID: userID,
+	})
+	if err != nil {
+		t.Fatalf("ListPermissionChanges failed: %v", err)
+	}
+
+	createPermission(t, fileID, userID, pb.Role_READ, userID)
+
+	res, err := srv.Permission.ListPermissionChanges(context.Background(), &pb.ListPermissionChangesRequest{
+		UserID:    userID,
+		SyncToken: initial.GetSyncToken(),
+	})
+	if err != nil {
+		t.Fatalf("ListPermissionChanges failed: %v", err)
+	}
+
+	if len(res.GetChanges()) != 1 || res.GetChanges()[0].GetType() != pb.ChangeType_CREATED {
+		t.Fatalf("expected the creation of the permission, got %v", res)
+	}
+
+	if res.GetChanges()[0].GetPermission().GetFileID() != fileID {
+		t.Fatalf("expected a change to the permission to %s, got %v", fileID, res.GetChanges()[0])
+	}
+}
+
+func TestHandleFileMoved(t *testing.T) {
+	fileID, folderID := newID("file"), newID("folder")
+
+	// A file that stayed in the same folder has nothing to recompute.
+	res, err := srv.Permission.HandleFileMoved(context.Background(), &pb.HandleFileMovedRequest{
+		FileID:    fileID,
+		OldParent: folderID,
+		NewParent: folderID,
+	})
+	if err != nil {
+		t.Fatalf("HandleFileMoved failed: %v", err)
+	}
+
+	if res.GetCreated() != 0 || res.GetDeleted() != 0 {
+		t.Fatalf("expected no inherited permissions to change, got %v", res)
+	}
+
+	// The subtrees of moved files are read from the file service, which the test server isn't configured with.
+	_, err = srv.Permission.HandleFileMoved(context.Background(), &pb.HandleFileMovedRequest{
+		FileID:    fileID,
+		NewParent: folderID,
+	})
+	assertCode(t, err, codes.FailedPrecondition)
+}
+
+func TestPermissionsExist(t *testing.T) {
+	fileID, owner := newID("file"), newID("user")
+	kept := createPermission(t, fileID, owner, pb.Role_WRITE, owner)
+	deleted := createPermission(t, fileID, newID("user"), pb.Role_READ, owner)
+	if _, err := srv.Permission.DeletePermission(context.Background(), &pb.DeletePermissionRequest{
+		FileID: fileID,
+		UserID: deleted.GetUserID(),
+	}); err != nil {
+		t.Fatalf("DeletePermission failed: %v", err)
+	}
+
+	ids := []string{kept.GetId(), deleted.GetId(), "malformed", kept.GetId()}
+	res, err := srv.Permission.PermissionsExist(context.Background(), &pb.PermissionsExistRequest{Ids: ids})
+	if err != nil {
+		t.Fatalf("PermissionsExist failed: %v", err)
+	}
+
+	expected := []bool{true, false, false, true}
+	if len(res.GetExists()) != len(expected) {
+		t.Fatalf("expected the existence of %d permissions, got %v", len(expected), res.GetExists())
+	}
+
+	for i, exists := range res.GetExists() {
+		if exists != expected[i] {
+			t.Errorf("expected the existence of %s to be %t, got %t", ids[i], expected[i], exists)
+		}
+	}
+}
+
+func TestPreShareHook(t *testing.T) {
+	infectedFileID, unscannedFileID := newID("file"), newID("file")
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var share struct {
+			FileID string `json:"fileID"`
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&share); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		switch share.FileID {
+		case infectedFileID:
+			w.Write([]byte(`{"allowed": false, "reason": "infected"}`))
+		case unscannedFileID:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.Write([]byte(`{"allowed": true}`))
+		}
+	}))
+	defer hook.Close()
+
+	newHookServer := func(failOpen bool) *pstesting.Server {
+		defer func() {
+			viper.Set("pre_share_hook_url", "")
+			viper.Set("pre_share_hook_fail_open", false)
+		}()
+
+		hookServer, err := pstesting.NewServer(map[string]interface{}{
+			"pre_share_hook_url":       hook.URL,
+			"pre_share_hook_fail_open": failOpen,
+		})
+		if err != nil {
+			t.Fatalf("NewServer with a pre-share hook failed: %v", err)
+		}
+
+		return hookServer
+	}
+
+	create := func(hookServer *pstesting.Server, fileID string) error {
+		userID := newID("user")
+		_, err := hookServer.Permission.CreatePermission(context.Background(), &pb.CreatePermissionRequest{
+			FileID:  fileID,
+			UserID:  userID,
+			Role:    pb.Role_READ,
+			Creator: userID,
+		})
+
+		return err
+	}
+
+	failClosed := newHookServer(false)
+	defer failClosed.Close()
+
+	if err := create(failClosed, newID("file")); err != nil {
+		t.Errorf("CreatePermission of a clean file failed: %v", err)
+	}
+
+	vetoed := expvarCount("pre_share_verdicts", "vetoed")
+	assertCode(t, create(failClosed, infectedFileID), codes.PermissionDenied)
+	if count := expvarCount("pre_share_verdicts", "vetoed"); count != vetoed+1 {
+		t.Errorf("expected the veto to be counted, got %d vetoes after %d", count, vetoed)
+	}
+
+	assertCode(t, create(failClosed, unscannedFileID), codes.Unavailable)
+
+	failOpen := newHookServer(true)
+	defer failOpen.Close()
+
+	if err := create(failOpen, unscannedFileID); err != nil {
+		t.Errorf("CreatePermission of a file without a verdict failed open: %v", err)
+	}
+
+	assertCode(t, create(failOpen, infectedFileID), codes.PermissionDenied)
+}
+
+func TestHandledRequestsMetric(t *testing.T) {
+	notFound := "/permission.Permission/GetPermission/" + codes.NotFound.String()
+	invalid := "/permission.Permission/GetPermission/" + codes.InvalidArgument.String()
+	before, beforeInvalid := expvarCount("handled_requests", notFound), expvarCount("handled_requests", invalid)
+
+	_, err := srv.Permission.GetPermission(context.Background(), &pb.GetPermissionRequest{
+		FileID: newID("file"),
+		UserID: newID("user"),
+	})
+	assertCode(t, err, codes.NotFound)
+
+	// The metrics middleware is outside of the validation middleware, so rejected requests are counted too.
+	_, err = srv.Permission.GetPermission(context.Background(), &pb.GetPermissionRequest{UserID: newID("user")})
+	assertCode(t, err, codes.InvalidArgument)
+
+	if count := expvarCount("handled_requests", notFound); count != before+1 {
+		t.Errorf("expected %d handled NotFound requests, got %d", before+1, count)
+	}
+
+	if count := expvarCount("handled_requests", invalid); count != beforeInvalid+1 {
+		t.Errorf("expected %d handled InvalidArgument requests, got %d", beforeInvalid+1, count)
+	}
+}
+
+func TestForwardedActor(t *testing.T) {
+	fileID, owner := newID("file"), newID("user")
+	createPermission(t, fileID, owner, pb.Role_WRITE, owner)
+
+	// The permissions that are created on behalf of an actor must be created by it.
+	ownerCtx := metadata.AppendToOutgoingContext(context.Background(), service.ActorHeader, owner)
+	req := &pb.CreatePermissionRequest{
+		FileID:  fileID,
+		UserID:  newID("user"),
+		Role:    pb.Role_READ,
+		Creator: newID("user"),
+	}
+	_, err := srv.Permission.CreatePermission(ownerCtx, req)
+	assertCode(t, err, codes.PermissionDenied)
+
+	req.Creator = owner
+	if _, err := srv.Permission.CreatePermission(ownerCtx, req); err != nil {
+		t.Fatalf("CreatePermission on behalf of the creator failed: %v", err)
+	}
+
+	// The callers of the tests aren't verified, so they aren't gateways that may forward actors.
+	defer viper.Set("actor_gateways", "*")
+	gatewayServer, err := pstesting.NewServer(map[string]interface{}{"actor_gateways": "api-gateway"})
+	if err != nil {
+		t.Fatalf("NewServer with actor gateways failed: %v", err)
+	}
+	defer gatewayServer.Close()
+
+	req.UserID = newID("user")
+	_, err = gatewayServer.Permission.CreatePermission(ownerCtx, req)
+	assertCode(t, err, codes.PermissionDenied)
+}
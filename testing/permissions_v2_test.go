@@ -0,0 +1,587 @@
+//go:build integration
+// +build integration
+
+package testing_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	pb "github.com/meateam/permission-service/proto"
+	pbv2 "github.com/meateam/permission-service/proto/v2"
+	"github.com/meateam/permission-service/service"
+	pstesting "github.com/meateam/permission-service/testing"
+	"github.com/spf13/viper"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+// createPermissionV2 creates a permission of userID to the file parent with role,
+// and fails t if it fails.
+func createPermissionV2(t *testing.T, parent string, userID string, role pbv2.Role) *pbv2.Permission {
+	t.Helper()
+
+	permission, err := srv.Permissions.CreatePermission(context.Background(), &pbv2.CreatePermissionRequest{
+		Parent: parent,
+		Permission: &pbv2.Permission{
+			UserId:  userID,
+			Role:    role,
+			Creator: userID,
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreatePermission(%s, %s) failed: %v", parent, userID, err)
+	}
+
+	return permission
+}
+
+func TestCreatePermissionV2(t *testing.T) {
+	parent, userID := "files/"+newID("file"), newID("user")
+	permission := createPermissionV2(t, parent, userID, pbv2.Role_READ)
+	if permission.GetName() != parent+"/permissions/"+userID || permission.GetRole() != pbv2.Role_READ {
+		t.Fatalf("unexpected permission %v", permission)
+	}
+
+	_, err := srv.Permissions.CreatePermission(context.Background(), &pbv2.CreatePermissionRequest{
+		Parent:     parent,
+		Permission: &pbv2.Permission{UserId: userID, Role: pbv2.Role_READ, Creator: userID},
+	})
+	assertCode(t, err, codes.AlreadyExists)
+}
+
+func TestGetPermissionV2(t *testing.T) {
+	parent, userID := "files/"+newID("file"), newID("user")
+	created := createPermissionV2(t, parent, userID, pbv2.Role_WRITE)
+
+	permission, err := srv.Permissions.GetPermission(context.Background(), &pbv2.GetPermissionRequest{
+		Name: created.GetName(),
+	})
+	if err != nil {
+		t.Fatalf("GetPermission failed: %v", err)
+	}
+
+	if permission.GetRole() != pbv2.Role_WRITE || permission.GetEtag() != created.GetEtag() {
+		t.Fatalf("expected %v, got %v", created, permission)
+	}
+
+	_, err = srv.Permissions.GetPermission(context.Background(), &pbv2.GetPermissionRequest{
+		Name: parent + "/permissions/" + newID("user"),
+	})
+	assertCode(t, err, codes.NotFound)
+}
+
+func TestListPermissions(t *testing.T) {
+	parent := "files/" + newID("file")
+	for i := 0; i < 3; i++ {
+		createPermissionV2(t, parent, newID("user"), pbv2.Role_READ)
+	}
+
+	listed := 0
+	pageToken := ""
+	for {
+		res, err := srv.Permissions.ListPermissions(context.Background(), &pbv2.ListPermissionsRequest{
+			Parent:    parent,
+			PageSize:  2,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			t.Fatalf("ListPermissions failed: %v", err)
+		}
+
+		listed += len(res.GetPermissions())
+		pageToken = res.GetNextPageToken()
+		if pageToken == "" {
+			break
+		}
+	}
+
+	if listed != 3 {
+		t.Fatalf("expected 3 permissions, got %d", listed)
+	}
+}
+
+func TestUpdatePermissionV2(t *testing.T) {
+	parent, userID := "files/"+newID("file"), newID("user")
+	created := createPermissionV2(t, parent, userID, pbv2.Role_READ)
+
+	updated, err := srv.Permissions.UpdatePermission(context.Background(), &pbv2.UpdatePermissionRequest{
+		Permission: &pbv2.Permission{Name: created.GetName(), Role: pbv2.Role_WRITE, Etag: created.GetEtag()},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"role"}},
+	})
+	if err != nil {
+		t.Fatalf("UpdatePermission failed: %v", err)
+	}
+
+	if updated.GetRole() != pbv2.Role_WRITE || updated.GetEtag() == created.GetEtag() {
+		t.Fatalf("expected the role to be updated to WRITE with a new etag, got %v", updated)
+	}
+
+	_, err = srv.Permissions.UpdatePermission(context.Background(), &pbv2.UpdatePermissionRequest{
+		Permission: &pbv2.Permission{Name: created.GetName(), Role: pbv2.Role_READ, Etag: created.GetEtag()},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"role"}},
+	})
+	assertCode(t, err, codes.Aborted)
+}
+
+func TestUpdatePermissions(t *testing.T) {
+	parent := "files/" + newID("file")
+	first := createPermissionV2(t, parent, newID("user"), pbv2.Role_WRITE)
+	second := createPermissionV2(t, parent, newID("user"), pbv2.Role_WRITE)
+	missing := parent + "/permissions/" + newID("user")
+
+	res, err := srv.Permissions.UpdatePermissions(context.Background(), &pbv2.UpdatePermissionsRequest{
+		Updates: []*pbv2.UpdatePermissionsRequest_RoleUpdate{
+			{Name: first.GetName(), Role: pbv2.Role_READ},
+			{Name: second.GetName(), Role: pbv2.Role_READ, Etag: second.GetEtag()},
+			{Name: missing, Role: pbv2.Role_READ},
+			{Name: first.GetName(), Role: pbv2.Role_READ},
+		},
+	})
+	if err != nil {
+		t.Fatalf("UpdatePermissions failed: %v", err)
+	}
+
+	wantCodes := []codes.Code{codes.OK, codes.OK, codes.NotFound, codes.InvalidArgument}
+	if len(res.GetResults()) != len(wantCodes) {
+		t.Fatalf("expected %d results, got %v", len(wantCodes), res.GetResults())
+	}
+
+	for i, result := range res.GetResults() {
+		if codes.Code(result.GetCode()) != wantCodes[i] {
+			t.Fatalf("expected result %d to be %s, got %v", i, wantCodes[i], result)
+		}
+
+		if wantCodes[i] == codes.OK && result.GetPermission().GetRole() != pbv2.Role_READ {
+			t.Fatalf("expected result %d to be updated to READ, got %v", i, result)
+		}
+	}
+
+	permission, err := srv.Permissions.GetPermission(context.Background(), &pbv2.GetPermissionRequest{
+		Name: second.GetName(),
+	})
+	if err != nil {
+		t.Fatalf("GetPermission failed: %v", err)
+	}
+
+	updated := res.GetResults()[1].GetPermission()
+	if permission.GetRole() != pbv2.Role_READ || permission.GetEtag() != updated.GetEtag() {
+		t.Fatalf("expected %v, got %v", updated, permission)
+	}
+
+	res, err = srv.Permissions.UpdatePermissions(context.Background(), &pbv2.UpdatePermissionsRequest{
+		Updates: []*pbv2.UpdatePermissionsRequest_RoleUpdate{
+			{Name: second.GetName(), Role: pbv2.Role_WRITE, Etag: second.GetEtag()},
+		},
+	})
+	if err != nil {
+		t.Fatalf("UpdatePermissions failed: %v", err)
+	}
+
+	if codes.Code(res.GetResults()[0].GetCode()) != codes.Aborted {
+		t.Fatalf("expected the update of a stale etag to be aborted, got %v", res.GetResults()[0])
+	}
+}
+
+func TestDeletePermissionV2(t *testing.T) {
+	parent, userID := "files/"+newID("file"), newID("user")
+	created := createPermissionV2(t, parent, userID, pbv2.Role_READ)
+
+	_, err := srv.Permissions.DeletePermission(context.Background(), &pbv2.DeletePermissionRequest{
+		Name: created.GetName(),
+	})
+	if err != nil {
+		t.Fatalf("DeletePermission failed: %v", err)
+	}
+
+	_, err = srv.Permissions.GetPermission(context.Background(), &pbv2.GetPermissionRequest{
+		Name: created.GetName(),
+	})
+	assertCode(t, err, codes.NotFound)
+}
+
+func TestSimulateAccess(t *testing.T) {
+	parent, reader, revoked := "files/"+newID("file"), newID("user"), newID("user")
+	createPermissionV2(t, parent, reader, pbv2.Role_READ)
+	createPermissionV2(t, parent, revoked, pbv2.Role_READ)
+
+	res, err := srv.Permissions.SimulateAccess(context.Background(), &pbv2.SimulateAccessRequest{
+		Parent: parent,
+		Changes: []*pbv2.AccessChange{
+			{UserId: reader, Role: pbv2.Role_WRITE},
+			{UserId: revoked, Revoke: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("SimulateAccess failed: %v", err)
+	}
+
+	simulated := make(map[string]pbv2.Role, len(res.GetAccesses()))
+	for _, access := range res.GetAccesses() {
+		simulated[access.GetUserId()] = access.GetSimulatedRole()
+	}
+
+	if simulated[reader] != pbv2.Role_WRITE || simulated[revoked] != pbv2.Role_ROLE_UNSPECIFIED {
+		t.Fatalf("unexpected simulated access %v", res)
+	}
+
+	// Simulating doesn't change the permissions.
+	permission, err := srv.Permissions.GetPermission(context.Background(), &pbv2.GetPermissionRequest{
+		Name: parent + "/permissions/" + reader,
+	})
+	if err != nil {
+		t.Fatalf("GetPermission failed: %v", err)
+	}
+
+	if permission.GetRole() != pbv2.Role_READ {
+		t.Fatalf("expected the role to remain READ, got %s", permission.GetRole())
+	}
+}
+
+func TestGetFolderSharingSummary(t *testing.T) {
+	folderID, fileID := newID("folder"), newID("file")
+	userA, userB := newID("user"), newID("user")
+	createPermissionV2(t, "files/"+folderID, userA, pbv2.Role_WRITE)
+	createPermission(t, fileID, userA, pb.Role_READ, userA)
+	createPermissionV2(t, "files/"+fileID, userB, pbv2.Role_READ)
+	_, err := srv.Permission.CreatePermission(context.Background(), &pb.CreatePermissionRequest{
+		FileID:      fileID,
+		UserID:      testDomain,
+		Role:        pb.Role_READ,
+		Creator:     userA,
+		GranteeType: "domain",
+	})
+	if err != nil {
+		t.Fatalf("CreatePermission failed: %v", err)
+	}
+
+	req := &pbv2.GetFolderSharingSummaryRequest{
+		Folder:        "files/" + folderID,
+		DescendantIds: []string{fileID, newID("file")},
+	}
+	summary, err := srv.Permissions.GetFolderSharingSummary(context.Background(), req)
+	if err != nil {
+		t.Fatalf("GetFolderSharingSummary failed: %v", err)
+	}
+
+	if summary.GetFiles() != 2 || summary.GetPermissions() != 4 || summary.GetGrantees() != 3 {
+		t.Fatalf("expected 4 permissions of 3 grantees to 2 files, got %v", summary)
+	}
+
+	roles := map[pbv2.Role]int64{}
+	for _, role := range summary.GetRoles() {
+		roles[role.GetRole()] = role.GetGrantees()
+	}
+
+	if len(roles) != 2 || roles[pbv2.Role_READ] != 3 || roles[pbv2.Role_WRITE] != 1 {
+		t.Errorf("expected 3 readers and a writer, got %v", summary.GetRoles())
+	}
+
+	external := summary.GetExternalGrantees()
+	if len(external) != 1 || external[0] != testDomain || summary.GetExternalFiles() != 1 {
+		t.Errorf("expected %s to be an external grantee of a file, got %v", testDomain, summary)
+	}
+
+	// Without its descendants, the folder's tree is the folder and the files that inherit its permissions.
+	req = &pbv2.GetFolderSharingSummaryRequest{Folder: "files/" + folderID}
+	summary, err = srv.Permissions.GetFolderSharingSummary(context.Background(), req)
+	if err != nil {
+		t.Fatalf("GetFolderSharingSummary failed: %v", err)
+	}
+
+	if summary.GetFiles() != 1 || summary.GetGrantees() != 1 || len(summary.GetExternalGrantees()) != 0 {
+		t.Errorf("expected a single permission to the folder, got %v", summary)
+	}
+
+	_, err = srv.Permissions.GetFolderSharingSummary(context.Background(), &pbv2.GetFolderSharingSummaryRequest{
+		Folder:        "files/" + folderID,
+		DescendantIds: []string{""},
+	})
+	assertCode(t, err, codes.InvalidArgument)
+}
+
+func TestListRoles(t *testing.T) {
+	res, err := srv.Permissions.ListRoles(context.Background(), &pbv2.ListRolesRequest{})
+	if err != nil {
+		t.Fatalf("ListRoles failed: %v", err)
+	}
+
+	// Every role that permissions may have is listed, in order.
+	if len(res.GetRoles()) != len(pbv2.Role_name)-1 {
+		t.Fatalf("expected %d roles, got %v", len(pbv2.Role_name)-1, res.GetRoles())
+	}
+
+	roles := map[pbv2.Role]*pbv2.RoleInfo{}
+	for i, role := range res.GetRoles() {
+		if role.GetOrder() != int32(i) || role.GetDisplayNameKey() == "" {
+			t.Errorf("unexpected role %v at %d", role, i)
+		}
+
+		roles[role.GetRole()] = role
+	}
+
+	read := roles[pbv2.Role_READ]
+	if read.GetName() != "READ" || read.GetDisplayNameKey() != "role.read.name" {
+		t.Errorf("unexpected READ role %v", read)
+	}
+
+	for _, kind := range read.GetCapabilities() {
+		if kind.GetResourceKind() == "file" &&
+			(len(kind.GetCapabilities()) != 1 || kind.GetCapabilities()[0] != pbv2.Capability_VIEW) {
+			t.Errorf("expected READ to only grant VIEW to files, got %v", kind)
+		}
+	}
+
+	includesRead := false
+	for _, included := range roles[pbv2.Role_WRITE].GetIncludes() {
+		includesRead = includesRead || included == pbv2.Role_READ
+	}
+
+	if !includesRead {
+		t.Errorf("expected WRITE to include READ, got %v", roles[pbv2.Role_WRITE])
+	}
+}
+
+func TestGetFrequentCollaborators(t *testing.T) {
+	// The collaborators are refreshed by a server that runs the recurring job every second.
+	defer viper.Set("recurring_jobs", "")
+	collaboratorsServer, err := pstesting.NewServer(map[string]interface{}{
+		"recurring_jobs": service.JobRefreshCollaborators + "=@every 1s",
+	})
+	if err != nil {
+		t.Fatalf("NewServer with recurring jobs failed: %v", err)
+	}
+	defer collaboratorsServer.Close()
+
+	userID, sharer, recipient, other := newID("user"), newID("user"), newID("user"), newID("user")
+	share := func(grantee string, creator string) {
+		fileID := newID("file")
+		createPermission(t, fileID, creator, pb.Role_WRITE, creator)
+		createPermission(t, fileID, grantee, pb.Role_READ, creator)
+	}
+
+	for i := 0; i < 3; i++ {
+		share(userID, sharer)
+	}
+
+	for i := 0; i < 2; i++ {
+		share(recipient, userID)
+	}
+
+	share(other, userID)
+
+	// The permissions that the user gave itself aren't collaborations.
+	createPermission(t, newID("file"), userID, pb.Role_WRITE, userID)
+
+	var res *pbv2.GetFrequentCollaboratorsResponse
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		res, err = collaboratorsServer.Permissions.GetFrequentCollaborators(
+			context.Background(),
+			&pbv2.GetFrequentCollaboratorsRequest{UserId: userID, Limit: 2},
+		)
+		if err != nil {
+			t.Fatalf("GetFrequentCollaborators failed: %v", err)
+		}
+
+		if len(res.GetCollaborators()) == 2 || time.Now().After(deadline) {
+			break
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	collaborators := res.GetCollaborators()
+	if len(collaborators) != 2 {
+		t.Fatalf("expected 2 collaborators, got %v", collaborators)
+	}
+
+	if collaborators[0].GetUserId() != sharer || collaborators[0].GetReceivedFrom() != 3 ||
+		collaborators[0].GetSharedWith() != 0 {
+		t.Errorf("expected %s to have shared 3 permissions with the user first, got %v", sharer, collaborators[0])
+	}
+
+	if collaborators[1].GetUserId() != recipient || collaborators[1].GetSharedWith() != 2 {
+		t.Errorf("expected the user to have shared 2 permissions with %s second, got %v", recipient, collaborators[1])
+	}
+
+	_, err = srv.Permissions.GetFrequentCollaborators(
+		context.Background(),
+		&pbv2.GetFrequentCollaboratorsRequest{UserId: userID, Limit: -1},
+	)
+	assertCode(t, err, codes.InvalidArgument)
+}
+
+func TestGetFileSharingActivity(t *testing.T) {
+	parent, owner, reader := "files/"+newID("file"), newID("user"), newID("user")
+	createPermissionV2(t, parent, owner, pbv2.Role_WRITE)
+	created := createPermissionV2(t, parent, reader, pbv2.Role_READ)
+	_, err := srv.Permissions.UpdatePermission(context.Background(), &pbv2.UpdatePermissionRequest{
+		Permission: &pbv2.Permission{Name: created.GetName(), Role: pbv2.Role_WRITE, Etag: created.GetEtag()},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"role"}},
+	})
+	if err != nil {
+		t.Fatalf("UpdatePermission failed: %v", err)
+	}
+
+	if _, err := srv.Permissions.DeletePermission(context.Background(), &pbv2.DeletePermissionRequest{
+		Name: created.GetName(),
+	}); err != nil {
+		t.Fatalf("DeletePermission failed: %v", err)
+	}
+
+	var activities []*pbv2.SharingActivity
+	pageToken := ""
+	for {
+		res, err := srv.Permissions.GetFileSharingActivity(
+			context.Background(),
+			&pbv2.GetFileSharingActivityRequest{Resource: parent, PageSize: 3, PageToken: pageToken},
+		)
+		if err != nil {
+			t.Fatalf("GetFileSharingActivity failed: %v", err)
+		}
+
+		activities = append(activities, res.GetActivities()...)
+		if pageToken = res.GetNextPageToken(); pageToken == "" {
+			break
+		}
+	}
+
+	// The activity is listed newest first.
+	expected := []struct {
+		kind      pbv2.SharingActivity_Kind
+		granteeID string
+		role      pbv2.Role
+	}{
+		{kind: pbv2.SharingActivity_REVOKED, granteeID: reader, role: pbv2.Role_WRITE},
+		{kind: pbv2.SharingActivity_ROLE_CHANGED, granteeID: reader, role: pbv2.Role_WRITE},
+		{kind: pbv2.SharingActivity_GRANTED, granteeID: reader, role: pbv2.Role_READ},
+		{kind: pbv2.SharingActivity_GRANTED, granteeID: owner, role: pbv2.Role_WRITE},
+	}
+	if len(activities) != len(expected) {
+		t.Fatalf("expected %d activities, got %v", len(expected), activities)
+	}
+
+	for i, activity := range activities {
+		if activity.GetKind() != expected[i].kind || activity.GetGranteeId() != expected[i].granteeID ||
+			activity.GetRole() != expected[i].role || activity.GetGranteeType() != "user" {
+			t.Errorf("unexpected activity %d: %v", i, activity)
+		}
+	}
+
+	if activities[1].GetPreviousRole() != pbv2.Role_READ {
+		t.Errorf("expected the role to have changed from READ, got %v", activities[1])
+	}
+
+	// The activity since after the changes is empty.
+	since, err := ptypes.TimestampProto(time.Now().Add(time.Second))
+	if err != nil {
+		t.Fatalf("TimestampProto failed: %v", err)
+	}
+
+	res, err := srv.Permissions.GetFileSharingActivity(
+		context.Background(),
+		&pbv2.GetFileSharingActivityRequest{Resource: parent, Since: since},
+	)
+	if err != nil {
+		t.Fatalf("GetFileSharingActivity since now failed: %v", err)
+	}
+
+	if len(res.GetActivities()) != 0 {
+		t.Errorf("expected no activity since now, got %v", res.GetActivities())
+	}
+}
+
+func TestAccessReview(t *testing.T) {
+	fileID, owner, revoked, certified := newID("file"), newID("user"), newID("user"), newID("user")
+	createPermission(t, fileID, owner, pb.Role_WRITE, owner)
+	createPermission(t, fileID, revoked, pb.Role_READ, owner)
+	createPermission(t, fileID, certified, pb.Role_READ, owner)
+
+	review, err := srv.Admin.CreateAccessReview(context.Background(), &pbv2.CreateAccessReviewRequest{
+		Title:     "quarterly review",
+		Resources: []string{"files/" + fileID},
+	})
+	if err != nil {
+		t.Fatalf("CreateAccessReview failed: %v", err)
+	}
+
+	// The owner reviews the grants of the file, but not its own.
+	if review.GetState() != pbv2.AccessReview_OPEN || review.GetItemCount() != 2 {
+		t.Errorf("CreateAccessReview = %v, expected an open campaign of 2 items", review)
+	}
+
+	ownerCtx := metadata.AppendToOutgoingContext(context.Background(), service.ActorHeader, owner)
+	items, err := srv.Permissions.ListAccessReviewItems(ownerCtx, &pbv2.ListAccessReviewItemsRequest{
+		AccessReview: review.GetName(),
+		PendingOnly:  true,
+	})
+	if err != nil {
+		t.Fatalf("ListAccessReviewItems failed: %v", err)
+	}
+
+	if len(items.GetItems()) != 2 {
+		t.Fatalf("ListAccessReviewItems returned %d items, expected 2", len(items.GetItems()))
+	}
+
+	for _, item := range items.GetItems() {
+		if item.GetReviewerId() != owner || item.GetDecision() != pbv2.AccessReviewItem_DECISION_UNSPECIFIED {
+			t.Errorf("item %v, expected a pending item reviewed by %s", item, owner)
+		}
+	}
+
+	decide := &pbv2.DecideAccessReviewItemsRequest{
+		AccessReview: review.GetName(),
+		Decisions: []*pbv2.DecideAccessReviewItemsRequest_Decision{
+			{Permission: "files/" + fileID + "/permissions/" + revoked, Decision: pbv2.AccessReviewItem_REVOKED},
+			{Permission: "files/" + fileID + "/permissions/" + certified, Decision: pbv2.AccessReviewItem_CERTIFIED},
+		},
+	}
+
+	// Only the reviewer of the items may decide them.
+	otherCtx := metadata.AppendToOutgoingContext(context.Background(), service.ActorHeader, newID("user"))
+	_, err = srv.Permissions.DecideAccessReviewItems(otherCtx, decide)
+	assertCode(t, err, codes.NotFound)
+
+	decided, err := srv.Permissions.DecideAccessReviewItems(ownerCtx, decide)
+	if err != nil {
+		t.Fatalf("DecideAccessReviewItems failed: %v", err)
+	}
+
+	for _, item := range decided.GetItems() {
+		if item.GetDecidedBy() != owner || item.GetDecideTime() == nil {
+			t.Errorf("decided item %v, expected it to be decided by %s", item, owner)
+		}
+	}
+
+	closed, err := srv.Admin.CloseAccessReview(context.Background(), &pbv2.CloseAccessReviewRequest{
+		Name: review.GetName(),
+	})
+	if err != nil {
+		t.Fatalf("CloseAccessReview failed: %v", err)
+	}
+
+	if closed.GetState() != pbv2.AccessReview_CLOSED || closed.GetRevokedCount() != 1 {
+		t.Errorf("CloseAccessReview = %v, expected a closed campaign with 1 revoked item", closed)
+	}
+
+	_, err = srv.Permission.GetPermission(context.Background(), &pb.GetPermissionRequest{
+		FileID: fileID,
+		UserID: revoked,
+	})
+	assertCode(t, err, codes.NotFound)
+
+	_, err = srv.Permission.GetPermission(context.Background(), &pb.GetPermissionRequest{
+		FileID: fileID,
+		UserID: certified,
+	})
+	if err != nil {
+		t.Errorf("GetPermission of the certified grant failed: %v", err)
+	}
+
+	_, err = srv.Permissions.DecideAccessReviewItems(ownerCtx, decide)
+	assertCode(t, err, codes.FailedPrecondition)
+}
@@ -0,0 +1,106 @@
+//go:build integration
+// +build integration
+
+package testing_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	pb "github.com/meateam/permission-service/proto"
+	pstesting "github.com/meateam/permission-service/testing"
+	"github.com/spf13/viper"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// newReadServer creates a permission server whose reads are served from readHost with readPreference.
+func newReadServer(readHost string, readPreference string) (*pstesting.Server, error) {
+	defer func() {
+		viper.Set("mongo_read_host", "")
+		viper.Set("mongo_read_preference", "")
+	}()
+
+	return pstesting.NewServer(map[string]interface{}{
+		"mongo_read_host":       readHost,
+		"mongo_read_preference": readPreference,
+	})
+}
+
+// filePermissions returns the number of the permissions of fileID that s lists, and fails t if listing fails.
+func filePermissions(t *testing.T, s *pstesting.Server, fileID string) int {
+	t.Helper()
+
+	res, err := s.Permission.GetFilePermissions(context.Background(), &pb.GetFilePermissionsRequest{FileID: fileID})
+	if err != nil {
+		t.Fatalf("GetFilePermissions failed: %v", err)
+	}
+
+	return len(res.GetPermissions())
+}
+
+func TestReadHost(t *testing.T) {
+	ctx := context.Background()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoConnectionString))
+	if err != nil {
+		t.Fatalf("failed connecting to mongodb: %v", err)
+	}
+	defer client.Disconnect(ctx)
+
+	// The reads are served from another database, so that it's known which of the clients served them.
+	readDBName := newID("reads")
+	readDB := client.Database(readDBName)
+	defer readDB.Drop(ctx)
+
+	readHost := strings.Replace(mongoConnectionString, "/"+pstesting.DatabaseName+"?", "/"+readDBName+"?", 1)
+	readServer, err := newReadServer(readHost, "primaryPreferred")
+	if err != nil {
+		t.Fatalf("creating the server of the reads failed: %v", err)
+	}
+	defer readServer.Close()
+
+	// The writes are served from the primary client, and aren't read from the read client until they're replicated.
+	fileID, userID := newID("file"), newID("user")
+	_, err = readServer.Permission.CreatePermission(ctx, &pb.CreatePermissionRequest{
+		FileID:  fileID,
+		UserID:  userID,
+		Role:    pb.Role_READ,
+		Creator: userID,
+	})
+	if err != nil {
+		t.Fatalf("CreatePermission failed: %v", err)
+	}
+
+	if count := filePermissions(t, srv, fileID); count != 1 {
+		t.Errorf("expected the permission to be written to the primary client, listed %d permissions", count)
+	}
+
+	if count := filePermissions(t, readServer, fileID); count != 0 {
+		t.Errorf("expected the permissions to be read from the read client, listed %d permissions", count)
+	}
+
+	var permission bson.M
+	filter := bson.D{bson.E{Key: "fileID", Value: fileID}}
+	permissions := client.Database(pstesting.DatabaseName).Collection("permissions")
+	if err := permissions.FindOne(ctx, filter).Decode(&permission); err != nil {
+		t.Fatalf("failed finding the created permission: %v", err)
+	}
+
+	if _, err := readDB.Collection("permissions").InsertOne(ctx, permission); err != nil {
+		t.Fatalf("failed replicating the permission: %v", err)
+	}
+
+	if count := filePermissions(t, readServer, fileID); count != 1 {
+		t.Errorf("expected the replicated permission to be read from the read client, listed %d permissions", count)
+	}
+}
+
+func TestReadPreferenceInvalid(t *testing.T) {
+	invalidServer, err := newReadServer("", "closest")
+	if err == nil {
+		invalidServer.Close()
+		t.Fatalf("expected a server with an invalid read preference to fail")
+	}
+}
@@ -0,0 +1,153 @@
+//go:build integration
+// +build integration
+
+package testing_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/meateam/permission-service/service"
+	pstesting "github.com/meateam/permission-service/testing"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fenceTimeout is the timeout of a primary region acquiring its write fence in the tests.
+const fenceTimeout = 10 * time.Second
+
+// newRegionServer creates a permission server of role in region, whose primary region is served at
+// primaryAddress if it's a replica, and fails t if it fails. The region's configuration is reset
+// once it's created, so that it doesn't apply to the servers that are created after it.
+func newRegionServer(t *testing.T, region string, role string, primaryAddress string) *pstesting.Server {
+	t.Helper()
+
+	defer func() {
+		viper.Set("region", "")
+		viper.Set("region_role", service.RegionPrimary)
+		viper.Set("primary_region_address", "")
+	}()
+
+	regionServer, err := pstesting.NewServer(map[string]interface{}{
+		"region":                 region,
+		"region_role":            role,
+		"primary_region_address": primaryAddress,
+	})
+	if err != nil {
+		t.Fatalf("creating the server of region %s failed: %v", region, err)
+	}
+
+	return regionServer
+}
+
+func TestRegions(t *testing.T) {
+	primary := newRegionServer(t, "us", service.RegionPrimary, "")
+	defer primary.Close()
+
+	// The replica region forwards the writes to the primary region's grpc address.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening failed: %v", err)
+	}
+
+	go primary.Serve(listener)
+
+	fileID, userID := newID("file"), newID("user")
+	req := &pb.CreatePermissionRequest{FileID: fileID, UserID: userID, Role: pb.Role_READ, Creator: userID}
+	deadline := time.Now().Add(fenceTimeout)
+	for {
+		_, err := primary.Permission.CreatePermission(context.Background(), req)
+		if err == nil {
+			break
+		}
+
+		if status.Code(err) != codes.Unavailable || time.Now().After(deadline) {
+			t.Fatalf("CreatePermission in the primary region failed: %v", err)
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	replica := newRegionServer(t, "eu", service.RegionReplica, listener.Addr().String())
+	defer replica.Close()
+
+	t.Run("ForwardsWrites", func(t *testing.T) {
+		forwardedUserID := newID("user")
+		_, err := replica.Permission.CreatePermission(context.Background(), &pb.CreatePermissionRequest{
+			FileID:  fileID,
+			UserID:  forwardedUserID,
+			Role:    pb.Role_WRITE,
+			Creator: userID,
+		})
+		if err != nil {
+			t.Fatalf("CreatePermission in the replica region failed: %v", err)
+		}
+
+		permission, err := primary.Permission.GetPermission(context.Background(), &pb.GetPermissionRequest{
+			FileID: fileID,
+			UserID: forwardedUserID,
+		})
+		if err != nil {
+			t.Fatalf("GetPermission of the forwarded permission failed: %v", err)
+		}
+
+		if permission.GetRole() != pb.Role_WRITE {
+			t.Errorf("forwarded permission role = %s, expected %s", permission.GetRole(), pb.Role_WRITE)
+		}
+
+		// The errors of the primary region are returned by the replica region.
+		_, err = replica.Permission.CreatePermission(context.Background(), &pb.CreatePermissionRequest{
+			FileID:  fileID,
+			Role:    pb.Role_WRITE,
+			Creator: userID,
+		})
+		assertCode(t, err, codes.InvalidArgument)
+	})
+
+	t.Run("ServesReads", func(t *testing.T) {
+		res, err := replica.Permission.IsPermitted(context.Background(), &pb.IsPermittedRequest{
+			FileID: fileID,
+			UserID: userID,
+			Role:   pb.Role_READ,
+		})
+		if err != nil {
+			t.Fatalf("IsPermitted in the replica region failed: %v", err)
+		}
+
+		if !res.GetPermitted() {
+			t.Errorf("IsPermitted in the replica region = false, expected true")
+		}
+	})
+
+	t.Run("RejectsStreamingWrites", func(t *testing.T) {
+		stream, err := replica.Admin.ImportPermissions(context.Background())
+		if err != nil {
+			t.Fatalf("ImportPermissions failed: %v", err)
+		}
+
+		_, err = stream.Recv()
+		assertCode(t, err, codes.FailedPrecondition)
+	})
+
+	t.Run("FencesOtherPrimaries", func(t *testing.T) {
+		other := newRegionServer(t, "ap", service.RegionPrimary, "")
+		defer other.Close()
+
+		_, err := other.Permission.CreatePermission(context.Background(), &pb.CreatePermissionRequest{
+			FileID:  fileID,
+			UserID:  newID("user"),
+			Role:    pb.Role_READ,
+			Creator: userID,
+		})
+		assertCode(t, err, codes.Unavailable)
+
+		rejection, ok := service.RejectionFromError(err)
+		if !ok || rejection.Rule != "region_fence" || rejection.Subject != "ap" {
+			t.Errorf("rejection = %+v, expected the region_fence of ap", rejection)
+		}
+	})
+}
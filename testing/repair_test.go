@@ -0,0 +1,144 @@
+//go:build integration
+// +build integration
+
+package testing_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	pb "github.com/meateam/permission-service/proto"
+	pbv2 "github.com/meateam/permission-service/proto/v2"
+	pstesting "github.com/meateam/permission-service/testing"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestRepairPermissions(t *testing.T) {
+	ctx := context.Background()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoConnectionString))
+	if err != nil {
+		t.Fatalf("failed connecting to mongodb: %v", err)
+	}
+	defer client.Disconnect(ctx)
+
+	permissions := client.Database(pstesting.DatabaseName).Collection("permissions")
+	fileID, userID := newID("file"), newID("user")
+	roleName, missingUser, duplicate := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	documents := []bson.D{
+		// A valid permission, which is kept over its duplicate since it was updated last.
+		{
+			bson.E{Key: "resourceType", Value: "file"},
+			bson.E{Key: "fileID", Value: fileID},
+			bson.E{Key: "userID", Value: userID},
+			bson.E{Key: "role", Value: int32(pb.Role_READ)},
+			bson.E{Key: "creator", Value: userID},
+			bson.E{Key: "version", Value: int64(1)},
+		},
+		// A role that's stored as its name, which is fixed.
+		{
+			bson.E{Key: "_id", Value: roleName},
+			bson.E{Key: "fileID", Value: newID("file")},
+			bson.E{Key: "userID", Value: userID},
+			bson.E{Key: "role", Value: "WRITE"},
+			bson.E{Key: "creator", Value: userID},
+		},
+		// A permission without a user, which is quarantined.
+		{
+			bson.E{Key: "_id", Value: missingUser},
+			bson.E{Key: "fileID", Value: newID("file")},
+			bson.E{Key: "role", Value: int32(pb.Role_READ)},
+			bson.E{Key: "creator", Value: userID},
+		},
+		// A permission of the same user and file as the valid one, without a resource type.
+		{
+			bson.E{Key: "_id", Value: duplicate},
+			bson.E{Key: "fileID", Value: fileID},
+			bson.E{Key: "userID", Value: userID},
+			bson.E{Key: "role", Value: int32(pb.Role_WRITE)},
+			bson.E{Key: "creator", Value: userID},
+		},
+	}
+	for i, document := range documents {
+		opts := options.InsertOne().SetBypassDocumentValidation(true)
+		if _, err := permissions.InsertOne(ctx, document, opts); err != nil {
+			t.Fatalf("failed inserting permission %d: %v", i, err)
+		}
+	}
+
+	resolutions := repairPermissions(t, pbv2.RepairPermissionsRequest_QUARANTINE)
+	expected := map[string]pbv2.MalformedPermission_Resolution{
+		roleName.Hex():    pbv2.MalformedPermission_FIXED,
+		missingUser.Hex(): pbv2.MalformedPermission_QUARANTINED,
+		duplicate.Hex():   pbv2.MalformedPermission_QUARANTINED,
+	}
+	for id, resolution := range expected {
+		if resolutions[id] != resolution {
+			t.Errorf("expected permission %s to be %s, got %s", id, resolution, resolutions[id])
+		}
+	}
+
+	var fixed struct {
+		Role interface{} `bson:"role"`
+	}
+	if err := permissions.FindOne(ctx, bson.D{bson.E{Key: "_id", Value: roleName}}).Decode(&fixed); err != nil {
+		t.Fatalf("failed finding the fixed permission: %v", err)
+	}
+
+	if fixed.Role != int32(pb.Role_WRITE) {
+		t.Errorf("expected the fixed role to be %d, got %v", pb.Role_WRITE, fixed.Role)
+	}
+
+	quarantined, err := client.Database(pstesting.DatabaseName).Collection("quarantinedPermissions").CountDocuments(
+		ctx,
+		bson.D{bson.E{Key: "_id", Value: bson.D{bson.E{Key: "$in", Value: bson.A{missingUser, duplicate}}}}},
+	)
+	if err != nil || quarantined != 2 {
+		t.Errorf("expected 2 quarantined permissions, got %d: %v", quarantined, err)
+	}
+
+	permission, err := srv.Permission.GetPermission(ctx, &pb.GetPermissionRequest{FileID: fileID, UserID: userID})
+	if err != nil || permission.GetRole() != pb.Role_READ {
+		t.Errorf("expected the valid permission to be kept, got %v: %v", permission, err)
+	}
+
+	// Once repaired, the permissions aren't malformed.
+	for id := range repairPermissions(t, pbv2.RepairPermissionsRequest_REPORT) {
+		if _, ok := expected[id]; ok {
+			t.Errorf("expected permission %s to be repaired", id)
+		}
+	}
+}
+
+// repairPermissions repairs the malformed permissions by action, and returns their resolutions by their IDs.
+func repairPermissions(
+	t *testing.T,
+	action pbv2.RepairPermissionsRequest_Action,
+) map[string]pbv2.MalformedPermission_Resolution {
+	stream, err := srv.Admin.RepairPermissions(context.Background(), &pbv2.RepairPermissionsRequest{
+		Action:    action,
+		BatchSize: 2,
+	})
+	if err != nil {
+		t.Fatalf("RepairPermissions failed: %v", err)
+	}
+
+	resolutions := map[string]pbv2.MalformedPermission_Resolution{}
+	for {
+		progress, err := stream.Recv()
+		if err == io.EOF {
+			return resolutions
+		}
+
+		if err != nil {
+			t.Fatalf("RepairPermissions failed: %v", err)
+		}
+
+		for _, permission := range progress.GetPermissions() {
+			resolutions[permission.GetId()] = permission.GetResolution()
+		}
+	}
+}
@@ -0,0 +1,167 @@
+//go:build integration
+// +build integration
+
+package testing_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/meateam/permission-service/proto"
+	pbv2 "github.com/meateam/permission-service/proto/v2"
+	"github.com/meateam/permission-service/service/mongodb"
+	pstesting "github.com/meateam/permission-service/testing"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// documentValidationFailureCode is the mongodb error code of a write of a document that doesn't match its schema.
+const documentValidationFailureCode = 121
+
+func TestSchemaValidation(t *testing.T) {
+	ctx := context.Background()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoConnectionString))
+	if err != nil {
+		t.Fatalf("failed connecting to mongodb: %v", err)
+	}
+	defer client.Disconnect(ctx)
+
+	permissions := client.Database(pstesting.DatabaseName).Collection("permissions")
+	fileID, userID := newID("file"), newID("user")
+	_, err = permissions.InsertOne(ctx, bson.D{
+		bson.E{Key: "fileID", Value: fileID},
+		bson.E{Key: "userID", Value: userID},
+		bson.E{Key: "role", Value: int32(pb.Role_READ)},
+		bson.E{Key: "creator", Value: userID},
+	})
+	if err != nil {
+		t.Fatalf("failed inserting a valid permission: %v", err)
+	}
+
+	invalid := []bson.D{
+		// A role that doesn't exist.
+		{
+			bson.E{Key: "fileID", Value: newID("file")},
+			bson.E{Key: "userID", Value: userID},
+			bson.E{Key: "role", Value: int32(len(pb.Role_name))},
+			bson.E{Key: "creator", Value: userID},
+		},
+		// A permission without a user.
+		{
+			bson.E{Key: "fileID", Value: newID("file")},
+			bson.E{Key: "role", Value: int32(pb.Role_READ)},
+			bson.E{Key: "creator", Value: userID},
+		},
+		// A label value that's too long.
+		{
+			bson.E{Key: "fileID", Value: newID("file")},
+			bson.E{Key: "userID", Value: userID},
+			bson.E{Key: "role", Value: int32(pb.Role_READ)},
+			bson.E{Key: "creator", Value: userID},
+			bson.E{Key: "labels", Value: bson.D{bson.E{Key: "team", Value: string(make([]byte, 64))}}},
+		},
+	}
+	for i, document := range invalid {
+		_, err := permissions.InsertOne(ctx, document)
+		writeException, ok := err.(mongo.WriteException)
+		if !ok || len(writeException.WriteErrors) != 1 ||
+			writeException.WriteErrors[0].Code != documentValidationFailureCode {
+			t.Errorf("expected invalid permission %d to fail validation, got %v", i, err)
+		}
+	}
+
+	// A valid permission may not be updated to an invalid one.
+	_, err = permissions.UpdateOne(
+		ctx,
+		bson.D{bson.E{Key: "fileID", Value: fileID}, bson.E{Key: "userID", Value: userID}},
+		bson.D{bson.E{Key: "$set", Value: bson.D{bson.E{Key: "role", Value: "READ"}}}},
+	)
+	if writeException, ok := err.(mongo.WriteException); !ok || len(writeException.WriteErrors) != 1 {
+		t.Errorf("expected the invalid update to fail validation, got %v", err)
+	}
+}
+
+func TestSchemaUpgrade(t *testing.T) {
+	ctx := context.Background()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoConnectionString))
+	if err != nil {
+		t.Fatalf("failed connecting to mongodb: %v", err)
+	}
+	defer client.Disconnect(ctx)
+
+	// The legacy permissions are stored without the fields that were introduced after them.
+	permissions := client.Database(pstesting.DatabaseName).Collection("permissions")
+	insertLegacy := func(fileID string, userID string) {
+		t.Helper()
+
+		_, err := permissions.InsertOne(ctx, bson.D{
+			bson.E{Key: "fileID", Value: fileID},
+			bson.E{Key: "userID", Value: userID},
+			bson.E{Key: "role", Value: int32(pb.Role_READ)},
+			bson.E{Key: "creator", Value: userID},
+		})
+		if err != nil {
+			t.Fatalf("failed inserting a legacy permission: %v", err)
+		}
+	}
+
+	assertUpgraded := func(fileID string, userID string) {
+		t.Helper()
+
+		var document bson.M
+		filter := bson.D{bson.E{Key: "fileID", Value: fileID}, bson.E{Key: "userID", Value: userID}}
+		if err := permissions.FindOne(ctx, filter).Decode(&document); err != nil {
+			t.Fatalf("failed finding the permission: %v", err)
+		}
+
+		if document["schemaVersion"] != int64(mongodb.CurrentSchemaVersion) ||
+			document["canReshare"] != true ||
+			document["resourceKind"] != "file" ||
+			document["granteeType"] != "user" {
+			t.Errorf("expected the permission to be upgraded, got %v", document)
+		}
+	}
+
+	// A legacy permission is upgraded when it's read.
+	readFileID, readUserID := newID("file"), newID("user")
+	insertLegacy(readFileID, readUserID)
+	permission, err := srv.Permission.GetPermission(ctx, &pb.GetPermissionRequest{
+		FileID: readFileID,
+		UserID: readUserID,
+	})
+	if err != nil {
+		t.Fatalf("GetPermission failed: %v", err)
+	}
+
+	if !permission.GetCanReshare() || permission.GetRole() != pb.Role_READ {
+		t.Errorf("unexpected upgraded permission %v", permission)
+	}
+
+	assertUpgraded(readFileID, readUserID)
+
+	// The legacy permissions that aren't read are upgraded by the backfill job.
+	backfillFileID, backfillUserID := newID("file"), newID("user")
+	insertLegacy(backfillFileID, backfillUserID)
+	job, err := srv.Admin.CreateJob(ctx, &pbv2.CreateJobRequest{
+		Operation: &pbv2.CreateJobRequest_UpgradeSchema{UpgradeSchema: &pbv2.UpgradeSchemaJob{BatchSize: 10}},
+	})
+	if err != nil {
+		t.Fatalf("CreateJob failed: %v", err)
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for job.GetState() == pbv2.JobState_RUNNING && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+		if job, err = srv.Admin.GetJob(ctx, &pbv2.GetJobRequest{Name: job.GetName()}); err != nil {
+			t.Fatalf("GetJob failed: %v", err)
+		}
+	}
+
+	if job.GetState() != pbv2.JobState_SUCCEEDED || job.GetDone() < 1 {
+		t.Fatalf("expected the job to upgrade the legacy permissions, got %v", job)
+	}
+
+	assertUpgraded(backfillFileID, backfillUserID)
+}
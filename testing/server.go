@@ -0,0 +1,154 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"time"
+
+	pb "github.com/meateam/permission-service/proto"
+	pbv2 "github.com/meateam/permission-service/proto/v2"
+	"github.com/meateam/permission-service/server"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	// listenerBufferSize is the size of the buffer of the in-memory listener of the server.
+	listenerBufferSize = 1 << 20
+
+	// servingTimeout is the timeout of the server warming up and becoming SERVING.
+	servingTimeout = time.Minute
+
+	// servingPollInterval is the interval between checks of whether the server is SERVING.
+	servingPollInterval = 100 * time.Millisecond
+)
+
+// errFatal is the panic of a server that failed to start instead of exiting the process.
+type errFatal struct{}
+
+// fatalHook records the message of the fatal entry of a server that failed to start.
+type fatalHook struct {
+	message string
+}
+
+// Levels returns the fatal level.
+func (h *fatalHook) Levels() []logrus.Level {
+	return []logrus.Level{logrus.FatalLevel}
+}
+
+// Fire records the message of entry.
+func (h *fatalHook) Fire(entry *logrus.Entry) error {
+	h.message = entry.Message
+	return nil
+}
+
+// Server is a permission server serving on an in-memory listener, and the clients connected to it.
+type Server struct {
+	*server.PermissionServer
+	conn *grpc.ClientConn
+
+	Permission  pb.PermissionClient
+	Permissions pbv2.PermissionsClient
+	Admin       pbv2.PermissionsAdminClient
+	Health      grpc_health_v1.HealthClient
+}
+
+// NewServer creates a permission server configured with config, that maps the keys of the server's
+// configuration, such as "mongo_host", to their values, serves it and returns it with its clients,
+// once it's SERVING, or any error if occurred. The configuration is global, so servers with different
+// configurations must not be created concurrently.
+func NewServer(config map[string]interface{}) (*Server, error) {
+	for key, value := range config {
+		viper.Set(key, value)
+	}
+
+	permissionServer, err := newPermissionServer()
+	if err != nil {
+		return nil, err
+	}
+
+	listener := bufconn.Listen(listenerBufferSize)
+	go permissionServer.Serve(listener)
+
+	conn, err := grpc.DialContext(
+		context.Background(),
+		"bufconn",
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+	)
+	if err != nil {
+		permissionServer.Stop()
+		return nil, fmt.Errorf("failed dialing the server: %v", err)
+	}
+
+	s := &Server{
+		PermissionServer: permissionServer,
+		conn:             conn,
+		Permission:       pb.NewPermissionClient(conn),
+		Permissions:      pbv2.NewPermissionsClient(conn),
+		Admin:            pbv2.NewPermissionsAdminClient(conn),
+		Health:           grpc_health_v1.NewHealthClient(conn),
+	}
+
+	if err := s.waitServing(); err != nil {
+		s.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// waitServing waits until the server is warmed up and SERVING, or returns an error after servingTimeout.
+func (s *Server) waitServing() error {
+	deadline := time.Now().Add(servingTimeout)
+	for {
+		res, err := s.Health.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+		if err == nil && res.GetStatus() == grpc_health_v1.HealthCheckResponse_SERVING {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("the server isn't SERVING after %s: %v, %v", servingTimeout, res.GetStatus(), err)
+		}
+
+		time.Sleep(servingPollInterval)
+	}
+}
+
+// newPermissionServer creates a permission server whose logs are discarded,
+// and returns an error instead of exiting the process if it fails.
+func newPermissionServer() (permissionServer *server.PermissionServer, err error) {
+	hook := &fatalHook{}
+	logger := logrus.New()
+	logger.Out = ioutil.Discard
+	logger.AddHook(hook)
+	logger.ExitFunc = func(int) {
+		panic(errFatal{})
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(errFatal); !ok {
+				panic(r)
+			}
+
+			err = fmt.Errorf("failed creating the server: %s", hook.message)
+		}
+	}()
+
+	return server.NewServer(logger), nil
+}
+
+// Close closes the clients' connection and stops the server.
+func (s *Server) Close() error {
+	err := s.conn.Close()
+	s.Stop()
+	return err
+}
@@ -0,0 +1,112 @@
+//go:build integration
+// +build integration
+
+package testing_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/meateam/permission-service/proto"
+	pstesting "github.com/meateam/permission-service/testing"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc/codes"
+)
+
+// outageCheckTimeout is the timeout of a permission check while the database is unavailable,
+// which is longer than the server selection timeout of the server of the outage.
+const outageCheckTimeout = 10 * time.Second
+
+func TestStaleReads(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), mongoStartTimeout)
+	defer cancel()
+
+	// The outage is of a database of its own, so that it doesn't affect the other tests.
+	outageMongo, err := pstesting.StartMongo(ctx)
+	if err != nil {
+		t.Fatalf("starting the database of the outage failed: %v", err)
+	}
+	defer outageMongo.Close()
+
+	outageServer, err := func() (*pstesting.Server, error) {
+		defer func() {
+			viper.Set("mongo_host", mongoConnectionString)
+			viper.Set("stale_read_max_age", 0)
+			viper.Set("mongo_server_selection_timeout", 0)
+		}()
+
+		return pstesting.NewServer(map[string]interface{}{
+			"mongo_host":                     outageMongo.ConnectionString,
+			"stale_read_max_age":             60,
+			"mongo_server_selection_timeout": 1,
+		})
+	}()
+	if err != nil {
+		t.Fatalf("creating the server of the outage failed: %v", err)
+	}
+	defer outageServer.Close()
+
+	fileID, userID, otherUserID := newID("file"), newID("user"), newID("user")
+	_, err = outageServer.Permission.CreatePermission(context.Background(), &pb.CreatePermissionRequest{
+		FileID:  fileID,
+		UserID:  userID,
+		Role:    pb.Role_READ,
+		Creator: userID,
+	})
+	if err != nil {
+		t.Fatalf("CreatePermission failed: %v", err)
+	}
+
+	check := func(userID string) (*pb.IsPermittedResponse, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), outageCheckTimeout)
+		defer cancel()
+
+		return outageServer.Permission.IsPermitted(ctx, &pb.IsPermittedRequest{
+			FileID: fileID,
+			UserID: userID,
+			Role:   pb.Role_READ,
+		})
+	}
+
+	// The checks before the outage are the last-known permissions.
+	res, err := check(userID)
+	if err != nil || !res.GetPermitted() || res.GetStale() {
+		t.Fatalf("IsPermitted before the outage = %v, %v, expected a permitted fresh check", res, err)
+	}
+
+	_, err = check(otherUserID)
+	assertCode(t, err, codes.NotFound)
+
+	if err := outageMongo.Close(); err != nil {
+		t.Fatalf("stopping the database failed: %v", err)
+	}
+
+	res, err = check(userID)
+	if err != nil {
+		t.Fatalf("IsPermitted during the outage failed: %v", err)
+	}
+
+	if !res.GetPermitted() || !res.GetStale() || res.GetMaxAge().GetSeconds() != 0 {
+		t.Errorf("IsPermitted during the outage = %v, expected a permitted stale check that isn't cached", res)
+	}
+
+	_, err = check(otherUserID)
+	assertCode(t, err, codes.NotFound)
+
+	// The checks of permissions that aren't known fail.
+	_, err = check(newID("user"))
+	assertCode(t, err, codes.Unavailable)
+
+	// Mutations are never served stale.
+	mutationCtx, cancelMutation := context.WithTimeout(context.Background(), outageCheckTimeout)
+	defer cancelMutation()
+
+	_, err = outageServer.Permission.DeletePermission(mutationCtx, &pb.DeletePermissionRequest{
+		FileID: fileID,
+		UserID: userID,
+	})
+	if err == nil {
+		t.Errorf("DeletePermission during the outage succeeded, expected it to fail")
+	}
+}
@@ -0,0 +1,66 @@
+//go:build integration
+// +build integration
+
+package testing_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/meateam/permission-service/service"
+	pstesting "github.com/meateam/permission-service/testing"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestOperationComments(t *testing.T) {
+	ctx := context.Background()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoConnectionString))
+	if err != nil {
+		t.Fatalf("failed connecting to mongodb: %v", err)
+	}
+	defer client.Disconnect(ctx)
+
+	// The profiler records every operation of the database while the test runs.
+	db := client.Database(pstesting.DatabaseName)
+	if err := db.RunCommand(ctx, bson.D{{Key: "profile", Value: 2}}).Err(); err != nil {
+		t.Fatalf("enabling the profiler failed: %v", err)
+	}
+	defer db.RunCommand(ctx, bson.D{{Key: "profile", Value: 0}})
+
+	fileID, userID := newID("file"), newID("user")
+	createPermission(t, fileID, userID, pb.Role_READ, userID)
+
+	requestID := newID("request")
+	requestCtx := metadata.AppendToOutgoingContext(ctx, service.CorrelationIDHeader, requestID)
+	if _, err := srv.Permission.IsPermitted(requestCtx, &pb.IsPermittedRequest{
+		FileID: fileID,
+		UserID: userID,
+		Role:   pb.Role_READ,
+	}); err != nil {
+		t.Fatalf("IsPermitted failed: %v", err)
+	}
+
+	var profiled struct {
+		Command struct {
+			Comment string `bson:"comment"`
+		} `bson:"command"`
+	}
+
+	err = db.Collection("system.profile").FindOne(ctx, bson.D{{
+		Key:   "command.comment",
+		Value: primitive.Regex{Pattern: "request_id=" + requestID},
+	}}).Decode(&profiled)
+	if err != nil {
+		t.Fatalf("finding the profiled operation of the request failed: %v", err)
+	}
+
+	if method := "method=/permission.Permission/IsPermitted"; !strings.Contains(profiled.Command.Comment, method) {
+		t.Errorf("the comment of the operation = %q, expected it to contain %q", profiled.Command.Comment, method)
+	}
+}
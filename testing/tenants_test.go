@@ -0,0 +1,164 @@
+//go:build integration
+// +build integration
+
+package testing_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	pb "github.com/meateam/permission-service/proto"
+	pbv2 "github.com/meateam/permission-service/proto/v2"
+	pstesting "github.com/meateam/permission-service/testing"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestOffboardTenant(t *testing.T) {
+	ctx := context.Background()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoConnectionString))
+	if err != nil {
+		t.Fatalf("failed connecting to mongodb: %v", err)
+	}
+	defer client.Disconnect(ctx)
+
+	db := client.Database(pstesting.DatabaseName)
+	tenantID := "offboarded"
+	_, err = db.Collection(tenantID+"_permissions").InsertMany(ctx, []interface{}{
+		bson.D{bson.E{Key: "fileID", Value: newID("file")}, bson.E{Key: "userID", Value: newID("user")}},
+		bson.D{bson.E{Key: "fileID", Value: newID("file")}, bson.E{Key: "userID", Value: newID("user")}},
+	})
+	if err != nil {
+		t.Fatalf("failed inserting the tenant's permissions: %v", err)
+	}
+
+	// The collections of a tenant whose ID starts with the tenant's prefix aren't the tenant's.
+	other := db.Collection(tenantID + "_other_permissions")
+	if _, err := other.InsertOne(ctx, bson.D{bson.E{Key: "fileID", Value: newID("file")}}); err != nil {
+		t.Fatalf("failed inserting the other tenant's permission: %v", err)
+	}
+
+	stream, err := srv.Admin.ExportTenantData(ctx, &pbv2.ExportTenantDataRequest{TenantId: tenantID})
+	if err != nil {
+		t.Fatalf("ExportTenantData failed: %v", err)
+	}
+
+	exported := 0
+	for {
+		record, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			t.Fatalf("ExportTenantData failed: %v", err)
+		}
+
+		if record.GetCollection() != "permissions" || record.GetDocument() == "" {
+			t.Errorf("expected a document of permissions, got %v", record)
+		}
+
+		exported++
+	}
+
+	if exported != 2 {
+		t.Fatalf("expected 2 exported documents, got %d", exported)
+	}
+
+	preview, err := srv.Admin.PurgeTenant(ctx, &pbv2.PurgeTenantRequest{TenantId: tenantID})
+	if err != nil {
+		t.Fatalf("PurgeTenant failed: %v", err)
+	}
+
+	if preview.GetPurged() || preview.GetDocuments() != 2 || preview.GetConfirmationToken() == "" {
+		t.Fatalf("expected a preview of the purge of 2 documents, got %v", preview)
+	}
+
+	_, err = srv.Admin.PurgeTenant(ctx, &pbv2.PurgeTenantRequest{TenantId: tenantID, ConfirmationToken: "invalid"})
+	assertCode(t, err, codes.FailedPrecondition)
+
+	confirm := &pbv2.PurgeTenantRequest{TenantId: tenantID, ConfirmationToken: preview.GetConfirmationToken()}
+	purge, err := srv.Admin.PurgeTenant(ctx, confirm)
+	if err != nil {
+		t.Fatalf("PurgeTenant failed: %v", err)
+	}
+
+	if !purge.GetPurged() || purge.GetDocuments() != 2 {
+		t.Fatalf("expected 2 purged documents, got %v", purge)
+	}
+
+	names, err := db.ListCollectionNames(ctx, bson.D{bson.E{Key: "name", Value: tenantID + "_permissions"}})
+	if err != nil || len(names) != 0 {
+		t.Errorf("expected the tenant's collection to be dropped, got %v: %v", names, err)
+	}
+
+	if count, err := other.CountDocuments(ctx, bson.D{}); err != nil || count != 1 {
+		t.Errorf("expected the other tenant's permission to be kept, got %d: %v", count, err)
+	}
+
+	// A token confirms a single purge.
+	_, err = srv.Admin.PurgeTenant(ctx, confirm)
+	assertCode(t, err, codes.FailedPrecondition)
+
+	_, err = srv.Admin.PurgeTenant(ctx, &pbv2.PurgeTenantRequest{TenantId: "invalid/tenant"})
+	assertCode(t, err, codes.InvalidArgument)
+}
+
+func TestTenantRateLimits(t *testing.T) {
+	ctx := context.Background()
+	limits, err := srv.Admin.SetTenantRateLimits(ctx, &pbv2.SetTenantRateLimitsRequest{WritesPerSecond: 1})
+	if err != nil {
+		t.Fatalf("SetTenantRateLimits failed: %v", err)
+	}
+	defer srv.Admin.SetTenantRateLimits(ctx, &pbv2.SetTenantRateLimitsRequest{})
+
+	if limits.GetWritesPerSecond() != 1 || limits.GetReadsPerSecond() != 0 || limits.GetUpdateTime() == nil {
+		t.Fatalf("expected a limit of 1 write per second, got %v", limits)
+	}
+
+	fileID, owner := newID("file"), newID("user")
+	createPermission(t, fileID, owner, pb.Role_WRITE, owner)
+	_, err = srv.Permission.CreatePermission(ctx, &pb.CreatePermissionRequest{
+		FileID:  fileID,
+		UserID:  newID("user"),
+		Role:    pb.Role_READ,
+		Creator: owner,
+	})
+	assertCode(t, err, codes.ResourceExhausted)
+
+	retryable := false
+	for _, detail := range status.Convert(err).Details() {
+		if retryInfo, ok := detail.(*errdetails.RetryInfo); ok && retryInfo.GetRetryDelay() != nil {
+			retryable = true
+		}
+	}
+
+	if !retryable {
+		t.Errorf("expected the rejection to have a retry delay, got %v", status.Convert(err).Details())
+	}
+
+	// The reads aren't limited by the limit of the writes.
+	for i := 0; i < 3; i++ {
+		_, err := srv.Permission.GetFilePermissions(ctx, &pb.GetFilePermissionsRequest{FileID: fileID})
+		if err != nil {
+			t.Fatalf("GetFilePermissions failed: %v", err)
+		}
+	}
+
+	got, err := srv.Admin.GetTenantRateLimits(ctx, &pbv2.GetTenantRateLimitsRequest{})
+	if err != nil {
+		t.Fatalf("GetTenantRateLimits failed: %v", err)
+	}
+
+	if got.GetWritesPerSecond() != 1 {
+		t.Errorf("expected a limit of 1 write per second, got %v", got)
+	}
+
+	_, err = srv.Admin.SetTenantRateLimits(ctx, &pbv2.SetTenantRateLimitsRequest{ReadsPerSecond: -1})
+	assertCode(t, err, codes.InvalidArgument)
+}
@@ -0,0 +1,91 @@
+//go:build integration
+// +build integration
+
+package testing_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	pb "github.com/meateam/permission-service/proto"
+	pbv2 "github.com/meateam/permission-service/proto/v2"
+	"github.com/meateam/permission-service/proto/validate"
+	"github.com/meateam/permission-service/service"
+	"google.golang.org/grpc/codes"
+)
+
+func TestValidateRequests(t *testing.T) {
+	// The requests that violate the rules of their protos are rejected before they're handled.
+	_, err := srv.Permission.CreatePermission(context.Background(), &pb.CreatePermissionRequest{
+		UserID:  newID("user"),
+		Role:    pb.Role_READ,
+		Creator: newID("user"),
+	})
+	assertCode(t, err, codes.InvalidArgument)
+
+	_, err = srv.Permission.CreatePermission(context.Background(), &pb.CreatePermissionRequest{
+		FileID:  newID("file"),
+		UserID:  newID("user"),
+		Role:    pb.Role(100),
+		Creator: newID("user"),
+	})
+	assertCode(t, err, codes.InvalidArgument)
+
+	_, err = srv.Permissions.SimulateAccess(context.Background(), &pbv2.SimulateAccessRequest{
+		Parent:  "files/" + newID("file"),
+		Changes: []*pbv2.AccessChange{{Role: pbv2.Role_READ}},
+	})
+	assertCode(t, err, codes.InvalidArgument)
+
+	// Clients check the same rules before sending their requests.
+	err = validate.Message(&pbv2.PurgeTenantRequest{TenantId: "invalid/tenant"})
+	assertCode(t, err, codes.InvalidArgument)
+
+	err = validate.Message(&pb.GetPermissionRequest{FileID: newID("file"), UserID: newID("user")})
+	if err != nil {
+		t.Fatalf("expected a valid request, got %v", err)
+	}
+}
+
+func TestValidateLengthInCharacters(t *testing.T) {
+	// The lengths are of characters rather than bytes, so messages in any language have the same limits.
+	creator := newID("user")
+	message := strings.Repeat("ש", service.MaxMessageLength)
+	res, err := srv.Permission.CreatePermission(context.Background(), &pb.CreatePermissionRequest{
+		FileID:  newID("file"),
+		UserID:  creator,
+		Role:    pb.Role_READ,
+		Creator: creator,
+		Message: message,
+	})
+	if err != nil {
+		t.Fatalf("CreatePermission of a message of %d characters failed: %v", service.MaxMessageLength, err)
+	}
+
+	if res.GetMessage() != message {
+		length := utf8.RuneCountInString(res.GetMessage())
+		t.Errorf("expected the message to be stored as is, got %d characters", length)
+	}
+
+	_, err = srv.Permission.CreatePermission(context.Background(), &pb.CreatePermissionRequest{
+		FileID:  newID("file"),
+		UserID:  creator,
+		Role:    pb.Role_READ,
+		Creator: creator,
+		Message: message + "ש",
+	})
+	assertCode(t, err, codes.InvalidArgument)
+
+	err = validate.Message(&pb.CreatePermissionRequest{
+		FileID:  newID("file"),
+		UserID:  creator,
+		Role:    pb.Role_READ,
+		Creator: creator,
+		Label:   strings.Repeat("ש", service.MaxLabelLength),
+	})
+	if err != nil {
+		t.Errorf("expected a label of %d characters to be valid, got %v", service.MaxLabelLength, err)
+	}
+}
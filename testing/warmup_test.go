@@ -0,0 +1,120 @@
+//go:build integration
+// +build integration
+
+package testing_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/meateam/permission-service/proto"
+	"github.com/meateam/permission-service/service/mongodb"
+	pstesting "github.com/meateam/permission-service/testing"
+	"github.com/spf13/viper"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// indexNames returns the names of the indexes of collection, and fails t if listing them fails.
+func indexNames(t *testing.T, collection *mongo.Collection) map[string]bool {
+	t.Helper()
+
+	cursor, err := collection.Indexes().List(context.Background())
+	if err != nil {
+		t.Fatalf("failed listing the indexes: %v", err)
+	}
+
+	var indexes []struct {
+		Name string `bson:"name"`
+	}
+	if err := cursor.All(context.Background(), &indexes); err != nil {
+		t.Fatalf("failed decoding the indexes: %v", err)
+	}
+
+	names := make(map[string]bool, len(indexes))
+	for _, index := range indexes {
+		names[index.Name] = true
+	}
+
+	return names
+}
+
+func TestVerifyIndexes(t *testing.T) {
+	ctx := context.Background()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoConnectionString))
+	if err != nil {
+		t.Fatalf("failed connecting to mongodb: %v", err)
+	}
+	defer client.Disconnect(ctx)
+
+	// The store's collections are prefixed so that dropping their indexes doesn't affect the other tests.
+	db := client.Database(pstesting.DatabaseName)
+	prefix := newID("indexes") + "."
+	store, err := mongodb.NewMongoStore(db, prefix, time.Hour)
+	if err != nil {
+		t.Fatalf("NewMongoStore failed: %v", err)
+	}
+
+	// Drop the indexes of the permissions, as if the collection was restored without them.
+	permissions := db.Collection(prefix + mongodb.PermissionCollectionName)
+	indexes := indexNames(t, permissions)
+	if _, err := permissions.Indexes().DropAll(ctx); err != nil {
+		t.Fatalf("failed dropping the indexes: %v", err)
+	}
+
+	if err := store.VerifyIndexes(ctx); err != nil {
+		t.Fatalf("VerifyIndexes failed: %v", err)
+	}
+
+	recreated := indexNames(t, permissions)
+	for name := range indexes {
+		if !recreated[name] {
+			t.Errorf("expected VerifyIndexes to recreate the index %s", name)
+		}
+	}
+
+	// Verifying indexes that exist doesn't change them.
+	if err := store.VerifyIndexes(ctx); err != nil {
+		t.Errorf("VerifyIndexes of existing indexes failed: %v", err)
+	}
+}
+
+func TestWarmUp(t *testing.T) {
+	fileID, userID := newID("file"), newID("user")
+	createPermission(t, fileID, userID, pb.Role_READ, userID)
+
+	// The server is SERVING only once it verified the indexes and read the hot files,
+	// of which a missing file and empty entries don't fail the warm-up.
+	warmUpServer, err := func() (*pstesting.Server, error) {
+		defer viper.Set("warm_up_files", "")
+
+		return pstesting.NewServer(map[string]interface{}{
+			"warm_up_files": fileID + ", " + newID("file") + ",",
+		})
+	}()
+	if err != nil {
+		t.Fatalf("creating the server of the warm-up failed: %v", err)
+	}
+	defer warmUpServer.Close()
+
+	ctx := context.Background()
+	res, err := warmUpServer.Health.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	if err != nil || res.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+		t.Errorf("expected the warmed up server to be SERVING, got %v, %v", res.GetStatus(), err)
+	}
+
+	permitted, err := warmUpServer.Permission.IsPermitted(ctx, &pb.IsPermittedRequest{
+		FileID: fileID,
+		UserID: userID,
+		Role:   pb.Role_READ,
+	})
+	if err != nil {
+		t.Fatalf("IsPermitted failed: %v", err)
+	}
+
+	if !permitted.GetPermitted() {
+		t.Errorf("IsPermitted = false, expected the permission of the hot file to be permitted")
+	}
+}